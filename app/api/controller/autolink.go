@@ -0,0 +1,69 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/autolink"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+)
+
+// AutolinkCreateInput is used for creating an autolink rule of a space or a repository.
+type AutolinkCreateInput struct {
+	Pattern     string `json:"pattern"`
+	TargetURL   string `json:"target_url"`
+	Description string `json:"description"`
+}
+
+func (in *AutolinkCreateInput) Sanitize() error {
+	in.Pattern = strings.TrimSpace(in.Pattern)
+	in.TargetURL = strings.TrimSpace(in.TargetURL)
+	in.Description = strings.TrimSpace(in.Description)
+
+	return validateAutolink(in.Pattern, in.TargetURL, in.Description)
+}
+
+// AutolinkUpdateInput is used for updating an autolink rule of a space or a repository.
+type AutolinkUpdateInput struct {
+	Pattern     *string `json:"pattern"`
+	TargetURL   *string `json:"target_url"`
+	Description *string `json:"description"`
+}
+
+// Apply sets the provided values to the autolink rule and validates the result.
+func (in *AutolinkUpdateInput) Apply(link *types.Autolink) error {
+	if in.Pattern != nil {
+		link.Pattern = strings.TrimSpace(*in.Pattern)
+	}
+	if in.TargetURL != nil {
+		link.TargetURL = strings.TrimSpace(*in.TargetURL)
+	}
+	if in.Description != nil {
+		link.Description = strings.TrimSpace(*in.Description)
+	}
+
+	return validateAutolink(link.Pattern, link.TargetURL, link.Description)
+}
+
+func validateAutolink(pattern, targetURL, description string) error {
+	if err := autolink.Validate(pattern, targetURL); err != nil {
+		return usererror.BadRequestf("Invalid autolink: %s.", err)
+	}
+
+	return check.Description(description)
+}
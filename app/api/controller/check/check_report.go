@@ -82,6 +82,11 @@ func SanitizeJSONPayload(source json.RawMessage, data any) (json.RawMessage, err
 		return nil, usererror.BadRequestf("Payload data doesn't match the required format: %s", err.Error())
 	}
 
+	return encodeJSONPayload(data)
+}
+
+// encodeJSONPayload marshals the payload data without escaping HTML characters.
+func encodeJSONPayload(data any) (json.RawMessage, error) {
 	buffer := bytes.NewBuffer(nil)
 	buffer.Grow(512)
 
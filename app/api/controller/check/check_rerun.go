@@ -0,0 +1,71 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Rerun requests a re-run of a status check reported by an external system.
+// The re-run isn't performed by Gitness, an event is emitted instead that the
+// external system can subscribe to (e.g. with a webhook) and report the new result.
+func (c *Controller) Rerun(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	commitSHA string,
+	checkUID string,
+) (*types.Check, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	if !git.ValidateCommitSHA(commitSHA) {
+		return nil, usererror.BadRequest("invalid commit SHA provided")
+	}
+
+	check, err := c.checkStore.Find(ctx, repo.ID, commitSHA, checkUID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return nil, usererror.NotFound("Status check not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find status check: %w", err)
+	}
+
+	if check.Payload.Kind == enum.CheckPayloadKindPipeline {
+		return nil, usererror.BadRequest("Pipeline status checks must be re-run by re-running the pipeline execution")
+	}
+
+	c.eventReporter.CheckRerunRequested(ctx, &repoevents.CheckRerunRequestedPayload{
+		RepoID:      repo.ID,
+		PrincipalID: session.Principal.ID,
+		CheckID:     check.ID,
+		CheckUID:    check.UID,
+		CommitSHA:   check.CommitSHA,
+	})
+
+	return &check, nil
+}
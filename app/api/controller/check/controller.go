@@ -22,6 +22,7 @@ import (
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
+	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/store/database/dbtx"
@@ -36,6 +37,8 @@ type Controller struct {
 	checkStore store.CheckStore
 	git        git.Interface
 	sanitizers map[enum.CheckPayloadKind]func(in *ReportInput, s *auth.Session) error
+
+	eventReporter *repoevents.Reporter
 }
 
 func NewController(
@@ -45,14 +48,16 @@ func NewController(
 	checkStore store.CheckStore,
 	git git.Interface,
 	sanitizers map[enum.CheckPayloadKind]func(in *ReportInput, s *auth.Session) error,
+	eventReporter *repoevents.Reporter,
 ) *Controller {
 	return &Controller{
-		tx:         tx,
-		authorizer: authorizer,
-		repoStore:  repoStore,
-		checkStore: checkStore,
-		git:        git,
-		sanitizers: sanitizers,
+		tx:            tx,
+		authorizer:    authorizer,
+		repoStore:     repoStore,
+		checkStore:    checkStore,
+		git:           git,
+		sanitizers:    sanitizers,
+		eventReporter: eventReporter,
 	}
 }
 
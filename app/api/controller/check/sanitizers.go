@@ -15,6 +15,8 @@
 package check
 
 import (
+	"strings"
+
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/types"
@@ -32,6 +34,8 @@ func ProvideCheckSanitizers() map[enum.CheckPayloadKind]func(in *ReportInput, s
 	registeredCheckSanitizers[enum.CheckPayloadKindMarkdown] = registeredCheckSanitizers[enum.CheckPayloadKindRaw]
 
 	registeredCheckSanitizers[enum.CheckPayloadKindPipeline] = createPipelinePayloadSanitizer()
+
+	registeredCheckSanitizers[enum.CheckPayloadKindReport] = createReportPayloadSanitizer()
 	return registeredCheckSanitizers
 }
 
@@ -68,6 +72,89 @@ func createRawPayloadSanitizer() func(in *ReportInput, s *auth.Session) error {
 	}
 }
 
+const (
+	checkReportSummaryMaxLength = 65536
+	checkReportMaxAnnotations   = 1000
+)
+
+func createReportPayloadSanitizer() func(in *ReportInput, s *auth.Session) error {
+	return func(in *ReportInput, s *auth.Session) error {
+		// the report payload kind doesn't support the version
+		if in.Payload.Version != "" {
+			return usererror.BadRequestf("Payload version must be empty for the payload kind '%s'",
+				in.Payload.Kind)
+		}
+
+		report := &types.CheckPayloadReport{}
+
+		if _, err := SanitizeJSONPayload(in.Payload.Data, report); err != nil {
+			return err
+		}
+
+		if len(report.Summary) > checkReportSummaryMaxLength {
+			return usererror.BadRequestf("Report summary can't be longer than %d characters",
+				checkReportSummaryMaxLength)
+		}
+
+		if len(report.Annotations) > checkReportMaxAnnotations {
+			return usererror.BadRequestf("Report can't contain more than %d annotations",
+				checkReportMaxAnnotations)
+		}
+
+		for i := range report.Annotations {
+			if err := sanitizeCheckAnnotation(&report.Annotations[i]); err != nil {
+				return err
+			}
+		}
+
+		if report.Annotations == nil {
+			report.Annotations = []types.CheckAnnotation{}
+		}
+
+		payloadDataJSON, err := encodeJSONPayload(report)
+		if err != nil {
+			return err
+		}
+
+		in.Payload.Data = payloadDataJSON
+
+		return nil
+	}
+}
+
+func sanitizeCheckAnnotation(annotation *types.CheckAnnotation) error {
+	annotation.Path = strings.Trim(strings.TrimSpace(annotation.Path), "/")
+	annotation.Title = strings.TrimSpace(annotation.Title)
+	annotation.Message = strings.TrimSpace(annotation.Message)
+
+	if annotation.Path == "" {
+		return usererror.BadRequest("Annotation path is missing")
+	}
+
+	if annotation.Message == "" {
+		return usererror.BadRequest("Annotation message is missing")
+	}
+
+	if annotation.LineStart < 1 {
+		return usererror.BadRequest("Annotation start line must be a positive number")
+	}
+
+	if annotation.LineEnd == 0 {
+		annotation.LineEnd = annotation.LineStart
+	}
+
+	if annotation.LineEnd < annotation.LineStart {
+		return usererror.BadRequest("Annotation end line can't be before the start line")
+	}
+
+	var ok bool
+	if annotation.Level, ok = annotation.Level.Sanitize(); !ok {
+		return usererror.BadRequest("Invalid value provided for annotation level")
+	}
+
+	return nil
+}
+
 func createPipelinePayloadSanitizer() func(in *ReportInput, s *auth.Session) error {
 	return func(in *ReportInput, s *auth.Session) error {
 		return usererror.BadRequest("Kind cannot be pipeline for external checks")
@@ -16,6 +16,7 @@ package connector
 
 import (
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/pipeline/secrets"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/types/check"
 )
@@ -25,6 +26,7 @@ type Controller struct {
 	connectorStore store.ConnectorStore
 	authorizer     authz.Authorizer
 	spaceStore     store.SpaceStore
+	secretsConfig  secrets.Config
 }
 
 func NewController(
@@ -32,11 +34,13 @@ func NewController(
 	authorizer authz.Authorizer,
 	connectorStore store.ConnectorStore,
 	spaceStore store.SpaceStore,
+	secretsConfig secrets.Config,
 ) *Controller {
 	return &Controller{
 		uidCheck:       uidCheck,
 		connectorStore: connectorStore,
 		authorizer:     authorizer,
 		spaceStore:     spaceStore,
+		secretsConfig:  secretsConfig,
 	}
 }
@@ -24,6 +24,7 @@ import (
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/pipeline/secrets"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
@@ -91,6 +92,23 @@ func (c *Controller) sanitizeCreateInput(in *CreateInput) error {
 		return err
 	}
 
+	if err := c.checkData(in.Type, in.Data); err != nil {
+		return err
+	}
+
 	in.Description = strings.TrimSpace(in.Description)
 	return check.Description(in.Description)
 }
+
+// checkData verifies the configuration of the connector types known by the server.
+func (c *Controller) checkData(connectorType, data string) error {
+	if !secrets.IsProviderType(connectorType) {
+		return nil
+	}
+
+	if err := secrets.ValidateConfig(c.secretsConfig, connectorType, data); err != nil {
+		return usererror.BadRequestf("Invalid secret manager configuration: %s.", err)
+	}
+
+	return nil
+}
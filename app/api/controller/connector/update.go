@@ -59,6 +59,12 @@ func (c *Controller) Update(
 		return nil, fmt.Errorf("failed to find connector: %w", err)
 	}
 
+	if in.Data != nil {
+		if err = c.checkData(connector.Type, *in.Data); err != nil {
+			return nil, err
+		}
+	}
+
 	return c.connectorStore.UpdateOptLock(ctx, connector, func(original *types.Connector) error {
 		if in.UID != nil {
 			original.UID = *in.UID
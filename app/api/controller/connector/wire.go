@@ -16,6 +16,7 @@ package connector
 
 import (
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/pipeline/secrets"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/types/check"
 
@@ -32,6 +33,7 @@ func ProvideController(
 	connectorStore store.ConnectorStore,
 	authorizer authz.Authorizer,
 	spaceStore store.SpaceStore,
+	secretsConfig secrets.Config,
 ) *Controller {
-	return NewController(uidCheck, authorizer, connectorStore, spaceStore)
+	return NewController(uidCheck, authorizer, connectorStore, spaceStore, secretsConfig)
 }
@@ -0,0 +1,104 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/jwt"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const deletionOperation = "delete"
+
+// DeletionGuard requires an explicit confirmation for the deletion of resources above the configured thresholds.
+type DeletionGuard struct {
+	enabled          bool
+	sizeThreshold    int64
+	pullReqThreshold int
+	lifetime         time.Duration
+}
+
+func NewDeletionGuard(config *types.Config) DeletionGuard {
+	return DeletionGuard{
+		enabled:          config.Deletion.RequireConfirmation,
+		sizeThreshold:    config.Deletion.SizeThreshold,
+		pullReqThreshold: config.Deletion.PullReqThreshold,
+		lifetime:         config.Deletion.ConfirmationLifetime,
+	}
+}
+
+// Enabled returns true if deletions above the thresholds require a confirmation.
+func (g DeletionGuard) Enabled() bool {
+	return g.enabled
+}
+
+// Check returns nil if the resource can be deleted. If the deletion requires a confirmation,
+// the provided confirmation token is verified. Without a confirmation token,
+// an error is returned that contains a new short-lived confirmation token and the impact of the deletion.
+func (g DeletionGuard) Check(
+	session *auth.Session,
+	resourceType enum.ResourceType,
+	resourceID int64,
+	impact types.DeletionImpact,
+	confirmationToken string,
+) error {
+	if !g.requiresConfirmation(impact) {
+		return nil
+	}
+
+	confirmation := jwt.SubClaimsConfirmation{
+		Operation:    deletionOperation,
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+	}
+
+	if confirmationToken != "" {
+		err := jwt.VerifyConfirmation(confirmationToken, session.Principal.ID, confirmation, session.Principal.Salt)
+		if err != nil {
+			return usererror.BadRequest("The confirmation token is invalid or expired")
+		}
+
+		return nil
+	}
+
+	token, expiresAt, err := jwt.GenerateForConfirmation(session.Principal.ID, confirmation, g.lifetime,
+		session.Principal.Salt)
+	if err != nil {
+		return fmt.Errorf("failed to generate deletion confirmation token: %w", err)
+	}
+
+	return usererror.NewWithPayload(http.StatusPreconditionRequired,
+		"The deletion has to be confirmed by repeating the request with the confirmation token",
+		map[string]any{
+			"confirmation_token": token,
+			"expires_at":         expiresAt.UnixMilli(),
+			"impact":             impact,
+		})
+}
+
+func (g DeletionGuard) requiresConfirmation(impact types.DeletionImpact) bool {
+	if !g.enabled {
+		return false
+	}
+
+	return (g.sizeThreshold > 0 && impact.Size > g.sizeThreshold) ||
+		(g.pullReqThreshold > 0 && impact.PullReqs > g.pullReqThreshold)
+}
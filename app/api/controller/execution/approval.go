@@ -0,0 +1,166 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/pipeline/triggerer/gate"
+	"github.com/harness/gitness/app/services/approval"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type ApprovalDecisionInput struct {
+	Decision enum.ApprovalDecision `json:"decision"`
+	Comment  string                `json:"comment"`
+}
+
+func (in *ApprovalDecisionInput) sanitize() error {
+	decision, ok := in.Decision.Sanitize()
+	if !ok || decision == "" {
+		return usererror.BadRequest("Decision must be either 'approve' or 'reject'.")
+	}
+	in.Decision = decision
+
+	return nil
+}
+
+// FindApproval returns the approval gate of an execution stage.
+func (c *Controller) FindApproval(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineUID string,
+	executionNum int64,
+	stageNum int64,
+) (*types.Approval, error) {
+	stage, a, err := c.getApproval(ctx, session, repoRef, pipelineUID, executionNum, stageNum,
+		enum.PermissionPipelineView)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.backfillApproval(ctx, stage, a)
+}
+
+// DecideApproval approves or rejects a blocked approval stage of an execution.
+func (c *Controller) DecideApproval(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineUID string,
+	executionNum int64,
+	stageNum int64,
+	in *ApprovalDecisionInput,
+) (*types.Approval, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	stage, a, err := c.getApproval(ctx, session, repoRef, pipelineUID, executionNum, stageNum,
+		enum.PermissionPipelineExecute)
+	if err != nil {
+		return nil, err
+	}
+
+	if !approval.CanDecide(a, &session.Principal) {
+		return nil, usererror.Forbidden("You are not allowed to decide on this approval.")
+	}
+
+	if a.State != enum.ApprovalStatePending || stage.Status != enum.CIStatusBlocked {
+		return nil, usererror.BadRequest("The stage isn't waiting for an approval.")
+	}
+
+	err = c.approvalSvc.Decide(ctx, stage, a, &session.Principal, in.Decision, in.Comment)
+	if errors.Is(err, approval.ErrAlreadyDecided) {
+		return nil, usererror.Conflict("The approval has already been decided.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decide on approval: %w", err)
+	}
+
+	return c.backfillApproval(ctx, stage, a)
+}
+
+func (c *Controller) getApproval(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineUID string,
+	executionNum int64,
+	stageNum int64,
+	reqPermission enum.Permission,
+) (*types.Stage, *types.Approval, error) {
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find repo by ref: %w", err)
+	}
+	err = apiauth.CheckPipeline(ctx, c.authorizer, session, repo.Path, pipelineUID, reqPermission)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to authorize: %w", err)
+	}
+
+	pipeline, err := c.pipelineStore.FindByUID(ctx, repo.ID, pipelineUID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	execution, err := c.executionStore.FindByNumber(ctx, pipeline.ID, executionNum)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find execution %d: %w", executionNum, err)
+	}
+
+	stage, err := c.stageStore.FindByNumber(ctx, execution.ID, int(stageNum))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find stage %d: %w", stageNum, err)
+	}
+
+	if stage.Kind != gate.StageKind {
+		return nil, nil, usererror.BadRequest("The stage isn't an approval stage.")
+	}
+
+	a, err := c.approvalStore.FindByStageID(ctx, stage.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find approval of stage %d: %w", stageNum, err)
+	}
+
+	return stage, a, nil
+}
+
+func (c *Controller) backfillApproval(
+	ctx context.Context,
+	stage *types.Stage,
+	a *types.Approval,
+) (*types.Approval, error) {
+	a.Deadline = approval.Deadline(stage, a)
+
+	if a.DecidedBy == nil {
+		return a, nil
+	}
+
+	decider, err := c.principalInfoCache.Get(ctx, *a.DecidedBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get decider principal info: %w", err)
+	}
+	a.Decider = decider
+
+	return a, nil
+}
@@ -0,0 +1,183 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"unicode"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/pipelineartifact"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const maxArtifactNameLength = 1024
+
+// ListArtifacts returns the artifacts uploaded by an execution.
+func (c *Controller) ListArtifacts(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineUID string,
+	executionNum int64,
+) ([]*types.PipelineArtifact, error) {
+	execution, err := c.getExecutionCheckAccess(ctx, session, repoRef, pipelineUID, executionNum)
+	if err != nil {
+		return nil, err
+	}
+
+	artifacts, err := c.artifacts.List(ctx, execution.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list artifacts: %w", err)
+	}
+
+	return artifacts, nil
+}
+
+// DownloadArtifact returns an artifact of an execution along with its content.
+// The caller is responsible for closing the returned reader.
+func (c *Controller) DownloadArtifact(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineUID string,
+	executionNum int64,
+	artifactID int64,
+) (*types.PipelineArtifact, io.ReadCloser, error) {
+	execution, err := c.getExecutionCheckAccess(ctx, session, repoRef, pipelineUID, executionNum)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	artifact, err := c.artifacts.Find(ctx, execution.ID, artifactID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find artifact: %w", err)
+	}
+
+	content, err := c.artifacts.Download(ctx, artifact)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to download artifact: %w", err)
+	}
+
+	return artifact, content, nil
+}
+
+// UploadArtifact stores the content as an artifact of a running execution.
+// Uploading artifacts requires push access to the repository, which pipeline executions of the repository have.
+func (c *Controller) UploadArtifact(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineUID string,
+	executionNum int64,
+	name string,
+	content io.Reader,
+) (*types.PipelineArtifact, error) {
+	if err := checkArtifactName(name); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo by ref: %w", err)
+	}
+
+	err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoPush, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authorize: %w", err)
+	}
+
+	pipeline, err := c.pipelineStore.FindByUID(ctx, repo.ID, pipelineUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	execution, err := c.executionStore.FindByNumber(ctx, pipeline.ID, executionNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find execution %d: %w", executionNum, err)
+	}
+
+	if execution.Status.IsDone() {
+		return nil, usererror.BadRequest("Artifacts can only be uploaded while the execution is running.")
+	}
+
+	artifact, err := c.artifacts.Upload(ctx, execution.ID, session.Principal.ID, name, content)
+	if errors.Is(err, pipelineartifact.ErrTooLarge) {
+		return nil, usererror.RequestTooLargef("The artifact exceeds the maximum artifact size.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload artifact: %w", err)
+	}
+
+	return artifact, nil
+}
+
+func (c *Controller) getExecutionCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineUID string,
+	executionNum int64,
+) (*types.Execution, error) {
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo by ref: %w", err)
+	}
+	err = apiauth.CheckPipeline(ctx, c.authorizer, session, repo.Path, pipelineUID, enum.PermissionPipelineView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authorize: %w", err)
+	}
+
+	pipeline, err := c.pipelineStore.FindByUID(ctx, repo.ID, pipelineUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	execution, err := c.executionStore.FindByNumber(ctx, pipeline.ID, executionNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find execution %d: %w", executionNum, err)
+	}
+
+	return execution, nil
+}
+
+// checkArtifactName checks that the artifact name is a relative, clean slash separated path.
+func checkArtifactName(name string) error {
+	if name == "" {
+		return usererror.BadRequest("Artifact name must be provided.")
+	}
+
+	if len(name) > maxArtifactNameLength {
+		return usererror.BadRequestf("Artifact name can't be longer than %d characters.", maxArtifactNameLength)
+	}
+
+	if strings.IndexFunc(name, unicode.IsControl) >= 0 {
+		return usererror.BadRequest("Artifact name can't contain control characters.")
+	}
+
+	if strings.HasPrefix(name, "/") || path.Clean(name) != name || name == ".." || strings.HasPrefix(name, "../") {
+		return usererror.BadRequest("Artifact name must be a clean relative path.")
+	}
+
+	return nil
+}
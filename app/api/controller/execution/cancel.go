@@ -64,5 +64,15 @@ func (c *Controller) Cancel(
 		log.Ctx(ctx).Warn().Err(err).Msg("could not update status check")
 	}
 
+	stages, err := c.stageStore.List(ctx, execution.ID)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("could not list stages to update status checks")
+		return execution, nil
+	}
+	err = checks.WriteStages(ctx, c.checkStore, execution, pipeline, stages)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("could not update stage status checks")
+	}
+
 	return execution, nil
 }
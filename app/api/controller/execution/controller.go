@@ -19,21 +19,27 @@ import (
 	"github.com/harness/gitness/app/pipeline/canceler"
 	"github.com/harness/gitness/app/pipeline/commit"
 	"github.com/harness/gitness/app/pipeline/triggerer"
+	"github.com/harness/gitness/app/services/approval"
+	"github.com/harness/gitness/app/services/pipelineartifact"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/store/database/dbtx"
 )
 
 type Controller struct {
-	tx             dbtx.Transactor
-	authorizer     authz.Authorizer
-	executionStore store.ExecutionStore
-	checkStore     store.CheckStore
-	canceler       canceler.Canceler
-	commitService  commit.Service
-	triggerer      triggerer.Triggerer
-	repoStore      store.RepoStore
-	stageStore     store.StageStore
-	pipelineStore  store.PipelineStore
+	tx                 dbtx.Transactor
+	authorizer         authz.Authorizer
+	executionStore     store.ExecutionStore
+	checkStore         store.CheckStore
+	canceler           canceler.Canceler
+	commitService      commit.Service
+	triggerer          triggerer.Triggerer
+	repoStore          store.RepoStore
+	stageStore         store.StageStore
+	pipelineStore      store.PipelineStore
+	approvalStore      store.ApprovalStore
+	principalInfoCache store.PrincipalInfoCache
+	approvalSvc        *approval.Service
+	artifacts          *pipelineartifact.Service
 }
 
 func NewController(
@@ -47,17 +53,25 @@ func NewController(
 	repoStore store.RepoStore,
 	stageStore store.StageStore,
 	pipelineStore store.PipelineStore,
+	approvalStore store.ApprovalStore,
+	principalInfoCache store.PrincipalInfoCache,
+	approvalSvc *approval.Service,
+	artifacts *pipelineartifact.Service,
 ) *Controller {
 	return &Controller{
-		tx:             tx,
-		authorizer:     authorizer,
-		executionStore: executionStore,
-		checkStore:     checkStore,
-		canceler:       canceler,
-		commitService:  commitService,
-		triggerer:      triggerer,
-		repoStore:      repoStore,
-		stageStore:     stageStore,
-		pipelineStore:  pipelineStore,
+		tx:                 tx,
+		authorizer:         authorizer,
+		executionStore:     executionStore,
+		checkStore:         checkStore,
+		canceler:           canceler,
+		commitService:      commitService,
+		triggerer:          triggerer,
+		repoStore:          repoStore,
+		stageStore:         stageStore,
+		pipelineStore:      pipelineStore,
+		approvalStore:      approvalStore,
+		principalInfoCache: principalInfoCache,
+		approvalSvc:        approvalSvc,
+		artifacts:          artifacts,
 	}
 }
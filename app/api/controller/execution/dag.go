@@ -0,0 +1,96 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// FindDAG returns the graph of the stages of an execution and their dependencies.
+func (c *Controller) FindDAG(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineUID string,
+	executionNum int64,
+) (*types.ExecutionDAG, error) {
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo by ref: %w", err)
+	}
+	err = apiauth.CheckPipeline(ctx, c.authorizer, session, repo.Path, pipelineUID, enum.PermissionPipelineView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authorize: %w", err)
+	}
+
+	pipeline, err := c.pipelineStore.FindByUID(ctx, repo.ID, pipelineUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	execution, err := c.executionStore.FindByNumber(ctx, pipeline.ID, executionNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find execution %d: %w", executionNum, err)
+	}
+
+	stages, err := c.stageStore.List(ctx, execution.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stages of execution %d: %w", executionNum, err)
+	}
+
+	return buildDAG(stages), nil
+}
+
+func buildDAG(stages []*types.Stage) *types.ExecutionDAG {
+	numbers := make(map[string]int64, len(stages))
+	for _, stage := range stages {
+		numbers[stage.Name] = stage.Number
+	}
+
+	dag := &types.ExecutionDAG{
+		Nodes: make([]types.ExecutionDAGNode, 0, len(stages)),
+		Edges: []types.ExecutionDAGEdge{},
+	}
+
+	for _, stage := range stages {
+		dag.Nodes = append(dag.Nodes, types.ExecutionDAGNode{
+			Stage:  stage.Number,
+			Name:   stage.Name,
+			Kind:   stage.Kind,
+			Status: stage.Status,
+		})
+
+		for _, dep := range stage.DependsOn {
+			from, ok := numbers[dep]
+			if !ok {
+				// ignore dependencies that don't refer to a stage of the execution.
+				continue
+			}
+
+			dag.Edges = append(dag.Edges, types.ExecutionDAGEdge{
+				From: from,
+				To:   stage.Number,
+			})
+		}
+	}
+
+	return dag
+}
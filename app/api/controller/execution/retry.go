@@ -0,0 +1,193 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/pipeline/triggerer"
+	"github.com/harness/gitness/app/pipeline/triggerer/gate"
+	"github.com/harness/gitness/app/pipeline/triggerer/retry"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// RetryInput defines what part of a finished execution is retried.
+type RetryInput struct {
+	// Stage is the number of the failed stage to retry. If not provided, the whole execution is retried.
+	Stage int64 `json:"stage"`
+	// Step is the number of the failed step of the stage the retry starts from.
+	// If not provided, the whole stage is retried.
+	Step int64 `json:"step"`
+}
+
+// Retry creates a new execution that retries a finished execution, a single failed stage of it,
+// or a failed stage starting from its failed step. The new execution runs on the same commit
+// with the same parameters, and references the retried execution as its parent.
+// Stages that aren't retried keep their results and the artifacts of the retried execution are copied.
+func (c *Controller) Retry(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineUID string,
+	executionNum int64,
+	in *RetryInput,
+) (*types.Execution, error) {
+	if in.Step > 0 && in.Stage <= 0 {
+		return nil, usererror.BadRequest("A stage is required to retry from a step.")
+	}
+
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo by ref: %w", err)
+	}
+	err = apiauth.CheckPipeline(ctx, c.authorizer, session, repo.Path,
+		pipelineUID, enum.PermissionPipelineExecute)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authorize: %w", err)
+	}
+
+	pipeline, err := c.pipelineStore.FindByUID(ctx, repo.ID, pipelineUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	parent, err := c.executionStore.FindByNumber(ctx, pipeline.ID, executionNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find execution %d: %w", executionNum, err)
+	}
+
+	if !parent.Status.IsDone() {
+		return nil, usererror.BadRequest("Only finished executions can be retried.")
+	}
+
+	r, err := c.getRetry(ctx, parent, in)
+	if err != nil {
+		return nil, err
+	}
+
+	hook := &triggerer.Hook{
+		Parent:       parent.Number,
+		Trigger:      session.Principal.UID,
+		TriggeredBy:  session.Principal.ID,
+		Action:       enum.TriggerAction(parent.Action),
+		Link:         parent.Link,
+		Timestamp:    parent.Timestamp,
+		Title:        parent.Title,
+		Message:      parent.Message,
+		Before:       parent.Before,
+		After:        parent.After,
+		Ref:          parent.Ref,
+		Fork:         parent.Fork,
+		Source:       parent.Source,
+		Target:       parent.Target,
+		AuthorLogin:  parent.Author,
+		AuthorName:   parent.AuthorName,
+		AuthorEmail:  parent.AuthorEmail,
+		AuthorAvatar: parent.AuthorAvatar,
+		Debug:        parent.Debug,
+		Cron:         parent.Cron,
+		Sender:       session.Principal.UID,
+		Params:       parent.Params,
+		Retry:        r,
+	}
+
+	execution, err := c.triggerer.Trigger(ctx, pipeline, hook)
+	if errors.Is(err, retry.ErrStepRetryUnsupported) {
+		return nil, usererror.BadRequest("Retrying from a step is only supported for v1 pipelines.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to trigger retry execution: %w", err)
+	}
+	if execution == nil || r.Stage == "" {
+		return execution, nil
+	}
+
+	// the stages that aren't retried don't run again, so their artifacts are carried over.
+	n, err := c.artifacts.Copy(ctx, parent.ID, execution.ID)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to copy artifacts of execution %d", parent.Number)
+	}
+	log.Ctx(ctx).Debug().Msgf("copied %d artifacts of execution %d", n, parent.Number)
+
+	return execution, nil
+}
+
+// getRetry returns the retry definition of the execution for the provided input.
+func (c *Controller) getRetry(
+	ctx context.Context,
+	parent *types.Execution,
+	in *RetryInput,
+) (*retry.Retry, error) {
+	if in.Stage <= 0 {
+		return &retry.Retry{}, nil
+	}
+
+	stages, err := c.stageStore.ListWithSteps(ctx, parent.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stages: %w", err)
+	}
+
+	var stage *types.Stage
+	for _, s := range stages {
+		if s.Number == in.Stage {
+			stage = s
+			break
+		}
+	}
+	if stage == nil {
+		return nil, usererror.NotFound(fmt.Sprintf("Stage %d not found.", in.Stage))
+	}
+
+	if stage.Kind == gate.StageKind {
+		return nil, usererror.BadRequest("Approval stages can't be retried.")
+	}
+
+	if !stage.Status.IsFailed() {
+		return nil, usererror.BadRequestf("Stage %d didn't fail.", in.Stage)
+	}
+
+	r := &retry.Retry{
+		Stages: stages,
+		Stage:  stage.Name,
+	}
+
+	if in.Step <= 0 {
+		return r, nil
+	}
+
+	for _, step := range stage.Steps {
+		if step.Number != in.Step {
+			continue
+		}
+
+		if !step.Status.IsFailed() {
+			return nil, usererror.BadRequestf("Step %d of stage %d didn't fail.", in.Step, in.Stage)
+		}
+
+		r.Step = step.Name
+
+		return r, nil
+	}
+
+	return nil, usererror.NotFound(fmt.Sprintf("Step %d of stage %d not found.", in.Step, in.Stage))
+}
@@ -19,6 +19,8 @@ import (
 	"github.com/harness/gitness/app/pipeline/canceler"
 	"github.com/harness/gitness/app/pipeline/commit"
 	"github.com/harness/gitness/app/pipeline/triggerer"
+	"github.com/harness/gitness/app/services/approval"
+	"github.com/harness/gitness/app/services/pipelineartifact"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/store/database/dbtx"
 
@@ -41,7 +43,12 @@ func ProvideController(
 	repoStore store.RepoStore,
 	stageStore store.StageStore,
 	pipelineStore store.PipelineStore,
+	approvalStore store.ApprovalStore,
+	principalInfoCache store.PrincipalInfoCache,
+	approvalSvc *approval.Service,
+	artifacts *pipelineartifact.Service,
 ) *Controller {
 	return NewController(tx, authorizer, executionStore, checkStore,
-		canceler, commitService, triggerer, repoStore, stageStore, pipelineStore)
+		canceler, commitService, triggerer, repoStore, stageStore, pipelineStore,
+		approvalStore, principalInfoCache, approvalSvc, artifacts)
 }
@@ -18,10 +18,14 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/harness/gitness/app/api/controller/limiter"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
 	eventsgit "github.com/harness/gitness/app/events/git"
+	"github.com/harness/gitness/app/services/githookext"
+	"github.com/harness/gitness/app/services/passkey"
+	"github.com/harness/gitness/app/services/policyhook"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -62,6 +66,11 @@ type Controller struct {
 	pullreqStore      store.PullReqStore
 	urlProvider       url.Provider
 	protectionManager *protection.Manager
+	resourceLimiter   limiter.ResourceLimiter
+	extensions        *githookext.Manager
+	policyHooks       *policyhook.Service
+	mirrorStore       store.RepoMirrorStore
+	passkeys          *passkey.Service
 }
 
 func NewController(
@@ -73,6 +82,11 @@ func NewController(
 	pullreqStore store.PullReqStore,
 	urlProvider url.Provider,
 	protectionManager *protection.Manager,
+	resourceLimiter limiter.ResourceLimiter,
+	extensions *githookext.Manager,
+	policyHooks *policyhook.Service,
+	mirrorStore store.RepoMirrorStore,
+	passkeys *passkey.Service,
 ) *Controller {
 	return &Controller{
 		authorizer:        authorizer,
@@ -83,6 +97,11 @@ func NewController(
 		pullreqStore:      pullreqStore,
 		urlProvider:       urlProvider,
 		protectionManager: protectionManager,
+		resourceLimiter:   resourceLimiter,
+		extensions:        extensions,
+		policyHooks:       policyHooks,
+		mirrorStore:       mirrorStore,
+		passkeys:          passkeys,
 	}
 }
 
@@ -75,9 +75,9 @@ func (c *Controller) reportReferenceEvents(
 	for _, refUpdate := range in.RefUpdates {
 		switch {
 		case strings.HasPrefix(refUpdate.Ref, gitReferenceNamePrefixBranch):
-			c.reportBranchEvent(ctx, repo, principalID, refUpdate)
+			c.reportBranchEvent(ctx, repo, principalID, refUpdate, in.PushOptions)
 		case strings.HasPrefix(refUpdate.Ref, gitReferenceNamePrefixTag):
-			c.reportTagEvent(ctx, repo, principalID, refUpdate)
+			c.reportTagEvent(ctx, repo, principalID, refUpdate, in.PushOptions)
 		default:
 			// Ignore any other references in post-receive
 		}
@@ -89,6 +89,7 @@ func (c *Controller) reportBranchEvent(
 	repo *types.Repository,
 	principalID int64,
 	branchUpdate hook.ReferenceUpdate,
+	pushOptions []string,
 ) {
 	switch {
 	case branchUpdate.Old == types.NilSHA:
@@ -97,6 +98,7 @@ func (c *Controller) reportBranchEvent(
 			PrincipalID: principalID,
 			Ref:         branchUpdate.Ref,
 			SHA:         branchUpdate.New,
+			PushOptions: pushOptions,
 		})
 	case branchUpdate.New == types.NilSHA:
 		c.gitReporter.BranchDeleted(ctx, &events.BranchDeletedPayload{
@@ -104,6 +106,7 @@ func (c *Controller) reportBranchEvent(
 			PrincipalID: principalID,
 			Ref:         branchUpdate.Ref,
 			SHA:         branchUpdate.Old,
+			PushOptions: pushOptions,
 		})
 	default:
 		result, err := c.git.IsAncestor(ctx, git.IsAncestorParams{
@@ -127,6 +130,7 @@ func (c *Controller) reportBranchEvent(
 			OldSHA:      branchUpdate.Old,
 			NewSHA:      branchUpdate.New,
 			Forced:      forced,
+			PushOptions: pushOptions,
 		})
 	}
 }
@@ -136,6 +140,7 @@ func (c *Controller) reportTagEvent(
 	repo *types.Repository,
 	principalID int64,
 	tagUpdate hook.ReferenceUpdate,
+	pushOptions []string,
 ) {
 	switch {
 	case tagUpdate.Old == types.NilSHA:
@@ -144,6 +149,7 @@ func (c *Controller) reportTagEvent(
 			PrincipalID: principalID,
 			Ref:         tagUpdate.Ref,
 			SHA:         tagUpdate.New,
+			PushOptions: pushOptions,
 		})
 	case tagUpdate.New == types.NilSHA:
 		c.gitReporter.TagDeleted(ctx, &events.TagDeletedPayload{
@@ -151,6 +157,7 @@ func (c *Controller) reportTagEvent(
 			PrincipalID: principalID,
 			Ref:         tagUpdate.Ref,
 			SHA:         tagUpdate.Old,
+			PushOptions: pushOptions,
 		})
 	default:
 		c.gitReporter.TagUpdated(ctx, &events.TagUpdatedPayload{
@@ -160,7 +167,8 @@ func (c *Controller) reportTagEvent(
 			OldSHA:      tagUpdate.Old,
 			NewSHA:      tagUpdate.New,
 			// tags can only be force updated!
-			Forced: true,
+			Forced:      true,
+			PushOptions: pushOptions,
 		})
 	}
 }
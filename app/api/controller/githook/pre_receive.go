@@ -20,10 +20,16 @@ import (
 	"strings"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller/limiter"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/githookext"
+	"github.com/harness/gitness/app/services/policyhook"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/git/hook"
+	gitness_store "github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -46,6 +52,16 @@ func (c *Controller) PreReceive(
 		return hook.Output{}, err
 	}
 
+	// mirrors are updated by their synchronization, which fetches the refs without running the git hooks.
+	isMirror, err := c.isMirror(ctx, repo.ID)
+	if err != nil {
+		return hook.Output{}, err
+	}
+	if isMirror {
+		output.Error = ptr.String(usererror.ErrMirrorIsReadOnly.Error())
+		return output, nil
+	}
+
 	refUpdates := groupRefsByAction(in.RefUpdates)
 
 	if slices.Contains(refUpdates.branches.deleted, repo.DefaultBranch) {
@@ -64,25 +80,135 @@ func (c *Controller) PreReceive(
 		return output, nil
 	}
 
+	err = c.checkRepoSize(ctx, repo, in.PackSize, &output)
+	if err != nil {
+		return hook.Output{}, fmt.Errorf("failed to check repository size: %w", err)
+	}
+	if output.Error != nil {
+		return output, nil
+	}
+
 	// TODO: use store.PrincipalInfoCache once we abstracted principals.
 	principal, err := c.principalStore.Find(ctx, in.PrincipalID)
 	if err != nil {
 		return hook.Output{}, fmt.Errorf("failed to find inner principal with id %d: %w", in.PrincipalID, err)
 	}
 
+	// admins that still have to register a passkey can't use their admin permissions to bypass the rules.
+	restricted, err := c.passkeys.AdminRestricted(ctx, principal)
+	if err != nil {
+		return hook.Output{}, fmt.Errorf("failed to check admin restrictions: %w", err)
+	}
+	if restricted {
+		principal.Admin = false
+	}
+
 	dummySession := &auth.Session{
 		Principal: *principal,
 		Metadata:  nil,
 	}
 
-	err = c.checkProtectionRules(ctx, dummySession, repo, refUpdates, &output)
+	findBinaryBlobs := c.binaryBlobFinder(repo, in)
+
+	// principals allowed to bypass the protection rules bypass them,
+	// unless the client explicitly requested to enforce them (`git push -o strict_rules`).
+	allowBypass := !slices.Contains(in.PushOptions, hook.PushOptionStrictRules)
+
+	err = c.checkProtectionRules(ctx, dummySession, repo, refUpdates, allowBypass, findBinaryBlobs, &output)
 	if err != nil {
 		return hook.Output{}, fmt.Errorf("failed to check protection rules: %w", err)
 	}
+	if output.Error != nil {
+		return output, nil
+	}
+
+	err = c.checkPolicyHooks(ctx, repo, in, &output)
+	if err != nil {
+		return hook.Output{}, fmt.Errorf("failed to check policy hooks: %w", err)
+	}
+	if output.Error != nil {
+		return output, nil
+	}
+
+	c.runExtensions(ctx, githookext.HookPreReceive, repo, principal, in.RefUpdates, in.PushOptions, &output)
 
 	return output, nil
 }
 
+// isMirror returns true if the repository is a mirror of a remote repository.
+func (c *Controller) isMirror(ctx context.Context, repoID int64) (bool, error) {
+	_, err := c.mirrorStore.Find(ctx, repoID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to find repository mirror: %w", err)
+	}
+
+	return true, nil
+}
+
+// checkPolicyHooks evaluates the policy hooks the repository declares for the created and updated branches.
+func (c *Controller) checkPolicyHooks(
+	ctx context.Context,
+	repo *types.Repository,
+	in types.GithookPreReceiveInput,
+	output *hook.Output,
+) error {
+	branches := make(map[string]string, len(in.RefUpdates))
+	for _, refUpdate := range in.RefUpdates {
+		if refUpdate.New == types.NilSHA || !strings.HasPrefix(refUpdate.Ref, gitReferenceNamePrefixBranch) {
+			continue
+		}
+		branches[refUpdate.Ref[len(gitReferenceNamePrefixBranch):]] = refUpdate.New
+	}
+
+	out, err := c.policyHooks.VerifyPush(ctx, policyhook.PushInput{
+		Repo:                repo,
+		Branches:            branches,
+		AlternateObjectDirs: in.Environment.AlternateObjectDirs,
+	})
+	if err != nil {
+		return err
+	}
+
+	output.Messages = append(output.Messages, out.Messages...)
+	output.Error = out.Error
+
+	return nil
+}
+
+// runExtensions runs the githook extensions of the operator for the hook.
+func (c *Controller) runExtensions(
+	ctx context.Context,
+	h githookext.Hook,
+	repo *types.Repository,
+	principal *types.Principal,
+	refUpdates []hook.ReferenceUpdate,
+	pushOptions []string,
+	output *hook.Output,
+) {
+	out := c.extensions.Run(ctx, &githookext.Input{
+		Hook: h,
+		Repo: githookext.Repo{
+			ID:            repo.ID,
+			Path:          repo.Path,
+			DefaultBranch: repo.DefaultBranch,
+		},
+		Principal: githookext.Principal{
+			ID:          principal.ID,
+			UID:         principal.UID,
+			Email:       principal.Email,
+			DisplayName: principal.DisplayName,
+		},
+		RefUpdates:  refUpdates,
+		PushOptions: pushOptions,
+	})
+
+	output.Messages = append(output.Messages, out.Messages...)
+	output.Error = out.Error
+}
+
 func (c *Controller) blockPullReqRefUpdate(refUpdates changedRefs) bool {
 	fn := func(ref string) bool {
 		return strings.HasPrefix(ref, gitReferenceNamePullReq)
@@ -93,11 +219,44 @@ func (c *Controller) blockPullReqRefUpdate(refUpdates changedRefs) bool {
 		slices.ContainsFunc(refUpdates.other.updated, fn)
 }
 
+// checkRepoSize verifies that the received objects don't make the repository exceed its size limit.
+func (c *Controller) checkRepoSize(
+	ctx context.Context,
+	repo *types.Repository,
+	packSize int64,
+	output *hook.Output,
+) error {
+	err := c.resourceLimiter.RepoSize(ctx, repo, packSize)
+
+	var violation *limiter.RepoSizeViolation
+	if !errors.As(err, &violation) {
+		return err
+	}
+
+	output.Messages = append(output.Messages,
+		fmt.Sprintf("Repository size limit: %d KiB", violation.Limit),
+		fmt.Sprintf("Current repository size: %d KiB", violation.CurrentSize),
+		fmt.Sprintf("Size of the push: %d KiB", violation.PushSize),
+	)
+
+	if violation.WarnOnly {
+		output.Messages = append(output.Messages,
+			"WARNING: The push exceeds the repository size limit. Pushes exceeding the limit might be rejected in the future.")
+		return nil
+	}
+
+	output.Error = ptr.String("Push rejected: the repository size limit would be exceeded.")
+
+	return nil
+}
+
 func (c *Controller) checkProtectionRules(
 	ctx context.Context,
 	session *auth.Session,
 	repo *types.Repository,
 	refUpdates changedRefs,
+	allowBypass bool,
+	findBinaryBlobs func(ctx context.Context, branch string) ([]protection.BinaryBlob, error),
 	output *hook.Output,
 ) error {
 	isRepoOwner, err := apiauth.IsRepoOwner(ctx, c.authorizer, session, repo)
@@ -120,7 +279,7 @@ func (c *Controller) checkProtectionRules(
 
 		violations, err := protectionRules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
 			Actor:       &session.Principal,
-			AllowBypass: true,
+			AllowBypass: allowBypass,
 			IsRepoOwner: isRepoOwner,
 			Repo:        repo,
 			RefAction:   refAction,
@@ -143,10 +302,31 @@ func (c *Controller) checkProtectionRules(
 		return errCheckAction
 	}
 
+	pushedBranches := append(slices.Clone(refUpdates.branches.created), refUpdates.branches.updated...)
+	if len(pushedBranches) > 0 {
+		violations, err := protectionRules.PushVerify(ctx, protection.PushVerifyInput{
+			Actor:           &session.Principal,
+			AllowBypass:     allowBypass,
+			IsRepoOwner:     isRepoOwner,
+			Repo:            repo,
+			RefNames:        pushedBranches,
+			FindBinaryBlobs: findBinaryBlobs,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to verify push protection rules for git push: %w", err)
+		}
+
+		ruleViolations = append(ruleViolations, violations...)
+	}
+
 	var criticalViolation bool
+	bypassable := true
 
 	for _, ruleViolation := range ruleViolations {
-		criticalViolation = criticalViolation || ruleViolation.IsCritical()
+		if ruleViolation.IsCritical() {
+			criticalViolation = true
+			bypassable = bypassable && ruleViolation.Bypassable
+		}
 		for _, violation := range ruleViolation.Violations {
 			message := fmt.Sprintf("Rule %q violation: %s", ruleViolation.Rule.UID, violation.Message)
 			output.Messages = append(output.Messages, message)
@@ -154,12 +334,65 @@ func (c *Controller) checkProtectionRules(
 	}
 
 	if criticalViolation {
+		if bypassable {
+			output.Messages = append(output.Messages,
+				fmt.Sprintf("You are allowed to bypass the rules, push without '-o %s' to do so.",
+					hook.PushOptionStrictRules))
+		}
 		output.Error = ptr.String("Blocked by protection rules.")
 	}
 
 	return nil
 }
 
+// binaryBlobFinder returns a function that finds the binary files the push introduces to a branch.
+// The results are cached, as multiple protection rules can ask for the same branch.
+func (c *Controller) binaryBlobFinder(
+	repo *types.Repository,
+	in types.GithookPreReceiveInput,
+) func(ctx context.Context, branch string) ([]protection.BinaryBlob, error) {
+	newSHAs := make(map[string]string, len(in.RefUpdates))
+	for _, refUpdate := range in.RefUpdates {
+		if strings.HasPrefix(refUpdate.Ref, gitReferenceNamePrefixBranch) {
+			newSHAs[refUpdate.Ref[len(gitReferenceNamePrefixBranch):]] = refUpdate.New
+		}
+	}
+
+	cache := make(map[string][]protection.BinaryBlob)
+
+	return func(ctx context.Context, branch string) ([]protection.BinaryBlob, error) {
+		if blobs, ok := cache[branch]; ok {
+			return blobs, nil
+		}
+
+		newSHA, ok := newSHAs[branch]
+		if !ok || newSHA == types.NilSHA {
+			return nil, nil
+		}
+
+		out, err := c.git.FindBinaryBlobs(ctx, &git.FindBinaryBlobsParams{
+			ReadParams:          git.ReadParams{RepoUID: repo.GitUID},
+			Revs:                []string{newSHA},
+			AlternateObjectDirs: in.Environment.AlternateObjectDirs,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to find binary blobs: %w", err)
+		}
+
+		blobs := make([]protection.BinaryBlob, len(out.Blobs))
+		for i, blob := range out.Blobs {
+			blobs[i] = protection.BinaryBlob{
+				Path: blob.Path,
+				Size: blob.Size,
+			}
+		}
+
+		cache[branch] = blobs
+
+		return blobs, nil
+	}
+}
+
 type changes struct {
 	created []string
 	deleted []string
@@ -16,20 +16,40 @@ package githook
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/githookext"
 	"github.com/harness/gitness/git/hook"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
 // Update executes the update hook for a git repository.
-//
-//nolint:revive // not yet implemented
 func (c *Controller) Update(
 	ctx context.Context,
 	session *auth.Session,
 	in types.GithookUpdateInput,
 ) (hook.Output, error) {
-	// We currently don't have any update action (nothing planned as of now)
-	return hook.Output{}, nil
+	output := hook.Output{}
+
+	if in.Internal {
+		// It's an internal call, so no need to run the extensions.
+		return output, nil
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, in.RepoID, enum.PermissionRepoPush)
+	if err != nil {
+		return hook.Output{}, err
+	}
+
+	// TODO: use store.PrincipalInfoCache once we abstracted principals.
+	principal, err := c.principalStore.Find(ctx, in.PrincipalID)
+	if err != nil {
+		return hook.Output{}, fmt.Errorf("failed to find inner principal with id %d: %w", in.PrincipalID, err)
+	}
+
+	c.runExtensions(ctx, githookext.HookUpdate, repo, principal, []hook.ReferenceUpdate{in.RefUpdate}, nil, &output)
+
+	return output, nil
 }
@@ -19,13 +19,18 @@ import (
 	"github.com/harness/gitness/app/api/controller/space"
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/store"
 )
 
 type Controller struct {
-	authorizer authz.Authorizer
-	repoCtrl   *repo.Controller
-	searcher   keywordsearch.Searcher
-	spaceCtrl  *space.Controller
+	authorizer     authz.Authorizer
+	repoCtrl       *repo.Controller
+	searcher       keywordsearch.Searcher
+	spaceCtrl      *space.Controller
+	repoStore      store.RepoStore
+	spaceStore     store.SpaceStore
+	principalStore store.PrincipalStore
+	pullreqStore   store.PullReqStore
 }
 
 func NewController(
@@ -33,11 +38,19 @@ func NewController(
 	searcher keywordsearch.Searcher,
 	repoCtrl *repo.Controller,
 	spaceCtrl *space.Controller,
+	repoStore store.RepoStore,
+	spaceStore store.SpaceStore,
+	principalStore store.PrincipalStore,
+	pullreqStore store.PullReqStore,
 ) *Controller {
 	return &Controller{
-		authorizer: authorizer,
-		searcher:   searcher,
-		repoCtrl:   repoCtrl,
-		spaceCtrl:  spaceCtrl,
+		authorizer:     authorizer,
+		searcher:       searcher,
+		repoCtrl:       repoCtrl,
+		spaceCtrl:      spaceCtrl,
+		repoStore:      repoStore,
+		spaceStore:     spaceStore,
+		principalStore: principalStore,
+		pullreqStore:   pullreqStore,
 	}
 }
@@ -0,0 +1,401 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	// globalSearchBatchSize is the number of items fetched from the database at once
+	// while collecting the results the principal is allowed to see.
+	globalSearchBatchSize = 100
+
+	// globalSearchMaxBatches limits the number of database queries per result group.
+	globalSearchMaxBatches = 10
+
+	// globalSearchMaxResults is the maximum number of results per group that are ranked.
+	globalSearchMaxResults = globalSearchBatchSize * globalSearchMaxBatches
+)
+
+// GlobalSearch searches repositories, spaces, users, pull requests and code.
+// The results are grouped by their type and only contain the resources the principal has access to.
+// Each group is paginated separately. Code is searched only if the search is restricted to a space.
+// The results of a group are ranked before they are paginated, so pages are consistent with each other.
+// Only the first globalSearchMaxResults matches of each group are ranked and returned.
+func (c *Controller) GlobalSearch(
+	ctx context.Context,
+	session *auth.Session,
+	filter *types.GlobalSearchFilter,
+) (*types.GlobalSearchResult, error) {
+	filter.Query = strings.TrimSpace(filter.Query)
+	if filter.Query == "" {
+		return nil, usererror.BadRequest("query cannot be empty.")
+	}
+
+	var scope *types.Space
+	if filter.SpacePath != "" {
+		space, err := c.spaceStore.FindByRef(ctx, filter.SpacePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find space: %w", err)
+		}
+
+		if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceView, true); err != nil {
+			return nil, err
+		}
+
+		scope = space
+	}
+
+	resultTypes := filter.Types
+	if len(resultTypes) == 0 {
+		resultTypes, _ = enum.GetAllSearchResultTypes()
+	}
+
+	groups := make([]types.GlobalSearchGroup, 0, len(resultTypes))
+	for _, resultType := range resultTypes {
+		var (
+			items []types.GlobalSearchItem
+			err   error
+		)
+
+		switch resultType {
+		case enum.SearchResultTypeRepo:
+			items, err = c.searchRepos(ctx, session, scope, filter)
+		case enum.SearchResultTypeSpace:
+			items, err = c.searchSpaces(ctx, session, scope, filter)
+		case enum.SearchResultTypeUser:
+			items, err = c.searchUsers(ctx, session, filter)
+		case enum.SearchResultTypePullReq:
+			items, err = c.searchPullReqs(ctx, session, scope, filter)
+		case enum.SearchResultTypeCode:
+			items, err = c.searchCode(ctx, session, scope, filter)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to search %s: %w", resultType, err)
+		}
+
+		sortSearchItems(items)
+		items, hasMore := pageSearchItems(items, filter.Page, filter.Size)
+
+		groups = append(groups, types.GlobalSearchGroup{
+			Type:    resultType,
+			Page:    filter.Page,
+			HasMore: hasMore,
+			Items:   items,
+		})
+	}
+
+	sortSearchGroups(groups)
+
+	return &types.GlobalSearchResult{Groups: groups}, nil
+}
+
+func (c *Controller) searchRepos(
+	ctx context.Context,
+	session *auth.Session,
+	scope *types.Space,
+	filter *types.GlobalSearchFilter,
+) ([]types.GlobalSearchItem, error) {
+	repos, err := collectPermitted(
+		func(page, size int) ([]*types.Repository, error) {
+			return c.repoStore.Search(ctx, &types.RepoFilter{
+				Page:  page,
+				Size:  size,
+				Query: filter.Query,
+				Sort:  enum.RepoAttrUID,
+				Order: enum.OrderAsc,
+			})
+		},
+		func(repo *types.Repository) (bool, error) {
+			if !inScope(scope, repo.Path) {
+				return false, nil
+			}
+			return permitted(apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoView, true))
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]types.GlobalSearchItem, len(repos))
+	for i, repo := range repos {
+		items[i] = types.GlobalSearchItem{
+			Type:  enum.SearchResultTypeRepo,
+			Score: searchScore(filter.Query, repo.UID, repo.Path),
+			Repo:  repo,
+		}
+	}
+
+	return items, nil
+}
+
+func (c *Controller) searchSpaces(
+	ctx context.Context,
+	session *auth.Session,
+	scope *types.Space,
+	filter *types.GlobalSearchFilter,
+) ([]types.GlobalSearchItem, error) {
+	spaces, err := collectPermitted(
+		func(page, size int) ([]*types.Space, error) {
+			return c.spaceStore.Search(ctx, &types.SpaceFilter{
+				Page:  page,
+				Size:  size,
+				Query: filter.Query,
+				Sort:  enum.SpaceAttrUID,
+				Order: enum.OrderAsc,
+			})
+		},
+		func(space *types.Space) (bool, error) {
+			if !inScope(scope, space.Path) {
+				return false, nil
+			}
+			return permitted(apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceView, true))
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]types.GlobalSearchItem, len(spaces))
+	for i, space := range spaces {
+		items[i] = types.GlobalSearchItem{
+			Type:  enum.SearchResultTypeSpace,
+			Score: searchScore(filter.Query, space.UID, space.Path),
+			Space: space,
+		}
+	}
+
+	return items, nil
+}
+
+func (c *Controller) searchUsers(
+	ctx context.Context,
+	session *auth.Session,
+	filter *types.GlobalSearchFilter,
+) ([]types.GlobalSearchItem, error) {
+	if session == nil {
+		// only authenticated principals can search for users
+		return []types.GlobalSearchItem{}, nil
+	}
+
+	principals, err := c.principalStore.List(ctx, &types.PrincipalFilter{
+		Page:  1,
+		Size:  globalSearchMaxResults,
+		Query: filter.Query,
+		Types: []enum.PrincipalType{enum.PrincipalTypeUser},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	items := make([]types.GlobalSearchItem, len(principals))
+	for i, principal := range principals {
+		items[i] = types.GlobalSearchItem{
+			Type:  enum.SearchResultTypeUser,
+			Score: searchScore(filter.Query, principal.UID, principal.DisplayName, principal.Email),
+			User:  principal.ToPrincipalInfo(),
+		}
+	}
+
+	return items, nil
+}
+
+func (c *Controller) searchPullReqs(
+	ctx context.Context,
+	session *auth.Session,
+	scope *types.Space,
+	filter *types.GlobalSearchFilter,
+) ([]types.GlobalSearchItem, error) {
+	// pull requests are checked using the permissions of their target repository
+	repoPermitted := make(map[int64]bool)
+
+	pullReqs, err := collectPermitted(
+		func(page, size int) ([]*types.PullReq, error) {
+			return c.pullreqStore.List(ctx, &types.PullReqFilter{
+				Page:  page,
+				Size:  size,
+				Query: filter.Query,
+				Sort:  enum.PullReqSortNumber,
+				Order: enum.OrderDesc,
+			})
+		},
+		func(pr *types.PullReq) (bool, error) {
+			if ok, exists := repoPermitted[pr.TargetRepoID]; exists {
+				return ok, nil
+			}
+
+			repo, err := c.repoStore.Find(ctx, pr.TargetRepoID)
+			if err != nil {
+				return false, fmt.Errorf("failed to find repository: %w", err)
+			}
+
+			ok := inScope(scope, repo.Path)
+			if ok {
+				ok, err = permitted(apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoView, true))
+				if err != nil {
+					return false, err
+				}
+			}
+
+			repoPermitted[pr.TargetRepoID] = ok
+
+			return ok, nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]types.GlobalSearchItem, len(pullReqs))
+	for i, pr := range pullReqs {
+		items[i] = types.GlobalSearchItem{
+			Type:    enum.SearchResultTypePullReq,
+			Score:   searchScore(filter.Query, pr.Title),
+			PullReq: pr,
+		}
+	}
+
+	return items, nil
+}
+
+func (c *Controller) searchCode(
+	ctx context.Context,
+	session *auth.Session,
+	scope *types.Space,
+	filter *types.GlobalSearchFilter,
+) ([]types.GlobalSearchItem, error) {
+	if scope == nil {
+		// searching code of all repositories is too expensive, code search must be restricted to a space.
+		return []types.GlobalSearchItem{}, nil
+	}
+
+	repoIDToPathMap, err := c.getReposBySpacePath(ctx, session, scope.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(repoIDToPathMap) == 0 {
+		return []types.GlobalSearchItem{}, nil
+	}
+
+	repoIDs := make([]int64, 0, len(repoIDToPathMap))
+	for repoID := range repoIDToPathMap {
+		repoIDs = append(repoIDs, repoID)
+	}
+
+	result, err := c.searcher.Search(ctx, repoIDs, filter.Query, globalSearchMaxResults)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search code: %w", err)
+	}
+
+	fileMatches := result.FileMatches
+
+	items := make([]types.GlobalSearchItem, len(fileMatches))
+	for i := range fileMatches {
+		fileMatch := fileMatches[i]
+		fileMatch.RepoPath = repoIDToPathMap[fileMatch.RepoID]
+
+		score := searchScore(filter.Query, path.Base(fileMatch.FileName))
+		if score < searchScoreContains {
+			score = searchScoreContains // the file content matches the query
+		}
+
+		items[i] = types.GlobalSearchItem{
+			Type:  enum.SearchResultTypeCode,
+			Score: score,
+			File:  &fileMatch,
+		}
+	}
+
+	return items, nil
+}
+
+// collectPermitted pages through the items returned by the fetch function
+// and returns all items that are accepted by the permit function.
+// At most globalSearchMaxBatches batches are fetched.
+func collectPermitted[T any](
+	fetch func(page, size int) ([]T, error),
+	permit func(item T) (bool, error),
+) ([]T, error) {
+	var result []T
+
+	for batch := 1; batch <= globalSearchMaxBatches; batch++ {
+		items, err := fetch(batch, globalSearchBatchSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range items {
+			ok, err := permit(item)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				result = append(result, item)
+			}
+		}
+
+		if len(items) < globalSearchBatchSize {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+// pageSearchItems returns the requested page of the ranked search results.
+// The returned flag is true if there are more results after the page.
+func pageSearchItems(items []types.GlobalSearchItem, page, size int) ([]types.GlobalSearchItem, bool) {
+	offset := (page - 1) * size
+	if offset >= len(items) {
+		return []types.GlobalSearchItem{}, false
+	}
+
+	items = items[offset:]
+	if len(items) > size {
+		return items[:size], true
+	}
+
+	return items, false
+}
+
+// permitted converts the result of an access check to a flag.
+func permitted(err error) (bool, error) {
+	if errors.Is(err, apiauth.ErrNotAuthorized) || errors.Is(err, apiauth.ErrNotAuthenticated) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// inScope returns true if the resource path is inside the scope space.
+func inScope(scope *types.Space, resourcePath string) bool {
+	if scope == nil {
+		return true
+	}
+
+	return strings.HasPrefix(strings.ToLower(resourcePath), strings.ToLower(scope.Path)+"/")
+}
@@ -0,0 +1,108 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Scores of global search results, depending on how well a value matches the query.
+const (
+	searchScoreExact      = 1.0
+	searchScorePrefix     = 0.75
+	searchScoreWordPrefix = 0.5
+	searchScoreContains   = 0.25
+)
+
+// searchScore returns the score of the best matching value. The comparison is case-insensitive.
+func searchScore(query string, values ...string) float64 {
+	query = strings.ToLower(query)
+
+	var best float64
+	for _, value := range values {
+		value = strings.ToLower(value)
+
+		var score float64
+		switch {
+		case value == query:
+			score = searchScoreExact
+		case strings.HasPrefix(value, query):
+			score = searchScorePrefix
+		case isWordPrefix(value, query):
+			score = searchScoreWordPrefix
+		case strings.Contains(value, query):
+			score = searchScoreContains
+		}
+
+		if score > best {
+			best = score
+		}
+	}
+
+	return best
+}
+
+// isWordPrefix returns true if any word of the value starts with the query.
+func isWordPrefix(value, query string) bool {
+	words := strings.FieldsFunc(value, func(r rune) bool {
+		return r == ' ' || r == '-' || r == '_' || r == '.' || r == '/'
+	})
+
+	for _, word := range words {
+		if strings.HasPrefix(word, query) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sortSearchItems sorts global search results by their score, best match first.
+func sortSearchItems(items []types.GlobalSearchItem) {
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Score > items[j].Score
+	})
+}
+
+// sortSearchGroups sorts global search result groups by the score of their best result.
+// Groups with equal scores are ordered by the result type.
+func sortSearchGroups(groups []types.GlobalSearchGroup) {
+	typeOrder := map[enum.SearchResultType]int{
+		enum.SearchResultTypeRepo:    0,
+		enum.SearchResultTypeSpace:   1,
+		enum.SearchResultTypePullReq: 2,
+		enum.SearchResultTypeUser:    3,
+		enum.SearchResultTypeCode:    4,
+	}
+
+	topScore := func(g types.GlobalSearchGroup) float64 {
+		if len(g.Items) == 0 {
+			return 0
+		}
+		return g.Items[0].Score
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		si, sj := topScore(groups[i]), topScore(groups[j])
+		if si != sj {
+			return si > sj
+		}
+		return typeOrder[groups[i].Type] < typeOrder[groups[j].Type]
+	})
+}
@@ -0,0 +1,136 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestSearchScore(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		values []string
+		exp    float64
+	}{
+		{name: "no-values", query: "abc", values: nil, exp: 0},
+		{name: "no-match", query: "abc", values: []string{"xyz"}, exp: 0},
+		{name: "exact", query: "Gitness", values: []string{"gitness"}, exp: searchScoreExact},
+		{name: "prefix", query: "git", values: []string{"gitness"}, exp: searchScorePrefix},
+		{name: "word-prefix", query: "ness", values: []string{"my-nessie"}, exp: searchScoreWordPrefix},
+		{name: "contains", query: "tne", values: []string{"gitness"}, exp: searchScoreContains},
+		{name: "best-value", query: "repo", values: []string{"space/repo", "repo"}, exp: searchScoreExact},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if want, got := test.exp, searchScore(test.query, test.values...); want != got {
+				t.Errorf("want=%v got=%v", want, got)
+			}
+		})
+	}
+}
+
+func TestCollectPermitted(t *testing.T) {
+	tests := []struct {
+		name    string
+		total   int
+		expLen  int
+		expLast int
+	}{
+		{name: "empty", total: 0, expLen: 0, expLast: 0},
+		{name: "single-batch", total: 50, expLen: 25, expLast: 50},
+		{name: "across-batches", total: 250, expLen: 125, expLast: 250},
+		{name: "max-batches", total: 2 * globalSearchMaxResults, expLen: globalSearchMaxResults / 2,
+			expLast: globalSearchMaxResults},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// items 1..total, only even items are permitted
+			fetch := func(page, size int) ([]int, error) {
+				var items []int
+				for i := (page-1)*size + 1; i <= page*size && i <= test.total; i++ {
+					items = append(items, i)
+				}
+				return items, nil
+			}
+			permit := func(item int) (bool, error) {
+				return item%2 == 0, nil
+			}
+
+			got, err := collectPermitted(fetch, permit)
+			if err != nil {
+				t.Fatalf("got error: %s", err.Error())
+			}
+
+			if want := test.expLen; want != len(got) {
+				t.Fatalf("length: want=%d got=%d", want, len(got))
+			}
+
+			if len(got) > 0 && got[len(got)-1] != test.expLast {
+				t.Errorf("last: want=%d got=%d", test.expLast, got[len(got)-1])
+			}
+		})
+	}
+}
+
+func TestPageSearchItems(t *testing.T) {
+	items := []types.GlobalSearchItem{
+		{Score: searchScoreContains},
+		{Score: searchScoreExact},
+		{Score: searchScorePrefix},
+		{Score: searchScoreWordPrefix},
+		{Score: searchScoreExact},
+	}
+
+	// the ranking must cover all results, not only a single page
+	sortSearchItems(items)
+
+	tests := []struct {
+		name       string
+		page, size int
+		exp        []float64
+		expMore    bool
+	}{
+		{name: "first-page", page: 1, size: 2, exp: []float64{searchScoreExact, searchScoreExact}, expMore: true},
+		{name: "second-page", page: 2, size: 2,
+			exp: []float64{searchScorePrefix, searchScoreWordPrefix}, expMore: true},
+		{name: "last-page", page: 3, size: 2, exp: []float64{searchScoreContains}, expMore: false},
+		{name: "past-end", page: 4, size: 2, exp: []float64{}, expMore: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, gotMore := pageSearchItems(items, test.page, test.size)
+
+			scores := make([]float64, len(got))
+			for i := range got {
+				scores[i] = got[i].Score
+			}
+
+			if want := test.exp; !reflect.DeepEqual(want, scores) {
+				t.Errorf("want=%v got=%v", want, scores)
+			}
+
+			if want := test.expMore; want != gotMore {
+				t.Errorf("has more: want=%t got=%t", want, gotMore)
+			}
+		})
+	}
+}
@@ -16,8 +16,10 @@ package limiter
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types"
 )
 
 var ErrMaxNumReposReached = errors.New("maximum number of repositories reached")
@@ -26,6 +28,27 @@ var ErrMaxNumReposReached = errors.New("maximum number of repositories reached")
 type ResourceLimiter interface {
 	// RepoCount allows the creation of a specified number of repositories.
 	RepoCount(ctx context.Context, count int) error
+
+	// RepoSize allows pushing the specified amount of data (in KiB) to the repository.
+	// In case the push would exceed the maximum size of the repository a *RepoSizeViolation is returned.
+	RepoSize(ctx context.Context, repo *types.Repository, pushSize int64) error
+}
+
+// RepoSizeViolation is returned in case a push would exceed the maximum size of a repository.
+// All sizes are in KiB.
+type RepoSizeViolation struct {
+	Limit       int64 `json:"limit"`
+	CurrentSize int64 `json:"current_size"`
+	PushSize    int64 `json:"push_size"`
+
+	// WarnOnly indicates that the push shouldn't be rejected, only the user should be warned.
+	WarnOnly bool `json:"warn_only"`
+}
+
+func (v *RepoSizeViolation) Error() string {
+	return fmt.Sprintf(
+		"repository size limit of %d KiB exceeded (current size: %d KiB, push size: %d KiB)",
+		v.Limit, v.CurrentSize, v.PushSize)
 }
 
 var _ ResourceLimiter = Unlimited{}
@@ -34,11 +57,48 @@ type Unlimited struct {
 }
 
 // NewResourceLimiter creates a new instance of ResourceLimiter.
-func NewResourceLimiter() ResourceLimiter {
-	return Unlimited{}
+func NewResourceLimiter(config *types.Config) ResourceLimiter {
+	if config.RepoSize.Limit <= 0 {
+		return Unlimited{}
+	}
+
+	return RepoSizeLimited{
+		limit:    config.RepoSize.Limit,
+		warnOnly: config.RepoSize.LimitWarnOnly,
+	}
 }
 
 //nolint:revive
 func (Unlimited) RepoCount(ctx context.Context, count int) error {
 	return nil
 }
+
+//nolint:revive
+func (Unlimited) RepoSize(ctx context.Context, repo *types.Repository, pushSize int64) error {
+	return nil
+}
+
+var _ ResourceLimiter = RepoSizeLimited{}
+
+// RepoSizeLimited limits the size of repositories, all other resources are unlimited.
+type RepoSizeLimited struct {
+	Unlimited
+
+	limit    int64
+	warnOnly bool
+}
+
+// RepoSize verifies that the push doesn't make the repository exceed the size limit.
+// The repository size is the one last calculated by the repository size calculator job.
+func (l RepoSizeLimited) RepoSize(_ context.Context, repo *types.Repository, pushSize int64) error {
+	if pushSize <= 0 || repo.Size+pushSize <= l.limit {
+		return nil
+	}
+
+	return &RepoSizeViolation{
+		Limit:       l.limit,
+		CurrentSize: repo.Size,
+		PushSize:    pushSize,
+		WarnOnly:    l.warnOnly,
+	}
+}
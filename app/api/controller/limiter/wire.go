@@ -15,6 +15,8 @@
 package limiter
 
 import (
+	"github.com/harness/gitness/types"
+
 	"github.com/google/wire"
 )
 
@@ -22,6 +24,6 @@ var WireSet = wire.NewSet(
 	ProvideLimiter,
 )
 
-func ProvideLimiter() (ResourceLimiter, error) {
-	return NewResourceLimiter(), nil
+func ProvideLimiter(config *types.Config) (ResourceLimiter, error) {
+	return NewResourceLimiter(config), nil
 }
@@ -20,10 +20,8 @@ import (
 	"encoding/json"
 	"fmt"
 
-	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/livelog"
-	"github.com/harness/gitness/types/enum"
 )
 
 func (c *Controller) Find(
@@ -35,36 +33,17 @@ func (c *Controller) Find(
 	stageNum int,
 	stepNum int,
 ) ([]*livelog.Line, error) {
-	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	step, err := c.findStep(ctx, session, repoRef, pipelineUID, executionNum, stageNum, stepNum)
 	if err != nil {
-		return nil, fmt.Errorf("failed to find repo by ref: %w", err)
-	}
-	err = apiauth.CheckPipeline(ctx, c.authorizer, session, repo.Path, pipelineUID, enum.PermissionPipelineView)
-	if err != nil {
-		return nil, fmt.Errorf("failed to authorize pipeline: %w", err)
-	}
-
-	pipeline, err := c.pipelineStore.FindByUID(ctx, repo.ID, pipelineUID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to find pipeline: %w", err)
-	}
-
-	execution, err := c.executionStore.FindByNumber(ctx, pipeline.ID, executionNum)
-	if err != nil {
-		return nil, fmt.Errorf("failed to find execution: %w", err)
-	}
-
-	stage, err := c.stageStore.FindByNumber(ctx, execution.ID, stageNum)
-	if err != nil {
-		return nil, fmt.Errorf("failed to find stage: %w", err)
+		return nil, err
 	}
 
-	step, err := c.stepStore.FindByNumber(ctx, stage.ID, stepNum)
-	if err != nil {
-		return nil, fmt.Errorf("failed to find step: %w", err)
-	}
+	return c.readLogs(ctx, step.ID)
+}
 
-	rc, err := c.logStore.Find(ctx, step.ID)
+// readLogs reads the stored logs of a completed step.
+func (c *Controller) readLogs(ctx context.Context, stepID int64) ([]*livelog.Line, error) {
+	rc, err := c.logStore.Find(ctx, stepID)
 	if err != nil {
 		return nil, fmt.Errorf("could not find logs: %w", err)
 	}
@@ -16,12 +16,9 @@ package logs
 
 import (
 	"context"
-	"fmt"
 
-	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/livelog"
-	"github.com/harness/gitness/types/enum"
 )
 
 func (c *Controller) Tail(
@@ -33,34 +30,70 @@ func (c *Controller) Tail(
 	stageNum int,
 	stepNum int,
 ) (<-chan *livelog.Line, <-chan error, error) {
-	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	step, err := c.findStep(ctx, session, repoRef, pipelineUID, executionNum, stageNum, stepNum)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to find repo by ref: %w", err)
-	}
-	err = apiauth.CheckPipeline(ctx, c.authorizer, session, repo.Path, pipelineUID, enum.PermissionPipelineView)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to authorize pipeline: %w", err)
-	}
-	pipeline, err := c.pipelineStore.FindByUID(ctx, repo.ID, pipelineUID)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to find pipeline: %w", err)
+		return nil, nil, err
 	}
 
-	execution, err := c.executionStore.FindByNumber(ctx, pipeline.ID, executionNum)
+	linec, errc := c.logStream.Tail(ctx, step.ID)
+	return linec, errc, nil
+}
+
+// TailFrom tails the logs of a step, starting with the line at the offset position.
+// This allows clients to resume tailing after a reconnect without receiving the complete logs again.
+// If the step is already completed, the remaining lines are read from the stored logs.
+func (c *Controller) TailFrom(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineUID string,
+	executionNum int64,
+	stageNum int,
+	stepNum int,
+	offset int,
+) (<-chan *livelog.Line, <-chan error, error) {
+	step, err := c.findStep(ctx, session, repoRef, pipelineUID, executionNum, stageNum, stepNum)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to find execution: %w", err)
+		return nil, nil, err
 	}
 
-	stage, err := c.stageStore.FindByNumber(ctx, execution.ID, stageNum)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to find stage: %w", err)
+	linec, errc := c.logStream.TailFrom(ctx, step.ID, offset)
+	if errc != nil || !step.Status.IsDone() {
+		return linec, errc, nil
 	}
 
-	step, err := c.stepStore.FindByNumber(ctx, stage.ID, stepNum)
+	lines, err := c.readLogs(ctx, step.ID)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to find step: %w", err)
+		return nil, nil, err
 	}
 
-	linec, errc := c.logStream.Tail(ctx, step.ID)
+	linec, errc = replayLines(ctx, lines, offset)
 	return linec, errc, nil
 }
+
+// replayLines sends the lines positioned at or after the offset. The error channel gets closed
+// once all lines are sent, the same way it gets closed when a live log stream ends.
+func replayLines(
+	ctx context.Context,
+	lines []*livelog.Line,
+	offset int,
+) (<-chan *livelog.Line, <-chan error) {
+	linec := make(chan *livelog.Line)
+	errc := make(chan error)
+
+	go func() {
+		defer close(errc)
+		for _, line := range lines {
+			if line.Number < offset {
+				continue
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case linec <- line:
+			}
+		}
+	}()
+
+	return linec, errc
+}
@@ -0,0 +1,82 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/pipeline/badge"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types/enum"
+)
+
+const branchRefPrefix = "refs/heads/"
+
+// Badge renders the SVG status badge of the latest execution of a pipeline on a branch.
+// If no branch is provided, the default branch of the pipeline is used.
+func (c *Controller) Badge(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	uid string,
+	branch string,
+) ([]byte, error) {
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo by ref: %w", err)
+	}
+
+	if !c.badgesUnauthenticated && !repo.IsPublic {
+		err = apiauth.CheckPipeline(ctx, c.authorizer, session, repo.Path, uid, enum.PermissionPipelineView)
+		if err != nil {
+			return nil, fmt.Errorf("failed to authorize pipeline: %w", err)
+		}
+	}
+
+	pipeline, err := c.pipelineStore.FindByUID(ctx, repo.ID, uid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	if branch == "" {
+		branch = pipeline.DefaultBranch
+	}
+	if branch == "" {
+		branch = repo.DefaultBranch
+	}
+
+	ref := branchRefPrefix + strings.TrimPrefix(branch, branchRefPrefix)
+
+	var status enum.CIStatus
+	execution, err := c.executionStore.FindLatestByRef(ctx, pipeline.ID, ref)
+	if err != nil && !errors.Is(err, store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find latest execution: %w", err)
+	}
+	if execution != nil {
+		status = execution.Status
+	}
+
+	svg, err := badge.Render(pipeline.UID, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render badge: %w", err)
+	}
+
+	return svg, nil
+}
@@ -16,31 +16,49 @@ package pipeline
 
 import (
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/pipeline/file"
+	"github.com/harness/gitness/app/pipeline/triggerer"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 )
 
 type Controller struct {
-	defaultBranch string
-	uidCheck      check.PathUID
-	repoStore     store.RepoStore
-	triggerStore  store.TriggerStore
-	authorizer    authz.Authorizer
-	pipelineStore store.PipelineStore
+	defaultBranch         string
+	badgesUnauthenticated bool
+	uidCheck              check.PathUID
+	repoStore             store.RepoStore
+	triggerStore          store.TriggerStore
+	authorizer            authz.Authorizer
+	pipelineStore         store.PipelineStore
+	executionStore        store.ExecutionStore
+	stageStore            store.StageStore
+	fileService           file.Service
+	triggerer             triggerer.Triggerer
 }
 
 func NewController(
+	config *types.Config,
 	uidCheck check.PathUID,
 	authorizer authz.Authorizer,
 	repoStore store.RepoStore,
 	triggerStore store.TriggerStore,
 	pipelineStore store.PipelineStore,
+	executionStore store.ExecutionStore,
+	stageStore store.StageStore,
+	fileService file.Service,
+	triggerer triggerer.Triggerer,
 ) *Controller {
 	return &Controller{
-		uidCheck:      uidCheck,
-		repoStore:     repoStore,
-		triggerStore:  triggerStore,
-		authorizer:    authorizer,
-		pipelineStore: pipelineStore,
+		badgesUnauthenticated: config.CI.Badges.Unauthenticated,
+		uidCheck:              uidCheck,
+		repoStore:             repoStore,
+		triggerStore:          triggerStore,
+		authorizer:            authorizer,
+		pipelineStore:         pipelineStore,
+		executionStore:        executionStore,
+		stageStore:            stageStore,
+		fileService:           fileService,
+		triggerer:             triggerer,
 	}
 }
@@ -0,0 +1,84 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/pipeline/stats"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	statsDefaultRange = 30 * 24 * time.Hour
+	statsMaxRange     = 90 * 24 * time.Hour
+)
+
+// Stats returns the duration and reliability statistics of the executions of a pipeline.
+// Without a time range, the statistics of the executions of the last 30 days are returned.
+func (c *Controller) Stats(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	uid string,
+	filter types.PipelineStatsFilter,
+) (*types.PipelineStats, error) {
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo by ref: %w", err)
+	}
+
+	err = apiauth.CheckPipeline(ctx, c.authorizer, session, repo.Path, uid, enum.PermissionPipelineView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authorize pipeline: %w", err)
+	}
+
+	if filter.Before == 0 {
+		filter.Before = time.Now().UnixMilli()
+	}
+	if filter.After == 0 {
+		filter.After = filter.Before - statsDefaultRange.Milliseconds()
+	}
+	if filter.After >= filter.Before {
+		return nil, usererror.BadRequest("The start of the time range must be before its end.")
+	}
+	if filter.Before-filter.After > statsMaxRange.Milliseconds() {
+		return nil, usererror.BadRequestf("The time range can't be longer than %d days.",
+			int(statsMaxRange.Hours()/24))
+	}
+
+	pipeline, err := c.pipelineStore.FindByUID(ctx, repo.ID, uid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	executions, err := c.executionStore.ListInRange(ctx, pipeline.ID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list executions: %w", err)
+	}
+
+	stages, err := c.stageStore.ListInRange(ctx, pipeline.ID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stages: %w", err)
+	}
+
+	return stats.Compute(filter, executions, stages), nil
+}
@@ -0,0 +1,78 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ValidateInput is used to validate a pipeline definition.
+type ValidateInput struct {
+	// Data is the pipeline definition. If empty, the config file of the pipeline is validated.
+	Data string `json:"data"`
+	// GitRef is the git reference the config file is read from if no data is provided.
+	// If empty, the default branch of the pipeline is used.
+	GitRef string `json:"git_ref"`
+}
+
+// Validate parses and lints a pipeline definition and returns the problems found.
+// Problems in the definition aren't returned as an error, but as part of the validation result.
+func (c *Controller) Validate(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	uid string,
+	in *ValidateInput,
+) (*types.PipelineValidation, error) {
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo by ref: %w", err)
+	}
+	err = apiauth.CheckPipeline(ctx, c.authorizer, session, repo.Path, uid, enum.PermissionPipelineView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authorize pipeline: %w", err)
+	}
+
+	pipeline, err := c.pipelineStore.FindByUID(ctx, repo.ID, uid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	data := []byte(in.Data)
+	if len(data) == 0 {
+		ref := in.GitRef
+		if ref == "" {
+			ref = pipeline.DefaultBranch
+		}
+		if ref == "" {
+			ref = repo.DefaultBranch
+		}
+
+		file, err := c.fileService.Get(ctx, repo, pipeline.ConfigPath, ref)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read pipeline config file: %w", err)
+		}
+
+		data = file.Data
+	}
+
+	return c.triggerer.Validate(ctx, repo, data), nil
+}
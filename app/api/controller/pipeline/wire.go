@@ -16,7 +16,10 @@ package pipeline
 
 import (
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/pipeline/file"
+	"github.com/harness/gitness/app/pipeline/triggerer"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 
 	"github.com/google/wire"
@@ -28,12 +31,17 @@ var WireSet = wire.NewSet(
 )
 
 func ProvideController(
+	config *types.Config,
 	uidCheck check.PathUID,
 	repoStore store.RepoStore,
 	triggerStore store.TriggerStore,
 	authorizer authz.Authorizer,
 	pipelineStore store.PipelineStore,
+	executionStore store.ExecutionStore,
+	stageStore store.StageStore,
+	fileService file.Service,
+	triggerer triggerer.Triggerer,
 ) *Controller {
-	return NewController(uidCheck, authorizer,
-		repoStore, triggerStore, pipelineStore)
+	return NewController(config, uidCheck, authorizer,
+		repoStore, triggerStore, pipelineStore, executionStore, stageStore, fileService, triggerer)
 }
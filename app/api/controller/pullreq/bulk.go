@@ -0,0 +1,289 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	// BulkJobType is the type of the background job that executes bulk pull request operations.
+	BulkJobType = "pullreq_bulk"
+
+	bulkJobUIDPrefix   = "pullreq_bulk_%d_"
+	bulkJobMaxDuration = 30 * time.Minute
+	bulkMaxPullReqs    = 500
+)
+
+// BulkJobInput is the data of a bulk operation job.
+type BulkJobInput struct {
+	RepoID            int64                     `json:"repo_id"`
+	PrincipalID       int64                     `json:"principal_id"`
+	Operation         enum.PullReqBulkOperation `json:"operation"`
+	Numbers           []int64                   `json:"numbers"`
+	NewTargetBranch   string                    `json:"new_target_branch,omitempty"`
+	AddReviewerIDs    []int64                   `json:"add_reviewer_ids,omitempty"`
+	RemoveReviewerIDs []int64                   `json:"remove_reviewer_ids,omitempty"`
+}
+
+type BulkInput struct {
+	Operation enum.PullReqBulkOperation `json:"operation"`
+
+	// Numbers explicitly lists the pull requests the operation is applied to.
+	Numbers []int64 `json:"numbers"`
+	// TargetBranch selects all open pull requests targeting the branch.
+	TargetBranch string `json:"target_branch"`
+
+	// NewTargetBranch is the branch the pull requests are retargeted to.
+	NewTargetBranch string `json:"new_target_branch"`
+
+	// AddReviewerIDs and RemoveReviewerIDs are the reviewer changes applied to the pull requests.
+	AddReviewerIDs    []int64 `json:"add_reviewer_ids"`
+	RemoveReviewerIDs []int64 `json:"remove_reviewer_ids"`
+}
+
+func (in *BulkInput) Check() error {
+	operation, ok := in.Operation.Sanitize()
+	if !ok {
+		return usererror.BadRequestf("Allowed operations are: %s, %s and %s",
+			enum.PullReqBulkOperationClose, enum.PullReqBulkOperationRetarget, enum.PullReqBulkOperationReviewers)
+	}
+
+	in.Operation = operation
+	in.TargetBranch = strings.TrimSpace(in.TargetBranch)
+	in.NewTargetBranch = strings.TrimSpace(in.NewTargetBranch)
+
+	if (len(in.Numbers) == 0) == (in.TargetBranch == "") {
+		return usererror.BadRequest("Either pull request numbers or a target branch must be provided.")
+	}
+
+	if len(in.Numbers) > bulkMaxPullReqs {
+		return usererror.BadRequestf("At most %d pull requests can be updated at once.", bulkMaxPullReqs)
+	}
+
+	for _, number := range in.Numbers {
+		if number <= 0 {
+			return usererror.BadRequest("Pull request numbers must be positive.")
+		}
+	}
+
+	in.Numbers = deduplicateNumbers(in.Numbers)
+
+	switch in.Operation {
+	case enum.PullReqBulkOperationClose:
+	case enum.PullReqBulkOperationRetarget:
+		if in.NewTargetBranch == "" {
+			return usererror.BadRequest("The new target branch must be provided.")
+		}
+		if in.NewTargetBranch == in.TargetBranch {
+			return usererror.BadRequest("The new target branch must differ from the current one.")
+		}
+	case enum.PullReqBulkOperationReviewers:
+		if len(in.AddReviewerIDs) == 0 && len(in.RemoveReviewerIDs) == 0 {
+			return usererror.BadRequest("At least one reviewer must be added or removed.")
+		}
+	}
+
+	in.AddReviewerIDs = deduplicateNumbers(in.AddReviewerIDs)
+	in.RemoveReviewerIDs = deduplicateNumbers(in.RemoveReviewerIDs)
+
+	return nil
+}
+
+type BulkOutput struct {
+	JobUID  string  `json:"job_uid"`
+	Numbers []int64 `json:"numbers"`
+}
+
+// Bulk schedules a background job that applies the operation to many pull requests of a repository.
+// The pull requests are processed one by one and the outcome for each of them is available with BulkProgress.
+func (c *Controller) Bulk(ctx context.Context,
+	session *auth.Session, repoRef string, in *BulkInput,
+) (*BulkOutput, error) {
+	if err := in.Check(); err != nil {
+		return nil, err
+	}
+
+	reqPermission := enum.PermissionRepoPush
+	if in.Operation == enum.PullReqBulkOperationReviewers {
+		reqPermission = enum.PermissionRepoEdit
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, reqPermission)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	if in.Operation == enum.PullReqBulkOperationRetarget {
+		if _, err = c.verifyBranchExistence(ctx, repo, in.NewTargetBranch); err != nil {
+			return nil, err
+		}
+	}
+
+	numbers := in.Numbers
+	if in.TargetBranch != "" {
+		numbers, err = c.listOpenNumbersForTargetBranch(ctx, repo.ID, in.TargetBranch)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(numbers) == 0 {
+		return nil, usererror.BadRequest("No pull requests match the provided criteria.")
+	}
+
+	data, err := json.Marshal(BulkJobInput{
+		RepoID:            repo.ID,
+		PrincipalID:       session.Principal.ID,
+		Operation:         in.Operation,
+		Numbers:           numbers,
+		NewTargetBranch:   in.NewTargetBranch,
+		AddReviewerIDs:    in.AddReviewerIDs,
+		RemoveReviewerIDs: in.RemoveReviewerIDs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal bulk job input: %w", err)
+	}
+
+	uid, err := job.UID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate bulk job uid: %w", err)
+	}
+
+	jobUID := fmt.Sprintf(bulkJobUIDPrefix, repo.ID) + uid
+
+	err = c.scheduler.RunJob(ctx, job.Definition{
+		UID:        jobUID,
+		Type:       BulkJobType,
+		MaxRetries: 0, // a partially applied operation must not be repeated
+		Timeout:    bulkJobMaxDuration,
+		Data:       string(data),
+		SpaceID:    repo.ParentID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to schedule bulk job: %w", err)
+	}
+
+	return &BulkOutput{
+		JobUID:  jobUID,
+		Numbers: numbers,
+	}, nil
+}
+
+type BulkResult struct {
+	Number  int64  `json:"number"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+type BulkProgressOutput struct {
+	State    job.State    `json:"state"`
+	Progress int          `json:"progress"`
+	Failure  string       `json:"failure,omitempty"`
+	Results  []BulkResult `json:"results"`
+}
+
+// BulkProgress returns the progress and the per pull request results of a bulk operation job.
+func (c *Controller) BulkProgress(ctx context.Context,
+	session *auth.Session, repoRef string, jobUID string,
+) (*BulkProgressOutput, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	// the job UID embeds the repository ID, which prevents reading jobs of other repositories.
+	if !strings.HasPrefix(jobUID, fmt.Sprintf(bulkJobUIDPrefix, repo.ID)) {
+		return nil, usererror.ErrNotFound
+	}
+
+	progress, err := c.scheduler.GetJobProgress(ctx, jobUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bulk job progress: %w", err)
+	}
+
+	results := []BulkResult{}
+	if progress.Result != "" {
+		if err = json.Unmarshal([]byte(progress.Result), &results); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal bulk job results: %w", err)
+		}
+	}
+
+	return &BulkProgressOutput{
+		State:    progress.State,
+		Progress: progress.Progress,
+		Failure:  progress.Failure,
+		Results:  results,
+	}, nil
+}
+
+func (c *Controller) listOpenNumbersForTargetBranch(ctx context.Context,
+	repoID int64, targetBranch string,
+) ([]int64, error) {
+	prs, err := c.pullreqStore.List(ctx, &types.PullReqFilter{
+		TargetRepoID: repoID,
+		TargetBranch: targetBranch,
+		States:       []enum.PullReqState{enum.PullReqStateOpen},
+		Page:         1,
+		Size:         bulkMaxPullReqs + 1,
+		Sort:         enum.PullReqSortNumber,
+		Order:        enum.OrderAsc,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull requests for target branch: %w", err)
+	}
+
+	if len(prs) > bulkMaxPullReqs {
+		return nil, usererror.BadRequestf(
+			"More than %d open pull requests target the branch, list the pull requests explicitly.",
+			bulkMaxPullReqs)
+	}
+
+	numbers := make([]int64, len(prs))
+	for i, pr := range prs {
+		numbers[i] = pr.Number
+	}
+
+	return numbers, nil
+}
+
+// deduplicateNumbers de-duplicates the IDs or numbers provided by the user, preserving their order.
+func deduplicateNumbers(in []int64) []int64 {
+	if len(in) == 0 {
+		return []int64{}
+	}
+
+	numberSet := make(map[int64]bool, len(in))
+	out := make([]int64, 0, len(in))
+	for _, number := range in {
+		if numberSet[number] {
+			continue
+		}
+		numberSet[number] = true
+		out = append(out, number)
+	}
+
+	return out
+}
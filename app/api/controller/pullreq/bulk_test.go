@@ -0,0 +1,107 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
+)
+
+func TestBulkInputCheck(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       BulkInput
+		wantErr     bool
+		wantNumbers []int64
+	}{
+		{
+			name:    "unknown-operation",
+			input:   BulkInput{Operation: "label", Numbers: []int64{1}},
+			wantErr: true,
+		},
+		{
+			name:    "no-selection",
+			input:   BulkInput{Operation: enum.PullReqBulkOperationClose},
+			wantErr: true,
+		},
+		{
+			name:    "numbers-and-branch",
+			input:   BulkInput{Operation: enum.PullReqBulkOperationClose, Numbers: []int64{1}, TargetBranch: "dev"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid-number",
+			input:   BulkInput{Operation: enum.PullReqBulkOperationClose, Numbers: []int64{1, 0}},
+			wantErr: true,
+		},
+		{
+			name:        "close-dedup",
+			input:       BulkInput{Operation: enum.PullReqBulkOperationClose, Numbers: []int64{3, 1, 3, 2, 1}},
+			wantNumbers: []int64{3, 1, 2},
+		},
+		{
+			name:    "retarget-without-branch",
+			input:   BulkInput{Operation: enum.PullReqBulkOperationRetarget, TargetBranch: "dev"},
+			wantErr: true,
+		},
+		{
+			name: "retarget-same-branch",
+			input: BulkInput{Operation: enum.PullReqBulkOperationRetarget,
+				TargetBranch: "dev", NewTargetBranch: " dev "},
+			wantErr: true,
+		},
+		{
+			name: "retarget",
+			input: BulkInput{Operation: enum.PullReqBulkOperationRetarget,
+				TargetBranch: "dev", NewTargetBranch: "main"},
+			wantNumbers: []int64{},
+		},
+		{
+			name:    "reviewers-without-changes",
+			input:   BulkInput{Operation: enum.PullReqBulkOperationReviewers, Numbers: []int64{1}},
+			wantErr: true,
+		},
+		{
+			name: "reviewers",
+			input: BulkInput{Operation: enum.PullReqBulkOperationReviewers,
+				Numbers: []int64{1}, RemoveReviewerIDs: []int64{7}},
+			wantNumbers: []int64{1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.input.Check()
+			if test.wantErr {
+				if err == nil {
+					t.Error("expected an error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+				return
+			}
+
+			if !slices.Equal(test.input.Numbers, test.wantNumbers) {
+				t.Errorf("want numbers=%v, got=%v", test.wantNumbers, test.input.Numbers)
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type CherryPickInput struct {
+	TargetBranch     string `json:"target_branch"`
+	Title            string `json:"title"`
+	Message          string `json:"message"`
+	CherryPickBranch string `json:"cherry_pick_branch"`
+	IsDraft          bool   `json:"is_draft"`
+	BypassRules      bool   `json:"bypass_rules"`
+}
+
+func (in *CherryPickInput) sanitize(pr *types.PullReq) error {
+	in.TargetBranch = strings.TrimSpace(in.TargetBranch)
+	if in.TargetBranch == "" {
+		return usererror.BadRequest("Target branch must be provided")
+	}
+
+	if in.TargetBranch == pr.TargetBranch {
+		return usererror.BadRequest("The pull request is already merged into the target branch")
+	}
+
+	in.Title = strings.TrimSpace(in.Title)
+	if in.Title == "" {
+		in.Title = fmt.Sprintf("[%s] %s", in.TargetBranch, pr.Title)
+	}
+
+	in.Message = strings.TrimSpace(in.Message)
+	if in.Message == "" {
+		in.Message = fmt.Sprintf("This cherry-picks pull request #%d.", pr.Number)
+	}
+
+	in.CherryPickBranch = strings.TrimSpace(in.CherryPickBranch)
+	if in.CherryPickBranch == "" {
+		in.CherryPickBranch = fmt.Sprintf("cherry-pick-pullreq-%d-%s", pr.Number, in.TargetBranch)
+	}
+
+	return nil
+}
+
+// CherryPick creates a new branch that applies the changes of a merged pull request on top of
+// the provided target branch and opens a new pull request from it against the target branch.
+// The creation of the new branch is subject to the branch protection rules of the repository.
+func (c *Controller) CherryPick(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *CherryPickInput,
+) (*types.PullReq, []types.RuleViolations, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateMerged || pr.MergeSHA == nil || pr.MergeMethod == nil {
+		return nil, nil, usererror.BadRequest("Only merged pull requests can be cherry-picked")
+	}
+
+	var mergeTargetSHA string
+	if pr.MergeTargetSHA != nil {
+		mergeTargetSHA = *pr.MergeTargetSHA
+	}
+
+	if err = in.sanitize(pr); err != nil {
+		return nil, nil, err
+	}
+
+	_, err = c.git.GetRef(ctx, git.GetRefParams{
+		ReadParams: git.ReadParams{RepoUID: repo.GitUID},
+		Name:       in.TargetBranch,
+		Type:       gitenum.RefTypeBranch,
+	})
+	if errors.AsStatus(err) == errors.StatusNotFound {
+		return nil, nil, usererror.NotFound(fmt.Sprintf("Branch %s not found", in.TargetBranch))
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get the target branch: %w", err)
+	}
+
+	if err = c.verifyBranchNotExists(ctx, repo, in.CherryPickBranch); err != nil {
+		return nil, nil, err
+	}
+
+	violations, err := c.verifyBranchCreation(ctx, session, repo, in.CherryPickBranch, in.BypassRules)
+	if err != nil {
+		return nil, nil, err
+	}
+	if protection.IsCritical(violations) {
+		return nil, violations, nil
+	}
+
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, repo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	signingKey, err := c.repoSigningKey(ctx, repo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	_, err = c.git.CherryPick(ctx, &git.CherryPickParams{
+		WriteParams:      writeParams,
+		TargetBranch:     in.TargetBranch,
+		CherryPickBranch: in.CherryPickBranch,
+		Method:           gitenum.MergeMethod(*pr.MergeMethod),
+		MergeSHA:         *pr.MergeSHA,
+		MergeTargetSHA:   mergeTargetSHA,
+		Title:            in.Title,
+		Message:          in.Message,
+		Committer:        identityFromPrincipalInfo(*bootstrap.NewSystemServiceSession().Principal.ToPrincipalInfo()),
+		CommitterDate:    &now,
+		Author:           identityFromPrincipalInfo(*session.Principal.ToPrincipalInfo()),
+		AuthorDate:       &now,
+		SigningKey:       signingKey,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cherryPickPR, err := c.Create(ctx, session, repoRef, &CreateInput{
+		IsDraft:      in.IsDraft,
+		Title:        in.Title,
+		Description:  in.Message,
+		SourceBranch: in.CherryPickBranch,
+		TargetBranch: in.TargetBranch,
+	})
+	if err != nil {
+		errDelete := c.git.DeleteBranch(ctx, &git.DeleteBranchParams{
+			WriteParams: writeParams,
+			BranchName:  in.CherryPickBranch,
+		})
+		if errDelete != nil {
+			// non-critical error
+			log.Ctx(ctx).Warn().Err(errDelete).Msg("failed to delete cherry-pick branch")
+		}
+
+		return nil, nil, fmt.Errorf("failed to create cherry-pick pull request: %w", err)
+	}
+
+	return cherryPickPR, nil, nil
+}
+
+// verifyBranchCreation verifies the creation of the branch against the protection rules of the repository.
+func (c *Controller) verifyBranchCreation(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	branchName string,
+	bypassRules bool,
+) ([]types.RuleViolations, error) {
+	isRepoOwner, err := apiauth.IsRepoOwner(ctx, c.authorizer, session, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine if user is repo owner: %w", err)
+	}
+
+	protectionRules, err := c.protectionManager.ForRepository(ctx, repo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch protection rules for the repository: %w", err)
+	}
+
+	violations, err := protectionRules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
+		Actor:       &session.Principal,
+		AllowBypass: bypassRules,
+		IsRepoOwner: isRepoOwner,
+		Repo:        repo,
+		RefAction:   protection.RefActionCreate,
+		RefType:     protection.RefTypeBranch,
+		RefNames:    []string{branchName},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+
+	return violations, nil
+}
@@ -92,7 +92,7 @@ func (c *Controller) CommentCreate(
 	prNum int64,
 	in *CommentCreateInput,
 ) (*types.PullReqActivity, error) {
-	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoReview)
 	if err != nil {
 		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
 	}
@@ -110,12 +110,21 @@ func (c *Controller) CommentCreate(
 	}
 
 	var cut git.DiffCutOutput
+	var anchor *types.CodeCommentAnchor
 	if in.IsCodeComment() {
 		// fetch code snippet from git for code comments
 		cut, err = c.fetchDiffCut(ctx, repo, pr, in)
 		if err != nil {
 			return nil, err
 		}
+
+		// the anchor is used to re-anchor the code comment if the source branch history gets rewritten
+		anchor, err = c.codeCommentMigrator.Anchor(ctx, repo.GitUID, in.SourceCommitSHA, in.Path,
+			cut.Header.NewLine, cut.Header.NewSpan)
+		if err != nil {
+			// non-critical error, the code comment will be marked as outdated instead of re-anchored
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to create code comment anchor")
+		}
 	}
 
 	err = controller.TxOptLock(ctx, c.tx, func(ctx context.Context) error {
@@ -135,7 +144,7 @@ func (c *Controller) CommentCreate(
 		// is written to the DB (as code comment, a reply, or ordinary comment).
 		switch {
 		case in.IsCodeComment():
-			setAsCodeComment(act, cut, in.Path, in.SourceCommitSHA)
+			setAsCodeComment(act, cut, in.Path, in.SourceCommitSHA, anchor)
 			_ = act.SetPayload(&types.PullRequestActivityPayloadCodeComment{
 				Title:        cut.LinesHeader,
 				Lines:        cut.Lines,
@@ -184,6 +193,8 @@ func (c *Controller) CommentCreate(
 		return nil, err
 	}
 
+	c.recordMentions(ctx, pr, act.Text)
+
 	if in.IsCodeComment() {
 		// Migrate the comment if necessary... Note: we still need to return the code comment as is.
 		c.migrateCodeComment(ctx, repo, pr, in, act.AsCodeComment(), cut)
@@ -310,7 +321,12 @@ func getCommentActivity(session *auth.Session, pr *types.PullReq, in *CommentCre
 	return act
 }
 
-func setAsCodeComment(a *types.PullReqActivity, cut git.DiffCutOutput, path, sourceCommitSHA string) {
+func setAsCodeComment(
+	a *types.PullReqActivity,
+	cut git.DiffCutOutput,
+	path, sourceCommitSHA string,
+	anchor *types.CodeCommentAnchor,
+) {
 	var falseBool bool
 	a.Type = enum.PullReqActivityTypeCodeComment
 	a.Kind = enum.PullReqActivityKindChangeComment
@@ -323,6 +339,8 @@ func setAsCodeComment(a *types.PullReqActivity, cut git.DiffCutOutput, path, sou
 		SpanNew:      cut.Header.NewSpan,
 		LineOld:      cut.Header.OldLine,
 		SpanOld:      cut.Header.OldSpan,
+		AnchorStatus: enum.CodeCommentAnchorStatusTracked,
+		Anchor:       anchor,
 	}
 }
 
@@ -23,16 +23,20 @@ import (
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/autolink"
 	"github.com/harness/gitness/app/services/codecomments"
 	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git"
 	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/job"
 	"github.com/harness/gitness/lock"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
@@ -40,30 +44,44 @@ import (
 )
 
 type Controller struct {
-	tx                  dbtx.Transactor
-	urlProvider         url.Provider
-	authorizer          authz.Authorizer
-	pullreqStore        store.PullReqStore
-	activityStore       store.PullReqActivityStore
-	codeCommentView     store.CodeCommentView
-	reviewStore         store.PullReqReviewStore
-	reviewerStore       store.PullReqReviewerStore
-	repoStore           store.RepoStore
-	principalStore      store.PrincipalStore
-	fileViewStore       store.PullReqFileViewStore
-	membershipStore     store.MembershipStore
-	checkStore          store.CheckStore
-	git                 git.Interface
-	eventReporter       *pullreqevents.Reporter
-	mtxManager          lock.MutexManager
-	codeCommentMigrator *codecomments.Migrator
-	pullreqService      *pullreq.Service
-	protectionManager   *protection.Manager
-	sseStreamer         sse.Streamer
-	codeOwners          *codeowners.Service
+	diffLimits git.DiffLimits
+
+	tx                   dbtx.Transactor
+	urlProvider          url.Provider
+	authorizer           authz.Authorizer
+	pullreqStore         store.PullReqStore
+	activityStore        store.PullReqActivityStore
+	codeCommentView      store.CodeCommentView
+	reviewStore          store.PullReqReviewStore
+	reviewerStore        store.PullReqReviewerStore
+	mentionStore         store.PullReqMentionStore
+	repoStore            store.RepoStore
+	principalStore       store.PrincipalStore
+	fileViewStore        store.PullReqFileViewStore
+	dependencyStore      store.PullReqDependencyStore
+	mergeTemplateStore   store.MergeTemplateStore
+	signingKeyStore      store.SigningKeyStore
+	membershipStore      store.MembershipStore
+	checkStore           store.CheckStore
+	watchStore           store.WatchStore
+	defaultReviewerStore store.DefaultReviewerStore
+	workingHoursStore    store.WorkingHoursStore
+	git                  git.Interface
+	eventReporter        *pullreqevents.Reporter
+	mtxManager           lock.MutexManager
+	codeCommentMigrator  *codecomments.Migrator
+	pullreqService       *pullreq.Service
+	protectionManager    *protection.Manager
+	sseStreamer          sse.Streamer
+	codeOwners           *codeowners.Service
+	encrypter            encrypt.Encrypter
+	scheduler            *job.Scheduler
+	autolinks            *autolink.Service
+	userGroupResolver    usergroup.Resolver
 }
 
 func NewController(
+	config *types.Config,
 	tx dbtx.Transactor,
 	urlProvider url.Provider,
 	authorizer authz.Authorizer,
@@ -72,11 +90,18 @@ func NewController(
 	codeCommentView store.CodeCommentView,
 	pullreqReviewStore store.PullReqReviewStore,
 	pullreqReviewerStore store.PullReqReviewerStore,
+	pullreqMentionStore store.PullReqMentionStore,
 	repoStore store.RepoStore,
 	principalStore store.PrincipalStore,
 	fileViewStore store.PullReqFileViewStore,
+	dependencyStore store.PullReqDependencyStore,
+	mergeTemplateStore store.MergeTemplateStore,
+	signingKeyStore store.SigningKeyStore,
 	membershipStore store.MembershipStore,
 	checkStore store.CheckStore,
+	watchStore store.WatchStore,
+	defaultReviewerStore store.DefaultReviewerStore,
+	workingHoursStore store.WorkingHoursStore,
 	git git.Interface,
 	eventReporter *pullreqevents.Reporter,
 	mtxManager lock.MutexManager,
@@ -85,29 +110,45 @@ func NewController(
 	protectionManager *protection.Manager,
 	sseStreamer sse.Streamer,
 	codeowners *codeowners.Service,
+	encrypter encrypt.Encrypter,
+	scheduler *job.Scheduler,
+	autolinks *autolink.Service,
+	userGroupResolver usergroup.Resolver,
 ) *Controller {
 	return &Controller{
-		tx:                  tx,
-		urlProvider:         urlProvider,
-		authorizer:          authorizer,
-		pullreqStore:        pullreqStore,
-		activityStore:       pullreqActivityStore,
-		codeCommentView:     codeCommentView,
-		reviewStore:         pullreqReviewStore,
-		reviewerStore:       pullreqReviewerStore,
-		repoStore:           repoStore,
-		principalStore:      principalStore,
-		fileViewStore:       fileViewStore,
-		membershipStore:     membershipStore,
-		checkStore:          checkStore,
-		git:                 git,
-		codeCommentMigrator: codeCommentMigrator,
-		eventReporter:       eventReporter,
-		mtxManager:          mtxManager,
-		pullreqService:      pullreqService,
-		protectionManager:   protectionManager,
-		sseStreamer:         sseStreamer,
-		codeOwners:          codeowners,
+		diffLimits:           newDiffLimits(config),
+		tx:                   tx,
+		urlProvider:          urlProvider,
+		authorizer:           authorizer,
+		pullreqStore:         pullreqStore,
+		activityStore:        pullreqActivityStore,
+		codeCommentView:      codeCommentView,
+		reviewStore:          pullreqReviewStore,
+		reviewerStore:        pullreqReviewerStore,
+		mentionStore:         pullreqMentionStore,
+		repoStore:            repoStore,
+		principalStore:       principalStore,
+		fileViewStore:        fileViewStore,
+		dependencyStore:      dependencyStore,
+		mergeTemplateStore:   mergeTemplateStore,
+		signingKeyStore:      signingKeyStore,
+		membershipStore:      membershipStore,
+		checkStore:           checkStore,
+		watchStore:           watchStore,
+		defaultReviewerStore: defaultReviewerStore,
+		workingHoursStore:    workingHoursStore,
+		git:                  git,
+		codeCommentMigrator:  codeCommentMigrator,
+		eventReporter:        eventReporter,
+		mtxManager:           mtxManager,
+		pullreqService:       pullreqService,
+		protectionManager:    protectionManager,
+		sseStreamer:          sseStreamer,
+		codeOwners:           codeowners,
+		encrypter:            encrypter,
+		scheduler:            scheduler,
+		autolinks:            autolinks,
+		userGroupResolver:    userGroupResolver,
 	}
 }
 
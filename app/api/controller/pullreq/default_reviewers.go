@@ -0,0 +1,161 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/usergroup"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// addDefaultReviewers adds the default reviewers of the target repository to a new pull request.
+// Failures are only logged, because the pull request is already created at this point.
+func (c *Controller) addDefaultReviewers(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	pr *types.PullReq,
+) {
+	principals, err := c.findDefaultReviewers(ctx, repo, pr)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to find default reviewers")
+		return
+	}
+
+	addedByInfo := session.Principal.ToPrincipalInfo()
+
+	for _, principal := range principals {
+		if principal.ID == pr.CreatedBy {
+			continue
+		}
+
+		if err = apiauth.CheckRepo(ctx, c.authorizer, &auth.Session{
+			Principal: *principal,
+		}, repo, enum.PermissionRepoReview, false); err != nil {
+			log.Ctx(ctx).Info().Msgf("Default reviewer principal: %s access error: %s", principal.UID, err)
+			continue
+		}
+
+		now := time.Now().UnixMilli()
+		reviewer := &types.PullReqReviewer{
+			PullReqID:      pr.ID,
+			PrincipalID:    principal.ID,
+			CreatedBy:      session.Principal.ID,
+			Created:        now,
+			Updated:        now,
+			RepoID:         repo.ID,
+			Type:           enum.PullReqReviewerTypeDefault,
+			ReviewDecision: enum.PullReqReviewDecisionPending,
+			Reviewer:       *principal.ToPrincipalInfo(),
+			AddedBy:        *addedByInfo,
+		}
+
+		if err = c.reviewerStore.Create(ctx, reviewer); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to add default reviewer %s", principal.UID)
+			continue
+		}
+
+		c.reportReviewerAddition(ctx, session, pr, reviewer)
+	}
+}
+
+// findDefaultReviewers returns the principals configured as default reviewers
+// for the target branch of the pull request. User groups are expanded to their users.
+func (c *Controller) findDefaultReviewers(
+	ctx context.Context,
+	repo *types.Repository,
+	pr *types.PullReq,
+) ([]*types.Principal, error) {
+	defaultReviewers, err := c.defaultReviewerStore.List(ctx, repo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list default reviewers: %w", err)
+	}
+
+	var principalIDs []int64
+	var userUIDs []string
+
+	for _, defaultReviewer := range defaultReviewers {
+		if len(defaultReviewer.TargetBranches) > 0 {
+			pattern := protection.Pattern{}
+			if err = json.Unmarshal(defaultReviewer.TargetBranches, &pattern); err != nil {
+				return nil, fmt.Errorf("failed to parse default reviewer target branch pattern: %w", err)
+			}
+
+			if !pattern.Matches(pr.TargetBranch, repo.DefaultBranch) {
+				continue
+			}
+		}
+
+		if defaultReviewer.PrincipalID != nil {
+			principalIDs = append(principalIDs, *defaultReviewer.PrincipalID)
+			continue
+		}
+
+		userGroup, err := c.userGroupResolver.Resolve(ctx, defaultReviewer.UserGroupID)
+		if errors.Is(err, usergroup.ErrNotFound) {
+			log.Ctx(ctx).Warn().Msgf("default reviewer user group %q not found", defaultReviewer.UserGroupID)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve user group %q: %w", defaultReviewer.UserGroupID, err)
+		}
+
+		userUIDs = append(userUIDs, userGroup.Users...)
+	}
+
+	principals := make([]*types.Principal, 0, len(principalIDs)+len(userUIDs))
+	seen := make(map[int64]struct{}, cap(principals))
+
+	add := func(principal *types.Principal) {
+		if _, ok := seen[principal.ID]; ok {
+			return
+		}
+		seen[principal.ID] = struct{}{}
+		principals = append(principals, principal)
+	}
+
+	for _, id := range principalIDs {
+		principal, err := c.principalStore.Find(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find default reviewer principal %d: %w", id, err)
+		}
+
+		add(principal)
+	}
+
+	if len(userUIDs) > 0 {
+		users, err := c.principalStore.FindManyByUID(ctx, userUIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find default reviewer group users: %w", err)
+		}
+
+		for _, user := range users {
+			add(user)
+		}
+	}
+
+	return principals, nil
+}
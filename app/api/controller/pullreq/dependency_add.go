@@ -0,0 +1,157 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type DependencyAddInput struct {
+	// RepoRef is the reference of the repository of the pull request dependency.
+	// If empty, the dependency is assumed to be in the same repository.
+	RepoRef string `json:"repo_ref"`
+	Number  int64  `json:"number"`
+}
+
+func (in *DependencyAddInput) sanitize() error {
+	if in.Number <= 0 {
+		return usererror.BadRequest("A valid pull request number must be provided.")
+	}
+
+	return nil
+}
+
+// DependencyAdd adds a new dependency to the pull request.
+// The pull request can't be merged while any of its dependencies is open.
+func (c *Controller) DependencyAdd(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	prNum int64,
+	in *DependencyAddInput,
+) (*types.PullReqDependencyInfo, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, prNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return nil, usererror.BadRequest("Dependencies can only be added to open pull requests.")
+	}
+
+	depRepo := repo
+	if in.RepoRef != "" {
+		depRepo, err = c.repoStore.FindByRef(ctx, in.RepoRef)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find dependency repository: %w", err)
+		}
+
+		if err = apiauth.CheckRepo(ctx, c.authorizer, session, depRepo, enum.PermissionRepoView, false); err != nil {
+			return nil, fmt.Errorf("access check failed for dependency repository: %w", err)
+		}
+	}
+
+	depPR, err := c.pullreqStore.FindByNumber(ctx, depRepo.ID, in.Number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find dependency pull request: %w", err)
+	}
+
+	if depPR.ID == pr.ID {
+		return nil, usererror.BadRequest("A pull request can't depend on itself.")
+	}
+
+	existing, err := c.dependencyStore.ListDependencies(ctx, pr.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull request dependencies: %w", err)
+	}
+
+	for _, dep := range existing {
+		if dep.DependsOnID == depPR.ID {
+			return nil, usererror.Conflict("The pull request already depends on the provided pull request.")
+		}
+	}
+
+	cycle, err := c.dependsOn(ctx, depPR.ID, pr.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for dependency cycles: %w", err)
+	}
+	if cycle {
+		return nil, usererror.BadRequest("The dependency would create a cycle.")
+	}
+
+	dep := &types.PullReqDependency{
+		PullReqID:   pr.ID,
+		DependsOnID: depPR.ID,
+		CreatedBy:   session.Principal.ID,
+		Created:     time.Now().UnixMilli(),
+	}
+
+	if err = c.dependencyStore.Create(ctx, dep); err != nil {
+		return nil, fmt.Errorf("failed to create pull request dependency: %w", err)
+	}
+
+	info := mapPullReqDependencyInfo(dep, depPR, depRepo)
+
+	return &info, nil
+}
+
+// dependsOn returns true if the pull request with ID prID directly or transitively depends
+// on the pull request with ID targetID.
+func (c *Controller) dependsOn(ctx context.Context, prID, targetID int64) (bool, error) {
+	visited := map[int64]struct{}{prID: {}}
+	queue := []int64{prID}
+
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+
+		deps, err := c.dependencyStore.ListDependencies(ctx, id)
+		if err != nil {
+			return false, err
+		}
+
+		for _, dep := range deps {
+			if dep.DependsOnID == targetID {
+				return true, nil
+			}
+
+			if _, ok := visited[dep.DependsOnID]; ok {
+				continue
+			}
+
+			visited[dep.DependsOnID] = struct{}{}
+			queue = append(queue, dep.DependsOnID)
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types/enum"
+)
+
+// DependencyDelete removes a dependency from the pull request.
+func (c *Controller) DependencyDelete(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	prNum int64,
+	dependencyID int64,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, prNum)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	dep, err := c.dependencyStore.Find(ctx, dependencyID)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request dependency: %w", err)
+	}
+
+	if dep.PullReqID != pr.ID {
+		return usererror.ErrNotFound
+	}
+
+	if err = c.dependencyStore.Delete(ctx, dep.ID); err != nil {
+		return fmt.Errorf("failed to delete pull request dependency: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,169 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// DependencyList returns the dependencies and the dependents of the pull request.
+func (c *Controller) DependencyList(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	prNum int64,
+) (*types.PullReqDependencies, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, prNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	dependencies, err := c.dependencyStore.ListDependencies(ctx, pr.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull request dependencies: %w", err)
+	}
+
+	dependents, err := c.dependencyStore.ListDependents(ctx, pr.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull request dependents: %w", err)
+	}
+
+	repos := map[int64]*dependencyRepo{repo.ID: {repo: repo, accessible: true}}
+
+	out := &types.PullReqDependencies{
+		Dependencies: make([]types.PullReqDependencyInfo, 0, len(dependencies)),
+		Dependents:   make([]types.PullReqDependencyInfo, 0, len(dependents)),
+	}
+
+	for _, dep := range dependencies {
+		info, err := c.dependencyInfo(ctx, session, dep, dep.DependsOnID, repos)
+		if err != nil {
+			return nil, err
+		}
+		out.Dependencies = append(out.Dependencies, info)
+	}
+
+	for _, dep := range dependents {
+		info, err := c.dependencyInfo(ctx, session, dep, dep.PullReqID, repos)
+		if err != nil {
+			return nil, err
+		}
+		out.Dependents = append(out.Dependents, info)
+	}
+
+	return out, nil
+}
+
+// listOpenDependencies returns all pull requests the provided pull request depends on that are still open.
+// The dependencies are returned regardless of the access of the caller, merge violations only report their numbers.
+func (c *Controller) listOpenDependencies(ctx context.Context, pr *types.PullReq) ([]*types.PullReq, error) {
+	dependencies, err := c.dependencyStore.ListDependencies(ctx, pr.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull request dependencies: %w", err)
+	}
+
+	var open []*types.PullReq
+	for _, dep := range dependencies {
+		depPR, err := c.pullreqStore.Find(ctx, dep.DependsOnID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find dependency pull request: %w", err)
+		}
+
+		if depPR.State == enum.PullReqStateOpen {
+			open = append(open, depPR)
+		}
+	}
+
+	return open, nil
+}
+
+// dependencyRepo is a repository of a pull request dependency and whether the caller can view it.
+type dependencyRepo struct {
+	repo       *types.Repository
+	accessible bool
+}
+
+// dependencyInfo returns the info about the pull request of the dependency relationship.
+// Pull requests of repositories the caller can't view are redacted to their IDs.
+func (c *Controller) dependencyInfo(
+	ctx context.Context,
+	session *auth.Session,
+	dep *types.PullReqDependency,
+	prID int64,
+	repos map[int64]*dependencyRepo,
+) (types.PullReqDependencyInfo, error) {
+	pr, err := c.pullreqStore.Find(ctx, prID)
+	if err != nil {
+		return types.PullReqDependencyInfo{}, fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	depRepo, ok := repos[pr.TargetRepoID]
+	if !ok {
+		repo, err := c.repoStore.Find(ctx, pr.TargetRepoID)
+		if err != nil {
+			return types.PullReqDependencyInfo{}, fmt.Errorf("failed to find repository: %w", err)
+		}
+
+		err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoView, false)
+		if err != nil && !errors.Is(err, apiauth.ErrNotAuthorized) && !errors.Is(err, apiauth.ErrNotAuthenticated) {
+			return types.PullReqDependencyInfo{}, fmt.Errorf("failed to check access to repository: %w", err)
+		}
+
+		depRepo = &dependencyRepo{repo: repo, accessible: err == nil}
+		repos[repo.ID] = depRepo
+	}
+
+	if !depRepo.accessible {
+		return types.PullReqDependencyInfo{
+			ID:         dep.ID,
+			RepoID:     depRepo.repo.ID,
+			Number:     pr.Number,
+			Created:    dep.Created,
+			Restricted: true,
+		}, nil
+	}
+
+	return mapPullReqDependencyInfo(dep, pr, depRepo.repo), nil
+}
+
+func mapPullReqDependencyInfo(
+	dep *types.PullReqDependency,
+	pr *types.PullReq,
+	repo *types.Repository,
+) types.PullReqDependencyInfo {
+	return types.PullReqDependencyInfo{
+		ID:       dep.ID,
+		RepoID:   repo.ID,
+		RepoPath: repo.Path,
+		Number:   pr.Number,
+		Title:    pr.Title,
+		State:    pr.State,
+		IsDraft:  pr.IsDraft,
+		Merged:   pr.Merged,
+		Created:  dep.Created,
+	}
+}
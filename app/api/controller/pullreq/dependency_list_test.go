@@ -0,0 +1,101 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type dependencyPullReqStore struct {
+	store.PullReqStore
+	prs map[int64]*types.PullReq
+}
+
+func (s dependencyPullReqStore) Find(_ context.Context, id int64) (*types.PullReq, error) {
+	return s.prs[id], nil
+}
+
+type dependencyRepoStore struct {
+	store.RepoStore
+	repos map[int64]*types.Repository
+}
+
+func (s dependencyRepoStore) Find(_ context.Context, id int64) (*types.Repository, error) {
+	return s.repos[id], nil
+}
+
+// spaceAuthorizer permits access to the resources of a single space.
+type spaceAuthorizer struct {
+	spacePath string
+}
+
+func (a spaceAuthorizer) Check(
+	_ context.Context,
+	_ *auth.Session,
+	scope *types.Scope,
+	_ *types.Resource,
+	_ enum.Permission,
+) (bool, error) {
+	return scope.SpacePath == a.spacePath, nil
+}
+
+func (a spaceAuthorizer) CheckAll(context.Context, *auth.Session, ...types.PermissionCheck) (bool, error) {
+	return false, nil
+}
+
+func TestDependencyInfoRedactsInaccessibleRepos(t *testing.T) {
+	c := &Controller{
+		authorizer: spaceAuthorizer{spacePath: "visible"},
+		pullreqStore: dependencyPullReqStore{prs: map[int64]*types.PullReq{
+			10: {ID: 10, Number: 1, TargetRepoID: 1, Title: "visible pr", State: enum.PullReqStateOpen},
+			20: {ID: 20, Number: 7, TargetRepoID: 2, Title: "secret pr", State: enum.PullReqStateOpen},
+		}},
+		repoStore: dependencyRepoStore{repos: map[int64]*types.Repository{
+			1: {ID: 1, Path: "visible/repo"},
+			2: {ID: 2, Path: "hidden/repo"},
+		}},
+	}
+
+	session := &auth.Session{Principal: types.Principal{ID: 1, Type: enum.PrincipalTypeUser}}
+	repos := map[int64]*dependencyRepo{}
+
+	info, err := c.dependencyInfo(context.Background(), session,
+		&types.PullReqDependency{ID: 100, Created: 5}, 10, repos)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if info.Restricted || info.Title != "visible pr" || info.RepoPath != "visible/repo" {
+		t.Errorf("expected the full info of the accessible pull request, got %+v", info)
+	}
+
+	info, err = c.dependencyInfo(context.Background(), session,
+		&types.PullReqDependency{ID: 200, Created: 6}, 20, repos)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := types.PullReqDependencyInfo{ID: 200, RepoID: 2, Number: 7, Created: 6, Restricted: true}
+	if info.ID != want.ID || info.RepoID != want.RepoID || info.Number != want.Number ||
+		info.Created != want.Created || info.Restricted != want.Restricted ||
+		info.Title != "" || info.RepoPath != "" || info.State != "" {
+		t.Errorf("expected redacted info %+v, got %+v", want, info)
+	}
+}
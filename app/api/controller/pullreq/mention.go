@@ -0,0 +1,67 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// maxMentions limits the number of principals that can be mentioned in a single text.
+const maxMentions = 50
+
+// mentionRegex matches principal mentions in the form of "@[<principal id>]".
+var mentionRegex = regexp.MustCompile(`@\[(\d+)]`)
+
+// parseMentions returns the de-duplicated IDs of the principals mentioned in the texts.
+func parseMentions(texts ...string) []int64 {
+	var ids []int64
+	seen := make(map[int64]bool)
+	for _, text := range texts {
+		for _, match := range mentionRegex.FindAllStringSubmatch(text, -1) {
+			id, err := strconv.ParseInt(match[1], 10, 64)
+			if err != nil || id <= 0 || seen[id] {
+				continue
+			}
+
+			seen[id] = true
+			ids = append(ids, id)
+
+			if len(ids) == maxMentions {
+				return ids
+			}
+		}
+	}
+
+	return ids
+}
+
+// recordMentions stores the principals mentioned in the texts, so that pull requests can be filtered by mentions.
+func (c *Controller) recordMentions(ctx context.Context, pr *types.PullReq, texts ...string) {
+	ids := parseMentions(texts...)
+	if len(ids) == 0 {
+		return
+	}
+
+	if err := c.mentionStore.Add(ctx, pr.ID, ids); err != nil {
+		// non-critical error
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to record pull request mentions")
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"testing"
+
+	"golang.org/x/exp/slices"
+)
+
+func TestParseMentions(t *testing.T) {
+	tests := []struct {
+		name  string
+		texts []string
+		want  []int64
+	}{
+		{
+			name:  "no-mentions",
+			texts: []string{"plain text @user [1]"},
+			want:  nil,
+		},
+		{
+			name:  "single",
+			texts: []string{"cc @[12] please"},
+			want:  []int64{12},
+		},
+		{
+			name:  "multiple-texts-deduplicated",
+			texts: []string{"@[3] and @[1]", "@[1], @[2]@[3]"},
+			want:  []int64{3, 1, 2},
+		},
+		{
+			name:  "invalid-ids",
+			texts: []string{"@[0] @[-1] @[abc] @[99999999999999999999] @[7]"},
+			want:  []int64{7},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := parseMentions(test.texts...)
+			if !slices.Equal(got, test.want) {
+				t.Errorf("want=%v, got=%v", test.want, got)
+			}
+		})
+	}
+}
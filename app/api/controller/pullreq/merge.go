@@ -86,7 +86,7 @@ func (c *Controller) Merge(
 		return nil, nil, err
 	}
 
-	requiredPermission := enum.PermissionRepoPush
+	requiredPermission := enum.PermissionRepoMerge
 	if in.DryRun {
 		requiredPermission = enum.PermissionRepoView
 	}
@@ -181,6 +181,11 @@ func (c *Controller) Merge(
 		return nil, nil, fmt.Errorf("CODEOWNERS evaluation failed: %w", err)
 	}
 
+	openDependencies, err := c.listOpenDependencies(ctx, pr)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	ruleOut, violations, err := protectionRules.MergeVerify(ctx, protection.MergeVerifyInput{
 		Actor:        &session.Principal,
 		AllowBypass:  in.BypassRules,
@@ -192,6 +197,7 @@ func (c *Controller) Merge(
 		Method:       in.Method,
 		CheckResults: checkResults,
 		CodeOwners:   codeOwnerWithApproval,
+		Dependencies: openDependencies,
 	})
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to verify protection rules: %w", err)
@@ -233,7 +239,8 @@ func (c *Controller) Merge(
 					pr.MergeSHA = &mergeOutput.MergeSHA
 					pr.MergeConflicts = nil
 				}
-				pr.Stats.DiffStats = types.NewDiffStats(mergeOutput.CommitCount, mergeOutput.ChangedFileCount)
+				pr.Stats.DiffStats = types.NewDiffStats(mergeOutput.CommitCount, mergeOutput.ChangedFileCount,
+					mergeOutput.Additions, mergeOutput.Deletions)
 				return nil
 			})
 			if err != nil {
@@ -263,14 +270,16 @@ func (c *Controller) Merge(
 	}
 
 	// TODO: for forking merge title might be different?
-	var mergeTitle string
-	author := *session.Principal.ToPrincipalInfo()
-	if in.Method == enum.MergeMethodSquash {
-		// squash commit should show as authored by PR author
-		author = pr.Author
-		mergeTitle = fmt.Sprintf("%s (#%d)", pr.Title, pr.Number)
-	} else {
-		mergeTitle = fmt.Sprintf("Merge branch '%s' of %s (#%d)", pr.SourceBranch, sourceRepo.Path, pr.Number)
+	mergeTitle, mergeMessage, err := c.mergeCommitTitleAndMessage(ctx, targetRepo, sourceRepo, pr, in.Method)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	author := mergeAuthor(session, pr, in.Method)
+
+	signingKey, err := c.repoSigningKey(ctx, targetRepo)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	now := time.Now()
@@ -280,7 +289,7 @@ func (c *Controller) Merge(
 		HeadRepoUID:     sourceRepo.GitUID,
 		HeadBranch:      pr.SourceBranch,
 		Title:           mergeTitle,
-		Message:         "",
+		Message:         mergeMessage,
 		Committer:       identityFromPrincipalInfo(*bootstrap.NewSystemServiceSession().Principal.ToPrincipalInfo()),
 		CommitterDate:   &now,
 		Author:          identityFromPrincipalInfo(author),
@@ -289,6 +298,7 @@ func (c *Controller) Merge(
 		RefName:         pr.TargetBranch,
 		HeadExpectedSHA: in.SourceSHA,
 		Method:          gitenum.MergeMethod(in.Method),
+		SigningKey:      signingKey,
 	})
 	if err != nil {
 		return nil, nil, fmt.Errorf("merge check execution failed: %w", err)
@@ -306,7 +316,8 @@ func (c *Controller) Merge(
 			pr.MergeTargetSHA = &mergeOutput.BaseSHA
 			pr.MergeSHA = nil
 			pr.MergeConflicts = mergeOutput.ConflictFiles
-			pr.Stats.DiffStats = types.NewDiffStats(mergeOutput.CommitCount, mergeOutput.ChangedFileCount)
+			pr.Stats.DiffStats = types.NewDiffStats(mergeOutput.CommitCount, mergeOutput.ChangedFileCount,
+				mergeOutput.Additions, mergeOutput.Deletions)
 			return nil
 		})
 		if err != nil {
@@ -341,7 +352,8 @@ func (c *Controller) Merge(
 		pr.MergeBaseSHA = mergeOutput.MergeBaseSHA
 		pr.MergeSHA = &mergeOutput.MergeSHA
 		pr.MergeConflicts = nil
-		pr.Stats.DiffStats = types.NewDiffStats(mergeOutput.CommitCount, mergeOutput.ChangedFileCount)
+		pr.Stats.DiffStats = types.NewDiffStats(mergeOutput.CommitCount, mergeOutput.ChangedFileCount,
+			mergeOutput.Additions, mergeOutput.Deletions)
 
 		// update sequence for PR activities
 		pr.ActivitySeq++
@@ -365,6 +377,11 @@ func (c *Controller) Merge(
 		TargetSHA:   mergeOutput.BaseSHA,
 		SourceSHA:   mergeOutput.HeadSHA,
 	}
+	for _, v := range violations {
+		if v.Bypassed && len(v.Violations) > 0 {
+			activityPayload.BypassedRules = append(activityPayload.BypassedRules, v.Rule.ID)
+		}
+	}
 	if _, errAct := c.activityStore.CreateWithPayload(ctx, pr, session.Principal.ID, activityPayload); errAct != nil {
 		// non-critical error
 		log.Ctx(ctx).Err(errAct).Msgf("failed to write pull req merge activity")
@@ -412,3 +429,13 @@ func (c *Controller) Merge(
 		RuleViolations: violations,
 	}, nil, nil
 }
+
+// mergeAuthor returns the author of the commit created by merging the pull request with the merge method.
+func mergeAuthor(session *auth.Session, pr *types.PullReq, method enum.MergeMethod) types.PrincipalInfo {
+	if method == enum.MergeMethodSquash {
+		// squash commit should show as authored by PR author
+		return pr.Author
+	}
+
+	return *session.Principal.ToPrincipalInfo()
+}
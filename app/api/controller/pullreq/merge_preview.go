@@ -0,0 +1,182 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
+)
+
+type MergePreviewOutput struct {
+	SourceSHA string         `json:"source_sha"`
+	TargetSHA string         `json:"target_sha"`
+	Methods   []MergePreview `json:"methods"`
+}
+
+// MergePreview is the outcome of merging the pull request with a specific merge method.
+// The SHAs of the previewed commits differ from the ones of the actual merge, because the commit dates differ.
+type MergePreview struct {
+	Method        enum.MergeMethod `json:"method"`
+	MergeBaseSHA  string           `json:"merge_base_sha"`
+	ConflictFiles []string         `json:"conflict_files,omitempty"`
+	Commits       []types.Commit   `json:"commits"`
+	Diff          []git.FileDiff   `json:"diff"`
+}
+
+// MergePreview computes the commits and the changes that merging the pull request would add to the target branch,
+// without performing the merge. If the method isn't provided, all merge methods allowed by the rules are previewed.
+func (c *Controller) MergePreview(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	method enum.MergeMethod,
+) (*MergePreviewOutput, error) {
+	if method != "" {
+		var ok bool
+		method, ok = method.Sanitize()
+		if !ok {
+			return nil, usererror.BadRequestf("Unsupported merge method: %s", method)
+		}
+	}
+
+	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, targetRepo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return nil, usererror.BadRequest("Pull request must be open")
+	}
+
+	sourceRepo := targetRepo
+	if pr.SourceRepoID != pr.TargetRepoID {
+		sourceRepo, err = c.repoStore.Find(ctx, pr.SourceRepoID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get source repository: %w", err)
+		}
+	}
+
+	protectionRules, err := c.protectionManager.ForRepository(ctx, targetRepo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch protection rules for the repository: %w", err)
+	}
+
+	// only the merge methods allowed by the rules are needed, the rest of the input can be left empty.
+	ruleOut, _, err := protectionRules.MergeVerify(ctx, protection.MergeVerifyInput{
+		Actor:      &session.Principal,
+		TargetRepo: targetRepo,
+		SourceRepo: sourceRepo,
+		PullReq:    pr,
+		CodeOwners: &codeowners.Evaluation{},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+
+	methods := ruleOut.AllowedMethods
+	if method != "" {
+		if !slices.Contains(ruleOut.AllowedMethods, method) {
+			return nil, usererror.BadRequestf("The merge method %q is not allowed. Allowed methods are %v.",
+				method, ruleOut.AllowedMethods)
+		}
+		methods = []enum.MergeMethod{method}
+	}
+
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, targetRepo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	out := &MergePreviewOutput{
+		SourceSHA: pr.SourceSHA,
+		Methods:   make([]MergePreview, len(methods)),
+	}
+
+	for i, m := range methods {
+		mergeTitle, mergeMessage, err := c.mergeCommitTitleAndMessage(ctx, targetRepo, sourceRepo, pr, m)
+		if err != nil {
+			return nil, err
+		}
+
+		author := mergeAuthor(session, pr, m)
+
+		now := time.Now()
+		mergeOutput, err := c.git.Merge(ctx, &git.MergeParams{
+			WriteParams:     writeParams,
+			BaseBranch:      pr.TargetBranch,
+			HeadRepoUID:     sourceRepo.GitUID,
+			HeadBranch:      pr.SourceBranch,
+			Title:           mergeTitle,
+			Message:         mergeMessage,
+			Committer:       identityFromPrincipalInfo(*bootstrap.NewSystemServiceSession().Principal.ToPrincipalInfo()),
+			CommitterDate:   &now,
+			Author:          identityFromPrincipalInfo(author),
+			AuthorDate:      &now,
+			RefType:         gitenum.RefTypeUndefined,
+			HeadExpectedSHA: pr.SourceSHA,
+			Method:          gitenum.MergeMethod(m),
+			Preview:         true,
+			PreviewLimits:   c.diffLimits,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to preview %s merge: %w", m, err)
+		}
+
+		commits := make([]types.Commit, len(mergeOutput.PreviewCommits))
+		for j := range mergeOutput.PreviewCommits {
+			commit, err := controller.MapCommit(&mergeOutput.PreviewCommits[j])
+			if err != nil {
+				return nil, fmt.Errorf("failed to map commit: %w", err)
+			}
+			commits[j] = *commit
+		}
+
+		diff := mergeOutput.PreviewDiff
+		if diff == nil {
+			diff = []git.FileDiff{}
+		}
+
+		out.TargetSHA = mergeOutput.BaseSHA
+		out.Methods[i] = MergePreview{
+			Method:        m,
+			MergeBaseSHA:  mergeOutput.MergeBaseSHA,
+			ConflictFiles: mergeOutput.ConflictFiles,
+			Commits:       commits,
+			Diff:          diff,
+		}
+	}
+
+	return out, nil
+}
@@ -0,0 +1,121 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/services/mergetemplate"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// mergeTemplateMaxCommits is the maximum number of pull request commits
+// used for the commit list and the co-author list of a merge template.
+const mergeTemplateMaxCommits = 100
+
+// mergeCommitTitleAndMessage returns the title and the message of the commit created by merging the pull request.
+// If the repository has a merge template for the merge method, the template is used.
+// Otherwise, the default title is returned with an empty message.
+func (c *Controller) mergeCommitTitleAndMessage(
+	ctx context.Context,
+	targetRepo *types.Repository,
+	sourceRepo *types.Repository,
+	pr *types.PullReq,
+	method enum.MergeMethod,
+) (string, string, error) {
+	var title string
+	if method == enum.MergeMethodSquash {
+		title = fmt.Sprintf("%s (#%d)", pr.Title, pr.Number)
+	} else {
+		title = fmt.Sprintf("Merge branch '%s' of %s (#%d)", pr.SourceBranch, sourceRepo.Path, pr.Number)
+	}
+
+	if method != enum.MergeMethodSquash && method != enum.MergeMethodMerge {
+		return title, "", nil
+	}
+
+	tmpl, err := c.mergeTemplateStore.Find(ctx, targetRepo.ID, method)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return title, "", nil
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("failed to find merge template: %w", err)
+	}
+
+	values := mergetemplate.Values{
+		Number:       pr.Number,
+		Title:        pr.Title,
+		Description:  pr.Description,
+		SourceBranch: pr.SourceBranch,
+		TargetBranch: pr.TargetBranch,
+		SourceRepo:   sourceRepo.Path,
+		Author:       mergetemplate.Identity{Name: pr.Author.DisplayName, Email: pr.Author.Email},
+	}
+
+	if mergetemplate.Uses(tmpl.Title+tmpl.Message, mergetemplate.PlaceholderCommits, mergetemplate.PlaceholderCoAuthors) {
+		output, err := c.git.ListCommits(ctx, &git.ListCommitsParams{
+			ReadParams: git.CreateReadParams(targetRepo),
+			GitREF:     pr.SourceSHA,
+			After:      pr.MergeBaseSHA,
+			Limit:      mergeTemplateMaxCommits,
+		})
+		if err != nil {
+			return "", "", fmt.Errorf("failed to list pull request commits: %w", err)
+		}
+
+		values.Commits, values.CoAuthors = mergeTemplateCommits(output.Commits, pr.Author.Email)
+	}
+
+	if tmpl.Title != "" {
+		title = mergetemplate.RenderTitle(tmpl.Title, values)
+	}
+
+	return title, mergetemplate.Render(tmpl.Message, values), nil
+}
+
+// mergeTemplateCommits returns titles of the commits, oldest first,
+// and the list of commit authors other than the pull request author.
+func mergeTemplateCommits(
+	commits []git.Commit,
+	authorEmail string,
+) ([]string, []mergetemplate.Identity) {
+	titles := make([]string, len(commits))
+	coAuthors := make([]mergetemplate.Identity, 0)
+	seen := map[string]struct{}{strings.ToLower(authorEmail): {}}
+
+	for i := range commits {
+		commit := &commits[len(commits)-1-i]
+		titles[i] = commit.Title
+
+		email := strings.ToLower(commit.Author.Identity.Email)
+		if _, ok := seen[email]; ok {
+			continue
+		}
+
+		seen[email] = struct{}{}
+		coAuthors = append(coAuthors, mergetemplate.Identity{
+			Name:  commit.Author.Identity.Name,
+			Email: commit.Author.Identity.Email,
+		})
+	}
+
+	return titles, coAuthors
+}
@@ -57,6 +57,11 @@ func (c *Controller) Commits(
 		return nil, err
 	}
 
+	linker, err := c.autolinks.ForRepository(ctx, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load autolinks: %w", err)
+	}
+
 	commits := make([]types.Commit, len(output.Commits))
 	for i := range output.Commits {
 		var commit *types.Commit
@@ -64,6 +69,7 @@ func (c *Controller) Commits(
 		if err != nil {
 			return nil, fmt.Errorf("failed to map commit: %w", err)
 		}
+		commit.Autolinks = linker.Resolve(commit.Message)
 		commits[i] = *commit
 	}
 
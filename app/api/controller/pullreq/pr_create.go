@@ -114,6 +114,8 @@ func (c *Controller) Create(
 		return nil, fmt.Errorf("pullreq creation failed: %w", err)
 	}
 
+	c.recordMentions(ctx, pr, pr.Title, pr.Description)
+
 	c.eventReporter.Created(ctx, &pullreqevents.CreatedPayload{
 		Base:         eventBase(pr, &session.Principal),
 		SourceBranch: in.SourceBranch,
@@ -121,6 +123,8 @@ func (c *Controller) Create(
 		SourceSHA:    sourceSHA,
 	})
 
+	c.addDefaultReviewers(ctx, session, targetRepo, pr)
+
 	if err = c.sseStreamer.Publish(ctx, targetRepo.ParentID, enum.SSETypePullRequestUpdated, pr); err != nil {
 		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
 	}
@@ -56,35 +56,85 @@ func (c *Controller) RawDiff(
 	}, w)
 }
 
+func newDiffLimits(config *types.Config) git.DiffLimits {
+	return git.DiffLimits{
+		MaxFiles:     config.Diff.MaxFiles,
+		MaxFileLines: config.Diff.MaxFileLines,
+		MaxFileBytes: config.Diff.MaxFileBytes,
+		MaxLines:     config.Diff.MaxLines,
+		MaxBytes:     config.Diff.MaxBytes,
+	}
+}
+
+// DiffOptions holds the optional parameters of a pull request diff request.
+type DiffOptions struct {
+	IncludePatch bool
+
+	// Paths restricts the diff to the listed files. Used to lazily fetch
+	// the diff of files returned without a patch.
+	Paths []string
+
+	// Page and Size paginate the list of changed files. Pagination is disabled if Size is zero.
+	Page int
+	Size int
+}
+
+// Diff returns a stream of file diffs of the pull request and the total number of changed files.
+// The total is only calculated if pagination is requested.
 func (c *Controller) Diff(
 	ctx context.Context,
 	session *auth.Session,
 	repoRef string,
 	pullreqNum int64,
 	setSHAs func(sourceSHA, mergeBaseSHA string),
-	includePatch bool,
-) (types.Stream[*git.FileDiff], error) {
+	opts DiffOptions,
+) (types.Stream[*git.FileDiff], int, error) {
 	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
 	if err != nil {
-		return nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
+		return nil, 0, fmt.Errorf("failed to acquire access to target repo: %w", err)
 	}
 
 	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get pull request by number: %w", err)
+		return nil, 0, fmt.Errorf("failed to get pull request by number: %w", err)
 	}
 
 	if setSHAs != nil {
 		setSHAs(pr.SourceSHA, pr.MergeBaseSHA)
 	}
 
-	reader := git.NewStreamReader(c.git.Diff(ctx, &git.DiffParams{
+	params := &git.DiffParams{
 		ReadParams:   git.CreateReadParams(repo),
 		BaseRef:      pr.MergeBaseSHA,
 		HeadRef:      pr.SourceSHA,
 		MergeBase:    true,
-		IncludePatch: includePatch,
-	}))
+		IncludePatch: opts.IncludePatch,
+		Paths:        opts.Paths,
+		Limits:       c.diffLimits,
+	}
+
+	if len(opts.Paths) > 0 {
+		// files are explicitly requested, only the per-file limits apply.
+		params.Limits = git.DiffLimits{
+			MaxFileLines: c.diffLimits.MaxFileLines,
+			MaxFileBytes: c.diffLimits.MaxFileBytes,
+		}
+	}
+
+	var total int
+	if opts.Size > 0 {
+		stat, err := c.git.DiffShortStat(ctx, params)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to get diff stats: %w", err)
+		}
+
+		total = stat.Files
+
+		params.SkipFiles = (opts.Page - 1) * opts.Size
+		params.MaxFiles = opts.Size
+	}
+
+	reader := git.NewStreamReader(c.git.Diff(ctx, params))
 
-	return reader, nil
+	return reader, total, nil
 }
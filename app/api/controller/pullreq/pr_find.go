@@ -49,7 +49,7 @@ func (c *Controller) Find(
 	headRef := pr.SourceSHA
 	baseRef := pr.MergeBaseSHA
 
-	if pr.Stats.DiffStats.Commits == nil || pr.Stats.FilesChanged == nil {
+	if pr.Stats.DiffStats.Commits == nil || pr.Stats.FilesChanged == nil || pr.Stats.DiffStats.Size == nil {
 		output, err := c.git.DiffStats(ctx, &git.DiffParams{
 			ReadParams: git.CreateReadParams(repo),
 			BaseRef:    baseRef,
@@ -59,8 +59,15 @@ func (c *Controller) Find(
 			return nil, err
 		}
 
-		pr.Stats.DiffStats = types.NewDiffStats(output.Commits, output.FilesChanged)
+		pr.Stats.DiffStats = types.NewDiffStats(output.Commits, output.FilesChanged, output.Additions, output.Deletions)
 	}
 
+	linker, err := c.autolinks.ForRepository(ctx, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load autolinks: %w", err)
+	}
+
+	pr.Autolinks = linker.Resolve(pr.Title, pr.Description)
+
 	return pr, nil
 }
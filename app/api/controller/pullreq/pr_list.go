@@ -74,5 +74,14 @@ func (c *Controller) List(
 		return nil, 0, err
 	}
 
+	linker, err := c.autolinks.ForRepository(ctx, repo)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to load autolinks: %w", err)
+	}
+
+	for _, pr := range list {
+		pr.Autolinks = linker.Resolve(pr.Title, pr.Description)
+	}
+
 	return list, count, nil
 }
@@ -0,0 +1,140 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Retarget changes the target branch of an open pull request.
+// The merge base is recalculated and the mergeability is reset so that it gets recomputed in the background.
+func (c *Controller) Retarget(ctx context.Context,
+	session *auth.Session, repoRef string, pullreqNum int64, targetBranch string,
+) (*types.PullReq, error) {
+	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, targetRepo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return nil, usererror.BadRequest("Only open pull requests can be retargeted.")
+	}
+
+	if pr.TargetBranch == targetBranch {
+		return pr, nil
+	}
+
+	sourceRepo := targetRepo
+	if pr.SourceRepoID != pr.TargetRepoID {
+		sourceRepo, err = c.repoStore.Find(ctx, pr.SourceRepoID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get source repo by id: %w", err)
+		}
+
+		if err = apiauth.CheckRepo(ctx, c.authorizer, session, sourceRepo,
+			enum.PermissionRepoView, false); err != nil {
+			return nil, fmt.Errorf("failed to acquire access to source repo: %w", err)
+		}
+	}
+
+	if pr.SourceRepoID == pr.TargetRepoID && pr.SourceBranch == targetBranch {
+		return nil, usererror.BadRequest("The target branch can't be the same as the source branch.")
+	}
+
+	targetSHA, err := c.verifyBranchExistence(ctx, targetRepo, targetBranch)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.checkIfAlreadyExists(ctx, pr.TargetRepoID, pr.SourceRepoID, targetBranch, pr.SourceBranch)
+	if err != nil {
+		return nil, err
+	}
+
+	mergeBaseResult, err := c.git.MergeBase(ctx, git.MergeBaseParams{
+		ReadParams: git.ReadParams{RepoUID: sourceRepo.GitUID},
+		Ref1:       pr.SourceSHA,
+		Ref2:       targetSHA,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find merge base: %w", err)
+	}
+
+	oldTargetBranch := pr.TargetBranch
+	oldTargetSHA := ""
+	if pr.MergeTargetSHA != nil {
+		oldTargetSHA = *pr.MergeTargetSHA
+	}
+
+	pr, err = c.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+		if pr.State != enum.PullReqStateOpen {
+			return usererror.BadRequest("Only open pull requests can be retargeted.")
+		}
+
+		pr.TargetBranch = targetBranch
+		pr.MergeBaseSHA = mergeBaseResult.MergeBaseSHA
+		pr.Edited = time.Now().UnixMilli()
+
+		// clear all merge (check) related fields, they are recomputed against the new target branch
+		pr.MergeCheckStatus = enum.MergeCheckStatusUnchecked
+		pr.MergeTargetSHA = nil
+		pr.MergeSHA = nil
+		pr.MergeConflicts = nil
+
+		pr.ActivitySeq++ // because we need to add the activity entry
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update pull request: %w", err)
+	}
+
+	payload := &types.PullRequestActivityPayloadTargetChange{
+		Old: oldTargetBranch,
+		New: pr.TargetBranch,
+	}
+	if _, errAct := c.activityStore.CreateWithPayload(ctx, pr, session.Principal.ID, payload); errAct != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(errAct).Msgf("failed to write pull request activity after target branch change")
+	}
+
+	c.eventReporter.TargetBranchUpdated(ctx, &pullreqevents.TargetBranchUpdatedPayload{
+		Base:         eventBase(pr, &session.Principal),
+		OldTargetSHA: oldTargetSHA,
+		NewTargetSHA: targetSHA,
+	})
+
+	if err = c.sseStreamer.Publish(ctx, targetRepo.ParentID, enum.SSETypePullRequestUpdated, pr); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish PR changed event")
+	}
+
+	return pr, nil
+}
@@ -0,0 +1,214 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	// splitMaxSize is the largest size of a pull request that doesn't need to be split.
+	splitMaxSize = enum.PullReqSizeM
+	// splitMaxDepth is the deepest directory level used for grouping the changed files.
+	splitMaxDepth = 3
+)
+
+// SplitSuggestions contains the size of a pull request and the suggested split points if it is oversized.
+type SplitSuggestions struct {
+	Size      enum.PullReqSize `json:"size"`
+	Oversized bool             `json:"oversized"`
+	Splits    []Split          `json:"splits"`
+}
+
+// Split is a cluster of directories with changed files that could be submitted as a separate pull request.
+type Split struct {
+	Directories  []string         `json:"directories"`
+	Paths        []string         `json:"paths"`
+	FilesChanged int64            `json:"files_changed"`
+	Additions    int64            `json:"additions"`
+	Deletions    int64            `json:"deletions"`
+	Size         enum.PullReqSize `json:"size"`
+}
+
+func (s *Split) add(other Split) {
+	s.Directories = append(s.Directories, other.Directories...)
+	s.Paths = append(s.Paths, other.Paths...)
+	s.FilesChanged += other.FilesChanged
+	s.Additions += other.Additions
+	s.Deletions += other.Deletions
+	s.Size = enum.PullReqSizeOf(s.Additions+s.Deletions, s.FilesChanged)
+}
+
+type splitFile struct {
+	path      string
+	additions int64
+	deletions int64
+}
+
+// SplitSuggestions returns the size of the pull request and, if the pull request is oversized,
+// suggests how to split it. The changed files are grouped by their top-level directory
+// and adjacent directories are clustered together as long as the cluster isn't oversized.
+func (c *Controller) SplitSuggestions(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) (*SplitSuggestions, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	reader := git.NewStreamReader(c.git.Diff(ctx, &git.DiffParams{
+		ReadParams:   git.CreateReadParams(repo),
+		BaseRef:      pr.MergeBaseSHA,
+		HeadRef:      pr.SourceSHA,
+		MergeBase:    true,
+		IncludePatch: false,
+	}))
+
+	var (
+		files     []splitFile
+		additions int64
+		deletions int64
+	)
+	for {
+		fileDiff, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read next file diff: %w", err)
+		}
+
+		path := fileDiff.Path
+		if fileDiff.Status == git.FileDiffStatusDeleted {
+			path = fileDiff.OldPath
+		}
+
+		files = append(files, splitFile{
+			path:      path,
+			additions: fileDiff.Additions,
+			deletions: fileDiff.Deletions,
+		})
+		additions += fileDiff.Additions
+		deletions += fileDiff.Deletions
+	}
+
+	size := enum.PullReqSizeOf(additions+deletions, int64(len(files)))
+	result := &SplitSuggestions{
+		Size:      size,
+		Oversized: size.IsLargerThan(splitMaxSize),
+		Splits:    []Split{},
+	}
+
+	if !result.Oversized {
+		return result, nil
+	}
+
+	result.Splits = suggestSplits(files)
+
+	return result, nil
+}
+
+// suggestSplits groups the files by directory and clusters adjacent directories.
+// If all files are in the same directory, the files are grouped by the next directory level.
+func suggestSplits(files []splitFile) []Split {
+	var groups []Split
+	for depth := 1; depth <= splitMaxDepth; depth++ {
+		groups = groupByDirectory(files, depth)
+		if len(groups) > 1 {
+			break
+		}
+	}
+
+	var splits []Split
+	for _, group := range groups {
+		if len(splits) > 0 {
+			last := &splits[len(splits)-1]
+			merged := *last
+			merged.Directories = nil
+			merged.Paths = nil
+			merged.add(group)
+			if !merged.Size.IsLargerThan(splitMaxSize) {
+				last.add(group)
+				continue
+			}
+		}
+
+		splits = append(splits, group)
+	}
+
+	return splits
+}
+
+// groupByDirectory groups the files by their directory truncated to the provided depth.
+// Files placed directly in the repository root are grouped under an empty directory.
+// The groups are sorted by the directory.
+func groupByDirectory(files []splitFile, depth int) []Split {
+	groupMap := make(map[string]*Split)
+	for _, file := range files {
+		dir := directoryOf(file.path, depth)
+
+		group, ok := groupMap[dir]
+		if !ok {
+			group = &Split{Directories: []string{dir}}
+			groupMap[dir] = group
+		}
+
+		group.add(Split{
+			Paths:        []string{file.path},
+			FilesChanged: 1,
+			Additions:    file.additions,
+			Deletions:    file.deletions,
+		})
+	}
+
+	groups := make([]Split, 0, len(groupMap))
+	for _, group := range groupMap {
+		groups = append(groups, *group)
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Directories[0] < groups[j].Directories[0]
+	})
+
+	return groups
+}
+
+// directoryOf returns the directory of the path limited to the provided number of path segments.
+func directoryOf(path string, depth int) string {
+	segments := strings.Split(path, "/")
+	segments = segments[:len(segments)-1] // drop the file name
+	if len(segments) > depth {
+		segments = segments[:depth]
+	}
+
+	return strings.Join(segments, "/")
+}
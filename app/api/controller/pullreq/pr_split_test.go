@@ -0,0 +1,73 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSuggestSplits(t *testing.T) {
+	tests := []struct {
+		name  string
+		files []splitFile
+		want  [][]string
+	}{
+		{
+			name: "top-level-directories",
+			files: []splitFile{
+				{path: "app/a.go", additions: 400},
+				{path: "app/sub/b.go", additions: 300},
+				{path: "web/c.ts", additions: 50},
+				{path: "web/d.ts", additions: 20},
+				{path: "README.md", additions: 5},
+			},
+			want: [][]string{{""}, {"app"}, {"web"}},
+		},
+		{
+			name: "small-directories-clustered",
+			files: []splitFile{
+				{path: "a/x.go", additions: 10},
+				{path: "b/x.go", additions: 10},
+				{path: "c/x.go", additions: 900},
+				{path: "d/x.go", additions: 10},
+			},
+			want: [][]string{{"a", "b"}, {"c"}, {"d"}},
+		},
+		{
+			name: "single-top-level-directory",
+			files: []splitFile{
+				{path: "app/api/x.go", additions: 300},
+				{path: "app/store/y.go", additions: 300},
+			},
+			want: [][]string{{"app/api"}, {"app/store"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			splits := suggestSplits(test.files)
+
+			got := make([][]string, len(splits))
+			for i, split := range splits {
+				got[i] = split.Directories
+			}
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("want=%v got=%v", test.want, got)
+			}
+		})
+	}
+}
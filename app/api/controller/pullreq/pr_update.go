@@ -99,6 +99,8 @@ func (c *Controller) Update(ctx context.Context,
 		return pr, fmt.Errorf("failed to update pull request: %w", err)
 	}
 
+	c.recordMentions(ctx, pr, pr.Title, pr.Description)
+
 	if needToWriteActivity {
 		payload := &types.PullRequestActivityPayloadTitleChange{
 			Old: oldTitle,
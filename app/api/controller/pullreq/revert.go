@@ -0,0 +1,162 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type RevertInput struct {
+	Title        string `json:"title"`
+	Message      string `json:"message"`
+	RevertBranch string `json:"revert_branch"`
+	IsDraft      bool   `json:"is_draft"`
+}
+
+func (in *RevertInput) sanitize(pr *types.PullReq) {
+	in.Title = strings.TrimSpace(in.Title)
+	if in.Title == "" {
+		in.Title = fmt.Sprintf("Revert %q", pr.Title)
+	}
+
+	in.Message = strings.TrimSpace(in.Message)
+	if in.Message == "" {
+		in.Message = fmt.Sprintf("This reverts pull request #%d.", pr.Number)
+	}
+
+	in.RevertBranch = strings.TrimSpace(in.RevertBranch)
+	if in.RevertBranch == "" {
+		in.RevertBranch = fmt.Sprintf("revert-pullreq-%d", pr.Number)
+	}
+}
+
+// Revert creates a new branch that reverts the changes of a merged pull request
+// and opens a new pull request from it against the target branch of the original pull request.
+func (c *Controller) Revert(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *RevertInput,
+) (*types.PullReq, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateMerged || pr.MergeSHA == nil || pr.MergeMethod == nil {
+		return nil, usererror.BadRequest("Only merged pull requests can be reverted")
+	}
+
+	var mergeTargetSHA string
+	if pr.MergeTargetSHA != nil {
+		mergeTargetSHA = *pr.MergeTargetSHA
+	}
+
+	in.sanitize(pr)
+
+	if err = c.verifyBranchNotExists(ctx, repo, in.RevertBranch); err != nil {
+		return nil, err
+	}
+
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	signingKey, err := c.repoSigningKey(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	_, err = c.git.Revert(ctx, &git.RevertParams{
+		WriteParams:    writeParams,
+		BaseBranch:     pr.TargetBranch,
+		RevertBranch:   in.RevertBranch,
+		Method:         gitenum.MergeMethod(*pr.MergeMethod),
+		MergeSHA:       *pr.MergeSHA,
+		MergeTargetSHA: mergeTargetSHA,
+		Title:          in.Title,
+		Message:        in.Message,
+		Committer:      identityFromPrincipalInfo(*bootstrap.NewSystemServiceSession().Principal.ToPrincipalInfo()),
+		CommitterDate:  &now,
+		Author:         identityFromPrincipalInfo(*session.Principal.ToPrincipalInfo()),
+		AuthorDate:     &now,
+		SigningKey:     signingKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	revertPR, err := c.Create(ctx, session, repoRef, &CreateInput{
+		IsDraft:      in.IsDraft,
+		Title:        in.Title,
+		Description:  in.Message,
+		SourceBranch: in.RevertBranch,
+		TargetBranch: pr.TargetBranch,
+	})
+	if err != nil {
+		errDelete := c.git.DeleteBranch(ctx, &git.DeleteBranchParams{
+			WriteParams: writeParams,
+			BranchName:  in.RevertBranch,
+		})
+		if errDelete != nil {
+			// non-critical error
+			log.Ctx(ctx).Warn().Err(errDelete).Msg("failed to delete revert branch")
+		}
+
+		return nil, fmt.Errorf("failed to create revert pull request: %w", err)
+	}
+
+	return revertPR, nil
+}
+
+// verifyBranchNotExists returns a conflict error if the branch already exists in the repository.
+func (c *Controller) verifyBranchNotExists(ctx context.Context, repo *types.Repository, branch string) error {
+	_, err := c.git.GetRef(ctx, git.GetRefParams{
+		ReadParams: git.ReadParams{RepoUID: repo.GitUID},
+		Name:       branch,
+		Type:       gitenum.RefTypeBranch,
+	})
+	if err == nil {
+		return usererror.Conflict(fmt.Sprintf("Branch %s already exists", branch))
+	}
+	if errors.AsStatus(err) != errors.StatusNotFound {
+		return fmt.Errorf("failed to check existence of branch %s: %w", branch, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,221 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ReviewerSuggestionSource is the reason a principal is suggested as a reviewer.
+type ReviewerSuggestionSource string
+
+const (
+	ReviewerSuggestionSourceCodeOwner       ReviewerSuggestionSource = "code_owner"
+	ReviewerSuggestionSourceDefaultReviewer ReviewerSuggestionSource = "default_reviewer"
+)
+
+// ReviewerAvailability tells if a suggested reviewer is currently within the working hours.
+type ReviewerAvailability string
+
+const (
+	// ReviewerAvailabilityAvailable means that the reviewer is currently within the working hours.
+	ReviewerAvailabilityAvailable ReviewerAvailability = "available"
+	// ReviewerAvailabilityUnavailable means that the reviewer is currently outside the working hours.
+	ReviewerAvailabilityUnavailable ReviewerAvailability = "unavailable"
+	// ReviewerAvailabilityUnknown means that the reviewer hasn't configured working hours.
+	ReviewerAvailabilityUnknown ReviewerAvailability = "unknown"
+)
+
+// ReviewerSuggestion is a candidate reviewer of a pull request.
+type ReviewerSuggestion struct {
+	Reviewer     types.PrincipalInfo        `json:"reviewer"`
+	Sources      []ReviewerSuggestionSource `json:"sources"`
+	Availability ReviewerAvailability       `json:"availability"`
+	// LocalTime is the current time in the timezone of the reviewer's working hours.
+	LocalTime string `json:"local_time,omitempty"`
+	// Best is set for the suggestion that is the best reviewer to assign right now.
+	Best bool `json:"best"`
+}
+
+// ReviewerSuggestions returns the code owners and default reviewers of the pull request
+// that aren't assigned as reviewers yet. Reviewers that are currently within their working hours
+// are listed first, followed by reviewers without working hours and reviewers outside of them.
+//
+//nolint:gocognit // refactor if needed
+func (c *Controller) ReviewerSuggestions(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	prNum int64,
+) ([]ReviewerSuggestion, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, prNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	reviewers, err := c.reviewerStore.List(ctx, pr.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reviewers: %w", err)
+	}
+
+	excluded := map[int64]struct{}{pr.CreatedBy: {}}
+	for _, reviewer := range reviewers {
+		excluded[reviewer.PrincipalID] = struct{}{}
+	}
+
+	var candidateIDs []int64
+	candidateSources := map[int64][]ReviewerSuggestionSource{}
+
+	addCandidate := func(principalID int64, source ReviewerSuggestionSource) {
+		if _, ok := excluded[principalID]; ok {
+			return
+		}
+
+		sources, ok := candidateSources[principalID]
+		if !ok {
+			candidateIDs = append(candidateIDs, principalID)
+		}
+
+		for _, s := range sources {
+			if s == source {
+				return
+			}
+		}
+
+		candidateSources[principalID] = append(sources, source)
+	}
+
+	ownerEvaluation, err := c.codeOwners.Evaluate(ctx, repo, pr, reviewers)
+	switch {
+	case errors.Is(err, codeowners.ErrNotFound):
+	case codeowners.IsTooLargeError(err):
+		log.Ctx(ctx).Warn().Err(err).Msg("skipping code owners for reviewer suggestions")
+	case err != nil:
+		return nil, fmt.Errorf("CODEOWNERS evaluation failed: %w", err)
+	default:
+		for _, entry := range ownerEvaluation.EvaluationEntries {
+			for _, owner := range entry.OwnerEvaluations {
+				addCandidate(owner.Owner.ID, ReviewerSuggestionSourceCodeOwner)
+			}
+			for _, group := range entry.UserGroupOwnerEvaluations {
+				for _, owner := range group.Evaluations {
+					addCandidate(owner.Owner.ID, ReviewerSuggestionSourceCodeOwner)
+				}
+			}
+		}
+	}
+
+	defaultReviewers, err := c.findDefaultReviewers(ctx, repo, pr)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, principal := range defaultReviewers {
+		addCandidate(principal.ID, ReviewerSuggestionSourceDefaultReviewer)
+	}
+
+	workingHours, err := c.workingHoursStore.Map(ctx, candidateIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get working hours of reviewers: %w", err)
+	}
+
+	now := time.Now()
+	suggestions := make([]ReviewerSuggestion, 0, len(candidateIDs))
+
+	for _, principalID := range candidateIDs {
+		principal, err := c.principalStore.Find(ctx, principalID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find principal %d: %w", principalID, err)
+		}
+
+		if err = apiauth.CheckRepo(ctx, c.authorizer, &auth.Session{
+			Principal: *principal,
+		}, repo, enum.PermissionRepoReview, false); err != nil {
+			continue
+		}
+
+		suggestion := ReviewerSuggestion{
+			Reviewer:     *principal.ToPrincipalInfo(),
+			Sources:      candidateSources[principalID],
+			Availability: ReviewerAvailabilityUnknown,
+		}
+
+		if hours, ok := workingHours[principalID]; ok {
+			suggestion.Availability, suggestion.LocalTime = reviewerAvailability(ctx, hours, now)
+		}
+
+		suggestions = append(suggestions, suggestion)
+	}
+
+	availabilityRank := map[ReviewerAvailability]int{
+		ReviewerAvailabilityAvailable:   0,
+		ReviewerAvailabilityUnknown:     1,
+		ReviewerAvailabilityUnavailable: 2,
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return availabilityRank[suggestions[i].Availability] < availabilityRank[suggestions[j].Availability]
+	})
+
+	if len(suggestions) > 0 && suggestions[0].Availability == ReviewerAvailabilityAvailable {
+		suggestions[0].Best = true
+	}
+
+	return suggestions, nil
+}
+
+// reviewerAvailability returns the availability of the reviewer and the reviewer's local time.
+func reviewerAvailability(
+	ctx context.Context,
+	hours *types.WorkingHours,
+	now time.Time,
+) (ReviewerAvailability, string) {
+	available, err := hours.Contains(now)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("invalid working hours of principal %d", hours.PrincipalID)
+		return ReviewerAvailabilityUnknown, ""
+	}
+
+	loc := time.UTC
+	if hours.Timezone != "" {
+		// the timezone is valid, otherwise Contains would have failed.
+		loc, _ = time.LoadLocation(hours.Timezone)
+	}
+
+	localTime := now.In(loc).Format(time.RFC3339)
+
+	if available {
+		return ReviewerAvailabilityAvailable, localTime
+	}
+
+	return ReviewerAvailabilityUnavailable, localTime
+}
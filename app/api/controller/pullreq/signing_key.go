@@ -0,0 +1,48 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+// repoSigningKey returns the key used to sign the commits created by merging pull requests of the repository.
+// If the repository doesn't have a signing key, nil is returned and the server signing key (if any) is used.
+func (c *Controller) repoSigningKey(ctx context.Context, repo *types.Repository) (*git.SigningKey, error) {
+	key, err := c.signingKeyStore.Find(ctx, repo.ID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repository signing key: %w", err)
+	}
+
+	privateKey, err := c.encrypter.Decrypt([]byte(key.PrivateKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt repository signing key: %w", err)
+	}
+
+	return &git.SigningKey{
+		Format:     gitenum.SigningFormat(key.Format),
+		PrivateKey: privateKey,
+	}, nil
+}
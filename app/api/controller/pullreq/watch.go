@@ -0,0 +1,138 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// WatchFind returns the watch level of the current user for the pull request.
+// If the user didn't set a watch level for the pull request, the repository watch level applies.
+func (c *Controller) WatchFind(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) (*types.PullReqWatch, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to the repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	watch, err := c.watchStore.FindPullReq(ctx, pr.ID, session.Principal.ID)
+	if err == nil {
+		return watch, nil
+	}
+	if !errors.Is(err, store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find pull request watch: %w", err)
+	}
+
+	level := controller.WatchDefaultLevel
+
+	repoWatch, err := c.watchStore.FindRepo(ctx, repo.ID, session.Principal.ID)
+	if err != nil && !errors.Is(err, store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find repository watch: %w", err)
+	}
+	if repoWatch != nil {
+		level = repoWatch.Level.PullReqLevel()
+	}
+
+	return &types.PullReqWatch{
+		PullReqID:   pr.ID,
+		PrincipalID: session.Principal.ID,
+		Level:       level,
+	}, nil
+}
+
+// WatchUpdate sets the watch level of the current user for the pull request.
+func (c *Controller) WatchUpdate(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *controller.WatchInput,
+) (*types.PullReqWatch, error) {
+	if err := in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	if !in.Level.IsValidForPullReq() {
+		return nil, usererror.BadRequestf("Watch level %q is not supported for pull requests.", in.Level)
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to the repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	watch := &types.PullReqWatch{
+		PullReqID:   pr.ID,
+		PrincipalID: session.Principal.ID,
+		Level:       in.Level,
+		Created:     now,
+		Updated:     now,
+	}
+
+	err = c.watchStore.UpsertPullReq(ctx, watch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upsert pull request watch: %w", err)
+	}
+
+	return watch, nil
+}
+
+// WatchDelete removes the watch level of the current user for the pull request.
+// After that, the repository watch level applies.
+func (c *Controller) WatchDelete(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to the repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	err = c.watchStore.DeletePullReq(ctx, pr.ID, session.Principal.ID)
+	if err != nil {
+		return fmt.Errorf("failed to delete pull request watch: %w", err)
+	}
+
+	return nil
+}
@@ -17,16 +17,21 @@ package pullreq
 import (
 	"github.com/harness/gitness/app/auth/authz"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/autolink"
 	"github.com/harness/gitness/app/services/codecomments"
 	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
 	"github.com/harness/gitness/lock"
 	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
 
 	"github.com/google/wire"
 )
@@ -36,26 +41,33 @@ var WireSet = wire.NewSet(
 	ProvideController,
 )
 
-func ProvideController(tx dbtx.Transactor, urlProvider url.Provider, authorizer authz.Authorizer,
+func ProvideController(config *types.Config, tx dbtx.Transactor, urlProvider url.Provider, authorizer authz.Authorizer,
 	pullReqStore store.PullReqStore, pullReqActivityStore store.PullReqActivityStore,
 	codeCommentsView store.CodeCommentView,
 	pullReqReviewStore store.PullReqReviewStore, pullReqReviewerStore store.PullReqReviewerStore,
+	pullReqMentionStore store.PullReqMentionStore,
 	repoStore store.RepoStore, principalStore store.PrincipalStore,
-	fileViewStore store.PullReqFileViewStore, membershipStore store.MembershipStore,
-	checkStore store.CheckStore,
+	fileViewStore store.PullReqFileViewStore, dependencyStore store.PullReqDependencyStore,
+	mergeTemplateStore store.MergeTemplateStore, signingKeyStore store.SigningKeyStore,
+	membershipStore store.MembershipStore,
+	checkStore store.CheckStore, watchStore store.WatchStore, defaultReviewerStore store.DefaultReviewerStore,
+	workingHoursStore store.WorkingHoursStore,
 	rpcClient git.Interface, eventReporter *pullreqevents.Reporter,
 	mtxManager lock.MutexManager, codeCommentMigrator *codecomments.Migrator,
 	pullreqService *pullreq.Service, ruleManager *protection.Manager, sseStreamer sse.Streamer,
-	codeOwners *codeowners.Service,
+	codeOwners *codeowners.Service, encrypter encrypt.Encrypter,
+	scheduler *job.Scheduler, autolinks *autolink.Service,
+	userGroupResolver usergroup.Resolver,
 ) *Controller {
-	return NewController(tx, urlProvider, authorizer,
+	return NewController(config, tx, urlProvider, authorizer,
 		pullReqStore, pullReqActivityStore,
 		codeCommentsView,
-		pullReqReviewStore, pullReqReviewerStore,
+		pullReqReviewStore, pullReqReviewerStore, pullReqMentionStore,
 		repoStore, principalStore,
-		fileViewStore, membershipStore,
-		checkStore,
+		fileViewStore, dependencyStore, mergeTemplateStore, signingKeyStore, membershipStore,
+		checkStore, watchStore, defaultReviewerStore, workingHoursStore,
 		rpcClient, eventReporter,
 		mtxManager, codeCommentMigrator,
-		pullreqService, ruleManager, sseStreamer, codeOwners)
+		pullreqService, ruleManager, sseStreamer, codeOwners, encrypter, scheduler, autolinks,
+		userGroupResolver)
 }
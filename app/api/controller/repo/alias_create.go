@@ -0,0 +1,92 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type AliasCreateInput struct {
+	UID string `json:"uid"`
+}
+
+func (c *Controller) sanitizeAliasCreateInput(in *AliasCreateInput) error {
+	in.UID = strings.TrimSpace(in.UID)
+
+	return c.uidCheck(in.UID, false)
+}
+
+// AliasCreate adds an alias to the repository. The alias must not conflict
+// with the identifier or an alias of any repository in the same space.
+func (c *Controller) AliasCreate(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *AliasCreateInput,
+) (*types.RepoAlias, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.sanitizeAliasCreateInput(in); err != nil {
+		return nil, err
+	}
+
+	// the reference resolves both the identifiers and the aliases of the repositories in the space.
+	spacePath, _, _ := paths.DisectLeaf(repo.Path)
+	_, err = c.repoStore.FindByRef(ctx, paths.Concatinate(spacePath, in.UID))
+	if err == nil {
+		return nil, usererror.Conflict(
+			fmt.Sprintf("A repository or a repository alias with the identifier %q already exists.", in.UID))
+	}
+	if !errors.Is(err, store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	alias := &types.RepoAlias{
+		RepoID:    repo.ID,
+		SpaceID:   repo.ParentID,
+		UID:       in.UID,
+		CreatedBy: session.Principal.ID,
+		Created:   time.Now().UnixMilli(),
+	}
+
+	err = c.repoAliasStore.Create(ctx, alias)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create repository alias: %w", err)
+	}
+
+	backfillAlias(repo, alias)
+
+	return alias, nil
+}
+
+// backfillAlias sets the paths of the alias of the repository.
+func backfillAlias(repo *types.Repository, alias *types.RepoAlias) {
+	spacePath, _, _ := paths.DisectLeaf(repo.Path)
+	alias.Path = paths.Concatinate(spacePath, alias.UID)
+	alias.RepoPath = repo.Path
+}
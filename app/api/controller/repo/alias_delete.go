@@ -0,0 +1,52 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types/enum"
+)
+
+// AliasDelete removes the alias from the repository.
+func (c *Controller) AliasDelete(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	id int64,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return err
+	}
+
+	alias, err := c.repoAliasStore.Find(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to find repository alias: %w", err)
+	}
+
+	if alias.RepoID != repo.ID {
+		return usererror.ErrNotFound
+	}
+
+	err = c.repoAliasStore.Delete(ctx, alias.ID)
+	if err != nil {
+		return fmt.Errorf("failed to delete repository alias: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Archive writes an archive of the files of the repo at the git reference to the provided writer.
+// The files in the archive are placed in a directory named after the repo and the git reference.
+func (c *Controller) Archive(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	gitRef string,
+	format gitenum.ArchiveFormat,
+	w io.Writer,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return err
+	}
+
+	err = c.git.Archive(ctx, &git.ArchiveParams{
+		ReadParams: git.CreateReadParams(repo),
+		GitRef:     gitRef,
+		Format:     format,
+		Prefix:     ArchivePrefix(repo.UID, gitRef),
+		MaxSize:    c.archiveMaxSize,
+	}, w)
+	if err != nil {
+		return fmt.Errorf("failed to create archive: %w", err)
+	}
+
+	return nil
+}
+
+// ArchivePrefix returns the name of the top level directory of an archive, e.g. "repo-feature-x/".
+func ArchivePrefix(repoUID string, gitRef string) string {
+	return repoUID + "-" + strings.ReplaceAll(gitRef, "/", "-") + "/"
+}
@@ -0,0 +1,65 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// AutolinkCreate adds an autolink rule to the repository.
+func (c *Controller) AutolinkCreate(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *controller.AutolinkCreateInput,
+) (*types.Autolink, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	link := &types.Autolink{
+		RepoID:      &repo.ID,
+		Pattern:     in.Pattern,
+		TargetURL:   in.TargetURL,
+		Description: in.Description,
+		CreatedBy:   session.Principal.ID,
+		Created:     now,
+		Updated:     now,
+	}
+
+	err = c.autolinkStore.Create(ctx, link)
+	if errors.Is(err, store.ErrDuplicate) {
+		return nil, usererror.Conflict("An autolink with the pattern already exists in the repository.")
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to create autolink: %w", err)
+	}
+
+	return link, nil
+}
@@ -0,0 +1,43 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// AutolinkList lists all autolink rules that apply to the repository:
+// the rules of the repository first, followed by the rules inherited from the space and its ancestors.
+func (c *Controller) AutolinkList(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) ([]*types.Autolink, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, err
+	}
+
+	links, err := c.autolinks.ListForRepository(ctx, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list autolinks: %w", err)
+	}
+
+	return links, nil
+}
@@ -15,7 +15,11 @@
 package repo
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"fmt"
+	"io"
 	"strings"
 
 	"github.com/harness/gitness/app/api/usererror"
@@ -23,12 +27,18 @@ import (
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
+// maxBlameIgnoreRevsFileSize is the maximum size of the ignore-revs file that is read for blame.
+const maxBlameIgnoreRevsFileSize = 256 * 1024
+
 func (c *Controller) Blame(ctx context.Context,
 	session *auth.Session,
 	repoRef, gitRef, path string,
 	lineFrom, lineTo int,
+	ignoreRevsFile string,
 ) (types.Stream[*git.BlamePart], error) {
 	path = strings.TrimSpace(path)
 	if path == "" {
@@ -48,6 +58,14 @@ func (c *Controller) Blame(ctx context.Context,
 		gitRef = repo.DefaultBranch
 	}
 
+	var ignoreRevs []string
+	if ignoreRevsFile = strings.TrimSpace(ignoreRevsFile); ignoreRevsFile != "" {
+		ignoreRevs, err = c.readBlameIgnoreRevs(ctx, repo, gitRef, ignoreRevsFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	reader := git.NewStreamReader(
 		c.git.Blame(ctx, &git.BlameParams{
 			ReadParams: git.CreateReadParams(repo),
@@ -55,7 +73,83 @@ func (c *Controller) Blame(ctx context.Context,
 			Path:       path,
 			LineFrom:   lineFrom,
 			LineTo:     lineTo,
+			IgnoreRevs: ignoreRevs,
 		}))
 
 	return reader, nil
 }
+
+// readBlameIgnoreRevs reads the list of commit SHAs to ignore from the provided file,
+// which is expected to be in the format of git's blame.ignoreRevsFile:
+// One commit SHA per line, with empty lines and comments (starting with '#') being ignored.
+func (c *Controller) readBlameIgnoreRevs(
+	ctx context.Context,
+	repo *types.Repository,
+	gitRef string,
+	filePath string,
+) ([]string, error) {
+	readParams := git.CreateReadParams(repo)
+	node, err := c.git.GetTreeNode(ctx, &git.GetTreeNodeParams{
+		ReadParams: readParams,
+		GitREF:     gitRef,
+		Path:       filePath,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ignore revs file tree node: %w", err)
+	}
+
+	if node.Node.Type != git.TreeNodeTypeBlob {
+		return nil, usererror.BadRequestf("Object at '/%s' isn't a file.", filePath)
+	}
+
+	blob, err := c.git.GetBlob(ctx, &git.GetBlobParams{
+		ReadParams: readParams,
+		SHA:        node.Node.SHA,
+		SizeLimit:  maxBlameIgnoreRevsFileSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ignore revs file blob: %w", err)
+	}
+
+	defer func() {
+		if err := blob.Content.Close(); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to close blob content reader")
+		}
+	}()
+
+	data, err := io.ReadAll(blob.Content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ignore revs file content: %w", err)
+	}
+
+	return parseBlameIgnoreRevs(data)
+}
+
+func parseBlameIgnoreRevs(data []byte) ([]string, error) {
+	var revs []string
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if idx := strings.IndexByte(line, '#'); idx >= 0 {
+			line = line[:idx]
+		}
+
+		line = strings.ToLower(strings.TrimSpace(line))
+		if line == "" {
+			continue
+		}
+
+		if !git.ValidateCommitSHA(line) {
+			return nil, usererror.BadRequestf("Ignore revs file contains an invalid commit SHA %q.", line)
+		}
+
+		revs = append(revs, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to parse ignore revs file: %w", err)
+	}
+
+	return revs, nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bundle"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type ApplyBundleInput struct {
+	// Prune deletes the branches and tags of the repository that aren't part of the bundle.
+	// It's only allowed for complete (not incremental) bundles.
+	Prune bool `json:"prune"`
+}
+
+// ApplyBundle updates the branches and tags of an existing repository from a bundle created by ExportBundle.
+// Incremental bundles can be applied if the repository contains the commit the bundle is based on.
+func (c *Controller) ApplyBundle(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *ApplyBundleInput,
+	r io.Reader,
+) (*types.Repository, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.CreateTemp("", "gitness-bundle-*.bundle")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary file for git bundle: %w", err)
+	}
+	defer func() {
+		_ = f.Close()
+		if errRemove := os.Remove(f.Name()); errRemove != nil {
+			log.Ctx(ctx).Warn().Err(errRemove).Msg("failed to remove temporary git bundle file")
+		}
+	}()
+
+	// the metadata is only restored when a new repository is created from the bundle.
+	manifest, _, err := bundle.Read(r, f)
+	if err != nil {
+		return nil, usererror.BadRequestf("Invalid repository bundle: %s", err)
+	}
+
+	if in.Prune && manifest.GitBundle.Since != "" {
+		return nil, usererror.BadRequest("Incremental bundles can't be applied with prune.")
+	}
+
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	err = c.git.ApplyBundle(ctx, &git.ApplyBundleParams{
+		WriteParams: writeParams,
+		BundlePath:  f.Name(),
+		Prune:       in.Prune,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	repo.GitURL = c.urlProvider.GenerateGITCloneURL(repo.Path)
+
+	return repo, nil
+}
@@ -0,0 +1,114 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bundle"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types/enum"
+	"github.com/harness/gitness/version"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ExportBundle writes a portable bundle of the repository to the provided writer.
+// The bundle can be imported into another instance using ImportBundle.
+// If sinceSHA is provided, the bundle is incremental and can be applied to a copy of the repository
+// that contains the commit using ApplyBundle.
+// If includeMetadata is true, the bundle also contains the pull requests, protection rules and webhooks.
+func (c *Controller) ExportBundle(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	sinceSHA string,
+	includeMetadata bool,
+	w io.Writer,
+) error {
+	if includeMetadata && sinceSHA != "" {
+		return usererror.BadRequest("Incremental bundles can't contain the repository metadata.")
+	}
+
+	// the metadata contains the protection rules and the webhooks of the repository.
+	permission := enum.PermissionRepoView
+	if includeMetadata {
+		permission = enum.PermissionRepoEdit
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, permission, false)
+	if err != nil {
+		return err
+	}
+
+	var metadata *bundle.Metadata
+	if includeMetadata {
+		metadata, err = c.backup.Collect(ctx, repo)
+		if err != nil {
+			return fmt.Errorf("failed to collect repository metadata: %w", err)
+		}
+	}
+
+	// the manifest precedes the git bundle and describes its size and checksum,
+	// so the git bundle has to be created before anything is written.
+	f, err := os.CreateTemp("", "gitness-bundle-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file for git bundle: %w", err)
+	}
+	defer func() {
+		_ = f.Close()
+		if errRemove := os.Remove(f.Name()); errRemove != nil {
+			log.Ctx(ctx).Warn().Err(errRemove).Msg("failed to remove temporary git bundle file")
+		}
+	}()
+
+	hasher := sha256.New()
+	err = c.git.CreateBundle(ctx, &git.CreateBundleParams{
+		ReadParams: git.CreateReadParams(repo),
+		Since:      sinceSHA,
+	}, io.MultiWriter(f, hasher))
+	if err != nil {
+		return fmt.Errorf("failed to create git bundle: %w", err)
+	}
+
+	size, err := f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return fmt.Errorf("failed to get git bundle size: %w", err)
+	}
+
+	if _, err = f.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to rewind git bundle: %w", err)
+	}
+
+	manifest := bundle.NewManifest(version.Version.String(), bundle.Repository{
+		UID:           repo.UID,
+		Description:   repo.Description,
+		DefaultBranch: repo.DefaultBranch,
+		IsPublic:      repo.IsPublic,
+	}, size, hasher.Sum(nil))
+	manifest.GitBundle.Since = sinceSHA
+
+	if err = bundle.Write(w, manifest, f, metadata); err != nil {
+		return fmt.Errorf("failed to write bundle: %w", err)
+	}
+
+	return nil
+}
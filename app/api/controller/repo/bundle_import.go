@@ -0,0 +1,167 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bundle"
+	"github.com/harness/gitness/app/services/importer"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+type ImportBundleInput struct {
+	ParentRef string `json:"parent_ref"`
+	// UID is the identifier of the new repository. Defaults to the identifier stored in the bundle.
+	UID string `json:"uid"`
+}
+
+// ImportBundle creates a new repository from a bundle created by ExportBundle.
+// The import doesn't require any connectivity to the instance the bundle was created on.
+// If the bundle contains the repository metadata, the pull requests, protection rules and webhooks are restored.
+func (c *Controller) ImportBundle(
+	ctx context.Context,
+	session *auth.Session,
+	in *ImportBundleInput,
+	r io.Reader,
+) (*types.Repository, error) {
+	parentSpace, err := c.getSpaceCheckAuthRepoCreation(ctx, session, in.ParentRef)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.CreateTemp("", "gitness-bundle-*.bundle")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary file for git bundle: %w", err)
+	}
+	defer func() {
+		_ = f.Close()
+		if errRemove := os.Remove(f.Name()); errRemove != nil {
+			log.Ctx(ctx).Warn().Err(errRemove).Msg("failed to remove temporary git bundle file")
+		}
+	}()
+
+	manifest, metadata, err := bundle.Read(r, f)
+	if err != nil {
+		return nil, usererror.BadRequestf("Invalid repository bundle: %s", err)
+	}
+
+	if manifest.GitBundle.Since != "" {
+		return nil, usererror.BadRequest(
+			"The bundle is incremental, it can only be applied to an existing copy of the repository.")
+	}
+
+	if in.UID == "" {
+		in.UID = manifest.Repository.UID
+	}
+
+	if err = c.sanitizeImportBundleInput(in); err != nil {
+		return nil, fmt.Errorf("failed to sanitize input: %w", err)
+	}
+
+	if err = controller.CheckRepoUIDNotAliased(ctx, c.repoAliasStore, parentSpace.ID, in.UID); err != nil {
+		return nil, err
+	}
+
+	if err = c.resourceLimiter.RepoCount(ctx, 1); err != nil {
+		return nil, errors.PreconditionFailed(err.Error())
+	}
+
+	repoInfo := importer.RepositoryInfo{
+		IsPublic:      manifest.Repository.IsPublic,
+		DefaultBranch: manifest.Repository.DefaultBranch,
+	}
+	repo := repoInfo.ToRepo(
+		parentSpace.ID,
+		in.UID,
+		manifest.Repository.Description,
+		&session.Principal,
+		c.publicResourceCreationEnabled,
+	)
+
+	if err = c.repoStore.Create(ctx, repo); err != nil {
+		return nil, fmt.Errorf("failed to create repository in storage: %w", err)
+	}
+
+	repoID := repo.ID
+	repo, err = c.importer.ImportBundle(ctx, repo, f.Name(), manifest.Repository.DefaultBranch, metadata != nil)
+	if err != nil {
+		if errDel := c.repoStore.Delete(context.Background(), repoID); errDel != nil {
+			log.Ctx(ctx).Warn().Err(errDel).Msg("failed to delete repository after failed bundle import")
+		}
+		return nil, err
+	}
+
+	if metadata != nil {
+		if err = c.restoreBundleMetadata(ctx, session, repo, metadata); err != nil {
+			if errDel := c.DeleteNoAuth(context.Background(), session, repo); errDel != nil {
+				log.Ctx(ctx).Warn().Err(errDel).Msg("failed to delete repository after failed metadata restore")
+			}
+			return nil, err
+		}
+	}
+
+	repo.GitURL = c.urlProvider.GenerateGITCloneURL(repo.Path)
+
+	return repo, nil
+}
+
+func (c *Controller) restoreBundleMetadata(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	metadata *bundle.Metadata,
+) error {
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, repo)
+	if err != nil {
+		return fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	result, err := c.backup.Restore(ctx, &session.Principal, repo, writeParams, metadata)
+	if err != nil {
+		return fmt.Errorf("failed to restore repository metadata: %w", err)
+	}
+
+	log.Ctx(ctx).Info().
+		Int64("repo.id", repo.ID).
+		Int("pull_requests", result.PullRequests).
+		Int("pull_requests_skipped", result.PullRequestsSkipped).
+		Int("rules", result.Rules).
+		Int("webhooks", result.Webhooks).
+		Msg("restored repository metadata from bundle")
+
+	return nil
+}
+
+func (c *Controller) sanitizeImportBundleInput(in *ImportBundleInput) error {
+	if err := c.validateParentRef(in.ParentRef); err != nil {
+		return err
+	}
+
+	if err := c.uidCheck(in.UID, false); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,168 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// maxCherryPickCommits is the maximum number of commits that can be cherry-picked in a single request.
+const maxCherryPickCommits = 50
+
+// CherryPickCommitsInput holds the data for cherry-picking commits onto a branch.
+type CherryPickCommitsInput struct {
+	// Branch is the branch the commits are cherry-picked onto (default: the default branch of the repo).
+	Branch string `json:"branch"`
+	// NewBranch is the optional name of a new branch that receives the cherry-picked commits instead of Branch.
+	NewBranch string `json:"new_branch"`
+	// Commits are the SHAs of the commits that are cherry-picked, in the order they are applied.
+	Commits []string `json:"commits"`
+	// Mainline is the parent number of merge commits that is used as the base of the cherry-pick.
+	Mainline int `json:"mainline"`
+
+	DryRunRules bool `json:"dry_run_rules"`
+	BypassRules bool `json:"bypass_rules"`
+}
+
+func (in *CherryPickCommitsInput) sanitize(repo *types.Repository) error {
+	in.Branch = strings.TrimSpace(in.Branch)
+	if in.Branch == "" {
+		in.Branch = repo.DefaultBranch
+	}
+
+	in.NewBranch = strings.TrimSpace(in.NewBranch)
+
+	if len(in.Commits) == 0 {
+		return usererror.BadRequest("At least one commit must be provided.")
+	}
+
+	if len(in.Commits) > maxCherryPickCommits {
+		return usererror.BadRequestf("At most %d commits can be cherry-picked at once.", maxCherryPickCommits)
+	}
+
+	for i := range in.Commits {
+		in.Commits[i] = strings.ToLower(strings.TrimSpace(in.Commits[i]))
+		if !git.ValidateCommitSHA(in.Commits[i]) {
+			return usererror.BadRequestf("Invalid commit SHA %q.", in.Commits[i])
+		}
+	}
+
+	if in.Mainline < 0 {
+		return usererror.BadRequest("Mainline can't be negative.")
+	}
+
+	return nil
+}
+
+// CherryPickCommits applies the changes of the provided commits on top of a branch, without a local clone.
+// If any commit can't be applied cleanly, a precondition failed error listing the conflicting files is returned.
+func (c *Controller) CherryPickCommits(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *CherryPickCommitsInput,
+) (types.CherryPickCommitsResponse, []types.RuleViolations, error) {
+	requiredPermission := enum.PermissionRepoPush
+	if in.DryRunRules {
+		requiredPermission = enum.PermissionRepoView
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, requiredPermission, false)
+	if err != nil {
+		return types.CherryPickCommitsResponse{}, nil, err
+	}
+
+	if err = in.sanitize(repo); err != nil {
+		return types.CherryPickCommitsResponse{}, nil, err
+	}
+
+	rules, isRepoOwner, err := c.fetchRules(ctx, session, repo)
+	if err != nil {
+		return types.CherryPickCommitsResponse{}, nil, err
+	}
+
+	refAction := protection.RefActionUpdate
+	branchName := in.Branch
+	if in.NewBranch != "" {
+		refAction = protection.RefActionCreate
+		branchName = in.NewBranch
+	}
+
+	violations, err := rules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
+		Actor:       &session.Principal,
+		AllowBypass: in.BypassRules,
+		IsRepoOwner: isRepoOwner,
+		Repo:        repo,
+		RefAction:   refAction,
+		RefType:     protection.RefTypeBranch,
+		RefNames:    []string{branchName},
+	})
+	if err != nil {
+		return types.CherryPickCommitsResponse{}, nil, fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+
+	if in.DryRunRules {
+		return types.CherryPickCommitsResponse{
+			DryRunRules:    true,
+			RuleViolations: violations,
+		}, nil, nil
+	}
+
+	if protection.IsCritical(violations) {
+		return types.CherryPickCommitsResponse{}, violations, nil
+	}
+
+	// Create internal write params. Note: This will skip the pre-commit protection rules check.
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, repo)
+	if err != nil {
+		return types.CherryPickCommitsResponse{}, nil, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	// sign the commits on behalf of the user, if instance-managed user signing keys are enabled.
+	signingKey, err := c.userSigning.SigningKey(ctx, session.Principal.ID)
+	if err != nil {
+		return types.CherryPickCommitsResponse{}, nil, fmt.Errorf("failed to get user signing key: %w", err)
+	}
+
+	now := time.Now()
+	output, err := c.git.CherryPickCommits(ctx, &git.CherryPickCommitsParams{
+		WriteParams:   writeParams,
+		Branch:        in.Branch,
+		NewBranch:     in.NewBranch,
+		Commits:       in.Commits,
+		Mainline:      in.Mainline,
+		Committer:     identityFromPrincipal(session.Principal),
+		CommitterDate: &now,
+		SigningKey:    signingKey,
+	})
+	if err != nil {
+		return types.CherryPickCommitsResponse{}, nil, err
+	}
+
+	return types.CherryPickCommitsResponse{
+		CommitID:       output.CommitSHA,
+		RuleViolations: violations,
+	}, nil, nil
+}
@@ -140,6 +140,12 @@ func (c *Controller) CommitFiles(ctx context.Context,
 		return types.CommitFilesResponse{}, nil, fmt.Errorf("failed to create RPC write params: %w", err)
 	}
 
+	// sign the commit on behalf of the user, if instance-managed user signing keys are enabled.
+	signingKey, err := c.userSigning.SigningKey(ctx, session.Principal.ID)
+	if err != nil {
+		return types.CommitFilesResponse{}, nil, fmt.Errorf("failed to get user signing key: %w", err)
+	}
+
 	now := time.Now()
 	commit, err := c.git.CommitFiles(ctx, &git.CommitFilesParams{
 		WriteParams:   writeParams,
@@ -152,6 +158,7 @@ func (c *Controller) CommitFiles(ctx context.Context,
 		CommitterDate: &now,
 		Author:        identityFromPrincipal(session.Principal),
 		AuthorDate:    &now,
+		SigningKey:    signingKey,
 	})
 	if err != nil {
 		return types.CommitFilesResponse{}, nil, err
@@ -0,0 +1,139 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type CommitCommentCreateInput struct {
+	// ParentID is set only for replies
+	ParentID int64  `json:"parent_id"`
+	Text     string `json:"text"`
+
+	// Used only for inline comments
+	Path         string `json:"path"`
+	LineStart    int    `json:"line_start"`
+	LineStartNew bool   `json:"line_start_new"`
+	LineEnd      int    `json:"line_end"`
+	LineEndNew   bool   `json:"line_end_new"`
+}
+
+func (in *CommitCommentCreateInput) sanitize() error {
+	if strings.TrimSpace(in.Text) == "" {
+		return usererror.BadRequest("Comment text can't be empty.")
+	}
+
+	if in.Path == "" {
+		if in.LineStart != 0 || in.LineEnd != 0 {
+			return usererror.BadRequest("Line numbers can be provided only for inline comments.")
+		}
+		return nil
+	}
+
+	if in.ParentID != 0 {
+		return usererror.BadRequest("Can't create a reply that is an inline comment.")
+	}
+
+	if in.LineStart <= 0 || in.LineEnd <= 0 {
+		return usererror.BadRequest("Inline comments require line numbers.")
+	}
+
+	if in.LineStartNew == in.LineEndNew && in.LineEnd < in.LineStart {
+		return usererror.BadRequest("The line range of the inline comment is invalid.")
+	}
+
+	return nil
+}
+
+// CommitCommentCreate creates a new comment directly on a commit.
+func (c *Controller) CommitCommentCreate(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	commitSHA string,
+	in *CommitCommentCreateInput,
+) (*types.CommitComment, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoReview, false)
+	if err != nil {
+		return nil, err
+	}
+
+	commitSHA, err = c.resolveCommitSHA(ctx, repo, commitSHA)
+	if err != nil {
+		return nil, err
+	}
+
+	var parentID *int64
+	if in.ParentID != 0 {
+		parent, err := c.getCommitComment(ctx, repo, commitSHA, in.ParentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find parent comment: %w", err)
+		}
+
+		if parent.ParentID != nil {
+			return nil, usererror.BadRequest("Can't create a reply to a reply.")
+		}
+
+		if parent.Deleted != nil {
+			return nil, usererror.BadRequest("Can't create a reply to a deleted comment.")
+		}
+
+		parentID = &parent.ID
+	}
+
+	now := time.Now().UnixMilli()
+	comment := &types.CommitComment{
+		RepoID:       repo.ID,
+		CommitSHA:    commitSHA,
+		ParentID:     parentID,
+		CreatedBy:    session.Principal.ID,
+		Created:      now,
+		Updated:      now,
+		Edited:       now,
+		Text:         in.Text,
+		Path:         in.Path,
+		LineStart:    in.LineStart,
+		LineStartNew: in.LineStartNew,
+		LineEnd:      in.LineEnd,
+		LineEndNew:   in.LineEndNew,
+		Author:       *session.Principal.ToPrincipalInfo(),
+	}
+
+	if err = c.commitCommentStore.Create(ctx, comment); err != nil {
+		return nil, fmt.Errorf("failed to create commit comment: %w", err)
+	}
+
+	c.eventReporter.CommitCommentCreated(ctx, &repoevents.CommitCommentCreatedPayload{
+		RepoID:      repo.ID,
+		PrincipalID: session.Principal.ID,
+		CommentID:   comment.ID,
+		CommitSHA:   commitSHA,
+	})
+
+	return comment, nil
+}
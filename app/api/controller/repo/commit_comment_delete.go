@@ -0,0 +1,58 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types/enum"
+)
+
+// CommitCommentDelete deletes a comment made directly on a commit.
+// The comment is only marked as deleted to preserve the replies to it.
+func (c *Controller) CommitCommentDelete(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	commitSHA string,
+	commentID int64,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoReview, false)
+	if err != nil {
+		return err
+	}
+
+	comment, err := c.getCommitCommentCheckEditAccess(ctx, session, repo, commitSHA, commentID)
+	if err != nil {
+		return err
+	}
+
+	if comment.Deleted != nil {
+		return nil
+	}
+
+	now := time.Now().UnixMilli()
+	comment.Deleted = &now
+	comment.Updated = now
+	comment.Text = ""
+
+	if err = c.commitCommentStore.Update(ctx, comment); err != nil {
+		return fmt.Errorf("failed to mark commit comment as deleted: %w", err)
+	}
+
+	return nil
+}
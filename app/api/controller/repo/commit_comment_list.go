@@ -0,0 +1,136 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// CommitCommentList lists all comments made directly on a commit, the oldest first.
+func (c *Controller) CommitCommentList(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	commitSHA string,
+) ([]*types.CommitComment, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, err
+	}
+
+	commitSHA, err = c.resolveCommitSHA(ctx, repo, commitSHA)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.listCommitComments(ctx, repo, commitSHA)
+}
+
+// listCommitComments returns all comments of the commit with the author info populated.
+func (c *Controller) listCommitComments(ctx context.Context,
+	repo *types.Repository,
+	commitSHA string,
+) ([]*types.CommitComment, error) {
+	comments, err := c.commitCommentStore.List(ctx, repo.ID, commitSHA)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commit comments: %w", err)
+	}
+
+	if len(comments) == 0 {
+		return comments, nil
+	}
+
+	authorIDs := make([]int64, len(comments))
+	for i, comment := range comments {
+		authorIDs[i] = comment.CreatedBy
+	}
+
+	authors, err := c.principalInfoCache.Map(ctx, authorIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit comment authors: %w", err)
+	}
+
+	for _, comment := range comments {
+		if author, ok := authors[comment.CreatedBy]; ok {
+			comment.Author = *author
+		}
+	}
+
+	return comments, nil
+}
+
+// resolveCommitSHA verifies that the commit exists in the repository and returns its full SHA.
+func (c *Controller) resolveCommitSHA(ctx context.Context, repo *types.Repository, commitSHA string) (string, error) {
+	out, err := c.git.GetCommit(ctx, &git.GetCommitParams{
+		ReadParams: git.CreateReadParams(repo),
+		SHA:        commitSHA,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get commit: %w", err)
+	}
+
+	return out.Commit.SHA, nil
+}
+
+// getCommitComment returns the comment if it belongs to the commit in the repository.
+func (c *Controller) getCommitComment(ctx context.Context,
+	repo *types.Repository,
+	commitSHA string,
+	commentID int64,
+) (*types.CommitComment, error) {
+	comment, err := c.commitCommentStore.Find(ctx, commentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find commit comment: %w", err)
+	}
+
+	if comment.RepoID != repo.ID || comment.CommitSHA != commitSHA {
+		return nil, usererror.NotFound("Commit comment not found.")
+	}
+
+	return comment, nil
+}
+
+// getCommitCommentCheckEditAccess returns the comment if it belongs to the commit
+// and if the current principal is its author.
+func (c *Controller) getCommitCommentCheckEditAccess(ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	commitSHA string,
+	commentID int64,
+) (*types.CommitComment, error) {
+	commitSHA, err := c.resolveCommitSHA(ctx, repo, commitSHA)
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err := c.getCommitComment(ctx, repo, commitSHA, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if comment.CreatedBy != session.Principal.ID {
+		return nil, usererror.BadRequest("Only own comments may be updated.")
+	}
+
+	comment.Author = *session.Principal.ToPrincipalInfo()
+
+	return comment, nil
+}
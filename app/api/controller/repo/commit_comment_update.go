@@ -0,0 +1,89 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type CommitCommentUpdateInput struct {
+	Text string `json:"text"`
+}
+
+func (in *CommitCommentUpdateInput) sanitize() error {
+	if strings.TrimSpace(in.Text) == "" {
+		return usererror.BadRequest("Comment text can't be empty.")
+	}
+
+	return nil
+}
+
+// CommitCommentUpdate updates the text of a comment made directly on a commit.
+func (c *Controller) CommitCommentUpdate(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	commitSHA string,
+	commentID int64,
+	in *CommitCommentUpdateInput,
+) (*types.CommitComment, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoReview, false)
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err := c.getCommitCommentCheckEditAccess(ctx, session, repo, commitSHA, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if comment.Deleted != nil {
+		return nil, usererror.BadRequest("Deleted comments can't be updated.")
+	}
+
+	if comment.Text == in.Text {
+		return comment, nil
+	}
+
+	now := time.Now().UnixMilli()
+	comment.Text = in.Text
+	comment.Edited = now
+	comment.Updated = now
+
+	if err = c.commitCommentStore.Update(ctx, comment); err != nil {
+		return nil, fmt.Errorf("failed to update commit comment: %w", err)
+	}
+
+	c.eventReporter.CommitCommentUpdated(ctx, &repoevents.CommitCommentUpdatedPayload{
+		RepoID:      repo.ID,
+		PrincipalID: session.Principal.ID,
+		CommentID:   comment.ID,
+		CommitSHA:   comment.CommitSHA,
+	})
+
+	return comment, nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// maxCompareFiles is the maximum number of changed files returned by the compare API.
+const maxCompareFiles = 300
+
+// CompareOutput contains the result of the comparison of two revisions.
+type CompareOutput struct {
+	BaseRef string `json:"base_ref"`
+	HeadRef string `json:"head_ref"`
+	// MergeBase is true if the three-dot semantics was used, i.e. the diff is against the merge base.
+	MergeBase    bool   `json:"merge_base"`
+	BaseSHA      string `json:"base_sha"`
+	HeadSHA      string `json:"head_sha"`
+	MergeBaseSHA string `json:"merge_base_sha,omitempty"`
+
+	// Commits are the commits reachable from the head ref that aren't reachable from the base ref.
+	Commits []types.Commit  `json:"commits"`
+	Stats   types.DiffStats `json:"stats"`
+
+	// Files is the list of changed files without patches. FilesTruncated is set
+	// if there are more changed files than returned - use the diff API to fetch all of them.
+	Files          []git.FileDiff `json:"files"`
+	FilesTruncated bool           `json:"files_truncated"`
+}
+
+// Compare compares two revisions of the repository. The path is in the form "base..head" for the two-dot,
+// or in the form "base...head" for the three-dot (merge base) semantics.
+func (c *Controller) Compare(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	path string,
+	page int,
+	limit int,
+) (*CompareOutput, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := parseDiffPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	readParams := git.CreateReadParams(repo)
+
+	compareOut, err := c.git.Compare(ctx, &git.CompareParams{
+		ReadParams: readParams,
+		BaseRef:    info.BaseRef,
+		HeadRef:    info.HeadRef,
+		MergeBase:  info.MergeBase,
+		Page:       int32(page),
+		Limit:      int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	commits, err := c.mapCommits(ctx, repo, compareOut.Commits)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]git.FileDiff, 0)
+	reader := git.NewStreamReader(c.git.Diff(ctx, &git.DiffParams{
+		ReadParams: readParams,
+		BaseRef:    compareOut.BaseSHA,
+		HeadRef:    compareOut.HeadSHA,
+		MergeBase:  info.MergeBase,
+		MaxFiles:   maxCompareFiles,
+	}))
+	for {
+		file, err := reader.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("failed to read diff: %w", err)
+		}
+		files = append(files, *file)
+	}
+
+	return &CompareOutput{
+		BaseRef:      info.BaseRef,
+		HeadRef:      info.HeadRef,
+		MergeBase:    info.MergeBase,
+		BaseSHA:      compareOut.BaseSHA,
+		HeadSHA:      compareOut.HeadSHA,
+		MergeBaseSHA: compareOut.MergeBaseSHA,
+		Commits:      commits,
+		Stats: types.NewDiffStats(compareOut.TotalCommits, compareOut.Stats.Files,
+			compareOut.Stats.Additions, compareOut.Stats.Deletions),
+		Files:          files,
+		FilesTruncated: compareOut.Stats.Files > len(files),
+	}, nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ComplianceFind evaluates the required files policies of the repository and returns the result.
+func (c *Controller) ComplianceFind(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*types.RepoCompliance, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, false)
+	if err != nil {
+		return nil, err
+	}
+
+	compliance, err := c.compliance.Evaluate(ctx, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate repository compliance: %w", err)
+	}
+
+	return compliance, nil
+}
+
+// CompliancePullReq opens a pull request that adds the missing required files to the repository
+// using the templates of the required files policies.
+func (c *Controller) CompliancePullReq(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*types.PullReq, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush, false)
+	if err != nil {
+		return nil, err
+	}
+
+	pr, err := c.compliance.CreatePullReq(ctx, session, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create compliance pull request: %w", err)
+	}
+
+	if pr == nil {
+		return nil, usererror.BadRequest(
+			"No pull request was created. Either there are no missing files with a template " +
+				"or a compliance pull request is already open.")
+	}
+
+	return pr, nil
+}
@@ -19,6 +19,9 @@ import (
 	"encoding/base64"
 	"fmt"
 	"io"
+	"net/url"
+	"path"
+	"strings"
 
 	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/auth"
@@ -46,11 +49,22 @@ const (
 )
 
 type ContentInfo struct {
-	Type         ContentType   `json:"type"`
-	SHA          string        `json:"sha"`
-	Name         string        `json:"name"`
-	Path         string        `json:"path"`
-	LatestCommit *types.Commit `json:"latest_commit,omitempty"`
+	Type         ContentType    `json:"type"`
+	SHA          string         `json:"sha"`
+	Name         string         `json:"name"`
+	Path         string         `json:"path"`
+	LatestCommit *types.Commit  `json:"latest_commit,omitempty"`
+	Submodule    *SubmoduleInfo `json:"submodule,omitempty"`
+}
+
+// SubmoduleInfo describes the repository and the commit a submodule entry points to.
+type SubmoduleInfo struct {
+	// URL is the url of the submodule as defined in the .gitmodules file.
+	URL string `json:"url"`
+	// ResolvedURL is the absolute url of the submodule, it differs from URL only for relative urls,
+	// which are resolved against the git clone url of the repository.
+	ResolvedURL string `json:"resolved_url"`
+	CommitSHA   string `json:"commit_sha"`
 }
 
 type GetContentOutput struct {
@@ -64,10 +78,14 @@ type Content interface {
 }
 
 type FileContent struct {
-	Encoding enum.ContentEncodingType `json:"encoding"`
-	Data     string                   `json:"data"`
-	Size     int64                    `json:"size"`
-	DataSize int64                    `json:"data_size"`
+	ContentType string                   `json:"content_type"`
+	IsBinary    bool                     `json:"is_binary"`
+	Size        int64                    `json:"size"`
+	Encoding    enum.ContentEncodingType `json:"encoding"`
+	Data        string                   `json:"data"`
+	// DataSize is smaller than Size if the data is truncated,
+	// the rest of the file can be retrieved with a range request of the raw content.
+	DataSize int64 `json:"data_size"`
 }
 
 func (c *FileContent) isContent() {}
@@ -94,12 +112,14 @@ func (c *SubmoduleContent) isContent() {}
 
 // GetContent finds the content of the repo at the given path.
 // If no gitRef is provided, the content is retrieved from the default branch.
+// If includeEntryCommits is set, the entries of a directory contain their latest commits.
 func (c *Controller) GetContent(ctx context.Context,
 	session *auth.Session,
 	repoRef string,
 	gitRef string,
 	repoPath string,
 	includeLatestCommit bool,
+	includeEntryCommits bool,
 ) (*GetContentOutput, error) {
 	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
 	if err != nil {
@@ -132,13 +152,22 @@ func (c *Controller) GetContent(ctx context.Context,
 	var content Content
 	switch info.Type {
 	case ContentTypeDir:
-		content, err = c.getDirContent(ctx, readParams, gitRef, repoPath, includeLatestCommit)
+		content, err = c.getDirContent(ctx, repo, readParams, gitRef, repoPath, includeEntryCommits)
 	case ContentTypeFile:
-		content, err = c.getFileContent(ctx, readParams, info.SHA)
+		content, err = c.getFileContent(ctx, readParams, info.SHA, info.Path)
 	case ContentTypeSymlink:
 		content, err = c.getSymlinkContent(ctx, readParams, info.SHA)
 	case ContentTypeSubmodule:
-		content, err = c.getSubmoduleContent(ctx, readParams, gitRef, repoPath, info.SHA)
+		var submodule *SubmoduleContent
+		submodule, err = c.getSubmoduleContent(ctx, readParams, gitRef, repoPath, info.SHA)
+		if err == nil {
+			content = submodule
+			info.Submodule = &SubmoduleInfo{
+				URL:         submodule.URL,
+				ResolvedURL: resolveSubmoduleURL(c.urlProvider.GenerateGITCloneURL(repo.Path), submodule.URL),
+				CommitSHA:   submodule.CommitSHA,
+			}
+		}
 	default:
 		err = fmt.Errorf("unknown tree node type '%s'", treeNodeOutput.Node.Type)
 	}
@@ -178,6 +207,7 @@ func (c *Controller) getSubmoduleContent(ctx context.Context,
 func (c *Controller) getFileContent(ctx context.Context,
 	readParams git.ReadParams,
 	blobSHA string,
+	filePath string,
 ) (*FileContent, error) {
 	output, err := c.git.GetBlob(ctx, &git.GetBlobParams{
 		ReadParams: readParams,
@@ -200,10 +230,12 @@ func (c *Controller) getFileContent(ctx context.Context,
 	}
 
 	return &FileContent{
-		Size:     output.Size,
-		DataSize: output.ContentSize,
-		Encoding: enum.ContentEncodingTypeBase64,
-		Data:     base64.StdEncoding.EncodeToString(content),
+		ContentType: fileContentType(output.ContentType, filePath),
+		IsBinary:    output.IsBinary,
+		Size:        output.Size,
+		Encoding:    enum.ContentEncodingTypeBase64,
+		Data:        base64.StdEncoding.EncodeToString(content),
+		DataSize:    output.ContentSize,
 	}, nil
 }
 
@@ -239,16 +271,17 @@ func (c *Controller) getSymlinkContent(ctx context.Context,
 }
 
 func (c *Controller) getDirContent(ctx context.Context,
+	repo *types.Repository,
 	readParams git.ReadParams,
 	gitRef string,
 	repoPath string,
-	includeLatestCommit bool,
+	includeEntryCommits bool,
 ) (*DirContent, error) {
 	output, err := c.git.ListTreeNodes(ctx, &git.ListTreeNodeParams{
 		ReadParams:          readParams,
 		GitREF:              gitRef,
 		Path:                repoPath,
-		IncludeLatestCommit: includeLatestCommit,
+		IncludeLatestCommit: includeEntryCommits,
 	})
 	if err != nil {
 		// TODO: handle not found error
@@ -256,11 +289,19 @@ func (c *Controller) getDirContent(ctx context.Context,
 	}
 
 	entries := make([]ContentInfo, len(output.Nodes))
+	hasSubmodules := false
 	for i, node := range output.Nodes {
-		entries[i], err = mapToContentInfo(node, nil, false)
+		entries[i], err = mapToContentInfo(node, node.LastCommit, includeEntryCommits)
 		if err != nil {
 			return nil, err
 		}
+		hasSubmodules = hasSubmodules || entries[i].Type == ContentTypeSubmodule
+	}
+
+	if hasSubmodules {
+		if err = c.populateSubmodules(ctx, repo, readParams, gitRef, entries); err != nil {
+			return nil, err
+		}
 	}
 
 	return &DirContent{
@@ -268,6 +309,62 @@ func (c *Controller) getDirContent(ctx context.Context,
 	}, nil
 }
 
+// populateSubmodules sets the submodule info of the submodule entries from the .gitmodules file.
+func (c *Controller) populateSubmodules(ctx context.Context,
+	repo *types.Repository,
+	readParams git.ReadParams,
+	gitRef string,
+	entries []ContentInfo,
+) error {
+	output, err := c.git.ListSubmodules(ctx, &git.ListSubmodulesParams{
+		ReadParams: readParams,
+		GitREF:     gitRef,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list submodules: %w", err)
+	}
+
+	urls := make(map[string]string, len(output.Submodules))
+	for _, submodule := range output.Submodules {
+		urls[submodule.Path] = submodule.URL
+	}
+
+	cloneURL := c.urlProvider.GenerateGITCloneURL(repo.Path)
+
+	for i := range entries {
+		if entries[i].Type != ContentTypeSubmodule {
+			continue
+		}
+
+		// submodules without an entry in .gitmodules are still reported, but without the url.
+		submoduleURL := urls[entries[i].Path]
+		entries[i].Submodule = &SubmoduleInfo{
+			URL:         submoduleURL,
+			ResolvedURL: resolveSubmoduleURL(cloneURL, submoduleURL),
+			CommitSHA:   entries[i].SHA,
+		}
+	}
+
+	return nil
+}
+
+// resolveSubmoduleURL resolves the relative submodule url (starting with "./" or "../")
+// against the url of the repository, the same way git does it. Absolute urls are returned unchanged.
+func resolveSubmoduleURL(repoURL string, submoduleURL string) string {
+	if !strings.HasPrefix(submoduleURL, "./") && !strings.HasPrefix(submoduleURL, "../") {
+		return submoduleURL
+	}
+
+	base, err := url.Parse(repoURL)
+	if err != nil {
+		return submoduleURL
+	}
+
+	base.Path = path.Join(base.Path, submoduleURL)
+
+	return base.String()
+}
+
 func mapToContentInfo(node git.TreeNode, commit *git.Commit, includeLatestCommit bool) (ContentInfo, error) {
 	typ, err := mapNodeModeToContentType(node.Mode)
 	if err != nil {
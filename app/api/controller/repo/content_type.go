@@ -0,0 +1,63 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"mime"
+	"path"
+	"strings"
+)
+
+const (
+	contentTypeText   = "text/plain; charset=utf-8"
+	contentTypeBinary = "application/octet-stream"
+)
+
+// fileContentType returns the media type of a file. The type detected from the content of the file is refined
+// using the file extension if the content detection found only generic text or binary data (e.g. css, json, svg).
+func fileContentType(detected string, filePath string) string {
+	if detected != contentTypeBinary && !strings.HasPrefix(detected, "text/plain") {
+		return detected
+	}
+
+	if byExtension := mime.TypeByExtension(path.Ext(filePath)); byExtension != "" {
+		return byExtension
+	}
+
+	return detected
+}
+
+// rawContentType returns the media type used to serve the raw content of a file.
+// Only media types that browsers can't execute scripts in are served as they are,
+// all other files (e.g. html, svg, xml) are served as plain text or binary data.
+func rawContentType(contentType string, isBinary bool) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	switch {
+	case mediaType == "image/svg+xml":
+	case strings.HasPrefix(mediaType, "image/"),
+		strings.HasPrefix(mediaType, "audio/"),
+		strings.HasPrefix(mediaType, "video/"),
+		mediaType == "application/pdf",
+		mediaType == "text/plain":
+		return contentType
+	}
+
+	if isBinary {
+		return contentTypeBinary
+	}
+
+	return contentTypeText
+}
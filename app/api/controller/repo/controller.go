@@ -21,17 +21,30 @@ import (
 	"strings"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/api/controller/limiter"
+	"github.com/harness/gitness/app/api/controller/pullreq"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
 	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/services/autolink"
+	"github.com/harness/gitness/app/services/backup"
 	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/commitindex"
+	"github.com/harness/gitness/app/services/compliance"
+	"github.com/harness/gitness/app/services/gitusage"
 	"github.com/harness/gitness/app/services/importer"
 	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/services/mirror"
+	"github.com/harness/gitness/app/services/pipelinecache"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/repostats"
+	"github.com/harness/gitness/app/services/usergroup"
+	"github.com/harness/gitness/app/services/usersigning"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
@@ -45,25 +58,48 @@ var (
 
 type Controller struct {
 	defaultBranch                 string
+	deletionGuard                 controller.DeletionGuard
 	publicResourceCreationEnabled bool
 
-	tx                 dbtx.Transactor
-	urlProvider        url.Provider
-	uidCheck           check.PathUID
-	authorizer         authz.Authorizer
-	repoStore          store.RepoStore
-	spaceStore         store.SpaceStore
-	pipelineStore      store.PipelineStore
-	principalStore     store.PrincipalStore
-	ruleStore          store.RuleStore
-	principalInfoCache store.PrincipalInfoCache
-	protectionManager  *protection.Manager
-	git                git.Interface
-	importer           *importer.Repository
-	codeOwners         *codeowners.Service
-	eventReporter      *repoevents.Reporter
-	indexer            keywordsearch.Indexer
-	resourceLimiter    limiter.ResourceLimiter
+	tx                   dbtx.Transactor
+	urlProvider          url.Provider
+	uidCheck             check.PathUID
+	authorizer           authz.Authorizer
+	repoStore            store.RepoStore
+	spaceStore           store.SpaceStore
+	pipelineStore        store.PipelineStore
+	principalStore       store.PrincipalStore
+	pullreqStore         store.PullReqStore
+	ruleStore            store.RuleStore
+	mergeTemplateStore   store.MergeTemplateStore
+	signingKeyStore      store.SigningKeyStore
+	commitCommentStore   store.CommitCommentStore
+	autolinkStore        store.AutolinkStore
+	watchStore           store.WatchStore
+	defaultReviewerStore store.DefaultReviewerStore
+	repoAliasStore       store.RepoAliasStore
+	savedComparisonStore store.SavedComparisonStore
+	principalInfoCache   store.PrincipalInfoCache
+	protectionManager    *protection.Manager
+	git                  git.Interface
+	importer             *importer.Repository
+	codeOwners           *codeowners.Service
+	eventReporter        *repoevents.Reporter
+	indexer              keywordsearch.Indexer
+	resourceLimiter      limiter.ResourceLimiter
+	encrypter            encrypt.Encrypter
+	compliance           *compliance.Service
+	userSigning          *usersigning.Service
+	autolinks            *autolink.Service
+	userGroupResolver    usergroup.Resolver
+	gitUsage             *gitusage.Recorder
+	pipelineCache        *pipelinecache.Service
+	repoStats            *repostats.Service
+	commitIndex          *commitindex.Service
+	mirrors              *mirror.Service
+	backup               *backup.Service
+	pullreqCtrl          *pullreq.Controller
+	archiveMaxSize       int64
 }
 
 func NewController(
@@ -76,7 +112,16 @@ func NewController(
 	spaceStore store.SpaceStore,
 	pipelineStore store.PipelineStore,
 	principalStore store.PrincipalStore,
+	pullreqStore store.PullReqStore,
 	ruleStore store.RuleStore,
+	mergeTemplateStore store.MergeTemplateStore,
+	signingKeyStore store.SigningKeyStore,
+	commitCommentStore store.CommitCommentStore,
+	autolinkStore store.AutolinkStore,
+	watchStore store.WatchStore,
+	defaultReviewerStore store.DefaultReviewerStore,
+	repoAliasStore store.RepoAliasStore,
+	savedComparisonStore store.SavedComparisonStore,
 	principalInfoCache store.PrincipalInfoCache,
 	protectionManager *protection.Manager,
 	git git.Interface,
@@ -85,9 +130,22 @@ func NewController(
 	eventReporter *repoevents.Reporter,
 	indexer keywordsearch.Indexer,
 	limiter limiter.ResourceLimiter,
+	encrypter encrypt.Encrypter,
+	compliance *compliance.Service,
+	userSigning *usersigning.Service,
+	autolinks *autolink.Service,
+	userGroupResolver usergroup.Resolver,
+	gitUsage *gitusage.Recorder,
+	pipelineCache *pipelinecache.Service,
+	repoStats *repostats.Service,
+	commitIndex *commitindex.Service,
+	mirrors *mirror.Service,
+	backup *backup.Service,
+	pullreqCtrl *pullreq.Controller,
 ) *Controller {
 	return &Controller{
 		defaultBranch:                 config.Git.DefaultBranch,
+		deletionGuard:                 controller.NewDeletionGuard(config),
 		publicResourceCreationEnabled: config.PublicResourceCreationEnabled,
 		tx:                            tx,
 		urlProvider:                   urlProvider,
@@ -97,7 +155,16 @@ func NewController(
 		spaceStore:                    spaceStore,
 		pipelineStore:                 pipelineStore,
 		principalStore:                principalStore,
+		pullreqStore:                  pullreqStore,
 		ruleStore:                     ruleStore,
+		mergeTemplateStore:            mergeTemplateStore,
+		signingKeyStore:               signingKeyStore,
+		commitCommentStore:            commitCommentStore,
+		autolinkStore:                 autolinkStore,
+		watchStore:                    watchStore,
+		defaultReviewerStore:          defaultReviewerStore,
+		repoAliasStore:                repoAliasStore,
+		savedComparisonStore:          savedComparisonStore,
 		principalInfoCache:            principalInfoCache,
 		protectionManager:             protectionManager,
 		git:                           git,
@@ -106,6 +173,19 @@ func NewController(
 		eventReporter:                 eventReporter,
 		indexer:                       indexer,
 		resourceLimiter:               limiter,
+		encrypter:                     encrypter,
+		compliance:                    compliance,
+		userSigning:                   userSigning,
+		autolinks:                     autolinks,
+		userGroupResolver:             userGroupResolver,
+		gitUsage:                      gitUsage,
+		pipelineCache:                 pipelineCache,
+		repoStats:                     repoStats,
+		commitIndex:                   commitIndex,
+		mirrors:                       mirrors,
+		backup:                        backup,
+		pullreqCtrl:                   pullreqCtrl,
+		archiveMaxSize:                config.Git.ArchiveMaxSize,
 	}
 }
 
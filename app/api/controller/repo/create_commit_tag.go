@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/git"
@@ -38,6 +39,14 @@ type CreateCommitTagInput struct {
 	// the tag will be lightweight, otherwise it'll be annotated.
 	Message string `json:"message"`
 
+	// GenerateReleaseNotes appends release notes generated from the pull requests merged
+	// since the previous tag to the message, which makes the tag annotated.
+	GenerateReleaseNotes bool `json:"generate_release_notes"`
+
+	// Sign signs the tag with the signing key of the user (if instance-managed user signing keys are enabled),
+	// or the signing key of the server otherwise. Only annotated tags can be signed.
+	Sign bool `json:"sign"`
+
 	BypassRules bool `json:"bypass_rules"`
 }
 
@@ -78,19 +87,49 @@ func (c *Controller) CreateCommitTag(ctx context.Context,
 		return nil, violations, nil
 	}
 
+	message := in.Message
+	if in.GenerateReleaseNotes {
+		notes, err := c.generateReleaseNotes(ctx, repo, &ReleaseNotesGenerateInput{
+			TagName: in.Name,
+			Target:  in.Target,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to generate release notes: %w", err)
+		}
+
+		if message != "" {
+			message += "\n\n"
+		}
+		message += notes.Body
+	}
+
+	if in.Sign && message == "" {
+		return nil, nil, usererror.BadRequest("Only annotated tags can be signed, the tag message is required.")
+	}
+
 	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, repo)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
 	}
 
+	var signingKey *git.SigningKey
+	if in.Sign {
+		signingKey, err = c.userSigning.SigningKey(ctx, session.Principal.ID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get user signing key: %w", err)
+		}
+	}
+
 	now := time.Now()
 	rpcOut, err := c.git.CreateCommitTag(ctx, &git.CreateCommitTagParams{
 		WriteParams: writeParams,
 		Name:        in.Name,
 		Target:      in.Target,
-		Message:     in.Message,
+		Message:     message,
 		Tagger:      identityFromPrincipal(session.Principal),
 		TaggerDate:  &now,
+		Sign:        in.Sign,
+		SigningKey:  signingKey,
 	})
 	if err != nil {
 		return nil, nil, err
@@ -101,5 +140,11 @@ func (c *Controller) CreateCommitTag(ctx context.Context,
 		return nil, nil, fmt.Errorf("failed to map tag received from service output: %w", err)
 	}
 
+	verifications, err := c.userSigning.VerifyTags(ctx, []git.CommitTag{rpcOut.CommitTag})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to verify tag signature: %w", err)
+	}
+	commitTag.Verification = verifications[0]
+
 	return &commitTag, nil, nil
 }
@@ -0,0 +1,134 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/usergroup"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type DefaultReviewerCreateInput struct {
+	PrincipalID    *int64              `json:"principal_id"`
+	UserGroupID    string              `json:"user_group_id"`
+	TargetBranches *protection.Pattern `json:"target_branches"`
+}
+
+func (in *DefaultReviewerCreateInput) sanitize() error {
+	if (in.PrincipalID == nil) == (in.UserGroupID == "") {
+		return usererror.BadRequest("Either a principal ID or a user group ID must be provided.")
+	}
+
+	if in.PrincipalID != nil && *in.PrincipalID <= 0 {
+		return usererror.BadRequest("A valid principal ID must be provided.")
+	}
+
+	if in.TargetBranches != nil {
+		if err := in.TargetBranches.Validate(); err != nil {
+			return usererror.BadRequestf("Invalid target branch pattern: %s", err)
+		}
+	}
+
+	return nil
+}
+
+// DefaultReviewerCreate adds a user or a user group to the default reviewers of the repository.
+func (c *Controller) DefaultReviewerCreate(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *DefaultReviewerCreateInput,
+) (*types.DefaultReviewer, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var principalInfo *types.PrincipalInfo
+
+	if in.PrincipalID != nil {
+		principal, err := c.principalStore.Find(ctx, *in.PrincipalID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find default reviewer principal: %w", err)
+		}
+
+		if err = apiauth.CheckRepo(ctx, c.authorizer, &auth.Session{
+			Principal: *principal,
+		}, repo, enum.PermissionRepoReview, false); err != nil {
+			log.Ctx(ctx).Info().Msgf("Default reviewer principal: %s access error: %s", principal.UID, err)
+			return nil, usererror.BadRequest("The reviewer doesn't have enough permissions for the repository.")
+		}
+
+		principalInfo = principal.ToPrincipalInfo()
+	} else {
+		_, err = c.userGroupResolver.Resolve(ctx, in.UserGroupID)
+		if errors.Is(err, usergroup.ErrNotFound) {
+			return nil, usererror.BadRequestf("User group %q not found.", in.UserGroupID)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve user group: %w", err)
+		}
+	}
+
+	now := time.Now().UnixMilli()
+	reviewer := &types.DefaultReviewer{
+		RepoID:      repo.ID,
+		PrincipalID: in.PrincipalID,
+		UserGroupID: in.UserGroupID,
+		CreatedBy:   session.Principal.ID,
+		Created:     now,
+		Updated:     now,
+		Principal:   principalInfo,
+	}
+
+	if in.TargetBranches != nil {
+		reviewer.TargetBranches = in.TargetBranches.JSON()
+	}
+
+	existing, err := c.defaultReviewerStore.List(ctx, repo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list default reviewers: %w", err)
+	}
+
+	for _, r := range existing {
+		if r.UserGroupID == reviewer.UserGroupID &&
+			(r.PrincipalID == nil) == (reviewer.PrincipalID == nil) &&
+			(r.PrincipalID == nil || *r.PrincipalID == *reviewer.PrincipalID) &&
+			string(r.TargetBranches) == string(reviewer.TargetBranches) {
+			return nil, usererror.Conflict("The default reviewer already exists.")
+		}
+	}
+
+	err = c.defaultReviewerStore.Create(ctx, reviewer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create default reviewer: %w", err)
+	}
+
+	return reviewer, nil
+}
@@ -0,0 +1,53 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types/enum"
+)
+
+// DefaultReviewerDelete removes the default reviewer from the repository.
+// Pull requests which already have the reviewer assigned are not affected.
+func (c *Controller) DefaultReviewerDelete(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	id int64,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return err
+	}
+
+	reviewer, err := c.defaultReviewerStore.Find(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to find default reviewer: %w", err)
+	}
+
+	if reviewer.RepoID != repo.ID {
+		return usererror.ErrNotFound
+	}
+
+	err = c.defaultReviewerStore.Delete(ctx, reviewer.ID)
+	if err != nil {
+		return fmt.Errorf("failed to delete default reviewer: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// DefaultReviewerList returns the default reviewers of the repository.
+func (c *Controller) DefaultReviewerList(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) ([]*types.DefaultReviewer, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, false)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewers, err := c.defaultReviewerStore.List(ctx, repo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list default reviewers: %w", err)
+	}
+
+	principalIDs := make([]int64, 0, len(reviewers))
+	for _, reviewer := range reviewers {
+		if reviewer.PrincipalID != nil {
+			principalIDs = append(principalIDs, *reviewer.PrincipalID)
+		}
+	}
+
+	principalInfos, err := c.principalInfoCache.Map(ctx, principalIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get principal infos: %w", err)
+	}
+
+	for _, reviewer := range reviewers {
+		if reviewer.PrincipalID != nil {
+			reviewer.Principal = principalInfos[*reviewer.PrincipalID]
+		}
+	}
+
+	return reviewers, nil
+}
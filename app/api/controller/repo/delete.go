@@ -31,7 +31,13 @@ import (
 )
 
 // Delete deletes a repo.
-func (c *Controller) Delete(ctx context.Context, session *auth.Session, repoRef string) error {
+// Deleting a large or active repository requires the confirmation token returned by the first call.
+func (c *Controller) Delete(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	confirmationToken string,
+) error {
 	// note: can't use c.getRepoCheckAccess because import job for repositories being imported must be cancelled.
 	repo, err := c.repoStore.FindByRef(ctx, repoRef)
 	if err != nil {
@@ -42,6 +48,14 @@ func (c *Controller) Delete(ctx context.Context, session *auth.Session, repoRef
 		return err
 	}
 
+	impact := types.DeletionImpact{}
+	impact.Add(repo)
+
+	err = c.deletionGuard.Check(session, enum.ResourceTypeRepo, repo.ID, impact, confirmationToken)
+	if err != nil {
+		return err
+	}
+
 	log.Ctx(ctx).Info().
 		Int64("repo.id", repo.ID).
 		Str("repo.path", repo.Path).
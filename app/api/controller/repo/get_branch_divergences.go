@@ -0,0 +1,95 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"strings"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types/enum"
+)
+
+type GetBranchDivergencesInput struct {
+	// Base is the ref against which the divergences are calculated.
+	// If the value is empty the divergences are calculated against the default branch of the repo.
+	Base     string   `json:"base"`
+	Branches []string `json:"branches"`
+}
+
+// BranchDivergence contains the count of diverging commits between a branch and the base ref.
+// If the branch doesn't exist both counts are -1.
+type BranchDivergence struct {
+	Branch string `json:"branch"`
+	// Ahead is the count of commits the branch is ahead of the base ref.
+	Ahead int32 `json:"ahead"`
+	// Behind is the count of commits the branch is behind the base ref.
+	Behind int32 `json:"behind"`
+}
+
+// GetBranchDivergences returns the ahead/behind counts of a set of branches relative to a base ref.
+func (c *Controller) GetBranchDivergences(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *GetBranchDivergencesInput,
+) ([]BranchDivergence, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// if no branches were provided return an empty list
+	if in == nil || len(in.Branches) == 0 {
+		return []BranchDivergence{}, nil
+	}
+
+	if len(in.Branches) > request.PerPageMax {
+		return nil, usererror.ErrRequestTooLarge
+	}
+
+	for _, branch := range in.Branches {
+		if strings.TrimSpace(branch) == "" {
+			return nil, usererror.BadRequest("Branch names can't be empty.")
+		}
+	}
+
+	base := in.Base
+	if base == "" {
+		base = repo.DefaultBranch
+	}
+
+	rpcOutput, err := c.git.GetBranchDivergences(ctx, &git.GetBranchDivergencesParams{
+		ReadParams: git.CreateReadParams(repo),
+		BaseRef:    base,
+		Branches:   in.Branches,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	divergences := make([]BranchDivergence, len(rpcOutput.Divergences))
+	for i := range rpcOutput.Divergences {
+		divergences[i] = BranchDivergence{
+			Branch: rpcOutput.Divergences[i].Branch,
+			Ahead:  rpcOutput.Divergences[i].Ahead,
+			Behind: rpcOutput.Divergences[i].Behind,
+		}
+	}
+
+	return divergences, nil
+}
@@ -50,5 +50,23 @@ func (c *Controller) GetCommit(ctx context.Context,
 		return nil, fmt.Errorf("failed to map commit: %w", err)
 	}
 
+	verifications, err := c.userSigning.VerifyCommits(ctx, []git.Commit{rpcCommit})
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify commit signature: %w", err)
+	}
+	commit.Verification = verifications[0]
+
+	commit.Comments, err = c.listCommitComments(ctx, repo, rpcCommit.SHA)
+	if err != nil {
+		return nil, err
+	}
+
+	linker, err := c.autolinks.ForRepository(ctx, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load autolinks: %w", err)
+	}
+
+	commit.Autolinks = linker.Resolve(commit.Message)
+
 	return commit, nil
 }
@@ -18,10 +18,12 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
 
@@ -48,10 +50,13 @@ func (c *Controller) GitServicePack(
 		return fmt.Errorf("failed to verify repo access: %w", err)
 	}
 
+	in := &countingReader{r: r}
+	out := &countingWriter{w: w}
+
 	params := &git.ServicePackParams{
 		// TODO: git shouldn't take a random string here, but instead have accepted enum values.
 		Service:     string(service),
-		Data:        r,
+		Data:        in,
 		Options:     nil,
 		GitProtocol: gitProtocol,
 	}
@@ -69,9 +74,56 @@ func (c *Controller) GitServicePack(
 		params.ReadParams = &readParams
 	}
 
-	if err = c.git.ServicePack(ctx, w, params); err != nil {
+	started := time.Now()
+
+	output, err := c.git.ServicePack(ctx, out, params)
+
+	// account the usage also for failed invocations (e.g. killed or aborted by the client).
+	usage := &types.GitUsage{
+		RepoID:        repo.ID,
+		Service:       service,
+		Invocations:   1,
+		CPUTime:       (output.UserTime + output.SystemTime).Milliseconds(),
+		WallTime:      time.Since(started).Milliseconds(),
+		BytesReceived: in.n,
+		BytesSent:     out.n,
+	}
+	if session != nil {
+		usage.PrincipalID = session.Principal.ID
+	}
+	c.gitUsage.Record(ctx, usage)
+
+	if output.Negotiation != nil {
+		c.gitUsage.RecordNegotiation(ctx, repo.ID, output.Negotiation)
+	}
+
+	if err != nil {
 		return fmt.Errorf("failed service pack operation %q  on git: %w", service, err)
 	}
 
 	return nil
 }
+
+// countingReader counts the bytes read from the underlying reader.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	return n, err
+}
+
+// countingWriter counts the bytes written to the underlying writer.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.n += int64(n)
+	return n, err
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/services/importer"
 	"github.com/harness/gitness/errors"
@@ -33,6 +34,9 @@ type ImportInput struct {
 	ProviderRepo string            `json:"provider_repo"`
 
 	Pipelines importer.PipelineOption `json:"pipelines"`
+
+	// Metadata defines which repository metadata (pull requests, webhooks) is imported besides the git data.
+	Metadata importer.MetadataOptions `json:"metadata"`
 }
 
 // Import creates a new empty repository and starts git import to it from a remote repository.
@@ -47,6 +51,10 @@ func (c *Controller) Import(ctx context.Context, session *auth.Session, in *Impo
 		return nil, fmt.Errorf("failed to sanitize input: %w", err)
 	}
 
+	if err = controller.CheckRepoUIDNotAliased(ctx, c.repoAliasStore, parentSpace.ID, in.UID); err != nil {
+		return nil, err
+	}
+
 	if err := c.resourceLimiter.RepoCount(ctx, 1); err != nil {
 		return nil, errors.PreconditionFailed(err.Error())
 	}
@@ -70,7 +78,8 @@ func (c *Controller) Import(ctx context.Context, session *auth.Session, in *Impo
 			return fmt.Errorf("failed to create repository in storage: %w", err)
 		}
 
-		err = c.importer.Run(ctx, provider, repo, remoteRepository.CloneURL, in.Pipelines)
+		err = c.importer.Run(ctx, provider, repo, remoteRepository.CloneURL, in.Pipelines,
+			in.ProviderRepo, in.Metadata)
 		if err != nil {
 			return fmt.Errorf("failed to start import repository job: %w", err)
 		}
@@ -99,5 +108,11 @@ func (c *Controller) sanitizeImportInput(in *ImportInput) error {
 		in.Pipelines = importer.PipelineOptionConvert
 	}
 
+	if !in.Metadata.IsEmpty() {
+		if err := importer.CheckMetadataSupport(in.Provider); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
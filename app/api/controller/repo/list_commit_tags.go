@@ -33,6 +33,12 @@ type CommitTag struct {
 	Message     string           `json:"message,omitempty"`
 	Tagger      *types.Signature `json:"tagger,omitempty"`
 	Commit      *types.Commit    `json:"commit,omitempty"`
+
+	// Signature is the armored signature of the tag (empty if the tag isn't signed).
+	Signature string `json:"signature,omitempty"`
+
+	// Verification is the result of the verification of the tag signature (nil if the tag isn't signed).
+	Verification *types.CommitVerification `json:"verification,omitempty"`
 }
 
 // ListCommitTags lists the commit tags of a repo.
@@ -60,12 +66,18 @@ func (c *Controller) ListCommitTags(ctx context.Context,
 		return nil, err
 	}
 
+	verifications, err := c.userSigning.VerifyTags(ctx, rpcOut.Tags)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify tag signatures: %w", err)
+	}
+
 	tags := make([]CommitTag, len(rpcOut.Tags))
 	for i := range rpcOut.Tags {
 		tags[i], err = mapCommitTag(rpcOut.Tags[i])
 		if err != nil {
 			return nil, fmt.Errorf("failed to map CommitTag: %w", err)
 		}
+		tags[i].Verification = verifications[i]
 	}
 
 	return tags, nil
@@ -104,6 +116,11 @@ func mapCommitTag(t git.CommitTag) (CommitTag, error) {
 		}
 	}
 
+	var signature string
+	if t.Signature != nil {
+		signature = t.Signature.Signature
+	}
+
 	return CommitTag{
 		Name:        t.Name,
 		SHA:         t.SHA,
@@ -112,5 +129,6 @@ func mapCommitTag(t git.CommitTag) (CommitTag, error) {
 		Message:     t.Message,
 		Tagger:      tagger,
 		Commit:      commit,
+		Signature:   signature,
 	}, nil
 }
@@ -57,14 +57,9 @@ func (c *Controller) ListCommits(ctx context.Context,
 		return types.ListCommitResponse{}, err
 	}
 
-	commits := make([]types.Commit, len(rpcOut.Commits))
-	for i := range rpcOut.Commits {
-		var commit *types.Commit
-		commit, err = controller.MapCommit(&rpcOut.Commits[i])
-		if err != nil {
-			return types.ListCommitResponse{}, fmt.Errorf("failed to map commit: %w", err)
-		}
-		commits[i] = *commit
+	commits, err := c.mapCommits(ctx, repo, rpcOut.Commits)
+	if err != nil {
+		return types.ListCommitResponse{}, err
 	}
 
 	renameDetailList := make([]types.RenameDetails, len(rpcOut.RenameDetails))
@@ -81,3 +76,34 @@ func (c *Controller) ListCommits(ctx context.Context,
 		TotalCommits:  rpcOut.TotalCommits,
 	}, nil
 }
+
+// mapCommits maps the git commits to API commits with their signature verification and autolinks.
+func (c *Controller) mapCommits(
+	ctx context.Context,
+	repo *types.Repository,
+	gitCommits []git.Commit,
+) ([]types.Commit, error) {
+	verifications, err := c.userSigning.VerifyCommits(ctx, gitCommits)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify commit signatures: %w", err)
+	}
+
+	linker, err := c.autolinks.ForRepository(ctx, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load autolinks: %w", err)
+	}
+
+	commits := make([]types.Commit, len(gitCommits))
+	for i := range gitCommits {
+		var commit *types.Commit
+		commit, err = controller.MapCommit(&gitCommits[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to map commit: %w", err)
+		}
+		commit.Verification = verifications[i]
+		commit.Autolinks = linker.Resolve(commit.Message)
+		commits[i] = *commit
+	}
+
+	return commits, nil
+}
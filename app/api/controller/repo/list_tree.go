@@ -0,0 +1,127 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type ListTreeOutput struct {
+	// CommitSHA is the commit the listed tree belongs to, all pages of a listing are from the same commit.
+	CommitSHA string        `json:"commit_sha"`
+	Entries   []ContentInfo `json:"entries"`
+	// NextCursor is set if there are more entries, it should be provided as the cursor to get the next page.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// ListTree lists a page of the entries of the directory at the given path.
+// In recursive mode the entries of all subdirectories are listed too, each directory right before its content.
+// If no gitRef is provided, the tree is listed for the default branch.
+// The cursor pins the commit of the first page, so the gitRef is ignored once a cursor is provided.
+func (c *Controller) ListTree(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	gitRef string,
+	repoPath string,
+	filter *types.TreeFilter,
+) (*ListTreeOutput, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var after string
+	if filter.Cursor != "" {
+		gitRef, after, err = decodeTreeCursor(filter.Cursor)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// set gitRef to default branch in case an empty reference was provided
+	if gitRef == "" {
+		gitRef = repo.DefaultBranch
+	}
+
+	readParams := git.CreateReadParams(repo)
+
+	output, err := c.git.ListTree(ctx, &git.ListTreeParams{
+		ReadParams: readParams,
+		GitREF:     gitRef,
+		Path:       repoPath,
+		Recursive:  filter.Recursive,
+		MaxDepth:   filter.MaxDepth,
+		Patterns:   filter.Include,
+		After:      after,
+		Limit:      filter.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]ContentInfo, len(output.Nodes))
+	hasSubmodules := false
+	for i, node := range output.Nodes {
+		entries[i], err = mapToContentInfo(node, nil, false)
+		if err != nil {
+			return nil, err
+		}
+		hasSubmodules = hasSubmodules || entries[i].Type == ContentTypeSubmodule
+	}
+
+	if hasSubmodules {
+		if err = c.populateSubmodules(ctx, repo, readParams, output.CommitSHA, entries); err != nil {
+			return nil, err
+		}
+	}
+
+	var nextCursor string
+	if output.HasMore && len(entries) > 0 {
+		nextCursor = encodeTreeCursor(output.CommitSHA, entries[len(entries)-1].Path)
+	}
+
+	return &ListTreeOutput{
+		CommitSHA:  output.CommitSHA,
+		Entries:    entries,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// encodeTreeCursor creates an opaque cursor from the listed commit and the path of the last listed entry.
+func encodeTreeCursor(commitSHA string, path string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(commitSHA + ":" + path))
+}
+
+func decodeTreeCursor(cursor string) (string, string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", "", usererror.BadRequest("Invalid cursor.")
+	}
+
+	commitSHA, path, ok := strings.Cut(string(raw), ":")
+	if !ok || !git.ValidateCommitSHA(commitSHA) || path == "" {
+		return "", "", usererror.BadRequest("Invalid cursor.")
+	}
+
+	return commitSHA, path, nil
+}
@@ -0,0 +1,47 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types/enum"
+)
+
+// MergeTemplateDelete deletes the merge template of the repository for the merge method.
+func (c *Controller) MergeTemplateDelete(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	method enum.MergeMethod,
+) error {
+	method, err := sanitizeMergeTemplateMethod(method)
+	if err != nil {
+		return err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return err
+	}
+
+	err = c.mergeTemplateStore.Delete(ctx, repo.ID, method)
+	if err != nil {
+		return fmt.Errorf("failed to delete merge template: %w", err)
+	}
+
+	return nil
+}
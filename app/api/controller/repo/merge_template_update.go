@@ -0,0 +1,108 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/mergetemplate"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type MergeTemplateUpdateInput struct {
+	Title   string `json:"title"`
+	Message string `json:"message"`
+}
+
+func (in *MergeTemplateUpdateInput) sanitize() error {
+	in.Title = strings.TrimSpace(in.Title)
+	in.Message = strings.TrimSpace(in.Message)
+
+	if in.Title == "" && in.Message == "" {
+		return usererror.BadRequest("Merge template title or message must be provided")
+	}
+
+	if strings.ContainsAny(in.Title, "\r\n") {
+		return usererror.BadRequest("Merge template title must be a single line")
+	}
+
+	if multiLine := mergetemplate.MultiLinePlaceholders(in.Title); len(multiLine) > 0 {
+		return usererror.BadRequestf("Merge template title doesn't support placeholders: %s",
+			strings.Join(multiLine, ", "))
+	}
+
+	if unknown := mergetemplate.UnknownPlaceholders(in.Title + in.Message); len(unknown) > 0 {
+		return usererror.BadRequestf("Unsupported merge template placeholders: %s", strings.Join(unknown, ", "))
+	}
+
+	return nil
+}
+
+// sanitizeMergeTemplateMethod checks if the merge method supports merge templates.
+// Only merge methods which create a new commit can have a template.
+func sanitizeMergeTemplateMethod(method enum.MergeMethod) (enum.MergeMethod, error) {
+	method, ok := method.Sanitize()
+	if !ok || (method != enum.MergeMethodMerge && method != enum.MergeMethodSquash) {
+		return "", usererror.BadRequestf("Merge templates are not supported for merge method %q", method)
+	}
+
+	return method, nil
+}
+
+// MergeTemplateUpdate creates or updates the merge template of the repository for the merge method.
+func (c *Controller) MergeTemplateUpdate(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	method enum.MergeMethod,
+	in *MergeTemplateUpdateInput,
+) (*types.MergeTemplate, error) {
+	method, err := sanitizeMergeTemplateMethod(method)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	tmpl := &types.MergeTemplate{
+		RepoID:    repo.ID,
+		Method:    method,
+		Title:     in.Title,
+		Message:   in.Message,
+		CreatedBy: session.Principal.ID,
+		Created:   now,
+		Updated:   now,
+	}
+
+	err = c.mergeTemplateStore.Upsert(ctx, tmpl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upsert merge template: %w", err)
+	}
+
+	return tmpl, nil
+}
@@ -0,0 +1,130 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/mirror"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type MirrorCreateInput struct {
+	RemoteURL string `json:"remote_url"`
+	Username  string `json:"username"`
+	Password  string `json:"password"`
+	// SyncInterval is the number of seconds between two synchronizations (optional).
+	SyncInterval int64 `json:"sync_interval"`
+	Disabled     bool  `json:"disabled"`
+}
+
+type MirrorUpdateInput struct {
+	RemoteURL    *string `json:"remote_url"`
+	Username     *string `json:"username"`
+	Password     *string `json:"password"`
+	SyncInterval *int64  `json:"sync_interval"`
+	Disabled     *bool   `json:"disabled"`
+}
+
+// FindMirror returns the mirror configuration and the synchronization status of the repository.
+func (c *Controller) FindMirror(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*types.RepositoryMirror, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.mirrors.Find(ctx, repo.ID)
+}
+
+// CreateMirror turns the empty repository into a read-only mirror of a remote repository.
+func (c *Controller) CreateMirror(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *MirrorCreateInput,
+) (*types.RepositoryMirror, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.mirrors.Create(ctx, repo, &session.Principal, mirror.Settings{
+		RemoteURL:    in.RemoteURL,
+		Username:     in.Username,
+		Password:     &in.Password,
+		SyncInterval: in.SyncInterval,
+		Disabled:     in.Disabled,
+	})
+}
+
+// UpdateMirror updates the mirror configuration of the repository.
+func (c *Controller) UpdateMirror(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *MirrorUpdateInput,
+) (*types.RepositoryMirror, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.mirrors.Update(ctx, repo.ID, func(settings *mirror.Settings) {
+		if in.RemoteURL != nil {
+			settings.RemoteURL = *in.RemoteURL
+		}
+		if in.Username != nil {
+			settings.Username = *in.Username
+		}
+		if in.Password != nil {
+			settings.Password = in.Password
+		}
+		if in.SyncInterval != nil {
+			settings.SyncInterval = *in.SyncInterval
+		}
+		if in.Disabled != nil {
+			settings.Disabled = *in.Disabled
+		}
+	})
+}
+
+// DeleteMirror stops mirroring the remote repository. The repository keeps its content and becomes writable.
+func (c *Controller) DeleteMirror(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return err
+	}
+
+	return c.mirrors.Delete(ctx, repo.ID)
+}
+
+// SyncMirror schedules the synchronization of the mirror repository with its remote repository.
+func (c *Controller) SyncMirror(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return err
+	}
+
+	return c.mirrors.Sync(ctx, repo)
+}
@@ -16,11 +16,13 @@ package repo
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
@@ -68,14 +70,28 @@ func (c *Controller) Move(ctx context.Context,
 		return nil, fmt.Errorf("failed to sanitize input: %w", err)
 	}
 
-	repo, err = c.repoStore.UpdateOptLock(ctx, repo, func(r *types.Repository) error {
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
 		if in.UID != nil {
-			r.UID = *in.UID
+			// renaming a repository to one of its own aliases replaces the alias.
+			if err := c.deleteOwnAlias(ctx, repo, *in.UID); err != nil {
+				return err
+			}
 		}
+
+		repo, err = c.repoStore.UpdateOptLock(ctx, repo, func(r *types.Repository) error {
+			if in.UID != nil {
+				r.UID = *in.UID
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update repo: %w", err)
+		}
+
 		return nil
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to update repo: %w", err)
+		return nil, err
 	}
 
 	repo.GitURL = c.urlProvider.GenerateGITCloneURL(repo.Path)
@@ -83,6 +99,28 @@ func (c *Controller) Move(ctx context.Context,
 	return repo, nil
 }
 
+// deleteOwnAlias deletes the alias of the repository with the given uid.
+// It fails if the uid is used as an alias of another repository.
+func (c *Controller) deleteOwnAlias(ctx context.Context, repo *types.Repository, uid string) error {
+	alias, err := c.repoAliasStore.FindByUID(ctx, repo.ParentID, uid)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find repository alias: %w", err)
+	}
+
+	if alias.RepoID != repo.ID {
+		return usererror.Conflict(fmt.Sprintf("The identifier %q is used as an alias of another repository.", alias.UID))
+	}
+
+	if err = c.repoAliasStore.Delete(ctx, alias.ID); err != nil {
+		return fmt.Errorf("failed to delete repository alias: %w", err)
+	}
+
+	return nil
+}
+
 func (c *Controller) sanitizeMoveInput(in *MoveInput) error {
 	if in.UID != nil {
 		if err := c.uidCheck(*in.UID, false); err != nil {
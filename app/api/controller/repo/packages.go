@@ -0,0 +1,133 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Packages lists the packages a repository provides and requires.
+// Package names are prefixed with their ecosystem, e.g. "go:github.com/harness/gitness".
+type Packages struct {
+	Provides []string
+	Requires []string
+}
+
+// packageNameParser returns the name of the package declared by a manifest file.
+type packageNameParser func(content []byte) (string, error)
+
+// packageManifests contains the dependency manifest files that declare the name of the package,
+// keyed by file name. Only those manifests can be used to find dependencies between repositories.
+var packageManifests = map[string]packageNameParser{
+	"go.mod":        parseGoModModule,
+	"package.json":  parseJSONName,
+	"composer.json": parseJSONName,
+}
+
+// PackagesNoAuth returns the packages declared by the dependency manifest files in the root
+// of the default branch of the repository WITHOUT checking for PermissionRepoView.
+func (c *Controller) PackagesNoAuth(ctx context.Context, repo *types.Repository) (*Packages, error) {
+	provides := map[string]struct{}{}
+	requires := map[string]struct{}{}
+
+	for fileName, parseName := range packageManifests {
+		manifest := dependencyManifests[fileName]
+
+		content, err := c.readManifestFile(ctx, repo, repo.DefaultBranch, fileName)
+		if errors.IsNotFound(err) {
+			// the default branch doesn't exist (yet)
+			return &Packages{Provides: []string{}, Requires: []string{}}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if content == nil {
+			continue
+		}
+
+		name, err := parseName(content)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to parse package name of manifest %q", fileName)
+			continue
+		}
+
+		dependencies, err := manifest.parse(content)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to parse dependency manifest %q", fileName)
+			continue
+		}
+
+		if name != "" {
+			provides[manifest.ecosystem+":"+name] = struct{}{}
+		}
+
+		for dependency := range dependencies {
+			requires[manifest.ecosystem+":"+dependency] = struct{}{}
+		}
+	}
+
+	return &Packages{
+		Provides: sortedKeys(provides),
+		Requires: sortedKeys(requires),
+	}, nil
+}
+
+func sortedKeys(m map[string]struct{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// parseGoModModule returns the module path of a go.mod file.
+func parseGoModModule(content []byte) (string, error) {
+	for _, line := range strings.Split(string(content), "\n") {
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = line[:i]
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && fields[0] == "module" {
+			return strings.Trim(fields[1], `"`), nil
+		}
+	}
+
+	return "", nil
+}
+
+// parseJSONName returns the package name of a json manifest file.
+func parseJSONName(content []byte) (string, error) {
+	var manifest struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return "", fmt.Errorf("failed to unmarshal manifest: %w", err)
+	}
+
+	return manifest.Name, nil
+}
@@ -0,0 +1,153 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/pipelinecache"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const maxPipelineCacheKeyLength = 512
+
+// PipelineCacheList lists the build caches of the repository.
+func (c *Controller) PipelineCacheList(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	filter *types.ListQueryFilter,
+) ([]*types.PipelineCache, int64, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, false)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	caches, count, err := c.pipelineCache.List(ctx, repo.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list pipeline caches: %w", err)
+	}
+
+	return caches, count, nil
+}
+
+// PipelineCacheDelete deletes a build cache of the repository.
+func (c *Controller) PipelineCacheDelete(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	cacheID int64,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return err
+	}
+
+	cache, err := c.pipelineCache.Find(ctx, repo.ID, cacheID)
+	if err != nil {
+		return fmt.Errorf("failed to find pipeline cache: %w", err)
+	}
+
+	if err = c.pipelineCache.Delete(ctx, cache); err != nil {
+		return fmt.Errorf("failed to delete pipeline cache: %w", err)
+	}
+
+	return nil
+}
+
+// PipelineCacheSave stores the content as the build cache of the repository with the provided key.
+// Saving caches requires push access, which pipeline executions of the repository have.
+func (c *Controller) PipelineCacheSave(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	key string,
+	content io.Reader,
+) (*types.PipelineCache, error) {
+	if err := checkPipelineCacheKey(key); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush, false)
+	if err != nil {
+		return nil, err
+	}
+
+	cache, err := c.pipelineCache.Save(ctx, repo.ID, session.Principal.ID, key, content)
+	if errors.Is(err, pipelinecache.ErrTooLarge) {
+		return nil, usererror.RequestTooLargef("The cache exceeds the maximum cache size.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to save pipeline cache: %w", err)
+	}
+
+	return cache, nil
+}
+
+// PipelineCacheRestore returns the build cache of the repository with the provided key.
+// If there's no such cache, the most recent cache with a key starting with one of the restore keys is returned.
+// The caller is responsible for closing the returned reader.
+func (c *Controller) PipelineCacheRestore(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	key string,
+	restoreKeys []string,
+) (*types.PipelineCache, io.ReadCloser, error) {
+	if err := checkPipelineCacheKey(key); err != nil {
+		return nil, nil, err
+	}
+
+	for _, restoreKey := range restoreKeys {
+		if err := checkPipelineCacheKey(restoreKey); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cache, content, err := c.pipelineCache.Restore(ctx, repo.ID, key, restoreKeys)
+	if errors.Is(err, pipelinecache.ErrNotFound) {
+		return nil, nil, usererror.NotFound("No matching cache found.")
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to restore pipeline cache: %w", err)
+	}
+
+	return cache, content, nil
+}
+
+func checkPipelineCacheKey(key string) error {
+	if key == "" {
+		return usererror.BadRequest("Cache key must be provided.")
+	}
+
+	if len(key) > maxPipelineCacheKeyLength {
+		return usererror.BadRequestf("Cache key can't be longer than %d characters.", maxPipelineCacheKeyLength)
+	}
+
+	if strings.IndexFunc(key, unicode.IsControl) >= 0 {
+		return usererror.BadRequest("Cache key can't contain control characters.")
+	}
+
+	return nil
+}
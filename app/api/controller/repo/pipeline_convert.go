@@ -0,0 +1,141 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/pipeline/convert"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// maxPipelineConvertSize is the maximum size of a pipeline definition that can be converted.
+const maxPipelineConvertSize = 65536
+
+// PipelineConvertInput is used to convert a pipeline definition of another CI system.
+type PipelineConvertInput struct {
+	// Format is the format of the definition. If empty, it's detected from the path.
+	Format enum.PipelineFormat `json:"format"`
+	// Data is the definition. If empty, the file at the path is converted.
+	Data string `json:"data"`
+	// Path is the path of the definition in the repository, e.g. ".github/workflows/build.yml".
+	Path string `json:"path"`
+	// GitRef is the git reference the file is read from. If empty, the default branch is used.
+	GitRef string `json:"git_ref"`
+}
+
+// PipelineConvert converts a GitHub Actions workflow or a GitLab CI configuration to a gitness pipeline.
+// Constructs that can't be converted aren't returned as an error, but as part of the conversion result.
+func (c *Controller) PipelineConvert(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *PipelineConvertInput,
+) (*types.PipelineConversion, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, err
+	}
+
+	format := in.Format
+	if format == "" {
+		var ok bool
+		if format, ok = convert.Detect(in.Path); !ok {
+			return nil, usererror.BadRequest("The pipeline format must be provided.")
+		}
+	}
+	if _, ok := format.Sanitize(); !ok {
+		return nil, usererror.BadRequestf("Unsupported pipeline format %q.", format)
+	}
+
+	data := []byte(in.Data)
+	if len(data) == 0 {
+		if in.Path == "" {
+			return nil, usererror.BadRequest("Either the pipeline definition or its path must be provided.")
+		}
+
+		data, err = c.readPipelineFile(ctx, repo, in.GitRef, in.Path)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(data) > maxPipelineConvertSize {
+		return nil, usererror.BadRequestf("The pipeline definition exceeds the maximum size of %d bytes.",
+			maxPipelineConvertSize)
+	}
+
+	result, err := convert.Convert(format, data)
+	if err != nil {
+		return nil, usererror.BadRequestf("Failed to convert the pipeline: %s.", err)
+	}
+
+	return result, nil
+}
+
+func (c *Controller) readPipelineFile(
+	ctx context.Context,
+	repo *types.Repository,
+	gitRef string,
+	filePath string,
+) ([]byte, error) {
+	if gitRef == "" {
+		gitRef = repo.DefaultBranch
+	}
+
+	readParams := git.CreateReadParams(repo)
+	node, err := c.git.GetTreeNode(ctx, &git.GetTreeNodeParams{
+		ReadParams: readParams,
+		GitREF:     gitRef,
+		Path:       filePath,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tree node: %w", err)
+	}
+
+	if node.Node.Type != git.TreeNodeTypeBlob {
+		return nil, usererror.BadRequestf("Object at '/%s' isn't a file.", filePath)
+	}
+
+	blob, err := c.git.GetBlob(ctx, &git.GetBlobParams{
+		ReadParams: readParams,
+		SHA:        node.Node.SHA,
+		SizeLimit:  maxPipelineConvertSize + 1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blob: %w", err)
+	}
+
+	defer func() {
+		if err := blob.Content.Close(); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to close blob content reader")
+		}
+	}()
+
+	data, err := io.ReadAll(blob.Content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blob content: %w", err)
+	}
+
+	return data, nil
+}
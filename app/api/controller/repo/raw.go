@@ -25,17 +25,34 @@ import (
 	"github.com/harness/gitness/types/enum"
 )
 
+// RawOutput contains the (partial) raw content of a file.
+type RawOutput struct {
+	Data io.ReadCloser
+	// Size is the size of the whole file.
+	Size int64
+	// Offset is the position of the first byte of the data in the file.
+	Offset int64
+	// DataSize is the number of bytes returned by Data.
+	DataSize int64
+	// ContentType is the media type the content should be served with.
+	ContentType string
+	IsBinary    bool
+}
+
 // Raw finds the file of the repo at the given path and returns its raw content.
 // If no gitRef is provided, the content is retrieved from the default branch.
+// The content starts at the provided offset, and if the length is positive, it contains at most length bytes.
 func (c *Controller) Raw(ctx context.Context,
 	session *auth.Session,
 	repoRef string,
 	gitRef string,
 	repoPath string,
-) (io.ReadCloser, int64, error) {
+	offset int64,
+	length int64,
+) (*RawOutput, error) {
 	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
 
 	// set gitRef to default branch in case an empty reference was provided
@@ -52,12 +69,12 @@ func (c *Controller) Raw(ctx context.Context,
 		IncludeLatestCommit: false,
 	})
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to read tree node: %w", err)
+		return nil, fmt.Errorf("failed to read tree node: %w", err)
 	}
 
 	// viewing Raw content is only supported for blob content
 	if treeNodeOutput.Node.Type != git.TreeNodeTypeBlob {
-		return nil, 0, usererror.BadRequestf(
+		return nil, usererror.BadRequestf(
 			"Object in '%s' at '/%s' is of type '%s'. Only objects of type %s support raw viewing.",
 			gitRef, repoPath, treeNodeOutput.Node.Type, git.TreeNodeTypeBlob)
 	}
@@ -65,11 +82,19 @@ func (c *Controller) Raw(ctx context.Context,
 	blobReader, err := c.git.GetBlob(ctx, &git.GetBlobParams{
 		ReadParams: readParams,
 		SHA:        treeNodeOutput.Node.SHA,
-		SizeLimit:  0, // no size limit, we stream whatever data there is
+		SizeLimit:  length, // no size limit unless requested, we stream whatever data there is
+		Offset:     offset,
 	})
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to read blob: %w", err)
+		return nil, fmt.Errorf("failed to read blob: %w", err)
 	}
 
-	return blobReader.Content, blobReader.ContentSize, nil
+	return &RawOutput{
+		Data:        blobReader.Content,
+		Size:        blobReader.Size,
+		Offset:      offset,
+		DataSize:    blobReader.ContentSize,
+		ContentType: rawContentType(fileContentType(blobReader.ContentType, repoPath), blobReader.IsBinary),
+		IsBinary:    blobReader.IsBinary,
+	}, nil
 }
@@ -0,0 +1,109 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type ReleaseChanges struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+
+	Commits []types.Commit `json:"commits"`
+	// CommitsTruncated is true if there are more commits between the releases than returned.
+	CommitsTruncated bool `json:"commits_truncated"`
+
+	PullReqs          []*types.PullReq           `json:"pull_reqs"`
+	DependencyChanges []DependencyManifestChange `json:"dependency_changes"`
+}
+
+// ReleaseChanges returns the commits, merged pull requests and dependency changes between two releases.
+// The release range is in the format "<from-tag>...<to-tag>".
+func (c *Controller) ReleaseChanges(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	releaseRange string,
+) (*ReleaseChanges, error) {
+	from, to, ok := strings.Cut(releaseRange, "...")
+	if !ok || from == "" || to == "" {
+		return nil, usererror.BadRequestf("Invalid release range %q, expected format is <from>...<to>.", releaseRange)
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, err
+	}
+
+	fromSHA, err := c.resolveRefCommitSHA(ctx, repo, gitReferenceNamePrefixTag+from)
+	if err != nil {
+		return nil, err
+	}
+
+	toSHA, err := c.resolveRefCommitSHA(ctx, repo, gitReferenceNamePrefixTag+to)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcOut, err := c.git.ListCommits(ctx, &git.ListCommitsParams{
+		ReadParams: git.CreateReadParams(repo),
+		GitREF:     toSHA,
+		After:      fromSHA,
+		Limit:      releaseNotesMaxCommits,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list release commits: %w", err)
+	}
+
+	commits := make([]types.Commit, len(rpcOut.Commits))
+	shas := make([]string, len(rpcOut.Commits))
+	for i := range rpcOut.Commits {
+		commit, err := controller.MapCommit(&rpcOut.Commits[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to map commit: %w", err)
+		}
+
+		commits[i] = *commit
+		shas[i] = commit.SHA
+	}
+
+	pullReqs, err := c.listMergedPullReqs(ctx, repo, shas)
+	if err != nil {
+		return nil, err
+	}
+
+	dependencyChanges, err := c.dependencyChanges(ctx, repo, fromSHA, toSHA)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReleaseChanges{
+		From:              from,
+		To:                to,
+		Commits:           commits,
+		CommitsTruncated:  len(commits) >= releaseNotesMaxCommits,
+		PullReqs:          pullReqs,
+		DependencyChanges: dependencyChanges,
+	}, nil
+}
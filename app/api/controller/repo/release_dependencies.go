@@ -0,0 +1,345 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/harness/gitness/git"
+	gittypes "github.com/harness/gitness/git/types"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// dependencyManifestMaxSize is the maximum size of a manifest file that is parsed for dependency changes.
+const dependencyManifestMaxSize = 1 << 20 // 1 MB
+
+type DependencyManifestChange struct {
+	Path      string `json:"path"`
+	Ecosystem string `json:"ecosystem"`
+	// Dependencies lists the changed dependencies of the manifest.
+	// It's empty for manifest formats that aren't parsed, only the change of the file is reported then.
+	Dependencies []DependencyChange `json:"dependencies"`
+}
+
+type DependencyChange struct {
+	Name        string                `json:"name"`
+	Change      enum.DependencyChange `json:"change"`
+	FromVersion string                `json:"from_version,omitempty"`
+	ToVersion   string                `json:"to_version,omitempty"`
+}
+
+// dependencyManifestParser returns the dependencies of a manifest file mapped to their version (constraint).
+type dependencyManifestParser func(content []byte) (map[string]string, error)
+
+type dependencyManifest struct {
+	ecosystem string
+	parse     dependencyManifestParser
+}
+
+// dependencyManifests contains the known dependency manifest files, keyed by file name.
+var dependencyManifests = map[string]dependencyManifest{
+	"go.mod":           {ecosystem: "go", parse: parseGoMod},
+	"package.json":     {ecosystem: "npm", parse: parsePackageJSON},
+	"composer.json":    {ecosystem: "composer", parse: parseComposerJSON},
+	"requirements.txt": {ecosystem: "pip", parse: parseRequirementsTxt},
+	"pyproject.toml":   {ecosystem: "pip"},
+	"Pipfile":          {ecosystem: "pip"},
+	"Cargo.toml":       {ecosystem: "cargo"},
+	"pom.xml":          {ecosystem: "maven"},
+	"build.gradle":     {ecosystem: "gradle"},
+	"build.gradle.kts": {ecosystem: "gradle"},
+	"Gemfile":          {ecosystem: "bundler"},
+}
+
+// dependencyChanges returns the changes of the dependency manifest files between two commits.
+func (c *Controller) dependencyChanges(
+	ctx context.Context,
+	repo *types.Repository,
+	fromSHA string,
+	toSHA string,
+) ([]DependencyManifestChange, error) {
+	diffOut, err := c.git.DiffFileNames(ctx, &git.DiffParams{
+		ReadParams: git.CreateReadParams(repo),
+		BaseRef:    fromSHA,
+		HeadRef:    toSHA,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list changed files: %w", err)
+	}
+
+	changes := make([]DependencyManifestChange, 0)
+
+	for _, filePath := range diffOut.Files {
+		manifest, ok := dependencyManifests[path.Base(filePath)]
+		if !ok {
+			continue
+		}
+
+		change := DependencyManifestChange{
+			Path:         filePath,
+			Ecosystem:    manifest.ecosystem,
+			Dependencies: []DependencyChange{},
+		}
+
+		if manifest.parse != nil {
+			dependencies, err := c.manifestDependencyChanges(ctx, repo, manifest.parse, filePath, fromSHA, toSHA)
+			if err != nil {
+				return nil, err
+			}
+
+			change.Dependencies = dependencies
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+func (c *Controller) manifestDependencyChanges(
+	ctx context.Context,
+	repo *types.Repository,
+	parse dependencyManifestParser,
+	filePath string,
+	fromSHA string,
+	toSHA string,
+) ([]DependencyChange, error) {
+	parseAt := func(sha string) (map[string]string, error) {
+		content, err := c.readManifestFile(ctx, repo, sha, filePath)
+		if err != nil || content == nil {
+			return map[string]string{}, err
+		}
+
+		return parse(content)
+	}
+
+	fromDependencies, err := parseAt(fromSHA)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to parse dependency manifest %q at %s", filePath, fromSHA)
+		return []DependencyChange{}, nil
+	}
+
+	toDependencies, err := parseAt(toSHA)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to parse dependency manifest %q at %s", filePath, toSHA)
+		return []DependencyChange{}, nil
+	}
+
+	return diffDependencies(fromDependencies, toDependencies), nil
+}
+
+// readManifestFile returns the content of the file at the provided commit,
+// or nil if the file doesn't exist there or is too big to be parsed.
+func (c *Controller) readManifestFile(
+	ctx context.Context,
+	repo *types.Repository,
+	sha string,
+	filePath string,
+) ([]byte, error) {
+	readParams := git.CreateReadParams(repo)
+
+	node, err := c.git.GetTreeNode(ctx, &git.GetTreeNodeParams{
+		ReadParams: readParams,
+		GitREF:     sha,
+		Path:       filePath,
+	})
+	if gittypes.IsPathNotFoundError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tree node of %q: %w", filePath, err)
+	}
+
+	if node.Node.Mode != git.TreeNodeModeFile {
+		return nil, nil
+	}
+
+	output, err := c.git.GetBlob(ctx, &git.GetBlobParams{
+		ReadParams: readParams,
+		SHA:        node.Node.SHA,
+		SizeLimit:  dependencyManifestMaxSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blob of %q: %w", filePath, err)
+	}
+
+	defer func() {
+		if err := output.Content.Close(); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to close blob content reader.")
+		}
+	}()
+
+	if output.Size > dependencyManifestMaxSize {
+		return nil, nil
+	}
+
+	content, err := io.ReadAll(output.Content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blob content of %q: %w", filePath, err)
+	}
+
+	return content, nil
+}
+
+// diffDependencies compares two sets of dependencies and returns the changes sorted by dependency name.
+func diffDependencies(from, to map[string]string) []DependencyChange {
+	changes := make([]DependencyChange, 0)
+
+	for name, toVersion := range to {
+		fromVersion, ok := from[name]
+		switch {
+		case !ok:
+			changes = append(changes, DependencyChange{
+				Name:      name,
+				Change:    enum.DependencyChangeAdded,
+				ToVersion: toVersion,
+			})
+		case fromVersion != toVersion:
+			changes = append(changes, DependencyChange{
+				Name:        name,
+				Change:      enum.DependencyChangeUpdated,
+				FromVersion: fromVersion,
+				ToVersion:   toVersion,
+			})
+		}
+	}
+
+	for name, fromVersion := range from {
+		if _, ok := to[name]; !ok {
+			changes = append(changes, DependencyChange{
+				Name:        name,
+				Change:      enum.DependencyChangeRemoved,
+				FromVersion: fromVersion,
+			})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Name < changes[j].Name
+	})
+
+	return changes
+}
+
+// parseGoMod returns the required modules of a go.mod file.
+func parseGoMod(content []byte) (map[string]string, error) {
+	dependencies := map[string]string{}
+	inRequireBlock := false
+
+	for _, line := range strings.Split(string(content), "\n") {
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = line[:i]
+		}
+
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 0:
+		case inRequireBlock && fields[0] == ")":
+			inRequireBlock = false
+		case inRequireBlock && len(fields) >= 2:
+			dependencies[strings.Trim(fields[0], `"`)] = fields[1]
+		case fields[0] == "require" && len(fields) == 2 && fields[1] == "(":
+			inRequireBlock = true
+		case fields[0] == "require" && len(fields) >= 3:
+			dependencies[strings.Trim(fields[1], `"`)] = fields[2]
+		}
+	}
+
+	return dependencies, nil
+}
+
+// parsePackageJSON returns the dependencies of a package.json file.
+func parsePackageJSON(content []byte) (map[string]string, error) {
+	return parseJSONDependencies(content,
+		"dependencies", "devDependencies", "peerDependencies", "optionalDependencies")
+}
+
+// parseComposerJSON returns the dependencies of a composer.json file.
+func parseComposerJSON(content []byte) (map[string]string, error) {
+	return parseJSONDependencies(content, "require", "require-dev")
+}
+
+// parseJSONDependencies returns the dependencies listed in the provided sections of a json manifest file.
+// If a dependency is listed in multiple sections, the version of the first section is used.
+func parseJSONDependencies(content []byte, sections ...string) (map[string]string, error) {
+	var manifest map[string]json.RawMessage
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal manifest: %w", err)
+	}
+
+	dependencies := map[string]string{}
+
+	for _, section := range sections {
+		raw, ok := manifest[section]
+		if !ok {
+			continue
+		}
+
+		var sectionDependencies map[string]string
+		if err := json.Unmarshal(raw, &sectionDependencies); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal manifest section %q: %w", section, err)
+		}
+
+		for name, version := range sectionDependencies {
+			if _, ok := dependencies[name]; !ok {
+				dependencies[name] = version
+			}
+		}
+	}
+
+	return dependencies, nil
+}
+
+// requirementRegexp matches a requirement specifier of a requirements.txt file, e.g. "requests[security]>=2.8.1".
+var requirementRegexp = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)\s*(\[[^\]]*\])?\s*(.*)$`)
+
+// parseRequirementsTxt returns the requirements of a requirements.txt file.
+// Options (e.g. "-r other.txt") and environment markers are ignored.
+func parseRequirementsTxt(content []byte) (map[string]string, error) {
+	dependencies := map[string]string{}
+
+	for _, line := range strings.Split(string(content), "\n") {
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		if i := strings.Index(line, ";"); i >= 0 {
+			line = line[:i]
+		}
+
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "-") {
+			continue
+		}
+
+		m := requirementRegexp.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+
+		dependencies[strings.ToLower(m[1])] = strings.ReplaceAll(m[3], " ", "")
+	}
+
+	return dependencies, nil
+}
@@ -0,0 +1,341 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/autolink"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	gitReferenceNamePrefixTag = "refs/tags/"
+
+	// releaseNotesMaxCommits is the maximum number of commits between two tags scanned for merged pull requests.
+	releaseNotesMaxCommits = 1000
+
+	// releaseNotesMaxTags is the maximum number of recent tags examined when looking for the previous tag.
+	releaseNotesMaxTags = 50
+
+	// releaseNotesMergeSHABatch is the number of merge SHAs looked up in one database query.
+	releaseNotesMergeSHABatch = 500
+)
+
+type ReleaseNotesGenerateInput struct {
+	// TagName is the tag of the release. It doesn't have to exist yet.
+	TagName string `json:"tag_name"`
+
+	// Target is used as the release commit if the tag doesn't exist yet.
+	// If not provided, the default branch of the repository is used.
+	Target string `json:"target"`
+
+	// PreviousTagName is the tag of the previous release.
+	// If not provided, the most recent tag reachable from the release commit is used.
+	PreviousTagName string `json:"previous_tag_name"`
+}
+
+func (in *ReleaseNotesGenerateInput) sanitize() error {
+	in.TagName = strings.TrimSpace(in.TagName)
+	in.Target = strings.TrimSpace(in.Target)
+	in.PreviousTagName = strings.TrimSpace(in.PreviousTagName)
+
+	if in.TagName == "" {
+		return usererror.BadRequest("Tag name must be provided.")
+	}
+
+	if in.TagName == in.PreviousTagName {
+		return usererror.BadRequest("The previous tag must be different from the release tag.")
+	}
+
+	return nil
+}
+
+type ReleaseNotes struct {
+	TagName         string `json:"tag_name"`
+	PreviousTagName string `json:"previous_tag_name,omitempty"`
+	Body            string `json:"body"`
+}
+
+// ReleaseNotesGenerate generates markdown release notes from the pull requests merged between two tags.
+func (c *Controller) ReleaseNotesGenerate(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *ReleaseNotesGenerateInput,
+) (*ReleaseNotes, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.generateReleaseNotes(ctx, repo, in)
+}
+
+func (c *Controller) generateReleaseNotes(ctx context.Context,
+	repo *types.Repository,
+	in *ReleaseNotesGenerateInput,
+) (*ReleaseNotes, error) {
+	headSHA, err := c.resolveRefCommitSHA(ctx, repo, gitReferenceNamePrefixTag+in.TagName)
+	if errors.IsNotFound(err) {
+		target := in.Target
+		if target == "" {
+			target = repo.DefaultBranch
+		}
+
+		headSHA, err = c.resolveRefCommitSHA(ctx, repo, target)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	previousTagName := in.PreviousTagName
+	var previousSHA string
+
+	if previousTagName != "" {
+		previousSHA, err = c.resolveRefCommitSHA(ctx, repo, gitReferenceNamePrefixTag+previousTagName)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		previousTagName, previousSHA, err = c.findPreviousTag(ctx, repo, in.TagName, headSHA)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	commits, err := c.git.ListCommits(ctx, &git.ListCommitsParams{
+		ReadParams: git.CreateReadParams(repo),
+		GitREF:     headSHA,
+		After:      previousSHA,
+		Limit:      releaseNotesMaxCommits,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list release commits: %w", err)
+	}
+
+	shas := make([]string, len(commits.Commits))
+	for i := range commits.Commits {
+		shas[i] = commits.Commits[i].SHA
+	}
+
+	pullReqs, err := c.listMergedPullReqs(ctx, repo, shas)
+	if err != nil {
+		return nil, err
+	}
+
+	linker, err := c.autolinks.ForRepository(ctx, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load autolinks: %w", err)
+	}
+
+	return &ReleaseNotes{
+		TagName:         in.TagName,
+		PreviousTagName: previousTagName,
+		Body: renderReleaseNotes(pullReqs, in.TagName, previousTagName, linker, func(number int64) string {
+			return c.urlProvider.GenerateUIPRURL(repo.Path, number)
+		}),
+	}, nil
+}
+
+// listMergedPullReqs returns the pull requests of the repository that were merged with one of the provided commits.
+func (c *Controller) listMergedPullReqs(
+	ctx context.Context,
+	repo *types.Repository,
+	shas []string,
+) ([]*types.PullReq, error) {
+	pullReqs := make([]*types.PullReq, 0)
+	for len(shas) > 0 {
+		n := releaseNotesMergeSHABatch
+		if n > len(shas) {
+			n = len(shas)
+		}
+
+		prs, err := c.pullreqStore.ListByMergeSHAs(ctx, repo.ID, shas[:n])
+		if err != nil {
+			return nil, fmt.Errorf("failed to list merged pull requests: %w", err)
+		}
+
+		pullReqs = append(pullReqs, prs...)
+		shas = shas[n:]
+	}
+
+	return pullReqs, nil
+}
+
+// resolveRefCommitSHA returns the SHA of the commit the git reference points to.
+func (c *Controller) resolveRefCommitSHA(ctx context.Context, repo *types.Repository, ref string) (string, error) {
+	out, err := c.git.ListCommits(ctx, &git.ListCommitsParams{
+		ReadParams: git.CreateReadParams(repo),
+		GitREF:     ref,
+		Limit:      1,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve git reference %q: %w", ref, err)
+	}
+
+	if len(out.Commits) == 0 {
+		return "", errors.NotFound("git reference %q not found", ref)
+	}
+
+	return out.Commits[0].SHA, nil
+}
+
+// findPreviousTag returns the most recent tag, other than the release tag, that is reachable from the release commit.
+// If there is no such tag, empty strings are returned and the release notes cover the whole history.
+func (c *Controller) findPreviousTag(ctx context.Context,
+	repo *types.Repository,
+	tagName string,
+	headSHA string,
+) (string, string, error) {
+	tags, err := c.git.ListCommitTags(ctx, &git.ListCommitTagsParams{
+		ReadParams:    git.CreateReadParams(repo),
+		IncludeCommit: true,
+		Sort:          git.TagSortOptionDate,
+		Order:         git.SortOrderDesc,
+		Page:          1,
+		PageSize:      releaseNotesMaxTags,
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	for _, tag := range tags.Tags {
+		if tag.Name == tagName || tag.Commit == nil || tag.Commit.SHA == headSHA {
+			continue
+		}
+
+		out, err := c.git.IsAncestor(ctx, git.IsAncestorParams{
+			ReadParams:          git.CreateReadParams(repo),
+			AncestorCommitSHA:   tag.Commit.SHA,
+			DescendantCommitSHA: headSHA,
+		})
+		if err != nil {
+			return "", "", fmt.Errorf("failed to check if tag %q is an ancestor: %w", tag.Name, err)
+		}
+
+		if out.Ancestor {
+			return tag.Name, tag.Commit.SHA, nil
+		}
+	}
+
+	return "", "", nil
+}
+
+// releaseNotesTitleRegexp matches conventional pull request titles, e.g. "feat(api)!: Add something".
+var releaseNotesTitleRegexp = regexp.MustCompile(`^(\w+)(\([^)]*\))?(!)?:\s*(.+)$`)
+
+type releaseNotesSection struct {
+	title   string
+	entries []string
+}
+
+// renderReleaseNotes renders markdown release notes. Pull requests are grouped
+// by the type prefix of their title (e.g. "feat:", "fix:"), authors are credited
+// for each pull request and listed as contributors at the end.
+// References in the titles are linked using the autolink rules of the repository.
+func renderReleaseNotes(
+	pullReqs []*types.PullReq,
+	tagName string,
+	previousTagName string,
+	linker *autolink.Linker,
+	prURL func(number int64) string,
+) string {
+	sections := []*releaseNotesSection{
+		{title: "Breaking Changes"},
+		{title: "Features"},
+		{title: "Bug Fixes"},
+		{title: "Performance"},
+		{title: "Documentation"},
+		{title: "Other Changes"},
+	}
+	sectionByType := map[string]*releaseNotesSection{
+		"feat":    sections[1],
+		"feature": sections[1],
+		"fix":     sections[2],
+		"bugfix":  sections[2],
+		"perf":    sections[3],
+		"docs":    sections[4],
+		"doc":     sections[4],
+	}
+
+	var contributors []string
+	seenContributors := map[int64]struct{}{}
+
+	for _, pr := range pullReqs {
+		section := sections[len(sections)-1]
+		title := strings.TrimSpace(pr.Title)
+
+		if m := releaseNotesTitleRegexp.FindStringSubmatch(title); m != nil {
+			if s, ok := sectionByType[strings.ToLower(m[1])]; ok {
+				section = s
+			}
+			if m[3] != "" {
+				section = sections[0]
+			}
+			title = m[4]
+		}
+
+		section.entries = append(section.entries, fmt.Sprintf("- %s ([#%d](%s)) by @%s",
+			linker.Markdown(title), pr.Number, prURL(pr.Number), pr.Author.UID))
+
+		if _, ok := seenContributors[pr.Author.ID]; !ok {
+			seenContributors[pr.Author.ID] = struct{}{}
+			contributors = append(contributors, "@"+pr.Author.UID)
+		}
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString("## What's Changed\n")
+
+	if len(pullReqs) == 0 {
+		sb.WriteString("\nNo pull requests were merged in this release.\n")
+	}
+
+	for _, section := range sections {
+		if len(section.entries) == 0 {
+			continue
+		}
+
+		sb.WriteString("\n### ")
+		sb.WriteString(section.title)
+		sb.WriteString("\n\n")
+		sb.WriteString(strings.Join(section.entries, "\n"))
+		sb.WriteString("\n")
+	}
+
+	if len(contributors) > 0 {
+		sb.WriteString("\n## Contributors\n\n")
+		sb.WriteString(strings.Join(contributors, ", "))
+		sb.WriteString("\n")
+	}
+
+	if previousTagName != "" {
+		sb.WriteString(fmt.Sprintf("\n**Full Changelog**: %s...%s\n", previousTagName, tagName))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,215 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// RevertCommitInput holds the data for reverting a commit.
+type RevertCommitInput struct {
+	// Branch is the branch the revert commit is created on (default: the default branch of the repo).
+	Branch string `json:"branch"`
+	// NewBranch is the optional name of a new branch that receives the revert commit instead of Branch.
+	NewBranch string `json:"new_branch"`
+	// Mainline is the parent number of a merge commit that is used as the base of the revert.
+	Mainline int `json:"mainline"`
+
+	Title   string `json:"title"`
+	Message string `json:"message"`
+
+	// OpenPullRequest opens a pull request from the new branch to the branch instead of updating the branch.
+	OpenPullRequest bool `json:"open_pull_request"`
+	IsDraft         bool `json:"is_draft"`
+
+	DryRunRules bool `json:"dry_run_rules"`
+	BypassRules bool `json:"bypass_rules"`
+}
+
+func (in *RevertCommitInput) sanitize(repo *types.Repository, commit *git.Commit) error {
+	in.Branch = strings.TrimSpace(in.Branch)
+	if in.Branch == "" {
+		in.Branch = repo.DefaultBranch
+	}
+
+	in.NewBranch = strings.TrimSpace(in.NewBranch)
+	if in.NewBranch == "" && in.OpenPullRequest {
+		in.NewBranch = fmt.Sprintf("revert-%s", commit.SHA[:7])
+	}
+
+	if in.NewBranch == in.Branch {
+		return usererror.BadRequest("The new branch must be different from the branch.")
+	}
+
+	if in.Mainline < 0 {
+		return usererror.BadRequest("Mainline can't be negative.")
+	}
+
+	in.Title = strings.TrimSpace(in.Title)
+	if in.Title == "" {
+		in.Title = fmt.Sprintf("Revert %q", commit.Title)
+	}
+
+	in.Message = strings.TrimSpace(in.Message)
+	if in.Message == "" {
+		in.Message = fmt.Sprintf("This reverts commit %s.", commit.SHA)
+	}
+
+	return nil
+}
+
+// RevertCommit creates a commit that reverts the changes of the provided commit on top of a branch.
+// If the commit can't be reverted cleanly, a precondition failed error listing the conflicting files is returned.
+// If requested, a pull request is opened from the new branch, the new branch is deleted if that fails.
+func (c *Controller) RevertCommit(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	commitSHA string,
+	in *RevertCommitInput,
+) (types.RevertCommitResponse, []types.RuleViolations, error) {
+	requiredPermission := enum.PermissionRepoPush
+	if in.DryRunRules {
+		requiredPermission = enum.PermissionRepoView
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, requiredPermission, false)
+	if err != nil {
+		return types.RevertCommitResponse{}, nil, err
+	}
+
+	commitOutput, err := c.git.GetCommit(ctx, &git.GetCommitParams{
+		ReadParams: git.CreateReadParams(repo),
+		SHA:        strings.TrimSpace(commitSHA),
+	})
+	if err != nil {
+		return types.RevertCommitResponse{}, nil, fmt.Errorf("failed to get commit: %w", err)
+	}
+
+	if err = in.sanitize(repo, &commitOutput.Commit); err != nil {
+		return types.RevertCommitResponse{}, nil, err
+	}
+
+	rules, isRepoOwner, err := c.fetchRules(ctx, session, repo)
+	if err != nil {
+		return types.RevertCommitResponse{}, nil, err
+	}
+
+	refAction := protection.RefActionUpdate
+	branchName := in.Branch
+	if in.NewBranch != "" {
+		refAction = protection.RefActionCreate
+		branchName = in.NewBranch
+	}
+
+	violations, err := rules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
+		Actor:       &session.Principal,
+		AllowBypass: in.BypassRules,
+		IsRepoOwner: isRepoOwner,
+		Repo:        repo,
+		RefAction:   refAction,
+		RefType:     protection.RefTypeBranch,
+		RefNames:    []string{branchName},
+	})
+	if err != nil {
+		return types.RevertCommitResponse{}, nil, fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+
+	if in.DryRunRules {
+		return types.RevertCommitResponse{
+			DryRunRules:    true,
+			Branch:         branchName,
+			RuleViolations: violations,
+		}, nil, nil
+	}
+
+	if protection.IsCritical(violations) {
+		return types.RevertCommitResponse{}, violations, nil
+	}
+
+	// Create internal write params. Note: This will skip the pre-commit protection rules check.
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, repo)
+	if err != nil {
+		return types.RevertCommitResponse{}, nil, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	// sign the commit on behalf of the user, if instance-managed user signing keys are enabled.
+	signingKey, err := c.userSigning.SigningKey(ctx, session.Principal.ID)
+	if err != nil {
+		return types.RevertCommitResponse{}, nil, fmt.Errorf("failed to get user signing key: %w", err)
+	}
+
+	now := time.Now()
+	output, err := c.git.RevertCommit(ctx, &git.RevertCommitParams{
+		WriteParams:   writeParams,
+		Branch:        in.Branch,
+		NewBranch:     in.NewBranch,
+		CommitSHA:     commitOutput.Commit.SHA,
+		Mainline:      in.Mainline,
+		Title:         in.Title,
+		Message:       in.Message,
+		Committer:     identityFromPrincipal(session.Principal),
+		CommitterDate: &now,
+		SigningKey:    signingKey,
+	})
+	if err != nil {
+		return types.RevertCommitResponse{}, nil, err
+	}
+
+	response := types.RevertCommitResponse{
+		CommitID:       output.RevertSHA,
+		Branch:         branchName,
+		RuleViolations: violations,
+	}
+
+	if !in.OpenPullRequest {
+		return response, nil, nil
+	}
+
+	response.PullReq, err = c.pullreqCtrl.Create(ctx, session, repo.Path, &pullreq.CreateInput{
+		IsDraft:      in.IsDraft,
+		Title:        in.Title,
+		Description:  in.Message,
+		SourceBranch: in.NewBranch,
+		TargetBranch: in.Branch,
+	})
+	if err != nil {
+		errDelete := c.git.DeleteBranch(ctx, &git.DeleteBranchParams{
+			WriteParams: writeParams,
+			BranchName:  in.NewBranch,
+		})
+		if errDelete != nil {
+			// non-critical error
+			log.Ctx(ctx).Warn().Err(errDelete).Msg("failed to delete revert branch")
+		}
+
+		return types.RevertCommitResponse{}, nil, fmt.Errorf("failed to create revert pull request: %w", err)
+	}
+
+	return response, nil, nil
+}
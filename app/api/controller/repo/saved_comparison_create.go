@@ -0,0 +1,126 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	gitnesserrors "github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+// SavedComparisonCreateInput is used for saving a comparison of two references of a repository.
+type SavedComparisonCreateInput struct {
+	UID         string `json:"uid"`
+	Description string `json:"description"`
+	BaseRef     string `json:"base_ref"`
+	HeadRef     string `json:"head_ref"`
+}
+
+func (in *SavedComparisonCreateInput) sanitize() error {
+	in.UID = strings.TrimSpace(in.UID)
+	in.Description = strings.TrimSpace(in.Description)
+	in.BaseRef = strings.TrimSpace(in.BaseRef)
+	in.HeadRef = strings.TrimSpace(in.HeadRef)
+
+	return validateSavedComparison(in.UID, in.Description, in.BaseRef, in.HeadRef)
+}
+
+// SavedComparisonCreate saves a named comparison of two references of the repository.
+func (c *Controller) SavedComparisonCreate(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *SavedComparisonCreateInput,
+) (*types.SavedComparison, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	if err = c.checkComparisonRefs(ctx, repo, in.BaseRef, in.HeadRef); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	comparison := &types.SavedComparison{
+		RepoID:      repo.ID,
+		UID:         in.UID,
+		Description: in.Description,
+		BaseRef:     in.BaseRef,
+		HeadRef:     in.HeadRef,
+		CreatedBy:   session.Principal.ID,
+		Created:     now,
+		Updated:     now,
+	}
+
+	err = c.savedComparisonStore.Create(ctx, comparison)
+	if errors.Is(err, store.ErrDuplicate) {
+		return nil, usererror.Conflict("A saved comparison with the identifier already exists in the repository.")
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to create saved comparison: %w", err)
+	}
+
+	comparison.URL = c.urlProvider.GenerateUICompareURL(repo.Path, comparison.BaseRef, comparison.HeadRef)
+
+	return comparison, nil
+}
+
+func validateSavedComparison(uid, description, baseRef, headRef string) error {
+	if err := check.UID(uid); err != nil {
+		return err
+	}
+
+	if err := check.Description(description); err != nil {
+		return err
+	}
+
+	if baseRef == "" || headRef == "" {
+		return usererror.BadRequest("Both the base and the head reference must be provided.")
+	}
+
+	return nil
+}
+
+// checkComparisonRefs verifies that both references of a comparison point to an existing commit.
+func (c *Controller) checkComparisonRefs(ctx context.Context, repo *types.Repository, refs ...string) error {
+	for _, ref := range refs {
+		_, err := c.git.GetCommit(ctx, &git.GetCommitParams{
+			ReadParams: git.CreateReadParams(repo),
+			SHA:        ref,
+		})
+		if gitnesserrors.IsNotFound(err) {
+			return usererror.BadRequestf("Reference %q doesn't exist.", ref)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to resolve reference %q: %w", ref, err)
+		}
+	}
+
+	return nil
+}
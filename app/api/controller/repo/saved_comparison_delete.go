@@ -0,0 +1,42 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types/enum"
+)
+
+// SavedComparisonDelete removes a saved comparison from the repository.
+func (c *Controller) SavedComparisonDelete(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	comparisonUID string,
+) error {
+	_, comparison, err := c.getSavedComparisonCheckAccess(ctx, session, repoRef, comparisonUID,
+		enum.PermissionRepoPush)
+	if err != nil {
+		return err
+	}
+
+	if err = c.savedComparisonStore.Delete(ctx, comparison.ID); err != nil {
+		return fmt.Errorf("failed to delete saved comparison: %w", err)
+	}
+
+	return nil
+}
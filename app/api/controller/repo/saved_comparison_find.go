@@ -0,0 +1,64 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	gitnesserrors "github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// SavedComparisonFind returns a saved comparison of the repository
+// along with the number of commits the head reference is ahead and behind the base reference.
+func (c *Controller) SavedComparisonFind(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	comparisonUID string,
+) (*types.SavedComparison, error) {
+	repo, comparison, err := c.getSavedComparisonCheckAccess(ctx, session, repoRef, comparisonUID,
+		enum.PermissionRepoView)
+	if err != nil {
+		return nil, err
+	}
+
+	comparison.URL = c.urlProvider.GenerateUICompareURL(repo.Path, comparison.BaseRef, comparison.HeadRef)
+
+	out, err := c.git.GetCommitDivergences(ctx, &git.GetCommitDivergencesParams{
+		ReadParams: git.CreateReadParams(repo),
+		Requests: []git.CommitDivergenceRequest{{
+			From: comparison.HeadRef,
+			To:   comparison.BaseRef,
+		}},
+	})
+	if gitnesserrors.IsNotFound(err) {
+		// one of the references has been deleted since the comparison was saved.
+		return comparison, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit divergence: %w", err)
+	}
+
+	if len(out.Divergences) == 1 {
+		comparison.Ahead = &out.Divergences[0].Ahead
+		comparison.Behind = &out.Divergences[0].Behind
+	}
+
+	return comparison, nil
+}
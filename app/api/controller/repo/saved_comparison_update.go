@@ -0,0 +1,108 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// SavedComparisonUpdateInput is used for updating a saved comparison of a repository.
+type SavedComparisonUpdateInput struct {
+	UID         *string `json:"uid"`
+	Description *string `json:"description"`
+	BaseRef     *string `json:"base_ref"`
+	HeadRef     *string `json:"head_ref"`
+}
+
+// apply sets the provided values to the saved comparison and validates the result.
+func (in *SavedComparisonUpdateInput) apply(comparison *types.SavedComparison) error {
+	if in.UID != nil {
+		comparison.UID = strings.TrimSpace(*in.UID)
+	}
+	if in.Description != nil {
+		comparison.Description = strings.TrimSpace(*in.Description)
+	}
+	if in.BaseRef != nil {
+		comparison.BaseRef = strings.TrimSpace(*in.BaseRef)
+	}
+	if in.HeadRef != nil {
+		comparison.HeadRef = strings.TrimSpace(*in.HeadRef)
+	}
+
+	return validateSavedComparison(comparison.UID, comparison.Description, comparison.BaseRef, comparison.HeadRef)
+}
+
+// SavedComparisonUpdate updates a saved comparison of the repository.
+func (c *Controller) SavedComparisonUpdate(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	comparisonUID string,
+	in *SavedComparisonUpdateInput,
+) (*types.SavedComparison, error) {
+	repo, comparison, err := c.getSavedComparisonCheckAccess(ctx, session, repoRef, comparisonUID,
+		enum.PermissionRepoPush)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = in.apply(comparison); err != nil {
+		return nil, err
+	}
+
+	if in.BaseRef != nil || in.HeadRef != nil {
+		if err = c.checkComparisonRefs(ctx, repo, comparison.BaseRef, comparison.HeadRef); err != nil {
+			return nil, err
+		}
+	}
+
+	err = c.savedComparisonStore.Update(ctx, comparison)
+	if errors.Is(err, store.ErrDuplicate) {
+		return nil, usererror.Conflict("A saved comparison with the identifier already exists in the repository.")
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to update saved comparison: %w", err)
+	}
+
+	comparison.URL = c.urlProvider.GenerateUICompareURL(repo.Path, comparison.BaseRef, comparison.HeadRef)
+
+	return comparison, nil
+}
+
+func (c *Controller) getSavedComparisonCheckAccess(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	comparisonUID string,
+	reqPermission enum.Permission,
+) (*types.Repository, *types.SavedComparison, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, reqPermission, reqPermission == enum.PermissionRepoView)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	comparison, err := c.savedComparisonStore.FindByUID(ctx, repo.ID, comparisonUID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find saved comparison: %w", err)
+	}
+
+	return repo, comparison, nil
+}
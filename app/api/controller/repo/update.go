@@ -23,17 +23,22 @@ import (
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/maps"
 )
 
 // UpdateInput is used for updating a repo.
 type UpdateInput struct {
 	Description *string `json:"description"`
 	IsPublic    *bool   `json:"is_public"`
+	// Metadata replaces all custom metadata of the repository.
+	Metadata *map[string]string `json:"metadata"`
 }
 
 func (in *UpdateInput) hasChanges(repo *types.Repository) bool {
 	return (in.Description != nil && *in.Description != repo.Description) ||
-		(in.IsPublic != nil && *in.IsPublic != repo.IsPublic)
+		(in.IsPublic != nil && *in.IsPublic != repo.IsPublic) ||
+		(in.Metadata != nil && !maps.Equal(*in.Metadata, repo.Metadata))
 }
 
 // Update updates a repository.
@@ -63,6 +68,9 @@ func (c *Controller) Update(ctx context.Context,
 		if in.IsPublic != nil {
 			repo.IsPublic = *in.IsPublic
 		}
+		if in.Metadata != nil {
+			repo.Metadata = *in.Metadata
+		}
 
 		return nil
 	})
@@ -90,5 +98,11 @@ func (c *Controller) sanitizeUpdateInput(in *UpdateInput) error {
 		}
 	}
 
+	if in.Metadata != nil {
+		if err := check.Metadata(*in.Metadata); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,58 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// WatchUpdate sets the watch level of the current user for the repository.
+func (c *Controller) WatchUpdate(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *controller.WatchInput,
+) (*types.RepoWatch, error) {
+	if err := in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, false)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	watch := &types.RepoWatch{
+		RepoID:      repo.ID,
+		PrincipalID: session.Principal.ID,
+		Level:       in.Level,
+		Created:     now,
+		Updated:     now,
+	}
+
+	err = c.watchStore.UpsertRepo(ctx, watch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upsert repository watch: %w", err)
+	}
+
+	return watch, nil
+}
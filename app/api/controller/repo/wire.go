@@ -16,14 +16,26 @@ package repo
 
 import (
 	"github.com/harness/gitness/app/api/controller/limiter"
+	"github.com/harness/gitness/app/api/controller/pullreq"
 	"github.com/harness/gitness/app/auth/authz"
 	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/services/autolink"
+	"github.com/harness/gitness/app/services/backup"
 	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/commitindex"
+	"github.com/harness/gitness/app/services/compliance"
+	"github.com/harness/gitness/app/services/gitusage"
 	"github.com/harness/gitness/app/services/importer"
 	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/services/mirror"
+	"github.com/harness/gitness/app/services/pipelinecache"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/repostats"
+	"github.com/harness/gitness/app/services/usergroup"
+	"github.com/harness/gitness/app/services/usersigning"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
@@ -47,7 +59,16 @@ func ProvideController(
 	spaceStore store.SpaceStore,
 	pipelineStore store.PipelineStore,
 	principalStore store.PrincipalStore,
+	pullreqStore store.PullReqStore,
 	ruleStore store.RuleStore,
+	mergeTemplateStore store.MergeTemplateStore,
+	signingKeyStore store.SigningKeyStore,
+	commitCommentStore store.CommitCommentStore,
+	autolinkStore store.AutolinkStore,
+	watchStore store.WatchStore,
+	defaultReviewerStore store.DefaultReviewerStore,
+	repoAliasStore store.RepoAliasStore,
+	savedComparisonStore store.SavedComparisonStore,
 	principalInfoCache store.PrincipalInfoCache,
 	protectionManager *protection.Manager,
 	rpcClient git.Interface,
@@ -56,10 +77,25 @@ func ProvideController(
 	reporeporter *repoevents.Reporter,
 	indexer keywordsearch.Indexer,
 	limiter limiter.ResourceLimiter,
+	encrypter encrypt.Encrypter,
+	compliance *compliance.Service,
+	userSigning *usersigning.Service,
+	autolinks *autolink.Service,
+	userGroupResolver usergroup.Resolver,
+	gitUsage *gitusage.Recorder,
+	pipelineCache *pipelinecache.Service,
+	repoStats *repostats.Service,
+	commitIndex *commitindex.Service,
+	mirrors *mirror.Service,
+	backup *backup.Service,
+	pullreqCtrl *pullreq.Controller,
 ) *Controller {
 	return NewController(config, tx, urlProvider,
 		uidCheck, authorizer, repoStore,
 		spaceStore, pipelineStore,
-		principalStore, ruleStore, principalInfoCache, protectionManager,
-		rpcClient, importer, codeOwners, reporeporter, indexer, limiter)
+		principalStore, pullreqStore, ruleStore, mergeTemplateStore, signingKeyStore, commitCommentStore, autolinkStore,
+		watchStore, defaultReviewerStore, repoAliasStore, savedComparisonStore, principalInfoCache,
+		protectionManager, rpcClient, importer, codeOwners, reporeporter, indexer, limiter, encrypter,
+		compliance, userSigning, autolinks, userGroupResolver, gitUsage, pipelineCache, repoStats,
+		commitIndex, mirrors, backup, pullreqCtrl)
 }
@@ -0,0 +1,44 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+)
+
+// CheckRepoUIDNotAliased returns a conflict error if the uid is already used
+// as an alias of a repository in the space.
+func CheckRepoUIDNotAliased(
+	ctx context.Context,
+	repoAliasStore store.RepoAliasStore,
+	spaceID int64,
+	uid string,
+) error {
+	alias, err := repoAliasStore.FindByUID(ctx, spaceID, uid)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find repository alias: %w", err)
+	}
+
+	return usererror.Conflict(fmt.Sprintf("The identifier %q is used as an alias of another repository.", alias.UID))
+}
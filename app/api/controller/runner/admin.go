@@ -0,0 +1,74 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// List returns all registered runners.
+func (c *Controller) List(ctx context.Context) ([]*types.Runner, error) {
+	runners, err := c.runnerStore.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list runners: %w", err)
+	}
+
+	now := time.Now()
+	for _, runner := range runners {
+		c.setOnline(runner, now)
+	}
+
+	return runners, nil
+}
+
+// Drain stops the runner from accepting new stages. The stages it already runs are finished.
+func (c *Controller) Drain(ctx context.Context, runnerUID string) (*types.Runner, error) {
+	runner, err := c.findRunner(ctx, runnerUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if runner.State != enum.RunnerStateDraining {
+		runner.State = enum.RunnerStateDraining
+		runner.Updated = time.Now().UnixMilli()
+
+		if err = c.runnerStore.UpdateState(ctx, runner); err != nil {
+			return nil, fmt.Errorf("failed to update runner state: %w", err)
+		}
+	}
+
+	c.setOnline(runner, time.Now())
+
+	return runner, nil
+}
+
+// Delete deletes the runner. The runner's token is invalidated, so it has to register again.
+func (c *Controller) Delete(ctx context.Context, runnerUID string) error {
+	runner, err := c.findRunner(ctx, runnerUID)
+	if err != nil {
+		return err
+	}
+
+	if err = c.runnerStore.Delete(ctx, runner.ID); err != nil {
+		return fmt.Errorf("failed to delete runner: %w", err)
+	}
+
+	return nil
+}
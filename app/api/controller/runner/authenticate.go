@@ -0,0 +1,57 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Authenticate returns the runner that authenticates with the provided token.
+// Every authenticated request counts as a heartbeat of the runner.
+func (c *Controller) Authenticate(ctx context.Context, token string) (*types.Runner, error) {
+	if token == "" {
+		return nil, usererror.ErrUnauthorized
+	}
+
+	runner, err := c.runnerStore.FindByTokenHash(ctx, hashToken(token))
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, usererror.ErrInvalidToken
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find runner: %w", err)
+	}
+
+	now := time.Now()
+	if now.Sub(time.UnixMilli(runner.LastHeartbeat)) >= heartbeatInterval {
+		runner.LastHeartbeat = now.UnixMilli()
+		if err = c.runnerStore.UpdateHeartbeat(ctx, runner); err != nil {
+			// don't fail the request of the runner, it'll be retried with the next one.
+			log.Ctx(ctx).Warn().Err(err).Str("runner", runner.UID).Msg("failed to update runner heartbeat")
+		}
+	}
+
+	runner.Online = true
+
+	return runner, nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/pipeline/manager"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+
+	runnerclient "github.com/drone/runner-go/client"
+)
+
+const (
+	// heartbeatInterval is the minimum duration between two updates of the runner heartbeat.
+	heartbeatInterval = 15 * time.Second
+)
+
+type Controller struct {
+	registrationToken string
+	heartbeatTimeout  time.Duration
+
+	runnerStore store.RunnerStore
+	stageStore  store.StageStore
+	stepStore   store.StepStore
+	manager     manager.ExecutionManager
+	client      runnerclient.Client
+}
+
+func NewController(
+	config *types.Config,
+	runnerStore store.RunnerStore,
+	stageStore store.StageStore,
+	stepStore store.StepStore,
+	manager manager.ExecutionManager,
+	client runnerclient.Client,
+) *Controller {
+	return &Controller{
+		registrationToken: config.CI.Runners.RegistrationToken,
+		heartbeatTimeout:  config.CI.Runners.HeartbeatTimeout,
+		runnerStore:       runnerStore,
+		stageStore:        stageStore,
+		stepStore:         stepStore,
+		manager:           manager,
+		client:            client,
+	}
+}
+
+func (c *Controller) findRunner(ctx context.Context, runnerUID string) (*types.Runner, error) {
+	if runnerUID == "" {
+		return nil, usererror.BadRequest("A valid runner identifier must be provided.")
+	}
+
+	runner, err := c.runnerStore.FindByUID(ctx, runnerUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find runner: %w", err)
+	}
+
+	return runner, nil
+}
+
+// setOnline marks the runner online if it sent a heartbeat within the heartbeat timeout.
+func (c *Controller) setOnline(runner *types.Runner, now time.Time) {
+	runner.Online = now.Sub(time.UnixMilli(runner.LastHeartbeat)) < c.heartbeatTimeout
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
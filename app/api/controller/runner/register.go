@@ -0,0 +1,153 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	maxRunnerLabels      = 32
+	maxRunnerLabelLength = 64
+)
+
+var errRegistrationDisabled = usererror.Forbidden("Runner registration is disabled.")
+
+// RegisterInput is used for registering a self-hosted runner.
+type RegisterInput struct {
+	RegistrationToken string   `json:"registration_token"`
+	UID               string   `json:"uid"`
+	Labels            []string `json:"labels"`
+	OS                string   `json:"os"`
+	Arch              string   `json:"arch"`
+	Version           string   `json:"version"`
+	// CacheDir is the directory on the runner machine for the cache volumes of pipelines.
+	CacheDir string `json:"cache_dir"`
+}
+
+func (in *RegisterInput) sanitize() error {
+	in.UID = strings.TrimSpace(in.UID)
+	if err := check.UID(in.UID); err != nil {
+		return err
+	}
+
+	if len(in.Labels) > maxRunnerLabels {
+		return usererror.BadRequestf("A runner can have at most %d labels.", maxRunnerLabels)
+	}
+
+	labels := make([]string, 0, len(in.Labels))
+	seen := make(map[string]struct{}, len(in.Labels))
+	for _, label := range in.Labels {
+		label = strings.TrimSpace(label)
+		if label == "" || len(label) > maxRunnerLabelLength {
+			return usererror.BadRequestf("Runner labels must be non-empty and at most %d characters long.",
+				maxRunnerLabelLength)
+		}
+		if _, ok := seen[label]; ok {
+			continue
+		}
+		seen[label] = struct{}{}
+		labels = append(labels, label)
+	}
+	in.Labels = labels
+
+	in.OS = strings.TrimSpace(in.OS)
+	in.Arch = strings.TrimSpace(in.Arch)
+	in.Version = strings.TrimSpace(in.Version)
+
+	in.CacheDir = strings.TrimSpace(in.CacheDir)
+	if in.CacheDir != "" {
+		if !path.IsAbs(in.CacheDir) {
+			return usererror.BadRequest("The cache directory of a runner must be an absolute path.")
+		}
+		in.CacheDir = path.Clean(in.CacheDir)
+	}
+
+	return nil
+}
+
+// Register registers a new self-hosted runner. The runner has to provide the registration token
+// configured on the server. The returned token is used by the runner to authenticate.
+func (c *Controller) Register(ctx context.Context, in *RegisterInput) (*types.RunnerRegistration, error) {
+	if c.registrationToken == "" {
+		return nil, errRegistrationDisabled
+	}
+
+	if subtle.ConstantTimeCompare([]byte(in.RegistrationToken), []byte(c.registrationToken)) != 1 {
+		return nil, usererror.ErrInvalidToken
+	}
+
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	token, err := generateRunnerToken()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	runner := &types.Runner{
+		UID:           in.UID,
+		Labels:        in.Labels,
+		OS:            in.OS,
+		Arch:          in.Arch,
+		Version:       in.Version,
+		CacheDir:      in.CacheDir,
+		State:         enum.RunnerStateActive,
+		TokenHash:     hashToken(token),
+		LastHeartbeat: now,
+		Created:       now,
+		Updated:       now,
+	}
+
+	err = c.runnerStore.Create(ctx, runner)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, usererror.Conflict(fmt.Sprintf("A runner with identifier %q already exists.", in.UID))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create runner: %w", err)
+	}
+
+	runner.Online = true
+
+	return &types.RunnerRegistration{
+		Runner: runner,
+		Token:  token,
+	}, nil
+}
+
+func generateRunnerToken() (string, error) {
+	var b [32]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("failed to generate runner token: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b[:]), nil
+}
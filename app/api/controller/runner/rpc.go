@@ -0,0 +1,172 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/pipeline/manager"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/drone/drone-go/drone"
+	runnerclient "github.com/drone/runner-go/client"
+)
+
+// The methods below implement the server side of the drone runner protocol,
+// so the stock drone runners can execute the stages as self-hosted runners.
+
+// RequestStage requests the next stage the runner can execute.
+// It blocks until a stage is available or the context is done.
+// Draining runners don't get any new stages, nil is returned for them.
+func (c *Controller) RequestStage(
+	ctx context.Context,
+	runner *types.Runner,
+	filter *runnerclient.Filter,
+) (*drone.Stage, error) {
+	if runner.State == enum.RunnerStateDraining {
+		return nil, nil //nolint:nilnil // no stage for a draining runner
+	}
+
+	stage, err := c.manager.Request(ctx, &manager.Request{
+		Kind:         filter.Kind,
+		Type:         filter.Type,
+		OS:           filter.OS,
+		Arch:         filter.Arch,
+		Variant:      filter.Variant,
+		Kernel:       filter.Kernel,
+		Labels:       filter.Labels,
+		RunnerLabels: runner.Labels,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return manager.ConvertToDroneStage(stage), nil
+}
+
+// AcceptStage accepts the stage for execution by the runner.
+func (c *Controller) AcceptStage(ctx context.Context, runner *types.Runner, stageID int64) (*drone.Stage, error) {
+	if runner.State == enum.RunnerStateDraining {
+		return nil, usererror.Forbidden("The runner is draining and can't accept new stages.")
+	}
+
+	// the stage is assigned to the runner, not to the machine the runner reports.
+	stage := &drone.Stage{ID: stageID, Machine: runner.UID}
+	if err := c.client.Accept(ctx, stage); err != nil {
+		return nil, err
+	}
+
+	return stage, nil
+}
+
+// StageDetails returns the details the runner needs to execute the stage.
+func (c *Controller) StageDetails(
+	ctx context.Context,
+	runner *types.Runner,
+	stageID int64,
+) (*runnerclient.Context, error) {
+	if err := c.checkStageOwner(ctx, runner, stageID); err != nil {
+		return nil, err
+	}
+
+	return c.client.Detail(ctx, &drone.Stage{ID: stageID})
+}
+
+// UpdateStage updates the stage executed by the runner.
+func (c *Controller) UpdateStage(
+	ctx context.Context,
+	runner *types.Runner,
+	stageID int64,
+	stage *drone.Stage,
+) (*drone.Stage, error) {
+	if err := c.checkStageOwner(ctx, runner, stageID); err != nil {
+		return nil, err
+	}
+
+	stage.ID = stageID
+	stage.Machine = runner.UID
+	if err := c.client.Update(ctx, stage); err != nil {
+		return nil, err
+	}
+
+	return stage, nil
+}
+
+// UpdateStep updates a step of a stage executed by the runner.
+func (c *Controller) UpdateStep(
+	ctx context.Context,
+	runner *types.Runner,
+	stepID int64,
+	step *drone.Step,
+) (*drone.Step, error) {
+	if err := c.checkStepOwner(ctx, runner, stepID); err != nil {
+		return nil, err
+	}
+
+	step.ID = stepID
+	if err := c.client.UpdateStep(ctx, step); err != nil {
+		return nil, err
+	}
+
+	return step, nil
+}
+
+// WriteLogs writes the lines to the live logs of a step executed by the runner.
+func (c *Controller) WriteLogs(ctx context.Context, runner *types.Runner, stepID int64, lines []*drone.Line) error {
+	if err := c.checkStepOwner(ctx, runner, stepID); err != nil {
+		return err
+	}
+
+	return c.client.Batch(ctx, stepID, lines)
+}
+
+// UploadLogs uploads the full logs of a step executed by the runner.
+func (c *Controller) UploadLogs(ctx context.Context, runner *types.Runner, stepID int64, lines []*drone.Line) error {
+	if err := c.checkStepOwner(ctx, runner, stepID); err != nil {
+		return err
+	}
+
+	return c.client.Upload(ctx, stepID, lines)
+}
+
+// Watch blocks until the execution gets canceled or the context is done.
+func (c *Controller) Watch(ctx context.Context, executionID int64) (bool, error) {
+	return c.client.Watch(ctx, executionID)
+}
+
+func (c *Controller) checkStageOwner(ctx context.Context, runner *types.Runner, stageID int64) error {
+	stage, err := c.stageStore.Find(ctx, stageID)
+	if err != nil {
+		return fmt.Errorf("failed to find stage: %w", err)
+	}
+
+	if stage.Machine != runner.UID {
+		return usererror.Forbidden("The stage isn't assigned to the runner.")
+	}
+
+	return nil
+}
+
+func (c *Controller) checkStepOwner(ctx context.Context, runner *types.Runner, stepID int64) error {
+	step, err := c.stepStore.Find(ctx, stepID)
+	if err != nil {
+		return fmt.Errorf("failed to find step: %w", err)
+	}
+
+	return c.checkStageOwner(ctx, runner, step.StageID)
+}
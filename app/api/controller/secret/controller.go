@@ -22,11 +22,12 @@ import (
 )
 
 type Controller struct {
-	uidCheck    check.PathUID
-	encrypter   encrypt.Encrypter
-	secretStore store.SecretStore
-	authorizer  authz.Authorizer
-	spaceStore  store.SpaceStore
+	uidCheck       check.PathUID
+	encrypter      encrypt.Encrypter
+	secretStore    store.SecretStore
+	connectorStore store.ConnectorStore
+	authorizer     authz.Authorizer
+	spaceStore     store.SpaceStore
 }
 
 func NewController(
@@ -34,13 +35,15 @@ func NewController(
 	authorizer authz.Authorizer,
 	encrypter encrypt.Encrypter,
 	secretStore store.SecretStore,
+	connectorStore store.ConnectorStore,
 	spaceStore store.SpaceStore,
 ) *Controller {
 	return &Controller{
-		uidCheck:    uidCheck,
-		encrypter:   encrypter,
-		secretStore: secretStore,
-		authorizer:  authorizer,
-		spaceStore:  spaceStore,
+		uidCheck:       uidCheck,
+		encrypter:      encrypter,
+		secretStore:    secretStore,
+		connectorStore: connectorStore,
+		authorizer:     authorizer,
+		spaceStore:     spaceStore,
 	}
 }
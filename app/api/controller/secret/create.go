@@ -16,6 +16,7 @@ package secret
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -24,7 +25,9 @@ import (
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/pipeline/secrets"
 	"github.com/harness/gitness/encrypt"
+	gitness_store "github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
@@ -41,6 +44,9 @@ type CreateInput struct {
 	SpaceRef    string `json:"space_ref"` // Ref of the parent space
 	UID         string `json:"uid"`
 	Data        string `json:"data"`
+	// Connector and Reference locate the secret in a secret manager, instead of storing its data.
+	Connector string `json:"connector"`
+	Reference string `json:"reference"`
 }
 
 func (c *Controller) Create(ctx context.Context, session *auth.Session, in *CreateInput) (*types.Secret, error) {
@@ -58,6 +64,10 @@ func (c *Controller) Create(ctx context.Context, session *auth.Session, in *Crea
 		return nil, fmt.Errorf("failed to sanitize input: %w", err)
 	}
 
+	if err := c.checkExternal(ctx, parentSpace.ID, in.Connector, in.Reference, in.Data); err != nil {
+		return nil, err
+	}
+
 	var secret *types.Secret
 	now := time.Now().UnixMilli()
 	secret = &types.Secret{
@@ -66,6 +76,8 @@ func (c *Controller) Create(ctx context.Context, session *auth.Session, in *Crea
 		Data:        in.Data,
 		SpaceID:     parentSpace.ID,
 		UID:         in.UID,
+		Connector:   in.Connector,
+		Reference:   in.Reference,
 		Created:     now,
 		Updated:     now,
 		Version:     0,
@@ -93,10 +105,46 @@ func (c *Controller) sanitizeCreateInput(in *CreateInput) error {
 		return err
 	}
 
+	in.Connector = strings.TrimSpace(in.Connector)
+	in.Reference = strings.TrimSpace(in.Reference)
+
 	in.Description = strings.TrimSpace(in.Description)
 	return check.Description(in.Description)
 }
 
+// checkExternal verifies that a secret stored in a secret manager references a secret manager connector
+// of the space and has no data of its own.
+func (c *Controller) checkExternal(ctx context.Context, spaceID int64, connectorUID, reference, data string) error {
+	if connectorUID == "" {
+		if reference != "" {
+			return usererror.BadRequest("A reference requires a secret manager connector.")
+		}
+		return nil
+	}
+
+	if data != "" {
+		return usererror.BadRequest("A secret stored in a secret manager can't have data.")
+	}
+
+	if _, err := secrets.ParseReference(reference); err != nil {
+		return usererror.BadRequestf("Invalid secret reference: %s.", err)
+	}
+
+	connector, err := c.connectorStore.FindByUID(ctx, spaceID, connectorUID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return usererror.BadRequestf("Connector %q not found in the space of the secret.", connectorUID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find connector: %w", err)
+	}
+
+	if !secrets.IsProviderType(connector.Type) {
+		return usererror.BadRequestf("Connector %q isn't a secret manager.", connectorUID)
+	}
+
+	return nil
+}
+
 // helper function returns the same secret with encrypted data.
 func enc(encrypt encrypt.Encrypter, secret *types.Secret) (*types.Secret, error) {
 	if secret == nil {
@@ -31,6 +31,8 @@ type UpdateInput struct {
 	UID         *string `json:"uid"`
 	Description *string `json:"description"`
 	Data        *string `json:"data"`
+	Connector   *string `json:"connector"`
+	Reference   *string `json:"reference"`
 }
 
 func (c *Controller) Update(
@@ -59,6 +61,22 @@ func (c *Controller) Update(
 		return nil, fmt.Errorf("failed to find secret: %w", err)
 	}
 
+	// the data of a secret is replaced when it's moved to or from a secret manager
+	connectorUID, reference := secret.Connector, secret.Reference
+	if in.Connector != nil {
+		connectorUID = *in.Connector
+	}
+	if in.Reference != nil {
+		reference = *in.Reference
+	}
+	var data string
+	if in.Data != nil {
+		data = *in.Data
+	}
+	if err = c.checkExternal(ctx, space.ID, connectorUID, reference, data); err != nil {
+		return nil, err
+	}
+
 	return c.secretStore.UpdateOptLock(ctx, secret, func(original *types.Secret) error {
 		if in.UID != nil {
 			original.UID = *in.UID
@@ -73,6 +91,11 @@ func (c *Controller) Update(
 			}
 			original.Data = string(data)
 		}
+		original.Connector = connectorUID
+		original.Reference = reference
+		if original.IsExternal() {
+			original.Data = ""
+		}
 
 		return nil
 	})
@@ -85,6 +108,13 @@ func (c *Controller) sanitizeUpdateInput(in *UpdateInput) error {
 		}
 	}
 
+	if in.Connector != nil {
+		*in.Connector = strings.TrimSpace(*in.Connector)
+	}
+	if in.Reference != nil {
+		*in.Reference = strings.TrimSpace(*in.Reference)
+	}
+
 	if in.Description != nil {
 		*in.Description = strings.TrimSpace(*in.Description)
 		if err := check.Description(*in.Description); err != nil {
@@ -32,8 +32,9 @@ func ProvideController(
 	uidCheck check.PathUID,
 	encrypter encrypt.Encrypter,
 	secretStore store.SecretStore,
+	connectorStore store.ConnectorStore,
 	authorizer authz.Authorizer,
 	spaceStore store.SpaceStore,
 ) *Controller {
-	return NewController(uidCheck, authorizer, encrypter, secretStore, spaceStore)
+	return NewController(uidCheck, authorizer, encrypter, secretStore, connectorStore, spaceStore)
 }
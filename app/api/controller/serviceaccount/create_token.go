@@ -58,6 +58,7 @@ func (c *Controller) CreateToken(
 	token, jwtToken, err := token.CreateSAT(
 		ctx,
 		c.tokenStore,
+		c.tokenPolicies,
 		&session.Principal,
 		sa,
 		in.UID,
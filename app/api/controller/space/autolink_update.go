@@ -0,0 +1,81 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// AutolinkUpdate updates an autolink rule of the space.
+func (c *Controller) AutolinkUpdate(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	autolinkID int64,
+	in *controller.AutolinkUpdateInput,
+) (*types.Autolink, error) {
+	link, err := c.getAutolinkCheckEditAccess(ctx, session, spaceRef, autolinkID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = in.Apply(link); err != nil {
+		return nil, err
+	}
+
+	err = c.autolinkStore.Update(ctx, link)
+	if errors.Is(err, store.ErrDuplicate) {
+		return nil, usererror.Conflict("An autolink with the pattern already exists in the space.")
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to update autolink: %w", err)
+	}
+
+	return link, nil
+}
+
+func (c *Controller) getAutolinkCheckEditAccess(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	autolinkID int64,
+) (*types.Autolink, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceEdit, false); err != nil {
+		return nil, err
+	}
+
+	link, err := c.autolinkStore.Find(ctx, autolinkID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find autolink: %w", err)
+	}
+
+	if link.SpaceID == nil || *link.SpaceID != space.ID {
+		return nil, usererror.ErrNotFound
+	}
+
+	return link, nil
+}
@@ -15,6 +15,7 @@
 package space
 
 import (
+	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/api/controller/limiter"
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/api/usererror"
@@ -23,6 +24,7 @@ import (
 	"github.com/harness/gitness/app/services/importer"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/token"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
@@ -35,28 +37,40 @@ var (
 	errPublicSpaceCreationDisabled = usererror.BadRequestf("Public space creation is disabled.")
 )
 
+// automationAccountEmailDomain is the email domain of the service accounts owning the space access tokens.
+const automationAccountEmailDomain = "automation.gitness.local"
+
 type Controller struct {
 	nestedSpacesEnabled           bool
+	deletionGuard                 controller.DeletionGuard
 	publicResourceCreationEnabled bool
 
-	tx              dbtx.Transactor
-	urlProvider     url.Provider
-	sseStreamer     sse.Streamer
-	uidCheck        check.PathUID
-	authorizer      authz.Authorizer
-	spacePathStore  store.SpacePathStore
-	pipelineStore   store.PipelineStore
-	secretStore     store.SecretStore
-	connectorStore  store.ConnectorStore
-	templateStore   store.TemplateStore
-	spaceStore      store.SpaceStore
-	repoStore       store.RepoStore
-	principalStore  store.PrincipalStore
-	repoCtrl        *repo.Controller
-	membershipStore store.MembershipStore
-	importer        *importer.Repository
-	exporter        *exporter.Repository
-	resourceLimiter limiter.ResourceLimiter
+	tx                dbtx.Transactor
+	urlProvider       url.Provider
+	sseStreamer       sse.Streamer
+	uidCheck          check.PathUID
+	authorizer        authz.Authorizer
+	spacePathStore    store.SpacePathStore
+	pipelineStore     store.PipelineStore
+	secretStore       store.SecretStore
+	connectorStore    store.ConnectorStore
+	templateStore     store.TemplateStore
+	spaceStore        store.SpaceStore
+	repoStore         store.RepoStore
+	principalStore    store.PrincipalStore
+	repoCtrl          *repo.Controller
+	membershipStore   store.MembershipStore
+	spacePinStore     store.SpacePinStore
+	pullreqStore      store.PullReqStore
+	requiredFileStore store.RequiredFileStore
+	complianceStore   store.RepoComplianceStore
+	autolinkStore     store.AutolinkStore
+	tokenStore        store.TokenStore
+	tokenPolicies     token.Policies
+	repoAliasStore    store.RepoAliasStore
+	importer          *importer.Repository
+	exporter          *exporter.Repository
+	resourceLimiter   limiter.ResourceLimiter
 }
 
 func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Provider,
@@ -64,11 +78,15 @@ func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Pro
 	spacePathStore store.SpacePathStore, pipelineStore store.PipelineStore, secretStore store.SecretStore,
 	connectorStore store.ConnectorStore, templateStore store.TemplateStore, spaceStore store.SpaceStore,
 	repoStore store.RepoStore, principalStore store.PrincipalStore, repoCtrl *repo.Controller,
-	membershipStore store.MembershipStore, importer *importer.Repository, exporter *exporter.Repository,
-	limiter limiter.ResourceLimiter,
+	membershipStore store.MembershipStore, spacePinStore store.SpacePinStore, pullreqStore store.PullReqStore,
+	requiredFileStore store.RequiredFileStore, complianceStore store.RepoComplianceStore,
+	autolinkStore store.AutolinkStore, importer *importer.Repository, exporter *exporter.Repository,
+	limiter limiter.ResourceLimiter, tokenStore store.TokenStore, tokenPolicies token.Policies,
+	repoAliasStore store.RepoAliasStore,
 ) *Controller {
 	return &Controller{
 		nestedSpacesEnabled:           config.NestedSpacesEnabled,
+		deletionGuard:                 controller.NewDeletionGuard(config),
 		publicResourceCreationEnabled: config.PublicResourceCreationEnabled,
 		tx:                            tx,
 		urlProvider:                   urlProvider,
@@ -85,6 +103,14 @@ func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Pro
 		principalStore:                principalStore,
 		repoCtrl:                      repoCtrl,
 		membershipStore:               membershipStore,
+		spacePinStore:                 spacePinStore,
+		pullreqStore:                  pullreqStore,
+		requiredFileStore:             requiredFileStore,
+		complianceStore:               complianceStore,
+		autolinkStore:                 autolinkStore,
+		tokenStore:                    tokenStore,
+		tokenPolicies:                 tokenPolicies,
+		repoAliasStore:                repoAliasStore,
 		importer:                      importer,
 		exporter:                      exporter,
 		resourceLimiter:               limiter,
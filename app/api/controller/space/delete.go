@@ -26,7 +26,13 @@ import (
 )
 
 // Delete deletes a space.
-func (c *Controller) Delete(ctx context.Context, session *auth.Session, spaceRef string) error {
+// Deleting a space with large or active repositories requires the confirmation token returned by the first call.
+func (c *Controller) Delete(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	confirmationToken string,
+) error {
 	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
 	if err != nil {
 		return err
@@ -35,9 +41,56 @@ func (c *Controller) Delete(ctx context.Context, session *auth.Session, spaceRef
 		return err
 	}
 
+	if c.deletionGuard.Enabled() {
+		impact := types.DeletionImpact{}
+		if err = c.deletionImpactNoAuth(ctx, space.ID, &impact); err != nil {
+			return fmt.Errorf("failed to get the deletion impact of space %d: %w", space.ID, err)
+		}
+
+		err = c.deletionGuard.Check(session, enum.ResourceTypeSpace, space.ID, impact, confirmationToken)
+		if err != nil {
+			return err
+		}
+	}
+
 	return c.DeleteNoAuth(ctx, session, space.ID)
 }
 
+// deletionImpactNoAuth adds the space with all its sub spaces and repositories to the deletion impact.
+func (c *Controller) deletionImpactNoAuth(ctx context.Context, spaceID int64, impact *types.DeletionImpact) error {
+	impact.Spaces++
+
+	subSpaces, _, err := c.ListSpacesNoAuth(ctx, spaceID, &types.SpaceFilter{
+		Page:  1,
+		Size:  math.MaxInt,
+		Order: enum.OrderAsc,
+		Sort:  enum.SpaceAttrNone,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list space %d sub spaces: %w", spaceID, err)
+	}
+	for _, space := range subSpaces {
+		if err = c.deletionImpactNoAuth(ctx, space.ID, impact); err != nil {
+			return err
+		}
+	}
+
+	repos, _, err := c.ListRepositoriesNoAuth(ctx, spaceID, &types.RepoFilter{
+		Page:  1,
+		Size:  math.MaxInt,
+		Order: enum.OrderAsc,
+		Sort:  enum.RepoAttrNone,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list space %d repositories: %w", spaceID, err)
+	}
+	for _, repo := range repos {
+		impact.Add(repo)
+	}
+
+	return nil
+}
+
 // DeleteNoAuth deletes the space - no authorization is verified.
 // WARNING this is meant for internal calls only.
 func (c *Controller) DeleteNoAuth(ctx context.Context, session *auth.Session, spaceID int64) error {
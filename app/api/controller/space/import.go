@@ -95,7 +95,7 @@ func (c *Controller) Import(ctx context.Context, session *auth.Session, in *Impo
 		}
 
 		jobGroupID := fmt.Sprintf("space-import-%d", space.ID)
-		err = c.importer.RunMany(ctx, jobGroupID, provider, repoIDs, cloneURLs, in.Pipelines)
+		err = c.importer.RunMany(ctx, jobGroupID, space.ID, provider, repoIDs, cloneURLs, in.Pipelines)
 		if err != nil {
 			return fmt.Errorf("failed to start import repository jobs: %w", err)
 		}
@@ -114,7 +114,13 @@ func (c *Controller) ImportRepositories(
 				c.publicResourceCreationEnabled,
 			)
 
-			err = c.repoStore.Create(ctx, repo)
+			// a repository alias with the same uid is treated as a duplicate repository.
+			_, err = c.repoAliasStore.FindByUID(ctx, space.ID, repo.UID)
+			if err == nil {
+				err = store.ErrDuplicate
+			} else if errors.Is(err, store.ErrResourceNotFound) {
+				err = c.repoStore.Create(ctx, repo)
+			}
 			if errors.Is(err, store.ErrDuplicate) {
 				log.Ctx(ctx).Warn().Err(err).Msg("skipping duplicate repo")
 				duplicateRepos = append(duplicateRepos, repo)
@@ -131,7 +137,7 @@ func (c *Controller) ImportRepositories(
 		}
 
 		jobGroupID := fmt.Sprintf("space-import-%d", space.ID)
-		err = c.importer.RunMany(ctx, jobGroupID, provider, repoIDs, cloneURLs, in.Pipelines)
+		err = c.importer.RunMany(ctx, jobGroupID, space.ID, provider, repoIDs, cloneURLs, in.Pipelines)
 		if err != nil {
 			return fmt.Errorf("failed to start import repository jobs: %w", err)
 		}
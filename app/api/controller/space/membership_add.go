@@ -70,7 +70,15 @@ func (c *Controller) MembershipAdd(ctx context.Context,
 		return nil, err
 	}
 
-	err = in.Validate()
+	return c.membershipAdd(ctx, session, space, in)
+}
+
+func (c *Controller) membershipAdd(ctx context.Context,
+	session *auth.Session,
+	space *types.Space,
+	in *MembershipAddInput,
+) (*types.MembershipUser, error) {
+	err := in.Validate()
 	if err != nil {
 		return nil, err
 	}
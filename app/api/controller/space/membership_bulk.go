@@ -0,0 +1,249 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// maxMembershipBulkItems is the maximum number of memberships that can be changed in a single request.
+const maxMembershipBulkItems = 1000
+
+// MembershipBulkAction defines the action performed on a single membership of a bulk request.
+type MembershipBulkAction string
+
+const (
+	// MembershipBulkActionAdd adds a new membership.
+	MembershipBulkActionAdd MembershipBulkAction = "add"
+	// MembershipBulkActionUpdate changes the role of an existing membership.
+	MembershipBulkActionUpdate MembershipBulkAction = "update"
+	// MembershipBulkActionRemove removes an existing membership.
+	MembershipBulkActionRemove MembershipBulkAction = "remove"
+	// MembershipBulkActionSet adds a new membership or changes the role of an existing one.
+	MembershipBulkActionSet MembershipBulkAction = "set"
+)
+
+type MembershipBulkItem struct {
+	Action  MembershipBulkAction `json:"action"`
+	UserUID string               `json:"user_uid"`
+	Role    enum.MembershipRole  `json:"role,omitempty"`
+}
+
+type MembershipBulkInput struct {
+	Items []MembershipBulkItem `json:"items"`
+}
+
+func (in *MembershipBulkInput) Validate() error {
+	if len(in.Items) == 0 {
+		return usererror.BadRequest("At least one membership must be provided")
+	}
+
+	if len(in.Items) > maxMembershipBulkItems {
+		return usererror.BadRequestf("At most %d memberships can be changed at once", maxMembershipBulkItems)
+	}
+
+	return nil
+}
+
+// MembershipBulkResult is the outcome of a single item of a bulk membership request.
+type MembershipBulkResult struct {
+	MembershipBulkItem
+	Success    bool                  `json:"success"`
+	Error      *usererror.Error      `json:"error,omitempty"`
+	Membership *types.MembershipUser `json:"membership,omitempty"`
+}
+
+// MembershipBulk adds, updates or removes many memberships of a space.
+// Every item is processed independently; the result of each is reported in the output.
+func (c *Controller) MembershipBulk(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *MembershipBulkInput,
+) ([]MembershipBulkResult, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceEdit, false); err != nil {
+		return nil, err
+	}
+
+	if err = in.Validate(); err != nil {
+		return nil, err
+	}
+
+	results := make([]MembershipBulkResult, len(in.Items))
+	for i, item := range in.Items {
+		membership, err := c.membershipBulkItem(ctx, session, space, item)
+
+		results[i] = MembershipBulkResult{
+			MembershipBulkItem: item,
+			Success:            err == nil,
+			Membership:         membership,
+		}
+
+		if err != nil {
+			log.Ctx(ctx).Debug().Err(err).
+				Str("user_uid", item.UserUID).
+				Str("action", string(item.Action)).
+				Msg("bulk membership item failed")
+
+			results[i].Error = usererror.Translate(err)
+		}
+	}
+
+	return results, nil
+}
+
+func (c *Controller) membershipBulkItem(ctx context.Context,
+	session *auth.Session,
+	space *types.Space,
+	item MembershipBulkItem,
+) (*types.MembershipUser, error) {
+	if item.UserUID == "" {
+		return nil, usererror.BadRequest("UserUID must be provided")
+	}
+
+	switch item.Action {
+	case MembershipBulkActionAdd:
+		return c.membershipAdd(ctx, session, space, &MembershipAddInput{UserUID: item.UserUID, Role: item.Role})
+	case MembershipBulkActionUpdate:
+		return c.membershipUpdate(ctx, space, item.UserUID, &MembershipUpdateInput{Role: item.Role})
+	case MembershipBulkActionRemove:
+		return nil, c.membershipDelete(ctx, space, item.UserUID)
+	case MembershipBulkActionSet:
+		membership, err := c.membershipUpdate(ctx, space, item.UserUID, &MembershipUpdateInput{Role: item.Role})
+		if errors.Is(err, store.ErrResourceNotFound) {
+			return c.membershipAdd(ctx, session, space, &MembershipAddInput{UserUID: item.UserUID, Role: item.Role})
+		}
+		return membership, err
+	default:
+		return nil, usererror.BadRequestf("Unsupported action '%s'", item.Action)
+	}
+}
+
+// MembershipImport sets the memberships of a space from CSV data.
+// Every record contains the user UID, the role and, optionally, the action (the default is "set").
+// An optional header row starting with "user_uid" is skipped.
+// Malformed records, unknown roles or actions and users listed more than once reject the whole import.
+func (c *Controller) MembershipImport(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	data io.Reader,
+) ([]MembershipBulkResult, error) {
+	in, err := parseMembershipCSV(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.MembershipBulk(ctx, session, spaceRef, in)
+}
+
+func parseMembershipCSV(data io.Reader) (*MembershipBulkInput, error) {
+	r := csv.NewReader(data)
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	in := &MembershipBulkInput{}
+	userLines := make(map[string]int)
+
+	for line := 1; ; line++ {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, usererror.BadRequestf("Invalid CSV data: %s", err)
+		}
+
+		if len(record) < 2 || len(record) > 3 {
+			return nil, usererror.BadRequestf(
+				"Invalid CSV data: line %d must contain the user UID, the role and optionally the action", line)
+		}
+
+		userUID := strings.TrimSpace(record[0])
+		if line == 1 && strings.EqualFold(userUID, "user_uid") {
+			continue
+		}
+
+		item, err := parseMembershipCSVRecord(userUID, record[1:])
+		if err != nil {
+			return nil, usererror.BadRequestf("Invalid CSV data: line %d: %s", line, err)
+		}
+
+		if prev, ok := userLines[strings.ToLower(userUID)]; ok {
+			return nil, usererror.BadRequestf("Invalid CSV data: user '%s' is listed on line %d and line %d",
+				userUID, prev, line)
+		}
+		userLines[strings.ToLower(userUID)] = line
+
+		in.Items = append(in.Items, item)
+
+		if len(in.Items) > maxMembershipBulkItems {
+			return nil, usererror.BadRequestf("At most %d memberships can be imported at once",
+				maxMembershipBulkItems)
+		}
+	}
+
+	return in, nil
+}
+
+// parseMembershipCSVRecord parses the role and the optional action of a CSV record.
+func parseMembershipCSVRecord(userUID string, fields []string) (MembershipBulkItem, error) {
+	if userUID == "" {
+		return MembershipBulkItem{}, errors.New("the user UID must be provided")
+	}
+
+	item := MembershipBulkItem{
+		Action:  MembershipBulkActionSet,
+		UserUID: userUID,
+	}
+	if len(fields) == 2 && strings.TrimSpace(fields[1]) != "" {
+		item.Action = MembershipBulkAction(strings.ToLower(strings.TrimSpace(fields[1])))
+	}
+
+	switch item.Action {
+	case MembershipBulkActionAdd, MembershipBulkActionUpdate, MembershipBulkActionSet, MembershipBulkActionRemove:
+	default:
+		return MembershipBulkItem{}, fmt.Errorf("unsupported action '%s'", item.Action)
+	}
+
+	role := strings.TrimSpace(fields[0])
+	if role == "" && item.Action == MembershipBulkActionRemove {
+		return item, nil
+	}
+
+	var ok bool
+	if item.Role, ok = enum.MembershipRole(strings.ToLower(role)).Sanitize(); !ok {
+		return MembershipBulkItem{}, fmt.Errorf("unknown role '%s'", role)
+	}
+
+	return item, nil
+}
@@ -0,0 +1,126 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestParseMembershipCSV(t *testing.T) {
+	tooMany := &strings.Builder{}
+	for i := 0; i <= maxMembershipBulkItems; i++ {
+		fmt.Fprintf(tooMany, "user%d,reader\n", i)
+	}
+
+	tests := []struct {
+		name    string
+		data    string
+		want    []MembershipBulkItem
+		wantErr string
+	}{
+		{
+			name: "header and default action",
+			data: "user_uid,role,action\nalice,reader\nbob, space_owner ,Remove\n",
+			want: []MembershipBulkItem{
+				{Action: MembershipBulkActionSet, UserUID: "alice", Role: enum.MembershipRoleReader},
+				{Action: MembershipBulkActionRemove, UserUID: "bob", Role: enum.MembershipRoleSpaceOwner},
+			},
+		},
+		{
+			name: "remove without role",
+			data: "alice,,remove\n",
+			want: []MembershipBulkItem{
+				{Action: MembershipBulkActionRemove, UserUID: "alice"},
+			},
+		},
+		{
+			name:    "too few fields",
+			data:    "alice,reader\nbob\n",
+			wantErr: "line 2 must contain",
+		},
+		{
+			name:    "too many fields",
+			data:    "alice,reader,add,extra\n",
+			wantErr: "line 1 must contain",
+		},
+		{
+			name:    "unterminated quote",
+			data:    "\"alice,reader\n",
+			wantErr: "Invalid CSV data",
+		},
+		{
+			name:    "empty user",
+			data:    " ,reader\n",
+			wantErr: "line 1: the user UID must be provided",
+		},
+		{
+			name:    "unknown role",
+			data:    "alice,owner\n",
+			wantErr: "line 1: unknown role 'owner'",
+		},
+		{
+			name:    "missing role",
+			data:    "alice,,add\n",
+			wantErr: "line 1: unknown role ''",
+		},
+		{
+			name:    "unknown action",
+			data:    "alice,reader,delete\n",
+			wantErr: "line 1: unsupported action 'delete'",
+		},
+		{
+			name:    "duplicate user",
+			data:    "alice,reader\nbob,reader\nAlice,contributor,update\n",
+			wantErr: "user 'Alice' is listed on line 1 and line 3",
+		},
+		{
+			name:    "too many rows",
+			data:    tooMany.String(),
+			wantErr: fmt.Sprintf("At most %d memberships", maxMembershipBulkItems),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			in, err := parseMembershipCSV(strings.NewReader(test.data))
+
+			if test.wantErr != "" {
+				var uErr *usererror.Error
+				if !errors.As(err, &uErr) {
+					t.Fatalf("expected user error containing %q, got: %v", test.wantErr, err)
+				}
+				if !strings.Contains(uErr.Message, test.wantErr) {
+					t.Errorf("expected error containing %q, got: %s", test.wantErr, uErr.Message)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(test.want, in.Items) {
+				t.Errorf("want=%+v got=%+v", test.want, in.Items)
+			}
+		})
+	}
+}
@@ -39,6 +39,13 @@ func (c *Controller) MembershipDelete(ctx context.Context,
 		return err
 	}
 
+	return c.membershipDelete(ctx, space, userUID)
+}
+
+func (c *Controller) membershipDelete(ctx context.Context,
+	space *types.Space,
+	userUID string,
+) error {
 	user, err := c.principalStore.FindUserByUID(ctx, userUID)
 	if err != nil {
 		return fmt.Errorf("failed to find user by uid: %w", err)
@@ -62,7 +62,15 @@ func (c *Controller) MembershipUpdate(ctx context.Context,
 		return nil, err
 	}
 
-	err = in.Validate()
+	return c.membershipUpdate(ctx, space, userUID, in)
+}
+
+func (c *Controller) membershipUpdate(ctx context.Context,
+	space *types.Space,
+	userUID string,
+	in *MembershipUpdateInput,
+) (*types.MembershipUser, error) {
+	err := in.Validate()
 	if err != nil {
 		return nil, err
 	}
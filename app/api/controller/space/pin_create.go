@@ -0,0 +1,150 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type PinCreateInput struct {
+	Type     enum.SpacePinType     `json:"type"`
+	RepoRef  string                `json:"repo_ref"`
+	Number   int64                 `json:"number"`
+	Tag      string                `json:"tag"`
+	Title    string                `json:"title"`
+	Position int64                 `json:"position"`
+	Audience enum.SpacePinAudience `json:"audience"`
+}
+
+func (in *PinCreateInput) sanitize() error {
+	pinType, ok := in.Type.Sanitize()
+	if !ok {
+		return usererror.BadRequestf("Unsupported pin type. Valid values are: %v", enum.SpacePinType("").Enum())
+	}
+	in.Type = pinType
+
+	audience, ok := in.Audience.Sanitize()
+	if !ok {
+		return usererror.BadRequestf("Unsupported pin audience. Valid values are: %v",
+			enum.SpacePinAudience("").Enum())
+	}
+	in.Audience = audience
+
+	if in.RepoRef == "" {
+		return usererror.BadRequest("A valid repository reference must be provided.")
+	}
+
+	in.Tag = strings.TrimSpace(in.Tag)
+	in.Title = strings.TrimSpace(in.Title)
+
+	switch in.Type {
+	case enum.SpacePinTypeRepo:
+		in.Number = 0
+		in.Tag = ""
+	case enum.SpacePinTypePullReq:
+		if in.Number <= 0 {
+			return usererror.BadRequest("A valid pull request number must be provided.")
+		}
+		in.Tag = ""
+	case enum.SpacePinTypeRelease:
+		if in.Tag == "" {
+			return usererror.BadRequest("Tag name must be provided.")
+		}
+		in.Number = 0
+	}
+
+	if in.Position < 0 {
+		return usererror.BadRequest("Position must be zero or a positive integer.")
+	}
+
+	return nil
+}
+
+// PinCreate pins a repository, a pull request or a release to the landing page of the space.
+func (c *Controller) PinCreate(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *PinCreateInput,
+) (*types.SpacePin, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceEdit, false); err != nil {
+		return nil, err
+	}
+
+	if err = in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.repoStore.FindByRef(ctx, in.RepoRef)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return nil, usererror.BadRequestf("Repository '%s' not found.", in.RepoRef)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to find the repository: %w", err)
+	}
+
+	if !strings.HasPrefix(repo.Path, space.Path+"/") {
+		return nil, usererror.BadRequest("Only repositories of the space can be pinned.")
+	}
+
+	if in.Type == enum.SpacePinTypePullReq {
+		_, err = c.pullreqStore.FindByNumber(ctx, repo.ID, in.Number)
+		if errors.Is(err, store.ErrResourceNotFound) {
+			return nil, usererror.BadRequestf("Pull request #%d not found.", in.Number)
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to find the pull request: %w", err)
+		}
+	}
+
+	now := time.Now().UnixMilli()
+	pin := &types.SpacePin{
+		SpaceID:   space.ID,
+		Type:      in.Type,
+		RepoID:    repo.ID,
+		Number:    in.Number,
+		Tag:       in.Tag,
+		Title:     in.Title,
+		Position:  in.Position,
+		Audience:  in.Audience,
+		CreatedBy: session.Principal.ID,
+		Created:   now,
+		Updated:   now,
+	}
+
+	err = c.spacePinStore.Create(ctx, pin)
+	if errors.Is(err, store.ErrDuplicate) {
+		return nil, usererror.Conflict("The item is already pinned to the space.")
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to create space pin: %w", err)
+	}
+
+	pin.RepoPath = repo.Path
+
+	return pin, nil
+}
@@ -0,0 +1,40 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+)
+
+// PinDelete unpins an item from the landing page of the space.
+func (c *Controller) PinDelete(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	pinID int64,
+) error {
+	_, pin, err := c.getPinCheckEditAccess(ctx, session, spaceRef, pinID)
+	if err != nil {
+		return err
+	}
+
+	if err = c.spacePinStore.Delete(ctx, pin.ID); err != nil {
+		return fmt.Errorf("failed to delete space pin: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,219 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// PinList returns all items pinned to the space, regardless of their audience.
+func (c *Controller) PinList(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) ([]*types.SpacePin, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceEdit, false); err != nil {
+		return nil, err
+	}
+
+	pins, err := c.spacePinStore.List(ctx, space.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list space pins: %w", err)
+	}
+
+	repos := make(map[int64]*types.Repository)
+	existing := make([]*types.SpacePin, 0, len(pins))
+	for _, pin := range pins {
+		repo, err := c.findPinnedRepo(ctx, pin.RepoID, repos)
+		if errors.Is(err, store.ErrResourceNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		pin.RepoPath = repo.Path
+
+		existing = append(existing, pin)
+	}
+
+	return existing, nil
+}
+
+// Landing returns the landing page content of the space.
+// Pinned items are filtered by their audience and by the repository access of the caller.
+func (c *Controller) Landing(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) (*types.SpaceLanding, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceView, true); err != nil {
+		return nil, err
+	}
+
+	pins, err := c.spacePinStore.List(ctx, space.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list space pins: %w", err)
+	}
+
+	audiences, err := c.pinAudiences(ctx, session, space)
+	if err != nil {
+		return nil, err
+	}
+
+	repos := make(map[int64]*types.Repository)
+	visible := make([]*types.SpacePin, 0, len(pins))
+	for _, pin := range pins {
+		if _, ok := audiences[pin.Audience]; !ok {
+			continue
+		}
+
+		repo, err := c.findPinnedRepo(ctx, pin.RepoID, repos)
+		if errors.Is(err, store.ErrResourceNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, enum.PermissionRepoView, true)
+		if errors.Is(err, apiauth.ErrNotAuthorized) || errors.Is(err, apiauth.ErrNotAuthenticated) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to check repository permission: %w", err)
+		}
+
+		pin.RepoPath = repo.Path
+
+		if pin.Type == enum.SpacePinTypePullReq {
+			pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pin.Number)
+			if errors.Is(err, store.ErrResourceNotFound) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to find pinned pull request: %w", err)
+			}
+
+			if pin.Title == "" {
+				pin.Title = pr.Title
+			}
+		}
+
+		if pin.Title == "" {
+			switch pin.Type {
+			case enum.SpacePinTypeRepo, enum.SpacePinTypePullReq:
+				pin.Title = repo.UID
+			case enum.SpacePinTypeRelease:
+				pin.Title = pin.Tag
+			}
+		}
+
+		visible = append(visible, pin)
+	}
+
+	return &types.SpaceLanding{
+		Space:  space,
+		Pinned: visible,
+	}, nil
+}
+
+// pinAudiences returns the set of pin audiences the caller belongs to.
+func (c *Controller) pinAudiences(ctx context.Context,
+	session *auth.Session,
+	space *types.Space,
+) (map[enum.SpacePinAudience]struct{}, error) {
+	audiences := map[enum.SpacePinAudience]struct{}{
+		enum.SpacePinAudienceEveryone: {},
+	}
+
+	if session == nil || session.Principal.ID == 0 {
+		return audiences, nil
+	}
+
+	// memberships are resolved by the authorizer, members of ancestor spaces are members of the space too.
+	isAdmin, err := c.hasSpacePermission(ctx, session, space, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, err
+	}
+	if isAdmin {
+		audiences[enum.SpacePinAudienceMembers] = struct{}{}
+		audiences[enum.SpacePinAudienceAdmins] = struct{}{}
+		return audiences, nil
+	}
+
+	// the space view permission is checked without the public access, it's granted by any membership role.
+	isMember, err := c.hasSpacePermission(ctx, session, space, enum.PermissionSpaceView)
+	if err != nil {
+		return nil, err
+	}
+	if isMember {
+		audiences[enum.SpacePinAudienceMembers] = struct{}{}
+	}
+
+	return audiences, nil
+}
+
+func (c *Controller) hasSpacePermission(ctx context.Context,
+	session *auth.Session,
+	space *types.Space,
+	permission enum.Permission,
+) (bool, error) {
+	err := apiauth.CheckSpace(ctx, c.authorizer, session, space, permission, false)
+	if errors.Is(err, apiauth.ErrNotAuthorized) || errors.Is(err, apiauth.ErrNotAuthenticated) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to check space permission: %w", err)
+	}
+
+	return true, nil
+}
+
+// findPinnedRepo finds the repository of the pin. Pins are deleted together with their repository,
+// store.ErrResourceNotFound is returned if the repository has been deleted concurrently.
+func (c *Controller) findPinnedRepo(ctx context.Context,
+	repoID int64,
+	repos map[int64]*types.Repository,
+) (*types.Repository, error) {
+	if repo, ok := repos[repoID]; ok {
+		return repo, nil
+	}
+
+	repo, err := c.repoStore.Find(ctx, repoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pinned repository: %w", err)
+	}
+
+	repos[repoID] = repo
+
+	return repo, nil
+}
@@ -0,0 +1,255 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type landingSpaceStore struct {
+	store.SpaceStore
+	spaces []*types.Space
+}
+
+func (s landingSpaceStore) Find(_ context.Context, id int64) (*types.Space, error) {
+	for _, space := range s.spaces {
+		if space.ID == id {
+			return space, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s landingSpaceStore) FindByRef(_ context.Context, spaceRef string) (*types.Space, error) {
+	for _, space := range s.spaces {
+		if space.Path == spaceRef {
+			return space, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+type landingMembershipStore struct {
+	store.MembershipStore
+	memberships []*types.Membership
+}
+
+func (s landingMembershipStore) Find(_ context.Context, key types.MembershipKey) (*types.Membership, error) {
+	for _, membership := range s.memberships {
+		if membership.MembershipKey == key {
+			return membership, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+type landingPinStore struct {
+	store.SpacePinStore
+	pins []*types.SpacePin
+}
+
+func (s landingPinStore) List(_ context.Context, spaceID int64) ([]*types.SpacePin, error) {
+	var pins []*types.SpacePin
+	for _, pin := range s.pins {
+		if pin.SpaceID == spaceID {
+			p := *pin
+			pins = append(pins, &p)
+		}
+	}
+	return pins, nil
+}
+
+type landingRepoStore struct {
+	store.RepoStore
+	repos []*types.Repository
+}
+
+func (s landingRepoStore) Find(_ context.Context, id int64) (*types.Repository, error) {
+	for _, repo := range s.repos {
+		if repo.ID == id {
+			return repo, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+// failingRepoAuthorizer grants every space permission and fails the repository permission checks.
+type failingRepoAuthorizer struct {
+	authz.Authorizer
+}
+
+func (failingRepoAuthorizer) Check(_ context.Context, _ *auth.Session, _ *types.Scope,
+	resource *types.Resource, _ enum.Permission) (bool, error) {
+	if resource.Type == enum.ResourceTypeRepo {
+		return false, errors.New("authorizer failure")
+	}
+	return true, nil
+}
+
+func TestLanding(t *testing.T) {
+	const (
+		ownerID         = 1
+		ancestorMember  = 2
+		outsiderID      = 3
+		deletedRepoID   = 99
+		publicRepoID    = 10
+		spaceID         = 2
+		rootSpaceID     = 1
+		pinEveryone     = 100
+		pinMembers      = 101
+		pinAdmins       = 102
+		pinDeletedRepo  = 103
+		anonymousUserID = 0
+	)
+
+	spaces := landingSpaceStore{spaces: []*types.Space{
+		{ID: rootSpaceID, Path: "root", IsPublic: true},
+		{ID: spaceID, ParentID: rootSpaceID, Path: "root/team", IsPublic: true},
+	}}
+	memberships := landingMembershipStore{memberships: []*types.Membership{
+		{MembershipKey: types.MembershipKey{SpaceID: spaceID, PrincipalID: ownerID}, Role: enum.MembershipRoleSpaceOwner},
+		// membership of the parent space only.
+		{MembershipKey: types.MembershipKey{SpaceID: rootSpaceID, PrincipalID: ancestorMember},
+			Role: enum.MembershipRoleReader},
+	}}
+
+	authorizer := authz.NewMembershipAuthorizer(
+		authz.NewPermissionCache(spaces, memberships, time.Minute), spaces)
+
+	c := &Controller{
+		authorizer: authorizer,
+		spaceStore: spaces,
+		spacePinStore: landingPinStore{pins: []*types.SpacePin{
+			{ID: pinEveryone, SpaceID: spaceID, Type: enum.SpacePinTypeRepo, RepoID: publicRepoID,
+				Audience: enum.SpacePinAudienceEveryone},
+			{ID: pinMembers, SpaceID: spaceID, Type: enum.SpacePinTypeRepo, RepoID: publicRepoID,
+				Audience: enum.SpacePinAudienceMembers},
+			{ID: pinAdmins, SpaceID: spaceID, Type: enum.SpacePinTypeRepo, RepoID: publicRepoID,
+				Audience: enum.SpacePinAudienceAdmins},
+			{ID: pinDeletedRepo, SpaceID: spaceID, Type: enum.SpacePinTypeRepo, RepoID: deletedRepoID,
+				Audience: enum.SpacePinAudienceEveryone},
+		}},
+		repoStore: landingRepoStore{repos: []*types.Repository{
+			{ID: publicRepoID, UID: "repo", Path: "root/team/repo", IsPublic: true},
+		}},
+	}
+
+	tests := []struct {
+		name    string
+		session *auth.Session
+		want    []int64
+	}{
+		{
+			name:    "anonymous",
+			session: &auth.Session{Principal: types.Principal{ID: anonymousUserID}},
+			want:    []int64{pinEveryone},
+		},
+		{
+			name:    "outsider",
+			session: &auth.Session{Principal: types.Principal{ID: outsiderID, Type: enum.PrincipalTypeUser}},
+			want:    []int64{pinEveryone},
+		},
+		{
+			name:    "member-of-ancestor-space",
+			session: &auth.Session{Principal: types.Principal{ID: ancestorMember, Type: enum.PrincipalTypeUser}},
+			want:    []int64{pinEveryone, pinMembers},
+		},
+		{
+			name:    "space-owner",
+			session: &auth.Session{Principal: types.Principal{ID: ownerID, Type: enum.PrincipalTypeUser}},
+			want:    []int64{pinEveryone, pinMembers, pinAdmins},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			landing, err := c.Landing(context.Background(), test.session, "root/team")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got := make([]int64, len(landing.Pinned))
+			for i, pin := range landing.Pinned {
+				got[i] = pin.ID
+				if pin.RepoPath != "root/team/repo" || pin.Title != "repo" {
+					t.Errorf("pin %d isn't populated: %+v", pin.ID, pin)
+				}
+			}
+
+			if len(got) != len(test.want) {
+				t.Fatalf("expected pins %v, got %v", test.want, got)
+			}
+			for i := range got {
+				if got[i] != test.want[i] {
+					t.Fatalf("expected pins %v, got %v", test.want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestPinListSkipsDeletedRepos(t *testing.T) {
+	spaces := landingSpaceStore{spaces: []*types.Space{{ID: 1, Path: "space"}}}
+
+	c := &Controller{
+		authorizer: authz.NewMembershipAuthorizer(nil, spaces),
+		spaceStore: spaces,
+		spacePinStore: landingPinStore{pins: []*types.SpacePin{
+			{ID: 1, SpaceID: 1, RepoID: 10},
+			{ID: 2, SpaceID: 1, RepoID: 99},
+		}},
+		repoStore: landingRepoStore{repos: []*types.Repository{{ID: 10, Path: "space/repo"}}},
+	}
+
+	admin := &auth.Session{Principal: types.Principal{ID: 1, Type: enum.PrincipalTypeUser, Admin: true}}
+
+	pins, err := c.PinList(context.Background(), admin, "space")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(pins) != 1 || pins[0].ID != 1 || pins[0].RepoPath != "space/repo" {
+		t.Errorf("expected only the pin of the existing repository, got %+v", pins)
+	}
+}
+
+func TestLandingRepoCheckFailure(t *testing.T) {
+	spaces := landingSpaceStore{spaces: []*types.Space{{ID: 1, Path: "space"}}}
+
+	c := &Controller{
+		authorizer: failingRepoAuthorizer{},
+		spaceStore: spaces,
+		spacePinStore: landingPinStore{pins: []*types.SpacePin{
+			{ID: 1, SpaceID: 1, Type: enum.SpacePinTypeRepo, RepoID: 10, Audience: enum.SpacePinAudienceEveryone},
+		}},
+		repoStore: landingRepoStore{repos: []*types.Repository{{ID: 10, Path: "space/repo"}}},
+	}
+
+	session := &auth.Session{Principal: types.Principal{ID: 1, Type: enum.PrincipalTypeUser}}
+
+	if _, err := c.Landing(context.Background(), session, "space"); err == nil {
+		t.Errorf("expected the failure of the repository permission check to be returned")
+	}
+}
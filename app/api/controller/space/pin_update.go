@@ -0,0 +1,121 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// PinUpdateInput is used for updating an item pinned to a space.
+type PinUpdateInput struct {
+	Title    *string                `json:"title"`
+	Position *int64                 `json:"position"`
+	Audience *enum.SpacePinAudience `json:"audience"`
+}
+
+func (in *PinUpdateInput) sanitize() error {
+	if in.Title != nil {
+		*in.Title = strings.TrimSpace(*in.Title)
+	}
+
+	if in.Position != nil && *in.Position < 0 {
+		return usererror.BadRequest("Position must be zero or a positive integer.")
+	}
+
+	if in.Audience != nil {
+		audience, ok := in.Audience.Sanitize()
+		if !ok {
+			return usererror.BadRequestf("Unsupported pin audience. Valid values are: %v",
+				enum.SpacePinAudience("").Enum())
+		}
+		in.Audience = &audience
+	}
+
+	return nil
+}
+
+// PinUpdate updates the title, the position or the audience of an item pinned to a space.
+func (c *Controller) PinUpdate(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	pinID int64,
+	in *PinUpdateInput,
+) (*types.SpacePin, error) {
+	_, pin, err := c.getPinCheckEditAccess(ctx, session, spaceRef, pinID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	if in.Title != nil {
+		pin.Title = *in.Title
+	}
+	if in.Position != nil {
+		pin.Position = *in.Position
+	}
+	if in.Audience != nil {
+		pin.Audience = *in.Audience
+	}
+
+	if err = c.spacePinStore.Update(ctx, pin); err != nil {
+		return nil, fmt.Errorf("failed to update space pin: %w", err)
+	}
+
+	repo, err := c.repoStore.Find(ctx, pin.RepoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pinned repository: %w", err)
+	}
+
+	pin.RepoPath = repo.Path
+
+	return pin, nil
+}
+
+func (c *Controller) getPinCheckEditAccess(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	pinID int64,
+) (*types.Space, *types.SpacePin, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceEdit, false); err != nil {
+		return nil, nil, err
+	}
+
+	pin, err := c.spacePinStore.Find(ctx, pinID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find space pin: %w", err)
+	}
+
+	if pin.SpaceID != space.ID {
+		return nil, nil, usererror.ErrNotFound
+	}
+
+	return space, pin, nil
+}
@@ -0,0 +1,137 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/depgraph"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// RepoDependencyOrder is the build order of the repositories of a space.
+type RepoDependencyOrder struct {
+	// Repos lists the repositories so that every repository comes after the repositories it depends on.
+	// Repositories of the same level don't depend on each other and can be built in parallel.
+	Repos []RepoDependencyOrderEntry `json:"repos"`
+	// Cycles lists the paths of the repositories of every dependency cycle.
+	Cycles [][]string `json:"cycles"`
+}
+
+type RepoDependencyOrderEntry struct {
+	ID        int64    `json:"id"`
+	Path      string   `json:"path"`
+	Level     int      `json:"level"`
+	DependsOn []string `json:"depends_on"`
+	InCycle   bool     `json:"in_cycle"`
+}
+
+// RepoDependencyOrder returns the order in which the repositories of the space have to be built,
+// based on the packages their dependency manifest files provide and require.
+func (c *Controller) RepoDependencyOrder(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) (*RepoDependencyOrder, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionRepoView, true); err != nil {
+		return nil, err
+	}
+
+	var repos []*types.Repository
+	page := 1
+	for {
+		reposInPage, err := c.repoStore.List(ctx, space.ID,
+			&types.RepoFilter{Size: 200, Page: page, Sort: enum.RepoAttrUID, Order: enum.OrderAsc})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list child repos: %w", err)
+		}
+		if len(reposInPage) == 0 {
+			break
+		}
+		page++
+		repos = append(repos, reposInPage...)
+	}
+
+	graph := depgraph.New()
+	repoMap := make(map[int64]*types.Repository, len(repos))
+	requires := make(map[int64][]string, len(repos))
+	providers := map[string][]int64{}
+
+	for _, repo := range repos {
+		graph.AddNode(repo.ID)
+		repoMap[repo.ID] = repo
+
+		packages, err := c.repoCtrl.PackagesNoAuth(ctx, repo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get packages of repository %q: %w", repo.Path, err)
+		}
+
+		requires[repo.ID] = packages.Requires
+		for _, pkg := range packages.Provides {
+			providers[pkg] = append(providers[pkg], repo.ID)
+		}
+	}
+
+	for _, repo := range repos {
+		for _, pkg := range requires[repo.ID] {
+			for _, providerID := range providers[pkg] {
+				if providerID == repo.ID {
+					continue
+				}
+				graph.AddDependency(repo.ID, providerID)
+			}
+		}
+	}
+
+	order := graph.Order()
+
+	out := &RepoDependencyOrder{
+		Repos:  make([]RepoDependencyOrderEntry, len(order.Nodes)),
+		Cycles: make([][]string, len(order.Cycles)),
+	}
+
+	for i, node := range order.Nodes {
+		dependsOn := make([]string, len(node.DependsOn))
+		for j, id := range node.DependsOn {
+			dependsOn[j] = repoMap[id].Path
+		}
+
+		out.Repos[i] = RepoDependencyOrderEntry{
+			ID:        node.ID,
+			Path:      repoMap[node.ID].Path,
+			Level:     node.Level,
+			DependsOn: dependsOn,
+			InCycle:   node.InCycle,
+		}
+	}
+
+	for i, cycle := range order.Cycles {
+		out.Cycles[i] = make([]string, len(cycle))
+		for j, id := range cycle {
+			out.Cycles[i][j] = repoMap[id].Path
+		}
+	}
+
+	return out, nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const maxRequiredFileTemplateSize = 64 << 10
+
+type RequiredFileCreateInput struct {
+	Path          string `json:"path"`
+	Template      string `json:"template"`
+	CreatePullReq bool   `json:"create_pullreq"`
+}
+
+func (in *RequiredFileCreateInput) sanitize() error {
+	filePath, err := sanitizeRequiredFilePath(in.Path)
+	if err != nil {
+		return err
+	}
+	in.Path = filePath
+
+	return sanitizeRequiredFileTemplate(in.Template, in.CreatePullReq)
+}
+
+// sanitizeRequiredFilePath returns the cleaned repository-relative path of a required file.
+func sanitizeRequiredFilePath(filePath string) (string, error) {
+	filePath = strings.Trim(strings.TrimSpace(filePath), "/")
+	if filePath == "" {
+		return "", usererror.BadRequest("Path of the required file must be provided.")
+	}
+
+	filePath = path.Clean(filePath)
+	if filePath == ".." || strings.HasPrefix(filePath, "../") {
+		return "", usererror.BadRequest("Path of the required file must be inside the repository.")
+	}
+
+	return filePath, nil
+}
+
+func sanitizeRequiredFileTemplate(template string, createPullReq bool) error {
+	if len(template) > maxRequiredFileTemplateSize {
+		return usererror.BadRequestf("Template of the required file can't be larger than %d bytes.",
+			maxRequiredFileTemplateSize)
+	}
+
+	if createPullReq && template == "" {
+		return usererror.BadRequest("Template must be provided to open pull requests with the required file.")
+	}
+
+	return nil
+}
+
+// RequiredFileCreate adds a required file policy to the space.
+func (c *Controller) RequiredFileCreate(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *RequiredFileCreateInput,
+) (*types.RequiredFile, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceEdit, false); err != nil {
+		return nil, err
+	}
+
+	if err = in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	file := &types.RequiredFile{
+		SpaceID:       space.ID,
+		Path:          in.Path,
+		Template:      in.Template,
+		CreatePullReq: in.CreatePullReq,
+		CreatedBy:     session.Principal.ID,
+		Created:       now,
+		Updated:       now,
+	}
+
+	err = c.requiredFileStore.Create(ctx, file)
+	if errors.Is(err, store.ErrDuplicate) {
+		return nil, usererror.Conflict("The file is already required by the space.")
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to create required file: %w", err)
+	}
+
+	return file, nil
+}
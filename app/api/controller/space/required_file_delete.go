@@ -0,0 +1,40 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+)
+
+// RequiredFileDelete removes a required file policy from the space.
+func (c *Controller) RequiredFileDelete(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	requiredFileID int64,
+) error {
+	file, err := c.getRequiredFileCheckEditAccess(ctx, session, spaceRef, requiredFileID)
+	if err != nil {
+		return err
+	}
+
+	if err = c.requiredFileStore.Delete(ctx, file.ID); err != nil {
+		return fmt.Errorf("failed to delete required file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// RequiredFileList returns the required file policies defined in the space.
+// Policies inherited from the parent spaces are not included.
+func (c *Controller) RequiredFileList(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) ([]*types.RequiredFile, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceView, false); err != nil {
+		return nil, err
+	}
+
+	files, err := c.requiredFileStore.List(ctx, space.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list required files: %w", err)
+	}
+
+	return files, nil
+}
+
+// ComplianceList returns the latest required files compliance results of the repositories in the space.
+func (c *Controller) ComplianceList(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) ([]*types.RepoCompliance, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceView, false); err != nil {
+		return nil, err
+	}
+
+	results, err := c.complianceStore.List(ctx, space.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repository compliance: %w", err)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// RequiredFileUpdateInput is used for updating a required file policy of a space.
+type RequiredFileUpdateInput struct {
+	Path          *string `json:"path"`
+	Template      *string `json:"template"`
+	CreatePullReq *bool   `json:"create_pullreq"`
+}
+
+// RequiredFileUpdate updates a required file policy of the space.
+func (c *Controller) RequiredFileUpdate(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	requiredFileID int64,
+	in *RequiredFileUpdateInput,
+) (*types.RequiredFile, error) {
+	file, err := c.getRequiredFileCheckEditAccess(ctx, session, spaceRef, requiredFileID)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.Path != nil {
+		file.Path, err = sanitizeRequiredFilePath(*in.Path)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if in.Template != nil {
+		file.Template = *in.Template
+	}
+	if in.CreatePullReq != nil {
+		file.CreatePullReq = *in.CreatePullReq
+	}
+
+	if err = sanitizeRequiredFileTemplate(file.Template, file.CreatePullReq); err != nil {
+		return nil, err
+	}
+
+	err = c.requiredFileStore.Update(ctx, file)
+	if errors.Is(err, store.ErrDuplicate) {
+		return nil, usererror.Conflict("The file is already required by the space.")
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to update required file: %w", err)
+	}
+
+	return file, nil
+}
+
+func (c *Controller) getRequiredFileCheckEditAccess(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	requiredFileID int64,
+) (*types.RequiredFile, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceEdit, false); err != nil {
+		return nil, err
+	}
+
+	file, err := c.requiredFileStore.Find(ctx, requiredFileID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find required file: %w", err)
+	}
+
+	if file.SpaceID != space.ID {
+		return nil, usererror.ErrNotFound
+	}
+
+	return file, nil
+}
@@ -0,0 +1,165 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/token"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/dchest/uniuri"
+)
+
+type TokenCreateInput struct {
+	UID      string              `json:"uid"`
+	Lifetime *time.Duration      `json:"lifetime"`
+	Role     enum.MembershipRole `json:"role"`
+}
+
+func (in *TokenCreateInput) Sanitize() error {
+	if err := check.UID(in.UID); err != nil {
+		return err
+	}
+
+	if err := check.TokenLifetime(in.Lifetime, true); err != nil {
+		return err
+	}
+
+	role, ok := in.Role.Sanitize()
+	if !ok || role == "" {
+		return usererror.BadRequest("A valid membership role must be provided.")
+	}
+	in.Role = role
+
+	return nil
+}
+
+// TokenCreate creates a new space access token. Space access tokens aren't tied to a user,
+// they belong to the automation service account of the space and grant the permissions
+// of the role within the space (and everything within it).
+func (c *Controller) TokenCreate(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *TokenCreateInput,
+) (*types.TokenResponse, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceEdit, false); err != nil {
+		return nil, err
+	}
+
+	if err = in.Sanitize(); err != nil {
+		return nil, err
+	}
+
+	sa, err := c.findOrCreateAutomationAccount(ctx, space)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		tkn      *types.Token
+		jwtToken string
+	)
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		tkn, jwtToken, err = token.CreateSpaceToken(
+			ctx,
+			c.tokenStore,
+			c.tokenPolicies,
+			&session.Principal,
+			sa,
+			space,
+			in.Role,
+			in.UID,
+			in.Lifetime,
+		)
+		return err
+	})
+	if errors.Is(err, store.ErrDuplicate) {
+		return nil, usererror.Conflict("A token with the uid already exists in the space.")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.TokenResponse{Token: *tkn, AccessToken: jwtToken}, nil
+}
+
+// automationAccountUID returns the uid of the service account owning the access tokens of the space.
+// It follows the format of generated service account uids, but can't collide with them.
+func automationAccountUID(spaceID int64) string {
+	return fmt.Sprintf("sa-%s-%d-automation", enum.ParentResourceTypeSpace, spaceID)
+}
+
+// findAutomationAccount returns the automation service account of the space.
+func (c *Controller) findAutomationAccount(ctx context.Context, space *types.Space) (*types.ServiceAccount, error) {
+	sa, err := c.principalStore.FindServiceAccountByUID(ctx, automationAccountUID(space.ID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find automation service account: %w", err)
+	}
+
+	return sa, nil
+}
+
+// findOrCreateAutomationAccount returns the automation service account of the space,
+// creating it with the first access token of the space.
+// Actions performed with space access tokens are attributed to this account.
+func (c *Controller) findOrCreateAutomationAccount(
+	ctx context.Context,
+	space *types.Space,
+) (*types.ServiceAccount, error) {
+	sa, err := c.findAutomationAccount(ctx, space)
+	if err == nil || !errors.Is(err, store.ErrResourceNotFound) {
+		return sa, err
+	}
+
+	uid := automationAccountUID(space.ID)
+	now := time.Now().UnixMilli()
+	sa = &types.ServiceAccount{
+		UID:         uid,
+		Email:       uid + "@" + automationAccountEmailDomain,
+		DisplayName: space.UID + " automation",
+		Salt:        uniuri.NewLen(uniuri.UUIDLen),
+		Created:     now,
+		Updated:     now,
+		ParentType:  enum.ParentResourceTypeSpace,
+		ParentID:    space.ID,
+	}
+
+	err = c.principalStore.CreateServiceAccount(ctx, sa)
+	if errors.Is(err, store.ErrDuplicate) {
+		// created concurrently by another request
+		return c.findAutomationAccount(ctx, space)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create automation service account: %w", err)
+	}
+
+	return sa, nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// TokenDelete deletes an access token of the space.
+func (c *Controller) TokenDelete(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	tokenUID string,
+) error {
+	_, _, tkn, err := c.getTokenCheckEditAccess(ctx, session, spaceRef, tokenUID)
+	if err != nil {
+		return err
+	}
+
+	if err = c.tokenStore.Delete(ctx, tkn.ID); err != nil {
+		return fmt.Errorf("failed to delete token: %w", err)
+	}
+
+	return nil
+}
+
+// getTokenCheckEditAccess fetches an access token of the space
+// and checks if the current user has permission to manage the tokens of the space.
+func (c *Controller) getTokenCheckEditAccess(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	tokenUID string,
+) (*types.Space, *types.ServiceAccount, *types.Token, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceEdit, false); err != nil {
+		return nil, nil, nil, err
+	}
+
+	sa, err := c.findAutomationAccount(ctx, space)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	tkn, err := c.tokenStore.FindByUID(ctx, sa.ID, tokenUID)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to find token: %w", err)
+	}
+
+	if tkn.Type != enum.TokenTypeSpace {
+		return nil, nil, nil, usererror.ErrNotFound
+	}
+
+	return space, sa, tkn, nil
+}
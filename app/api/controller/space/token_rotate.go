@@ -0,0 +1,74 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/token"
+	"github.com/harness/gitness/types"
+
+	"github.com/gotidy/ptr"
+)
+
+// TokenRotate replaces an access token of the space with a new one that has the same uid, role and lifetime.
+// The old token is invalidated immediately.
+func (c *Controller) TokenRotate(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	tokenUID string,
+) (*types.TokenResponse, error) {
+	space, sa, oldTkn, err := c.getTokenCheckEditAccess(ctx, session, spaceRef, tokenUID)
+	if err != nil {
+		return nil, err
+	}
+
+	var lifetime *time.Duration
+	if oldTkn.ExpiresAt != nil {
+		lifetime = ptr.Duration(time.Duration(*oldTkn.ExpiresAt-oldTkn.IssuedAt) * time.Millisecond)
+	}
+
+	var (
+		tkn      *types.Token
+		jwtToken string
+	)
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := c.tokenStore.Delete(ctx, oldTkn.ID); err != nil {
+			return fmt.Errorf("failed to delete token: %w", err)
+		}
+
+		tkn, jwtToken, err = token.CreateSpaceToken(
+			ctx,
+			c.tokenStore,
+			c.tokenPolicies,
+			&session.Principal,
+			sa,
+			space,
+			oldTkn.Role,
+			oldTkn.UID,
+			lifetime,
+		)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.TokenResponse{Token: *tkn, AccessToken: jwtToken}, nil
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/harness/gitness/app/services/importer"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/token"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
@@ -40,12 +41,17 @@ func ProvideController(config *types.Config, tx dbtx.Transactor, urlProvider url
 	pipelineStore store.PipelineStore, secretStore store.SecretStore,
 	connectorStore store.ConnectorStore, templateStore store.TemplateStore,
 	spaceStore store.SpaceStore, repoStore store.RepoStore, principalStore store.PrincipalStore,
-	repoCtrl *repo.Controller, membershipStore store.MembershipStore, importer *importer.Repository,
+	repoCtrl *repo.Controller, membershipStore store.MembershipStore, spacePinStore store.SpacePinStore,
+	pullreqStore store.PullReqStore, requiredFileStore store.RequiredFileStore,
+	complianceStore store.RepoComplianceStore, autolinkStore store.AutolinkStore, importer *importer.Repository,
 	exporter *exporter.Repository, limiter limiter.ResourceLimiter,
+	tokenStore store.TokenStore, tokenPolicies token.Policies, repoAliasStore store.RepoAliasStore,
 ) *Controller {
 	return NewController(config, tx, urlProvider, sseStreamer, uidCheck, authorizer,
 		spacePathStore, pipelineStore, secretStore,
 		connectorStore, templateStore,
 		spaceStore, repoStore, principalStore,
-		repoCtrl, membershipStore, importer, exporter, limiter)
+		repoCtrl, membershipStore, spacePinStore, pullreqStore,
+		requiredFileStore, complianceStore, autolinkStore, importer, exporter, limiter,
+		tokenStore, tokenPolicies, repoAliasStore)
 }
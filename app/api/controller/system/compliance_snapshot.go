@@ -0,0 +1,59 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/types"
+)
+
+// ListComplianceSnapshots returns a page of compliance snapshots, the most recent first.
+func (c *Controller) ListComplianceSnapshots(
+	ctx context.Context,
+	pagination *types.Pagination,
+) ([]*types.ComplianceSnapshot, int64, error) {
+	count, err := c.complianceSnapshotStore.Count(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count compliance snapshots: %w", err)
+	}
+
+	snapshots, err := c.complianceSnapshotStore.List(ctx, pagination)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list compliance snapshots: %w", err)
+	}
+
+	return snapshots, count, nil
+}
+
+// ExportComplianceSnapshots returns the complete chain of compliance snapshots
+// together with the result of the hash chain verification.
+func (c *Controller) ExportComplianceSnapshots(ctx context.Context) (*types.ComplianceSnapshotExport, error) {
+	snapshots, err := c.complianceSnapshotStore.ListAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list compliance snapshots: %w", err)
+	}
+
+	invalidID := types.VerifyComplianceSnapshots(snapshots)
+
+	return &types.ComplianceSnapshotExport{
+		Exported:  time.Now().UnixMilli(),
+		Valid:     invalidID == 0,
+		InvalidID: invalidID,
+		Snapshots: snapshots,
+	}, nil
+}
@@ -17,19 +17,45 @@ package system
 import (
 	"context"
 
+	"github.com/harness/gitness/app/services/repomaintenance"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/leader"
 	"github.com/harness/gitness/types"
 )
 
 type Controller struct {
-	principalStore store.PrincipalStore
-	config         *types.Config
+	principalStore          store.PrincipalStore
+	complianceSnapshotStore store.ComplianceSnapshotStore
+	gitUsageStore           store.GitUsageStore
+	repoStore               store.RepoStore
+	repoAliasStore          store.RepoAliasStore
+	principalInfoCache      store.PrincipalInfoCache
+	leaderElector           *leader.Elector
+	repoMaintenance         *repomaintenance.Service
+	config                  *types.Config
 }
 
-func NewController(principalStore store.PrincipalStore, config *types.Config) *Controller {
+func NewController(
+	principalStore store.PrincipalStore,
+	complianceSnapshotStore store.ComplianceSnapshotStore,
+	gitUsageStore store.GitUsageStore,
+	repoStore store.RepoStore,
+	repoAliasStore store.RepoAliasStore,
+	principalInfoCache store.PrincipalInfoCache,
+	leaderElector *leader.Elector,
+	repoMaintenance *repomaintenance.Service,
+	config *types.Config,
+) *Controller {
 	return &Controller{
-		principalStore: principalStore,
-		config:         config,
+		principalStore:          principalStore,
+		complianceSnapshotStore: complianceSnapshotStore,
+		gitUsageStore:           gitUsageStore,
+		repoStore:               repoStore,
+		repoAliasStore:          repoAliasStore,
+		principalInfoCache:      principalInfoCache,
+		leaderElector:           leaderElector,
+		repoMaintenance:         repoMaintenance,
+		config:                  config,
 	}
 }
 
@@ -41,3 +67,8 @@ func (c *Controller) IsUserSignupAllowed(ctx context.Context) (bool, error) {
 
 	return usrCount == 0 || c.config.UserSignupEnabled, nil
 }
+
+// IsLeader returns true if this instance is the leader, i.e. it runs the singleton background services.
+func (c *Controller) IsLeader() bool {
+	return c.leaderElector.IsLeader()
+}
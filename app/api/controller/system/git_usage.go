@@ -0,0 +1,78 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+// ListGitUsage returns the resources consumed by git service pack invocations,
+// aggregated per principal, repository and git service.
+func (c *Controller) ListGitUsage(
+	ctx context.Context,
+	filter *types.GitUsageFilter,
+) ([]*types.GitUsage, error) {
+	usages, err := c.gitUsageStore.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list git usage: %w", err)
+	}
+
+	if err = c.backfillGitUsage(ctx, usages); err != nil {
+		return nil, err
+	}
+
+	return usages, nil
+}
+
+// backfillGitUsage populates the principal info and the repository path of the git usage entries.
+func (c *Controller) backfillGitUsage(ctx context.Context, usages []*types.GitUsage) error {
+	principalIDs := make([]int64, 0, len(usages))
+	for _, usage := range usages {
+		if usage.PrincipalID != 0 {
+			principalIDs = append(principalIDs, usage.PrincipalID)
+		}
+	}
+
+	principals, err := c.principalInfoCache.Map(ctx, principalIDs)
+	if err != nil {
+		return fmt.Errorf("failed to load principal infos: %w", err)
+	}
+
+	repoPaths := make(map[int64]string)
+	for _, usage := range usages {
+		usage.Principal = principals[usage.PrincipalID]
+
+		path, ok := repoPaths[usage.RepoID]
+		if !ok {
+			repo, err := c.repoStore.Find(ctx, usage.RepoID)
+			if err != nil && !errors.Is(err, store.ErrResourceNotFound) {
+				return fmt.Errorf("failed to find repository: %w", err)
+			}
+			if repo != nil {
+				path = repo.Path
+			}
+			repoPaths[usage.RepoID] = path
+		}
+
+		usage.RepoPath = path
+	}
+
+	return nil
+}
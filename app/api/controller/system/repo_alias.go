@@ -0,0 +1,64 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+// ListRepoAliases returns a page of the aliases of all repositories.
+func (c *Controller) ListRepoAliases(
+	ctx context.Context,
+	pagination *types.Pagination,
+) ([]*types.RepoAlias, int64, error) {
+	count, err := c.repoAliasStore.CountAll(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count repository aliases: %w", err)
+	}
+
+	aliases, err := c.repoAliasStore.ListAll(ctx, pagination)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list repository aliases: %w", err)
+	}
+
+	repoPaths := make(map[int64]string)
+	for _, alias := range aliases {
+		path, ok := repoPaths[alias.RepoID]
+		if !ok {
+			repo, err := c.repoStore.Find(ctx, alias.RepoID)
+			if err != nil && !errors.Is(err, store.ErrResourceNotFound) {
+				return nil, 0, fmt.Errorf("failed to find repository: %w", err)
+			}
+			if repo != nil {
+				path = repo.Path
+			}
+			repoPaths[alias.RepoID] = path
+		}
+
+		alias.RepoPath = path
+		if path != "" {
+			spacePath, _, _ := paths.DisectLeaf(path)
+			alias.Path = paths.Concatinate(spacePath, alias.UID)
+		}
+	}
+
+	return aliases, count, nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/services/repomaintenance"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/job"
+)
+
+type RepoMaintenanceInput struct {
+	// Tasks are the maintenance tasks to run. If empty, the tasks are selected
+	// based on the current state of the repository.
+	Tasks []gitenum.MaintenanceTask `json:"tasks"`
+}
+
+type RepoMaintenanceOutput struct {
+	State    job.State                 `json:"state,omitempty"`
+	Progress int                       `json:"progress,omitempty"`
+	Failure  string                    `json:"failure,omitempty"`
+	Tasks    []gitenum.MaintenanceTask `json:"tasks,omitempty"`
+	Stats    git.ObjectStats           `json:"stats"`
+}
+
+// TriggerRepoMaintenance schedules the maintenance of the repository.
+func (c *Controller) TriggerRepoMaintenance(
+	ctx context.Context,
+	repoRef string,
+	in *RepoMaintenanceInput,
+) (*RepoMaintenanceOutput, error) {
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	tasks, err := c.repoMaintenance.Trigger(ctx, repo, in.Tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := c.repoMaintenance.Stats(ctx, repo.GitUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RepoMaintenanceOutput{
+		State: job.JobStateScheduled,
+		Tasks: tasks,
+		Stats: stats,
+	}, nil
+}
+
+// GetRepoMaintenance returns the state of the latest maintenance and the current object stats of the repository.
+func (c *Controller) GetRepoMaintenance(
+	ctx context.Context,
+	repoRef string,
+) (*RepoMaintenanceOutput, error) {
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	stats, err := c.repoMaintenance.Stats(ctx, repo.GitUID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &RepoMaintenanceOutput{Stats: stats}
+
+	progress, err := c.repoMaintenance.GetProgress(ctx, repo.ID)
+	if errors.Is(err, repomaintenance.ErrNotFound) {
+		// the maintenance of the repository has never been run.
+		return out, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	out.State = progress.State
+	out.Progress = progress.Progress
+	out.Failure = progress.Failure
+
+	if progress.State == job.JobStateFinished && progress.Result != "" {
+		var result repomaintenance.Result
+		if err = json.Unmarshal([]byte(progress.Result), &result); err == nil {
+			out.Tasks = result.Tasks
+		}
+	}
+
+	return out, nil
+}
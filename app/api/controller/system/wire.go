@@ -15,7 +15,9 @@
 package system
 
 import (
+	"github.com/harness/gitness/app/services/repomaintenance"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/leader"
 	"github.com/harness/gitness/types"
 
 	"github.com/google/wire"
@@ -26,6 +28,17 @@ var WireSet = wire.NewSet(
 	NewController,
 )
 
-func ProvideController(principalStore store.PrincipalStore, config *types.Config) *Controller {
-	return NewController(principalStore, config)
+func ProvideController(
+	principalStore store.PrincipalStore,
+	complianceSnapshotStore store.ComplianceSnapshotStore,
+	gitUsageStore store.GitUsageStore,
+	repoStore store.RepoStore,
+	repoAliasStore store.RepoAliasStore,
+	principalInfoCache store.PrincipalInfoCache,
+	leaderElector *leader.Elector,
+	repoMaintenance *repomaintenance.Service,
+	config *types.Config,
+) *Controller {
+	return NewController(principalStore, complianceSnapshotStore, gitUsageStore, repoStore, repoAliasStore,
+		principalInfoCache, leaderElector, repoMaintenance, config)
 }
@@ -24,6 +24,7 @@ import (
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	pipelinetemplate "github.com/harness/gitness/app/pipeline/template"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
@@ -88,6 +89,10 @@ func (c *Controller) sanitizeCreateInput(in *CreateInput) error {
 		return err
 	}
 
+	if err := pipelinetemplate.Validate(in.Data); err != nil {
+		return usererror.BadRequestf("Invalid template data: %s", err)
+	}
+
 	in.Description = strings.TrimSpace(in.Description)
 	return check.Description(in.Description)
 }
@@ -20,7 +20,9 @@ import (
 	"strings"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	pipelinetemplate "github.com/harness/gitness/app/pipeline/template"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
@@ -88,7 +90,11 @@ func (c *Controller) sanitizeUpdateInput(in *UpdateInput) error {
 		}
 	}
 
-	// TODO: Validate Data
+	if in.Data != nil {
+		if err := pipelinetemplate.Validate(*in.Data); err != nil {
+			return usererror.BadRequestf("Invalid template data: %s", err)
+		}
+	}
 
 	return nil
 }
@@ -15,8 +15,11 @@
 package trigger
 
 import (
+	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/bmatcuk/doublestar/v4"
 )
 
 const (
@@ -24,6 +27,9 @@ const (
 	// TODO: Check whether this is sufficient for other SCM providers once we
 	// add support. For now it's good to have a limit and increase if needed.
 	triggerMaxSecretLength = 4096
+
+	// triggerMaxPaths defines the max allowed number of path patterns of a trigger.
+	triggerMaxPaths = 100
 )
 
 // checkSecret validates the secret of a trigger.
@@ -48,6 +54,23 @@ func checkActions(actions []enum.TriggerAction) error {
 	return nil
 }
 
+// checkPaths validates the path filters of a trigger.
+func checkPaths(paths types.TriggerPaths) error {
+	if len(paths.Include)+len(paths.Exclude) > triggerMaxPaths {
+		return check.NewValidationErrorf("A trigger can have at most %d path patterns.", triggerMaxPaths)
+	}
+
+	for _, patterns := range [][]string{paths.Include, paths.Exclude} {
+		for _, pattern := range patterns {
+			if pattern == "" || !doublestar.ValidatePattern(pattern) {
+				return check.NewValidationErrorf("The provided path pattern '%s' is invalid.", pattern)
+			}
+		}
+	}
+
+	return nil
+}
+
 // deduplicateActions de-duplicates the actions provided by in the trigger.
 func deduplicateActions(in []enum.TriggerAction) []enum.TriggerAction {
 	if len(in) == 0 {
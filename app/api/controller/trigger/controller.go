@@ -23,6 +23,7 @@ import (
 type Controller struct {
 	authorizer    authz.Authorizer
 	triggerStore  store.TriggerStore
+	scheduleStore store.ScheduleStore
 	uidCheck      check.PathUID
 	pipelineStore store.PipelineStore
 	repoStore     store.RepoStore
@@ -31,6 +32,7 @@ type Controller struct {
 func NewController(
 	authorizer authz.Authorizer,
 	triggerStore store.TriggerStore,
+	scheduleStore store.ScheduleStore,
 	uidCheck check.PathUID,
 	pipelineStore store.PipelineStore,
 	repoStore store.RepoStore,
@@ -38,6 +40,7 @@ func NewController(
 	return &Controller{
 		authorizer:    authorizer,
 		triggerStore:  triggerStore,
+		scheduleStore: scheduleStore,
 		uidCheck:      uidCheck,
 		pipelineStore: pipelineStore,
 		repoStore:     repoStore,
@@ -33,6 +33,7 @@ type CreateInput struct {
 	Secret      string               `json:"secret"`
 	Disabled    bool                 `json:"disabled"`
 	Actions     []enum.TriggerAction `json:"actions"`
+	Paths       types.TriggerPaths   `json:"paths"`
 }
 
 func (c *Controller) Create(
@@ -71,6 +72,7 @@ func (c *Controller) Create(
 		CreatedBy:   session.Principal.ID,
 		RepoID:      repo.ID,
 		Actions:     deduplicateActions(in.Actions),
+		Paths:       in.Paths,
 		UID:         in.UID,
 		PipelineID:  pipeline.ID,
 		Created:     now,
@@ -95,6 +97,9 @@ func (c *Controller) checkCreateInput(in *CreateInput) error {
 	if err := checkActions(in.Actions); err != nil {
 		return err
 	}
+	if err := checkPaths(in.Paths); err != nil {
+		return err
+	}
 	if err := c.uidCheck(in.UID, false); err != nil { //nolint:revive
 		return err
 	}
@@ -0,0 +1,116 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trigger
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/schedule"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+type ScheduleCreateInput struct {
+	Description string `json:"description"`
+	UID         string `json:"uid"`
+	Cron        string `json:"cron"`
+	Timezone    string `json:"timezone"`
+	Branch      string `json:"branch"`
+	Disabled    bool   `json:"disabled"`
+}
+
+// ScheduleCreate creates a new schedule that periodically triggers executions of the pipeline.
+func (c *Controller) ScheduleCreate(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineUID string,
+	in *ScheduleCreateInput,
+) (*types.Schedule, error) {
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo by ref: %w", err)
+	}
+	// Schedule permissions are associated with pipeline permissions. If a user has permissions
+	// to edit the pipeline, they will have permissions to create a schedule as well.
+	err = apiauth.CheckPipeline(ctx, c.authorizer, session, repo.Path, pipelineUID, enum.PermissionPipelineEdit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authorize pipeline: %w", err)
+	}
+
+	err = c.checkScheduleCreateInput(in)
+	if err != nil {
+		return nil, fmt.Errorf("invalid input: %w", err)
+	}
+
+	pipeline, err := c.pipelineStore.FindByUID(ctx, repo.ID, pipelineUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	now := time.Now()
+
+	nextRun, err := schedule.NextRun(in.Cron, in.Timezone, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate next run: %w", err)
+	}
+
+	sc := &types.Schedule{
+		Description: in.Description,
+		UID:         in.UID,
+		PipelineID:  pipeline.ID,
+		RepoID:      repo.ID,
+		CreatedBy:   session.Principal.ID,
+		Cron:        in.Cron,
+		Timezone:    in.Timezone,
+		Branch:      in.Branch,
+		Disabled:    in.Disabled,
+		NextRun:     nextRun.UnixMilli(),
+		Created:     now.UnixMilli(),
+		Updated:     now.UnixMilli(),
+		Version:     0,
+	}
+	err = c.scheduleStore.Create(ctx, sc)
+	if err != nil {
+		return nil, fmt.Errorf("schedule creation failed: %w", err)
+	}
+
+	return sc, nil
+}
+
+func (c *Controller) checkScheduleCreateInput(in *ScheduleCreateInput) error {
+	in.Description = strings.TrimSpace(in.Description)
+	in.Cron = strings.TrimSpace(in.Cron)
+	in.Timezone = strings.TrimSpace(in.Timezone)
+	in.Branch = strings.TrimSpace(in.Branch)
+
+	if err := check.Description(in.Description); err != nil {
+		return err
+	}
+	if err := check.CronExpression(in.Cron, in.Timezone); err != nil {
+		return err
+	}
+	if err := c.uidCheck(in.UID, false); err != nil { //nolint:revive
+		return err
+	}
+
+	return nil
+}
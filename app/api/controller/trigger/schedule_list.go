@@ -0,0 +1,62 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trigger
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ScheduleList lists the schedules of the pipeline.
+func (c *Controller) ScheduleList(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineUID string,
+	filter types.ListQueryFilter,
+) ([]*types.Schedule, int64, error) {
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find repo by ref: %w", err)
+	}
+	// Schedule permissions are associated with pipeline permissions. If a user has permissions
+	// to view the pipeline, they will have permissions to list schedules as well.
+	err = apiauth.CheckPipeline(ctx, c.authorizer, session, repo.Path, pipelineUID, enum.PermissionPipelineView)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to authorize pipeline: %w", err)
+	}
+
+	pipeline, err := c.pipelineStore.FindByUID(ctx, repo.ID, pipelineUID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	count, err := c.scheduleStore.Count(ctx, pipeline.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count schedules: %w", err)
+	}
+
+	schedules, err := c.scheduleStore.List(ctx, pipeline.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list schedules: %w", err)
+	}
+
+	return schedules, count, nil
+}
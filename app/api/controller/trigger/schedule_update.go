@@ -0,0 +1,151 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trigger
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/schedule"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ScheduleUpdateInput is used for updating a schedule.
+type ScheduleUpdateInput struct {
+	Description *string `json:"description"`
+	UID         *string `json:"uid"`
+	Cron        *string `json:"cron"`
+	Timezone    *string `json:"timezone"`
+	Branch      *string `json:"branch"`
+	Disabled    *bool   `json:"disabled"`
+}
+
+// ScheduleUpdate updates a schedule of the pipeline.
+// The next run is recalculated if the cron expression or timezone changes or the schedule gets enabled.
+func (c *Controller) ScheduleUpdate(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineUID string,
+	scheduleUID string,
+	in *ScheduleUpdateInput,
+) (*types.Schedule, error) {
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo by ref: %w", err)
+	}
+	// Schedule permissions are associated with pipeline permissions. If a user has permissions
+	// to edit the pipeline, they will have permissions to edit the schedule as well.
+	err = apiauth.CheckPipeline(ctx, c.authorizer, session, repo.Path, pipelineUID, enum.PermissionPipelineEdit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authorize pipeline: %w", err)
+	}
+
+	err = c.checkScheduleUpdateInput(in)
+	if err != nil {
+		return nil, fmt.Errorf("invalid input: %w", err)
+	}
+
+	pipeline, err := c.pipelineStore.FindByUID(ctx, repo.ID, pipelineUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	sc, err := c.scheduleStore.FindByUID(ctx, pipeline.ID, scheduleUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find schedule: %w", err)
+	}
+
+	return c.scheduleStore.UpdateOptLock(ctx,
+		sc, func(original *types.Schedule) error {
+			recalculate := false
+
+			if in.UID != nil {
+				original.UID = *in.UID
+			}
+			if in.Description != nil {
+				original.Description = *in.Description
+			}
+			if in.Cron != nil && *in.Cron != original.Cron {
+				original.Cron = *in.Cron
+				recalculate = true
+			}
+			if in.Timezone != nil && *in.Timezone != original.Timezone {
+				original.Timezone = *in.Timezone
+				recalculate = true
+			}
+			if in.Branch != nil {
+				original.Branch = *in.Branch
+			}
+			if in.Disabled != nil {
+				recalculate = recalculate || (original.Disabled && !*in.Disabled)
+				original.Disabled = *in.Disabled
+			}
+
+			if !recalculate {
+				return nil
+			}
+
+			nextRun, err := schedule.NextRun(original.Cron, original.Timezone, time.Now())
+			if err != nil {
+				return fmt.Errorf("failed to calculate next run: %w", err)
+			}
+
+			original.NextRun = nextRun.UnixMilli()
+
+			return nil
+		})
+}
+
+func (c *Controller) checkScheduleUpdateInput(in *ScheduleUpdateInput) error {
+	if in.UID != nil {
+		if err := c.uidCheck(*in.UID, false); err != nil {
+			return err
+		}
+	}
+
+	if in.Description != nil {
+		*in.Description = strings.TrimSpace(*in.Description)
+		if err := check.Description(*in.Description); err != nil {
+			return err
+		}
+	}
+
+	if in.Cron != nil {
+		*in.Cron = strings.TrimSpace(*in.Cron)
+		if err := check.CronExpression(*in.Cron, ""); err != nil {
+			return err
+		}
+	}
+
+	if in.Timezone != nil {
+		*in.Timezone = strings.TrimSpace(*in.Timezone)
+		if err := check.Timezone(*in.Timezone); err != nil {
+			return err
+		}
+	}
+
+	if in.Branch != nil {
+		*in.Branch = strings.TrimSpace(*in.Branch)
+	}
+
+	return nil
+}
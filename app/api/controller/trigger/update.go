@@ -31,6 +31,7 @@ type UpdateInput struct {
 	Description *string              `json:"description"`
 	UID         *string              `json:"uid"`
 	Actions     []enum.TriggerAction `json:"actions"`
+	Paths       *types.TriggerPaths  `json:"paths"`
 	Secret      *string              `json:"secret"`
 	Disabled    *bool                `json:"disabled"` // can be nil, so keeping it a pointer
 }
@@ -79,6 +80,9 @@ func (c *Controller) Update(
 			if in.Actions != nil {
 				original.Actions = deduplicateActions(in.Actions)
 			}
+			if in.Paths != nil {
+				original.Paths = *in.Paths
+			}
 			if in.Secret != nil {
 				original.Secret = *in.Secret
 			}
@@ -116,5 +120,11 @@ func (c *Controller) checkUpdateInput(in *UpdateInput) error {
 		}
 	}
 
+	if in.Paths != nil {
+		if err := checkPaths(*in.Paths); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
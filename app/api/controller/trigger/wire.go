@@ -30,9 +30,10 @@ var WireSet = wire.NewSet(
 func ProvideController(
 	authorizer authz.Authorizer,
 	triggerStore store.TriggerStore,
+	scheduleStore store.ScheduleStore,
 	uidCheck check.PathUID,
 	pipelineStore store.PipelineStore,
 	repoStore store.RepoStore,
 ) *Controller {
-	return NewController(authorizer, triggerStore, uidCheck, pipelineStore, repoStore)
+	return NewController(authorizer, triggerStore, scheduleStore, uidCheck, pipelineStore, repoStore)
 }
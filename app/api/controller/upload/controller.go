@@ -25,6 +25,7 @@ import (
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/blobscan"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/types"
@@ -42,16 +43,19 @@ type Controller struct {
 	authorizer authz.Authorizer
 	repoStore  store.RepoStore
 	blobStore  blob.Store
+	blobScan   *blobscan.Service
 }
 
 func NewController(authorizer authz.Authorizer,
 	repoStore store.RepoStore,
 	blobStore blob.Store,
+	blobScan *blobscan.Service,
 ) *Controller {
 	return &Controller{
 		authorizer: authorizer,
 		repoStore:  repoStore,
 		blobStore:  blobStore,
+		blobScan:   blobScan,
 	}
 }
 func (c *Controller) getRepoCheckAccess(ctx context.Context,
@@ -17,11 +17,13 @@ package upload
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/blobscan"
 	"github.com/harness/gitness/types/enum"
 
 	"github.com/google/uuid"
@@ -61,10 +63,16 @@ func (c *Controller) Upload(ctx context.Context,
 	fileName := fmt.Sprintf(fileNameFmt, uid, extn)
 
 	fileBucketPath := getFileBucketPath(repo.ID, fileName)
-	err = c.blobStore.Upload(ctx, bufReader, fileBucketPath)
+	err = c.blobScan.Upload(ctx, bufReader, fileBucketPath)
+	var infectedErr *blobscan.InfectedError
+	if errors.As(err, &infectedErr) {
+		return nil, usererror.UnprocessableEntityf(
+			"The file was rejected as it contains a threat (%s).", infectedErr.Threat)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to upload file: %w", err)
 	}
+
 	return &Result{
 		FilePath: fileName,
 	}, nil
@@ -18,7 +18,10 @@ import (
 	"context"
 
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/passkey"
+	"github.com/harness/gitness/app/services/usersigning"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/token"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
@@ -34,6 +37,12 @@ type Controller struct {
 	principalStore    store.PrincipalStore
 	tokenStore        store.TokenStore
 	membershipStore   store.MembershipStore
+	spaceStore        store.SpaceStore
+	tokenPolicies     token.Policies
+	userSigning       *usersigning.Service
+	passkeys          *passkey.Service
+	workingHoursStore store.WorkingHoursStore
+	patRequireSpaces  bool
 }
 
 func NewController(
@@ -43,6 +52,12 @@ func NewController(
 	principalStore store.PrincipalStore,
 	tokenStore store.TokenStore,
 	membershipStore store.MembershipStore,
+	spaceStore store.SpaceStore,
+	tokenPolicies token.Policies,
+	userSigning *usersigning.Service,
+	passkeys *passkey.Service,
+	workingHoursStore store.WorkingHoursStore,
+	patRequireSpaces bool,
 ) *Controller {
 	return &Controller{
 		tx:                tx,
@@ -51,6 +66,12 @@ func NewController(
 		principalStore:    principalStore,
 		tokenStore:        tokenStore,
 		membershipStore:   membershipStore,
+		spaceStore:        spaceStore,
+		tokenPolicies:     tokenPolicies,
+		userSigning:       userSigning,
+		passkeys:          passkeys,
+		workingHoursStore: workingHoursStore,
+		patRequireSpaces:  patRequireSpaces,
 	}
 }
 
@@ -16,9 +16,11 @@ package user
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/token"
 	"github.com/harness/gitness/types"
@@ -29,6 +31,8 @@ import (
 type CreateTokenInput struct {
 	UID      string         `json:"uid"`
 	Lifetime *time.Duration `json:"lifetime"`
+	// Spaces optionally restricts the token to the provided spaces (and everything within them).
+	Spaces []string `json:"spaces"`
 }
 
 /*
@@ -57,17 +61,69 @@ func (c *Controller) CreateAccessToken(
 		return nil, err
 	}
 
-	token, jwtToken, err := token.CreatePAT(
-		ctx,
-		c.tokenStore,
-		&session.Principal,
-		user,
-		in.UID,
-		in.Lifetime,
+	spaceIDs, err := c.getTokenSpaceIDs(ctx, session, in.Spaces)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		tkn      *types.Token
+		jwtToken string
 	)
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		tkn, jwtToken, err = token.CreatePAT(
+			ctx,
+			c.tokenStore,
+			c.tokenPolicies,
+			&session.Principal,
+			user,
+			in.UID,
+			in.Lifetime,
+			spaceIDs,
+		)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return &types.TokenResponse{Token: *token, AccessToken: jwtToken}, nil
+	return &types.TokenResponse{Token: *tkn, AccessToken: jwtToken}, nil
+}
+
+// getTokenSpaceIDs returns the ids of the spaces the token is restricted to.
+// The caller is required to have access to every space of the list.
+func (c *Controller) getTokenSpaceIDs(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRefs []string,
+) ([]int64, error) {
+	if len(spaceRefs) == 0 {
+		if c.patRequireSpaces {
+			return nil, usererror.BadRequest("Personal access tokens must be restricted to at least one space")
+		}
+
+		return nil, nil
+	}
+
+	spaceIDs := make([]int64, 0, len(spaceRefs))
+	seen := make(map[int64]struct{}, len(spaceRefs))
+	for _, spaceRef := range spaceRefs {
+		space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find space %q: %w", spaceRef, err)
+		}
+
+		if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceView, false); err != nil {
+			return nil, err
+		}
+
+		if _, ok := seen[space.ID]; ok {
+			continue
+		}
+		seen[space.ID] = struct{}{}
+
+		spaceIDs = append(spaceIDs, space.ID)
+	}
+
+	return spaceIDs, nil
 }
@@ -36,6 +36,8 @@ type LoginInput struct {
 	Password        string `json:"password"`
 }
 
+var errPasskeyRequired = usererror.Forbidden("A passkey is required to sign in.")
+
 /*
  * Login attempts to login as a specific user - returns the session token if successful.
  */
@@ -45,36 +47,66 @@ func (c *Controller) Login(
 ) (*types.TokenResponse, error) {
 	// no auth check required, password is used for it.
 
-	user, err := findUserFromUID(ctx, c.principalStore, in.LoginIdentifier)
+	user, err := c.findLoginUser(ctx, in.LoginIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = verifyPassword(ctx, user, in.Password); err != nil {
+		return nil, err
+	}
+
+	passkeyRequired, err := c.passkeys.Required(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	if passkeyRequired {
+		return nil, errPasskeyRequired
+	}
+
+	return c.createSession(ctx, user)
+}
+
+// findLoginUser finds the user by uid or email.
+func (c *Controller) findLoginUser(ctx context.Context, loginIdentifier string) (*types.User, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, loginIdentifier)
 	if errors.Is(err, store.ErrResourceNotFound) {
-		user, err = findUserFromEmail(ctx, c.principalStore, in.LoginIdentifier)
+		user, err = findUserFromEmail(ctx, c.principalStore, loginIdentifier)
 	}
 
 	// always return not found for security reasons.
 	if err != nil {
 		log.Ctx(ctx).Debug().Err(err).
-			Str("user_uid", in.LoginIdentifier).
+			Str("user_uid", loginIdentifier).
 			Msgf("failed to retrieve user during login.")
 		return nil, usererror.ErrNotFound
 	}
 
-	err = bcrypt.CompareHashAndPassword(
+	return user, nil
+}
+
+func verifyPassword(ctx context.Context, user *types.User, password string) error {
+	err := bcrypt.CompareHashAndPassword(
 		[]byte(user.Password),
-		[]byte(in.Password),
+		[]byte(password),
 	)
 	if err != nil {
-		log.Debug().Err(err).
+		log.Ctx(ctx).Debug().Err(err).
 			Str("user_uid", user.UID).
 			Msg("invalid password")
 
-		return nil, usererror.ErrNotFound
+		return usererror.ErrNotFound
 	}
 
+	return nil
+}
+
+func (c *Controller) createSession(ctx context.Context, user *types.User) (*types.TokenResponse, error) {
 	tokenUID, err := generateSessionTokenUID()
 	if err != nil {
 		return nil, err
 	}
-	token, jwtToken, err := token.CreateUserSession(ctx, c.tokenStore, user, tokenUID)
+	token, jwtToken, err := token.CreateUserSession(ctx, c.tokenStore, c.tokenPolicies, user, tokenUID)
 	if err != nil {
 		return nil, err
 	}
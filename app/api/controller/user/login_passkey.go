@@ -0,0 +1,74 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+)
+
+type LoginPasskeyBeginInput struct {
+	LoginIdentifier string `json:"login_identifier"`
+
+	// Password is required unless passwordless login is enabled, then the passkey is the second factor.
+	Password string `json:"password"`
+}
+
+type LoginPasskeyFinishInput struct {
+	ChallengeUID string          `json:"challenge_uid"`
+	Credential   json.RawMessage `json:"credential"`
+}
+
+// LoginPasskeyBegin begins the passkey login ceremony of a user.
+// The returned options are passed to the browser, and its response to LoginPasskeyFinish.
+func (c *Controller) LoginPasskeyBegin(
+	ctx context.Context,
+	in *LoginPasskeyBeginInput,
+) (*types.PasskeyCeremony, error) {
+	// no auth check required, the passkey (and the password) is used for it.
+
+	if !c.passkeys.Passwordless() && in.Password == "" {
+		return nil, usererror.BadRequest("Password is required.")
+	}
+
+	user, err := c.findLoginUser(ctx, in.LoginIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.Password != "" {
+		if err = verifyPassword(ctx, user, in.Password); err != nil {
+			return nil, err
+		}
+	}
+
+	return c.passkeys.BeginLogin(ctx, user)
+}
+
+// LoginPasskeyFinish verifies the passkey of the user - returns the session token if successful.
+func (c *Controller) LoginPasskeyFinish(
+	ctx context.Context,
+	in *LoginPasskeyFinishInput,
+) (*types.TokenResponse, error) {
+	user, err := c.passkeys.FinishLogin(ctx, in.ChallengeUID, in.Credential)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.createSession(ctx, user)
+}
@@ -0,0 +1,108 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"encoding/json"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type RegisterPasskeyInput struct {
+	ChallengeUID string          `json:"challenge_uid"`
+	Name         string          `json:"name"`
+	Credential   json.RawMessage `json:"credential"`
+}
+
+// ListPasskeys lists the passkeys of a user.
+func (c *Controller) ListPasskeys(ctx context.Context, session *auth.Session,
+	userUID string) ([]*types.Passkey, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure principal has required permissions on parent.
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserView); err != nil {
+		return nil, err
+	}
+
+	return c.passkeys.List(ctx, user.ID)
+}
+
+// BeginPasskeyRegistration begins the registration of a new passkey of a user.
+// The returned options are passed to the browser, and its response to RegisterPasskey.
+func (c *Controller) BeginPasskeyRegistration(ctx context.Context, session *auth.Session,
+	userUID string) (*types.PasskeyCeremony, error) {
+	user, err := c.findPasskeyOwner(ctx, session, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.passkeys.BeginRegistration(ctx, user)
+}
+
+// RegisterPasskey verifies the browser's response to the registration challenge and stores the new passkey.
+func (c *Controller) RegisterPasskey(ctx context.Context, session *auth.Session,
+	userUID string, in *RegisterPasskeyInput) (*types.Passkey, error) {
+	user, err := c.findPasskeyOwner(ctx, session, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.passkeys.FinishRegistration(ctx, user, in.ChallengeUID, in.Name, in.Credential)
+}
+
+// DeletePasskey deletes a passkey of a user.
+func (c *Controller) DeletePasskey(ctx context.Context, session *auth.Session,
+	userUID string, passkeyID int64) error {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return err
+	}
+
+	// Ensure principal has required permissions on parent.
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return err
+	}
+
+	return c.passkeys.Delete(ctx, user, passkeyID)
+}
+
+// findPasskeyOwner finds the user a passkey gets registered for.
+// Passkeys are created by the user's own authenticator, so they can't be registered on behalf of other users.
+func (c *Controller) findPasskeyOwner(ctx context.Context, session *auth.Session,
+	userUID string) (*types.User, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure principal has required permissions on parent.
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	if session.Principal.ID != user.ID {
+		return nil, usererror.Forbidden("Passkeys can only be registered by the user.")
+	}
+
+	return user, nil
+}
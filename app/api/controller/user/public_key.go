@@ -0,0 +1,80 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type CreatePublicKeyInput struct {
+	// Content is the armored OpenPGP public key or the SSH public key (in authorized_keys format).
+	Content string `json:"content"`
+	Comment string `json:"comment"`
+}
+
+// ListPublicKeys lists the public keys a user registered to get their signatures verified.
+func (c *Controller) ListPublicKeys(ctx context.Context, session *auth.Session,
+	userUID string) ([]*types.PublicKey, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure principal has required permissions on parent.
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserView); err != nil {
+		return nil, err
+	}
+
+	return c.userSigning.ListPublicKeys(ctx, user.ID)
+}
+
+// CreatePublicKey registers a public key of a user.
+// Commits and tags signed with the key are reported as verified.
+func (c *Controller) CreatePublicKey(ctx context.Context, session *auth.Session,
+	userUID string, in *CreatePublicKeyInput) (*types.PublicKey, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure principal has required permissions on parent.
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	return c.userSigning.AddPublicKey(ctx, user.ID, in.Content, in.Comment)
+}
+
+// DeletePublicKey deletes a public key of a user.
+// Commits and tags signed with the key are no longer reported as verified.
+func (c *Controller) DeletePublicKey(ctx context.Context, session *auth.Session,
+	userUID string, keyID int64) error {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return err
+	}
+
+	// Ensure principal has required permissions on parent.
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return err
+	}
+
+	return c.userSigning.DeletePublicKey(ctx, user.ID, keyID)
+}
@@ -55,7 +55,7 @@ func (c *Controller) Register(ctx context.Context, sysCtrl *system.Controller,
 	}
 
 	// TODO: how should we name session tokens?
-	token, jwtToken, err := token.CreateUserSession(ctx, c.tokenStore, user, "register")
+	token, jwtToken, err := token.CreateUserSession(ctx, c.tokenStore, c.tokenPolicies, user, "register")
 	if err != nil {
 		return nil, fmt.Errorf("failed to create token after successful user creation: %w", err)
 	}
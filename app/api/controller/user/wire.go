@@ -16,8 +16,12 @@ package user
 
 import (
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/passkey"
+	"github.com/harness/gitness/app/services/usersigning"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/token"
 	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 
 	"github.com/google/wire"
@@ -29,12 +33,18 @@ var WireSet = wire.NewSet(
 )
 
 func ProvideController(
+	config *types.Config,
 	tx dbtx.Transactor,
 	principalUIDCheck check.PrincipalUID,
 	authorizer authz.Authorizer,
 	principalStore store.PrincipalStore,
 	tokenStore store.TokenStore,
 	membershipStore store.MembershipStore,
+	spaceStore store.SpaceStore,
+	tokenPolicies token.Policies,
+	userSigning *usersigning.Service,
+	passkeys *passkey.Service,
+	workingHoursStore store.WorkingHoursStore,
 ) *Controller {
 	return NewController(
 		tx,
@@ -42,5 +52,11 @@ func ProvideController(
 		authorizer,
 		principalStore,
 		tokenStore,
-		membershipStore)
+		membershipStore,
+		spaceStore,
+		tokenPolicies,
+		userSigning,
+		passkeys,
+		workingHoursStore,
+		config.Token.PATRequireSpaces)
 }
@@ -0,0 +1,160 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+// WorkingHoursInput is the input for setting the working hours of a user.
+type WorkingHoursInput struct {
+	Timezone string         `json:"timezone"`
+	Days     []enum.Weekday `json:"days"`
+	Start    string         `json:"start"`
+	End      string         `json:"end"`
+}
+
+func (in *WorkingHoursInput) sanitize() error {
+	if err := check.Timezone(in.Timezone); err != nil {
+		return err
+	}
+
+	if len(in.Days) == 0 {
+		return usererror.BadRequest("At least one working day is required.")
+	}
+
+	seen := make(map[enum.Weekday]struct{}, len(in.Days))
+	days := make([]enum.Weekday, 0, len(in.Days))
+	for _, day := range in.Days {
+		day, ok := day.Sanitize()
+		if !ok {
+			return usererror.BadRequest("Invalid working day provided.")
+		}
+		if _, ok := seen[day]; ok {
+			continue
+		}
+		seen[day] = struct{}{}
+		days = append(days, day)
+	}
+	in.Days = days
+
+	start, err := time.Parse(types.WorkingHoursTimeLayout, in.Start)
+	if err != nil {
+		return usererror.BadRequestf("The start of the working hours has to be in the format %q.",
+			types.WorkingHoursTimeLayout)
+	}
+
+	end, err := time.Parse(types.WorkingHoursTimeLayout, in.End)
+	if err != nil {
+		return usererror.BadRequestf("The end of the working hours has to be in the format %q.",
+			types.WorkingHoursTimeLayout)
+	}
+
+	if start.Equal(end) {
+		return usererror.BadRequest("The start and the end of the working hours can't be the same.")
+	}
+
+	in.Start = start.Format(types.WorkingHoursTimeLayout)
+	in.End = end.Format(types.WorkingHoursTimeLayout)
+
+	return nil
+}
+
+// FindWorkingHours returns the working hours profile of a user.
+func (c *Controller) FindWorkingHours(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+) (*types.WorkingHours, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserView); err != nil {
+		return nil, err
+	}
+
+	return c.workingHoursStore.Find(ctx, user.ID)
+}
+
+// SetWorkingHours creates or replaces the working hours profile of a user.
+func (c *Controller) SetWorkingHours(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	in *WorkingHoursInput,
+) (*types.WorkingHours, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	if err = in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	hours := &types.WorkingHours{
+		PrincipalID: user.ID,
+		Timezone:    in.Timezone,
+		Days:        in.Days,
+		Start:       in.Start,
+		End:         in.End,
+		Created:     now,
+		Updated:     now,
+	}
+
+	if err = c.workingHoursStore.Upsert(ctx, hours); err != nil {
+		return nil, fmt.Errorf("failed to store working hours: %w", err)
+	}
+
+	return hours, nil
+}
+
+// DeleteWorkingHours deletes the working hours profile of a user.
+func (c *Controller) DeleteWorkingHours(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+) error {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return err
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return err
+	}
+
+	if err = c.workingHoursStore.Delete(ctx, user.ID); err != nil {
+		return fmt.Errorf("failed to delete working hours: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,223 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package variable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	maxKeyLength   = 255
+	maxValueLength = 64 << 10
+
+	// reservedKeyPrefix is the prefix of the environment variables set by the system,
+	// they can't be overridden by variables.
+	reservedKeyPrefix = "DRONE_"
+)
+
+var keyRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+type Controller struct {
+	tx                 dbtx.Transactor
+	authorizer         authz.Authorizer
+	repoStore          store.RepoStore
+	spaceStore         store.SpaceStore
+	secretStore        store.SecretStore
+	variableStore      store.VariableStore
+	variableAuditStore store.VariableAuditStore
+}
+
+func NewController(
+	tx dbtx.Transactor,
+	authorizer authz.Authorizer,
+	repoStore store.RepoStore,
+	spaceStore store.SpaceStore,
+	secretStore store.SecretStore,
+	variableStore store.VariableStore,
+	variableAuditStore store.VariableAuditStore,
+) *Controller {
+	return &Controller{
+		tx:                 tx,
+		authorizer:         authorizer,
+		repoStore:          repoStore,
+		spaceStore:         spaceStore,
+		secretStore:        secretStore,
+		variableStore:      variableStore,
+		variableAuditStore: variableAuditStore,
+	}
+}
+
+// parent is the repository or the space that owns variables.
+type parent struct {
+	typ enum.VariableParent
+	id  int64
+
+	// secretSpace is the space in which the secrets referenced by the variables are.
+	secretSpace *types.Space
+}
+
+// getParentCheckAccess fetches the repository or the space that owns variables
+// and checks if the current user has permission to read (or edit) its variables.
+func (c *Controller) getParentCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	parentType enum.VariableParent,
+	parentRef string,
+	edit bool,
+) (*parent, error) {
+	switch parentType {
+	case enum.VariableParentRepo:
+		repo, err := c.repoStore.FindByRef(ctx, parentRef)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find repository: %w", err)
+		}
+
+		permission := enum.PermissionRepoView
+		if edit {
+			permission = enum.PermissionRepoEdit
+		}
+
+		if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, permission, false); err != nil {
+			return nil, fmt.Errorf("access check failed: %w", err)
+		}
+
+		secretSpace, err := c.spaceStore.Find(ctx, repo.ParentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find parent space of the repository: %w", err)
+		}
+
+		return &parent{typ: parentType, id: repo.ID, secretSpace: secretSpace}, nil
+
+	case enum.VariableParentSpace:
+		space, err := c.spaceStore.FindByRef(ctx, parentRef)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find space: %w", err)
+		}
+
+		permission := enum.PermissionSpaceView
+		if edit {
+			permission = enum.PermissionSpaceEdit
+		}
+
+		if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, permission, false); err != nil {
+			return nil, fmt.Errorf("access check failed: %w", err)
+		}
+
+		return &parent{typ: parentType, id: space.ID, secretSpace: space}, nil
+
+	default:
+		return nil, usererror.BadRequestf("Variable parent type '%s' is not supported.", parentType)
+	}
+}
+
+// checkSecret verifies that the referenced secret exists and that the current user may use it.
+func (c *Controller) checkSecret(
+	ctx context.Context,
+	session *auth.Session,
+	p *parent,
+	secretUID string,
+) error {
+	if secretUID == "" {
+		return nil
+	}
+
+	err := apiauth.CheckSecret(ctx, c.authorizer, session, p.secretSpace.Path, secretUID, enum.PermissionSecretAccess)
+	if err != nil {
+		return fmt.Errorf("access check of the secret failed: %w", err)
+	}
+
+	_, err = c.secretStore.FindByUID(ctx, p.secretSpace.ID, secretUID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return usererror.BadRequestf("Secret %q doesn't exist in space %q.", secretUID, p.secretSpace.Path)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find secret: %w", err)
+	}
+
+	return nil
+}
+
+// audit records a change of a variable in the audit log.
+func (c *Controller) audit(
+	ctx context.Context,
+	session *auth.Session,
+	variable *types.Variable,
+	action enum.VariableAuditAction,
+) error {
+	entry := &types.VariableAudit{
+		ParentID:    variable.ParentID,
+		ParentType:  variable.ParentType,
+		Key:         variable.Key,
+		Action:      action,
+		PrincipalID: session.Principal.ID,
+		Created:     time.Now().UnixMilli(),
+	}
+
+	if action != enum.VariableAuditActionDeleted {
+		entry.Value = variable.Value
+		entry.SecretUID = variable.SecretUID
+	}
+
+	if err := c.variableAuditStore.Create(ctx, entry); err != nil {
+		return fmt.Errorf("failed to create variable audit log entry: %w", err)
+	}
+
+	return nil
+}
+
+func checkKey(key string) error {
+	if key == "" {
+		return usererror.BadRequest("Variable key must be provided.")
+	}
+	if len(key) > maxKeyLength {
+		return usererror.BadRequestf("Variable key can be at most %d characters long.", maxKeyLength)
+	}
+	if !keyRegex.MatchString(key) {
+		return usererror.BadRequest(
+			"Variable key can only contain letters, digits and underscores, and can't start with a digit.")
+	}
+	if strings.HasPrefix(strings.ToUpper(key), reservedKeyPrefix) {
+		return usererror.BadRequestf("Variable key can't start with %q, it's reserved for system variables.",
+			reservedKeyPrefix)
+	}
+
+	return nil
+}
+
+func checkValue(value, secretUID string) error {
+	if value != "" && secretUID != "" {
+		return usererror.BadRequest("Variable can either have a value or reference a secret, but not both.")
+	}
+	if len(value) > maxValueLength {
+		return usererror.BadRequestf("Variable value can be at most %d bytes long.", maxValueLength)
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package variable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// CreateInput is the input used for creating a variable.
+// The variable either has a plain value, or references a secret by its UID.
+type CreateInput struct {
+	Key       string `json:"key"`
+	Value     string `json:"value"`
+	SecretUID string `json:"secret_uid"`
+}
+
+func (in *CreateInput) sanitize() error {
+	in.Key = strings.TrimSpace(in.Key)
+	in.SecretUID = strings.TrimSpace(in.SecretUID)
+
+	if err := checkKey(in.Key); err != nil {
+		return err
+	}
+
+	return checkValue(in.Value, in.SecretUID)
+}
+
+// Create creates a new variable of a repository or a space.
+func (c *Controller) Create(
+	ctx context.Context,
+	session *auth.Session,
+	parentType enum.VariableParent,
+	parentRef string,
+	in *CreateInput,
+) (*types.Variable, error) {
+	p, err := c.getParentCheckAccess(ctx, session, parentType, parentRef, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	if err = c.checkSecret(ctx, session, p, in.SecretUID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	variable := &types.Variable{
+		ParentID:   p.id,
+		ParentType: p.typ,
+		Key:        in.Key,
+		Value:      in.Value,
+		SecretUID:  in.SecretUID,
+		CreatedBy:  session.Principal.ID,
+		UpdatedBy:  session.Principal.ID,
+		Created:    now,
+		Updated:    now,
+	}
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := c.variableStore.Create(ctx, variable); err != nil {
+			return err
+		}
+
+		return c.audit(ctx, session, variable, enum.VariableAuditActionCreated)
+	})
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, usererror.Conflict(fmt.Sprintf("Variable %q already exists.", in.Key))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create variable: %w", err)
+	}
+
+	return variable, nil
+}
@@ -0,0 +1,45 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package variable
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Find finds a variable of a repository or a space.
+func (c *Controller) Find(
+	ctx context.Context,
+	session *auth.Session,
+	parentType enum.VariableParent,
+	parentRef string,
+	key string,
+) (*types.Variable, error) {
+	p, err := c.getParentCheckAccess(ctx, session, parentType, parentRef, false)
+	if err != nil {
+		return nil, err
+	}
+
+	variable, err := c.variableStore.FindByKey(ctx, p.typ, p.id, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find variable: %w", err)
+	}
+
+	return variable, nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package variable
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// List lists the variables of a repository or a space.
+func (c *Controller) List(
+	ctx context.Context,
+	session *auth.Session,
+	parentType enum.VariableParent,
+	parentRef string,
+	filter *types.ListQueryFilter,
+) ([]*types.Variable, int64, error) {
+	p, err := c.getParentCheckAccess(ctx, session, parentType, parentRef, false)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	count, err := c.variableStore.Count(ctx, p.typ, p.id, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count variables: %w", err)
+	}
+
+	variables, err := c.variableStore.List(ctx, p.typ, p.id, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list variables: %w", err)
+	}
+
+	return variables, count, nil
+}
+
+// ListAudit lists the audit log of the variables of a repository or a space, newest first.
+func (c *Controller) ListAudit(
+	ctx context.Context,
+	session *auth.Session,
+	parentType enum.VariableParent,
+	parentRef string,
+	filter *types.ListQueryFilter,
+) ([]*types.VariableAudit, int64, error) {
+	p, err := c.getParentCheckAccess(ctx, session, parentType, parentRef, false)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	count, err := c.variableAuditStore.Count(ctx, p.typ, p.id, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count variable audit log entries: %w", err)
+	}
+
+	entries, err := c.variableAuditStore.List(ctx, p.typ, p.id, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list variable audit log entries: %w", err)
+	}
+
+	return entries, count, nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package variable
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// UpdateInput is the input used for updating a variable.
+// Setting the value removes the secret reference and vice versa.
+type UpdateInput struct {
+	Value     *string `json:"value"`
+	SecretUID *string `json:"secret_uid"`
+}
+
+func (in *UpdateInput) sanitize(variable *types.Variable) error {
+	if in.SecretUID != nil {
+		secretUID := strings.TrimSpace(*in.SecretUID)
+		in.SecretUID = &secretUID
+	}
+
+	value, secretUID := variable.Value, variable.SecretUID
+	switch {
+	case in.Value != nil && in.SecretUID != nil:
+		value, secretUID = *in.Value, *in.SecretUID
+	case in.Value != nil:
+		value, secretUID = *in.Value, ""
+	case in.SecretUID != nil:
+		value, secretUID = "", *in.SecretUID
+	}
+
+	if err := checkValue(value, secretUID); err != nil {
+		return err
+	}
+
+	in.Value, in.SecretUID = &value, &secretUID
+
+	return nil
+}
+
+// Update updates a variable of a repository or a space.
+func (c *Controller) Update(
+	ctx context.Context,
+	session *auth.Session,
+	parentType enum.VariableParent,
+	parentRef string,
+	key string,
+	in *UpdateInput,
+) (*types.Variable, error) {
+	p, err := c.getParentCheckAccess(ctx, session, parentType, parentRef, true)
+	if err != nil {
+		return nil, err
+	}
+
+	variable, err := c.variableStore.FindByKey(ctx, p.typ, p.id, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find variable: %w", err)
+	}
+
+	if err = in.sanitize(variable); err != nil {
+		return nil, err
+	}
+
+	if *in.Value == variable.Value && *in.SecretUID == variable.SecretUID {
+		return variable, nil
+	}
+
+	if *in.SecretUID != variable.SecretUID {
+		if err = c.checkSecret(ctx, session, p, *in.SecretUID); err != nil {
+			return nil, err
+		}
+	}
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		variable, err = c.variableStore.UpdateOptLock(ctx, variable, func(variable *types.Variable) error {
+			variable.Value = *in.Value
+			variable.SecretUID = *in.SecretUID
+			variable.UpdatedBy = session.Principal.ID
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		return c.audit(ctx, session, variable, enum.VariableAuditActionUpdated)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update variable: %w", err)
+	}
+
+	return variable, nil
+}
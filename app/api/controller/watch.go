@@ -0,0 +1,39 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types/enum"
+)
+
+// WatchDefaultLevel is the watch level of users who didn't set one explicitly.
+const WatchDefaultLevel = enum.WatchLevelParticipating
+
+// WatchInput is used for setting the watch level of a repository or a pull request.
+type WatchInput struct {
+	Level enum.WatchLevel `json:"level"`
+}
+
+func (in *WatchInput) Sanitize() error {
+	level, ok := in.Level.Sanitize()
+	if !ok {
+		return usererror.BadRequestf("Invalid watch level %q.", in.Level)
+	}
+
+	in.Level = level
+
+	return nil
+}
@@ -15,65 +15,21 @@
 package webhook
 
 import (
-	"net"
-	"net/url"
+	"errors"
 
 	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/services/webhook"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
 )
 
 const (
-	// webhookMaxURLLength defines the max allowed length of a webhook URL.
-	webhookMaxURLLength = 2048
 	// webhookMaxSecretLength defines the max allowed length of a webhook secret.
 	webhookMaxSecretLength = 4096
 )
 
 var ErrInternalWebhookOperationNotAllowed = usererror.Forbidden("changes to internal webhooks are not allowed")
 
-// checkURL validates the url of a webhook.
-func checkURL(rawURL string, allowLoopback bool, allowPrivateNetwork bool) error {
-	// check URL
-	if len(rawURL) > webhookMaxURLLength {
-		return check.NewValidationErrorf("The URL of a webhook can be at most %d characters long.",
-			webhookMaxURLLength)
-	}
-
-	parsedURL, err := url.Parse(rawURL)
-	if err != nil {
-		return check.NewValidationErrorf("The provided webhook url is invalid: %s", err)
-	}
-
-	host := parsedURL.Hostname()
-	if host == "" {
-		return check.NewValidationError("The URL of a webhook has to have a non-empty host.")
-	}
-
-	// basic validation for loopback / private network addresses (only sanitary to give user an early error)
-	// IMPORTANT: during webook execution loopback / private network addresses are blocked (handles DNS resolution)
-
-	if host == "localhost" {
-		return check.NewValidationError("localhost is not allowed.")
-	}
-
-	if ip := net.ParseIP(host); ip != nil {
-		if !allowLoopback && ip.IsLoopback() {
-			return check.NewValidationError("Loopback IP addresses are not allowed.")
-		}
-
-		if !allowPrivateNetwork && ip.IsPrivate() {
-			return check.NewValidationError("Private IP addresses are not allowed.")
-		}
-	}
-
-	if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
-		return check.NewValidationError("The scheme of a webhook must be either http or https.")
-	}
-
-	return nil
-}
-
 // checkSecret validates the secret of a webhook.
 func checkSecret(secret string) error {
 	if len(secret) > webhookMaxSecretLength {
@@ -96,6 +52,22 @@ func checkTriggers(triggers []enum.WebhookTrigger) error {
 	return nil
 }
 
+// checkPayloadTemplate validates the payload template of a webhook.
+func checkPayloadTemplate(templateType enum.WebhookPayloadTemplateType, tmpl string) error {
+	if templateType != "" {
+		if _, ok := templateType.Sanitize(); !ok {
+			return check.NewValidationErrorf("The provided payload template type '%s' is invalid.", templateType)
+		}
+	}
+
+	err := webhook.CheckPayloadTemplate(templateType, tmpl)
+	if errors.Is(err, webhook.ErrPayloadTemplateInvalid) {
+		return check.NewValidationError(err.Error())
+	}
+
+	return err
+}
+
 // deduplicateTriggers de-duplicates the triggers provided by the user.
 func deduplicateTriggers(in []enum.WebhookTrigger) []enum.WebhookTrigger {
 	if len(in) == 0 {
@@ -22,6 +22,7 @@ import (
 
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/webhook"
 	"github.com/harness/gitness/app/store/database/migrate"
 	"github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
@@ -41,6 +42,9 @@ type CreateInput struct {
 	Enabled     bool                  `json:"enabled"`
 	Insecure    bool                  `json:"insecure"`
 	Triggers    []enum.WebhookTrigger `json:"triggers"`
+
+	PayloadTemplateType enum.WebhookPayloadTemplateType `json:"payload_template_type"`
+	PayloadTemplate     string                          `json:"payload_template"`
 }
 
 // Create creates a new webhook.
@@ -102,6 +106,8 @@ func (c *Controller) Create(
 		Insecure:              in.Insecure,
 		Triggers:              deduplicateTriggers(in.Triggers),
 		LatestExecutionResult: nil,
+		PayloadTemplateType:   in.PayloadTemplateType,
+		PayloadTemplate:       in.PayloadTemplate,
 	}
 
 	err = c.webhookStore.Create(ctx, hook)
@@ -139,13 +145,16 @@ func checkCreateInput(in *CreateInput, allowLoopback bool, allowPrivateNetwork b
 	if err := check.Description(in.Description); err != nil {
 		return err
 	}
-	if err := checkURL(in.URL, allowLoopback, allowPrivateNetwork); err != nil {
+	if err := webhook.CheckURL(in.URL, allowLoopback, allowPrivateNetwork); err != nil {
 		return err
 	}
 	if err := checkSecret(in.Secret); err != nil {
 		return err
 	}
-	if err := checkTriggers(in.Triggers); err != nil { //nolint:revive
+	if err := checkTriggers(in.Triggers); err != nil {
+		return err
+	}
+	if err := checkPayloadTemplate(in.PayloadTemplateType, in.PayloadTemplate); err != nil { //nolint:revive
 		return err
 	}
 
@@ -0,0 +1,90 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/webhook"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+type PreviewPayloadInput struct {
+	PayloadTemplateType enum.WebhookPayloadTemplateType `json:"payload_template_type"`
+	PayloadTemplate     string                          `json:"payload_template"`
+
+	// Trigger selects the sample payload the template is rendered with. Ignored if a payload is provided.
+	Trigger enum.WebhookTrigger `json:"trigger"`
+	// Payload is the canonical payload the template is rendered with, e.g. the body of a previous execution.
+	Payload json.RawMessage `json:"payload"`
+}
+
+type PreviewPayloadOutput struct {
+	// Payload is the canonical payload the template got rendered with.
+	Payload json.RawMessage `json:"payload"`
+	// Rendered is the payload that would be sent by a webhook with the template.
+	Rendered string `json:"rendered"`
+}
+
+// PreviewPayload renders a payload template without storing it,
+// allowing the user to verify the template before configuring it on a webhook.
+func (c *Controller) PreviewPayload(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *PreviewPayloadInput,
+) (*PreviewPayloadOutput, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.PayloadTemplateType == "" {
+		return nil, usererror.BadRequest("Payload template type must be provided.")
+	}
+	if err = checkPayloadTemplate(in.PayloadTemplateType, in.PayloadTemplate); err != nil {
+		return nil, err
+	}
+
+	payload := in.Payload
+	if len(payload) == 0 {
+		trigger, ok := in.Trigger.Sanitize()
+		if !ok || trigger == "" {
+			return nil, check.NewValidationErrorf("The provided webhook trigger '%s' is invalid.", in.Trigger)
+		}
+
+		payload, err = json.Marshal(c.webhookService.SamplePayload(trigger, repo, &session.Principal))
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize sample payload: %w", err)
+		}
+	} else if !json.Valid(payload) {
+		return nil, usererror.BadRequest("The provided payload isn't valid JSON.")
+	}
+
+	rendered, err := webhook.RenderPayload(ctx, in.PayloadTemplateType, in.PayloadTemplate, payload)
+	if err != nil {
+		return nil, usererror.BadRequestf("Failed to render payload template: %s", err)
+	}
+
+	return &PreviewPayloadOutput{
+		Payload:  payload,
+		Rendered: string(rendered),
+	}, nil
+}
@@ -19,6 +19,7 @@ import (
 	"fmt"
 
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/webhook"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
@@ -34,6 +35,9 @@ type UpdateInput struct {
 	Enabled     *bool                 `json:"enabled"`
 	Insecure    *bool                 `json:"insecure"`
 	Triggers    []enum.WebhookTrigger `json:"triggers"`
+
+	PayloadTemplateType *enum.WebhookPayloadTemplateType `json:"payload_template_type"`
+	PayloadTemplate     *string                          `json:"payload_template"`
 }
 
 // Update updates an existing webhook.
@@ -93,6 +97,19 @@ func (c *Controller) Update(
 	if in.Triggers != nil {
 		hook.Triggers = deduplicateTriggers(in.Triggers)
 	}
+	if in.PayloadTemplateType != nil {
+		hook.PayloadTemplateType = *in.PayloadTemplateType
+	}
+	if in.PayloadTemplate != nil {
+		hook.PayloadTemplate = *in.PayloadTemplate
+	}
+
+	// the template type and template can be updated separately, only their combination can be validated.
+	if in.PayloadTemplateType != nil || in.PayloadTemplate != nil {
+		if err = checkPayloadTemplate(hook.PayloadTemplateType, hook.PayloadTemplate); err != nil {
+			return nil, err
+		}
+	}
 
 	if err = c.webhookStore.Update(ctx, hook); err != nil {
 		return nil, err
@@ -118,7 +135,7 @@ func checkUpdateInput(in *UpdateInput, allowLoopback bool, allowPrivateNetwork b
 		}
 	}
 	if in.URL != nil {
-		if err := checkURL(*in.URL, allowLoopback, allowPrivateNetwork); err != nil {
+		if err := webhook.CheckURL(*in.URL, allowLoopback, allowPrivateNetwork); err != nil {
 			return err
 		}
 	}
@@ -0,0 +1,158 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/logs"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/livelog"
+
+	"github.com/rs/zerolog/log"
+)
+
+// reconnectDelay is the delay after which SSE clients reconnect when the connection gets lost.
+var reconnectDelay = 3 * time.Second
+
+// HandleTailFrom returns an http.HandlerFunc that tails the logs of a step as server-sent events.
+// Each line is sent with its position as the event ID, so clients can resume tailing after a reconnect,
+// either using the offset query parameter or the Last-Event-ID header sent by SSE clients.
+//
+//nolint:gocognit,errcheck,cyclop // refactor if needed.
+func HandleTailFrom(logCtrl *logs.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+		pipelineUID, err := request.GetPipelineUIDFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+		executionNum, err := request.GetExecutionNumberFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+		stageNum, err := request.GetStageNumberFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+		stepNum, err := request.GetStepNumberFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+		offset, err := request.GetLogOffsetFromRequest(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		f, ok := w.(http.Flusher)
+		if !ok {
+			log.Ctx(ctx).Error().Msg("http writer type assertion failed")
+			render.InternalError(w)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, tailMaxTime)
+		defer cancel()
+
+		linec, errc, err := logCtrl.TailFrom(
+			ctx, session, repoRef, pipelineUID,
+			executionNum, int(stageNum), int(stepNum), offset)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		h := w.Header()
+		h.Set("Content-Type", "text/event-stream")
+		h.Set("Cache-Control", "no-cache")
+		h.Set("Connection", "keep-alive")
+		h.Set("X-Accel-Buffering", "no")
+		h.Set("Access-Control-Allow-Origin", "*")
+
+		fmt.Fprintf(w, "retry: %d\n\n", reconnectDelay.Milliseconds())
+		f.Flush()
+
+		// the step isn't running and no logs are stored (yet).
+		if errc == nil {
+			io.WriteString(w, "event: error\ndata: eof\n\n")
+			f.Flush()
+			return
+		}
+
+		enc := json.NewEncoder(w)
+		writeLine := func(line *livelog.Line) {
+			fmt.Fprintf(w, "id: %d\ndata: ", line.Number)
+			enc.Encode(line)
+			io.WriteString(w, "\n")
+		}
+
+		pingTicker := time.NewTicker(pingInterval)
+		defer pingTicker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				// the client reconnects and resumes from the last received line.
+				return
+			case <-pingTicker.C:
+				io.WriteString(w, ": ping\n\n")
+				f.Flush()
+			case line := <-linec:
+				writeLine(line)
+				f.Flush()
+			case err := <-errc:
+				if errors.Is(err, livelog.ErrSubscriberLagged) {
+					// end the response without eof, the client reconnects and resumes from the last received line.
+					return
+				}
+				if err != nil {
+					log.Ctx(ctx).Warn().Err(err).Msg("received error in the tail channel")
+				}
+
+				// the stream ended, send the lines that are still buffered before eof.
+				for drained := false; !drained; {
+					select {
+					case line := <-linec:
+						writeLine(line)
+					default:
+						drained = true
+					}
+				}
+
+				io.WriteString(w, "event: error\ndata: eof\n\n")
+				f.Flush()
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/pipeline"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/pipeline/badge"
+)
+
+// HandleBadge writes the SVG status badge of a pipeline.
+func HandleBadge(pipelineCtrl *pipeline.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+		pipelineUID, err := request.GetPipelineUIDFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		branch := request.GetBranchFromQuery(r)
+
+		svg, err := pipelineCtrl.Badge(ctx, session, repoRef, pipelineUID, branch)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		// badges are embedded by proxies (e.g. for READMEs) which must not serve outdated statuses.
+		render.NoCache(w)
+		w.Header().Set("Content-Type", badge.ContentType)
+		render.Reader(ctx, w, http.StatusOK, bytes.NewReader(svg))
+	}
+}
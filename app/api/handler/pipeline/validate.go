@@ -0,0 +1,87 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/pipeline"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+)
+
+// maxConfigSize is the maximum size of a pipeline definition that can be validated.
+const maxConfigSize = 1 << 20 // 1 MiB
+
+// HandleValidate validates a pipeline definition provided in a json body,
+// or the config file of the pipeline if no definition is provided.
+func HandleValidate(pipelineCtrl *pipeline.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		in := new(pipeline.ValidateInput)
+		err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxConfigSize)).Decode(in)
+		if err != nil && !errors.Is(err, io.EOF) { // allow empty body
+			render.BadRequestf(w, "Invalid Request Body: %s.", err)
+			return
+		}
+
+		handleValidate(w, r, pipelineCtrl, in)
+	}
+}
+
+// HandleValidateRaw validates a raw pipeline definition provided as the request body.
+func HandleValidateRaw(pipelineCtrl *pipeline.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxConfigSize))
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+		if len(data) == 0 {
+			render.BadRequestf(w, "The pipeline definition must be provided.")
+			return
+		}
+
+		handleValidate(w, r, pipelineCtrl, &pipeline.ValidateInput{Data: string(data)})
+	}
+}
+
+func handleValidate(w http.ResponseWriter, r *http.Request, pipelineCtrl *pipeline.Controller,
+	in *pipeline.ValidateInput,
+) {
+	ctx := r.Context()
+	session, _ := request.AuthSessionFrom(ctx)
+
+	pipelineUID, err := request.GetPipelineUIDFromPath(r)
+	if err != nil {
+		render.TranslatedUserError(w, err)
+		return
+	}
+	repoRef, err := request.GetRepoRefFromPath(r)
+	if err != nil {
+		render.TranslatedUserError(w, err)
+		return
+	}
+
+	validation, err := pipelineCtrl.Validate(ctx, session, repoRef, pipelineUID, in)
+	if err != nil {
+		render.TranslatedUserError(w, err)
+		return
+	}
+
+	render.JSON(w, http.StatusOK, validation)
+}
@@ -55,12 +55,28 @@ func HandleDiff(pullreqCtrl *pullreq.Controller) http.HandlerFunc {
 		}
 
 		_, includePatch := request.QueryParam(r, "include_patch")
-		stream, err := pullreqCtrl.Diff(ctx, session, repoRef, pullreqNumber, setSHAs, includePatch)
+		paths, _ := request.QueryParamList(r, request.QueryParamPath)
+		opts := pullreq.DiffOptions{
+			IncludePatch: includePatch,
+			Paths:        paths,
+		}
+
+		_, paginate := request.QueryParam(r, request.QueryParamLimit)
+		if paginate {
+			opts.Page = request.ParsePage(r)
+			opts.Size = request.ParseLimit(r)
+		}
+
+		stream, total, err := pullreqCtrl.Diff(ctx, session, repoRef, pullreqNumber, setSHAs, opts)
 		if err != nil {
 			render.TranslatedUserError(w, err)
 			return
 		}
 
+		if paginate {
+			render.Pagination(r, w, opts.Page, opts.Size, total)
+		}
+
 		render.JSONArrayDynamic(ctx, w, stream)
 	}
 }
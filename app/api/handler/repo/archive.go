@@ -0,0 +1,60 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	gitenum "github.com/harness/gitness/git/enum"
+)
+
+// HandleArchive streams an archive of the files of the repository at a git reference.
+// The git reference and the format are taken from the file name, e.g. "main.zip" or "v1.0.tar.gz".
+func HandleArchive(repoCtrl *repo.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		name := request.GetOptionalRemainderFromPath(r)
+		gitRef, format, ok := gitenum.ParseArchiveFormat(name)
+		if !ok {
+			render.TranslatedUserError(w, usererror.BadRequestf(
+				"Archive name must be a git reference followed by one of the extensions %v.",
+				gitenum.ArchiveFormats()))
+			return
+		}
+
+		fileName := strings.TrimSuffix(repo.ArchivePrefix(path.Base(repoRef), gitRef), "/") + "." + string(format)
+		w.Header().Set("Content-Type", format.ContentType())
+		w.Header().Set("Content-Disposition", `attachment; filename="`+fileName+`"`)
+
+		err = repoCtrl.Archive(ctx, session, repoRef, gitRef, format, w)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+	}
+}
@@ -53,7 +53,11 @@ func HandleBlame(repoCtrl *repo.Controller) http.HandlerFunc {
 
 		gitRef := request.GetGitRefFromQueryOrDefault(r, "")
 
-		stream, err := repoCtrl.Blame(ctx, session, repoRef, gitRef, path, int(lineFrom), int(lineTo))
+		// ignore_revs_file is optional, path of a file in the repository listing commits to ignore
+		ignoreRevsFile := request.QueryParamOrDefault(r, request.QueryParamIgnoreRevsFile, "")
+
+		stream, err := repoCtrl.Blame(ctx, session, repoRef, gitRef, path,
+			int(lineFrom), int(lineTo), ignoreRevsFile)
 		if err != nil {
 			render.TranslatedUserError(w, err)
 			return
@@ -0,0 +1,56 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"net/http"
+	"path"
+
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+)
+
+// HandleExportBundle writes a portable bundle of the repository.
+// The bundle is incremental if the since_sha query parameter is provided,
+// and contains the repository metadata if the metadata query parameter is true.
+func HandleExportBundle(repoCtrl *repo.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		sinceSHA := request.QueryParamOrDefault(r, request.QueryParamSinceSHA, "")
+
+		includeMetadata, err := request.QueryParamAsBoolOrDefault(r, request.QueryParamMetadata, false)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/x-tar")
+		w.Header().Set("Content-Disposition", `attachment; filename="`+path.Base(repoRef)+`.tar"`)
+
+		err = repoCtrl.ExportBundle(ctx, session, repoRef, sinceSHA, includeMetadata, w)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+	}
+}
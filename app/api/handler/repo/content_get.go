@@ -41,9 +41,15 @@ func HandleGetContent(repoCtrl *repo.Controller) http.HandlerFunc {
 			return
 		}
 
+		includeEntryCommits, err := request.GetIncludeEntryCommitsFromQueryOrDefault(r, false)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
 		repoPath := request.GetOptionalRemainderFromPath(r)
 
-		resp, err := repoCtrl.GetContent(ctx, session, repoRef, gitRef, repoPath, includeCommit)
+		resp, err := repoCtrl.GetContent(ctx, session, repoRef, gitRef, repoPath, includeCommit, includeEntryCommits)
 		if err != nil {
 			render.TranslatedUserError(w, err)
 			return
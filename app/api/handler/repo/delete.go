@@ -35,7 +35,7 @@ func HandleDelete(repoCtrl *repo.Controller) http.HandlerFunc {
 			return
 		}
 
-		err = repoCtrl.Delete(ctx, session, repoRef)
+		err = repoCtrl.Delete(ctx, session, repoRef, request.GetConfirmationTokenFromQuery(r))
 		if err != nil {
 			render.TranslatedUserError(w, err)
 			return
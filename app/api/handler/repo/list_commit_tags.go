@@ -41,7 +41,11 @@ func HandleListCommitTags(repoCtrl *repo.Controller) http.HandlerFunc {
 			return
 		}
 
-		filter := request.ParseTagFilter(r)
+		filter, err := request.ParseTagFilter(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
 
 		tags, err := repoCtrl.ListCommitTags(ctx, session, repoRef, includeCommit, filter)
 		if err != nil {
@@ -0,0 +1,67 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+
+	"github.com/rs/zerolog/log"
+)
+
+// HandlePipelineCacheRestore handles API that returns the content of a build cache of a repository.
+// The key of the restored cache is returned in a response header, it differs from the requested key
+// if the cache got matched by one of the restore keys.
+func HandlePipelineCacheRestore(repoCtrl *repo.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		key, err := request.GetPipelineCacheKeyFromQuery(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		restoreKeys := request.GetPipelineCacheRestoreKeysFromQuery(r)
+
+		cache, content, err := repoCtrl.PipelineCacheRestore(ctx, session, repoRef, key, restoreKeys)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		defer func() {
+			if err := content.Close(); err != nil {
+				log.Ctx(ctx).Warn().Err(err).Msgf("failed to close pipeline cache content reader.")
+			}
+		}()
+
+		w.Header().Add("Content-Length", fmt.Sprint(cache.Size))
+		w.Header().Add(request.HeaderPipelineCacheKey, cache.Key)
+
+		render.Reader(ctx, w, http.StatusOK, content)
+	}
+}
@@ -17,6 +17,7 @@ package repo
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/api/render"
@@ -41,20 +42,34 @@ func HandleRaw(repoCtrl *repo.Controller) http.HandlerFunc {
 		gitRef := request.GetGitRefFromQueryOrDefault(r, "")
 		path := request.GetOptionalRemainderFromPath(r)
 
-		dataReader, dataLength, err := repoCtrl.Raw(ctx, session, repoRef, gitRef, path)
+		offset, length, isRange := request.GetByteRangeFromHeader(r)
+
+		out, err := repoCtrl.Raw(ctx, session, repoRef, gitRef, path, offset, length)
 		if err != nil {
 			render.TranslatedUserError(w, err)
 			return
 		}
 
 		defer func() {
-			if err := dataReader.Close(); err != nil {
+			if err := out.Data.Close(); err != nil {
 				log.Ctx(ctx).Warn().Err(err).Msgf("failed to close blob content reader.")
 			}
 		}()
 
-		w.Header().Add("Content-Length", fmt.Sprint(dataLength))
+		w.Header().Set("Content-Type", out.ContentType)
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.Header().Set("X-Content-Binary", strconv.FormatBool(out.IsBinary))
+		w.Header().Set("Accept-Ranges", "bytes")
+		w.Header().Add("Content-Length", fmt.Sprint(out.DataSize))
+
+		if !isRange || out.DataSize == 0 {
+			render.Reader(ctx, w, http.StatusOK, out.Data)
+			return
+		}
+
+		w.Header().Set("Content-Range",
+			fmt.Sprintf("bytes %d-%d/%d", out.Offset, out.Offset+out.DataSize-1, out.Size))
 
-		render.Reader(ctx, w, http.StatusOK, dataReader)
+		render.Reader(ctx, w, http.StatusPartialContent, out.Data)
 	}
 }
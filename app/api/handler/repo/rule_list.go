@@ -34,7 +34,11 @@ func HandleRuleList(repoCtrl *repo.Controller) http.HandlerFunc {
 			return
 		}
 
-		filter := request.ParseRuleFilter(r)
+		filter, err := request.ParseRuleFilter(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
 
 		rules, rulesCount, err := repoCtrl.RuleList(ctx, session, repoRef, filter)
 		if err != nil {
@@ -0,0 +1,93 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/runner"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/types"
+)
+
+// longPollTimeout is the duration after which long polling requests are answered with no content,
+// the runners repeat such requests.
+const longPollTimeout = 30 * time.Second
+
+// authenticate returns the runner authenticated by the token of the request.
+// If the authentication fails, the error is written to the response.
+func authenticate(w http.ResponseWriter, r *http.Request, runnerCtrl *runner.Controller) (*types.Runner, bool) {
+	runner, err := runnerCtrl.Authenticate(r.Context(), request.GetRunnerTokenFromHeader(r))
+	if err != nil {
+		render.TranslatedUserError(w, err)
+		return nil, false
+	}
+
+	return runner, true
+}
+
+// HandlePing returns an http.HandlerFunc that records a heartbeat of the runner
+// and returns the runner, its state tells whether it should request new stages.
+func HandlePing(runnerCtrl *runner.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		runner, ok := authenticate(w, r, runnerCtrl)
+		if !ok {
+			return
+		}
+
+		render.JSON(w, http.StatusOK, runner)
+	}
+}
+
+// HandleWatch returns an http.HandlerFunc that waits for the cancellation of an execution.
+// It responds with OK if the execution got canceled and with no content if it didn't
+// get canceled within the long polling timeout.
+func HandleWatch(runnerCtrl *runner.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := authenticate(w, r, runnerCtrl); !ok {
+			return
+		}
+
+		executionID, err := request.GetRunnerBuildIDFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), longPollTimeout)
+		defer cancel()
+
+		canceled, err := runnerCtrl.Watch(ctx, executionID)
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		if !canceled {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
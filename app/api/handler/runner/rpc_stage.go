@@ -0,0 +1,153 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/runner"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+
+	"github.com/drone/drone-go/drone"
+	runnerclient "github.com/drone/runner-go/client"
+)
+
+// HandleRequestStage returns an http.HandlerFunc that waits for the next stage the runner can execute.
+// It responds with no content if there's no such stage within the long polling timeout.
+func HandleRequestStage(runnerCtrl *runner.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		runner, ok := authenticate(w, r, runnerCtrl)
+		if !ok {
+			return
+		}
+
+		filter := new(runnerclient.Filter)
+		err := json.NewDecoder(r.Body).Decode(filter)
+		if err != nil {
+			render.BadRequestf(w, "Invalid request body: %s.", err)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), longPollTimeout)
+		defer cancel()
+
+		stage, err := runnerCtrl.RequestStage(ctx, runner, filter)
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		if stage == nil {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, stage)
+	}
+}
+
+// HandleAcceptStage returns an http.HandlerFunc that accepts a stage for execution by the runner.
+func HandleAcceptStage(runnerCtrl *runner.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		runner, ok := authenticate(w, r, runnerCtrl)
+		if !ok {
+			return
+		}
+
+		stageID, err := request.GetRunnerStageIDFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		stage, err := runnerCtrl.AcceptStage(ctx, runner, stageID)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, stage)
+	}
+}
+
+// HandleStageDetails returns an http.HandlerFunc that writes the details
+// the runner needs to execute a stage.
+func HandleStageDetails(runnerCtrl *runner.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		runner, ok := authenticate(w, r, runnerCtrl)
+		if !ok {
+			return
+		}
+
+		stageID, err := request.GetRunnerStageIDFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		details, err := runnerCtrl.StageDetails(ctx, runner, stageID)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, details)
+	}
+}
+
+// HandleUpdateStage returns an http.HandlerFunc that updates a stage executed by the runner.
+func HandleUpdateStage(runnerCtrl *runner.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		runner, ok := authenticate(w, r, runnerCtrl)
+		if !ok {
+			return
+		}
+
+		stageID, err := request.GetRunnerStageIDFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		in := new(drone.Stage)
+		err = json.NewDecoder(r.Body).Decode(in)
+		if err != nil {
+			render.BadRequestf(w, "Invalid request body: %s.", err)
+			return
+		}
+
+		stage, err := runnerCtrl.UpdateStage(ctx, runner, stageID, in)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, stage)
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/runner"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+
+	"github.com/drone/drone-go/drone"
+)
+
+// HandleUpdateStep returns an http.HandlerFunc that updates a step of a stage executed by the runner.
+func HandleUpdateStep(runnerCtrl *runner.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		runner, ok := authenticate(w, r, runnerCtrl)
+		if !ok {
+			return
+		}
+
+		stepID, err := request.GetRunnerStepIDFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		in := new(drone.Step)
+		err = json.NewDecoder(r.Body).Decode(in)
+		if err != nil {
+			render.BadRequestf(w, "Invalid request body: %s.", err)
+			return
+		}
+
+		step, err := runnerCtrl.UpdateStep(ctx, runner, stepID, in)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, step)
+	}
+}
+
+// HandleWriteLogs returns an http.HandlerFunc that writes a batch of lines to the live logs of a step.
+func HandleWriteLogs(runnerCtrl *runner.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		runner, ok := authenticate(w, r, runnerCtrl)
+		if !ok {
+			return
+		}
+
+		stepID, err := request.GetRunnerStepIDFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		var lines []*drone.Line
+		err = json.NewDecoder(r.Body).Decode(&lines)
+		if err != nil {
+			render.BadRequestf(w, "Invalid request body: %s.", err)
+			return
+		}
+
+		err = runnerCtrl.WriteLogs(ctx, runner, stepID, lines)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// HandleUploadLogs returns an http.HandlerFunc that uploads the full logs of a step.
+func HandleUploadLogs(runnerCtrl *runner.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		runner, ok := authenticate(w, r, runnerCtrl)
+		if !ok {
+			return
+		}
+
+		stepID, err := request.GetRunnerStepIDFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		var lines []*drone.Line
+		err = json.NewDecoder(r.Body).Decode(&lines)
+		if err != nil {
+			render.BadRequestf(w, "Invalid request body: %s.", err)
+			return
+		}
+
+		err = runnerCtrl.UploadLogs(ctx, runner, stepID, lines)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		// the runners repeat requests answered with no content, respond with OK.
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// HandleUploadCard returns an http.HandlerFunc that accepts the card of a step.
+// Cards aren't supported, they are discarded like with the embedded runner.
+func HandleUploadCard(runnerCtrl *runner.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := authenticate(w, r, runnerCtrl); !ok {
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
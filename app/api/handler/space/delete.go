@@ -33,7 +33,7 @@ func HandleDelete(spaceCtrl *space.Controller) http.HandlerFunc {
 			return
 		}
 
-		err = spaceCtrl.Delete(ctx, session, spaceRef)
+		err = spaceCtrl.Delete(ctx, session, spaceRef, request.GetConfirmationTokenFromQuery(r))
 		if err != nil {
 			render.TranslatedUserError(w, err)
 			return
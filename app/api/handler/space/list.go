@@ -34,7 +34,11 @@ func HandleListSpaces(spaceCtrl *space.Controller) http.HandlerFunc {
 			return
 		}
 
-		spaceFilter := request.ParseSpaceFilter(r)
+		spaceFilter, err := request.ParseSpaceFilter(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
 		if spaceFilter.Order == enum.OrderDefault {
 			spaceFilter.Order = enum.OrderAsc
 		}
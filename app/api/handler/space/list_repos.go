@@ -34,7 +34,11 @@ func HandleListRepos(spaceCtrl *space.Controller) http.HandlerFunc {
 			return
 		}
 
-		filter := request.ParseRepoFilter(r)
+		filter, err := request.ParseRepoFilter(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
 		if filter.Order == enum.OrderDefault {
 			filter.Order = enum.OrderAsc
 		}
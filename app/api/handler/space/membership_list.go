@@ -34,7 +34,11 @@ func HandleMembershipList(spaceCtrl *space.Controller) http.HandlerFunc {
 			return
 		}
 
-		filter := request.ParseMembershipUserFilter(r)
+		filter, err := request.ParseMembershipUserFilter(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
 
 		memberships, membershipsCount, err := spaceCtrl.MembershipList(ctx, session, spaceRef, filter)
 		if err != nil {
@@ -0,0 +1,58 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/system"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+)
+
+// HandleListComplianceSnapshots returns an http.HandlerFunc that lists the compliance snapshots.
+func HandleListComplianceSnapshots(sysCtrl *system.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		pagination := request.ParsePaginationFromRequest(r)
+
+		snapshots, count, err := sysCtrl.ListComplianceSnapshots(ctx, &pagination)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		render.Pagination(r, w, pagination.Page, pagination.Size, int(count))
+		render.JSON(w, http.StatusOK, snapshots)
+	}
+}
+
+// HandleExportComplianceSnapshots returns an http.HandlerFunc that exports
+// the complete, verified chain of compliance snapshots as a JSON file.
+func HandleExportComplianceSnapshots(sysCtrl *system.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		export, err := sysCtrl.ExportComplianceSnapshots(ctx)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Disposition", `attachment; filename="compliance-snapshots.json"`)
+		render.JSON(w, http.StatusOK, export)
+	}
+}
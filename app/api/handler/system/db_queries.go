@@ -0,0 +1,38 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package system
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/store/database/dbtx"
+)
+
+// HandleGetQueryReport returns an http.HandlerFunc that returns the database query statistics
+// together with the most recent slow queries.
+func HandleGetQueryReport() http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		render.JSON(w, http.StatusOK, dbtx.GetQueryReport())
+	}
+}
+
+// HandleResetQueryStats returns an http.HandlerFunc that discards the collected database query statistics.
+func HandleResetQueryStats() http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		dbtx.ResetQueryStats()
+		render.DeleteSuccessful(w)
+	}
+}
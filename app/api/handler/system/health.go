@@ -14,10 +14,25 @@
 
 package system
 
-import "net/http"
+import (
+	"net/http"
 
-// HandleHealth writes a 200 OK status to the http.Response
-// if the server is healthy.
-func HandleHealth(w http.ResponseWriter, _ *http.Request) {
-	w.WriteHeader(http.StatusOK)
+	"github.com/harness/gitness/app/api/controller/system"
+	"github.com/harness/gitness/app/api/render"
+)
+
+type HealthOutput struct {
+	// Leader is true if the instance is the leader, i.e. it runs the singleton background services.
+	// Other instances are warm standby.
+	Leader bool `json:"leader"`
+}
+
+// HandleHealth returns an http.HandlerFunc that writes a 200 OK status
+// and the leadership status of the instance to the http.Response if the server is healthy.
+func HandleHealth(sysCtrl *system.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		render.JSON(w, http.StatusOK, HealthOutput{
+			Leader: sysCtrl.IsLeader(),
+		})
+	}
 }
@@ -28,7 +28,11 @@ func HandleMembershipSpaces(userCtrl *user.Controller) http.HandlerFunc {
 		session, _ := request.AuthSessionFrom(ctx)
 		userUID := session.Principal.UID
 
-		filter := request.ParseMembershipSpaceFilter(r)
+		filter, err := request.ParseMembershipSpaceFilter(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
 
 		membershipSpaces, membershipSpaceCount, err := userCtrl.MembershipSpaces(ctx, session, userUID, filter)
 		if err != nil {
@@ -30,7 +30,11 @@ func HandleList(userCtrl *user.Controller) http.HandlerFunc {
 		ctx := r.Context()
 		session, _ := request.AuthSessionFrom(ctx)
 
-		filter := request.ParseUserFilter(r)
+		filter, err := request.ParseUserFilter(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
 		if filter.Order == enum.OrderDefault {
 			filter.Order = enum.OrderAsc
 		}
@@ -35,7 +35,11 @@ func HandleList(webhookCtrl *webhook.Controller) http.HandlerFunc {
 			return
 		}
 
-		filter := request.ParseWebhookFilter(r)
+		filter, err := request.ParseWebhookFilter(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
 		if filter.Order == enum.OrderDefault {
 			filter.Order = enum.OrderAsc
 		}
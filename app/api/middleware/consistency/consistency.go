@@ -0,0 +1,137 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consistency
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/cache"
+)
+
+const (
+	// HeaderToken is the header used to return the consistency token after a successful mutation
+	// and to provide it with the subsequent reads.
+	HeaderToken = "X-Consistency-Token"
+
+	tokenVersion = "v1"
+)
+
+// NewToken returns an opaque consistency token for a write completed at the provided time.
+func NewToken(t time.Time) string {
+	raw := tokenVersion + ":" + strconv.FormatInt(t.UnixMicro(), 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseToken returns the time of the write the consistency token was issued for.
+// Times in the future are clamped to now, so a crafted token can't disable the caches.
+func ParseToken(token string) (time.Time, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid consistency token encoding: %w", err)
+	}
+
+	version, value, ok := strings.Cut(string(raw), ":")
+	if !ok || version != tokenVersion {
+		return time.Time{}, fmt.Errorf("unsupported consistency token")
+	}
+
+	micro, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid consistency token value: %w", err)
+	}
+
+	t := time.UnixMicro(micro)
+	if now := time.Now(); t.After(now) {
+		return now, nil
+	}
+
+	return t, nil
+}
+
+// Handler returns an http.HandlerFunc middleware that provides read-after-write consistency.
+// Successful mutations return a consistency token in the response header. If a read request
+// provides the token, cached data older than the write the token was issued for isn't used.
+func Handler() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !isMutation(r.Method) {
+				token := r.Header.Get(HeaderToken)
+				if token == "" {
+					next.ServeHTTP(w, r)
+					return
+				}
+
+				notBefore, err := ParseToken(token)
+				if err != nil {
+					render.BadRequestf(w, "Invalid consistency token: %s.", err)
+					return
+				}
+
+				next.ServeHTTP(w, r.WithContext(cache.WithNotBefore(r.Context(), notBefore)))
+				return
+			}
+
+			next.ServeHTTP(&tokenWriter{ResponseWriter: w}, r)
+		})
+	}
+}
+
+func isMutation(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	default:
+		return true
+	}
+}
+
+// tokenWriter adds the consistency token header to successful responses.
+type tokenWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+func (w *tokenWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+
+	if status >= 200 && status < 300 {
+		w.Header().Set(HeaderToken, NewToken(time.Now()))
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *tokenWriter) Write(data []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *tokenWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
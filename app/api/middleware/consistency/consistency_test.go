@@ -0,0 +1,126 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consistency
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/cache"
+)
+
+func TestToken(t *testing.T) {
+	now := time.UnixMicro(time.Now().UnixMicro())
+
+	parsed, err := ParseToken(NewToken(now))
+	if err != nil {
+		t.Fatalf("failed to parse token: %v", err)
+	}
+
+	if !parsed.Equal(now) {
+		t.Errorf("want=%v got=%v", now, parsed)
+	}
+
+	future, err := ParseToken(NewToken(now.Add(24 * time.Hour)))
+	if err != nil {
+		t.Fatalf("failed to parse future token: %v", err)
+	}
+
+	if future.After(time.Now()) {
+		t.Errorf("future token should be clamped to now, got %v", future)
+	}
+
+	for _, token := range []string{"", "!", "djI6MTIz", "djE6YWJj"} {
+		if _, err = ParseToken(token); err == nil {
+			t.Errorf("token %q should be rejected", token)
+		}
+	}
+}
+
+func TestHandler(t *testing.T) {
+	var (
+		notBefore    time.Time
+		hasNotBefore bool
+	)
+
+	handler := Handler()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		notBefore, hasNotBefore = cache.NotBeforeFrom(r.Context())
+
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{}`))
+	}))
+
+	t.Run("mutation", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+
+		if rec.Header().Get(HeaderToken) == "" {
+			t.Errorf("expected consistency token in the response")
+		}
+	})
+
+	t.Run("failed-mutation", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPatch, "/fail", nil))
+
+		if token := rec.Header().Get(HeaderToken); token != "" {
+			t.Errorf("unexpected consistency token in the response: %s", token)
+		}
+	})
+
+	t.Run("read-without-token", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if hasNotBefore {
+			t.Errorf("unexpected not before time in the context")
+		}
+		if token := rec.Header().Get(HeaderToken); token != "" {
+			t.Errorf("unexpected consistency token in the response: %s", token)
+		}
+	})
+
+	t.Run("read-with-token", func(t *testing.T) {
+		written := time.UnixMicro(time.Now().UnixMicro())
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(HeaderToken, NewToken(written))
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if !hasNotBefore || !notBefore.Equal(written) {
+			t.Errorf("want not before time %v, got %v", written, notBefore)
+		}
+	})
+
+	t.Run("read-with-invalid-token", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(HeaderToken, "invalid")
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if want, got := http.StatusBadRequest, rec.Code; want != got {
+			t.Errorf("status: want=%d got=%d", want, got)
+		}
+	})
+}
@@ -0,0 +1,77 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtiming
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/harness/gitness/store/database/dbtx"
+)
+
+const (
+	// HeaderServerTiming is the standard header used to report the database time of the request.
+	HeaderServerTiming = "Server-Timing"
+
+	// HeaderQueryCount is the header used to report the number of database queries executed by the request.
+	HeaderQueryCount = "X-Database-Queries"
+)
+
+// Handler returns an http.HandlerFunc middleware that reports the number of database queries
+// and the time spent on them in the response headers. As the headers are written before the body,
+// only the queries executed before the response is started are included.
+func Handler() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, timer := dbtx.WithQueryTimer(r.Context())
+			next.ServeHTTP(&timingWriter{ResponseWriter: w, timer: timer}, r.WithContext(ctx))
+		})
+	}
+}
+
+// timingWriter adds the database timing headers to the response.
+type timingWriter struct {
+	http.ResponseWriter
+	timer       *dbtx.QueryTimer
+	wroteHeader bool
+}
+
+func (w *timingWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+
+	durationMs := float64(w.timer.Duration().Microseconds()) / 1000
+	w.Header().Add(HeaderServerTiming, fmt.Sprintf("db;dur=%.3f", durationMs))
+	w.Header().Set(HeaderQueryCount, strconv.FormatInt(w.timer.Count(), 10))
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *timingWriter) Write(data []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *timingWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
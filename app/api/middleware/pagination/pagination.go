@@ -0,0 +1,177 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pagination
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/harness/gitness/app/api/render"
+)
+
+const (
+	// QueryParamEnvelope is the query parameter that enables the pagination envelope.
+	QueryParamEnvelope = "envelope"
+)
+
+// query parameters that are not echoed back as part of the filter.
+var nonFilterParams = map[string]struct{}{
+	"page":             {},
+	"limit":            {},
+	"cursor":           {},
+	QueryParamEnvelope: {},
+	"access_token":     {},
+	"token":            {},
+}
+
+// Envelope wraps a list returned by an API in a standard structure.
+type Envelope struct {
+	Data       json.RawMessage `json:"data"`
+	Pagination Info            `json:"pagination"`
+}
+
+// Info contains pagination metadata of a list.
+// Total and TotalPages are only available for lists for which the total count is cheap to compute.
+type Info struct {
+	Page       int                 `json:"page,omitempty"`
+	Limit      int                 `json:"limit,omitempty"`
+	Total      *int                `json:"total,omitempty"`
+	TotalPages *int                `json:"total_pages,omitempty"`
+	Next       string              `json:"next,omitempty"`
+	Prev       string              `json:"prev,omitempty"`
+	NextCursor string              `json:"next_cursor,omitempty"`
+	Filter     map[string][]string `json:"filter"`
+}
+
+// Handler returns an http.HandlerFunc middleware that, if requested with the envelope query parameter,
+// wraps paginated JSON responses in an Envelope. The pagination metadata is the one the handler recorded
+// using the render pagination helpers. JSON lists are always wrapped, other responses only if the handler
+// recorded pagination metadata (e.g. cursor based pagination), all remaining responses are written unchanged.
+func Handler() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if enabled, _ := strconv.ParseBool(r.URL.Query().Get(QueryParamEnvelope)); !enabled {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx, info := render.WithPaginationInfo(r.Context())
+			r = r.WithContext(ctx)
+			bw := &bufferedWriter{ResponseWriter: w}
+
+			next.ServeHTTP(bw, r)
+
+			bw.finish(r, info)
+		})
+	}
+}
+
+// bufferedWriter buffers successful JSON responses so they can be wrapped after the handler completes.
+// All other responses are written directly to the underlying http.ResponseWriter.
+type bufferedWriter struct {
+	http.ResponseWriter
+	status      int
+	buffering   bool
+	passthrough bool
+	body        bytes.Buffer
+}
+
+func (w *bufferedWriter) WriteHeader(status int) {
+	if w.buffering || w.passthrough {
+		return
+	}
+
+	contentType := w.Header().Get("Content-Type")
+	if status >= 200 && status < 300 && strings.HasPrefix(contentType, "application/json") {
+		w.status = status
+		w.buffering = true
+		return
+	}
+
+	w.passthrough = true
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	if !w.buffering && !w.passthrough {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if w.buffering {
+		return w.body.Write(data)
+	}
+
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *bufferedWriter) Flush() {
+	if w.buffering {
+		return
+	}
+
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// finish writes the buffered response, wrapped in the envelope if the response is paginated.
+func (w *bufferedWriter) finish(r *http.Request, recorded *render.PaginationInfo) {
+	if !w.buffering {
+		return
+	}
+
+	data := bytes.TrimSpace(w.body.Bytes())
+	isList := len(data) > 0 && data[0] == '['
+	if !isList && (len(data) == 0 || *recorded == (render.PaginationInfo{})) {
+		w.ResponseWriter.WriteHeader(w.status)
+		_, _ = w.ResponseWriter.Write(w.body.Bytes())
+		return
+	}
+
+	env := Envelope{
+		Data:       data,
+		Pagination: newInfo(recorded, r),
+	}
+
+	w.ResponseWriter.WriteHeader(w.status)
+	_ = json.NewEncoder(w.ResponseWriter).Encode(env)
+}
+
+// newInfo builds the pagination metadata from the metadata recorded by the handler
+// and the query parameters of the request.
+func newInfo(recorded *render.PaginationInfo, r *http.Request) Info {
+	info := Info{
+		Page:       recorded.Page,
+		Limit:      recorded.Limit,
+		Total:      recorded.Total,
+		TotalPages: recorded.TotalPages,
+		Next:       recorded.Next,
+		Prev:       recorded.Prev,
+		NextCursor: recorded.NextCursor,
+		Filter:     make(map[string][]string),
+	}
+
+	for key, values := range r.URL.Query() {
+		if _, ok := nonFilterParams[key]; ok {
+			continue
+		}
+		info.Filter[key] = values
+	}
+
+	return info
+}
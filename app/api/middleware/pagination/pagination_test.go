@@ -0,0 +1,123 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pagination
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/app/api/render"
+)
+
+func TestHandler(t *testing.T) {
+	list := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		render.Pagination(r, w, 2, 10, 35)
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`[1,2,3]`))
+	})
+
+	cursor := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		render.PaginationCursor(r, w, 2, "abc")
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"entries":[1,2]}`))
+	})
+
+	object := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id":1}`))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		Handler()(list).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/list?page=2", nil))
+
+		if want, got := `[1,2,3]`, rec.Body.String(); want != got {
+			t.Errorf("want=%s got=%s", want, got)
+		}
+	})
+
+	t.Run("list", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/list?page=2&limit=10&envelope=true&query=abc", nil)
+		Handler()(list).ServeHTTP(rec, req)
+
+		if want, got := http.StatusOK, rec.Code; want != got {
+			t.Errorf("status: want=%d got=%d", want, got)
+		}
+
+		var env Envelope
+		if err := json.Unmarshal(rec.Body.Bytes(), &env); err != nil {
+			t.Fatalf("failed to decode envelope: %s", err.Error())
+		}
+
+		if want, got := `[1,2,3]`, string(env.Data); want != got {
+			t.Errorf("data: want=%s got=%s", want, got)
+		}
+
+		total, totalPages := 35, 4
+		expInfo := Info{
+			Page:       2,
+			Limit:      10,
+			Total:      &total,
+			TotalPages: &totalPages,
+			Next:       "/list?envelope=true&limit=10&page=3&query=abc",
+			Prev:       "/list?envelope=true&limit=10&page=1&query=abc",
+			Filter:     map[string][]string{"query": {"abc"}},
+		}
+		if want, got := expInfo, env.Pagination; !reflect.DeepEqual(want, got) {
+			t.Errorf("pagination: want=%+v got=%+v", want, got)
+		}
+	})
+
+	t.Run("cursor", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/tree?limit=2&cursor=xyz&envelope=true&recursive=true", nil)
+		Handler()(cursor).ServeHTTP(rec, req)
+
+		var env Envelope
+		if err := json.Unmarshal(rec.Body.Bytes(), &env); err != nil {
+			t.Fatalf("failed to decode envelope: %s", err.Error())
+		}
+
+		if want, got := `{"entries":[1,2]}`, string(env.Data); want != got {
+			t.Errorf("data: want=%s got=%s", want, got)
+		}
+
+		expInfo := Info{
+			Limit:      2,
+			Next:       "/tree?cursor=abc&envelope=true&limit=2&recursive=true",
+			NextCursor: "abc",
+			Filter:     map[string][]string{"recursive": {"true"}},
+		}
+		if want, got := expInfo, env.Pagination; !reflect.DeepEqual(want, got) {
+			t.Errorf("pagination: want=%+v got=%+v", want, got)
+		}
+	})
+
+	t.Run("object", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		Handler()(object).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/obj?envelope=true", nil))
+
+		if want, got := `{"id":1}`, rec.Body.String(); want != got {
+			t.Errorf("want=%s got=%s", want, got)
+		}
+	})
+}
@@ -46,6 +46,16 @@ type loginRequest struct {
 	user.LoginInput
 }
 
+// request to begin the passkey login ceremony.
+type loginPasskeyBeginRequest struct {
+	user.LoginPasskeyBeginInput
+}
+
+// request to finish the passkey login ceremony.
+type loginPasskeyFinishRequest struct {
+	user.LoginPasskeyFinishInput
+}
+
 // request to register an account.
 type registerRequest struct {
 	user.RegisterInput
@@ -62,9 +72,31 @@ func buildAccount(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&onLogin, new(types.TokenResponse), http.StatusOK)
 	_ = reflector.SetJSONResponse(&onLogin, new(usererror.Error), http.StatusBadRequest)
 	_ = reflector.SetJSONResponse(&onLogin, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&onLogin, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.SetJSONResponse(&onLogin, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/login", onLogin)
 
+	onLoginPasskeyBegin := openapi3.Operation{}
+	onLoginPasskeyBegin.WithTags("account")
+	onLoginPasskeyBegin.WithMapOfAnything(map[string]interface{}{"operationId": "onLoginPasskeyBegin"})
+	_ = reflector.SetRequest(&onLoginPasskeyBegin, new(loginPasskeyBeginRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&onLoginPasskeyBegin, new(types.PasskeyCeremony), http.StatusOK)
+	_ = reflector.SetJSONResponse(&onLoginPasskeyBegin, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&onLoginPasskeyBegin, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&onLoginPasskeyBegin, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/login/passkey/begin", onLoginPasskeyBegin)
+
+	onLoginPasskey := openapi3.Operation{}
+	onLoginPasskey.WithTags("account")
+	onLoginPasskey.WithParameters(queryParameterIncludeCookie)
+	onLoginPasskey.WithMapOfAnything(map[string]interface{}{"operationId": "onLoginPasskey"})
+	_ = reflector.SetRequest(&onLoginPasskey, new(loginPasskeyFinishRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&onLoginPasskey, new(types.TokenResponse), http.StatusOK)
+	_ = reflector.SetJSONResponse(&onLoginPasskey, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&onLoginPasskey, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&onLoginPasskey, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/login/passkey", onLoginPasskey)
+
 	opLogout := openapi3.Operation{}
 	opLogout.WithTags("account")
 	opLogout.WithMapOfAnything(map[string]interface{}{"operationId": "opLogout"})
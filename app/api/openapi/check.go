@@ -90,6 +90,23 @@ func checkOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/checks/commits/{commit_sha}",
 		listStatusCheckResults)
 
+	rerunStatusCheck := openapi3.Operation{}
+	rerunStatusCheck.WithTags(tag)
+	rerunStatusCheck.WithMapOfAnything(map[string]interface{}{"operationId": "rerunStatusCheck"})
+	_ = reflector.SetRequest(&rerunStatusCheck, struct {
+		repoRequest
+		CommitSHA string `path:"commit_sha"`
+		CheckUID  string `path:"check_uid"`
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&rerunStatusCheck, new(types.Check), http.StatusAccepted)
+	_ = reflector.SetJSONResponse(&rerunStatusCheck, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&rerunStatusCheck, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&rerunStatusCheck, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&rerunStatusCheck, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&rerunStatusCheck, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/checks/commits/{commit_sha}/{check_uid}/rerun",
+		rerunStatusCheck)
+
 	listStatusCheckRecent := openapi3.Operation{}
 	listStatusCheckRecent.WithTags(tag)
 	listStatusCheckRecent.WithParameters(
@@ -96,3 +96,17 @@ var queryParameterAfter = openapi3.ParameterOrRef{
 		},
 	},
 }
+
+var queryParameterConfirmationToken = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamConfirmationToken,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The confirmation token returned by a previous attempt of the operation."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
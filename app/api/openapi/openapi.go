@@ -62,6 +62,8 @@ func Generate() *openapi3.Spec {
 	webhookOperations(&reflector)
 	checkOperations(&reflector)
 	uploadOperations(&reflector)
+	runnerOperations(&reflector)
+	variableOperations(&reflector)
 
 	//
 	// define security scheme
@@ -17,7 +17,9 @@ package openapi
 import (
 	"net/http"
 
+	"github.com/harness/gitness/app/api/controller/execution"
 	"github.com/harness/gitness/app/api/controller/pipeline"
+	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/api/controller/trigger"
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/api/usererror"
@@ -43,12 +45,38 @@ type triggerRequest struct {
 	UID string `path:"trigger_uid"`
 }
 
+type scheduleRequest struct {
+	pipelineRequest
+	UID string `path:"schedule_uid"`
+}
+
 type logRequest struct {
 	executionRequest
 	StageNum string `path:"stage_number"`
 	StepNum  string `path:"step_number"`
 }
 
+type logTailRequest struct {
+	logRequest
+	Offset      int    `query:"offset" description:"Position of the first log line to send."`
+	LastEventID string `header:"Last-Event-ID" description:"Position of the last received log line, sent by SSE clients on reconnect."` //nolint:lll // struct tags can't be multiline
+}
+
+type approvalRequest struct {
+	executionRequest
+	StageNum string `path:"stage_number"`
+}
+
+type artifactRequest struct {
+	executionRequest
+	ID int64 `path:"artifact_id"`
+}
+
+type decideApprovalRequest struct {
+	approvalRequest
+	execution.ApprovalDecisionInput
+}
+
 type createExecutionRequest struct {
 	pipelineRequest
 }
@@ -58,6 +86,11 @@ type createTriggerRequest struct {
 	trigger.CreateInput
 }
 
+type createScheduleRequest struct {
+	pipelineRequest
+	trigger.ScheduleCreateInput
+}
+
 type createPipelineRequest struct {
 	repoRequest
 	pipeline.CreateInput
@@ -67,10 +100,19 @@ type getExecutionRequest struct {
 	executionRequest
 }
 
+type retryExecutionRequest struct {
+	executionRequest
+	execution.RetryInput
+}
+
 type getTriggerRequest struct {
 	triggerRequest
 }
 
+type getScheduleRequest struct {
+	scheduleRequest
+}
+
 type getPipelineRequest struct {
 	pipelineRequest
 }
@@ -80,6 +122,21 @@ type updateTriggerRequest struct {
 	trigger.UpdateInput
 }
 
+type updateScheduleRequest struct {
+	scheduleRequest
+	trigger.ScheduleUpdateInput
+}
+
+type validatePipelineRequest struct {
+	pipelineRequest
+	pipeline.ValidateInput
+}
+
+type convertPipelineRequest struct {
+	repoRequest
+	repo.PipelineConvertInput
+}
+
 type updatePipelineRequest struct {
 	pipelineRequest
 	pipeline.UpdateInput
@@ -99,6 +156,35 @@ var queryParameterLatest = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterBadgeBranch = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamBranch,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Branch of the latest execution. Defaults to the default branch of the pipeline."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterStatsBefore = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamBefore,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The statistics include only executions created before this timestamp (unix millis)."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeInteger),
+				Minimum: ptr.Float64(0),
+			},
+		},
+	},
+}
+
 var queryParameterBranch = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamBranch,
@@ -113,6 +199,20 @@ var queryParameterBranch = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterArtifactName = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamArtifactName,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The name (relative path) of the artifact."),
+		Required:    ptr.Bool(true),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
 func pipelineOperations(reflector *openapi3.Reflector) {
 	opCreate := openapi3.Operation{}
 	opCreate.WithTags("pipeline")
@@ -148,6 +248,80 @@ func pipelineOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/pipelines/{pipeline_uid}", opFind)
 
+	opBadge := openapi3.Operation{}
+	opBadge.WithTags("pipeline")
+	opBadge.WithMapOfAnything(map[string]interface{}{"operationId": "pipelineBadge"})
+	opBadge.WithParameters(queryParameterBadgeBranch)
+	_ = reflector.SetRequest(&opBadge, new(getPipelineRequest), http.MethodGet)
+	_ = reflector.SetStringResponse(&opBadge, http.StatusOK, "image/svg+xml")
+	_ = reflector.SetJSONResponse(&opBadge, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opBadge, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opBadge, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opBadge, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/pipelines/{pipeline_uid}/badge", opBadge)
+
+	opStats := openapi3.Operation{}
+	opStats.WithTags("pipeline")
+	opStats.WithMapOfAnything(map[string]interface{}{"operationId": "pipelineStats"})
+	opStats.WithParameters(queryParameterAfter, queryParameterStatsBefore)
+	_ = reflector.SetRequest(&opStats, new(getPipelineRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opStats, new(types.PipelineStats), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opStats, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opStats, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opStats, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opStats, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opStats, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/pipelines/{pipeline_uid}/stats", opStats)
+
+	opInvalidateCache := openapi3.Operation{}
+	opInvalidateCache.WithTags("pipeline")
+	opInvalidateCache.WithMapOfAnything(map[string]interface{}{"operationId": "invalidatePipelineCacheVolumes"})
+	_ = reflector.SetRequest(&opInvalidateCache, new(getPipelineRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opInvalidateCache, new(types.Pipeline), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opInvalidateCache, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opInvalidateCache, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opInvalidateCache, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opInvalidateCache, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pipelines/{pipeline_uid}/cache-volumes", opInvalidateCache)
+
+	opValidate := openapi3.Operation{}
+	opValidate.WithTags("pipeline")
+	opValidate.WithMapOfAnything(map[string]interface{}{"operationId": "validatePipeline"})
+	_ = reflector.SetRequest(&opValidate, new(validatePipelineRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opValidate, new(types.PipelineValidation), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opValidate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opValidate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opValidate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opValidate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opValidate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/pipelines/{pipeline_uid}/validate", opValidate)
+
+	opValidateRaw := openapi3.Operation{}
+	opValidateRaw.WithTags("pipeline")
+	opValidateRaw.WithMapOfAnything(map[string]interface{}{"operationId": "validatePipelineRaw"})
+	_ = reflector.SetRequest(&opValidateRaw, new(getPipelineRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opValidateRaw, new(types.PipelineValidation), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opValidateRaw, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opValidateRaw, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opValidateRaw, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opValidateRaw, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opValidateRaw, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pipelines/{pipeline_uid}/validate/raw", opValidateRaw)
+
+	opConvert := openapi3.Operation{}
+	opConvert.WithTags("pipeline")
+	opConvert.WithMapOfAnything(map[string]interface{}{"operationId": "convertPipeline"})
+	_ = reflector.SetRequest(&opConvert, new(convertPipelineRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opConvert, new(types.PipelineConversion), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opConvert, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opConvert, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opConvert, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opConvert, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opConvert, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/pipelines/convert", opConvert)
+
 	opDelete := openapi3.Operation{}
 	opDelete.WithTags("pipeline")
 	opDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deletePipeline"})
@@ -197,6 +371,18 @@ func pipelineOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodGet,
 		"/repos/{repo_ref}/pipelines/{pipeline_uid}/executions/{execution_number}", executionFind)
 
+	executionFindDAG := openapi3.Operation{}
+	executionFindDAG.WithTags("pipeline")
+	executionFindDAG.WithMapOfAnything(map[string]interface{}{"operationId": "findExecutionDAG"})
+	_ = reflector.SetRequest(&executionFindDAG, new(getExecutionRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&executionFindDAG, new(types.ExecutionDAG), http.StatusOK)
+	_ = reflector.SetJSONResponse(&executionFindDAG, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&executionFindDAG, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&executionFindDAG, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&executionFindDAG, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pipelines/{pipeline_uid}/executions/{execution_number}/dag", executionFindDAG)
+
 	executionCancel := openapi3.Operation{}
 	executionCancel.WithTags("pipeline")
 	executionCancel.WithMapOfAnything(map[string]interface{}{"operationId": "cancelExecution"})
@@ -209,6 +395,88 @@ func pipelineOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodPost,
 		"/repos/{repo_ref}/pipelines/{pipeline_uid}/executions/{execution_number}/cancel", executionCancel)
 
+	executionRetry := openapi3.Operation{}
+	executionRetry.WithTags("pipeline")
+	executionRetry.WithMapOfAnything(map[string]interface{}{"operationId": "retryExecution"})
+	_ = reflector.SetRequest(&executionRetry, new(retryExecutionRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&executionRetry, new(types.Execution), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&executionRetry, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&executionRetry, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&executionRetry, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&executionRetry, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&executionRetry, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pipelines/{pipeline_uid}/executions/{execution_number}/retry", executionRetry)
+
+	approvalFind := openapi3.Operation{}
+	approvalFind.WithTags("pipeline")
+	approvalFind.WithMapOfAnything(map[string]interface{}{"operationId": "findApproval"})
+	_ = reflector.SetRequest(&approvalFind, new(approvalRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&approvalFind, new(types.Approval), http.StatusOK)
+	_ = reflector.SetJSONResponse(&approvalFind, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&approvalFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&approvalFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&approvalFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&approvalFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pipelines/{pipeline_uid}/executions/{execution_number}/stages/{stage_number}/approval",
+		approvalFind)
+
+	approvalDecide := openapi3.Operation{}
+	approvalDecide.WithTags("pipeline")
+	approvalDecide.WithMapOfAnything(map[string]interface{}{"operationId": "decideApproval"})
+	_ = reflector.SetRequest(&approvalDecide, new(decideApprovalRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&approvalDecide, new(types.Approval), http.StatusOK)
+	_ = reflector.SetJSONResponse(&approvalDecide, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&approvalDecide, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&approvalDecide, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&approvalDecide, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&approvalDecide, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&approvalDecide, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pipelines/{pipeline_uid}/executions/{execution_number}/stages/{stage_number}/approval",
+		approvalDecide)
+
+	artifactList := openapi3.Operation{}
+	artifactList.WithTags("pipeline")
+	artifactList.WithMapOfAnything(map[string]interface{}{"operationId": "listArtifacts"})
+	_ = reflector.SetRequest(&artifactList, new(getExecutionRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&artifactList, []types.PipelineArtifact{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&artifactList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&artifactList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&artifactList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&artifactList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pipelines/{pipeline_uid}/executions/{execution_number}/artifacts", artifactList)
+
+	artifactUpload := openapi3.Operation{}
+	artifactUpload.WithTags("pipeline")
+	artifactUpload.WithMapOfAnything(map[string]interface{}{"operationId": "uploadArtifact"})
+	artifactUpload.WithParameters(queryParameterArtifactName)
+	_ = reflector.SetRequest(&artifactUpload, new(getExecutionRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&artifactUpload, new(types.PipelineArtifact), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&artifactUpload, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&artifactUpload, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&artifactUpload, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&artifactUpload, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&artifactUpload, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&artifactUpload, new(usererror.Error), http.StatusRequestEntityTooLarge)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/repos/{repo_ref}/pipelines/{pipeline_uid}/executions/{execution_number}/artifacts", artifactUpload)
+
+	artifactDownload := openapi3.Operation{}
+	artifactDownload.WithTags("pipeline")
+	artifactDownload.WithMapOfAnything(map[string]interface{}{"operationId": "downloadArtifact"})
+	_ = reflector.SetRequest(&artifactDownload, new(artifactRequest), http.MethodGet)
+	_ = reflector.SetStringResponse(&artifactDownload, http.StatusOK, "application/octet-stream")
+	_ = reflector.SetJSONResponse(&artifactDownload, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&artifactDownload, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&artifactDownload, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&artifactDownload, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pipelines/{pipeline_uid}/executions/{execution_number}/artifacts/{artifact_id}",
+		artifactDownload)
+
 	executionDelete := openapi3.Operation{}
 	executionDelete.WithTags("pipeline")
 	executionDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteExecution"})
@@ -296,6 +564,68 @@ func pipelineOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodGet,
 		"/repos/{repo_ref}/pipelines/{pipeline_uid}/triggers", triggerList)
 
+	scheduleCreate := openapi3.Operation{}
+	scheduleCreate.WithTags("pipeline")
+	scheduleCreate.WithMapOfAnything(map[string]interface{}{"operationId": "createSchedule"})
+	_ = reflector.SetRequest(&scheduleCreate, new(createScheduleRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&scheduleCreate, new(types.Schedule), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&scheduleCreate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&scheduleCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&scheduleCreate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&scheduleCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pipelines/{pipeline_uid}/schedules", scheduleCreate)
+
+	scheduleFind := openapi3.Operation{}
+	scheduleFind.WithTags("pipeline")
+	scheduleFind.WithMapOfAnything(map[string]interface{}{"operationId": "findSchedule"})
+	_ = reflector.SetRequest(&scheduleFind, new(getScheduleRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&scheduleFind, new(types.Schedule), http.StatusOK)
+	_ = reflector.SetJSONResponse(&scheduleFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&scheduleFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&scheduleFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&scheduleFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pipelines/{pipeline_uid}/schedules/{schedule_uid}", scheduleFind)
+
+	scheduleDelete := openapi3.Operation{}
+	scheduleDelete.WithTags("pipeline")
+	scheduleDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteSchedule"})
+	_ = reflector.SetRequest(&scheduleDelete, new(getScheduleRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&scheduleDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&scheduleDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&scheduleDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&scheduleDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&scheduleDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pipelines/{pipeline_uid}/schedules/{schedule_uid}", scheduleDelete)
+
+	scheduleUpdate := openapi3.Operation{}
+	scheduleUpdate.WithTags("pipeline")
+	scheduleUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateSchedule"})
+	_ = reflector.SetRequest(&scheduleUpdate, new(updateScheduleRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&scheduleUpdate, new(types.Schedule), http.StatusOK)
+	_ = reflector.SetJSONResponse(&scheduleUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&scheduleUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&scheduleUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&scheduleUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&scheduleUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch,
+		"/repos/{repo_ref}/pipelines/{pipeline_uid}/schedules/{schedule_uid}", scheduleUpdate)
+
+	scheduleList := openapi3.Operation{}
+	scheduleList.WithTags("pipeline")
+	scheduleList.WithMapOfAnything(map[string]interface{}{"operationId": "listSchedules"})
+	scheduleList.WithParameters(queryParameterQueryRepo, queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&scheduleList, new(pipelineRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&scheduleList, []types.Schedule{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&scheduleList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&scheduleList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&scheduleList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&scheduleList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pipelines/{pipeline_uid}/schedules", scheduleList)
+
 	logView := openapi3.Operation{}
 	logView.WithTags("pipeline")
 	logView.WithMapOfAnything(map[string]interface{}{"operationId": "viewLogs"})
@@ -308,4 +638,18 @@ func pipelineOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&logView, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet,
 		"/repos/{repo_ref}/pipelines/{pipeline_uid}/executions/{execution_number}/logs/{stage_number}/{step_number}", logView)
+
+	logTail := openapi3.Operation{}
+	logTail.WithTags("pipeline")
+	logTail.WithMapOfAnything(map[string]interface{}{"operationId": "tailLogs"})
+	_ = reflector.SetRequest(&logTail, new(logTailRequest), http.MethodGet)
+	_ = reflector.SetStringResponse(&logTail, http.StatusOK, "text/event-stream")
+	_ = reflector.SetJSONResponse(&logTail, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&logTail, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&logTail, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&logTail, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&logTail, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pipelines/{pipeline_uid}/executions/{execution_number}/logs/{stage_number}/{step_number}/tail",
+		logTail)
 }
@@ -17,6 +17,7 @@ package openapi
 import (
 	"net/http"
 
+	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/api/controller/pullreq"
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/api/usererror"
@@ -37,6 +38,16 @@ type listPullReqRequest struct {
 	repoRequest
 }
 
+type bulkPullReqRequest struct {
+	repoRequest
+	pullreq.BulkInput
+}
+
+type bulkPullReqProgressRequest struct {
+	repoRequest
+	JobUID string `path:"pullreq_bulk_job_uid"`
+}
+
 type pullReqRequest struct {
 	repoRequest
 	ID int64 `path:"pullreq_number"`
@@ -65,6 +76,16 @@ type mergePullReq struct {
 	pullreq.MergeInput
 }
 
+type revertPullReq struct {
+	pullReqRequest
+	pullreq.RevertInput
+}
+
+type cherryPickPullReq struct {
+	pullReqRequest
+	pullreq.CherryPickInput
+}
+
 type commentCreatePullReqRequest struct {
 	pullReqRequest
 	pullreq.CommentCreateInput
@@ -122,11 +143,21 @@ type fileViewDeletePullReqRequest struct {
 	Path string `path:"file_path"`
 }
 
+type dependencyAddPullReqRequest struct {
+	pullReqRequest
+	pullreq.DependencyAddInput
+}
+
+type dependencyDeletePullReqRequest struct {
+	pullReqRequest
+	DependencyID int64 `path:"pullreq_dependency_id"`
+}
+
 var queryParameterQueryPullRequest = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamQuery,
 		In:          openapi3.ParameterInQuery,
-		Description: ptr.String("The substring by which the pull requests are filtered."),
+		Description: ptr.String("The substring of the title or description by which the pull requests are filtered."),
 		Required:    ptr.Bool(false),
 		Schema: &openapi3.SchemaOrRef{
 			Schema: &openapi3.Schema{
@@ -136,6 +167,104 @@ var queryParameterQueryPullRequest = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterIsDraftPullRequest = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamIsDraft,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("If provided, only draft (true) or only ready (false) pull requests are returned."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeBoolean),
+			},
+		},
+	},
+}
+
+var queryParameterReviewerIDPullRequest = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamReviewerID,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Only pull requests where the principal with the ID is a reviewer are returned."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+			},
+		},
+	},
+}
+
+var queryParameterMentionedIDPullRequest = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamMentionedID,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Only pull requests where the principal with the ID has been mentioned are returned."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+			},
+		},
+	},
+}
+
+var queryParameterCreatedGtPullRequest = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamCreatedGt,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Only pull requests created after the time (unix milliseconds) are returned."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+			},
+		},
+	},
+}
+
+var queryParameterCreatedLtPullRequest = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamCreatedLt,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Only pull requests created before the time (unix milliseconds) are returned."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+			},
+		},
+	},
+}
+
+var queryParameterUpdatedGtPullRequest = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamUpdatedGt,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Only pull requests updated after the time (unix milliseconds) are returned."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+			},
+		},
+	},
+}
+
+var queryParameterUpdatedLtPullRequest = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamUpdatedLt,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Only pull requests updated before the time (unix milliseconds) are returned."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+			},
+		},
+	},
+}
+
 var queryParameterSourceRepoRefPullRequest = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        "source_repo_ref",
@@ -213,6 +342,21 @@ var queryParameterStatePullRequest = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterMergeMethodPullRequest = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamMergeMethod,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The merge method to preview. If omitted, all allowed merge methods are previewed."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+				Enum: enum.MergeMethod("").Enum(),
+			},
+		},
+	},
+}
+
 var queryParameterSortPullRequest = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamSort,
@@ -229,6 +373,25 @@ var queryParameterSortPullRequest = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterPathPullRequestDiff = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamPath,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The paths of the files to include in the diff."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeString),
+					},
+				},
+			},
+		},
+	},
+}
+
 var queryParameterKindPullRequestActivity = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamKind,
@@ -304,6 +467,9 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 		queryParameterStatePullRequest, queryParameterSourceRepoRefPullRequest,
 		queryParameterSourceBranchPullRequest, queryParameterTargetBranchPullRequest,
 		queryParameterQueryPullRequest, queryParameterCreatedByPullRequest,
+		queryParameterIsDraftPullRequest, queryParameterReviewerIDPullRequest, queryParameterMentionedIDPullRequest,
+		queryParameterCreatedGtPullRequest, queryParameterCreatedLtPullRequest,
+		queryParameterUpdatedGtPullRequest, queryParameterUpdatedLtPullRequest,
 		queryParameterOrder, queryParameterSortPullRequest,
 		queryParameterPage, queryParameterLimit)
 	_ = reflector.SetRequest(&listPullReq, new(listPullReqRequest), http.MethodGet)
@@ -314,6 +480,30 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&listPullReq, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/pullreq", listPullReq)
 
+	bulkPullReq := openapi3.Operation{}
+	bulkPullReq.WithTags("pullreq")
+	bulkPullReq.WithMapOfAnything(map[string]interface{}{"operationId": "bulkPullReq"})
+	_ = reflector.SetRequest(&bulkPullReq, new(bulkPullReqRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&bulkPullReq, new(pullreq.BulkOutput), http.StatusAccepted)
+	_ = reflector.SetJSONResponse(&bulkPullReq, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&bulkPullReq, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&bulkPullReq, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&bulkPullReq, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/pullreq/bulk", bulkPullReq)
+
+	bulkPullReqProgress := openapi3.Operation{}
+	bulkPullReqProgress.WithTags("pullreq")
+	bulkPullReqProgress.WithMapOfAnything(map[string]interface{}{"operationId": "bulkPullReqProgress"})
+	_ = reflector.SetRequest(&bulkPullReqProgress, new(bulkPullReqProgressRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&bulkPullReqProgress, new(pullreq.BulkProgressOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&bulkPullReqProgress, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&bulkPullReqProgress, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&bulkPullReqProgress, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&bulkPullReqProgress, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&bulkPullReqProgress, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/bulk/{pullreq_bulk_job_uid}", bulkPullReqProgress)
+
 	getPullReq := openapi3.Operation{}
 	getPullReq.WithTags("pullreq")
 	getPullReq.WithMapOfAnything(map[string]interface{}{"operationId": "getPullReq"})
@@ -347,6 +537,20 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&statePullReq, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/pullreq/{pullreq_number}/state", statePullReq)
 
+	mergePreviewPullReq := openapi3.Operation{}
+	mergePreviewPullReq.WithTags("pullreq")
+	mergePreviewPullReq.WithMapOfAnything(map[string]interface{}{"operationId": "mergePreviewPullReq"})
+	mergePreviewPullReq.WithParameters(queryParameterMergeMethodPullRequest)
+	_ = reflector.SetRequest(&mergePreviewPullReq, new(pullReqRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&mergePreviewPullReq, new(pullreq.MergePreviewOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&mergePreviewPullReq, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&mergePreviewPullReq, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&mergePreviewPullReq, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&mergePreviewPullReq, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&mergePreviewPullReq, new(usererror.Error), http.StatusPreconditionFailed)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/merge-preview", mergePreviewPullReq)
+
 	listPullReqActivities := openapi3.Operation{}
 	listPullReqActivities.WithTags("pullreq")
 	listPullReqActivities.WithMapOfAnything(map[string]interface{}{"operationId": "listPullReqActivities"})
@@ -434,6 +638,18 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodGet,
 		"/repos/{repo_ref}/pullreq/{pullreq_number}/reviewers", reviewerList)
 
+	reviewerSuggestions := openapi3.Operation{}
+	reviewerSuggestions.WithTags("pullreq")
+	reviewerSuggestions.WithMapOfAnything(map[string]interface{}{"operationId": "reviewerSuggestionsPullReq"})
+	_ = reflector.SetRequest(&reviewerSuggestions, new(pullReqRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&reviewerSuggestions, new([]pullreq.ReviewerSuggestion), http.StatusOK)
+	_ = reflector.SetJSONResponse(&reviewerSuggestions, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&reviewerSuggestions, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&reviewerSuggestions, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&reviewerSuggestions, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/reviewers/suggestions", reviewerSuggestions)
+
 	reviewerDelete := openapi3.Operation{}
 	reviewerDelete.WithTags("pullreq")
 	reviewerDelete.WithMapOfAnything(map[string]interface{}{"operationId": "reviewerDeletePullReq"})
@@ -473,6 +689,35 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodPost,
 		"/repos/{repo_ref}/pullreq/{pullreq_number}/merge", mergePullReqOp)
 
+	revertPullReqOp := openapi3.Operation{}
+	revertPullReqOp.WithTags("pullreq")
+	revertPullReqOp.WithMapOfAnything(map[string]interface{}{"operationId": "revertPullReqOp"})
+	_ = reflector.SetRequest(&revertPullReqOp, new(revertPullReq), http.MethodPost)
+	_ = reflector.SetJSONResponse(&revertPullReqOp, new(types.PullReq), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&revertPullReqOp, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&revertPullReqOp, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&revertPullReqOp, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&revertPullReqOp, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&revertPullReqOp, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&revertPullReqOp, new(usererror.Error), http.StatusPreconditionFailed)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/revert", revertPullReqOp)
+
+	cherryPickPullReqOp := openapi3.Operation{}
+	cherryPickPullReqOp.WithTags("pullreq")
+	cherryPickPullReqOp.WithMapOfAnything(map[string]interface{}{"operationId": "cherryPickPullReqOp"})
+	_ = reflector.SetRequest(&cherryPickPullReqOp, new(cherryPickPullReq), http.MethodPost)
+	_ = reflector.SetJSONResponse(&cherryPickPullReqOp, new(types.PullReq), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&cherryPickPullReqOp, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&cherryPickPullReqOp, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&cherryPickPullReqOp, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&cherryPickPullReqOp, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&cherryPickPullReqOp, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&cherryPickPullReqOp, new(usererror.Error), http.StatusPreconditionFailed)
+	_ = reflector.SetJSONResponse(&cherryPickPullReqOp, new(types.RulesViolations), http.StatusUnprocessableEntity)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/cherry-pick", cherryPickPullReqOp)
+
 	opListCommits := openapi3.Operation{}
 	opListCommits.WithTags("pullreq")
 	opListCommits.WithMapOfAnything(map[string]interface{}{"operationId": "listPullReqCommits"})
@@ -532,6 +777,46 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodDelete,
 		"/repos/{repo_ref}/pullreq/{pullreq_number}/file-views/{file_path}", fileViewDelete)
 
+	dependencyList := openapi3.Operation{}
+	dependencyList.WithTags("pullreq")
+	dependencyList.WithMapOfAnything(map[string]interface{}{"operationId": "dependencyListPullReq"})
+	_ = reflector.SetRequest(&dependencyList, new(pullReqRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&dependencyList, new(types.PullReqDependencies), http.StatusOK)
+	_ = reflector.SetJSONResponse(&dependencyList, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&dependencyList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&dependencyList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&dependencyList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&dependencyList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/dependencies", dependencyList)
+
+	dependencyAdd := openapi3.Operation{}
+	dependencyAdd.WithTags("pullreq")
+	dependencyAdd.WithMapOfAnything(map[string]interface{}{"operationId": "dependencyAddPullReq"})
+	_ = reflector.SetRequest(&dependencyAdd, new(dependencyAddPullReqRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&dependencyAdd, new(types.PullReqDependencyInfo), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&dependencyAdd, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&dependencyAdd, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&dependencyAdd, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&dependencyAdd, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&dependencyAdd, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&dependencyAdd, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/dependencies", dependencyAdd)
+
+	dependencyDelete := openapi3.Operation{}
+	dependencyDelete.WithTags("pullreq")
+	dependencyDelete.WithMapOfAnything(map[string]interface{}{"operationId": "dependencyDeletePullReq"})
+	_ = reflector.SetRequest(&dependencyDelete, new(dependencyDeletePullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&dependencyDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&dependencyDelete, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&dependencyDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&dependencyDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&dependencyDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&dependencyDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/dependencies/{pullreq_dependency_id}", dependencyDelete)
+
 	codeOwners := openapi3.Operation{}
 	codeOwners.WithTags("pullreq")
 	codeOwners.WithMapOfAnything(map[string]interface{}{"operationId": "codeownersPullReq"})
@@ -549,6 +834,7 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	opDiff := openapi3.Operation{}
 	opDiff.WithTags("pullreq")
 	opDiff.WithMapOfAnything(map[string]interface{}{"operationId": "diffPullReq"})
+	opDiff.WithParameters(queryParameterPathPullRequestDiff, queryParameterPage, queryParameterLimit)
 	_ = reflector.SetStringResponse(&opDiff, http.StatusOK, "text/plain")
 	_ = reflector.SetJSONResponse(&opDiff, new([]git.FileDiff), http.StatusOK)
 	_ = reflector.SetJSONResponse(&opDiff, new(usererror.Error), http.StatusInternalServerError)
@@ -556,4 +842,56 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opDiff, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.SetJSONResponse(&opDiff, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/pullreq/{pullreq_number}/diff", opDiff)
+
+	opSplitSuggestions := openapi3.Operation{}
+	opSplitSuggestions.WithTags("pullreq")
+	opSplitSuggestions.WithMapOfAnything(map[string]interface{}{"operationId": "splitSuggestionsPullReq"})
+	_ = reflector.SetRequest(&opSplitSuggestions, new(pullReqRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opSplitSuggestions, new(pullreq.SplitSuggestions), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSplitSuggestions, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSplitSuggestions, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSplitSuggestions, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSplitSuggestions, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/split-suggestions", opSplitSuggestions)
+
+	opPullReqWatchFind := openapi3.Operation{}
+	opPullReqWatchFind.WithTags("pullreq")
+	opPullReqWatchFind.WithMapOfAnything(map[string]interface{}{"operationId": "getPullReqWatch"})
+	_ = reflector.SetRequest(&opPullReqWatchFind, new(pullReqRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opPullReqWatchFind, new(types.PullReqWatch), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opPullReqWatchFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opPullReqWatchFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opPullReqWatchFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opPullReqWatchFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/watch", opPullReqWatchFind)
+
+	opPullReqWatchUpdate := openapi3.Operation{}
+	opPullReqWatchUpdate.WithTags("pullreq")
+	opPullReqWatchUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updatePullReqWatch"})
+	_ = reflector.SetRequest(&opPullReqWatchUpdate, &struct {
+		pullReqRequest
+		controller.WatchInput
+	}{}, http.MethodPut)
+	_ = reflector.SetJSONResponse(&opPullReqWatchUpdate, new(types.PullReqWatch), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opPullReqWatchUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opPullReqWatchUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opPullReqWatchUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opPullReqWatchUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opPullReqWatchUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/watch", opPullReqWatchUpdate)
+
+	opPullReqWatchDelete := openapi3.Operation{}
+	opPullReqWatchDelete.WithTags("pullreq")
+	opPullReqWatchDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deletePullReqWatch"})
+	_ = reflector.SetRequest(&opPullReqWatchDelete, new(pullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opPullReqWatchDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opPullReqWatchDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opPullReqWatchDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opPullReqWatchDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opPullReqWatchDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/watch", opPullReqWatchDelete)
 }
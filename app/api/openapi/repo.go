@@ -17,6 +17,7 @@ package openapi
 import (
 	"net/http"
 
+	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/api/usererror"
@@ -58,6 +59,16 @@ type getContentRequest struct {
 	Path string `path:"path"`
 }
 
+type getRawRequest struct {
+	getContentRequest
+	Range string `header:"Range" description:"Single byte range of the file to return, e.g. bytes=0-1023 or bytes=1024-."` //nolint:lll // struct tags can't be multiline
+}
+
+type archiveRequest struct {
+	repoRequest
+	Name string `path:"name" description:"The git reference followed by the archive format, e.g. main.zip or v1.0.tar.gz."`
+}
+
 type pathsDetailsRequest struct {
 	repoRequest
 	repo.PathsDetailsInput
@@ -73,6 +84,16 @@ type commitFilesRequest struct {
 	repo.CommitFilesOptions
 }
 
+type cherryPickCommitsRequest struct {
+	repoRequest
+	repo.CherryPickCommitsInput
+}
+
+type revertCommitRequest struct {
+	GetCommitRequest
+	repo.RevertCommitInput
+}
+
 // contentType is a plugin for repo.ContentType to allow using oneof.
 type contentType string
 
@@ -115,11 +136,31 @@ type GetCommitRequest struct {
 	CommitSHA string `path:"commit_sha"`
 }
 
+type commitCommentCreateRequest struct {
+	GetCommitRequest
+	repo.CommitCommentCreateInput
+}
+
+type commitCommentRequest struct {
+	GetCommitRequest
+	ID int64 `path:"commit_comment_id"`
+}
+
+type commitCommentUpdateRequest struct {
+	commitCommentRequest
+	repo.CommitCommentUpdateInput
+}
+
 type calculateCommitDivergenceRequest struct {
 	repoRequest
 	repo.GetCommitDivergencesInput
 }
 
+type calculateBranchDivergenceRequest struct {
+	repoRequest
+	repo.GetBranchDivergencesInput
+}
+
 type listBranchesRequest struct {
 	repoRequest
 }
@@ -143,6 +184,16 @@ type createTagRequest struct {
 	repo.CreateCommitTagInput
 }
 
+type releaseNotesGenerateRequest struct {
+	repoRequest
+	repo.ReleaseNotesGenerateInput
+}
+
+type releaseChangesRequest struct {
+	repoRequest
+	ReleaseRange string `path:"release_range"`
+}
+
 type listTagsRequest struct {
 	repoRequest
 }
@@ -245,6 +296,102 @@ var queryParameterUntil = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterIncludeEntryCommits = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamIncludeEntryCommits,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Indicates whether the latest commits of directory entries should be included."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeBoolean),
+				Default: ptrptr(false),
+			},
+		},
+	},
+}
+
+var queryParameterRecursive = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamRecursive,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Indicates whether the entries of all subdirectories should be listed too."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeBoolean),
+				Default: ptrptr(false),
+			},
+		},
+	},
+}
+
+var queryParameterMaxDepth = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamMaxDepth,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The maximum depth of the listed entries relative to the path, unlimited if omitted."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeInteger),
+				Minimum: ptr.Float64(1.0),
+			},
+		},
+	},
+}
+
+var queryParameterInclude = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name: request.QueryParamInclude,
+		In:   openapi3.ParameterInQuery,
+		Description: ptr.String("Glob patterns of the entries to list. " +
+			"Patterns without a slash are matched against the entry name, others against the full entry path."),
+		Required: ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeString),
+					},
+				},
+			},
+		},
+	},
+}
+
+var queryParameterCursor = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamCursor,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The cursor returned with the previous page, the git_ref is ignored if it's provided."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterTreeLimit = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamLimit,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The maximum number of entries to return."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeInteger),
+				Default: ptrptr(request.TreeLimitDefault),
+				Minimum: ptr.Float64(1.0),
+				Maximum: ptr.Float64(request.TreeLimitMax),
+			},
+		},
+	},
+}
+
 var queryParameterIncludeCommit = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamIncludeCommit,
@@ -290,6 +437,20 @@ var queryParameterLineTo = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterIgnoreRevsFile = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamIgnoreRevsFile,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Path of a file in the repository listing commits to ignore (e.g. .git-blame-ignore-revs)"),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
 // TODO: this is technically coming from harness package, but we can't reference that.
 var queryParameterSpacePath = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
@@ -400,6 +561,48 @@ var queryParameterCommitter = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterQueryCommitSearch = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamQuery,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The text searched for in the commit title and message."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterCommitSearchAuthor = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamAuthor,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The text searched for in the commit author name and email."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterCommitSearchCommitter = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamCommitter,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The text searched for in the commit committer name and email."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
 var queryParameterQueryRuleList = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamQuery,
@@ -446,6 +649,54 @@ var queryParameterBypassRules = openapi3.ParameterOrRef{
 }
 
 //nolint:funlen
+var queryParameterQueryPipelineCache = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamQuery,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The substring which is used to filter the pipeline caches by their key."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterPipelineCacheKey = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamKey,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The key of the pipeline cache."),
+		Required:    ptr.Bool(true),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterPipelineCacheRestoreKey = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name: request.QueryParamRestoreKey,
+		In:   openapi3.ParameterInQuery,
+		Description: ptr.String("The key prefixes tried in order if there's no cache with the key. " +
+			"The most recently saved cache with a key starting with the prefix is restored."),
+		Required: ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeString),
+					},
+				},
+			},
+		},
+	},
+}
+
 func repoOperations(reflector *openapi3.Reflector) {
 	createRepository := openapi3.Operation{}
 	createRepository.WithTags("repository")
@@ -471,6 +722,76 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&importRepository, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/import", importRepository)
 
+	opImportBundle := openapi3.Operation{}
+	opImportBundle.WithTags("repository")
+	opImportBundle.WithMapOfAnything(map[string]interface{}{"operationId": "importRepositoryBundle"})
+	_ = reflector.SetRequest(&opImportBundle, &struct {
+		ParentRef string `query:"parent_ref"`
+		UID       string `query:"uid"`
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opImportBundle, new(types.Repository), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opImportBundle, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opImportBundle, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opImportBundle, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opImportBundle, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opImportBundle, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/import-bundle", opImportBundle)
+
+	opExportBundle := openapi3.Operation{}
+	opExportBundle.WithTags("repository")
+	opExportBundle.WithMapOfAnything(map[string]interface{}{"operationId": "exportRepositoryBundle"})
+	_ = reflector.SetRequest(&opExportBundle, &struct {
+		repoRequest
+		SinceSHA string `query:"since_sha"`
+		Metadata bool   `query:"metadata"`
+	}{}, http.MethodGet)
+	_ = reflector.SetStringResponse(&opExportBundle, http.StatusOK, "application/x-tar")
+	_ = reflector.SetJSONResponse(&opExportBundle, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opExportBundle, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opExportBundle, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opExportBundle, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opExportBundle, new(usererror.Error), http.StatusPreconditionFailed)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/bundle", opExportBundle)
+
+	opApplyBundle := openapi3.Operation{}
+	opApplyBundle.WithTags("repository")
+	opApplyBundle.WithMapOfAnything(map[string]interface{}{"operationId": "applyRepositoryBundle"})
+	_ = reflector.SetRequest(&opApplyBundle, &struct {
+		repoRequest
+		Prune bool `query:"prune"`
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opApplyBundle, new(types.Repository), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opApplyBundle, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opApplyBundle, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opApplyBundle, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opApplyBundle, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opApplyBundle, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opApplyBundle, new(usererror.Error), http.StatusPreconditionFailed)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/bundle", opApplyBundle)
+
+	opGetStats := openapi3.Operation{}
+	opGetStats.WithTags("repository")
+	opGetStats.WithMapOfAnything(map[string]interface{}{"operationId": "getRepositoryStats"})
+	_ = reflector.SetRequest(&opGetStats, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opGetStats, new(types.RepositoryStats), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opGetStats, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opGetStats, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opGetStats, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opGetStats, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/stats", opGetStats)
+
+	opRefreshStats := openapi3.Operation{}
+	opRefreshStats.WithTags("repository")
+	opRefreshStats.WithMapOfAnything(map[string]interface{}{"operationId": "refreshRepositoryStats"})
+	_ = reflector.SetRequest(&opRefreshStats, new(repoRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opRefreshStats, nil, http.StatusAccepted)
+	_ = reflector.SetJSONResponse(&opRefreshStats, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRefreshStats, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRefreshStats, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRefreshStats, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opRefreshStats, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/stats/refresh", opRefreshStats)
+
 	opFind := openapi3.Operation{}
 	opFind.WithTags("repository")
 	opFind.WithMapOfAnything(map[string]interface{}{"operationId": "findRepository"})
@@ -497,12 +818,14 @@ func repoOperations(reflector *openapi3.Reflector) {
 	opDelete := openapi3.Operation{}
 	opDelete.WithTags("repository")
 	opDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteRepository"})
+	opDelete.WithParameters(queryParameterConfirmationToken)
 	_ = reflector.SetRequest(&opDelete, new(repoRequest), http.MethodDelete)
 	_ = reflector.SetJSONResponse(&opDelete, nil, http.StatusNoContent)
 	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusUnauthorized)
 	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusPreconditionRequired)
 	_ = reflector.Spec.AddOperation(http.MethodDelete, "/repos/{repo_ref}", opDelete)
 
 	opMove := openapi3.Operation{}
@@ -530,7 +853,7 @@ func repoOperations(reflector *openapi3.Reflector) {
 	opGetContent := openapi3.Operation{}
 	opGetContent.WithTags("repository")
 	opGetContent.WithMapOfAnything(map[string]interface{}{"operationId": "getContent"})
-	opGetContent.WithParameters(queryParameterGitRef, queryParameterIncludeCommit)
+	opGetContent.WithParameters(queryParameterGitRef, queryParameterIncludeCommit, queryParameterIncludeEntryCommits)
 	_ = reflector.SetRequest(&opGetContent, new(getContentRequest), http.MethodGet)
 	_ = reflector.SetJSONResponse(&opGetContent, new(getContentOutput), http.StatusOK)
 	_ = reflector.SetJSONResponse(&opGetContent, new(usererror.Error), http.StatusInternalServerError)
@@ -539,6 +862,20 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opGetContent, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/content/{path}", opGetContent)
 
+	opListTree := openapi3.Operation{}
+	opListTree.WithTags("repository")
+	opListTree.WithMapOfAnything(map[string]interface{}{"operationId": "listTree"})
+	opListTree.WithParameters(queryParameterGitRef, queryParameterRecursive, queryParameterMaxDepth,
+		queryParameterInclude, queryParameterCursor, queryParameterTreeLimit)
+	_ = reflector.SetRequest(&opListTree, new(getContentRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListTree, new(repo.ListTreeOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListTree, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opListTree, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListTree, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListTree, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opListTree, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/tree/{path}", opListTree)
+
 	opPathDetails := openapi3.Operation{}
 	opPathDetails.WithTags("repository")
 	opPathDetails.WithMapOfAnything(map[string]interface{}{"operationId": "pathDetails"})
@@ -555,20 +892,35 @@ func repoOperations(reflector *openapi3.Reflector) {
 	opGetRaw.WithTags("repository")
 	opGetRaw.WithMapOfAnything(map[string]interface{}{"operationId": "getRaw"})
 	opGetRaw.WithParameters(queryParameterGitRef)
-	_ = reflector.SetRequest(&opGetRaw, new(getContentRequest), http.MethodGet)
+	_ = reflector.SetRequest(&opGetRaw, new(getRawRequest), http.MethodGet)
 	// TODO: Figure out how to provide proper list of all potential mime types
 	_ = reflector.SetStringResponse(&opGetRaw, http.StatusOK, "")
+	_ = reflector.SetStringResponse(&opGetRaw, http.StatusPartialContent, "")
+	_ = reflector.SetJSONResponse(&opGetRaw, new(usererror.Error), http.StatusBadRequest)
 	_ = reflector.SetJSONResponse(&opGetRaw, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&opGetRaw, new(usererror.Error), http.StatusUnauthorized)
 	_ = reflector.SetJSONResponse(&opGetRaw, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.SetJSONResponse(&opGetRaw, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/raw/{path}", opGetRaw)
 
+	opArchive := openapi3.Operation{}
+	opArchive.WithTags("repository")
+	opArchive.WithMapOfAnything(map[string]interface{}{"operationId": "getArchive"})
+	_ = reflector.SetRequest(&opArchive, new(archiveRequest), http.MethodGet)
+	_ = reflector.SetStringResponse(&opArchive, http.StatusOK, "application/octet-stream")
+	_ = reflector.SetJSONResponse(&opArchive, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opArchive, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opArchive, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opArchive, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opArchive, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opArchive, new(usererror.Error), http.StatusPreconditionFailed)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/archive/{name}", opArchive)
+
 	opGetBlame := openapi3.Operation{}
 	opGetBlame.WithTags("repository")
 	opGetBlame.WithMapOfAnything(map[string]interface{}{"operationId": "getBlame"})
 	opGetBlame.WithParameters(queryParameterGitRef,
-		queryParameterLineFrom, queryParameterLineTo)
+		queryParameterLineFrom, queryParameterLineTo, queryParameterIgnoreRevsFile)
 	_ = reflector.SetRequest(&opGetBlame, new(getBlameRequest), http.MethodGet)
 	_ = reflector.SetJSONResponse(&opGetBlame, []git.BlamePart{}, http.StatusOK)
 	_ = reflector.SetJSONResponse(&opGetBlame, new(usererror.Error), http.StatusInternalServerError)
@@ -590,6 +942,21 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opListCommits, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/commits", opListCommits)
 
+	opSearchCommits := openapi3.Operation{}
+	opSearchCommits.WithTags("repository")
+	opSearchCommits.WithMapOfAnything(map[string]interface{}{"operationId": "searchCommits"})
+	opSearchCommits.WithParameters(queryParameterQueryCommitSearch, queryParameterCommitSearchAuthor,
+		queryParameterCommitSearchCommitter, queryParameterSince, queryParameterUntil,
+		queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opSearchCommits, new(listCommitsRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opSearchCommits, []types.Commit{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSearchCommits, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSearchCommits, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSearchCommits, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSearchCommits, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opSearchCommits, new(usererror.Error), http.StatusPreconditionFailed)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/commits/search", opSearchCommits)
+
 	opGetCommit := openapi3.Operation{}
 	opGetCommit.WithTags("repository")
 	opGetCommit.WithMapOfAnything(map[string]interface{}{"operationId": "getCommit"})
@@ -613,6 +980,19 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/commits/calculate-divergence",
 		opCalulateCommitDivergence)
 
+	opCalulateBranchDivergence := openapi3.Operation{}
+	opCalulateBranchDivergence.WithTags("repository")
+	opCalulateBranchDivergence.WithMapOfAnything(map[string]interface{}{"operationId": "calculateBranchDivergence"})
+	_ = reflector.SetRequest(&opCalulateBranchDivergence, new(calculateBranchDivergenceRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCalulateBranchDivergence, []repo.BranchDivergence{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opCalulateBranchDivergence, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCalulateBranchDivergence, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCalulateBranchDivergence, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCalulateBranchDivergence, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCalulateBranchDivergence, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/branches/calculate-divergence",
+		opCalulateBranchDivergence)
+
 	opCreateBranch := openapi3.Operation{}
 	opCreateBranch.WithTags("repository")
 	opCreateBranch.WithMapOfAnything(map[string]interface{}{"operationId": "createBranch"})
@@ -704,6 +1084,32 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opDeleteTag, new(types.RulesViolations), http.StatusUnprocessableEntity)
 	_ = reflector.Spec.AddOperation(http.MethodDelete, "/repos/{repo_ref}/tags/{tag_name}", opDeleteTag)
 
+	opReleaseNotesGenerate := openapi3.Operation{}
+	opReleaseNotesGenerate.WithTags("repository")
+	opReleaseNotesGenerate.WithMapOfAnything(map[string]interface{}{"operationId": "generateRepoReleaseNotes"})
+	_ = reflector.SetRequest(&opReleaseNotesGenerate, new(releaseNotesGenerateRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opReleaseNotesGenerate, new(repo.ReleaseNotes), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opReleaseNotesGenerate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opReleaseNotesGenerate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opReleaseNotesGenerate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opReleaseNotesGenerate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opReleaseNotesGenerate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/releases/generate-notes",
+		opReleaseNotesGenerate)
+
+	opReleaseChanges := openapi3.Operation{}
+	opReleaseChanges.WithTags("repository")
+	opReleaseChanges.WithMapOfAnything(map[string]interface{}{"operationId": "getRepoReleaseChanges"})
+	_ = reflector.SetRequest(&opReleaseChanges, new(releaseChangesRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opReleaseChanges, new(repo.ReleaseChanges), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opReleaseChanges, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opReleaseChanges, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opReleaseChanges, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opReleaseChanges, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opReleaseChanges, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/releases/{release_range}/changes",
+		opReleaseChanges)
+
 	opCommitFiles := openapi3.Operation{}
 	opCommitFiles.WithTags("repository")
 	opCommitFiles.WithMapOfAnything(map[string]interface{}{"operationId": "commitFiles"})
@@ -717,6 +1123,34 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opCommitFiles, new(types.RulesViolations), http.StatusUnprocessableEntity)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/commits", opCommitFiles)
 
+	opCherryPickCommits := openapi3.Operation{}
+	opCherryPickCommits.WithTags("repository")
+	opCherryPickCommits.WithMapOfAnything(map[string]interface{}{"operationId": "cherryPickCommits"})
+	_ = reflector.SetRequest(&opCherryPickCommits, new(cherryPickCommitsRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCherryPickCommits, types.CherryPickCommitsResponse{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opCherryPickCommits, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCherryPickCommits, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCherryPickCommits, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCherryPickCommits, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCherryPickCommits, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opCherryPickCommits, new(usererror.Error), http.StatusPreconditionFailed)
+	_ = reflector.SetJSONResponse(&opCherryPickCommits, new(types.RulesViolations), http.StatusUnprocessableEntity)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/commits/cherry-pick", opCherryPickCommits)
+
+	opRevertCommit := openapi3.Operation{}
+	opRevertCommit.WithTags("repository")
+	opRevertCommit.WithMapOfAnything(map[string]interface{}{"operationId": "revertCommit"})
+	_ = reflector.SetRequest(&opRevertCommit, new(revertCommitRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opRevertCommit, types.RevertCommitResponse{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRevertCommit, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRevertCommit, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRevertCommit, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRevertCommit, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRevertCommit, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opRevertCommit, new(usererror.Error), http.StatusPreconditionFailed)
+	_ = reflector.SetJSONResponse(&opRevertCommit, new(types.RulesViolations), http.StatusUnprocessableEntity)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/commits/{commit_sha}/revert", opRevertCommit)
+
 	opDiff := openapi3.Operation{}
 	opDiff.WithTags("repository")
 	opDiff.WithMapOfAnything(map[string]interface{}{"operationId": "rawDiff"})
@@ -739,6 +1173,57 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opCommitDiff, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/commits/{commit_sha}/diff", opCommitDiff)
 
+	opCommitCommentList := openapi3.Operation{}
+	opCommitCommentList.WithTags("repository")
+	opCommitCommentList.WithMapOfAnything(map[string]interface{}{"operationId": "listCommitComments"})
+	_ = reflector.SetRequest(&opCommitCommentList, new(GetCommitRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opCommitCommentList, []types.CommitComment{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opCommitCommentList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCommitCommentList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCommitCommentList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCommitCommentList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/commits/{commit_sha}/comments", opCommitCommentList)
+
+	opCommitCommentCreate := openapi3.Operation{}
+	opCommitCommentCreate.WithTags("repository")
+	opCommitCommentCreate.WithMapOfAnything(map[string]interface{}{"operationId": "createCommitComment"})
+	_ = reflector.SetRequest(&opCommitCommentCreate, new(commitCommentCreateRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCommitCommentCreate, new(types.CommitComment), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCommitCommentCreate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCommitCommentCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCommitCommentCreate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCommitCommentCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCommitCommentCreate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/commits/{commit_sha}/comments", opCommitCommentCreate)
+
+	opCommitCommentUpdate := openapi3.Operation{}
+	opCommitCommentUpdate.WithTags("repository")
+	opCommitCommentUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateCommitComment"})
+	_ = reflector.SetRequest(&opCommitCommentUpdate, new(commitCommentUpdateRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opCommitCommentUpdate, new(types.CommitComment), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opCommitCommentUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCommitCommentUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCommitCommentUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCommitCommentUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCommitCommentUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch,
+		"/repos/{repo_ref}/commits/{commit_sha}/comments/{commit_comment_id}", opCommitCommentUpdate)
+
+	opCommitCommentDelete := openapi3.Operation{}
+	opCommitCommentDelete.WithTags("repository")
+	opCommitCommentDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteCommitComment"})
+	_ = reflector.SetRequest(&opCommitCommentDelete, new(commitCommentRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opCommitCommentDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opCommitCommentDelete, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCommitCommentDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCommitCommentDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCommitCommentDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCommitCommentDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/commits/{commit_sha}/comments/{commit_comment_id}", opCommitCommentDelete)
+
 	opDiffStats := openapi3.Operation{}
 	opDiffStats.WithTags("repository")
 	opDiffStats.WithMapOfAnything(map[string]interface{}{"operationId": "diffStats"})
@@ -749,6 +1234,19 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opDiffStats, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/diff-stats/{range}", opDiffStats)
 
+	opCompare := openapi3.Operation{}
+	opCompare.WithTags("repository")
+	opCompare.WithMapOfAnything(map[string]interface{}{"operationId": "compare"})
+	opCompare.WithParameters(queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opCompare, new(getRawDiffRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opCompare, new(repo.CompareOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opCompare, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCompare, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCompare, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCompare, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCompare, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/compare/{range}", opCompare)
+
 	opMergeCheck := openapi3.Operation{}
 	opMergeCheck.WithTags("repository")
 	opMergeCheck.WithMapOfAnything(map[string]interface{}{"operationId": "mergeCheck"})
@@ -853,4 +1351,484 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opCodeOwnerValidate, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.SetJSONResponse(&opCodeOwnerValidate, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/codeowners/validate", opCodeOwnerValidate)
+
+	opMergeTemplateList := openapi3.Operation{}
+	opMergeTemplateList.WithTags("repository")
+	opMergeTemplateList.WithMapOfAnything(map[string]interface{}{"operationId": "listMergeTemplates"})
+	_ = reflector.SetRequest(&opMergeTemplateList, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opMergeTemplateList, []types.MergeTemplate{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opMergeTemplateList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMergeTemplateList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMergeTemplateList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMergeTemplateList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/merge-templates", opMergeTemplateList)
+
+	opMergeTemplateUpdate := openapi3.Operation{}
+	opMergeTemplateUpdate.WithTags("repository")
+	opMergeTemplateUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateMergeTemplate"})
+	_ = reflector.SetRequest(&opMergeTemplateUpdate, &struct {
+		repoRequest
+		Method enum.MergeMethod `path:"merge_method"`
+		repo.MergeTemplateUpdateInput
+	}{}, http.MethodPut)
+	_ = reflector.SetJSONResponse(&opMergeTemplateUpdate, new(types.MergeTemplate), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opMergeTemplateUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opMergeTemplateUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMergeTemplateUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMergeTemplateUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMergeTemplateUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/repos/{repo_ref}/merge-templates/{merge_method}", opMergeTemplateUpdate)
+
+	opMergeTemplateDelete := openapi3.Operation{}
+	opMergeTemplateDelete.WithTags("repository")
+	opMergeTemplateDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteMergeTemplate"})
+	_ = reflector.SetRequest(&opMergeTemplateDelete, &struct {
+		repoRequest
+		Method enum.MergeMethod `path:"merge_method"`
+	}{}, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opMergeTemplateDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opMergeTemplateDelete, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opMergeTemplateDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMergeTemplateDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMergeTemplateDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMergeTemplateDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/merge-templates/{merge_method}", opMergeTemplateDelete)
+
+	opSigningKeyFind := openapi3.Operation{}
+	opSigningKeyFind.WithTags("repository")
+	opSigningKeyFind.WithMapOfAnything(map[string]interface{}{"operationId": "getRepoSigningKey"})
+	_ = reflector.SetRequest(&opSigningKeyFind, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opSigningKeyFind, new(types.SigningKey), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSigningKeyFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSigningKeyFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSigningKeyFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSigningKeyFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/signing-key", opSigningKeyFind)
+
+	opSigningKeyUpdate := openapi3.Operation{}
+	opSigningKeyUpdate.WithTags("repository")
+	opSigningKeyUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateRepoSigningKey"})
+	_ = reflector.SetRequest(&opSigningKeyUpdate, &struct {
+		repoRequest
+		repo.SigningKeyUpdateInput
+	}{}, http.MethodPut)
+	_ = reflector.SetJSONResponse(&opSigningKeyUpdate, new(types.SigningKey), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSigningKeyUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSigningKeyUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSigningKeyUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSigningKeyUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSigningKeyUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/repos/{repo_ref}/signing-key", opSigningKeyUpdate)
+
+	opSigningKeyDelete := openapi3.Operation{}
+	opSigningKeyDelete.WithTags("repository")
+	opSigningKeyDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteRepoSigningKey"})
+	_ = reflector.SetRequest(&opSigningKeyDelete, new(repoRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opSigningKeyDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opSigningKeyDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opSigningKeyDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSigningKeyDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSigningKeyDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/repos/{repo_ref}/signing-key", opSigningKeyDelete)
+
+	opMirrorFind := openapi3.Operation{}
+	opMirrorFind.WithTags("repository")
+	opMirrorFind.WithMapOfAnything(map[string]interface{}{"operationId": "getRepoMirror"})
+	_ = reflector.SetRequest(&opMirrorFind, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opMirrorFind, new(types.RepositoryMirror), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opMirrorFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMirrorFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMirrorFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMirrorFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/mirror", opMirrorFind)
+
+	opMirrorCreate := openapi3.Operation{}
+	opMirrorCreate.WithTags("repository")
+	opMirrorCreate.WithMapOfAnything(map[string]interface{}{"operationId": "createRepoMirror"})
+	_ = reflector.SetRequest(&opMirrorCreate, &struct {
+		repoRequest
+		repo.MirrorCreateInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opMirrorCreate, new(types.RepositoryMirror), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opMirrorCreate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opMirrorCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMirrorCreate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMirrorCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMirrorCreate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opMirrorCreate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opMirrorCreate, new(usererror.Error), http.StatusPreconditionFailed)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/mirror", opMirrorCreate)
+
+	opMirrorUpdate := openapi3.Operation{}
+	opMirrorUpdate.WithTags("repository")
+	opMirrorUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateRepoMirror"})
+	_ = reflector.SetRequest(&opMirrorUpdate, &struct {
+		repoRequest
+		repo.MirrorUpdateInput
+	}{}, http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opMirrorUpdate, new(types.RepositoryMirror), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opMirrorUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opMirrorUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMirrorUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMirrorUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMirrorUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/repos/{repo_ref}/mirror", opMirrorUpdate)
+
+	opMirrorDelete := openapi3.Operation{}
+	opMirrorDelete.WithTags("repository")
+	opMirrorDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteRepoMirror"})
+	_ = reflector.SetRequest(&opMirrorDelete, new(repoRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opMirrorDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opMirrorDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMirrorDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMirrorDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMirrorDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/repos/{repo_ref}/mirror", opMirrorDelete)
+
+	opMirrorSync := openapi3.Operation{}
+	opMirrorSync.WithTags("repository")
+	opMirrorSync.WithMapOfAnything(map[string]interface{}{"operationId": "syncRepoMirror"})
+	_ = reflector.SetRequest(&opMirrorSync, new(repoRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opMirrorSync, nil, http.StatusAccepted)
+	_ = reflector.SetJSONResponse(&opMirrorSync, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMirrorSync, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMirrorSync, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMirrorSync, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opMirrorSync, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/mirror/sync", opMirrorSync)
+
+	opRepoAutolinkList := openapi3.Operation{}
+	opRepoAutolinkList.WithTags("repository")
+	opRepoAutolinkList.WithMapOfAnything(map[string]interface{}{"operationId": "listRepoAutolinks"})
+	_ = reflector.SetRequest(&opRepoAutolinkList, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opRepoAutolinkList, []types.Autolink{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRepoAutolinkList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRepoAutolinkList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRepoAutolinkList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRepoAutolinkList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/autolinks", opRepoAutolinkList)
+
+	opRepoAutolinkCreate := openapi3.Operation{}
+	opRepoAutolinkCreate.WithTags("repository")
+	opRepoAutolinkCreate.WithMapOfAnything(map[string]interface{}{"operationId": "createRepoAutolink"})
+	_ = reflector.SetRequest(&opRepoAutolinkCreate, &struct {
+		repoRequest
+		controller.AutolinkCreateInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opRepoAutolinkCreate, new(types.Autolink), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opRepoAutolinkCreate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRepoAutolinkCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRepoAutolinkCreate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRepoAutolinkCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRepoAutolinkCreate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opRepoAutolinkCreate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/autolinks", opRepoAutolinkCreate)
+
+	opRepoAutolinkUpdate := openapi3.Operation{}
+	opRepoAutolinkUpdate.WithTags("repository")
+	opRepoAutolinkUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateRepoAutolink"})
+	_ = reflector.SetRequest(&opRepoAutolinkUpdate, &struct {
+		repoRequest
+		AutolinkID int64 `path:"autolink_id"`
+		controller.AutolinkUpdateInput
+	}{}, http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opRepoAutolinkUpdate, new(types.Autolink), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRepoAutolinkUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRepoAutolinkUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRepoAutolinkUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRepoAutolinkUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRepoAutolinkUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opRepoAutolinkUpdate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPatch,
+		"/repos/{repo_ref}/autolinks/{autolink_id}", opRepoAutolinkUpdate)
+
+	opRepoAutolinkDelete := openapi3.Operation{}
+	opRepoAutolinkDelete.WithTags("repository")
+	opRepoAutolinkDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteRepoAutolink"})
+	_ = reflector.SetRequest(&opRepoAutolinkDelete, &struct {
+		repoRequest
+		AutolinkID int64 `path:"autolink_id"`
+	}{}, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opRepoAutolinkDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opRepoAutolinkDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRepoAutolinkDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRepoAutolinkDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRepoAutolinkDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/autolinks/{autolink_id}", opRepoAutolinkDelete)
+
+	opRepoSavedComparisonList := openapi3.Operation{}
+	opRepoSavedComparisonList.WithTags("repository")
+	opRepoSavedComparisonList.WithMapOfAnything(map[string]interface{}{"operationId": "listRepoSavedComparisons"})
+	_ = reflector.SetRequest(&opRepoSavedComparisonList, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opRepoSavedComparisonList, []types.SavedComparison{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRepoSavedComparisonList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRepoSavedComparisonList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRepoSavedComparisonList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRepoSavedComparisonList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/comparisons", opRepoSavedComparisonList)
+
+	opRepoSavedComparisonCreate := openapi3.Operation{}
+	opRepoSavedComparisonCreate.WithTags("repository")
+	opRepoSavedComparisonCreate.WithMapOfAnything(map[string]interface{}{"operationId": "createRepoSavedComparison"})
+	_ = reflector.SetRequest(&opRepoSavedComparisonCreate, &struct {
+		repoRequest
+		repo.SavedComparisonCreateInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opRepoSavedComparisonCreate, new(types.SavedComparison), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opRepoSavedComparisonCreate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRepoSavedComparisonCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRepoSavedComparisonCreate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRepoSavedComparisonCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRepoSavedComparisonCreate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opRepoSavedComparisonCreate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/comparisons", opRepoSavedComparisonCreate)
+
+	opRepoSavedComparisonFind := openapi3.Operation{}
+	opRepoSavedComparisonFind.WithTags("repository")
+	opRepoSavedComparisonFind.WithMapOfAnything(map[string]interface{}{"operationId": "findRepoSavedComparison"})
+	_ = reflector.SetRequest(&opRepoSavedComparisonFind, &struct {
+		repoRequest
+		ComparisonUID string `path:"comparison_uid"`
+	}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opRepoSavedComparisonFind, new(types.SavedComparison), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRepoSavedComparisonFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRepoSavedComparisonFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRepoSavedComparisonFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRepoSavedComparisonFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/comparisons/{comparison_uid}", opRepoSavedComparisonFind)
+
+	opRepoSavedComparisonUpdate := openapi3.Operation{}
+	opRepoSavedComparisonUpdate.WithTags("repository")
+	opRepoSavedComparisonUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateRepoSavedComparison"})
+	_ = reflector.SetRequest(&opRepoSavedComparisonUpdate, &struct {
+		repoRequest
+		ComparisonUID string `path:"comparison_uid"`
+		repo.SavedComparisonUpdateInput
+	}{}, http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opRepoSavedComparisonUpdate, new(types.SavedComparison), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRepoSavedComparisonUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRepoSavedComparisonUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRepoSavedComparisonUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRepoSavedComparisonUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRepoSavedComparisonUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opRepoSavedComparisonUpdate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPatch,
+		"/repos/{repo_ref}/comparisons/{comparison_uid}", opRepoSavedComparisonUpdate)
+
+	opRepoSavedComparisonDelete := openapi3.Operation{}
+	opRepoSavedComparisonDelete.WithTags("repository")
+	opRepoSavedComparisonDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteRepoSavedComparison"})
+	_ = reflector.SetRequest(&opRepoSavedComparisonDelete, &struct {
+		repoRequest
+		ComparisonUID string `path:"comparison_uid"`
+	}{}, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opRepoSavedComparisonDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opRepoSavedComparisonDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRepoSavedComparisonDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRepoSavedComparisonDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRepoSavedComparisonDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/comparisons/{comparison_uid}", opRepoSavedComparisonDelete)
+
+	opPipelineCacheList := openapi3.Operation{}
+	opPipelineCacheList.WithTags("repository")
+	opPipelineCacheList.WithMapOfAnything(map[string]interface{}{"operationId": "listPipelineCaches"})
+	opPipelineCacheList.WithParameters(queryParameterQueryPipelineCache, queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opPipelineCacheList, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opPipelineCacheList, []types.PipelineCache{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opPipelineCacheList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opPipelineCacheList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opPipelineCacheList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opPipelineCacheList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/pipeline-caches", opPipelineCacheList)
+
+	opPipelineCacheRestore := openapi3.Operation{}
+	opPipelineCacheRestore.WithTags("repository")
+	opPipelineCacheRestore.WithMapOfAnything(map[string]interface{}{"operationId": "restorePipelineCache"})
+	opPipelineCacheRestore.WithParameters(queryParameterPipelineCacheKey, queryParameterPipelineCacheRestoreKey)
+	_ = reflector.SetRequest(&opPipelineCacheRestore, new(repoRequest), http.MethodGet)
+	_ = reflector.SetStringResponse(&opPipelineCacheRestore, http.StatusOK, "application/octet-stream")
+	_ = reflector.SetJSONResponse(&opPipelineCacheRestore, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opPipelineCacheRestore, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opPipelineCacheRestore, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opPipelineCacheRestore, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opPipelineCacheRestore, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/pipeline-caches/content", opPipelineCacheRestore)
+
+	opPipelineCacheSave := openapi3.Operation{}
+	opPipelineCacheSave.WithTags("repository")
+	opPipelineCacheSave.WithMapOfAnything(map[string]interface{}{"operationId": "savePipelineCache"})
+	opPipelineCacheSave.WithParameters(queryParameterPipelineCacheKey)
+	_ = reflector.SetRequest(&opPipelineCacheSave, new(repoRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opPipelineCacheSave, new(types.PipelineCache), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opPipelineCacheSave, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opPipelineCacheSave, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opPipelineCacheSave, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opPipelineCacheSave, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opPipelineCacheSave, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opPipelineCacheSave, new(usererror.Error), http.StatusRequestEntityTooLarge)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/repos/{repo_ref}/pipeline-caches/content", opPipelineCacheSave)
+
+	opPipelineCacheDelete := openapi3.Operation{}
+	opPipelineCacheDelete.WithTags("repository")
+	opPipelineCacheDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deletePipelineCache"})
+	_ = reflector.SetRequest(&opPipelineCacheDelete, &struct {
+		repoRequest
+		PipelineCacheID int64 `path:"pipeline_cache_id"`
+	}{}, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opPipelineCacheDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opPipelineCacheDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opPipelineCacheDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opPipelineCacheDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opPipelineCacheDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pipeline-caches/{pipeline_cache_id}", opPipelineCacheDelete)
+
+	opRepoAliasList := openapi3.Operation{}
+	opRepoAliasList.WithTags("repository")
+	opRepoAliasList.WithMapOfAnything(map[string]interface{}{"operationId": "listRepoAliases"})
+	_ = reflector.SetRequest(&opRepoAliasList, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opRepoAliasList, []types.RepoAlias{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRepoAliasList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRepoAliasList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRepoAliasList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRepoAliasList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/aliases", opRepoAliasList)
+
+	opRepoAliasCreate := openapi3.Operation{}
+	opRepoAliasCreate.WithTags("repository")
+	opRepoAliasCreate.WithMapOfAnything(map[string]interface{}{"operationId": "createRepoAlias"})
+	_ = reflector.SetRequest(&opRepoAliasCreate, &struct {
+		repoRequest
+		repo.AliasCreateInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opRepoAliasCreate, new(types.RepoAlias), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opRepoAliasCreate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRepoAliasCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRepoAliasCreate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRepoAliasCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRepoAliasCreate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opRepoAliasCreate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/aliases", opRepoAliasCreate)
+
+	opRepoAliasDelete := openapi3.Operation{}
+	opRepoAliasDelete.WithTags("repository")
+	opRepoAliasDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteRepoAlias"})
+	_ = reflector.SetRequest(&opRepoAliasDelete, &struct {
+		repoRequest
+		RepoAliasID int64 `path:"repo_alias_id"`
+	}{}, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opRepoAliasDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opRepoAliasDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRepoAliasDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRepoAliasDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRepoAliasDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/aliases/{repo_alias_id}", opRepoAliasDelete)
+
+	opDefaultReviewerList := openapi3.Operation{}
+	opDefaultReviewerList.WithTags("repository")
+	opDefaultReviewerList.WithMapOfAnything(map[string]interface{}{"operationId": "listRepoDefaultReviewers"})
+	_ = reflector.SetRequest(&opDefaultReviewerList, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opDefaultReviewerList, []types.DefaultReviewer{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opDefaultReviewerList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDefaultReviewerList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDefaultReviewerList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDefaultReviewerList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/default-reviewers", opDefaultReviewerList)
+
+	opDefaultReviewerCreate := openapi3.Operation{}
+	opDefaultReviewerCreate.WithTags("repository")
+	opDefaultReviewerCreate.WithMapOfAnything(map[string]interface{}{"operationId": "createRepoDefaultReviewer"})
+	_ = reflector.SetRequest(&opDefaultReviewerCreate, &struct {
+		repoRequest
+		repo.DefaultReviewerCreateInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opDefaultReviewerCreate, new(types.DefaultReviewer), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opDefaultReviewerCreate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opDefaultReviewerCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDefaultReviewerCreate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDefaultReviewerCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDefaultReviewerCreate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opDefaultReviewerCreate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/default-reviewers", opDefaultReviewerCreate)
+
+	opDefaultReviewerDelete := openapi3.Operation{}
+	opDefaultReviewerDelete.WithTags("repository")
+	opDefaultReviewerDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteRepoDefaultReviewer"})
+	_ = reflector.SetRequest(&opDefaultReviewerDelete, &struct {
+		repoRequest
+		DefaultReviewerID int64 `path:"default_reviewer_id"`
+	}{}, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDefaultReviewerDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDefaultReviewerDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDefaultReviewerDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDefaultReviewerDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDefaultReviewerDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/default-reviewers/{default_reviewer_id}", opDefaultReviewerDelete)
+
+	opRepoWatchFind := openapi3.Operation{}
+	opRepoWatchFind.WithTags("repository")
+	opRepoWatchFind.WithMapOfAnything(map[string]interface{}{"operationId": "getRepoWatch"})
+	_ = reflector.SetRequest(&opRepoWatchFind, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opRepoWatchFind, new(types.RepoWatch), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRepoWatchFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRepoWatchFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRepoWatchFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRepoWatchFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/watch", opRepoWatchFind)
+
+	opRepoWatchUpdate := openapi3.Operation{}
+	opRepoWatchUpdate.WithTags("repository")
+	opRepoWatchUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateRepoWatch"})
+	_ = reflector.SetRequest(&opRepoWatchUpdate, &struct {
+		repoRequest
+		controller.WatchInput
+	}{}, http.MethodPut)
+	_ = reflector.SetJSONResponse(&opRepoWatchUpdate, new(types.RepoWatch), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRepoWatchUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRepoWatchUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRepoWatchUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRepoWatchUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRepoWatchUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/repos/{repo_ref}/watch", opRepoWatchUpdate)
+
+	opRepoWatchDelete := openapi3.Operation{}
+	opRepoWatchDelete.WithTags("repository")
+	opRepoWatchDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteRepoWatch"})
+	_ = reflector.SetRequest(&opRepoWatchDelete, new(repoRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opRepoWatchDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opRepoWatchDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRepoWatchDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRepoWatchDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRepoWatchDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/repos/{repo_ref}/watch", opRepoWatchDelete)
+
+	opComplianceFind := openapi3.Operation{}
+	opComplianceFind.WithTags("repository")
+	opComplianceFind.WithMapOfAnything(map[string]interface{}{"operationId": "getRepoCompliance"})
+	_ = reflector.SetRequest(&opComplianceFind, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opComplianceFind, new(types.RepoCompliance), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opComplianceFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opComplianceFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opComplianceFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opComplianceFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/compliance", opComplianceFind)
+
+	opCompliancePullReq := openapi3.Operation{}
+	opCompliancePullReq.WithTags("repository")
+	opCompliancePullReq.WithMapOfAnything(map[string]interface{}{"operationId": "createRepoCompliancePullReq"})
+	_ = reflector.SetRequest(&opCompliancePullReq, new(repoRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCompliancePullReq, new(types.PullReq), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCompliancePullReq, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCompliancePullReq, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCompliancePullReq, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCompliancePullReq, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCompliancePullReq, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/compliance/pullreq", opCompliancePullReq)
 }
@@ -0,0 +1,79 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/runner"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type (
+	// runnerRequest is the request for runner specific admin operations.
+	runnerRequest struct {
+		RunnerUID string `path:"runner_uid"`
+	}
+)
+
+// runnerOperations is used to add the self-hosted runner operations to the openapi spec.
+func runnerOperations(reflector *openapi3.Reflector) {
+	opRegister := openapi3.Operation{}
+	opRegister.WithTags("runners")
+	opRegister.WithMapOfAnything(map[string]interface{}{"operationId": "registerRunner"})
+	_ = reflector.SetRequest(&opRegister, new(runner.RegisterInput), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opRegister, new(types.RunnerRegistration), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opRegister, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRegister, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRegister, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRegister, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opRegister, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/runners/register", opRegister)
+
+	opList := openapi3.Operation{}
+	opList.WithTags("admin")
+	opList.WithMapOfAnything(map[string]interface{}{"operationId": "adminListRunners"})
+	_ = reflector.SetRequest(&opList, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opList, new([]types.Runner), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/runners", opList)
+
+	opDrain := openapi3.Operation{}
+	opDrain.WithTags("admin")
+	opDrain.WithMapOfAnything(map[string]interface{}{"operationId": "adminDrainRunner"})
+	_ = reflector.SetRequest(&opDrain, new(runnerRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opDrain, new(types.Runner), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opDrain, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDrain, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDrain, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDrain, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/admin/runners/{runner_uid}/drain", opDrain)
+
+	opDelete := openapi3.Operation{}
+	opDelete.WithTags("admin")
+	opDelete.WithMapOfAnything(map[string]interface{}{"operationId": "adminDeleteRunner"})
+	_ = reflector.SetRequest(&opDelete, new(runnerRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/admin/runners/{runner_uid}", opDelete)
+}
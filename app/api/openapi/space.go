@@ -17,6 +17,7 @@ package openapi
 import (
 	"net/http"
 
+	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/api/controller/space"
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/api/usererror"
@@ -231,12 +232,14 @@ func spaceOperations(reflector *openapi3.Reflector) {
 	opDelete := openapi3.Operation{}
 	opDelete.WithTags("space")
 	opDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteSpace"})
+	opDelete.WithParameters(queryParameterConfirmationToken)
 	_ = reflector.SetRequest(&opDelete, new(spaceRequest), http.MethodDelete)
 	_ = reflector.SetJSONResponse(&opDelete, nil, http.StatusNoContent)
 	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusUnauthorized)
 	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusPreconditionRequired)
 	_ = reflector.Spec.AddOperation(http.MethodDelete, "/spaces/{space_ref}", opDelete)
 
 	opMove := openapi3.Operation{}
@@ -277,6 +280,17 @@ func spaceOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opRepos, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/repos", opRepos)
 
+	opRepoDependencyOrder := openapi3.Operation{}
+	opRepoDependencyOrder.WithTags("space")
+	opRepoDependencyOrder.WithMapOfAnything(map[string]interface{}{"operationId": "repoDependencyOrder"})
+	_ = reflector.SetRequest(&opRepoDependencyOrder, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opRepoDependencyOrder, new(space.RepoDependencyOrder), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRepoDependencyOrder, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRepoDependencyOrder, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRepoDependencyOrder, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRepoDependencyOrder, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/repo-dependency-order", opRepoDependencyOrder)
+
 	opTemplates := openapi3.Operation{}
 	opTemplates.WithTags("space")
 	opTemplates.WithMapOfAnything(map[string]interface{}{"operationId": "listTemplates"})
@@ -338,6 +352,36 @@ func spaceOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opMembershipAdd, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/spaces/{space_ref}/members", opMembershipAdd)
 
+	opMembershipBulk := openapi3.Operation{}
+	opMembershipBulk.WithTags("space")
+	opMembershipBulk.WithMapOfAnything(map[string]interface{}{"operationId": "membershipBulk"})
+	_ = reflector.SetRequest(&opMembershipBulk, struct {
+		spaceRequest
+		space.MembershipBulkInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opMembershipBulk, []space.MembershipBulkResult{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opMembershipBulk, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opMembershipBulk, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMembershipBulk, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMembershipBulk, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMembershipBulk, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/spaces/{space_ref}/members/bulk", opMembershipBulk)
+
+	opMembershipImport := openapi3.Operation{}
+	opMembershipImport.WithTags("space")
+	opMembershipImport.WithMapOfAnything(map[string]interface{}{"operationId": "membershipImport"})
+	opMembershipImport.WithDescription("Sets the memberships from CSV data with the columns: user_uid, role and " +
+		"optionally action (add, update, remove or set; default: set).")
+	_ = reflector.SetRequest(&opMembershipImport, new(spaceRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opMembershipImport, []space.MembershipBulkResult{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opMembershipImport, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opMembershipImport, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMembershipImport, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMembershipImport, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMembershipImport, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opMembershipImport, new(usererror.Error), http.StatusRequestEntityTooLarge)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/spaces/{space_ref}/members/import", opMembershipImport)
+
 	opMembershipDelete := openapi3.Operation{}
 	opMembershipDelete.WithTags("space")
 	opMembershipDelete.WithMapOfAnything(map[string]interface{}{"operationId": "membershipDelete"})
@@ -383,4 +427,261 @@ func spaceOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opMembershipList, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.SetJSONResponse(&opMembershipList, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/members", opMembershipList)
+
+	opLanding := openapi3.Operation{}
+	opLanding.WithTags("space")
+	opLanding.WithMapOfAnything(map[string]interface{}{"operationId": "getSpaceLanding"})
+	_ = reflector.SetRequest(&opLanding, spaceRequest{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opLanding, new(types.SpaceLanding), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opLanding, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opLanding, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opLanding, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opLanding, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/landing", opLanding)
+
+	opPinList := openapi3.Operation{}
+	opPinList.WithTags("space")
+	opPinList.WithMapOfAnything(map[string]interface{}{"operationId": "listSpacePins"})
+	_ = reflector.SetRequest(&opPinList, spaceRequest{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opPinList, []types.SpacePin{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opPinList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opPinList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opPinList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opPinList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/pins", opPinList)
+
+	opPinCreate := openapi3.Operation{}
+	opPinCreate.WithTags("space")
+	opPinCreate.WithMapOfAnything(map[string]interface{}{"operationId": "createSpacePin"})
+	_ = reflector.SetRequest(&opPinCreate, struct {
+		spaceRequest
+		space.PinCreateInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opPinCreate, new(types.SpacePin), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opPinCreate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opPinCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opPinCreate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opPinCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opPinCreate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opPinCreate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/spaces/{space_ref}/pins", opPinCreate)
+
+	opPinUpdate := openapi3.Operation{}
+	opPinUpdate.WithTags("space")
+	opPinUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateSpacePin"})
+	_ = reflector.SetRequest(&opPinUpdate, struct {
+		spaceRequest
+		PinID int64 `path:"pin_id"`
+		space.PinUpdateInput
+	}{}, http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opPinUpdate, new(types.SpacePin), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opPinUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opPinUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opPinUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opPinUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opPinUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/spaces/{space_ref}/pins/{pin_id}", opPinUpdate)
+
+	opPinDelete := openapi3.Operation{}
+	opPinDelete.WithTags("space")
+	opPinDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteSpacePin"})
+	_ = reflector.SetRequest(&opPinDelete, struct {
+		spaceRequest
+		PinID int64 `path:"pin_id"`
+	}{}, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opPinDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opPinDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opPinDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opPinDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opPinDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/spaces/{space_ref}/pins/{pin_id}", opPinDelete)
+
+	opRequiredFileList := openapi3.Operation{}
+	opRequiredFileList.WithTags("space")
+	opRequiredFileList.WithMapOfAnything(map[string]interface{}{"operationId": "listSpaceRequiredFiles"})
+	_ = reflector.SetRequest(&opRequiredFileList, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opRequiredFileList, []types.RequiredFile{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRequiredFileList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRequiredFileList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRequiredFileList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRequiredFileList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/required-files", opRequiredFileList)
+
+	opRequiredFileCreate := openapi3.Operation{}
+	opRequiredFileCreate.WithTags("space")
+	opRequiredFileCreate.WithMapOfAnything(map[string]interface{}{"operationId": "createSpaceRequiredFile"})
+	_ = reflector.SetRequest(&opRequiredFileCreate, &struct {
+		spaceRequest
+		space.RequiredFileCreateInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opRequiredFileCreate, new(types.RequiredFile), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opRequiredFileCreate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRequiredFileCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRequiredFileCreate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRequiredFileCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRequiredFileCreate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opRequiredFileCreate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/spaces/{space_ref}/required-files", opRequiredFileCreate)
+
+	opRequiredFileUpdate := openapi3.Operation{}
+	opRequiredFileUpdate.WithTags("space")
+	opRequiredFileUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateSpaceRequiredFile"})
+	_ = reflector.SetRequest(&opRequiredFileUpdate, &struct {
+		spaceRequest
+		RequiredFileID int64 `path:"required_file_id"`
+		space.RequiredFileUpdateInput
+	}{}, http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opRequiredFileUpdate, new(types.RequiredFile), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRequiredFileUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRequiredFileUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRequiredFileUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRequiredFileUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRequiredFileUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opRequiredFileUpdate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPatch,
+		"/spaces/{space_ref}/required-files/{required_file_id}", opRequiredFileUpdate)
+
+	opRequiredFileDelete := openapi3.Operation{}
+	opRequiredFileDelete.WithTags("space")
+	opRequiredFileDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteSpaceRequiredFile"})
+	_ = reflector.SetRequest(&opRequiredFileDelete, &struct {
+		spaceRequest
+		RequiredFileID int64 `path:"required_file_id"`
+	}{}, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opRequiredFileDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opRequiredFileDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRequiredFileDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRequiredFileDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRequiredFileDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/spaces/{space_ref}/required-files/{required_file_id}", opRequiredFileDelete)
+
+	opAutolinkList := openapi3.Operation{}
+	opAutolinkList.WithTags("space")
+	opAutolinkList.WithMapOfAnything(map[string]interface{}{"operationId": "listSpaceAutolinks"})
+	_ = reflector.SetRequest(&opAutolinkList, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opAutolinkList, []types.Autolink{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opAutolinkList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opAutolinkList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opAutolinkList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opAutolinkList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/autolinks", opAutolinkList)
+
+	opAutolinkCreate := openapi3.Operation{}
+	opAutolinkCreate.WithTags("space")
+	opAutolinkCreate.WithMapOfAnything(map[string]interface{}{"operationId": "createSpaceAutolink"})
+	_ = reflector.SetRequest(&opAutolinkCreate, &struct {
+		spaceRequest
+		controller.AutolinkCreateInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opAutolinkCreate, new(types.Autolink), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opAutolinkCreate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opAutolinkCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opAutolinkCreate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opAutolinkCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opAutolinkCreate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opAutolinkCreate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/spaces/{space_ref}/autolinks", opAutolinkCreate)
+
+	opAutolinkUpdate := openapi3.Operation{}
+	opAutolinkUpdate.WithTags("space")
+	opAutolinkUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateSpaceAutolink"})
+	_ = reflector.SetRequest(&opAutolinkUpdate, &struct {
+		spaceRequest
+		AutolinkID int64 `path:"autolink_id"`
+		controller.AutolinkUpdateInput
+	}{}, http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opAutolinkUpdate, new(types.Autolink), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opAutolinkUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opAutolinkUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opAutolinkUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opAutolinkUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opAutolinkUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opAutolinkUpdate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPatch,
+		"/spaces/{space_ref}/autolinks/{autolink_id}", opAutolinkUpdate)
+
+	opAutolinkDelete := openapi3.Operation{}
+	opAutolinkDelete.WithTags("space")
+	opAutolinkDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteSpaceAutolink"})
+	_ = reflector.SetRequest(&opAutolinkDelete, &struct {
+		spaceRequest
+		AutolinkID int64 `path:"autolink_id"`
+	}{}, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opAutolinkDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opAutolinkDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opAutolinkDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opAutolinkDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opAutolinkDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/spaces/{space_ref}/autolinks/{autolink_id}", opAutolinkDelete)
+
+	opTokenList := openapi3.Operation{}
+	opTokenList.WithTags("space")
+	opTokenList.WithMapOfAnything(map[string]interface{}{"operationId": "listSpaceTokens"})
+	_ = reflector.SetRequest(&opTokenList, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opTokenList, []types.Token{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opTokenList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opTokenList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opTokenList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opTokenList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/tokens", opTokenList)
+
+	opTokenCreate := openapi3.Operation{}
+	opTokenCreate.WithTags("space")
+	opTokenCreate.WithMapOfAnything(map[string]interface{}{"operationId": "createSpaceToken"})
+	_ = reflector.SetRequest(&opTokenCreate, &struct {
+		spaceRequest
+		space.TokenCreateInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opTokenCreate, new(types.TokenResponse), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opTokenCreate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opTokenCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opTokenCreate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opTokenCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opTokenCreate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opTokenCreate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/spaces/{space_ref}/tokens", opTokenCreate)
+
+	opTokenRotate := openapi3.Operation{}
+	opTokenRotate.WithTags("space")
+	opTokenRotate.WithMapOfAnything(map[string]interface{}{"operationId": "rotateSpaceToken"})
+	_ = reflector.SetRequest(&opTokenRotate, &struct {
+		spaceRequest
+		TokenUID string `path:"token_uid"`
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opTokenRotate, new(types.TokenResponse), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opTokenRotate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opTokenRotate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opTokenRotate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opTokenRotate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opTokenRotate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/spaces/{space_ref}/tokens/{token_uid}/rotate", opTokenRotate)
+
+	opTokenDelete := openapi3.Operation{}
+	opTokenDelete.WithTags("space")
+	opTokenDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteSpaceToken"})
+	_ = reflector.SetRequest(&opTokenDelete, &struct {
+		spaceRequest
+		TokenUID string `path:"token_uid"`
+	}{}, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opTokenDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opTokenDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opTokenDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opTokenDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opTokenDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/spaces/{space_ref}/tokens/{token_uid}", opTokenDelete)
+
+	opComplianceList := openapi3.Operation{}
+	opComplianceList.WithTags("space")
+	opComplianceList.WithMapOfAnything(map[string]interface{}{"operationId": "listSpaceCompliance"})
+	_ = reflector.SetRequest(&opComplianceList, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opComplianceList, []types.RepoCompliance{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opComplianceList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opComplianceList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opComplianceList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opComplianceList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/compliance", opComplianceList)
 }
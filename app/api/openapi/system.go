@@ -17,12 +17,95 @@ package openapi
 import (
 	"net/http"
 
+	controllersystem "github.com/harness/gitness/app/api/controller/system"
 	"github.com/harness/gitness/app/api/handler/system"
+	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 
+	"github.com/gotidy/ptr"
 	"github.com/swaggest/openapi-go/openapi3"
 )
 
+var queryParameterGitUsageSince = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamSince,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Epoch (in milliseconds) since when the git usage should be aggregated."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+			},
+		},
+	},
+}
+
+var queryParameterGitUsageUntil = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamUntil,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Epoch (in milliseconds) until when the git usage should be aggregated."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+			},
+		},
+	},
+}
+
+var queryParameterGitUsagePrincipalID = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamPrincipalID,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Return only the git usage of the principal with the provided ID."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+			},
+		},
+	},
+}
+
+var queryParameterGitUsageRepoID = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamRepoID,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Return only the git usage of the repository with the provided ID."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+			},
+		},
+	},
+}
+
+var queryParameterSortGitUsage = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamSort,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The data by which the git usage is sorted."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeString),
+				Default: ptrptr(enum.GitUsageSortCPUTime),
+				Enum:    enum.GitUsageSort("").Enum(),
+			},
+		},
+	},
+}
+
+type repoMaintenanceRequest struct {
+	repoRequest
+	controllersystem.RepoMaintenanceInput
+}
+
 // helper function that constructs the openapi specification
 // for the system registration config endpoints.
 func buildSystem(reflector *openapi3.Reflector) {
@@ -34,4 +117,100 @@ func buildSystem(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opGetConfig, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&opGetConfig, new(usererror.Error), http.StatusBadRequest)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/system/config", opGetConfig)
+
+	opGetHealth := openapi3.Operation{}
+	opGetHealth.WithTags("system")
+	opGetHealth.WithMapOfAnything(map[string]interface{}{"operationId": "getSystemHealth"})
+	_ = reflector.SetRequest(&opGetHealth, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opGetHealth, new(system.HealthOutput), http.StatusOK)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/system/health", opGetHealth)
+
+	opListSnapshots := openapi3.Operation{}
+	opListSnapshots.WithTags("admin")
+	opListSnapshots.WithMapOfAnything(map[string]interface{}{"operationId": "adminListComplianceSnapshots"})
+	opListSnapshots.WithParameters(queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opListSnapshots, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListSnapshots, new([]types.ComplianceSnapshot), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListSnapshots, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListSnapshots, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListSnapshots, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/compliance/snapshots", opListSnapshots)
+
+	opExportSnapshots := openapi3.Operation{}
+	opExportSnapshots.WithTags("admin")
+	opExportSnapshots.WithMapOfAnything(map[string]interface{}{"operationId": "adminExportComplianceSnapshots"})
+	_ = reflector.SetRequest(&opExportSnapshots, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opExportSnapshots, new(types.ComplianceSnapshotExport), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opExportSnapshots, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opExportSnapshots, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opExportSnapshots, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/compliance/snapshots/export", opExportSnapshots)
+
+	opListGitUsage := openapi3.Operation{}
+	opListGitUsage.WithTags("admin")
+	opListGitUsage.WithMapOfAnything(map[string]interface{}{"operationId": "adminListGitUsage"})
+	opListGitUsage.WithParameters(queryParameterGitUsageSince, queryParameterGitUsageUntil,
+		queryParameterGitUsagePrincipalID, queryParameterGitUsageRepoID,
+		queryParameterSortGitUsage, queryParameterOrder, queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opListGitUsage, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListGitUsage, new([]types.GitUsage), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListGitUsage, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opListGitUsage, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListGitUsage, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListGitUsage, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/git-usage", opListGitUsage)
+
+	opListRepoAliases := openapi3.Operation{}
+	opListRepoAliases.WithTags("admin")
+	opListRepoAliases.WithMapOfAnything(map[string]interface{}{"operationId": "adminListRepoAliases"})
+	opListRepoAliases.WithParameters(queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opListRepoAliases, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListRepoAliases, new([]types.RepoAlias), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListRepoAliases, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListRepoAliases, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListRepoAliases, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/repo-aliases", opListRepoAliases)
+
+	opGetRepoMaintenance := openapi3.Operation{}
+	opGetRepoMaintenance.WithTags("admin")
+	opGetRepoMaintenance.WithMapOfAnything(map[string]interface{}{"operationId": "adminGetRepoMaintenance"})
+	_ = reflector.SetRequest(&opGetRepoMaintenance, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opGetRepoMaintenance, new(controllersystem.RepoMaintenanceOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opGetRepoMaintenance, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opGetRepoMaintenance, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opGetRepoMaintenance, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opGetRepoMaintenance, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/repos/{repo_ref}/maintenance", opGetRepoMaintenance)
+
+	opTriggerRepoMaintenance := openapi3.Operation{}
+	opTriggerRepoMaintenance.WithTags("admin")
+	opTriggerRepoMaintenance.WithMapOfAnything(
+		map[string]interface{}{"operationId": "adminTriggerRepoMaintenance"})
+	_ = reflector.SetRequest(&opTriggerRepoMaintenance, new(repoMaintenanceRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opTriggerRepoMaintenance, new(controllersystem.RepoMaintenanceOutput), http.StatusAccepted)
+	_ = reflector.SetJSONResponse(&opTriggerRepoMaintenance, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opTriggerRepoMaintenance, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opTriggerRepoMaintenance, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opTriggerRepoMaintenance, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opTriggerRepoMaintenance, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opTriggerRepoMaintenance, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/admin/repos/{repo_ref}/maintenance", opTriggerRepoMaintenance)
+
+	opGetQueryReport := openapi3.Operation{}
+	opGetQueryReport.WithTags("admin")
+	opGetQueryReport.WithMapOfAnything(map[string]interface{}{"operationId": "adminGetDatabaseQueryReport"})
+	_ = reflector.SetRequest(&opGetQueryReport, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opGetQueryReport, new(dbtx.QueryReport), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opGetQueryReport, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opGetQueryReport, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/db/queries", opGetQueryReport)
+
+	opResetQueryStats := openapi3.Operation{}
+	opResetQueryStats.WithTags("admin")
+	opResetQueryStats.WithMapOfAnything(map[string]interface{}{"operationId": "adminResetDatabaseQueryStats"})
+	_ = reflector.SetRequest(&opResetQueryStats, nil, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opResetQueryStats, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opResetQueryStats, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opResetQueryStats, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/admin/db/queries", opResetQueryStats)
 }
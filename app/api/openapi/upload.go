@@ -42,6 +42,7 @@ func uploadOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetRequest(&opUpload, new(UploadRequest), http.MethodPost)
 	_ = reflector.SetJSONResponse(&opUpload, new(upload.Result), http.StatusCreated)
 	_ = reflector.SetJSONResponse(&opUpload, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpload, new(usererror.Error), http.StatusUnprocessableEntity)
 	_ = reflector.SetJSONResponse(&opUpload, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.SetJSONResponse(&opUpload, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&opUpload, new(usererror.Error), http.StatusUnauthorized)
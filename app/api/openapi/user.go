@@ -31,6 +31,18 @@ type createTokenRequest struct {
 	user.CreateTokenInput
 }
 
+type signingKeyRequest struct {
+	ID int64 `path:"signing_key_id"`
+}
+
+type publicKeyRequest struct {
+	ID int64 `path:"public_key_id"`
+}
+
+type passkeyRequest struct {
+	ID int64 `path:"passkey_id"`
+}
+
 var queryParameterMembershipSpaces = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamQuery,
@@ -99,4 +111,123 @@ func buildUser(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opMemberSpaces, new([]types.MembershipSpace), http.StatusOK)
 	_ = reflector.SetJSONResponse(&opMemberSpaces, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/memberships", opMemberSpaces)
+
+	opListSigningKeys := openapi3.Operation{}
+	opListSigningKeys.WithTags("user")
+	opListSigningKeys.WithMapOfAnything(map[string]interface{}{"operationId": "listSigningKeys"})
+	_ = reflector.SetRequest(&opListSigningKeys, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListSigningKeys, new([]types.UserSigningKey), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListSigningKeys, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opListSigningKeys, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/signing-keys", opListSigningKeys)
+
+	opIssueSigningKey := openapi3.Operation{}
+	opIssueSigningKey.WithTags("user")
+	opIssueSigningKey.WithMapOfAnything(map[string]interface{}{"operationId": "issueSigningKey"})
+	_ = reflector.SetRequest(&opIssueSigningKey, nil, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opIssueSigningKey, new(types.UserSigningKey), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opIssueSigningKey, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opIssueSigningKey, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/signing-keys", opIssueSigningKey)
+
+	opRevokeSigningKey := openapi3.Operation{}
+	opRevokeSigningKey.WithTags("user")
+	opRevokeSigningKey.WithMapOfAnything(map[string]interface{}{"operationId": "revokeSigningKey"})
+	_ = reflector.SetRequest(&opRevokeSigningKey, new(signingKeyRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opRevokeSigningKey, new(types.UserSigningKey), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRevokeSigningKey, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRevokeSigningKey, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opRevokeSigningKey, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/user/signing-keys/{signing_key_id}", opRevokeSigningKey)
+
+	opListPublicKeys := openapi3.Operation{}
+	opListPublicKeys.WithTags("user")
+	opListPublicKeys.WithMapOfAnything(map[string]interface{}{"operationId": "listPublicKeys"})
+	_ = reflector.SetRequest(&opListPublicKeys, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListPublicKeys, new([]types.PublicKey), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListPublicKeys, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/keys", opListPublicKeys)
+
+	opCreatePublicKey := openapi3.Operation{}
+	opCreatePublicKey.WithTags("user")
+	opCreatePublicKey.WithMapOfAnything(map[string]interface{}{"operationId": "createPublicKey"})
+	_ = reflector.SetRequest(&opCreatePublicKey, new(user.CreatePublicKeyInput), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreatePublicKey, new(types.PublicKey), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreatePublicKey, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreatePublicKey, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opCreatePublicKey, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/keys", opCreatePublicKey)
+
+	opDeletePublicKey := openapi3.Operation{}
+	opDeletePublicKey.WithTags("user")
+	opDeletePublicKey.WithMapOfAnything(map[string]interface{}{"operationId": "deletePublicKey"})
+	_ = reflector.SetRequest(&opDeletePublicKey, new(publicKeyRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeletePublicKey, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeletePublicKey, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opDeletePublicKey, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/user/keys/{public_key_id}", opDeletePublicKey)
+
+	opListPasskeys := openapi3.Operation{}
+	opListPasskeys.WithTags("user")
+	opListPasskeys.WithMapOfAnything(map[string]interface{}{"operationId": "listPasskeys"})
+	_ = reflector.SetRequest(&opListPasskeys, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListPasskeys, new([]types.Passkey), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListPasskeys, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opListPasskeys, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/passkeys", opListPasskeys)
+
+	opBeginPasskeyRegistration := openapi3.Operation{}
+	opBeginPasskeyRegistration.WithTags("user")
+	opBeginPasskeyRegistration.WithMapOfAnything(map[string]interface{}{"operationId": "beginPasskeyRegistration"})
+	_ = reflector.SetRequest(&opBeginPasskeyRegistration, nil, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opBeginPasskeyRegistration, new(types.PasskeyCeremony), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opBeginPasskeyRegistration, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opBeginPasskeyRegistration, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/passkeys/registration", opBeginPasskeyRegistration)
+
+	opRegisterPasskey := openapi3.Operation{}
+	opRegisterPasskey.WithTags("user")
+	opRegisterPasskey.WithMapOfAnything(map[string]interface{}{"operationId": "registerPasskey"})
+	_ = reflector.SetRequest(&opRegisterPasskey, new(user.RegisterPasskeyInput), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opRegisterPasskey, new(types.Passkey), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opRegisterPasskey, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRegisterPasskey, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opRegisterPasskey, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/passkeys", opRegisterPasskey)
+
+	opDeletePasskey := openapi3.Operation{}
+	opDeletePasskey.WithTags("user")
+	opDeletePasskey.WithMapOfAnything(map[string]interface{}{"operationId": "deletePasskey"})
+	_ = reflector.SetRequest(&opDeletePasskey, new(passkeyRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeletePasskey, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeletePasskey, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opDeletePasskey, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opDeletePasskey, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/user/passkeys/{passkey_id}", opDeletePasskey)
+
+	opFindWorkingHours := openapi3.Operation{}
+	opFindWorkingHours.WithTags("user")
+	opFindWorkingHours.WithMapOfAnything(map[string]interface{}{"operationId": "findWorkingHours"})
+	_ = reflector.SetRequest(&opFindWorkingHours, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFindWorkingHours, new(types.WorkingHours), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFindWorkingHours, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opFindWorkingHours, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/working-hours", opFindWorkingHours)
+
+	opSetWorkingHours := openapi3.Operation{}
+	opSetWorkingHours.WithTags("user")
+	opSetWorkingHours.WithMapOfAnything(map[string]interface{}{"operationId": "setWorkingHours"})
+	_ = reflector.SetRequest(&opSetWorkingHours, new(user.WorkingHoursInput), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opSetWorkingHours, new(types.WorkingHours), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSetWorkingHours, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSetWorkingHours, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/user/working-hours", opSetWorkingHours)
+
+	opDeleteWorkingHours := openapi3.Operation{}
+	opDeleteWorkingHours.WithTags("user")
+	opDeleteWorkingHours.WithMapOfAnything(map[string]interface{}{"operationId": "deleteWorkingHours"})
+	_ = reflector.SetRequest(&opDeleteWorkingHours, nil, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeleteWorkingHours, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeleteWorkingHours, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/user/working-hours", opDeleteWorkingHours)
 }
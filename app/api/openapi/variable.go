@@ -0,0 +1,142 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/variable"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type createRepoVariableRequest struct {
+	repoRequest
+	variable.CreateInput
+}
+
+type repoVariableRequest struct {
+	repoRequest
+	Key string `path:"variable_key"`
+}
+
+type updateRepoVariableRequest struct {
+	repoVariableRequest
+	variable.UpdateInput
+}
+
+type createSpaceVariableRequest struct {
+	spaceRequest
+	variable.CreateInput
+}
+
+type spaceVariableRequest struct {
+	spaceRequest
+	Key string `path:"variable_key"`
+}
+
+type updateSpaceVariableRequest struct {
+	spaceVariableRequest
+	variable.UpdateInput
+}
+
+func variableOperations(reflector *openapi3.Reflector) {
+	variableParentOperations(reflector, "Repo", "/repos/{repo_ref}",
+		new(repoRequest), new(createRepoVariableRequest), new(repoVariableRequest), new(updateRepoVariableRequest))
+	variableParentOperations(reflector, "Space", "/spaces/{space_ref}",
+		new(spaceRequest), new(createSpaceVariableRequest), new(spaceVariableRequest), new(updateSpaceVariableRequest))
+}
+
+func variableParentOperations(
+	reflector *openapi3.Reflector,
+	parent string,
+	path string,
+	parentReq interface{},
+	createReq interface{},
+	variableReq interface{},
+	updateReq interface{},
+) {
+	opList := openapi3.Operation{}
+	opList.WithTags("variable")
+	opList.WithMapOfAnything(map[string]interface{}{"operationId": "list" + parent + "Variables"})
+	opList.WithParameters(queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opList, parentReq, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opList, new([]types.Variable), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, path+"/variables", opList)
+
+	opCreate := openapi3.Operation{}
+	opCreate.WithTags("variable")
+	opCreate.WithMapOfAnything(map[string]interface{}{"operationId": "create" + parent + "Variable"})
+	_ = reflector.SetRequest(&opCreate, createReq, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreate, new(types.Variable), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, path+"/variables", opCreate)
+
+	opFind := openapi3.Operation{}
+	opFind.WithTags("variable")
+	opFind.WithMapOfAnything(map[string]interface{}{"operationId": "find" + parent + "Variable"})
+	_ = reflector.SetRequest(&opFind, variableReq, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFind, new(types.Variable), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, path+"/variables/{variable_key}", opFind)
+
+	opUpdate := openapi3.Operation{}
+	opUpdate.WithTags("variable")
+	opUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "update" + parent + "Variable"})
+	_ = reflector.SetRequest(&opUpdate, updateReq, http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdate, new(types.Variable), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, path+"/variables/{variable_key}", opUpdate)
+
+	opDelete := openapi3.Operation{}
+	opDelete.WithTags("variable")
+	opDelete.WithMapOfAnything(map[string]interface{}{"operationId": "delete" + parent + "Variable"})
+	_ = reflector.SetRequest(&opDelete, variableReq, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, path+"/variables/{variable_key}", opDelete)
+
+	opAudit := openapi3.Operation{}
+	opAudit.WithTags("variable")
+	opAudit.WithMapOfAnything(map[string]interface{}{"operationId": "list" + parent + "VariableAudits"})
+	opAudit.WithParameters(queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opAudit, parentReq, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opAudit, new([]types.VariableAudit), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opAudit, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opAudit, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opAudit, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opAudit, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, path+"/variable-audits", opAudit)
+}
@@ -38,6 +38,11 @@ type createWebhookRequest struct {
 	webhook.CreateInput
 }
 
+type previewWebhookPayloadRequest struct {
+	repoRequest
+	webhook.PreviewPayloadInput
+}
+
 type listWebhooksRequest struct {
 	repoRequest
 }
@@ -122,6 +127,18 @@ func webhookOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&createWebhook, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/webhooks", createWebhook)
 
+	previewWebhookPayload := openapi3.Operation{}
+	previewWebhookPayload.WithTags("webhook")
+	previewWebhookPayload.WithMapOfAnything(map[string]interface{}{"operationId": "previewWebhookPayload"})
+	_ = reflector.SetRequest(&previewWebhookPayload, new(previewWebhookPayloadRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&previewWebhookPayload, new(webhook.PreviewPayloadOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&previewWebhookPayload, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&previewWebhookPayload, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&previewWebhookPayload, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&previewWebhookPayload, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/webhooks/payload-preview",
+		previewWebhookPayload)
+
 	listWebhooks := openapi3.Operation{}
 	listWebhooks.WithTags("webhook")
 	listWebhooks.WithMapOfAnything(map[string]interface{}{"operationId": "listWebhooks"})
@@ -15,6 +15,7 @@
 package render
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -24,6 +25,35 @@ import (
 // format string for the link header value.
 var linkf = `<%s>; rel="%s"`
 
+// PaginationInfo contains the pagination metadata written by the pagination helpers.
+// Total and TotalPages are only set for lists for which the total count is known.
+type PaginationInfo struct {
+	Page       int
+	Limit      int
+	Total      *int
+	TotalPages *int
+	Next       string
+	Prev       string
+	NextCursor string
+}
+
+type paginationInfoKey struct{}
+
+// WithPaginationInfo returns a context in which the pagination helpers record the pagination metadata
+// of the response in the returned PaginationInfo, in addition to writing the pagination headers.
+func WithPaginationInfo(ctx context.Context) (context.Context, *PaginationInfo) {
+	info := &PaginationInfo{}
+	return context.WithValue(ctx, paginationInfoKey{}, info), info
+}
+
+// paginationInfoFrom returns the PaginationInfo of the request, or a throwaway one if none is recorded.
+func paginationInfoFrom(r *http.Request) *PaginationInfo {
+	if info, ok := r.Context().Value(paginationInfoKey{}).(*PaginationInfo); ok {
+		return info
+	}
+	return &PaginationInfo{}
+}
+
 // Pagination writes the pagination and link headers to the http.Response.
 func Pagination(r *http.Request, w http.ResponseWriter, page, size, total int) {
 	var (
@@ -45,6 +75,10 @@ func Pagination(r *http.Request, w http.ResponseWriter, page, size, total int) {
 	w.Header().Set("x-total", strconv.Itoa(total))
 	w.Header().Set("x-total-pages", strconv.Itoa(last))
 	w.Header().Add("Link", fmt.Sprintf(linkf, uri.String(), "last"))
+
+	info := paginationInfoFrom(r)
+	info.Total = &total
+	info.TotalPages = &last
 }
 
 // PaginationNoTotal writes the pagination and link headers to the http.Response when total is unknown.
@@ -54,6 +88,10 @@ func PaginationNoTotal(r *http.Request, w http.ResponseWriter, page int, size in
 		prev = max(page-1, 1)
 	)
 
+	info := paginationInfoFrom(r)
+	info.Page = page
+	info.Limit = size
+
 	// write basic headers
 	w.Header().Set("x-page", strconv.Itoa(page))
 	w.Header().Set("x-per-page", strconv.Itoa(size))
@@ -70,6 +108,7 @@ func PaginationNoTotal(r *http.Request, w http.ResponseWriter, page int, size in
 		// write the next page to the header.
 		w.Header().Set("x-next-page", strconv.Itoa(next))
 		w.Header().Add("Link", fmt.Sprintf(linkf, uri.String(), "next"))
+		info.Next = uri.String()
 	}
 
 	if page > 1 {
@@ -80,12 +119,41 @@ func PaginationNoTotal(r *http.Request, w http.ResponseWriter, page int, size in
 		// write the previous page to the header.
 		w.Header().Set("x-prev-page", strconv.Itoa(prev))
 		w.Header().Add("Link", fmt.Sprintf(linkf, uri.String(), "prev"))
+		info.Prev = uri.String()
 	}
 }
 
 // PaginationLimit writes the x-total header.
-func PaginationLimit(_ *http.Request, w http.ResponseWriter, total int) {
+func PaginationLimit(r *http.Request, w http.ResponseWriter, total int) {
 	w.Header().Set("x-total", strconv.Itoa(total))
+
+	paginationInfoFrom(r).Total = &total
+}
+
+// PaginationCursor writes the pagination and link headers to the http.Response for cursor based pagination.
+// An empty nextCursor indicates that there are no more entries.
+func PaginationCursor(r *http.Request, w http.ResponseWriter, limit int, nextCursor string) {
+	info := paginationInfoFrom(r)
+	info.Limit = limit
+	info.NextCursor = nextCursor
+
+	w.Header().Set("x-per-page", strconv.Itoa(limit))
+
+	if nextCursor == "" {
+		return
+	}
+
+	uri := *r.URL
+	params := uri.Query()
+	params.Del("access_token")
+	params.Del("token")
+	params.Set("cursor", nextCursor)
+	params.Set("limit", strconv.Itoa(limit))
+	uri.RawQuery = params.Encode()
+
+	w.Header().Set("x-next-cursor", nextCursor)
+	w.Header().Add("Link", fmt.Sprintf(linkf, uri.String(), "next"))
+	info.Next = uri.String()
 }
 
 func getPaginationBaseURL(r *http.Request, page int, size int) url.URL {
@@ -20,6 +20,15 @@ import (
 	"github.com/harness/gitness/types"
 )
 
+const (
+	PathParamCheckUID = "check_uid"
+)
+
+// GetCheckUIDFromPath extracts the status check UID from the URL.
+func GetCheckUIDFromPath(r *http.Request) (string, error) {
+	return PathParamOrError(r, PathParamCheckUID)
+}
+
 // ParseCheckListOptions extracts the status check list API options from the url.
 func ParseCheckListOptions(r *http.Request) types.CheckListOptions {
 	return types.CheckListOptions{
@@ -42,6 +42,8 @@ const (
 	PerPageDefault  = 30
 	PerPageMax      = 100
 
+	QueryParamConfirmationToken = "confirmation_token"
+
 	// TODO: have shared constants across all services?
 	HeaderRequestID       = "X-Request-Id"
 	HeaderUserAgent       = "User-Agent"
@@ -59,6 +61,11 @@ func GetRemainderFromPath(r *http.Request) (string, error) {
 	return PathParamOrError(r, PathParamRemainder)
 }
 
+// GetConfirmationTokenFromQuery returns the optional confirmation token of a destructive operation.
+func GetConfirmationTokenFromQuery(r *http.Request) string {
+	return r.URL.Query().Get(QueryParamConfirmationToken)
+}
+
 // ParseQuery extracts the query parameter from the url.
 func ParseQuery(r *http.Request) string {
 	return r.URL.Query().Get(QueryParamQuery)
@@ -87,10 +94,8 @@ func ParseLimit(r *http.Request) int {
 }
 
 // ParseOrder extracts the order parameter from the url.
-func ParseOrder(r *http.Request) enum.Order {
-	return enum.ParseOrder(
-		r.URL.Query().Get(QueryParamOrder),
-	)
+func ParseOrder(r *http.Request) (enum.Order, error) {
+	return QueryParamAsEnum(r, QueryParamOrder, enum.ParseOrderStrict)
 }
 
 // ParseSort extracts the sort parameter from the url.
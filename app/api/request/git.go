@@ -17,6 +17,7 @@ package request
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/harness/gitness/app/api/usererror"
@@ -25,18 +26,33 @@ import (
 )
 
 const (
-	QueryParamGitRef        = "git_ref"
-	QueryParamIncludeCommit = "include_commit"
-	PathParamCommitSHA      = "commit_sha"
-	QueryParamLineFrom      = "line_from"
-	QueryParamLineTo        = "line_to"
-	QueryParamPath          = "path"
-	QueryParamSince         = "since"
-	QueryParamUntil         = "until"
-	QueryParamCommitter     = "committer"
-	QueryParamInternal      = "internal"
-	QueryParamService       = "service"
-	HeaderParamGitProtocol  = "Git-Protocol"
+	QueryParamGitRef              = "git_ref"
+	QueryParamIncludeCommit       = "include_commit"
+	QueryParamIncludeEntryCommits = "include_entry_commits"
+	QueryParamPrune               = "prune"
+	PathParamCommitSHA            = "commit_sha"
+	PathParamCommitComment        = "commit_comment_id"
+	QueryParamLineFrom            = "line_from"
+	QueryParamLineTo              = "line_to"
+	QueryParamIgnoreRevsFile      = "ignore_revs_file"
+	QueryParamPath                = "path"
+	QueryParamSince               = "since"
+	QueryParamSinceSHA            = "since_sha"
+	QueryParamMetadata            = "metadata"
+	QueryParamUntil               = "until"
+	QueryParamCommitter           = "committer"
+	QueryParamAuthor              = "author"
+	QueryParamRecursive           = "recursive"
+	QueryParamMaxDepth            = "max_depth"
+	QueryParamInclude             = "include"
+	QueryParamCursor              = "cursor"
+	QueryParamInternal            = "internal"
+	QueryParamService             = "service"
+	HeaderParamGitProtocol        = "Git-Protocol"
+	HeaderParamRange              = "Range"
+
+	TreeLimitDefault = 100
+	TreeLimitMax     = 1000
 )
 
 func GetGitRefFromQueryOrDefault(r *http.Request, deflt string) string {
@@ -47,44 +63,102 @@ func GetIncludeCommitFromQueryOrDefault(r *http.Request, deflt bool) (bool, erro
 	return QueryParamAsBoolOrDefault(r, QueryParamIncludeCommit, deflt)
 }
 
+// GetIncludeEntryCommitsFromQueryOrDefault returns whether the last commits of directory entries should be included.
+func GetIncludeEntryCommitsFromQueryOrDefault(r *http.Request, deflt bool) (bool, error) {
+	return QueryParamAsBoolOrDefault(r, QueryParamIncludeEntryCommits, deflt)
+}
+
+// GetByteRangeFromHeader returns the offset and the length of the byte range requested with the Range header.
+// The returned length is zero if the range is open-ended. Only a single range from a specific offset is supported,
+// for anything else (suffix ranges, multiple ranges, invalid values) false is returned and the range is ignored.
+func GetByteRangeFromHeader(r *http.Request) (int64, int64, bool) {
+	spec, ok := strings.CutPrefix(r.Header.Get(HeaderParamRange), "bytes=")
+	if !ok || strings.Contains(spec, ",") {
+		return 0, 0, false
+	}
+
+	startStr, endStr, ok := strings.Cut(spec, "-")
+	if !ok {
+		return 0, 0, false
+	}
+
+	start, err := strconv.ParseInt(strings.TrimSpace(startStr), 10, 64)
+	if err != nil || start < 0 {
+		return 0, 0, false
+	}
+
+	endStr = strings.TrimSpace(endStr)
+	if endStr == "" {
+		return start, 0, true
+	}
+
+	end, err := strconv.ParseInt(endStr, 10, 64)
+	if err != nil || end < start {
+		return 0, 0, false
+	}
+
+	return start, end - start + 1, true
+}
+
 func GetCommitSHAFromPath(r *http.Request) (string, error) {
 	return PathParamOrError(r, PathParamCommitSHA)
 }
 
+// GetCommitCommentIDFromPath returns the id of the commit comment from the path.
+func GetCommitCommentIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamCommitComment)
+}
+
 // ParseSortBranch extracts the branch sort parameter from the url.
-func ParseSortBranch(r *http.Request) enum.BranchSortOption {
-	return enum.ParseBranchSortOption(
-		r.URL.Query().Get(QueryParamSort),
-	)
+func ParseSortBranch(r *http.Request) (enum.BranchSortOption, error) {
+	return QueryParamAsEnum(r, QueryParamSort, enum.ParseBranchSortOptionStrict)
 }
 
 // ParseBranchFilter extracts the branch filter from the url.
-func ParseBranchFilter(r *http.Request) *types.BranchFilter {
+func ParseBranchFilter(r *http.Request) (*types.BranchFilter, error) {
+	order, err := ParseOrder(r)
+	if err != nil {
+		return nil, err
+	}
+
+	sort, err := ParseSortBranch(r)
+	if err != nil {
+		return nil, err
+	}
+
 	return &types.BranchFilter{
 		Query: ParseQuery(r),
-		Sort:  ParseSortBranch(r),
-		Order: ParseOrder(r),
+		Sort:  sort,
+		Order: order,
 		Page:  ParsePage(r),
 		Size:  ParseLimit(r),
-	}
+	}, nil
 }
 
 // ParseSortTag extracts the tag sort parameter from the url.
-func ParseSortTag(r *http.Request) enum.TagSortOption {
-	return enum.ParseTagSortOption(
-		r.URL.Query().Get(QueryParamSort),
-	)
+func ParseSortTag(r *http.Request) (enum.TagSortOption, error) {
+	return QueryParamAsEnum(r, QueryParamSort, enum.ParseTagSortOptionStrict)
 }
 
 // ParseTagFilter extracts the tag filter from the url.
-func ParseTagFilter(r *http.Request) *types.TagFilter {
+func ParseTagFilter(r *http.Request) (*types.TagFilter, error) {
+	order, err := ParseOrder(r)
+	if err != nil {
+		return nil, err
+	}
+
+	sort, err := ParseSortTag(r)
+	if err != nil {
+		return nil, err
+	}
+
 	return &types.TagFilter{
 		Query: ParseQuery(r),
-		Sort:  ParseSortTag(r),
-		Order: ParseOrder(r),
+		Sort:  sort,
+		Order: order,
 		Page:  ParsePage(r),
 		Size:  ParseLimit(r),
-	}
+	}, nil
 }
 
 // ParseCommitFilter extracts the commit filter from the url.
@@ -112,6 +186,103 @@ func ParseCommitFilter(r *http.Request) (*types.CommitFilter, error) {
 	}, nil
 }
 
+// ParseCommitSearchFilter extracts the commit search filter from the url.
+func ParseCommitSearchFilter(r *http.Request) (*types.CommitSearchFilter, error) {
+	// since is optional, skipped if set to 0
+	since, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamSince, 0)
+	if err != nil {
+		return nil, err
+	}
+	// until is optional, skipped if set to 0
+	until, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamUntil, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &types.CommitSearchFilter{
+		PaginationFilter: types.PaginationFilter{
+			Page:  ParsePage(r),
+			Limit: ParseLimit(r),
+		},
+		Query:     ParseQuery(r),
+		Author:    QueryParamOrDefault(r, QueryParamAuthor, ""),
+		Committer: QueryParamOrDefault(r, QueryParamCommitter, ""),
+		Since:     since,
+		Until:     until,
+	}, nil
+}
+
+// ParseTreeFilter extracts the tree listing filter from the url.
+func ParseTreeFilter(r *http.Request) (*types.TreeFilter, error) {
+	recursive, err := QueryParamAsBoolOrDefault(r, QueryParamRecursive, false)
+	if err != nil {
+		return nil, err
+	}
+	// max depth is optional, skipped if set to 0
+	maxDepth, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamMaxDepth, 0)
+	if err != nil {
+		return nil, err
+	}
+	limit, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamLimit, TreeLimitDefault)
+	if err != nil {
+		return nil, err
+	}
+	if limit > TreeLimitMax {
+		limit = TreeLimitMax
+	}
+	include, _ := QueryParamList(r, QueryParamInclude)
+	return &types.TreeFilter{
+		Recursive: recursive,
+		MaxDepth:  int(maxDepth),
+		Include:   include,
+		Cursor:    QueryParamOrDefault(r, QueryParamCursor, ""),
+		Limit:     int(limit),
+	}, nil
+}
+
+// ParseGitUsageFilter extracts the git usage filter from the url.
+func ParseGitUsageFilter(r *http.Request) (*types.GitUsageFilter, error) {
+	// since is optional, skipped if set to 0
+	since, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamSince, 0)
+	if err != nil {
+		return nil, err
+	}
+	// until is optional, skipped if set to 0
+	until, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamUntil, 0)
+	if err != nil {
+		return nil, err
+	}
+	principalID, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamPrincipalID, 0)
+	if err != nil {
+		return nil, err
+	}
+	repoID, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamRepoID, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	sort, err := QueryParamAsEnum(r, QueryParamSort, func(s string) (enum.GitUsageSort, error) {
+		return enum.SanitizeStrict(enum.GitUsageSort(s), enum.GetAllGitUsageSorts)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := ParseOrder(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.GitUsageFilter{
+		Pagination:  ParsePaginationFromRequest(r),
+		Since:       since,
+		Until:       until,
+		PrincipalID: principalID,
+		RepoID:      repoID,
+		Sort:        sort,
+		Order:       order,
+	}, nil
+}
+
 // GetGitProtocolFromHeadersOrDefault returns the git protocol from the request headers.
 func GetGitProtocolFromHeadersOrDefault(r *http.Request, deflt string) string {
 	return GetHeaderOrDefault(r, HeaderParamGitProtocol, deflt)
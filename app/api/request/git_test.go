@@ -0,0 +1,53 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetByteRangeFromHeader(t *testing.T) {
+	tests := []struct {
+		header     string
+		wantOffset int64
+		wantLength int64
+		wantOK     bool
+	}{
+		{header: "", wantOK: false},
+		{header: "bytes=0-99", wantOffset: 0, wantLength: 100, wantOK: true},
+		{header: "bytes=100-", wantOffset: 100, wantLength: 0, wantOK: true},
+		{header: "bytes=5-5", wantOffset: 5, wantLength: 1, wantOK: true},
+		{header: "bytes=-100", wantOK: false},
+		{header: "bytes=10-5", wantOK: false},
+		{header: "bytes=0-1,5-6", wantOK: false},
+		{header: "lines=0-1", wantOK: false},
+		{header: "bytes=a-b", wantOK: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.header, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.Header.Set(HeaderParamRange, test.header)
+
+			offset, length, ok := GetByteRangeFromHeader(r)
+			if ok != test.wantOK || offset != test.wantOffset || length != test.wantLength {
+				t.Errorf("GetByteRangeFromHeader(%q) = (%d, %d, %t), want (%d, %d, %t)", test.header,
+					offset, length, ok, test.wantOffset, test.wantLength, test.wantOK)
+			}
+		})
+	}
+}
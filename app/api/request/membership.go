@@ -22,33 +22,49 @@ import (
 )
 
 // ParseMembershipUserSort extracts the membership sort parameter from the url.
-func ParseMembershipUserSort(r *http.Request) enum.MembershipUserSort {
-	return enum.ParseMembershipUserSort(
-		r.URL.Query().Get(QueryParamSort),
-	)
+func ParseMembershipUserSort(r *http.Request) (enum.MembershipUserSort, error) {
+	return QueryParamAsEnum(r, QueryParamSort, enum.ParseMembershipUserSortStrict)
 }
 
 // ParseMembershipUserFilter extracts the membership filter from the url.
-func ParseMembershipUserFilter(r *http.Request) types.MembershipUserFilter {
+func ParseMembershipUserFilter(r *http.Request) (types.MembershipUserFilter, error) {
+	order, err := ParseOrder(r)
+	if err != nil {
+		return types.MembershipUserFilter{}, err
+	}
+
+	sort, err := ParseMembershipUserSort(r)
+	if err != nil {
+		return types.MembershipUserFilter{}, err
+	}
+
 	return types.MembershipUserFilter{
 		ListQueryFilter: ParseListQueryFilterFromRequest(r),
-		Sort:            ParseMembershipUserSort(r),
-		Order:           ParseOrder(r),
-	}
+		Sort:            sort,
+		Order:           order,
+	}, nil
 }
 
 // ParseMembershipSpaceSort extracts the membership space sort parameter from the url.
-func ParseMembershipSpaceSort(r *http.Request) enum.MembershipSpaceSort {
-	return enum.ParseMembershipSpaceSort(
-		r.URL.Query().Get(QueryParamSort),
-	)
+func ParseMembershipSpaceSort(r *http.Request) (enum.MembershipSpaceSort, error) {
+	return QueryParamAsEnum(r, QueryParamSort, enum.ParseMembershipSpaceSortStrict)
 }
 
 // ParseMembershipSpaceFilter extracts the membership space filter from the url.
-func ParseMembershipSpaceFilter(r *http.Request) types.MembershipSpaceFilter {
+func ParseMembershipSpaceFilter(r *http.Request) (types.MembershipSpaceFilter, error) {
+	order, err := ParseOrder(r)
+	if err != nil {
+		return types.MembershipSpaceFilter{}, err
+	}
+
+	sort, err := ParseMembershipSpaceSort(r)
+	if err != nil {
+		return types.MembershipSpaceFilter{}, err
+	}
+
 	return types.MembershipSpaceFilter{
 		ListQueryFilter: ParseListQueryFilterFromRequest(r),
-		Sort:            ParseMembershipSpaceSort(r),
-		Order:           ParseOrder(r),
-	}
+		Sort:            sort,
+		Order:           order,
+	}, nil
 }
@@ -17,6 +17,10 @@ package request
 import (
 	"net/http"
 	"net/url"
+	"strconv"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
 )
 
 const (
@@ -25,8 +29,11 @@ const (
 	PathParamStageNumber     = "stage_number"
 	PathParamStepNumber      = "step_number"
 	PathParamTriggerUID      = "trigger_uid"
+	PathParamScheduleUID     = "schedule_uid"
 	QueryParamLatest         = "latest"
 	QueryParamBranch         = "branch"
+	QueryParamLogOffset      = "offset"
+	HeaderLastEventID        = "Last-Event-ID"
 )
 
 func GetPipelineUIDFromPath(r *http.Request) (string, error) {
@@ -69,3 +76,55 @@ func GetTriggerUIDFromPath(r *http.Request) (string, error) {
 	// paths are unescaped
 	return url.PathUnescape(rawRef)
 }
+
+func GetScheduleUIDFromPath(r *http.Request) (string, error) {
+	rawRef, err := PathParamOrError(r, PathParamScheduleUID)
+	if err != nil {
+		return "", err
+	}
+
+	// paths are unescaped
+	return url.PathUnescape(rawRef)
+}
+
+// GetLogOffsetFromRequest returns the position of the first log line a client wants to receive.
+// The offset query parameter takes precedence over the Last-Event-ID header, which is sent by
+// SSE clients on reconnect and contains the position of the last line the client received.
+func GetLogOffsetFromRequest(r *http.Request) (int, error) {
+	if value, ok := QueryParam(r, QueryParamLogOffset); ok {
+		offset, err := strconv.Atoi(value)
+		if err != nil || offset < 0 {
+			return 0, usererror.BadRequestf("Parameter '%s' must be a non-negative integer.", QueryParamLogOffset)
+		}
+		return offset, nil
+	}
+
+	if value := r.Header.Get(HeaderLastEventID); value != "" {
+		lastPos, err := strconv.Atoi(value)
+		if err != nil || lastPos < 0 {
+			return 0, usererror.BadRequestf("Header '%s' must be a non-negative integer.", HeaderLastEventID)
+		}
+		return lastPos + 1, nil
+	}
+
+	return 0, nil
+}
+
+// ParsePipelineStatsFilter extracts the time range of the pipeline statistics from the url.
+func ParsePipelineStatsFilter(r *http.Request) (types.PipelineStatsFilter, error) {
+	// after is optional, the default range is used if set to 0
+	after, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamAfter, 0)
+	if err != nil {
+		return types.PipelineStatsFilter{}, err
+	}
+	// before is optional, the current time is used if set to 0
+	before, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamBefore, 0)
+	if err != nil {
+		return types.PipelineStatsFilter{}, err
+	}
+
+	return types.PipelineStatsFilter{
+		After:  after,
+		Before: before,
+	}, nil
+}
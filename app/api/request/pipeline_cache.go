@@ -0,0 +1,45 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+)
+
+const (
+	PathParamPipelineCacheID = "pipeline_cache_id"
+
+	QueryParamKey        = "key"
+	QueryParamRestoreKey = "restore_key"
+
+	// HeaderPipelineCacheKey is the response header with the key of the restored cache.
+	HeaderPipelineCacheKey = "X-Cache-Key"
+)
+
+// GetPipelineCacheIDFromPath extracts the pipeline cache ID from the URL.
+func GetPipelineCacheIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamPipelineCacheID)
+}
+
+// GetPipelineCacheKeyFromQuery extracts the pipeline cache key from the URL.
+func GetPipelineCacheKeyFromQuery(r *http.Request) (string, error) {
+	return QueryParamOrError(r, QueryParamKey)
+}
+
+// GetPipelineCacheRestoreKeysFromQuery extracts the fallback pipeline cache key prefixes from the URL.
+func GetPipelineCacheRestoreKeysFromQuery(r *http.Request) []string {
+	restoreKeys, _ := QueryParamList(r, QueryParamRestoreKey)
+	return restoreKeys
+}
@@ -48,20 +48,28 @@ func GetServiceAccountUIDFromPath(r *http.Request) (string, error) {
 }
 
 // ParseSortUser extracts the user sort parameter from the url.
-func ParseSortUser(r *http.Request) enum.UserAttr {
-	return enum.ParseUserAttr(
-		r.URL.Query().Get(QueryParamSort),
-	)
+func ParseSortUser(r *http.Request) (enum.UserAttr, error) {
+	return QueryParamAsEnum(r, QueryParamSort, enum.ParseUserAttrStrict)
 }
 
 // ParseUserFilter extracts the user filter from the url.
-func ParseUserFilter(r *http.Request) *types.UserFilter {
+func ParseUserFilter(r *http.Request) (*types.UserFilter, error) {
+	order, err := ParseOrder(r)
+	if err != nil {
+		return nil, err
+	}
+
+	sort, err := ParseSortUser(r)
+	if err != nil {
+		return nil, err
+	}
+
 	return &types.UserFilter{
-		Order: ParseOrder(r),
+		Order: order,
 		Page:  ParsePage(r),
-		Sort:  ParseSortUser(r),
+		Sort:  sort,
 		Size:  ParseLimit(r),
-	}
+	}, nil
 }
 
 // ParsePrincipalTypes extracts the principal types from the url.
@@ -25,6 +25,18 @@ const (
 	PathParamPullReqNumber    = "pullreq_number"
 	PathParamPullReqCommentID = "pullreq_comment_id"
 	PathParamReviewerID       = "pullreq_reviewer_id"
+	PathParamDependencyID     = "pullreq_dependency_id"
+	PathParamBulkJobUID       = "pullreq_bulk_job_uid"
+
+	QueryParamMergeMethod = "method"
+
+	QueryParamIsDraft     = "is_draft"
+	QueryParamReviewerID  = "reviewer_id"
+	QueryParamMentionedID = "mentioned_id"
+	QueryParamCreatedGt   = "created_gt"
+	QueryParamCreatedLt   = "created_lt"
+	QueryParamUpdatedGt   = "updated_gt"
+	QueryParamUpdatedLt   = "updated_lt"
 )
 
 func GetPullReqNumberFromPath(r *http.Request) (int64, error) {
@@ -35,14 +47,28 @@ func GetReviewerIDFromPath(r *http.Request) (int64, error) {
 	return PathParamAsPositiveInt64(r, PathParamReviewerID)
 }
 
+func GetDependencyIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamDependencyID)
+}
+
+func GetBulkJobUIDFromPath(r *http.Request) (string, error) {
+	return PathParamOrError(r, PathParamBulkJobUID)
+}
+
 func GetPullReqCommentIDPath(r *http.Request) (int64, error) {
 	return PathParamAsPositiveInt64(r, PathParamPullReqCommentID)
 }
 
+// ParseMergeMethod extracts the optional merge method from the url.
+func ParseMergeMethod(r *http.Request) enum.MergeMethod {
+	return enum.MergeMethod(r.URL.Query().Get(QueryParamMergeMethod))
+}
+
 // ParseSortPullReq extracts the pull request sort parameter from the url.
-func ParseSortPullReq(r *http.Request) enum.PullReqSort {
-	result, _ := enum.PullReqSort(r.URL.Query().Get(QueryParamSort)).Sanitize()
-	return result
+func ParseSortPullReq(r *http.Request) (enum.PullReqSort, error) {
+	return QueryParamAsEnum(r, QueryParamSort, func(s string) (enum.PullReqSort, error) {
+		return enum.SanitizeStrict(enum.PullReqSort(s), enum.GetAllPullReqSorts)
+	})
 }
 
 // parsePullReqStates extracts the pull request states from the url.
@@ -65,11 +91,58 @@ func parsePullReqStates(r *http.Request) []enum.PullReqState {
 
 // ParsePullReqFilter extracts the pull request query parameter from the url.
 func ParsePullReqFilter(r *http.Request) (*types.PullReqFilter, error) {
-	// created_by is optional, skipped if set to 0
+	// created_by, reviewer_id and mentioned_id are optional, skipped if set to 0
 	createdBy, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamCreatedBy, 0)
 	if err != nil {
 		return nil, err
 	}
+	reviewerID, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamReviewerID, 0)
+	if err != nil {
+		return nil, err
+	}
+	mentionedID, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamMentionedID, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// time ranges are optional, skipped if set to 0
+	createdGt, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamCreatedGt, 0)
+	if err != nil {
+		return nil, err
+	}
+	createdLt, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamCreatedLt, 0)
+	if err != nil {
+		return nil, err
+	}
+	updatedGt, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamUpdatedGt, 0)
+	if err != nil {
+		return nil, err
+	}
+	updatedLt, err := QueryParamAsPositiveInt64OrDefault(r, QueryParamUpdatedLt, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// is_draft is optional, skipped if not provided
+	var isDraft *bool
+	if value, ok := QueryParam(r, QueryParamIsDraft); ok && value != "" {
+		draft, err := QueryParamAsBoolOrDefault(r, QueryParamIsDraft, false)
+		if err != nil {
+			return nil, err
+		}
+		isDraft = &draft
+	}
+
+	sort, err := ParseSortPullReq(r)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := ParseOrder(r)
+	if err != nil {
+		return nil, err
+	}
+
 	return &types.PullReqFilter{
 		Page:          ParsePage(r),
 		Size:          ParseLimit(r),
@@ -79,8 +152,15 @@ func ParsePullReqFilter(r *http.Request) (*types.PullReqFilter, error) {
 		SourceBranch:  r.URL.Query().Get("source_branch"),
 		TargetBranch:  r.URL.Query().Get("target_branch"),
 		States:        parsePullReqStates(r),
-		Sort:          ParseSortPullReq(r),
-		Order:         ParseOrder(r),
+		Sort:          sort,
+		Order:         order,
+		IsDraft:       isDraft,
+		ReviewerID:    reviewerID,
+		MentionedID:   mentionedID,
+		CreatedGt:     createdGt,
+		CreatedLt:     createdLt,
+		UpdatedGt:     updatedGt,
+		UpdatedLt:     updatedLt,
 	}, nil
 }
 
@@ -23,8 +23,11 @@ import (
 )
 
 const (
-	PathParamRepoRef = "repo_ref"
-	QueryParamRepoID = "repo_id"
+	PathParamRepoRef     = "repo_ref"
+	PathParamMergeMethod = "merge_method"
+	QueryParamRepoID     = "repo_id"
+	QueryParamParentRef  = "parent_ref"
+	QueryParamUID        = "uid"
 )
 
 func GetRepoRefFromPath(r *http.Request) (string, error) {
@@ -37,20 +40,38 @@ func GetRepoRefFromPath(r *http.Request) (string, error) {
 	return url.PathUnescape(rawRef)
 }
 
+// GetMergeMethodFromPath extracts the merge method from the URL.
+func GetMergeMethodFromPath(r *http.Request) (enum.MergeMethod, error) {
+	rawMethod, err := PathParamOrError(r, PathParamMergeMethod)
+	if err != nil {
+		return "", err
+	}
+
+	return enum.MergeMethod(rawMethod), nil
+}
+
 // ParseSortRepo extracts the repo sort parameter from the url.
-func ParseSortRepo(r *http.Request) enum.RepoAttr {
-	return enum.ParseRepoAttr(
-		r.URL.Query().Get(QueryParamSort),
-	)
+func ParseSortRepo(r *http.Request) (enum.RepoAttr, error) {
+	return QueryParamAsEnum(r, QueryParamSort, enum.ParseRepoAttrStrict)
 }
 
 // ParseRepoFilter extracts the repository filter from the url.
-func ParseRepoFilter(r *http.Request) *types.RepoFilter {
+func ParseRepoFilter(r *http.Request) (*types.RepoFilter, error) {
+	order, err := ParseOrder(r)
+	if err != nil {
+		return nil, err
+	}
+
+	sort, err := ParseSortRepo(r)
+	if err != nil {
+		return nil, err
+	}
+
 	return &types.RepoFilter{
 		Query: ParseQuery(r),
-		Order: ParseOrder(r),
+		Order: order,
 		Page:  ParsePage(r),
-		Sort:  ParseSortRepo(r),
+		Sort:  sort,
 		Size:  ParseLimit(r),
-	}
+	}, nil
 }
@@ -28,13 +28,23 @@ const (
 )
 
 // ParseRuleFilter extracts the protection rule query parameters from the url.
-func ParseRuleFilter(r *http.Request) *types.RuleFilter {
+func ParseRuleFilter(r *http.Request) (*types.RuleFilter, error) {
+	order, err := ParseOrder(r)
+	if err != nil {
+		return nil, err
+	}
+
+	sort, err := parseRuleSort(r)
+	if err != nil {
+		return nil, err
+	}
+
 	return &types.RuleFilter{
 		ListQueryFilter: ParseListQueryFilterFromRequest(r),
 		States:          parseRuleStates(r),
-		Sort:            parseRuleSort(r),
-		Order:           ParseOrder(r),
-	}
+		Sort:            sort,
+		Order:           order,
+	}, nil
 }
 
 // parseRuleStates extracts the protection rule states from the url.
@@ -61,10 +71,8 @@ func GetRuleUIDFromPath(r *http.Request) (string, error) {
 }
 
 // parseRuleSort extracts the protection rule sort parameter from the URL.
-func parseRuleSort(r *http.Request) enum.RuleSort {
-	return enum.ParseRuleSortAttr(
-		r.URL.Query().Get(QueryParamSort),
-	)
+func parseRuleSort(r *http.Request) (enum.RuleSort, error) {
+	return QueryParamAsEnum(r, QueryParamSort, enum.ParseRuleSortAttrStrict)
 }
 
 // ParseBypassRulesFromQuery extracts the bypass rules parameter from the URL query.
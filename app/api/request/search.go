@@ -0,0 +1,56 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	QueryParamSearchQuery = "q"
+	QueryParamSpacePath   = "space_path"
+)
+
+// ParseGlobalSearchFilter extracts the global search query parameters from the url.
+func ParseGlobalSearchFilter(r *http.Request) *types.GlobalSearchFilter {
+	return &types.GlobalSearchFilter{
+		Query:     r.URL.Query().Get(QueryParamSearchQuery),
+		Types:     parseSearchResultTypes(r),
+		SpacePath: r.URL.Query().Get(QueryParamSpacePath),
+		Page:      ParsePage(r),
+		Size:      ParseLimit(r),
+	}
+}
+
+// parseSearchResultTypes extracts the global search result types from the url.
+func parseSearchResultTypes(r *http.Request) []enum.SearchResultType {
+	strTypes, _ := QueryParamList(r, QueryParamType)
+	m := make(map[enum.SearchResultType]struct{}) // use map to eliminate duplicates
+	for _, s := range strTypes {
+		if t, ok := enum.SearchResultType(s).Sanitize(); ok {
+			m[t] = struct{}{}
+		}
+	}
+
+	resultTypes := make([]enum.SearchResultType, 0, len(m))
+	for t := range m {
+		resultTypes = append(resultTypes, t)
+	}
+
+	return resultTypes
+}
@@ -23,7 +23,9 @@ import (
 )
 
 const (
-	PathParamSpaceRef = "space_ref"
+	PathParamSpaceRef       = "space_ref"
+	PathParamPinID          = "pin_id"
+	PathParamRequiredFileID = "required_file_id"
 )
 
 func GetSpaceRefFromPath(r *http.Request) (string, error) {
@@ -36,20 +38,36 @@ func GetSpaceRefFromPath(r *http.Request) (string, error) {
 	return url.PathUnescape(rawRef)
 }
 
+func GetPinIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamPinID)
+}
+
+func GetRequiredFileIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamRequiredFileID)
+}
+
 // ParseSortSpace extracts the space sort parameter from the url.
-func ParseSortSpace(r *http.Request) enum.SpaceAttr {
-	return enum.ParseSpaceAttr(
-		r.URL.Query().Get(QueryParamSort),
-	)
+func ParseSortSpace(r *http.Request) (enum.SpaceAttr, error) {
+	return QueryParamAsEnum(r, QueryParamSort, enum.ParseSpaceAttrStrict)
 }
 
 // ParseSpaceFilter extracts the space filter from the url.
-func ParseSpaceFilter(r *http.Request) *types.SpaceFilter {
+func ParseSpaceFilter(r *http.Request) (*types.SpaceFilter, error) {
+	order, err := ParseOrder(r)
+	if err != nil {
+		return nil, err
+	}
+
+	sort, err := ParseSortSpace(r)
+	if err != nil {
+		return nil, err
+	}
+
 	return &types.SpaceFilter{
 		Query: ParseQuery(r),
-		Order: ParseOrder(r),
+		Order: order,
 		Page:  ParsePage(r),
-		Sort:  ParseSortSpace(r),
+		Sort:  sort,
 		Size:  ParseLimit(r),
-	}
+	}, nil
 }
@@ -181,6 +181,17 @@ func PathParamAsPositiveInt64(r *http.Request, paramName string) (int64, error)
 	return valueInt, nil
 }
 
+// QueryParamAsEnum parses the query parameter with the provided strict enum parse function.
+// Values that aren't accepted by the enum are rejected with a bad request error.
+func QueryParamAsEnum[E any](r *http.Request, paramName string, parse func(string) (E, error)) (E, error) {
+	value, err := parse(r.URL.Query().Get(paramName))
+	if err != nil {
+		return value, usererror.BadRequestf("Parameter '%s' is invalid: %s.", paramName, err)
+	}
+
+	return value, nil
+}
+
 // QueryParamAsBoolOrDefault tries to retrieve the parameter from the query and parse it to bool.
 func QueryParamAsBoolOrDefault(r *http.Request, paramName string, deflt bool) (bool, error) {
 	rawValue, ok := QueryParam(r, paramName)
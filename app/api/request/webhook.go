@@ -35,14 +35,24 @@ func GetWebhookExecutionIDFromPath(r *http.Request) (int64, error) {
 }
 
 // ParseWebhookFilter extracts the Webhook query parameters for listing from the url.
-func ParseWebhookFilter(r *http.Request) *types.WebhookFilter {
+func ParseWebhookFilter(r *http.Request) (*types.WebhookFilter, error) {
+	order, err := ParseOrder(r)
+	if err != nil {
+		return nil, err
+	}
+
+	sort, err := ParseSortWebhook(r)
+	if err != nil {
+		return nil, err
+	}
+
 	return &types.WebhookFilter{
 		Query: ParseQuery(r),
 		Page:  ParsePage(r),
 		Size:  ParseLimit(r),
-		Sort:  ParseSortWebhook(r),
-		Order: ParseOrder(r),
-	}
+		Sort:  sort,
+		Order: order,
+	}, nil
 }
 
 // ParseWebhookExecutionFilter extracts the WebhookExecution query parameters for listing from the url.
@@ -54,8 +64,6 @@ func ParseWebhookExecutionFilter(r *http.Request) *types.WebhookExecutionFilter
 }
 
 // ParseSortWebhook extracts the webhook sort parameter from the url.
-func ParseSortWebhook(r *http.Request) enum.WebhookAttr {
-	return enum.ParseWebhookAttr(
-		r.URL.Query().Get(QueryParamSort),
-	)
+func ParseSortWebhook(r *http.Request) (enum.WebhookAttr, error) {
+	return QueryParamAsEnum(r, QueryParamSort, enum.ParseWebhookAttrStrict)
 }
@@ -70,6 +70,10 @@ var (
 	// ErrPullReqRefsCantBeModified is returned if a user tries to tinker with a pull request git ref.
 	ErrPullReqRefsCantBeModified = New(http.StatusBadRequest, "The pull request git refs can't be modified")
 
+	// ErrMirrorIsReadOnly is returned if a user tries to modify the git refs of a mirror repository.
+	ErrMirrorIsReadOnly = New(http.StatusBadRequest,
+		"The repository is a mirror, its git refs are only updated by the synchronization")
+
 	// ErrRequestTooLarge is returned if the request it too large.
 	ErrRequestTooLarge = New(http.StatusRequestEntityTooLarge, "The request is too large")
 
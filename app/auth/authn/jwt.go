@@ -20,34 +20,51 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/jwt"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/token"
 	"github.com/harness/gitness/types"
 
 	gojwt "github.com/golang-jwt/jwt"
+	"github.com/rs/zerolog/log"
 )
 
 var _ Authenticator = (*JWTAuthenticator)(nil)
 
+// lastUsedUpdateInterval is the minimum duration between two updates of the last used time of a token.
+const lastUsedUpdateInterval = time.Minute
+
+// AdminRestrictor decides whether an admin is authenticated without its admin permissions.
+type AdminRestrictor interface {
+	AdminRestricted(ctx context.Context, principal *types.Principal) (bool, error)
+}
+
 // JWTAuthenticator uses the provided JWT to authenticate the caller.
 type JWTAuthenticator struct {
-	cookieName     string
-	principalStore store.PrincipalStore
-	tokenStore     store.TokenStore
+	cookieName      string
+	principalStore  store.PrincipalStore
+	tokenStore      store.TokenStore
+	tokenPolicies   token.Policies
+	adminRestrictor AdminRestrictor
 }
 
 func NewTokenAuthenticator(
 	principalStore store.PrincipalStore,
 	tokenStore store.TokenStore,
+	tokenPolicies token.Policies,
+	adminRestrictor AdminRestrictor,
 	cookieName string,
 ) *JWTAuthenticator {
 	return &JWTAuthenticator{
-		cookieName:     cookieName,
-		principalStore: principalStore,
-		tokenStore:     tokenStore,
+		cookieName:      cookieName,
+		principalStore:  principalStore,
+		tokenStore:      tokenStore,
+		tokenPolicies:   tokenPolicies,
+		adminRestrictor: adminRestrictor,
 	}
 }
 
@@ -94,6 +111,18 @@ func (a *JWTAuthenticator) Authenticate(r *http.Request) (*auth.Session, error)
 		return nil, fmt.Errorf("jwt is missing sub-claims")
 	}
 
+	if principal.Admin {
+		restricted, err := a.adminRestrictor.AdminRestricted(ctx, principal)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check admin restrictions: %w", err)
+		}
+
+		// the admin can only act as a regular user, e.g. to register the passkey the admin policy requires.
+		if restricted {
+			principal.Admin = false
+		}
+	}
+
 	return &auth.Session{
 		Principal: *principal,
 		Metadata:  metadata,
@@ -117,9 +146,25 @@ func (a *JWTAuthenticator) metadataFromTokenClaims(
 			principal.ID, tkn.PrincipalID)
 	}
 
+	now := time.Now()
+
+	if err = a.tokenPolicies.Validate(principal.Type, tkn, now); err != nil {
+		return nil, err
+	}
+
+	// the last used time is only updated periodically to avoid a db write on every request
+	if tkn.LastUsed == nil || now.Sub(time.UnixMilli(*tkn.LastUsed)) > lastUsedUpdateInterval {
+		if err = a.tokenStore.UpdateLastUsed(ctx, tkn.ID, now.UnixMilli()); err != nil {
+			// non-critical error
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to update last used time of token %d", tkn.ID)
+		}
+	}
+
 	return &auth.TokenMetadata{
 		TokenType: tkn.Type,
 		TokenID:   tkn.ID,
+		SpaceIDs:  tkn.SpaceIDs,
+		Role:      tkn.Role,
 	}, nil
 }
 
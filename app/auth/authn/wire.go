@@ -15,7 +15,9 @@
 package authn
 
 import (
+	"github.com/harness/gitness/app/services/passkey"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/token"
 	"github.com/harness/gitness/types"
 
 	"github.com/google/wire"
@@ -30,6 +32,8 @@ func ProvideAuthenticator(
 	config *types.Config,
 	principalStore store.PrincipalStore,
 	tokenStore store.TokenStore,
+	tokenPolicies token.Policies,
+	passkeys *passkey.Service,
 ) Authenticator {
-	return NewTokenAuthenticator(principalStore, tokenStore, config.Token.CookieName)
+	return NewTokenAuthenticator(principalStore, tokenStore, tokenPolicies, passkeys, config.Token.CookieName)
 }
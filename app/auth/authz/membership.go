@@ -16,11 +16,13 @@ package authz
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -72,6 +74,14 @@ func (a *MembershipAuthorizer) Check(
 		session.Metadata,
 	)
 
+	// a token restricted to spaces limits the access of any principal, including system admins
+	if tokenMetadata, ok := session.Metadata.(*auth.TokenMetadata); ok && len(tokenMetadata.SpaceIDs) > 0 {
+		allowed, err := a.checkTokenSpaces(ctx, tokenMetadata, scope, resource, permission)
+		if err != nil || !allowed {
+			return false, err
+		}
+	}
+
 	if session.Principal.Admin {
 		return true, nil // system admin can call any API
 	}
@@ -124,8 +134,14 @@ func (a *MembershipAuthorizer) Check(
 		return a.checkWithMembershipMetadata(ctx, membershipMetadata, spacePath, permission)
 	}
 
+	// a token with a role grants the permissions of the role (within the spaces it's restricted to)
+	if tokenMetadata, ok := session.Metadata.(*auth.TokenMetadata); ok && tokenMetadata.Role != "" {
+		return a.checkWithTokenRole(tokenMetadata, permission)
+	}
+
 	// ensure we aren't bypassing unknown metadata with impact on authorization
-	if session.Metadata != nil && session.Metadata.ImpactsAuthorization() {
+	_, isTokenMetadata := session.Metadata.(*auth.TokenMetadata)
+	if session.Metadata != nil && !isTokenMetadata && session.Metadata.ImpactsAuthorization() {
 		return false, fmt.Errorf("session contains unknown metadata that impacts authorization: %T", session.Metadata)
 	}
 
@@ -179,3 +195,64 @@ func (a *MembershipAuthorizer) checkWithMembershipMetadata(
 	// access is granted by ephemeral membership
 	return true, nil
 }
+
+// checkWithTokenRole checks access using the role of the token instead of the memberships of the principal.
+// The scope of the request was already verified against the spaces of the token.
+func (a *MembershipAuthorizer) checkWithTokenRole(
+	tokenMetadata *auth.TokenMetadata,
+	requestedPermission enum.Permission,
+) (bool, error) {
+	if len(tokenMetadata.SpaceIDs) == 0 {
+		return false, fmt.Errorf("token %d with role '%s' isn't restricted to any space",
+			tokenMetadata.TokenID, tokenMetadata.Role)
+	}
+
+	return roleHasPermission(tokenMetadata.Role, requestedPermission), nil
+}
+
+// checkTokenSpaces checks whether the requested resource is within one of the spaces the token is restricted to.
+// Restricted tokens can't be used to modify the user they belong to, preventing them from creating broader tokens.
+func (a *MembershipAuthorizer) checkTokenSpaces(
+	ctx context.Context,
+	tokenMetadata *auth.TokenMetadata,
+	scope *types.Scope,
+	resource *types.Resource,
+	permission enum.Permission,
+) (bool, error) {
+	var requestedSpacePath string
+
+	//nolint:exhaustive // everything else is scoped by the space path
+	switch resource.Type {
+	case enum.ResourceTypeSpace:
+		requestedSpacePath = paths.Concatinate(scope.SpacePath, resource.Name)
+	case enum.ResourceTypeUser:
+		return permission == enum.PermissionUserView, nil
+	case enum.ResourceTypeService:
+		return false, nil
+	default:
+		requestedSpacePath = scope.SpacePath
+	}
+
+	for _, spaceID := range tokenMetadata.SpaceIDs {
+		space, err := a.spaceStore.Find(ctx, spaceID)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			// the space got deleted - it doesn't grant access to anything anymore
+			continue
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to find space: %w", err)
+		}
+
+		if paths.IsAncesterOf(space.Path, requestedSpacePath) {
+			return true, nil
+		}
+	}
+
+	log.Ctx(ctx).Debug().Msgf(
+		"requested permission scope '%s' is outside of the spaces the token %d is restricted to",
+		requestedSpacePath,
+		tokenMetadata.TokenID,
+	)
+
+	return false, nil
+}
@@ -31,10 +31,14 @@ func (m *EmptyMetadata) ImpactsAuthorization() bool {
 type TokenMetadata struct {
 	TokenType enum.TokenType
 	TokenID   int64
+	// SpaceIDs optionally restricts the access of the token to the listed spaces.
+	SpaceIDs []int64
+	// Role optionally replaces the permissions of the principal with the ones of the role.
+	Role enum.MembershipRole
 }
 
 func (m *TokenMetadata) ImpactsAuthorization() bool {
-	return false
+	return len(m.SpaceIDs) > 0 || m.Role != ""
 }
 
 // MembershipMetadata contains information about an ephemeral membership grant.
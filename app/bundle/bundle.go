@@ -0,0 +1,289 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bundle implements the portable repository bundle format used to migrate repositories
+// between instances without network connectivity between them.
+//
+// A bundle is a tar archive with the following entries, in this order:
+//   - manifest.json: the Manifest, describing the repository and the other entries.
+//   - repository.bundle: a git bundle with all references of the repository.
+//   - metadata.json (optional): the Metadata of the repository (pull requests, rules, webhooks).
+package bundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+const (
+	// SchemaVersion is the latest version of the manifest supported by this package.
+	// Bundles with a newer schema version are rejected.
+	SchemaVersion = 2
+
+	// schemaVersionGitOnly is the version of the manifest of bundles without the metadata.
+	// Such bundles can still be imported by instances that only support the first schema version.
+	schemaVersionGitOnly = 1
+
+	// FileManifest is the name of the manifest entry of the bundle.
+	FileManifest = "manifest.json"
+	// FileGitBundle is the name of the git bundle entry of the bundle.
+	FileGitBundle = "repository.bundle"
+	// FileMetadata is the name of the metadata entry of the bundle.
+	FileMetadata = "metadata.json"
+
+	// maxManifestSize is the maximum size of the manifest entry.
+	maxManifestSize = 1 << 20
+	// maxMetadataSize is the maximum size of the metadata entry.
+	maxMetadataSize = 1 << 30
+)
+
+// Manifest describes the content of a bundle.
+type Manifest struct {
+	SchemaVersion int    `json:"schema_version"`
+	Created       int64  `json:"created"`
+	Version       string `json:"version"`
+
+	Repository Repository `json:"repository"`
+	GitBundle  GitBundle  `json:"git_bundle"`
+
+	// Metadata identifies the metadata entry, nil if the bundle doesn't contain the metadata.
+	Metadata *MetadataFile `json:"metadata,omitempty"`
+}
+
+// Repository holds the repository settings that are restored on import.
+type Repository struct {
+	UID           string `json:"uid"`
+	Description   string `json:"description"`
+	DefaultBranch string `json:"default_branch"`
+	IsPublic      bool   `json:"is_public"`
+}
+
+// GitBundle identifies the git bundle entry of a bundle.
+type GitBundle struct {
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+	// Since is the commit an incremental git bundle is based on (empty if the bundle is complete).
+	// Incremental bundles can only be applied to repositories containing the commit.
+	Since string `json:"since,omitempty"`
+}
+
+// MetadataFile identifies the metadata entry of a bundle.
+type MetadataFile struct {
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// NewManifest returns the manifest of a bundle of the repository with the provided git bundle.
+func NewManifest(version string, repo Repository, gitBundleSize int64, gitBundleSHA256 []byte) *Manifest {
+	return &Manifest{
+		SchemaVersion: schemaVersionGitOnly,
+		Created:       time.Now().UnixMilli(),
+		Version:       version,
+		Repository:    repo,
+		GitBundle: GitBundle{
+			Size:   gitBundleSize,
+			SHA256: hex.EncodeToString(gitBundleSHA256),
+		},
+	}
+}
+
+// Write writes a bundle with the manifest, the git bundle and the optional metadata to w.
+// The git bundle must match the size declared by the manifest.
+// If the metadata is provided, the manifest is updated to describe it.
+func Write(w io.Writer, manifest *Manifest, gitBundle io.Reader, metadata *Metadata) error {
+	var metadataData []byte
+	if metadata != nil {
+		var err error
+		metadataData, err = json.Marshal(metadata)
+		if err != nil {
+			return fmt.Errorf("failed to marshal metadata: %w", err)
+		}
+
+		sum := sha256.Sum256(metadataData)
+
+		manifest.SchemaVersion = SchemaVersion
+		manifest.Metadata = &MetadataFile{
+			Size:   int64(len(metadataData)),
+			SHA256: hex.EncodeToString(sum[:]),
+		}
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+
+	modTime := time.UnixMilli(manifest.Created)
+	tw := tar.NewWriter(w)
+
+	err = tw.WriteHeader(&tar.Header{
+		Name:    FileManifest,
+		Mode:    0o644,
+		Size:    int64(len(data)),
+		ModTime: modTime,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write manifest header: %w", err)
+	}
+
+	if _, err = tw.Write(data); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+
+	err = tw.WriteHeader(&tar.Header{
+		Name:    FileGitBundle,
+		Mode:    0o644,
+		Size:    manifest.GitBundle.Size,
+		ModTime: modTime,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write git bundle header: %w", err)
+	}
+
+	if _, err = io.CopyN(tw, gitBundle, manifest.GitBundle.Size); err != nil {
+		return fmt.Errorf("failed to write git bundle: %w", err)
+	}
+
+	if metadataData != nil {
+		err = tw.WriteHeader(&tar.Header{
+			Name:    FileMetadata,
+			Mode:    0o644,
+			Size:    int64(len(metadataData)),
+			ModTime: modTime,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to write metadata header: %w", err)
+		}
+
+		if _, err = tw.Write(metadataData); err != nil {
+			return fmt.Errorf("failed to write metadata: %w", err)
+		}
+	}
+
+	if err = tw.Close(); err != nil {
+		return fmt.Errorf("failed to finish bundle: %w", err)
+	}
+
+	return nil
+}
+
+// Read reads a bundle from r, copies its git bundle to gitBundle and returns the manifest
+// along with the metadata (nil if the bundle doesn't contain it).
+// The git bundle and the metadata are verified against the sizes and the checksums declared by the manifest.
+func Read(r io.Reader, gitBundle io.Writer) (*Manifest, *Metadata, error) {
+	tr := tar.NewReader(r)
+
+	manifest, err := readManifest(tr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = readEntry(tr, FileGitBundle, gitBundle, manifest.GitBundle.Size, manifest.GitBundle.SHA256)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if manifest.Metadata == nil {
+		return manifest, nil, nil
+	}
+
+	buf := &bytes.Buffer{}
+	err = readEntry(tr, FileMetadata, buf, manifest.Metadata.Size, manifest.Metadata.SHA256)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	metadata := &Metadata{}
+	if err = json.Unmarshal(buf.Bytes(), metadata); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse metadata: %w", err)
+	}
+
+	return manifest, metadata, nil
+}
+
+// readEntry reads the next entry of the bundle to w and verifies its name, size and checksum.
+func readEntry(tr *tar.Reader, name string, w io.Writer, size int64, checksum string) error {
+	hdr, err := tr.Next()
+	if errors.Is(err, io.EOF) {
+		return fmt.Errorf("the bundle doesn't contain %s", name)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read bundle: %w", err)
+	}
+
+	if hdr.Name != name {
+		return fmt.Errorf("unexpected bundle entry %q, expected %s", hdr.Name, name)
+	}
+
+	if hdr.Size != size {
+		return fmt.Errorf("%s size %d doesn't match the manifest size %d", name, hdr.Size, size)
+	}
+
+	hasher := sha256.New()
+	if _, err = io.Copy(io.MultiWriter(w, hasher), tr); err != nil {
+		return fmt.Errorf("failed to read %s: %w", name, err)
+	}
+
+	if sum := hex.EncodeToString(hasher.Sum(nil)); sum != checksum {
+		return fmt.Errorf("%s checksum %s doesn't match the manifest checksum %s", name, sum, checksum)
+	}
+
+	return nil
+}
+
+func readManifest(tr *tar.Reader) (*Manifest, error) {
+	hdr, err := tr.Next()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("the bundle is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bundle: %w", err)
+	}
+
+	if hdr.Name != FileManifest {
+		return nil, fmt.Errorf("unexpected bundle entry %q, expected %s", hdr.Name, FileManifest)
+	}
+
+	if hdr.Size > maxManifestSize {
+		return nil, fmt.Errorf("the manifest exceeds the maximum size of %d bytes", maxManifestSize)
+	}
+
+	manifest := &Manifest{}
+	if err = json.NewDecoder(tr).Decode(manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+
+	if manifest.SchemaVersion < 1 || manifest.SchemaVersion > SchemaVersion {
+		return nil, fmt.Errorf("unsupported manifest schema version %d, the latest supported version is %d",
+			manifest.SchemaVersion, SchemaVersion)
+	}
+
+	if manifest.GitBundle.Size <= 0 || manifest.GitBundle.SHA256 == "" {
+		return nil, errors.New("the manifest doesn't describe the git bundle")
+	}
+
+	if m := manifest.Metadata; m != nil && (m.Size <= 0 || m.Size > maxMetadataSize || m.SHA256 == "") {
+		return nil, fmt.Errorf("the manifest describes invalid metadata (the maximum size is %d bytes)",
+			maxMetadataSize)
+	}
+
+	return manifest, nil
+}
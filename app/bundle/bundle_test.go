@@ -0,0 +1,169 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWriteRead(t *testing.T) {
+	gitBundle := []byte("# v2 git bundle\nfake content\n")
+	sum := sha256.Sum256(gitBundle)
+
+	repo := Repository{
+		UID:           "repo",
+		Description:   "a repository",
+		DefaultBranch: "main",
+		IsPublic:      true,
+	}
+	manifest := NewManifest("1.0.0", repo, int64(len(gitBundle)), sum[:])
+
+	buf := &bytes.Buffer{}
+	if err := Write(buf, manifest, bytes.NewReader(gitBundle), nil); err != nil {
+		t.Fatalf("failed to write bundle: %s", err)
+	}
+
+	gotBundle := &bytes.Buffer{}
+	got, gotMetadata, err := Read(buf, gotBundle)
+	if err != nil {
+		t.Fatalf("failed to read bundle: %s", err)
+	}
+
+	if gotMetadata != nil {
+		t.Errorf("expected no metadata, got: %+v", gotMetadata)
+	}
+
+	if !reflect.DeepEqual(manifest, got) {
+		t.Errorf("manifest mismatch: want=%+v got=%+v", manifest, got)
+	}
+
+	if !bytes.Equal(gitBundle, gotBundle.Bytes()) {
+		t.Errorf("git bundle mismatch: want=%q got=%q", gitBundle, gotBundle.Bytes())
+	}
+}
+
+func TestWriteReadMetadata(t *testing.T) {
+	gitBundle := []byte("# v2 git bundle\nfake content\n")
+	sum := sha256.Sum256(gitBundle)
+
+	merged := int64(1700000000000)
+	metadata := &Metadata{
+		Principals: []Principal{{ID: 3, UID: "admin", Email: "admin@example.com", DisplayName: "Admin"}},
+		PullRequests: []PullRequest{{
+			Number:       1,
+			CreatedBy:    3,
+			State:        "merged",
+			Title:        "feature",
+			SourceBranch: "feature",
+			TargetBranch: "main",
+			Merged:       &merged,
+			Activities:   []Activity{{CreatedBy: 3, Order: 1, Type: "comment", Kind: "comment", Text: "LGTM"}},
+		}},
+		Rules: []Rule{{
+			UID:        "protect-main",
+			Type:       "branch",
+			State:      "active",
+			CreatedBy:  3,
+			Pattern:    json.RawMessage(`{"default":true}`),
+			Definition: json.RawMessage(`{"bypass":{"user_ids":[3]}}`),
+		}},
+		Webhooks: []Webhook{{UID: "ci", URL: "https://ci.example.com", Enabled: true, HasSecret: true}},
+	}
+
+	manifest := NewManifest("1.0.0", Repository{UID: "repo"}, int64(len(gitBundle)), sum[:])
+
+	buf := &bytes.Buffer{}
+	if err := Write(buf, manifest, bytes.NewReader(gitBundle), metadata); err != nil {
+		t.Fatalf("failed to write bundle: %s", err)
+	}
+
+	if manifest.SchemaVersion != SchemaVersion || manifest.Metadata == nil {
+		t.Fatalf("expected the manifest to describe the metadata, got: %+v", manifest)
+	}
+
+	got, gotMetadata, err := Read(buf, &bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("failed to read bundle: %s", err)
+	}
+
+	if !reflect.DeepEqual(manifest, got) {
+		t.Errorf("manifest mismatch: want=%+v got=%+v", manifest, got)
+	}
+
+	if !reflect.DeepEqual(metadata, gotMetadata) {
+		t.Errorf("metadata mismatch: want=%+v got=%+v", metadata, gotMetadata)
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	gitBundle := []byte("# v2 git bundle\n")
+	sum := sha256.Sum256(gitBundle)
+
+	tests := []struct {
+		name     string
+		manifest func(m *Manifest)
+		content  []byte
+		errMsg   string
+	}{
+		{
+			name:     "newer schema version",
+			manifest: func(m *Manifest) { m.SchemaVersion = SchemaVersion + 1 },
+			errMsg:   "unsupported manifest schema version",
+		},
+		{
+			name:     "checksum mismatch",
+			manifest: func(m *Manifest) { m.GitBundle.SHA256 = strings.Repeat("0", 64) },
+			errMsg:   "doesn't match the manifest checksum",
+		},
+		{
+			name:     "missing git bundle",
+			manifest: func(m *Manifest) { m.GitBundle.Size = 0 },
+			errMsg:   "doesn't describe the git bundle",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			manifest := NewManifest("1.0.0", Repository{UID: "repo"}, int64(len(gitBundle)), sum[:])
+			test.manifest(manifest)
+
+			buf := &bytes.Buffer{}
+			_ = Write(buf, manifest, bytes.NewReader(gitBundle), nil)
+
+			_, _, err := Read(buf, &bytes.Buffer{})
+			if err == nil || !strings.Contains(err.Error(), test.errMsg) {
+				t.Errorf("expected error containing %q, got: %v", test.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestReadUnexpectedEntry(t *testing.T) {
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+	_ = tw.WriteHeader(&tar.Header{Name: "other.txt", Mode: 0o644, Size: 1})
+	_, _ = tw.Write([]byte("x"))
+	_ = tw.Close()
+
+	if _, _, err := Read(buf, &bytes.Buffer{}); err == nil {
+		t.Error("expected an error")
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundle
+
+import "encoding/json"
+
+// Metadata contains the repository data stored outside of git.
+// Principals are referenced by their ID on the source instance and resolved
+// through the Principals list when the metadata is restored.
+// Webhook secrets are never included.
+type Metadata struct {
+	Principals   []Principal   `json:"principals"`
+	PullRequests []PullRequest `json:"pull_requests"`
+	Rules        []Rule        `json:"rules"`
+	Webhooks     []Webhook     `json:"webhooks"`
+}
+
+// Principal identifies a principal referenced by the metadata.
+type Principal struct {
+	ID          int64  `json:"id"`
+	UID         string `json:"uid"`
+	Email       string `json:"email"`
+	DisplayName string `json:"display_name"`
+}
+
+// PullRequest is a pull request targeting the repository.
+type PullRequest struct {
+	Number    int64  `json:"number"`
+	CreatedBy int64  `json:"created_by"`
+	Created   int64  `json:"created"`
+	Edited    int64  `json:"edited"`
+	State     string `json:"state"`
+	IsDraft   bool   `json:"is_draft"`
+
+	Title       string `json:"title"`
+	Description string `json:"description"`
+
+	// Fork is true if the source branch belongs to another repository.
+	Fork         bool   `json:"fork,omitempty"`
+	SourceBranch string `json:"source_branch"`
+	SourceSHA    string `json:"source_sha"`
+	TargetBranch string `json:"target_branch"`
+	MergeBaseSHA string `json:"merge_base_sha"`
+
+	MergedBy       *int64  `json:"merged_by,omitempty"`
+	Merged         *int64  `json:"merged,omitempty"`
+	MergeMethod    *string `json:"merge_method,omitempty"`
+	MergeSHA       *string `json:"merge_sha,omitempty"`
+	MergeTargetSHA *string `json:"merge_target_sha,omitempty"`
+
+	Activities []Activity `json:"activities"`
+}
+
+// Activity is an entry of the activity log of a pull request (a comment, a code comment or a system event).
+type Activity struct {
+	CreatedBy int64  `json:"created_by"`
+	Created   int64  `json:"created"`
+	Edited    int64  `json:"edited"`
+	Deleted   *int64 `json:"deleted,omitempty"`
+
+	Order    int64 `json:"order"`
+	SubOrder int64 `json:"sub_order"`
+
+	Type     string                 `json:"type"`
+	Kind     string                 `json:"kind"`
+	Text     string                 `json:"text"`
+	Payload  json.RawMessage        `json:"payload,omitempty"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+
+	ResolvedBy *int64 `json:"resolved_by,omitempty"`
+	Resolved   *int64 `json:"resolved,omitempty"`
+
+	CodeComment *CodeComment `json:"code_comment,omitempty"`
+}
+
+// CodeComment contains the location of a code comment.
+type CodeComment struct {
+	Outdated     bool   `json:"outdated"`
+	MergeBaseSHA string `json:"merge_base_sha"`
+	SourceSHA    string `json:"source_sha"`
+	Path         string `json:"path"`
+	LineNew      int    `json:"line_new"`
+	SpanNew      int    `json:"span_new"`
+	LineOld      int    `json:"line_old"`
+	SpanOld      int    `json:"span_old"`
+}
+
+// Rule is a protection rule of the repository.
+type Rule struct {
+	UID         string          `json:"uid"`
+	Description string          `json:"description"`
+	Type        string          `json:"type"`
+	State       string          `json:"state"`
+	CreatedBy   int64           `json:"created_by"`
+	Created     int64           `json:"created"`
+	Pattern     json.RawMessage `json:"pattern"`
+	Definition  json.RawMessage `json:"definition"`
+}
+
+// Webhook is a webhook of the repository.
+type Webhook struct {
+	UID         string `json:"uid"`
+	DisplayName string `json:"display_name"`
+	Description string `json:"description"`
+	URL         string `json:"url"`
+	CreatedBy   int64  `json:"created_by"`
+	Created     int64  `json:"created"`
+	Enabled     bool   `json:"enabled"`
+	Insecure    bool   `json:"insecure"`
+	// HasSecret is true if the webhook had a secret, which isn't part of the metadata.
+	HasSecret bool     `json:"has_secret"`
+	Triggers  []string `json:"triggers"`
+
+	PayloadTemplateType string `json:"payload_template_type,omitempty"`
+	PayloadTemplate     string `json:"payload_template,omitempty"`
+}
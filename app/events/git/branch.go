@@ -25,10 +25,11 @@ import (
 const BranchCreatedEvent events.EventType = "branch-created"
 
 type BranchCreatedPayload struct {
-	RepoID      int64  `json:"repo_id"`
-	PrincipalID int64  `json:"principal_id"`
-	Ref         string `json:"ref"`
-	SHA         string `json:"sha"`
+	RepoID      int64    `json:"repo_id"`
+	PrincipalID int64    `json:"principal_id"`
+	Ref         string   `json:"ref"`
+	SHA         string   `json:"sha"`
+	PushOptions []string `json:"push_options,omitempty"`
 }
 
 func (r *Reporter) BranchCreated(ctx context.Context, payload *BranchCreatedPayload) {
@@ -49,12 +50,13 @@ func (r *Reader) RegisterBranchCreated(fn events.HandlerFunc[*BranchCreatedPaylo
 const BranchUpdatedEvent events.EventType = "branch-updated"
 
 type BranchUpdatedPayload struct {
-	RepoID      int64  `json:"repo_id"`
-	PrincipalID int64  `json:"principal_id"`
-	Ref         string `json:"ref"`
-	OldSHA      string `json:"old_sha"`
-	NewSHA      string `json:"new_sha"`
-	Forced      bool   `json:"forced"`
+	RepoID      int64    `json:"repo_id"`
+	PrincipalID int64    `json:"principal_id"`
+	Ref         string   `json:"ref"`
+	OldSHA      string   `json:"old_sha"`
+	NewSHA      string   `json:"new_sha"`
+	Forced      bool     `json:"forced"`
+	PushOptions []string `json:"push_options,omitempty"`
 }
 
 func (r *Reporter) BranchUpdated(ctx context.Context, payload *BranchUpdatedPayload) {
@@ -75,10 +77,11 @@ func (r *Reader) RegisterBranchUpdated(fn events.HandlerFunc[*BranchUpdatedPaylo
 const BranchDeletedEvent events.EventType = "branch-deleted"
 
 type BranchDeletedPayload struct {
-	RepoID      int64  `json:"repo_id"`
-	PrincipalID int64  `json:"principal_id"`
-	Ref         string `json:"ref"`
-	SHA         string `json:"sha"`
+	RepoID      int64    `json:"repo_id"`
+	PrincipalID int64    `json:"principal_id"`
+	Ref         string   `json:"ref"`
+	SHA         string   `json:"sha"`
+	PushOptions []string `json:"push_options,omitempty"`
 }
 
 func (r *Reporter) BranchDeleted(ctx context.Context, payload *BranchDeletedPayload) {
@@ -25,10 +25,11 @@ import (
 const TagCreatedEvent events.EventType = "tag-created"
 
 type TagCreatedPayload struct {
-	RepoID      int64  `json:"repo_id"`
-	PrincipalID int64  `json:"principal_id"`
-	Ref         string `json:"ref"`
-	SHA         string `json:"sha"`
+	RepoID      int64    `json:"repo_id"`
+	PrincipalID int64    `json:"principal_id"`
+	Ref         string   `json:"ref"`
+	SHA         string   `json:"sha"`
+	PushOptions []string `json:"push_options,omitempty"`
 }
 
 func (r *Reporter) TagCreated(ctx context.Context, payload *TagCreatedPayload) {
@@ -49,12 +50,13 @@ func (r *Reader) RegisterTagCreated(fn events.HandlerFunc[*TagCreatedPayload],
 const TagUpdatedEvent events.EventType = "tag-updated"
 
 type TagUpdatedPayload struct {
-	RepoID      int64  `json:"repo_id"`
-	PrincipalID int64  `json:"principal_id"`
-	Ref         string `json:"ref"`
-	OldSHA      string `json:"old_sha"`
-	NewSHA      string `json:"new_sha"`
-	Forced      bool   `json:"forced"`
+	RepoID      int64    `json:"repo_id"`
+	PrincipalID int64    `json:"principal_id"`
+	Ref         string   `json:"ref"`
+	OldSHA      string   `json:"old_sha"`
+	NewSHA      string   `json:"new_sha"`
+	Forced      bool     `json:"forced"`
+	PushOptions []string `json:"push_options,omitempty"`
 }
 
 func (r *Reporter) TagUpdated(ctx context.Context, payload *TagUpdatedPayload) {
@@ -75,10 +77,11 @@ func (r *Reader) RegisterTagUpdated(fn events.HandlerFunc[*TagUpdatedPayload],
 const TagDeletedEvent events.EventType = "tag-deleted"
 
 type TagDeletedPayload struct {
-	RepoID      int64  `json:"repo_id"`
-	PrincipalID int64  `json:"principal_id"`
-	Ref         string `json:"ref"`
-	SHA         string `json:"sha"`
+	RepoID      int64    `json:"repo_id"`
+	PrincipalID int64    `json:"principal_id"`
+	Ref         string   `json:"ref"`
+	SHA         string   `json:"sha"`
+	PushOptions []string `json:"push_options,omitempty"`
 }
 
 func (r *Reporter) TagDeleted(ctx context.Context, payload *TagDeletedPayload) {
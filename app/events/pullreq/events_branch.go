@@ -51,3 +51,30 @@ func (r *Reader) RegisterBranchUpdated(fn events.HandlerFunc[*BranchUpdatedPaylo
 	opts ...events.HandlerOption) error {
 	return events.ReaderRegisterEvent(r.innerReader, BranchUpdatedEvent, fn, opts...)
 }
+
+const TargetBranchUpdatedEvent events.EventType = "target-branch-updated"
+
+type TargetBranchUpdatedPayload struct {
+	Base
+	OldTargetSHA string `json:"old_target_sha"`
+	NewTargetSHA string `json:"new_target_sha"`
+}
+
+func (r *Reporter) TargetBranchUpdated(ctx context.Context, payload *TargetBranchUpdatedPayload) {
+	if payload == nil {
+		return
+	}
+
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, TargetBranchUpdatedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send pull request target branch updated event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported pull request target branch updated event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterTargetBranchUpdated(fn events.HandlerFunc[*TargetBranchUpdatedPayload],
+	opts ...events.HandlerOption) error {
+	return events.ReaderRegisterEvent(r.innerReader, TargetBranchUpdatedEvent, fn, opts...)
+}
@@ -0,0 +1,51 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+
+	"github.com/harness/gitness/events"
+
+	"github.com/rs/zerolog/log"
+)
+
+const CheckRerunRequestedEvent events.EventType = "check-rerun-requested"
+
+type CheckRerunRequestedPayload struct {
+	RepoID      int64  `json:"repo_id"`
+	PrincipalID int64  `json:"principal_id"`
+	CheckID     int64  `json:"check_id"`
+	CheckUID    string `json:"check_uid"`
+	CommitSHA   string `json:"commit_sha"`
+}
+
+func (r *Reporter) CheckRerunRequested(ctx context.Context, payload *CheckRerunRequestedPayload) {
+	if payload == nil {
+		return
+	}
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, CheckRerunRequestedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send check rerun requested event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported check rerun requested event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterCheckRerunRequested(fn events.HandlerFunc[*CheckRerunRequestedPayload],
+	opts ...events.HandlerOption) error {
+	return events.ReaderRegisterEvent(r.innerReader, CheckRerunRequestedEvent, fn, opts...)
+}
@@ -0,0 +1,77 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+
+	"github.com/harness/gitness/events"
+
+	"github.com/rs/zerolog/log"
+)
+
+const CommitCommentCreatedEvent events.EventType = "commit-comment-created"
+
+type CommitCommentCreatedPayload struct {
+	RepoID      int64  `json:"repo_id"`
+	PrincipalID int64  `json:"principal_id"`
+	CommentID   int64  `json:"comment_id"`
+	CommitSHA   string `json:"commit_sha"`
+}
+
+func (r *Reporter) CommitCommentCreated(ctx context.Context, payload *CommitCommentCreatedPayload) {
+	if payload == nil {
+		return
+	}
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, CommitCommentCreatedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send commit comment created event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported commit comment created event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterCommitCommentCreated(fn events.HandlerFunc[*CommitCommentCreatedPayload],
+	opts ...events.HandlerOption) error {
+	return events.ReaderRegisterEvent(r.innerReader, CommitCommentCreatedEvent, fn, opts...)
+}
+
+const CommitCommentUpdatedEvent events.EventType = "commit-comment-updated"
+
+type CommitCommentUpdatedPayload struct {
+	RepoID      int64  `json:"repo_id"`
+	PrincipalID int64  `json:"principal_id"`
+	CommentID   int64  `json:"comment_id"`
+	CommitSHA   string `json:"commit_sha"`
+}
+
+func (r *Reporter) CommitCommentUpdated(ctx context.Context, payload *CommitCommentUpdatedPayload) {
+	if payload == nil {
+		return
+	}
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, CommitCommentUpdatedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send commit comment updated event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported commit comment updated event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterCommitCommentUpdated(fn events.HandlerFunc[*CommitCommentUpdatedPayload],
+	opts ...events.HandlerOption) error {
+	return events.ReaderRegisterEvent(r.innerReader, CommitCommentUpdatedEvent, fn, opts...)
+}
@@ -16,8 +16,12 @@ package githook
 
 import (
 	"github.com/harness/gitness/app/api/controller/githook"
+	"github.com/harness/gitness/app/api/controller/limiter"
 	"github.com/harness/gitness/app/auth/authz"
 	eventsgit "github.com/harness/gitness/app/events/git"
+	"github.com/harness/gitness/app/services/githookext"
+	"github.com/harness/gitness/app/services/passkey"
+	"github.com/harness/gitness/app/services/policyhook"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -49,6 +53,11 @@ func ProvideController(
 	pullreqStore store.PullReqStore,
 	urlProvider url.Provider,
 	protectionManager *protection.Manager,
+	resourceLimiter limiter.ResourceLimiter,
+	extensions *githookext.Manager,
+	policyHooks *policyhook.Service,
+	mirrorStore store.RepoMirrorStore,
+	passkeys *passkey.Service,
 	githookFactory hook.ClientFactory,
 ) *githook.Controller {
 	ctrl := githook.NewController(
@@ -59,7 +68,12 @@ func ProvideController(
 		git,
 		pullreqStore,
 		urlProvider,
-		protectionManager)
+		protectionManager,
+		resourceLimiter,
+		extensions,
+		policyHooks,
+		mirrorStore,
+		passkeys)
 
 	// TODO: improve wiring if possible
 	if fct, ok := githookFactory.(*ControllerClientFactory); ok {
@@ -34,8 +34,9 @@ type Claims struct {
 
 	PrincipalID int64 `json:"pid,omitempty"`
 
-	Token      *SubClaimsToken      `json:"tkn,omitempty"`
-	Membership *SubClaimsMembership `json:"ms,omitempty"`
+	Token        *SubClaimsToken        `json:"tkn,omitempty"`
+	Membership   *SubClaimsMembership   `json:"ms,omitempty"`
+	Confirmation *SubClaimsConfirmation `json:"cnf,omitempty"`
 }
 
 // SubClaimsToken contains information about the token the JWT was created for.
@@ -50,6 +51,14 @@ type SubClaimsMembership struct {
 	SpaceID int64               `json:"sid,omitempty"`
 }
 
+// SubClaimsConfirmation contains the operation the JWT confirms.
+// NOTE: A JWT with this sub-claim can't be used for authentication.
+type SubClaimsConfirmation struct {
+	Operation    string            `json:"op,omitempty"`
+	ResourceType enum.ResourceType `json:"rt,omitempty"`
+	ResourceID   int64             `json:"rid,omitempty"`
+}
+
 // GenerateForToken generates a jwt for a given token.
 func GenerateForToken(token *types.Token, secret string) (string, error) {
 	var expiresAt int64
@@ -111,3 +120,61 @@ func GenerateWithMembership(
 
 	return res, nil
 }
+
+// GenerateForConfirmation generates a jwt confirming an operation on a resource by the principal.
+func GenerateForConfirmation(
+	principalID int64,
+	confirmation SubClaimsConfirmation,
+	lifetime time.Duration,
+	secret string,
+) (string, time.Time, error) {
+	issuedAt := time.Now()
+	expiresAt := issuedAt.Add(lifetime)
+
+	jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{
+		StandardClaims: jwt.StandardClaims{
+			Issuer: issuer,
+			// times required to be in sec
+			IssuedAt:  issuedAt.Unix(),
+			ExpiresAt: expiresAt.Unix(),
+		},
+		PrincipalID:  principalID,
+		Confirmation: &confirmation,
+	})
+
+	res, err := jwtToken.SignedString([]byte(secret))
+	if err != nil {
+		return "", time.Time{}, errors.Wrap(err, "Failed to sign token")
+	}
+
+	return res, expiresAt, nil
+}
+
+// VerifyConfirmation verifies that the jwt confirms the operation on the resource by the principal.
+func VerifyConfirmation(
+	token string,
+	principalID int64,
+	confirmation SubClaimsConfirmation,
+	secret string,
+) error {
+	claims := &Claims{}
+	parsed, err := jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("invalid HMAC signature for JWT")
+		}
+		return []byte(secret), nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "parsing of JWT claims failed")
+	}
+
+	if !parsed.Valid {
+		return errors.New("parsed JWT token is invalid")
+	}
+
+	if claims.PrincipalID != principalID || claims.Confirmation == nil || *claims.Confirmation != confirmation {
+		return errors.New("JWT doesn't confirm the operation")
+	}
+
+	return nil
+}
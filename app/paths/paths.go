@@ -92,7 +92,7 @@ func IsAncesterOf(path string, other string) bool {
 	other = strings.Trim(other, types.PathSeparator)
 
 	// add "/" to both to handle space1/inner and space1/in
-	return strings.Contains(
+	return strings.HasPrefix(
 		other+types.PathSeparator,
 		path+types.PathSeparator,
 	)
@@ -0,0 +1,39 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package paths
+
+import "testing"
+
+func TestIsAncesterOf(t *testing.T) {
+	tests := []struct {
+		path  string
+		other string
+		want  bool
+	}{
+		{path: "space1", other: "space1", want: true},
+		{path: "space1", other: "space1/inner", want: true},
+		{path: "/space1/", other: "space1/inner/repo", want: true},
+		{path: "space1/inner", other: "space1", want: false},
+		{path: "space1/in", other: "space1/inner", want: false},
+		{path: "space1", other: "other/space1", want: false},
+		{path: "space1", other: "", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := IsAncesterOf(tt.path, tt.other); got != tt.want {
+			t.Errorf("IsAncesterOf(%q, %q): want=%t got=%t", tt.path, tt.other, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package artifactstep
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/ghodss/yaml"
+)
+
+const (
+	stepTypeArtifact = "artifact"
+	stepTypeRun      = "run"
+)
+
+// pathRegex restricts the artifact paths to characters that don't need quoting,
+// as the paths are left unquoted in the script to have their glob patterns expanded by the shell.
+var pathRegex = regexp.MustCompile(`^[a-zA-Z0-9_.*?\[\]@+,=/-]+$`)
+
+// Options configures how the artifact steps get converted.
+type Options struct {
+	// URL is the URL of the artifact API of the execution, reachable from the build containers.
+	URL string
+	// Image is the container image that runs the artifact steps, it requires sh and curl.
+	Image string
+}
+
+// Convert replaces the artifact steps in a v1 pipeline definition with run steps that upload
+// the files using the artifact API of the server. The run steps authenticate with
+// the netrc password, which is injected into all steps of an execution.
+// If the definition doesn't contain any artifact steps, the original data is returned.
+func Convert(data []byte, opts Options) ([]byte, error) {
+	if !bytes.Contains(data, []byte(stepTypeArtifact)) {
+		return data, nil
+	}
+
+	raw, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		// let the pipeline spec parser report the error
+		return data, nil //nolint:nilerr
+	}
+
+	doc := map[string]any{}
+	if err = json.Unmarshal(raw, &doc); err != nil {
+		return data, nil //nolint:nilerr
+	}
+
+	spec, _ := doc["spec"].(map[string]any)
+	stages, _ := spec["stages"].([]any)
+
+	converted := 0
+	for idx, s := range stages {
+		stage, _ := s.(map[string]any)
+		stageSpec, _ := stage["spec"].(map[string]any)
+		steps, _ := stageSpec["steps"].([]any)
+
+		n, err := convertSteps(steps, opts)
+		if err != nil {
+			return nil, fmt.Errorf("invalid artifact step in stage %d: %w", idx+1, err)
+		}
+
+		converted += n
+	}
+
+	if converted == 0 {
+		return data, nil
+	}
+
+	// JSON is valid YAML, so the output can be used in place of the original definition.
+	out, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal pipeline definition: %w", err)
+	}
+
+	return out, nil
+}
+
+// convertSteps converts the artifact steps in place, including the steps nested in group and parallel steps.
+func convertSteps(steps []any, opts Options) (int, error) {
+	converted := 0
+	for _, st := range steps {
+		s, _ := st.(map[string]any)
+		spec, _ := s["spec"].(map[string]any)
+
+		if nested, ok := spec["steps"].([]any); ok {
+			n, err := convertSteps(nested, opts)
+			if err != nil {
+				return 0, err
+			}
+			converted += n
+			continue
+		}
+
+		if s["type"] != stepTypeArtifact {
+			continue
+		}
+
+		paths, err := parsePaths(spec)
+		if err != nil {
+			return 0, err
+		}
+
+		s["type"] = stepTypeRun
+		s["spec"] = map[string]any{
+			"container": map[string]any{"image": opts.Image},
+			"script":    script(paths, opts.URL),
+		}
+
+		converted++
+	}
+
+	return converted, nil
+}
+
+func parsePaths(spec map[string]any) ([]string, error) {
+	list, ok := spec["paths"].([]any)
+	if !ok || len(list) == 0 {
+		return nil, fmt.Errorf("paths must be a non-empty list")
+	}
+
+	paths := make([]string, len(list))
+	for i, item := range list {
+		p, _ := item.(string)
+		if !pathRegex.MatchString(p) {
+			return nil, fmt.Errorf("path %q is empty or contains unsupported characters", p)
+		}
+
+		// the artifact name is the path relative to the workspace, which is shared by the steps of a stage.
+		if path.IsAbs(p) || path.Clean(p) != p || p == ".." || strings.HasPrefix(p, "../") {
+			return nil, fmt.Errorf("path %q must be a clean path relative to the workspace", p)
+		}
+
+		paths[i] = p
+	}
+
+	return paths, nil
+}
+
+// script returns the shell script of the run step that replaces the artifact step.
+// Every path has to match at least one file, otherwise the step fails.
+func script(paths []string, artifactsURL string) string {
+	return strings.Join([]string{
+		fmt.Sprintf("for f in %s; do", strings.Join(paths, " ")),
+		`  if [ ! -f "$f" ]; then echo "artifact not found: $f"; exit 1; fi`,
+		`  curl -fsS -o /dev/null -H "Authorization: Bearer $DRONE_NETRC_PASSWORD" ` +
+			fmt.Sprintf(`-T "$f" --url-query "name=$f" %s`, quote(artifactsURL)),
+		`  echo "artifact uploaded: $f"`,
+		`done`,
+	}, "\n")
+}
+
+// quote returns the string quoted for use in a posix shell.
+func quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
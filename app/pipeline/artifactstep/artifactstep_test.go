@@ -0,0 +1,145 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package artifactstep
+
+import (
+	"strings"
+	"testing"
+
+	v1yaml "github.com/drone/spec/dist/go"
+)
+
+func TestConvert(t *testing.T) {
+	data := []byte(`
+version: 1
+kind: pipeline
+spec:
+  stages:
+  - name: build
+    type: ci
+    spec:
+      steps:
+      - name: build
+        type: run
+        spec:
+          container: golang
+          script: go build -o dist/app
+      - name: group
+        type: group
+        spec:
+          steps:
+          - name: upload
+            type: artifact
+            spec:
+              paths: [dist/app, "reports/*.xml"]
+`)
+
+	out, err := Convert(data, Options{URL: "http://host/api/v1/repos/1/pipelines/p/executions/2/artifacts", Image: "curl"})
+	if err != nil {
+		t.Fatalf("failed to convert artifact steps: %s", err)
+	}
+
+	config, err := v1yaml.ParseBytes(out)
+	if err != nil {
+		t.Fatalf("failed to parse converted definition: %s", err)
+	}
+
+	pipeline, _ := config.Spec.(*v1yaml.Pipeline)
+	stage, _ := pipeline.Stages[0].Spec.(*v1yaml.StageCI)
+	if len(stage.Steps) != 2 {
+		t.Fatalf("want 2 steps, got %d", len(stage.Steps))
+	}
+
+	group, _ := stage.Steps[1].Spec.(*v1yaml.StepGroup)
+	upload, ok := group.Steps[0].Spec.(*v1yaml.StepRun)
+	if !ok {
+		t.Fatalf("artifact step isn't a run step: %T", group.Steps[0].Spec)
+	}
+	if upload.Container == nil || upload.Container.Image != "curl" {
+		t.Errorf("artifact step doesn't run in the artifact image: %+v", upload.Container)
+	}
+
+	script := strings.Join(upload.Script, "\n")
+	if !strings.Contains(script, "for f in dist/app reports/*.xml; do") {
+		t.Errorf("script doesn't iterate over the paths:\n%s", script)
+	}
+	if !strings.Contains(script, "'http://host/api/v1/repos/1/pipelines/p/executions/2/artifacts'") {
+		t.Errorf("script doesn't upload to the artifact API:\n%s", script)
+	}
+}
+
+func TestConvertNoArtifactSteps(t *testing.T) {
+	data := []byte(`
+version: 1
+kind: pipeline
+spec:
+  stages:
+  - name: build
+    type: ci
+    spec:
+      steps:
+      - name: test
+        type: run
+        spec:
+          container: alpine
+          script: echo artifact
+`)
+
+	out, err := Convert(data, Options{})
+	if err != nil {
+		t.Fatalf("failed to convert artifact steps: %s", err)
+	}
+
+	if string(out) != string(data) {
+		t.Errorf("definition without artifact steps got changed")
+	}
+}
+
+func TestConvertInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+	}{
+		{name: "no paths", spec: "{}"},
+		{name: "paths not a list", spec: "{paths: dist}"},
+		{name: "empty path", spec: `{paths: [""]}`},
+		{name: "absolute path", spec: "{paths: [/etc/passwd]}"},
+		{name: "path outside workspace", spec: "{paths: [../secret]}"},
+		{name: "unclean path", spec: "{paths: [./dist/app]}"},
+		{name: "shell characters", spec: `{paths: ["a;rm -rf b"]}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data := []byte(`
+version: 1
+kind: pipeline
+spec:
+  stages:
+  - name: build
+    type: ci
+    spec:
+      steps:
+      - name: upload
+        type: artifact
+        spec: ` + test.spec + `
+`)
+
+			if _, err := Convert(data, Options{}); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package badge renders the SVG status badges of pipelines.
+package badge
+
+import (
+	"bytes"
+	"html/template"
+	"unicode/utf8"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	// ContentType is the media type of the rendered badges.
+	ContentType = "image/svg+xml"
+
+	colorGreen  = "#4c1"
+	colorRed    = "#e05d44"
+	colorYellow = "#dfb317"
+	colorGrey   = "#9f9f9f"
+
+	// charWidth is the approximate width (in pixels) of a character in 11px Verdana,
+	// precise text measuring isn't worth the cost for the few words a badge contains.
+	charWidth = 7
+	padding   = 10
+)
+
+var tmpl = template.Must(template.New("badge").Parse(
+	`<svg xmlns="http://www.w3.org/2000/svg" width="{{.Width}}" height="20" role="img" aria-label="{{.Label}}: {{.Message}}">` +
+		`<title>{{.Label}}: {{.Message}}</title>` +
+		`<linearGradient id="s" x2="0" y2="100%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/>` +
+		`<stop offset="1" stop-opacity=".1"/></linearGradient>` +
+		`<clipPath id="r"><rect width="{{.Width}}" height="20" rx="3" fill="#fff"/></clipPath>` +
+		`<g clip-path="url(#r)">` +
+		`<rect width="{{.LabelWidth}}" height="20" fill="#555"/>` +
+		`<rect x="{{.LabelWidth}}" width="{{.MessageWidth}}" height="20" fill="{{.Color}}"/>` +
+		`<rect width="{{.Width}}" height="20" fill="url(#s)"/></g>` +
+		`<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">` +
+		`<text x="{{.LabelX}}" y="14">{{.Label}}</text>` +
+		`<text x="{{.MessageX}}" y="14">{{.Message}}</text></g></svg>`,
+))
+
+type data struct {
+	Label        string
+	Message      string
+	Color        string
+	Width        int
+	LabelWidth   int
+	MessageWidth int
+	LabelX       int
+	MessageX     int
+}
+
+// Render returns the SVG badge with the provided label that reflects the execution status.
+// An empty status renders the badge of a pipeline that has never been executed.
+func Render(label string, status enum.CIStatus) ([]byte, error) {
+	message, color := describe(status)
+
+	labelWidth := utf8.RuneCountInString(label)*charWidth + padding
+	messageWidth := utf8.RuneCountInString(message)*charWidth + padding
+
+	buf := &bytes.Buffer{}
+	err := tmpl.Execute(buf, data{
+		Label:        label,
+		Message:      message,
+		Color:        color,
+		Width:        labelWidth + messageWidth,
+		LabelWidth:   labelWidth,
+		MessageWidth: messageWidth,
+		LabelX:       labelWidth / 2,
+		MessageX:     labelWidth + messageWidth/2,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func describe(status enum.CIStatus) (string, string) {
+	switch status {
+	case enum.CIStatusSuccess:
+		return "passing", colorGreen
+	case enum.CIStatusFailure, enum.CIStatusError:
+		return "failing", colorRed
+	case enum.CIStatusKilled:
+		return "cancelled", colorGrey
+	case enum.CIStatusDeclined:
+		return "declined", colorGrey
+	case enum.CIStatusSkipped:
+		return "skipped", colorGrey
+	case enum.CIStatusRunning:
+		return "running", colorYellow
+	case enum.CIStatusPending, enum.CIStatusBlocked, enum.CIStatusWaitingOnDeps:
+		return "pending", colorYellow
+	default:
+		return "unknown", colorGrey
+	}
+}
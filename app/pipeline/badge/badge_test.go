@@ -0,0 +1,64 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package badge
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name    string
+		label   string
+		status  enum.CIStatus
+		message string
+		color   string
+	}{
+		{name: "success", label: "build", status: enum.CIStatusSuccess, message: "passing", color: colorGreen},
+		{name: "failure", label: "build", status: enum.CIStatusFailure, message: "failing", color: colorRed},
+		{name: "error", label: "build", status: enum.CIStatusError, message: "failing", color: colorRed},
+		{name: "running", label: "build", status: enum.CIStatusRunning, message: "running", color: colorYellow},
+		{name: "pending", label: "build", status: enum.CIStatusPending, message: "pending", color: colorYellow},
+		{name: "killed", label: "build", status: enum.CIStatusKilled, message: "cancelled", color: colorGrey},
+		{name: "none", label: "build", status: "", message: "unknown", color: colorGrey},
+		{name: "escaped", label: "<a&b>", status: enum.CIStatusSuccess, message: "passing", color: colorGreen},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			svg, err := Render(test.label, test.status)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			s := string(svg)
+			if !strings.HasPrefix(s, "<svg ") || !strings.HasSuffix(s, "</svg>") {
+				t.Errorf("not an svg document: %s", s)
+			}
+			if !strings.Contains(s, ">"+test.message+"</text>") {
+				t.Errorf("expected message %q in: %s", test.message, s)
+			}
+			if !strings.Contains(s, `fill="`+test.color+`"`) {
+				t.Errorf("expected color %q in: %s", test.color, s)
+			}
+			if strings.Contains(s, "<a&b>") {
+				t.Errorf("label isn't escaped: %s", s)
+			}
+		})
+	}
+}
@@ -0,0 +1,244 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachestep
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/ghodss/yaml"
+)
+
+const (
+	stepTypeCache = "cache"
+	stepTypeRun   = "run"
+
+	actionRestore = "restore"
+	actionSave    = "save"
+
+	archivePath = "/tmp/gitness-cache.tar"
+)
+
+// Options configures how the cache steps get converted.
+type Options struct {
+	// URL is the URL of the cache content API of the repository, reachable from the build containers.
+	URL string
+	// Image is the container image that runs the cache steps, it requires sh, tar and curl.
+	Image string
+}
+
+// step is a cache step declared in a v1 pipeline definition.
+type step struct {
+	Action      string
+	Key         string
+	RestoreKeys []string
+	Paths       []string
+}
+
+// Convert replaces the cache steps in a v1 pipeline definition with run steps that restore
+// or save the cache using the cache API of the server. The run steps authenticate with
+// the netrc password, which is injected into all steps of an execution.
+// If the definition doesn't contain any cache steps, the original data is returned.
+func Convert(data []byte, opts Options) ([]byte, error) {
+	if !bytes.Contains(data, []byte(stepTypeCache)) {
+		return data, nil
+	}
+
+	raw, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		// let the pipeline spec parser report the error
+		return data, nil //nolint:nilerr
+	}
+
+	doc := map[string]any{}
+	if err = json.Unmarshal(raw, &doc); err != nil {
+		return data, nil //nolint:nilerr
+	}
+
+	spec, _ := doc["spec"].(map[string]any)
+	stages, _ := spec["stages"].([]any)
+
+	converted := 0
+	for idx, s := range stages {
+		stage, _ := s.(map[string]any)
+		stageSpec, _ := stage["spec"].(map[string]any)
+		steps, _ := stageSpec["steps"].([]any)
+
+		n, err := convertSteps(steps, opts)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cache step in stage %d: %w", idx+1, err)
+		}
+
+		converted += n
+	}
+
+	if converted == 0 {
+		return data, nil
+	}
+
+	// JSON is valid YAML, so the output can be used in place of the original definition.
+	out, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal pipeline definition: %w", err)
+	}
+
+	return out, nil
+}
+
+// convertSteps converts the cache steps in place, including the steps nested in group and parallel steps.
+func convertSteps(steps []any, opts Options) (int, error) {
+	converted := 0
+	for _, st := range steps {
+		s, _ := st.(map[string]any)
+		spec, _ := s["spec"].(map[string]any)
+
+		if nested, ok := spec["steps"].([]any); ok {
+			n, err := convertSteps(nested, opts)
+			if err != nil {
+				return 0, err
+			}
+			converted += n
+			continue
+		}
+
+		if s["type"] != stepTypeCache {
+			continue
+		}
+
+		cache, err := parseStep(spec)
+		if err != nil {
+			return 0, err
+		}
+
+		s["type"] = stepTypeRun
+		s["spec"] = map[string]any{
+			"container": map[string]any{"image": opts.Image},
+			"script":    cache.script(opts.URL),
+		}
+
+		converted++
+	}
+
+	return converted, nil
+}
+
+func parseStep(spec map[string]any) (step, error) {
+	var s step
+
+	s.Action, _ = spec["action"].(string)
+	if s.Action != actionRestore && s.Action != actionSave {
+		return step{}, fmt.Errorf("action must be either %q or %q", actionRestore, actionSave)
+	}
+
+	s.Key, _ = spec["key"].(string)
+	if s.Key == "" {
+		return step{}, fmt.Errorf("key must be provided")
+	}
+
+	var err error
+
+	s.RestoreKeys, err = stringList(spec, "restore_keys")
+	if err != nil {
+		return step{}, err
+	}
+
+	s.Paths, err = stringList(spec, "paths")
+	if err != nil {
+		return step{}, err
+	}
+
+	if s.Action == actionSave && len(s.Paths) == 0 {
+		return step{}, fmt.Errorf("paths must be provided")
+	}
+
+	for _, p := range s.Paths {
+		// only the workspace is shared by the steps of a stage.
+		if path.IsAbs(p) || p == ".." || strings.HasPrefix(path.Clean(p), "../") {
+			return step{}, fmt.Errorf("path %q must be relative to the workspace", p)
+		}
+	}
+
+	return s, nil
+}
+
+func stringList(spec map[string]any, name string) ([]string, error) {
+	value, ok := spec[name]
+	if !ok {
+		return nil, nil
+	}
+
+	list, ok := value.([]any)
+	if !ok {
+		return nil, fmt.Errorf("%s must be a list", name)
+	}
+
+	result := make([]string, len(list))
+	for i, item := range list {
+		s, ok := item.(string)
+		if !ok || s == "" {
+			return nil, fmt.Errorf("%s must be non-empty strings", name)
+		}
+		result[i] = s
+	}
+
+	return result, nil
+}
+
+// script returns the shell script of the run step that replaces the cache step.
+// Failing to restore a cache doesn't fail the step, failing to save it does.
+func (s step) script(contentURL string) string {
+	query := url.Values{}
+	query.Set("key", s.Key)
+	auth := `-H "Authorization: Bearer $DRONE_NETRC_PASSWORD"`
+
+	if s.Action == actionSave {
+		paths := make([]string, len(s.Paths))
+		for i, p := range s.Paths {
+			paths[i] = quote(p)
+		}
+
+		return strings.Join([]string{
+			fmt.Sprintf("tar -cf %s %s", archivePath, strings.Join(paths, " ")),
+			fmt.Sprintf("curl -fsS -o /dev/null %s -T %s %s",
+				auth, archivePath, quote(contentURL+"?"+query.Encode())),
+			fmt.Sprintf("rm -f %s", archivePath),
+			fmt.Sprintf("echo %s", quote("cache saved: "+s.Key)),
+		}, "\n")
+	}
+
+	query["restore_key"] = s.RestoreKeys
+
+	return strings.Join([]string{
+		fmt.Sprintf("status=$(curl -sS -o %s -w '%%{http_code}' %s %s || true)",
+			archivePath, auth, quote(contentURL+"?"+query.Encode())),
+		`if [ "$status" = "200" ]; then`,
+		fmt.Sprintf("  tar -xf %s && echo 'cache restored'", archivePath),
+		`elif [ "$status" = "404" ]; then`,
+		fmt.Sprintf("  echo %s", quote("cache not found: "+s.Key)),
+		`else`,
+		`  echo "failed to restore cache: $status"`,
+		`fi`,
+		fmt.Sprintf("rm -f %s", archivePath),
+	}, "\n")
+}
+
+// quote returns the string quoted for use in a posix shell.
+func quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
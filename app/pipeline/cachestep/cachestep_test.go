@@ -0,0 +1,149 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachestep
+
+import (
+	"strings"
+	"testing"
+
+	v1yaml "github.com/drone/spec/dist/go"
+)
+
+func TestConvert(t *testing.T) {
+	data := []byte(`
+version: 1
+kind: pipeline
+spec:
+  stages:
+  - name: build
+    type: ci
+    spec:
+      steps:
+      - name: restore
+        type: cache
+        spec:
+          action: restore
+          key: npm-v2
+          restore_keys: [npm-]
+      - name: test
+        type: run
+        spec:
+          container: node
+          script: npm ci
+      - name: save
+        type: cache
+        spec:
+          action: save
+          key: npm-v2
+          paths: [node_modules, "it's"]
+`)
+
+	out, err := Convert(data, Options{URL: "http://host/api/v1/repos/1/pipeline-caches/content", Image: "curl"})
+	if err != nil {
+		t.Fatalf("failed to convert cache steps: %s", err)
+	}
+
+	config, err := v1yaml.ParseBytes(out)
+	if err != nil {
+		t.Fatalf("failed to parse converted definition: %s", err)
+	}
+
+	pipeline, _ := config.Spec.(*v1yaml.Pipeline)
+	stage, _ := pipeline.Stages[0].Spec.(*v1yaml.StageCI)
+	if len(stage.Steps) != 3 {
+		t.Fatalf("want 3 steps, got %d", len(stage.Steps))
+	}
+
+	restore, ok := stage.Steps[0].Spec.(*v1yaml.StepRun)
+	if !ok {
+		t.Fatalf("restore step isn't a run step: %T", stage.Steps[0].Spec)
+	}
+	if restore.Container == nil || restore.Container.Image != "curl" {
+		t.Errorf("restore step doesn't run in the cache image: %+v", restore.Container)
+	}
+	if script := strings.Join(restore.Script, "\n"); !strings.Contains(script, "?key=npm-v2&restore_key=npm-'") {
+		t.Errorf("restore script doesn't request the keys:\n%s", script)
+	}
+
+	save, _ := stage.Steps[2].Spec.(*v1yaml.StepRun)
+	if script := strings.Join(save.Script, "\n"); !strings.Contains(script,
+		`tar -cf /tmp/gitness-cache.tar 'node_modules' 'it'\''s'`) {
+		t.Errorf("save script doesn't archive the paths:\n%s", script)
+	}
+}
+
+func TestConvertNoCacheSteps(t *testing.T) {
+	data := []byte(`
+version: 1
+kind: pipeline
+spec:
+  stages:
+  - name: build
+    type: ci
+    spec:
+      steps:
+      - name: test
+        type: run
+        spec:
+          container: alpine
+          script: echo cache
+`)
+
+	out, err := Convert(data, Options{})
+	if err != nil {
+		t.Fatalf("failed to convert cache steps: %s", err)
+	}
+
+	if string(out) != string(data) {
+		t.Errorf("definition without cache steps got changed")
+	}
+}
+
+func TestConvertInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+	}{
+		{name: "no action", spec: "{key: k, paths: [a]}"},
+		{name: "unknown action", spec: "{action: drop, key: k}"},
+		{name: "no key", spec: "{action: save, paths: [a]}"},
+		{name: "save without paths", spec: "{action: save, key: k}"},
+		{name: "absolute path", spec: "{action: save, key: k, paths: [/root/.npm]}"},
+		{name: "path outside workspace", spec: "{action: save, key: k, paths: [a/../../b]}"},
+		{name: "restore keys not a list", spec: "{action: restore, key: k, restore_keys: k-}"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data := []byte(`
+version: 1
+kind: pipeline
+spec:
+  stages:
+  - name: build
+    type: ci
+    spec:
+      steps:
+      - name: cache
+        type: cache
+        spec: ` + test.spec + `
+`)
+
+			if _, err := Convert(data, Options{}); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,269 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachevolume
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ghodss/yaml"
+)
+
+const (
+	volumeTypeCache = "cache"
+	volumeTypeHost  = "host"
+
+	// DockerPath is the path the docker layer cache volumes get mounted at.
+	DockerPath = "/var/lib/docker"
+)
+
+var keyRegex = regexp.MustCompile(`^[a-zA-Z0-9._-]{1,100}$`)
+
+// Options configures how the cache volumes get converted.
+type Options struct {
+	// Dir is the directory on the runner machine that holds the cache volumes.
+	Dir string
+	// RepoID, PipelineID and Generation identify the cache volumes of a pipeline on the runner machine.
+	// Incrementing the generation invalidates all cache volumes of the pipeline.
+	RepoID     int64
+	PipelineID int64
+	Generation int64
+	// Privileged lists the plugin images that run docker, they get the docker layer cache volumes mounted.
+	Privileged []string
+}
+
+// volume is a cache volume declared in a stage of a v1 pipeline definition.
+type volume struct {
+	Name   string
+	Key    string
+	Docker bool
+}
+
+// Convert replaces the cache volumes in a v1 pipeline definition with host volumes
+// in a per pipeline directory on the runner machine, so the repeated builds executed
+// by the same runner reuse the content of the volumes. Cache volumes declared with
+// the docker hint get mounted into the steps that run docker, so they reuse the image layers.
+// If the definition doesn't contain any cache volumes, the original data is returned.
+func Convert(data []byte, opts Options) ([]byte, error) {
+	if !bytes.Contains(data, []byte(volumeTypeCache)) {
+		return data, nil
+	}
+
+	raw, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		// let the pipeline spec parser report the error
+		return data, nil //nolint:nilerr
+	}
+
+	doc := map[string]any{}
+	if err = json.Unmarshal(raw, &doc); err != nil {
+		return data, nil //nolint:nilerr
+	}
+
+	spec, _ := doc["spec"].(map[string]any)
+	stages, _ := spec["stages"].([]any)
+
+	converted := 0
+	for idx, s := range stages {
+		stage, _ := s.(map[string]any)
+		stageSpec, _ := stage["spec"].(map[string]any)
+
+		n, err := convertStage(stageSpec, opts)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cache volume in stage %d: %w", idx+1, err)
+		}
+
+		converted += n
+	}
+
+	if converted == 0 {
+		return data, nil
+	}
+
+	// JSON is valid YAML, so the output can be used in place of the original definition.
+	out, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal pipeline definition: %w", err)
+	}
+
+	return out, nil
+}
+
+// convertStage converts the cache volumes of a stage in place and mounts
+// the docker layer cache volumes into the steps that run docker.
+func convertStage(spec map[string]any, opts Options) (int, error) {
+	volumes, _ := spec["volumes"].([]any)
+
+	converted := 0
+	dockerVolume := ""
+	for _, v := range volumes {
+		vol, _ := v.(map[string]any)
+		if vol["type"] != volumeTypeCache {
+			continue
+		}
+
+		cache, err := parseVolume(vol)
+		if err != nil {
+			return 0, err
+		}
+
+		if cache.Docker {
+			if dockerVolume != "" {
+				return 0, fmt.Errorf("only one docker layer cache volume is allowed, found %q and %q",
+					dockerVolume, cache.Name)
+			}
+			dockerVolume = cache.Name
+		}
+
+		vol["type"] = volumeTypeHost
+		vol["spec"] = map[string]any{
+			"path": opts.path(cache.Key),
+		}
+
+		converted++
+	}
+
+	if dockerVolume != "" {
+		steps, _ := spec["steps"].([]any)
+		mountDocker(steps, dockerVolume, opts.Privileged)
+	}
+
+	return converted, nil
+}
+
+// mountDocker mounts the docker layer cache volume into the steps that run docker,
+// including the steps nested in group and parallel steps. These are the privileged
+// background steps (docker in docker) and the plugin steps using one of the privileged images.
+// Steps that already mount a volume at the docker path are left unchanged.
+func mountDocker(steps []any, name string, privileged []string) {
+	for _, st := range steps {
+		s, _ := st.(map[string]any)
+		spec, _ := s["spec"].(map[string]any)
+
+		if nested, ok := spec["steps"].([]any); ok {
+			mountDocker(nested, name, privileged)
+			continue
+		}
+
+		if !runsDocker(s["type"], spec, privileged) {
+			continue
+		}
+
+		mounts, _ := spec["mount"].([]any)
+		if hasMountPath(mounts, DockerPath) {
+			continue
+		}
+
+		spec["mount"] = append(mounts, map[string]any{
+			"name": name,
+			"path": DockerPath,
+		})
+	}
+}
+
+func runsDocker(stepType any, spec map[string]any, privileged []string) bool {
+	if spec == nil {
+		return false
+	}
+
+	switch stepType {
+	case "background":
+		p, _ := spec["privileged"].(bool)
+		return p
+	case "plugin":
+		if p, _ := spec["privileged"].(bool); p {
+			return true
+		}
+		image, _ := spec["image"].(string)
+		for _, img := range privileged {
+			if imageName(image) == imageName(img) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func hasMountPath(mounts []any, p string) bool {
+	for _, m := range mounts {
+		mount, _ := m.(map[string]any)
+		if mountPath, _ := mount["path"].(string); path.Clean(mountPath) == p {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseVolume(vol map[string]any) (volume, error) {
+	var v volume
+
+	v.Name, _ = vol["name"].(string)
+	if v.Name == "" {
+		return volume{}, fmt.Errorf("name must be provided")
+	}
+
+	spec, _ := vol["spec"].(map[string]any)
+
+	v.Key, _ = spec["key"].(string)
+	if v.Key == "" {
+		v.Key = v.Name
+	}
+
+	if !keyRegex.MatchString(v.Key) || v.Key == "." || v.Key == ".." {
+		return volume{}, fmt.Errorf("key %q of volume %q must consist of at most 100 letters, digits, "+
+			"dots, dashes and underscores", v.Key, v.Name)
+	}
+
+	if docker, ok := spec["docker"]; ok {
+		v.Docker, ok = docker.(bool)
+		if !ok {
+			return volume{}, fmt.Errorf("docker of volume %q must be a boolean", v.Name)
+		}
+	}
+
+	return v, nil
+}
+
+// path returns the path of the cache volume with the key on the runner machine.
+func (o Options) path(key string) string {
+	return path.Join(o.Dir,
+		strconv.FormatInt(o.RepoID, 10),
+		strconv.FormatInt(o.PipelineID, 10),
+		strconv.FormatInt(o.Generation, 10),
+		key)
+}
+
+// imageName returns the name of the image without the tag, digest and the default registry.
+func imageName(image string) string {
+	if i := strings.Index(image, "@"); i >= 0 {
+		image = image[:i]
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image = image[:i]
+	}
+
+	image = strings.TrimPrefix(image, "docker.io/")
+	image = strings.TrimPrefix(image, "index.docker.io/")
+	image = strings.TrimPrefix(image, "library/")
+
+	return image
+}
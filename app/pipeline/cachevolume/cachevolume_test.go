@@ -0,0 +1,187 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachevolume
+
+import (
+	"testing"
+
+	v1yaml "github.com/drone/spec/dist/go"
+)
+
+func TestConvert(t *testing.T) {
+	data := []byte(`
+version: 1
+kind: pipeline
+spec:
+  stages:
+  - name: build
+    type: ci
+    spec:
+      volumes:
+      - name: gomod
+        type: cache
+        spec:
+          key: go-modules
+      - name: layers
+        type: cache
+        spec:
+          docker: true
+      steps:
+      - name: test
+        type: run
+        spec:
+          container: golang
+          script: go test ./...
+          mount:
+          - name: gomod
+            path: /go/pkg/mod
+      - name: publish
+        type: plugin
+        spec:
+          image: plugins/docker:20
+          with:
+            repo: octocat/hello-world
+`)
+
+	out, err := Convert(data, Options{
+		Dir:        "/var/lib/gitness/cache-volumes",
+		RepoID:     1,
+		PipelineID: 2,
+		Generation: 3,
+		Privileged: []string{"plugins/docker"},
+	})
+	if err != nil {
+		t.Fatalf("failed to convert cache volumes: %s", err)
+	}
+
+	config, err := v1yaml.ParseBytes(out)
+	if err != nil {
+		t.Fatalf("failed to parse converted definition: %s", err)
+	}
+
+	pipeline, _ := config.Spec.(*v1yaml.Pipeline)
+	stage, _ := pipeline.Stages[0].Spec.(*v1yaml.StageCI)
+	if len(stage.Volumes) != 2 {
+		t.Fatalf("want 2 volumes, got %d", len(stage.Volumes))
+	}
+
+	for i, want := range []string{
+		"/var/lib/gitness/cache-volumes/1/2/3/go-modules",
+		"/var/lib/gitness/cache-volumes/1/2/3/layers",
+	} {
+		host, ok := stage.Volumes[i].Spec.(*v1yaml.VolumeHost)
+		if !ok {
+			t.Fatalf("volume %d isn't a host volume: %T", i, stage.Volumes[i].Spec)
+		}
+		if host.Path != want {
+			t.Errorf("want volume %d at %q, got %q", i, want, host.Path)
+		}
+	}
+
+	run, _ := stage.Steps[0].Spec.(*v1yaml.StepRun)
+	if len(run.Mount) != 1 || run.Mount[0].Name != "gomod" {
+		t.Errorf("run step mounts got changed: %+v", run.Mount)
+	}
+
+	plugin, _ := stage.Steps[1].Spec.(*v1yaml.StepPlugin)
+	if len(plugin.Mount) != 1 || plugin.Mount[0].Name != "layers" || plugin.Mount[0].Path != DockerPath {
+		t.Errorf("docker plugin step doesn't mount the layer cache: %+v", plugin.Mount)
+	}
+}
+
+func TestConvertNoCacheVolumes(t *testing.T) {
+	data := []byte(`
+version: 1
+kind: pipeline
+spec:
+  stages:
+  - name: build
+    type: ci
+    spec:
+      volumes:
+      - name: tmp
+        type: temp
+      steps:
+      - name: test
+        type: run
+        spec:
+          container: alpine
+          script: echo cache
+`)
+
+	out, err := Convert(data, Options{})
+	if err != nil {
+		t.Fatalf("failed to convert cache volumes: %s", err)
+	}
+
+	if string(out) != string(data) {
+		t.Errorf("definition without cache volumes got changed")
+	}
+}
+
+func TestConvertInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		volumes string
+	}{
+		{name: "no name", volumes: "[{type: cache}]"},
+		{name: "invalid key", volumes: "[{name: v, type: cache, spec: {key: a/b}}]"},
+		{name: "parent key", volumes: "[{name: v, type: cache, spec: {key: ..}}]"},
+		{name: "docker not a boolean", volumes: "[{name: v, type: cache, spec: {docker: yes please}}]"},
+		{name: "two docker volumes", volumes: "[{name: a, type: cache, spec: {docker: true}}, " +
+			"{name: b, type: cache, spec: {docker: true}}]"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data := []byte(`
+version: 1
+kind: pipeline
+spec:
+  stages:
+  - name: build
+    type: ci
+    spec:
+      volumes: ` + test.volumes + `
+      steps:
+      - name: test
+        type: run
+        spec:
+          container: alpine
+          script: echo cache
+`)
+
+			if _, err := Convert(data, Options{}); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestImageName(t *testing.T) {
+	tests := map[string]string{
+		"plugins/docker":                  "plugins/docker",
+		"plugins/docker:20":               "plugins/docker",
+		"docker.io/plugins/docker:latest": "plugins/docker",
+		"plugins/docker@sha256:abc":       "plugins/docker",
+		"registry:5000/plugins/docker":    "registry:5000/plugins/docker",
+	}
+
+	for image, want := range tests {
+		if got := imageName(image); got != want {
+			t.Errorf("want name %q of image %q, got %q", want, image, got)
+		}
+	}
+}
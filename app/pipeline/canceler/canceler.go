@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"time"
 
+	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/pipeline/scheduler"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
@@ -35,6 +36,7 @@ type service struct {
 	scheduler      scheduler.Scheduler
 	stageStore     store.StageStore
 	stepStore      store.StepStore
+	repoReporter   *repoevents.Reporter
 }
 
 // Canceler cancels a build.
@@ -52,6 +54,7 @@ func New(
 	scheduler scheduler.Scheduler,
 	stageStore store.StageStore,
 	stepStore store.StepStore,
+	repoReporter *repoevents.Reporter,
 ) Canceler {
 	return &service{
 		executionStore: executionStore,
@@ -60,6 +63,7 @@ func New(
 		scheduler:      scheduler,
 		stageStore:     stageStore,
 		stepStore:      stepStore,
+		repoReporter:   repoReporter,
 	}
 }
 
@@ -94,6 +98,14 @@ func (s *service) Cancel(ctx context.Context, repo *types.Repository, execution
 		return fmt.Errorf("could not update execution status to canceled: %w", err)
 	}
 
+	s.repoReporter.ExecutionCompleted(ctx, &repoevents.ExecutionCompletedPayload{
+		RepoID:      execution.RepoID,
+		PipelineID:  execution.PipelineID,
+		ExecutionID: execution.ID,
+		PrincipalID: execution.CreatedBy,
+		Status:      execution.Status,
+	})
+
 	stages, err := s.stageStore.ListWithSteps(ctx, execution.ID)
 	if err != nil {
 		return fmt.Errorf("could not list stages with steps: %w", err)
@@ -15,6 +15,7 @@
 package canceler
 
 import (
+	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/pipeline/scheduler"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
@@ -34,6 +35,7 @@ func ProvideCanceler(
 	repoStore store.RepoStore,
 	scheduler scheduler.Scheduler,
 	stageStore store.StageStore,
-	stepStore store.StepStore) Canceler {
-	return New(executionStore, sseStreamer, repoStore, scheduler, stageStore, stepStore)
+	stepStore store.StepStore,
+	repoReporter *repoevents.Reporter) Canceler {
+	return New(executionStore, sseStreamer, repoStore, scheduler, stageStore, stepStore, repoReporter)
 }
@@ -69,3 +69,89 @@ func Write(
 	}
 	return nil
 }
+
+// WriteStages writes the state of each of the execution stages to the check store.
+// Only executions triggered by a pull request report stage checks, to allow
+// branch protection rules to require individual pipeline stages.
+func WriteStages(
+	ctx context.Context,
+	checkStore store.CheckStore,
+	execution *types.Execution,
+	pipeline *types.Pipeline,
+	stages []*types.Stage,
+) error {
+	if execution.Event != enum.TriggerEventPullRequest {
+		return nil
+	}
+
+	for _, stage := range stages {
+		if err := writeStage(ctx, checkStore, execution, pipeline, stage); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeStage(
+	ctx context.Context,
+	checkStore store.CheckStore,
+	execution *types.Execution,
+	pipeline *types.Pipeline,
+	stage *types.Stage,
+) error {
+	payload := types.CheckPayloadInternal{
+		Number:      execution.Number,
+		RepoID:      execution.RepoID,
+		PipelineID:  execution.PipelineID,
+		StageNumber: stage.Number,
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("could not marshal stage check payload: %w", err)
+	}
+	now := time.Now().UnixMilli()
+	check := &types.Check{
+		RepoID:    execution.RepoID,
+		UID:       StageCheckUID(pipeline.UID, stage.Name),
+		Summary:   stage.Name,
+		Created:   now,
+		Updated:   now,
+		CreatedBy: execution.CreatedBy,
+		Status:    stage.Status.ConvertToCheckStatus(),
+		CommitSHA: execution.After,
+		Metadata:  []byte("{}"),
+		Payload: types.CheckPayload{
+			Version: "1",
+			Kind:    enum.CheckPayloadKindPipeline,
+			Data:    data,
+		},
+	}
+	err = checkStore.Upsert(ctx, check)
+	if err != nil {
+		return fmt.Errorf("could not upsert stage check to check store: %w", err)
+	}
+	return nil
+}
+
+// StageCheckUID returns the identifier of the status check reporting the given pipeline stage.
+// Characters not allowed in status check identifiers are replaced with a dash.
+func StageCheckUID(pipelineUID, stageName string) string {
+	uid := []rune(pipelineUID + "." + stageName)
+	for i, r := range uid {
+		if !isCheckUIDRune(r) {
+			uid[i] = '-'
+		}
+	}
+	if len(uid) > maxCheckUIDLength {
+		uid = uid[:maxCheckUIDLength]
+	}
+	return string(uid)
+}
+
+const maxCheckUIDLength = 127
+
+func isCheckUIDRune(r rune) bool {
+	return (r >= '0' && r <= '9') || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') ||
+		r == '-' || r == '_' || r == '.' || r == '$'
+}
@@ -16,8 +16,10 @@ package commit
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/types"
 )
@@ -73,3 +75,60 @@ func (f *service) FindCommit(
 	// convert the RPC commit output to a types.Commit.
 	return controller.MapCommit(&commitOutput.Commit)
 }
+
+// FindTag finds information about a tag in gitness for the tag name.
+func (f *service) FindTag(
+	ctx context.Context,
+	repo *types.Repository,
+	name string,
+) (*Tag, error) {
+	readParams := git.ReadParams{
+		RepoUID: repo.GitUID,
+	}
+	tagsOutput, err := f.git.ListCommitTags(ctx, &git.ListCommitTagsParams{
+		ReadParams: readParams,
+		Query:      name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	// the query matches tags containing the name, so look for the exact match.
+	for _, tag := range tagsOutput.Tags {
+		if tag.Name != name {
+			continue
+		}
+
+		return &Tag{
+			Name:        tag.Name,
+			SHA:         tag.SHA,
+			IsAnnotated: tag.IsAnnotated,
+			Title:       tag.Title,
+			Message:     tag.Message,
+		}, nil
+	}
+
+	return nil, errors.NotFound("tag %q not found", name)
+}
+
+// ListChangedFiles lists the files changed between the merge base of the two commits and the head commit.
+func (f *service) ListChangedFiles(
+	ctx context.Context,
+	repo *types.Repository,
+	baseSHA string,
+	headSHA string,
+) ([]string, error) {
+	diffOutput, err := f.git.DiffFileNames(ctx, &git.DiffParams{
+		ReadParams: git.ReadParams{
+			RepoUID: repo.GitUID,
+		},
+		BaseRef:   baseSHA,
+		HeadRef:   headSHA,
+		MergeBase: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get changed files: %w", err)
+	}
+
+	return diffOutput.Files, nil
+}
@@ -30,5 +30,21 @@ type (
 
 		// FindCommit returns information about a commit in a repo.
 		FindCommit(ctx context.Context, repo *types.Repository, sha string) (*types.Commit, error)
+
+		// FindTag returns information about a tag in a repo.
+		FindTag(ctx context.Context, repo *types.Repository, name string) (*Tag, error)
+
+		// ListChangedFiles returns the paths of the files changed between the merge base
+		// of the two commits and the head commit.
+		ListChangedFiles(ctx context.Context, repo *types.Repository, baseSHA, headSHA string) ([]string, error)
+	}
+
+	// Tag contains the information about a tag.
+	Tag struct {
+		Name        string
+		SHA         string
+		IsAnnotated bool
+		Title       string
+		Message     string
 	}
 )
@@ -0,0 +1,181 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package convert converts pipeline definitions of other CI systems to gitness pipelines
+// and reports the constructs that can't be converted.
+package convert
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/drone/go-convert/convert/github"
+	githubyaml "github.com/drone/go-convert/convert/github/yaml"
+	"github.com/drone/go-convert/convert/gitlab"
+	gitlabyaml "github.com/drone/go-convert/convert/gitlab/yaml"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+// Detect returns the format of the pipeline definition based on its path in the repository.
+func Detect(filePath string) (enum.PipelineFormat, bool) {
+	filePath = strings.TrimPrefix(path.Clean("/"+filePath), "/")
+	ext := path.Ext(filePath)
+
+	switch {
+	case path.Base(filePath) == ".gitlab-ci.yml":
+		return enum.PipelineFormatGitLabCI, true
+	case path.Dir(filePath) == ".github/workflows" && (ext == ".yml" || ext == ".yaml"):
+		return enum.PipelineFormatGitHubActions, true
+	default:
+		return "", false
+	}
+}
+
+// Convert converts the pipeline definition of the given format to a gitness pipeline.
+// It fails only if the definition can't be parsed, constructs that have no equivalent
+// are dropped and listed in the result.
+func Convert(format enum.PipelineFormat, data []byte) (*types.PipelineConversion, error) {
+	var (
+		converted   []byte
+		unsupported []types.PipelineConversionIssue
+		err         error
+	)
+
+	switch format {
+	case enum.PipelineFormatGitHubActions:
+		var workflow *githubyaml.Pipeline
+		if workflow, err = githubyaml.ParseBytes(data); err != nil {
+			return nil, fmt.Errorf("failed to parse workflow: %w", err)
+		}
+		unsupported = checkGitHub(workflow)
+		converted, err = github.New().ConvertBytes(data)
+	case enum.PipelineFormatGitLabCI:
+		var config *gitlabyaml.Pipeline
+		if config, err = gitlabyaml.ParseBytes(data); err != nil {
+			return nil, fmt.Errorf("failed to parse configuration: %w", err)
+		}
+		unsupported = checkGitLab(config)
+		converted, err = gitlab.New().ConvertBytes(data)
+	default:
+		return nil, fmt.Errorf("unsupported pipeline format %q", format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert pipeline: %w", err)
+	}
+
+	return &types.PipelineConversion{
+		Data:        string(converted),
+		Unsupported: unsupported,
+	}, nil
+}
+
+// report collects the unsupported constructs of a pipeline definition.
+type report []types.PipelineConversionIssue
+
+func (r *report) add(present bool, path, message string) {
+	if present {
+		*r = append(*r, types.PipelineConversionIssue{Path: path, Message: message})
+	}
+}
+
+func checkGitHub(workflow *githubyaml.Pipeline) []types.PipelineConversionIssue {
+	r := report{}
+
+	r.add(workflow.On != nil, "on", "Workflow triggers aren't converted, create pipeline triggers instead.")
+	r.add(workflow.Concurrency != nil, "concurrency", "Concurrency groups aren't converted.")
+	r.add(workflow.Defaults != nil, "defaults", "Default shell and working directory aren't converted.")
+	r.add(workflow.Permissions != nil, "permissions", "Token permissions aren't supported.")
+	r.add(workflow.RunName != "", "run-name", "Custom run names aren't supported.")
+
+	names := maps.Keys(workflow.Jobs)
+	slices.Sort(names)
+
+	for _, name := range names {
+		job := workflow.Jobs[name]
+		if job == nil {
+			continue
+		}
+
+		prefix := "jobs." + name + "."
+
+		r.add(job.Uses != "", prefix+"uses", "Reusable workflows aren't supported, the job is converted without steps.")
+		r.add(len(job.Needs) > 0, prefix+"needs", "Job dependencies aren't converted, stages run in sequence.")
+		r.add(len(job.Outputs) > 0, prefix+"outputs", "Job outputs aren't converted.")
+		r.add(job.Concurrency != nil, prefix+"concurrency", "Concurrency groups aren't converted.")
+		r.add(job.Defaults != nil, prefix+"defaults", "Default shell and working directory aren't converted.")
+		r.add(job.Environment != nil, prefix+"environment", "Deployment environments aren't supported.")
+		r.add(job.Permissions != nil, prefix+"permissions", "Token permissions aren't supported.")
+		r.add(job.Secrets != nil, prefix+"secrets", "Secrets passed to reusable workflows aren't supported.")
+		r.add(job.TimeoutMin != 0, prefix+"timeout-minutes", "Job timeouts aren't converted.")
+		r.add(job.Container != nil && job.Container.Credentials != nil, prefix+"container.credentials",
+			"Container registry credentials aren't converted, use a connector instead.")
+		r.add(job.Strategy != nil && (job.Strategy.MaxParallel != 0 || job.Strategy.FailFast),
+			prefix+"strategy", "Matrix fail-fast and max-parallel settings aren't converted.")
+
+		for i, step := range job.Steps {
+			if step == nil {
+				continue
+			}
+
+			stepPrefix := fmt.Sprintf("%ssteps[%d].", prefix, i)
+
+			r.add(step.If != "", stepPrefix+"if", "Step conditions aren't converted, the step always runs.")
+		}
+	}
+
+	return r
+}
+
+func checkGitLab(config *gitlabyaml.Pipeline) []types.PipelineConversionIssue {
+	r := report{}
+
+	r.add(len(config.Include) > 0, "include", "Included configuration files aren't converted.")
+	r.add(config.Workflow != nil, "workflow", "Workflow rules aren't converted, create pipeline triggers instead.")
+	r.add(config.Pages != nil, "pages", "GitLab Pages aren't supported.")
+
+	names := maps.Keys(config.Jobs)
+	slices.Sort(names)
+
+	for _, name := range names {
+		job := config.Jobs[name]
+		if job == nil {
+			continue
+		}
+
+		prefix := name + "."
+
+		r.add(len(job.Rules) > 0, prefix+"rules", "Job rules aren't converted, the job always runs.")
+		r.add(job.Only != nil, prefix+"only", "Job conditions aren't converted, the job always runs.")
+		r.add(job.Except != nil, prefix+"except", "Job conditions aren't converted, the job always runs.")
+		r.add(job.When != "" && job.When != "on_success", prefix+"when", "Job conditions aren't converted, the job always runs.")
+		r.add(job.Needs != nil, prefix+"needs", "Job dependencies aren't converted, jobs run in sequence.")
+		r.add(len(job.Dependencies) > 0, prefix+"dependencies", "Artifact dependencies aren't converted.")
+		r.add(job.Artifacts != nil, prefix+"artifacts", "Job artifacts aren't converted, use artifact steps instead.")
+		r.add(job.Environment != nil, prefix+"environment", "Deployment environments aren't supported.")
+		r.add(job.Release != nil, prefix+"release", "Releases aren't converted.")
+		r.add(job.Trigger != nil, prefix+"trigger", "Downstream pipelines aren't supported.")
+		r.add(job.ResourceGroup != "", prefix+"resource_group", "Resource groups aren't converted.")
+		r.add(job.Coverage != "", prefix+"coverage", "Coverage reports aren't supported.")
+		r.add(len(job.Hooks) > 0, prefix+"hooks", "Job hooks aren't supported.")
+		r.add(len(job.IDTokens) > 0, prefix+"id_tokens", "OIDC tokens aren't supported.")
+		r.add(job.Interruptible, prefix+"interruptible", "Interruptible jobs aren't supported.")
+	}
+
+	return r
+}
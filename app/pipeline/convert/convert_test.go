@@ -0,0 +1,125 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package convert
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		path     string
+		expect   enum.PipelineFormat
+		expectOK bool
+	}{
+		{path: ".gitlab-ci.yml", expect: enum.PipelineFormatGitLabCI, expectOK: true},
+		{path: "/.github/workflows/build.yml", expect: enum.PipelineFormatGitHubActions, expectOK: true},
+		{path: ".github/workflows/release.yaml", expect: enum.PipelineFormatGitHubActions, expectOK: true},
+		{path: ".github/workflows/nested/build.yml", expectOK: false},
+		{path: ".github/dependabot.yml", expectOK: false},
+		{path: ".harness/pipeline.yaml", expectOK: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			format, ok := Detect(test.path)
+			if ok != test.expectOK || format != test.expect {
+				t.Errorf("expected (%q, %t), got (%q, %t)", test.expect, test.expectOK, format, ok)
+			}
+		})
+	}
+}
+
+func TestConvertGitHubActions(t *testing.T) {
+	const workflow = `
+name: build
+on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    needs: lint
+    steps:
+      - uses: actions/checkout@v4
+      - run: go test ./...
+        if: github.event_name == 'push'
+  lint:
+    runs-on: ubuntu-latest
+    steps:
+      - run: golangci-lint run
+`
+
+	result, err := Convert(enum.PipelineFormatGitHubActions, []byte(workflow))
+	if err != nil {
+		t.Fatalf("failed to convert: %s", err)
+	}
+
+	if !strings.Contains(result.Data, "go test ./...") || !strings.Contains(result.Data, "golangci-lint run") {
+		t.Errorf("converted pipeline misses the steps:\n%s", result.Data)
+	}
+
+	expect := []types.PipelineConversionIssue{
+		{Path: "on", Message: "Workflow triggers aren't converted, create pipeline triggers instead."},
+		{Path: "jobs.test.needs", Message: "Job dependencies aren't converted, stages run in sequence."},
+		{Path: "jobs.test.steps[1].if", Message: "Step conditions aren't converted, the step always runs."},
+	}
+	if !reflect.DeepEqual(result.Unsupported, expect) {
+		t.Errorf("expected unsupported %v, got %v", expect, result.Unsupported)
+	}
+}
+
+func TestConvertGitLabCI(t *testing.T) {
+	const config = `
+stages:
+  - test
+test:
+  stage: test
+  image: golang:1.20
+  script:
+    - go test ./...
+  rules:
+    - if: $CI_COMMIT_BRANCH == "main"
+  artifacts:
+    paths:
+      - coverage.out
+`
+
+	result, err := Convert(enum.PipelineFormatGitLabCI, []byte(config))
+	if err != nil {
+		t.Fatalf("failed to convert: %s", err)
+	}
+
+	if !strings.Contains(result.Data, "go test ./...") {
+		t.Errorf("converted pipeline misses the script:\n%s", result.Data)
+	}
+
+	expect := []types.PipelineConversionIssue{
+		{Path: "test.rules", Message: "Job rules aren't converted, the job always runs."},
+		{Path: "test.artifacts", Message: "Job artifacts aren't converted, use artifact steps instead."},
+	}
+	if !reflect.DeepEqual(result.Unsupported, expect) {
+		t.Errorf("expected unsupported %v, got %v", expect, result.Unsupported)
+	}
+}
+
+func TestConvertInvalid(t *testing.T) {
+	if _, err := Convert(enum.PipelineFormatGitHubActions, []byte("jobs: [")); err == nil {
+		t.Error("expected an error for invalid yaml")
+	}
+}
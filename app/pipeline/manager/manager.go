@@ -23,9 +23,21 @@ import (
 	"time"
 
 	"github.com/harness/gitness/app/bootstrap"
+	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/jwt"
+	"github.com/harness/gitness/app/pipeline/artifactstep"
+	"github.com/harness/gitness/app/pipeline/cachestep"
+	"github.com/harness/gitness/app/pipeline/cachevolume"
 	"github.com/harness/gitness/app/pipeline/file"
+	"github.com/harness/gitness/app/pipeline/runner"
 	"github.com/harness/gitness/app/pipeline/scheduler"
+	"github.com/harness/gitness/app/pipeline/secrets"
+	"github.com/harness/gitness/app/pipeline/template"
+	"github.com/harness/gitness/app/pipeline/triggerer/condition"
+	"github.com/harness/gitness/app/pipeline/triggerer/gate"
+	"github.com/harness/gitness/app/pipeline/triggerer/matrix"
+	"github.com/harness/gitness/app/pipeline/triggerer/retry"
+	"github.com/harness/gitness/app/pipeline/variables"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	urlprovider "github.com/harness/gitness/app/url"
@@ -35,6 +47,7 @@ import (
 	"github.com/harness/gitness/types/enum"
 
 	"github.com/rs/zerolog/log"
+	"golang.org/x/exp/maps"
 )
 
 const (
@@ -60,6 +73,10 @@ type (
 		Variant string            `json:"variant"`
 		Kernel  string            `json:"kernel"`
 		Labels  map[string]string `json:"labels,omitempty"`
+
+		// RunnerLabels are the labels of the self-hosted runner requesting the stage,
+		// used to route stages that require runner labels with runs_on.
+		RunnerLabels []string `json:"runner_labels,omitempty"`
 	}
 
 	// Config represents a pipeline config file.
@@ -140,12 +157,19 @@ type Manager struct {
 	Repos     store.RepoStore
 	Scheduler scheduler.Scheduler
 	Secrets   store.SecretStore
+	Spaces    store.SpaceStore
 	// Status  store.StatusService
-	Stages store.StageStore
-	Steps  store.StepStore
+	Stages    store.StageStore
+	Steps     store.StepStore
+	Templates store.TemplateStore
+	Variables store.VariableStore
+	Runners   store.RunnerStore
+	// SecretResolver fetches the values of the secrets stored in secret managers.
+	SecretResolver *secrets.Resolver
 	// System  *store.System
 	Users store.PrincipalStore
 	// Webhook store.WebhookSender
+	RepoReporter *repoevents.Reporter
 }
 
 func New(
@@ -164,6 +188,12 @@ func New(
 	stageStore store.StageStore,
 	stepStore store.StepStore,
 	userStore store.PrincipalStore,
+	spaceStore store.SpaceStore,
+	templateStore store.TemplateStore,
+	variableStore store.VariableStore,
+	runnerStore store.RunnerStore,
+	secretResolver *secrets.Resolver,
+	repoReporter *repoevents.Reporter,
 ) *Manager {
 	return &Manager{
 		Config:      config,
@@ -181,6 +211,13 @@ func New(
 		Stages:      stageStore,
 		Steps:       stepStore,
 		Users:       userStore,
+		Spaces:      spaceStore,
+		Templates:   templateStore,
+		Variables:   variableStore,
+		Runners:     runnerStore,
+
+		SecretResolver: secretResolver,
+		RepoReporter:   repoReporter,
 	}
 }
 
@@ -204,6 +241,8 @@ func (m *Manager) Request(ctx context.Context, args *Request) (*types.Stage, err
 		Kernel:  args.Kernel,
 		Variant: args.Variant,
 		Labels:  args.Labels,
+
+		RunnerLabels: args.RunnerLabels,
 	})
 	if err != nil && ctx.Err() != nil {
 		log.Debug().Err(err).Msg("manager: context canceled")
@@ -318,6 +357,23 @@ func (m *Manager) Details(_ context.Context, stageID int64) (*ExecutionContext,
 		return nil, err
 	}
 
+	// Secrets stored in secret managers are fetched when the stage starts.
+	err = m.SecretResolver.Resolve(noContext, secrets)
+	if err != nil {
+		log.Warn().Err(err).Msg("manager: cannot fetch secrets from secret manager")
+		return nil, err
+	}
+
+	// Variables of the repository and its spaces are injected as environment variables.
+	// Parameters provided when the execution was triggered take precedence over them.
+	env, err := variables.Resolve(noContext, m.Spaces, m.Variables, m.SecretResolver, repo)
+	if err != nil {
+		log.Warn().Err(err).Msg("manager: cannot resolve variables")
+		return nil, err
+	}
+	maps.Copy(env, execution.Params)
+	execution.Params = env
+
 	// Fetch contents of YAML from the execution ref at the pipeline config path.
 	file, err := m.FileService.Get(noContext, repo, pipeline.ConfigPath, execution.After)
 	if err != nil {
@@ -325,6 +381,81 @@ func (m *Manager) Details(_ context.Context, stageID int64) (*ExecutionContext,
 		return nil, err
 	}
 
+	// Templates are resolved the same way as when the execution was triggered.
+	file.Data, err = template.Resolve(file.Data, template.SpaceFinder(noContext, m.Spaces, m.Templates, repo.ParentID))
+	if err != nil {
+		log.Warn().Err(err).Msg("manager: cannot resolve templates")
+		return nil, err
+	}
+
+	// Approval steps are handled by the server and aren't understood by the runners.
+	file.Data, _, err = gate.Extract(file.Data)
+	if err != nil {
+		log.Warn().Err(err).Msg("manager: cannot extract approval steps")
+		return nil, err
+	}
+
+	// Cache steps are executed as run steps that talk to the cache API of the server.
+	file.Data, err = cachestep.Convert(file.Data, cachestep.Options{
+		URL:   fmt.Sprintf("%s/v1/repos/%d/pipeline-caches/content", m.urlProvider.GetContainerAPIURL(), repo.ID),
+		Image: m.Config.CI.Cache.Image,
+	})
+	if err != nil {
+		log.Warn().Err(err).Msg("manager: cannot convert cache steps")
+		return nil, err
+	}
+
+	// Artifact steps are executed as run steps that upload the files to the artifact API of the server.
+	file.Data, err = artifactstep.Convert(file.Data, artifactstep.Options{
+		URL: fmt.Sprintf("%s/v1/repos/%d/pipelines/%s/executions/%d/artifacts",
+			m.urlProvider.GetContainerAPIURL(), repo.ID, pipeline.UID, execution.Number),
+		Image: m.Config.CI.Artifacts.Image,
+	})
+	if err != nil {
+		log.Warn().Err(err).Msg("manager: cannot convert artifact steps")
+		return nil, err
+	}
+
+	// Cache volumes are stored on the runner machine, in the directory reported by the runner if any.
+	file.Data, err = cachevolume.Convert(file.Data, cachevolume.Options{
+		Dir:        m.cacheVolumesDir(noContext, stage),
+		RepoID:     repo.ID,
+		PipelineID: pipeline.ID,
+		Generation: pipeline.CacheGeneration,
+		Privileged: runner.Privileged,
+	})
+	if err != nil {
+		log.Warn().Err(err).Msg("manager: cannot convert cache volumes")
+		return nil, err
+	}
+
+	// A matrix leg only gets to see its own stage, with the matrix values resolved.
+	if len(stage.Matrix) > 0 {
+		file.Data, err = matrix.ResolveConfig(file.Data, stage.Name)
+		if err != nil {
+			log.Warn().Err(err).Msg("manager: cannot resolve matrix leg")
+			return nil, err
+		}
+	}
+
+	// Step conditions are evaluated by the server, the runner only gets to see their results.
+	if len(stage.StepConditions) > 0 {
+		file.Data, err = condition.ResolveConfig(file.Data, stage.Name, stage.StepConditions)
+		if err != nil {
+			log.Warn().Err(err).Msg("manager: cannot resolve step conditions")
+			return nil, err
+		}
+	}
+
+	// A retried stage skips the steps before the step the retry starts from.
+	if stage.RetryFrom != "" {
+		file.Data, err = retry.ResolveConfig(file.Data, stage.Name, stage.RetryFrom)
+		if err != nil {
+			log.Warn().Err(err).Msg("manager: cannot resolve retried steps")
+			return nil, err
+		}
+	}
+
 	netrc, err := m.createNetrc(repo)
 	if err != nil {
 		log.Warn().Err(err).Msg("manager: failed to create netrc")
@@ -341,6 +472,28 @@ func (m *Manager) Details(_ context.Context, stageID int64) (*ExecutionContext,
 	}, nil
 }
 
+// cacheVolumesDir returns the directory of the cache volumes on the machine executing the stage.
+// Self-hosted runners can report their own directory, otherwise the configured directory is used.
+func (m *Manager) cacheVolumesDir(ctx context.Context, stage *types.Stage) string {
+	if stage.Machine == "" {
+		return m.Config.CI.Cache.VolumesDir
+	}
+
+	r, err := m.Runners.FindByUID(ctx, stage.Machine)
+	if err != nil {
+		if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+			log.Warn().Err(err).Msg("manager: cannot find runner of stage")
+		}
+		return m.Config.CI.Cache.VolumesDir
+	}
+
+	if r.CacheDir == "" {
+		return m.Config.CI.Cache.VolumesDir
+	}
+
+	return r.CacheDir
+}
+
 func (m *Manager) createNetrc(repo *types.Repository) (*Netrc, error) {
 	pipelinePrincipal := bootstrap.NewPipelineServiceSession().Principal
 	jwt, err := jwt.GenerateWithMembership(
@@ -431,6 +584,8 @@ func (m *Manager) BeforeStage(_ context.Context, stage *types.Stage) error {
 		Steps:       m.Steps,
 		Stages:      m.Stages,
 		Users:       m.Users,
+
+		RepoReporter: m.RepoReporter,
 	}
 
 	return s.do(noContext, stage)
@@ -448,6 +603,8 @@ func (m *Manager) AfterStage(_ context.Context, stage *types.Stage) error {
 		Scheduler:   m.Scheduler,
 		Steps:       m.Steps,
 		Stages:      m.Stages,
+
+		RepoReporter: m.RepoReporter,
 	}
 	return t.do(noContext, stage)
 }
@@ -19,6 +19,7 @@ import (
 	"errors"
 	"time"
 
+	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/pipeline/checks"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
@@ -38,6 +39,8 @@ type setup struct {
 	Steps       store.StepStore
 	Stages      store.StageStore
 	Users       store.PrincipalStore
+
+	RepoReporter *repoevents.Reporter
 }
 
 func (s *setup) do(ctx context.Context, stage *types.Stage) error {
@@ -87,11 +90,19 @@ func (s *setup) do(ctx context.Context, stage *types.Stage) error {
 		}
 	}
 
-	_, err = s.updateExecution(noContext, execution)
+	started, err := s.updateExecution(noContext, execution)
 	if err != nil {
 		log.Error().Err(err).Msg("manager: cannot update the execution")
 		return err
 	}
+	if started {
+		s.RepoReporter.ExecutionStarted(ctx, &repoevents.ExecutionStartedPayload{
+			RepoID:      execution.RepoID,
+			PipelineID:  execution.PipelineID,
+			ExecutionID: execution.ID,
+			PrincipalID: execution.CreatedBy,
+		})
+	}
 	pipeline, err := s.Pipelines.Find(ctx, execution.PipelineID)
 	if err != nil {
 		log.Error().Err(err).Msg("manager: cannot find pipeline")
@@ -107,6 +118,10 @@ func (s *setup) do(ctx context.Context, stage *types.Stage) error {
 		log.Error().Err(err).Msg("manager: could not list stages with steps")
 		return err
 	}
+	err = checks.WriteStages(ctx, s.Checks, execution, pipeline, stages)
+	if err != nil {
+		log.Error().Err(err).Msg("manager: could not write stages to checks store")
+	}
 	execution.Stages = stages
 	err = s.SSEStreamer.Publish(noContext, repo.ParentID, enum.SSETypeExecutionRunning, execution)
 	if err != nil {
@@ -20,8 +20,10 @@ import (
 	"strings"
 	"time"
 
+	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/pipeline/checks"
 	"github.com/harness/gitness/app/pipeline/scheduler"
+	"github.com/harness/gitness/app/pipeline/triggerer/gate"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/livelog"
@@ -43,6 +45,8 @@ type teardown struct {
 	Repos       store.RepoStore
 	Steps       store.StepStore
 	Stages      store.StageStore
+
+	RepoReporter *repoevents.Reporter
 }
 
 //nolint:gocognit // refactor if needed.
@@ -127,6 +131,17 @@ func (t *teardown) do(ctx context.Context, stage *types.Stage) error {
 		return err
 	}
 
+	pipeline, err := t.Pipelines.Find(ctx, execution.PipelineID)
+	if err != nil {
+		log.Error().Err(err).Msg("manager: cannot find pipeline")
+		return err
+	}
+	// try to write the stages to the checks store - if not, log an error and continue
+	err = checks.WriteStages(ctx, t.Checks, execution, pipeline, stages)
+	if err != nil {
+		log.Error().Err(err).Msg("manager: could not write stages to checks store")
+	}
+
 	if !isexecutionComplete(stages) {
 		log.Warn().Err(err).
 			Msg("manager: execution pending completion of additional stages")
@@ -135,6 +150,9 @@ func (t *teardown) do(ctx context.Context, stage *types.Stage) error {
 
 	log.Info().Msg("manager: execution is finished, teardown")
 
+	// the execution is already done if it was canceled, the completion has been reported then.
+	alreadyDone := execution.Status.IsDone()
+
 	execution.Status = enum.CIStatusSuccess
 	execution.Finished = time.Now().UnixMilli()
 	for _, sibling := range stages {
@@ -150,6 +168,10 @@ func (t *teardown) do(ctx context.Context, stage *types.Stage) error {
 			execution.Status = enum.CIStatusError
 			break
 		}
+		if sibling.Status == enum.CIStatusDeclined {
+			execution.Status = enum.CIStatusDeclined
+			break
+		}
 	}
 	if execution.Started == 0 {
 		execution.Started = execution.Finished
@@ -167,6 +189,16 @@ func (t *teardown) do(ctx context.Context, stage *types.Stage) error {
 		return err
 	}
 
+	if !alreadyDone {
+		t.RepoReporter.ExecutionCompleted(ctx, &repoevents.ExecutionCompletedPayload{
+			RepoID:      execution.RepoID,
+			PipelineID:  execution.PipelineID,
+			ExecutionID: execution.ID,
+			PrincipalID: execution.CreatedBy,
+			Status:      execution.Status,
+		})
+	}
+
 	execution.Stages = stages
 	err = t.SSEStreamer.Publish(noContext, repo.ParentID, enum.SSETypeExecutionCompleted, execution)
 	if err != nil {
@@ -174,11 +206,6 @@ func (t *teardown) do(ctx context.Context, stage *types.Stage) error {
 			Msg("manager: could not publish execution completed event")
 	}
 
-	pipeline, err := t.Pipelines.Find(ctx, execution.PipelineID)
-	if err != nil {
-		log.Error().Err(err).Msg("manager: cannot find pipeline")
-		return err
-	}
 	// try to write to the checks store - if not, log an error and continue
 	err = checks.Write(ctx, t.Checks, execution, pipeline)
 	if err != nil {
@@ -261,7 +288,6 @@ func isexecutionComplete(stages []*types.Stage) bool {
 		if stage.Status == enum.CIStatusPending ||
 			stage.Status == enum.CIStatusRunning ||
 			stage.Status == enum.CIStatusWaitingOnDeps ||
-			stage.Status == enum.CIStatusDeclined ||
 			stage.Status == enum.CIStatusBlocked {
 			return false
 		}
@@ -320,6 +346,28 @@ func (t *teardown) scheduleDownstream(
 			Str("stage.depends_on", strings.Join(sibling.DependsOn, ",")).
 			Logger()
 
+		// approval stages aren't executed by runners, they wait for a decision instead.
+		if sibling.Kind == gate.StageKind {
+			log.Debug().Msg("manager: block approval stage")
+
+			sibling.Status = enum.CIStatusBlocked
+			sibling.Started = time.Now().UnixMilli()
+			err := t.Stages.Update(noContext, sibling)
+			if errors.Is(err, gitness_store.ErrVersionConflict) {
+				rErr := t.resync(ctx, sibling)
+				if rErr != nil {
+					log.Warn().Err(rErr).Msg("failed to resync after version conflict")
+				}
+				continue
+			}
+			if err != nil {
+				log.Error().Err(err).
+					Msg("manager: cannot update stage status")
+				errs = multierror.Append(errs, err)
+			}
+			continue
+		}
+
 		log.Debug().Msg("manager: schedule next stage")
 
 		sibling.Status = enum.CIStatusPending
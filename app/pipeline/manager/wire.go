@@ -15,8 +15,10 @@
 package manager
 
 import (
+	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/pipeline/file"
 	"github.com/harness/gitness/app/pipeline/scheduler"
+	"github.com/harness/gitness/app/pipeline/secrets"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -49,9 +51,16 @@ func ProvideExecutionManager(
 	secretStore store.SecretStore,
 	stageStore store.StageStore,
 	stepStore store.StepStore,
-	userStore store.PrincipalStore) ExecutionManager {
+	userStore store.PrincipalStore,
+	spaceStore store.SpaceStore,
+	templateStore store.TemplateStore,
+	variableStore store.VariableStore,
+	runnerStore store.RunnerStore,
+	secretResolver *secrets.Resolver,
+	repoReporter *repoevents.Reporter) ExecutionManager {
 	return New(config, executionStore, pipelineStore, urlProvider, sseStreamer, fileService, logStore,
-		logStream, checkStore, repoStore, scheduler, secretStore, stageStore, stepStore, userStore)
+		logStream, checkStore, repoStore, scheduler, secretStore, stageStore, stepStore, userStore,
+		spaceStore, templateStore, variableStore, runnerStore, secretResolver, repoReporter)
 }
 
 // ProvideExecutionClient provides a client implementation to interact with the execution manager.
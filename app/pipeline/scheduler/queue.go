@@ -16,15 +16,19 @@ package scheduler
 
 import (
 	"context"
+	"fmt"
+	"sort"
 	"sync"
 	"time"
 
+	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/lock"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
 	"github.com/rs/zerolog/log"
+	"golang.org/x/exp/slices"
 )
 
 type queue struct {
@@ -37,10 +41,24 @@ type queue struct {
 	store    store.StageStore
 	workers  map[*worker]struct{}
 	ctx      context.Context
+
+	executionStore store.ExecutionStore
+	repoStore      store.RepoStore
+	// spaceLimit is the maximum number of executions running at a time in a root space, zero means no limit.
+	spaceLimit int
+	// priorities holds the priority of executions by trigger event.
+	priorities map[string]int
 }
 
 // newQueue returns a new Queue backed by the build datastore.
-func newQueue(store store.StageStore, lock lock.MutexManager) (*queue, error) {
+func newQueue(
+	store store.StageStore,
+	executionStore store.ExecutionStore,
+	repoStore store.RepoStore,
+	lock lock.MutexManager,
+	spaceLimit int,
+	priorities map[string]int,
+) (*queue, error) {
 	const lockKey = "build_queue"
 	mx, err := lock.NewMutex(lockKey)
 	if err != nil {
@@ -53,6 +71,11 @@ func newQueue(store store.StageStore, lock lock.MutexManager) (*queue, error) {
 		workers:  map[*worker]struct{}{},
 		interval: time.Minute,
 		ctx:      context.Background(),
+
+		executionStore: executionStore,
+		repoStore:      repoStore,
+		spaceLimit:     spaceLimit,
+		priorities:     priorities,
 	}
 	go func() {
 		if err := q.start(); err != nil {
@@ -87,6 +110,7 @@ func (q *queue) Request(ctx context.Context, params Filter) (*types.Stage, error
 		kernel:  params.Kernel,
 		variant: params.Variant,
 		labels:  params.Labels,
+		runsOn:  params.RunnerLabels,
 		channel: make(chan *types.Stage),
 		done:    ctx.Done(),
 	}
@@ -133,6 +157,17 @@ func (q *queue) signal(ctx context.Context) error {
 		return err
 	}
 
+	executions, err := q.executionStore.ListIncomplete(ctx)
+	if err != nil {
+		return err
+	}
+	progress := newExecutionProgress(items, executions, q.priorities)
+
+	// dispatch stages of executions with higher priority first, then in the order of creation.
+	sort.SliceStable(items, func(i, j int) bool {
+		return progress.precedes(items[i], items[j])
+	})
+
 	q.Lock()
 	defer q.Unlock()
 	for _, item := range items {
@@ -146,14 +181,29 @@ func (q *queue) signal(ctx context.Context) error {
 		// if the stage defines concurrency limits we
 		// need to make sure those limits are not exceeded
 		// before proceeding.
-		if !withinLimits(item, items) {
+		if !withinLimits(item, items, progress.precedes) {
 			continue
 		}
 
 		// if the system defines concurrency limits
 		// per repository we need to make sure those limits
 		// are not exceeded before proceeding.
-		if shouldThrottle(item, items, item.LimitRepo) {
+		if shouldThrottle(item, items, item.LimitRepo, progress.precedes) {
+			continue
+		}
+
+		// only one execution of a concurrency group runs at a time.
+		if !progress.withinConcurrencyGroup(item) {
+			continue
+		}
+
+		// if the system defines a limit of running executions
+		// per space we need to make sure it's not exceeded.
+		throttle, err := q.shouldThrottleSpace(ctx, item, progress)
+		if err != nil {
+			return err
+		}
+		if throttle {
 			continue
 		}
 
@@ -191,8 +241,14 @@ func (q *queue) signal(ctx context.Context) error {
 				}
 			}
 
+			// the worker must have all the runner labels the stage requires.
+			if !checkRunsOn(item.RunsOn, w.runsOn) {
+				continue
+			}
+
 			select {
 			case w.channel <- item:
+				progress.markRunning(item)
 			case <-w.done:
 			}
 
@@ -203,6 +259,42 @@ func (q *queue) signal(ctx context.Context) error {
 	return nil
 }
 
+// shouldThrottleSpace returns true if the execution of the stage isn't running yet
+// and the limit of running executions of its root space is reached.
+func (q *queue) shouldThrottleSpace(ctx context.Context, stage *types.Stage, progress *executionProgress) (bool, error) {
+	if q.spaceLimit == 0 {
+		return false, nil
+	}
+
+	if progress.isRunning(stage.ExecutionID) {
+		return false, nil
+	}
+
+	root, err := progress.rootSpace(ctx, q.repoStore, stage.RepoID)
+	if err != nil {
+		return false, err
+	}
+
+	count := 0
+	for executionID := range progress.running {
+		execution, ok := progress.executions[executionID]
+		if !ok {
+			continue
+		}
+
+		executionRoot, err := progress.rootSpace(ctx, q.repoStore, execution.RepoID)
+		if err != nil {
+			return false, err
+		}
+
+		if executionRoot == root {
+			count++
+		}
+	}
+
+	return count >= q.spaceLimit, nil
+}
+
 func (q *queue) start() error {
 	for {
 		select {
@@ -230,6 +322,7 @@ type worker struct {
 	kernel  string
 	variant string
 	labels  map[string]string
+	runsOn  []string
 	channel chan *types.Stage
 	done    <-chan struct{}
 }
@@ -246,7 +339,17 @@ func checkLabels(a, b map[string]string) bool {
 	return true
 }
 
-func withinLimits(stage *types.Stage, siblings []*types.Stage) bool {
+// checkRunsOn returns true if all the required labels are among the runner labels.
+func checkRunsOn(required, runnerLabels []string) bool {
+	for _, label := range required {
+		if !slices.Contains(runnerLabels, label) {
+			return false
+		}
+	}
+	return true
+}
+
+func withinLimits(stage *types.Stage, siblings []*types.Stage, precedes func(a, b *types.Stage) bool) bool {
 	if stage.Limit == 0 {
 		return true
 	}
@@ -261,7 +364,7 @@ func withinLimits(stage *types.Stage, siblings []*types.Stage) bool {
 		if sibling.Name != stage.Name {
 			continue
 		}
-		if sibling.ID < stage.ID ||
+		if precedes(sibling, stage) ||
 			sibling.Status == enum.CIStatusRunning {
 			count++
 		}
@@ -269,7 +372,12 @@ func withinLimits(stage *types.Stage, siblings []*types.Stage) bool {
 	return count < stage.Limit
 }
 
-func shouldThrottle(stage *types.Stage, siblings []*types.Stage, limit int) bool {
+func shouldThrottle(
+	stage *types.Stage,
+	siblings []*types.Stage,
+	limit int,
+	precedes func(a, b *types.Stage) bool,
+) bool {
 	// if no throttle limit is defined (default) then
 	// return false to indicate no throttling is needed.
 	if limit == 0 {
@@ -289,9 +397,10 @@ func shouldThrottle(stage *types.Stage, siblings []*types.Stage, limit int) bool
 		if sibling.RepoID != stage.RepoID {
 			continue
 		}
-		// ignore this stage and stages that were
-		// scheduled after this stage.
-		if sibling.ID >= stage.ID {
+		// ignore this stage and pending stages that are
+		// queued after this stage.
+		if sibling.ID == stage.ID ||
+			(sibling.Status != enum.CIStatusRunning && !precedes(sibling, stage)) {
 			continue
 		}
 		count++
@@ -301,6 +410,131 @@ func shouldThrottle(stage *types.Stage, siblings []*types.Stage, limit int) bool
 	return count >= limit
 }
 
+// executionProgress holds the executions in progress during a single pass of the queue.
+type executionProgress struct {
+	executions map[int64]*types.Execution
+	// running holds the executions that have a stage running or dispatched to a runner.
+	running map[int64]struct{}
+	// roots caches the root space path by repository ID.
+	roots map[int64]string
+	// priorities holds the priority of executions by trigger event.
+	priorities map[string]int
+}
+
+func newExecutionProgress(
+	stages []*types.Stage,
+	executions []*types.Execution,
+	priorities map[string]int,
+) *executionProgress {
+	p := &executionProgress{
+		executions: make(map[int64]*types.Execution, len(executions)),
+		running:    make(map[int64]struct{}),
+		roots:      make(map[int64]string),
+		priorities: priorities,
+	}
+
+	for _, execution := range executions {
+		p.executions[execution.ID] = execution
+		if execution.Status == enum.CIStatusRunning {
+			p.running[execution.ID] = struct{}{}
+		}
+	}
+
+	for _, stage := range stages {
+		if stage.Status == enum.CIStatusRunning || stage.Machine != "" {
+			p.running[stage.ExecutionID] = struct{}{}
+		}
+	}
+
+	return p
+}
+
+func (p *executionProgress) isRunning(executionID int64) bool {
+	_, ok := p.running[executionID]
+	return ok
+}
+
+// priority returns the priority of the execution, based on the event that triggered it.
+func (p *executionProgress) priority(executionID int64) int {
+	execution, ok := p.executions[executionID]
+	if !ok {
+		return 0
+	}
+
+	return p.priorities[string(execution.Event)]
+}
+
+// precedes returns true if the stage a is queued before the stage b:
+// its execution has higher priority, or the same priority and the stage was created earlier.
+func (p *executionProgress) precedes(a, b *types.Stage) bool {
+	if priorityA, priorityB := p.priority(a.ExecutionID), p.priority(b.ExecutionID); priorityA != priorityB {
+		return priorityA > priorityB
+	}
+
+	return a.ID < b.ID
+}
+
+// executionPrecedes returns true if the execution a is queued before the execution b.
+func (p *executionProgress) executionPrecedes(a, b *types.Execution) bool {
+	if priorityA, priorityB := p.priority(a.ID), p.priority(b.ID); priorityA != priorityB {
+		return priorityA > priorityB
+	}
+
+	return a.ID < b.ID
+}
+
+func (p *executionProgress) markRunning(stage *types.Stage) {
+	p.running[stage.ExecutionID] = struct{}{}
+}
+
+// withinConcurrencyGroup returns false if the execution of the stage belongs to a concurrency group
+// and another execution of the same group is running or is queued before it.
+func (p *executionProgress) withinConcurrencyGroup(stage *types.Stage) bool {
+	execution, ok := p.executions[stage.ExecutionID]
+	if !ok || execution.ConcurrencyGroup == "" {
+		return true
+	}
+
+	// once an execution of the group is running it's allowed to complete.
+	if p.isRunning(execution.ID) {
+		return true
+	}
+
+	for _, other := range p.executions {
+		if other.ID == execution.ID ||
+			other.RepoID != execution.RepoID ||
+			other.ConcurrencyGroup != execution.ConcurrencyGroup {
+			continue
+		}
+
+		if p.executionPrecedes(other, execution) || p.isRunning(other.ID) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (p *executionProgress) rootSpace(ctx context.Context, repoStore store.RepoStore, repoID int64) (string, error) {
+	if root, ok := p.roots[repoID]; ok {
+		return root, nil
+	}
+
+	repo, err := repoStore.Find(ctx, repoID)
+	if err != nil {
+		return "", fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	root, _, err := paths.DisectRoot(repo.Path)
+	if err != nil {
+		return "", fmt.Errorf("failed to get root space of repository: %w", err)
+	}
+
+	p.roots[repoID] = root
+
+	return root, nil
+}
+
 // matchResource is a helper function that returns.
 func matchResource(kinda, typea, kindb, typeb string) bool {
 	if kinda == "" {
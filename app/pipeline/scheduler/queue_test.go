@@ -0,0 +1,197 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestWithinConcurrencyGroup(t *testing.T) {
+	executions := []*types.Execution{
+		{ID: 1, RepoID: 1, Status: enum.CIStatusPending, ConcurrencyGroup: "deploy"},
+		{ID: 2, RepoID: 1, Status: enum.CIStatusPending, ConcurrencyGroup: "deploy"},
+		{ID: 3, RepoID: 1, Status: enum.CIStatusPending, ConcurrencyGroup: "test"},
+		{ID: 4, RepoID: 2, Status: enum.CIStatusPending, ConcurrencyGroup: "deploy"},
+		{ID: 5, RepoID: 1, Status: enum.CIStatusPending},
+	}
+
+	tests := []struct {
+		name      string
+		stages    []*types.Stage
+		stage     *types.Stage
+		expectRun bool
+	}{
+		{
+			name:      "oldest execution of the group",
+			stage:     &types.Stage{ExecutionID: 1},
+			expectRun: true,
+		},
+		{
+			name:      "newer execution of the group",
+			stage:     &types.Stage{ExecutionID: 2},
+			expectRun: false,
+		},
+		{
+			name:      "other group",
+			stage:     &types.Stage{ExecutionID: 3},
+			expectRun: true,
+		},
+		{
+			name:      "same group in other repository",
+			stage:     &types.Stage{ExecutionID: 4},
+			expectRun: true,
+		},
+		{
+			name:      "no group",
+			stage:     &types.Stage{ExecutionID: 5},
+			expectRun: true,
+		},
+		{
+			name: "newer execution already running",
+			stages: []*types.Stage{
+				{ExecutionID: 2, Status: enum.CIStatusRunning},
+			},
+			stage:     &types.Stage{ExecutionID: 2},
+			expectRun: true,
+		},
+		{
+			name: "older execution blocked by a running one",
+			stages: []*types.Stage{
+				{ExecutionID: 2, Machine: "runner"},
+			},
+			stage:     &types.Stage{ExecutionID: 1},
+			expectRun: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			progress := newExecutionProgress(test.stages, executions, nil)
+			if got := progress.withinConcurrencyGroup(test.stage); got != test.expectRun {
+				t.Errorf("expected %t, got %t", test.expectRun, got)
+			}
+		})
+	}
+}
+
+func TestCheckRunsOn(t *testing.T) {
+	tests := []struct {
+		name         string
+		required     []string
+		runnerLabels []string
+		expect       bool
+	}{
+		{
+			name:   "no labels required",
+			expect: true,
+		},
+		{
+			name:         "no labels required, labeled runner",
+			runnerLabels: []string{"linux"},
+			expect:       true,
+		},
+		{
+			name:         "runner has all labels",
+			required:     []string{"linux", "gpu"},
+			runnerLabels: []string{"gpu", "large", "linux"},
+			expect:       true,
+		},
+		{
+			name:         "runner misses a label",
+			required:     []string{"linux", "gpu"},
+			runnerLabels: []string{"linux"},
+			expect:       false,
+		},
+		{
+			name:     "runner without labels",
+			required: []string{"linux"},
+			expect:   false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := checkRunsOn(test.required, test.runnerLabels); got != test.expect {
+				t.Errorf("expected %t, got %t", test.expect, got)
+			}
+		})
+	}
+}
+
+func TestExecutionProgressPrecedes(t *testing.T) {
+	priorities := map[string]int{
+		enum.TriggerEventManual:      3,
+		enum.TriggerEventPullRequest: 2,
+	}
+	executions := []*types.Execution{
+		{ID: 1, Event: enum.TriggerEventCron},
+		{ID: 2, Event: enum.TriggerEventPullRequest},
+		{ID: 3, Event: enum.TriggerEventManual},
+		{ID: 4, Event: enum.TriggerEventCron},
+	}
+	progress := newExecutionProgress(nil, executions, priorities)
+
+	cron1 := &types.Stage{ID: 1, ExecutionID: 1}
+	pullReq := &types.Stage{ID: 2, ExecutionID: 2}
+	manual := &types.Stage{ID: 3, ExecutionID: 3}
+	cron2 := &types.Stage{ID: 4, ExecutionID: 4}
+
+	tests := []struct {
+		name   string
+		a, b   *types.Stage
+		expect bool
+	}{
+		{name: "manual before pull request", a: manual, b: pullReq, expect: true},
+		{name: "pull request before cron", a: pullReq, b: cron1, expect: true},
+		{name: "cron after manual", a: cron1, b: manual, expect: false},
+		{name: "older first within priority", a: cron1, b: cron2, expect: true},
+		{name: "newer after within priority", a: cron2, b: cron1, expect: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := progress.precedes(test.a, test.b); got != test.expect {
+				t.Errorf("expected %t, got %t", test.expect, got)
+			}
+		})
+	}
+}
+
+func TestShouldThrottleByPriority(t *testing.T) {
+	executions := []*types.Execution{
+		{ID: 1, Event: enum.TriggerEventCron},
+		{ID: 2, Event: enum.TriggerEventManual},
+	}
+	progress := newExecutionProgress(nil, executions, map[string]int{enum.TriggerEventManual: 1})
+
+	cron := &types.Stage{ID: 1, RepoID: 1, ExecutionID: 1, Status: enum.CIStatusPending}
+	manual := &types.Stage{ID: 2, RepoID: 1, ExecutionID: 2, Status: enum.CIStatusPending}
+	stages := []*types.Stage{cron, manual}
+
+	if shouldThrottle(manual, stages, 1, progress.precedes) {
+		t.Error("expected the manual stage not to be throttled by the pending cron stage")
+	}
+	if !shouldThrottle(cron, stages, 1, progress.precedes) {
+		t.Error("expected the cron stage to be throttled by the pending manual stage")
+	}
+
+	cron.Status = enum.CIStatusRunning
+	if !shouldThrottle(manual, stages, 1, progress.precedes) {
+		t.Error("expected the manual stage to be throttled by the running cron stage")
+	}
+}
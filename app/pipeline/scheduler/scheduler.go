@@ -32,6 +32,10 @@ type Filter struct {
 	Kernel  string
 	Variant string
 	Labels  map[string]string
+
+	// RunnerLabels are the labels of the runner, a stage is returned only if
+	// the runner has all the labels the stage requires with runs_on.
+	RunnerLabels []string
 }
 
 // Scheduler schedules Build stages for execution.
@@ -57,8 +61,15 @@ type scheduler struct {
 }
 
 // newScheduler provides an instance of a scheduler with cancel abilities.
-func newScheduler(stageStore store.StageStore, lock lock.MutexManager) (Scheduler, error) {
-	q, err := newQueue(stageStore, lock)
+func newScheduler(
+	stageStore store.StageStore,
+	executionStore store.ExecutionStore,
+	repoStore store.RepoStore,
+	lock lock.MutexManager,
+	spaceLimit int,
+	priorities map[string]int,
+) (Scheduler, error) {
+	q, err := newQueue(stageStore, executionStore, repoStore, lock, spaceLimit, priorities)
 	if err != nil {
 		return nil, err
 	}
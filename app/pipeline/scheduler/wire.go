@@ -17,6 +17,7 @@ package scheduler
 import (
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/lock"
+	"github.com/harness/gitness/types"
 
 	"github.com/google/wire"
 )
@@ -28,8 +29,12 @@ var WireSet = wire.NewSet(
 
 // ProvideScheduler provides a scheduler which can be used to schedule and request builds.
 func ProvideScheduler(
+	config *types.Config,
 	stageStore store.StageStore,
+	executionStore store.ExecutionStore,
+	repoStore store.RepoStore,
 	lock lock.MutexManager,
 ) (Scheduler, error) {
-	return newScheduler(stageStore, lock)
+	return newScheduler(stageStore, executionStore, repoStore, lock,
+		config.CI.ExecutionLimitPerSpace, config.CI.QueuePriorities)
 }
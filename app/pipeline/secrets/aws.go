@@ -0,0 +1,100 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+)
+
+// awsConfig is the configuration of an AWS Secrets Manager connector.
+type awsConfig struct {
+	Region string `json:"region"`
+	// AccessKeyID and SecretAccessKeySecret (the UID of the internal secret holding the secret access key)
+	// are the credentials of the connector. Without them, the default credentials of the server are used,
+	// if the server allows it.
+	AccessKeyID           string `json:"access_key_id,omitempty"`
+	SecretAccessKeySecret string `json:"secret_access_key_secret,omitempty"`
+}
+
+func parseAWSConfig(c Config, data []byte) (*awsConfig, error) {
+	config := &awsConfig{}
+	if err := parseConfig(data, config); err != nil {
+		return nil, err
+	}
+
+	if config.Region == "" {
+		return nil, errors.New("the aws region is required")
+	}
+
+	if (config.AccessKeyID == "") != (config.SecretAccessKeySecret == "") {
+		return nil, errors.New("the aws access key id and secret access key secret must be provided together")
+	}
+
+	if config.AccessKeyID == "" && !c.AllowServerCredentials {
+		return nil, errors.New("the aws access key id and secret access key secret are required")
+	}
+
+	return config, nil
+}
+
+type awsSecretsManager struct {
+	client *secretsmanager.SecretsManager
+}
+
+func newAWS(ctx context.Context, c Config, data []byte, creds Credentials) (Provider, error) {
+	config, err := parseAWSConfig(c, data)
+	if err != nil {
+		return nil, err
+	}
+
+	awsConfig := &aws.Config{Region: aws.String(config.Region)}
+	if config.AccessKeyID != "" {
+		secretAccessKey, err := creds(ctx, config.SecretAccessKeySecret)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find the aws secret access key: %w", err)
+		}
+		awsConfig.Credentials = credentials.NewStaticCredentials(config.AccessKeyID, secretAccessKey, "")
+	}
+
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create aws session: %w", err)
+	}
+
+	return &awsSecretsManager{client: secretsmanager.New(sess)}, nil
+}
+
+// Get returns the current value of the secret with the name or ARN of the reference.
+func (a *awsSecretsManager) Get(ctx context.Context, ref Reference) (string, error) {
+	out, err := a.client.GetSecretValueWithContext(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(ref.Path),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret %q from aws secrets manager: %w", ref.Path, err)
+	}
+
+	if out.SecretString == nil {
+		return ref.field(string(out.SecretBinary))
+	}
+
+	return ref.field(*out.SecretString)
+}
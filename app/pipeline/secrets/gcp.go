@@ -0,0 +1,107 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/api/option"
+	"google.golang.org/api/secretmanager/v1"
+)
+
+// gcpConfig is the configuration of a Google Cloud Secret Manager connector.
+type gcpConfig struct {
+	Project string `json:"project"`
+	// CredentialsSecret is the UID of the internal secret holding the service account key (JSON).
+	// Without it, the application default credentials of the server are used, if the server allows it.
+	CredentialsSecret string `json:"credentials_secret,omitempty"`
+}
+
+func parseGCPConfig(c Config, data []byte) (*gcpConfig, error) {
+	config := &gcpConfig{}
+	if err := parseConfig(data, config); err != nil {
+		return nil, err
+	}
+
+	if config.Project == "" {
+		return nil, errors.New("the gcp project is required")
+	}
+
+	if config.CredentialsSecret == "" && !c.AllowServerCredentials {
+		return nil, errors.New("the gcp credentials secret is required")
+	}
+
+	return config, nil
+}
+
+type gcpSecretManager struct {
+	project string
+	service *secretmanager.Service
+}
+
+func newGCP(ctx context.Context, c Config, data []byte, credentials Credentials) (Provider, error) {
+	config, err := parseGCPConfig(c, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []option.ClientOption
+	if config.CredentialsSecret != "" {
+		key, err := credentials(ctx, config.CredentialsSecret)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find the gcp credentials: %w", err)
+		}
+		opts = append(opts, option.WithCredentialsJSON([]byte(key)))
+	}
+
+	service, err := secretmanager.NewService(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gcp secret manager client: %w", err)
+	}
+
+	return &gcpSecretManager{project: config.Project, service: service}, nil
+}
+
+// Get returns the value of the secret with the name of the reference.
+// The name can select a version (name@version), the latest version is used otherwise.
+func (g *gcpSecretManager) Get(ctx context.Context, ref Reference) (string, error) {
+	name, version, _ := strings.Cut(ref.Path, "@")
+	if version == "" {
+		version = "latest"
+	}
+
+	resp, err := g.service.Projects.Secrets.Versions.
+		Access(fmt.Sprintf("projects/%s/secrets/%s/versions/%s", g.project, name, version)).
+		Context(ctx).
+		Do()
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret %q from gcp secret manager: %w", ref.Path, err)
+	}
+
+	if resp.Payload == nil {
+		return "", fmt.Errorf("secret %q has no payload", ref.Path)
+	}
+
+	value, err := base64.StdEncoding.DecodeString(resp.Payload.Data)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode secret %q: %w", ref.Path, err)
+	}
+
+	return ref.field(string(value))
+}
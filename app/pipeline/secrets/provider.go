@@ -0,0 +1,174 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package secrets resolves the values of pipeline secrets that are stored in external secret managers.
+// The secret managers are configured as connectors of a space; a secret that references such a connector
+// doesn't store a value, its value is fetched from the secret manager when an execution needs it.
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// TypeVault is the connector type of HashiCorp Vault (KV secrets engine).
+	TypeVault = "vault"
+	// TypeAWSSecretsManager is the connector type of AWS Secrets Manager.
+	TypeAWSSecretsManager = "aws_secrets_manager"
+	// TypeGCPSecretManager is the connector type of Google Cloud Secret Manager.
+	TypeGCPSecretManager = "gcp_secret_manager"
+)
+
+// Provider fetches secret values from a secret manager.
+type Provider interface {
+	// Get returns the value of the secret with the provided reference.
+	Get(ctx context.Context, ref Reference) (string, error)
+}
+
+// Config defines what secret managers are allowed to use and connect to.
+type Config struct {
+	// AllowServerCredentials allows connectors without credentials, which use the cloud credentials of the server.
+	AllowServerCredentials bool
+	// AllowLoopback and AllowPrivateNetwork allow secret managers on loopback and private network addresses.
+	AllowLoopback       bool
+	AllowPrivateNetwork bool
+}
+
+// Credentials returns the value of the internal secret with the provided UID.
+// Secret managers get their credentials from the internal secrets of the space of their connector.
+type Credentials func(ctx context.Context, uid string) (string, error)
+
+type factory struct {
+	validate func(config Config, data []byte) error
+	create   func(ctx context.Context, config Config, data []byte, credentials Credentials) (Provider, error)
+}
+
+var factories = map[string]factory{
+	TypeVault: {
+		validate: func(config Config, data []byte) error { _, err := parseVaultConfig(config, data); return err },
+		create:   newVault,
+	},
+	TypeAWSSecretsManager: {
+		validate: func(config Config, data []byte) error { _, err := parseAWSConfig(config, data); return err },
+		create:   newAWS,
+	},
+	TypeGCPSecretManager: {
+		validate: func(config Config, data []byte) error { _, err := parseGCPConfig(config, data); return err },
+		create:   newGCP,
+	},
+}
+
+// IsProviderType returns true if connectors of the type are secret managers.
+func IsProviderType(connectorType string) bool {
+	_, ok := factories[connectorType]
+	return ok
+}
+
+// ValidateConfig verifies the configuration (the data of the connector) of a secret manager.
+func ValidateConfig(config Config, connectorType string, data string) error {
+	f, ok := factories[connectorType]
+	if !ok {
+		return fmt.Errorf("unknown secret manager type %q", connectorType)
+	}
+
+	return f.validate(config, []byte(data))
+}
+
+// NewProvider creates the provider of the secret manager with the provided type and configuration.
+func NewProvider(
+	ctx context.Context,
+	config Config,
+	connectorType string,
+	data string,
+	credentials Credentials,
+) (Provider, error) {
+	f, ok := factories[connectorType]
+	if !ok {
+		return nil, fmt.Errorf("unknown secret manager type %q", connectorType)
+	}
+
+	return f.create(ctx, config, []byte(data), credentials)
+}
+
+// Reference identifies a secret in a secret manager. It's written as `path#key`,
+// where key optionally selects a field of a secret that holds a JSON object.
+type Reference struct {
+	Path string
+	Key  string
+}
+
+// ParseReference parses a reference of a secret in a secret manager.
+func ParseReference(s string) (Reference, error) {
+	path, key, _ := strings.Cut(strings.TrimSpace(s), "#")
+	if path == "" {
+		return Reference{}, errors.New("the reference must contain the path of the secret")
+	}
+
+	return Reference{Path: path, Key: key}, nil
+}
+
+func (r Reference) String() string {
+	if r.Key == "" {
+		return r.Path
+	}
+	return r.Path + "#" + r.Key
+}
+
+// field returns the field of the JSON object in the value selected by the key of the reference,
+// or the whole value if the reference has no key.
+func (r Reference) field(value string) (string, error) {
+	if r.Key == "" {
+		return value, nil
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(value), &fields); err != nil {
+		return "", fmt.Errorf("secret %q isn't a JSON object: %w", r.Path, err)
+	}
+
+	return lookup(fields, r)
+}
+
+// lookup returns the field selected by the key of the reference. Values that aren't strings are JSON encoded.
+func lookup(fields map[string]any, r Reference) (string, error) {
+	v, ok := fields[r.Key]
+	if !ok {
+		return "", fmt.Errorf("secret %q has no key %q", r.Path, r.Key)
+	}
+
+	if s, ok := v.(string); ok {
+		return s, nil
+	}
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode key %q of secret %q: %w", r.Key, r.Path, err)
+	}
+
+	return string(raw), nil
+}
+
+// parseConfig decodes the configuration of a secret manager, rejecting unknown fields.
+func parseConfig(data []byte, config any) error {
+	dec := json.NewDecoder(strings.NewReader(string(data)))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(config); err != nil {
+		return fmt.Errorf("malformed configuration: %w", err)
+	}
+	return nil
+}
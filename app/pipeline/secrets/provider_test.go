@@ -0,0 +1,92 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import "testing"
+
+func TestParseReference(t *testing.T) {
+	ref, err := ParseReference(" ci/deploy#token ")
+	if err != nil {
+		t.Fatalf("failed to parse reference: %s", err)
+	}
+	if ref.Path != "ci/deploy" || ref.Key != "token" || ref.String() != "ci/deploy#token" {
+		t.Errorf("unexpected reference %+v", ref)
+	}
+
+	if _, err = ParseReference("#token"); err == nil {
+		t.Errorf("expected an error for a reference without a path")
+	}
+}
+
+func TestReferenceField(t *testing.T) {
+	value := `{"user":"admin","port":5432}`
+
+	if got, _ := (Reference{Path: "db"}).field(value); got != value {
+		t.Errorf("expected the whole value without a key, got %q", got)
+	}
+	if got, _ := (Reference{Path: "db", Key: "user"}).field(value); got != "admin" {
+		t.Errorf("expected the user field, got %q", got)
+	}
+	if got, _ := (Reference{Path: "db", Key: "port"}).field(value); got != "5432" {
+		t.Errorf("expected the encoded port field, got %q", got)
+	}
+	if _, err := (Reference{Path: "db", Key: "password"}).field(value); err == nil {
+		t.Errorf("expected an error for a missing key")
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	serverCredentials := Config{AllowServerCredentials: true}
+	privateNetwork := Config{AllowLoopback: true, AllowPrivateNetwork: true}
+
+	tests := []struct {
+		config Config
+		typ    string
+		data   string
+		valid  bool
+	}{
+		{Config{}, TypeVault, `{"address":"https://vault:8200","token_secret":"vault-token"}`, true},
+		{Config{}, TypeVault, `{"address":"vault:8200","token_secret":"vault-token"}`, false},
+		{Config{}, TypeVault, `{"address":"https://vault:8200"}`, false},
+		{Config{}, TypeVault, `{"address":"https://vault:8200","token_secret":"t","kv_version":3}`, false},
+		{Config{}, TypeVault, `{"address":"https://vault:8200","token_secret":"t","token":"plain"}`, false},
+		{Config{}, TypeVault, `{"address":"https://127.0.0.1:8200","token_secret":"t"}`, false},
+		{Config{}, TypeVault, `{"address":"http://10.0.0.1:8200","token_secret":"t"}`, false},
+		{Config{}, TypeVault, `{"address":"http://169.254.169.254","token_secret":"t"}`, false},
+		{privateNetwork, TypeVault, `{"address":"http://10.0.0.1:8200","token_secret":"t"}`, true},
+		{Config{}, TypeVault, `{"address":"https://vault:8200","token_secret":"t","mount":"kv/../sys"}`, false},
+		{Config{}, TypeVault, `{"address":"https://vault:8200","token_secret":"t","mount":"kv/data"}`, true},
+		{Config{}, TypeAWSSecretsManager, `{"region":"us-east-1"}`, false},
+		{serverCredentials, TypeAWSSecretsManager, `{"region":"us-east-1"}`, true},
+		{Config{}, TypeAWSSecretsManager, `{"region":"us-east-1","access_key_id":"AKIA"}`, false},
+		{Config{}, TypeAWSSecretsManager,
+			`{"region":"us-east-1","access_key_id":"AKIA","secret_access_key_secret":"aws-key"}`, true},
+		{Config{}, TypeGCPSecretManager, `{"project":"ci","credentials_secret":"gcp-key"}`, true},
+		{Config{}, TypeGCPSecretManager, `{"project":"ci"}`, false},
+		{serverCredentials, TypeGCPSecretManager, `{"project":"ci"}`, true},
+		{Config{}, TypeGCPSecretManager, `{}`, false},
+		{Config{}, "github", `{}`, false},
+	}
+
+	for _, test := range tests {
+		err := ValidateConfig(test.config, test.typ, test.data)
+		if test.valid && err != nil {
+			t.Errorf("expected %s config %s to be valid, got %s", test.typ, test.data, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("expected %s config %s to be invalid", test.typ, test.data)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/types"
+)
+
+// Resolver fetches the values of secrets stored in secret managers.
+type Resolver struct {
+	config         Config
+	connectorStore store.ConnectorStore
+	secretStore    store.SecretStore
+	encrypter      encrypt.Encrypter
+}
+
+func NewResolver(
+	config Config,
+	connectorStore store.ConnectorStore,
+	secretStore store.SecretStore,
+	encrypter encrypt.Encrypter,
+) *Resolver {
+	return &Resolver{
+		config:         config,
+		connectorStore: connectorStore,
+		secretStore:    secretStore,
+		encrypter:      encrypter,
+	}
+}
+
+// Resolve sets the values of the secrets stored in secret managers. Other secrets are left untouched.
+// Each secret manager is connected to once per call.
+func (r *Resolver) Resolve(ctx context.Context, secrets []*types.Secret) error {
+	providers := map[string]Provider{}
+	for _, secret := range secrets {
+		if !secret.IsExternal() {
+			continue
+		}
+
+		key := fmt.Sprintf("%d/%s", secret.SpaceID, secret.Connector)
+		provider, ok := providers[key]
+		if !ok {
+			var err error
+			provider, err = r.provider(ctx, secret.SpaceID, secret.Connector)
+			if err != nil {
+				return fmt.Errorf("failed to connect to secret manager of secret %q: %w", secret.UID, err)
+			}
+			providers[key] = provider
+		}
+
+		ref, err := ParseReference(secret.Reference)
+		if err != nil {
+			return fmt.Errorf("invalid reference of secret %q: %w", secret.UID, err)
+		}
+
+		secret.Data, err = provider.Get(ctx, ref)
+		if err != nil {
+			return fmt.Errorf("failed to fetch secret %q: %w", secret.UID, err)
+		}
+	}
+
+	return nil
+}
+
+// FindByUID returns the secret with the UID in the space, with its value fetched if it's stored in a secret manager.
+func (r *Resolver) FindByUID(ctx context.Context, spaceID int64, uid string) (*types.Secret, error) {
+	secret, err := r.secretStore.FindByUID(ctx, spaceID, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = r.Resolve(ctx, []*types.Secret{secret}); err != nil {
+		return nil, err
+	}
+
+	return secret, nil
+}
+
+func (r *Resolver) provider(ctx context.Context, spaceID int64, connectorUID string) (Provider, error) {
+	connector, err := r.connectorStore.FindByUID(ctx, spaceID, connectorUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find connector %q: %w", connectorUID, err)
+	}
+
+	credentials := func(ctx context.Context, uid string) (string, error) {
+		secret, err := r.secretStore.FindByUID(ctx, spaceID, uid)
+		if err != nil {
+			return "", fmt.Errorf("failed to find secret %q: %w", uid, err)
+		}
+		if secret.IsExternal() {
+			return "", fmt.Errorf("the credentials of a secret manager can't be stored in a secret manager")
+		}
+
+		return r.encrypter.Decrypt([]byte(secret.Data))
+	}
+
+	return NewProvider(ctx, r.config, connector.Type, connector.Data, credentials)
+}
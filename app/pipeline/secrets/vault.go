@@ -0,0 +1,191 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/services/webhook"
+)
+
+const (
+	vaultDefaultMount = "secret"
+	vaultDefaultKey   = "value"
+	vaultTimeout      = 30 * time.Second
+)
+
+// vaultConfig is the configuration of a HashiCorp Vault connector.
+type vaultConfig struct {
+	// Address is the URL of the Vault server, e.g. https://vault.example.com:8200.
+	Address string `json:"address"`
+	// Namespace is the Vault Enterprise namespace of the secrets.
+	Namespace string `json:"namespace,omitempty"`
+	// Mount is the path the KV secrets engine is mounted at, secret by default.
+	Mount string `json:"mount,omitempty"`
+	// KVVersion is the version of the KV secrets engine, 2 by default.
+	KVVersion int `json:"kv_version,omitempty"`
+	// TokenSecret is the UID of the internal secret holding the Vault token.
+	TokenSecret string `json:"token_secret"`
+}
+
+func parseVaultConfig(c Config, data []byte) (*vaultConfig, error) {
+	config := &vaultConfig{}
+	if err := parseConfig(data, config); err != nil {
+		return nil, err
+	}
+
+	u, err := url.Parse(config.Address)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, errors.New("the vault address must be an http or https URL")
+	}
+	if err = webhook.CheckURL(config.Address, c.AllowLoopback, c.AllowPrivateNetwork); err != nil {
+		return nil, fmt.Errorf("the vault address isn't allowed: %w", err)
+	}
+	config.Address = strings.TrimSuffix(config.Address, "/")
+
+	config.Mount = strings.Trim(config.Mount, "/")
+	if config.Mount == "" {
+		config.Mount = vaultDefaultMount
+	}
+	if err = checkVaultPath(config.Mount); err != nil {
+		return nil, fmt.Errorf("invalid vault mount: %w", err)
+	}
+
+	if config.KVVersion == 0 {
+		config.KVVersion = 2
+	}
+	if config.KVVersion != 1 && config.KVVersion != 2 {
+		return nil, errors.New("the vault KV version must be 1 or 2")
+	}
+
+	if config.TokenSecret == "" {
+		return nil, errors.New("the vault token secret is required")
+	}
+
+	return config, nil
+}
+
+type vault struct {
+	config *vaultConfig
+	token  string
+	client *http.Client
+}
+
+func newVault(ctx context.Context, c Config, data []byte, credentials Credentials) (Provider, error) {
+	config, err := parseVaultConfig(c, data)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := credentials(ctx, config.TokenSecret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the vault token: %w", err)
+	}
+
+	return &vault{
+		config: config,
+		token:  token,
+		client: webhook.NewHTTPClient(c.AllowLoopback, c.AllowPrivateNetwork, false),
+	}, nil
+}
+
+// Get reads the secret from the KV secrets engine. Vault secrets are key-value pairs,
+// references without a key read the key "value".
+func (v *vault) Get(ctx context.Context, ref Reference) (string, error) {
+	path := strings.Trim(ref.Path, "/")
+	if err := checkVaultPath(path); err != nil {
+		return "", fmt.Errorf("invalid path of secret %q: %w", ref.Path, err)
+	}
+	if v.config.KVVersion == 2 {
+		path = "data/" + path
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, vaultTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		fmt.Sprintf("%s/v1/%s/%s", v.config.Address, v.config.Mount, path), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create vault request: %w", err)
+	}
+
+	req.Header.Set("X-Vault-Token", v.token)
+	if v.config.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", v.config.Namespace)
+	}
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret %q from vault: %w", ref.Path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return "", fmt.Errorf("failed to read secret %q from vault: unexpected status %d", ref.Path, resp.StatusCode)
+	}
+
+	var body struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("failed to decode vault response: %w", err)
+	}
+
+	data := body.Data
+	if v.config.KVVersion == 2 {
+		var versioned struct {
+			Data json.RawMessage `json:"data"`
+		}
+		if err = json.Unmarshal(body.Data, &versioned); err != nil {
+			return "", fmt.Errorf("failed to decode vault response: %w", err)
+		}
+		data = versioned.Data
+	}
+
+	var fields map[string]any
+	if err = json.Unmarshal(data, &fields); err != nil || fields == nil {
+		return "", fmt.Errorf("secret %q not found in vault", ref.Path)
+	}
+
+	if ref.Key == "" {
+		ref.Key = vaultDefaultKey
+	}
+
+	return lookup(fields, ref)
+}
+
+// checkVaultPath verifies that the path stays below the mount, i.e. it has no empty, "." or ".." segments.
+// The path is inserted into the request URL, so these segments would reach other Vault APIs.
+func checkVaultPath(path string) error {
+	for _, segment := range strings.Split(path, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return errors.New("the path must not contain empty, '.' or '..' segments")
+		}
+		if strings.ContainsAny(segment, "?#%\\") {
+			return errors.New("the path must not contain '?', '#', '%' or '\\'")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestVault(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "s.token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if r.URL.Path != "/v1/kv/data/ci/deploy" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"data": map[string]any{
+				"data": map[string]any{"value": "v", "token": "t"},
+			},
+		})
+	}))
+	defer server.Close()
+
+	config := `{"address":"` + server.URL + `","mount":"kv","token_secret":"vault-token"}`
+	credentials := func(_ context.Context, uid string) (string, error) {
+		if uid != "vault-token" {
+			return "", errors.New("not found")
+		}
+		return "s.token", nil
+	}
+
+	provider, err := NewProvider(context.Background(), Config{AllowLoopback: true}, TypeVault, config, credentials)
+	if err != nil {
+		t.Fatalf("failed to create provider: %s", err)
+	}
+
+	if got, err := provider.Get(context.Background(), Reference{Path: "ci/deploy"}); err != nil || got != "v" {
+		t.Errorf("expected the value key, got %q (%v)", got, err)
+	}
+	if got, err := provider.Get(context.Background(), Reference{Path: "ci/deploy", Key: "token"}); err != nil || got != "t" {
+		t.Errorf("expected the token key, got %q (%v)", got, err)
+	}
+	if _, err := provider.Get(context.Background(), Reference{Path: "ci/unknown"}); err == nil {
+		t.Errorf("expected an error for a missing secret")
+	}
+	if _, err := provider.Get(context.Background(), Reference{Path: "../../sys/mounts"}); err == nil {
+		t.Errorf("expected an error for a path outside of the mount")
+	}
+
+	if _, err := NewProvider(context.Background(), Config{}, TypeVault, config, credentials); err == nil {
+		t.Errorf("expected an error for a loopback address")
+	}
+}
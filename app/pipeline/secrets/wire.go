@@ -0,0 +1,48 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideConfig,
+	ProvideResolver,
+)
+
+// ProvideConfig provides the configuration of the secret managers.
+func ProvideConfig(config *types.Config) Config {
+	return Config{
+		AllowServerCredentials: config.CI.SecretManagers.AllowServerCredentials,
+		AllowLoopback:          config.Webhook.AllowLoopback,
+		AllowPrivateNetwork:    config.Webhook.AllowPrivateNetwork,
+	}
+}
+
+// ProvideResolver provides the resolver of the secrets stored in secret managers.
+func ProvideResolver(
+	config Config,
+	connectorStore store.ConnectorStore,
+	secretStore store.SecretStore,
+	encrypter encrypt.Encrypter,
+) *Resolver {
+	return NewResolver(config, connectorStore, secretStore, encrypter)
+}
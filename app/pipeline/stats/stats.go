@@ -0,0 +1,164 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package stats computes the duration and reliability statistics of pipelines.
+package stats
+
+import (
+	"sort"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// MaxStageFailures is the maximum number of stages reported as failure hotspots.
+const MaxStageFailures = 10
+
+// Compute computes the statistics of the executions of a pipeline created in the time range of the filter.
+// The stages are the stages of the executions, stages of other executions are ignored.
+func Compute(
+	filter types.PipelineStatsFilter,
+	executions []*types.Execution,
+	stages []*types.Stage,
+) *types.PipelineStats {
+	stats := &types.PipelineStats{
+		After:         filter.After,
+		Before:        filter.Before,
+		Total:         len(executions),
+		StageFailures: []types.StageFailureStats{},
+	}
+
+	created := make(map[int64]int64, len(executions))
+	var durations, queueTimes []int64
+	for _, execution := range executions {
+		created[execution.ID] = execution.Created
+
+		if execution.Started > 0 && execution.Started >= execution.Created {
+			queueTimes = append(queueTimes, execution.Started-execution.Created)
+		}
+
+		if !completed(execution.Status) {
+			continue
+		}
+
+		stats.Completed++
+		if execution.Status == enum.CIStatusSuccess {
+			stats.Succeeded++
+		} else if execution.Status.IsFailed() {
+			stats.Failed++
+		}
+
+		if execution.Started > 0 && execution.Finished >= execution.Started {
+			durations = append(durations, execution.Finished-execution.Started)
+		}
+	}
+
+	if stats.Completed > 0 {
+		stats.SuccessRate = float64(stats.Succeeded) / float64(stats.Completed)
+	}
+
+	stats.Duration = Durations(durations)
+	stats.QueueTime = Durations(queueTimes)
+	stats.StageFailures = stageFailures(stages, created)
+
+	return stats
+}
+
+// completed returns true if the execution or stage ran to its end.
+func completed(status enum.CIStatus) bool {
+	return status.IsDone() && status != enum.CIStatusSkipped
+}
+
+func stageFailures(stages []*types.Stage, created map[int64]int64) []types.StageFailureStats {
+	byName := make(map[string]*types.StageFailureStats)
+	for _, stage := range stages {
+		executionCreated, ok := created[stage.ExecutionID]
+		if !ok || !completed(stage.Status) {
+			continue
+		}
+
+		s, ok := byName[stage.Name]
+		if !ok {
+			s = &types.StageFailureStats{Name: stage.Name}
+			byName[stage.Name] = s
+		}
+
+		s.Completed++
+		if stage.Status.IsFailed() {
+			s.Failed++
+			if executionCreated > s.LastFailed {
+				s.LastFailed = executionCreated
+			}
+		}
+	}
+
+	failures := make([]types.StageFailureStats, 0, len(byName))
+	for _, s := range byName {
+		if s.Failed == 0 {
+			continue
+		}
+
+		s.FailureRate = float64(s.Failed) / float64(s.Completed)
+		failures = append(failures, *s)
+	}
+
+	sort.Slice(failures, func(i, j int) bool {
+		if failures[i].Failed != failures[j].Failed {
+			return failures[i].Failed > failures[j].Failed
+		}
+		if failures[i].FailureRate != failures[j].FailureRate {
+			return failures[i].FailureRate > failures[j].FailureRate
+		}
+		return failures[i].Name < failures[j].Name
+	})
+
+	if len(failures) > MaxStageFailures {
+		failures = failures[:MaxStageFailures]
+	}
+
+	return failures
+}
+
+// Durations summarizes the durations. The percentiles use the nearest-rank method.
+func Durations(durations []int64) types.DurationStats {
+	if len(durations) == 0 {
+		return types.DurationStats{}
+	}
+
+	sorted := make([]int64, len(durations))
+	copy(sorted, durations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var sum int64
+	for _, d := range sorted {
+		sum += d
+	}
+
+	return types.DurationStats{
+		Count:   len(sorted),
+		Average: sum / int64(len(sorted)),
+		P50:     percentile(sorted, 50),
+		P95:     percentile(sorted, 95),
+		Max:     sorted[len(sorted)-1],
+	}
+}
+
+// percentile returns the p-th percentile of the sorted values using the nearest-rank method.
+func percentile(sorted []int64, p int) int64 {
+	rank := (p*len(sorted) + 99) / 100 // ceil(p/100 * n)
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
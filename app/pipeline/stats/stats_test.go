@@ -0,0 +1,82 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stats
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestDurations(t *testing.T) {
+	durations := make([]int64, 0, 20)
+	for i := 20; i > 0; i-- {
+		durations = append(durations, int64(i*100))
+	}
+
+	got := Durations(durations)
+	expected := types.DurationStats{Count: 20, Average: 1050, P50: 1000, P95: 1900, Max: 2000}
+	if got != expected {
+		t.Errorf("expected %+v, got %+v", expected, got)
+	}
+
+	if got := Durations(nil); got != (types.DurationStats{}) {
+		t.Errorf("expected empty stats without durations, got %+v", got)
+	}
+}
+
+func TestCompute(t *testing.T) {
+	executions := []*types.Execution{
+		{ID: 1, Status: enum.CIStatusSuccess, Created: 100, Started: 110, Finished: 210},
+		{ID: 2, Status: enum.CIStatusFailure, Created: 200, Started: 230, Finished: 530},
+		{ID: 3, Status: enum.CIStatusSuccess, Created: 300, Started: 300, Finished: 500},
+		{ID: 4, Status: enum.CIStatusRunning, Created: 400, Started: 450},
+		{ID: 5, Status: enum.CIStatusSkipped, Created: 500},
+	}
+	stages := []*types.Stage{
+		{ExecutionID: 1, Name: "build", Status: enum.CIStatusSuccess},
+		{ExecutionID: 1, Name: "test", Status: enum.CIStatusSuccess},
+		{ExecutionID: 2, Name: "build", Status: enum.CIStatusSuccess},
+		{ExecutionID: 2, Name: "test", Status: enum.CIStatusFailure},
+		{ExecutionID: 3, Name: "build", Status: enum.CIStatusSuccess},
+		{ExecutionID: 3, Name: "test", Status: enum.CIStatusSuccess},
+		{ExecutionID: 4, Name: "build", Status: enum.CIStatusRunning},
+		{ExecutionID: 9, Name: "test", Status: enum.CIStatusFailure},
+	}
+
+	stats := Compute(types.PipelineStatsFilter{After: 1, Before: 1000}, executions, stages)
+
+	if stats.Total != 5 || stats.Completed != 3 || stats.Succeeded != 2 || stats.Failed != 1 {
+		t.Errorf("unexpected counts: %+v", stats)
+	}
+	if stats.SuccessRate < 0.66 || stats.SuccessRate > 0.67 {
+		t.Errorf("expected a success rate of 2/3, got %f", stats.SuccessRate)
+	}
+	if stats.Duration.Count != 3 || stats.Duration.P50 != 200 || stats.Duration.Max != 300 {
+		t.Errorf("unexpected duration stats: %+v", stats.Duration)
+	}
+	if stats.QueueTime.Count != 4 || stats.QueueTime.Max != 50 {
+		t.Errorf("unexpected queue time stats: %+v", stats.QueueTime)
+	}
+
+	if len(stats.StageFailures) != 1 {
+		t.Fatalf("expected one failing stage, got %+v", stats.StageFailures)
+	}
+	expected := types.StageFailureStats{Name: "test", Completed: 3, Failed: 1, FailureRate: 1.0 / 3, LastFailed: 200}
+	if stats.StageFailures[0] != expected {
+		t.Errorf("expected %+v, got %+v", expected, stats.StageFailures[0])
+	}
+}
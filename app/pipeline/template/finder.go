@@ -0,0 +1,54 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package template
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+)
+
+// SpaceFinder returns a Finder that looks up the templates of the space and its ancestors.
+// The template of the closest space wins, which allows spaces to override shared templates.
+func SpaceFinder(
+	ctx context.Context,
+	spaceStore store.SpaceStore,
+	templateStore store.TemplateStore,
+	spaceID int64,
+) Finder {
+	return func(name string) (string, error) {
+		for id := spaceID; id > 0; {
+			tmpl, err := templateStore.FindByUID(ctx, id, name)
+			if err == nil {
+				return tmpl.Data, nil
+			}
+			if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+				return "", fmt.Errorf("failed to find template: %w", err)
+			}
+
+			space, err := spaceStore.Find(ctx, id)
+			if err != nil {
+				return "", fmt.Errorf("failed to find space: %w", err)
+			}
+
+			id = space.ParentID
+		}
+
+		return "", errors.New("template not found")
+	}
+}
@@ -0,0 +1,420 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package template resolves the stage and step templates referenced by v1 pipeline definitions.
+//
+// A stage or step of type template references a template by name and provides its inputs:
+//
+//	stages:
+//	- name: build
+//	  type: template
+//	  spec:
+//	    name: go-build
+//	    inputs:
+//	      version: "1.21"
+//
+// The referenced template is a v1 document of kind template with typed inputs.
+// Inputs are used in the template as ${{ inputs.<name> }} expressions.
+package template
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	v1yaml "github.com/drone/spec/dist/go"
+	"github.com/ghodss/yaml"
+)
+
+const (
+	kindTemplate = "template"
+	typeTemplate = "template"
+
+	typeStage = "stage"
+	typeStep  = "step"
+
+	inputTypeString  = "string"
+	inputTypeNumber  = "number"
+	inputTypeBoolean = "boolean"
+	inputTypeArray   = "array"
+)
+
+// inputExpression matches the input expressions in the strings of a template.
+var inputExpression = regexp.MustCompile(`\$\{\{\s*inputs\.([a-zA-Z0-9_-]+)\s*\}\}`)
+
+// Finder returns the definition of the template with the provided name.
+type Finder func(name string) (string, error)
+
+// definition is a parsed template.
+type definition struct {
+	Type   string
+	Inputs map[string]*v1yaml.Input
+	// Body is the stage or the step of the template.
+	Body map[string]any
+}
+
+// Validate returns an error if the data isn't a valid stage or step template.
+func Validate(data string) error {
+	_, err := parse(data)
+	return err
+}
+
+// Resolve replaces the stages and steps of type template in a v1 pipeline definition
+// with the referenced templates, with the inputs substituted.
+// If the definition doesn't reference any templates, the original data is returned.
+func Resolve(data []byte, find Finder) ([]byte, error) {
+	if !bytes.Contains(data, []byte(typeTemplate)) {
+		return data, nil
+	}
+
+	raw, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		// let the pipeline spec parser report the error
+		return data, nil //nolint:nilerr
+	}
+
+	doc := map[string]any{}
+	if err = json.Unmarshal(raw, &doc); err != nil {
+		return data, nil //nolint:nilerr
+	}
+
+	spec, _ := doc["spec"].(map[string]any)
+	stages, _ := spec["stages"].([]any)
+
+	resolved := 0
+	for idx, s := range stages {
+		stage, _ := s.(map[string]any)
+
+		if stage["type"] == typeTemplate {
+			stage, err = resolve(stage, typeStage, find)
+			if err != nil {
+				return nil, fmt.Errorf("invalid template in stage %d: %w", idx+1, err)
+			}
+
+			stages[idx] = stage
+			resolved++
+		}
+
+		stageSpec, _ := stage["spec"].(map[string]any)
+		steps, _ := stageSpec["steps"].([]any)
+
+		n, err := resolveSteps(steps, find)
+		if err != nil {
+			return nil, fmt.Errorf("invalid template in stage %d: %w", idx+1, err)
+		}
+
+		resolved += n
+	}
+
+	if resolved == 0 {
+		return data, nil
+	}
+
+	// JSON is valid YAML, so the output can be used in place of the original definition.
+	out, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal pipeline definition: %w", err)
+	}
+
+	return out, nil
+}
+
+// resolveSteps resolves the step templates in place, including the steps nested in group and parallel steps.
+func resolveSteps(steps []any, find Finder) (int, error) {
+	resolved := 0
+	for idx, st := range steps {
+		s, _ := st.(map[string]any)
+		spec, _ := s["spec"].(map[string]any)
+
+		if nested, ok := spec["steps"].([]any); ok {
+			n, err := resolveSteps(nested, find)
+			if err != nil {
+				return 0, err
+			}
+			resolved += n
+			continue
+		}
+
+		if s["type"] != typeTemplate {
+			continue
+		}
+
+		step, err := resolve(s, typeStep, find)
+		if err != nil {
+			return 0, fmt.Errorf("step %d: %w", idx+1, err)
+		}
+
+		steps[idx] = step
+		resolved++
+	}
+
+	return resolved, nil
+}
+
+// resolve returns the template referenced by the stage or step, with the inputs substituted.
+// The attributes of the referencing stage or step (e.g. name or when) override the ones of the template.
+func resolve(ref map[string]any, kind string, find Finder) (map[string]any, error) {
+	spec, _ := ref["spec"].(map[string]any)
+
+	name, _ := spec["name"].(string)
+	if name == "" {
+		return nil, fmt.Errorf("template name must be provided")
+	}
+
+	inputs, _ := spec["inputs"].(map[string]any)
+
+	data, err := find(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find template %q: %w", name, err)
+	}
+
+	def, err := parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("template %q is invalid: %w", name, err)
+	}
+
+	if def.Type != kind {
+		return nil, fmt.Errorf("template %q is a %s template, expected a %s template", name, def.Type, kind)
+	}
+
+	values, err := def.values(inputs)
+	if err != nil {
+		return nil, fmt.Errorf("invalid inputs for template %q: %w", name, err)
+	}
+
+	body, err := substitute(def.Body, values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to substitute inputs of template %q: %w", name, err)
+	}
+
+	result, _ := body.(map[string]any)
+	if result["type"] == typeTemplate {
+		return nil, fmt.Errorf("template %q references another template, nested templates aren't supported", name)
+	}
+
+	for k, v := range ref {
+		if k == "type" || k == "spec" {
+			continue
+		}
+		result[k] = v
+	}
+
+	return result, nil
+}
+
+// parse parses and validates a stage or step template.
+func parse(data string) (*definition, error) {
+	raw, err := yaml.YAMLToJSON([]byte(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse yaml: %w", err)
+	}
+
+	var doc struct {
+		Kind string `json:"kind"`
+		Type string `json:"type"`
+		Spec struct {
+			Inputs map[string]*v1yaml.Input `json:"inputs"`
+			Stage  map[string]any           `json:"stage"`
+			Step   map[string]any           `json:"step"`
+		} `json:"spec"`
+	}
+	if err = json.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	if doc.Kind != kindTemplate {
+		return nil, fmt.Errorf("kind must be %q", kindTemplate)
+	}
+
+	def := &definition{
+		Type:   doc.Type,
+		Inputs: doc.Spec.Inputs,
+	}
+
+	switch doc.Type {
+	case typeStage:
+		def.Body = doc.Spec.Stage
+	case typeStep:
+		def.Body = doc.Spec.Step
+	default:
+		return nil, fmt.Errorf("type must be either %q or %q", typeStage, typeStep)
+	}
+
+	if def.Body == nil {
+		return nil, fmt.Errorf("%s template requires a %s", def.Type, def.Type)
+	}
+
+	for name, input := range def.Inputs {
+		if input == nil {
+			return nil, fmt.Errorf("input %q must be defined", name)
+		}
+		switch input.Type {
+		case "", inputTypeString, inputTypeNumber, inputTypeBoolean, inputTypeArray:
+		default:
+			return nil, fmt.Errorf("input %q has an unsupported type %q", name, input.Type)
+		}
+		if input.Default != nil {
+			if err = checkInput(input, input.Default); err != nil {
+				return nil, fmt.Errorf("default of input %q is invalid: %w", name, err)
+			}
+		}
+	}
+
+	return def, nil
+}
+
+// values returns the values of all inputs of the template, it applies the defaults
+// and fails for missing required inputs, unknown inputs and values of the wrong type.
+func (d *definition) values(provided map[string]any) (map[string]any, error) {
+	for name := range provided {
+		if _, ok := d.Inputs[name]; !ok {
+			return nil, fmt.Errorf("unknown input %q", name)
+		}
+	}
+
+	values := make(map[string]any, len(d.Inputs))
+	for name, input := range d.Inputs {
+		value, ok := provided[name]
+		if !ok || value == nil {
+			if input.Required && input.Default == nil {
+				return nil, fmt.Errorf("input %q is required", name)
+			}
+			values[name] = input.Default
+			continue
+		}
+
+		if err := checkInput(input, value); err != nil {
+			return nil, fmt.Errorf("input %q is invalid: %w", name, err)
+		}
+
+		values[name] = value
+	}
+
+	return values, nil
+}
+
+// checkInput returns an error if the value doesn't match the type of the input.
+func checkInput(input *v1yaml.Input, value any) error {
+	switch input.Type {
+	case inputTypeString:
+		if _, ok := value.(string); !ok {
+			return fmt.Errorf("must be a string")
+		}
+	case inputTypeNumber:
+		if _, ok := value.(float64); !ok {
+			return fmt.Errorf("must be a number")
+		}
+	case inputTypeBoolean:
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("must be a boolean")
+		}
+	case inputTypeArray:
+		if _, ok := value.([]any); !ok {
+			return fmt.Errorf("must be an array")
+		}
+	}
+
+	if len(input.Enum) > 0 {
+		s, err := stringify(value)
+		if err != nil {
+			return err
+		}
+		for _, allowed := range input.Enum {
+			if s == allowed {
+				return nil
+			}
+		}
+		return fmt.Errorf("must be one of %s", strings.Join(input.Enum, ", "))
+	}
+
+	return nil
+}
+
+// substitute returns a copy of the value with all input expressions replaced.
+// A string that consists of a single expression is replaced with the value as is, to retain its type.
+func substitute(value any, values map[string]any) (any, error) {
+	switch v := value.(type) {
+	case string:
+		if m := inputExpression.FindStringSubmatch(v); m != nil && m[0] == v {
+			input, ok := values[m[1]]
+			if !ok {
+				return nil, fmt.Errorf("unknown input %q", m[1])
+			}
+			return input, nil
+		}
+
+		var err error
+		out := inputExpression.ReplaceAllStringFunc(v, func(expr string) string {
+			name := inputExpression.FindStringSubmatch(expr)[1]
+			input, ok := values[name]
+			if !ok {
+				err = fmt.Errorf("unknown input %q", name)
+				return expr
+			}
+
+			s, errStringify := stringify(input)
+			if errStringify != nil {
+				err = errStringify
+				return expr
+			}
+
+			return s
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return out, nil
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, item := range v {
+			var err error
+			if out[k], err = substitute(item, values); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			var err error
+			if out[i], err = substitute(item, values); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+	default:
+		return v, nil
+	}
+}
+
+// stringify returns the value as used within a string.
+func stringify(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case float64, bool:
+		return fmt.Sprint(v), nil
+	default:
+		out, err := json.Marshal(v)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal input value: %w", err)
+		}
+		return string(out), nil
+	}
+}
@@ -0,0 +1,282 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package template
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	v1yaml "github.com/drone/spec/dist/go"
+)
+
+const stageTemplate = `
+kind: template
+type: stage
+spec:
+  inputs:
+    version:
+      type: string
+      default: "1.20"
+    race:
+      type: boolean
+      required: true
+    os:
+      type: string
+      enum: [linux, windows]
+  stage:
+    type: ci
+    spec:
+      steps:
+      - name: test
+        type: run
+        spec:
+          container: golang:${{ inputs.version }}
+          script: go test -race=${{ inputs.race }} ./...
+          envs:
+            GOOS: ${{ inputs.os }}
+`
+
+const stepTemplate = `
+kind: template
+type: step
+spec:
+  inputs:
+    message:
+      type: string
+      required: true
+  step:
+    type: run
+    spec:
+      container: alpine
+      script: echo ${{ inputs.message }}
+`
+
+func finder(templates map[string]string) Finder {
+	return func(name string) (string, error) {
+		data, ok := templates[name]
+		if !ok {
+			return "", errors.New("template not found")
+		}
+		return data, nil
+	}
+}
+
+func TestResolve(t *testing.T) {
+	data := []byte(`
+version: 1
+kind: pipeline
+spec:
+  stages:
+  - name: build
+    type: template
+    spec:
+      name: go
+      inputs:
+        race: true
+        os: linux
+  - name: notify
+    type: ci
+    spec:
+      steps:
+      - name: hello
+        type: template
+        spec:
+          name: echo
+          inputs:
+            message: hello
+`)
+
+	out, err := Resolve(data, finder(map[string]string{"go": stageTemplate, "echo": stepTemplate}))
+	if err != nil {
+		t.Fatalf("failed to resolve templates: %s", err)
+	}
+
+	config, err := v1yaml.ParseBytes(out)
+	if err != nil {
+		t.Fatalf("failed to parse resolved definition: %s", err)
+	}
+
+	pipeline, _ := config.Spec.(*v1yaml.Pipeline)
+	if len(pipeline.Stages) != 2 {
+		t.Fatalf("want 2 stages, got %d", len(pipeline.Stages))
+	}
+
+	if name := pipeline.Stages[0].Name; name != "build" {
+		t.Errorf("want stage name %q, got %q", "build", name)
+	}
+
+	build, ok := pipeline.Stages[0].Spec.(*v1yaml.StageCI)
+	if !ok {
+		t.Fatalf("build stage isn't a ci stage: %T", pipeline.Stages[0].Spec)
+	}
+
+	test, _ := build.Steps[0].Spec.(*v1yaml.StepRun)
+	if test.Container == nil || test.Container.Image != "golang:1.20" {
+		t.Errorf("test step doesn't use the default version: %+v", test.Container)
+	}
+	if script := strings.Join(test.Script, "\n"); script != "go test -race=true ./..." {
+		t.Errorf("unexpected test script: %s", script)
+	}
+	if goos := test.Envs["GOOS"]; goos != "linux" {
+		t.Errorf("want GOOS %q, got %q", "linux", goos)
+	}
+
+	notify, _ := pipeline.Stages[1].Spec.(*v1yaml.StageCI)
+	hello, ok := notify.Steps[0].Spec.(*v1yaml.StepRun)
+	if !ok {
+		t.Fatalf("hello step isn't a run step: %T", notify.Steps[0].Spec)
+	}
+	if name := notify.Steps[0].Name; name != "hello" {
+		t.Errorf("want step name %q, got %q", "hello", name)
+	}
+	if script := strings.Join(hello.Script, "\n"); script != "echo hello" {
+		t.Errorf("unexpected hello script: %s", script)
+	}
+}
+
+func TestResolveNoTemplates(t *testing.T) {
+	data := []byte(`
+version: 1
+kind: pipeline
+spec:
+  stages:
+  - name: build
+    type: ci
+    spec:
+      steps:
+      - name: test
+        type: run
+        spec:
+          container: golang
+          script: go test ./...
+`)
+
+	out, err := Resolve(data, finder(nil))
+	if err != nil {
+		t.Fatalf("failed to resolve templates: %s", err)
+	}
+	if string(out) != string(data) {
+		t.Errorf("definition without templates was modified")
+	}
+}
+
+func TestResolveErrors(t *testing.T) {
+	templates := map[string]string{"go": stageTemplate, "echo": stepTemplate}
+
+	tests := []struct {
+		name   string
+		inputs string
+		tmpl   string
+		errMsg string
+	}{
+		{
+			name:   "missing template",
+			tmpl:   "unknown",
+			inputs: "{}",
+			errMsg: `failed to find template "unknown"`,
+		},
+		{
+			name:   "step template as stage",
+			tmpl:   "echo",
+			inputs: "{message: hi}",
+			errMsg: "expected a stage template",
+		},
+		{
+			name:   "missing required input",
+			tmpl:   "go",
+			inputs: "{}",
+			errMsg: `input "race" is required`,
+		},
+		{
+			name:   "unknown input",
+			tmpl:   "go",
+			inputs: "{race: true, arch: arm64}",
+			errMsg: `unknown input "arch"`,
+		},
+		{
+			name:   "wrong type",
+			tmpl:   "go",
+			inputs: "{race: yes please}",
+			errMsg: "must be a boolean",
+		},
+		{
+			name:   "not in enum",
+			tmpl:   "go",
+			inputs: "{race: true, os: darwin}",
+			errMsg: "must be one of linux, windows",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := []byte(`
+version: 1
+kind: pipeline
+spec:
+  stages:
+  - name: build
+    type: template
+    spec:
+      name: ` + tt.tmpl + `
+      inputs: ` + tt.inputs + `
+`)
+
+			_, err := Resolve(data, finder(templates))
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("expected error to contain %q, got %q", tt.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		data  string
+		valid bool
+	}{
+		{name: "stage template", data: stageTemplate, valid: true},
+		{name: "step template", data: stepTemplate, valid: true},
+		{name: "pipeline", data: "kind: pipeline\nspec: {}", valid: false},
+		{name: "unknown type", data: "kind: template\ntype: pipeline\nspec: {}", valid: false},
+		{name: "missing stage", data: "kind: template\ntype: stage\nspec: {}", valid: false},
+		{
+			name:  "unsupported input type",
+			data:  "kind: template\ntype: step\nspec:\n  inputs: {a: {type: object}}\n  step: {type: run}",
+			valid: false,
+		},
+		{
+			name:  "invalid default",
+			data:  "kind: template\ntype: step\nspec:\n  inputs: {a: {type: number, default: one}}\n  step: {type: run}",
+			valid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.data)
+			if tt.valid && err != nil {
+				t.Errorf("expected template to be valid, got %s", err)
+			}
+			if !tt.valid && err == nil {
+				t.Errorf("expected template to be invalid")
+			}
+		})
+	}
+}
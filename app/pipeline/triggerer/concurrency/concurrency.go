@@ -0,0 +1,110 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package concurrency
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ghodss/yaml"
+)
+
+const (
+	keyConcurrency = "concurrency"
+
+	// maxGroupLength is the maximum length of a concurrency group.
+	maxGroupLength = 256
+)
+
+// Concurrency is the concurrency configuration declared in a v1 pipeline definition:
+//
+//	spec:
+//	  concurrency:
+//	    group: deploy-${{ build.target }}
+//	    cancel_in_progress: true
+//
+// The short form `concurrency: <group>` declares just the group.
+type Concurrency struct {
+	// Group is the name of the concurrency group, only one execution of a group runs at a time
+	// within a repository. The group can contain expressions, they are expanded by the caller.
+	Group string
+	// CancelInProgress cancels the executions of the group that are in progress when a new one is triggered.
+	CancelInProgress bool
+}
+
+// Parse returns the concurrency configuration of a v1 pipeline definition,
+// or nil if the definition doesn't declare any.
+func Parse(data []byte) (*Concurrency, error) {
+	if !bytes.Contains(data, []byte(keyConcurrency)) {
+		return nil, nil //nolint:nilnil // no concurrency configuration
+	}
+
+	raw, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		// let the pipeline spec parser report the error
+		return nil, nil //nolint:nilerr,nilnil
+	}
+
+	doc := map[string]any{}
+	if err = json.Unmarshal(raw, &doc); err != nil {
+		return nil, nil //nolint:nilerr,nilnil
+	}
+
+	spec, _ := doc["spec"].(map[string]any)
+	value, ok := spec[keyConcurrency]
+	if !ok || value == nil {
+		return nil, nil //nolint:nilnil // no concurrency configuration
+	}
+
+	var c Concurrency
+	switch v := value.(type) {
+	case string:
+		c.Group = v
+	case map[string]any:
+		group, ok := v["group"].(string)
+		if !ok {
+			return nil, fmt.Errorf("concurrency group must be a string")
+		}
+		c.Group = group
+
+		if cancel, ok := v["cancel_in_progress"]; ok {
+			c.CancelInProgress, ok = cancel.(bool)
+			if !ok {
+				return nil, fmt.Errorf("concurrency cancel_in_progress must be a boolean")
+			}
+		}
+	default:
+		return nil, fmt.Errorf("concurrency must be a group name or an object")
+	}
+
+	if err = CheckGroup(c.Group); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// CheckGroup checks whether the (expanded) concurrency group is valid.
+func CheckGroup(group string) error {
+	if group == "" {
+		return fmt.Errorf("concurrency group can't be empty")
+	}
+	if len(group) > maxGroupLength {
+		return fmt.Errorf("concurrency group can't be longer than %d characters", maxGroupLength)
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package concurrency
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want *Concurrency
+	}{
+		{
+			name: "none",
+			data: "version: 1\nkind: pipeline\nspec:\n  stages: []\n",
+			want: nil,
+		},
+		{
+			name: "short form",
+			data: "version: 1\nkind: pipeline\nspec:\n  concurrency: deploy\n  stages: []\n",
+			want: &Concurrency{Group: "deploy"},
+		},
+		{
+			name: "object",
+			data: `
+version: 1
+kind: pipeline
+spec:
+  concurrency:
+    group: deploy-${{ build.target }}
+    cancel_in_progress: true
+  stages: []
+`,
+			want: &Concurrency{Group: "deploy-${{ build.target }}", CancelInProgress: true},
+		},
+		{
+			name: "stage name",
+			data: "version: 1\nkind: pipeline\nspec:\n  stages:\n  - name: concurrency\n",
+			want: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := Parse([]byte(test.data))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(test.want, got) {
+				t.Errorf("want=%+v got=%+v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "empty group", data: "spec:\n  concurrency: ''\n"},
+		{name: "missing group", data: "spec:\n  concurrency:\n    cancel_in_progress: true\n"},
+		{name: "invalid cancel", data: "spec:\n  concurrency:\n    group: a\n    cancel_in_progress: yes please\n"},
+		{name: "list", data: "spec:\n  concurrency: [a]\n"},
+		{name: "too long", data: "spec:\n  concurrency: " + strings.Repeat("a", maxGroupLength+1) + "\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := Parse([]byte(test.data)); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,319 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package condition
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/harness/gitness/types"
+
+	"github.com/drone-runners/drone-runner-docker/engine2/script"
+	v1yaml "github.com/drone/spec/dist/go"
+	"github.com/drone/spec/dist/go/parse/normalize"
+)
+
+const (
+	keyBranch = "branch"
+	keyEvent  = "event"
+	keyRef    = "ref"
+	keyStatus = "status"
+
+	// prefixVariables is the prefix of the keys that match the value of a variable, e.g. `variables.DEPLOY`.
+	prefixVariables = "variables."
+
+	// InputVariables is the name of the input that gives condition expressions access to the variables.
+	InputVariables = "variables"
+
+	statusSuccess = "success"
+	statusFailure = "failure"
+)
+
+// Context contains the values of the execution the declarative conditions are evaluated against.
+type Context struct {
+	Branch    string
+	Event     string
+	Ref       string
+	Variables map[string]string
+}
+
+// Evaluate evaluates a `when` condition. Expression conditions are evaluated with the provided inputs,
+// the same way the runner evaluates them. Declarative conditions are evaluated against the context:
+// the conditions in a list are alternatives, and all keys of a single condition have to match.
+// The status key decides whether it runs if everything before succeeded (success) or if something failed (failure);
+// without it, it only runs if everything before succeeded.
+func Evaluate(when *v1yaml.When, ctx Context, inputs map[string]any) (types.ConditionResult, error) {
+	if when == nil {
+		return types.ConditionResult{Matched: true, OnSuccess: true}, nil
+	}
+
+	if when.Eval != "" {
+		onSuccess, onFailure, err := script.EvalWhen(when.Eval, inputs)
+		if err != nil {
+			return types.ConditionResult{}, fmt.Errorf("could not evaluate condition expression: %w", err)
+		}
+
+		return types.ConditionResult{
+			Matched:   onSuccess || onFailure,
+			OnSuccess: onSuccess,
+			OnFailure: onFailure,
+		}, nil
+	}
+
+	if len(when.Cond) == 0 {
+		return types.ConditionResult{Matched: true, OnSuccess: true}, nil
+	}
+
+	result := types.ConditionResult{}
+	for _, cond := range when.Cond {
+		matched, onSuccess, onFailure, err := evaluateCond(cond, ctx)
+		if err != nil {
+			return types.ConditionResult{}, err
+		}
+		if !matched {
+			continue
+		}
+
+		result.Matched = true
+		result.OnSuccess = result.OnSuccess || onSuccess
+		result.OnFailure = result.OnFailure || onFailure
+	}
+
+	return result, nil
+}
+
+func evaluateCond(cond map[string]*v1yaml.Expr, ctx Context) (matched, onSuccess, onFailure bool, err error) {
+	onSuccess = true
+
+	for key, expr := range cond {
+		var value string
+		switch {
+		case key == keyBranch:
+			value = ctx.Branch
+		case key == keyEvent:
+			value = ctx.Event
+		case key == keyRef:
+			value = ctx.Ref
+		case key == keyStatus:
+			if err = checkStatus(expr); err != nil {
+				return false, false, false, err
+			}
+
+			if onSuccess, err = match(expr, statusSuccess); err != nil {
+				return false, false, false, err
+			}
+			if onFailure, err = match(expr, statusFailure); err != nil {
+				return false, false, false, err
+			}
+
+			continue
+		case strings.HasPrefix(key, prefixVariables) && len(key) > len(prefixVariables):
+			value = ctx.Variables[strings.TrimPrefix(key, prefixVariables)]
+		default:
+			return false, false, false, fmt.Errorf("unsupported condition %q", key)
+		}
+
+		ok, err := match(expr, value)
+		if err != nil {
+			return false, false, false, fmt.Errorf("invalid condition %q: %w", key, err)
+		}
+		if !ok {
+			return false, false, false, nil
+		}
+	}
+
+	return true, onSuccess, onFailure, nil
+}
+
+// match returns true if the value matches the expression. The values of the expression can be glob patterns.
+func match(expr *v1yaml.Expr, value string) (bool, error) {
+	if expr == nil {
+		return true, nil
+	}
+
+	if expr.Eq != "" {
+		ok, err := path.Match(expr.Eq, value)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+
+	if len(expr.In) > 0 {
+		found := false
+		for _, pattern := range expr.In {
+			ok, err := path.Match(pattern, value)
+			if err != nil {
+				return false, err
+			}
+			if ok {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false, nil
+		}
+	}
+
+	if expr.Not != nil {
+		ok, err := match(expr.Not, value)
+		if err != nil || ok {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// checkStatus verifies that the status condition only uses the known statuses.
+func checkStatus(expr *v1yaml.Expr) error {
+	for ; expr != nil; expr = expr.Not {
+		values := expr.In
+		if expr.Eq != "" {
+			values = append([]string{expr.Eq}, values...)
+		}
+
+		for _, value := range values {
+			if value != statusSuccess && value != statusFailure {
+				return fmt.Errorf("invalid status condition %q, only %q and %q are supported",
+					value, statusSuccess, statusFailure)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Steps evaluates the conditions of the steps of a CI stage, including the steps of step groups,
+// and returns the results keyed by the step ID. The stage must be normalized, so that all steps have a unique ID.
+// If no step has a condition, nil is returned.
+func Steps(stage *v1yaml.StageCI, ctx Context, inputs map[string]any) (map[string]types.ConditionResult, error) {
+	var results map[string]types.ConditionResult
+
+	var walk func(steps []*v1yaml.Step) error
+	walk = func(steps []*v1yaml.Step) error {
+		for _, step := range steps {
+			if step == nil {
+				continue
+			}
+
+			switch spec := step.Spec.(type) {
+			case *v1yaml.StepGroup:
+				if err := walk(spec.Steps); err != nil {
+					return err
+				}
+			case *v1yaml.StepParallel:
+				if err := walk(spec.Steps); err != nil {
+					return err
+				}
+			}
+
+			if step.When == nil {
+				continue
+			}
+
+			result, err := Evaluate(step.When, ctx, inputs)
+			if err != nil {
+				return fmt.Errorf("invalid condition of step %q: %w", step.Id, err)
+			}
+
+			if results == nil {
+				results = make(map[string]types.ConditionResult)
+			}
+			results[step.Id] = result
+		}
+
+		return nil
+	}
+
+	if err := walk(stage.Steps); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// Expression returns the condition expression understood by the runner that corresponds to the result.
+func Expression(result types.ConditionResult) string {
+	switch {
+	case result.OnSuccess && result.OnFailure:
+		return "always()"
+	case result.OnSuccess:
+		return "success()"
+	case result.OnFailure:
+		return "failure()"
+	default:
+		return "false"
+	}
+}
+
+// ResolveConfig replaces the conditions of the steps of the stage with the expressions corresponding
+// to their results, so the runner executes the steps the same way they were evaluated by the server.
+func ResolveConfig(data []byte, stageName string, results map[string]types.ConditionResult) ([]byte, error) {
+	config, err := v1yaml.ParseBytes(data)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse v1 yaml: %w", err)
+	}
+
+	// Normalize the config the same way the triggerer does to get matching stage and step IDs.
+	err = normalize.Normalize(config)
+	if err != nil {
+		return nil, fmt.Errorf("could not normalize v1 yaml: %w", err)
+	}
+
+	pipeline, ok := config.Spec.(*v1yaml.Pipeline)
+	if !ok {
+		return nil, fmt.Errorf("config is not a pipeline")
+	}
+
+	var walk func(steps []*v1yaml.Step)
+	walk = func(steps []*v1yaml.Step) {
+		for _, step := range steps {
+			if step == nil {
+				continue
+			}
+
+			switch spec := step.Spec.(type) {
+			case *v1yaml.StepGroup:
+				walk(spec.Steps)
+			case *v1yaml.StepParallel:
+				walk(spec.Steps)
+			}
+
+			if result, ok := results[step.Id]; ok {
+				step.When = &v1yaml.When{Eval: Expression(result)}
+			}
+		}
+	}
+
+	for _, stage := range pipeline.Stages {
+		if stage.Id != stageName {
+			continue
+		}
+
+		spec, ok := stage.Spec.(*v1yaml.StageCI)
+		if !ok {
+			return nil, fmt.Errorf("stage %q isn't a CI stage", stageName)
+		}
+
+		walk(spec.Steps)
+
+		return json.Marshal(config)
+	}
+
+	return nil, fmt.Errorf("stage %q not found in the pipeline", stageName)
+}
@@ -0,0 +1,189 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package condition
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/types"
+
+	v1yaml "github.com/drone/spec/dist/go"
+	"github.com/drone/spec/dist/go/parse/normalize"
+)
+
+func TestEvaluate(t *testing.T) {
+	ctx := Context{
+		Branch:    "main",
+		Event:     "push",
+		Ref:       "refs/heads/main",
+		Variables: map[string]string{"DEPLOY": "true"},
+	}
+
+	tests := []struct {
+		name     string
+		when     *v1yaml.When
+		expected types.ConditionResult
+	}{
+		{
+			name:     "no condition",
+			when:     nil,
+			expected: types.ConditionResult{Matched: true, OnSuccess: true},
+		},
+		{
+			name: "matching branch",
+			when: &v1yaml.When{Cond: []map[string]*v1yaml.Expr{
+				{"branch": {In: []string{"main", "release/*"}}},
+			}},
+			expected: types.ConditionResult{Matched: true, OnSuccess: true},
+		},
+		{
+			name: "branch doesn't match",
+			when: &v1yaml.When{Cond: []map[string]*v1yaml.Expr{
+				{"branch": {Eq: "release/*"}},
+			}},
+			expected: types.ConditionResult{},
+		},
+		{
+			name: "all keys have to match",
+			when: &v1yaml.When{Cond: []map[string]*v1yaml.Expr{
+				{"branch": {Eq: "main"}, "event": {Eq: "pull_request"}},
+			}},
+			expected: types.ConditionResult{},
+		},
+		{
+			name: "any condition can match",
+			when: &v1yaml.When{Cond: []map[string]*v1yaml.Expr{
+				{"event": {Eq: "pull_request"}},
+				{"ref": {Eq: "refs/heads/*"}},
+			}},
+			expected: types.ConditionResult{Matched: true, OnSuccess: true},
+		},
+		{
+			name: "negated event",
+			when: &v1yaml.When{Cond: []map[string]*v1yaml.Expr{
+				{"event": {Not: &v1yaml.Expr{Eq: "push"}}},
+			}},
+			expected: types.ConditionResult{},
+		},
+		{
+			name: "on failure",
+			when: &v1yaml.When{Cond: []map[string]*v1yaml.Expr{
+				{"status": {Eq: "failure"}},
+			}},
+			expected: types.ConditionResult{Matched: true, OnFailure: true},
+		},
+		{
+			name: "always",
+			when: &v1yaml.When{Cond: []map[string]*v1yaml.Expr{
+				{"status": {In: []string{"success", "failure"}}, "variables.DEPLOY": {Eq: "true"}},
+			}},
+			expected: types.ConditionResult{Matched: true, OnSuccess: true, OnFailure: true},
+		},
+		{
+			name: "variable doesn't match",
+			when: &v1yaml.When{Cond: []map[string]*v1yaml.Expr{
+				{"variables.DEPLOY": {Eq: "false"}},
+			}},
+			expected: types.ConditionResult{},
+		},
+		{
+			name:     "expression",
+			when:     &v1yaml.When{Eval: "failure()"},
+			expected: types.ConditionResult{Matched: true, OnFailure: true},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := Evaluate(test.when, ctx, map[string]any{})
+			if err != nil {
+				t.Fatalf("failed to evaluate the condition: %s", err)
+			}
+			if result != test.expected {
+				t.Errorf("expected %+v, got %+v", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestEvaluateInvalid(t *testing.T) {
+	conds := []map[string]*v1yaml.Expr{
+		{"unknown": {Eq: "value"}},
+		{"status": {Eq: "skipped"}},
+		{"branch": {Eq: "["}},
+	}
+
+	for _, cond := range conds {
+		when := &v1yaml.When{Cond: []map[string]*v1yaml.Expr{cond}}
+		if _, err := Evaluate(when, Context{}, nil); err == nil {
+			t.Errorf("expected an error for the condition %v", cond)
+		}
+	}
+}
+
+func TestResolveConfig(t *testing.T) {
+	data := []byte(`
+version: 1
+kind: pipeline
+spec:
+  stages:
+  - name: build
+    type: ci
+    spec:
+      steps:
+      - name: test
+        type: run
+        spec:
+          container: alpine
+          script: go test
+      - name: notify
+        type: run
+        when:
+          status:
+            eq: failure
+        spec:
+          container: alpine
+          script: echo failed
+`)
+
+	config, err := v1yaml.ParseBytes(data)
+	if err != nil {
+		t.Fatalf("failed to parse the config: %s", err)
+	}
+	if err = normalize.Normalize(config); err != nil {
+		t.Fatalf("failed to normalize the config: %s", err)
+	}
+
+	stage := config.Spec.(*v1yaml.Pipeline).Stages[0].Spec.(*v1yaml.StageCI)
+	results, err := Steps(stage, Context{}, nil)
+	if err != nil {
+		t.Fatalf("failed to evaluate the step conditions: %s", err)
+	}
+
+	expected := types.ConditionResult{Matched: true, OnFailure: true}
+	if len(results) != 1 || results["notify"] != expected {
+		t.Fatalf("expected only the notify step to have a condition, got %+v", results)
+	}
+
+	resolved, err := ResolveConfig(data, "build", results)
+	if err != nil {
+		t.Fatalf("failed to resolve the config: %s", err)
+	}
+
+	if !strings.Contains(string(resolved), `"when":"failure()"`) {
+		t.Errorf("expected the condition to be replaced with its expression, got %s", resolved)
+	}
+}
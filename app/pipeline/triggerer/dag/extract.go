@@ -0,0 +1,100 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dag
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ghodss/yaml"
+)
+
+const keyDependsOn = "depends_on"
+
+// Extract finds the stage dependencies declared with `depends_on` in a v1 pipeline definition
+// and returns them keyed by the index of their stage. A dependency is either a single stage name
+// or a list of stage names. If any stage declares dependencies, the returned map contains an entry
+// for every stage, stages without a declaration have no dependencies.
+// If the definition doesn't declare any dependencies, nil is returned.
+// The pipeline spec parser ignores the declarations, so the definition doesn't need to be changed.
+func Extract(data []byte) (map[int][]string, error) {
+	if !bytes.Contains(data, []byte(keyDependsOn)) {
+		return nil, nil //nolint:nilnil // no dependencies declared
+	}
+
+	raw, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		// let the pipeline spec parser report the error
+		return nil, nil //nolint:nilerr,nilnil
+	}
+
+	doc := map[string]any{}
+	if err = json.Unmarshal(raw, &doc); err != nil {
+		return nil, nil //nolint:nilerr,nilnil
+	}
+
+	spec, _ := doc["spec"].(map[string]any)
+	stages, _ := spec["stages"].([]any)
+
+	deps := make(map[int][]string, len(stages))
+	found := false
+	for idx, s := range stages {
+		stage, _ := s.(map[string]any)
+		value, ok := stage[keyDependsOn]
+		if !ok {
+			deps[idx] = nil
+			continue
+		}
+
+		names, err := parseDependsOn(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid dependencies of stage %d: %w", idx+1, err)
+		}
+
+		deps[idx] = names
+		found = true
+	}
+
+	if !found {
+		return nil, nil //nolint:nilnil // no dependencies declared
+	}
+
+	return deps, nil
+}
+
+func parseDependsOn(value any) ([]string, error) {
+	switch v := value.(type) {
+	case nil:
+		return []string{}, nil
+	case string:
+		if v == "" {
+			return nil, fmt.Errorf("stage names must be non-empty strings")
+		}
+		return []string{v}, nil
+	case []any:
+		names := make([]string, 0, len(v))
+		for _, item := range v {
+			name, ok := item.(string)
+			if !ok || name == "" {
+				return nil, fmt.Errorf("stage names must be non-empty strings")
+			}
+			names = append(names, name)
+		}
+		return names, nil
+	default:
+		return nil, fmt.Errorf("%s must be a stage name or a list of stage names", keyDependsOn)
+	}
+}
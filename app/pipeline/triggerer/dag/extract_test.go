@@ -0,0 +1,82 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dag
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtract(t *testing.T) {
+	data := []byte(`
+version: 1
+kind: pipeline
+spec:
+  stages:
+  - name: backend
+    type: ci
+    spec:
+      steps: []
+  - name: frontend
+    type: ci
+    spec:
+      steps: []
+  - name: deploy
+    type: ci
+    depends_on: [backend, frontend]
+    spec:
+      steps: []
+  - name: notify
+    type: ci
+    depends_on: deploy
+    spec:
+      steps: []
+`)
+
+	deps, err := Extract(data)
+	if err != nil {
+		t.Fatalf("failed to extract dependencies: %s", err)
+	}
+
+	want := map[int][]string{
+		0: nil,
+		1: nil,
+		2: {"backend", "frontend"},
+		3: {"deploy"},
+	}
+	if !reflect.DeepEqual(want, deps) {
+		t.Errorf("want=%v got=%v", want, deps)
+	}
+}
+
+func TestExtractNoDependencies(t *testing.T) {
+	data := []byte("version: 1\nkind: pipeline\nspec:\n  stages:\n  - name: build\n    type: ci\n")
+
+	deps, err := Extract(data)
+	if err != nil {
+		t.Fatalf("failed to extract dependencies: %s", err)
+	}
+	if deps != nil {
+		t.Errorf("expected no dependencies, got %v", deps)
+	}
+}
+
+func TestExtractInvalid(t *testing.T) {
+	data := []byte("version: 1\nkind: pipeline\nspec:\n  stages:\n  - name: build\n    depends_on: [1]\n")
+
+	if _, err := Extract(data); err == nil {
+		t.Errorf("expected an error for a non-string dependency")
+	}
+}
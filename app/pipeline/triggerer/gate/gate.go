@@ -0,0 +1,140 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/types/check"
+
+	"github.com/ghodss/yaml"
+)
+
+const (
+	// StageKind is the kind of the stages that represent approval gates.
+	// Such stages are never picked up by a runner.
+	StageKind = "approval"
+
+	stepTypeApproval = "approval"
+)
+
+// Gate is a manual approval gate declared in a v1 pipeline definition.
+type Gate struct {
+	// Timeout is the time the gate waits for a decision, zero means no timeout.
+	Timeout time.Duration
+	// Approvers is the list of principal UIDs or emails allowed to decide.
+	// If it's empty, any user who can execute the pipeline can decide.
+	Approvers []string
+}
+
+// Extract finds the approval steps in a v1 pipeline definition and returns the gates they declare,
+// keyed by the index of their stage. An approval step must be the only step of its stage.
+// The returned definition has the steps of the approval stages removed, so it can be parsed
+// by the pipeline spec parser and executed by the runners. If the definition doesn't contain
+// any approval steps, the original data is returned.
+func Extract(data []byte) ([]byte, map[int]Gate, error) {
+	if !bytes.Contains(data, []byte(stepTypeApproval)) {
+		return data, nil, nil
+	}
+
+	raw, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		// let the pipeline spec parser report the error
+		return data, nil, nil //nolint:nilerr
+	}
+
+	doc := map[string]any{}
+	if err = json.Unmarshal(raw, &doc); err != nil {
+		return data, nil, nil //nolint:nilerr
+	}
+
+	spec, _ := doc["spec"].(map[string]any)
+	stages, _ := spec["stages"].([]any)
+
+	gates := map[int]Gate{}
+	for idx, s := range stages {
+		stage, _ := s.(map[string]any)
+		stageSpec, _ := stage["spec"].(map[string]any)
+		steps, _ := stageSpec["steps"].([]any)
+
+		for _, st := range steps {
+			step, _ := st.(map[string]any)
+			if step["type"] != stepTypeApproval {
+				continue
+			}
+
+			if len(steps) != 1 {
+				return nil, nil, fmt.Errorf("approval step must be the only step of stage %d", idx+1)
+			}
+
+			gate, err := parseGate(step)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid approval step in stage %d: %w", idx+1, err)
+			}
+
+			gates[idx] = gate
+
+			// the approval is handled by the server, the stage itself doesn't run anything.
+			stage["spec"] = map[string]any{}
+		}
+	}
+
+	if len(gates) == 0 {
+		return data, nil, nil
+	}
+
+	// JSON is valid YAML, so the output can be used in place of the original definition.
+	out, err := json.Marshal(doc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal pipeline definition: %w", err)
+	}
+
+	return out, gates, nil
+}
+
+func parseGate(step map[string]any) (Gate, error) {
+	var gate Gate
+
+	if timeout, ok := step["timeout"]; ok {
+		s, ok := timeout.(string)
+		if !ok {
+			return Gate{}, fmt.Errorf("timeout must be a duration string")
+		}
+		if err := check.Duration(s, 0, 0); err != nil {
+			return Gate{}, fmt.Errorf("invalid timeout: %w", err)
+		}
+		gate.Timeout, _ = time.ParseDuration(s)
+	}
+
+	spec, _ := step["spec"].(map[string]any)
+	if approvers, ok := spec["approvers"]; ok {
+		list, ok := approvers.([]any)
+		if !ok {
+			return Gate{}, fmt.Errorf("approvers must be a list")
+		}
+		for _, approver := range list {
+			s, ok := approver.(string)
+			if !ok || s == "" {
+				return Gate{}, fmt.Errorf("approvers must be non-empty strings")
+			}
+			gate.Approvers = append(gate.Approvers, s)
+		}
+	}
+
+	return gate, nil
+}
@@ -0,0 +1,145 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gate
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	v1yaml "github.com/drone/spec/dist/go"
+)
+
+func TestExtract(t *testing.T) {
+	data := []byte(`
+version: 1
+kind: pipeline
+spec:
+  stages:
+  - name: build
+    type: ci
+    spec:
+      steps:
+      - name: test
+        type: run
+        spec:
+          container: alpine
+          script: echo test
+  - name: approve
+    type: ci
+    spec:
+      steps:
+      - name: approve
+        type: approval
+        timeout: 1h
+        spec:
+          approvers: [admin, dev@example.com]
+`)
+
+	out, gates, err := Extract(data)
+	if err != nil {
+		t.Fatalf("failed to extract gates: %s", err)
+	}
+
+	want := map[int]Gate{
+		1: {Timeout: time.Hour, Approvers: []string{"admin", "dev@example.com"}},
+	}
+	if !reflect.DeepEqual(want, gates) {
+		t.Errorf("want=%v got=%v", want, gates)
+	}
+
+	config, err := v1yaml.ParseBytes(out)
+	if err != nil {
+		t.Fatalf("failed to parse the pipeline without approval steps: %s", err)
+	}
+
+	stages := config.Spec.(*v1yaml.Pipeline).Stages
+	if len(stages) != 2 {
+		t.Fatalf("expected 2 stages, got %d", len(stages))
+	}
+	if steps := stages[1].Spec.(*v1yaml.StageCI).Steps; len(steps) != 0 {
+		t.Errorf("expected the approval stage to have no steps, got %d", len(steps))
+	}
+}
+
+func TestExtractNoApprovals(t *testing.T) {
+	data := []byte("version: 1\nkind: pipeline\nspec:\n  stages: []\n")
+
+	out, gates, err := Extract(data)
+	if err != nil {
+		t.Fatalf("failed to extract gates: %s", err)
+	}
+	if gates != nil {
+		t.Errorf("expected no gates, got %v", gates)
+	}
+	if string(out) != string(data) {
+		t.Errorf("expected the definition to be unchanged")
+	}
+}
+
+func TestExtractInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{
+			name: "approval with other steps",
+			data: `
+spec:
+  stages:
+  - type: ci
+    spec:
+      steps:
+      - type: approval
+      - type: run
+        spec:
+          script: echo test
+`,
+		},
+		{
+			name: "invalid timeout",
+			data: `
+spec:
+  stages:
+  - type: ci
+    spec:
+      steps:
+      - type: approval
+        timeout: soon
+`,
+		},
+		{
+			name: "invalid approvers",
+			data: `
+spec:
+  stages:
+  - type: ci
+    spec:
+      steps:
+      - type: approval
+        spec:
+          approvers: admin
+`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, _, err := Extract([]byte(test.data)); err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,264 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matrix
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/drone-runners/drone-runner-docker/engine2/script"
+	v1yaml "github.com/drone/spec/dist/go"
+	"github.com/drone/spec/dist/go/parse/normalize"
+)
+
+// MaxLegs is the maximum number of legs a single matrix stage can expand to.
+const MaxLegs = 64
+
+// Leg is a single permutation of a matrix stage.
+type Leg struct {
+	Name string
+	Axis map[string]string
+}
+
+// Legs returns the legs the stage fans out to, or nil if the stage has no matrix strategy.
+// The permutations are the cartesian product of all axis values (keys in sorted order)
+// minus the excluded combinations, followed by the explicitly included combinations.
+// Leg names are derived from the (normalized) stage id and are stable across calls.
+func Legs(stage *v1yaml.Stage) ([]Leg, error) {
+	spec := matrixOf(stage)
+	if spec == nil {
+		return nil, nil
+	}
+
+	keys := make([]string, 0, len(spec.Axis))
+	for k := range spec.Axis {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var axes []map[string]string
+	if len(keys) > 0 {
+		axes = []map[string]string{{}}
+	}
+	for _, k := range keys {
+		next := make([]map[string]string, 0, len(axes)*len(spec.Axis[k]))
+		for _, axis := range axes {
+			for _, v := range spec.Axis[k] {
+				perm := make(map[string]string, len(axis)+1)
+				for kk, vv := range axis {
+					perm[kk] = vv
+				}
+				perm[k] = v
+				next = append(next, perm)
+			}
+		}
+		axes = next
+		if len(axes) > MaxLegs {
+			return nil, fmt.Errorf("matrix of stage %q exceeds the maximum of %d legs", stage.Id, MaxLegs)
+		}
+	}
+
+	legs := make([]Leg, 0, len(axes)+len(spec.Include))
+	for _, axis := range axes {
+		if isExcluded(axis, spec.Exclude) {
+			continue
+		}
+		legs = append(legs, Leg{Axis: axis})
+	}
+	for _, include := range spec.Include {
+		if len(include) == 0 {
+			continue
+		}
+		legs = append(legs, Leg{Axis: include})
+	}
+
+	if len(legs) == 0 {
+		return nil, fmt.Errorf("matrix of stage %q doesn't produce any legs", stage.Id)
+	}
+	if len(legs) > MaxLegs {
+		return nil, fmt.Errorf("matrix of stage %q exceeds the maximum of %d legs", stage.Id, MaxLegs)
+	}
+
+	for i := range legs {
+		// Stage IDs are slugs containing only lowercase letters and numbers.
+		legs[i].Name = stage.Id + "leg" + strconv.Itoa(i+1)
+	}
+
+	return legs, nil
+}
+
+// ResolveConfig takes a v1 pipeline definition and returns a definition containing only the
+// leg of a matrix stage with the provided name. The returned stage has no matrix strategy,
+// the leg's values are exposed as stage environment variables and all matrix expressions
+// (for example ${{ matrix.os }}) are replaced with the leg's values.
+// The returned definition is JSON, which is a valid YAML document.
+func ResolveConfig(data []byte, legName string) ([]byte, error) {
+	config, err := v1yaml.ParseBytes(data)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse v1 yaml: %w", err)
+	}
+
+	// Normalize the config the same way the triggerer does to get matching stage IDs.
+	err = normalize.Normalize(config)
+	if err != nil {
+		return nil, fmt.Errorf("could not normalize v1 yaml: %w", err)
+	}
+
+	pipeline, ok := config.Spec.(*v1yaml.Pipeline)
+	if !ok {
+		return nil, fmt.Errorf("config is not a pipeline")
+	}
+
+	for _, stage := range pipeline.Stages {
+		if !strings.HasPrefix(legName, stage.Id) {
+			continue
+		}
+
+		legs, err := Legs(stage)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, leg := range legs {
+			if leg.Name != legName {
+				continue
+			}
+
+			resolved, err := resolveStage(stage, leg)
+			if err != nil {
+				return nil, err
+			}
+
+			pipeline.Stages = []*v1yaml.Stage{resolved}
+
+			return json.Marshal(config)
+		}
+	}
+
+	return nil, fmt.Errorf("matrix leg %q not found in the pipeline", legName)
+}
+
+func resolveStage(stage *v1yaml.Stage, leg Leg) (*v1yaml.Stage, error) {
+	stage.Id = leg.Name
+	stage.Strategy = nil
+
+	if spec, ok := stage.Spec.(*v1yaml.StageCI); ok {
+		envs := make(map[string]string, len(leg.Axis)+len(spec.Envs))
+		for k, v := range leg.Axis {
+			envs[k] = v
+		}
+		for k, v := range spec.Envs {
+			envs[k] = v
+		}
+		spec.Envs = envs
+	}
+
+	raw, err := json.Marshal(stage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal stage: %w", err)
+	}
+
+	var tree any
+	if err = json.Unmarshal(raw, &tree); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal stage: %w", err)
+	}
+
+	inputs := map[string]any{"matrix": leg.Axis}
+	raw, err = json.Marshal(expand(tree, inputs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal resolved stage: %w", err)
+	}
+
+	resolved := new(v1yaml.Stage)
+	if err = json.Unmarshal(raw, resolved); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal resolved stage: %w", err)
+	}
+
+	return resolved, nil
+}
+
+// expand replaces all expressions that reference the matrix in all strings of the tree.
+// Other expressions are left untouched, they are evaluated by the runner.
+func expand(tree any, inputs map[string]any) any {
+	switch v := tree.(type) {
+	case string:
+		return expandString(v, inputs)
+	case []any:
+		for i := range v {
+			v[i] = expand(v[i], inputs)
+		}
+	case map[string]any:
+		for k := range v {
+			v[k] = expand(v[k], inputs)
+		}
+	}
+	return tree
+}
+
+func expandString(s string, inputs map[string]any) string {
+	var sb strings.Builder
+	for {
+		start := strings.Index(s, "${{")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(s[start:], "}}")
+		if end < 0 {
+			break
+		}
+		end += start + len("}}")
+
+		expr := s[start:end]
+		if strings.Contains(expr, "matrix.") {
+			expr = script.Expand(expr, inputs)
+		}
+
+		sb.WriteString(s[:start])
+		sb.WriteString(expr)
+		s = s[end:]
+	}
+	sb.WriteString(s)
+	return sb.String()
+}
+
+func matrixOf(stage *v1yaml.Stage) *v1yaml.Matrix {
+	if stage.Strategy == nil {
+		return nil
+	}
+	spec, _ := stage.Strategy.Spec.(*v1yaml.Matrix)
+	return spec
+}
+
+func isExcluded(axis map[string]string, excludes []map[string]string) bool {
+	for _, exclude := range excludes {
+		if len(exclude) == 0 {
+			continue
+		}
+		match := true
+		for k, v := range exclude {
+			if axis[k] != v {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,141 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matrix
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	v1yaml "github.com/drone/spec/dist/go"
+	"github.com/drone/spec/dist/go/parse/normalize"
+)
+
+const pipelineYAML = `
+version: 1
+kind: pipeline
+spec:
+  stages:
+  - name: build
+    type: ci
+    strategy:
+      type: matrix
+      spec:
+        axis:
+          os: [linux, windows]
+          go: ["1.20", "1.21"]
+        exclude:
+        - os: windows
+          go: "1.20"
+        include:
+        - os: darwin
+          go: "1.21"
+    spec:
+      envs:
+        os: overridden
+      steps:
+      - name: test
+        type: run
+        spec:
+          container: golang:${{ matrix.go }}
+          script: echo ${{ build.number }} && go test ./...
+  - name: notify
+    type: ci
+    spec:
+      steps:
+      - name: notify
+        type: run
+        spec:
+          container: alpine
+          script: echo done
+`
+
+func TestLegs(t *testing.T) {
+	config, err := v1yaml.ParseString(pipelineYAML)
+	if err != nil {
+		t.Fatalf("failed to parse yaml: %s", err)
+	}
+	if err = normalize.Normalize(config); err != nil {
+		t.Fatalf("failed to normalize yaml: %s", err)
+	}
+
+	stages := config.Spec.(*v1yaml.Pipeline).Stages
+
+	legs, err := Legs(stages[0])
+	if err != nil {
+		t.Fatalf("failed to calculate legs: %s", err)
+	}
+
+	want := []Leg{
+		{Name: "buildleg1", Axis: map[string]string{"go": "1.20", "os": "linux"}},
+		{Name: "buildleg2", Axis: map[string]string{"go": "1.21", "os": "linux"}},
+		{Name: "buildleg3", Axis: map[string]string{"go": "1.21", "os": "windows"}},
+		{Name: "buildleg4", Axis: map[string]string{"go": "1.21", "os": "darwin"}},
+	}
+	if !reflect.DeepEqual(want, legs) {
+		t.Errorf("want=%v got=%v", want, legs)
+	}
+
+	legs, err = Legs(stages[1])
+	if err != nil {
+		t.Fatalf("failed to calculate legs: %s", err)
+	}
+	if legs != nil {
+		t.Errorf("expected no legs for a stage without matrix, got %v", legs)
+	}
+}
+
+func TestResolveConfig(t *testing.T) {
+	data, err := ResolveConfig([]byte(pipelineYAML), "buildleg3")
+	if err != nil {
+		t.Fatalf("failed to resolve config: %s", err)
+	}
+
+	config, err := v1yaml.ParseBytes(data)
+	if err != nil {
+		t.Fatalf("failed to parse resolved config: %s", err)
+	}
+
+	stages := config.Spec.(*v1yaml.Pipeline).Stages
+	if len(stages) != 1 {
+		t.Fatalf("expected a single stage, got %d", len(stages))
+	}
+
+	stage := stages[0]
+	if stage.Id != "buildleg3" {
+		t.Errorf("unexpected stage id: %s", stage.Id)
+	}
+	if stage.Strategy != nil {
+		t.Errorf("expected the matrix strategy to be removed")
+	}
+
+	spec := stage.Spec.(*v1yaml.StageCI)
+	wantEnvs := map[string]string{"go": "1.21", "os": "overridden"}
+	if !reflect.DeepEqual(wantEnvs, spec.Envs) {
+		t.Errorf("want envs=%v got=%v", wantEnvs, spec.Envs)
+	}
+
+	run := spec.Steps[0].Spec.(*v1yaml.StepRun)
+	if run.Container.Image != "golang:1.21" {
+		t.Errorf("unexpected image: %s", run.Container.Image)
+	}
+	if !strings.Contains(strings.Join(run.Script, ""), "${{ build.number }}") {
+		t.Errorf("expected non-matrix expressions to be preserved: %v", run.Script)
+	}
+
+	if _, err = ResolveConfig([]byte(pipelineYAML), "buildleg9"); err == nil {
+		t.Errorf("expected an error for an unknown leg")
+	}
+}
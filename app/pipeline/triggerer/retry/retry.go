@@ -0,0 +1,133 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	v1yaml "github.com/drone/spec/dist/go"
+	"github.com/drone/spec/dist/go/parse/normalize"
+)
+
+// ErrStepRetryUnsupported is returned if a retry from a step is requested for a legacy pipeline definition.
+var ErrStepRetryUnsupported = errors.New("retrying from a step is only supported for v1 pipelines")
+
+// Retry describes the part of a finished execution that is retried by a new execution.
+type Retry struct {
+	// Stages are the stages of the retried execution.
+	Stages []*types.Stage
+	// Stage is the name of the retried stage. If empty, the whole execution is retried.
+	Stage string
+	// Step is the name of the step of the retried stage the retry starts from.
+	// If empty, the whole stage is retried.
+	Step string
+}
+
+// Apply prepares the stages of the retry execution. Only the retried stage gets executed,
+// all other stages keep the results of the retried execution.
+// Stages that didn't exist in the retried execution are skipped.
+func (r *Retry) Apply(stages []*types.Stage) error {
+	if r.Stage == "" {
+		return nil
+	}
+
+	prev := make(map[string]*types.Stage, len(r.Stages))
+	for _, stage := range r.Stages {
+		prev[stage.Name] = stage
+	}
+
+	found := false
+	for _, stage := range stages {
+		if stage.Name == r.Stage {
+			// the stages the retried stage depends on have already completed.
+			stage.DependsOn = nil
+			stage.Status = enum.CIStatusPending
+			stage.RetryFrom = r.Step
+			found = true
+			continue
+		}
+
+		// approvals of stages that aren't executed aren't needed.
+		stage.Approval = nil
+
+		p, ok := prev[stage.Name]
+		if !ok {
+			stage.Status = enum.CIStatusSkipped
+			continue
+		}
+
+		stage.Status = p.Status
+		stage.Error = p.Error
+		stage.ErrIgnore = p.ErrIgnore
+		stage.ExitCode = p.ExitCode
+		stage.Machine = p.Machine
+		stage.Started = p.Started
+		stage.Stopped = p.Stopped
+	}
+
+	if !found {
+		return fmt.Errorf("stage %q doesn't exist in the pipeline anymore", r.Stage)
+	}
+
+	return nil
+}
+
+// ResolveConfig takes a v1 pipeline definition and returns a definition in which the stage
+// with the provided name starts with the step with the provided name; all steps before it are removed.
+// The returned definition is JSON, which is a valid YAML document.
+func ResolveConfig(data []byte, stageName, stepName string) ([]byte, error) {
+	config, err := v1yaml.ParseBytes(data)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse v1 yaml: %w", err)
+	}
+
+	// Normalize the config the same way the triggerer does to get matching stage and step IDs.
+	err = normalize.Normalize(config)
+	if err != nil {
+		return nil, fmt.Errorf("could not normalize v1 yaml: %w", err)
+	}
+
+	pipeline, ok := config.Spec.(*v1yaml.Pipeline)
+	if !ok {
+		return nil, fmt.Errorf("config is not a pipeline")
+	}
+
+	for _, stage := range pipeline.Stages {
+		if stage.Id != stageName {
+			continue
+		}
+
+		spec, ok := stage.Spec.(*v1yaml.StageCI)
+		if !ok {
+			return nil, fmt.Errorf("stage %q isn't a CI stage", stageName)
+		}
+
+		for idx, step := range spec.Steps {
+			if step.Id == stepName {
+				spec.Steps = spec.Steps[idx:]
+				return json.Marshal(config)
+			}
+		}
+
+		return nil, fmt.Errorf("step %q not found in the top level steps of stage %q", stepName, stageName)
+	}
+
+	return nil, fmt.Errorf("stage %q not found in the pipeline", stageName)
+}
@@ -0,0 +1,109 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	v1yaml "github.com/drone/spec/dist/go"
+)
+
+func TestApply(t *testing.T) {
+	r := &Retry{
+		Stages: []*types.Stage{
+			{Name: "build", Status: enum.CIStatusSuccess, ExitCode: 0, Started: 10, Stopped: 20},
+			{Name: "test", Status: enum.CIStatusFailure, ExitCode: 1, Started: 20, Stopped: 30},
+		},
+		Stage: "test",
+		Step:  "unit",
+	}
+
+	stages := []*types.Stage{
+		{Name: "build", Status: enum.CIStatusPending},
+		{Name: "test", Status: enum.CIStatusWaitingOnDeps, DependsOn: []string{"build"}},
+		{Name: "deploy", Status: enum.CIStatusWaitingOnDeps, DependsOn: []string{"test"}},
+	}
+
+	if err := r.Apply(stages); err != nil {
+		t.Fatalf("failed to apply retry: %s", err)
+	}
+
+	if stages[0].Status != enum.CIStatusSuccess || stages[0].Started != 10 || stages[0].Stopped != 20 {
+		t.Errorf("expected the build stage to keep its results, got %+v", stages[0])
+	}
+	if stages[1].Status != enum.CIStatusPending || len(stages[1].DependsOn) != 0 || stages[1].RetryFrom != "unit" {
+		t.Errorf("expected the test stage to be retried, got %+v", stages[1])
+	}
+	if stages[2].Status != enum.CIStatusSkipped {
+		t.Errorf("expected the new deploy stage to be skipped, got %s", stages[2].Status)
+	}
+}
+
+func TestApplyMissingStage(t *testing.T) {
+	r := &Retry{Stage: "test"}
+	if err := r.Apply([]*types.Stage{{Name: "build"}}); err == nil {
+		t.Errorf("expected an error for a stage that doesn't exist")
+	}
+}
+
+func TestResolveConfig(t *testing.T) {
+	data := []byte(`
+version: 1
+kind: pipeline
+spec:
+  stages:
+  - name: build
+    type: ci
+    spec:
+      steps:
+      - name: prepare
+        type: run
+        spec:
+          container: alpine
+          script: echo prepare
+      - name: test
+        type: run
+        spec:
+          container: alpine
+          script: echo test
+      - name: publish
+        type: run
+        spec:
+          container: alpine
+          script: echo publish
+`)
+
+	out, err := ResolveConfig(data, "build", "test")
+	if err != nil {
+		t.Fatalf("failed to resolve config: %s", err)
+	}
+
+	config, err := v1yaml.ParseBytes(out)
+	if err != nil {
+		t.Fatalf("failed to parse the resolved config: %s", err)
+	}
+
+	steps := config.Spec.(*v1yaml.Pipeline).Stages[0].Spec.(*v1yaml.StageCI).Steps
+	if len(steps) != 2 || steps[0].Id != "test" || steps[1].Id != "publish" {
+		t.Errorf("expected the steps test and publish, got %d steps", len(steps))
+	}
+
+	if _, err = ResolveConfig(data, "build", "lint"); err == nil {
+		t.Errorf("expected an error for a step that doesn't exist")
+	}
+}
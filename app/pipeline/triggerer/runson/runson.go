@@ -0,0 +1,114 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ghodss/yaml"
+)
+
+const keyRunsOn = "runs_on"
+
+// Extract finds the runner labels required with `runs_on` in a v1 pipeline definition
+// and returns them keyed by the index of their stage. The labels are either a single label
+// or a list of labels. The labels declared for the whole pipeline apply to the stages
+// that don't declare their own. Stages without labels can run on any runner.
+// If the definition doesn't require any labels, nil is returned.
+// The pipeline spec parser ignores the declarations, so the definition doesn't need to be changed.
+func Extract(data []byte) (map[int][]string, error) {
+	if !bytes.Contains(data, []byte(keyRunsOn)) {
+		return nil, nil //nolint:nilnil // no labels required
+	}
+
+	raw, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		// let the pipeline spec parser report the error
+		return nil, nil //nolint:nilerr,nilnil
+	}
+
+	doc := map[string]any{}
+	if err = json.Unmarshal(raw, &doc); err != nil {
+		return nil, nil //nolint:nilerr,nilnil
+	}
+
+	spec, _ := doc["spec"].(map[string]any)
+	stages, _ := spec["stages"].([]any)
+
+	var defaults []string
+	if value, ok := spec[keyRunsOn]; ok {
+		defaults, err = parseRunsOn(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid runner labels of the pipeline: %w", err)
+		}
+	}
+
+	labels := make(map[int][]string, len(stages))
+	found := false
+	for idx, s := range stages {
+		stage, _ := s.(map[string]any)
+		value, ok := stage[keyRunsOn]
+		if !ok {
+			labels[idx] = defaults
+			found = found || len(defaults) > 0
+			continue
+		}
+
+		stageLabels, err := parseRunsOn(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid runner labels of stage %d: %w", idx+1, err)
+		}
+
+		labels[idx] = stageLabels
+		found = found || len(stageLabels) > 0
+	}
+
+	if !found {
+		return nil, nil //nolint:nilnil // no labels required
+	}
+
+	return labels, nil
+}
+
+func parseRunsOn(value any) ([]string, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		if v == "" {
+			return nil, fmt.Errorf("runner labels must be non-empty strings")
+		}
+		return []string{v}, nil
+	case []any:
+		labels := make([]string, 0, len(v))
+		seen := make(map[string]struct{}, len(v))
+		for _, item := range v {
+			label, ok := item.(string)
+			if !ok || label == "" {
+				return nil, fmt.Errorf("runner labels must be non-empty strings")
+			}
+			if _, ok := seen[label]; ok {
+				continue
+			}
+			seen[label] = struct{}{}
+			labels = append(labels, label)
+		}
+		return labels, nil
+	default:
+		return nil, fmt.Errorf("%s must be a runner label or a list of runner labels", keyRunsOn)
+	}
+}
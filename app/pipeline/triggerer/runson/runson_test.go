@@ -0,0 +1,78 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runson
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtract(t *testing.T) {
+	data := []byte(`
+version: 1
+kind: pipeline
+spec:
+  runs_on: linux
+  stages:
+  - name: build
+    type: ci
+    spec:
+      steps: []
+  - name: train
+    type: ci
+    runs_on: [linux, gpu, gpu]
+    spec:
+      steps: []
+  - name: notify
+    type: ci
+    runs_on: []
+    spec:
+      steps: []
+`)
+
+	labels, err := Extract(data)
+	if err != nil {
+		t.Fatalf("failed to extract runner labels: %s", err)
+	}
+
+	want := map[int][]string{
+		0: {"linux"},
+		1: {"linux", "gpu"},
+		2: {},
+	}
+	if !reflect.DeepEqual(want, labels) {
+		t.Errorf("want=%v got=%v", want, labels)
+	}
+}
+
+func TestExtractNoLabels(t *testing.T) {
+	data := []byte("version: 1\nkind: pipeline\nspec:\n  stages:\n  - name: build\n    type: ci\n")
+
+	labels, err := Extract(data)
+	if err != nil {
+		t.Fatalf("failed to extract runner labels: %s", err)
+	}
+	if labels != nil {
+		t.Errorf("expected no labels, got %v", labels)
+	}
+}
+
+func TestExtractInvalid(t *testing.T) {
+	data := []byte("version: 1\nkind: pipeline\nspec:\n  stages:\n  - name: build\n    runs_on: [1]\n")
+
+	if _, err := Extract(data); err == nil {
+		t.Errorf("expected an error for a non-string label")
+	}
+}
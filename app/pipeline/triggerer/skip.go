@@ -15,9 +15,22 @@
 package triggerer
 
 import (
+	"strings"
+
+	"github.com/harness/gitness/types/enum"
+
 	"github.com/drone/drone-yaml/yaml"
 )
 
+// skipInstructions are the markers in the commit message or title of a push or pull request
+// that prevent the pipelines from being triggered.
+var skipInstructions = []string{
+	"[skip ci]",
+	"[ci skip]",
+	"[no ci]",
+	"***no_ci***",
+}
+
 func skipBranch(document *yaml.Pipeline, branch string) bool {
 	return !document.Trigger.Branch.Match(branch)
 }
@@ -41,3 +54,26 @@ func skipRepo(document *yaml.Pipeline, repo string) bool {
 func skipCron(document *yaml.Pipeline, cron string) bool {
 	return !document.Trigger.Cron.Match(cron)
 }
+
+// skipMessage returns true if the hook was triggered by a push or a pull request
+// and its title or message contains an instruction to skip the execution.
+func skipMessage(hook *Hook) bool {
+	if hook.Trigger != enum.TriggerHook {
+		return false
+	}
+
+	switch hook.Action.GetTriggerEvent() {
+	case enum.TriggerEventPush, enum.TriggerEventPullRequest:
+	default:
+		return false
+	}
+
+	text := strings.ToLower(hook.Title + "\n" + hook.Message)
+	for _, instruction := range skipInstructions {
+		if strings.Contains(text, instruction) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,68 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerer
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestSkipMessage(t *testing.T) {
+	tests := []struct {
+		name string
+		hook Hook
+		want bool
+	}{
+		{
+			name: "push-skip",
+			hook: Hook{Trigger: enum.TriggerHook, Action: enum.TriggerActionBranchUpdated, Message: "docs: typo [skip ci]"},
+			want: true,
+		},
+		{
+			name: "push-skip-case-insensitive",
+			hook: Hook{Trigger: enum.TriggerHook, Action: enum.TriggerActionBranchCreated, Title: "Update README [CI SKIP]"},
+			want: true,
+		},
+		{
+			name: "pullreq-skip",
+			hook: Hook{Trigger: enum.TriggerHook, Action: enum.TriggerActionPullReqCreated, Title: "[skip ci] WIP"},
+			want: true,
+		},
+		{
+			name: "push-no-skip",
+			hook: Hook{Trigger: enum.TriggerHook, Action: enum.TriggerActionBranchUpdated, Message: "skip ci"},
+			want: false,
+		},
+		{
+			name: "tag",
+			hook: Hook{Trigger: enum.TriggerHook, Action: enum.TriggerActionTagCreated, Message: "[skip ci]"},
+			want: false,
+		},
+		{
+			name: "manual",
+			hook: Hook{Trigger: "admin", Action: enum.TriggerActionBranchUpdated, Message: "[skip ci]"},
+			want: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := skipMessage(&test.hook); got != test.want {
+				t.Errorf("want=%t got=%t", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timeout
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/types/check"
+
+	v1yaml "github.com/drone/spec/dist/go"
+	"github.com/ghodss/yaml"
+)
+
+const (
+	keyTimeout = "timeout"
+
+	// minTimeout is the shortest timeout that can be declared.
+	minTimeout = time.Second
+)
+
+// Extract finds the timeouts declared with `timeout` on the stages of a v1 pipeline definition
+// and returns them keyed by the index of their stage. If no stage declares a timeout, nil is returned.
+// The pipeline spec parser ignores the declarations, so the definition doesn't need to be changed.
+// The pipeline and step timeouts are part of the pipeline spec, see Parse and Steps.
+func Extract(data []byte) (map[int]time.Duration, error) {
+	if !bytes.Contains(data, []byte(keyTimeout)) {
+		return nil, nil //nolint:nilnil // no timeouts declared
+	}
+
+	raw, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		// let the pipeline spec parser report the error
+		return nil, nil //nolint:nilerr,nilnil
+	}
+
+	doc := map[string]any{}
+	if err = json.Unmarshal(raw, &doc); err != nil {
+		return nil, nil //nolint:nilerr,nilnil
+	}
+
+	spec, _ := doc["spec"].(map[string]any)
+	stages, _ := spec["stages"].([]any)
+
+	var timeouts map[int]time.Duration
+	for idx, s := range stages {
+		stage, _ := s.(map[string]any)
+		value, ok := stage[keyTimeout]
+		if !ok {
+			continue
+		}
+
+		str, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("timeout of stage %d must be a duration string", idx+1)
+		}
+
+		timeout, err := Parse(str)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout of stage %d: %w", idx+1, err)
+		}
+
+		if timeouts == nil {
+			timeouts = make(map[int]time.Duration)
+		}
+		timeouts[idx] = timeout
+	}
+
+	return timeouts, nil
+}
+
+// Parse parses a timeout declaration. An empty declaration means no timeout.
+func Parse(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	if err := check.Duration(value, minTimeout, 0); err != nil {
+		return 0, err
+	}
+
+	timeout, _ := time.ParseDuration(value)
+
+	return timeout, nil
+}
+
+// Steps returns the timeouts declared by the steps of a CI stage, including the steps of step groups,
+// keyed by the step ID. The stage must be normalized, so that all steps have a unique ID.
+// If no step declares a timeout, nil is returned.
+func Steps(stage *v1yaml.StageCI) (map[string]time.Duration, error) {
+	var timeouts map[string]time.Duration
+
+	var walk func(steps []*v1yaml.Step) error
+	walk = func(steps []*v1yaml.Step) error {
+		for _, step := range steps {
+			if step == nil {
+				continue
+			}
+
+			switch spec := step.Spec.(type) {
+			case *v1yaml.StepGroup:
+				if err := walk(spec.Steps); err != nil {
+					return err
+				}
+			case *v1yaml.StepParallel:
+				if err := walk(spec.Steps); err != nil {
+					return err
+				}
+			}
+
+			timeout, err := Parse(step.Timeout)
+			if err != nil {
+				return fmt.Errorf("invalid timeout of step %q: %w", step.Id, err)
+			}
+			if timeout == 0 {
+				continue
+			}
+
+			if timeouts == nil {
+				timeouts = make(map[string]time.Duration)
+			}
+			timeouts[step.Id] = timeout
+		}
+
+		return nil
+	}
+
+	if err := walk(stage.Steps); err != nil {
+		return nil, err
+	}
+
+	return timeouts, nil
+}
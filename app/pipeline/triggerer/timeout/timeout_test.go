@@ -0,0 +1,102 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timeout
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	v1yaml "github.com/drone/spec/dist/go"
+)
+
+func TestExtract(t *testing.T) {
+	data := []byte(`
+version: 1
+kind: pipeline
+spec:
+  stages:
+  - name: build
+    type: ci
+    timeout: 30m
+    spec:
+      steps: []
+  - name: test
+    type: ci
+    spec:
+      steps: []
+`)
+
+	timeouts, err := Extract(data)
+	if err != nil {
+		t.Fatalf("failed to extract stage timeouts: %s", err)
+	}
+
+	want := map[int]time.Duration{0: 30 * time.Minute}
+	if !reflect.DeepEqual(want, timeouts) {
+		t.Errorf("want=%v got=%v", want, timeouts)
+	}
+}
+
+func TestExtractInvalid(t *testing.T) {
+	tests := []string{
+		"timeout: 10",
+		"timeout: forever",
+		"timeout: 10ms",
+	}
+
+	for _, test := range tests {
+		data := []byte(`
+version: 1
+kind: pipeline
+spec:
+  stages:
+  - name: build
+    type: ci
+    ` + test + `
+    spec:
+      steps: []
+`)
+
+		if _, err := Extract(data); err == nil {
+			t.Errorf("expected an error for %q", test)
+		}
+	}
+}
+
+func TestSteps(t *testing.T) {
+	stage := &v1yaml.StageCI{
+		Steps: []*v1yaml.Step{
+			{Id: "compile", Timeout: "5m"},
+			{Id: "lint"},
+			{Id: "group", Spec: &v1yaml.StepGroup{
+				Steps: []*v1yaml.Step{{Id: "unit", Timeout: "90s"}},
+			}},
+		},
+	}
+
+	timeouts, err := Steps(stage)
+	if err != nil {
+		t.Fatalf("failed to get step timeouts: %s", err)
+	}
+
+	want := map[string]time.Duration{
+		"compile": 5 * time.Minute,
+		"unit":    90 * time.Second,
+	}
+	if !reflect.DeepEqual(want, timeouts) {
+		t.Errorf("want=%v got=%v", want, timeouts)
+	}
+}
@@ -15,17 +15,32 @@
 package triggerer
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"regexp"
 	"runtime/debug"
 	"time"
 
+	"github.com/harness/gitness/app/pipeline/artifactstep"
+	"github.com/harness/gitness/app/pipeline/cachestep"
+	"github.com/harness/gitness/app/pipeline/cachevolume"
+	"github.com/harness/gitness/app/pipeline/canceler"
 	"github.com/harness/gitness/app/pipeline/checks"
 	"github.com/harness/gitness/app/pipeline/file"
 	"github.com/harness/gitness/app/pipeline/manager"
 	"github.com/harness/gitness/app/pipeline/scheduler"
+	"github.com/harness/gitness/app/pipeline/secrets"
+	"github.com/harness/gitness/app/pipeline/template"
+	"github.com/harness/gitness/app/pipeline/triggerer/concurrency"
+	"github.com/harness/gitness/app/pipeline/triggerer/condition"
 	"github.com/harness/gitness/app/pipeline/triggerer/dag"
+	"github.com/harness/gitness/app/pipeline/triggerer/gate"
+	"github.com/harness/gitness/app/pipeline/triggerer/matrix"
+	"github.com/harness/gitness/app/pipeline/triggerer/retry"
+	"github.com/harness/gitness/app/pipeline/triggerer/runson"
+	"github.com/harness/gitness/app/pipeline/triggerer/timeout"
+	"github.com/harness/gitness/app/pipeline/variables"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/store/database/dbtx"
@@ -67,6 +82,17 @@ type Hook struct {
 	Cron         string             `json:"cron"`
 	Sender       string             `json:"sender"`
 	Params       map[string]string  `json:"params"`
+
+	// Retry is set if the execution retries (a part of) a finished execution, which is the parent.
+	Retry *retry.Retry `json:"-"`
+}
+
+// event returns the trigger event of the hook.
+func (h *Hook) event() string {
+	if h.Trigger == enum.TriggerCron {
+		return enum.TriggerEventCron
+	}
+	return string(h.Action.GetTriggerEvent())
 }
 
 // Triggerer is responsible for triggering a Execution from an
@@ -74,41 +100,66 @@ type Hook struct {
 // returned.
 type Triggerer interface {
 	Trigger(ctx context.Context, pipeline *types.Pipeline, hook *Hook) (*types.Execution, error)
+
+	// Validate parses and lints a pipeline definition of the repository the same way
+	// it's done when an execution is triggered, and returns the problems found.
+	Validate(ctx context.Context, repo *types.Repository, data []byte) *types.PipelineValidation
 }
 
 type triggerer struct {
 	executionStore store.ExecutionStore
 	checkStore     store.CheckStore
 	stageStore     store.StageStore
+	approvalStore  store.ApprovalStore
 	tx             dbtx.Transactor
 	pipelineStore  store.PipelineStore
 	fileService    file.Service
 	urlProvider    url.Provider
 	scheduler      scheduler.Scheduler
+	canceler       canceler.Canceler
 	repoStore      store.RepoStore
+	spaceStore     store.SpaceStore
+	templateStore  store.TemplateStore
+	variableStore  store.VariableStore
+	secretResolver *secrets.Resolver
+	defaultTimeout time.Duration
 }
 
 func New(
 	executionStore store.ExecutionStore,
 	checkStore store.CheckStore,
 	stageStore store.StageStore,
+	approvalStore store.ApprovalStore,
 	pipelineStore store.PipelineStore,
 	tx dbtx.Transactor,
 	repoStore store.RepoStore,
 	urlProvider url.Provider,
 	scheduler scheduler.Scheduler,
+	canceler canceler.Canceler,
 	fileService file.Service,
+	spaceStore store.SpaceStore,
+	templateStore store.TemplateStore,
+	variableStore store.VariableStore,
+	secretResolver *secrets.Resolver,
+	defaultTimeout time.Duration,
 ) Triggerer {
 	return &triggerer{
 		executionStore: executionStore,
 		checkStore:     checkStore,
 		stageStore:     stageStore,
+		approvalStore:  approvalStore,
 		scheduler:      scheduler,
+		canceler:       canceler,
 		urlProvider:    urlProvider,
 		tx:             tx,
 		pipelineStore:  pipelineStore,
 		fileService:    fileService,
 		repoStore:      repoStore,
+		spaceStore:     spaceStore,
+		templateStore:  templateStore,
+		variableStore:  variableStore,
+		secretResolver: secretResolver,
+		defaultTimeout: defaultTimeout,
 	}
 }
 
@@ -134,7 +185,13 @@ func (t *triggerer) Trigger(
 		}
 	}()
 
-	event := string(base.Action.GetTriggerEvent())
+	event := base.event()
+
+	if skipMessage(base) {
+		log.Info().Msg("trigger: skipping execution, skip instruction found in the commit message")
+		//nolint:nilnil // on purpose
+		return nil, nil
+	}
 
 	repo, err := t.repoStore.Find(ctx, pipeline.RepoID)
 	if err != nil {
@@ -184,6 +241,7 @@ func (t *triggerer) Trigger(
 	// and creating stages accordingly. For V1 YAML - for now we can just parse the stages
 	// and create them sequentially.
 	stages := []*types.Stage{}
+	var conc *concurrency.Concurrency
 	//nolint:nestif // refactor if needed
 	if !isV1Yaml(file.Data) {
 		manifest, err := yaml.ParseString(string(file.Data))
@@ -305,10 +363,50 @@ func (t *triggerer) Trigger(
 			}
 		}
 	} else {
-		stages, err = parseV1Stages(file.Data, repo, execution)
+		// Templates are resolved first, as they can contain any of the other v1 extensions.
+		finder := template.SpaceFinder(ctx, t.spaceStore, t.templateStore, repo.ParentID)
+		data, err := template.Resolve(file.Data, finder)
+		if err != nil {
+			log.Warn().Err(err).Msg("trigger: cannot resolve templates")
+			return t.createExecutionWithError(ctx, pipeline, base, err.Error())
+		}
+
+		// Variables are only resolved if conditions can reference them.
+		var vars map[string]string
+		if bytes.Contains(data, []byte(condition.InputVariables)) {
+			vars, err = variables.Resolve(ctx, t.spaceStore, t.variableStore, t.secretResolver, repo)
+			if err != nil {
+				log.Error().Err(err).Msg("trigger: could not resolve variables")
+				return nil, err
+			}
+			vars = combine(vars, execution.Params)
+		}
+
+		stages, conc, err = parseV1Stages(data, repo, execution, vars)
 		if err != nil {
 			return nil, fmt.Errorf("could not parse v1 YAML into stages: %w", err)
 		}
+
+		if allSkipped(stages) {
+			log.Info().Msg("trigger: skipping execution, no stage conditions matched")
+			//nolint:nilnil // on purpose
+			return nil, nil
+		}
+	}
+
+	if execution.Timeout == 0 {
+		execution.Timeout = t.defaultTimeout.Milliseconds()
+	}
+
+	if base.Retry != nil {
+		if base.Retry.Step != "" && !isV1Yaml(file.Data) {
+			return nil, retry.ErrStepRetryUnsupported
+		}
+
+		if err = base.Retry.Apply(stages); err != nil {
+			log.Warn().Err(err).Msg("trigger: cannot retry execution")
+			return t.createExecutionWithError(ctx, pipeline, base, err.Error())
+		}
 	}
 
 	// Increment pipeline number using optimistic locking.
@@ -328,11 +426,19 @@ func (t *triggerer) Trigger(
 		return nil, err
 	}
 
+	if conc != nil && conc.CancelInProgress {
+		t.cancelInProgress(ctx, repo, execution)
+	}
+
 	// try to write to check store. log on failure but don't error out the execution
 	err = checks.Write(ctx, t.checkStore, execution, pipeline)
 	if err != nil {
 		log.Error().Err(err).Msg("trigger: could not write to check store")
 	}
+	err = checks.WriteStages(ctx, t.checkStore, execution, pipeline, stages)
+	if err != nil {
+		log.Error().Err(err).Msg("trigger: could not write stages to check store")
+	}
 
 	for _, stage := range stages {
 		if stage.Status != enum.CIStatusPending {
@@ -348,6 +454,29 @@ func (t *triggerer) Trigger(
 	return execution, nil
 }
 
+// cancelInProgress cancels the executions of the concurrency group of the execution
+// that were triggered before it and are still in progress.
+func (t *triggerer) cancelInProgress(ctx context.Context, repo *types.Repository, execution *types.Execution) {
+	executions, err := t.executionStore.ListIncomplete(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("trigger: failed to list executions in progress")
+		return
+	}
+
+	for _, e := range executions {
+		if e.RepoID != repo.ID || e.ConcurrencyGroup != execution.ConcurrencyGroup || e.ID >= execution.ID {
+			continue
+		}
+
+		if err = t.canceler.Cancel(ctx, repo, e); err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("execution.id", e.ID).
+				Str("execution.concurrency_group", e.ConcurrencyGroup).
+				Msg("trigger: failed to cancel execution of the concurrency group")
+		}
+	}
+}
+
 func trunc(s string, i int) string {
 	runes := []rune(s)
 	if len(runes) > i {
@@ -359,85 +488,357 @@ func trunc(s string, i int) string {
 // parseV1Stages tries to parse the yaml into a list of stages and returns an error
 // if we are unable to do so or the yaml contains something unexpected.
 // Currently, all the stages will be executed one after the other on completion.
+// Stages with a matrix strategy fan out into one stage per leg; the legs run in parallel
+// and the next stage waits for all of them.
 // Once we have depends on in v1, this will be changed to use the DAG.
 //
 //nolint:gocognit // refactor if needed.
-func parseV1Stages(data []byte, repo *types.Repository, execution *types.Execution) ([]*types.Stage, error) {
+func parseV1Stages(
+	data []byte,
+	repo *types.Repository,
+	execution *types.Execution,
+	vars map[string]string,
+) ([]*types.Stage, *concurrency.Concurrency, error) {
 	stages := []*types.Stage{}
-	// For V1 YAML, just go through the YAML and create stages serially for now
+
+	conc, err := concurrency.Parse(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not parse concurrency: %w", err)
+	}
+
+	// Approval steps aren't part of the pipeline spec, they are extracted before parsing.
+	data, gates, err := gate.Extract(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not parse approval steps: %w", err)
+	}
+
+	// Cache and artifact steps are converted to run steps before execution, the stages remain the same.
+	data, err = cachestep.Convert(data, cachestep.Options{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not parse cache steps: %w", err)
+	}
+
+	data, err = artifactstep.Convert(data, artifactstep.Options{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not parse artifact steps: %w", err)
+	}
+
+	// Cache volumes are converted to host volumes on the runner machine before execution.
+	data, err = cachevolume.Convert(data, cachevolume.Options{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not parse cache volumes: %w", err)
+	}
+
+	// Stages run serially, unless the pipeline declares the stage dependencies.
+	deps, err := dag.Extract(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not parse stage dependencies: %w", err)
+	}
+
+	// Stages run on any runner, unless the pipeline requires runner labels.
+	runsOn, err := runson.Extract(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not parse runner labels: %w", err)
+	}
+
+	// Stages run without a timeout of their own, unless the pipeline declares one.
+	stageTimeouts, err := timeout.Extract(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not parse stage timeouts: %w", err)
+	}
+
 	config, err := v1yaml.ParseBytes(data)
 	if err != nil {
-		return nil, fmt.Errorf("could not parse v1 yaml: %w", err)
+		return nil, nil, fmt.Errorf("could not parse v1 yaml: %w", err)
 	}
 
 	// Normalize the config to make sure stage names and step names are unique
 	err = normalize.Normalize(config)
 	if err != nil {
-		return nil, fmt.Errorf("could not normalize v1 yaml: %w", err)
+		return nil, nil, fmt.Errorf("could not normalize v1 yaml: %w", err)
 	}
 
 	if config.Kind != "pipeline" {
-		return nil, fmt.Errorf("cannot support non-pipeline kinds in v1 at the moment: %w", err)
+		return nil, nil, fmt.Errorf("cannot support non-pipeline kinds in v1 at the moment: %w", err)
 	}
 
 	inputParams := map[string]interface{}{}
 	inputParams["repo"] = inputs.Repo(manager.ConvertToDroneRepo(repo))
 	inputParams["build"] = inputs.Build(manager.ConvertToDroneBuild(execution))
 
-	var prevStage string
+	if conc != nil {
+		conc.Group = script.Expand(conc.Group, inputParams)
+		if err = concurrency.CheckGroup(conc.Group); err != nil {
+			return nil, nil, fmt.Errorf("invalid concurrency group: %w", err)
+		}
+		execution.ConcurrencyGroup = conc.Group
+	}
+
+	// conditions are evaluated against the execution and the variables of the repository
+	condCtx := condition.Context{
+		Branch:    execution.Target,
+		Event:     execution.Event,
+		Ref:       execution.Ref,
+		Variables: vars,
+	}
+
+	// names of the stages (or all legs of the matrix stage) the next stage depends on
+	var prevStages []string
+
+	// names of the stages (or all legs of the matrix stage) created for each stage of the pipeline
+	stageLegs := make(map[int][]string)
 
 	switch v := config.Spec.(type) {
 	case *v1yaml.Pipeline:
 		// Expand expressions in strings and matrices
 		script.ExpandConfig(config, inputParams)
+		// expanding the config leaks the matrix of the last matrix stage into the params
+		delete(inputParams, "matrix")
+
+		if v.Options != nil {
+			pipelineTimeout, err := timeout.Parse(v.Options.Timeout)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid pipeline timeout: %w", err)
+			}
+			execution.Timeout = pipelineTimeout.Milliseconds()
+		}
+
+		stageNames := make(map[string]struct{}, len(v.Stages))
+		for _, stage := range v.Stages {
+			stageNames[stage.Id] = struct{}{}
+		}
 
 		for idx, stage := range v.Stages {
 			// Only parse CI stages for now
-			switch stage.Spec.(type) {
+			switch stageSpec := stage.Spec.(type) {
 			case *v1yaml.StageCI:
-				now := time.Now().UnixMilli()
-				var onSuccess, onFailure bool
-				onSuccess = true
-				if stage.When != nil {
-					if when := stage.When.Eval; when != "" {
-						// TODO: pass in params for resolution
-						onSuccess, onFailure, err = script.EvalWhen(when, inputParams)
-						if err != nil {
-							return nil, fmt.Errorf("could not resolve when condition for stage: %w", err)
-						}
-					}
+				approvalGate, isGate := gates[idx]
+				if isGate && stage.Strategy != nil {
+					return nil, nil, fmt.Errorf("approval stage %q can't have a strategy", stage.Id)
 				}
 
-				dependsOn := []string{}
-				if prevStage != "" {
-					dependsOn = append(dependsOn, prevStage)
+				stepTimeouts, err := timeout.Steps(stageSpec)
+				if err != nil {
+					return nil, nil, fmt.Errorf("could not parse step timeouts of stage %q: %w", stage.Id, err)
+				}
+
+				legs, err := matrix.Legs(stage)
+				if err != nil {
+					return nil, nil, fmt.Errorf("could not expand matrix of stage: %w", err)
 				}
-				status := enum.CIStatusWaitingOnDeps
-				// If the stage has no dependencies, it can be picked up for execution.
-				if len(dependsOn) == 0 {
-					status = enum.CIStatusPending
+				if legs == nil {
+					// a regular stage is treated as a matrix with a single unnamed leg
+					legs = []matrix.Leg{{}}
 				}
-				temp := &types.Stage{
-					RepoID:    repo.ID,
-					Number:    int64(idx + 1),
-					Name:      stage.Id, // for v1, ID is the unique identifier per stage
-					Created:   now,
-					Updated:   now,
-					Status:    status,
-					OnSuccess: onSuccess,
-					OnFailure: onFailure,
-					DependsOn: dependsOn,
+
+				dependsOn := []string{}
+				if deps == nil {
+					dependsOn = append(dependsOn, prevStages...)
+				}
+
+				prevStages = make([]string, 0, len(legs))
+				for _, leg := range legs {
+					name := stage.Id // for v1, ID is the unique identifier per stage
+					if leg.Name != "" {
+						if _, exists := stageNames[leg.Name]; exists {
+							return nil, nil, fmt.Errorf("matrix leg name %q conflicts with an existing stage", leg.Name)
+						}
+						stageNames[leg.Name] = struct{}{}
+
+						name = leg.Name
+					}
+
+					params := make(map[string]interface{}, len(inputParams)+2)
+					for k, v := range inputParams {
+						params[k] = v
+					}
+					params[condition.InputVariables] = vars
+					if leg.Name != "" {
+						params["matrix"] = leg.Axis
+					}
+
+					now := time.Now().UnixMilli()
+					cond, err := condition.Evaluate(stage.When, condCtx, params)
+					if err != nil {
+						return nil, nil, fmt.Errorf("could not resolve when condition for stage %q: %w", stage.Id, err)
+					}
+
+					stepConditions, err := condition.Steps(stageSpec, condCtx, params)
+					if err != nil {
+						return nil, nil, fmt.Errorf("could not resolve when conditions of stage %q: %w", stage.Id, err)
+					}
+
+					temp := &types.Stage{
+						RepoID:    repo.ID,
+						Number:    int64(len(stages) + 1),
+						Name:      name,
+						Created:   now,
+						Updated:   now,
+						OnSuccess: cond.OnSuccess,
+						OnFailure: cond.OnFailure,
+						DependsOn: dependsOn,
+						Matrix:    leg.Axis,
+						RunsOn:    runsOn[idx],
+						Timeout:   stageTimeouts[idx].Milliseconds(),
+
+						StepConditions: stepConditions,
+					}
+					for stepName, stepTimeout := range stepTimeouts {
+						if temp.StepTimeouts == nil {
+							temp.StepTimeouts = make(map[string]int64, len(stepTimeouts))
+						}
+						temp.StepTimeouts[stepName] = stepTimeout.Milliseconds()
+					}
+					if isGate {
+						temp.Kind = gate.StageKind
+						temp.Approval = &types.Approval{
+							Approvers: approvalGate.Approvers,
+							Timeout:   approvalGate.Timeout.Milliseconds(),
+							State:     enum.ApprovalStatePending,
+							Created:   now,
+							Updated:   now,
+						}
+					}
+					prevStages = append(prevStages, temp.Name)
+					stageLegs[idx] = append(stageLegs[idx], temp.Name)
+					stages = append(stages, temp)
 				}
-				prevStage = temp.Name
-				stages = append(stages, temp)
 			default:
-				return nil, fmt.Errorf("only CI stage supported in v1 at the moment")
+				return nil, nil, fmt.Errorf("only CI stage supported in v1 at the moment")
+			}
+		}
+
+		if deps != nil {
+			err = applyDependencies(stages, v.Stages, deps, stageLegs)
+			if err != nil {
+				return nil, nil, err
 			}
 		}
 	default:
-		return nil, fmt.Errorf("unknown yaml: %w", err)
+		return nil, nil, fmt.Errorf("unknown yaml: %w", err)
+	}
+
+	skipUnmatchedStages(stages)
+
+	for _, stage := range stages {
+		if stage.Status == enum.CIStatusSkipped {
+			continue
+		}
+
+		stage.Status = enum.CIStatusWaitingOnDeps
+		// If the stage has no dependencies, it can be picked up for execution.
+		// Approval stages without dependencies immediately wait for a decision.
+		if len(stage.DependsOn) == 0 {
+			stage.Status = enum.CIStatusPending
+			if stage.Kind == gate.StageKind {
+				stage.Status = enum.CIStatusBlocked
+				stage.Started = stage.Created
+			}
+		}
+	}
+
+	return stages, conc, nil
+}
+
+// allSkipped returns true if all stages were skipped.
+func allSkipped(stages []*types.Stage) bool {
+	for _, stage := range stages {
+		if stage.Status != enum.CIStatusSkipped {
+			return false
+		}
+	}
+	return true
+}
+
+// skipUnmatchedStages marks the stages that would run right away, but whose conditions don't match, as skipped.
+// The skipped stages are removed from the dependencies of the other stages, which can make them run right away too.
+// Stages that wait on other stages are decided by the manager once their dependencies completed.
+func skipUnmatchedStages(stages []*types.Stage) {
+	skipped := make(map[string]struct{})
+	for {
+		changed := false
+		for _, stage := range stages {
+			if stage.Status == enum.CIStatusSkipped || len(stage.DependsOn) > 0 || stage.OnSuccess {
+				continue
+			}
+
+			stage.Status = enum.CIStatusSkipped
+			stage.Started = stage.Created
+			stage.Stopped = stage.Created
+			skipped[stage.Name] = struct{}{}
+			changed = true
+		}
+
+		if !changed {
+			return
+		}
+
+		for _, stage := range stages {
+			dependsOn := make([]string, 0, len(stage.DependsOn))
+			for _, dep := range stage.DependsOn {
+				if _, ok := skipped[dep]; !ok {
+					dependsOn = append(dependsOn, dep)
+				}
+			}
+			stage.DependsOn = dependsOn
+		}
+	}
+}
+
+// applyDependencies sets the dependencies of the stages to the ones declared in the pipeline.
+// A dependency on a matrix stage is a dependency on all of its legs.
+func applyDependencies(
+	stages []*types.Stage,
+	pipelineStages []*v1yaml.Stage,
+	deps map[int][]string,
+	stageLegs map[int][]string,
+) error {
+	// stages can be referenced by their name or by their (normalized) ID
+	indexes := make(map[string]int, 2*len(pipelineStages))
+	for idx, stage := range pipelineStages {
+		indexes[stage.Id] = idx
+		if stage.Name != "" {
+			indexes[stage.Name] = idx
+		}
+	}
+
+	legIndexes := make(map[string]int, len(stages))
+	for idx, legs := range stageLegs {
+		for _, leg := range legs {
+			legIndexes[leg] = idx
+		}
 	}
-	return stages, nil
+
+	graph := dag.New()
+	for _, stage := range stages {
+		idx := legIndexes[stage.Name]
+
+		dependsOn := []string{}
+		seen := make(map[int]struct{}, len(deps[idx]))
+		for _, dep := range deps[idx] {
+			depIdx, ok := indexes[dep]
+			if !ok {
+				return fmt.Errorf("stage %q depends on unknown stage %q", pipelineStages[idx].Id, dep)
+			}
+			if depIdx == idx {
+				return fmt.Errorf("stage %q can't depend on itself", pipelineStages[idx].Id)
+			}
+			if _, ok := seen[depIdx]; ok {
+				continue
+			}
+			seen[depIdx] = struct{}{}
+			dependsOn = append(dependsOn, stageLegs[depIdx]...)
+		}
+
+		stage.DependsOn = dependsOn
+		graph.Add(stage.Name, dependsOn...)
+	}
+
+	if graph.DetectCycles() {
+		return fmt.Errorf("dependency cycle detected in the pipeline stages")
+	}
+
+	return nil
 }
 
 // Checks whether YAML is V1 Yaml or drone Yaml.
@@ -465,6 +866,16 @@ func (t *triggerer) createExecutionWithStages(
 			if err != nil {
 				return err
 			}
+
+			if stage.Approval == nil {
+				continue
+			}
+
+			stage.Approval.StageID = stage.ID
+			err = t.approvalStore.Create(ctx, stage.Approval)
+			if err != nil {
+				return err
+			}
 		}
 		return nil
 	})
@@ -497,7 +908,7 @@ func (t *triggerer) createExecutionWithError(
 		Parent:       base.Parent,
 		Status:       enum.CIStatusError,
 		Error:        message,
-		Event:        string(base.Action.GetTriggerEvent()),
+		Event:        base.event(),
 		Action:       string(base.Action),
 		Link:         base.Link,
 		Title:        base.Title,
@@ -0,0 +1,101 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerer
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestParseV1StagesConditions(t *testing.T) {
+	data := []byte(`
+version: 1
+kind: pipeline
+spec:
+  stages:
+  - name: release
+    type: ci
+    when:
+      branch:
+        eq: release/*
+    spec:
+      steps:
+      - name: publish
+        type: run
+        spec:
+          container: alpine
+          script: echo publish
+  - name: build
+    type: ci
+    spec:
+      steps:
+      - name: test
+        type: run
+        spec:
+          container: alpine
+          script: go test
+      - name: notify
+        type: run
+        when:
+          status:
+            eq: failure
+        spec:
+          container: alpine
+          script: echo failed
+  - name: deploy
+    type: ci
+    when:
+      variables.DEPLOY:
+        eq: "true"
+    spec:
+      steps:
+      - name: deploy
+        type: run
+        spec:
+          container: alpine
+          script: echo deploy
+`)
+
+	repo := &types.Repository{ID: 1}
+	execution := &types.Execution{Event: "push", Target: "main", Ref: "refs/heads/main"}
+
+	stages, _, err := parseV1Stages(data, repo, execution, map[string]string{"DEPLOY": "true"})
+	if err != nil {
+		t.Fatalf("failed to parse stages: %s", err)
+	}
+
+	if len(stages) != 3 {
+		t.Fatalf("expected 3 stages, got %d", len(stages))
+	}
+
+	if stages[0].Status != enum.CIStatusSkipped {
+		t.Errorf("expected the release stage to be skipped, got %s", stages[0].Status)
+	}
+
+	if stages[1].Status != enum.CIStatusPending || len(stages[1].DependsOn) != 0 {
+		t.Errorf("expected the build stage to run right away, got %s %v", stages[1].Status, stages[1].DependsOn)
+	}
+
+	expected := types.ConditionResult{Matched: true, OnFailure: true}
+	if cond, ok := stages[1].StepConditions["notify"]; !ok || cond != expected {
+		t.Errorf("expected the condition of the notify step to be recorded, got %+v", stages[1].StepConditions)
+	}
+
+	if stages[2].Status != enum.CIStatusWaitingOnDeps || !stages[2].OnSuccess {
+		t.Errorf("expected the deploy stage to wait for the build stage, got %s", stages[2].Status)
+	}
+}
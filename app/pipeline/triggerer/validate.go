@@ -0,0 +1,190 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerer
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+
+	"github.com/harness/gitness/app/pipeline/template"
+	"github.com/harness/gitness/app/pipeline/triggerer/dag"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/drone/drone-yaml/yaml"
+	"github.com/drone/drone-yaml/yaml/linter"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+var (
+	// errorLineRegex matches the line yaml parsers report in their errors (e.g. "yaml: line 3: ...").
+	errorLineRegex = regexp.MustCompile(`\bline (\d+)`)
+	// errorStageIndexRegex matches the one based stage index used in errors of the v1 extensions.
+	errorStageIndexRegex = regexp.MustCompile(`stage (\d+)`)
+	// errorStageNameRegex matches the quoted stage name used in errors of the v1 extensions.
+	errorStageNameRegex = regexp.MustCompile(`stage "([^"]+)"`)
+)
+
+func (t *triggerer) Validate(
+	ctx context.Context,
+	repo *types.Repository,
+	data []byte,
+) *types.PipelineValidation {
+	var root yamlv3.Node
+	if err := yamlv3.Unmarshal(data, &root); err != nil {
+		return invalid(locateError(nil, err))
+	}
+
+	if !isV1Yaml(data) {
+		return validateLegacy(&root, data)
+	}
+
+	finder := template.SpaceFinder(ctx, t.spaceStore, t.templateStore, repo.ParentID)
+	resolved, err := template.Resolve(data, finder)
+	if err != nil {
+		return invalid(locateError(&root, err))
+	}
+
+	// the stages are parsed for a push to the default branch, the values of expressions don't matter.
+	execution := &types.Execution{
+		RepoID: repo.ID,
+		Event:  enum.TriggerEventPush,
+		Ref:    "refs/heads/" + repo.DefaultBranch,
+		Source: repo.DefaultBranch,
+		Target: repo.DefaultBranch,
+	}
+	if _, _, err = parseV1Stages(resolved, repo, execution, nil); err != nil {
+		return invalid(locateError(&root, err))
+	}
+
+	return &types.PipelineValidation{
+		Valid:  true,
+		Errors: []types.PipelineConfigError{},
+	}
+}
+
+// validateLegacy validates a legacy drone pipeline definition.
+func validateLegacy(root *yamlv3.Node, data []byte) *types.PipelineValidation {
+	manifest, err := yaml.ParseString(string(data))
+	if err != nil {
+		return invalid(locateError(root, err))
+	}
+
+	if err = linter.Manifest(manifest, true); err != nil {
+		return invalid(locateError(root, err))
+	}
+
+	// the resources are linted as well, the manifest linter only checks the pipelines and their dependencies.
+	for _, resource := range manifest.Resources {
+		if err = linter.Lint(resource, true); err != nil {
+			return invalid(locateError(root, err))
+		}
+	}
+
+	graph := dag.New()
+	for _, document := range manifest.Resources {
+		if pipeline, ok := document.(*yaml.Pipeline); ok {
+			graph.Add(pipeline.Name, pipeline.DependsOn...)
+		}
+	}
+	if graph.DetectCycles() {
+		return invalid(types.PipelineConfigError{Message: "dependency cycle detected in the pipelines"})
+	}
+
+	return &types.PipelineValidation{
+		Valid:  true,
+		Errors: []types.PipelineConfigError{},
+	}
+}
+
+func invalid(e types.PipelineConfigError) *types.PipelineValidation {
+	return &types.PipelineValidation{
+		Valid:  false,
+		Errors: []types.PipelineConfigError{e},
+	}
+}
+
+// locateError returns the error along with its position in the definition if it can be determined:
+// yaml parse errors contain the line, errors of the v1 extensions reference the stage.
+func locateError(root *yamlv3.Node, err error) types.PipelineConfigError {
+	msg := err.Error()
+	e := types.PipelineConfigError{Message: msg}
+
+	if m := errorLineRegex.FindStringSubmatch(msg); m != nil {
+		e.Line, _ = strconv.Atoi(m[1])
+		return e
+	}
+
+	stages := v1Stages(root)
+
+	if m := errorStageNameRegex.FindStringSubmatch(msg); m != nil {
+		for _, stage := range stages {
+			if mappingValue(stage, "name") == m[1] || mappingValue(stage, "id") == m[1] {
+				e.Line, e.Column = stage.Line, stage.Column
+				return e
+			}
+		}
+	}
+
+	if m := errorStageIndexRegex.FindStringSubmatch(msg); m != nil {
+		idx, _ := strconv.Atoi(m[1])
+		if idx >= 1 && idx <= len(stages) {
+			e.Line, e.Column = stages[idx-1].Line, stages[idx-1].Column
+		}
+	}
+
+	return e
+}
+
+// v1Stages returns the nodes of the stages of a v1 pipeline definition.
+func v1Stages(root *yamlv3.Node) []*yamlv3.Node {
+	if root == nil || len(root.Content) == 0 {
+		return nil
+	}
+
+	spec := mappingNode(root.Content[0], "spec")
+	stages := mappingNode(spec, "stages")
+	if stages == nil || stages.Kind != yamlv3.SequenceNode {
+		return nil
+	}
+
+	return stages.Content
+}
+
+// mappingNode returns the value node of the key of a yaml mapping or nil if it doesn't exist.
+func mappingNode(node *yamlv3.Node, key string) *yamlv3.Node {
+	if node == nil || node.Kind != yamlv3.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+// mappingValue returns the scalar value of the key of a yaml mapping or an empty string if it doesn't exist.
+func mappingValue(node *yamlv3.Node, key string) string {
+	value := mappingNode(node, key)
+	if value == nil || value.Kind != yamlv3.ScalarNode {
+		return ""
+	}
+
+	return value.Value
+}
@@ -0,0 +1,122 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       string
+		wantValid  bool
+		wantLine   int
+		wantColumn int
+	}{
+		{
+			name: "valid",
+			data: `
+version: 1
+kind: pipeline
+spec:
+  stages:
+  - name: build
+    type: ci
+    spec:
+      steps:
+      - name: test
+        type: run
+        spec:
+          container: alpine
+          script: go test
+`,
+			wantValid: true,
+		},
+		{
+			name: "syntax error",
+			data: `
+version: 1
+kind: pipeline
+spec:
+  stages:
+  - name: build
+     type: ci
+`,
+			wantLine: 7,
+		},
+		{
+			name: "unknown dependency",
+			data: `
+version: 1
+kind: pipeline
+spec:
+  stages:
+  - name: build
+    type: ci
+    spec:
+      steps: []
+  - name: deploy
+    type: ci
+    depends_on: [publish]
+    spec:
+      steps: []
+`,
+			wantLine:   10,
+			wantColumn: 5,
+		},
+		{
+			name: "legacy lint error",
+			data: `
+kind: pipeline
+type: docker
+name: default
+
+steps:
+- name: test
+  image: alpine
+  commands: [echo]
+- name: test
+  image: alpine
+  commands: [echo]
+`,
+		},
+	}
+
+	tr := &triggerer{}
+	repo := &types.Repository{ID: 1, DefaultBranch: "main"}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := tr.Validate(context.Background(), repo, []byte(test.data))
+			if got.Valid != test.wantValid {
+				t.Fatalf("want valid=%t, got %t: %+v", test.wantValid, got.Valid, got.Errors)
+			}
+			if test.wantValid {
+				return
+			}
+			if len(got.Errors) != 1 {
+				t.Fatalf("want one error, got %d", len(got.Errors))
+			}
+			if got.Errors[0].Line != test.wantLine || got.Errors[0].Column != test.wantColumn {
+				t.Errorf("want error at %d:%d, got %d:%d (%s)", test.wantLine, test.wantColumn,
+					got.Errors[0].Line, got.Errors[0].Column, got.Errors[0].Message)
+			}
+		})
+	}
+}
@@ -15,11 +15,14 @@
 package triggerer
 
 import (
+	"github.com/harness/gitness/app/pipeline/canceler"
 	"github.com/harness/gitness/app/pipeline/file"
 	"github.com/harness/gitness/app/pipeline/scheduler"
+	"github.com/harness/gitness/app/pipeline/secrets"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
 
 	"github.com/google/wire"
 )
@@ -31,16 +34,24 @@ var WireSet = wire.NewSet(
 
 // ProvideTriggerer provides a triggerer which can execute builds.
 func ProvideTriggerer(
+	config *types.Config,
 	executionStore store.ExecutionStore,
 	checkStore store.CheckStore,
 	stageStore store.StageStore,
+	approvalStore store.ApprovalStore,
 	tx dbtx.Transactor,
 	pipelineStore store.PipelineStore,
 	fileService file.Service,
 	scheduler scheduler.Scheduler,
+	canceler canceler.Canceler,
 	repoStore store.RepoStore,
 	urlProvider url.Provider,
+	spaceStore store.SpaceStore,
+	templateStore store.TemplateStore,
+	variableStore store.VariableStore,
+	secretResolver *secrets.Resolver,
 ) Triggerer {
-	return New(executionStore, checkStore, stageStore, pipelineStore,
-		tx, repoStore, urlProvider, scheduler, fileService)
+	return New(executionStore, checkStore, stageStore, approvalStore, pipelineStore,
+		tx, repoStore, urlProvider, scheduler, canceler, fileService, spaceStore, templateStore,
+		variableStore, secretResolver, config.CI.Timeout)
 }
@@ -0,0 +1,111 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package variables
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Level holds the variables of a single repository or space.
+type Level struct {
+	// SecretSpaceID is the ID of the space in which the secrets referenced by the variables are.
+	SecretSpaceID int64
+	Variables     []*types.Variable
+}
+
+// SecretFinder returns the value of the secret with the given UID in the space with the given ID.
+type SecretFinder func(spaceID int64, uid string) (string, error)
+
+// Merge merges the variables of the levels, ordered from the lowest to the highest precedence,
+// into a map of environment variables. Secret backed variables get the values of their secrets.
+func Merge(levels []Level, findSecret SecretFinder) (map[string]string, error) {
+	env := map[string]string{}
+	for _, level := range levels {
+		for _, v := range level.Variables {
+			if v.SecretUID == "" {
+				env[v.Key] = v.Value
+				continue
+			}
+
+			value, err := findSecret(level.SecretSpaceID, v.SecretUID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to find secret %q of variable %q: %w", v.SecretUID, v.Key, err)
+			}
+
+			env[v.Key] = value
+		}
+	}
+
+	return env, nil
+}
+
+// Secrets finds the secrets referenced by secret backed variables.
+type Secrets interface {
+	// FindByUID returns the secret with the UID in the space.
+	FindByUID(ctx context.Context, spaceID int64, uid string) (*types.Secret, error)
+}
+
+// Resolve returns the environment variables defined by the variables of the repository and of its spaces.
+// The variables of a space override the variables of its ancestor spaces,
+// and the variables of the repository override the variables of all its spaces.
+func Resolve(
+	ctx context.Context,
+	spaceStore store.SpaceStore,
+	variableStore store.VariableStore,
+	secrets Secrets,
+	repo *types.Repository,
+) (map[string]string, error) {
+	repoVariables, err := variableStore.ListAll(ctx, enum.VariableParentRepo, repo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list variables of the repository: %w", err)
+	}
+
+	// collect the levels from the highest precedence (the repository) to the lowest (the root space)...
+	levels := []Level{{SecretSpaceID: repo.ParentID, Variables: repoVariables}}
+	for id := repo.ParentID; id > 0; {
+		spaceVariables, err := variableStore.ListAll(ctx, enum.VariableParentSpace, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list variables of the space: %w", err)
+		}
+
+		levels = append(levels, Level{SecretSpaceID: id, Variables: spaceVariables})
+
+		space, err := spaceStore.Find(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find space: %w", err)
+		}
+
+		id = space.ParentID
+	}
+
+	// ... and merge them in reverse order.
+	for i, j := 0, len(levels)-1; i < j; i, j = i+1, j-1 {
+		levels[i], levels[j] = levels[j], levels[i]
+	}
+
+	return Merge(levels, func(spaceID int64, uid string) (string, error) {
+		secret, err := secrets.FindByUID(ctx, spaceID, uid)
+		if err != nil {
+			return "", err
+		}
+
+		return secret.Data, nil
+	})
+}
@@ -0,0 +1,106 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package variables
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestMerge(t *testing.T) {
+	secrets := map[int64]map[string]string{
+		1: {"token": "root-token"},
+		2: {"token": "child-token"},
+	}
+	findSecret := func(spaceID int64, uid string) (string, error) {
+		value, ok := secrets[spaceID][uid]
+		if !ok {
+			return "", errors.New("not found")
+		}
+		return value, nil
+	}
+
+	tests := []struct {
+		name    string
+		levels  []Level
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "empty",
+			want: map[string]string{},
+		},
+		{
+			name: "deeper levels override",
+			levels: []Level{
+				{SecretSpaceID: 1, Variables: []*types.Variable{
+					{Key: "A", Value: "root"},
+					{Key: "B", Value: "root"},
+					{Key: "C", Value: "root"},
+				}},
+				{SecretSpaceID: 2, Variables: []*types.Variable{
+					{Key: "B", Value: "child"},
+					{Key: "C", Value: "child"},
+				}},
+				{SecretSpaceID: 2, Variables: []*types.Variable{
+					{Key: "C", Value: "repo"},
+				}},
+			},
+			want: map[string]string{"A": "root", "B": "child", "C": "repo"},
+		},
+		{
+			name: "secrets are looked up in the level's space",
+			levels: []Level{
+				{SecretSpaceID: 1, Variables: []*types.Variable{
+					{Key: "ROOT_TOKEN", SecretUID: "token"},
+				}},
+				{SecretSpaceID: 2, Variables: []*types.Variable{
+					{Key: "CHILD_TOKEN", SecretUID: "token"},
+				}},
+			},
+			want: map[string]string{"ROOT_TOKEN": "root-token", "CHILD_TOKEN": "child-token"},
+		},
+		{
+			name: "missing secret",
+			levels: []Level{
+				{SecretSpaceID: 2, Variables: []*types.Variable{
+					{Key: "TOKEN", SecretUID: "missing"},
+				}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := Merge(test.levels, findSecret)
+			if test.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("want=%v got=%v", test.want, got)
+			}
+		})
+	}
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/harness/gitness/app/api/controller/principal"
 	"github.com/harness/gitness/app/api/controller/pullreq"
 	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/controller/runner"
 	"github.com/harness/gitness/app/api/controller/secret"
 	"github.com/harness/gitness/app/api/controller/serviceaccount"
 	"github.com/harness/gitness/app/api/controller/space"
@@ -38,6 +39,7 @@ import (
 	"github.com/harness/gitness/app/api/controller/trigger"
 	"github.com/harness/gitness/app/api/controller/upload"
 	"github.com/harness/gitness/app/api/controller/user"
+	"github.com/harness/gitness/app/api/controller/variable"
 	"github.com/harness/gitness/app/api/controller/webhook"
 	"github.com/harness/gitness/app/api/handler/account"
 	handlercheck "github.com/harness/gitness/app/api/handler/check"
@@ -52,6 +54,7 @@ import (
 	handlerpullreq "github.com/harness/gitness/app/api/handler/pullreq"
 	handlerrepo "github.com/harness/gitness/app/api/handler/repo"
 	"github.com/harness/gitness/app/api/handler/resource"
+	handlerrunner "github.com/harness/gitness/app/api/handler/runner"
 	handlersecret "github.com/harness/gitness/app/api/handler/secret"
 	handlerserviceaccount "github.com/harness/gitness/app/api/handler/serviceaccount"
 	handlerspace "github.com/harness/gitness/app/api/handler/space"
@@ -61,11 +64,15 @@ import (
 	handlerupload "github.com/harness/gitness/app/api/handler/upload"
 	handleruser "github.com/harness/gitness/app/api/handler/user"
 	"github.com/harness/gitness/app/api/handler/users"
+	handlervariable "github.com/harness/gitness/app/api/handler/variable"
 	handlerwebhook "github.com/harness/gitness/app/api/handler/webhook"
 	"github.com/harness/gitness/app/api/middleware/address"
 	middlewareauthn "github.com/harness/gitness/app/api/middleware/authn"
+	"github.com/harness/gitness/app/api/middleware/consistency"
+	"github.com/harness/gitness/app/api/middleware/dbtiming"
 	"github.com/harness/gitness/app/api/middleware/encode"
 	"github.com/harness/gitness/app/api/middleware/logging"
+	"github.com/harness/gitness/app/api/middleware/pagination"
 	middlewareprincipal "github.com/harness/gitness/app/api/middleware/principal"
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/auth/authn"
@@ -115,6 +122,8 @@ func NewAPIHandler(
 	sysCtrl *system.Controller,
 	uploadCtrl *upload.Controller,
 	searchCtrl *keywordsearch.Controller,
+	runnerCtrl *runner.Controller,
+	variableCtrl *variable.Controller,
 ) APIHandler {
 	// Use go-chi router for inner routing.
 	r := chi.NewRouter()
@@ -130,17 +139,28 @@ func NewAPIHandler(
 	r.Use(logging.HLogAccessLogHandler())
 	r.Use(address.Handler("", ""))
 
+	// report the per request database time in debug mode.
+	if config.Debug {
+		r.Use(dbtiming.Handler())
+	}
+
 	// configure cors middleware
 	r.Use(corsHandler(config))
 
 	// for now always attempt auth - enforced per operation.
 	r.Use(middlewareauthn.Attempt(authenticator))
 
+	// provide read-after-write consistency tokens.
+	r.Use(consistency.Handler())
+
+	// optionally wrap list responses in a pagination envelope.
+	r.Use(pagination.Handler())
+
 	r.Route("/v1", func(r chi.Router) {
 		setupRoutesV1(r, appCtx, config, repoCtrl, executionCtrl, triggerCtrl, logCtrl, pipelineCtrl,
 			connectorCtrl, templateCtrl, pluginCtrl, secretCtrl, spaceCtrl, pullreqCtrl,
 			webhookCtrl, githookCtrl, saCtrl, userCtrl, principalCtrl, checkCtrl, sysCtrl, uploadCtrl,
-			searchCtrl)
+			searchCtrl, runnerCtrl, variableCtrl)
 	})
 
 	// wrap router in terminatedPath encoder.
@@ -184,10 +204,12 @@ func setupRoutesV1(r chi.Router,
 	sysCtrl *system.Controller,
 	uploadCtrl *upload.Controller,
 	searchCtrl *keywordsearch.Controller,
+	runnerCtrl *runner.Controller,
+	variableCtrl *variable.Controller,
 ) {
-	setupSpaces(r, appCtx, spaceCtrl)
+	setupSpaces(r, appCtx, spaceCtrl, variableCtrl)
 	setupRepos(r, repoCtrl, pipelineCtrl, executionCtrl, triggerCtrl, logCtrl, pullreqCtrl, webhookCtrl, checkCtrl,
-		uploadCtrl)
+		uploadCtrl, variableCtrl)
 	setupConnectors(r, connectorCtrl)
 	setupTemplates(r, templateCtrl)
 	setupSecrets(r, secretCtrl)
@@ -195,16 +217,18 @@ func setupRoutesV1(r chi.Router,
 	setupServiceAccounts(r, saCtrl)
 	setupPrincipals(r, principalCtrl)
 	setupInternal(r, githookCtrl)
-	setupAdmin(r, userCtrl)
+	setupAdmin(r, userCtrl, sysCtrl, runnerCtrl)
 	setupAccount(r, userCtrl, sysCtrl, config)
 	setupSystem(r, config, sysCtrl)
 	setupResources(r)
 	setupPlugins(r, pluginCtrl)
 	setupKeywordSearch(r, searchCtrl)
+	setupRunners(r, runnerCtrl)
 }
 
 // nolint: revive // it's the app context, it shouldn't be the first argument
-func setupSpaces(r chi.Router, appCtx context.Context, spaceCtrl *space.Controller) {
+func setupSpaces(r chi.Router, appCtx context.Context, spaceCtrl *space.Controller,
+	variableCtrl *variable.Controller) {
 	r.Route("/spaces", func(r chi.Router) {
 		// Create takes path and parentId via body, not uri
 		r.Post("/", handlerspace.HandleCreate(spaceCtrl))
@@ -222,6 +246,7 @@ func setupSpaces(r chi.Router, appCtx context.Context, spaceCtrl *space.Controll
 			r.Post("/move", handlerspace.HandleMove(spaceCtrl))
 			r.Get("/spaces", handlerspace.HandleListSpaces(spaceCtrl))
 			r.Get("/repos", handlerspace.HandleListRepos(spaceCtrl))
+			r.Get("/repo-dependency-order", handlerspace.HandleRepoDependencyOrder(spaceCtrl))
 			r.Get("/service-accounts", handlerspace.HandleListServiceAccounts(spaceCtrl))
 			r.Get("/secrets", handlerspace.HandleListSecrets(spaceCtrl))
 			r.Get("/connectors", handlerspace.HandleListConnectors(spaceCtrl))
@@ -232,11 +257,55 @@ func setupSpaces(r chi.Router, appCtx context.Context, spaceCtrl *space.Controll
 			r.Route("/members", func(r chi.Router) {
 				r.Get("/", handlerspace.HandleMembershipList(spaceCtrl))
 				r.Post("/", handlerspace.HandleMembershipAdd(spaceCtrl))
+				r.Post("/bulk", handlerspace.HandleMembershipBulk(spaceCtrl))
+				r.Post("/import", handlerspace.HandleMembershipImport(spaceCtrl))
 				r.Route(fmt.Sprintf("/{%s}", request.PathParamUserUID), func(r chi.Router) {
 					r.Delete("/", handlerspace.HandleMembershipDelete(spaceCtrl))
 					r.Patch("/", handlerspace.HandleMembershipUpdate(spaceCtrl))
 				})
 			})
+
+			r.Get("/landing", handlerspace.HandleLanding(spaceCtrl))
+
+			r.Route("/pins", func(r chi.Router) {
+				r.Get("/", handlerspace.HandlePinList(spaceCtrl))
+				r.Post("/", handlerspace.HandlePinCreate(spaceCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamPinID), func(r chi.Router) {
+					r.Patch("/", handlerspace.HandlePinUpdate(spaceCtrl))
+					r.Delete("/", handlerspace.HandlePinDelete(spaceCtrl))
+				})
+			})
+
+			r.Route("/required-files", func(r chi.Router) {
+				r.Get("/", handlerspace.HandleRequiredFileList(spaceCtrl))
+				r.Post("/", handlerspace.HandleRequiredFileCreate(spaceCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamRequiredFileID), func(r chi.Router) {
+					r.Patch("/", handlerspace.HandleRequiredFileUpdate(spaceCtrl))
+					r.Delete("/", handlerspace.HandleRequiredFileDelete(spaceCtrl))
+				})
+			})
+
+			r.Route("/tokens", func(r chi.Router) {
+				r.Get("/", handlerspace.HandleTokenList(spaceCtrl))
+				r.Post("/", handlerspace.HandleTokenCreate(spaceCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamTokenUID), func(r chi.Router) {
+					r.Delete("/", handlerspace.HandleTokenDelete(spaceCtrl))
+					r.Post("/rotate", handlerspace.HandleTokenRotate(spaceCtrl))
+				})
+			})
+
+			r.Route("/autolinks", func(r chi.Router) {
+				r.Get("/", handlerspace.HandleAutolinkList(spaceCtrl))
+				r.Post("/", handlerspace.HandleAutolinkCreate(spaceCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamAutolinkID), func(r chi.Router) {
+					r.Patch("/", handlerspace.HandleAutolinkUpdate(spaceCtrl))
+					r.Delete("/", handlerspace.HandleAutolinkDelete(spaceCtrl))
+				})
+			})
+
+			r.Get("/compliance", handlerspace.HandleComplianceList(spaceCtrl))
+
+			SetupVariables(r, variableCtrl, enum.VariableParentSpace)
 		})
 	})
 }
@@ -251,11 +320,13 @@ func setupRepos(r chi.Router,
 	webhookCtrl *webhook.Controller,
 	checkCtrl *check.Controller,
 	uploadCtrl *upload.Controller,
+	variableCtrl *variable.Controller,
 ) {
 	r.Route("/repos", func(r chi.Router) {
 		// Create takes path and parentId via body, not uri
 		r.Post("/", handlerrepo.HandleCreate(repoCtrl))
 		r.Post("/import", handlerrepo.HandleImport(repoCtrl))
+		r.Post("/import-bundle", handlerrepo.HandleImportBundle(repoCtrl))
 		r.Route(fmt.Sprintf("/{%s}", request.PathParamRepoRef), func(r chi.Router) {
 			// repo level operations
 			r.Get("/", handlerrepo.HandleFind(repoCtrl))
@@ -266,6 +337,13 @@ func setupRepos(r chi.Router,
 			r.Get("/service-accounts", handlerrepo.HandleListServiceAccounts(repoCtrl))
 
 			r.Get("/import-progress", handlerrepo.HandleImportProgress(repoCtrl))
+			r.Get("/bundle", handlerrepo.HandleExportBundle(repoCtrl))
+			r.Post("/bundle", handlerrepo.HandleApplyBundle(repoCtrl))
+
+			r.Route("/stats", func(r chi.Router) {
+				r.Get("/", handlerrepo.HandleGetStats(repoCtrl))
+				r.Post("/refresh", handlerrepo.HandleRefreshStats(repoCtrl))
+			})
 
 			// content operations
 			// NOTE: this allows /content and /content/ to both be valid (without any other tricks.)
@@ -274,6 +352,10 @@ func setupRepos(r chi.Router,
 				r.Get("/*", handlerrepo.HandleGetContent(repoCtrl))
 			})
 
+			r.Route("/tree", func(r chi.Router) {
+				r.Get("/*", handlerrepo.HandleListTree(repoCtrl))
+			})
+
 			r.Post("/path-details", handlerrepo.HandlePathsDetails(repoCtrl))
 
 			r.Route("/blame", func(r chi.Router) {
@@ -282,19 +364,36 @@ func setupRepos(r chi.Router,
 
 			r.Route("/raw", func(r chi.Router) {
 				r.Get("/*", handlerrepo.HandleRaw(repoCtrl))
+				r.Head("/*", handlerrepo.HandleRaw(repoCtrl))
+			})
+
+			r.Route("/archive", func(r chi.Router) {
+				r.Get("/*", handlerrepo.HandleArchive(repoCtrl))
 			})
 
 			// commit operations
 			r.Route("/commits", func(r chi.Router) {
 				r.Get("/", handlerrepo.HandleListCommits(repoCtrl))
+				r.Get("/search", handlerrepo.HandleSearchCommits(repoCtrl))
 
 				r.Post("/calculate-divergence", handlerrepo.HandleCalculateCommitDivergence(repoCtrl))
+				r.Post("/cherry-pick", handlerrepo.HandleCherryPickCommits(repoCtrl))
 				r.Post("/", handlerrepo.HandleCommitFiles(repoCtrl))
 
 				// per commit operations
 				r.Route(fmt.Sprintf("/{%s}", request.PathParamCommitSHA), func(r chi.Router) {
 					r.Get("/", handlerrepo.HandleGetCommit(repoCtrl))
 					r.Get("/diff", handlerrepo.HandleCommitDiff(repoCtrl))
+					r.Post("/revert", handlerrepo.HandleRevertCommit(repoCtrl))
+
+					r.Route("/comments", func(r chi.Router) {
+						r.Get("/", handlerrepo.HandleCommitCommentList(repoCtrl))
+						r.Post("/", handlerrepo.HandleCommitCommentCreate(repoCtrl))
+						r.Route(fmt.Sprintf("/{%s}", request.PathParamCommitComment), func(r chi.Router) {
+							r.Patch("/", handlerrepo.HandleCommitCommentUpdate(repoCtrl))
+							r.Delete("/", handlerrepo.HandleCommitCommentDelete(repoCtrl))
+						})
+					})
 				})
 			})
 
@@ -302,6 +401,7 @@ func setupRepos(r chi.Router,
 			r.Route("/branches", func(r chi.Router) {
 				r.Get("/", handlerrepo.HandleListBranches(repoCtrl))
 				r.Post("/", handlerrepo.HandleCreateBranch(repoCtrl))
+				r.Post("/calculate-divergence", handlerrepo.HandleCalculateBranchDivergence(repoCtrl))
 
 				// per branch operations (can't be grouped in single route)
 				r.Get("/*", handlerrepo.HandleGetBranch(repoCtrl))
@@ -315,6 +415,12 @@ func setupRepos(r chi.Router,
 				r.Delete("/*", handlerrepo.HandleDeleteCommitTag(repoCtrl))
 			})
 
+			r.Route("/releases", func(r chi.Router) {
+				r.Post("/generate-notes", handlerrepo.HandleReleaseNotesGenerate(repoCtrl))
+				r.Get(fmt.Sprintf("/{%s}/changes", request.PathParamReleaseRange),
+					handlerrepo.HandleReleaseChanges(repoCtrl))
+			})
+
 			// diffs
 			r.Route("/diff", func(r chi.Router) {
 				r.Get("/*", handlerrepo.HandleDiff(repoCtrl))
@@ -322,16 +428,97 @@ func setupRepos(r chi.Router,
 			r.Route("/diff-stats", func(r chi.Router) {
 				r.Get("/*", handlerrepo.HandleDiffStats(repoCtrl))
 			})
+			r.Route("/compare", func(r chi.Router) {
+				r.Get("/*", handlerrepo.HandleCompare(repoCtrl))
+			})
 			r.Route("/merge-check", func(r chi.Router) {
 				r.Post("/*", handlerrepo.HandleMergeCheck(repoCtrl))
 			})
 
 			r.Get("/codeowners/validate", handlerrepo.HandleCodeOwnersValidate(repoCtrl))
 
+			r.Route("/merge-templates", func(r chi.Router) {
+				r.Get("/", handlerrepo.HandleMergeTemplateList(repoCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamMergeMethod), func(r chi.Router) {
+					r.Put("/", handlerrepo.HandleMergeTemplateUpdate(repoCtrl))
+					r.Delete("/", handlerrepo.HandleMergeTemplateDelete(repoCtrl))
+				})
+			})
+
+			r.Route("/mirror", func(r chi.Router) {
+				r.Get("/", handlerrepo.HandleMirrorFind(repoCtrl))
+				r.Post("/", handlerrepo.HandleMirrorCreate(repoCtrl))
+				r.Patch("/", handlerrepo.HandleMirrorUpdate(repoCtrl))
+				r.Delete("/", handlerrepo.HandleMirrorDelete(repoCtrl))
+				r.Post("/sync", handlerrepo.HandleMirrorSync(repoCtrl))
+			})
+
+			r.Route("/signing-key", func(r chi.Router) {
+				r.Get("/", handlerrepo.HandleSigningKeyFind(repoCtrl))
+				r.Put("/", handlerrepo.HandleSigningKeyUpdate(repoCtrl))
+				r.Delete("/", handlerrepo.HandleSigningKeyDelete(repoCtrl))
+			})
+
+			r.Route("/aliases", func(r chi.Router) {
+				r.Get("/", handlerrepo.HandleAliasList(repoCtrl))
+				r.Post("/", handlerrepo.HandleAliasCreate(repoCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamRepoAliasID), func(r chi.Router) {
+					r.Delete("/", handlerrepo.HandleAliasDelete(repoCtrl))
+				})
+			})
+
+			r.Route("/default-reviewers", func(r chi.Router) {
+				r.Get("/", handlerrepo.HandleDefaultReviewerList(repoCtrl))
+				r.Post("/", handlerrepo.HandleDefaultReviewerCreate(repoCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamDefaultReviewerID), func(r chi.Router) {
+					r.Delete("/", handlerrepo.HandleDefaultReviewerDelete(repoCtrl))
+				})
+			})
+
+			r.Route("/watch", func(r chi.Router) {
+				r.Get("/", handlerrepo.HandleWatchFind(repoCtrl))
+				r.Put("/", handlerrepo.HandleWatchUpdate(repoCtrl))
+				r.Delete("/", handlerrepo.HandleWatchDelete(repoCtrl))
+			})
+
+			r.Route("/autolinks", func(r chi.Router) {
+				r.Get("/", handlerrepo.HandleAutolinkList(repoCtrl))
+				r.Post("/", handlerrepo.HandleAutolinkCreate(repoCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamAutolinkID), func(r chi.Router) {
+					r.Patch("/", handlerrepo.HandleAutolinkUpdate(repoCtrl))
+					r.Delete("/", handlerrepo.HandleAutolinkDelete(repoCtrl))
+				})
+			})
+
+			r.Route("/comparisons", func(r chi.Router) {
+				r.Get("/", handlerrepo.HandleSavedComparisonList(repoCtrl))
+				r.Post("/", handlerrepo.HandleSavedComparisonCreate(repoCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamSavedComparisonUID), func(r chi.Router) {
+					r.Get("/", handlerrepo.HandleSavedComparisonFind(repoCtrl))
+					r.Patch("/", handlerrepo.HandleSavedComparisonUpdate(repoCtrl))
+					r.Delete("/", handlerrepo.HandleSavedComparisonDelete(repoCtrl))
+				})
+			})
+
+			r.Route("/pipeline-caches", func(r chi.Router) {
+				r.Get("/", handlerrepo.HandlePipelineCacheList(repoCtrl))
+				r.Get("/content", handlerrepo.HandlePipelineCacheRestore(repoCtrl))
+				r.Put("/content", handlerrepo.HandlePipelineCacheSave(repoCtrl))
+				r.Delete(fmt.Sprintf("/{%s}", request.PathParamPipelineCacheID),
+					handlerrepo.HandlePipelineCacheDelete(repoCtrl))
+			})
+
+			r.Route("/compliance", func(r chi.Router) {
+				r.Get("/", handlerrepo.HandleComplianceFind(repoCtrl))
+				r.Post("/pullreq", handlerrepo.HandleCompliancePullReq(repoCtrl))
+			})
+
 			SetupPullReq(r, pullreqCtrl)
 
 			SetupWebhook(r, webhookCtrl)
 
+			SetupVariables(r, variableCtrl, enum.VariableParentRepo)
+
 			setupPipelines(r, repoCtrl, pipelineCtrl, executionCtrl, triggerCtrl, logCtrl)
 
 			SetupChecks(r, checkCtrl)
@@ -362,10 +549,16 @@ func setupPipelines(
 		// Create takes path and parentId via body, not uri
 		r.Post("/", handlerpipeline.HandleCreate(pipelineCtrl))
 		r.Get("/generate", handlerrepo.HandlePipelineGenerate(repoCtrl))
+		r.Post("/convert", handlerrepo.HandlePipelineConvert(repoCtrl))
 		r.Route(fmt.Sprintf("/{%s}", request.PathParamPipelineRef), func(r chi.Router) {
 			r.Get("/", handlerpipeline.HandleFind(pipelineCtrl))
 			r.Patch("/", handlerpipeline.HandleUpdate(pipelineCtrl))
 			r.Delete("/", handlerpipeline.HandleDelete(pipelineCtrl))
+			r.Get("/badge", handlerpipeline.HandleBadge(pipelineCtrl))
+			r.Get("/stats", handlerpipeline.HandleStats(pipelineCtrl))
+			r.Delete("/cache-volumes", handlerpipeline.HandleInvalidateCacheVolumes(pipelineCtrl))
+			r.Post("/validate", handlerpipeline.HandleValidate(pipelineCtrl))
+			r.Post("/validate/raw", handlerpipeline.HandleValidateRaw(pipelineCtrl))
 			setupExecutions(r, executionCtrl, logCtrl)
 			setupTriggers(r, triggerCtrl)
 		})
@@ -430,8 +623,20 @@ func setupExecutions(
 		r.Post("/", handlerexecution.HandleCreate(executionCtrl))
 		r.Route(fmt.Sprintf("/{%s}", request.PathParamExecutionNumber), func(r chi.Router) {
 			r.Get("/", handlerexecution.HandleFind(executionCtrl))
+			r.Get("/dag", handlerexecution.HandleFindDAG(executionCtrl))
 			r.Post("/cancel", handlerexecution.HandleCancel(executionCtrl))
+			r.Post("/retry", handlerexecution.HandleRetry(executionCtrl))
 			r.Delete("/", handlerexecution.HandleDelete(executionCtrl))
+			r.Route(fmt.Sprintf("/stages/{%s}/approval", request.PathParamStageNumber), func(r chi.Router) {
+				r.Get("/", handlerexecution.HandleFindApproval(executionCtrl))
+				r.Post("/", handlerexecution.HandleDecideApproval(executionCtrl))
+			})
+			r.Route("/artifacts", func(r chi.Router) {
+				r.Get("/", handlerexecution.HandleListArtifacts(executionCtrl))
+				r.Put("/", handlerexecution.HandleUploadArtifact(executionCtrl))
+				r.Get(fmt.Sprintf("/{%s}", request.PathParamPipelineArtifactID),
+					handlerexecution.HandleDownloadArtifact(executionCtrl))
+			})
 			r.Get(
 				fmt.Sprintf("/logs/{%s}/{%s}",
 					request.PathParamStageNumber,
@@ -443,6 +648,11 @@ func setupExecutions(
 					request.PathParamStageNumber,
 					request.PathParamStepNumber,
 				), handlerlogs.HandleTail(logCtrl))
+			r.Get(
+				fmt.Sprintf("/logs/{%s}/{%s}/tail",
+					request.PathParamStageNumber,
+					request.PathParamStepNumber,
+				), handlerlogs.HandleTailFrom(logCtrl))
 		})
 	})
 }
@@ -460,6 +670,15 @@ func setupTriggers(
 			r.Delete("/", handlertrigger.HandleDelete(triggerCtrl))
 		})
 	})
+	r.Route("/schedules", func(r chi.Router) {
+		r.Get("/", handlertrigger.HandleScheduleList(triggerCtrl))
+		r.Post("/", handlertrigger.HandleScheduleCreate(triggerCtrl))
+		r.Route(fmt.Sprintf("/{%s}", request.PathParamScheduleUID), func(r chi.Router) {
+			r.Get("/", handlertrigger.HandleScheduleFind(triggerCtrl))
+			r.Patch("/", handlertrigger.HandleScheduleUpdate(triggerCtrl))
+			r.Delete("/", handlertrigger.HandleScheduleDelete(triggerCtrl))
+		})
+	})
 }
 
 func setupInternal(r chi.Router, githookCtrl *controllergithook.Controller) {
@@ -480,6 +699,10 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 	r.Route("/pullreq", func(r chi.Router) {
 		r.Post("/", handlerpullreq.HandleCreate(pullreqCtrl))
 		r.Get("/", handlerpullreq.HandleList(pullreqCtrl))
+		r.Route("/bulk", func(r chi.Router) {
+			r.Post("/", handlerpullreq.HandleBulk(pullreqCtrl))
+			r.Get(fmt.Sprintf("/{%s}", request.PathParamBulkJobUID), handlerpullreq.HandleBulkProgress(pullreqCtrl))
+		})
 
 		r.Route(fmt.Sprintf("/{%s}", request.PathParamPullReqNumber), func(r chi.Router) {
 			r.Get("/", handlerpullreq.HandleFind(pullreqCtrl))
@@ -497,6 +720,7 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 			r.Route("/reviewers", func(r chi.Router) {
 				r.Get("/", handlerpullreq.HandleReviewerList(pullreqCtrl))
 				r.Put("/", handlerpullreq.HandleReviewerAdd(pullreqCtrl))
+				r.Get("/suggestions", handlerpullreq.HandleReviewerSuggestions(pullreqCtrl))
 				r.Route(fmt.Sprintf("/{%s}", request.PathParamReviewerID), func(r chi.Router) {
 					r.Delete("/", handlerpullreq.HandleReviewerDelete(pullreqCtrl))
 				})
@@ -505,6 +729,9 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 				r.Post("/", handlerpullreq.HandleReviewSubmit(pullreqCtrl))
 			})
 			r.Post("/merge", handlerpullreq.HandleMerge(pullreqCtrl))
+			r.Get("/merge-preview", handlerpullreq.HandleMergePreview(pullreqCtrl))
+			r.Post("/revert", handlerpullreq.HandleRevert(pullreqCtrl))
+			r.Post("/cherry-pick", handlerpullreq.HandleCherryPick(pullreqCtrl))
 			r.Get("/commits", handlerpullreq.HandleCommits(pullreqCtrl))
 			r.Get("/metadata", handlerpullreq.HandleMetadata(pullreqCtrl))
 
@@ -513,16 +740,46 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 				r.Get("/", handlerpullreq.HandleFileViewList(pullreqCtrl))
 				r.Delete("/*", handlerpullreq.HandleFileViewDelete(pullreqCtrl))
 			})
+			r.Route("/dependencies", func(r chi.Router) {
+				r.Get("/", handlerpullreq.HandleDependencyList(pullreqCtrl))
+				r.Post("/", handlerpullreq.HandleDependencyAdd(pullreqCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamDependencyID), func(r chi.Router) {
+					r.Delete("/", handlerpullreq.HandleDependencyDelete(pullreqCtrl))
+				})
+			})
 			r.Get("/codeowners", handlerpullreq.HandleCodeOwner(pullreqCtrl))
 			r.Get("/diff", handlerpullreq.HandleDiff(pullreqCtrl))
+			r.Get("/split-suggestions", handlerpullreq.HandleSplitSuggestions(pullreqCtrl))
+			r.Route("/watch", func(r chi.Router) {
+				r.Get("/", handlerpullreq.HandleWatchFind(pullreqCtrl))
+				r.Put("/", handlerpullreq.HandleWatchUpdate(pullreqCtrl))
+				r.Delete("/", handlerpullreq.HandleWatchDelete(pullreqCtrl))
+			})
 		})
 	})
 }
 
+// SetupVariables sets up the routes of the pipeline variables of a repository or a space.
+func SetupVariables(r chi.Router, variableCtrl *variable.Controller, parentType enum.VariableParent) {
+	r.Route("/variables", func(r chi.Router) {
+		r.Get("/", handlervariable.HandleList(variableCtrl, parentType))
+		r.Post("/", handlervariable.HandleCreate(variableCtrl, parentType))
+
+		r.Route(fmt.Sprintf("/{%s}", request.PathParamVariableKey), func(r chi.Router) {
+			r.Get("/", handlervariable.HandleFind(variableCtrl, parentType))
+			r.Patch("/", handlervariable.HandleUpdate(variableCtrl, parentType))
+			r.Delete("/", handlervariable.HandleDelete(variableCtrl, parentType))
+		})
+	})
+
+	r.Get("/variable-audits", handlervariable.HandleListAudit(variableCtrl, parentType))
+}
+
 func SetupWebhook(r chi.Router, webhookCtrl *webhook.Controller) {
 	r.Route("/webhooks", func(r chi.Router) {
 		r.Post("/", handlerwebhook.HandleCreate(webhookCtrl))
 		r.Get("/", handlerwebhook.HandleList(webhookCtrl))
+		r.Post("/payload-preview", handlerwebhook.HandlePreviewPayload(webhookCtrl))
 
 		r.Route(fmt.Sprintf("/{%s}", request.PathParamWebhookUID), func(r chi.Router) {
 			r.Get("/", handlerwebhook.HandleFind(webhookCtrl))
@@ -547,6 +804,7 @@ func SetupChecks(r chi.Router, checkCtrl *check.Controller) {
 		r.Route(fmt.Sprintf("/commits/{%s}", request.PathParamCommitSHA), func(r chi.Router) {
 			r.Put("/", handlercheck.HandleCheckReport(checkCtrl))
 			r.Get("/", handlercheck.HandleCheckList(checkCtrl))
+			r.Post(fmt.Sprintf("/{%s}/rerun", request.PathParamCheckUID), handlercheck.HandleCheckRerun(checkCtrl))
 		})
 	})
 }
@@ -571,6 +829,13 @@ func setupUser(r chi.Router, userCtrl *user.Controller) {
 		r.Patch("/", handleruser.HandleUpdate(userCtrl))
 		r.Get("/memberships", handleruser.HandleMembershipSpaces(userCtrl))
 
+		// WORKING HOURS
+		r.Route("/working-hours", func(r chi.Router) {
+			r.Get("/", handleruser.HandleFindWorkingHours(userCtrl))
+			r.Put("/", handleruser.HandleSetWorkingHours(userCtrl))
+			r.Delete("/", handleruser.HandleDeleteWorkingHours(userCtrl))
+		})
+
 		// PAT
 		r.Route("/tokens", func(r chi.Router) {
 			r.Get("/", handleruser.HandleListTokens(userCtrl, enum.TokenTypePAT))
@@ -582,6 +847,40 @@ func setupUser(r chi.Router, userCtrl *user.Controller) {
 			})
 		})
 
+		// INSTANCE-MANAGED SIGNING KEYS
+		r.Route("/signing-keys", func(r chi.Router) {
+			r.Get("/", handleruser.HandleListSigningKeys(userCtrl))
+			r.Post("/", handleruser.HandleIssueSigningKey(userCtrl))
+
+			// per key operations
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamSigningKeyID), func(r chi.Router) {
+				r.Delete("/", handleruser.HandleRevokeSigningKey(userCtrl))
+			})
+		})
+
+		// PUBLIC KEYS
+		r.Route("/keys", func(r chi.Router) {
+			r.Get("/", handleruser.HandleListPublicKeys(userCtrl))
+			r.Post("/", handleruser.HandleCreatePublicKey(userCtrl))
+
+			// per key operations
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamPublicKeyID), func(r chi.Router) {
+				r.Delete("/", handleruser.HandleDeletePublicKey(userCtrl))
+			})
+		})
+
+		// PASSKEYS
+		r.Route("/passkeys", func(r chi.Router) {
+			r.Get("/", handleruser.HandleListPasskeys(userCtrl))
+			r.Post("/", handleruser.HandleRegisterPasskey(userCtrl))
+			r.Post("/registration", handleruser.HandleBeginPasskeyRegistration(userCtrl))
+
+			// per passkey operations
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamPasskeyID), func(r chi.Router) {
+				r.Delete("/", handleruser.HandleDeletePasskey(userCtrl))
+			})
+		})
+
 		// SESSION TOKENS
 		r.Route("/sessions", func(r chi.Router) {
 			r.Get("/", handleruser.HandleListTokens(userCtrl, enum.TokenTypeSession))
@@ -619,7 +918,7 @@ func setupServiceAccounts(r chi.Router, saCtrl *serviceaccount.Controller) {
 
 func setupSystem(r chi.Router, config *types.Config, sysCtrl *system.Controller) {
 	r.Route("/system", func(r chi.Router) {
-		r.Get("/health", handlersystem.HandleHealth)
+		r.Get("/health", handlersystem.HandleHealth(sysCtrl))
 		r.Get("/version", handlersystem.HandleVersion)
 		r.Get("/config", handlersystem.HandleGetConfig(config, sysCtrl))
 	})
@@ -639,10 +938,11 @@ func setupPrincipals(r chi.Router, principalCtrl principal.Controller) {
 }
 
 func setupKeywordSearch(r chi.Router, searchCtrl *keywordsearch.Controller) {
+	r.Get("/search", handlerkeywordsearch.HandleGlobalSearch(searchCtrl))
 	r.Post("/search", handlerkeywordsearch.HandleSearch(searchCtrl))
 }
 
-func setupAdmin(r chi.Router, userCtrl *user.Controller) {
+func setupAdmin(r chi.Router, userCtrl *user.Controller, sysCtrl *system.Controller, runnerCtrl *runner.Controller) {
 	r.Route("/admin", func(r chi.Router) {
 		r.Use(middlewareprincipal.RestrictToAdmin())
 		r.Route("/users", func(r chi.Router) {
@@ -656,12 +956,68 @@ func setupAdmin(r chi.Router, userCtrl *user.Controller) {
 				r.Patch("/admin", handleruser.HandleUpdateAdmin(userCtrl))
 			})
 		})
+
+		r.Route("/compliance/snapshots", func(r chi.Router) {
+			r.Get("/", handlersystem.HandleListComplianceSnapshots(sysCtrl))
+			r.Get("/export", handlersystem.HandleExportComplianceSnapshots(sysCtrl))
+		})
+
+		r.Get("/git-usage", handlersystem.HandleListGitUsage(sysCtrl))
+		r.Get("/repo-aliases", handlersystem.HandleListRepoAliases(sysCtrl))
+
+		r.Route(fmt.Sprintf("/repos/{%s}/maintenance", request.PathParamRepoRef), func(r chi.Router) {
+			r.Get("/", handlersystem.HandleGetRepoMaintenance(sysCtrl))
+			r.Post("/", handlersystem.HandleTriggerRepoMaintenance(sysCtrl))
+		})
+		r.Get("/metrics", handlersystem.HandleMetrics())
+
+		r.Route("/db/queries", func(r chi.Router) {
+			r.Get("/", handlersystem.HandleGetQueryReport())
+			r.Delete("/", handlersystem.HandleResetQueryStats())
+		})
+
+		r.Route("/runners", func(r chi.Router) {
+			r.Get("/", handlerrunner.HandleList(runnerCtrl))
+
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamRunnerUID), func(r chi.Router) {
+				r.Delete("/", handlerrunner.HandleDelete(runnerCtrl))
+				r.Post("/drain", handlerrunner.HandleDrain(runnerCtrl))
+			})
+		})
+	})
+}
+
+// setupRunners sets up the routes used by the self-hosted runners.
+// Registered runners use the drone runner protocol, authenticated with their runner token.
+func setupRunners(r chi.Router, runnerCtrl *runner.Controller) {
+	r.Route("/runners", func(r chi.Router) {
+		r.Post("/register", handlerrunner.HandleRegister(runnerCtrl))
+
+		r.Route("/rpc/v2", func(r chi.Router) {
+			r.Post("/ping", handlerrunner.HandlePing(runnerCtrl))
+			r.Post("/stage", handlerrunner.HandleRequestStage(runnerCtrl))
+			r.Route(fmt.Sprintf("/stage/{%s}", request.PathParamRunnerStageID), func(r chi.Router) {
+				r.Post("/", handlerrunner.HandleAcceptStage(runnerCtrl))
+				r.Get("/", handlerrunner.HandleStageDetails(runnerCtrl))
+				r.Put("/", handlerrunner.HandleUpdateStage(runnerCtrl))
+			})
+			r.Route(fmt.Sprintf("/step/{%s}", request.PathParamRunnerStepID), func(r chi.Router) {
+				r.Put("/", handlerrunner.HandleUpdateStep(runnerCtrl))
+				r.Post("/logs/batch", handlerrunner.HandleWriteLogs(runnerCtrl))
+				r.Post("/logs/upload", handlerrunner.HandleUploadLogs(runnerCtrl))
+				r.Post("/card", handlerrunner.HandleUploadCard(runnerCtrl))
+			})
+			r.Post(fmt.Sprintf("/build/{%s}/watch", request.PathParamRunnerBuildID),
+				handlerrunner.HandleWatch(runnerCtrl))
+		})
 	})
 }
 
 func setupAccount(r chi.Router, userCtrl *user.Controller, sysCtrl *system.Controller, config *types.Config) {
 	cookieName := config.Token.CookieName
 	r.Post("/login", account.HandleLogin(userCtrl, cookieName))
+	r.Post("/login/passkey/begin", account.HandleLoginPasskeyBegin(userCtrl))
+	r.Post("/login/passkey", account.HandleLoginPasskeyFinish(userCtrl, cookieName))
 	r.Post("/register", account.HandleRegister(userCtrl, sysCtrl, cookieName))
 	r.Post("/logout", account.HandleLogout(userCtrl, cookieName))
 }
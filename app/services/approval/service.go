@@ -0,0 +1,191 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package approval
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/pipeline/manager"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobType = "pipeline-approvals-expire"
+
+	// expiredApprovalsBatchSize is the maximum number of timed out approvals processed by a single job run.
+	expiredApprovalsBatchSize = 100
+)
+
+// ErrAlreadyDecided is returned if the approval was decided by someone else in the meantime.
+var ErrAlreadyDecided = errors.New("approval is already decided")
+
+// Service resolves the approval gates of pipeline executions,
+// either with a decision of a user or when the approval times out.
+type Service struct {
+	cron             string
+	maxDur           time.Duration
+	approvalStore    store.ApprovalStore
+	stageStore       store.StageStore
+	executionManager manager.ExecutionManager
+	scheduler        *job.Scheduler
+}
+
+func (s *Service) Register(ctx context.Context) error {
+	err := s.scheduler.AddRecurring(ctx, jobType, jobType, s.cron, s.maxDur)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for pipeline approval service: %w", err)
+	}
+
+	return nil
+}
+
+// CanDecide returns true if the principal is allowed to decide on the approval.
+// An empty list of approvers allows anyone with the permission to execute the pipeline.
+func CanDecide(approval *types.Approval, principal *types.Principal) bool {
+	if len(approval.Approvers) == 0 {
+		return true
+	}
+
+	for _, approver := range approval.Approvers {
+		if strings.EqualFold(approver, principal.UID) ||
+			(principal.Email != "" && strings.EqualFold(approver, principal.Email)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Deadline returns the time in unix milliseconds by which the blocked stage must be decided on,
+// or zero if the approval doesn't time out or the stage isn't blocked yet.
+func Deadline(stage *types.Stage, approval *types.Approval) int64 {
+	if approval.Timeout <= 0 || stage.Status != enum.CIStatusBlocked || stage.Started == 0 {
+		return 0
+	}
+	return stage.Started + approval.Timeout
+}
+
+// Decide records the decision of the principal and resumes the execution
+// if the stage got approved, or fails it if the stage got rejected.
+func (s *Service) Decide(
+	ctx context.Context,
+	stage *types.Stage,
+	approval *types.Approval,
+	principal *types.Principal,
+	decision enum.ApprovalDecision,
+	comment string,
+) error {
+	state := enum.ApprovalStateApproved
+	status := enum.CIStatusSuccess
+	var stageErr string
+	if decision == enum.ApprovalDecisionReject {
+		state = enum.ApprovalStateRejected
+		status = enum.CIStatusDeclined
+		stageErr = fmt.Sprintf("Rejected by %s", principal.DisplayName)
+	}
+
+	return s.resolve(ctx, stage, approval, state, &principal.ID, comment, status, stageErr)
+}
+
+// Handle fails the blocked stages whose approval timed out.
+func (s *Service) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	approvals, err := s.approvalStore.ListExpired(ctx, time.Now().UnixMilli(), expiredApprovalsBatchSize)
+	if err != nil {
+		return "", fmt.Errorf("failed to list expired approvals: %w", err)
+	}
+
+	var expired int
+	for _, approval := range approvals {
+		if ctx.Err() != nil {
+			break
+		}
+
+		log := log.Ctx(ctx).With().
+			Int64("stage_id", approval.StageID).
+			Logger()
+
+		stage, err := s.stageStore.Find(ctx, approval.StageID)
+		if err != nil {
+			log.Warn().Err(err).Msg("failed to find stage of expired approval")
+			continue
+		}
+
+		err = s.resolve(ctx, stage, approval, enum.ApprovalStateExpired, nil, "",
+			enum.CIStatusFailure, "Approval timed out")
+		if errors.Is(err, ErrAlreadyDecided) {
+			continue
+		}
+		if err != nil {
+			log.Warn().Err(err).Msg("failed to expire approval")
+			continue
+		}
+
+		expired++
+	}
+
+	return fmt.Sprintf("expired %d approvals", expired), nil
+}
+
+func (s *Service) resolve(
+	ctx context.Context,
+	stage *types.Stage,
+	approval *types.Approval,
+	state enum.ApprovalState,
+	decidedBy *int64,
+	comment string,
+	status enum.CIStatus,
+	stageErr string,
+) error {
+	now := time.Now().UnixMilli()
+
+	approval.State = state
+	approval.DecidedBy = decidedBy
+	approval.Decided = now
+	approval.Comment = comment
+
+	// the optimistic lock guarantees that an approval is resolved only once.
+	err := s.approvalStore.Update(ctx, approval)
+	if errors.Is(err, gitness_store.ErrVersionConflict) {
+		return ErrAlreadyDecided
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update approval: %w", err)
+	}
+
+	stage.Status = status
+	stage.Error = stageErr
+	stage.Stopped = now
+	if stage.Started == 0 {
+		stage.Started = now
+	}
+
+	// completing the stage schedules the downstream stages or finishes the execution.
+	err = s.executionManager.AfterStage(ctx, stage)
+	if err != nil {
+		return fmt.Errorf("failed to complete approval stage: %w", err)
+	}
+
+	return nil
+}
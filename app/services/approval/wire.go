@@ -0,0 +1,53 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package approval
+
+import (
+	"github.com/harness/gitness/app/pipeline/manager"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	ProvideService,
+)
+
+func ProvideService(
+	config *types.Config,
+	approvalStore store.ApprovalStore,
+	stageStore store.StageStore,
+	executionManager manager.ExecutionManager,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+) (*Service, error) {
+	s := &Service{
+		cron:             config.PipelineApproval.CRON,
+		maxDur:           config.PipelineApproval.MaxDuration,
+		approvalStore:    approvalStore,
+		stageStore:       stageStore,
+		executionManager: executionManager,
+		scheduler:        scheduler,
+	}
+
+	err := executor.Register(jobType, s)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
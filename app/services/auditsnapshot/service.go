@@ -0,0 +1,202 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditsnapshot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const jobType = "compliance-snapshot"
+
+// Service periodically freezes the audit aggregates of the previous month
+// into immutable, hash chained compliance snapshots.
+type Service struct {
+	enabled       bool
+	cron          string
+	maxDur        time.Duration
+	snapshotStore store.ComplianceSnapshotStore
+	activityStore store.PullReqActivityStore
+	repoStore     store.RepoStore
+	scheduler     *job.Scheduler
+}
+
+func (s *Service) Register(ctx context.Context) error {
+	if !s.enabled {
+		return nil
+	}
+
+	err := s.scheduler.AddRecurring(ctx, jobType, jobType, s.cron, s.maxDur)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for compliance snapshot service: %w", err)
+	}
+
+	return nil
+}
+
+// Handle creates a snapshot for every complete month that isn't covered by a snapshot yet.
+// Without any previous snapshot, only the previous month is recorded.
+func (s *Service) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	if !s.enabled {
+		return "", nil
+	}
+
+	now := time.Now().UTC()
+	currentMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	prev, err := s.snapshotStore.Last(ctx)
+	if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return "", fmt.Errorf("failed to find last compliance snapshot: %w", err)
+	}
+
+	start := currentMonth.AddDate(0, -1, 0)
+	if prev != nil {
+		start = time.UnixMilli(prev.PeriodEnd).UTC()
+	}
+
+	var created int
+	for ; start.Before(currentMonth); start = start.AddDate(0, 1, 0) {
+		end := start.AddDate(0, 1, 0)
+
+		snapshot, err := s.createSnapshot(ctx, prev, start.UnixMilli(), end.UnixMilli())
+		if errors.Is(err, gitness_store.ErrDuplicate) {
+			// the snapshot was concurrently created by another instance.
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to create compliance snapshot for %s: %w", start.Format("2006-01"), err)
+		}
+
+		log.Ctx(ctx).Info().
+			Int64("snapshot_id", snapshot.ID).
+			Str("period", start.Format("2006-01")).
+			Msg("created compliance snapshot")
+
+		prev = snapshot
+		created++
+	}
+
+	return fmt.Sprintf("created %d compliance snapshots", created), nil
+}
+
+func (s *Service) createSnapshot(
+	ctx context.Context,
+	prev *types.ComplianceSnapshot,
+	periodStart, periodEnd int64,
+) (*types.ComplianceSnapshot, error) {
+	data, err := s.aggregate(ctx, periodStart, periodEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	dataJSON, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal compliance snapshot data: %w", err)
+	}
+
+	snapshot := &types.ComplianceSnapshot{
+		PeriodStart: periodStart,
+		PeriodEnd:   periodEnd,
+		Data:        dataJSON,
+		Created:     time.Now().UnixMilli(),
+	}
+	if prev != nil {
+		snapshot.PrevHash = prev.Hash
+	}
+
+	snapshot.Hash = snapshot.ComputeHash()
+
+	if err = s.snapshotStore.Create(ctx, snapshot); err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+// aggregate collects the pull request merges and rule bypasses in the period [periodStart, periodEnd).
+func (s *Service) aggregate(
+	ctx context.Context,
+	periodStart, periodEnd int64,
+) (*types.ComplianceSnapshotData, error) {
+	activities, err := s.activityStore.ListAll(ctx, &types.PullReqActivityFilter{
+		After:  periodStart - 1,
+		Before: periodEnd,
+		Types:  []enum.PullReqActivityType{enum.PullReqActivityTypeMerge},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list merge activities: %w", err)
+	}
+
+	data := &types.ComplianceSnapshotData{}
+	repos := map[int64]*types.ComplianceSnapshotRepoData{}
+	bypassedBy := map[int64]map[int64]struct{}{}
+
+	for _, act := range activities {
+		repoData, ok := repos[act.RepoID]
+		if !ok {
+			repoData = &types.ComplianceSnapshotRepoData{RepoID: act.RepoID}
+			if repo, err := s.repoStore.Find(ctx, act.RepoID); err == nil {
+				repoData.RepoPath = repo.Path
+			}
+
+			repos[act.RepoID] = repoData
+			bypassedBy[act.RepoID] = map[int64]struct{}{}
+		}
+
+		repoData.PullReqsMerged++
+		data.PullReqsMerged++
+
+		payload, err := act.GetPayload()
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("activity_id", act.ID).Msg("failed to get merge activity payload")
+			continue
+		}
+
+		merge, ok := payload.(*types.PullRequestActivityPayloadMerge)
+		if !ok || len(merge.BypassedRules) == 0 {
+			continue
+		}
+
+		repoData.RuleBypasses++
+		data.RuleBypasses++
+		bypassedBy[act.RepoID][act.CreatedBy] = struct{}{}
+	}
+
+	data.Repos = make([]types.ComplianceSnapshotRepoData, 0, len(repos))
+	for repoID, repoData := range repos {
+		for principalID := range bypassedBy[repoID] {
+			repoData.BypassedBy = append(repoData.BypassedBy, principalID)
+		}
+		sort.Slice(repoData.BypassedBy, func(i, j int) bool { return repoData.BypassedBy[i] < repoData.BypassedBy[j] })
+
+		data.Repos = append(data.Repos, *repoData)
+	}
+
+	sort.Slice(data.Repos, func(i, j int) bool { return data.Repos[i].RepoID < data.Repos[j].RepoID })
+
+	return data, nil
+}
@@ -0,0 +1,237 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autolink
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/harness/gitness/types"
+)
+
+const (
+	maxPatternLength   = 256
+	maxTargetURLLength = 2048
+
+	// maxReferences limits the number of references resolved in a single call.
+	maxReferences = 100
+)
+
+var placeholderRegex = regexp.MustCompile(`\{(\d+)\}`)
+
+// markdownEscaper escapes the characters that would break the text of a markdown link.
+var markdownEscaper = strings.NewReplacer(`\`, `\\`, "[", `\[`, "]", `\]`)
+
+// Validate returns an error if the pattern or the target URL template of an autolink rule is invalid.
+func Validate(pattern, targetURL string) error {
+	if pattern == "" {
+		return errors.New("pattern must be provided")
+	}
+	if len(pattern) > maxPatternLength {
+		return fmt.Errorf("pattern can't be longer than %d characters", maxPatternLength)
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("pattern is not a valid regular expression: %w", err)
+	}
+
+	if re.MatchString("") {
+		return errors.New("pattern must not match an empty string")
+	}
+
+	if len(targetURL) > maxTargetURLLength {
+		return fmt.Errorf("target URL can't be longer than %d characters", maxTargetURLLength)
+	}
+
+	if !strings.HasPrefix(targetURL, "http://") && !strings.HasPrefix(targetURL, "https://") {
+		return errors.New("target URL must be an absolute http or https URL")
+	}
+
+	for _, m := range placeholderRegex.FindAllStringSubmatch(targetURL, -1) {
+		idx, _ := strconv.Atoi(m[1])
+		if idx > re.NumSubexp() {
+			return fmt.Errorf("target URL placeholder %s refers to a non-existing capture group", m[0])
+		}
+	}
+
+	if _, err = url.Parse(placeholderRegex.ReplaceAllString(targetURL, "x")); err != nil {
+		return fmt.Errorf("target URL is invalid: %w", err)
+	}
+
+	return nil
+}
+
+type rule struct {
+	re        *regexp.Regexp
+	targetURL string
+}
+
+// Linker resolves references in texts using a set of autolink rules.
+type Linker struct {
+	rules []rule
+}
+
+// New returns a Linker for the provided autolink rules.
+// The rules are applied in the provided order, the first rule that matches a reference wins.
+// Invalid rules are ignored.
+func New(autolinks []*types.Autolink) *Linker {
+	rules := make([]rule, 0, len(autolinks))
+	for _, autolink := range autolinks {
+		re, err := regexp.Compile(autolink.Pattern)
+		if err != nil {
+			continue
+		}
+
+		rules = append(rules, rule{re: re, targetURL: autolink.TargetURL})
+	}
+
+	return &Linker{rules: rules}
+}
+
+// Resolve returns all distinct references found in the texts along with the links they resolve to.
+// A reference is matched only as a whole word, e.g. pattern `JIRA-\d+` doesn't match "XJIRA-1".
+func (l *Linker) Resolve(texts ...string) []types.AutolinkReference {
+	if l == nil || len(l.rules) == 0 {
+		return nil
+	}
+
+	var refs []types.AutolinkReference
+	seen := map[string]struct{}{}
+
+	for _, text := range texts {
+		for _, r := range l.rules {
+			for _, loc := range r.re.FindAllStringSubmatchIndex(text, -1) {
+				if !isWholeWord(text, loc[0], loc[1]) {
+					continue
+				}
+
+				ref := text[loc[0]:loc[1]]
+				if _, ok := seen[ref]; ok {
+					continue
+				}
+				seen[ref] = struct{}{}
+
+				refs = append(refs, types.AutolinkReference{
+					Text: ref,
+					URL:  expand(r.targetURL, text, loc),
+				})
+
+				if len(refs) >= maxReferences {
+					return refs
+				}
+			}
+		}
+	}
+
+	return refs
+}
+
+// Markdown returns the markdown text with all references replaced by links to the resolved URLs.
+// If rules match overlapping references, the first rule wins. Existing links and code aren't detected,
+// so the method is meant for plain text that is embedded into markdown (e.g. titles).
+func (l *Linker) Markdown(text string) string {
+	if l == nil || len(l.rules) == 0 {
+		return text
+	}
+
+	type match struct {
+		start, end int
+		url        string
+	}
+
+	var matches []match
+	for _, r := range l.rules {
+	nextLoc:
+		for _, loc := range r.re.FindAllStringSubmatchIndex(text, -1) {
+			if !isWholeWord(text, loc[0], loc[1]) {
+				continue
+			}
+
+			for _, m := range matches {
+				if loc[0] < m.end && m.start < loc[1] {
+					continue nextLoc
+				}
+			}
+
+			matches = append(matches, match{start: loc[0], end: loc[1], url: expand(r.targetURL, text, loc)})
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool { return matches[i].start < matches[j].start })
+
+	sb := strings.Builder{}
+	pos := 0
+	for _, m := range matches {
+		sb.WriteString(text[pos:m.start])
+		sb.WriteString("[")
+		sb.WriteString(markdownEscaper.Replace(text[m.start:m.end]))
+		sb.WriteString("](")
+		sb.WriteString(m.url)
+		sb.WriteString(")")
+		pos = m.end
+	}
+	sb.WriteString(text[pos:])
+
+	return sb.String()
+}
+
+// expand replaces placeholders in the target URL template with the escaped values of the matched groups.
+func expand(targetURL, text string, loc []int) string {
+	return placeholderRegex.ReplaceAllStringFunc(targetURL, func(placeholder string) string {
+		idx, _ := strconv.Atoi(placeholder[1 : len(placeholder)-1])
+		if 2*idx+1 >= len(loc) || loc[2*idx] < 0 {
+			return ""
+		}
+
+		return url.PathEscape(text[loc[2*idx]:loc[2*idx+1]])
+	})
+}
+
+// isWholeWord returns true if the match isn't a part of a longer word.
+func isWholeWord(text string, start, end int) bool {
+	if start == end {
+		return false
+	}
+
+	if start > 0 {
+		before, _ := utf8.DecodeLastRuneInString(text[:start])
+		first, _ := utf8.DecodeRuneInString(text[start:])
+		if isWordRune(before) && isWordRune(first) {
+			return false
+		}
+	}
+
+	if end < len(text) {
+		last, _ := utf8.DecodeLastRuneInString(text[:end])
+		after, _ := utf8.DecodeRuneInString(text[end:])
+		if isWordRune(last) && isWordRune(after) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,169 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autolink
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		pattern   string
+		targetURL string
+		valid     bool
+	}{
+		{
+			name:      "whole-match",
+			pattern:   `JIRA-\d+`,
+			targetURL: "https://jira.example.com/browse/{0}",
+			valid:     true,
+		},
+		{
+			name:      "capture-group",
+			pattern:   `#(\d+)`,
+			targetURL: "https://tracker.example.com/issues/{1}",
+			valid:     true,
+		},
+		{
+			name:      "empty-pattern",
+			pattern:   "",
+			targetURL: "https://example.com/{0}",
+		},
+		{
+			name:      "invalid-pattern",
+			pattern:   `JIRA-(\d+`,
+			targetURL: "https://example.com/{0}",
+		},
+		{
+			name:      "matches-empty",
+			pattern:   `\d*`,
+			targetURL: "https://example.com/{0}",
+		},
+		{
+			name:      "relative-url",
+			pattern:   `JIRA-\d+`,
+			targetURL: "/browse/{0}",
+		},
+		{
+			name:      "non-existing-group",
+			pattern:   `JIRA-(\d+)`,
+			targetURL: "https://example.com/{2}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := Validate(test.pattern, test.targetURL)
+			if test.valid && err != nil {
+				t.Errorf("expected valid, got: %s", err)
+			} else if !test.valid && err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestLinkerResolve(t *testing.T) {
+	linker := New([]*types.Autolink{
+		{Pattern: `JIRA-\d+`, TargetURL: "https://jira.example.com/browse/{0}"},
+		{Pattern: `#(\d+)`, TargetURL: "https://tracker.example.com/issues/{1}"},
+		{Pattern: `JIRA-(\d+)`, TargetURL: "https://other.example.com/{1}"},
+	})
+
+	tests := []struct {
+		name  string
+		texts []string
+		exp   []types.AutolinkReference
+	}{
+		{
+			name:  "no-references",
+			texts: []string{"Fix the build"},
+			exp:   nil,
+		},
+		{
+			name:  "multiple-texts-and-rules",
+			texts: []string{"JIRA-12: Fix the build", "Fixes #7 and JIRA-12, see JIRA-3."},
+			exp: []types.AutolinkReference{
+				{Text: "JIRA-12", URL: "https://jira.example.com/browse/JIRA-12"},
+				{Text: "JIRA-3", URL: "https://jira.example.com/browse/JIRA-3"},
+				{Text: "#7", URL: "https://tracker.example.com/issues/7"},
+			},
+		},
+		{
+			name:  "part-of-word",
+			texts: []string{"XJIRA-1 JIRA-2x JIRA-3"},
+			exp: []types.AutolinkReference{
+				{Text: "JIRA-3", URL: "https://jira.example.com/browse/JIRA-3"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			refs := linker.Resolve(test.texts...)
+			if !reflect.DeepEqual(test.exp, refs) {
+				t.Errorf("expected %+v, got %+v", test.exp, refs)
+			}
+		})
+	}
+}
+
+func TestLinkerMarkdown(t *testing.T) {
+	linker := New([]*types.Autolink{
+		{Pattern: `JIRA-\d+`, TargetURL: "https://jira.example.com/browse/{0}"},
+		{Pattern: `#(\d+)`, TargetURL: "https://tracker.example.com/issues/{1}"},
+		{Pattern: `[A-Z]+-\d+`, TargetURL: "https://other.example.com/{0}"},
+	})
+
+	tests := []struct {
+		name string
+		text string
+		exp  string
+	}{
+		{
+			name: "no-references",
+			text: "Fix the build",
+			exp:  "Fix the build",
+		},
+		{
+			name: "multiple-references",
+			text: "JIRA-12: fixes #7 and JIRA-12",
+			exp: "[JIRA-12](https://jira.example.com/browse/JIRA-12): fixes [#7](https://tracker.example.com/issues/7)" +
+				" and [JIRA-12](https://jira.example.com/browse/JIRA-12)",
+		},
+		{
+			name: "first-rule-wins",
+			text: "JIRA-1 and OPS-2",
+			exp:  "[JIRA-1](https://jira.example.com/browse/JIRA-1) and [OPS-2](https://other.example.com/OPS-2)",
+		},
+		{
+			name: "part-of-word",
+			text: "1JIRA-1 JIRA-2x",
+			exp:  "1JIRA-1 JIRA-2x",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := linker.Markdown(test.text); got != test.exp {
+				t.Errorf("expected %q, got %q", test.exp, got)
+			}
+		})
+	}
+}
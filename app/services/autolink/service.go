@@ -0,0 +1,73 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autolink
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+)
+
+// Service provides the autolink rules that apply to repositories.
+type Service struct {
+	autolinkStore store.AutolinkStore
+	spaceStore    store.SpaceStore
+}
+
+func NewService(autolinkStore store.AutolinkStore, spaceStore store.SpaceStore) *Service {
+	return &Service{
+		autolinkStore: autolinkStore,
+		spaceStore:    spaceStore,
+	}
+}
+
+// ListForRepository returns all autolink rules that apply to the repository:
+// the rules of the repository first, followed by the rules of its space and the ancestor spaces.
+func (s *Service) ListForRepository(ctx context.Context, repo *types.Repository) ([]*types.Autolink, error) {
+	autolinks, err := s.autolinkStore.ListByRepo(ctx, repo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repository autolinks: %w", err)
+	}
+
+	for id := repo.ParentID; id > 0; {
+		space, err := s.spaceStore.Find(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find space: %w", err)
+		}
+
+		spaceAutolinks, err := s.autolinkStore.ListBySpace(ctx, space.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list space autolinks: %w", err)
+		}
+
+		autolinks = append(autolinks, spaceAutolinks...)
+
+		id = space.ParentID
+	}
+
+	return autolinks, nil
+}
+
+// ForRepository returns the Linker that resolves references using the rules that apply to the repository.
+func (s *Service) ForRepository(ctx context.Context, repo *types.Repository) (*Linker, error) {
+	autolinks, err := s.ListForRepository(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(autolinks), nil
+}
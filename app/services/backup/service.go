@@ -0,0 +1,899 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/bundle"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/webhook"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/encrypt"
+	gitnesserrors "github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// pageSize is the page size used to list the metadata of a repository.
+const pageSize = 100
+
+// Service collects the metadata of a repository (pull requests with their activities,
+// protection rules and webhooks) for the portable repository bundle and restores it into another repository.
+type Service struct {
+	tx                 dbtx.Transactor
+	git                git.Interface
+	repoStore          store.RepoStore
+	principalStore     store.PrincipalStore
+	pullReqStore       store.PullReqStore
+	activityStore      store.PullReqActivityStore
+	ruleStore          store.RuleStore
+	webhookStore       store.WebhookStore
+	principalInfoCache store.PrincipalInfoCache
+	protectionManager  *protection.Manager
+	encrypter          encrypt.Encrypter
+
+	webhookAllowLoopback       bool
+	webhookAllowPrivateNetwork bool
+}
+
+// RestoreResult contains the number of restored entries.
+type RestoreResult struct {
+	PullRequests        int `json:"pull_requests"`
+	PullRequestsSkipped int `json:"pull_requests_skipped"`
+	Rules               int `json:"rules"`
+	Webhooks            int `json:"webhooks"`
+}
+
+// Collect returns the metadata of the repository. Webhook secrets are not included.
+func (s *Service) Collect(ctx context.Context, repo *types.Repository) (*bundle.Metadata, error) {
+	principalIDs := make(map[int64]struct{})
+	addPrincipal := func(ids ...*int64) {
+		for _, id := range ids {
+			if id != nil {
+				principalIDs[*id] = struct{}{}
+			}
+		}
+	}
+
+	pullReqs, err := s.collectPullReqs(ctx, repo, addPrincipal)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := s.collectRules(ctx, repo, addPrincipal)
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks, err := s.collectWebhooks(ctx, repo, addPrincipal)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, 0, len(principalIDs))
+	for id := range principalIDs {
+		ids = append(ids, id)
+	}
+
+	infos, err := s.principalInfoCache.Map(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get principal infos: %w", err)
+	}
+
+	principals := make([]bundle.Principal, 0, len(infos))
+	for _, info := range infos {
+		principals = append(principals, bundle.Principal{
+			ID:          info.ID,
+			UID:         info.UID,
+			Email:       info.Email,
+			DisplayName: info.DisplayName,
+		})
+	}
+
+	sort.Slice(principals, func(i, j int) bool {
+		return principals[i].ID < principals[j].ID
+	})
+
+	return &bundle.Metadata{
+		Principals:   principals,
+		PullRequests: pullReqs,
+		Rules:        rules,
+		Webhooks:     webhooks,
+	}, nil
+}
+
+func (s *Service) collectPullReqs(
+	ctx context.Context,
+	repo *types.Repository,
+	addPrincipal func(ids ...*int64),
+) ([]bundle.PullRequest, error) {
+	var pullReqs []bundle.PullRequest
+
+	for page := 1; ; page++ {
+		list, err := s.pullReqStore.List(ctx, &types.PullReqFilter{
+			Page:         page,
+			Size:         pageSize,
+			TargetRepoID: repo.ID,
+			Sort:         enum.PullReqSortNumber,
+			Order:        enum.OrderAsc,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pull requests: %w", err)
+		}
+
+		for _, pr := range list {
+			activities, err := s.activityStore.List(ctx, pr.ID, &types.PullReqActivityFilter{})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list activities of pull request #%d: %w", pr.Number, err)
+			}
+
+			addPrincipal(&pr.CreatedBy, pr.MergedBy)
+			for _, act := range activities {
+				addPrincipal(&act.CreatedBy, act.ResolvedBy)
+			}
+
+			pullReqs = append(pullReqs, exportedPullReq(pr, activities))
+		}
+
+		if len(list) < pageSize {
+			return pullReqs, nil
+		}
+	}
+}
+
+func (s *Service) collectRules(
+	ctx context.Context,
+	repo *types.Repository,
+	addPrincipal func(ids ...*int64),
+) ([]bundle.Rule, error) {
+	var rules []bundle.Rule
+
+	for page := 1; ; page++ {
+		list, err := s.ruleStore.List(ctx, nil, &repo.ID, &types.RuleFilter{
+			ListQueryFilter: types.ListQueryFilter{
+				Pagination: types.Pagination{Page: page, Size: pageSize},
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list protection rules: %w", err)
+		}
+
+		for i := range list {
+			rule := &list[i]
+
+			definition, err := s.protectionManager.FromJSON(rule.Type, rule.Definition, false)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse definition of protection rule %s: %w", rule.UID, err)
+			}
+
+			userIDs, err := definition.UserIDs()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get users of protection rule %s: %w", rule.UID, err)
+			}
+
+			addPrincipal(&rule.CreatedBy)
+			for i := range userIDs {
+				addPrincipal(&userIDs[i])
+			}
+
+			rules = append(rules, bundle.Rule{
+				UID:         rule.UID,
+				Description: rule.Description,
+				Type:        string(rule.Type),
+				State:       string(rule.State),
+				CreatedBy:   rule.CreatedBy,
+				Created:     rule.Created,
+				Pattern:     rule.Pattern,
+				Definition:  rule.Definition,
+			})
+		}
+
+		if len(list) < pageSize {
+			return rules, nil
+		}
+	}
+}
+
+func (s *Service) collectWebhooks(
+	ctx context.Context,
+	repo *types.Repository,
+	addPrincipal func(ids ...*int64),
+) ([]bundle.Webhook, error) {
+	var webhooks []bundle.Webhook
+
+	for page := 1; ; page++ {
+		list, err := s.webhookStore.List(ctx, enum.WebhookParentRepo, repo.ID, &types.WebhookFilter{
+			Page:         page,
+			Size:         pageSize,
+			SkipInternal: true,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list webhooks: %w", err)
+		}
+
+		for _, hook := range list {
+			addPrincipal(&hook.CreatedBy)
+
+			triggers := make([]string, len(hook.Triggers))
+			for i, trigger := range hook.Triggers {
+				triggers[i] = string(trigger)
+			}
+
+			// the secret is stored encrypted, so an empty secret is never stored as an empty string.
+			hasSecret := false
+			if hook.Secret != "" {
+				secret, err := s.encrypter.Decrypt([]byte(hook.Secret))
+				if err != nil {
+					return nil, fmt.Errorf("failed to decrypt secret of webhook %s: %w", hook.UID, err)
+				}
+				hasSecret = secret != ""
+			}
+
+			webhooks = append(webhooks, bundle.Webhook{
+				UID:                 hook.UID,
+				DisplayName:         hook.DisplayName,
+				Description:         hook.Description,
+				URL:                 hook.URL,
+				CreatedBy:           hook.CreatedBy,
+				Created:             hook.Created,
+				Enabled:             hook.Enabled,
+				Insecure:            hook.Insecure,
+				HasSecret:           hasSecret,
+				Triggers:            triggers,
+				PayloadTemplateType: string(hook.PayloadTemplateType),
+				PayloadTemplate:     hook.PayloadTemplate,
+			})
+		}
+
+		if len(list) < pageSize {
+			return webhooks, nil
+		}
+	}
+}
+
+func exportedPullReq(pr *types.PullReq, activities []*types.PullReqActivity) bundle.PullRequest {
+	var mergeMethod *string
+	if pr.MergeMethod != nil {
+		method := string(*pr.MergeMethod)
+		mergeMethod = &method
+	}
+
+	result := bundle.PullRequest{
+		Number:         pr.Number,
+		CreatedBy:      pr.CreatedBy,
+		Created:        pr.Created,
+		Edited:         pr.Edited,
+		State:          string(pr.State),
+		IsDraft:        pr.IsDraft,
+		Title:          pr.Title,
+		Description:    pr.Description,
+		Fork:           pr.SourceRepoID != pr.TargetRepoID,
+		SourceBranch:   pr.SourceBranch,
+		SourceSHA:      pr.SourceSHA,
+		TargetBranch:   pr.TargetBranch,
+		MergeBaseSHA:   pr.MergeBaseSHA,
+		MergedBy:       pr.MergedBy,
+		Merged:         pr.Merged,
+		MergeMethod:    mergeMethod,
+		MergeSHA:       pr.MergeSHA,
+		MergeTargetSHA: pr.MergeTargetSHA,
+		Activities:     make([]bundle.Activity, len(activities)),
+	}
+
+	for i, act := range activities {
+		result.Activities[i] = bundle.Activity{
+			CreatedBy:  act.CreatedBy,
+			Created:    act.Created,
+			Edited:     act.Edited,
+			Deleted:    act.Deleted,
+			Order:      act.Order,
+			SubOrder:   act.SubOrder,
+			Type:       string(act.Type),
+			Kind:       string(act.Kind),
+			Text:       act.Text,
+			Payload:    act.PayloadRaw,
+			Metadata:   act.Metadata,
+			ResolvedBy: act.ResolvedBy,
+			Resolved:   act.Resolved,
+		}
+
+		if act.CodeComment != nil {
+			result.Activities[i].CodeComment = &bundle.CodeComment{
+				Outdated:     act.CodeComment.Outdated,
+				MergeBaseSHA: act.CodeComment.MergeBaseSHA,
+				SourceSHA:    act.CodeComment.SourceSHA,
+				Path:         act.CodeComment.Path,
+				LineNew:      act.CodeComment.LineNew,
+				SpanNew:      act.CodeComment.SpanNew,
+				LineOld:      act.CodeComment.LineOld,
+				SpanOld:      act.CodeComment.SpanOld,
+			}
+		}
+	}
+
+	return result
+}
+
+// Restore creates the pull requests, protection rules and webhooks of the metadata in the repository.
+// The git data must have been restored already, including the pull request head references.
+//
+// The metadata can't be trusted, so everything is restored the way it would be created through the API:
+// Pull requests and comments are attributed to the principal performing the restore (the original authors
+// are only mentioned in the text), only comments are restored from the activities and webhooks are validated
+// like any other webhook. Webhooks that had a secret are restored disabled, because the secrets are not part
+// of the metadata. Users referenced by protection rules are matched by their UID and then by their email,
+// unknown users are removed from the rules.
+func (s *Service) Restore(
+	ctx context.Context,
+	principal *types.Principal,
+	repo *types.Repository,
+	writeParams git.WriteParams,
+	metadata *bundle.Metadata,
+) (RestoreResult, error) {
+	var result RestoreResult
+
+	principalUIDs := make(map[int64]string, len(metadata.Principals))
+	for _, p := range metadata.Principals {
+		principalUIDs[p.ID] = p.UID
+	}
+
+	var maxNumber int64
+	for i := range metadata.PullRequests {
+		exported := &metadata.PullRequests[i]
+
+		restored, err := s.restorePullReq(ctx, principal, repo, writeParams, exported, principalUIDs)
+		if err != nil {
+			return result, fmt.Errorf("failed to restore pull request #%d: %w", exported.Number, err)
+		}
+
+		if !restored {
+			result.PullRequestsSkipped++
+			continue
+		}
+
+		result.PullRequests++
+		if exported.Number > maxNumber {
+			maxNumber = exported.Number
+		}
+	}
+
+	if maxNumber > 0 {
+		_, err := s.repoStore.UpdateOptLock(ctx, repo, func(repo *types.Repository) error {
+			// new pull requests must not reuse the numbers of the restored ones.
+			if maxNumber > repo.PullReqSeq {
+				repo.PullReqSeq = maxNumber
+			}
+			return nil
+		})
+		if err != nil {
+			return result, fmt.Errorf("failed to update pull request sequence of the repository: %w", err)
+		}
+	}
+
+	if len(metadata.Rules) > 0 {
+		principalIDs, err := s.mapPrincipals(ctx, metadata.Principals)
+		if err != nil {
+			return result, err
+		}
+
+		for i := range metadata.Rules {
+			if err = s.restoreRule(ctx, principal, repo, &metadata.Rules[i], principalIDs); err != nil {
+				return result, fmt.Errorf("failed to restore protection rule %s: %w", metadata.Rules[i].UID, err)
+			}
+			result.Rules++
+		}
+	}
+
+	for i := range metadata.Webhooks {
+		if err := s.restoreWebhook(ctx, principal, repo, &metadata.Webhooks[i]); err != nil {
+			return result, fmt.Errorf("failed to restore webhook %s: %w", metadata.Webhooks[i].UID, err)
+		}
+		result.Webhooks++
+	}
+
+	return result, nil
+}
+
+// mapPrincipals maps the IDs of the exported principals to the IDs of the principals of this instance.
+// Principals that don't exist on this instance are not part of the result.
+func (s *Service) mapPrincipals(ctx context.Context, principals []bundle.Principal) (map[int64]int64, error) {
+	mapping := make(map[int64]int64, len(principals))
+
+	for _, exported := range principals {
+		p, err := s.principalStore.FindByUID(ctx, exported.UID)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) && exported.Email != "" {
+			p, err = s.principalStore.FindByEmail(ctx, exported.Email)
+		}
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to find principal %s: %w", exported.UID, err)
+		}
+
+		mapping[exported.ID] = p.ID
+	}
+
+	return mapping, nil
+}
+
+// restorePullReq restores the pull request along with its comments. It returns false if the pull request
+// can't be restored, because its source commit isn't available in the repository.
+func (s *Service) restorePullReq(
+	ctx context.Context,
+	principal *types.Principal,
+	repo *types.Repository,
+	writeParams git.WriteParams,
+	exported *bundle.PullRequest,
+	principalUIDs map[int64]string,
+) (bool, error) {
+	log := log.Ctx(ctx).With().
+		Int64("repo.id", repo.ID).
+		Int64("pullreq.number", exported.Number).
+		Logger()
+
+	if exported.Number <= 0 {
+		return false, fmt.Errorf("invalid pull request number %d", exported.Number)
+	}
+
+	readParams := git.CreateReadParams(repo)
+
+	_, err := s.git.GetCommit(ctx, &git.GetCommitParams{
+		ReadParams: readParams,
+		SHA:        exported.SourceSHA,
+	})
+	if gitnesserrors.IsNotFound(err) || gitnesserrors.IsInvalidArgument(err) {
+		log.Warn().Msg("skipping restore of pull request because its source commit isn't available")
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get source commit: %w", err)
+	}
+
+	state, ok := enum.PullReqState(exported.State).Sanitize()
+	if !ok {
+		return false, fmt.Errorf("invalid pull request state %q", exported.State)
+	}
+
+	var note string
+	if state == enum.PullReqStateOpen {
+		// an open pull request needs the source branch, but the branches of forks aren't part of the bundle.
+		isAvailable := false
+		if !exported.Fork {
+			_, err = s.git.GetBranch(ctx, &git.GetBranchParams{
+				ReadParams: readParams,
+				BranchName: exported.SourceBranch,
+			})
+			if err != nil && !gitnesserrors.IsNotFound(err) {
+				return false, fmt.Errorf("failed to get source branch: %w", err)
+			}
+			isAvailable = err == nil
+		}
+
+		if !isAvailable {
+			state = enum.PullReqStateClosed
+			note = fmt.Sprintf("The source branch `%s` isn't available in this repository, "+
+				"so the pull request has been restored as closed.", exported.SourceBranch)
+		}
+	}
+
+	pr, activities, err := restoredPullReq(principal, repo, exported, state, note, principalUIDs)
+	if err != nil {
+		return false, err
+	}
+
+	err = s.git.UpdateRef(ctx, git.UpdateRefParams{
+		WriteParams: writeParams,
+		Type:        gitenum.RefTypePullReqHead,
+		Name:        strconv.FormatInt(exported.Number, 10),
+		NewValue:    exported.SourceSHA,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to update pull request head reference: %w", err)
+	}
+
+	err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.pullReqStore.Create(ctx, pr); err != nil {
+			return fmt.Errorf("failed to create pull request: %w", err)
+		}
+
+		parentIDs := make(map[int64]int64)
+		for _, act := range activities {
+			act.PullReqID = pr.ID
+
+			if act.SubOrder > 0 {
+				parentID, ok := parentIDs[act.Order]
+				if !ok {
+					// a reply whose parent is missing can't be restored.
+					continue
+				}
+				act.ParentID = &parentID
+			}
+
+			if err := s.activityStore.Create(ctx, act); err != nil {
+				return fmt.Errorf("failed to create pull request comment: %w", err)
+			}
+
+			if act.SubOrder == 0 {
+				parentIDs[act.Order] = act.ID
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// restoredPullReq creates the pull request and its comments from the exported pull request.
+// Everything is attributed to the principal performing the restore, the original authors are mentioned in the text.
+// Activities other than comments (system activities) are not restored.
+func restoredPullReq(
+	principal *types.Principal,
+	repo *types.Repository,
+	exported *bundle.PullRequest,
+	state enum.PullReqState,
+	note string,
+	principalUIDs map[int64]string,
+) (*types.PullReq, []*types.PullReqActivity, error) {
+	now := time.Now().UnixMilli()
+
+	pr := &types.PullReq{
+		Number:           exported.Number,
+		CreatedBy:        principal.ID,
+		Created:          exported.Created,
+		Updated:          now,
+		Edited:           exported.Edited,
+		State:            state,
+		IsDraft:          exported.IsDraft && state == enum.PullReqStateOpen,
+		Title:            exported.Title,
+		Description:      restoredText("opened", principalUIDs[exported.CreatedBy], note, exported.Description),
+		SourceRepoID:     repo.ID,
+		SourceBranch:     exported.SourceBranch,
+		SourceSHA:        exported.SourceSHA,
+		TargetRepoID:     repo.ID,
+		TargetBranch:     exported.TargetBranch,
+		MergeCheckStatus: enum.MergeCheckStatusUnchecked,
+		MergeBaseSHA:     exported.MergeBaseSHA,
+	}
+
+	if state == enum.PullReqStateMerged {
+		merged := now
+		if exported.Merged != nil {
+			merged = *exported.Merged
+		}
+
+		pr.Merged = &merged
+		pr.MergedBy = &principal.ID
+		pr.MergeTargetSHA = exported.MergeTargetSHA
+		pr.MergeSHA = exported.MergeSHA
+
+		if exported.MergeMethod != nil {
+			method, ok := enum.MergeMethod(*exported.MergeMethod).Sanitize()
+			if !ok {
+				return nil, nil, fmt.Errorf("invalid merge method %q", *exported.MergeMethod)
+			}
+			pr.MergeMethod = &method
+		}
+	}
+
+	replySeq := make(map[int64]int64)
+	for _, act := range exported.Activities {
+		if act.SubOrder > replySeq[act.Order] {
+			replySeq[act.Order] = act.SubOrder
+		}
+	}
+
+	activities := make([]*types.PullReqActivity, 0, len(exported.Activities))
+	for i := range exported.Activities {
+		act := &exported.Activities[i]
+
+		activity, err := restoredComment(principal, repo, act, principalUIDs)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid activity %d.%d: %w", act.Order, act.SubOrder, err)
+		}
+		if activity == nil {
+			continue
+		}
+
+		if act.SubOrder == 0 {
+			activity.ReplySeq = replySeq[act.Order]
+		}
+
+		if act.Order > pr.ActivitySeq {
+			pr.ActivitySeq = act.Order
+		}
+
+		if activity.Deleted == nil {
+			pr.CommentCount++
+		}
+		if activity.IsBlocking() {
+			pr.UnresolvedCount++
+		}
+
+		activities = append(activities, activity)
+	}
+
+	return pr, activities, nil
+}
+
+// restoredComment creates the comment from the exported activity. It returns nil if the activity isn't a comment.
+// The payload of the comment is validated against the type of the comment.
+func restoredComment(
+	principal *types.Principal,
+	repo *types.Repository,
+	act *bundle.Activity,
+	principalUIDs map[int64]string,
+) (*types.PullReqActivity, error) {
+	activityType := enum.PullReqActivityType(act.Type)
+	activityKind := enum.PullReqActivityKind(act.Kind)
+
+	switch {
+	case activityType == enum.PullReqActivityTypeComment && activityKind == enum.PullReqActivityKindComment:
+	case activityType == enum.PullReqActivityTypeCodeComment && activityKind == enum.PullReqActivityKindChangeComment:
+		if act.CodeComment == nil {
+			return nil, errors.New("code comment without location")
+		}
+	default:
+		return nil, nil
+	}
+
+	if act.Order <= 0 || act.SubOrder < 0 {
+		return nil, fmt.Errorf("invalid order %d.%d", act.Order, act.SubOrder)
+	}
+
+	now := time.Now().UnixMilli()
+
+	activity := &types.PullReqActivity{
+		CreatedBy: principal.ID,
+		Created:   act.Created,
+		Updated:   now,
+		Edited:    act.Edited,
+		Deleted:   act.Deleted,
+		RepoID:    repo.ID,
+		Order:     act.Order,
+		SubOrder:  act.SubOrder,
+		Type:      activityType,
+		Kind:      activityKind,
+		Text:      restoredText("posted", principalUIDs[act.CreatedBy], "", act.Text),
+	}
+
+	if act.Resolved != nil {
+		resolved := *act.Resolved
+		activity.Resolved = &resolved
+		activity.ResolvedBy = &principal.ID
+	}
+
+	if activityType == enum.PullReqActivityTypeComment {
+		_ = activity.SetPayload(types.PullRequestActivityPayloadComment{})
+		return activity, nil
+	}
+
+	cc := act.CodeComment
+	if cc.Path == "" || cc.LineNew < 0 || cc.SpanNew < 0 || cc.LineOld < 0 || cc.SpanOld < 0 {
+		return nil, errors.New("invalid code comment location")
+	}
+
+	activity.CodeComment = &types.CodeCommentFields{
+		Outdated:     cc.Outdated,
+		MergeBaseSHA: cc.MergeBaseSHA,
+		SourceSHA:    cc.SourceSHA,
+		Path:         cc.Path,
+		LineNew:      cc.LineNew,
+		SpanNew:      cc.SpanNew,
+		LineOld:      cc.LineOld,
+		SpanOld:      cc.SpanOld,
+	}
+
+	// the payload is decoded into its type and encoded again, so only the known fields are stored.
+	activity.PayloadRaw = act.Payload
+	payload, err := activity.GetPayload()
+	if errors.Is(err, types.ErrNoPayload) {
+		payload = &types.PullRequestActivityPayloadCodeComment{}
+	} else if err != nil {
+		return nil, fmt.Errorf("invalid code comment payload: %w", err)
+	}
+
+	if err = activity.SetPayload(payload); err != nil {
+		return nil, fmt.Errorf("invalid code comment payload: %w", err)
+	}
+
+	return activity, nil
+}
+
+// restoredText prefixes the text with the original author and the note.
+func restoredText(action string, authorUID string, note string, text string) string {
+	header := "_Restored from a repository bundle"
+	if authorUID != "" {
+		header += fmt.Sprintf(", originally %s by @%s", action, authorUID)
+	}
+	header += "._"
+
+	if note != "" {
+		header += "\n\n_" + note + "_"
+	}
+
+	if text = strings.TrimSpace(text); text == "" {
+		return header
+	}
+
+	return header + "\n\n" + text
+}
+
+func (s *Service) restoreRule(
+	ctx context.Context,
+	principal *types.Principal,
+	repo *types.Repository,
+	exported *bundle.Rule,
+	principalIDs map[int64]int64,
+) error {
+	if err := check.UID(exported.UID); err != nil {
+		return err
+	}
+
+	if err := check.Description(exported.Description); err != nil {
+		return err
+	}
+
+	state, ok := enum.RuleState(exported.State).Sanitize()
+	if !ok {
+		return fmt.Errorf("invalid rule state %q", exported.State)
+	}
+
+	var pattern protection.Pattern
+	if err := json.Unmarshal(exported.Pattern, &pattern); err != nil {
+		return fmt.Errorf("invalid rule pattern: %w", err)
+	}
+
+	if err := pattern.Validate(); err != nil {
+		return fmt.Errorf("invalid rule pattern: %w", err)
+	}
+
+	ruleType := types.RuleType(exported.Type)
+
+	// the users referenced by the rule, e.g. the ones allowed to bypass it, must exist on this instance.
+	definition, err := s.protectionManager.ReplaceUserIDs(ruleType, exported.Definition, principalIDs)
+	if err != nil {
+		return fmt.Errorf("invalid rule definition: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	rule := &types.Rule{
+		CreatedBy:   principal.ID,
+		Created:     now,
+		Updated:     now,
+		RepoID:      &repo.ID,
+		UID:         exported.UID,
+		Description: exported.Description,
+		Type:        ruleType,
+		State:       state,
+		Pattern:     pattern.JSON(),
+		Definition:  definition,
+	}
+
+	if err = s.ruleStore.Create(ctx, rule); err != nil {
+		return fmt.Errorf("failed to create protection rule: %w", err)
+	}
+
+	return nil
+}
+
+// restoreWebhook creates the webhook after validating it like any webhook created through the API.
+func (s *Service) restoreWebhook(
+	ctx context.Context,
+	principal *types.Principal,
+	repo *types.Repository,
+	exported *bundle.Webhook,
+) error {
+	if err := check.UID(exported.UID); err != nil {
+		return err
+	}
+
+	displayName := exported.DisplayName
+	if displayName == "" {
+		displayName = exported.UID
+	}
+
+	if err := check.DisplayName(displayName); err != nil {
+		return err
+	}
+
+	if err := check.Description(exported.Description); err != nil {
+		return err
+	}
+
+	if err := webhook.CheckURL(exported.URL, s.webhookAllowLoopback, s.webhookAllowPrivateNetwork); err != nil {
+		return err
+	}
+
+	triggers := make([]enum.WebhookTrigger, 0, len(exported.Triggers))
+	seen := make(map[enum.WebhookTrigger]struct{}, len(exported.Triggers))
+	for _, t := range exported.Triggers {
+		trigger, ok := enum.WebhookTrigger(t).Sanitize()
+		if !ok {
+			return check.NewValidationErrorf("The provided webhook trigger '%s' is invalid.", t)
+		}
+		if _, ok := seen[trigger]; ok {
+			continue
+		}
+		seen[trigger] = struct{}{}
+		triggers = append(triggers, trigger)
+	}
+
+	payloadTemplateType := enum.WebhookPayloadTemplateType(exported.PayloadTemplateType)
+	if payloadTemplateType != "" {
+		var ok bool
+		if payloadTemplateType, ok = payloadTemplateType.Sanitize(); !ok {
+			return check.NewValidationErrorf("The provided payload template type '%s' is invalid.",
+				exported.PayloadTemplateType)
+		}
+	}
+
+	err := webhook.CheckPayloadTemplate(payloadTemplateType, exported.PayloadTemplate)
+	if errors.Is(err, webhook.ErrPayloadTemplateInvalid) {
+		return check.NewValidationError(err.Error())
+	}
+	if err != nil {
+		return err
+	}
+
+	encryptedSecret, err := s.encrypter.Encrypt("")
+	if err != nil {
+		return fmt.Errorf("failed to encrypt webhook secret: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	hook := &types.Webhook{
+		ParentID:            repo.ID,
+		ParentType:          enum.WebhookParentRepo,
+		CreatedBy:           principal.ID,
+		Created:             now,
+		Updated:             now,
+		UID:                 exported.UID,
+		DisplayName:         displayName,
+		Description:         exported.Description,
+		URL:                 exported.URL,
+		Secret:              string(encryptedSecret),
+		Enabled:             exported.Enabled && !exported.HasSecret,
+		Insecure:            exported.Insecure,
+		Triggers:            triggers,
+		PayloadTemplateType: payloadTemplateType,
+		PayloadTemplate:     exported.PayloadTemplate,
+	}
+
+	if err = s.webhookStore.Create(ctx, hook); err != nil {
+		return fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,158 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/harness/gitness/app/bundle"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestExportRestorePullReq(t *testing.T) {
+	const (
+		author   = int64(10)
+		reviewer = int64(11)
+		unknown  = int64(12)
+		restorer = int64(99)
+	)
+
+	resolved := int64(1700000002000)
+	deleted := int64(1700000003000)
+	reviewerID := reviewer
+
+	source := &types.PullReq{
+		ID:           5,
+		Number:       7,
+		CreatedBy:    author,
+		Created:      1700000000000,
+		Edited:       1700000004000,
+		State:        enum.PullReqStateOpen,
+		IsDraft:      true,
+		Title:        "feature",
+		Description:  "details",
+		SourceRepoID: 1,
+		SourceBranch: "feature",
+		SourceSHA:    "abc",
+		TargetRepoID: 1,
+		TargetBranch: "main",
+		MergeBaseSHA: "def",
+		MergedBy:     &reviewerID,
+	}
+
+	activities := []*types.PullReqActivity{
+		{CreatedBy: author, Order: 1, Kind: enum.PullReqActivityKindSystem, Type: enum.PullReqActivityTypeTitleChange},
+		{CreatedBy: reviewer, Order: 2, Kind: enum.PullReqActivityKindComment, Type: enum.PullReqActivityTypeComment,
+			Text: "LGTM"},
+		{CreatedBy: author, Order: 2, SubOrder: 1, Kind: enum.PullReqActivityKindComment,
+			Type: enum.PullReqActivityTypeComment},
+		{CreatedBy: unknown, Order: 3, Kind: enum.PullReqActivityKindChangeComment,
+			Type: enum.PullReqActivityTypeCodeComment, ResolvedBy: &reviewerID, Resolved: &resolved,
+			CodeComment: &types.CodeCommentFields{Path: "main.go", LineNew: 4, SpanNew: 1},
+			PayloadRaw:  json.RawMessage(`{"injected":true}`)},
+		{CreatedBy: reviewer, Order: 4, Kind: enum.PullReqActivityKindComment, Type: enum.PullReqActivityTypeComment,
+			Deleted: &deleted},
+	}
+
+	exported := exportedPullReq(source, activities)
+
+	principalUIDs := map[int64]string{author: "author", reviewer: "reviewer"}
+	principal := &types.Principal{ID: restorer}
+	repo := &types.Repository{ID: 2}
+
+	pr, restored, err := restoredPullReq(principal, repo, &exported, enum.PullReqStateClosed, "note", principalUIDs)
+	if err != nil {
+		t.Fatalf("failed to restore pull request: %s", err)
+	}
+
+	if pr.Number != 7 || pr.SourceRepoID != 2 || pr.TargetRepoID != 2 || pr.SourceSHA != "abc" {
+		t.Errorf("unexpected pull request: %+v", pr)
+	}
+	if pr.CreatedBy != restorer || pr.MergedBy != nil {
+		t.Errorf("unexpected pull request principals: created_by=%d merged_by=%v", pr.CreatedBy, pr.MergedBy)
+	}
+	if pr.State != enum.PullReqStateClosed || pr.IsDraft {
+		t.Errorf("expected a closed non-draft pull request, got state=%s draft=%t", pr.State, pr.IsDraft)
+	}
+	want := "_Restored from a repository bundle, originally opened by @author._\n\n_note_\n\ndetails"
+	if pr.Description != want {
+		t.Errorf("unexpected description: want=%q got=%q", want, pr.Description)
+	}
+	if pr.ActivitySeq != 4 || pr.CommentCount != 3 || pr.UnresolvedCount != 1 {
+		t.Errorf("unexpected counters: activity_seq=%d comments=%d unresolved=%d",
+			pr.ActivitySeq, pr.CommentCount, pr.UnresolvedCount)
+	}
+
+	// the system activity isn't restored.
+	if len(restored) != len(activities)-1 {
+		t.Fatalf("expected %d activities, got %d", len(activities)-1, len(restored))
+	}
+	if restored[0].ReplySeq != 1 || restored[1].ReplySeq != 0 {
+		t.Errorf("unexpected reply sequences: %d, %d", restored[0].ReplySeq, restored[1].ReplySeq)
+	}
+	want = "_Restored from a repository bundle, originally posted by @reviewer._\n\nLGTM"
+	if restored[0].Text != want {
+		t.Errorf("unexpected comment text: want=%q got=%q", want, restored[0].Text)
+	}
+	for _, act := range restored {
+		if act.RepoID != 2 || act.CreatedBy != restorer {
+			t.Errorf("unexpected activity repo ID or author: %d, %d", act.RepoID, act.CreatedBy)
+		}
+		if act.ResolvedBy != nil && *act.ResolvedBy != restorer {
+			t.Errorf("unexpected resolver: %d", *act.ResolvedBy)
+		}
+	}
+	if cc := restored[2].CodeComment; cc == nil || cc.Path != "main.go" || cc.LineNew != 4 {
+		t.Errorf("unexpected code comment: %+v", cc)
+	}
+	if string(restored[2].PayloadRaw) == `{"injected":true}` {
+		t.Errorf("expected the code comment payload to be sanitized, got: %s", restored[2].PayloadRaw)
+	}
+}
+
+func TestRestoredCommentRejectsInvalid(t *testing.T) {
+	principal := &types.Principal{ID: 1}
+	repo := &types.Repository{ID: 2}
+
+	tests := []struct {
+		name string
+		act  bundle.Activity
+	}{
+		{
+			name: "code comment without location",
+			act:  bundle.Activity{Order: 1, Type: "code-comment", Kind: "change-comment"},
+		},
+		{
+			name: "invalid payload",
+			act: bundle.Activity{Order: 1, Type: "code-comment", Kind: "change-comment",
+				Payload:     json.RawMessage(`{"line_start_new":"x"}`),
+				CodeComment: &bundle.CodeComment{Path: "main.go"}},
+		},
+		{
+			name: "invalid order",
+			act:  bundle.Activity{Order: 0, Type: "comment", Kind: "comment"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := restoredComment(principal, repo, &test.act, nil); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
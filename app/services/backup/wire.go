@@ -0,0 +1,62 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/webhook"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/store/database/dbtx"
+
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	ProvideService,
+)
+
+func ProvideService(
+	webhookConfig webhook.Config,
+	tx dbtx.Transactor,
+	git git.Interface,
+	repoStore store.RepoStore,
+	principalStore store.PrincipalStore,
+	pullReqStore store.PullReqStore,
+	activityStore store.PullReqActivityStore,
+	ruleStore store.RuleStore,
+	webhookStore store.WebhookStore,
+	principalInfoCache store.PrincipalInfoCache,
+	protectionManager *protection.Manager,
+	encrypter encrypt.Encrypter,
+) *Service {
+	return &Service{
+		tx:                 tx,
+		git:                git,
+		repoStore:          repoStore,
+		principalStore:     principalStore,
+		pullReqStore:       pullReqStore,
+		activityStore:      activityStore,
+		ruleStore:          ruleStore,
+		webhookStore:       webhookStore,
+		principalInfoCache: principalInfoCache,
+		protectionManager:  protectionManager,
+		encrypter:          encrypter,
+
+		webhookAllowLoopback:       webhookConfig.AllowLoopback,
+		webhookAllowPrivateNetwork: webhookConfig.AllowPrivateNetwork,
+	}
+}
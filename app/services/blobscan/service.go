@@ -0,0 +1,129 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blobscan
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/scanner"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// quarantinePathPrefix is the blob store path prefix of quarantined files.
+	// It's outside any path served to users.
+	quarantinePathPrefix = "quarantine/"
+
+	metadataScanStatus = "scan-status"
+	metadataScanEngine = "scan-engine"
+	metadataScanThreat = "scan-threat"
+	metadataScanned    = "scanned"
+)
+
+// InfectedError is returned if the uploaded content contains a threat.
+type InfectedError struct {
+	Threat      string
+	Quarantined bool
+}
+
+func (e *InfectedError) Error() string {
+	return fmt.Sprintf("content is infected with %s", e.Threat)
+}
+
+// Service scans files before they are stored in the blob store.
+type Service struct {
+	scanner   scanner.Scanner
+	blobStore blob.Store
+	action    scanner.Action
+	failOpen  bool
+}
+
+func NewService(
+	scanner scanner.Scanner,
+	blobStore blob.Store,
+	action scanner.Action,
+	failOpen bool,
+) *Service {
+	return &Service{
+		scanner:   scanner,
+		blobStore: blobStore,
+		action:    action,
+		failOpen:  failOpen,
+	}
+}
+
+// Upload scans the content and uploads it to the blob store, with the scan result stored in the blob metadata.
+// Infected content is either rejected or stored in quarantine (depending on the configured action),
+// in both cases an InfectedError is returned. The content is buffered in memory,
+// it's up to the caller to limit its size.
+func (s *Service) Upload(ctx context.Context, content io.Reader, filePath string) error {
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return fmt.Errorf("failed to read content: %w", err)
+	}
+
+	result, err := s.scanner.Scan(ctx, bytes.NewReader(data))
+	if err != nil {
+		if !s.failOpen {
+			return fmt.Errorf("failed to scan content: %w", err)
+		}
+
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to scan file %q, accepting it unscanned", filePath)
+
+		result = scanner.Result{Status: scanner.StatusFailed}
+	}
+
+	if result.Status == scanner.StatusInfected {
+		log.Ctx(ctx).Warn().
+			Str("threat", result.Threat).
+			Str("engine", string(result.Engine)).
+			Msgf("detected threat in file %q", filePath)
+
+		if s.action != scanner.ActionQuarantine {
+			return &InfectedError{Threat: result.Threat}
+		}
+
+		filePath = quarantinePathPrefix + filePath
+	}
+
+	if err = s.blobStore.Upload(ctx, bytes.NewReader(data), filePath); err != nil {
+		return fmt.Errorf("failed to upload file: %w", err)
+	}
+
+	metadata := map[string]string{
+		metadataScanStatus: string(result.Status),
+		metadataScanEngine: string(result.Engine),
+		metadataScanned:    time.Now().UTC().Format(time.RFC3339),
+	}
+	if result.Threat != "" {
+		metadata[metadataScanThreat] = result.Threat
+	}
+
+	if err = s.blobStore.SetMetadata(ctx, filePath, metadata); err != nil {
+		return fmt.Errorf("failed to store scan result in file metadata: %w", err)
+	}
+
+	if result.Status == scanner.StatusInfected {
+		return &InfectedError{Threat: result.Threat, Quarantined: true}
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeGitUsage        = "gitness:cleanup:git-usage"
+	jobCronGitUsage        = "17 3 * * *" // At 03:17 every day.
+	jobMaxDurationGitUsage = 1 * time.Minute
+)
+
+type gitUsageCleanupJob struct {
+	retentionTime time.Duration
+
+	gitUsageStore store.GitUsageStore
+}
+
+func newGitUsageCleanupJob(
+	retentionTime time.Duration,
+	gitUsageStore store.GitUsageStore,
+) *gitUsageCleanupJob {
+	return &gitUsageCleanupJob{
+		retentionTime: retentionTime,
+
+		gitUsageStore: gitUsageStore,
+	}
+}
+
+// Handle purges the git usage that is past the retention time.
+func (j *gitUsageCleanupJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	olderThan := time.Now().Add(-j.retentionTime)
+
+	log.Ctx(ctx).Info().Msgf(
+		"start purging git usage older than %s (aka recorded before %s)",
+		j.retentionTime,
+		olderThan.Format(time.RFC3339Nano))
+
+	n, err := j.gitUsageStore.DeleteOld(ctx, olderThan)
+	if err != nil {
+		return "", fmt.Errorf("failed to delete old git usage: %w", err)
+	}
+
+	result := "no old git usage found"
+	if n > 0 {
+		result = fmt.Sprintf("deleted %d git usage entries", n)
+	}
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
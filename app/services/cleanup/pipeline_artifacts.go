@@ -0,0 +1,73 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/services/pipelineartifact"
+	"github.com/harness/gitness/job"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypePipelineArtifacts        = "gitness:cleanup:pipeline-artifacts"
+	jobCronPipelineArtifacts        = "47 3 * * *" // At 03:47 every day.
+	jobMaxDurationPipelineArtifacts = 10 * time.Minute
+)
+
+type pipelineArtifactsCleanupJob struct {
+	retentionTime time.Duration
+
+	artifacts *pipelineartifact.Service
+}
+
+func newPipelineArtifactsCleanupJob(
+	retentionTime time.Duration,
+	artifacts *pipelineartifact.Service,
+) *pipelineArtifactsCleanupJob {
+	return &pipelineArtifactsCleanupJob{
+		retentionTime: retentionTime,
+
+		artifacts: artifacts,
+	}
+}
+
+// Handle purges the pipeline artifacts that are past the retention time.
+func (j *pipelineArtifactsCleanupJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	olderThan := time.Now().Add(-j.retentionTime)
+
+	log.Ctx(ctx).Info().Msgf(
+		"start purging pipeline artifacts older than %s (aka created before %s)",
+		j.retentionTime,
+		olderThan.Format(time.RFC3339Nano))
+
+	n, err := j.artifacts.Prune(ctx, olderThan)
+	if err != nil {
+		return "", fmt.Errorf("failed to delete old pipeline artifacts (deleted %d): %w", n, err)
+	}
+
+	result := "no old pipeline artifacts found"
+	if n > 0 {
+		result = fmt.Sprintf("deleted %d pipeline artifacts", n)
+	}
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
@@ -20,12 +20,15 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/harness/gitness/app/services/pipelineartifact"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/job"
 )
 
 type Config struct {
 	WebhookExecutionsRetentionTime time.Duration
+	GitUsageRetentionTime          time.Duration
+	PipelineArtifactRetentionTime  time.Duration
 }
 
 func (c *Config) Prepare() error {
@@ -35,6 +38,12 @@ func (c *Config) Prepare() error {
 	if c.WebhookExecutionsRetentionTime <= 0 {
 		return errors.New("config.WebhookExecutionsRetentionTime has to be provided")
 	}
+	if c.GitUsageRetentionTime <= 0 {
+		return errors.New("config.GitUsageRetentionTime has to be provided")
+	}
+	if c.PipelineArtifactRetentionTime <= 0 {
+		return errors.New("config.PipelineArtifactRetentionTime has to be provided")
+	}
 	return nil
 }
 
@@ -45,6 +54,8 @@ type Service struct {
 	executor              *job.Executor
 	webhookExecutionStore store.WebhookExecutionStore
 	tokenStore            store.TokenStore
+	gitUsageStore         store.GitUsageStore
+	pipelineArtifacts     *pipelineartifact.Service
 }
 
 func NewService(
@@ -53,6 +64,8 @@ func NewService(
 	executor *job.Executor,
 	webhookExecutionStore store.WebhookExecutionStore,
 	tokenStore store.TokenStore,
+	gitUsageStore store.GitUsageStore,
+	pipelineArtifacts *pipelineartifact.Service,
 ) (*Service, error) {
 	if err := config.Prepare(); err != nil {
 		return nil, fmt.Errorf("provided cleanup config is invalid: %w", err)
@@ -65,6 +78,8 @@ func NewService(
 		executor:              executor,
 		webhookExecutionStore: webhookExecutionStore,
 		tokenStore:            tokenStore,
+		gitUsageStore:         gitUsageStore,
+		pipelineArtifacts:     pipelineArtifacts,
 	}, nil
 }
 
@@ -104,6 +119,28 @@ func (s *Service) scheduleRecurringCleanupJobs(ctx context.Context) error {
 		return fmt.Errorf("failed to schedule token job: %w", err)
 	}
 
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypeGitUsage,
+		jobTypeGitUsage,
+		jobCronGitUsage,
+		jobMaxDurationGitUsage,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule git usage job: %w", err)
+	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypePipelineArtifacts,
+		jobTypePipelineArtifacts,
+		jobCronPipelineArtifacts,
+		jobMaxDurationPipelineArtifacts,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule pipeline artifacts job: %w", err)
+	}
+
 	return nil
 }
 
@@ -128,5 +165,25 @@ func (s *Service) registerJobHandlers() error {
 		return fmt.Errorf("failed to register job handler for token cleanup: %w", err)
 	}
 
+	if err := s.executor.Register(
+		jobTypeGitUsage,
+		newGitUsageCleanupJob(
+			s.config.GitUsageRetentionTime,
+			s.gitUsageStore,
+		),
+	); err != nil {
+		return fmt.Errorf("failed to register job handler for git usage cleanup: %w", err)
+	}
+
+	if err := s.executor.Register(
+		jobTypePipelineArtifacts,
+		newPipelineArtifactsCleanupJob(
+			s.config.PipelineArtifactRetentionTime,
+			s.pipelineArtifacts,
+		),
+	); err != nil {
+		return fmt.Errorf("failed to register job handler for pipeline artifacts cleanup: %w", err)
+	}
+
 	return nil
 }
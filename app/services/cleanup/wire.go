@@ -15,6 +15,7 @@
 package cleanup
 
 import (
+	"github.com/harness/gitness/app/services/pipelineartifact"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/job"
 
@@ -31,6 +32,8 @@ func ProvideService(
 	executor *job.Executor,
 	webhookExecutionStore store.WebhookExecutionStore,
 	tokenStore store.TokenStore,
+	gitUsageStore store.GitUsageStore,
+	pipelineArtifacts *pipelineartifact.Service,
 ) (*Service, error) {
 	return NewService(
 		config,
@@ -38,5 +41,7 @@ func ProvideService(
 		executor,
 		webhookExecutionStore,
 		tokenStore,
+		gitUsageStore,
+		pipelineArtifacts,
 	)
 }
@@ -0,0 +1,277 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codecomments
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	// anchorContextLines is the number of lines before and after the commented lines that are kept in the anchor.
+	anchorContextLines = 3
+
+	// maxAnchorFileSize is the size of the largest file in which code comments can be re-anchored.
+	maxAnchorFileSize = 1 << 22 // 4 MB
+)
+
+type fileFetcher interface {
+	GetTreeNode(ctx context.Context, params *git.GetTreeNodeParams) (*git.GetTreeNodeOutput, error)
+	GetBlob(ctx context.Context, params *git.GetBlobParams) (*git.GetBlobOutput, error)
+}
+
+// Anchor returns the anchor of a code comment that covers span lines starting with the line
+// of the file at the provided commit. It returns nil if there are no lines to anchor to.
+func (migrator *Migrator) Anchor(
+	ctx context.Context,
+	repoGitUID string,
+	sha string,
+	path string,
+	line int,
+	span int,
+) (*types.CodeCommentAnchor, error) {
+	if span <= 0 {
+		return nil, nil //nolint:nilnil // only removed lines are commented, there's nothing to anchor to
+	}
+
+	blobSHA, err := migrator.getBlobSHA(ctx, repoGitUID, sha, path)
+	if err != nil {
+		return nil, err
+	}
+
+	lines, err := migrator.getBlobLines(ctx, repoGitUID, blobSHA)
+	if err != nil {
+		return nil, err
+	}
+
+	if line < 1 || line+span-1 > len(lines) {
+		return nil, fmt.Errorf("lines %d-%d are outside of the file %s", line, line+span-1, path)
+	}
+
+	return newAnchor(blobSHA, lines, line, span), nil
+}
+
+// reanchor tries to find the lines the code comment is anchored to in the file at the provided commit.
+// If the lines are found, the code comment is moved to them, otherwise the code comment is marked as outdated.
+// Code comments without an anchor (created before anchors were introduced) are just marked as outdated.
+func (migrator *Migrator) reanchor(
+	ctx context.Context,
+	repoGitUID string,
+	sha string,
+	path string,
+	cc *types.CodeComment,
+) {
+	anchor := cc.Anchor
+	if anchor == nil || len(anchor.Lines) == 0 || migrator.fileFetcher == nil {
+		cc.Outdated = true
+		if cc.AnchorStatus != "" {
+			cc.AnchorStatus = enum.CodeCommentAnchorStatusLost
+		}
+		return
+	}
+
+	line, newAnchor, err := migrator.findAnchor(ctx, repoGitUID, sha, path, anchor)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).
+			Msgf("failed to re-anchor code comment %d in file %s at commit %s", cc.ID, path, sha)
+	}
+	if line == 0 {
+		cc.Outdated = true
+		cc.AnchorStatus = enum.CodeCommentAnchorStatusLost
+		return
+	}
+
+	cc.Outdated = false
+	cc.AnchorStatus = enum.CodeCommentAnchorStatusReanchored
+	cc.Path = path
+	cc.LineNew = line
+	cc.SpanNew = len(anchor.Lines)
+	cc.Anchor = newAnchor
+}
+
+// findAnchor returns the line number of the first anchored line in the file at the provided commit
+// and the anchor updated to the file's current content. It returns zero if the lines can't be found.
+func (migrator *Migrator) findAnchor(
+	ctx context.Context,
+	repoGitUID string,
+	sha string,
+	path string,
+	anchor *types.CodeCommentAnchor,
+) (int, *types.CodeCommentAnchor, error) {
+	blobSHA, err := migrator.getBlobSHA(ctx, repoGitUID, sha, path)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if blobSHA == anchor.BlobSHA {
+		// The file content is the same as when the anchor was taken.
+		return anchor.Line, anchor, nil
+	}
+
+	lines, err := migrator.getBlobLines(ctx, repoGitUID, blobSHA)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	line := matchAnchor(lines, anchor)
+	if line == 0 {
+		return 0, nil, nil
+	}
+
+	return line, newAnchor(blobSHA, lines, line, len(anchor.Lines)), nil
+}
+
+func (migrator *Migrator) getBlobSHA(ctx context.Context, repoGitUID, sha, path string) (string, error) {
+	node, err := migrator.fileFetcher.GetTreeNode(ctx, &git.GetTreeNodeParams{
+		ReadParams: git.ReadParams{RepoUID: repoGitUID},
+		GitREF:     sha,
+		Path:       path,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get tree node of file %s: %w", path, err)
+	}
+
+	if node.Node.Type != git.TreeNodeTypeBlob {
+		return "", fmt.Errorf("path %s is not a file", path)
+	}
+
+	return node.Node.SHA, nil
+}
+
+func (migrator *Migrator) getBlobLines(ctx context.Context, repoGitUID, blobSHA string) ([]string, error) {
+	output, err := migrator.fileFetcher.GetBlob(ctx, &git.GetBlobParams{
+		ReadParams: git.ReadParams{RepoUID: repoGitUID},
+		SHA:        blobSHA,
+		SizeLimit:  maxAnchorFileSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blob %s: %w", blobSHA, err)
+	}
+
+	defer func() {
+		if err := output.Content.Close(); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to close blob content reader")
+		}
+	}()
+
+	if output.Size > output.ContentSize {
+		return nil, fmt.Errorf("blob %s is too large", blobSHA)
+	}
+
+	content, err := io.ReadAll(output.Content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blob %s: %w", blobSHA, err)
+	}
+
+	return splitLines(string(content)), nil
+}
+
+func splitLines(content string) []string {
+	if content == "" {
+		return nil
+	}
+
+	lines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+	for i := range lines {
+		lines[i] = strings.TrimSuffix(lines[i], "\r")
+	}
+
+	return lines
+}
+
+func newAnchor(blobSHA string, lines []string, line, span int) *types.CodeCommentAnchor {
+	start := line - 1
+	end := start + span
+
+	beforeStart := start - anchorContextLines
+	if beforeStart < 0 {
+		beforeStart = 0
+	}
+
+	afterEnd := end + anchorContextLines
+	if afterEnd > len(lines) {
+		afterEnd = len(lines)
+	}
+
+	return &types.CodeCommentAnchor{
+		BlobSHA: blobSHA,
+		Line:    line,
+		Before:  slices.Clone(lines[beforeStart:start]),
+		Lines:   slices.Clone(lines[start:end]),
+		After:   slices.Clone(lines[end:afterEnd]),
+	}
+}
+
+// matchAnchor returns the line number at which the anchored lines are found in the file.
+// If the lines are found in several places, the surrounding lines are used to pick the best match.
+// It returns zero if the lines aren't found or if the best match is ambiguous.
+func matchAnchor(lines []string, anchor *types.CodeCommentAnchor) int {
+	span := len(anchor.Lines)
+
+	bestLine := 0
+	bestScore := -1
+	ambiguous := false
+
+	for i := 0; i+span <= len(lines); i++ {
+		if !slices.Equal(lines[i:i+span], anchor.Lines) {
+			continue
+		}
+
+		score := contextScore(lines, i, i+span, anchor)
+		switch {
+		case score > bestScore:
+			bestLine, bestScore, ambiguous = i+1, score, false
+		case score == bestScore:
+			ambiguous = true
+		}
+	}
+
+	if ambiguous {
+		return 0
+	}
+
+	return bestLine
+}
+
+// contextScore returns the number of consecutive lines before and after the lines [start, end)
+// that are the same as the surrounding lines stored in the anchor.
+func contextScore(lines []string, start, end int, anchor *types.CodeCommentAnchor) int {
+	score := 0
+
+	for k := 1; k <= len(anchor.Before) && start-k >= 0; k++ {
+		if lines[start-k] != anchor.Before[len(anchor.Before)-k] {
+			break
+		}
+		score++
+	}
+
+	for k := 0; k < len(anchor.After) && end+k < len(lines); k++ {
+		if lines[end+k] != anchor.After[k] {
+			break
+		}
+		score++
+	}
+
+	return score
+}
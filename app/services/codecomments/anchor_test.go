@@ -0,0 +1,254 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codecomments
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestMatchAnchor(t *testing.T) {
+	tests := []struct {
+		name   string
+		lines  []string
+		anchor types.CodeCommentAnchor
+		exp    int
+	}{
+		{
+			name:   "single-match",
+			lines:  []string{"a", "b", "c", "d"},
+			anchor: types.CodeCommentAnchor{Lines: []string{"b", "c"}},
+			exp:    2,
+		},
+		{
+			name:   "no-match",
+			lines:  []string{"a", "b", "c", "d"},
+			anchor: types.CodeCommentAnchor{Lines: []string{"b", "d"}},
+			exp:    0,
+		},
+		{
+			name:   "ambiguous",
+			lines:  []string{"x", "}", "y", "}"},
+			anchor: types.CodeCommentAnchor{Lines: []string{"}"}},
+			exp:    0,
+		},
+		{
+			name:   "context-before-picks-match",
+			lines:  []string{"x", "}", "y", "}"},
+			anchor: types.CodeCommentAnchor{Before: []string{"y"}, Lines: []string{"}"}},
+			exp:    4,
+		},
+		{
+			name:   "context-after-picks-match",
+			lines:  []string{"}", "x", "}", "y"},
+			anchor: types.CodeCommentAnchor{Lines: []string{"}"}, After: []string{"y"}},
+			exp:    3,
+		},
+		{
+			name:  "longer-context-wins",
+			lines: []string{"a", "b", "c", "z", "b", "c"},
+			anchor: types.CodeCommentAnchor{
+				Before: []string{"x", "a", "b"},
+				Lines:  []string{"c"},
+			},
+			exp: 3,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := matchAnchor(test.lines, &test.anchor); got != test.exp {
+				t.Errorf("want=%d got=%d", test.exp, got)
+			}
+		})
+	}
+}
+
+func TestNewAnchor(t *testing.T) {
+	lines := []string{"1", "2", "3", "4", "5", "6", "7", "8"}
+
+	anchor := newAnchor("blob", lines, 2, 2)
+	if want, got := []string{"1"}, anchor.Before; strings.Join(want, ",") != strings.Join(got, ",") {
+		t.Errorf("before: want=%v got=%v", want, got)
+	}
+	if want, got := []string{"2", "3"}, anchor.Lines; strings.Join(want, ",") != strings.Join(got, ",") {
+		t.Errorf("lines: want=%v got=%v", want, got)
+	}
+	if want, got := []string{"4", "5", "6"}, anchor.After; strings.Join(want, ",") != strings.Join(got, ",") {
+		t.Errorf("after: want=%v got=%v", want, got)
+	}
+	if anchor.Line != 2 || anchor.BlobSHA != "blob" {
+		t.Errorf("unexpected anchor position: %d %s", anchor.Line, anchor.BlobSHA)
+	}
+}
+
+func TestMigratorReanchor(t *testing.T) {
+	const (
+		fileName  = "blah"
+		shaSrcOld = "old"
+		shaSrcNew = "new"
+	)
+
+	anchor := &types.CodeCommentAnchor{
+		BlobSHA: "blob-old",
+		Line:    2,
+		Before:  []string{"func f() {"},
+		Lines:   []string{"\treturn 42"},
+		After:   []string{"}"},
+	}
+
+	tests := []struct {
+		name       string
+		headers    []git.HunkHeader
+		notFound   bool
+		blobSHA    string
+		content    string
+		anchor     *types.CodeCommentAnchor
+		expLine    int
+		expSHA     string
+		expStatus  enum.CodeCommentAnchorStatus
+		expOutdate bool
+	}{
+		{
+			name:      "overlapping-hunk-lines-moved",
+			headers:   []git.HunkHeader{{OldLine: 1, OldSpan: 3, NewLine: 1, NewSpan: 6}},
+			blobSHA:   "blob-new",
+			content:   "// doc\n\nfunc f() {\n\treturn 42\n}\n",
+			anchor:    anchor,
+			expLine:   4,
+			expSHA:    shaSrcNew,
+			expStatus: enum.CodeCommentAnchorStatusReanchored,
+		},
+		{
+			name:      "commit-not-found-same-blob",
+			notFound:  true,
+			blobSHA:   "blob-old",
+			anchor:    anchor,
+			expLine:   2,
+			expSHA:    shaSrcNew,
+			expStatus: enum.CodeCommentAnchorStatusReanchored,
+		},
+		{
+			name:       "commit-not-found-lines-changed",
+			notFound:   true,
+			blobSHA:    "blob-new",
+			content:    "func f() {\n\treturn 43\n}\n",
+			anchor:     anchor,
+			expLine:    2,
+			expSHA:     shaSrcOld,
+			expStatus:  enum.CodeCommentAnchorStatusLost,
+			expOutdate: true,
+		},
+		{
+			name:       "no-anchor",
+			notFound:   true,
+			blobSHA:    "blob-new",
+			content:    "func f() {\n\treturn 42\n}\n",
+			anchor:     nil,
+			expLine:    2,
+			expSHA:     shaSrcOld,
+			expStatus:  "",
+			expOutdate: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := &Migrator{
+				hunkHeaderFetcher: testHunkHeaderFetcher{
+					fileName: fileName,
+					headers:  test.headers,
+					notFound: test.notFound,
+				},
+				fileFetcher: testFileFetcher{
+					blobSHA: test.blobSHA,
+					content: test.content,
+				},
+			}
+
+			status := enum.CodeCommentAnchorStatusTracked
+			if test.anchor == nil {
+				status = ""
+			}
+
+			cc := &types.CodeComment{
+				CodeCommentFields: types.CodeCommentFields{
+					SourceSHA:    shaSrcOld,
+					Path:         fileName,
+					LineNew:      2,
+					SpanNew:      1,
+					LineOld:      2,
+					SpanOld:      1,
+					AnchorStatus: status,
+					Anchor:       test.anchor,
+				},
+			}
+
+			m.MigrateNew(context.Background(), "not-important", shaSrcNew, []*types.CodeComment{cc})
+
+			if want, got := test.expOutdate, cc.Outdated; want != got {
+				t.Errorf("outdated: want=%t got=%t", want, got)
+			}
+			if want, got := test.expLine, cc.LineNew; want != got {
+				t.Errorf("line new: want=%d got=%d", want, got)
+			}
+			if want, got := test.expSHA, cc.SourceSHA; want != got {
+				t.Errorf("source sha: want=%s got=%s", want, got)
+			}
+			if want, got := test.expStatus, cc.AnchorStatus; want != got {
+				t.Errorf("anchor status: want=%s got=%s", want, got)
+			}
+			if !cc.Outdated && cc.Anchor.BlobSHA != test.blobSHA {
+				t.Errorf("anchor blob sha: want=%s got=%s", test.blobSHA, cc.Anchor.BlobSHA)
+			}
+		})
+	}
+}
+
+type testFileFetcher struct {
+	blobSHA string
+	content string
+}
+
+func (f testFileFetcher) GetTreeNode(
+	_ context.Context,
+	params *git.GetTreeNodeParams,
+) (*git.GetTreeNodeOutput, error) {
+	return &git.GetTreeNodeOutput{
+		Node: git.TreeNode{
+			Type: git.TreeNodeTypeBlob,
+			SHA:  f.blobSHA,
+			Path: params.Path,
+		},
+	}, nil
+}
+
+func (f testFileFetcher) GetBlob(
+	_ context.Context,
+	_ *git.GetBlobParams,
+) (*git.GetBlobOutput, error) {
+	return &git.GetBlobOutput{
+		SHA:         f.blobSHA,
+		Size:        int64(len(f.content)),
+		ContentSize: int64(len(f.content)),
+		Content:     io.NopCloser(strings.NewReader(f.content)),
+	}, nil
+}
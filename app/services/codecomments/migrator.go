@@ -28,6 +28,7 @@ import (
 // Migrator is a utility used to migrate code comments after update of the pull request's source branch.
 type Migrator struct {
 	hunkHeaderFetcher hunkHeaderFetcher
+	fileFetcher       fileFetcher
 }
 
 type hunkHeaderFetcher interface {
@@ -37,6 +38,8 @@ type hunkHeaderFetcher interface {
 // MigrateNew updates the "+" (the added lines) part of code comments
 // after a new commit on the pull request's source branch.
 // The parameter newSHA should contain the latest commit SHA of the pull request's source branch.
+// Code comments whose lines can't be followed through the diff (for example after a force push or a rebase)
+// are re-anchored by searching for the commented lines in the latest version of the file.
 func (migrator *Migrator) MigrateNew(
 	ctx context.Context,
 	repoGitUID string,
@@ -48,6 +51,7 @@ func (migrator *Migrator) MigrateNew(
 		repoGitUID,
 		newSHA,
 		comments,
+		true,
 		func(codeComment *types.CodeComment) string {
 			return codeComment.SourceSHA
 		},
@@ -76,6 +80,7 @@ func (migrator *Migrator) MigrateOld(
 		repoGitUID,
 		newSHA,
 		comments,
+		false,
 		func(codeComment *types.CodeComment) string {
 			return codeComment.MergeBaseSHA
 		},
@@ -97,6 +102,7 @@ func (migrator *Migrator) migrate(
 	repoGitUID string,
 	newSHA string,
 	comments []*types.CodeComment,
+	canReanchor bool,
 	getSHA func(codeComment *types.CodeComment) string,
 	setSHA func(codeComment *types.CodeComment, sha string),
 	getCommentStartEnd func(codeComment *types.CodeComment) (int, int),
@@ -118,10 +124,18 @@ func (migrator *Migrator) migrate(
 			TargetCommitSHA: newSHA,
 		})
 		if errors.AsStatus(errDiff) == errors.StatusNotFound {
-			// Handle the commit SHA not found error and mark all code comments as outdated.
+			// Handle the commit SHA not found error: Try to re-anchor the code comments or mark them as outdated.
 			for _, codeComments := range fileMap {
 				for _, codeComment := range codeComments {
-					codeComment.Outdated = true
+					if !canReanchor {
+						codeComment.Outdated = true
+						continue
+					}
+
+					migrator.reanchor(ctx, repoGitUID, newSHA, codeComment.Path, codeComment)
+					if !codeComment.Outdated {
+						setSHA(codeComment, newSHA)
+					}
 				}
 			}
 			continue
@@ -132,6 +146,9 @@ func (migrator *Migrator) migrate(
 			continue
 		}
 
+		// code comments affected by changes, to be re-anchored after all the files are processed
+		var lost []reanchorCandidate
+
 		// Traverse all the changed files
 		for _, file := range diffSummary.Files {
 			var codeComments []*types.CodeComment
@@ -180,6 +197,9 @@ func (migrator *Migrator) migrate(
 					if outdated {
 						cc.CodeCommentFields = initialValuesMap[cc.ID] // revert the CC to the original values
 						cc.Outdated = true
+						if canReanchor {
+							lost = append(lost, reanchorCandidate{codeComment: cc, path: file.FileHeader.NewName})
+						}
 						continue
 					}
 
@@ -188,6 +208,10 @@ func (migrator *Migrator) migrate(
 			}
 		}
 
+		for _, candidate := range lost {
+			migrator.reanchor(ctx, repoGitUID, newSHA, candidate.path, candidate.codeComment)
+		}
+
 		for _, codeComments := range fileMap {
 			for _, codeComment := range codeComments {
 				if codeComment.Outdated {
@@ -199,6 +223,11 @@ func (migrator *Migrator) migrate(
 	}
 }
 
+type reanchorCandidate struct {
+	codeComment *types.CodeComment
+	path        string
+}
+
 // mapCodeComments groups code comments to maps, first by commit SHA and then by file name.
 // It assumes the incoming list is already sorted.
 func mapCodeComments(
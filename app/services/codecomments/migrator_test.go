@@ -18,6 +18,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/types"
 )
@@ -332,15 +333,22 @@ func TestMigrator(t *testing.T) {
 	}
 }
 
+var errTestNotFound = errors.NotFound("commit not found")
+
 type testHunkHeaderFetcher struct {
 	fileName string
 	headers  []git.HunkHeader
+	notFound bool
 }
 
 func (f testHunkHeaderFetcher) GetDiffHunkHeaders(
 	_ context.Context,
 	_ git.GetDiffHunkHeadersParams,
 ) (git.GetDiffHunkHeadersOutput, error) {
+	if f.notFound {
+		return git.GetDiffHunkHeadersOutput{}, errTestNotFound
+	}
+
 	return git.GetDiffHunkHeadersOutput{
 		Files: []git.DiffFileHunkHeaders{
 			{
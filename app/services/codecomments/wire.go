@@ -29,5 +29,6 @@ func ProvideMigrator(
 ) *Migrator {
 	return &Migrator{
 		hunkHeaderFetcher: git,
+		fileFetcher:       git,
 	}
 }
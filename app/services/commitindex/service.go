@@ -0,0 +1,351 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commitindex
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	gitevents "github.com/harness/gitness/app/events/git"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobType               = "commit-index"
+	jobUIDPrefix          = "commit-index-"
+	eventsReaderGroupName = "gitness:commitindex"
+
+	// insertBatchSize is the number of commits collected before they are stored in the index.
+	insertBatchSize = 1000
+)
+
+var (
+	ErrDisabled   = errors.PreconditionFailed("Commit search is disabled.")
+	ErrNotIndexed = errors.NotFound("Commits of the repository haven't been indexed yet, try again later.")
+)
+
+// Input is the data of the job that indexes the commits of a single repository.
+type Input struct {
+	RepoID int64 `json:"repo_id"`
+}
+
+// Service maintains the commit search index of repositories.
+// The index contains the commits of the default branch and it's updated incrementally:
+// only the new commits are indexed on a push, the whole index is rebuilt only if the history was rewritten.
+type Service struct {
+	enabled   bool
+	jobMaxDur time.Duration
+
+	git              git.Interface
+	repoStore        store.RepoStore
+	commitIndexStore store.CommitIndexStore
+	scheduler        *job.Scheduler
+}
+
+var _ job.Handler = (*Service)(nil)
+
+// Search returns the commits of the default branch of the repository matching the filter, and their total count.
+// If the index is outdated, its update is scheduled and the currently indexed commits are searched.
+func (s *Service) Search(
+	ctx context.Context,
+	repo *types.Repository,
+	filter *types.CommitSearchFilter,
+) ([]types.Commit, int64, error) {
+	if !s.enabled {
+		return nil, 0, ErrDisabled
+	}
+
+	state, err := s.commitIndexStore.FindState(ctx, repo.ID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		if err = s.scheduleIndexing(ctx, repo); err != nil {
+			return nil, 0, err
+		}
+		return nil, 0, ErrNotIndexed
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find commit index state: %w", err)
+	}
+
+	// the default branch could have been changed or an update event missed, the index catches up in the background.
+	if head, errHead := s.getHead(ctx, repo); errHead == nil && head != state.CommitSHA {
+		if err = s.scheduleIndexing(ctx, repo); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("repo_id", repo.ID).Msg("failed to schedule commit indexing")
+		}
+	}
+
+	count, err := s.commitIndexStore.Count(ctx, repo.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count commits: %w", err)
+	}
+
+	commits, err := s.commitIndexStore.Search(ctx, repo.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to search commits: %w", err)
+	}
+
+	return commits, count, nil
+}
+
+// scheduleIndexing schedules the update of the commit index of the repository,
+// unless the update is already scheduled or running.
+func (s *Service) scheduleIndexing(ctx context.Context, repo *types.Repository) error {
+	jobUID := jobUIDPrefix + fmt.Sprint(repo.ID)
+
+	progress, err := s.scheduler.GetJobProgress(ctx, jobUID)
+	if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return fmt.Errorf("failed to get commit index job progress: %w", err)
+	}
+	if err == nil {
+		if progress.State == job.JobStateScheduled || progress.State == job.JobStateRunning {
+			return nil
+		}
+
+		// the previous job is finished, remove it to be able to reuse its UID.
+		if err = s.scheduler.PurgeJobByUID(ctx, jobUID); err != nil {
+			return fmt.Errorf("failed to purge previous commit index job: %w", err)
+		}
+	}
+
+	data, err := json.Marshal(Input{RepoID: repo.ID})
+	if err != nil {
+		return fmt.Errorf("failed to marshal commit index job input: %w", err)
+	}
+
+	err = s.scheduler.RunJob(ctx, job.Definition{
+		UID:        jobUID,
+		Type:       jobType,
+		MaxRetries: 1,
+		Timeout:    s.jobMaxDur,
+		Data:       string(data),
+		SpaceID:    repo.ParentID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to schedule commit index job: %w", err)
+	}
+
+	return nil
+}
+
+// Handle updates the commit index of a single repository.
+func (s *Service) Handle(ctx context.Context, data string, _ job.ProgressReporter) (string, error) {
+	var input Input
+	if err := json.Unmarshal([]byte(data), &input); err != nil {
+		return "", fmt.Errorf("failed to unmarshal commit index job input: %w", err)
+	}
+
+	repo, err := s.repoStore.Find(ctx, input.RepoID)
+	if err != nil {
+		return "", fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	if err = s.index(ctx, repo); err != nil {
+		return "", err
+	}
+
+	return "", nil
+}
+
+func (s *Service) index(ctx context.Context, repo *types.Repository) error {
+	head, err := s.getHead(ctx, repo)
+	if errors.IsNotFound(err) {
+		return nil // nothing to index, the default branch doesn't exist yet.
+	}
+	if err != nil {
+		return err
+	}
+
+	readParams := git.CreateReadParams(repo)
+
+	var exclude string
+
+	state, err := s.commitIndexStore.FindState(ctx, repo.ID)
+	if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return fmt.Errorf("failed to find commit index state: %w", err)
+	}
+	if err == nil {
+		if state.CommitSHA == head {
+			return nil
+		}
+
+		// the previously indexed commit might not exist anymore, in that case the whole index is rebuilt.
+		ancestorOut, errAncestor := s.git.IsAncestor(ctx, git.IsAncestorParams{
+			ReadParams:          readParams,
+			AncestorCommitSHA:   state.CommitSHA,
+			DescendantCommitSHA: head,
+		})
+		if errAncestor != nil && !errors.IsNotFound(errAncestor) {
+			return fmt.Errorf("failed to check if the indexed commit is an ancestor: %w", errAncestor)
+		}
+
+		if errAncestor == nil && ancestorOut.Ancestor {
+			exclude = state.CommitSHA
+		}
+	}
+
+	// the history was rewritten or the default branch was changed, the whole index needs to be rebuilt.
+	if exclude == "" {
+		if err = s.commitIndexStore.DeleteAll(ctx, repo.ID); err != nil {
+			return fmt.Errorf("failed to delete commit index: %w", err)
+		}
+	}
+
+	commitCh, errCh := s.git.WalkCommits(ctx, &git.WalkCommitsParams{
+		ReadParams: readParams,
+		GitREF:     head,
+		ExcludeSHA: exclude,
+	})
+
+	batch := make([]types.Commit, 0, insertBatchSize)
+	count := 0
+
+	for commit := range commitCh {
+		batch = append(batch, mapCommit(commit))
+		if len(batch) < insertBatchSize {
+			continue
+		}
+
+		if err = s.commitIndexStore.Insert(ctx, repo.ID, batch); err != nil {
+			return fmt.Errorf("failed to index commits: %w", err)
+		}
+
+		count += len(batch)
+		batch = batch[:0]
+	}
+
+	if err = <-errCh; err != nil {
+		return fmt.Errorf("failed to walk commits: %w", err)
+	}
+
+	if err = s.commitIndexStore.Insert(ctx, repo.ID, batch); err != nil {
+		return fmt.Errorf("failed to index commits: %w", err)
+	}
+
+	count += len(batch)
+
+	err = s.commitIndexStore.UpsertState(ctx, &types.CommitIndexState{
+		RepoID:    repo.ID,
+		CommitSHA: head,
+		Updated:   time.Now().UnixMilli(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store commit index state: %w", err)
+	}
+
+	log.Ctx(ctx).Info().
+		Int64("repo_id", repo.ID).
+		Int("commits", count).
+		Bool("incremental", exclude != "").
+		Msg("commit index updated")
+
+	return nil
+}
+
+func (s *Service) getHead(ctx context.Context, repo *types.Repository) (string, error) {
+	out, err := s.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: git.CreateReadParams(repo),
+		BranchName: repo.DefaultBranch,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get default branch: %w", err)
+	}
+
+	return out.Branch.SHA, nil
+}
+
+func mapCommit(c *git.Commit) types.Commit {
+	return types.Commit{
+		SHA:     c.SHA,
+		Title:   c.Title,
+		Message: c.Message,
+		Author: types.Signature{
+			Identity: types.Identity{
+				Name:  c.Author.Identity.Name,
+				Email: c.Author.Identity.Email,
+			},
+			When: c.Author.When,
+		},
+		Committer: types.Signature{
+			Identity: types.Identity{
+				Name:  c.Committer.Identity.Name,
+				Email: c.Committer.Identity.Email,
+			},
+			When: c.Committer.When,
+		},
+	}
+}
+
+func (s *Service) launchEventReader(
+	ctx context.Context,
+	readerName string,
+	concurrency int,
+	maxRetries int,
+	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
+) error {
+	_, err := gitReaderFactory.Launch(ctx, eventsReaderGroupName, readerName,
+		func(r *gitevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(maxRetries),
+				))
+
+			_ = r.RegisterBranchCreated(s.handleEventBranchCreated)
+			_ = r.RegisterBranchUpdated(s.handleEventBranchUpdated)
+
+			return nil
+		})
+	if err != nil {
+		return fmt.Errorf("failed to launch git event reader for commit index: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) handleEventBranchCreated(ctx context.Context,
+	event *events.Event[*gitevents.BranchCreatedPayload]) error {
+	return s.handleBranchChange(ctx, event.Payload.RepoID, event.Payload.Ref)
+}
+
+func (s *Service) handleEventBranchUpdated(ctx context.Context,
+	event *events.Event[*gitevents.BranchUpdatedPayload]) error {
+	return s.handleBranchChange(ctx, event.Payload.RepoID, event.Payload.Ref)
+}
+
+// handleBranchChange schedules indexing of the repository if its default branch has changed.
+func (s *Service) handleBranchChange(ctx context.Context, repoID int64, ref string) error {
+	repo, err := s.repoStore.Find(ctx, repoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	if ref != "refs/heads/"+repo.DefaultBranch {
+		return nil
+	}
+
+	return s.scheduleIndexing(ctx, repo)
+}
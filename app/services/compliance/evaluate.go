@@ -0,0 +1,153 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compliance
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	gittypes "github.com/harness/gitness/git/types"
+	"github.com/harness/gitness/types"
+)
+
+// Policies returns the required files policies that apply to repositories of the space.
+// Policies are inherited from all parent spaces. If the same path is required by multiple spaces,
+// the policy of the space closest to the repository is used.
+func (s *Service) Policies(ctx context.Context, spaceID int64) ([]*types.RequiredFile, error) {
+	return s.policies(ctx, spaceID, nil)
+}
+
+func (s *Service) policies(
+	ctx context.Context,
+	spaceID int64,
+	cache map[int64][]*types.RequiredFile,
+) ([]*types.RequiredFile, error) {
+	if policies, ok := cache[spaceID]; ok {
+		return policies, nil
+	}
+
+	var policies []*types.RequiredFile
+	paths := map[string]struct{}{}
+
+	for id := spaceID; id > 0; {
+		space, err := s.spaceStore.Find(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find space: %w", err)
+		}
+
+		spacePolicies, err := s.requiredFileStore.List(ctx, space.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list required files: %w", err)
+		}
+
+		for _, p := range spacePolicies {
+			key := strings.ToLower(p.Path)
+			if _, exists := paths[key]; exists {
+				continue
+			}
+
+			paths[key] = struct{}{}
+			policies = append(policies, p)
+		}
+
+		id = space.ParentID
+	}
+
+	sort.Slice(policies, func(i, j int) bool {
+		return policies[i].Path < policies[j].Path
+	})
+
+	if cache != nil {
+		cache[spaceID] = policies
+	}
+
+	return policies, nil
+}
+
+// Evaluate checks if all required files exist on the default branch of the repository
+// and stores the result.
+func (s *Service) Evaluate(ctx context.Context, repo *types.Repository) (*types.RepoCompliance, error) {
+	policies, err := s.Policies(ctx, repo.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.evaluate(ctx, repo, policies)
+}
+
+func (s *Service) evaluate(
+	ctx context.Context,
+	repo *types.Repository,
+	policies []*types.RequiredFile,
+) (*types.RepoCompliance, error) {
+	compliance := &types.RepoCompliance{
+		RepoID:       repo.ID,
+		RepoUID:      repo.UID,
+		Compliant:    true,
+		MissingFiles: []string{},
+		Checked:      time.Now().UnixMilli(),
+	}
+
+	if len(policies) == 0 {
+		// the repository isn't subject to any policy, remove the stale result (if any)
+		if err := s.complianceStore.Delete(ctx, repo.ID); err != nil {
+			return nil, fmt.Errorf("failed to delete repository compliance: %w", err)
+		}
+
+		return compliance, nil
+	}
+
+	for _, p := range policies {
+		exists, err := s.fileExists(ctx, repo, p.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		if !exists {
+			compliance.MissingFiles = append(compliance.MissingFiles, p.Path)
+		}
+	}
+
+	compliance.Compliant = len(compliance.MissingFiles) == 0
+
+	if err := s.complianceStore.Upsert(ctx, compliance); err != nil {
+		return nil, fmt.Errorf("failed to store repository compliance: %w", err)
+	}
+
+	return compliance, nil
+}
+
+// fileExists checks if the file exists on the default branch of the repository.
+// An empty repository doesn't contain any file.
+func (s *Service) fileExists(ctx context.Context, repo *types.Repository, path string) (bool, error) {
+	_, err := s.git.GetTreeNode(ctx, &git.GetTreeNodeParams{
+		ReadParams: git.ReadParams{RepoUID: repo.GitUID},
+		GitREF:     repo.DefaultBranch,
+		Path:       path,
+	})
+	if gittypes.IsPathNotFoundError(err) || errors.AsStatus(err) == errors.StatusNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to check existence of %q: %w", path, err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compliance
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	gitevents "github.com/harness/gitness/app/events/git"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/stream"
+)
+
+const eventsReaderGroupName = "gitness:compliance"
+
+func (s *Service) launchEventReader(
+	ctx context.Context,
+	readerName string,
+	concurrency int,
+	maxRetries int,
+	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
+) error {
+	_, err := gitReaderFactory.Launch(ctx, eventsReaderGroupName, readerName,
+		func(r *gitevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(maxRetries),
+				))
+
+			_ = r.RegisterBranchCreated(s.handleEventBranchCreated)
+			_ = r.RegisterBranchUpdated(s.handleEventBranchUpdated)
+			_ = r.RegisterBranchDeleted(s.handleEventBranchDeleted)
+
+			return nil
+		})
+	if err != nil {
+		return fmt.Errorf("failed to launch git event reader for compliance: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) handleEventBranchCreated(ctx context.Context,
+	event *events.Event[*gitevents.BranchCreatedPayload]) error {
+	return s.handleBranchChange(ctx, event.Payload.RepoID, event.Payload.Ref)
+}
+
+func (s *Service) handleEventBranchUpdated(ctx context.Context,
+	event *events.Event[*gitevents.BranchUpdatedPayload]) error {
+	return s.handleBranchChange(ctx, event.Payload.RepoID, event.Payload.Ref)
+}
+
+func (s *Service) handleEventBranchDeleted(ctx context.Context,
+	event *events.Event[*gitevents.BranchDeletedPayload]) error {
+	return s.handleBranchChange(ctx, event.Payload.RepoID, event.Payload.Ref)
+}
+
+// handleBranchChange evaluates the repository if its default branch has changed
+// and the repository is subject to at least one required files policy.
+func (s *Service) handleBranchChange(ctx context.Context, repoID int64, ref string) error {
+	repo, err := s.repoStore.Find(ctx, repoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	if ref != "refs/heads/"+repo.DefaultBranch {
+		return nil
+	}
+
+	policies, err := s.Policies(ctx, repo.ParentID)
+	if err != nil {
+		return err
+	}
+
+	if len(policies) == 0 {
+		return nil
+	}
+
+	if _, err = s.evaluate(ctx, repo, policies); err != nil {
+		return fmt.Errorf("failed to evaluate repository compliance: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,171 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compliance
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/githook"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// PullReqBranch is the branch used by pull requests that add missing required files.
+const PullReqBranch = "compliance/required-files"
+
+// CreatePullReq opens a pull request that adds the missing required files to the repository.
+// Only the files with a template are added. If there is nothing to add, or the compliance branch
+// already exists (e.g. a previous pull request is still open), nil is returned.
+func (s *Service) CreatePullReq(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+) (*types.PullReq, error) {
+	policies, err := s.Policies(ctx, repo.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	compliance, err := s.evaluate(ctx, repo, policies)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.createPullReq(ctx, session, repo, policies, compliance.MissingFiles, true)
+}
+
+// createPullReq commits the missing files to the compliance branch and opens the pull request
+// the same way as the pull request API does. The branch is deleted if the pull request can't be created.
+func (s *Service) createPullReq(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	policies []*types.RequiredFile,
+	missingFiles []string,
+	force bool,
+) (*types.PullReq, error) {
+	missing := make(map[string]struct{}, len(missingFiles))
+	for _, path := range missingFiles {
+		missing[path] = struct{}{}
+	}
+
+	var actions []git.CommitFileAction
+	var paths []string
+	for _, p := range policies {
+		if _, ok := missing[p.Path]; !ok || p.Template == "" || (!force && !p.CreatePullReq) {
+			continue
+		}
+
+		actions = append(actions, git.CommitFileAction{
+			Action:  git.CreateAction,
+			Path:    p.Path,
+			Payload: []byte(p.Template),
+		})
+		paths = append(paths, p.Path)
+	}
+
+	if len(actions) == 0 {
+		return nil, nil
+	}
+
+	_, err := s.git.GetRef(ctx, git.GetRefParams{
+		ReadParams: git.ReadParams{RepoUID: repo.GitUID},
+		Name:       PullReqBranch,
+		Type:       gitenum.RefTypeBranch,
+	})
+	if err == nil {
+		return nil, nil
+	}
+	if errors.AsStatus(err) != errors.StatusNotFound {
+		return nil, fmt.Errorf("failed to check existence of the compliance branch: %w", err)
+	}
+
+	principal := &session.Principal
+
+	envVars, err := githook.GenerateEnvironmentVariables(ctx, s.urlProvider.GetInternalAPIURL(),
+		repo.ID, principal.ID, false, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate git hook environment variables: %w", err)
+	}
+
+	writeParams := git.WriteParams{
+		Actor: git.Identity{
+			Name:  principal.DisplayName,
+			Email: principal.Email,
+		},
+		RepoUID: repo.GitUID,
+		EnvVars: envVars,
+	}
+
+	now := time.Now()
+	title := "Add required files: " + strings.Join(paths, ", ")
+
+	_, err = s.git.CommitFiles(ctx, &git.CommitFilesParams{
+		WriteParams:   writeParams,
+		Title:         title,
+		Branch:        repo.DefaultBranch,
+		NewBranch:     PullReqBranch,
+		Actions:       actions,
+		Committer:     &writeParams.Actor,
+		CommitterDate: &now,
+		Author:        &writeParams.Actor,
+		AuthorDate:    &now,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to commit required files: %w", err)
+	}
+
+	pr, err := s.pullreqCreator.Create(ctx, session, repo.Path, &pullreq.CreateInput{
+		Title:        title,
+		Description:  pullReqDescription(paths),
+		SourceBranch: PullReqBranch,
+		TargetBranch: repo.DefaultBranch,
+	})
+	if err != nil {
+		errDelete := s.git.DeleteBranch(ctx, &git.DeleteBranchParams{
+			WriteParams: writeParams,
+			BranchName:  PullReqBranch,
+		})
+		if errDelete != nil {
+			// non-critical error
+			log.Ctx(ctx).Warn().Err(errDelete).Msg("failed to delete compliance branch")
+		}
+
+		return nil, fmt.Errorf("failed to create compliance pull request: %w", err)
+	}
+
+	return pr, nil
+}
+
+func pullReqDescription(paths []string) string {
+	sb := strings.Builder{}
+	sb.WriteString("This pull request adds the files required by the space policies of the repository:\n\n")
+	for _, path := range paths {
+		sb.WriteString("- `")
+		sb.WriteString(path)
+		sb.WriteString("`\n")
+	}
+
+	return sb.String()
+}
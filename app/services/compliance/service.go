@@ -0,0 +1,189 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compliance
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const jobType = "repo-compliance"
+
+// Service evaluates the required files policies of spaces against their repositories
+// and optionally opens pull requests that add the missing files.
+type Service struct {
+	enabled           bool
+	cron              string
+	maxDur            time.Duration
+	git               git.Interface
+	urlProvider       url.Provider
+	repoStore         store.RepoStore
+	spaceStore        store.SpaceStore
+	requiredFileStore store.RequiredFileStore
+	complianceStore   store.RepoComplianceStore
+	pullreqCreator    PullReqCreator
+	scheduler         *job.Scheduler
+}
+
+// PullReqCreator creates pull requests, it's implemented by the pull request controller.
+// Compliance pull requests are created the same way as the pull requests created through the API.
+type PullReqCreator interface {
+	Create(
+		ctx context.Context,
+		session *auth.Session,
+		repoRef string,
+		in *pullreq.CreateInput,
+	) (*types.PullReq, error)
+}
+
+func (s *Service) Register(ctx context.Context) error {
+	if !s.enabled {
+		return nil
+	}
+
+	err := s.scheduler.AddRecurring(ctx, jobType, jobType, s.cron, s.maxDur)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for compliance service: %w", err)
+	}
+
+	return nil
+}
+
+// Handle evaluates the repositories that are affected by changes since their last evaluation
+// and opens pull requests for the non-compliant ones (if enabled by the policies).
+// Pushes to the default branch are evaluated as they happen, see handleBranchChange.
+func (s *Service) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	if !s.enabled {
+		return "", nil
+	}
+
+	repoInfos, err := s.repoStore.ListSizeInfos(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list repositories: %w", err)
+	}
+
+	// policies are cached per space for the duration of the job
+	cache := map[int64][]*types.RequiredFile{}
+
+	var evaluated, missing, pullreqs int
+	for _, repoInfo := range repoInfos {
+		if ctx.Err() != nil {
+			break
+		}
+
+		log := log.Ctx(ctx).With().Int64("repo_id", repoInfo.ID).Logger()
+
+		repo, err := s.repoStore.Find(ctx, repoInfo.ID)
+		if err != nil {
+			log.Warn().Err(err).Msg("failed to find repository")
+			continue
+		}
+
+		policies, err := s.policies(ctx, repo.ParentID, cache)
+		if err != nil {
+			log.Warn().Err(err).Msg("failed to get required files policies")
+			continue
+		}
+
+		compliance, err := s.complianceStore.Find(ctx, repo.ID)
+		if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+			log.Warn().Err(err).Msg("failed to find repository compliance")
+			continue
+		}
+
+		if needsEvaluation(repo, policies, compliance) {
+			compliance, err = s.evaluate(ctx, repo, policies)
+			if err != nil {
+				log.Warn().Err(err).Msg("failed to evaluate repository compliance")
+				continue
+			}
+
+			evaluated++
+		}
+
+		if len(policies) == 0 || compliance.Compliant {
+			continue
+		}
+
+		missing++
+
+		pr, err := s.createPullReq(ctx, bootstrap.NewSystemServiceSession(), repo, policies, compliance.MissingFiles, false)
+		if err != nil {
+			log.Warn().Err(err).Msg("failed to create compliance pull request")
+			continue
+		}
+
+		if pr != nil {
+			pullreqs++
+		}
+	}
+
+	return fmt.Sprintf("evaluated %d repositories, %d not compliant, %d pull requests opened",
+		evaluated, missing, pullreqs), nil
+}
+
+// needsEvaluation returns true if the stored compliance result of the repository is outdated
+// because of a change of the policies or of the repository since the result was checked.
+func needsEvaluation(
+	repo *types.Repository,
+	policies []*types.RequiredFile,
+	compliance *types.RepoCompliance,
+) bool {
+	if compliance == nil {
+		return len(policies) > 0
+	}
+
+	// all policies have been removed, the stale result is removed by the evaluation.
+	if len(policies) == 0 {
+		return true
+	}
+
+	// the repository has been changed, e.g. moved to another space or its default branch changed.
+	if repo.Updated > compliance.Checked {
+		return true
+	}
+
+	required := make(map[string]struct{}, len(policies))
+	for _, p := range policies {
+		if p.Created > compliance.Checked || p.Updated > compliance.Checked {
+			return true
+		}
+
+		required[p.Path] = struct{}{}
+	}
+
+	// a policy of a missing file has been removed.
+	for _, path := range compliance.MissingFiles {
+		if _, ok := required[path]; !ok {
+			return true
+		}
+	}
+
+	return false
+}
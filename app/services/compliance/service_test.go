@@ -0,0 +1,236 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compliance
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/url"
+	gitness_errors "github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+)
+
+func TestNeedsEvaluation(t *testing.T) {
+	const checked = 1000
+
+	policies := []*types.RequiredFile{
+		{Path: "LICENSE", Created: 100, Updated: 100},
+		{Path: "SECURITY.md", Created: 200, Updated: 300},
+	}
+
+	tests := []struct {
+		name       string
+		repo       *types.Repository
+		policies   []*types.RequiredFile
+		compliance *types.RepoCompliance
+		want       bool
+	}{
+		{
+			name:     "never-evaluated",
+			repo:     &types.Repository{Updated: 10},
+			policies: policies,
+			want:     true,
+		},
+		{
+			name: "never-evaluated-without-policies",
+			repo: &types.Repository{Updated: 10},
+			want: false,
+		},
+		{
+			name:       "up-to-date",
+			repo:       &types.Repository{Updated: 10},
+			policies:   policies,
+			compliance: &types.RepoCompliance{Checked: checked, MissingFiles: []string{"LICENSE"}},
+			want:       false,
+		},
+		{
+			name: "policy-added",
+			repo: &types.Repository{Updated: 10},
+			policies: append([]*types.RequiredFile{{Path: "CODEOWNERS", Created: 2000, Updated: 2000}},
+				policies...),
+			compliance: &types.RepoCompliance{Checked: checked, Compliant: true},
+			want:       true,
+		},
+		{
+			name:       "policy-updated",
+			repo:       &types.Repository{Updated: 10},
+			policies:   []*types.RequiredFile{{Path: "LICENSE.md", Created: 100, Updated: 2000}},
+			compliance: &types.RepoCompliance{Checked: checked, Compliant: true},
+			want:       true,
+		},
+		{
+			name:       "policy-of-missing-file-removed",
+			repo:       &types.Repository{Updated: 10},
+			policies:   policies[:1],
+			compliance: &types.RepoCompliance{Checked: checked, MissingFiles: []string{"SECURITY.md"}},
+			want:       true,
+		},
+		{
+			name:       "policy-of-existing-file-removed",
+			repo:       &types.Repository{Updated: 10},
+			policies:   policies[:1],
+			compliance: &types.RepoCompliance{Checked: checked, Compliant: true},
+			want:       false,
+		},
+		{
+			name:       "all-policies-removed",
+			repo:       &types.Repository{Updated: 10},
+			compliance: &types.RepoCompliance{Checked: checked, Compliant: true},
+			want:       true,
+		},
+		{
+			name:       "repository-changed",
+			repo:       &types.Repository{Updated: 2000},
+			policies:   policies,
+			compliance: &types.RepoCompliance{Checked: checked, Compliant: true},
+			want:       true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := needsEvaluation(test.repo, test.policies, test.compliance); got != test.want {
+				t.Errorf("expected %t, got %t", test.want, got)
+			}
+		})
+	}
+}
+
+type pullReqGit struct {
+	git.Interface
+	commits         []*git.CommitFilesParams
+	deletedBranches []string
+}
+
+func (g *pullReqGit) GetRef(context.Context, git.GetRefParams) (git.GetRefResponse, error) {
+	return git.GetRefResponse{}, gitness_errors.NotFound("branch not found")
+}
+
+func (g *pullReqGit) CommitFiles(_ context.Context, params *git.CommitFilesParams) (git.CommitFilesResponse, error) {
+	g.commits = append(g.commits, params)
+	return git.CommitFilesResponse{CommitID: "abc"}, nil
+}
+
+func (g *pullReqGit) DeleteBranch(_ context.Context, params *git.DeleteBranchParams) error {
+	g.deletedBranches = append(g.deletedBranches, params.BranchName)
+	return nil
+}
+
+type fakePullReqCreator struct {
+	err    error
+	inputs []*pullreq.CreateInput
+}
+
+func (c *fakePullReqCreator) Create(
+	_ context.Context,
+	_ *auth.Session,
+	_ string,
+	in *pullreq.CreateInput,
+) (*types.PullReq, error) {
+	c.inputs = append(c.inputs, in)
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	return &types.PullReq{Number: 1, Title: in.Title}, nil
+}
+
+type fakeURLProvider struct {
+	url.Provider
+}
+
+func (fakeURLProvider) GetInternalAPIURL() string {
+	return "http://localhost:3000/api"
+}
+
+func TestCreatePullReq(t *testing.T) {
+	policies := []*types.RequiredFile{
+		{Path: "LICENSE", Template: "MIT", CreatePullReq: true},
+		{Path: "SECURITY.md", Template: "Report issues privately.", CreatePullReq: false},
+		{Path: "CODEOWNERS"},
+	}
+	missing := []string{"CODEOWNERS", "LICENSE", "SECURITY.md"}
+	repo := &types.Repository{ID: 1, Path: "space/repo", GitUID: "git-uid", DefaultBranch: "main"}
+	session := &auth.Session{Principal: types.Principal{ID: 1, DisplayName: "Gitness", Email: "system@gitness.io"}}
+
+	t.Run("through-the-pullreq-controller", func(t *testing.T) {
+		g := &pullReqGit{}
+		creator := &fakePullReqCreator{}
+		s := &Service{git: g, urlProvider: fakeURLProvider{}, pullreqCreator: creator}
+
+		pr, err := s.createPullReq(context.Background(), session, repo, policies, missing, false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if pr == nil {
+			t.Fatal("expected a pull request")
+		}
+
+		if len(g.commits) != 1 || len(g.commits[0].Actions) != 1 || g.commits[0].Actions[0].Path != "LICENSE" {
+			t.Errorf("expected a commit adding only LICENSE, got %+v", g.commits)
+		}
+
+		if len(creator.inputs) != 1 {
+			t.Fatalf("expected one pull request creation, got %d", len(creator.inputs))
+		}
+		in := creator.inputs[0]
+		if in.SourceBranch != PullReqBranch || in.TargetBranch != "main" || in.Title != "Add required files: LICENSE" {
+			t.Errorf("unexpected pull request input: %+v", in)
+		}
+
+		if len(g.deletedBranches) != 0 {
+			t.Errorf("expected the branch to be kept, deleted: %v", g.deletedBranches)
+		}
+	})
+
+	t.Run("branch-deleted-on-failure", func(t *testing.T) {
+		g := &pullReqGit{}
+		creator := &fakePullReqCreator{err: errors.New("failed")}
+		s := &Service{git: g, urlProvider: fakeURLProvider{}, pullreqCreator: creator}
+
+		_, err := s.createPullReq(context.Background(), session, repo, policies, missing, true)
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+
+		if len(g.commits) != 1 || len(g.commits[0].Actions) != 2 {
+			t.Errorf("expected a commit adding LICENSE and SECURITY.md, got %+v", g.commits)
+		}
+
+		if len(g.deletedBranches) != 1 || g.deletedBranches[0] != PullReqBranch {
+			t.Errorf("expected the compliance branch to be deleted, deleted: %v", g.deletedBranches)
+		}
+	})
+
+	t.Run("nothing-to-add", func(t *testing.T) {
+		g := &pullReqGit{}
+		creator := &fakePullReqCreator{}
+		s := &Service{git: g, urlProvider: fakeURLProvider{}, pullreqCreator: creator}
+
+		pr, err := s.createPullReq(context.Background(), session, repo, policies, []string{"CODEOWNERS"}, true)
+		if err != nil || pr != nil {
+			t.Fatalf("expected no pull request and no error, got %v, %v", pr, err)
+		}
+
+		if len(g.commits) != 0 || len(creator.inputs) != 0 {
+			t.Error("expected no commit and no pull request")
+		}
+	})
+}
@@ -0,0 +1,80 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compliance
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	gitevents "github.com/harness/gitness/app/events/git"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	ProvideService,
+)
+
+func ProvideService(
+	ctx context.Context,
+	config *types.Config,
+	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
+	git git.Interface,
+	urlProvider url.Provider,
+	repoStore store.RepoStore,
+	spaceStore store.SpaceStore,
+	requiredFileStore store.RequiredFileStore,
+	complianceStore store.RepoComplianceStore,
+	pullreqCtrl *pullreq.Controller,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+) (*Service, error) {
+	s := &Service{
+		enabled:           config.Compliance.Enabled,
+		cron:              config.Compliance.CRON,
+		maxDur:            config.Compliance.MaxDuration,
+		git:               git,
+		urlProvider:       urlProvider,
+		repoStore:         repoStore,
+		spaceStore:        spaceStore,
+		requiredFileStore: requiredFileStore,
+		complianceStore:   complianceStore,
+		pullreqCreator:    pullreqCtrl,
+		scheduler:         scheduler,
+	}
+
+	err := executor.Register(jobType, s)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.enabled {
+		return s, nil
+	}
+
+	err = s.launchEventReader(ctx, config.InstanceID,
+		config.Compliance.Concurrency, config.Compliance.MaxRetries, gitReaderFactory)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
@@ -0,0 +1,220 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package depgraph orders the nodes of a dependency graph so that every node
+// comes after the nodes it depends on, and reports the dependency cycles.
+package depgraph
+
+import (
+	"sort"
+)
+
+// Graph is a directed graph of dependencies between nodes identified by an ID.
+type Graph struct {
+	nodes     []int64
+	index     map[int64]int
+	dependsOn map[int64][]int64
+}
+
+// Node is a node of an ordered graph.
+type Node struct {
+	ID int64
+	// Level is the length of the longest dependency chain below the node.
+	// Nodes without dependencies have level 0, nodes of the same level don't depend on each other
+	// unless they are part of the same cycle.
+	Level int
+	// DependsOn lists the direct dependencies of the node.
+	DependsOn []int64
+	// InCycle is true if the node is part of a dependency cycle.
+	InCycle bool
+}
+
+// Order is the result of ordering a graph.
+type Order struct {
+	// Nodes lists all nodes ordered by their level.
+	Nodes []Node
+	// Cycles lists the nodes of every dependency cycle.
+	Cycles [][]int64
+}
+
+// New returns a new empty dependency graph.
+func New() *Graph {
+	return &Graph{
+		index:     map[int64]int{},
+		dependsOn: map[int64][]int64{},
+	}
+}
+
+// AddNode adds the node to the graph. Adding a node that's already in the graph is a no-op.
+func (g *Graph) AddNode(id int64) {
+	if _, ok := g.index[id]; ok {
+		return
+	}
+
+	g.index[id] = len(g.nodes)
+	g.nodes = append(g.nodes, id)
+}
+
+// AddDependency adds an edge to the graph marking that node id depends on node dependsOn.
+// Both nodes are added to the graph if needed.
+func (g *Graph) AddDependency(id, dependsOn int64) {
+	g.AddNode(id)
+	g.AddNode(dependsOn)
+
+	for _, dep := range g.dependsOn[id] {
+		if dep == dependsOn {
+			return
+		}
+	}
+
+	g.dependsOn[id] = append(g.dependsOn[id], dependsOn)
+}
+
+// Order returns the nodes of the graph in dependency order. The nodes of a cycle share the same level
+// which is above the levels of all dependencies of the cycle.
+// Nodes of the same level are returned in the order they were added to the graph.
+func (g *Graph) Order() Order {
+	components := g.stronglyConnectedComponents()
+
+	componentOf := make(map[int64]int, len(g.nodes))
+	for i, component := range components {
+		for _, id := range component {
+			componentOf[id] = i
+		}
+	}
+
+	// Components are produced in reverse topological order,
+	// so the dependencies of a component always come before the component itself.
+	componentLevels := make([]int, len(components))
+	cycles := make([][]int64, 0)
+	inCycle := make(map[int64]bool)
+
+	for i, component := range components {
+		level := 0
+		isCycle := len(component) > 1
+
+		for _, id := range component {
+			for _, dep := range g.dependsOn[id] {
+				depComponent := componentOf[dep]
+				if depComponent == i {
+					isCycle = true
+					continue
+				}
+
+				if componentLevels[depComponent]+1 > level {
+					level = componentLevels[depComponent] + 1
+				}
+			}
+		}
+
+		componentLevels[i] = level
+
+		if isCycle {
+			sort.Slice(component, func(a, b int) bool {
+				return g.index[component[a]] < g.index[component[b]]
+			})
+			cycles = append(cycles, component)
+			for _, id := range component {
+				inCycle[id] = true
+			}
+		}
+	}
+
+	sort.Slice(cycles, func(a, b int) bool {
+		return g.index[cycles[a][0]] < g.index[cycles[b][0]]
+	})
+
+	nodes := make([]Node, len(g.nodes))
+	for i, id := range g.nodes {
+		nodes[i] = Node{
+			ID:        id,
+			Level:     componentLevels[componentOf[id]],
+			DependsOn: append([]int64{}, g.dependsOn[id]...),
+			InCycle:   inCycle[id],
+		}
+	}
+
+	sort.SliceStable(nodes, func(a, b int) bool {
+		return nodes[a].Level < nodes[b].Level
+	})
+
+	return Order{
+		Nodes:  nodes,
+		Cycles: cycles,
+	}
+}
+
+// stronglyConnectedComponents returns the strongly connected components of the graph using Tarjan's algorithm.
+// The components are returned in reverse topological order: a component comes after all components it depends on.
+func (g *Graph) stronglyConnectedComponents() [][]int64 {
+	var (
+		counter    int
+		stack      []int64
+		onStack    = map[int64]bool{}
+		indexes    = map[int64]int{}
+		lowLinks   = map[int64]int{}
+		components [][]int64
+	)
+
+	var visit func(id int64)
+	visit = func(id int64) {
+		indexes[id] = counter
+		lowLinks[id] = counter
+		counter++
+
+		stack = append(stack, id)
+		onStack[id] = true
+
+		for _, dep := range g.dependsOn[id] {
+			if _, visited := indexes[dep]; !visited {
+				visit(dep)
+				lowLinks[id] = minInt(lowLinks[id], lowLinks[dep])
+			} else if onStack[dep] {
+				lowLinks[id] = minInt(lowLinks[id], indexes[dep])
+			}
+		}
+
+		if lowLinks[id] != indexes[id] {
+			return
+		}
+
+		var component []int64
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component = append(component, top)
+			if top == id {
+				break
+			}
+		}
+
+		components = append(components, component)
+	}
+
+	for _, id := range g.nodes {
+		if _, visited := indexes[id]; !visited {
+			visit(id)
+		}
+	}
+
+	return components
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
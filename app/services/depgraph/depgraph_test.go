@@ -0,0 +1,104 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package depgraph
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOrder(t *testing.T) {
+	type edge struct{ id, dependsOn int64 }
+
+	tests := []struct {
+		name       string
+		nodes      []int64
+		edges      []edge
+		wantIDs    []int64
+		wantLevels []int
+		wantCycles [][]int64
+	}{
+		{
+			name:       "independent",
+			nodes:      []int64{3, 1, 2},
+			wantIDs:    []int64{3, 1, 2},
+			wantLevels: []int{0, 0, 0},
+			wantCycles: [][]int64{},
+		},
+		{
+			name:       "chain",
+			nodes:      []int64{1, 2, 3},
+			edges:      []edge{{1, 2}, {2, 3}},
+			wantIDs:    []int64{3, 2, 1},
+			wantLevels: []int{0, 1, 2},
+			wantCycles: [][]int64{},
+		},
+		{
+			name:       "diamond",
+			nodes:      []int64{1, 2, 3, 4},
+			edges:      []edge{{1, 2}, {1, 3}, {2, 4}, {3, 4}},
+			wantIDs:    []int64{4, 2, 3, 1},
+			wantLevels: []int{0, 1, 1, 2},
+			wantCycles: [][]int64{},
+		},
+		{
+			name:       "cycle",
+			nodes:      []int64{1, 2, 3, 4},
+			edges:      []edge{{1, 2}, {2, 3}, {3, 2}, {3, 4}},
+			wantIDs:    []int64{4, 2, 3, 1},
+			wantLevels: []int{0, 1, 1, 2},
+			wantCycles: [][]int64{{2, 3}},
+		},
+		{
+			name:       "self dependency",
+			nodes:      []int64{1, 2},
+			edges:      []edge{{1, 1}, {2, 1}},
+			wantIDs:    []int64{1, 2},
+			wantLevels: []int{0, 1},
+			wantCycles: [][]int64{{1}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := New()
+			for _, id := range test.nodes {
+				g.AddNode(id)
+			}
+			for _, e := range test.edges {
+				g.AddDependency(e.id, e.dependsOn)
+			}
+
+			order := g.Order()
+
+			ids := make([]int64, len(order.Nodes))
+			levels := make([]int, len(order.Nodes))
+			for i, node := range order.Nodes {
+				ids[i] = node.ID
+				levels[i] = node.Level
+			}
+
+			if !reflect.DeepEqual(ids, test.wantIDs) {
+				t.Errorf("got ids %v, want %v", ids, test.wantIDs)
+			}
+			if !reflect.DeepEqual(levels, test.wantLevels) {
+				t.Errorf("got levels %v, want %v", levels, test.wantLevels)
+			}
+			if !reflect.DeepEqual(order.Cycles, test.wantCycles) {
+				t.Errorf("got cycles %v, want %v", order.Cycles, test.wantCycles)
+			}
+		})
+	}
+}
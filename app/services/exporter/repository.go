@@ -140,6 +140,7 @@ func (r *Repository) RunManyForSpace(
 			MaxRetries: exportJobMaxRetries,
 			Timeout:    exportJobMaxDuration,
 			Data:       base64.StdEncoding.EncodeToString(encryptedData),
+			SpaceID:    spaceID,
 		}
 	}
 
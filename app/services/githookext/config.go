@@ -0,0 +1,94 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githookext
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+const (
+	TypeExecutable = "executable"
+	TypeHTTP       = "http"
+)
+
+// Config is the configuration of an external extension, as listed in the extensions config file.
+type Config struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+
+	// Command and Args are used by extensions of type "executable".
+	Command string   `json:"command,omitempty"`
+	Args    []string `json:"args,omitempty"`
+
+	// URL and Headers are used by extensions of type "http".
+	URL     string            `json:"url,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+
+	Hooks         []Hook        `json:"hooks,omitempty"`
+	Timeout       string        `json:"timeout,omitempty"`
+	FailurePolicy FailurePolicy `json:"failure_policy,omitempty"`
+}
+
+// LoadConfig reads the list of external extensions from a JSON file.
+func LoadConfig(path string) ([]Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read githook extensions config: %w", err)
+	}
+
+	var configs []Config
+	if err = json.Unmarshal(data, &configs); err != nil {
+		return nil, fmt.Errorf("failed to parse githook extensions config: %w", err)
+	}
+
+	return configs, nil
+}
+
+// RegisterExternal registers the extension described by the config.
+func (m *Manager) RegisterExternal(config Config) error {
+	var extension Extension
+
+	switch config.Type {
+	case TypeExecutable:
+		if config.Command == "" {
+			return fmt.Errorf("githook extension %q has no command", config.Name)
+		}
+		extension = &Executable{Command: config.Command, Args: config.Args}
+	case TypeHTTP:
+		if config.URL == "" {
+			return fmt.Errorf("githook extension %q has no url", config.Name)
+		}
+		extension = &HTTP{URL: config.URL, Headers: config.Headers}
+	default:
+		return fmt.Errorf("githook extension %q has unknown type %q", config.Name, config.Type)
+	}
+
+	var timeout time.Duration
+	if config.Timeout != "" {
+		var err error
+		if timeout, err = time.ParseDuration(config.Timeout); err != nil {
+			return fmt.Errorf("githook extension %q has invalid timeout: %w", config.Name, err)
+		}
+	}
+
+	return m.Register(config.Name, extension, Options{
+		Hooks:         config.Hooks,
+		Timeout:       timeout,
+		FailurePolicy: config.FailurePolicy,
+	})
+}
@@ -0,0 +1,89 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githookext
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/harness/gitness/git/hook"
+
+	"github.com/gotidy/ptr"
+)
+
+// Executable is an extension that runs an external executable.
+// The executable receives the input as JSON on stdin and every line it writes to stdout
+// is shown to the user. A non-zero exit code rejects the git operation, with the first
+// line of stderr as the reason.
+type Executable struct {
+	Command string
+	Args    []string
+}
+
+func (e *Executable) Run(ctx context.Context, in *Input) (hook.Output, error) {
+	stdin, err := json.Marshal(in)
+	if err != nil {
+		return hook.Output{}, fmt.Errorf("failed to marshal input: %w", err)
+	}
+
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+
+	//nolint:gosec // the command is configured by the operator.
+	cmd := exec.CommandContext(ctx, e.Command, e.Args...)
+	cmd.Stdin = bytes.NewReader(stdin)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	err = cmd.Run()
+
+	var errExit *exec.ExitError
+	if err != nil && (!errors.As(err, &errExit) || ctx.Err() != nil) {
+		return hook.Output{}, fmt.Errorf("failed to run %q: %w", e.Command, err)
+	}
+
+	output := hook.Output{
+		Messages: lines(stdout.String()),
+	}
+
+	if err != nil {
+		reason := "Push rejected by githook extension."
+		if errLines := lines(stderr.String()); len(errLines) > 0 {
+			reason = errLines[0]
+		}
+
+		output.Error = ptr.String(reason)
+	}
+
+	return output, nil
+}
+
+func lines(s string) []string {
+	var result []string
+
+	scanner := bufio.NewScanner(strings.NewReader(s))
+	for scanner.Scan() {
+		if line := strings.TrimRight(scanner.Text(), "\r"); line != "" {
+			result = append(result, line)
+		}
+	}
+
+	return result
+}
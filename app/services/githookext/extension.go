@@ -0,0 +1,138 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package githookext runs custom logic of operators as part of the server side git hooks.
+// Extensions are either registered in-process or configured as external executables or HTTP endpoints,
+// and they run alongside the built-in checks (e.g. protection rules) of the pre-receive and update hooks.
+package githookext
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/git/hook"
+)
+
+// Hook is the name of a server side git hook an extension can run in.
+type Hook string
+
+const (
+	HookPreReceive Hook = "pre-receive"
+	HookUpdate     Hook = "update"
+)
+
+// FailurePolicy defines how a failure of an extension (an error, a timeout, ...) affects the git operation.
+type FailurePolicy string
+
+const (
+	// FailurePolicyReject rejects the git operation if the extension fails.
+	FailurePolicyReject FailurePolicy = "reject"
+	// FailurePolicyIgnore ignores the failure of the extension and lets the git operation proceed.
+	FailurePolicyIgnore FailurePolicy = "ignore"
+)
+
+var (
+	ErrAlreadyRegistered = errors.New("githook extension already registered")
+	ErrNameEmpty         = errors.New("githook extension name can't be empty")
+)
+
+type (
+	// Extension is custom logic executed by the server side git hooks.
+	Extension interface {
+		// Run is called with the reference updates of a git operation.
+		// A non-nil Error in the output rejects the operation, while a returned error
+		// is a failure of the extension that's handled according to its failure policy.
+		Run(ctx context.Context, in *Input) (hook.Output, error)
+	}
+
+	// ExtensionFunc is an adapter to allow the use of ordinary functions as extensions.
+	ExtensionFunc func(ctx context.Context, in *Input) (hook.Output, error)
+
+	// Input is the input of an extension.
+	Input struct {
+		Hook       Hook                   `json:"hook"`
+		Repo       Repo                   `json:"repo"`
+		Principal  Principal              `json:"principal"`
+		RefUpdates []hook.ReferenceUpdate `json:"ref_updates"`
+		// PushOptions contains the push options the client sent (not available in the update hook).
+		PushOptions []string `json:"push_options,omitempty"`
+	}
+
+	Repo struct {
+		ID            int64  `json:"id"`
+		Path          string `json:"path"`
+		DefaultBranch string `json:"default_branch"`
+	}
+
+	Principal struct {
+		ID          int64  `json:"id"`
+		UID         string `json:"uid"`
+		Email       string `json:"email"`
+		DisplayName string `json:"display_name"`
+	}
+
+	// Options define when an extension runs and how its failures are handled.
+	Options struct {
+		// Hooks are the git hooks the extension runs in. It runs in all of them if empty.
+		Hooks []Hook
+		// Timeout is the maximum duration of a single run of the extension.
+		Timeout time.Duration
+		// FailurePolicy defines what happens when the extension fails. Defaults to FailurePolicyReject.
+		FailurePolicy FailurePolicy
+	}
+)
+
+func (f ExtensionFunc) Run(ctx context.Context, in *Input) (hook.Output, error) {
+	return f(ctx, in)
+}
+
+func (o *Options) sanitize(defaultTimeout time.Duration) error {
+	for _, h := range o.Hooks {
+		switch h {
+		case HookPreReceive, HookUpdate:
+		default:
+			return fmt.Errorf("unknown git hook %q", h)
+		}
+	}
+
+	if o.Timeout <= 0 {
+		o.Timeout = defaultTimeout
+	}
+
+	switch o.FailurePolicy {
+	case "":
+		o.FailurePolicy = FailurePolicyReject
+	case FailurePolicyReject, FailurePolicyIgnore:
+	default:
+		return fmt.Errorf("unknown failure policy %q", o.FailurePolicy)
+	}
+
+	return nil
+}
+
+func (o *Options) runsIn(h Hook) bool {
+	if len(o.Hooks) == 0 {
+		return true
+	}
+
+	for _, oh := range o.Hooks {
+		if oh == h {
+			return true
+		}
+	}
+
+	return false
+}
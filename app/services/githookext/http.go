@@ -0,0 +1,80 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githookext
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/harness/gitness/git/hook"
+)
+
+// maxResponseSize is the maximum size (in bytes) of the response of an HTTP extension.
+const maxResponseSize = 1 << 20
+
+// HTTP is an extension that calls an external HTTP endpoint.
+// The input is sent as the JSON body of a POST request, and the endpoint is expected to respond
+// with status 200 and the JSON representation of hook.Output (messages and an optional error).
+type HTTP struct {
+	URL     string
+	Headers map[string]string
+	Client  *http.Client
+}
+
+func (e *HTTP) Run(ctx context.Context, in *Input) (hook.Output, error) {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return hook.Output{}, fmt.Errorf("failed to marshal input: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.URL, bytes.NewReader(body))
+	if err != nil {
+		return hook.Output{}, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range e.Headers {
+		req.Header.Set(key, value)
+	}
+
+	client := e.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return hook.Output{}, fmt.Errorf("failed to call %q: %w", e.URL, err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return hook.Output{}, fmt.Errorf("unexpected response status %d from %q", resp.StatusCode, e.URL)
+	}
+
+	output := hook.Output{}
+	if err = json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&output); err != nil {
+		return hook.Output{}, fmt.Errorf("failed to decode response from %q: %w", e.URL, err)
+	}
+
+	return output, nil
+}
@@ -0,0 +1,122 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githookext
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/git/hook"
+
+	"github.com/gotidy/ptr"
+	"github.com/rs/zerolog/log"
+)
+
+type registration struct {
+	name      string
+	extension Extension
+	options   Options
+}
+
+// Manager runs the registered extensions.
+type Manager struct {
+	defaultTimeout time.Duration
+	registrations  []registration
+}
+
+// NewManager creates a new Manager. The default timeout applies to extensions registered without one.
+func NewManager(defaultTimeout time.Duration) *Manager {
+	if defaultTimeout <= 0 {
+		defaultTimeout = 10 * time.Second
+	}
+
+	return &Manager{
+		defaultTimeout: defaultTimeout,
+	}
+}
+
+// Register registers an extension. Extensions run in the order they've been registered.
+func (m *Manager) Register(name string, extension Extension, options Options) error {
+	if name == "" {
+		return ErrNameEmpty
+	}
+
+	for _, r := range m.registrations {
+		if r.name == name {
+			return ErrAlreadyRegistered
+		}
+	}
+
+	if err := options.sanitize(m.defaultTimeout); err != nil {
+		return fmt.Errorf("invalid options of githook extension %q: %w", name, err)
+	}
+
+	m.registrations = append(m.registrations, registration{
+		name:      name,
+		extension: extension,
+		options:   options,
+	})
+
+	return nil
+}
+
+// Run runs the extensions registered for the hook of the input, and stops at the first one
+// that rejects the git operation. The messages of all extensions that ran are part of the output.
+func (m *Manager) Run(ctx context.Context, in *Input) hook.Output {
+	output := hook.Output{}
+
+	for _, r := range m.registrations {
+		if !r.options.runsIn(in.Hook) {
+			continue
+		}
+
+		out, err := m.run(ctx, r, in)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Str("extension", r.name).
+				Str("hook", string(in.Hook)).
+				Msg("githook extension failed")
+
+			if r.options.FailurePolicy == FailurePolicyIgnore {
+				continue
+			}
+
+			output.Error = ptr.String(fmt.Sprintf("Githook extension %q failed.", r.name))
+			return output
+		}
+
+		output.Messages = append(output.Messages, out.Messages...)
+
+		if out.Error != nil {
+			output.Error = out.Error
+			return output
+		}
+	}
+
+	return output
+}
+
+func (m *Manager) run(ctx context.Context, r registration, in *Input) (hook.Output, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.options.Timeout)
+	defer cancel()
+
+	out, err := r.extension.Run(ctx, in)
+	if err != nil && ctx.Err() != nil {
+		return hook.Output{}, fmt.Errorf("timed out after %s: %w", r.options.Timeout, err)
+	}
+
+	return out, err
+}
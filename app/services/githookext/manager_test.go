@@ -0,0 +1,166 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githookext
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/git/hook"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/gotidy/ptr"
+)
+
+func TestManager_Run(t *testing.T) {
+	allow := ExtensionFunc(func(context.Context, *Input) (hook.Output, error) {
+		return hook.Output{Messages: []string{"allowed"}}, nil
+	})
+	reject := ExtensionFunc(func(context.Context, *Input) (hook.Output, error) {
+		return hook.Output{Messages: []string{"rejected"}, Error: ptr.String("not allowed")}, nil
+	})
+	fail := ExtensionFunc(func(context.Context, *Input) (hook.Output, error) {
+		return hook.Output{}, errors.New("failure")
+	})
+	hang := ExtensionFunc(func(ctx context.Context, _ *Input) (hook.Output, error) {
+		<-ctx.Done()
+		return hook.Output{}, ctx.Err()
+	})
+
+	type extension struct {
+		name    string
+		ext     Extension
+		options Options
+	}
+
+	tests := []struct {
+		name       string
+		extensions []extension
+		hook       Hook
+		exp        hook.Output
+	}{
+		{
+			name:       "no-extensions",
+			extensions: nil,
+			hook:       HookPreReceive,
+			exp:        hook.Output{},
+		},
+		{
+			name: "allowed",
+			extensions: []extension{
+				{name: "a", ext: allow},
+				{name: "b", ext: allow},
+			},
+			hook: HookPreReceive,
+			exp:  hook.Output{Messages: []string{"allowed", "allowed"}},
+		},
+		{
+			name: "rejected-stops",
+			extensions: []extension{
+				{name: "a", ext: reject},
+				{name: "b", ext: allow},
+			},
+			hook: HookPreReceive,
+			exp:  hook.Output{Messages: []string{"rejected"}, Error: ptr.String("not allowed")},
+		},
+		{
+			name: "other-hook-skipped",
+			extensions: []extension{
+				{name: "a", ext: reject, options: Options{Hooks: []Hook{HookUpdate}}},
+				{name: "b", ext: allow, options: Options{Hooks: []Hook{HookPreReceive}}},
+			},
+			hook: HookPreReceive,
+			exp:  hook.Output{Messages: []string{"allowed"}},
+		},
+		{
+			name: "failure-rejects",
+			extensions: []extension{
+				{name: "a", ext: fail},
+				{name: "b", ext: allow},
+			},
+			hook: HookUpdate,
+			exp:  hook.Output{Error: ptr.String(`Githook extension "a" failed.`)},
+		},
+		{
+			name: "failure-ignored",
+			extensions: []extension{
+				{name: "a", ext: fail, options: Options{FailurePolicy: FailurePolicyIgnore}},
+				{name: "b", ext: allow},
+			},
+			hook: HookUpdate,
+			exp:  hook.Output{Messages: []string{"allowed"}},
+		},
+		{
+			name: "timeout-rejects",
+			extensions: []extension{
+				{name: "a", ext: hang, options: Options{Timeout: time.Millisecond}},
+			},
+			hook: HookPreReceive,
+			exp:  hook.Output{Error: ptr.String(`Githook extension "a" failed.`)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := NewManager(time.Second)
+			for _, e := range test.extensions {
+				if err := m.Register(e.name, e.ext, e.options); err != nil {
+					t.Fatalf("failed to register extension: %v", err)
+				}
+			}
+
+			out := m.Run(context.Background(), &Input{Hook: test.hook})
+			if diff := cmp.Diff(test.exp, out); diff != "" {
+				t.Errorf("unexpected output (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestExecutable_Run(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		exp  hook.Output
+	}{
+		{
+			name: "allowed",
+			args: []string{"-c", "cat > /dev/null; echo checked"},
+			exp:  hook.Output{Messages: []string{"checked"}},
+		},
+		{
+			name: "rejected",
+			args: []string{"-c", "echo checked; echo 'commit message too short' >&2; exit 1"},
+			exp:  hook.Output{Messages: []string{"checked"}, Error: ptr.String("commit message too short")},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			e := &Executable{Command: "sh", Args: test.args}
+
+			out, err := e.Run(context.Background(), &Input{Hook: HookPreReceive})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(test.exp, out); diff != "" {
+				t.Errorf("unexpected output (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
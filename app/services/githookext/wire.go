@@ -0,0 +1,49 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githookext
+
+import (
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideManager,
+)
+
+// ProvideManager provides the githook extension manager with the external extensions of the config file.
+// In-process extensions can be added to the returned manager using Register.
+func ProvideManager(config *types.Config) (*Manager, error) {
+	m := NewManager(config.GithookExtensions.Timeout)
+
+	if config.GithookExtensions.ConfigPath == "" {
+		return m, nil
+	}
+
+	configs, err := LoadConfig(config.GithookExtensions.ConfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range configs {
+		if err = m.RegisterExternal(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitusage
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	metricNamespace            = "gitness"
+	metricSubsystem            = "git_service_pack"
+	metricSubsystemNegotiation = "git_upload_pack"
+	labelService               = "service"
+	labelProtocol              = "protocol"
+	labelCommand               = "command"
+	labelCached                = "cached"
+)
+
+// The metrics are labeled by the git service only, the usage per principal
+// and repository is available via the admin API (see GitUsageStore).
+var (
+	invocationsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricNamespace,
+		Subsystem: metricSubsystem,
+		Name:      "invocations_total",
+		Help:      "Total number of git service pack invocations.",
+	}, []string{labelService})
+
+	cpuSecondsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricNamespace,
+		Subsystem: metricSubsystem,
+		Name:      "cpu_seconds_total",
+		Help:      "Total CPU time consumed by git service pack processes in seconds.",
+	}, []string{labelService})
+
+	durationSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricNamespace,
+		Subsystem: metricSubsystem,
+		Name:      "duration_seconds",
+		Help:      "Duration of git service pack invocations in seconds.",
+		Buckets:   []float64{0.1, 0.5, 1, 5, 15, 60, 300, 900, 3600},
+	}, []string{labelService})
+
+	bytesReceivedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricNamespace,
+		Subsystem: metricSubsystem,
+		Name:      "received_bytes_total",
+		Help:      "Total number of bytes received from clients by git service pack invocations.",
+	}, []string{labelService})
+
+	bytesSentTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricNamespace,
+		Subsystem: metricSubsystem,
+		Name:      "sent_bytes_total",
+		Help:      "Total number of bytes sent to clients by git service pack invocations.",
+	}, []string{labelService})
+
+	negotiationRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricNamespace,
+		Subsystem: metricSubsystemNegotiation,
+		Name:      "requests_total",
+		Help:      "Total number of upload-pack requests by protocol version, command and whether served from cache.",
+	}, []string{labelProtocol, labelCommand, labelCached})
+
+	negotiationWants = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricNamespace,
+		Subsystem: metricSubsystemNegotiation,
+		Name:      "wants",
+		Help:      "Number of objects and refs wanted by a single upload-pack request.",
+		Buckets:   prometheus.ExponentialBuckets(1, 4, 8),
+	}, []string{labelProtocol})
+
+	negotiationHaves = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricNamespace,
+		Subsystem: metricSubsystemNegotiation,
+		Name:      "haves",
+		Help:      "Number of common object candidates sent by a single upload-pack request.",
+		Buckets:   prometheus.ExponentialBuckets(1, 4, 8),
+	}, []string{labelProtocol})
+)
@@ -0,0 +1,97 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitusage
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// recordTimeout is the maximum time spent persisting the usage of a single invocation.
+const recordTimeout = 10 * time.Second
+
+// Recorder accounts the resources consumed by git service pack invocations
+// against the requesting principal and the repository.
+type Recorder struct {
+	gitUsageStore store.GitUsageStore
+}
+
+func NewRecorder(gitUsageStore store.GitUsageStore) *Recorder {
+	return &Recorder{
+		gitUsageStore: gitUsageStore,
+	}
+}
+
+// Record updates the metrics and persists the usage of a single service pack invocation.
+// Failures are only logged, as the accounting must never fail the git operation.
+func (r *Recorder) Record(ctx context.Context, usage *types.GitUsage) {
+	service := string(usage.Service)
+
+	invocationsTotal.WithLabelValues(service).Inc()
+	cpuSecondsTotal.WithLabelValues(service).Add(float64(usage.CPUTime) / 1000)
+	durationSeconds.WithLabelValues(service).Observe(float64(usage.WallTime) / 1000)
+	bytesReceivedTotal.WithLabelValues(service).Add(float64(usage.BytesReceived))
+	bytesSentTotal.WithLabelValues(service).Add(float64(usage.BytesSent))
+
+	// the request context might already be canceled (e.g. the client disconnected),
+	// but the usage of the invocation has to be recorded regardless.
+	logger := log.Ctx(ctx)
+	ctx, cancel := context.WithTimeout(logger.WithContext(context.Background()), recordTimeout)
+	defer cancel()
+
+	if err := r.gitUsageStore.Record(ctx, time.Now(), usage); err != nil {
+		logger.Warn().Err(err).
+			Int64("principal_id", usage.PrincipalID).
+			Int64("repo_id", usage.RepoID).
+			Msgf("failed to record usage of git %s", service)
+	}
+}
+
+// RecordNegotiation updates the metrics of the upload-pack negotiation and logs its details.
+func (r *Recorder) RecordNegotiation(ctx context.Context, repoID int64, negotiation *git.UploadPackNegotiation) {
+	protocol := strconv.Itoa(negotiation.ProtocolVersion)
+
+	command := negotiation.Command
+	if command == "" {
+		command = "fetch"
+	}
+
+	negotiationRequestsTotal.WithLabelValues(protocol, command, strconv.FormatBool(negotiation.PackCached)).Inc()
+	if command == "fetch" {
+		negotiationWants.WithLabelValues(protocol).Observe(float64(negotiation.Wants + negotiation.WantRefs))
+		negotiationHaves.WithLabelValues(protocol).Observe(float64(negotiation.Haves))
+	}
+
+	log.Ctx(ctx).Debug().
+		Int64("repo_id", repoID).
+		Int("protocol", negotiation.ProtocolVersion).
+		Str("command", command).
+		Int("wants", negotiation.Wants).
+		Int("want_refs", negotiation.WantRefs).
+		Int("haves", negotiation.Haves).
+		Int("ref_prefixes", negotiation.RefPrefixes).
+		Bool("done", negotiation.Done).
+		Bool("filter", negotiation.Filter).
+		Bool("deepen", negotiation.Deepen).
+		Bool("pack_cached", negotiation.PackCached).
+		Msg("upload-pack negotiation")
+}
@@ -19,12 +19,20 @@ import (
 	"strings"
 )
 
-const jobIDPrefix = "import-repo-"
+const (
+	jobIDPrefix         = "import-repo-"
+	metadataJobIDPrefix = "import-metadata-"
+)
 
 func JobIDFromRepoID(repoID int64) string {
 	return jobIDPrefix + strconv.FormatInt(repoID, 10)
 }
 
+// MetadataJobIDFromRepoID returns the UID of the job that imports the pull requests and webhooks of the repository.
+func MetadataJobIDFromRepoID(repoID int64) string {
+	return metadataJobIDPrefix + strconv.FormatInt(repoID, 10)
+}
+
 func RepoIDFromJobID(jobID string) int64 {
 	if !strings.HasPrefix(jobID, jobIDPrefix) {
 		return 0
@@ -0,0 +1,780 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/bootstrap"
+	gitnesserrors "github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/drone/go-scm/scm"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	metadataJobType        = "repository_import_metadata"
+	metadataJobMaxRetries  = 3
+	metadataJobMaxDuration = 2 * time.Hour
+
+	metadataPageSize = 100
+
+	importedWebhookUIDPrefix = "imported-"
+)
+
+// MetadataOptions defines which repository metadata, besides the git data, gets imported from the provider.
+type MetadataOptions struct {
+	// PullRequests enables the import of pull requests along with their comments and labels.
+	PullRequests bool `json:"pull_requests"`
+
+	// Webhooks enables the import of webhooks. The webhooks are imported disabled,
+	// because their secrets can't be read from the provider and the payloads differ from the provider's.
+	Webhooks bool `json:"webhooks"`
+}
+
+func (o MetadataOptions) IsEmpty() bool {
+	return !o.PullRequests && !o.Webhooks
+}
+
+// MetadataResult is the result (and the reported progress) of the metadata import job.
+type MetadataResult struct {
+	PullRequests        int `json:"pull_requests"`
+	PullRequestsSkipped int `json:"pull_requests_skipped"`
+	Comments            int `json:"comments"`
+	Webhooks            int `json:"webhooks"`
+}
+
+// CheckMetadataSupport returns an error if importing of metadata isn't supported for the provider.
+func CheckMetadataSupport(provider Provider) error {
+	switch provider.Type {
+	case ProviderTypeGitHub, ProviderTypeGitLab, ProviderTypeBitbucket:
+		return nil
+	case ProviderTypeStash, ProviderTypeGitea, ProviderTypeGogs:
+	}
+
+	return usererror.BadRequestf(
+		"Import of pull requests and webhooks isn't supported for %s repositories.", provider.Type)
+}
+
+// pullReqRefSpecs returns the refspecs that fetch the heads of all pull requests of the provider's repository
+// as gitness pull request references. Not all providers expose the pull request heads as git references.
+func pullReqRefSpecs(providerType ProviderType) []string {
+	switch providerType {
+	case ProviderTypeGitHub:
+		return []string{"refs/pull/*/head:refs/pullreq/*/head"}
+	case ProviderTypeGitLab:
+		return []string{"refs/merge-requests/*/head:refs/pullreq/*/head"}
+	case ProviderTypeBitbucket, ProviderTypeStash, ProviderTypeGitea, ProviderTypeGogs:
+	}
+
+	return nil
+}
+
+// metadataImporter is the background job handler that imports the repository metadata
+// after the git data has been imported. The job is resumable: Already imported entries are skipped,
+// so a failed or interrupted job continues where it stopped when retried.
+type metadataImporter struct {
+	r *Repository
+}
+
+var _ job.Handler = (*metadataImporter)(nil)
+
+func (r *Repository) runMetadataImport(ctx context.Context, repo *types.Repository, input Input) error {
+	data, err := r.encodeJobInput(input)
+	if err != nil {
+		return err
+	}
+
+	return r.scheduler.RunJob(ctx, job.Definition{
+		UID:        MetadataJobIDFromRepoID(repo.ID),
+		Type:       metadataJobType,
+		MaxRetries: metadataJobMaxRetries,
+		Timeout:    metadataJobMaxDuration,
+		Data:       data,
+		SpaceID:    repo.ParentID,
+	})
+}
+
+// Handle is the repository metadata import background job handler.
+//
+//nolint:gocognit // refactor if needed.
+func (m *metadataImporter) Handle(ctx context.Context, data string, fn job.ProgressReporter) (string, error) {
+	r := m.r
+	systemPrincipal := bootstrap.NewSystemServiceSession().Principal
+
+	input, err := r.getJobInput(data)
+	if err != nil {
+		return "", err
+	}
+
+	repo, err := r.repoStore.Find(ctx, input.RepoID)
+	if err != nil {
+		return "", fmt.Errorf("failed to find repo by id: %w", err)
+	}
+
+	if !repo.Importing {
+		return "", fmt.Errorf("repository %s is not being imported", repo.UID)
+	}
+
+	log := log.Ctx(ctx).With().
+		Int64("repo.id", repo.ID).
+		Str("repo.path", repo.Path).
+		Logger()
+
+	client, err := getScmClientWithTransport(input.Provider, false)
+	if err != nil {
+		return "", fmt.Errorf("failed to create scm client: %w", err)
+	}
+
+	var result MetadataResult
+
+	reportProgress := func(done, total int) {
+		resultJSON, _ := json.Marshal(result)
+		if errReport := fn(done*job.ProgressMax/total, string(resultJSON)); errReport != nil {
+			log.Warn().Err(errReport).Msg("failed to report repository metadata import progress")
+		}
+	}
+
+	var pullReqs []*scm.PullRequest
+	if input.Metadata.PullRequests {
+		pullReqs, err = listPullRequests(ctx, client, input.Provider, input.ProviderRepo)
+		if err != nil {
+			return "", fmt.Errorf("failed to list pull requests: %w", err)
+		}
+
+		log.Info().Msgf("importing %d pull requests", len(pullReqs))
+	}
+
+	// the last step is the import of the webhooks and the completion of the import.
+	total := len(pullReqs) + 1
+
+	var maxNumber int64
+	for i, scmPR := range pullReqs {
+		if number := int64(scmPR.Number); number > maxNumber {
+			maxNumber = number
+		}
+
+		pr, err := r.importPullRequest(ctx, &systemPrincipal, client, input, repo, scmPR)
+		if err != nil {
+			return "", fmt.Errorf("failed to import pull request #%d: %w", scmPR.Number, err)
+		}
+
+		if pr == nil {
+			result.PullRequestsSkipped++
+		} else {
+			result.PullRequests++
+			result.Comments += pr.CommentCount
+		}
+
+		reportProgress(i+1, total)
+	}
+
+	if input.Metadata.Webhooks {
+		result.Webhooks, err = r.importWebhooks(ctx, client, input, repo)
+		if err != nil {
+			return "", fmt.Errorf("failed to import webhooks: %w", err)
+		}
+	}
+
+	repo, err = r.repoStore.UpdateOptLock(ctx, repo, func(repo *types.Repository) error {
+		if !repo.Importing {
+			return errors.New("repository has already finished importing")
+		}
+
+		// new pull requests must not reuse the numbers of the imported ones.
+		if maxNumber > repo.PullReqSeq {
+			repo.PullReqSeq = maxNumber
+		}
+		repo.Importing = false
+
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to update repository after metadata import: %w", err)
+	}
+
+	r.completeImport(ctx, &systemPrincipal, repo, input.Pipelines)
+
+	log.Info().
+		Int("pull_requests", result.PullRequests).
+		Int("pull_requests_skipped", result.PullRequestsSkipped).
+		Int("comments", result.Comments).
+		Int("webhooks", result.Webhooks).
+		Msg("completed repository metadata import")
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal job result: %w", err)
+	}
+
+	return string(resultJSON), nil
+}
+
+func listPullRequests(
+	ctx context.Context,
+	client *scm.Client,
+	provider Provider,
+	repoSlug string,
+) ([]*scm.PullRequest, error) {
+	opts := scm.PullRequestListOptions{
+		Size:   metadataPageSize,
+		Open:   true,
+		Closed: true,
+	}
+
+	var pullReqs []*scm.PullRequest
+	for {
+		page, resp, err := client.PullRequests.List(ctx, repoSlug, opts)
+		if err = convertSCMError(provider, repoSlug, resp, err); err != nil {
+			return nil, err
+		}
+
+		pullReqs = append(pullReqs, page...)
+
+		if resp.Page.Next == 0 || len(page) == 0 {
+			break
+		}
+
+		opts.Page = resp.Page.Next
+	}
+
+	sort.Slice(pullReqs, func(i, j int) bool {
+		return pullReqs[i].Number < pullReqs[j].Number
+	})
+
+	return pullReqs, nil
+}
+
+func listPullRequestComments(
+	ctx context.Context,
+	client *scm.Client,
+	provider Provider,
+	repoSlug string,
+	number int,
+) ([]*scm.Comment, error) {
+	opts := scm.ListOptions{
+		Size: metadataPageSize,
+	}
+
+	var comments []*scm.Comment
+	for {
+		page, resp, err := client.PullRequests.ListComments(ctx, repoSlug, number, opts)
+		if errors.Is(err, scm.ErrNotSupported) {
+			return nil, nil
+		}
+		if err = convertSCMError(provider, repoSlug, resp, err); err != nil {
+			return nil, err
+		}
+
+		comments = append(comments, page...)
+
+		if resp.Page.Next == 0 || len(page) == 0 {
+			break
+		}
+
+		opts.Page = resp.Page.Next
+	}
+
+	sort.SliceStable(comments, func(i, j int) bool {
+		return comments[i].Created.Before(comments[j].Created)
+	})
+
+	return comments, nil
+}
+
+// importPullRequest imports the pull request along with its comments. It returns nil if the pull request
+// can't be imported, because its commits aren't available in the repository.
+// Pull requests that have already been imported are not imported again.
+//
+//nolint:gocognit // refactor if needed.
+func (r *Repository) importPullRequest(
+	ctx context.Context,
+	principal *types.Principal,
+	client *scm.Client,
+	input Input,
+	repo *types.Repository,
+	scmPR *scm.PullRequest,
+) (*types.PullReq, error) {
+	number := int64(scmPR.Number)
+
+	pr, err := r.pullReqStore.FindByNumber(ctx, repo.ID, number)
+	if err == nil {
+		return pr, nil
+	}
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	log := log.Ctx(ctx).With().
+		Int64("repo.id", repo.ID).
+		Int64("pullreq.number", number).
+		Logger()
+
+	readParams := git.CreateReadParams(repo)
+
+	state := enum.PullReqStateOpen
+	switch {
+	case scmPR.Merged:
+		state = enum.PullReqStateMerged
+	case scmPR.Closed:
+		state = enum.PullReqStateClosed
+	}
+
+	var note string
+
+	sourceSHA := scmPR.Sha
+	if sourceSHA == "" {
+		sourceSHA = scmPR.Head.Sha
+	}
+
+	if state == enum.PullReqStateOpen {
+		// an open pull request needs the source branch, but the branches of forks aren't imported.
+		isFork := scmPR.Fork != "" && !strings.EqualFold(scmPR.Fork, input.ProviderRepo)
+
+		var branch *git.GetBranchOutput
+		if !isFork {
+			branch, err = r.git.GetBranch(ctx, &git.GetBranchParams{
+				ReadParams: readParams,
+				BranchName: scmPR.Source,
+			})
+			if err != nil && !gitnesserrors.IsNotFound(err) {
+				return nil, fmt.Errorf("failed to get source branch: %w", err)
+			}
+		}
+
+		if isFork || branch == nil {
+			state = enum.PullReqStateClosed
+			note = fmt.Sprintf("The source branch `%s` isn't available in this repository, "+
+				"so the pull request has been imported as closed.", scmPR.Source)
+		} else {
+			sourceSHA = branch.Branch.SHA
+		}
+	}
+
+	if sourceSHA == "" || !r.commitExists(ctx, readParams, sourceSHA) {
+		log.Warn().Msg("skipping import of pull request because its source commit isn't available")
+		return nil, nil
+	}
+
+	// the merge base is calculated against the base commit known to the provider if possible,
+	// because the target branch already contains the changes of merged pull requests.
+	mergeBaseRef := scmPR.Target
+	if scmPR.Base.Sha != "" && r.commitExists(ctx, readParams, scmPR.Base.Sha) {
+		mergeBaseRef = scmPR.Base.Sha
+	}
+
+	mergeBase, err := r.git.MergeBase(ctx, git.MergeBaseParams{
+		ReadParams: readParams,
+		Ref1:       sourceSHA,
+		Ref2:       mergeBaseRef,
+	})
+	if err != nil {
+		log.Warn().Err(err).Msg("skipping import of pull request because its merge base can't be found")
+		return nil, nil
+	}
+
+	comments, err := listPullRequestComments(ctx, client, input.Provider, input.ProviderRepo, scmPR.Number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull request comments: %w", err)
+	}
+
+	writeParams, err := r.createRPCWriteParams(ctx, principal, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.git.UpdateRef(ctx, git.UpdateRefParams{
+		WriteParams: writeParams,
+		Type:        gitenum.RefTypePullReqHead,
+		Name:        strconv.FormatInt(number, 10),
+		NewValue:    sourceSHA,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update pull request head reference: %w", err)
+	}
+
+	pr = importedPullReq(repo, input.Provider.Type, scmPR, state, sourceSHA, mergeBase.MergeBaseSHA, note)
+
+	activities := make([]*types.PullReqActivity, len(comments))
+	for i, comment := range comments {
+		activities[i] = importedComment(repo, input.Provider.Type, comment, int64(i+1))
+	}
+
+	pr.ActivitySeq = int64(len(activities))
+	pr.CommentCount = len(activities)
+
+	err = r.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := r.pullReqStore.Create(ctx, pr); err != nil {
+			return fmt.Errorf("failed to create pull request: %w", err)
+		}
+
+		for _, act := range activities {
+			act.PullReqID = pr.ID
+			if err := r.activityStore.Create(ctx, act); err != nil {
+				return fmt.Errorf("failed to create pull request comment: %w", err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pr, nil
+}
+
+func (r *Repository) commitExists(ctx context.Context, readParams git.ReadParams, sha string) bool {
+	_, err := r.git.GetCommit(ctx, &git.GetCommitParams{
+		ReadParams: readParams,
+		SHA:        sha,
+	})
+	return err == nil
+}
+
+// importedPullReq creates the pull request object from the provider's pull request.
+// The pull request is attributed to the principal that started the import.
+func importedPullReq(
+	repo *types.Repository,
+	providerType ProviderType,
+	scmPR *scm.PullRequest,
+	state enum.PullReqState,
+	sourceSHA string,
+	mergeBaseSHA string,
+	note string,
+) *types.PullReq {
+	created := unixMilliOrNow(scmPR.Created)
+	updated := unixMilliOrNow(scmPR.Updated)
+
+	pr := &types.PullReq{
+		Number:           int64(scmPR.Number),
+		CreatedBy:        repo.CreatedBy,
+		Created:          created,
+		Updated:          updated,
+		Edited:           updated,
+		State:            state,
+		Title:            scmPR.Title,
+		Description:      importedPullReqDescription(providerType, scmPR, note),
+		SourceRepoID:     repo.ID,
+		SourceBranch:     scmPR.Source,
+		SourceSHA:        sourceSHA,
+		TargetRepoID:     repo.ID,
+		TargetBranch:     scmPR.Target,
+		MergeCheckStatus: enum.MergeCheckStatusUnchecked,
+		MergeBaseSHA:     mergeBaseSHA,
+	}
+
+	if state == enum.PullReqStateMerged {
+		// the providers don't report the merge time, the last update is the closest approximation.
+		pr.Merged = &updated
+		pr.MergedBy = &repo.CreatedBy
+		if scmPR.Merge != "" {
+			mergeSHA := scmPR.Merge
+			pr.MergeSHA = &mergeSHA
+		}
+	}
+
+	return pr
+}
+
+// importedComment creates the pull request comment from the provider's comment.
+// Imported comments are marked as resolved, because they must not block merging.
+func importedComment(
+	repo *types.Repository,
+	providerType ProviderType,
+	comment *scm.Comment,
+	order int64,
+) *types.PullReqActivity {
+	created := unixMilliOrNow(comment.Created)
+	updated := unixMilliOrNow(comment.Updated)
+
+	act := &types.PullReqActivity{
+		CreatedBy:  repo.CreatedBy,
+		Created:    created,
+		Updated:    updated,
+		Edited:     updated,
+		RepoID:     repo.ID,
+		Order:      order,
+		Type:       enum.PullReqActivityTypeComment,
+		Kind:       enum.PullReqActivityKindComment,
+		Text:       importedCommentText(providerType, comment),
+		ResolvedBy: &repo.CreatedBy,
+		Resolved:   &created,
+	}
+
+	_ = act.SetPayload(types.PullRequestActivityPayloadComment{})
+
+	return act
+}
+
+func importedPullReqDescription(providerType ProviderType, scmPR *scm.PullRequest, note string) string {
+	sb := strings.Builder{}
+
+	source := fmt.Sprintf("#%d", scmPR.Number)
+	if scmPR.Link != "" {
+		source = fmt.Sprintf("[#%d](%s)", scmPR.Number, scmPR.Link)
+	}
+
+	fmt.Fprintf(&sb, "_Imported from %s pull request %s", providerName(providerType), source)
+	if author := userName(scmPR.Author); author != "" {
+		fmt.Fprintf(&sb, ", opened by @%s", author)
+	}
+	sb.WriteString("._\n")
+
+	if len(scmPR.Labels) > 0 {
+		labels := make([]string, len(scmPR.Labels))
+		for i, label := range scmPR.Labels {
+			labels[i] = "`" + label.Name + "`"
+		}
+		fmt.Fprintf(&sb, "\n_Labels: %s_\n", strings.Join(labels, ", "))
+	}
+
+	if note != "" {
+		fmt.Fprintf(&sb, "\n_%s_\n", note)
+	}
+
+	if body := strings.TrimSpace(scmPR.Body); body != "" {
+		sb.WriteString("\n")
+		sb.WriteString(body)
+	}
+
+	return strings.TrimSpace(sb.String())
+}
+
+func importedCommentText(providerType ProviderType, comment *scm.Comment) string {
+	header := fmt.Sprintf("_Imported from %s", providerName(providerType))
+	if author := userName(comment.Author); author != "" {
+		header += fmt.Sprintf(", posted by @%s", author)
+	}
+	header += "._"
+
+	return header + "\n\n" + strings.TrimSpace(comment.Body)
+}
+
+// importWebhooks imports the webhooks of the provider's repository and returns the number of imported webhooks.
+// The webhooks are imported disabled. Webhooks that can't be read (missing admin permission) are skipped.
+func (r *Repository) importWebhooks(
+	ctx context.Context,
+	client *scm.Client,
+	input Input,
+	repo *types.Repository,
+) (int, error) {
+	log := log.Ctx(ctx).With().
+		Int64("repo.id", repo.ID).
+		Logger()
+
+	opts := scm.ListOptions{
+		Size: metadataPageSize,
+	}
+
+	var hooks []*scm.Hook
+	for {
+		page, resp, err := client.Repositories.ListHooks(ctx, input.ProviderRepo, opts)
+		if err != nil && resp != nil && (resp.Status == http.StatusUnauthorized ||
+			resp.Status == http.StatusForbidden || resp.Status == http.StatusNotFound) {
+			log.Warn().Err(err).Msg("skipping import of webhooks because they can't be read")
+			return 0, nil
+		}
+		if err = convertSCMError(input.Provider, input.ProviderRepo, resp, err); err != nil {
+			return 0, err
+		}
+
+		hooks = append(hooks, page...)
+
+		if resp.Page.Next == 0 || len(page) == 0 {
+			break
+		}
+
+		opts.Page = resp.Page.Next
+	}
+
+	count := 0
+	for _, scmHook := range hooks {
+		hook := importedWebhook(repo, input.Provider.Type, scmHook)
+		if hook == nil {
+			log.Warn().Str("webhook.id", scmHook.ID).
+				Msg("skipping import of webhook because none of its events is supported")
+			continue
+		}
+
+		_, err := r.webhookStore.FindByUID(ctx, enum.WebhookParentRepo, repo.ID, hook.UID)
+		if err == nil {
+			count++
+			continue
+		}
+		if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return 0, fmt.Errorf("failed to find webhook: %w", err)
+		}
+
+		encryptedSecret, err := r.encrypter.Encrypt("")
+		if err != nil {
+			return 0, fmt.Errorf("failed to encrypt webhook secret: %w", err)
+		}
+
+		hook.Secret = string(encryptedSecret)
+
+		if err = r.webhookStore.Create(ctx, hook); err != nil {
+			return 0, fmt.Errorf("failed to create webhook: %w", err)
+		}
+
+		count++
+	}
+
+	return count, nil
+}
+
+// importedWebhook creates the disabled webhook from the provider's webhook.
+// It returns nil if none of the webhook's events can be mapped to a webhook trigger.
+func importedWebhook(repo *types.Repository, providerType ProviderType, scmHook *scm.Hook) *types.Webhook {
+	triggers := webhookTriggers(providerType, scmHook.Events)
+	if len(triggers) == 0 {
+		return nil
+	}
+
+	uid := importedWebhookUIDPrefix + strings.Trim(scmHook.ID, "{}")
+	now := time.Now().UnixMilli()
+
+	return &types.Webhook{
+		ParentID:    repo.ID,
+		ParentType:  enum.WebhookParentRepo,
+		CreatedBy:   repo.CreatedBy,
+		Created:     now,
+		Updated:     now,
+		UID:         uid,
+		DisplayName: uid,
+		Description: fmt.Sprintf("Imported from %s webhook %s (events: %s).",
+			providerName(providerType), scmHook.ID, strings.Join(scmHook.Events, ", ")),
+		URL:      scmHook.Target,
+		Enabled:  false,
+		Insecure: scmHook.SkipVerify,
+		Triggers: triggers,
+	}
+}
+
+var (
+	branchTriggers = []enum.WebhookTrigger{
+		enum.WebhookTriggerBranchCreated,
+		enum.WebhookTriggerBranchUpdated,
+		enum.WebhookTriggerBranchDeleted,
+	}
+	tagTriggers = []enum.WebhookTrigger{
+		enum.WebhookTriggerTagCreated,
+		enum.WebhookTriggerTagUpdated,
+		enum.WebhookTriggerTagDeleted,
+	}
+	pullReqTriggers = []enum.WebhookTrigger{
+		enum.WebhookTriggerPullReqCreated,
+		enum.WebhookTriggerPullReqReopened,
+		enum.WebhookTriggerPullReqBranchUpdated,
+		enum.WebhookTriggerPullReqClosed,
+		enum.WebhookTriggerPullReqMerged,
+	}
+)
+
+// webhookEvents maps the webhook events of the providers (as reported by go-scm) to webhook triggers.
+var webhookEvents = map[ProviderType]map[string][]enum.WebhookTrigger{
+	ProviderTypeGitHub: {
+		"push":                        append(append([]enum.WebhookTrigger{}, branchTriggers...), tagTriggers...),
+		"create":                      {enum.WebhookTriggerBranchCreated, enum.WebhookTriggerTagCreated},
+		"delete":                      {enum.WebhookTriggerBranchDeleted, enum.WebhookTriggerTagDeleted},
+		"pull_request":                pullReqTriggers,
+		"issue_comment":               {enum.WebhookTriggerPullReqCommentCreated},
+		"pull_request_review_comment": {enum.WebhookTriggerPullReqCommentCreated},
+		"commit_comment":              {enum.WebhookTriggerCommitCommentCreated},
+	},
+	ProviderTypeGitLab: {
+		"push":    branchTriggers,
+		"tag":     tagTriggers,
+		"merge":   pullReqTriggers,
+		"comment": {enum.WebhookTriggerPullReqCommentCreated, enum.WebhookTriggerCommitCommentCreated},
+	},
+	ProviderTypeBitbucket: {
+		"repo:push":                   append(append([]enum.WebhookTrigger{}, branchTriggers...), tagTriggers...),
+		"pullrequest:created":         {enum.WebhookTriggerPullReqCreated},
+		"pullrequest:updated":         {enum.WebhookTriggerPullReqBranchUpdated},
+		"pullrequest:fulfilled":       {enum.WebhookTriggerPullReqMerged},
+		"pullrequest:rejected":        {enum.WebhookTriggerPullReqClosed},
+		"pullrequest:comment_created": {enum.WebhookTriggerPullReqCommentCreated},
+		"repo:commit_comment_created": {enum.WebhookTriggerCommitCommentCreated},
+	},
+}
+
+func webhookTriggers(providerType ProviderType, events []string) []enum.WebhookTrigger {
+	set := make(map[enum.WebhookTrigger]struct{})
+	for _, event := range events {
+		if providerType == ProviderTypeGitHub && event == "*" {
+			all, _ := enum.GetAllWebhookTriggers()
+			return all
+		}
+
+		for _, trigger := range webhookEvents[providerType][event] {
+			set[trigger] = struct{}{}
+		}
+	}
+
+	triggers := make([]enum.WebhookTrigger, 0, len(set))
+	for trigger := range set {
+		triggers = append(triggers, trigger)
+	}
+
+	sort.Slice(triggers, func(i, j int) bool {
+		return triggers[i] < triggers[j]
+	})
+
+	return triggers
+}
+
+func providerName(providerType ProviderType) string {
+	switch providerType {
+	case ProviderTypeGitHub:
+		return "GitHub"
+	case ProviderTypeGitLab:
+		return "GitLab"
+	case ProviderTypeBitbucket:
+		return "Bitbucket"
+	case ProviderTypeStash, ProviderTypeGitea, ProviderTypeGogs:
+	}
+
+	return string(providerType)
+}
+
+func userName(user scm.User) string {
+	if user.Login != "" {
+		return user.Login
+	}
+	return user.Name
+}
+
+func unixMilliOrNow(t time.Time) int64 {
+	if t.IsZero() {
+		return time.Now().UnixMilli()
+	}
+	return t.UnixMilli()
+}
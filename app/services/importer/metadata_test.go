@@ -0,0 +1,180 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package importer
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/drone/go-scm/scm"
+)
+
+func TestWebhookTriggers(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider ProviderType
+		events   []string
+		exp      []enum.WebhookTrigger
+	}{
+		{
+			name:     "github-pull-request-and-comments",
+			provider: ProviderTypeGitHub,
+			events:   []string{"pull_request", "issue_comment", "pull_request_review_comment"},
+			exp: []enum.WebhookTrigger{
+				enum.WebhookTriggerPullReqBranchUpdated,
+				enum.WebhookTriggerPullReqClosed,
+				enum.WebhookTriggerPullReqCommentCreated,
+				enum.WebhookTriggerPullReqCreated,
+				enum.WebhookTriggerPullReqMerged,
+				enum.WebhookTriggerPullReqReopened,
+			},
+		},
+		{
+			name:     "gitlab-tag",
+			provider: ProviderTypeGitLab,
+			events:   []string{"tag", "issues"},
+			exp: []enum.WebhookTrigger{
+				enum.WebhookTriggerTagCreated,
+				enum.WebhookTriggerTagDeleted,
+				enum.WebhookTriggerTagUpdated,
+			},
+		},
+		{
+			name:     "bitbucket-merged",
+			provider: ProviderTypeBitbucket,
+			events:   []string{"pullrequest:fulfilled", "issue:created"},
+			exp:      []enum.WebhookTrigger{enum.WebhookTriggerPullReqMerged},
+		},
+		{
+			name:     "unsupported-events",
+			provider: ProviderTypeGitHub,
+			events:   []string{"issues", "release"},
+			exp:      []enum.WebhookTrigger{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := webhookTriggers(test.provider, test.events)
+			if !reflect.DeepEqual(got, test.exp) {
+				t.Errorf("want=%v got=%v", test.exp, got)
+			}
+		})
+	}
+
+	all, _ := enum.GetAllWebhookTriggers()
+	if got := webhookTriggers(ProviderTypeGitHub, []string{"*"}); len(got) != len(all) {
+		t.Errorf("wildcard: want %d triggers, got %d", len(all), len(got))
+	}
+}
+
+func TestImportedWebhook(t *testing.T) {
+	repo := &types.Repository{ID: 7, CreatedBy: 3}
+
+	hook := importedWebhook(repo, ProviderTypeBitbucket, &scm.Hook{
+		ID:     "{5a1c2d3e-0000-4000-8000-000000000001}",
+		Target: "https://ci.example.com/hook",
+		Events: []string{"repo:push"},
+	})
+	if hook == nil {
+		t.Fatal("expected a webhook")
+	}
+
+	if hook.UID != "imported-5a1c2d3e-0000-4000-8000-000000000001" {
+		t.Errorf("unexpected uid: %s", hook.UID)
+	}
+	if hook.Enabled {
+		t.Error("imported webhooks must be disabled")
+	}
+	if hook.ParentID != repo.ID || hook.ParentType != enum.WebhookParentRepo || hook.CreatedBy != repo.CreatedBy {
+		t.Errorf("unexpected webhook owner: %+v", hook)
+	}
+	if len(hook.Triggers) != 6 {
+		t.Errorf("expected branch and tag triggers, got %v", hook.Triggers)
+	}
+
+	if hook := importedWebhook(repo, ProviderTypeGitHub, &scm.Hook{ID: "1", Events: []string{"issues"}}); hook != nil {
+		t.Errorf("expected no webhook for unsupported events, got %+v", hook)
+	}
+}
+
+func TestImportedPullReq(t *testing.T) {
+	repo := &types.Repository{ID: 7, CreatedBy: 3}
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	updated := created.Add(time.Hour)
+
+	scmPR := &scm.PullRequest{
+		Number:  12,
+		Title:   "Add feature",
+		Body:    "Feature description.",
+		Source:  "feature",
+		Target:  "main",
+		Link:    "https://github.com/org/repo/pull/12",
+		Merged:  true,
+		Merge:   "1111111111111111111111111111111111111111",
+		Author:  scm.User{Login: "octocat"},
+		Created: created,
+		Updated: updated,
+		Labels:  []scm.Label{{Name: "bug"}, {Name: "ui"}},
+	}
+
+	pr := importedPullReq(repo, ProviderTypeGitHub, scmPR, enum.PullReqStateMerged,
+		"2222222222222222222222222222222222222222", "3333333333333333333333333333333333333333", "")
+
+	if pr.Number != 12 || pr.State != enum.PullReqStateMerged || pr.CreatedBy != repo.CreatedBy {
+		t.Errorf("unexpected pull request: %+v", pr)
+	}
+	if pr.Created != created.UnixMilli() || pr.Edited != updated.UnixMilli() {
+		t.Errorf("unexpected timestamps: created=%d edited=%d", pr.Created, pr.Edited)
+	}
+	if pr.Merged == nil || *pr.Merged != updated.UnixMilli() {
+		t.Errorf("unexpected merge time: %v", pr.Merged)
+	}
+	if pr.MergeSHA == nil || *pr.MergeSHA != scmPR.Merge {
+		t.Errorf("unexpected merge sha: %v", pr.MergeSHA)
+	}
+
+	const expDescription = "_Imported from GitHub pull request [#12](https://github.com/org/repo/pull/12)" +
+		", opened by @octocat._\n\n_Labels: `bug`, `ui`_\n\nFeature description."
+	if pr.Description != expDescription {
+		t.Errorf("unexpected description:\n%s", pr.Description)
+	}
+}
+
+func TestImportedComment(t *testing.T) {
+	repo := &types.Repository{ID: 7, CreatedBy: 3}
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	act := importedComment(repo, ProviderTypeGitLab, &scm.Comment{
+		Body:    " Looks good. ",
+		Author:  scm.User{Name: "Jane"},
+		Created: created,
+		Updated: created,
+	}, 2)
+
+	if act.Order != 2 || act.SubOrder != 0 || act.RepoID != repo.ID {
+		t.Errorf("unexpected comment: %+v", act)
+	}
+	if act.Text != "_Imported from GitLab, posted by @Jane._\n\nLooks good." {
+		t.Errorf("unexpected text: %q", act.Text)
+	}
+	if act.IsBlocking() {
+		t.Error("imported comments must not block merging")
+	}
+}
@@ -51,6 +51,12 @@ var (
 	ErrNotFound = errors.New("import not found")
 )
 
+// gitRefSpecs are the refspecs of the git references that are imported: all branches and tags.
+var gitRefSpecs = []string{"refs/heads/*:refs/heads/*", "refs/tags/*:refs/tags/*"}
+
+// bundlePullReqRefSpec is the refspec of the pull request references of bundles created by this application.
+const bundlePullReqRefSpec = "refs/pullreq/*/head:refs/pullreq/*/head"
+
 type Repository struct {
 	defaultBranch string
 	urlProvider   gitnessurl.Provider
@@ -59,6 +65,9 @@ type Repository struct {
 	repoStore     store.RepoStore
 	pipelineStore store.PipelineStore
 	triggerStore  store.TriggerStore
+	pullReqStore  store.PullReqStore
+	activityStore store.PullReqActivityStore
+	webhookStore  store.WebhookStore
 	encrypter     encrypt.Encrypter
 	scheduler     *job.Scheduler
 	sseStreamer   sse.Streamer
@@ -85,6 +94,11 @@ type Input struct {
 	GitPass   string         `json:"git_pass"`
 	CloneURL  string         `json:"clone_url"`
 	Pipelines PipelineOption `json:"pipelines"`
+
+	// Provider, ProviderRepo and Metadata are used only if the repository metadata should be imported.
+	Provider     Provider        `json:"provider"`
+	ProviderRepo string          `json:"provider_repo"`
+	Metadata     MetadataOptions `json:"metadata"`
 }
 
 const jobType = "repository_import"
@@ -100,14 +114,24 @@ func (r *Repository) Run(
 	repo *types.Repository,
 	cloneURL string,
 	pipelines PipelineOption,
+	providerRepo string,
+	metadata MetadataOptions,
 ) error {
-	jobDef, err := r.getJobDef(JobIDFromRepoID(repo.ID), Input{
+	input := Input{
 		RepoID:    repo.ID,
 		GitUser:   provider.Username,
 		GitPass:   provider.Password,
 		CloneURL:  cloneURL,
 		Pipelines: pipelines,
-	})
+	}
+
+	if !metadata.IsEmpty() {
+		input.Provider = provider
+		input.ProviderRepo = providerRepo
+		input.Metadata = metadata
+	}
+
+	jobDef, err := r.getJobDef(JobIDFromRepoID(repo.ID), repo.ParentID, input)
 	if err != nil {
 		return err
 	}
@@ -118,6 +142,7 @@ func (r *Repository) Run(
 // RunMany starts background jobs that import the provided repositories from the provided clone URLs.
 func (r *Repository) RunMany(ctx context.Context,
 	groupID string,
+	spaceID int64,
 	provider Provider,
 	repoIDs []int64,
 	cloneURLs []string,
@@ -135,7 +160,7 @@ func (r *Repository) RunMany(ctx context.Context,
 		repoID := repoIDs[k]
 		cloneURL := cloneURLs[k]
 
-		jobDef, err := r.getJobDef(JobIDFromRepoID(repoID), Input{
+		jobDef, err := r.getJobDef(JobIDFromRepoID(repoID), spaceID, Input{
 			RepoID:    repoID,
 			GitUser:   provider.Username,
 			GitPass:   provider.Password,
@@ -157,17 +182,10 @@ func (r *Repository) RunMany(ctx context.Context,
 	return nil
 }
 
-func (r *Repository) getJobDef(jobUID string, input Input) (job.Definition, error) {
-	data, err := json.Marshal(input)
+func (r *Repository) getJobDef(jobUID string, spaceID int64, input Input) (job.Definition, error) {
+	data, err := r.encodeJobInput(input)
 	if err != nil {
-		return job.Definition{}, fmt.Errorf("failed to marshal job input json: %w", err)
-	}
-
-	strData := strings.TrimSpace(string(data))
-
-	encryptedData, err := r.encrypter.Encrypt(strData)
-	if err != nil {
-		return job.Definition{}, fmt.Errorf("failed to encrypt job input: %w", err)
+		return job.Definition{}, err
 	}
 
 	return job.Definition{
@@ -175,29 +193,55 @@ func (r *Repository) getJobDef(jobUID string, input Input) (job.Definition, erro
 		Type:       jobType,
 		MaxRetries: importJobMaxRetries,
 		Timeout:    importJobMaxDuration,
-		Data:       base64.StdEncoding.EncodeToString(encryptedData),
+		Data:       data,
+		SpaceID:    spaceID,
 	}, nil
 }
 
 func (r *Repository) getJobInput(data string) (Input, error) {
+	var input Input
+
+	if err := r.decodeJobInput(data, &input); err != nil {
+		return Input{}, err
+	}
+
+	return input, nil
+}
+
+// encodeJobInput marshals the job input and encrypts it, because it contains the provider credentials.
+func (r *Repository) encodeJobInput(input any) (string, error) {
+	data, err := json.Marshal(input)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal job input json: %w", err)
+	}
+
+	strData := strings.TrimSpace(string(data))
+
+	encryptedData, err := r.encrypter.Encrypt(strData)
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt job input: %w", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(encryptedData), nil
+}
+
+func (r *Repository) decodeJobInput(data string, input any) error {
 	encrypted, err := base64.StdEncoding.DecodeString(data)
 	if err != nil {
-		return Input{}, fmt.Errorf("failed to base64 decode job input: %w", err)
+		return fmt.Errorf("failed to base64 decode job input: %w", err)
 	}
 
 	decrypted, err := r.encrypter.Decrypt(encrypted)
 	if err != nil {
-		return Input{}, fmt.Errorf("failed to decrypt job input: %w", err)
+		return fmt.Errorf("failed to decrypt job input: %w", err)
 	}
 
-	var input Input
-
-	err = json.NewDecoder(strings.NewReader(decrypted)).Decode(&input)
+	err = json.NewDecoder(strings.NewReader(decrypted)).Decode(input)
 	if err != nil {
-		return Input{}, fmt.Errorf("failed to unmarshal job input json: %w", err)
+		return fmt.Errorf("failed to unmarshal job input json: %w", err)
 	}
 
-	return input, nil
+	return nil
 }
 
 // Handle is repository import background job handler.
@@ -239,7 +283,7 @@ func (r *Repository) Handle(ctx context.Context, data string, _ job.ProgressRepo
 
 	log.Info().Msg("create git repository")
 
-	gitUID, err := r.createGitRepository(ctx, &systemPrincipal, repo.ID)
+	gitUID, err := r.createGitRepository(ctx, &systemPrincipal, repo.ID, r.defaultBranch)
 	if err != nil {
 		return "", fmt.Errorf("failed to create empty git repository: %w", err)
 	}
@@ -260,7 +304,13 @@ func (r *Repository) Handle(ctx context.Context, data string, _ job.ProgressRepo
 
 		log.Info().Msg("sync repository")
 
-		defaultBranch, err := r.syncGitRepository(ctx, &systemPrincipal, repo, cloneURLWithAuth)
+		refSpecs := append([]string{}, gitRefSpecs...)
+		if input.Metadata.PullRequests {
+			// the pull request heads are needed for the pull requests that get imported later.
+			refSpecs = append(refSpecs, pullReqRefSpecs(input.Provider.Type)...)
+		}
+
+		defaultBranch, err := r.syncGitRepository(ctx, &systemPrincipal, repo, cloneURLWithAuth, refSpecs)
 		if err != nil {
 			return fmt.Errorf("failed to sync git repository from '%s': %w", input.CloneURL, err)
 		}
@@ -280,7 +330,8 @@ func (r *Repository) Handle(ctx context.Context, data string, _ job.ProgressRepo
 
 			repo.GitUID = gitUID
 			repo.DefaultBranch = defaultBranch
-			repo.Importing = false
+			// the repository stays in the importing state until its metadata is imported.
+			repo.Importing = !input.Metadata.IsEmpty()
 
 			return nil
 		})
@@ -288,20 +339,135 @@ func (r *Repository) Handle(ctx context.Context, data string, _ job.ProgressRepo
 			return fmt.Errorf("failed to update repository after import: %w", err)
 		}
 
-		if input.Pipelines != PipelineOptionConvert {
-			return nil // assumes the value is enum.PipelineOptionIgnore
+		if !input.Metadata.IsEmpty() {
+			log.Info().Msg("schedule import of repository metadata")
+
+			err = r.runMetadataImport(ctx, repo, input)
+			if err != nil {
+				return fmt.Errorf("failed to start repository metadata import job: %w", err)
+			}
 		}
 
+		return nil
+	}()
+	if err != nil {
+		log.Error().Err(err).Msg("failed repository import - cleanup git repository")
+
+		repo.GitUID = gitUID // make sure to delete the correct directory
+
+		if errDel := r.deleteGitRepository(context.Background(), &systemPrincipal, repo); errDel != nil {
+			log.Warn().Err(errDel).
+				Msg("failed to delete git repository after failed import")
+		}
+
+		return "", fmt.Errorf("failed to import repository: %w", err)
+	}
+
+	if !input.Metadata.IsEmpty() {
+		log.Info().Msg("completed git import, the repository metadata will be imported by a separate job")
+		return "", nil
+	}
+
+	r.completeImport(ctx, &systemPrincipal, repo, input.Pipelines)
+
+	log.Info().Msg("completed repository import")
+
+	return "", nil
+}
+
+// completeImport converts the pipelines, notifies the clients and indexes the repository
+// once the repository is no longer marked as being imported.
+func (r *Repository) completeImport(ctx context.Context,
+	principal *types.Principal,
+	repo *types.Repository,
+	pipelines PipelineOption,
+) {
+	log := log.Ctx(ctx).With().
+		Int64("repo.id", repo.ID).
+		Str("repo.path", repo.Path).
+		Logger()
+
+	if pipelines == PipelineOptionConvert {
 		const convertPipelinesCommitMessage = "autoconvert pipeline"
-		err = r.processPipelines(ctx, &systemPrincipal, repo, convertPipelinesCommitMessage)
+		err := r.processPipelines(ctx, principal, repo, convertPipelinesCommitMessage)
 		if err != nil {
 			log.Warn().Err(err).Msg("failed to convert pipelines")
 		}
+	}
+
+	err := r.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypeRepositoryImportCompleted, repo)
+	if err != nil {
+		log.Warn().Err(err).Msg("failed to publish import completion SSE")
+	}
+
+	err = r.indexer.Index(ctx, repo)
+	if err != nil {
+		log.Warn().Err(err).Msg("failed to index repository")
+	}
+}
+
+// ImportBundle populates the git repository of a repository that is marked as importing
+// from a git bundle file. Unlike imports from a remote, it runs synchronously.
+// If withPullReqRefs is true, the pull request references are imported along with the branches and tags.
+func (r *Repository) ImportBundle(ctx context.Context,
+	repo *types.Repository,
+	bundlePath string,
+	defaultBranch string,
+	withPullReqRefs bool,
+) (*types.Repository, error) {
+	systemPrincipal := bootstrap.NewSystemServiceSession().Principal
+
+	if !repo.Importing {
+		return nil, fmt.Errorf("repository %s is not being imported", repo.UID)
+	}
+
+	if defaultBranch == "" {
+		defaultBranch = r.defaultBranch
+	}
+
+	log := log.Ctx(ctx).With().
+		Int64("repo.id", repo.ID).
+		Str("repo.path", repo.Path).
+		Logger()
+
+	// the bundle doesn't carry the HEAD of the source repository,
+	// so the default branch has to be set when the git repository is created.
+	gitUID, err := r.createGitRepository(ctx, &systemPrincipal, repo.ID, defaultBranch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create empty git repository: %w", err)
+	}
+
+	err = func() error {
+		repo, err = r.repoStore.UpdateOptLock(ctx, repo, func(repo *types.Repository) error {
+			repo.GitUID = gitUID
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update repository prior to the import: %w", err)
+		}
+
+		refSpecs := append([]string{}, gitRefSpecs...)
+		if withPullReqRefs {
+			refSpecs = append(refSpecs, bundlePullReqRefSpec)
+		}
+
+		if _, err = r.syncGitRepository(ctx, &systemPrincipal, repo, bundlePath, refSpecs); err != nil {
+			return fmt.Errorf("failed to sync git repository from bundle: %w", err)
+		}
+
+		repo, err = r.repoStore.UpdateOptLock(ctx, repo, func(repo *types.Repository) error {
+			repo.DefaultBranch = defaultBranch
+			repo.Importing = false
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update repository after import: %w", err)
+		}
 
 		return nil
 	}()
 	if err != nil {
-		log.Error().Err(err).Msg("failed repository import - cleanup git repository")
+		log.Error().Err(err).Msg("failed bundle import - cleanup git repository")
 
 		repo.GitUID = gitUID // make sure to delete the correct directory
 
@@ -310,7 +476,7 @@ func (r *Repository) Handle(ctx context.Context, data string, _ job.ProgressRepo
 				Msg("failed to delete git repository after failed import")
 		}
 
-		return "", fmt.Errorf("failed to import repository: %w", err)
+		return nil, fmt.Errorf("failed to import repository bundle: %w", err)
 	}
 
 	err = r.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypeRepositoryImportCompleted, repo)
@@ -323,13 +489,22 @@ func (r *Repository) Handle(ctx context.Context, data string, _ job.ProgressRepo
 		log.Warn().Err(err).Msg("failed to index repository")
 	}
 
-	log.Info().Msg("completed repository import")
+	log.Info().Msg("completed repository bundle import")
 
-	return "", nil
+	return repo, nil
 }
 
 func (r *Repository) GetProgress(ctx context.Context, repo *types.Repository) (job.Progress, error) {
-	progress, err := r.scheduler.GetJobProgress(ctx, JobIDFromRepoID(repo.ID))
+	// once the git data is imported, the progress is the one of the metadata import (if requested).
+	progress, err := r.scheduler.GetJobProgress(ctx, MetadataJobIDFromRepoID(repo.ID))
+	if err == nil {
+		return progress, nil
+	}
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return job.Progress{}, fmt.Errorf("failed to get metadata import job progress: %w", err)
+	}
+
+	progress, err = r.scheduler.GetJobProgress(ctx, JobIDFromRepoID(repo.ID))
 	if errors.Is(err, gitness_store.ErrResourceNotFound) {
 		if repo.Importing {
 			// if the job is not found but repo is marked as importing, return state=failed
@@ -356,12 +531,18 @@ func (r *Repository) Cancel(ctx context.Context, repo *types.Repository) error {
 		return fmt.Errorf("failed to cancel job: %w", err)
 	}
 
+	err = r.scheduler.CancelJob(ctx, MetadataJobIDFromRepoID(repo.ID))
+	if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return fmt.Errorf("failed to cancel metadata import job: %w", err)
+	}
+
 	return nil
 }
 
 func (r *Repository) createGitRepository(ctx context.Context,
 	principal *types.Principal,
 	repoID int64,
+	defaultBranch string,
 ) (string, error) {
 	now := time.Now()
 
@@ -376,7 +557,7 @@ func (r *Repository) createGitRepository(ctx context.Context,
 			Email: principal.Email,
 		},
 		EnvVars:       envVars,
-		DefaultBranch: r.defaultBranch,
+		DefaultBranch: defaultBranch,
 		Files:         nil,
 		Author: &git.Identity{
 			Name:  principal.DisplayName,
@@ -400,6 +581,7 @@ func (r *Repository) syncGitRepository(ctx context.Context,
 	principal *types.Principal,
 	repo *types.Repository,
 	sourceCloneURL string,
+	refSpecs []string,
 ) (string, error) {
 	writeParams, err := r.createRPCWriteParams(ctx, principal, repo)
 	if err != nil {
@@ -410,7 +592,7 @@ func (r *Repository) syncGitRepository(ctx context.Context,
 		WriteParams:       writeParams,
 		Source:            sourceCloneURL,
 		CreateIfNotExists: false,
-		RefSpecs:          []string{"refs/heads/*:refs/heads/*", "refs/tags/*:refs/tags/*"},
+		RefSpecs:          refSpecs,
 	})
 	if err != nil {
 		return "", fmt.Errorf("failed to sync repository: %w", err)
@@ -40,6 +40,9 @@ func ProvideRepoImporter(
 	repoStore store.RepoStore,
 	pipelineStore store.PipelineStore,
 	triggerStore store.TriggerStore,
+	pullReqStore store.PullReqStore,
+	activityStore store.PullReqActivityStore,
+	webhookStore store.WebhookStore,
 	encrypter encrypt.Encrypter,
 	scheduler *job.Scheduler,
 	executor *job.Executor,
@@ -54,6 +57,9 @@ func ProvideRepoImporter(
 		repoStore:     repoStore,
 		pipelineStore: pipelineStore,
 		triggerStore:  triggerStore,
+		pullReqStore:  pullReqStore,
+		activityStore: activityStore,
+		webhookStore:  webhookStore,
 		encrypter:     encrypter,
 		scheduler:     scheduler,
 		sseStreamer:   sseStreamer,
@@ -65,5 +71,10 @@ func ProvideRepoImporter(
 		return nil, err
 	}
 
+	err = executor.Register(metadataJobType, &metadataImporter{r: importer})
+	if err != nil {
+		return nil, err
+	}
+
 	return importer, nil
 }
@@ -0,0 +1,158 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergetemplate
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Placeholders supported in merge commit title and message templates.
+const (
+	PlaceholderNumber       = "{number}"
+	PlaceholderTitle        = "{title}"
+	PlaceholderDescription  = "{description}"
+	PlaceholderSourceBranch = "{source_branch}"
+	PlaceholderTargetBranch = "{target_branch}"
+	PlaceholderSourceRepo   = "{source_repo}"
+	PlaceholderAuthor       = "{author}"
+	PlaceholderCoAuthors    = "{co_authors}"
+	PlaceholderCommits      = "{commits}"
+)
+
+var placeholders = map[string]struct{}{
+	PlaceholderNumber:       {},
+	PlaceholderTitle:        {},
+	PlaceholderDescription:  {},
+	PlaceholderSourceBranch: {},
+	PlaceholderTargetBranch: {},
+	PlaceholderSourceRepo:   {},
+	PlaceholderAuthor:       {},
+	PlaceholderCoAuthors:    {},
+	PlaceholderCommits:      {},
+}
+
+// multiLinePlaceholders are the placeholders that can be replaced with multiple lines,
+// they aren't supported in title templates.
+var multiLinePlaceholders = []string{
+	PlaceholderDescription,
+	PlaceholderCoAuthors,
+	PlaceholderCommits,
+}
+
+// maxTitleLength is the maximum number of characters of a rendered title.
+const maxTitleLength = 256
+
+var placeholderRegex = regexp.MustCompile(`\{[a-z_]+\}`)
+
+// Identity is a name and email pair of a commit author.
+type Identity struct {
+	Name  string
+	Email string
+}
+
+func (i Identity) String() string {
+	return i.Name + " <" + i.Email + ">"
+}
+
+// Values contains values used to replace the placeholders of a template.
+type Values struct {
+	Number       int64
+	Title        string
+	Description  string
+	SourceBranch string
+	TargetBranch string
+	SourceRepo   string
+	Author       Identity
+	CoAuthors    []Identity
+	Commits      []string
+}
+
+// UnknownPlaceholders returns all placeholders in the template that are not supported.
+func UnknownPlaceholders(tmpl string) []string {
+	var unknown []string
+	for _, p := range placeholderRegex.FindAllString(tmpl, -1) {
+		if _, ok := placeholders[p]; !ok {
+			unknown = append(unknown, p)
+		}
+	}
+
+	return unknown
+}
+
+// MultiLinePlaceholders returns all placeholders in the template that can be replaced with multiple lines.
+func MultiLinePlaceholders(tmpl string) []string {
+	var used []string
+	for _, p := range multiLinePlaceholders {
+		if strings.Contains(tmpl, p) {
+			used = append(used, p)
+		}
+	}
+
+	return used
+}
+
+// Uses returns true if the template contains any of the provided placeholders.
+func Uses(tmpl string, placeholders ...string) bool {
+	for _, p := range placeholders {
+		if strings.Contains(tmpl, p) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Render replaces all supported placeholders in the template with the provided values.
+// Unsupported placeholders are left as they are.
+func Render(tmpl string, v Values) string {
+	coAuthors := make([]string, len(v.CoAuthors))
+	for i, coAuthor := range v.CoAuthors {
+		coAuthors[i] = "Co-authored-by: " + coAuthor.String()
+	}
+
+	commits := make([]string, len(v.Commits))
+	for i, commit := range v.Commits {
+		commits[i] = "* " + commit
+	}
+
+	r := strings.NewReplacer(
+		PlaceholderNumber, strconv.FormatInt(v.Number, 10),
+		PlaceholderTitle, v.Title,
+		PlaceholderDescription, v.Description,
+		PlaceholderSourceBranch, v.SourceBranch,
+		PlaceholderTargetBranch, v.TargetBranch,
+		PlaceholderSourceRepo, v.SourceRepo,
+		PlaceholderAuthor, v.Author.String(),
+		PlaceholderCoAuthors, strings.Join(coAuthors, "\n"),
+		PlaceholderCommits, strings.Join(commits, "\n"),
+	)
+
+	return strings.TrimSpace(r.Replace(tmpl))
+}
+
+// RenderTitle renders the title template like Render, but keeps only the first line of the result
+// and truncates it to at most maxTitleLength characters.
+func RenderTitle(tmpl string, v Values) string {
+	title, _, _ := strings.Cut(Render(tmpl, v), "\n")
+	title = strings.TrimSpace(title)
+
+	if runes := []rune(title); len(runes) > maxTitleLength {
+		title = strings.TrimSpace(string(runes[:maxTitleLength]))
+	}
+
+	return title
+}
@@ -0,0 +1,155 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mergetemplate
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	values := Values{
+		Number:       7,
+		Title:        "Add feature",
+		Description:  "Some description",
+		SourceBranch: "feature",
+		TargetBranch: "main",
+		SourceRepo:   "space/repo",
+		Author:       Identity{Name: "Jane", Email: "jane@example.com"},
+		CoAuthors: []Identity{
+			{Name: "John", Email: "john@example.com"},
+			{Name: "Mike", Email: "mike@example.com"},
+		},
+		Commits: []string{"first", "second"},
+	}
+
+	tests := []struct {
+		name string
+		tmpl string
+		exp  string
+	}{
+		{
+			name: "empty",
+			tmpl: "",
+			exp:  "",
+		},
+		{
+			name: "squash-title",
+			tmpl: "{title} (#{number})",
+			exp:  "Add feature (#7)",
+		},
+		{
+			name: "merge-title",
+			tmpl: "Merge branch '{source_branch}' of {source_repo} into {target_branch}",
+			exp:  "Merge branch 'feature' of space/repo into main",
+		},
+		{
+			name: "message-with-lists",
+			tmpl: "{commits}\n\n{co_authors}\n",
+			exp: "* first\n* second\n\n" +
+				"Co-authored-by: John <john@example.com>\nCo-authored-by: Mike <mike@example.com>",
+		},
+		{
+			name: "unknown-placeholder",
+			tmpl: "{author}: {unknown}",
+			exp:  "Jane <jane@example.com>: {unknown}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if want, got := test.exp, Render(test.tmpl, values); want != got {
+				t.Errorf("want=%q got=%q", want, got)
+			}
+		})
+	}
+}
+
+func TestUnknownPlaceholders(t *testing.T) {
+	tests := []struct {
+		name string
+		tmpl string
+		exp  []string
+	}{
+		{
+			name: "none",
+			tmpl: "{title} (#{number}) {}",
+			exp:  nil,
+		},
+		{
+			name: "unknown",
+			tmpl: "{title} {foo} {number} {bar_baz}",
+			exp:  []string{"{foo}", "{bar_baz}"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if want, got := test.exp, UnknownPlaceholders(test.tmpl); !reflect.DeepEqual(want, got) {
+				t.Errorf("want=%v got=%v", want, got)
+			}
+		})
+	}
+}
+
+func TestRenderTitle(t *testing.T) {
+	long := strings.Repeat("x", maxTitleLength+10)
+
+	tests := []struct {
+		name   string
+		tmpl   string
+		values Values
+		exp    string
+	}{
+		{
+			name:   "single-line",
+			tmpl:   "{title} (#{number})",
+			values: Values{Number: 7, Title: "Add feature"},
+			exp:    "Add feature (#7)",
+		},
+		{
+			name:   "first-line-only",
+			tmpl:   "{description}",
+			values: Values{Description: "First line\nSecond line"},
+			exp:    "First line",
+		},
+		{
+			name:   "truncated",
+			tmpl:   "{title}",
+			values: Values{Title: long},
+			exp:    long[:maxTitleLength],
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if want, got := test.exp, RenderTitle(test.tmpl, test.values); want != got {
+				t.Errorf("want=%q got=%q", want, got)
+			}
+		})
+	}
+}
+
+func TestMultiLinePlaceholders(t *testing.T) {
+	got := MultiLinePlaceholders("{title} {description} {commits}")
+	if want := []string{PlaceholderDescription, PlaceholderCommits}; !reflect.DeepEqual(want, got) {
+		t.Errorf("want=%v got=%v", want, got)
+	}
+
+	if got := MultiLinePlaceholders("{title} (#{number})"); got != nil {
+		t.Errorf("want no placeholders, got=%v", got)
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mirror
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/harness/gitness/errors"
+)
+
+var (
+	errLoopbackNotAllowed = errors.InvalidArgument(
+		"The remote URL mustn't point to a loopback address.")
+	errPrivateNetworkNotAllowed = errors.InvalidArgument(
+		"The remote URL mustn't point to a private, link-local or unspecified address.")
+)
+
+// lookupIPAddr resolves the host to its IP addresses.
+type lookupIPAddr func(ctx context.Context, host string) ([]net.IPAddr, error)
+
+// checkRemote resolves the host of the remote URL and verifies that none of its addresses is forbidden.
+// It returns the curl resolve entry ("HOST:PORT:ADDRESS[,ADDRESS]") that pins the host to the checked addresses,
+// that way a DNS change between the check and the fetch can't be used to reach a forbidden address.
+func (s *Service) checkRemote(ctx context.Context, remoteURL *url.URL) (string, error) {
+	host := remoteURL.Hostname()
+	if host == "" {
+		return "", errors.InvalidArgument("The remote URL must be a valid absolute URL.")
+	}
+
+	if strings.EqualFold(strings.TrimSuffix(host, "."), "localhost") && !s.allowLoopback {
+		return "", errLoopbackNotAllowed
+	}
+
+	var addrs []net.IPAddr
+	if ip := net.ParseIP(host); ip != nil {
+		addrs = []net.IPAddr{{IP: ip}}
+	} else {
+		lookup := s.lookupIPAddr
+		if lookup == nil {
+			lookup = net.DefaultResolver.LookupIPAddr
+		}
+
+		var err error
+		addrs, err = lookup(ctx, host)
+		if err != nil || len(addrs) == 0 {
+			return "", errors.InvalidArgument("Failed to resolve the host %q of the remote URL.", host)
+		}
+	}
+
+	ips := make([]string, len(addrs))
+	for i, addr := range addrs {
+		if err := s.checkIP(addr.IP); err != nil {
+			return "", err
+		}
+
+		ips[i] = addr.IP.String()
+		if addr.IP.To4() == nil {
+			ips[i] = "[" + ips[i] + "]"
+		}
+	}
+
+	port := remoteURL.Port()
+	if port == "" {
+		port = "443"
+		if remoteURL.Scheme == "http" {
+			port = "80"
+		}
+	}
+
+	return fmt.Sprintf("%s:%s:%s", host, port, strings.Join(ips, ",")), nil
+}
+
+// checkIP applies the same restrictions as the webhook HTTP client. Link-local addresses
+// (e.g. the cloud metadata endpoint 169.254.169.254) are treated as private network addresses.
+func (s *Service) checkIP(ip net.IP) error {
+	if !s.allowLoopback && ip.IsLoopback() {
+		return errLoopbackNotAllowed
+	}
+
+	if !s.allowPrivateNetwork &&
+		(ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsUnspecified()) {
+		return errPrivateNetworkNotAllowed
+	}
+
+	return nil
+}
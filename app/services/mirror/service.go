@@ -0,0 +1,536 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mirror
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeScheduler = "repo-mirror-scheduler"
+	jobType          = "repo-mirror-sync"
+	jobUIDPrefix     = "repo-mirror-"
+
+	// dueMirrorsBatchSize is the maximum number of due mirrors processed by a single run of the scheduler job.
+	dueMirrorsBatchSize = 100
+)
+
+var (
+	ErrNotFound       = errors.NotFound("The repository isn't a mirror.")
+	ErrAlreadyMirror  = errors.Conflict("The repository is already a mirror.")
+	ErrRepoNotEmpty   = errors.PreconditionFailed("Only an empty repository can be turned into a mirror.")
+	ErrSyncInProgress = errors.Conflict("Synchronization of the mirror is already in progress.")
+)
+
+// Input is the data of the job that synchronizes a single mirror.
+type Input struct {
+	RepoID int64 `json:"repo_id"`
+}
+
+// Settings are the user defined settings of a mirror.
+type Settings struct {
+	RemoteURL string
+	Username  string
+	// Password is the password or access token used to authenticate to the remote. Nil keeps the current one.
+	Password *string
+	// SyncInterval is the number of seconds between two synchronizations. Zero stands for the default interval.
+	SyncInterval int64
+	Disabled     bool
+}
+
+// Service keeps mirror repositories in sync with their upstream repositories.
+// Mirrors are synchronized periodically, a synchronization can be requested explicitly.
+type Service struct {
+	enabled         bool
+	cron            string
+	maxDur          time.Duration
+	jobMaxDur       time.Duration
+	defaultInterval time.Duration
+	minInterval     time.Duration
+
+	// allowLoopback and allowPrivateNetwork follow the webhook configuration,
+	// a mirror remote is as capable of reaching internal services as a webhook.
+	allowLoopback       bool
+	allowPrivateNetwork bool
+	lookupIPAddr        lookupIPAddr
+
+	git         git.Interface
+	repoStore   store.RepoStore
+	mirrorStore store.RepoMirrorStore
+	encrypter   encrypt.Encrypter
+	indexer     keywordsearch.Indexer
+	scheduler   *job.Scheduler
+}
+
+var _ job.Handler = (*Service)(nil)
+
+// Register registers the recurring job that schedules the synchronization of the due mirrors.
+func (s *Service) Register(ctx context.Context) error {
+	if !s.enabled {
+		return nil
+	}
+
+	err := s.scheduler.AddRecurring(ctx, jobTypeScheduler, jobTypeScheduler, s.cron, s.maxDur)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for repository mirrors: %w", err)
+	}
+
+	return nil
+}
+
+// Find returns the mirror configuration and the synchronization status of the repository.
+func (s *Service) Find(ctx context.Context, repoID int64) (*types.RepositoryMirror, error) {
+	mirror, err := s.mirrorStore.Find(ctx, repoID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repository mirror: %w", err)
+	}
+
+	return mirror, nil
+}
+
+// Create turns the empty repository into a mirror of the remote repository
+// and schedules its first synchronization.
+func (s *Service) Create(
+	ctx context.Context,
+	repo *types.Repository,
+	principal *types.Principal,
+	settings Settings,
+) (*types.RepositoryMirror, error) {
+	if err := s.sanitizeSettings(ctx, &settings); err != nil {
+		return nil, err
+	}
+
+	_, err := s.mirrorStore.Find(ctx, repo.ID)
+	if err == nil {
+		return nil, ErrAlreadyMirror
+	}
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find repository mirror: %w", err)
+	}
+
+	// the synchronization replaces all branches and tags, so only empty repositories can become a mirror.
+	branches, err := s.git.ListBranches(ctx, &git.ListBranchesParams{
+		ReadParams: git.CreateReadParams(repo),
+		Page:       1,
+		PageSize:   1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches: %w", err)
+	}
+	if len(branches.Branches) > 0 {
+		return nil, ErrRepoNotEmpty
+	}
+
+	now := time.Now().UnixMilli()
+	mirror := &types.RepositoryMirror{
+		RepoID:    repo.ID,
+		SyncState: enum.MirrorSyncStatePending,
+		NextSync:  now,
+		CreatedBy: principal.ID,
+		Created:   now,
+		Updated:   now,
+	}
+
+	if err = s.applySettings(mirror, settings); err != nil {
+		return nil, err
+	}
+
+	if err = s.mirrorStore.Create(ctx, mirror); err != nil {
+		return nil, fmt.Errorf("failed to create repository mirror: %w", err)
+	}
+
+	if err = s.scheduleSync(ctx, repo); err != nil && !errors.Is(err, ErrSyncInProgress) {
+		// the scheduler job picks the mirror up, as its next synchronization is due.
+		log.Ctx(ctx).Warn().Err(err).Int64("repo_id", repo.ID).Msg("failed to schedule first mirror synchronization")
+	}
+
+	return mirror, nil
+}
+
+// Update updates the settings of the mirror of the repository.
+// The mirror is synchronized right away if the remote changes.
+func (s *Service) Update(
+	ctx context.Context,
+	repoID int64,
+	mutateFn func(settings *Settings),
+) (*types.RepositoryMirror, error) {
+	mirror, err := s.Find(ctx, repoID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.mirrorStore.UpdateOptLock(ctx, mirror, func(mirror *types.RepositoryMirror) error {
+		settings := Settings{
+			RemoteURL:    mirror.RemoteURL,
+			Username:     mirror.Username,
+			Password:     nil,
+			SyncInterval: mirror.SyncInterval,
+			Disabled:     mirror.Disabled,
+		}
+
+		mutateFn(&settings)
+
+		if err := s.sanitizeSettings(ctx, &settings); err != nil {
+			return err
+		}
+
+		remoteChanged := settings.RemoteURL != mirror.RemoteURL ||
+			settings.Username != mirror.Username ||
+			settings.Password != nil
+
+		if err := s.applySettings(mirror, settings); err != nil {
+			return err
+		}
+
+		if remoteChanged {
+			mirror.NextSync = time.Now().UnixMilli()
+		} else if mirror.LastSyncFinished > 0 {
+			mirror.NextSync = mirror.LastSyncFinished + mirror.SyncInterval*1000
+		}
+
+		return nil
+	})
+}
+
+// Delete stops mirroring the upstream repository, the repository keeps its content and becomes writable.
+func (s *Service) Delete(ctx context.Context, repoID int64) error {
+	if _, err := s.Find(ctx, repoID); err != nil {
+		return err
+	}
+
+	if err := s.mirrorStore.Delete(ctx, repoID); err != nil {
+		return fmt.Errorf("failed to delete repository mirror: %w", err)
+	}
+
+	return nil
+}
+
+// Sync schedules the synchronization of the mirror repository.
+func (s *Service) Sync(ctx context.Context, repo *types.Repository) error {
+	if _, err := s.Find(ctx, repo.ID); err != nil {
+		return err
+	}
+
+	return s.scheduleSync(ctx, repo)
+}
+
+func (s *Service) scheduleSync(ctx context.Context, repo *types.Repository) error {
+	jobUID := jobUIDPrefix + fmt.Sprint(repo.ID)
+
+	progress, err := s.scheduler.GetJobProgress(ctx, jobUID)
+	if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return fmt.Errorf("failed to get repository mirror job progress: %w", err)
+	}
+	if err == nil {
+		if progress.State == job.JobStateScheduled || progress.State == job.JobStateRunning {
+			return ErrSyncInProgress
+		}
+
+		// the previous job is finished, remove it to be able to reuse its UID.
+		if err = s.scheduler.PurgeJobByUID(ctx, jobUID); err != nil {
+			return fmt.Errorf("failed to purge previous repository mirror job: %w", err)
+		}
+	}
+
+	data, err := json.Marshal(Input{RepoID: repo.ID})
+	if err != nil {
+		return fmt.Errorf("failed to marshal repository mirror job input: %w", err)
+	}
+
+	err = s.scheduler.RunJob(ctx, job.Definition{
+		UID:        jobUID,
+		Type:       jobType,
+		MaxRetries: 0,
+		Timeout:    s.jobMaxDur,
+		Data:       string(data),
+		SpaceID:    repo.ParentID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to schedule repository mirror job: %w", err)
+	}
+
+	return nil
+}
+
+// Handle synchronizes a single mirror repository with its upstream repository.
+func (s *Service) Handle(ctx context.Context, data string, _ job.ProgressReporter) (string, error) {
+	var input Input
+	if err := json.Unmarshal([]byte(data), &input); err != nil {
+		return "", fmt.Errorf("failed to unmarshal repository mirror job input: %w", err)
+	}
+
+	repo, err := s.repoStore.Find(ctx, input.RepoID)
+	if err != nil {
+		return "", fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	mirror, err := s.mirrorStore.Find(ctx, repo.ID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return "mirror has been removed", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to find repository mirror: %w", err)
+	}
+
+	mirror, err = s.mirrorStore.UpdateOptLock(ctx, mirror, func(mirror *types.RepositoryMirror) error {
+		mirror.SyncState = enum.MirrorSyncStateRunning
+		mirror.LastSyncStarted = time.Now().UnixMilli()
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to update repository mirror state: %w", err)
+	}
+
+	errSync := s.sync(ctx, repo, mirror)
+
+	// the status is updated even if the job has been canceled or timed out.
+	finished := time.Now().UnixMilli()
+	_, err = s.mirrorStore.UpdateOptLock(context.Background(), mirror,
+		func(mirror *types.RepositoryMirror) error {
+			mirror.LastSyncFinished = finished
+			mirror.NextSync = finished + mirror.SyncInterval*1000
+
+			if errSync != nil {
+				mirror.SyncState = enum.MirrorSyncStateFailed
+				mirror.SyncError = errSync.Error()
+				return nil
+			}
+
+			mirror.SyncState = enum.MirrorSyncStateSucceeded
+			mirror.SyncError = ""
+			mirror.LastSynced = finished
+
+			return nil
+		})
+	if err != nil {
+		return "", fmt.Errorf("failed to update repository mirror state: %w", err)
+	}
+
+	if errSync != nil {
+		return "", errSync
+	}
+
+	return "", nil
+}
+
+// sync fetches the branches and tags of the upstream repository. Branches and tags that
+// don't exist on the upstream anymore are removed. Errors never contain the credentials.
+func (s *Service) sync(ctx context.Context, repo *types.Repository, mirror *types.RepositoryMirror) error {
+	remoteURL, err := url.Parse(mirror.RemoteURL)
+	if err != nil {
+		return fmt.Errorf("failed to parse remote URL: %w", err)
+	}
+
+	// the host is checked again, it could resolve to a different address than when the mirror was saved.
+	resolve, err := s.checkRemote(ctx, remoteURL)
+	if err != nil {
+		return err
+	}
+
+	var password string
+	if mirror.Password != "" {
+		password, err = s.encrypter.Decrypt([]byte(mirror.Password))
+		if err != nil {
+			return fmt.Errorf("failed to decrypt mirror credentials: %w", err)
+		}
+	}
+
+	if mirror.Username != "" || password != "" {
+		remoteURL.User = url.UserPassword(mirror.Username, password)
+	}
+
+	systemPrincipal := bootstrap.NewSystemServiceSession().Principal
+
+	out, err := s.git.SyncRepository(ctx, &git.SyncRepositoryParams{
+		WriteParams: git.WriteParams{
+			Actor: git.Identity{
+				Name:  systemPrincipal.DisplayName,
+				Email: systemPrincipal.Email,
+			},
+			RepoUID: repo.GitUID,
+		},
+		Source:            remoteURL.String(),
+		CreateIfNotExists: false,
+		RefSpecs:          []string{"refs/heads/*:refs/heads/*", "refs/tags/*:refs/tags/*"},
+		Resolve:           []string{resolve},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fetch from remote: %s", redact(err.Error(), remoteURL, password))
+	}
+
+	if out.DefaultBranch != "" && out.DefaultBranch != repo.DefaultBranch {
+		repo, err = s.repoStore.UpdateOptLock(ctx, repo, func(repo *types.Repository) error {
+			repo.DefaultBranch = out.DefaultBranch
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update default branch of repository: %w", err)
+		}
+	}
+
+	if err = s.indexer.Index(ctx, repo); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Int64("repo_id", repo.ID).Msg("failed to index mirror repository")
+	}
+
+	return nil
+}
+
+// redact removes the credentials from the message.
+func redact(message string, remoteURL *url.URL, password string) string {
+	message = strings.ReplaceAll(message, remoteURL.String(), remoteURL.Redacted())
+	if password != "" {
+		message = strings.ReplaceAll(message, password, "xxxxx")
+	}
+
+	return message
+}
+
+func (s *Service) sanitizeSettings(ctx context.Context, settings *Settings) error {
+	settings.RemoteURL = strings.TrimSpace(settings.RemoteURL)
+	settings.Username = strings.TrimSpace(settings.Username)
+
+	remoteURL, err := url.Parse(settings.RemoteURL)
+	if err != nil || remoteURL.Host == "" {
+		return errors.InvalidArgument("The remote URL must be a valid absolute URL.")
+	}
+
+	// other schemes, like file, would give access to the local file system of the server.
+	if remoteURL.Scheme != "http" && remoteURL.Scheme != "https" {
+		return errors.InvalidArgument("The remote URL must use the http or https scheme.")
+	}
+
+	if remoteURL.User != nil {
+		return errors.InvalidArgument("The remote URL mustn't contain credentials, provide them separately.")
+	}
+
+	if _, err = s.checkRemote(ctx, remoteURL); err != nil {
+		return err
+	}
+
+	if settings.SyncInterval == 0 {
+		settings.SyncInterval = int64(s.defaultInterval / time.Second)
+	}
+
+	if settings.SyncInterval < int64(s.minInterval/time.Second) {
+		return errors.InvalidArgument("The sync interval must be at least %d seconds.",
+			int64(s.minInterval/time.Second))
+	}
+
+	return nil
+}
+
+func (s *Service) applySettings(mirror *types.RepositoryMirror, settings Settings) error {
+	mirror.RemoteURL = settings.RemoteURL
+	mirror.Username = settings.Username
+	mirror.SyncInterval = settings.SyncInterval
+	mirror.Disabled = settings.Disabled
+
+	if settings.Password == nil {
+		return nil
+	}
+
+	if *settings.Password == "" {
+		mirror.Password = ""
+		return nil
+	}
+
+	encrypted, err := s.encrypter.Encrypt(*settings.Password)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt mirror credentials: %w", err)
+	}
+
+	mirror.Password = string(encrypted)
+
+	return nil
+}
+
+// dueScheduler is the handler of the recurring job that schedules the synchronization of the due mirrors.
+type dueScheduler struct {
+	s *Service
+}
+
+func (d dueScheduler) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	s := d.s
+	if !s.enabled {
+		return "", nil
+	}
+
+	now := time.Now().UnixMilli()
+
+	mirrors, err := s.mirrorStore.ListDue(ctx, now, dueMirrorsBatchSize)
+	if err != nil {
+		return "", fmt.Errorf("failed to list due repository mirrors: %w", err)
+	}
+
+	var scheduled int
+	for _, mirror := range mirrors {
+		if ctx.Err() != nil {
+			break
+		}
+
+		log := log.Ctx(ctx).With().Int64("repo_id", mirror.RepoID).Logger()
+
+		// the next sync is moved forward right away, otherwise the mirror would be picked up
+		// by every run of the job until its synchronization finishes.
+		_, err = s.mirrorStore.UpdateOptLock(ctx, mirror, func(mirror *types.RepositoryMirror) error {
+			mirror.NextSync = now + mirror.SyncInterval*1000
+			return nil
+		})
+		if err != nil {
+			log.Warn().Err(err).Msg("failed to update next sync of repository mirror")
+			continue
+		}
+
+		repo, err := s.repoStore.Find(ctx, mirror.RepoID)
+		if err != nil {
+			log.Warn().Err(err).Msg("failed to find mirror repository")
+			continue
+		}
+
+		err = s.scheduleSync(ctx, repo)
+		if errors.Is(err, ErrSyncInProgress) {
+			continue
+		}
+		if err != nil {
+			log.Warn().Err(err).Msg("failed to schedule repository mirror synchronization")
+			continue
+		}
+
+		scheduled++
+	}
+
+	return fmt.Sprintf("processed %d due mirrors, scheduled %d synchronizations", len(mirrors), scheduled), nil
+}
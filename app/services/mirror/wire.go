@@ -0,0 +1,70 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mirror
+
+import (
+	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	ProvideService,
+)
+
+func ProvideService(
+	config *types.Config,
+	git git.Interface,
+	repoStore store.RepoStore,
+	mirrorStore store.RepoMirrorStore,
+	encrypter encrypt.Encrypter,
+	indexer keywordsearch.Indexer,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+) (*Service, error) {
+	s := &Service{
+		enabled:         config.RepoMirror.Enabled,
+		cron:            config.RepoMirror.CRON,
+		maxDur:          config.RepoMirror.MaxDuration,
+		jobMaxDur:       config.RepoMirror.JobMaxDuration,
+		defaultInterval: config.RepoMirror.DefaultSyncInterval,
+		minInterval:     config.RepoMirror.MinSyncInterval,
+
+		allowLoopback:       config.Webhook.AllowLoopback,
+		allowPrivateNetwork: config.Webhook.AllowPrivateNetwork,
+
+		git:         git,
+		repoStore:   repoStore,
+		mirrorStore: mirrorStore,
+		encrypter:   encrypter,
+		indexer:     indexer,
+		scheduler:   scheduler,
+	}
+
+	if err := executor.Register(jobType, s); err != nil {
+		return nil, err
+	}
+
+	if err := executor.Register(jobTypeScheduler, dueScheduler{s: s}); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
@@ -88,6 +88,11 @@ func (s *Service) processPullReqBranchUpdatedEvent(
 		}
 	}
 
+	reviewerPrincipals, err = s.pullReqRecipients(ctx, base, reviewerPrincipals)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get notification recipients: %w", err)
+	}
+
 	return &PullReqBranchUpdatedPayload{
 		Base:      base,
 		NewSHA:    event.Payload.NewSHA,
@@ -36,4 +36,6 @@ type Client interface {
 		recipients []*types.PrincipalInfo,
 		payload *PullReqStateChangedPayload,
 	) error
+	SendPullReqCreated(ctx context.Context, recipients []*types.PrincipalInfo, payload *PullReqCreatedPayload) error
+	SendReleaseCreated(ctx context.Context, recipients []*types.PrincipalInfo, payload *ReleaseCreatedPayload) error
 }
@@ -43,6 +43,10 @@ func (s *Service) notifyCommentCreated(
 		)
 	}
 
+	if len(recipients) == 0 {
+		return nil
+	}
+
 	err = s.notificationClient.SendCommentCreated(ctx, recipients, payload)
 	if err != nil {
 		return fmt.Errorf(
@@ -77,6 +81,11 @@ func (s *Service) processCommentCreatedEvent(
 		base.Author,
 	}
 
+	recipients, err = s.pullReqRecipients(ctx, base, recipients)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get notification recipients: %w", err)
+	}
+
 	return &CommentCreatedPayload{
 		Base:      base,
 		Commenter: commenter,
@@ -19,6 +19,7 @@ import (
 	"context"
 	"fmt"
 
+	gitevents "github.com/harness/gitness/app/events/git"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/services/notification/mailer"
 	"github.com/harness/gitness/types"
@@ -30,6 +31,8 @@ const (
 	TemplatePullReqBranchUpdated = "pullreq_branch_updated.html"
 	TemplateNameReviewSubmitted  = "review_submitted.html"
 	TemplatePullReqStateChanged  = "pullreq_state_changed.html"
+	TemplatePullReqCreated       = "pullreq_created.html"
+	TemplateReleaseCreated       = "release_created.html"
 )
 
 type MailClient struct {
@@ -131,6 +134,40 @@ func (m MailClient) SendPullReqStateChanged(
 	return m.Mailer.Send(ctx, *email)
 }
 
+func (m MailClient) SendPullReqCreated(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *PullReqCreatedPayload,
+) error {
+	email, err := GenerateEmailFromPayload(TemplatePullReqCreated, recipients, payload.Base, payload)
+	if err != nil {
+		return fmt.Errorf("failed to generate mail requests after processing %s event: %w",
+			pullreqevents.CreatedEvent, err)
+	}
+
+	return m.Mailer.Send(ctx, *email)
+}
+
+func (m MailClient) SendReleaseCreated(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *ReleaseCreatedPayload,
+) error {
+	body, err := GetHTMLBody(TemplateReleaseCreated, payload)
+	if err != nil {
+		return fmt.Errorf("failed to generate mail requests after processing %s event: %w",
+			gitevents.TagCreatedEvent, err)
+	}
+
+	var email mailer.Payload
+	email.Body = string(body)
+	email.Subject = fmt.Sprintf(subjectReleaseEvent, payload.Repo.UID, payload.Tag)
+	email.RepoRef = payload.Repo.Path
+	email.ToRecipients = RetrieveEmailsFromPrincipals(recipients)
+
+	return m.Mailer.Send(ctx, email)
+}
+
 func GetSubjectPullRequest(
 	repoUID string,
 	prNum int64,
@@ -0,0 +1,81 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
+)
+
+type PullReqCreatedPayload struct {
+	Base *BasePullReqPayload
+}
+
+func (s *Service) notifyPullReqCreated(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.CreatedPayload],
+) error {
+	payload, recipients, err := s.processPullReqCreatedEvent(ctx, event)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to process %s event for pullReqID %d: %w",
+			pullreqevents.CreatedEvent,
+			event.Payload.PullReqID,
+			err,
+		)
+	}
+
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	err = s.notificationClient.SendPullReqCreated(ctx, recipients, payload)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to send email for event %s for pullReqID %d: %w",
+			pullreqevents.CreatedEvent,
+			event.Payload.PullReqID,
+			err,
+		)
+	}
+
+	return nil
+}
+
+func (s *Service) processPullReqCreatedEvent(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.CreatedPayload],
+) (*PullReqCreatedPayload, []*types.PrincipalInfo, error) {
+	base, err := s.getBasePayload(ctx, event.Payload.Base)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get base payload: %w", err)
+	}
+
+	// Only watchers are notified about new pull requests, the author doesn't need a notification.
+	recipients, err := s.pullReqRecipients(ctx, base, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get notification recipients: %w", err)
+	}
+
+	recipients = excludePrincipal(recipients, base.PullReq.CreatedBy)
+
+	return &PullReqCreatedPayload{
+		Base: base,
+	}, recipients, nil
+}
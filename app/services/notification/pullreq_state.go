@@ -51,6 +51,10 @@ func (s *Service) notifyPullReqStateMerged(
 		)
 	}
 
+	if len(recipients) == 0 {
+		return nil
+	}
+
 	if err = s.notificationClient.SendPullReqStateChanged(
 		ctx,
 		recipients,
@@ -80,6 +84,10 @@ func (s *Service) notifyPullReqStateClosed(
 		)
 	}
 
+	if len(recipients) == 0 {
+		return nil
+	}
+
 	if err = s.notificationClient.SendPullReqStateChanged(
 		ctx,
 		recipients,
@@ -109,6 +117,10 @@ func (s *Service) notifyPullReqStateReOpened(
 		)
 	}
 
+	if len(recipients) == 0 {
+		return nil
+	}
+
 	if err = s.notificationClient.SendPullReqStateChanged(
 		ctx,
 		recipients,
@@ -169,6 +181,11 @@ func (s *Service) processPullReqStateChangedEvent(
 
 	recipients[len(reviewers)] = author
 
+	recipients, err = s.pullReqRecipients(ctx, basePayload, recipients)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get notification recipients: %w", err)
+	}
+
 	return &PullReqStateChangedPayload{
 		Base:      basePayload,
 		ChangedBy: stateModifierPrincipal,
@@ -0,0 +1,96 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	gitevents "github.com/harness/gitness/app/events/git"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
+)
+
+const gitReferenceNamePrefixTag = "refs/tags/"
+
+type ReleaseCreatedPayload struct {
+	Repo    *types.Repository
+	Creator *types.PrincipalInfo
+	Tag     string
+	SHA     string
+	RepoURL string
+}
+
+func (s *Service) notifyReleaseCreated(
+	ctx context.Context,
+	event *events.Event[*gitevents.TagCreatedPayload],
+) error {
+	payload, recipients, err := s.processReleaseCreatedEvent(ctx, event)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to process %s event for repoID %d: %w",
+			gitevents.TagCreatedEvent,
+			event.Payload.RepoID,
+			err,
+		)
+	}
+
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	err = s.notificationClient.SendReleaseCreated(ctx, recipients, payload)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to send email for event %s for repoID %d: %w",
+			gitevents.TagCreatedEvent,
+			event.Payload.RepoID,
+			err,
+		)
+	}
+
+	return nil
+}
+
+func (s *Service) processReleaseCreatedEvent(
+	ctx context.Context,
+	event *events.Event[*gitevents.TagCreatedPayload],
+) (*ReleaseCreatedPayload, []*types.PrincipalInfo, error) {
+	repo, err := s.repoStore.Find(ctx, event.Payload.RepoID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch repo from repoStore: %w", err)
+	}
+
+	creator, err := s.principalInfoCache.Get(ctx, event.Payload.PrincipalID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get principal info for %d: %w", event.Payload.PrincipalID, err)
+	}
+
+	recipients, err := s.releaseRecipients(ctx, repo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get notification recipients: %w", err)
+	}
+
+	recipients = excludePrincipal(recipients, event.Payload.PrincipalID)
+
+	return &ReleaseCreatedPayload{
+		Repo:    repo,
+		Creator: creator,
+		Tag:     strings.TrimPrefix(event.Payload.Ref, gitReferenceNamePrefixTag),
+		SHA:     event.Payload.SHA,
+		RepoURL: s.urlProvider.GenerateUIRepoURL(repo.Path),
+	}, recipients, nil
+}
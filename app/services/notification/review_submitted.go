@@ -45,6 +45,10 @@ func (s *Service) notifyReviewSubmitted(
 		)
 	}
 
+	if len(recipients) == 0 {
+		return nil
+	}
+
 	err = s.notificationClient.SendReviewSubmitted(
 		ctx,
 		recipients,
@@ -91,10 +95,15 @@ func (s *Service) processReviewSubmittedEvent(
 		)
 	}
 
+	recipients, err := s.pullReqRecipients(ctx, base, []*types.PrincipalInfo{authorPrincipal})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get notification recipients: %w", err)
+	}
+
 	return &ReviewSubmittedPayload{
 		Base:     base,
 		Author:   authorPrincipal,
 		Decision: event.Payload.Decision,
 		Reviewer: reviewerPrincipal,
-	}, []*types.PrincipalInfo{authorPrincipal}, nil
+	}, recipients, nil
 }
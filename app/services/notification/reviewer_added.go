@@ -42,6 +42,10 @@ func (s *Service) notifyReviewerAdded(
 		)
 	}
 
+	if len(recipients) == 0 {
+		return nil
+	}
+
 	err = s.notificationClient.SendReviewerAdded(ctx, recipients, payload)
 	if err != nil {
 		return fmt.Errorf(
@@ -74,6 +78,11 @@ func (s *Service) processReviewerAddedEvent(
 		reviewerPrincipal,
 	}
 
+	recipients, err = s.pullReqRecipients(ctx, base, recipients)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get notification recipients: %w", err)
+	}
+
 	return &ReviewerAddedPayload{
 		Base:     base,
 		Reviewer: reviewerPrincipal,
@@ -22,6 +22,8 @@ import (
 	"io/fs"
 	"path"
 
+	"github.com/harness/gitness/app/auth/authz"
+	gitevents "github.com/harness/gitness/app/events/git"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -34,6 +36,7 @@ const (
 	eventReaderGroupName = "gitness:notification"
 	templatesDir         = "templates"
 	subjectPullReqEvent  = "[%s] %s (PR #%d)"
+	subjectReleaseEvent  = "[%s] New release: %s"
 )
 
 var (
@@ -88,6 +91,7 @@ type Service struct {
 	config                Config
 	notificationClient    Client
 	prReaderFactory       *events.ReaderFactory[*pullreqevents.Reader]
+	gitReaderFactory      *events.ReaderFactory[*gitevents.Reader]
 	pullReqStore          store.PullReqStore
 	repoStore             store.RepoStore
 	principalInfoView     store.PrincipalInfoView
@@ -95,6 +99,9 @@ type Service struct {
 	pullReqReviewersStore store.PullReqReviewerStore
 	pullReqActivityStore  store.PullReqActivityStore
 	spacePathStore        store.SpacePathStore
+	watchStore            store.WatchStore
+	principalStore        store.PrincipalStore
+	authorizer            authz.Authorizer
 	urlProvider           url.Provider
 }
 
@@ -103,6 +110,7 @@ func NewService(
 	config Config,
 	notificationClient Client,
 	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
 	pullReqStore store.PullReqStore,
 	repoStore store.RepoStore,
 	principalInfoView store.PrincipalInfoView,
@@ -110,12 +118,16 @@ func NewService(
 	pullReqReviewersStore store.PullReqReviewerStore,
 	pullReqActivityStore store.PullReqActivityStore,
 	spacePathStore store.SpacePathStore,
+	watchStore store.WatchStore,
+	principalStore store.PrincipalStore,
+	authorizer authz.Authorizer,
 	urlProvider url.Provider,
 ) (*Service, error) {
 	service := &Service{
 		config:                config,
 		notificationClient:    notificationClient,
 		prReaderFactory:       prReaderFactory,
+		gitReaderFactory:      gitReaderFactory,
 		pullReqStore:          pullReqStore,
 		repoStore:             repoStore,
 		principalInfoView:     principalInfoView,
@@ -123,6 +135,9 @@ func NewService(
 		pullReqReviewersStore: pullReqReviewersStore,
 		pullReqActivityStore:  pullReqActivityStore,
 		spacePathStore:        spacePathStore,
+		watchStore:            watchStore,
+		principalStore:        principalStore,
+		authorizer:            authorizer,
 		urlProvider:           urlProvider,
 	}
 
@@ -138,6 +153,7 @@ func NewService(
 					stream.WithMaxRetries(config.MaxRetries),
 				))
 
+			_ = r.RegisterCreated(service.notifyPullReqCreated)
 			_ = r.RegisterReviewerAdded(service.notifyReviewerAdded)
 			_ = r.RegisterCommentCreated(service.notifyCommentCreated)
 			_ = r.RegisterBranchUpdated(service.notifyPullReqBranchUpdated)
@@ -153,6 +169,25 @@ func NewService(
 		return nil, fmt.Errorf("failed to launch event reader for %s: %w", eventReaderGroupName, err)
 	}
 
+	_, err = service.gitReaderFactory.Launch(
+		ctx,
+		eventReaderGroupName,
+		config.EventReaderName,
+		func(r *gitevents.Reader,
+		) error {
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterTagCreated(service.notifyReleaseCreated)
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch git event reader for %s: %w", eventReaderGroupName, err)
+	}
+
 	return service, nil
 }
 
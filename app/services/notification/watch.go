@@ -0,0 +1,198 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
+)
+
+// pullReqRecipients returns the recipients of a pull request notification.
+// Participants (author, reviewers...) are notified unless they chose to ignore the pull request,
+// users who watch all pull request activity of the repository or the pull request are notified as well.
+func (s *Service) pullReqRecipients(
+	ctx context.Context,
+	base *BasePullReqPayload,
+	participants []*types.PrincipalInfo,
+) ([]*types.PrincipalInfo, error) {
+	levels, err := s.pullReqWatchLevels(ctx, base.Repo.ID, base.PullReq.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	recipients := make([]*types.PrincipalInfo, 0, len(participants))
+	seen := make(map[int64]struct{}, len(participants))
+	for _, participant := range participants {
+		if participant == nil {
+			continue
+		}
+		if _, ok := seen[participant.ID]; ok {
+			continue
+		}
+		seen[participant.ID] = struct{}{}
+
+		if levels[participant.ID] == enum.WatchLevelIgnore {
+			continue
+		}
+
+		recipients = append(recipients, participant)
+	}
+
+	watchers, err := s.watchers(ctx, base.Repo, levels, seen, enum.WatchLevelAll)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(recipients, watchers...), nil
+}
+
+// releaseRecipients returns the users that watch releases of the repository.
+func (s *Service) releaseRecipients(ctx context.Context, repo *types.Repository) ([]*types.PrincipalInfo, error) {
+	repoWatches, err := s.watchStore.ListRepo(ctx, repo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repository watches: %w", err)
+	}
+
+	levels := make(map[int64]enum.WatchLevel, len(repoWatches))
+	for _, w := range repoWatches {
+		if w.Level.IncludesReleases() {
+			levels[w.PrincipalID] = enum.WatchLevelReleases
+		}
+	}
+
+	return s.watchers(ctx, repo, levels, nil, enum.WatchLevelReleases)
+}
+
+// pullReqWatchLevels returns the effective pull request watch levels of all users that have one.
+// The watch level of the pull request takes precedence over the watch level of the repository.
+func (s *Service) pullReqWatchLevels(
+	ctx context.Context,
+	repoID int64,
+	pullReqID int64,
+) (map[int64]enum.WatchLevel, error) {
+	repoWatches, err := s.watchStore.ListRepo(ctx, repoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repository watches: %w", err)
+	}
+
+	pullReqWatches, err := s.watchStore.ListPullReq(ctx, pullReqID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull request watches: %w", err)
+	}
+
+	levels := make(map[int64]enum.WatchLevel, len(repoWatches)+len(pullReqWatches))
+	for _, w := range repoWatches {
+		levels[w.PrincipalID] = w.Level.PullReqLevel()
+	}
+	for _, w := range pullReqWatches {
+		levels[w.PrincipalID] = w.Level
+	}
+
+	return levels, nil
+}
+
+// watchers returns the users with the required watch level that still have access to the repository.
+func (s *Service) watchers(
+	ctx context.Context,
+	repo *types.Repository,
+	levels map[int64]enum.WatchLevel,
+	exclude map[int64]struct{},
+	level enum.WatchLevel,
+) ([]*types.PrincipalInfo, error) {
+	ids := make([]int64, 0, len(levels))
+	for id, l := range levels {
+		if l != level {
+			continue
+		}
+		if _, ok := exclude[id]; ok {
+			continue
+		}
+
+		canView, err := s.canViewRepo(ctx, id, repo)
+		if err != nil {
+			return nil, err
+		}
+		if !canView {
+			continue
+		}
+
+		ids = append(ids, id)
+	}
+
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	slices.Sort(ids)
+
+	infoMap, err := s.principalInfoCache.Map(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch watcher principal infos: %w", err)
+	}
+
+	watchers := make([]*types.PrincipalInfo, 0, len(ids))
+	for _, id := range ids {
+		if info, ok := infoMap[id]; ok {
+			watchers = append(watchers, info)
+		}
+	}
+
+	return watchers, nil
+}
+
+// canViewRepo checks if the principal is still allowed to view the repository.
+// Watches are not removed when a user loses access, so they are checked before every notification.
+func (s *Service) canViewRepo(ctx context.Context, principalID int64, repo *types.Repository) (bool, error) {
+	principal, err := s.principalStore.Find(ctx, principalID)
+	if err != nil {
+		return false, fmt.Errorf("failed to find watcher principal %d: %w", principalID, err)
+	}
+
+	if principal.Blocked {
+		return false, nil
+	}
+
+	session := &auth.Session{Principal: *principal}
+
+	err = apiauth.CheckRepo(ctx, s.authorizer, session, repo, enum.PermissionRepoView, false)
+	if errors.Is(err, apiauth.ErrNotAuthorized) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to check watcher access to the repository: %w", err)
+	}
+
+	return true, nil
+}
+
+// excludePrincipal removes the principal (usually the one who triggered the event) from the recipients.
+func excludePrincipal(recipients []*types.PrincipalInfo, principalID int64) []*types.PrincipalInfo {
+	filtered := recipients[:0]
+	for _, recipient := range recipients {
+		if recipient.ID != principalID {
+			filtered = append(filtered, recipient)
+		}
+	}
+
+	return filtered
+}
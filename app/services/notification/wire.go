@@ -17,6 +17,8 @@ package notification
 import (
 	"context"
 
+	"github.com/harness/gitness/app/auth/authz"
+	gitevents "github.com/harness/gitness/app/events/git"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/services/notification/mailer"
 	"github.com/harness/gitness/app/store"
@@ -36,6 +38,7 @@ func ProvideNotificationService(
 	notificationClient Client,
 	pullReqConfig Config,
 	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
 	pullReqStore store.PullReqStore,
 	repoStore store.RepoStore,
 	principalInfoView store.PrincipalInfoView,
@@ -43,6 +46,9 @@ func ProvideNotificationService(
 	pullReqReviewersStore store.PullReqReviewerStore,
 	pullReqActivityStore store.PullReqActivityStore,
 	spacePathStore store.SpacePathStore,
+	watchStore store.WatchStore,
+	principalStore store.PrincipalStore,
+	authorizer authz.Authorizer,
 	urlProvider url.Provider,
 ) (*Service, error) {
 	return NewService(
@@ -50,6 +56,7 @@ func ProvideNotificationService(
 		pullReqConfig,
 		notificationClient,
 		prReaderFactory,
+		gitReaderFactory,
 		pullReqStore,
 		repoStore,
 		principalInfoView,
@@ -57,6 +64,9 @@ func ProvideNotificationService(
 		pullReqReviewersStore,
 		pullReqActivityStore,
 		spacePathStore,
+		watchStore,
+		principalStore,
+		authorizer,
 		urlProvider,
 	)
 }
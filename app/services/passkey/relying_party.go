@@ -0,0 +1,64 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package passkey
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/harness/gitness/types"
+
+	"github.com/go-webauthn/webauthn/webauthn"
+)
+
+// relyingParty returns the WebAuthn relying party configuration.
+// The origin defaults to the UI URL and the relying party ID defaults to the host of the origin.
+func relyingParty(config *types.Config) (*webauthn.Config, error) {
+	origin := config.WebAuthn.RPOrigin
+	if origin == "" {
+		origin = config.URL.UI
+	}
+
+	originURL, err := url.Parse(origin)
+	if err != nil || originURL.Hostname() == "" {
+		return nil, fmt.Errorf("invalid webauthn relying party origin %q", origin)
+	}
+
+	rpID := config.WebAuthn.RPID
+	if rpID == "" {
+		rpID = originURL.Hostname()
+	}
+
+	if config.WebAuthn.Timeout <= 0 {
+		return nil, fmt.Errorf("webauthn timeout must be positive, got %s", config.WebAuthn.Timeout)
+	}
+
+	// the challenges expire after the timeout, the relying party enforces it as well.
+	timeout := webauthn.TimeoutConfig{
+		Enforce:    true,
+		Timeout:    config.WebAuthn.Timeout,
+		TimeoutUVD: config.WebAuthn.Timeout,
+	}
+
+	return &webauthn.Config{
+		RPDisplayName: config.WebAuthn.RPDisplayName,
+		RPID:          rpID,
+		RPOrigins:     []string{originURL.Scheme + "://" + originURL.Host},
+		Timeouts: webauthn.TimeoutsConfig{
+			Login:        timeout,
+			Registration: timeout,
+		},
+	}, nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package passkey
+
+import (
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestRelyingParty(t *testing.T) {
+	tests := []struct {
+		name       string
+		uiURL      string
+		rpOrigin   string
+		rpID       string
+		wantOrigin string
+		wantID     string
+		wantErr    bool
+	}{
+		{
+			name:       "derived-from-ui-url",
+			uiURL:      "https://git.example.com:8443/ui",
+			wantOrigin: "https://git.example.com:8443",
+			wantID:     "git.example.com",
+		},
+		{
+			name:       "explicit-origin",
+			uiURL:      "http://localhost:3000",
+			rpOrigin:   "https://code.example.com",
+			wantOrigin: "https://code.example.com",
+			wantID:     "code.example.com",
+		},
+		{
+			name:       "explicit-id",
+			uiURL:      "https://code.example.com",
+			rpID:       "example.com",
+			wantOrigin: "https://code.example.com",
+			wantID:     "example.com",
+		},
+		{
+			name:    "no-host",
+			uiURL:   "/ui",
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := &types.Config{}
+			config.URL.UI = test.uiURL
+			config.WebAuthn.RPOrigin = test.rpOrigin
+			config.WebAuthn.RPID = test.rpID
+			config.WebAuthn.RPDisplayName = "Gitness"
+			config.WebAuthn.Timeout = 5 * time.Minute
+
+			rp, err := relyingParty(config)
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if len(rp.RPOrigins) != 1 || rp.RPOrigins[0] != test.wantOrigin {
+				t.Errorf("origin mismatch: want=%q got=%q", test.wantOrigin, rp.RPOrigins)
+			}
+			if rp.RPID != test.wantID {
+				t.Errorf("id mismatch: want=%q got=%q", test.wantID, rp.RPID)
+			}
+			if rp.Timeouts.Login.Timeout != 5*time.Minute || rp.Timeouts.Registration.Timeout != 5*time.Minute {
+				t.Errorf("timeout mismatch: want=5m got=%+v", rp.Timeouts)
+			}
+		})
+	}
+}
@@ -0,0 +1,473 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package passkey
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/store"
+	gitnessstore "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/rs/zerolog/log"
+)
+
+const maxNameLength = 64
+
+var (
+	errPasskeysDisabled  = usererror.BadRequest("Passkeys are disabled.")
+	errChallengeInvalid  = usererror.BadRequest("The passkey challenge is invalid or expired.")
+	errPasskeyVerifyFail = usererror.BadRequest("The passkey could not be verified.")
+	errLastAdminPasskey  = usererror.BadRequest(
+		"The last passkey of an admin account can't be deleted, admin accounts are required to sign in with a passkey.")
+)
+
+// Service performs the WebAuthn registration and login ceremonies and maintains the passkeys of users.
+type Service struct {
+	webAuthn         *webauthn.WebAuthn
+	timeout          time.Duration
+	passwordless     bool
+	requireForAdmins bool
+	tx               dbtx.Transactor
+	passkeyStore     store.PasskeyStore
+	challengeStore   store.PasskeyChallengeStore
+	principalStore   store.PrincipalStore
+}
+
+// NewService returns a new passkey service. A nil webAuthn disables passkeys.
+func NewService(
+	webAuthn *webauthn.WebAuthn,
+	timeout time.Duration,
+	passwordless bool,
+	requireForAdmins bool,
+	tx dbtx.Transactor,
+	passkeyStore store.PasskeyStore,
+	challengeStore store.PasskeyChallengeStore,
+	principalStore store.PrincipalStore,
+) *Service {
+	return &Service{
+		webAuthn:         webAuthn,
+		timeout:          timeout,
+		passwordless:     passwordless,
+		requireForAdmins: requireForAdmins,
+		tx:               tx,
+		passkeyStore:     passkeyStore,
+		challengeStore:   challengeStore,
+		principalStore:   principalStore,
+	}
+}
+
+// Enabled returns true if users can register passkeys and sign in with them.
+func (s *Service) Enabled() bool {
+	return s.webAuthn != nil
+}
+
+// Passwordless returns true if a passkey is sufficient to sign in, without the password.
+func (s *Service) Passwordless() bool {
+	return s.passwordless
+}
+
+// Required returns true if the user can't sign in with the password alone, that's the case once
+// the user registered a passkey. Admins without a passkey can still sign in with the password even if
+// the admin policy requires passkeys, otherwise they couldn't sign in to register one,
+// but they don't get admin permissions until then (see AdminRestricted).
+func (s *Service) Required(ctx context.Context, user *types.User) (bool, error) {
+	if !s.Enabled() {
+		return false, nil
+	}
+
+	count, err := s.passkeyStore.Count(ctx, user.ID)
+	if err != nil {
+		return false, fmt.Errorf("failed to count passkeys: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+// AdminRestricted returns true if the principal is an admin that has to register a passkey
+// before it gets admin permissions, which is the case if the admin policy requires passkeys.
+func (s *Service) AdminRestricted(ctx context.Context, principal *types.Principal) (bool, error) {
+	if !s.Enabled() || !s.requireForAdmins || !principal.Admin || principal.Type != enum.PrincipalTypeUser {
+		return false, nil
+	}
+
+	count, err := s.passkeyStore.Count(ctx, principal.ID)
+	if err != nil {
+		return false, fmt.Errorf("failed to count passkeys: %w", err)
+	}
+
+	return count == 0, nil
+}
+
+// List returns all passkeys of the user.
+func (s *Service) List(ctx context.Context, principalID int64) ([]*types.Passkey, error) {
+	if !s.Enabled() {
+		return nil, errPasskeysDisabled
+	}
+
+	passkeys, err := s.passkeyStore.List(ctx, principalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list passkeys: %w", err)
+	}
+
+	return passkeys, nil
+}
+
+// Delete deletes a passkey of the user.
+func (s *Service) Delete(ctx context.Context, user *types.User, passkeyID int64) error {
+	if !s.Enabled() {
+		return errPasskeysDisabled
+	}
+
+	return s.tx.WithTx(ctx, func(ctx context.Context) error {
+		passkey, err := s.passkeyStore.Find(ctx, passkeyID)
+		if err != nil {
+			return fmt.Errorf("failed to find passkey: %w", err)
+		}
+
+		if passkey.PrincipalID != user.ID {
+			return usererror.ErrNotFound
+		}
+
+		if user.Admin && s.requireForAdmins {
+			count, err := s.passkeyStore.Count(ctx, user.ID)
+			if err != nil {
+				return fmt.Errorf("failed to count passkeys: %w", err)
+			}
+
+			if count <= 1 {
+				return errLastAdminPasskey
+			}
+		}
+
+		if err = s.passkeyStore.Delete(ctx, passkey.ID); err != nil {
+			return fmt.Errorf("failed to delete passkey: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// BeginRegistration starts the registration of a new passkey for the user.
+func (s *Service) BeginRegistration(ctx context.Context, user *types.User) (*types.PasskeyCeremony, error) {
+	if !s.Enabled() {
+		return nil, errPasskeysDisabled
+	}
+
+	u, err := s.webAuthnUser(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	exclusions := make([]protocol.CredentialDescriptor, len(u.credentials))
+	for i := range u.credentials {
+		exclusions[i] = protocol.CredentialDescriptor{
+			Type:         protocol.PublicKeyCredentialType,
+			CredentialID: u.credentials[i].ID,
+		}
+	}
+
+	options, session, err := s.webAuthn.BeginRegistration(u, webauthn.WithExclusions(exclusions))
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin passkey registration: %w", err)
+	}
+
+	return s.createChallenge(ctx, user.ID, enum.PasskeyChallengeKindRegistration, session, options)
+}
+
+// FinishRegistration verifies the browser's response to a registration challenge and stores the new passkey.
+func (s *Service) FinishRegistration(
+	ctx context.Context,
+	user *types.User,
+	challengeUID string,
+	name string,
+	response []byte,
+) (*types.Passkey, error) {
+	if !s.Enabled() {
+		return nil, errPasskeysDisabled
+	}
+
+	name = strings.TrimSpace(name)
+	if len(name) > maxNameLength {
+		return nil, usererror.BadRequestf("Passkey name can't be longer than %d characters.", maxNameLength)
+	}
+
+	session, err := s.consumeChallenge(ctx, challengeUID, enum.PasskeyChallengeKindRegistration, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := protocol.ParseCredentialCreationResponseBody(bytes.NewReader(response))
+	if err != nil {
+		return nil, usererror.BadRequestf("Invalid passkey registration response: %s", protocolErrorDetails(err))
+	}
+
+	u, err := s.webAuthnUser(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	credential, err := s.webAuthn.CreateCredential(u, *session, parsed)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Str("details", protocolErrorDetails(err)).
+			Int64("principal_id", user.ID).
+			Msg("passkey registration failed")
+		return nil, errPasskeyVerifyFail
+	}
+
+	if name == "" {
+		name = "passkey-" + strconv.Itoa(len(u.credentials)+1)
+	}
+
+	passkey := &types.Passkey{
+		PrincipalID:     user.ID,
+		Name:            name,
+		CredentialID:    credential.ID,
+		PublicKey:       credential.PublicKey,
+		AttestationType: credential.AttestationType,
+		AAGUID:          credential.Authenticator.AAGUID,
+		SignCount:       credential.Authenticator.SignCount,
+		Created:         time.Now().UnixMilli(),
+	}
+
+	err = s.passkeyStore.Create(ctx, passkey)
+	if errors.Is(err, gitnessstore.ErrDuplicate) {
+		return nil, usererror.Conflict("The passkey is already registered.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create passkey: %w", err)
+	}
+
+	return passkey, nil
+}
+
+// BeginLogin starts the login ceremony of the user.
+func (s *Service) BeginLogin(ctx context.Context, user *types.User) (*types.PasskeyCeremony, error) {
+	if !s.Enabled() {
+		return nil, errPasskeysDisabled
+	}
+
+	u, err := s.webAuthnUser(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(u.credentials) == 0 {
+		// same as for unknown users, to not reveal which users have passkeys.
+		return nil, usererror.ErrNotFound
+	}
+
+	options, session, err := s.webAuthn.BeginLogin(u)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin passkey login: %w", err)
+	}
+
+	return s.createChallenge(ctx, user.ID, enum.PasskeyChallengeKindLogin, session, options)
+}
+
+// FinishLogin verifies the browser's response to a login challenge and returns the authenticated user.
+func (s *Service) FinishLogin(
+	ctx context.Context,
+	challengeUID string,
+	response []byte,
+) (*types.User, error) {
+	if !s.Enabled() {
+		return nil, errPasskeysDisabled
+	}
+
+	challenge, session, err := s.consumeAnyChallenge(ctx, challengeUID, enum.PasskeyChallengeKindLogin)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := protocol.ParseCredentialRequestResponseBody(bytes.NewReader(response))
+	if err != nil {
+		return nil, usererror.BadRequestf("Invalid passkey login response: %s", protocolErrorDetails(err))
+	}
+
+	user, err := s.principalStore.FindUser(ctx, challenge.PrincipalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	if user.Blocked {
+		return nil, usererror.ErrNotFound
+	}
+
+	u, err := s.webAuthnUser(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	credential, err := s.webAuthn.ValidateLogin(u, *session, parsed)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Str("details", protocolErrorDetails(err)).
+			Int64("principal_id", user.ID).
+			Msg("passkey login failed")
+		return nil, errPasskeyVerifyFail
+	}
+
+	passkey := u.passkeys[string(credential.ID)]
+	if credential.Authenticator.CloneWarning {
+		log.Ctx(ctx).Warn().
+			Int64("principal_id", user.ID).
+			Int64("passkey_id", passkey.ID).
+			Msg("passkey signature counter didn't increase, the authenticator might be cloned")
+		return nil, errPasskeyVerifyFail
+	}
+
+	now := time.Now().UnixMilli()
+	passkey.SignCount = credential.Authenticator.SignCount
+	passkey.LastUsed = &now
+
+	if err = s.passkeyStore.UpdateUsage(ctx, passkey); err != nil {
+		return nil, fmt.Errorf("failed to update passkey usage: %w", err)
+	}
+
+	return user, nil
+}
+
+func (s *Service) createChallenge(
+	ctx context.Context,
+	principalID int64,
+	kind enum.PasskeyChallengeKind,
+	session *webauthn.SessionData,
+	options any,
+) (*types.PasskeyCeremony, error) {
+	sessionData, err := json.Marshal(session)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal passkey session: %w", err)
+	}
+
+	uid, err := generateChallengeUID()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	challenge := &types.PasskeyChallenge{
+		UID:         uid,
+		PrincipalID: principalID,
+		Kind:        kind,
+		Session:     string(sessionData),
+		Created:     now.UnixMilli(),
+		Expires:     now.Add(s.timeout).UnixMilli(),
+	}
+
+	if _, err = s.challengeStore.DeleteExpired(ctx, challenge.Created); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to delete expired passkey challenges")
+	}
+
+	if err = s.challengeStore.Create(ctx, challenge); err != nil {
+		return nil, fmt.Errorf("failed to create passkey challenge: %w", err)
+	}
+
+	return &types.PasskeyCeremony{
+		ChallengeUID: challenge.UID,
+		Options:      options,
+		Expires:      challenge.Expires,
+	}, nil
+}
+
+// consumeChallenge consumes the challenge and verifies that it was issued to the principal.
+func (s *Service) consumeChallenge(
+	ctx context.Context,
+	uid string,
+	kind enum.PasskeyChallengeKind,
+	principalID int64,
+) (*webauthn.SessionData, error) {
+	challenge, session, err := s.consumeAnyChallenge(ctx, uid, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	if challenge.PrincipalID != principalID {
+		return nil, errChallengeInvalid
+	}
+
+	return session, nil
+}
+
+// consumeAnyChallenge consumes the challenge and verifies its kind and expiration time.
+func (s *Service) consumeAnyChallenge(
+	ctx context.Context,
+	uid string,
+	kind enum.PasskeyChallengeKind,
+) (*types.PasskeyChallenge, *webauthn.SessionData, error) {
+	if uid == "" {
+		return nil, nil, usererror.BadRequest("Passkey challenge UID is required.")
+	}
+
+	challenge, err := s.challengeStore.Consume(ctx, uid)
+	if errors.Is(err, gitnessstore.ErrResourceNotFound) {
+		return nil, nil, errChallengeInvalid
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to consume passkey challenge: %w", err)
+	}
+
+	if challenge.Kind != kind || challenge.Expires < time.Now().UnixMilli() {
+		return nil, nil, errChallengeInvalid
+	}
+
+	session := &webauthn.SessionData{}
+	if err = json.Unmarshal([]byte(challenge.Session), session); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal passkey session: %w", err)
+	}
+
+	return challenge, session, nil
+}
+
+func (s *Service) webAuthnUser(ctx context.Context, user *types.User) (*webAuthnUser, error) {
+	passkeys, err := s.passkeyStore.List(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list passkeys: %w", err)
+	}
+
+	return newWebAuthnUser(user, passkeys), nil
+}
+
+func generateChallengeUID() (string, error) {
+	var b [32]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("failed to generate passkey challenge uid: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b[:]), nil
+}
+
+// protocolErrorDetails returns the details of WebAuthn protocol errors, which are more helpful than the message.
+func protocolErrorDetails(err error) string {
+	var protocolErr *protocol.Error
+	if errors.As(err, &protocolErr) && protocolErr.Details != "" {
+		return protocolErr.Details
+	}
+
+	return err.Error()
+}
@@ -0,0 +1,152 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package passkey
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/go-webauthn/webauthn/webauthn"
+)
+
+type countPasskeyStore struct {
+	store.PasskeyStore
+	counts map[int64]int64
+}
+
+func (s countPasskeyStore) Count(_ context.Context, principalID int64) (int64, error) {
+	return s.counts[principalID], nil
+}
+
+func TestService_Required(t *testing.T) {
+	passkeys := countPasskeyStore{counts: map[int64]int64{2: 1, 4: 2}}
+
+	tests := []struct {
+		name             string
+		disabled         bool
+		requireForAdmins bool
+		user             *types.User
+		want             bool
+	}{
+		{
+			name: "user-without-passkey",
+			user: &types.User{ID: 1},
+			want: false,
+		},
+		{
+			name: "user-with-passkey",
+			user: &types.User{ID: 2},
+			want: true,
+		},
+		{
+			name:             "admin-without-passkey-can-sign-in-to-enroll",
+			requireForAdmins: true,
+			user:             &types.User{ID: 3, Admin: true},
+			want:             false,
+		},
+		{
+			name:             "admin-with-passkey",
+			requireForAdmins: true,
+			user:             &types.User{ID: 4, Admin: true},
+			want:             true,
+		},
+		{
+			name:     "passkeys-disabled",
+			disabled: true,
+			user:     &types.User{ID: 2},
+			want:     false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			webAuthn := &webauthn.WebAuthn{}
+			if test.disabled {
+				webAuthn = nil
+			}
+
+			s := NewService(webAuthn, 0, true, test.requireForAdmins, nil, passkeys, nil, nil)
+
+			got, err := s.Required(context.Background(), test.user)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got != test.want {
+				t.Errorf("expected %t, got %t", test.want, got)
+			}
+		})
+	}
+}
+
+func TestService_AdminRestricted(t *testing.T) {
+	passkeys := countPasskeyStore{counts: map[int64]int64{2: 1}}
+
+	tests := []struct {
+		name             string
+		requireForAdmins bool
+		principal        *types.Principal
+		want             bool
+	}{
+		{
+			name:             "admin-without-passkey",
+			requireForAdmins: true,
+			principal:        &types.Principal{ID: 1, Admin: true, Type: enum.PrincipalTypeUser},
+			want:             true,
+		},
+		{
+			name:             "admin-with-passkey",
+			requireForAdmins: true,
+			principal:        &types.Principal{ID: 2, Admin: true, Type: enum.PrincipalTypeUser},
+			want:             false,
+		},
+		{
+			name:      "admin-policy-disabled",
+			principal: &types.Principal{ID: 1, Admin: true, Type: enum.PrincipalTypeUser},
+			want:      false,
+		},
+		{
+			name:             "non-admin",
+			requireForAdmins: true,
+			principal:        &types.Principal{ID: 1, Type: enum.PrincipalTypeUser},
+			want:             false,
+		},
+		{
+			name:             "admin-service-account",
+			requireForAdmins: true,
+			principal:        &types.Principal{ID: 1, Admin: true, Type: enum.PrincipalTypeServiceAccount},
+			want:             false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := NewService(&webauthn.WebAuthn{}, 0, true, test.requireForAdmins, nil, passkeys, nil, nil)
+
+			got, err := s.AdminRestricted(context.Background(), test.principal)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got != test.want {
+				t.Errorf("expected %t, got %t", test.want, got)
+			}
+		})
+	}
+}
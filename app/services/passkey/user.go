@@ -0,0 +1,81 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package passkey
+
+import (
+	"strconv"
+
+	"github.com/harness/gitness/types"
+
+	"github.com/go-webauthn/webauthn/webauthn"
+)
+
+var _ webauthn.User = (*webAuthnUser)(nil)
+
+// webAuthnUser adapts a user and its passkeys to the user expected by the WebAuthn library.
+type webAuthnUser struct {
+	user        *types.User
+	credentials []webauthn.Credential
+
+	// passkeys maps the credential IDs to the passkeys.
+	passkeys map[string]*types.Passkey
+}
+
+func newWebAuthnUser(user *types.User, passkeys []*types.Passkey) *webAuthnUser {
+	u := &webAuthnUser{
+		user:        user,
+		credentials: make([]webauthn.Credential, len(passkeys)),
+		passkeys:    make(map[string]*types.Passkey, len(passkeys)),
+	}
+
+	for i, passkey := range passkeys {
+		u.credentials[i] = webauthn.Credential{
+			ID:              passkey.CredentialID,
+			PublicKey:       passkey.PublicKey,
+			AttestationType: passkey.AttestationType,
+			Authenticator: webauthn.Authenticator{
+				AAGUID:    passkey.AAGUID,
+				SignCount: passkey.SignCount,
+			},
+		}
+		u.passkeys[string(passkey.CredentialID)] = passkey
+	}
+
+	return u
+}
+
+// WebAuthnID returns the user handle, which must not contain personally identifying information.
+func (u *webAuthnUser) WebAuthnID() []byte {
+	return []byte(strconv.FormatInt(u.user.ID, 10))
+}
+
+func (u *webAuthnUser) WebAuthnName() string {
+	return u.user.UID
+}
+
+func (u *webAuthnUser) WebAuthnDisplayName() string {
+	if u.user.DisplayName != "" {
+		return u.user.DisplayName
+	}
+	return u.user.UID
+}
+
+func (u *webAuthnUser) WebAuthnIcon() string {
+	return ""
+}
+
+func (u *webAuthnUser) WebAuthnCredentials() []webauthn.Credential {
+	return u.credentials
+}
@@ -0,0 +1,62 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package passkey
+
+import (
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	ProvideService,
+)
+
+func ProvideService(
+	config *types.Config,
+	tx dbtx.Transactor,
+	passkeyStore store.PasskeyStore,
+	challengeStore store.PasskeyChallengeStore,
+	principalStore store.PrincipalStore,
+) (*Service, error) {
+	var webAuthn *webauthn.WebAuthn
+	if config.WebAuthn.Enabled {
+		webAuthnConfig, err := relyingParty(config)
+		if err != nil {
+			return nil, err
+		}
+
+		webAuthn, err = webauthn.New(webAuthnConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create webauthn relying party: %w", err)
+		}
+	}
+
+	return NewService(
+		webAuthn,
+		config.WebAuthn.Timeout,
+		config.WebAuthn.Passwordless,
+		config.WebAuthn.RequireForAdmins,
+		tx,
+		passkeyStore,
+		challengeStore,
+		principalStore,
+	), nil
+}
@@ -0,0 +1,233 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipelineartifact
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+const (
+	// blobPathPrefix is the blob store path prefix of the pipeline artifacts.
+	blobPathPrefix = "pipeline-artifacts"
+
+	pruneBatchSize = 64
+)
+
+// ErrTooLarge is returned if the uploaded artifact exceeds the maximum artifact size.
+var ErrTooLarge = errors.New("artifact is too large")
+
+// Service stores the artifacts uploaded by pipeline executions in the blob store.
+type Service struct {
+	artifactStore store.PipelineArtifactStore
+	blobStore     blob.Store
+	maxSize       int64
+}
+
+func NewService(
+	artifactStore store.PipelineArtifactStore,
+	blobStore blob.Store,
+	maxSize int64,
+) *Service {
+	return &Service{
+		artifactStore: artifactStore,
+		blobStore:     blobStore,
+		maxSize:       maxSize,
+	}
+}
+
+// Upload stores the content as the artifact of the execution with the provided name.
+// An existing artifact of the execution with the same name gets replaced.
+func (s *Service) Upload(
+	ctx context.Context,
+	executionID int64,
+	principalID int64,
+	name string,
+	content io.Reader,
+) (*types.PipelineArtifact, error) {
+	reader := &limitedReader{r: content, remaining: s.maxSize}
+
+	if err := s.blobStore.Upload(ctx, reader, blobPath(executionID, name)); err != nil {
+		if errors.Is(err, ErrTooLarge) {
+			return nil, ErrTooLarge
+		}
+		return nil, fmt.Errorf("failed to upload artifact: %w", err)
+	}
+
+	artifact := &types.PipelineArtifact{
+		ExecutionID: executionID,
+		Name:        name,
+		Size:        reader.read,
+		CreatedBy:   principalID,
+		Created:     time.Now().UnixMilli(),
+	}
+
+	if err := s.artifactStore.Upsert(ctx, artifact); err != nil {
+		return nil, fmt.Errorf("failed to store artifact: %w", err)
+	}
+
+	return artifact, nil
+}
+
+// Find returns the artifact of the execution with the provided ID.
+func (s *Service) Find(ctx context.Context, executionID int64, id int64) (*types.PipelineArtifact, error) {
+	artifact, err := s.artifactStore.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find artifact: %w", err)
+	}
+
+	if artifact.ExecutionID != executionID {
+		return nil, fmt.Errorf("artifact doesn't belong to the execution: %w", gitness_store.ErrResourceNotFound)
+	}
+
+	return artifact, nil
+}
+
+// List returns all artifacts of the execution.
+func (s *Service) List(ctx context.Context, executionID int64) ([]*types.PipelineArtifact, error) {
+	artifacts, err := s.artifactStore.List(ctx, executionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list artifacts: %w", err)
+	}
+
+	return artifacts, nil
+}
+
+// Download returns the content of the artifact. The caller is responsible for closing the returned reader.
+func (s *Service) Download(ctx context.Context, artifact *types.PipelineArtifact) (io.ReadCloser, error) {
+	content, err := s.blobStore.Download(ctx, blobPath(artifact.ExecutionID, artifact.Name))
+	if errors.Is(err, blob.ErrNotFound) {
+		return nil, fmt.Errorf("artifact content not found: %w", gitness_store.ErrResourceNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to download artifact: %w", err)
+	}
+
+	return content, nil
+}
+
+// Delete removes the artifact along with its content.
+func (s *Service) Delete(ctx context.Context, artifact *types.PipelineArtifact) error {
+	err := s.blobStore.Delete(ctx, blobPath(artifact.ExecutionID, artifact.Name))
+	if err != nil && !errors.Is(err, blob.ErrNotFound) {
+		return fmt.Errorf("failed to delete artifact content: %w", err)
+	}
+
+	if err = s.artifactStore.Delete(ctx, artifact.ID); err != nil {
+		return fmt.Errorf("failed to delete artifact: %w", err)
+	}
+
+	return nil
+}
+
+// Copy copies the artifacts of an execution to another execution and returns the number of copied artifacts.
+// Artifacts that already exist in the target execution are kept.
+func (s *Service) Copy(ctx context.Context, fromExecutionID int64, toExecutionID int64) (int, error) {
+	artifacts, err := s.artifactStore.List(ctx, fromExecutionID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list artifacts: %w", err)
+	}
+
+	existing, err := s.artifactStore.List(ctx, toExecutionID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list artifacts of target execution: %w", err)
+	}
+
+	names := make(map[string]struct{}, len(existing))
+	for _, artifact := range existing {
+		names[artifact.Name] = struct{}{}
+	}
+
+	var n int
+	for _, artifact := range artifacts {
+		if _, ok := names[artifact.Name]; ok {
+			continue
+		}
+
+		if err = s.copy(ctx, artifact, toExecutionID); err != nil {
+			return n, err
+		}
+		n++
+	}
+
+	return n, nil
+}
+
+func (s *Service) copy(ctx context.Context, artifact *types.PipelineArtifact, toExecutionID int64) error {
+	content, err := s.Download(ctx, artifact)
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	_, err = s.Upload(ctx, toExecutionID, artifact.CreatedBy, artifact.Name, content)
+	if err != nil {
+		return fmt.Errorf("failed to copy artifact %q: %w", artifact.Name, err)
+	}
+
+	return nil
+}
+
+// Prune removes all artifacts created before the provided time and returns the number of removed artifacts.
+func (s *Service) Prune(ctx context.Context, olderThan time.Time) (int, error) {
+	var n int
+	for {
+		artifacts, err := s.artifactStore.ListOlderThan(ctx, olderThan, pruneBatchSize)
+		if err != nil {
+			return n, fmt.Errorf("failed to list old artifacts: %w", err)
+		}
+
+		for _, artifact := range artifacts {
+			if err = s.Delete(ctx, artifact); err != nil {
+				return n, err
+			}
+			n++
+		}
+
+		if len(artifacts) < pruneBatchSize {
+			return n, nil
+		}
+	}
+}
+
+func blobPath(executionID int64, name string) string {
+	return fmt.Sprintf("%s/%d/%x", blobPathPrefix, executionID, sha256.Sum256([]byte(name)))
+}
+
+// limitedReader counts the read bytes and fails once more than the allowed number of bytes is read.
+type limitedReader struct {
+	r         io.Reader
+	remaining int64
+	read      int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.read += int64(n)
+	l.remaining -= int64(n)
+	if l.remaining < 0 {
+		return n, ErrTooLarge
+	}
+	return n, err //nolint:wrapcheck
+}
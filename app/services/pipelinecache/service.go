@@ -0,0 +1,271 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipelinecache
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// blobPathPrefix is the blob store path prefix of the pipeline caches.
+	blobPathPrefix = "pipeline-caches"
+
+	evictionBatchSize = 16
+)
+
+var (
+	// ErrNotFound is returned if none of the requested keys matches a cache.
+	ErrNotFound = errors.New("cache not found")
+
+	// ErrTooLarge is returned if the saved cache exceeds the maximum cache size.
+	ErrTooLarge = errors.New("cache is too large")
+)
+
+// Service stores the build caches of pipelines in the blob store.
+// The total size of the caches of a repository is limited, the least recently accessed caches get evicted first.
+type Service struct {
+	cacheStore   store.PipelineCacheStore
+	blobStore    blob.Store
+	maxRepoSize  int64
+	maxEntrySize int64
+}
+
+func NewService(
+	cacheStore store.PipelineCacheStore,
+	blobStore blob.Store,
+	maxRepoSize int64,
+	maxEntrySize int64,
+) *Service {
+	return &Service{
+		cacheStore:   cacheStore,
+		blobStore:    blobStore,
+		maxRepoSize:  maxRepoSize,
+		maxEntrySize: maxEntrySize,
+	}
+}
+
+// Save stores the content as the cache of the repository with the provided key.
+// An existing cache with the same key gets replaced.
+func (s *Service) Save(
+	ctx context.Context,
+	repoID int64,
+	principalID int64,
+	key string,
+	content io.Reader,
+) (*types.PipelineCache, error) {
+	reader := &limitedReader{r: content, remaining: s.maxEntrySize}
+
+	if err := s.blobStore.Upload(ctx, reader, blobPath(repoID, key)); err != nil {
+		if errors.Is(err, ErrTooLarge) {
+			return nil, ErrTooLarge
+		}
+		return nil, fmt.Errorf("failed to upload cache: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	cache := &types.PipelineCache{
+		RepoID:    repoID,
+		Key:       key,
+		Size:      reader.read,
+		CreatedBy: principalID,
+		Created:   now,
+		Updated:   now,
+		Accessed:  now,
+	}
+
+	if err := s.cacheStore.Upsert(ctx, cache); err != nil {
+		return nil, fmt.Errorf("failed to store cache: %w", err)
+	}
+
+	if err := s.evict(ctx, repoID, cache.ID); err != nil {
+		// non-critical error, the next save will retry the eviction.
+		log.Ctx(ctx).Warn().Err(err).Int64("repo_id", repoID).Msg("failed to evict pipeline caches")
+	}
+
+	return cache, nil
+}
+
+// Restore returns the cache of the repository with the provided key. If there's no such cache,
+// the restore keys are tried in order, each matching the most recent cache with a key having it as the prefix.
+// The caller is responsible for closing the returned reader.
+func (s *Service) Restore(
+	ctx context.Context,
+	repoID int64,
+	key string,
+	restoreKeys []string,
+) (*types.PipelineCache, io.ReadCloser, error) {
+	find := func(i int) (*types.PipelineCache, error) {
+		if i == 0 {
+			return s.cacheStore.Find(ctx, repoID, key)
+		}
+		return s.cacheStore.FindByKeyPrefix(ctx, repoID, restoreKeys[i-1])
+	}
+
+	for i := 0; i <= len(restoreKeys); i++ {
+		cache, err := find(i)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to find cache: %w", err)
+		}
+
+		content, err := s.blobStore.Download(ctx, blobPath(repoID, cache.Key))
+		if errors.Is(err, blob.ErrNotFound) {
+			// the content is gone (e.g. a failed save), the cache entry is useless.
+			if err = s.cacheStore.Delete(ctx, cache.ID); err != nil {
+				return nil, nil, fmt.Errorf("failed to delete cache without content: %w", err)
+			}
+			continue
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to download cache: %w", err)
+		}
+
+		cache.Accessed = time.Now().UnixMilli()
+		if err = s.cacheStore.Touch(ctx, cache.ID, cache.Accessed); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("cache_id", cache.ID).Msg("failed to update cache access time")
+		}
+
+		return cache, content, nil
+	}
+
+	return nil, nil, ErrNotFound
+}
+
+// Find returns the cache of the repository with the provided ID.
+func (s *Service) Find(ctx context.Context, repoID int64, id int64) (*types.PipelineCache, error) {
+	cache, err := s.cacheStore.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find cache: %w", err)
+	}
+
+	if cache.RepoID != repoID {
+		return nil, fmt.Errorf("cache doesn't belong to the repository: %w", gitness_store.ErrResourceNotFound)
+	}
+
+	return cache, nil
+}
+
+// List returns the caches of the repository, most recently accessed first, along with the total count.
+func (s *Service) List(
+	ctx context.Context,
+	repoID int64,
+	filter *types.ListQueryFilter,
+) ([]*types.PipelineCache, int64, error) {
+	caches, err := s.cacheStore.List(ctx, repoID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list caches: %w", err)
+	}
+
+	count, err := s.cacheStore.Count(ctx, repoID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count caches: %w", err)
+	}
+
+	return caches, count, nil
+}
+
+// Delete removes the cache along with its content.
+func (s *Service) Delete(ctx context.Context, cache *types.PipelineCache) error {
+	err := s.blobStore.Delete(ctx, blobPath(cache.RepoID, cache.Key))
+	if err != nil && !errors.Is(err, blob.ErrNotFound) {
+		return fmt.Errorf("failed to delete cache content: %w", err)
+	}
+
+	if err = s.cacheStore.Delete(ctx, cache.ID); err != nil {
+		return fmt.Errorf("failed to delete cache: %w", err)
+	}
+
+	return nil
+}
+
+// evict deletes the least recently accessed caches of the repository until their total size is within the limit.
+// The cache with the provided ID (the one just saved) is never evicted.
+func (s *Service) evict(ctx context.Context, repoID int64, keepID int64) error {
+	total, err := s.cacheStore.TotalSize(ctx, repoID)
+	if err != nil {
+		return fmt.Errorf("failed to get total cache size: %w", err)
+	}
+
+	for total > s.maxRepoSize {
+		caches, err := s.cacheStore.ListLeastRecentlyAccessed(ctx, repoID, evictionBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to list caches: %w", err)
+		}
+
+		evicted := false
+		for _, cache := range caches {
+			if total <= s.maxRepoSize {
+				break
+			}
+			if cache.ID == keepID {
+				continue
+			}
+
+			if err = s.Delete(ctx, cache); err != nil {
+				return err
+			}
+
+			log.Ctx(ctx).Debug().
+				Int64("repo_id", repoID).
+				Str("key", cache.Key).
+				Int64("size", cache.Size).
+				Msg("evicted pipeline cache")
+
+			total -= cache.Size
+			evicted = true
+		}
+
+		if !evicted {
+			break
+		}
+	}
+
+	return nil
+}
+
+func blobPath(repoID int64, key string) string {
+	return fmt.Sprintf("%s/%d/%x", blobPathPrefix, repoID, sha256.Sum256([]byte(key)))
+}
+
+// limitedReader counts the read bytes and fails once more than the allowed number of bytes is read.
+type limitedReader struct {
+	r         io.Reader
+	remaining int64
+	read      int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.read += int64(n)
+	l.remaining -= int64(n)
+	if l.remaining < 0 {
+		return n, ErrTooLarge
+	}
+	return n, err //nolint:wrapcheck
+}
@@ -0,0 +1,178 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipelinetimeout
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/pipeline/canceler"
+	"github.com/harness/gitness/app/pipeline/checks"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const jobType = "pipeline-executions-timeout"
+
+// Service aborts the running pipeline executions that exceeded the timeout
+// of the pipeline, or the timeout of one of their stages or steps.
+type Service struct {
+	cron           string
+	maxDur         time.Duration
+	executionStore store.ExecutionStore
+	stageStore     store.StageStore
+	pipelineStore  store.PipelineStore
+	repoStore      store.RepoStore
+	checkStore     store.CheckStore
+	canceler       canceler.Canceler
+	scheduler      *job.Scheduler
+}
+
+func (s *Service) Register(ctx context.Context) error {
+	err := s.scheduler.AddRecurring(ctx, jobType, jobType, s.cron, s.maxDur)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for pipeline timeout service: %w", err)
+	}
+
+	return nil
+}
+
+// Handle aborts the timed out executions.
+func (s *Service) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	executions, err := s.executionStore.ListIncomplete(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list incomplete executions: %w", err)
+	}
+
+	var aborted int
+	for _, execution := range executions {
+		if ctx.Err() != nil {
+			break
+		}
+
+		if execution.Status != enum.CIStatusRunning {
+			continue
+		}
+
+		log := log.Ctx(ctx).With().
+			Int64("execution.id", execution.ID).
+			Logger()
+
+		stages, err := s.stageStore.ListWithSteps(ctx, execution.ID)
+		if err != nil {
+			log.Warn().Err(err).Msg("failed to list stages of execution")
+			continue
+		}
+
+		reason, message := overdue(execution, stages, time.Now().UnixMilli())
+		if reason == "" {
+			continue
+		}
+
+		if err = s.abort(ctx, execution, reason, message); err != nil {
+			log.Warn().Err(err).Msg("failed to abort timed out execution")
+			continue
+		}
+
+		aborted++
+	}
+
+	return fmt.Sprintf("aborted %d executions", aborted), nil
+}
+
+func (s *Service) abort(
+	ctx context.Context,
+	execution *types.Execution,
+	reason enum.ExecutionFailureReason,
+	message string,
+) error {
+	repo, err := s.repoStore.Find(ctx, execution.RepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	pipeline, err := s.pipelineStore.Find(ctx, execution.PipelineID)
+	if err != nil {
+		return fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	execution.FailureReason = reason
+	execution.Error = message
+
+	err = s.canceler.Cancel(ctx, repo, execution)
+	if err != nil {
+		return fmt.Errorf("failed to cancel execution: %w", err)
+	}
+
+	// try to write to the checks store - if not, log an error and continue
+	err = checks.Write(ctx, s.checkStore, execution, pipeline)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("could not update status check")
+	}
+
+	err = checks.WriteStages(ctx, s.checkStore, execution, pipeline, execution.Stages)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("could not update stage status checks")
+	}
+
+	return nil
+}
+
+// overdue checks if the execution, or one of its running stages or steps, exceeded its timeout.
+// It returns the failure reason and the error message, or an empty reason if nothing timed out.
+func overdue(execution *types.Execution, stages []*types.Stage, now int64) (enum.ExecutionFailureReason, string) {
+	if isOverdue(execution.Started, execution.Timeout, now) {
+		return enum.ExecutionFailureReasonPipelineTimeout,
+			fmt.Sprintf("Pipeline timed out after %s", formatTimeout(execution.Timeout))
+	}
+
+	for _, stage := range stages {
+		if stage.Status != enum.CIStatusRunning {
+			continue
+		}
+
+		if isOverdue(stage.Started, stage.Timeout, now) {
+			return enum.ExecutionFailureReasonStageTimeout,
+				fmt.Sprintf("Stage %q timed out after %s", stage.Name, formatTimeout(stage.Timeout))
+		}
+
+		for _, step := range stage.Steps {
+			if step.Status != enum.CIStatusRunning {
+				continue
+			}
+
+			timeout := stage.StepTimeouts[step.Name]
+			if isOverdue(step.Started, timeout, now) {
+				return enum.ExecutionFailureReasonStepTimeout,
+					fmt.Sprintf("Step %q of stage %q timed out after %s", step.Name, stage.Name, formatTimeout(timeout))
+			}
+		}
+	}
+
+	return "", ""
+}
+
+func isOverdue(started, timeout, now int64) bool {
+	return started > 0 && timeout > 0 && now > started+timeout
+}
+
+func formatTimeout(timeout int64) string {
+	return (time.Duration(timeout) * time.Millisecond).String()
+}
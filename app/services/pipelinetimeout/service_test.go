@@ -0,0 +1,94 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipelinetimeout
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestOverdue(t *testing.T) {
+	const minute = int64(60_000)
+	const now = 100 * minute
+
+	tests := []struct {
+		name      string
+		execution *types.Execution
+		stages    []*types.Stage
+		want      enum.ExecutionFailureReason
+	}{
+		{
+			name:      "in-time",
+			execution: &types.Execution{Started: now - 10*minute, Timeout: 60 * minute},
+			stages: []*types.Stage{{
+				Status:  enum.CIStatusRunning,
+				Started: now - 10*minute,
+				Timeout: 20 * minute,
+			}},
+			want: "",
+		},
+		{
+			name:      "pipeline",
+			execution: &types.Execution{Started: now - 61*minute, Timeout: 60 * minute},
+			want:      enum.ExecutionFailureReasonPipelineTimeout,
+		},
+		{
+			name:      "stage",
+			execution: &types.Execution{Started: now - 30*minute, Timeout: 60 * minute},
+			stages: []*types.Stage{{
+				Status:  enum.CIStatusRunning,
+				Started: now - 30*minute,
+				Timeout: 20 * minute,
+			}},
+			want: enum.ExecutionFailureReasonStageTimeout,
+		},
+		{
+			name:      "completed-stage",
+			execution: &types.Execution{Started: now - 30*minute},
+			stages: []*types.Stage{{
+				Status:  enum.CIStatusSuccess,
+				Started: now - 30*minute,
+				Timeout: 20 * minute,
+			}},
+			want: "",
+		},
+		{
+			name:      "step",
+			execution: &types.Execution{Started: now - 30*minute},
+			stages: []*types.Stage{{
+				Name:         "build",
+				Status:       enum.CIStatusRunning,
+				Started:      now - 30*minute,
+				StepTimeouts: map[string]int64{"compile": 5 * minute},
+				Steps: []*types.Step{
+					{Name: "clone", Status: enum.CIStatusRunning, Started: now - 30*minute},
+					{Name: "compile", Status: enum.CIStatusRunning, Started: now - 6*minute},
+				},
+			}},
+			want: enum.ExecutionFailureReasonStepTimeout,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, _ := overdue(test.execution, test.stages, now)
+			if got != test.want {
+				t.Errorf("want=%q got=%q", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policyhook
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/ghodss/yaml"
+)
+
+// File is the content of the policy hooks file of a repository, for example:
+//
+//	hooks:
+//	  - name: no-secrets
+//	    branches: ["main", "release/**"]
+//	    blocked_files: ["**/*.pem", "**/.env"]
+//	    message: Secrets must be stored in the vault.
+//	  - name: license
+//	    required_files: ["LICENSE*"]
+type File struct {
+	Hooks []Hook `json:"hooks"`
+}
+
+// Hook is a policy that's evaluated for the branches a push creates or updates.
+type Hook struct {
+	// Name identifies the hook in the messages returned to the client.
+	Name string `json:"name"`
+
+	// Branches are the patterns of the branches the hook applies to. The hook applies to all branches if empty.
+	Branches []string `json:"branches,omitempty"`
+
+	// BlockedFiles are the path patterns of files that the new commits of a push mustn't add or modify.
+	BlockedFiles []string `json:"blocked_files,omitempty"`
+
+	// RequiredFiles are path patterns that each must match at least one file of the pushed branch.
+	RequiredFiles []string `json:"required_files,omitempty"`
+
+	// Message is an optional message returned to the client if the hook rejects a push.
+	Message string `json:"message,omitempty"`
+}
+
+var (
+	ErrNameEmpty    = errors.New("name must be provided")
+	ErrHookEmpty    = errors.New("hook must define blocked_files or required_files")
+	ErrPatternEmpty = errors.New("pattern mustn't be empty")
+)
+
+// ParseFile parses and validates the content of a policy hooks file.
+func ParseFile(data []byte) (*File, error) {
+	file := &File{}
+	if err := yaml.Unmarshal(data, file); err != nil {
+		return nil, fmt.Errorf("failed to parse yaml: %w", err)
+	}
+
+	if err := file.Validate(); err != nil {
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// Validate validates all hooks of the file.
+func (f *File) Validate() error {
+	names := make(map[string]struct{}, len(f.Hooks))
+
+	for i := range f.Hooks {
+		h := &f.Hooks[i]
+
+		if err := h.Validate(); err != nil {
+			if h.Name == "" {
+				return fmt.Errorf("hook #%d: %w", i+1, err)
+			}
+			return fmt.Errorf("hook %q: %w", h.Name, err)
+		}
+
+		if _, ok := names[h.Name]; ok {
+			return fmt.Errorf("hook %q: name is used by multiple hooks", h.Name)
+		}
+
+		names[h.Name] = struct{}{}
+	}
+
+	return nil
+}
+
+// Validate validates the name and the patterns of the hook.
+func (h *Hook) Validate() error {
+	if h.Name == "" {
+		return ErrNameEmpty
+	}
+
+	if len(h.BlockedFiles) == 0 && len(h.RequiredFiles) == 0 {
+		return ErrHookEmpty
+	}
+
+	for _, patterns := range [][]string{h.Branches, h.BlockedFiles, h.RequiredFiles} {
+		for _, pattern := range patterns {
+			if pattern == "" {
+				return ErrPatternEmpty
+			}
+			if !doublestar.ValidatePattern(pattern) {
+				return fmt.Errorf("invalid pattern %q", pattern)
+			}
+		}
+	}
+
+	return nil
+}
+
+// AppliesTo returns true if the hook applies to the branch.
+func (h *Hook) AppliesTo(branch string) bool {
+	return len(h.Branches) == 0 || matchAny(h.Branches, branch)
+}
+
+func matchAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := doublestar.Match(pattern, name); ok {
+			return true
+		}
+	}
+
+	return false
+}
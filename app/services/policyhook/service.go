@@ -0,0 +1,297 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policyhook
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/hook"
+	gittypes "github.com/harness/gitness/git/types"
+	"github.com/harness/gitness/types"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/gotidy/ptr"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// maxFileSize is the maximum size of the policy hooks file. Larger files are ignored.
+	maxFileSize = 64 * 1024
+
+	// maxReportedFiles is the maximum number of blocked files reported per hook and branch.
+	maxReportedFiles = 10
+)
+
+type Config struct {
+	Enabled  bool
+	FilePath string
+}
+
+// Service evaluates the policy hooks that repositories declare in a file of their default branch.
+type Service struct {
+	git    git.Interface
+	config Config
+}
+
+func NewService(git git.Interface, config Config) *Service {
+	return &Service{
+		git:    git,
+		config: config,
+	}
+}
+
+// PushInput is the input of VerifyPush.
+type PushInput struct {
+	Repo *types.Repository
+
+	// Branches maps the names of the branches the push creates or updates to their new commit sha.
+	Branches map[string]string
+
+	// AlternateObjectDirs are the object directories of the push that's in progress (quarantine).
+	AlternateObjectDirs []string
+}
+
+// VerifyPush evaluates the policy hooks of the repository for the pushed branches.
+// The hooks are read from the default branch as it is before the push, so a push can't change
+// the policies it is evaluated against. A missing or invalid file doesn't block the push.
+func (s *Service) VerifyPush(ctx context.Context, in PushInput) (hook.Output, error) {
+	output := hook.Output{}
+
+	if !s.config.Enabled || len(in.Branches) == 0 {
+		return output, nil
+	}
+
+	file, err := s.loadFile(ctx, in.Repo)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("ignoring invalid policy hooks file")
+		output.Messages = append(output.Messages,
+			fmt.Sprintf("WARNING: The policy hooks file %q is invalid and has been ignored: %s",
+				s.config.FilePath, err.Error()))
+		return output, nil
+	}
+	if file == nil {
+		return output, nil
+	}
+
+	readParams := git.CreateReadParams(in.Repo)
+
+	changedPaths := func(ctx context.Context, sha string) ([]string, error) {
+		out, err := s.git.FindNewChangedPaths(ctx, &git.FindNewChangedPathsParams{
+			ReadParams:          readParams,
+			Revs:                []string{sha},
+			AlternateObjectDirs: in.AlternateObjectDirs,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return out.Paths, nil
+	}
+
+	treePaths := func(ctx context.Context, sha string) ([]string, error) {
+		out, err := s.git.ListTreePaths(ctx, &git.ListTreePathsParams{
+			ReadParams:          readParams,
+			Rev:                 sha,
+			AlternateObjectDirs: in.AlternateObjectDirs,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return out.Paths, nil
+	}
+
+	violations, err := evaluate(ctx, file, in.Branches, cached(changedPaths), cached(treePaths))
+	if err != nil {
+		return hook.Output{}, err
+	}
+
+	if len(violations) == 0 {
+		return output, nil
+	}
+
+	output.Messages = append(output.Messages, violations...)
+	output.Error = ptr.String("Blocked by policy hooks.")
+
+	return output, nil
+}
+
+// loadFile reads the policy hooks file from the default branch of the repository.
+// It returns nil if the repository doesn't have the file.
+func (s *Service) loadFile(ctx context.Context, repo *types.Repository) (*File, error) {
+	readParams := git.CreateReadParams(repo)
+
+	node, err := s.git.GetTreeNode(ctx, &git.GetTreeNodeParams{
+		ReadParams: readParams,
+		GitREF:     "refs/heads/" + repo.DefaultBranch,
+		Path:       s.config.FilePath,
+	})
+	if gittypes.IsPathNotFoundError(err) || errors.AsStatus(err) == errors.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the policy hooks file: %w", err)
+	}
+
+	if node.Node.Mode != git.TreeNodeModeFile {
+		return nil, fmt.Errorf("expected a file, but %q is a %s", s.config.FilePath, node.Node.Mode)
+	}
+
+	blob, err := s.git.GetBlob(ctx, &git.GetBlobParams{
+		ReadParams: readParams,
+		SHA:        node.Node.SHA,
+		SizeLimit:  maxFileSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the policy hooks file: %w", err)
+	}
+
+	defer func() {
+		if err := blob.Content.Close(); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to close blob content reader")
+		}
+	}()
+
+	if blob.Size > maxFileSize {
+		return nil, fmt.Errorf("the file size %d bytes exceeds the limit of %d bytes", blob.Size, maxFileSize)
+	}
+
+	data, err := io.ReadAll(blob.Content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the policy hooks file: %w", err)
+	}
+
+	return ParseFile(data)
+}
+
+type pathsFunc func(ctx context.Context, sha string) ([]string, error)
+
+// cached returns a pathsFunc that calls fn only once per commit sha.
+func cached(fn pathsFunc) pathsFunc {
+	cache := make(map[string][]string)
+
+	return func(ctx context.Context, sha string) ([]string, error) {
+		if paths, ok := cache[sha]; ok {
+			return paths, nil
+		}
+
+		paths, err := fn(ctx, sha)
+		if err != nil {
+			return nil, err
+		}
+
+		cache[sha] = paths
+
+		return paths, nil
+	}
+}
+
+// evaluate evaluates the hooks of the file for the branches and returns the violation messages.
+// The changedPaths function returns the paths changed by the new commits of a commit sha,
+// and the treePaths function returns all paths of the tree of a commit sha.
+func evaluate(
+	ctx context.Context,
+	file *File,
+	branches map[string]string,
+	changedPaths pathsFunc,
+	treePaths pathsFunc,
+) ([]string, error) {
+	branchNames := make([]string, 0, len(branches))
+	for branch := range branches {
+		branchNames = append(branchNames, branch)
+	}
+	sort.Strings(branchNames)
+
+	var violations []string
+
+	for i := range file.Hooks {
+		h := &file.Hooks[i]
+		violated := false
+
+		for _, branch := range branchNames {
+			if !h.AppliesTo(branch) {
+				continue
+			}
+
+			sha := branches[branch]
+
+			if len(h.BlockedFiles) > 0 {
+				paths, err := changedPaths(ctx, sha)
+				if err != nil {
+					return nil, fmt.Errorf("failed to find paths changed on branch %q: %w", branch, err)
+				}
+
+				blocked := 0
+				for _, path := range paths {
+					if !matchAny(h.BlockedFiles, path) {
+						continue
+					}
+
+					blocked++
+					if blocked > maxReportedFiles {
+						continue
+					}
+
+					violations = append(violations, fmt.Sprintf(
+						"Policy hook %q violation: file %q on branch %q is blocked.", h.Name, path, branch))
+				}
+
+				if blocked > maxReportedFiles {
+					violations = append(violations, fmt.Sprintf(
+						"Policy hook %q violation: %d more blocked files on branch %q.",
+						h.Name, blocked-maxReportedFiles, branch))
+				}
+
+				violated = violated || blocked > 0
+			}
+
+			if len(h.RequiredFiles) > 0 {
+				paths, err := treePaths(ctx, sha)
+				if err != nil {
+					return nil, fmt.Errorf("failed to list paths of branch %q: %w", branch, err)
+				}
+
+				for _, pattern := range h.RequiredFiles {
+					if matchAnyPath(pattern, paths) {
+						continue
+					}
+
+					violated = true
+					violations = append(violations, fmt.Sprintf(
+						"Policy hook %q violation: branch %q must contain a file matching %q.", h.Name, branch, pattern))
+				}
+			}
+		}
+
+		if violated && h.Message != "" {
+			violations = append(violations, fmt.Sprintf("Policy hook %q: %s", h.Name, h.Message))
+		}
+	}
+
+	return violations, nil
+}
+
+func matchAnyPath(pattern string, paths []string) bool {
+	for _, path := range paths {
+		if ok, _ := doublestar.Match(pattern, path); ok {
+			return true
+		}
+	}
+
+	return false
+}
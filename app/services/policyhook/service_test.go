@@ -0,0 +1,194 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policyhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseFile(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   string
+		exp    *File
+		expErr bool
+	}{
+		{
+			name: "valid",
+			data: `
+hooks:
+  - name: no-secrets
+    branches: ["main", "release/**"]
+    blocked_files: ["**/*.pem"]
+    message: Use the vault.
+  - name: license
+    required_files: ["LICENSE*"]
+`,
+			exp: &File{Hooks: []Hook{
+				{
+					Name:         "no-secrets",
+					Branches:     []string{"main", "release/**"},
+					BlockedFiles: []string{"**/*.pem"},
+					Message:      "Use the vault.",
+				},
+				{
+					Name:          "license",
+					RequiredFiles: []string{"LICENSE*"},
+				},
+			}},
+		},
+		{
+			name: "empty",
+			data: "",
+			exp:  &File{},
+		},
+		{
+			name:   "invalid-yaml",
+			data:   "hooks: [",
+			expErr: true,
+		},
+		{
+			name:   "missing-name",
+			data:   `hooks: [{blocked_files: ["*.pem"]}]`,
+			expErr: true,
+		},
+		{
+			name:   "duplicate-name",
+			data:   `hooks: [{name: a, blocked_files: ["*.pem"]}, {name: a, required_files: ["README.md"]}]`,
+			expErr: true,
+		},
+		{
+			name:   "no-policy",
+			data:   `hooks: [{name: a, branches: ["main"]}]`,
+			expErr: true,
+		},
+		{
+			name:   "invalid-pattern",
+			data:   `hooks: [{name: a, blocked_files: ["[a-"]}]`,
+			expErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			file, err := ParseFile([]byte(test.data))
+			if test.expErr {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(test.exp, file); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	file := &File{Hooks: []Hook{
+		{
+			Name:         "no-secrets",
+			Branches:     []string{"main", "release/**"},
+			BlockedFiles: []string{"**/*.pem", "**/.env"},
+			Message:      "Use the vault.",
+		},
+		{
+			Name:          "license",
+			RequiredFiles: []string{"LICENSE*"},
+		},
+	}}
+
+	changed := map[string][]string{
+		"sha-clean":  {"main.go"},
+		"sha-secret": {"main.go", "certs/server.pem", ".env"},
+	}
+	tree := map[string][]string{
+		"sha-clean":  {"LICENSE.md", "main.go"},
+		"sha-secret": {"main.go", "certs/server.pem", ".env"},
+	}
+
+	paths := func(m map[string][]string) pathsFunc {
+		return func(_ context.Context, sha string) ([]string, error) {
+			p, ok := m[sha]
+			if !ok {
+				return nil, fmt.Errorf("unknown sha %q", sha)
+			}
+			return p, nil
+		}
+	}
+
+	tests := []struct {
+		name     string
+		branches map[string]string
+		exp      []string
+	}{
+		{
+			name:     "compliant",
+			branches: map[string]string{"main": "sha-clean"},
+			exp:      nil,
+		},
+		{
+			name:     "blocked-and-missing",
+			branches: map[string]string{"release/1.0": "sha-secret"},
+			exp: []string{
+				`Policy hook "no-secrets" violation: file "certs/server.pem" on branch "release/1.0" is blocked.`,
+				`Policy hook "no-secrets" violation: file ".env" on branch "release/1.0" is blocked.`,
+				`Policy hook "no-secrets": Use the vault.`,
+				`Policy hook "license" violation: branch "release/1.0" must contain a file matching "LICENSE*".`,
+			},
+		},
+		{
+			name:     "branch-not-matching",
+			branches: map[string]string{"feature": "sha-secret"},
+			exp: []string{
+				`Policy hook "license" violation: branch "feature" must contain a file matching "LICENSE*".`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			violations, err := evaluate(context.Background(), file, test.branches, paths(changed), paths(tree))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(test.exp, violations); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+
+	t.Run("error", func(t *testing.T) {
+		fail := func(context.Context, string) ([]string, error) {
+			return nil, errors.New("failure")
+		}
+
+		_, err := evaluate(context.Background(), file, map[string]string{"main": "sha"}, fail, fail)
+		if err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
@@ -34,6 +34,22 @@ func (v DefBypass) matches(actor *types.Principal, isRepoOwner bool) bool {
 			slices.Contains(v.UserIDs, actor.ID))
 }
 
+// replaceUserIDs replaces the user IDs using the mapping. The IDs missing from the mapping are removed.
+func (v *DefBypass) replaceUserIDs(mapping map[int64]int64) {
+	if len(v.UserIDs) == 0 {
+		return
+	}
+
+	userIDs := make([]int64, 0, len(v.UserIDs))
+	for _, id := range v.UserIDs {
+		if newID, ok := mapping[id]; ok && !slices.Contains(userIDs, newID) {
+			userIDs = append(userIDs, newID)
+		}
+	}
+
+	v.UserIDs = userIDs
+}
+
 func (v DefBypass) Sanitize() error {
 	if err := validateIDSlice(v.UserIDs); err != nil {
 		return fmt.Errorf("user IDs error: %w", err)
@@ -28,6 +28,7 @@ type Branch struct {
 	Bypass    DefBypass    `json:"bypass"`
 	PullReq   DefPullReq   `json:"pullreq"`
 	Lifecycle DefLifecycle `json:"lifecycle"`
+	Push      DefPush      `json:"push"`
 }
 
 var (
@@ -74,10 +75,34 @@ func (v *Branch) RefChangeVerify(
 	return
 }
 
+func (v *Branch) PushVerify(
+	ctx context.Context,
+	in PushVerifyInput,
+) (violations []types.RuleViolations, err error) {
+	if len(in.RefNames) == 0 {
+		return []types.RuleViolations{}, nil
+	}
+
+	violations, err = v.Push.PushVerify(ctx, in)
+
+	bypassable := v.Bypass.matches(in.Actor, in.IsRepoOwner)
+	bypassed := in.AllowBypass && bypassable
+	for i := range violations {
+		violations[i].Bypassable = bypassable
+		violations[i].Bypassed = bypassed
+	}
+
+	return
+}
+
 func (v *Branch) UserIDs() ([]int64, error) {
 	return v.Bypass.UserIDs, nil
 }
 
+func (v *Branch) ReplaceUserIDs(mapping map[int64]int64) {
+	v.Bypass.replaceUserIDs(mapping)
+}
+
 func (v *Branch) Sanitize() error {
 	if err := v.Bypass.Sanitize(); err != nil {
 		return fmt.Errorf("bypass: %w", err)
@@ -91,5 +116,9 @@ func (v *Branch) Sanitize() error {
 		return fmt.Errorf("lifecycle: %w", err)
 	}
 
+	if err := v.Push.Sanitize(); err != nil {
+		return fmt.Errorf("push: %w", err)
+	}
+
 	return nil
 }
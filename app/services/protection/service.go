@@ -34,6 +34,7 @@ type (
 	Protection interface {
 		MergeVerifier
 		RefChangeVerifier
+		PushVerifier
 
 		UserIDs() ([]int64, error)
 	}
@@ -41,6 +42,10 @@ type (
 	Definition interface {
 		Sanitizer
 		Protection
+
+		// ReplaceUserIDs replaces the user IDs referenced by the definition using the mapping.
+		// The user IDs missing from the mapping are removed.
+		ReplaceUserIDs(mapping map[int64]int64)
 	}
 
 	// DefinitionGenerator is the function that creates blank rules.
@@ -90,6 +95,10 @@ func (m *Manager) Register(ruleType types.RuleType, gen DefinitionGenerator) err
 }
 
 func (m *Manager) FromJSON(ruleType types.RuleType, message json.RawMessage, strict bool) (Protection, error) {
+	return m.definitionFromJSON(ruleType, message, strict)
+}
+
+func (m *Manager) definitionFromJSON(ruleType types.RuleType, message json.RawMessage, strict bool) (Definition, error) {
 	gen := m.defGenMap[ruleType]
 	if gen == nil {
 		return nil, ErrUnrecognizedType
@@ -123,6 +132,23 @@ func (m *Manager) SanitizeJSON(ruleType types.RuleType, message json.RawMessage)
 	return toJSON(r)
 }
 
+// ReplaceUserIDs replaces the user IDs referenced by the rule definition using the mapping.
+// It's used when a rule is restored on an instance where the users have different IDs.
+func (m *Manager) ReplaceUserIDs(
+	ruleType types.RuleType,
+	message json.RawMessage,
+	mapping map[int64]int64,
+) (json.RawMessage, error) {
+	r, err := m.definitionFromJSON(ruleType, message, false)
+	if err != nil {
+		return nil, err
+	}
+
+	r.ReplaceUserIDs(mapping)
+
+	return toJSON(r)
+}
+
 func (m *Manager) ForRepository(ctx context.Context, repoID int64) (Protection, error) {
 	ruleInfos, err := m.ruleStore.ListAllRepoRules(ctx, repoID)
 	if err != nil {
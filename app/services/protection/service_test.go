@@ -16,6 +16,7 @@ package protection
 
 import (
 	"encoding/json"
+	"reflect"
 	"testing"
 
 	"github.com/harness/gitness/types"
@@ -131,3 +132,30 @@ func TestManager_SanitizeJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestManager_ReplaceUserIDs(t *testing.T) {
+	m := NewManager(nil)
+	if err := m.Register(TypeBranch, func() Definition { return &Branch{} }); err != nil {
+		t.Fatalf("failed to register rule type: %s", err)
+	}
+
+	def := json.RawMessage(`{"bypass":{"user_ids":[1,2,3],"repo_owners":true}}`)
+
+	got, err := m.ReplaceUserIDs(TypeBranch, def, map[int64]int64{1: 10, 3: 10})
+	if err != nil {
+		t.Fatalf("failed to replace user IDs: %s", err)
+	}
+
+	branch := &Branch{}
+	if err = json.Unmarshal(got, branch); err != nil {
+		t.Fatalf("failed to unmarshal definition: %s", err)
+	}
+
+	if want := []int64{10}; !reflect.DeepEqual(branch.Bypass.UserIDs, want) {
+		t.Errorf("user IDs mismatch: want=%v got=%v", want, branch.Bypass.UserIDs)
+	}
+
+	if !branch.Bypass.RepoOwners {
+		t.Error("expected the rest of the definition to be preserved")
+	}
+}
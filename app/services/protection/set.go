@@ -43,6 +43,8 @@ func (s ruleSet) MergeVerify(
 		out.AllowedMethods = slices.Clone(enum.MergeMethods)
 	}
 
+	violations = append(violations, verifyDependencies(in)...)
+
 	for _, r := range s.rules {
 		matches, err := matchesName(r.Pattern, in.TargetRepo.DefaultBranch, in.PullReq.TargetBranch)
 		if err != nil {
@@ -103,6 +105,38 @@ func (s ruleSet) RefChangeVerify(ctx context.Context, in RefChangeVerifyInput) (
 	return violations, nil
 }
 
+func (s ruleSet) PushVerify(ctx context.Context, in PushVerifyInput) ([]types.RuleViolations, error) {
+	var violations []types.RuleViolations
+
+	for _, r := range s.rules {
+		matched, err := matchedNames(r.Pattern, in.Repo.DefaultBranch, in.RefNames...)
+		if err != nil {
+			return nil, err
+		}
+		if len(matched) == 0 {
+			continue
+		}
+
+		protection, err := s.manager.FromJSON(r.Type, r.Definition, false)
+		if err != nil {
+			return nil,
+				fmt.Errorf("failed to parse protection definition ID=%d Type=%s: %w", r.ID, r.Type, err)
+		}
+
+		ruleIn := in
+		ruleIn.RefNames = matched
+
+		rVs, err := protection.PushVerify(ctx, ruleIn)
+		if err != nil {
+			return nil, err
+		}
+
+		violations = append(violations, backFillRule(rVs, r.RuleInfo)...)
+	}
+
+	return violations, nil
+}
+
 func (s ruleSet) UserIDs() ([]int64, error) {
 	mapIDs := make(map[int64]struct{})
 	for _, rule := range s.rules {
@@ -61,6 +61,32 @@ func TestRuleSet_MergeVerify(t *testing.T) {
 			},
 			expViol: nil,
 		},
+		{
+			name:  "open-dependencies",
+			rules: []types.RuleInfoInternal{},
+			input: MergeVerifyInput{
+				Actor:      &types.Principal{ID: 1},
+				Method:     enum.MergeMethodMerge,
+				TargetRepo: &types.Repository{ID: 1, DefaultBranch: "main"},
+				PullReq:    &types.PullReq{ID: 1, SourceBranch: "pr", TargetBranch: "main"},
+				Dependencies: []*types.PullReq{
+					{ID: 2, Number: 2, State: enum.PullReqStateMerged},
+					{ID: 3, Number: 3, State: enum.PullReqStateOpen},
+				},
+			},
+			expOut: MergeVerifyOutput{
+				DeleteSourceBranch: false,
+				AllowedMethods:     nil,
+			},
+			expViol: []types.RuleViolations{
+				{
+					Rule: ruleInfoPullReqDependencies,
+					Violations: []types.Violation{
+						{Code: codePullReqDependenciesOpen},
+					},
+				},
+			},
+		},
 		{
 			name: "two-rules-delete-source-branch",
 			rules: []types.RuleInfoInternal{
@@ -43,6 +43,7 @@ type (
 		Method       enum.MergeMethod
 		CheckResults []types.CheckResult
 		CodeOwners   *codeowners.Evaluation
+		Dependencies []*types.PullReq
 	}
 
 	MergeVerifyOutput struct {
@@ -68,8 +69,38 @@ const (
 	codePullReqStatusChecksReqUIDs                   = "pullreq.status_checks.required_uids"
 	codePullReqMergeStrategiesAllowed                = "pullreq.merge.strategies_allowed"
 	codePullReqMergeDeleteBranch                     = "pullreq.merge.delete_branch"
+	codePullReqDependenciesOpen                      = "pullreq.dependencies.open"
 )
 
+// ruleInfoPullReqDependencies describes violations caused by open pull request dependencies.
+// These violations aren't caused by any protection rule, so they are always active and can't be bypassed.
+var ruleInfoPullReqDependencies = types.RuleInfo{
+	UID:   "pullreq-dependencies",
+	Type:  "pullreq_dependencies",
+	State: enum.RuleStateActive,
+}
+
+// verifyDependencies reports a violation if any of the pull request dependencies is still open.
+func verifyDependencies(in MergeVerifyInput) []types.RuleViolations {
+	var numbers []string
+	for _, dep := range in.Dependencies {
+		if dep.State == enum.PullReqStateOpen {
+			numbers = append(numbers, fmt.Sprintf("#%d", dep.Number))
+		}
+	}
+
+	if len(numbers) == 0 {
+		return nil
+	}
+
+	violations := types.RuleViolations{Rule: ruleInfoPullReqDependencies}
+	violations.Addf(codePullReqDependenciesOpen,
+		"The pull request depends on pull requests that are still open: %s",
+		strings.Join(numbers, ", "))
+
+	return []types.RuleViolations{violations}
+}
+
 //nolint:gocognit // well aware of this
 func (v *DefPullReq) MergeVerify(
 	_ context.Context,
@@ -259,14 +290,6 @@ func (v *DefMerge) Sanitize() error {
 	return nil
 }
 
-type DefPush struct {
-	Block bool `json:"block,omitempty"`
-}
-
-func (v *DefPush) Sanitize() error {
-	return nil
-}
-
 type DefPullReq struct {
 	Approvals    DefApprovals    `json:"approvals"`
 	Comments     DefComments     `json:"comments"`
@@ -0,0 +1,159 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/harness/gitness/types"
+
+	"golang.org/x/exp/slices"
+)
+
+type (
+	PushVerifier interface {
+		PushVerify(ctx context.Context, in PushVerifyInput) ([]types.RuleViolations, error)
+	}
+
+	PushVerifyInput struct {
+		Actor       *types.Principal
+		AllowBypass bool
+		IsRepoOwner bool
+		Repo        *types.Repository
+		// RefNames contains the names of the branches that are created or updated by the push.
+		RefNames []string
+		// FindBinaryBlobs returns the binary files the push introduces to the branch.
+		// It's only called if a matching rule requires it, as finding binary files is expensive.
+		FindBinaryBlobs func(ctx context.Context, branch string) ([]BinaryBlob, error)
+	}
+
+	// BinaryBlob describes a binary file introduced by a push.
+	BinaryBlob struct {
+		Path string
+		Size int64
+	}
+
+	DefPush struct {
+		// BlockBinaries rejects pushes that introduce new binary files (that aren't stored in LFS).
+		BlockBinaries bool `json:"block_binaries,omitempty"`
+		// AllowedBinaryExtensions lists extensions (e.g. ".png") of binary files that are allowed regardless.
+		AllowedBinaryExtensions []string `json:"allowed_binary_extensions,omitempty"`
+		// AllowedBinarySize is the size (in bytes) up to which binary files are allowed regardless.
+		AllowedBinarySize int64 `json:"allowed_binary_size,omitempty"`
+	}
+)
+
+// ensures that the DefPush type implements Sanitizer and PushVerifier interfaces.
+var (
+	_ Sanitizer    = (*DefPush)(nil)
+	_ PushVerifier = (*DefPush)(nil)
+)
+
+const (
+	codePushBinariesBlocked = "push.binaries.blocked"
+
+	// maxReportedBinaryBlobs is the maximum number of binary files reported per branch.
+	maxReportedBinaryBlobs = 10
+)
+
+func (v *DefPush) PushVerify(ctx context.Context, in PushVerifyInput) ([]types.RuleViolations, error) {
+	if !v.BlockBinaries || in.FindBinaryBlobs == nil {
+		return nil, nil
+	}
+
+	var violations types.RuleViolations
+
+	for _, branch := range in.RefNames {
+		blobs, err := in.FindBinaryBlobs(ctx, branch)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find binary files for branch %q: %w", branch, err)
+		}
+
+		var blocked int
+		for _, blob := range blobs {
+			if v.binaryAllowed(blob) {
+				continue
+			}
+
+			blocked++
+			if blocked > maxReportedBinaryBlobs {
+				continue
+			}
+
+			violations.Addf(codePushBinariesBlocked,
+				"Binary file %q (%d bytes) is not allowed on branch %q. Please use Git LFS.",
+				blob.Path, blob.Size, branch)
+		}
+
+		if blocked > maxReportedBinaryBlobs {
+			violations.Addf(codePushBinariesBlocked,
+				"%d more binary files are not allowed on branch %q.",
+				blocked-maxReportedBinaryBlobs, branch)
+		}
+	}
+
+	if len(violations.Violations) > 0 {
+		return []types.RuleViolations{violations}, nil
+	}
+
+	return nil, nil
+}
+
+func (v *DefPush) binaryAllowed(blob BinaryBlob) bool {
+	if v.AllowedBinarySize > 0 && blob.Size <= v.AllowedBinarySize {
+		return true
+	}
+
+	ext := strings.ToLower(path.Ext(blob.Path))
+	if ext == "" {
+		return false
+	}
+
+	_, found := slices.BinarySearch(v.AllowedBinaryExtensions, ext)
+
+	return found
+}
+
+func (v *DefPush) Sanitize() error {
+	if v.AllowedBinarySize < 0 {
+		return errors.New("allowed binary size can't be negative")
+	}
+
+	for i, ext := range v.AllowedBinaryExtensions {
+		ext = strings.ToLower(strings.TrimSpace(ext))
+		if ext == "" || ext == "." {
+			return errors.New("allowed binary extension can't be empty")
+		}
+
+		if !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+
+		if strings.ContainsAny(ext[1:], "./") {
+			return fmt.Errorf("invalid allowed binary extension: %s", v.AllowedBinaryExtensions[i])
+		}
+
+		v.AllowedBinaryExtensions[i] = ext
+	}
+
+	slices.Sort(v.AllowedBinaryExtensions)
+	v.AllowedBinaryExtensions = slices.Compact(v.AllowedBinaryExtensions)
+
+	return nil
+}
@@ -0,0 +1,143 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protection
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+// nolint:gocognit // it's a unit test
+func TestDefPush_PushVerify(t *testing.T) {
+	const branch = "main"
+
+	blobs := []BinaryBlob{
+		{Path: "docs/logo.PNG", Size: 2048},
+		{Path: "bin/tool", Size: 100},
+		{Path: "data/archive.zip", Size: 4096},
+	}
+
+	tests := []struct {
+		name      string
+		def       DefPush
+		expCodes  []string
+		expParams [][]any
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:     "push.binaries.blocked",
+			def:      DefPush{BlockBinaries: true},
+			expCodes: []string{"push.binaries.blocked", "push.binaries.blocked", "push.binaries.blocked"},
+			expParams: [][]any{
+				{"docs/logo.PNG", int64(2048), branch},
+				{"bin/tool", int64(100), branch},
+				{"data/archive.zip", int64(4096), branch},
+			},
+		},
+		{
+			name: "push.binaries.blocked-allowed-extension",
+			def: DefPush{
+				BlockBinaries:           true,
+				AllowedBinaryExtensions: []string{"png", ".ZIP"},
+			},
+			expCodes:  []string{"push.binaries.blocked"},
+			expParams: [][]any{{"bin/tool", int64(100), branch}},
+		},
+		{
+			name: "push.binaries.blocked-allowed-size",
+			def: DefPush{
+				BlockBinaries:     true,
+				AllowedBinarySize: 2048,
+			},
+			expCodes:  []string{"push.binaries.blocked"},
+			expParams: [][]any{{"data/archive.zip", int64(4096), branch}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			in := PushVerifyInput{
+				RefNames: []string{branch},
+				FindBinaryBlobs: func(context.Context, string) ([]BinaryBlob, error) {
+					return blobs, nil
+				},
+			}
+
+			if err := test.def.Sanitize(); err != nil {
+				t.Errorf("def invalid: %s", err.Error())
+				return
+			}
+
+			violations, err := test.def.PushVerify(context.Background(), in)
+			if err != nil {
+				t.Errorf("got an error: %s", err.Error())
+				return
+			}
+
+			inspectBranchViolations(t, test.expCodes, test.expParams, violations)
+		})
+	}
+}
+
+func TestDefPush_Sanitize(t *testing.T) {
+	tests := []struct {
+		name    string
+		def     DefPush
+		expExts []string
+		expErr  bool
+	}{
+		{
+			name:    "normalized",
+			def:     DefPush{AllowedBinaryExtensions: []string{"PNG", " .zip", ".png"}},
+			expExts: []string{".png", ".zip"},
+		},
+		{
+			name:   "empty-extension",
+			def:    DefPush{AllowedBinaryExtensions: []string{"."}},
+			expErr: true,
+		},
+		{
+			name:   "invalid-extension",
+			def:    DefPush{AllowedBinaryExtensions: []string{".tar.gz"}},
+			expErr: true,
+		},
+		{
+			name:   "negative-size",
+			def:    DefPush{AllowedBinarySize: -1},
+			expErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.def.Sanitize()
+			if test.expErr != (err != nil) {
+				t.Errorf("error mismatch: want=%t got=%v", test.expErr, err)
+				return
+			}
+
+			if test.expErr {
+				return
+			}
+
+			if want, got := test.expExts, test.def.AllowedBinaryExtensions; !reflect.DeepEqual(want, got) {
+				t.Errorf("extensions mismatch: want=%v got=%v", want, got)
+			}
+		})
+	}
+}
@@ -42,25 +42,10 @@ var (
 func (s *Service) triggerPREventOnBranchUpdate(ctx context.Context,
 	event *events.Event[*gitevents.BranchUpdatedPayload],
 ) error {
-	// we should always update PR mergeable status check when target branch is updated.
-	// - main
-	//    |- develop
-	//         |- feature1
-	//         |- feature2
-	// when feature2 merge changes into develop branch then feature1 branch is not consistent anymore
-	// and need to run mergeable check even nothing was changed on feature1, same applies to main if someone
-	// push new commit to main then develop should merge status should be unchecked.
-	if branch, err := getBranchFromRef(event.Payload.Ref); err == nil {
-		err = s.pullreqStore.UpdateMergeCheckStatus(ctx, event.Payload.RepoID, branch, enum.MergeCheckStatusUnchecked)
-		if err != nil {
-			return err
-		}
-	}
-
 	// TODO: This function is currently executed directly on branch update event.
 	// TODO: But it should be executed after the PR's head ref has been updated.
 	// TODO: This is to make sure the commit exists on the target repository for forked repositories.
-	s.forEveryOpenPR(ctx, event.Payload.RepoID, event.Payload.Ref, func(pr *types.PullReq) error {
+	s.forEveryOpenPR(ctx, event.Payload.RepoID, event.Payload.Ref, false, func(pr *types.PullReq) error {
 		// First check if the merge base has changed
 
 		targetRepo, err := s.repoGitInfoCache.Get(ctx, pr.TargetRepoID)
@@ -103,8 +88,7 @@ func (s *Service) triggerPREventOnBranchUpdate(ctx context.Context,
 			pr.MergeCheckStatus = enum.MergeCheckStatusUnchecked
 			pr.MergeSHA = nil
 			pr.MergeConflicts = nil
-			pr.Stats.DiffStats.Commits = nil
-			pr.Stats.DiffStats.FilesChanged = nil
+			pr.Stats.DiffStats = types.DiffStats{}
 
 			return nil
 		})
@@ -150,12 +134,52 @@ func (s *Service) triggerPREventOnBranchUpdate(ctx context.Context,
 	return nil
 }
 
+// triggerMergeCheckOnTargetBranchUpdate handles branch update events. For every open pull request
+// that targets the updated branch it triggers the pull request Target Branch Updated event,
+// so the mergeability of the pull request gets recomputed in the background.
+func (s *Service) triggerMergeCheckOnTargetBranchUpdate(ctx context.Context,
+	event *events.Event[*gitevents.BranchUpdatedPayload],
+) error {
+	// we should always update PR mergeable status check when target branch is updated.
+	// - main
+	//    |- develop
+	//         |- feature1
+	//         |- feature2
+	// when feature2 merge changes into develop branch then feature1 branch is not consistent anymore
+	// and need to run mergeable check even nothing was changed on feature1, same applies to main if someone
+	// push new commit to main then develop should merge status should be unchecked.
+	if branch, err := getBranchFromRef(event.Payload.Ref); err == nil {
+		err = s.pullreqStore.UpdateMergeCheckStatus(ctx, event.Payload.RepoID, branch, enum.MergeCheckStatusUnchecked)
+		if err != nil {
+			return err
+		}
+	}
+
+	s.forEveryOpenPR(ctx, event.Payload.RepoID, event.Payload.Ref, true, func(pr *types.PullReq) error {
+		s.pullreqEvReporter.TargetBranchUpdated(ctx, &pullreqevents.TargetBranchUpdatedPayload{
+			Base: pullreqevents.Base{
+				PullReqID:    pr.ID,
+				SourceRepoID: pr.SourceRepoID,
+				TargetRepoID: pr.TargetRepoID,
+				PrincipalID:  event.Payload.PrincipalID,
+				Number:       pr.Number,
+			},
+			OldTargetSHA: event.Payload.OldSHA,
+			NewTargetSHA: event.Payload.NewSHA,
+		})
+
+		return nil
+	})
+
+	return nil
+}
+
 // closePullReqOnBranchDelete handles branch delete events.
 // It closes every open pull request for the branch and triggers the pull request BranchDeleted event.
 func (s *Service) closePullReqOnBranchDelete(ctx context.Context,
 	event *events.Event[*gitevents.BranchDeletedPayload],
 ) error {
-	s.forEveryOpenPR(ctx, event.Payload.RepoID, event.Payload.Ref, func(pr *types.PullReq) error {
+	s.forEveryOpenPR(ctx, event.Payload.RepoID, event.Payload.Ref, false, func(pr *types.PullReq) error {
 		targetRepo, err := s.repoGitInfoCache.Get(ctx, pr.TargetRepoID)
 		if err != nil {
 			return fmt.Errorf("failed to get repo info: %w", err)
@@ -234,8 +258,9 @@ func (s *Service) closePullReqOnBranchDelete(ctx context.Context,
 
 // forEveryOpenPR is utility function that executes the provided function
 // for every open pull request created with the source branch given as a git ref.
+// If target is true, the function is executed for every open pull request targeting the branch instead.
 func (s *Service) forEveryOpenPR(ctx context.Context,
-	repoID int64, ref string,
+	repoID int64, ref string, target bool,
 	fn func(pr *types.PullReq) error,
 ) {
 	const largeLimit = 1000000
@@ -246,15 +271,22 @@ func (s *Service) forEveryOpenPR(ctx context.Context,
 		return
 	}
 
-	pullreqList, err := s.pullreqStore.List(ctx, &types.PullReqFilter{
-		Page:         0,
-		Size:         largeLimit,
-		SourceRepoID: repoID,
-		SourceBranch: branch,
-		States:       []enum.PullReqState{enum.PullReqStateOpen},
-		Sort:         enum.PullReqSortNumber,
-		Order:        enum.OrderAsc,
-	})
+	filter := &types.PullReqFilter{
+		Page:   0,
+		Size:   largeLimit,
+		States: []enum.PullReqState{enum.PullReqStateOpen},
+		Sort:   enum.PullReqSortNumber,
+		Order:  enum.OrderAsc,
+	}
+	if target {
+		filter.TargetRepoID = repoID
+		filter.TargetBranch = branch
+	} else {
+		filter.SourceRepoID = repoID
+		filter.SourceBranch = branch
+	}
+
+	pullreqList, err := s.pullreqStore.List(ctx, filter)
 	if err != nil {
 		log.Ctx(ctx).Err(err).Msg("failed to get list of open pull requests")
 		return
@@ -65,6 +65,29 @@ func (s *Service) mergeCheckOnBranchUpdate(ctx context.Context,
 	)
 }
 
+// mergeCheckOnTargetBranchUpdate handles pull request Target Branch Updated events.
+// It recomputes the mergeability of the pull request against the latest commit of the target branch.
+func (s *Service) mergeCheckOnTargetBranchUpdate(ctx context.Context,
+	event *events.Event[*pullreqevents.TargetBranchUpdatedPayload],
+) error {
+	pr, err := s.pullreqStore.FindByNumber(ctx, event.Payload.TargetRepoID, event.Payload.Number)
+	if err != nil {
+		return fmt.Errorf("failed to get pull request number %d: %w", event.Payload.Number, err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return nil
+	}
+
+	// the mergeability was already recomputed against the new target (e.g. by a merge dry-run)
+	if pr.MergeCheckStatus != enum.MergeCheckStatusUnchecked &&
+		pr.MergeTargetSHA != nil && *pr.MergeTargetSHA == event.Payload.NewTargetSHA {
+		return nil
+	}
+
+	return s.updateMergeDataInner(ctx, pr, "", pr.SourceSHA)
+}
+
 // mergeCheckOnReopen handles pull request StateChanged events.
 // It updates the PR head git ref to point to the source branch commit SHA.
 func (s *Service) mergeCheckOnReopen(ctx context.Context,
@@ -235,7 +258,8 @@ func (s *Service) updateMergeDataInner(
 			pr.MergeSHA = &mergeOutput.MergeSHA
 			pr.MergeConflicts = nil
 		}
-		pr.Stats.DiffStats = types.NewDiffStats(mergeOutput.CommitCount, mergeOutput.ChangedFileCount)
+		pr.Stats.DiffStats = types.NewDiffStats(mergeOutput.CommitCount, mergeOutput.ChangedFileCount,
+			mergeOutput.Additions, mergeOutput.Deletions)
 
 		return nil
 	})
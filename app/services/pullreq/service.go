@@ -112,6 +112,27 @@ func New(ctx context.Context,
 		return nil, err
 	}
 
+	// recompute mergeability of the pull requests when their target branch gets updated
+
+	const groupGitTarget = "gitness:pullreq:git-target"
+	_, err = gitReaderFactory.Launch(ctx, groupGitTarget, config.InstanceID,
+		func(r *gitevents.Reader) error {
+			const idleTimeout = 15 * time.Second
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(3),
+				))
+
+			_ = r.RegisterBranchUpdated(service.triggerMergeCheckOnTargetBranchUpdate)
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
 	// pull request ref maintenance
 
 	const groupPullReqHeadRef = "gitness:pullreq:headref"
@@ -192,6 +213,7 @@ func New(ctx context.Context,
 
 			_ = r.RegisterCreated(service.mergeCheckOnCreated)
 			_ = r.RegisterBranchUpdated(service.mergeCheckOnBranchUpdate)
+			_ = r.RegisterTargetBranchUpdated(service.mergeCheckOnTargetBranchUpdate)
 			_ = r.RegisterReopened(service.mergeCheckOnReopen)
 			_ = r.RegisterClosed(service.mergeCheckOnClosed)
 			_ = r.RegisterMerged(service.mergeCheckOnMerged)
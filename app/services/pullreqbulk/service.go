@@ -0,0 +1,143 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreqbulk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Operator applies the bulk operations to a single pull request.
+type Operator interface {
+	State(ctx context.Context, session *auth.Session, repoRef string, pullreqNum int64,
+		in *pullreq.StateInput) (*types.PullReq, error)
+	Retarget(ctx context.Context, session *auth.Session, repoRef string, pullreqNum int64,
+		targetBranch string) (*types.PullReq, error)
+	ReviewerAdd(ctx context.Context, session *auth.Session, repoRef string, prNum int64,
+		in *pullreq.ReviewerAddInput) (*types.PullReqReviewer, error)
+	ReviewerDelete(ctx context.Context, session *auth.Session, repoRef string, prNum, reviewerID int64) error
+}
+
+// Service is the background job handler that executes bulk pull request operations.
+type Service struct {
+	operator       Operator
+	principalStore store.PrincipalStore
+}
+
+var _ job.Handler = (*Service)(nil)
+
+// Handle applies the operation to each pull request in turn.
+// A failure of a single pull request doesn't abort the job, it's recorded in the per pull request results instead.
+func (s *Service) Handle(ctx context.Context, data string, progress job.ProgressReporter) (string, error) {
+	var input pullreq.BulkJobInput
+	if err := json.Unmarshal([]byte(data), &input); err != nil {
+		return "", fmt.Errorf("failed to unmarshal bulk job input: %w", err)
+	}
+
+	principal, err := s.principalStore.Find(ctx, input.PrincipalID)
+	if err != nil {
+		return "", fmt.Errorf("failed to find principal that requested the bulk operation: %w", err)
+	}
+
+	session := &auth.Session{
+		Principal: *principal,
+		Metadata:  &auth.EmptyMetadata{},
+	}
+	repoRef := strconv.FormatInt(input.RepoID, 10)
+
+	results := make([]pullreq.BulkResult, 0, len(input.Numbers))
+	for i, number := range input.Numbers {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+
+		result := pullreq.BulkResult{Number: number, Success: true}
+		if err := s.apply(ctx, session, repoRef, number, &input); err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("repo_id", input.RepoID).
+				Int64("pullreq_number", number).
+				Msgf("bulk %s operation failed for pull request", input.Operation)
+
+			result.Success = false
+			result.Error = errorMessage(err)
+		}
+
+		results = append(results, result)
+
+		resultsJSON, err := json.Marshal(results)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal bulk job results: %w", err)
+		}
+
+		if err = progress((i+1)*job.ProgressMax/len(input.Numbers), string(resultsJSON)); err != nil {
+			return "", fmt.Errorf("failed to report bulk job progress: %w", err)
+		}
+	}
+
+	resultsJSON, err := json.Marshal(results)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal bulk job results: %w", err)
+	}
+
+	return string(resultsJSON), nil
+}
+
+func (s *Service) apply(ctx context.Context,
+	session *auth.Session, repoRef string, number int64, input *pullreq.BulkJobInput,
+) error {
+	switch input.Operation {
+	case enum.PullReqBulkOperationClose:
+		_, err := s.operator.State(ctx, session, repoRef, number,
+			&pullreq.StateInput{State: enum.PullReqStateClosed})
+		return err
+
+	case enum.PullReqBulkOperationRetarget:
+		_, err := s.operator.Retarget(ctx, session, repoRef, number, input.NewTargetBranch)
+		return err
+
+	case enum.PullReqBulkOperationReviewers:
+		for _, reviewerID := range input.RemoveReviewerIDs {
+			if err := s.operator.ReviewerDelete(ctx, session, repoRef, number, reviewerID); err != nil {
+				return err
+			}
+		}
+		for _, reviewerID := range input.AddReviewerIDs {
+			if _, err := s.operator.ReviewerAdd(ctx, session, repoRef, number,
+				&pullreq.ReviewerAddInput{ReviewerID: reviewerID}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return fmt.Errorf("unsupported bulk operation %q", input.Operation)
+}
+
+// errorMessage returns the user facing message of the error, internal errors are not exposed.
+func errorMessage(err error) string {
+	return usererror.Translate(err).Message
+}
@@ -0,0 +1,316 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repomaintenance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeScan  = "repo-maintenance-scan"
+	jobType      = "repo-maintenance"
+	jobUIDPrefix = "repo-maintenance-"
+)
+
+var (
+	ErrInProgress = errors.Conflict("Maintenance of the repository is already in progress.")
+	ErrNotFound   = errors.NotFound("No maintenance of the repository was found.")
+)
+
+// Input is the data of the maintenance job of a single repository.
+type Input struct {
+	RepoID int64                     `json:"repo_id"`
+	Tasks  []gitenum.MaintenanceTask `json:"tasks"`
+}
+
+// Result is the result of the maintenance job of a single repository.
+type Result struct {
+	Tasks []gitenum.MaintenanceTask `json:"tasks"`
+	Stats git.ObjectStats           `json:"stats"`
+}
+
+// Service runs git maintenance tasks (gc, repack, commit-graph) on repositories.
+// The repositories are periodically scanned and the tasks are scheduled for those
+// that need them. Maintenance of a repository can also be triggered manually.
+type Service struct {
+	enabled               bool
+	cron                  string
+	maxDur                time.Duration
+	jobMaxDur             time.Duration
+	numWorkers            int
+	looseObjectsThreshold int
+	packsThreshold        int
+
+	git       git.Interface
+	repoStore store.RepoStore
+	scheduler *job.Scheduler
+}
+
+var _ job.Handler = (*Service)(nil)
+
+// Register registers the recurring job that scans the repositories for the need of maintenance.
+func (s *Service) Register(ctx context.Context) error {
+	if !s.enabled {
+		return nil
+	}
+
+	err := s.scheduler.AddRecurring(ctx, jobTypeScan, jobTypeScan, s.cron, s.maxDur)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for repository maintenance: %w", err)
+	}
+
+	return nil
+}
+
+// Trigger schedules the maintenance of the repository. If no tasks are provided,
+// the tasks are selected based on the current state of the repository.
+func (s *Service) Trigger(
+	ctx context.Context,
+	repo *types.Repository,
+	tasks []gitenum.MaintenanceTask,
+) ([]gitenum.MaintenanceTask, error) {
+	if len(tasks) == 0 {
+		stats, err := s.Stats(ctx, repo.GitUID)
+		if err != nil {
+			return nil, err
+		}
+
+		tasks = s.requiredTasks(stats)
+		if len(tasks) == 0 {
+			// trigger has been requested explicitly, so run at least a gc.
+			tasks = []gitenum.MaintenanceTask{gitenum.MaintenanceTaskGC}
+		}
+	}
+
+	for _, task := range tasks {
+		if _, ok := task.Sanitize(); !ok {
+			return nil, errors.InvalidArgument("Unknown maintenance task %q.", task)
+		}
+	}
+
+	if err := s.schedule(ctx, repo.ID, repo.ParentID, tasks); err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+// GetProgress returns the progress of the latest maintenance job of the repository.
+func (s *Service) GetProgress(ctx context.Context, repoID int64) (job.Progress, error) {
+	progress, err := s.scheduler.GetJobProgress(ctx, jobUIDFromRepoID(repoID))
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return job.Progress{}, ErrNotFound
+	}
+	if err != nil {
+		return job.Progress{}, fmt.Errorf("failed to get repository maintenance job progress: %w", err)
+	}
+
+	return progress, nil
+}
+
+// Stats returns the object statistics of the repository.
+func (s *Service) Stats(ctx context.Context, gitUID string) (git.ObjectStats, error) {
+	stats, err := s.git.GetObjectStats(ctx, &git.GetObjectStatsParams{
+		ReadParams: git.ReadParams{RepoUID: gitUID},
+	})
+	if err != nil {
+		return git.ObjectStats{}, fmt.Errorf("failed to get repository object stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+// requiredTasks returns the maintenance tasks the repository needs based on its object statistics.
+func (s *Service) requiredTasks(stats git.ObjectStats) []gitenum.MaintenanceTask {
+	var tasks []gitenum.MaintenanceTask
+
+	switch {
+	case stats.LooseObjects >= s.looseObjectsThreshold || stats.Garbage > 0:
+		// gc repacks all objects and writes the commit-graph too.
+		return []gitenum.MaintenanceTask{gitenum.MaintenanceTaskGC}
+	case stats.Packs >= s.packsThreshold:
+		tasks = append(tasks, gitenum.MaintenanceTaskRepack)
+	case !stats.HasBitmapIndex && stats.PackedObjects > 0:
+		// without the bitmap index, every large fetch has to walk the history to find the objects to send.
+		tasks = append(tasks, gitenum.MaintenanceTaskRepack)
+	}
+
+	if !stats.HasCommitGraph && stats.PackedObjects+stats.LooseObjects > 0 {
+		tasks = append(tasks, gitenum.MaintenanceTaskCommitGraph)
+	}
+
+	return tasks
+}
+
+func (s *Service) schedule(ctx context.Context, repoID, spaceID int64, tasks []gitenum.MaintenanceTask) error {
+	jobUID := jobUIDFromRepoID(repoID)
+
+	progress, err := s.scheduler.GetJobProgress(ctx, jobUID)
+	if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return fmt.Errorf("failed to get repository maintenance job progress: %w", err)
+	}
+	if err == nil {
+		if progress.State == job.JobStateScheduled || progress.State == job.JobStateRunning {
+			return ErrInProgress
+		}
+
+		// the job of the previous maintenance is finished, remove it to be able to reuse its UID.
+		if err = s.scheduler.PurgeJobByUID(ctx, jobUID); err != nil {
+			return fmt.Errorf("failed to purge previous repository maintenance job: %w", err)
+		}
+	}
+
+	data, err := json.Marshal(Input{RepoID: repoID, Tasks: tasks})
+	if err != nil {
+		return fmt.Errorf("failed to marshal repository maintenance job input: %w", err)
+	}
+
+	err = s.scheduler.RunJob(ctx, job.Definition{
+		UID:        jobUID,
+		Type:       jobType,
+		MaxRetries: 1,
+		Timeout:    s.jobMaxDur,
+		Data:       string(data),
+		SpaceID:    spaceID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to schedule repository maintenance job: %w", err)
+	}
+
+	return nil
+}
+
+// Handle runs the maintenance tasks on a single repository.
+func (s *Service) Handle(ctx context.Context, data string, _ job.ProgressReporter) (string, error) {
+	var input Input
+	if err := json.Unmarshal([]byte(data), &input); err != nil {
+		return "", fmt.Errorf("failed to unmarshal repository maintenance job input: %w", err)
+	}
+
+	repo, err := s.repoStore.Find(ctx, input.RepoID)
+	if err != nil {
+		return "", fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	log := log.Ctx(ctx).With().Int64("repo_id", repo.ID).Str("repo_git_uid", repo.GitUID).Logger()
+	log.Info().Msgf("start repository maintenance: %v", input.Tasks)
+
+	err = s.git.RunMaintenance(ctx, &git.RunMaintenanceParams{
+		ReadParams: git.ReadParams{RepoUID: repo.GitUID},
+		Tasks:      input.Tasks,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to run repository maintenance: %w", err)
+	}
+
+	stats, err := s.Stats(ctx, repo.GitUID)
+	if err != nil {
+		return "", err
+	}
+
+	result, err := json.Marshal(Result{Tasks: input.Tasks, Stats: stats})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal repository maintenance job result: %w", err)
+	}
+
+	log.Info().Msg("completed repository maintenance")
+
+	return string(result), nil
+}
+
+// scanner is the handler of the recurring job that schedules the maintenance of the repositories that need it.
+type scanner struct {
+	s *Service
+}
+
+func (sc scanner) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	s := sc.s
+	if !s.enabled {
+		return "", nil
+	}
+
+	repos, err := s.repoStore.ListSizeInfos(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list repositories: %w", err)
+	}
+
+	var wg sync.WaitGroup
+	taskCh := make(chan *types.RepositorySizeInfo)
+	for i := 0; i < s.numWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for repo := range taskCh {
+				s.scanRepo(ctx, repo)
+			}
+		}()
+	}
+	for _, repo := range repos {
+		select {
+		case <-ctx.Done():
+			break
+		case taskCh <- repo:
+		}
+	}
+	close(taskCh)
+	wg.Wait()
+
+	return "", nil
+}
+
+func (s *Service) scanRepo(ctx context.Context, repo *types.RepositorySizeInfo) {
+	log := log.Ctx(ctx).With().Int64("repo_id", repo.ID).Str("repo_git_uid", repo.GitUID).Logger()
+
+	stats, err := s.Stats(ctx, repo.GitUID)
+	if err != nil {
+		log.Warn().Err(err).Msg("failed to get repository object stats")
+		return
+	}
+
+	tasks := s.requiredTasks(stats)
+	if len(tasks) == 0 {
+		return
+	}
+
+	// the space is used only for fair scheduling, the scan job doesn't need it.
+	err = s.schedule(ctx, repo.ID, 0, tasks)
+	if errors.Is(err, ErrInProgress) {
+		return
+	}
+	if err != nil {
+		log.Warn().Err(err).Msg("failed to schedule repository maintenance")
+		return
+	}
+
+	log.Debug().Msgf("scheduled repository maintenance: %v", tasks)
+}
+
+func jobUIDFromRepoID(repoID int64) string {
+	return jobUIDPrefix + fmt.Sprint(repoID)
+}
@@ -0,0 +1,94 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repomaintenance
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestService_requiredTasks(t *testing.T) {
+	s := &Service{
+		looseObjectsThreshold: 100,
+		packsThreshold:        10,
+	}
+
+	tests := []struct {
+		name  string
+		stats git.ObjectStats
+		want  []gitenum.MaintenanceTask
+	}{
+		{
+			name:  "empty repository",
+			stats: git.ObjectStats{},
+			want:  nil,
+		},
+		{
+			name: "clean repository",
+			stats: git.ObjectStats{
+				LooseObjects: 10, PackedObjects: 500, Packs: 2, HasCommitGraph: true, HasBitmapIndex: true,
+			},
+			want: nil,
+		},
+		{
+			name:  "too many loose objects",
+			stats: git.ObjectStats{LooseObjects: 100, Packs: 20, HasCommitGraph: false},
+			want:  []gitenum.MaintenanceTask{gitenum.MaintenanceTaskGC},
+		},
+		{
+			name:  "garbage",
+			stats: git.ObjectStats{PackedObjects: 500, Packs: 1, Garbage: 1, HasCommitGraph: true},
+			want:  []gitenum.MaintenanceTask{gitenum.MaintenanceTaskGC},
+		},
+		{
+			name:  "too many packs",
+			stats: git.ObjectStats{PackedObjects: 500, Packs: 10, HasCommitGraph: true},
+			want:  []gitenum.MaintenanceTask{gitenum.MaintenanceTaskRepack},
+		},
+		{
+			name:  "too many packs without commit-graph",
+			stats: git.ObjectStats{PackedObjects: 500, Packs: 10},
+			want:  []gitenum.MaintenanceTask{gitenum.MaintenanceTaskRepack, gitenum.MaintenanceTaskCommitGraph},
+		},
+		{
+			name:  "missing commit-graph",
+			stats: git.ObjectStats{PackedObjects: 500, Packs: 1, HasBitmapIndex: true},
+			want:  []gitenum.MaintenanceTask{gitenum.MaintenanceTaskCommitGraph},
+		},
+		{
+			name:  "missing bitmap index",
+			stats: git.ObjectStats{PackedObjects: 500, Packs: 1, HasCommitGraph: true},
+			want:  []gitenum.MaintenanceTask{gitenum.MaintenanceTaskRepack},
+		},
+		{
+			name:  "missing bitmap index and commit-graph",
+			stats: git.ObjectStats{PackedObjects: 500, Packs: 1},
+			want:  []gitenum.MaintenanceTask{gitenum.MaintenanceTaskRepack, gitenum.MaintenanceTaskCommitGraph},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := s.requiredTasks(test.stats)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("requiredTasks() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,246 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repostats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeScan  = "repo-stats-calculator"
+	jobType      = "repo-stats"
+	jobUIDPrefix = "repo-stats-"
+)
+
+var (
+	ErrNotCalculated = errors.NotFound("Statistics of the repository haven't been calculated yet.")
+	ErrInProgress    = errors.Conflict("Calculation of the repository statistics is already in progress.")
+)
+
+// Input is the data of the job that calculates the stats of a single repository.
+type Input struct {
+	RepoID int64 `json:"repo_id"`
+}
+
+// Service calculates the disk usage and object statistics of repositories.
+// The stats of all repositories are refreshed periodically,
+// the refresh of a single repository can be requested explicitly.
+type Service struct {
+	enabled      bool
+	cron         string
+	maxDur       time.Duration
+	jobMaxDur    time.Duration
+	numWorkers   int
+	largestBlobs int
+
+	git            git.Interface
+	repoStore      store.RepoStore
+	repoStatsStore store.RepoStatsStore
+	scheduler      *job.Scheduler
+}
+
+var _ job.Handler = (*Service)(nil)
+
+// Register registers the recurring job that calculates the stats of all repositories.
+func (s *Service) Register(ctx context.Context) error {
+	if !s.enabled {
+		return nil
+	}
+
+	err := s.scheduler.AddRecurring(ctx, jobTypeScan, jobTypeScan, s.cron, s.maxDur)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for repository stats: %w", err)
+	}
+
+	return nil
+}
+
+// Find returns the last calculated stats of the repository.
+func (s *Service) Find(ctx context.Context, repoID int64) (*types.RepositoryStats, error) {
+	stats, err := s.repoStatsStore.Find(ctx, repoID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, ErrNotCalculated
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repository stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+// Refresh schedules the calculation of the stats of the repository.
+func (s *Service) Refresh(ctx context.Context, repo *types.Repository) error {
+	jobUID := jobUIDPrefix + fmt.Sprint(repo.ID)
+
+	progress, err := s.scheduler.GetJobProgress(ctx, jobUID)
+	if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return fmt.Errorf("failed to get repository stats job progress: %w", err)
+	}
+	if err == nil {
+		if progress.State == job.JobStateScheduled || progress.State == job.JobStateRunning {
+			return ErrInProgress
+		}
+
+		// the previous job is finished, remove it to be able to reuse its UID.
+		if err = s.scheduler.PurgeJobByUID(ctx, jobUID); err != nil {
+			return fmt.Errorf("failed to purge previous repository stats job: %w", err)
+		}
+	}
+
+	data, err := json.Marshal(Input{RepoID: repo.ID})
+	if err != nil {
+		return fmt.Errorf("failed to marshal repository stats job input: %w", err)
+	}
+
+	err = s.scheduler.RunJob(ctx, job.Definition{
+		UID:        jobUID,
+		Type:       jobType,
+		MaxRetries: 1,
+		Timeout:    s.jobMaxDur,
+		Data:       string(data),
+		SpaceID:    repo.ParentID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to schedule repository stats job: %w", err)
+	}
+
+	return nil
+}
+
+// Handle calculates the stats of a single repository.
+func (s *Service) Handle(ctx context.Context, data string, _ job.ProgressReporter) (string, error) {
+	var input Input
+	if err := json.Unmarshal([]byte(data), &input); err != nil {
+		return "", fmt.Errorf("failed to unmarshal repository stats job input: %w", err)
+	}
+
+	repo, err := s.repoStore.Find(ctx, input.RepoID)
+	if err != nil {
+		return "", fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	if err = s.calculate(ctx, repo.ID, repo.GitUID); err != nil {
+		return "", err
+	}
+
+	return "", nil
+}
+
+func (s *Service) calculate(ctx context.Context, repoID int64, gitUID string) error {
+	readParams := git.ReadParams{RepoUID: gitUID}
+
+	objectStats, err := s.git.GetObjectStats(ctx, &git.GetObjectStatsParams{ReadParams: readParams})
+	if err != nil {
+		return fmt.Errorf("failed to get object stats: %w", err)
+	}
+
+	blobStats, err := s.git.GetBlobStats(ctx, &git.GetBlobStatsParams{
+		ReadParams:   readParams,
+		LargestLimit: s.largestBlobs,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get blob stats: %w", err)
+	}
+
+	largestBlobs := make([]types.RepositoryStatsBlob, len(blobStats.Largest))
+	for i, blob := range blobStats.Largest {
+		largestBlobs[i] = types.RepositoryStatsBlob{
+			SHA:  blob.SHA,
+			Path: blob.Path,
+			Size: blob.Size,
+		}
+	}
+
+	err = s.repoStatsStore.Upsert(ctx, &types.RepositoryStats{
+		RepoID:        repoID,
+		DiskSize:      objectStats.LooseSize + objectStats.PackSize,
+		LooseObjects:  objectStats.LooseObjects,
+		LooseSize:     objectStats.LooseSize,
+		PackedObjects: objectStats.PackedObjects,
+		Packs:         objectStats.Packs,
+		PackSize:      objectStats.PackSize,
+		Blobs:         blobStats.Blobs,
+		BlobsSize:     blobStats.Size,
+		LargestBlobs:  largestBlobs,
+		LFS: types.RepositoryStatsLFSUsage{
+			Objects: blobStats.LFSObjects,
+			Size:    blobStats.LFSSize,
+		},
+		Updated: time.Now().UnixMilli(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store repository stats: %w", err)
+	}
+
+	return nil
+}
+
+// calculator is the handler of the recurring job that calculates the stats of all repositories.
+type calculator struct {
+	s *Service
+}
+
+func (c calculator) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	s := c.s
+	if !s.enabled {
+		return "", nil
+	}
+
+	repos, err := s.repoStore.ListSizeInfos(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list repositories: %w", err)
+	}
+
+	var wg sync.WaitGroup
+	taskCh := make(chan *types.RepositorySizeInfo)
+	for i := 0; i < s.numWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for repo := range taskCh {
+				if err := s.calculate(ctx, repo.ID, repo.GitUID); err != nil {
+					log.Ctx(ctx).Warn().Err(err).
+						Int64("repo_id", repo.ID).
+						Str("repo_git_uid", repo.GitUID).
+						Msg("failed to calculate repository stats")
+				}
+			}
+		}()
+	}
+	for _, repo := range repos {
+		select {
+		case <-ctx.Done():
+			break
+		case taskCh <- repo:
+		}
+	}
+	close(taskCh)
+	wg.Wait()
+
+	return "", nil
+}
@@ -0,0 +1,199 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/pipeline/commit"
+	"github.com/harness/gitness/app/pipeline/triggerer"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/drone/go-scm/scm"
+	"github.com/gorhill/cronexpr"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobType = "pipeline-schedules"
+
+	// dueSchedulesBatchSize is the maximum number of due schedules processed by a single job run.
+	dueSchedulesBatchSize = 100
+)
+
+// Service periodically triggers the executions of pipelines that have a due schedule.
+type Service struct {
+	enabled       bool
+	cron          string
+	maxDur        time.Duration
+	scheduleStore store.ScheduleStore
+	pipelineStore store.PipelineStore
+	repoStore     store.RepoStore
+	commitSvc     commit.Service
+	triggerSvc    triggerer.Triggerer
+	scheduler     *job.Scheduler
+}
+
+func (s *Service) Register(ctx context.Context) error {
+	if !s.enabled {
+		return nil
+	}
+
+	err := s.scheduler.AddRecurring(ctx, jobType, jobType, s.cron, s.maxDur)
+	if err != nil {
+		return fmt.Errorf("failed to register recurring job for pipeline schedule service: %w", err)
+	}
+
+	return nil
+}
+
+// Handle triggers the executions of all due schedules and calculates their next run.
+func (s *Service) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	if !s.enabled {
+		return "", nil
+	}
+
+	now := time.Now()
+
+	schedules, err := s.scheduleStore.ListDue(ctx, now.UnixMilli(), dueSchedulesBatchSize)
+	if err != nil {
+		return "", fmt.Errorf("failed to list due schedules: %w", err)
+	}
+
+	var triggered int
+	for _, schedule := range schedules {
+		if ctx.Err() != nil {
+			break
+		}
+
+		log := log.Ctx(ctx).With().
+			Int64("pipeline_id", schedule.PipelineID).
+			Str("schedule_uid", schedule.UID).
+			Logger()
+
+		// the next run is moved forward even if the execution can't be triggered,
+		// otherwise a broken schedule would be retried on every run of the job.
+		_, err = s.scheduleStore.UpdateOptLock(ctx, schedule, func(schedule *types.Schedule) error {
+			nextRun, err := NextRun(schedule.Cron, schedule.Timezone, now)
+			if err != nil {
+				return err
+			}
+
+			schedule.LastRun = now.UnixMilli()
+			schedule.NextRun = nextRun.UnixMilli()
+
+			return nil
+		})
+		if err != nil {
+			log.Warn().Err(err).Msg("failed to update next run of schedule")
+			continue
+		}
+
+		execution, err := s.trigger(ctx, schedule)
+		if err != nil {
+			log.Warn().Err(err).Msg("failed to trigger scheduled execution")
+			continue
+		}
+
+		if execution != nil {
+			triggered++
+		}
+	}
+
+	return fmt.Sprintf("processed %d due schedules, triggered %d executions", len(schedules), triggered), nil
+}
+
+func (s *Service) trigger(ctx context.Context, schedule *types.Schedule) (*types.Execution, error) {
+	pipeline, err := s.pipelineStore.Find(ctx, schedule.PipelineID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	repo, err := s.repoStore.Find(ctx, schedule.RepoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	// If the branch is empty, use the default branch specified in the pipeline.
+	// It that is also empty, use the repo default branch.
+	branch := schedule.Branch
+	if branch == "" {
+		branch = pipeline.DefaultBranch
+		if branch == "" {
+			branch = repo.DefaultBranch
+		}
+	}
+	ref := scm.ExpandRef(branch, "refs/heads")
+
+	commit, err := s.commitSvc.FindRef(ctx, repo, ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch commit: %w", err)
+	}
+
+	hook := &triggerer.Hook{
+		Trigger:     enum.TriggerCron,
+		TriggeredBy: schedule.CreatedBy,
+		Cron:        schedule.UID,
+		AuthorLogin: commit.Author.Identity.Name,
+		AuthorName:  commit.Author.Identity.Name,
+		AuthorEmail: commit.Author.Identity.Email,
+		Ref:         ref,
+		Message:     commit.Message,
+		Before:      commit.SHA,
+		After:       commit.SHA,
+		Source:      branch,
+		Target:      branch,
+		Params:      map[string]string{},
+		Timestamp:   commit.Author.When.UnixMilli(),
+	}
+
+	return s.triggerSvc.Trigger(ctx, pipeline, hook)
+}
+
+// NextRun returns the next time after the provided time the cron expression matches in the provided timezone.
+// An empty timezone stands for UTC.
+func NextRun(cron string, timezone string, after time.Time) (time.Time, error) {
+	// schedules are evaluated at most once a minute, hence expressions with seconds or years aren't supported.
+	if err := check.CronExpression(cron, timezone); err != nil {
+		return time.Time{}, err
+	}
+
+	expr, err := cronexpr.Parse(strings.TrimSpace(cron))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid cron expression: %w", err)
+	}
+
+	loc := time.UTC
+	if timezone != "" {
+		loc, err = time.LoadLocation(timezone)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid timezone: %w", err)
+		}
+	}
+
+	next := expr.Next(after.In(loc))
+	if next.IsZero() {
+		return time.Time{}, fmt.Errorf("cron expression never matches")
+	}
+
+	return next, nil
+}
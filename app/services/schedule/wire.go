@@ -0,0 +1,59 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedule
+
+import (
+	"github.com/harness/gitness/app/pipeline/commit"
+	"github.com/harness/gitness/app/pipeline/triggerer"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	ProvideService,
+)
+
+func ProvideService(
+	config *types.Config,
+	scheduleStore store.ScheduleStore,
+	pipelineStore store.PipelineStore,
+	repoStore store.RepoStore,
+	commitSvc commit.Service,
+	triggerSvc triggerer.Triggerer,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+) (*Service, error) {
+	s := &Service{
+		enabled:       config.PipelineSchedule.Enabled,
+		cron:          config.PipelineSchedule.CRON,
+		maxDur:        config.PipelineSchedule.MaxDuration,
+		scheduleStore: scheduleStore,
+		pipelineStore: pipelineStore,
+		repoStore:     repoStore,
+		commitSvc:     commitSvc,
+		triggerSvc:    triggerSvc,
+		scheduler:     scheduler,
+	}
+
+	err := executor.Register(jobType, s)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
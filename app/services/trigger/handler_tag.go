@@ -17,16 +17,32 @@ package trigger
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/harness/gitness/app/bootstrap"
 	gitevents "github.com/harness/gitness/app/events/git"
 	"github.com/harness/gitness/app/pipeline/triggerer"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// Build parameters providing the tag and release details to the pipeline.
+const (
+	paramTag          = "DRONE_TAG"
+	paramRelease      = "DRONE_RELEASE"
+	paramReleaseTitle = "DRONE_RELEASE_TITLE"
 )
 
+// ExtractTag returns the name of the tag of the ref.
+func ExtractTag(ref string) string {
+	return strings.TrimPrefix(ref, "refs/tags/")
+}
+
 func (s *Service) handleEventTagCreated(ctx context.Context,
 	event *events.Event[*gitevents.TagCreatedPayload]) error {
+	tagName := ExtractTag(event.Payload.Ref)
 	hook := &triggerer.Hook{
 		Trigger:     enum.TriggerHook,
 		Action:      enum.TriggerActionTagCreated,
@@ -36,12 +52,55 @@ func (s *Service) handleEventTagCreated(ctx context.Context,
 		After:       event.Payload.SHA,
 		Source:      event.Payload.Ref,
 		Target:      event.Payload.Ref,
+		Params:      map[string]string{paramTag: tagName},
 	}
 	err := s.augmentCommitInfo(ctx, hook, event.Payload.RepoID, event.Payload.SHA)
 	if err != nil {
 		return fmt.Errorf("could not augment commit info: %w", err)
 	}
-	return s.trigger(ctx, event.Payload.RepoID, enum.TriggerActionTagCreated, hook)
+
+	var errs error
+	if err = s.trigger(ctx, event.Payload.RepoID, enum.TriggerActionTagCreated, hook); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+
+	if err = s.triggerReleasePublished(ctx, event.Payload.RepoID, tagName, *hook); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+
+	return errs
+}
+
+// triggerReleasePublished fires the release triggers if the created tag is a release.
+// Releases are published as annotated tags, lightweight tags are ignored.
+func (s *Service) triggerReleasePublished(
+	ctx context.Context,
+	repoID int64,
+	tagName string,
+	hook triggerer.Hook,
+) error {
+	repo, err := s.repoStore.Find(ctx, repoID)
+	if err != nil {
+		return fmt.Errorf("could not find repo: %w", err)
+	}
+
+	tag, err := s.commitSvc.FindTag(ctx, repo, tagName)
+	if err != nil {
+		return fmt.Errorf("could not find tag: %w", err)
+	}
+
+	if !tag.IsAnnotated {
+		return nil
+	}
+
+	hook.Action = enum.TriggerActionReleasePublished
+	hook.Params = map[string]string{
+		paramTag:          tagName,
+		paramRelease:      tagName,
+		paramReleaseTitle: tag.Title,
+	}
+
+	return s.trigger(ctx, repoID, enum.TriggerActionReleasePublished, &hook)
 }
 
 func (s *Service) handleEventTagUpdated(ctx context.Context,
@@ -55,6 +114,7 @@ func (s *Service) handleEventTagUpdated(ctx context.Context,
 		After:       event.Payload.NewSHA,
 		Source:      event.Payload.Ref,
 		Target:      event.Payload.Ref,
+		Params:      map[string]string{paramTag: ExtractTag(event.Payload.Ref)},
 	}
 	err := s.augmentCommitInfo(ctx, hook, event.Payload.RepoID, event.Payload.NewSHA)
 	if err != nil {
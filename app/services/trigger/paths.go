@@ -0,0 +1,113 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trigger
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/pipeline/triggerer"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/rs/zerolog/log"
+)
+
+// changedFiles holds the files changed by the event of a hook. They are loaded lazily,
+// so the diff is computed at most once for all triggers of the repository.
+type changedFiles struct {
+	repoID int64
+	hook   *triggerer.Hook
+
+	loaded bool
+	known  bool
+	files  []string
+	err    error
+}
+
+// getChangedFiles returns the files changed by the event of the hook. If the changes can't be
+// determined for the event (e.g. a new branch or a tag), known is false.
+func (s *Service) getChangedFiles(ctx context.Context, changes *changedFiles) ([]string, bool, error) {
+	if changes.loaded {
+		return changes.files, changes.known, changes.err
+	}
+	changes.loaded = true
+
+	hook := changes.hook
+
+	event := hook.Action.GetTriggerEvent()
+	if event != enum.TriggerEventPush && event != enum.TriggerEventPullRequest {
+		return nil, false, nil
+	}
+
+	if hook.Before == "" || hook.Before == types.NilSHA || hook.After == "" {
+		return nil, false, nil
+	}
+
+	repo, err := s.repoStore.Find(ctx, changes.repoID)
+	if err != nil {
+		changes.err = fmt.Errorf("could not find repo: %w", err)
+		return nil, false, changes.err
+	}
+
+	changes.files, changes.err = s.commitSvc.ListChangedFiles(ctx, repo, hook.Before, hook.After)
+	changes.known = changes.err == nil
+
+	return changes.files, changes.known, changes.err
+}
+
+// matchPaths returns true if the trigger has no path filters, the changed files can't be determined,
+// or at least one of the changed files is included and not excluded by the path filters of the trigger.
+func (s *Service) matchPaths(ctx context.Context, t *types.Trigger, changes *changedFiles) bool {
+	if t.Paths.IsEmpty() {
+		return true
+	}
+
+	files, known, err := s.getChangedFiles(ctx, changes)
+	if err != nil {
+		// fail open, a superfluous execution is better than a missing one.
+		log.Ctx(ctx).Warn().Err(err).
+			Int64("trigger.id", t.ID).
+			Msg("failed to get changed files, ignoring path filters of the trigger")
+		return true
+	}
+	if !known {
+		return true
+	}
+
+	for _, file := range files {
+		if matchAnyPath(t.Paths.Include, file, true) && !matchAnyPath(t.Paths.Exclude, file, false) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchAnyPath returns true if the file matches any of the patterns, or returns matchEmpty if there are no patterns.
+func matchAnyPath(patterns []string, file string, matchEmpty bool) bool {
+	if len(patterns) == 0 {
+		return matchEmpty
+	}
+
+	for _, pattern := range patterns {
+		if ok, _ := doublestar.Match(pattern, file); ok {
+			return true
+		}
+	}
+
+	return false
+}
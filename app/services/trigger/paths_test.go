@@ -0,0 +1,105 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trigger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/pipeline/triggerer"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestMatchPaths(t *testing.T) {
+	hook := &triggerer.Hook{
+		Action: enum.TriggerActionBranchUpdated,
+		Before: "1111111111111111111111111111111111111111",
+		After:  "2222222222222222222222222222222222222222",
+	}
+
+	tests := []struct {
+		name  string
+		paths types.TriggerPaths
+		files []string
+		want  bool
+	}{
+		{
+			name:  "no-filters",
+			files: []string{"docs/index.md"},
+			want:  true,
+		},
+		{
+			name:  "excluded-docs",
+			paths: types.TriggerPaths{Exclude: []string{"docs/**", "**/*.md"}},
+			files: []string{"docs/index.md", "README.md"},
+			want:  false,
+		},
+		{
+			name:  "excluded-docs-with-code",
+			paths: types.TriggerPaths{Exclude: []string{"docs/**", "**/*.md"}},
+			files: []string{"docs/index.md", "app/main.go"},
+			want:  true,
+		},
+		{
+			name:  "included",
+			paths: types.TriggerPaths{Include: []string{"app/**"}},
+			files: []string{"docs/index.md", "app/main.go"},
+			want:  true,
+		},
+		{
+			name:  "not-included",
+			paths: types.TriggerPaths{Include: []string{"app/**"}},
+			files: []string{"docs/index.md"},
+			want:  false,
+		},
+		{
+			name:  "included-but-excluded",
+			paths: types.TriggerPaths{Include: []string{"app/**"}, Exclude: []string{"**/*_test.go"}},
+			files: []string{"app/main_test.go"},
+			want:  false,
+		},
+		{
+			name:  "no-changes",
+			paths: types.TriggerPaths{Include: []string{"app/**"}},
+			want:  false,
+		},
+	}
+
+	s := &Service{}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			changes := &changedFiles{hook: hook, loaded: true, known: true, files: test.files}
+			trigger := &types.Trigger{Paths: test.paths}
+			if got := s.matchPaths(context.Background(), trigger, changes); got != test.want {
+				t.Errorf("want=%t got=%t", test.want, got)
+			}
+		})
+	}
+}
+
+func TestMatchPathsUnknownChanges(t *testing.T) {
+	// the changes of a new branch aren't known, the path filters are ignored.
+	hook := &triggerer.Hook{
+		Action: enum.TriggerActionBranchCreated,
+		After:  "2222222222222222222222222222222222222222",
+	}
+
+	s := &Service{}
+	trigger := &types.Trigger{Paths: types.TriggerPaths{Include: []string{"app/**"}}}
+	if !s.matchPaths(context.Background(), trigger, &changedFiles{hook: hook}) {
+		t.Errorf("expected the trigger to match")
+	}
+}
@@ -164,6 +164,8 @@ func (s *Service) trigger(ctx context.Context, repoID int64,
 		}
 	}
 
+	changes := &changedFiles{repoID: repoID, hook: hook}
+
 	var errs error
 	for _, t := range validTriggers {
 		// TODO: We can make a minor optimization here to not fetch a pipeline each time
@@ -179,6 +181,11 @@ func (s *Service) trigger(ctx context.Context, repoID int64,
 			continue
 		}
 
+		// Don't fire triggers if none of the changed files match the path filters.
+		if !s.matchPaths(ctx, t, changes) {
+			continue
+		}
+
 		_, err = s.triggerSvc.Trigger(ctx, pipeline, hook)
 		if err != nil {
 			errs = multierror.Append(errs, err)
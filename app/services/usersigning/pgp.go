@@ -0,0 +1,114 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usersigning
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	//nolint:staticcheck // deprecated, but sufficient for verifying signatures.
+	"golang.org/x/crypto/openpgp"
+	//nolint:staticcheck // deprecated, but sufficient for verifying signatures.
+	"golang.org/x/crypto/openpgp/armor"
+	//nolint:staticcheck // deprecated, but sufficient for verifying signatures.
+	pgperrors "golang.org/x/crypto/openpgp/errors"
+	//nolint:staticcheck // deprecated, but sufficient for verifying signatures.
+	"golang.org/x/crypto/openpgp/packet"
+)
+
+const pgpSigArmorType = "PGP SIGNATURE"
+
+var (
+	errNotPGPSignature = errors.New("not a pgp signature")
+	errPGPUnknownKey   = errors.New("pgp signature was created by an unknown key")
+)
+
+// parsePGPPublicKey parses an armored OpenPGP public key and returns the fingerprint of its primary key.
+func parsePGPPublicKey(armored string) (string, error) {
+	entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(armored))
+	if err != nil {
+		return "", fmt.Errorf("failed to read pgp public key: %w", err)
+	}
+
+	if len(entities) != 1 {
+		return "", fmt.Errorf("expected a single pgp public key, got %d", len(entities))
+	}
+
+	if entities[0].PrivateKey != nil {
+		return "", errors.New("pgp key must not contain a private key")
+	}
+
+	return pgpFingerprint(entities[0]), nil
+}
+
+func pgpFingerprint(entity *openpgp.Entity) string {
+	return fmt.Sprintf("%X", entity.PrimaryKey.Fingerprint)
+}
+
+// pgpSignatureIssuer returns the id of the key that created the armored signature.
+// errNotPGPSignature is returned if the signature is of a different format (e.g. SSH).
+func pgpSignatureIssuer(armored string) (string, error) {
+	block, err := armor.Decode(strings.NewReader(armored))
+	if err != nil || block.Type != pgpSigArmorType {
+		return "", errNotPGPSignature
+	}
+
+	p, err := packet.Read(block.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read pgp signature packet: %w", err)
+	}
+
+	var issuer *uint64
+	switch sig := p.(type) {
+	case *packet.Signature:
+		issuer = sig.IssuerKeyId
+	case *packet.SignatureV3:
+		issuer = &sig.IssuerKeyId
+	default:
+		return "", fmt.Errorf("unexpected pgp packet %T", p)
+	}
+
+	if issuer == nil {
+		return "", errors.New("pgp signature has no issuer")
+	}
+
+	return fmt.Sprintf("%016X", *issuer), nil
+}
+
+// verifyPGPSignature verifies that the armored signature was created over the payload by one of the keys.
+// It returns the fingerprint of the primary key of the signer, or errPGPUnknownKey if none of the keys signed it.
+func verifyPGPSignature(armoredKeys []string, signature string, payload string) (string, error) {
+	var keyring openpgp.EntityList
+	for _, armoredKey := range armoredKeys {
+		entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(armoredKey))
+		if err != nil {
+			return "", fmt.Errorf("failed to read pgp public key: %w", err)
+		}
+
+		keyring = append(keyring, entities...)
+	}
+
+	signer, err := openpgp.CheckArmoredDetachedSignature(keyring,
+		strings.NewReader(payload), strings.NewReader(signature))
+	if errors.Is(err, pgperrors.ErrUnknownIssuer) {
+		return "", errPGPUnknownKey
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return pgpFingerprint(signer), nil
+}
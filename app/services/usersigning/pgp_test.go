@@ -0,0 +1,102 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usersigning
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	//nolint:staticcheck // deprecated, but sufficient for verifying signatures.
+	"golang.org/x/crypto/openpgp"
+	//nolint:staticcheck // deprecated, but sufficient for verifying signatures.
+	"golang.org/x/crypto/openpgp/armor"
+)
+
+func generatePGPKey(t *testing.T, email string) (*openpgp.Entity, string) {
+	t.Helper()
+
+	entity, err := openpgp.NewEntity("test", "", email, nil)
+	if err != nil {
+		t.Fatalf("failed to generate pgp key: %s", err)
+	}
+
+	buf := &bytes.Buffer{}
+	w, err := armor.Encode(buf, openpgp.PublicKeyType, nil)
+	if err != nil {
+		t.Fatalf("failed to create armor encoder: %s", err)
+	}
+	if err = entity.Serialize(w); err != nil {
+		t.Fatalf("failed to serialize pgp public key: %s", err)
+	}
+	if err = w.Close(); err != nil {
+		t.Fatalf("failed to close armor encoder: %s", err)
+	}
+
+	return entity, buf.String()
+}
+
+func TestPGPSignatureVerify(t *testing.T) {
+	signer, signerKey := generatePGPKey(t, "signer@example.com")
+	_, otherKey := generatePGPKey(t, "other@example.com")
+
+	fingerprint, err := parsePGPPublicKey(signerKey)
+	if err != nil {
+		t.Fatalf("failed to parse pgp public key: %s", err)
+	}
+	if want := pgpFingerprint(signer); fingerprint != want {
+		t.Errorf("fingerprint: want=%s got=%s", want, fingerprint)
+	}
+
+	const payload = "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n\ncommit message\n"
+
+	sig := &bytes.Buffer{}
+	if err = openpgp.ArmoredDetachSign(sig, signer, strings.NewReader(payload), nil); err != nil {
+		t.Fatalf("failed to sign payload: %s", err)
+	}
+	signature := sig.String()
+
+	issuer, err := pgpSignatureIssuer(signature)
+	if err != nil {
+		t.Fatalf("failed to get signature issuer: %s", err)
+	}
+	if want := fingerprint[len(fingerprint)-16:]; issuer != want {
+		t.Errorf("issuer: want=%s got=%s", want, issuer)
+	}
+
+	got, err := verifyPGPSignature([]string{otherKey, signerKey}, signature, payload)
+	if err != nil {
+		t.Fatalf("failed to verify valid signature: %s", err)
+	}
+	if got != fingerprint {
+		t.Errorf("signer fingerprint: want=%s got=%s", fingerprint, got)
+	}
+
+	_, err = verifyPGPSignature([]string{otherKey}, signature, payload)
+	if !errors.Is(err, errPGPUnknownKey) {
+		t.Errorf("expected unknown key error, got: %v", err)
+	}
+
+	_, err = verifyPGPSignature([]string{signerKey}, signature, payload+"tampered")
+	if err == nil || errors.Is(err, errPGPUnknownKey) {
+		t.Errorf("expected bad signature error, got: %v", err)
+	}
+
+	_, err = pgpSignatureIssuer("-----BEGIN SSH SIGNATURE-----\nU1NIU0lH\n-----END SSH SIGNATURE-----\n")
+	if !errors.Is(err, errNotPGPSignature) {
+		t.Errorf("expected not a pgp signature error, got: %v", err)
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usersigning
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	gitnessstore "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const pgpPublicKeyArmorHeader = "-----BEGIN PGP PUBLIC KEY BLOCK-----"
+
+// ListPublicKeys returns all public keys registered by the principal.
+func (s *Service) ListPublicKeys(ctx context.Context, principalID int64) ([]*types.PublicKey, error) {
+	keys, err := s.publicKeyStore.List(ctx, principalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list public keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// AddPublicKey registers a public key of the principal. Commits and tags signed with the key are verified.
+func (s *Service) AddPublicKey(
+	ctx context.Context,
+	principalID int64,
+	content string,
+	comment string,
+) (*types.PublicKey, error) {
+	content = strings.TrimSpace(content)
+	comment = strings.TrimSpace(comment)
+
+	key := &types.PublicKey{
+		PrincipalID: principalID,
+		Content:     content,
+		Comment:     comment,
+		Created:     time.Now().UnixMilli(),
+	}
+
+	var err error
+	if strings.HasPrefix(content, pgpPublicKeyArmorHeader) {
+		key.Format = enum.SigningKeyFormatOpenPGP
+		key.Fingerprint, err = parsePGPPublicKey(content)
+		if err != nil {
+			return nil, usererror.BadRequestf("Invalid OpenPGP public key: %s", err)
+		}
+	} else {
+		publicKey, sshComment, _, _, errParse := ssh.ParseAuthorizedKey([]byte(content))
+		if errParse != nil {
+			return nil, usererror.BadRequest(
+				"Public key must be an armored OpenPGP public key or an SSH public key in authorized_keys format.")
+		}
+
+		key.Format = enum.SigningKeyFormatSSH
+		key.Fingerprint = ssh.FingerprintSHA256(publicKey)
+		if key.Comment == "" {
+			key.Comment = sshComment
+		}
+	}
+
+	err = s.publicKeyStore.Create(ctx, key)
+	if errors.Is(err, gitnessstore.ErrDuplicate) {
+		return nil, usererror.Conflict("The public key is already registered.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create public key: %w", err)
+	}
+
+	return key, nil
+}
+
+// DeletePublicKey deletes a public key of the principal. Commits and tags signed with the key are no longer verified.
+func (s *Service) DeletePublicKey(ctx context.Context, principalID int64, keyID int64) error {
+	key, err := s.publicKeyStore.Find(ctx, keyID)
+	if err != nil {
+		return fmt.Errorf("failed to find public key: %w", err)
+	}
+
+	if key.PrincipalID != principalID {
+		return usererror.ErrNotFound
+	}
+
+	if err = s.publicKeyStore.Delete(ctx, keyID); err != nil {
+		return fmt.Errorf("failed to delete public key: %w", err)
+	}
+
+	return nil
+}
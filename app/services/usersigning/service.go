@@ -0,0 +1,198 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usersigning
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	gitnessstore "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+var errUserKeysDisabled = usererror.BadRequest("Instance-managed signing keys are disabled.")
+
+// Service maintains the instance-managed signing keys used to sign the commits
+// the server creates on behalf of users and the public keys registered by users,
+// and verifies the signatures of commits and tags.
+type Service struct {
+	enabled            bool
+	tx                 dbtx.Transactor
+	keyStore           store.UserSigningKeyStore
+	publicKeyStore     store.PublicKeyStore
+	principalStore     store.PrincipalStore
+	principalInfoCache store.PrincipalInfoCache
+	encrypter          encrypt.Encrypter
+}
+
+func NewService(
+	enabled bool,
+	tx dbtx.Transactor,
+	keyStore store.UserSigningKeyStore,
+	publicKeyStore store.PublicKeyStore,
+	principalStore store.PrincipalStore,
+	principalInfoCache store.PrincipalInfoCache,
+	encrypter encrypt.Encrypter,
+) *Service {
+	return &Service{
+		enabled:            enabled,
+		tx:                 tx,
+		keyStore:           keyStore,
+		publicKeyStore:     publicKeyStore,
+		principalStore:     principalStore,
+		principalInfoCache: principalInfoCache,
+		encrypter:          encrypter,
+	}
+}
+
+// Enabled returns true if the instance-managed user signing keys are enabled.
+func (s *Service) Enabled() bool {
+	return s.enabled
+}
+
+// List returns all signing keys of the principal.
+func (s *Service) List(ctx context.Context, principalID int64) ([]*types.UserSigningKey, error) {
+	if !s.enabled {
+		return nil, errUserKeysDisabled
+	}
+
+	keys, err := s.keyStore.List(ctx, principalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user signing keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// Issue creates a new signing key for the principal. The previously active key, if any, is rotated.
+func (s *Service) Issue(ctx context.Context, principalID int64) (*types.UserSigningKey, error) {
+	if !s.enabled {
+		return nil, errUserKeysDisabled
+	}
+
+	return s.issue(ctx, principalID)
+}
+
+func (s *Service) issue(ctx context.Context, principalID int64) (*types.UserSigningKey, error) {
+	generated, err := generateSSHKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate signing key: %w", err)
+	}
+
+	privateKey, err := s.encrypter.Encrypt(generated.privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt signing key: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	key := &types.UserSigningKey{
+		PrincipalID: principalID,
+		Format:      enum.SigningKeyFormatSSH,
+		Fingerprint: generated.fingerprint,
+		PublicKey:   generated.publicKey,
+		PrivateKey:  string(privateKey),
+		Created:     now,
+	}
+
+	err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+		active, err := s.keyStore.FindActive(ctx, principalID)
+		if err != nil && !errors.Is(err, gitnessstore.ErrResourceNotFound) {
+			return fmt.Errorf("failed to find active user signing key: %w", err)
+		}
+
+		if active != nil {
+			active.Rotated = &now
+			if err = s.keyStore.Update(ctx, active); err != nil {
+				return fmt.Errorf("failed to rotate user signing key: %w", err)
+			}
+		}
+
+		if err = s.keyStore.Create(ctx, key); err != nil {
+			return fmt.Errorf("failed to create user signing key: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// Revoke revokes a signing key of the principal. Commits signed with a revoked key are no longer verified.
+func (s *Service) Revoke(ctx context.Context, principalID int64, keyID int64) (*types.UserSigningKey, error) {
+	if !s.enabled {
+		return nil, errUserKeysDisabled
+	}
+
+	key, err := s.keyStore.Find(ctx, keyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user signing key: %w", err)
+	}
+
+	if key.PrincipalID != principalID {
+		return nil, usererror.ErrNotFound
+	}
+
+	if key.Revoked != nil {
+		return key, nil
+	}
+
+	now := time.Now().UnixMilli()
+	key.Revoked = &now
+
+	if err = s.keyStore.Update(ctx, key); err != nil {
+		return nil, fmt.Errorf("failed to revoke user signing key: %w", err)
+	}
+
+	return key, nil
+}
+
+// SigningKey returns the key that should be used to sign the commits created on behalf of the principal.
+// If the principal doesn't have an active key yet, a new one is issued.
+// Nil is returned if the instance-managed user signing keys are disabled.
+func (s *Service) SigningKey(ctx context.Context, principalID int64) (*git.SigningKey, error) {
+	if !s.enabled {
+		return nil, nil
+	}
+
+	key, err := s.keyStore.FindActive(ctx, principalID)
+	if errors.Is(err, gitnessstore.ErrResourceNotFound) {
+		key, err = s.issue(ctx, principalID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user signing key: %w", err)
+	}
+
+	privateKey, err := s.encrypter.Decrypt([]byte(key.PrivateKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt user signing key: %w", err)
+	}
+
+	return &git.SigningKey{
+		Format:     gitenum.SigningFormat(key.Format),
+		PrivateKey: privateKey,
+	}, nil
+}
@@ -0,0 +1,114 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usersigning
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	opensshKeyMagic      = "openssh-key-v1\x00"
+	opensshKeyPEMType    = "OPENSSH PRIVATE KEY"
+	opensshKeyBlockSize  = 8
+	opensshKeyNoneCipher = "none"
+)
+
+type generatedKey struct {
+	privateKey  string
+	publicKey   string
+	fingerprint string
+}
+
+// generateSSHKey generates a new ed25519 key pair.
+// The private key is returned in the (unencrypted) OpenSSH format as expected by ssh-keygen,
+// the public key in the authorized_keys format.
+func generateSSHKey() (generatedKey, error) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return generatedKey{}, fmt.Errorf("failed to generate ed25519 key: %w", err)
+	}
+
+	sshPublic, err := ssh.NewPublicKey(public)
+	if err != nil {
+		return generatedKey{}, fmt.Errorf("failed to convert ed25519 public key: %w", err)
+	}
+
+	privatePEM, err := marshalED25519PrivateKey(private, sshPublic)
+	if err != nil {
+		return generatedKey{}, err
+	}
+
+	return generatedKey{
+		privateKey:  string(privatePEM),
+		publicKey:   strings.TrimSpace(string(ssh.MarshalAuthorizedKey(sshPublic))),
+		fingerprint: ssh.FingerprintSHA256(sshPublic),
+	}, nil
+}
+
+// marshalED25519PrivateKey encodes the key in the OpenSSH private key format (see PROTOCOL.key in OpenSSH).
+func marshalED25519PrivateKey(private ed25519.PrivateKey, public ssh.PublicKey) ([]byte, error) {
+	var checkBytes [4]byte
+	if _, err := rand.Read(checkBytes[:]); err != nil {
+		return nil, fmt.Errorf("failed to generate check bytes: %w", err)
+	}
+	check := binary.BigEndian.Uint32(checkBytes[:])
+
+	privateBlock := ssh.Marshal(struct {
+		Check1  uint32
+		Check2  uint32
+		KeyType string
+		Public  []byte
+		Private []byte
+		Comment string
+	}{
+		Check1:  check,
+		Check2:  check,
+		KeyType: ssh.KeyAlgoED25519,
+		Public:  private.Public().(ed25519.PublicKey),
+		Private: private,
+	})
+
+	// the private block is padded with the bytes 1, 2, 3, ... to a multiple of the cipher block size
+	for i := byte(1); len(privateBlock)%opensshKeyBlockSize != 0; i++ {
+		privateBlock = append(privateBlock, i)
+	}
+
+	key := ssh.Marshal(struct {
+		CipherName   string
+		KDFName      string
+		KDFOptions   string
+		NumKeys      uint32
+		PublicKey    []byte
+		PrivateBlock []byte
+	}{
+		CipherName:   opensshKeyNoneCipher,
+		KDFName:      opensshKeyNoneCipher,
+		NumKeys:      1,
+		PublicKey:    public.Marshal(),
+		PrivateBlock: privateBlock,
+	})
+
+	return pem.EncodeToMemory(&pem.Block{
+		Type:  opensshKeyPEMType,
+		Bytes: append([]byte(opensshKeyMagic), key...),
+	}), nil
+}
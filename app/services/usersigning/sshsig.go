@@ -0,0 +1,112 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usersigning
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hash"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	sshSigMagic     = "SSHSIG"
+	sshSigPEMType   = "SSH SIGNATURE"
+	sshSigVersion   = 1
+	sshSigNamespace = "git"
+)
+
+var errNotSSHSignature = errors.New("not an ssh signature")
+
+// sshSignature is the wire format of an ssh signature (see PROTOCOL.sshsig in OpenSSH).
+type sshSignature struct {
+	Version       uint32
+	PublicKey     []byte
+	Namespace     string
+	Reserved      string
+	HashAlgorithm string
+	Signature     []byte
+}
+
+// parseSSHSignature parses the armored ssh signature and returns the public key that created it.
+// errNotSSHSignature is returned if the signature is of a different format (e.g. OpenPGP).
+func parseSSHSignature(armored string) (*sshSignature, ssh.PublicKey, error) {
+	block, _ := pem.Decode([]byte(armored))
+	if block == nil || block.Type != sshSigPEMType {
+		return nil, nil, errNotSSHSignature
+	}
+
+	if !bytes.HasPrefix(block.Bytes, []byte(sshSigMagic)) {
+		return nil, nil, errors.New("ssh signature is missing the magic preamble")
+	}
+
+	sig := &sshSignature{}
+	if err := ssh.Unmarshal(block.Bytes[len(sshSigMagic):], sig); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal ssh signature: %w", err)
+	}
+
+	if sig.Version != sshSigVersion {
+		return nil, nil, fmt.Errorf("unsupported ssh signature version %d", sig.Version)
+	}
+
+	publicKey, err := ssh.ParsePublicKey(sig.PublicKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse ssh signature public key: %w", err)
+	}
+
+	return sig, publicKey, nil
+}
+
+// verify verifies that the signature was created by the public key over the payload.
+func (sig *sshSignature) verify(publicKey ssh.PublicKey, payload []byte) error {
+	if sig.Namespace != sshSigNamespace {
+		return fmt.Errorf("unexpected ssh signature namespace %q", sig.Namespace)
+	}
+
+	var h hash.Hash
+	switch sig.HashAlgorithm {
+	case "sha256":
+		h = sha256.New()
+	case "sha512":
+		h = sha512.New()
+	default:
+		return fmt.Errorf("unsupported ssh signature hash algorithm %q", sig.HashAlgorithm)
+	}
+	_, _ = h.Write(payload)
+
+	signedData := append([]byte(sshSigMagic), ssh.Marshal(struct {
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Hash          []byte
+	}{
+		Namespace:     sig.Namespace,
+		Reserved:      sig.Reserved,
+		HashAlgorithm: sig.HashAlgorithm,
+		Hash:          h.Sum(nil),
+	})...)
+
+	signature := &ssh.Signature{}
+	if err := ssh.Unmarshal(sig.Signature, signature); err != nil {
+		return fmt.Errorf("failed to unmarshal ssh signature blob: %w", err)
+	}
+
+	return publicKey.Verify(signedData, signature)
+}
@@ -0,0 +1,101 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usersigning
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+func TestGenerateSSHKey(t *testing.T) {
+	key, err := generateSSHKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %s", err)
+	}
+
+	signer, err := ssh.ParsePrivateKey([]byte(key.privateKey))
+	if err != nil {
+		t.Fatalf("failed to parse generated private key: %s", err)
+	}
+
+	publicKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(key.publicKey))
+	if err != nil {
+		t.Fatalf("failed to parse generated public key: %s", err)
+	}
+
+	if got, want := ssh.FingerprintSHA256(signer.PublicKey()), key.fingerprint; got != want {
+		t.Errorf("private key fingerprint: want=%s got=%s", want, got)
+	}
+	if got, want := ssh.FingerprintSHA256(publicKey), key.fingerprint; got != want {
+		t.Errorf("public key fingerprint: want=%s got=%s", want, got)
+	}
+}
+
+func TestSSHSignatureVerify(t *testing.T) {
+	program, err := exec.LookPath("ssh-keygen")
+	if err != nil {
+		t.Skip("ssh-keygen is not available")
+	}
+
+	key, err := generateSSHKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %s", err)
+	}
+
+	keyPath := filepath.Join(t.TempDir(), "key")
+	if err = os.WriteFile(keyPath, []byte(key.privateKey), 0o600); err != nil {
+		t.Fatalf("failed to write key: %s", err)
+	}
+
+	const payload = "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n\ncommit message\n"
+
+	cmd := exec.Command(program, "-Y", "sign", "-n", sshSigNamespace, "-f", keyPath)
+	cmd.Stdin = strings.NewReader(payload)
+	armored, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("failed to sign payload: %s", err)
+	}
+
+	sig, publicKey, err := parseSSHSignature(string(armored))
+	if err != nil {
+		t.Fatalf("failed to parse signature: %s", err)
+	}
+
+	if got, want := ssh.FingerprintSHA256(publicKey), key.fingerprint; got != want {
+		t.Errorf("signature key fingerprint: want=%s got=%s", want, got)
+	}
+
+	if err = sig.verify(publicKey, []byte(payload)); err != nil {
+		t.Errorf("valid signature failed to verify: %s", err)
+	}
+
+	if err = sig.verify(publicKey, []byte(payload+"tampered")); err == nil {
+		t.Error("signature of a tampered payload verified")
+	}
+}
+
+func TestParseSSHSignatureOtherFormat(t *testing.T) {
+	const armored = "-----BEGIN PGP SIGNATURE-----\n\niQEzBAABCAAdFiEE\n-----END PGP SIGNATURE-----\n"
+
+	if _, _, err := parseSSHSignature(armored); !errors.Is(err, errNotSSHSignature) {
+		t.Errorf("want errNotSSHSignature, got %v", err)
+	}
+}
@@ -0,0 +1,307 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usersigning
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/harness/gitness/git"
+	gitnessstore "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+	"golang.org/x/crypto/ssh"
+)
+
+// VerifyCommits verifies the signatures of the commits against the instance-managed user signing keys
+// and the public keys registered by users. OpenPGP signatures are verified against the keys of the committer,
+// while ssh signatures are verified against the key they were created with.
+// The returned slice holds the verification result of each commit, or nil if the commit isn't signed.
+func (s *Service) VerifyCommits(ctx context.Context, commits []git.Commit) ([]*types.CommitVerification, error) {
+	v := s.newVerifier()
+	verifications := make([]*types.CommitVerification, len(commits))
+
+	for i := range commits {
+		if commits[i].Signature == nil {
+			continue
+		}
+
+		verification, err := v.verify(ctx, commits[i].Signature, commits[i].Committer.Identity.Email)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify commit %s: %w", commits[i].SHA, err)
+		}
+
+		verifications[i] = verification
+	}
+
+	return verifications, nil
+}
+
+// VerifyTags verifies the signatures of the annotated tags the same way as VerifyCommits verifies commits,
+// with the tagger in place of the committer.
+// The returned slice holds the verification result of each tag, or nil if the tag isn't signed.
+func (s *Service) VerifyTags(ctx context.Context, tags []git.CommitTag) ([]*types.CommitVerification, error) {
+	v := s.newVerifier()
+	verifications := make([]*types.CommitVerification, len(tags))
+
+	for i := range tags {
+		if tags[i].Signature == nil || tags[i].Tagger == nil {
+			continue
+		}
+
+		verification, err := v.verify(ctx, tags[i].Signature, tags[i].Tagger.Identity.Email)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify tag %s: %w", tags[i].Name, err)
+		}
+
+		verifications[i] = verification
+	}
+
+	return verifications, nil
+}
+
+// verifier caches the keys, as the verified commits are often signed by the same few keys.
+type verifier struct {
+	*Service
+	sshKeys       map[string]*types.UserSigningKey
+	sshPublicKeys map[string]*types.PublicKey
+	pgpKeys       map[string][]*types.PublicKey
+}
+
+func (s *Service) newVerifier() *verifier {
+	return &verifier{
+		Service:       s,
+		sshKeys:       map[string]*types.UserSigningKey{},
+		sshPublicKeys: map[string]*types.PublicKey{},
+		pgpKeys:       map[string][]*types.PublicKey{},
+	}
+}
+
+func (v *verifier) verify(
+	ctx context.Context,
+	signature *git.CommitSignature,
+	email string,
+) (*types.CommitVerification, error) {
+	verification, err := v.verifySSH(ctx, signature)
+	if !errors.Is(err, errNotSSHSignature) {
+		return verification, err
+	}
+
+	verification, err = v.verifyPGP(ctx, signature, email)
+	if !errors.Is(err, errNotPGPSignature) {
+		return verification, err
+	}
+
+	return &types.CommitVerification{
+		Reason: enum.CommitVerificationReasonUnsupportedFormat,
+	}, nil
+}
+
+func (v *verifier) verifySSH(
+	ctx context.Context,
+	signature *git.CommitSignature,
+) (*types.CommitVerification, error) {
+	sig, publicKey, err := parseSSHSignature(signature.Signature)
+	if errors.Is(err, errNotSSHSignature) {
+		return nil, err
+	}
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("failed to parse commit signature")
+		return &types.CommitVerification{
+			Format: enum.SigningKeyFormatSSH,
+			Reason: enum.CommitVerificationReasonBadSignature,
+		}, nil
+	}
+
+	fingerprint := ssh.FingerprintSHA256(publicKey)
+
+	key, ok := v.sshKeys[fingerprint]
+	if !ok && v.enabled {
+		key, err = v.keyStore.FindByFingerprint(ctx, fingerprint)
+		if err != nil && !errors.Is(err, gitnessstore.ErrResourceNotFound) {
+			return nil, fmt.Errorf("failed to find user signing key: %w", err)
+		}
+
+		v.sshKeys[fingerprint] = key
+	}
+
+	verification := &types.CommitVerification{
+		Format:         enum.SigningKeyFormatSSH,
+		KeyFingerprint: fingerprint,
+	}
+
+	if key == nil {
+		return v.verifySSHPublicKey(ctx, sig, publicKey, signature.Payload, verification)
+	}
+
+	if err = sig.verify(publicKey, []byte(signature.Payload)); err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("commit signature is invalid")
+		verification.Reason = enum.CommitVerificationReasonBadSignature
+		return verification, nil
+	}
+
+	verification.Signer, err = v.principalInfoCache.Get(ctx, key.PrincipalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get signer principal info: %w", err)
+	}
+
+	if key.Revoked != nil {
+		verification.Reason = enum.CommitVerificationReasonRevokedKey
+		return verification, nil
+	}
+
+	verification.Verified = true
+	verification.Reason = enum.CommitVerificationReasonValid
+
+	return verification, nil
+}
+
+// verifySSHPublicKey verifies the ssh signature against the ssh public keys registered by users.
+func (v *verifier) verifySSHPublicKey(
+	ctx context.Context,
+	sig *sshSignature,
+	publicKey ssh.PublicKey,
+	payload string,
+	verification *types.CommitVerification,
+) (*types.CommitVerification, error) {
+	key, ok := v.sshPublicKeys[verification.KeyFingerprint]
+	if !ok {
+		var err error
+		key, err = v.publicKeyStore.FindByFingerprint(ctx, verification.KeyFingerprint)
+		if err != nil && !errors.Is(err, gitnessstore.ErrResourceNotFound) {
+			return nil, fmt.Errorf("failed to find public key: %w", err)
+		}
+
+		v.sshPublicKeys[verification.KeyFingerprint] = key
+	}
+
+	if key == nil || key.Format != enum.SigningKeyFormatSSH {
+		verification.Reason = enum.CommitVerificationReasonUnknownKey
+		return verification, nil
+	}
+
+	if err := sig.verify(publicKey, []byte(payload)); err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("commit signature is invalid")
+		verification.Reason = enum.CommitVerificationReasonBadSignature
+		return verification, nil
+	}
+
+	var err error
+	verification.Signer, err = v.principalInfoCache.Get(ctx, key.PrincipalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get signer principal info: %w", err)
+	}
+
+	verification.Verified = true
+	verification.Reason = enum.CommitVerificationReasonValid
+
+	return verification, nil
+}
+
+func (v *verifier) verifyPGP(
+	ctx context.Context,
+	signature *git.CommitSignature,
+	email string,
+) (*types.CommitVerification, error) {
+	issuer, err := pgpSignatureIssuer(signature.Signature)
+	if errors.Is(err, errNotPGPSignature) {
+		return nil, err
+	}
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("failed to parse commit signature")
+		return &types.CommitVerification{
+			Format: enum.SigningKeyFormatOpenPGP,
+			Reason: enum.CommitVerificationReasonBadSignature,
+		}, nil
+	}
+
+	verification := &types.CommitVerification{
+		Format:         enum.SigningKeyFormatOpenPGP,
+		KeyFingerprint: issuer,
+	}
+
+	keys, err := v.findPGPKeys(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(keys) == 0 {
+		verification.Reason = enum.CommitVerificationReasonUnknownKey
+		return verification, nil
+	}
+
+	armoredKeys := make([]string, len(keys))
+	for i, key := range keys {
+		armoredKeys[i] = key.Content
+	}
+
+	fingerprint, err := verifyPGPSignature(armoredKeys, signature.Signature, signature.Payload)
+	if errors.Is(err, errPGPUnknownKey) {
+		verification.Reason = enum.CommitVerificationReasonUnknownKey
+		return verification, nil
+	}
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("commit signature is invalid")
+		verification.Reason = enum.CommitVerificationReasonBadSignature
+		return verification, nil
+	}
+
+	verification.KeyFingerprint = fingerprint
+
+	verification.Signer, err = v.principalInfoCache.Get(ctx, keys[0].PrincipalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get signer principal info: %w", err)
+	}
+
+	verification.Verified = true
+	verification.Reason = enum.CommitVerificationReasonValid
+
+	return verification, nil
+}
+
+// findPGPKeys returns the OpenPGP public keys of the user with the email address.
+func (v *verifier) findPGPKeys(ctx context.Context, email string) ([]*types.PublicKey, error) {
+	if keys, ok := v.pgpKeys[email]; ok {
+		return keys, nil
+	}
+
+	principal, err := v.principalStore.FindByEmail(ctx, email)
+	if errors.Is(err, gitnessstore.ErrResourceNotFound) {
+		v.pgpKeys[email] = nil
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find principal by email: %w", err)
+	}
+
+	keys, err := v.publicKeyStore.List(ctx, principal.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list public keys: %w", err)
+	}
+
+	var pgpKeys []*types.PublicKey
+	for _, key := range keys {
+		if key.Format == enum.SigningKeyFormatOpenPGP {
+			pgpKeys = append(pgpKeys, key)
+		}
+	}
+
+	v.pgpKeys[email] = pgpKeys
+
+	return pgpKeys, nil
+}
@@ -0,0 +1,163 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usersigning
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/pem"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git"
+	gitnessstore "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/crypto/ssh"
+)
+
+type publicKeyStoreMock struct {
+	store.PublicKeyStore
+	keys map[string]*types.PublicKey
+}
+
+func (s *publicKeyStoreMock) FindByFingerprint(_ context.Context, fingerprint string) (*types.PublicKey, error) {
+	if key, ok := s.keys[fingerprint]; ok {
+		return key, nil
+	}
+	return nil, gitnessstore.ErrResourceNotFound
+}
+
+type principalInfoCacheMock struct {
+	store.PrincipalInfoCache
+}
+
+func (principalInfoCacheMock) Get(_ context.Context, id int64) (*types.PrincipalInfo, error) {
+	return &types.PrincipalInfo{ID: id}, nil
+}
+
+// signSSH creates an ssh signature of the payload in the format created by "git commit -S" with an ssh key.
+func signSSH(t *testing.T, signer ssh.Signer, payload string) string {
+	t.Helper()
+
+	h := sha512.Sum512([]byte(payload))
+	signedData := append([]byte(sshSigMagic), ssh.Marshal(struct {
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Hash          []byte
+	}{
+		Namespace:     sshSigNamespace,
+		HashAlgorithm: "sha512",
+		Hash:          h[:],
+	})...)
+
+	signature, err := signer.Sign(rand.Reader, signedData)
+	if err != nil {
+		t.Fatalf("failed to sign payload: %s", err)
+	}
+
+	blob := append([]byte(sshSigMagic), ssh.Marshal(sshSignature{
+		Version:       sshSigVersion,
+		PublicKey:     signer.PublicKey().Marshal(),
+		Namespace:     sshSigNamespace,
+		HashAlgorithm: "sha512",
+		Signature:     ssh.Marshal(signature),
+	})...)
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: sshSigPEMType, Bytes: blob}))
+}
+
+func TestVerifyCommits_SSHPublicKey(t *testing.T) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %s", err)
+	}
+
+	signer, err := ssh.NewSignerFromKey(privateKey)
+	if err != nil {
+		t.Fatalf("failed to create signer: %s", err)
+	}
+
+	_, otherPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %s", err)
+	}
+
+	otherSigner, err := ssh.NewSignerFromKey(otherPrivateKey)
+	if err != nil {
+		t.Fatalf("failed to create signer: %s", err)
+	}
+
+	fingerprint := ssh.FingerprintSHA256(signer.PublicKey())
+
+	s := NewService(false, nil, nil, &publicKeyStoreMock{keys: map[string]*types.PublicKey{
+		fingerprint: {PrincipalID: 42, Format: enum.SigningKeyFormatSSH, Fingerprint: fingerprint},
+	}}, nil, principalInfoCacheMock{}, nil)
+
+	const payload = "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n\ncommit message\n"
+
+	commits := []git.Commit{
+		{SHA: "valid", Signature: &git.CommitSignature{
+			Signature: signSSH(t, signer, payload),
+			Payload:   payload,
+		}},
+		{SHA: "tampered", Signature: &git.CommitSignature{
+			Signature: signSSH(t, signer, payload),
+			Payload:   payload + "tampered",
+		}},
+		{SHA: "unknown", Signature: &git.CommitSignature{
+			Signature: signSSH(t, otherSigner, payload),
+			Payload:   payload,
+		}},
+		{SHA: "unsigned"},
+	}
+
+	verifications, err := s.VerifyCommits(context.Background(), commits)
+	if err != nil {
+		t.Fatalf("failed to verify commits: %s", err)
+	}
+
+	tests := []struct {
+		verified bool
+		reason   enum.CommitVerificationReason
+	}{
+		{verified: true, reason: enum.CommitVerificationReasonValid},
+		{verified: false, reason: enum.CommitVerificationReasonBadSignature},
+		{verified: false, reason: enum.CommitVerificationReasonUnknownKey},
+	}
+
+	for i, test := range tests {
+		v := verifications[i]
+		if v == nil {
+			t.Fatalf("commit %s: expected verification", commits[i].SHA)
+		}
+		if v.Verified != test.verified || v.Reason != test.reason || v.Format != enum.SigningKeyFormatSSH {
+			t.Errorf("commit %s: want verified=%t reason=%s, got verified=%t reason=%s format=%s",
+				commits[i].SHA, test.verified, test.reason, v.Verified, v.Reason, v.Format)
+		}
+	}
+
+	if signer := verifications[0].Signer; signer == nil || signer.ID != 42 {
+		t.Errorf("expected signer with id 42, got %v", signer)
+	}
+
+	if verifications[3] != nil {
+		t.Errorf("expected no verification of unsigned commit, got %v", verifications[3])
+	}
+}
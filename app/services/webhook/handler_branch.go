@@ -19,11 +19,14 @@ import (
 	"fmt"
 
 	gitevents "github.com/harness/gitness/app/events/git"
+	"github.com/harness/gitness/app/services/autolink"
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 // ReferencePayload describes the payload of Reference related webhook triggers.
@@ -33,6 +36,7 @@ type ReferencePayload struct {
 	ReferenceSegment
 	ReferenceDetailsSegment
 	ReferenceUpdateSegment
+	PushSegment
 }
 
 // handleEventBranchCreated handles branch created events
@@ -42,7 +46,7 @@ func (s *Service) handleEventBranchCreated(ctx context.Context,
 	return s.triggerForEventWithRepo(ctx, enum.WebhookTriggerBranchCreated,
 		event.ID, event.Payload.PrincipalID, event.Payload.RepoID,
 		func(principal *types.Principal, repo *types.Repository) (any, error) {
-			commitInfo, err := s.fetchCommitInfoForEvent(ctx, repo.GitUID, event.Payload.SHA)
+			commitInfo, err := s.fetchCommitInfoForEvent(ctx, repo, event.Payload.SHA)
 			if err != nil {
 				return nil, err
 			}
@@ -68,6 +72,9 @@ func (s *Service) handleEventBranchCreated(ctx context.Context,
 					OldSHA: types.NilSHA,
 					Forced: false,
 				},
+				PushSegment: PushSegment{
+					PushOptions: event.Payload.PushOptions,
+				},
 			}, nil
 		})
 }
@@ -79,7 +86,7 @@ func (s *Service) handleEventBranchUpdated(ctx context.Context,
 	return s.triggerForEventWithRepo(ctx, enum.WebhookTriggerBranchUpdated,
 		event.ID, event.Payload.PrincipalID, event.Payload.RepoID,
 		func(principal *types.Principal, repo *types.Repository) (any, error) {
-			commitInfo, err := s.fetchCommitInfoForEvent(ctx, repo.GitUID, event.Payload.NewSHA)
+			commitInfo, err := s.fetchCommitInfoForEvent(ctx, repo, event.Payload.NewSHA)
 			if err != nil {
 				return nil, err
 			}
@@ -105,6 +112,9 @@ func (s *Service) handleEventBranchUpdated(ctx context.Context,
 					OldSHA: event.Payload.OldSHA,
 					Forced: event.Payload.Forced,
 				},
+				PushSegment: PushSegment{
+					PushOptions: event.Payload.PushOptions,
+				},
 			}, nil
 		})
 }
@@ -138,14 +148,21 @@ func (s *Service) handleEventBranchDeleted(ctx context.Context,
 					OldSHA: event.Payload.SHA,
 					Forced: false,
 				},
+				PushSegment: PushSegment{
+					PushOptions: event.Payload.PushOptions,
+				},
 			}, nil
 		})
 }
 
-func (s *Service) fetchCommitInfoForEvent(ctx context.Context, repoUID string, sha string) (CommitInfo, error) {
+func (s *Service) fetchCommitInfoForEvent(
+	ctx context.Context,
+	repo *types.Repository,
+	sha string,
+) (CommitInfo, error) {
 	out, err := s.git.GetCommit(ctx, &git.GetCommitParams{
 		ReadParams: git.ReadParams{
-			RepoUID: repoUID,
+			RepoUID: repo.GitUID,
 		},
 		SHA: sha,
 	})
@@ -160,5 +177,17 @@ func (s *Service) fetchCommitInfoForEvent(ctx context.Context, repoUID string, s
 		return CommitInfo{}, fmt.Errorf("failed to get commit with sha '%s': %w", sha, err)
 	}
 
-	return commitInfoFrom(out.Commit), nil
+	return commitInfoFrom(out.Commit, s.autolinker(ctx, repo)), nil
+}
+
+// autolinker returns the Linker with the autolink rules of the repository.
+// Autolinks are optional in payloads, so if the rules can't be loaded, no references are resolved.
+func (s *Service) autolinker(ctx context.Context, repo *types.Repository) *autolink.Linker {
+	linker, err := s.autolinks.ForRepository(ctx, repo)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to load autolinks of repo %d", repo.ID)
+		return nil
+	}
+
+	return linker
 }
@@ -0,0 +1,72 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// CheckRerunPayload describes the body of the check rerun requested trigger.
+type CheckRerunPayload struct {
+	BaseSegment
+	ReferenceDetailsSegment
+	CheckSegment
+}
+
+// handleEventCheckRerunRequested handles check rerun requested events
+// and triggers check rerun requested webhooks for the repo.
+func (s *Service) handleEventCheckRerunRequested(ctx context.Context,
+	event *events.Event[*repoevents.CheckRerunRequestedPayload]) error {
+	return s.triggerForEventWithRepo(ctx, enum.WebhookTriggerCheckRerunRequested, event.ID,
+		event.Payload.PrincipalID, event.Payload.RepoID,
+		func(principal *types.Principal, repo *types.Repository) (any, error) {
+			check, err := s.checkStore.Find(ctx, repo.ID, event.Payload.CommitSHA, event.Payload.CheckUID)
+			if errors.Is(err, store.ErrResourceNotFound) {
+				return nil, events.NewDiscardEventErrorf("status check '%s' doesn't exist anymore",
+					event.Payload.CheckUID)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to get status check '%s': %w", event.Payload.CheckUID, err)
+			}
+
+			commitInfo, err := s.fetchCommitInfoForEvent(ctx, repo, event.Payload.CommitSHA)
+			if err != nil {
+				return nil, err
+			}
+
+			return &CheckRerunPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   enum.WebhookTriggerCheckRerunRequested,
+					Repo:      repositoryInfoFrom(repo, s.urlProvider),
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				ReferenceDetailsSegment: ReferenceDetailsSegment{
+					SHA:    event.Payload.CommitSHA,
+					Commit: &commitInfo,
+				},
+				CheckSegment: CheckSegment{
+					Check: checkInfoFrom(&check),
+				},
+			}, nil
+		})
+}
@@ -0,0 +1,86 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// CommitCommentPayload describes the body of the commit comment created and updated triggers.
+type CommitCommentPayload struct {
+	BaseSegment
+	ReferenceDetailsSegment
+	CommitCommentSegment
+}
+
+// handleEventCommitCommentCreated handles commit comment created events
+// and triggers commit comment created webhooks for the repo.
+func (s *Service) handleEventCommitCommentCreated(ctx context.Context,
+	event *events.Event[*repoevents.CommitCommentCreatedPayload]) error {
+	return s.handleCommitComment(ctx, enum.WebhookTriggerCommitCommentCreated, event.ID,
+		event.Payload.PrincipalID, event.Payload.RepoID, event.Payload.CommentID, event.Payload.CommitSHA)
+}
+
+// handleEventCommitCommentUpdated handles commit comment updated events
+// and triggers commit comment updated webhooks for the repo.
+func (s *Service) handleEventCommitCommentUpdated(ctx context.Context,
+	event *events.Event[*repoevents.CommitCommentUpdatedPayload]) error {
+	return s.handleCommitComment(ctx, enum.WebhookTriggerCommitCommentUpdated, event.ID,
+		event.Payload.PrincipalID, event.Payload.RepoID, event.Payload.CommentID, event.Payload.CommitSHA)
+}
+
+func (s *Service) handleCommitComment(ctx context.Context,
+	triggerType enum.WebhookTrigger, eventID string,
+	principalID, repoID, commentID int64, commitSHA string,
+) error {
+	return s.triggerForEventWithRepo(ctx, triggerType, eventID, principalID, repoID,
+		func(principal *types.Principal, repo *types.Repository) (any, error) {
+			comment, err := s.commitCommentStore.Find(ctx, commentID)
+			if errors.Is(err, store.ErrResourceNotFound) {
+				return nil, events.NewDiscardEventErrorf("commit comment with id '%d' doesn't exist anymore", commentID)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to get commit comment for id '%d': %w", commentID, err)
+			}
+
+			commitInfo, err := s.fetchCommitInfoForEvent(ctx, repo, commitSHA)
+			if err != nil {
+				return nil, err
+			}
+
+			return &CommitCommentPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   triggerType,
+					Repo:      repositoryInfoFrom(repo, s.urlProvider),
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				ReferenceDetailsSegment: ReferenceDetailsSegment{
+					SHA:    commitSHA,
+					Commit: &commitInfo,
+				},
+				CommitCommentSegment: CommitCommentSegment{
+					CommentInfo: commitCommentInfoFrom(comment),
+				},
+			}, nil
+		})
+}
@@ -0,0 +1,95 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ExecutionPayload describes the body of the pipeline execution triggers.
+type ExecutionPayload struct {
+	BaseSegment
+	ExecutionSegment
+}
+
+// handleEventExecutionStarted handles execution started events
+// and triggers execution started webhooks for the repo.
+func (s *Service) handleEventExecutionStarted(ctx context.Context,
+	event *events.Event[*repoevents.ExecutionStartedPayload]) error {
+	return s.triggerForExecution(ctx, enum.WebhookTriggerExecutionStarted, event.ID,
+		event.Payload.PrincipalID, event.Payload.RepoID, event.Payload.ExecutionID, event.Payload.PipelineID)
+}
+
+// handleEventExecutionCompleted handles execution completed events and triggers
+// execution completed or execution failed webhooks for the repo, depending on the execution status.
+func (s *Service) handleEventExecutionCompleted(ctx context.Context,
+	event *events.Event[*repoevents.ExecutionCompletedPayload]) error {
+	trigger := enum.WebhookTriggerExecutionCompleted
+	if event.Payload.Status != enum.CIStatusSuccess {
+		trigger = enum.WebhookTriggerExecutionFailed
+	}
+
+	return s.triggerForExecution(ctx, trigger, event.ID,
+		event.Payload.PrincipalID, event.Payload.RepoID, event.Payload.ExecutionID, event.Payload.PipelineID)
+}
+
+func (s *Service) triggerForExecution(ctx context.Context,
+	trigger enum.WebhookTrigger,
+	eventID string,
+	principalID int64,
+	repoID int64,
+	executionID int64,
+	pipelineID int64,
+) error {
+	return s.triggerForEventWithRepo(ctx, trigger, eventID, principalID, repoID,
+		func(principal *types.Principal, repo *types.Repository) (any, error) {
+			execution, err := s.executionStore.Find(ctx, executionID)
+			if errors.Is(err, store.ErrResourceNotFound) {
+				return nil, events.NewDiscardEventErrorf("execution with id '%d' doesn't exist anymore",
+					executionID)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to get execution for id '%d': %w", executionID, err)
+			}
+
+			pipeline, err := s.pipelineStore.Find(ctx, pipelineID)
+			if errors.Is(err, store.ErrResourceNotFound) {
+				return nil, events.NewDiscardEventErrorf("pipeline with id '%d' doesn't exist anymore",
+					pipelineID)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to get pipeline for id '%d': %w", pipelineID, err)
+			}
+
+			return &ExecutionPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   trigger,
+					Repo:      repositoryInfoFrom(repo, s.urlProvider),
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				ExecutionSegment: ExecutionSegment{
+					Execution: executionInfoFrom(execution, pipeline, repo, s.urlProvider),
+				},
+			}, nil
+		})
+}
@@ -46,7 +46,7 @@ func (s *Service) handleEventPullReqCreated(ctx context.Context,
 	return s.triggerForEventWithPullReq(ctx, enum.WebhookTriggerPullReqCreated,
 		event.ID, event.Payload.PrincipalID, event.Payload.PullReqID,
 		func(principal *types.Principal, pr *types.PullReq, targetRepo, sourceRepo *types.Repository) (any, error) {
-			commitInfo, err := s.fetchCommitInfoForEvent(ctx, sourceRepo.GitUID, event.Payload.SourceSHA)
+			commitInfo, err := s.fetchCommitInfoForEvent(ctx, sourceRepo, event.Payload.SourceSHA)
 			if err != nil {
 				return nil, err
 			}
@@ -60,7 +60,7 @@ func (s *Service) handleEventPullReqCreated(ctx context.Context,
 					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
 				},
 				PullReqSegment: PullReqSegment{
-					PullReq: pullReqInfoFrom(pr, targetRepo, s.urlProvider),
+					PullReq: pullReqInfoFrom(pr, targetRepo, s.urlProvider, s.autolinker(ctx, targetRepo)),
 				},
 				PullReqTargetReferenceSegment: PullReqTargetReferenceSegment{
 					TargetRef: ReferenceInfo{
@@ -93,7 +93,7 @@ func (s *Service) handleEventPullReqReopened(ctx context.Context,
 	return s.triggerForEventWithPullReq(ctx, enum.WebhookTriggerPullReqReopened,
 		event.ID, event.Payload.PrincipalID, event.Payload.PullReqID,
 		func(principal *types.Principal, pr *types.PullReq, targetRepo, sourceRepo *types.Repository) (any, error) {
-			commitInfo, err := s.fetchCommitInfoForEvent(ctx, sourceRepo.GitUID, event.Payload.SourceSHA)
+			commitInfo, err := s.fetchCommitInfoForEvent(ctx, sourceRepo, event.Payload.SourceSHA)
 			if err != nil {
 				return nil, err
 			}
@@ -107,7 +107,7 @@ func (s *Service) handleEventPullReqReopened(ctx context.Context,
 					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
 				},
 				PullReqSegment: PullReqSegment{
-					PullReq: pullReqInfoFrom(pr, targetRepo, s.urlProvider),
+					PullReq: pullReqInfoFrom(pr, targetRepo, s.urlProvider, s.autolinker(ctx, targetRepo)),
 				},
 				PullReqTargetReferenceSegment: PullReqTargetReferenceSegment{
 					TargetRef: ReferenceInfo{
@@ -147,7 +147,7 @@ func (s *Service) handleEventPullReqBranchUpdated(ctx context.Context,
 	return s.triggerForEventWithPullReq(ctx, enum.WebhookTriggerPullReqBranchUpdated,
 		event.ID, event.Payload.PrincipalID, event.Payload.PullReqID,
 		func(principal *types.Principal, pr *types.PullReq, targetRepo, sourceRepo *types.Repository) (any, error) {
-			commitInfo, err := s.fetchCommitInfoForEvent(ctx, sourceRepo.GitUID, event.Payload.NewSHA)
+			commitInfo, err := s.fetchCommitInfoForEvent(ctx, sourceRepo, event.Payload.NewSHA)
 			if err != nil {
 				return nil, err
 			}
@@ -161,7 +161,7 @@ func (s *Service) handleEventPullReqBranchUpdated(ctx context.Context,
 					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
 				},
 				PullReqSegment: PullReqSegment{
-					PullReq: pullReqInfoFrom(pr, targetRepo, s.urlProvider),
+					PullReq: pullReqInfoFrom(pr, targetRepo, s.urlProvider, s.autolinker(ctx, targetRepo)),
 				},
 				PullReqTargetReferenceSegment: PullReqTargetReferenceSegment{
 					TargetRef: ReferenceInfo{
@@ -201,7 +201,7 @@ func (s *Service) handleEventPullReqClosed(ctx context.Context,
 	return s.triggerForEventWithPullReq(ctx, enum.WebhookTriggerPullReqClosed,
 		event.ID, event.Payload.PrincipalID, event.Payload.PullReqID,
 		func(principal *types.Principal, pr *types.PullReq, targetRepo, sourceRepo *types.Repository) (any, error) {
-			commitInfo, err := s.fetchCommitInfoForEvent(ctx, sourceRepo.GitUID, event.Payload.SourceSHA)
+			commitInfo, err := s.fetchCommitInfoForEvent(ctx, sourceRepo, event.Payload.SourceSHA)
 			if err != nil {
 				return nil, err
 			}
@@ -215,7 +215,7 @@ func (s *Service) handleEventPullReqClosed(ctx context.Context,
 					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
 				},
 				PullReqSegment: PullReqSegment{
-					PullReq: pullReqInfoFrom(pr, targetRepo, s.urlProvider),
+					PullReq: pullReqInfoFrom(pr, targetRepo, s.urlProvider, s.autolinker(ctx, targetRepo)),
 				},
 				PullReqTargetReferenceSegment: PullReqTargetReferenceSegment{
 					TargetRef: ReferenceInfo{
@@ -251,7 +251,7 @@ func (s *Service) handleEventPullReqMerged(ctx context.Context,
 	return s.triggerForEventWithPullReq(ctx, enum.WebhookTriggerPullReqMerged,
 		event.ID, event.Payload.PrincipalID, event.Payload.PullReqID,
 		func(principal *types.Principal, pr *types.PullReq, targetRepo, sourceRepo *types.Repository) (any, error) {
-			commitInfo, err := s.fetchCommitInfoForEvent(ctx, sourceRepo.GitUID, event.Payload.SourceSHA)
+			commitInfo, err := s.fetchCommitInfoForEvent(ctx, sourceRepo, event.Payload.SourceSHA)
 			if err != nil {
 				return nil, err
 			}
@@ -265,7 +265,7 @@ func (s *Service) handleEventPullReqMerged(ctx context.Context,
 					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
 				},
 				PullReqSegment: PullReqSegment{
-					PullReq: pullReqInfoFrom(pr, targetRepo, s.urlProvider),
+					PullReq: pullReqInfoFrom(pr, targetRepo, s.urlProvider, s.autolinker(ctx, targetRepo)),
 				},
 				PullReqTargetReferenceSegment: PullReqTargetReferenceSegment{
 					TargetRef: ReferenceInfo{
@@ -310,7 +310,7 @@ func (s *Service) handleEventPullReqComment(
 			if err != nil {
 				return nil, fmt.Errorf("failed to get activity by id for acitivity id %d: %w", event.Payload.ActivityID, err)
 			}
-			commitInfo, err := s.fetchCommitInfoForEvent(ctx, sourceRepo.GitUID, event.Payload.SourceSHA)
+			commitInfo, err := s.fetchCommitInfoForEvent(ctx, sourceRepo, event.Payload.SourceSHA)
 			if err != nil {
 				return nil, err
 			}
@@ -321,7 +321,7 @@ func (s *Service) handleEventPullReqComment(
 					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
 				},
 				PullReqSegment: PullReqSegment{
-					PullReq: pullReqInfoFrom(pr, targetRepo, s.urlProvider),
+					PullReq: pullReqInfoFrom(pr, targetRepo, s.urlProvider, s.autolinker(ctx, targetRepo)),
 				},
 				PullReqTargetReferenceSegment: PullReqTargetReferenceSegment{
 					TargetRef: ReferenceInfo{
@@ -30,7 +30,7 @@ func (s *Service) handleEventTagCreated(ctx context.Context,
 	return s.triggerForEventWithRepo(ctx, enum.WebhookTriggerTagCreated,
 		event.ID, event.Payload.PrincipalID, event.Payload.RepoID,
 		func(principal *types.Principal, repo *types.Repository) (any, error) {
-			commitInfo, err := s.fetchCommitInfoForEvent(ctx, repo.GitUID, event.Payload.SHA)
+			commitInfo, err := s.fetchCommitInfoForEvent(ctx, repo, event.Payload.SHA)
 			if err != nil {
 				return nil, err
 			}
@@ -56,6 +56,9 @@ func (s *Service) handleEventTagCreated(ctx context.Context,
 					OldSHA: types.NilSHA,
 					Forced: false,
 				},
+				PushSegment: PushSegment{
+					PushOptions: event.Payload.PushOptions,
+				},
 			}, nil
 		})
 }
@@ -67,7 +70,7 @@ func (s *Service) handleEventTagUpdated(ctx context.Context,
 	return s.triggerForEventWithRepo(ctx, enum.WebhookTriggerTagUpdated,
 		event.ID, event.Payload.PrincipalID, event.Payload.RepoID,
 		func(principal *types.Principal, repo *types.Repository) (any, error) {
-			commitInfo, err := s.fetchCommitInfoForEvent(ctx, repo.GitUID, event.Payload.NewSHA)
+			commitInfo, err := s.fetchCommitInfoForEvent(ctx, repo, event.Payload.NewSHA)
 			if err != nil {
 				return nil, err
 			}
@@ -93,6 +96,9 @@ func (s *Service) handleEventTagUpdated(ctx context.Context,
 					OldSHA: event.Payload.OldSHA,
 					Forced: event.Payload.Forced,
 				},
+				PushSegment: PushSegment{
+					PushOptions: event.Payload.PushOptions,
+				},
 			}, nil
 		})
 }
@@ -126,6 +132,9 @@ func (s *Service) handleEventTagDeleted(ctx context.Context,
 					OldSHA: event.Payload.SHA,
 					Forced: false,
 				},
+				PushSegment: PushSegment{
+					PushOptions: event.Payload.PushOptions,
+				},
 			}, nil
 		})
 }
@@ -30,7 +30,9 @@ var (
 	errPrivateNetworkNotAllowed = errors.New("private network not allowed")
 )
 
-func newHTTPClient(allowLoopback bool, allowPrivateNetwork bool, disableSSLVerification bool) *http.Client {
+// NewHTTPClient returns an http client that refuses to send requests to loopback and private network addresses,
+// unless they are explicitly allowed. The addresses are checked after DNS resolution.
+func NewHTTPClient(allowLoopback bool, allowPrivateNetwork bool, disableSSLVerification bool) *http.Client {
 	// no customizations? use default client
 	if allowLoopback && allowPrivateNetwork && !disableSSLVerification {
 		return http.DefaultClient
@@ -86,7 +88,7 @@ func newHTTPClient(allowLoopback bool, allowPrivateNetwork bool, disableSSLVerif
 			return nil, errLoopbackNotAllowed
 		}
 
-		if !allowPrivateNetwork && tcpAddr.IP.IsPrivate() {
+		if !allowPrivateNetwork && isPrivateNetwork(tcpAddr.IP) {
 			return nil, errPrivateNetworkNotAllowed
 		}
 
@@ -99,3 +101,8 @@ func newHTTPClient(allowLoopback bool, allowPrivateNetwork bool, disableSSLVerif
 	// httpClient is similar to http.DefaultClient, just with custom http.Transport
 	return &http.Client{Transport: tr}
 }
+
+// isPrivateNetwork returns true for private network and link-local addresses (e.g. cloud metadata endpoints).
+func isPrivateNetwork(ip net.IP) bool {
+	return ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast()
+}
@@ -0,0 +1,199 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"time"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	sampleSHA    = "8a4e6a3f5c1d2b7e9f0a1b2c3d4e5f60718293a4"
+	sampleOldSHA = "5d41402abc4b2a76b9719d911017c592ae3f6c1b"
+)
+
+// SamplePayload returns an example of the canonical payload of the trigger for the repository.
+// The repository and principal are real, all other values (commits, pull request, comments) are made up.
+// It is used to preview payload templates.
+func (s *Service) SamplePayload(
+	trigger enum.WebhookTrigger,
+	repo *types.Repository,
+	principal *types.Principal,
+) any {
+	repoInfo := repositoryInfoFrom(repo, s.urlProvider)
+	principalInfo := principalInfoFrom(principal.ToPrincipalInfo())
+	base := BaseSegment{
+		Trigger:   trigger,
+		Repo:      repoInfo,
+		Principal: principalInfo,
+	}
+
+	now := time.Now()
+	signature := SignatureInfo{
+		Identity: IdentityInfo{Name: principal.DisplayName, Email: principal.Email},
+		When:     now,
+	}
+	details := ReferenceDetailsSegment{
+		SHA: sampleSHA,
+		Commit: &CommitInfo{
+			SHA:       sampleSHA,
+			Message:   "Update README",
+			Author:    signature,
+			Committer: signature,
+		},
+	}
+
+	branchRef := ReferenceSegment{
+		Ref: ReferenceInfo{Name: gitReferenceNamePrefixBranch + "feature", Repo: repoInfo},
+	}
+	pullReq := PullReqSegment{
+		PullReq: PullReqInfo{
+			Number:       1,
+			State:        enum.PullReqStateOpen,
+			Title:        "Update README",
+			SourceRepoID: repo.ID,
+			SourceBranch: "feature",
+			TargetRepoID: repo.ID,
+			TargetBranch: repo.DefaultBranch,
+			Author:       principalInfo,
+			PrURL:        s.urlProvider.GenerateUIPRURL(repo.Path, 1),
+		},
+	}
+	targetRef := PullReqTargetReferenceSegment{
+		TargetRef: ReferenceInfo{Name: gitReferenceNamePrefixBranch + repo.DefaultBranch, Repo: repoInfo},
+	}
+
+	//nolint:exhaustive // the reference triggers are handled by the default case.
+	switch trigger {
+	case enum.WebhookTriggerPullReqCreated, enum.WebhookTriggerPullReqReopened:
+		return &PullReqCreatedPayload{
+			BaseSegment:                   base,
+			PullReqSegment:                pullReq,
+			PullReqTargetReferenceSegment: targetRef,
+			ReferenceSegment:              branchRef,
+			ReferenceDetailsSegment:       details,
+		}
+	case enum.WebhookTriggerPullReqBranchUpdated:
+		return &PullReqBranchUpdatedPayload{
+			BaseSegment:                   base,
+			PullReqSegment:                pullReq,
+			PullReqTargetReferenceSegment: targetRef,
+			ReferenceSegment:              branchRef,
+			ReferenceDetailsSegment:       details,
+			ReferenceUpdateSegment:        ReferenceUpdateSegment{OldSHA: sampleOldSHA},
+		}
+	case enum.WebhookTriggerPullReqClosed:
+		pullReq.PullReq.State = enum.PullReqStateClosed
+		return &PullReqClosedPayload{
+			BaseSegment:                   base,
+			PullReqSegment:                pullReq,
+			PullReqTargetReferenceSegment: targetRef,
+			ReferenceSegment:              branchRef,
+			ReferenceDetailsSegment:       details,
+		}
+	case enum.WebhookTriggerPullReqMerged:
+		mergeMethod := enum.MergeMethodMerge
+		pullReq.PullReq.State = enum.PullReqStateMerged
+		pullReq.PullReq.MergeStrategy = &mergeMethod
+		return &PullReqMergedPayload{
+			BaseSegment:                   base,
+			PullReqSegment:                pullReq,
+			PullReqTargetReferenceSegment: targetRef,
+			ReferenceSegment:              branchRef,
+			ReferenceDetailsSegment:       details,
+		}
+	case enum.WebhookTriggerPullReqCommentCreated:
+		return &PullReqCommentPayload{
+			BaseSegment:                   base,
+			PullReqSegment:                pullReq,
+			PullReqTargetReferenceSegment: targetRef,
+			ReferenceSegment:              branchRef,
+			ReferenceDetailsSegment:       details,
+			PullReqCommentSegment:         PullReqCommentSegment{CommentInfo: CommentInfo{ID: 1, Text: "Looks good!"}},
+		}
+	case enum.WebhookTriggerCommitCommentCreated, enum.WebhookTriggerCommitCommentUpdated:
+		return &CommitCommentPayload{
+			BaseSegment:             base,
+			ReferenceDetailsSegment: details,
+			CommitCommentSegment: CommitCommentSegment{
+				CommentInfo: CommitCommentInfo{ID: 1, Text: "Looks good!", Path: "README.md", LineStart: 1, LineEnd: 1},
+			},
+		}
+	case enum.WebhookTriggerCheckRerunRequested:
+		return &CheckRerunPayload{
+			BaseSegment:             base,
+			ReferenceDetailsSegment: details,
+			CheckSegment: CheckSegment{
+				Check: CheckInfo{ID: 1, UID: "build", Status: enum.CheckStatusFailure, Summary: "Build failed"},
+			},
+		}
+	case enum.WebhookTriggerExecutionStarted,
+		enum.WebhookTriggerExecutionCompleted,
+		enum.WebhookTriggerExecutionFailed:
+		execution := ExecutionInfo{
+			PipelineID:  1,
+			PipelineUID: "default",
+			Number:      1,
+			Status:      enum.CIStatusRunning,
+			Trigger:     "manual",
+			Event:       "manual",
+			Ref:         gitReferenceNamePrefixBranch + repo.DefaultBranch,
+			SHA:         sampleSHA,
+			Started:     now.UnixMilli(),
+			URL:         s.urlProvider.GenerateUIBuildURL(repo.Path, "default", 1),
+		}
+		if trigger != enum.WebhookTriggerExecutionStarted {
+			execution.Status = enum.CIStatusSuccess
+			execution.Finished = now.UnixMilli()
+		}
+		if trigger == enum.WebhookTriggerExecutionFailed {
+			execution.Status = enum.CIStatusFailure
+		}
+		return &ExecutionPayload{
+			BaseSegment:      base,
+			ExecutionSegment: ExecutionSegment{Execution: execution},
+		}
+	}
+
+	// branch and tag triggers
+	ref := ReferenceSegment{
+		Ref: ReferenceInfo{Name: gitReferenceNamePrefixBranch + repo.DefaultBranch, Repo: repoInfo},
+	}
+	if trigger == enum.WebhookTriggerTagCreated ||
+		trigger == enum.WebhookTriggerTagUpdated ||
+		trigger == enum.WebhookTriggerTagDeleted {
+		ref.Ref.Name = "refs/tags/v1.0.0"
+	}
+
+	update := ReferenceUpdateSegment{}
+	switch trigger { //nolint:exhaustive // only updates and deletions have a previous SHA.
+	case enum.WebhookTriggerBranchUpdated, enum.WebhookTriggerTagUpdated:
+		update.OldSHA = sampleOldSHA
+	case enum.WebhookTriggerBranchDeleted, enum.WebhookTriggerTagDeleted:
+		update.OldSHA = sampleSHA
+		details = ReferenceDetailsSegment{SHA: types.NilSHA}
+	case enum.WebhookTriggerBranchCreated, enum.WebhookTriggerTagCreated:
+		update.OldSHA = types.NilSHA
+	}
+
+	return &ReferencePayload{
+		BaseSegment:             base,
+		ReferenceSegment:        ref,
+		ReferenceDetailsSegment: details,
+		ReferenceUpdateSegment:  update,
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/itchyny/gojq"
+)
+
+const (
+	// payloadTemplateMaxLength defines the max allowed length of a webhook payload template.
+	payloadTemplateMaxLength = 64 * 1024
+
+	// payloadTemplateTimeLimit defines the time limit of evaluating a jq payload template.
+	payloadTemplateTimeLimit = time.Second
+)
+
+// ErrPayloadTemplateInvalid is returned if a payload template can't be parsed.
+var ErrPayloadTemplateInvalid = errors.New("payload template is invalid")
+
+// payloadTemplateFuncs are the functions available in go payload templates.
+var payloadTemplateFuncs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+// CheckPayloadTemplate verifies that the payload template of the given type can be parsed.
+// An empty template type means no template is used, in which case the template has to be empty as well.
+func CheckPayloadTemplate(templateType enum.WebhookPayloadTemplateType, tmpl string) error {
+	if templateType == "" {
+		if tmpl != "" {
+			return fmt.Errorf("%w: a template requires a template type", ErrPayloadTemplateInvalid)
+		}
+		return nil
+	}
+
+	if len(tmpl) > payloadTemplateMaxLength {
+		return fmt.Errorf("%w: the template can be at most %d characters long",
+			ErrPayloadTemplateInvalid, payloadTemplateMaxLength)
+	}
+
+	switch templateType {
+	case enum.WebhookPayloadTemplateTypeGoTemplate:
+		_, err := parseGoTemplate(tmpl)
+		return err
+	case enum.WebhookPayloadTemplateTypeJQ:
+		_, err := parseJQ(tmpl)
+		return err
+	default:
+		return fmt.Errorf("%w: unknown template type '%s'", ErrPayloadTemplateInvalid, templateType)
+	}
+}
+
+// RenderPayload transforms the canonical JSON payload using the payload template of the given type.
+// Go templates get the decoded payload as data and render text, jq expressions have to produce
+// exactly one value which is rendered as JSON.
+func RenderPayload(
+	ctx context.Context,
+	templateType enum.WebhookPayloadTemplateType,
+	tmpl string,
+	payload []byte,
+) ([]byte, error) {
+	// numbers are kept as is, otherwise IDs and timestamps would be rendered in scientific notation.
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+
+	var data any
+	if err := decoder.Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode payload: %w", err)
+	}
+
+	switch templateType {
+	case enum.WebhookPayloadTemplateTypeGoTemplate:
+		t, err := parseGoTemplate(tmpl)
+		if err != nil {
+			return nil, err
+		}
+
+		buf := &bytes.Buffer{}
+		if err = t.Execute(buf, data); err != nil {
+			return nil, fmt.Errorf("failed to execute template: %w", err)
+		}
+
+		return buf.Bytes(), nil
+
+	case enum.WebhookPayloadTemplateTypeJQ:
+		code, err := parseJQ(tmpl)
+		if err != nil {
+			return nil, err
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, payloadTemplateTimeLimit)
+		defer cancel()
+
+		return runJQ(ctx, code, data)
+
+	default:
+		return nil, fmt.Errorf("%w: unknown template type '%s'", ErrPayloadTemplateInvalid, templateType)
+	}
+}
+
+func parseGoTemplate(tmpl string) (*template.Template, error) {
+	t, err := template.New("payload").Funcs(payloadTemplateFuncs).Parse(tmpl)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrPayloadTemplateInvalid, err)
+	}
+
+	return t, nil
+}
+
+func parseJQ(tmpl string) (*gojq.Code, error) {
+	query, err := gojq.Parse(tmpl)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrPayloadTemplateInvalid, err)
+	}
+
+	code, err := gojq.Compile(query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrPayloadTemplateInvalid, err)
+	}
+
+	return code, nil
+}
+
+func runJQ(ctx context.Context, code *gojq.Code, data any) ([]byte, error) {
+	iter := code.RunWithContext(ctx, data)
+
+	result, ok := iter.Next()
+	if !ok {
+		return nil, errors.New("jq expression produced no value")
+	}
+	if err, ok := result.(error); ok {
+		return nil, fmt.Errorf("failed to evaluate jq expression: %w", err)
+	}
+
+	if _, ok := iter.Next(); ok {
+		return nil, errors.New("jq expression produced more than one value")
+	}
+
+	out, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize jq result: %w", err)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,118 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestRenderPayload(t *testing.T) {
+	payload := []byte(`{"trigger":"branch_updated","repo":{"id":1234567,"path":"space/repo"},` +
+		`"ref":{"name":"refs/heads/main"},"sha":"abc"}`)
+
+	tests := []struct {
+		name         string
+		templateType enum.WebhookPayloadTemplateType
+		tmpl         string
+		exp          string
+	}{
+		{
+			name:         "go-template",
+			templateType: enum.WebhookPayloadTemplateTypeGoTemplate,
+			tmpl:         `{"repository": {{json .repo.path}}, "id": {{.repo.id}}{{with .nope}}, "missing": true{{end}}}`,
+			exp:          `{"repository": "space/repo", "id": 1234567}`,
+		},
+		{
+			name:         "go-template-form",
+			templateType: enum.WebhookPayloadTemplateTypeGoTemplate,
+			tmpl:         `ref={{.ref.name}}&sha={{.sha}}`,
+			exp:          `ref=refs/heads/main&sha=abc`,
+		},
+		{
+			name:         "jq",
+			templateType: enum.WebhookPayloadTemplateTypeJQ,
+			tmpl:         `{ref: .ref.name, repository: {id: .repo.id, name: (.repo.path | split("/") | last)}}`,
+			exp:          `{"ref":"refs/heads/main","repository":{"id":1234567,"name":"repo"}}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			out, err := RenderPayload(context.Background(), test.templateType, test.tmpl, payload)
+			if err != nil {
+				t.Fatalf("failed to render payload: %s", err)
+			}
+
+			if string(out) != test.exp {
+				t.Errorf("want %q, got %q", test.exp, string(out))
+			}
+		})
+	}
+}
+
+func TestRenderPayloadErrors(t *testing.T) {
+	payload := []byte(`{"commits":[{"sha":"a"},{"sha":"b"}]}`)
+
+	tests := []struct {
+		name string
+		tmpl string
+	}{
+		{name: "no-value", tmpl: `empty`},
+		{name: "multiple-values", tmpl: `.commits[]`},
+		{name: "runtime-error", tmpl: `.commits.sha`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := RenderPayload(context.Background(), enum.WebhookPayloadTemplateTypeJQ, test.tmpl, payload); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestCheckPayloadTemplate(t *testing.T) {
+	tests := []struct {
+		name         string
+		templateType enum.WebhookPayloadTemplateType
+		tmpl         string
+		valid        bool
+	}{
+		{name: "none", templateType: "", tmpl: "", valid: true},
+		{name: "template-without-type", templateType: "", tmpl: "{}", valid: false},
+		{name: "go-template", templateType: enum.WebhookPayloadTemplateTypeGoTemplate, tmpl: "{{.sha}}", valid: true},
+		{name: "go-template-invalid", templateType: enum.WebhookPayloadTemplateTypeGoTemplate, tmpl: "{{.sha", valid: false},
+		{name: "jq", templateType: enum.WebhookPayloadTemplateTypeJQ, tmpl: "{sha}", valid: true},
+		{name: "jq-invalid", templateType: enum.WebhookPayloadTemplateTypeJQ, tmpl: "{sha", valid: false},
+		{name: "jq-unknown-function", templateType: enum.WebhookPayloadTemplateTypeJQ, tmpl: "nope(1)", valid: false},
+		{name: "unknown-type", templateType: "mustache", tmpl: "{{sha}}", valid: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := CheckPayloadTemplate(test.templateType, test.tmpl)
+			if test.valid && err != nil {
+				t.Errorf("expected template to be valid, got %s", err)
+			}
+			if !test.valid && !errors.Is(err, ErrPayloadTemplateInvalid) {
+				t.Errorf("expected invalid template error, got %v", err)
+			}
+		})
+	}
+}
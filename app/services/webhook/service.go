@@ -23,6 +23,8 @@ import (
 
 	gitevents "github.com/harness/gitness/app/events/git"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/services/autolink"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/encrypt"
@@ -84,7 +86,12 @@ type Service struct {
 	principalStore        store.PrincipalStore
 	git                   git.Interface
 	activityStore         store.PullReqActivityStore
+	commitCommentStore    store.CommitCommentStore
+	checkStore            store.CheckStore
+	executionStore        store.ExecutionStore
+	pipelineStore         store.PipelineStore
 	encrypter             encrypt.Encrypter
+	autolinks             *autolink.Service
 
 	secureHTTPClient   *http.Client
 	insecureHTTPClient *http.Client
@@ -100,15 +107,21 @@ func NewService(
 	config Config,
 	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
 	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	repoReaderFactory *events.ReaderFactory[*repoevents.Reader],
 	webhookStore store.WebhookStore,
 	webhookExecutionStore store.WebhookExecutionStore,
 	repoStore store.RepoStore,
 	pullreqStore store.PullReqStore,
 	activityStore store.PullReqActivityStore,
+	commitCommentStore store.CommitCommentStore,
+	checkStore store.CheckStore,
+	executionStore store.ExecutionStore,
+	pipelineStore store.PipelineStore,
 	urlProvider url.Provider,
 	principalStore store.PrincipalStore,
 	git git.Interface,
 	encrypter encrypt.Encrypter,
+	autolinks *autolink.Service,
 ) (*Service, error) {
 	if err := config.Prepare(); err != nil {
 		return nil, fmt.Errorf("provided webhook service config is invalid: %w", err)
@@ -119,16 +132,21 @@ func NewService(
 		repoStore:             repoStore,
 		pullreqStore:          pullreqStore,
 		activityStore:         activityStore,
+		commitCommentStore:    commitCommentStore,
+		checkStore:            checkStore,
+		executionStore:        executionStore,
+		pipelineStore:         pipelineStore,
 		urlProvider:           urlProvider,
 		principalStore:        principalStore,
 		git:                   git,
 		encrypter:             encrypter,
+		autolinks:             autolinks,
 
-		secureHTTPClient:   newHTTPClient(config.AllowLoopback, config.AllowPrivateNetwork, false),
-		insecureHTTPClient: newHTTPClient(config.AllowLoopback, config.AllowPrivateNetwork, true),
+		secureHTTPClient:   NewHTTPClient(config.AllowLoopback, config.AllowPrivateNetwork, false),
+		insecureHTTPClient: NewHTTPClient(config.AllowLoopback, config.AllowPrivateNetwork, true),
 
-		secureHTTPClientInternal:   newHTTPClient(config.AllowLoopback, true, false),
-		insecureHTTPClientInternal: newHTTPClient(config.AllowLoopback, true, true),
+		secureHTTPClientInternal:   NewHTTPClient(config.AllowLoopback, true, false),
+		insecureHTTPClientInternal: NewHTTPClient(config.AllowLoopback, true, true),
 
 		config: config,
 	}
@@ -182,5 +200,28 @@ func NewService(
 		return nil, fmt.Errorf("failed to launch pr event reader for webhooks: %w", err)
 	}
 
+	_, err = repoReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *repoevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			// register events
+			_ = r.RegisterCommitCommentCreated(service.handleEventCommitCommentCreated)
+			_ = r.RegisterCommitCommentUpdated(service.handleEventCommitCommentUpdated)
+			_ = r.RegisterCheckRerunRequested(service.handleEventCheckRerunRequested)
+			_ = r.RegisterExecutionStarted(service.handleEventExecutionStarted)
+			_ = r.RegisterExecutionCompleted(service.handleEventExecutionCompleted)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch repo event reader for webhooks: %w", err)
+	}
+
 	return service, nil
 }
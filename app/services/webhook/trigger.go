@@ -317,6 +317,21 @@ func (s *Service) prepareHTTPRequest(ctx context.Context, execution *types.Webho
 			execution.Result = enum.WebhookExecutionResultFatalError
 			return nil, fmt.Errorf("failed to serialize body to json: %w", err)
 		}
+
+		// transform the canonical payload if the webhook has a payload template
+		if webhook.PayloadTemplateType != "" {
+			rendered, err := RenderPayload(ctx, webhook.PayloadTemplateType, webhook.PayloadTemplate, bBuff.Bytes())
+			if err != nil {
+				// ASSUMPTION: there was an issue with the static user input, not retriable
+				tErr := fmt.Errorf("failed to render payload template: %w", err)
+				execution.Error = tErr.Error()
+				execution.Result = enum.WebhookExecutionResultFatalError
+				return nil, tErr
+			}
+
+			bBuff.Reset()
+			bBuff.Write(rendered)
+		}
 	}
 	// set executioon body and mark it as retriggerable
 	execution.Request.Body = bBuff.String()
@@ -334,7 +349,7 @@ func (s *Service) prepareHTTPRequest(ctx context.Context, execution *types.Webho
 
 	// setup headers
 	req.Header.Add("User-Agent", fmt.Sprintf("%s/%s", s.config.UserAgentIdentity, version.Version))
-	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add("Content-Type", payloadContentType(bBuff.Bytes()))
 	req.Header.Add(s.toXHeader("Trigger"), string(triggerType))
 	req.Header.Add(s.toXHeader("Webhook-Parent-Type"), string(webhook.ParentType))
 	req.Header.Add(s.toXHeader("Webhook-Parent-Id"), fmt.Sprint(webhook.ParentID))
@@ -367,6 +382,15 @@ func (s *Service) prepareHTTPRequest(ctx context.Context, execution *types.Webho
 	return req, nil
 }
 
+// payloadContentType returns the content type of the request body.
+// Payload templates aren't required to produce JSON (e.g. form encoded data), any other output is sent as text.
+func payloadContentType(body []byte) string {
+	if json.Valid(body) {
+		return "application/json"
+	}
+	return "text/plain; charset=utf-8"
+}
+
 func (s *Service) toXHeader(name string) string {
 	return fmt.Sprintf("X-%s-%s", s.config.HeaderIdentity, name)
 }
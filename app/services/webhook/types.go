@@ -17,6 +17,7 @@ package webhook
 import (
 	"time"
 
+	"github.com/harness/gitness/app/services/autolink"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/types"
@@ -53,6 +54,12 @@ type ReferenceUpdateSegment struct {
 	Forced bool   `json:"forced"`
 }
 
+// PushSegment contains the details of the git push that triggered reference related payloads for webhooks.
+type PushSegment struct {
+	// PushOptions are the push options the client sent (e.g. `git push -o <option>`).
+	PushOptions []string `json:"push_options,omitempty"`
+}
+
 // PullReqTargetReferenceSegment contains details for the pull req target reference for webhooks.
 type PullReqTargetReferenceSegment struct {
 	TargetRef ReferenceInfo `json:"target_ref"`
@@ -68,6 +75,21 @@ type PullReqCommentSegment struct {
 	CommentInfo CommentInfo `json:"comment"`
 }
 
+// CommitCommentSegment contains details for all commit comment related payloads for webhooks.
+type CommitCommentSegment struct {
+	CommentInfo CommitCommentInfo `json:"comment"`
+}
+
+// CheckSegment contains details for all status check related payloads for webhooks.
+type CheckSegment struct {
+	Check CheckInfo `json:"check"`
+}
+
+// ExecutionSegment contains details for all pipeline execution related payloads for webhooks.
+type ExecutionSegment struct {
+	Execution ExecutionInfo `json:"execution"`
+}
+
 // RepositoryInfo describes the repo related info for a webhook payload.
 // NOTE: don't use types package as we want webhook payload to be independent from API calls.
 type RepositoryInfo struct {
@@ -76,6 +98,8 @@ type RepositoryInfo struct {
 	UID           string `json:"uid"`
 	DefaultBranch string `json:"default_branch"`
 	GitURL        string `json:"git_url"`
+	// Metadata contains the custom metadata of the repository, e.g. routing hints for downstream systems.
+	Metadata map[string]string `json:"metadata,omitempty"`
 }
 
 // repositoryInfoFrom gets the RespositoryInfo from a types.Repository.
@@ -86,6 +110,7 @@ func repositoryInfoFrom(repo *types.Repository, urlProvider url.Provider) Reposi
 		UID:           repo.UID,
 		DefaultBranch: repo.DefaultBranch,
 		GitURL:        urlProvider.GenerateGITCloneURL(repo.Path),
+		Metadata:      repo.Metadata,
 	}
 }
 
@@ -103,10 +128,16 @@ type PullReqInfo struct {
 	MergeStrategy *enum.MergeMethod `json:"merge_strategy,omitempty"`
 	Author        PrincipalInfo     `json:"author"`
 	PrURL         string            `json:"pr_url"`
+	Autolinks     []AutolinkInfo    `json:"autolinks,omitempty"`
 }
 
 // pullReqInfoFrom gets the PullReqInfo from a types.PullReq.
-func pullReqInfoFrom(pr *types.PullReq, repo *types.Repository, urlProvider url.Provider) PullReqInfo {
+func pullReqInfoFrom(
+	pr *types.PullReq,
+	repo *types.Repository,
+	urlProvider url.Provider,
+	linker *autolink.Linker,
+) PullReqInfo {
 	return PullReqInfo{
 		Number:        pr.Number,
 		State:         pr.State,
@@ -119,6 +150,7 @@ func pullReqInfoFrom(pr *types.PullReq, repo *types.Repository, urlProvider url.
 		MergeStrategy: pr.MergeMethod,
 		Author:        principalInfoFrom(&pr.Author),
 		PrURL:         urlProvider.GenerateUIPRURL(repo.Path, pr.Number),
+		Autolinks:     autolinkInfosFrom(linker.Resolve(pr.Title, pr.Description)),
 	}
 }
 
@@ -150,20 +182,46 @@ func principalInfoFrom(principal *types.PrincipalInfo) PrincipalInfo {
 // CommitInfo describes the commit related info for a webhook payload.
 // NOTE: don't use types package as we want webhook payload to be independent from API calls.
 type CommitInfo struct {
-	SHA       string        `json:"sha"`
-	Message   string        `json:"message"`
-	Author    SignatureInfo `json:"author"`
-	Committer SignatureInfo `json:"committer"`
+	SHA       string         `json:"sha"`
+	Message   string         `json:"message"`
+	Author    SignatureInfo  `json:"author"`
+	Committer SignatureInfo  `json:"committer"`
+	Autolinks []AutolinkInfo `json:"autolinks,omitempty"`
 }
 
 // commitInfoFrom gets the CommitInfo from a git.Commit.
-func commitInfoFrom(commit git.Commit) CommitInfo {
+func commitInfoFrom(commit git.Commit, linker *autolink.Linker) CommitInfo {
 	return CommitInfo{
 		SHA:       commit.SHA,
 		Message:   commit.Message,
 		Author:    signatureInfoFrom(commit.Author),
 		Committer: signatureInfoFrom(commit.Committer),
+		Autolinks: autolinkInfosFrom(linker.Resolve(commit.Message)),
+	}
+}
+
+// AutolinkInfo describes a custom autolink reference found in a text for a webhook payload.
+// NOTE: don't use types package as we want webhook payload to be independent from API calls.
+type AutolinkInfo struct {
+	Text string `json:"text"`
+	URL  string `json:"url"`
+}
+
+// autolinkInfosFrom gets the AutolinkInfos from types.AutolinkReferences.
+func autolinkInfosFrom(refs []types.AutolinkReference) []AutolinkInfo {
+	if len(refs) == 0 {
+		return nil
+	}
+
+	infos := make([]AutolinkInfo, len(refs))
+	for i, ref := range refs {
+		infos[i] = AutolinkInfo{
+			Text: ref.Text,
+			URL:  ref.URL,
+		}
 	}
+
+	return infos
 }
 
 // SignatureInfo describes the commit signature related info for a webhook payload.
@@ -207,3 +265,87 @@ type CommentInfo struct {
 	ID   int64  `json:"id"`
 	Text string `json:"text"`
 }
+
+// CommitCommentInfo describes the comment made directly on a commit for a webhook payload.
+// NOTE: don't use types package as we want webhook payload to be independent from API calls.
+type CommitCommentInfo struct {
+	ID        int64  `json:"id"`
+	ParentID  *int64 `json:"parent_id,omitempty"`
+	Text      string `json:"text"`
+	Path      string `json:"path,omitempty"`
+	LineStart int    `json:"line_start,omitempty"`
+	LineEnd   int    `json:"line_end,omitempty"`
+}
+
+// commitCommentInfoFrom gets the CommitCommentInfo from a types.CommitComment.
+func commitCommentInfoFrom(comment *types.CommitComment) CommitCommentInfo {
+	return CommitCommentInfo{
+		ID:        comment.ID,
+		ParentID:  comment.ParentID,
+		Text:      comment.Text,
+		Path:      comment.Path,
+		LineStart: comment.LineStart,
+		LineEnd:   comment.LineEnd,
+	}
+}
+
+// CheckInfo describes a status check for a webhook payload.
+// NOTE: don't use types package as we want webhook payload to be independent from API calls.
+type CheckInfo struct {
+	ID      int64            `json:"id"`
+	UID     string           `json:"uid"`
+	Status  enum.CheckStatus `json:"status"`
+	Summary string           `json:"summary"`
+	Link    string           `json:"link"`
+}
+
+// checkInfoFrom gets the CheckInfo from a types.Check.
+func checkInfoFrom(check *types.Check) CheckInfo {
+	return CheckInfo{
+		ID:      check.ID,
+		UID:     check.UID,
+		Status:  check.Status,
+		Summary: check.Summary,
+		Link:    check.Link,
+	}
+}
+
+// ExecutionInfo describes a pipeline execution for a webhook payload.
+// NOTE: don't use types package as we want webhook payload to be independent from API calls.
+type ExecutionInfo struct {
+	PipelineID  int64         `json:"pipeline_id"`
+	PipelineUID string        `json:"pipeline_uid"`
+	Number      int64         `json:"number"`
+	Status      enum.CIStatus `json:"status"`
+	Error       string        `json:"error,omitempty"`
+	Trigger     string        `json:"trigger"`
+	Event       string        `json:"event"`
+	Ref         string        `json:"ref"`
+	SHA         string        `json:"sha"`
+	Started     int64         `json:"started,omitempty"`
+	Finished    int64         `json:"finished,omitempty"`
+	URL         string        `json:"url"`
+}
+
+// executionInfoFrom gets the ExecutionInfo from a types.Execution.
+func executionInfoFrom(
+	execution *types.Execution,
+	pipeline *types.Pipeline,
+	repo *types.Repository,
+	urlProvider url.Provider,
+) ExecutionInfo {
+	return ExecutionInfo{
+		PipelineID:  pipeline.ID,
+		PipelineUID: pipeline.UID,
+		Number:      execution.Number,
+		Status:      execution.Status,
+		Error:       execution.Error,
+		Trigger:     execution.Trigger,
+		Event:       execution.Event,
+		Ref:         execution.Ref,
+		SHA:         execution.After,
+		Started:     execution.Started,
+		Finished:    execution.Finished,
+		URL:         urlProvider.GenerateUIBuildURL(repo.Path, pipeline.UID, execution.Number),
+	}
+}
@@ -19,6 +19,8 @@ import (
 
 	gitevents "github.com/harness/gitness/app/events/git"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/services/autolink"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/encrypt"
@@ -37,17 +39,23 @@ func ProvideService(ctx context.Context,
 	config Config,
 	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
 	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	repoReaderFactory *events.ReaderFactory[*repoevents.Reader],
 	webhookStore store.WebhookStore,
 	webhookExecutionStore store.WebhookExecutionStore,
 	repoStore store.RepoStore,
 	pullreqStore store.PullReqStore,
 	activityStore store.PullReqActivityStore,
+	commitCommentStore store.CommitCommentStore,
+	checkStore store.CheckStore,
+	executionStore store.ExecutionStore,
+	pipelineStore store.PipelineStore,
 	urlProvider url.Provider,
 	principalStore store.PrincipalStore,
 	git git.Interface,
 	encrypter encrypt.Encrypter,
+	autolinks *autolink.Service,
 ) (*Service, error) {
-	return NewService(ctx, config, gitReaderFactory, prReaderFactory,
-		webhookStore, webhookExecutionStore, repoStore, pullreqStore, activityStore,
-		urlProvider, principalStore, git, encrypter)
+	return NewService(ctx, config, gitReaderFactory, prReaderFactory, repoReaderFactory,
+		webhookStore, webhookExecutionStore, repoStore, pullreqStore, activityStore, commitCommentStore,
+		checkStore, executionStore, pipelineStore, urlProvider, principalStore, git, encrypter, autolinks)
 }
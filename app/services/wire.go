@@ -15,12 +15,21 @@
 package services
 
 import (
+	"github.com/harness/gitness/app/services/approval"
+	"github.com/harness/gitness/app/services/auditsnapshot"
 	"github.com/harness/gitness/app/services/cleanup"
+	"github.com/harness/gitness/app/services/compliance"
 	"github.com/harness/gitness/app/services/keywordsearch"
 	"github.com/harness/gitness/app/services/metric"
+	"github.com/harness/gitness/app/services/mirror"
 	"github.com/harness/gitness/app/services/notification"
+	"github.com/harness/gitness/app/services/pipelinetimeout"
 	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/pullreqbulk"
+	"github.com/harness/gitness/app/services/repomaintenance"
 	"github.com/harness/gitness/app/services/reposize"
+	"github.com/harness/gitness/app/services/repostats"
+	"github.com/harness/gitness/app/services/schedule"
 	"github.com/harness/gitness/app/services/trigger"
 	"github.com/harness/gitness/app/services/webhook"
 	"github.com/harness/gitness/job"
@@ -42,6 +51,15 @@ type Services struct {
 	Cleanup            *cleanup.Service
 	Notification       *notification.Service
 	Keywordsearch      *keywordsearch.Service
+	Compliance         *compliance.Service
+	ComplianceSnapshot *auditsnapshot.Service
+	PipelineSchedule   *schedule.Service
+	PipelineApproval   *approval.Service
+	PipelineTimeout    *pipelinetimeout.Service
+	RepoMaintenance    *repomaintenance.Service
+	RepoStats          *repostats.Service
+	RepoMirror         *mirror.Service
+	PullReqBulk        *pullreqbulk.Service
 }
 
 func ProvideServices(
@@ -54,6 +72,15 @@ func ProvideServices(
 	cleanupSvc *cleanup.Service,
 	notificationSvc *notification.Service,
 	keywordsearchSvc *keywordsearch.Service,
+	complianceSvc *compliance.Service,
+	complianceSnapshotSvc *auditsnapshot.Service,
+	pipelineScheduleSvc *schedule.Service,
+	pipelineApprovalSvc *approval.Service,
+	pipelineTimeoutSvc *pipelinetimeout.Service,
+	repoMaintenanceSvc *repomaintenance.Service,
+	repoStatsSvc *repostats.Service,
+	repoMirrorSvc *mirror.Service,
+	pullReqBulkSvc *pullreqbulk.Service,
 ) Services {
 	return Services{
 		Webhook:            webhooksSvc,
@@ -65,5 +92,14 @@ func ProvideServices(
 		Cleanup:            cleanupSvc,
 		Notification:       notificationSvc,
 		Keywordsearch:      keywordsearchSvc,
+		Compliance:         complianceSvc,
+		ComplianceSnapshot: complianceSnapshotSvc,
+		PipelineSchedule:   pipelineScheduleSvc,
+		PipelineApproval:   pipelineApprovalSvc,
+		PipelineTimeout:    pipelineTimeoutSvc,
+		RepoMaintenance:    repoMaintenanceSvc,
+		RepoStats:          repoStatsSvc,
+		RepoMirror:         repoMirrorSvc,
+		PullReqBulk:        pullReqBulkSvc,
 	}
 }
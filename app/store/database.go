@@ -174,6 +174,9 @@ type (
 
 		// List returns a list of child spaces in a space.
 		List(ctx context.Context, id int64, opts *types.SpaceFilter) ([]*types.Space, error)
+
+		// Search returns a list of spaces from all levels of the space hierarchy matching the query.
+		Search(ctx context.Context, opts *types.SpaceFilter) ([]*types.Space, error)
 	}
 
 	// RepoStore defines the repository data storage.
@@ -182,6 +185,7 @@ type (
 		Find(ctx context.Context, id int64) (*types.Repository, error)
 
 		// FindByRef finds the repo using the repoRef as either the id or the repo path.
+		// The last segment of the repo path can also be a repo alias.
 		FindByRef(ctx context.Context, repoRef string) (*types.Repository, error)
 
 		// Create a new repo.
@@ -206,6 +210,9 @@ type (
 		// List returns a list of repos in a space.
 		List(ctx context.Context, parentID int64, opts *types.RepoFilter) ([]*types.Repository, error)
 
+		// Search returns a list of repos from all spaces matching the query.
+		Search(ctx context.Context, opts *types.RepoFilter) ([]*types.Repository, error)
+
 		// ListSizeInfos returns a list of all repo sizes.
 		ListSizeInfos(ctx context.Context) ([]*types.RepositorySizeInfo, error)
 	}
@@ -236,9 +243,12 @@ type (
 		// FindByUID finds the token by principalId and tokenUID
 		FindByUID(ctx context.Context, principalID int64, tokenUID string) (*types.Token, error)
 
-		// Create saves the token details.
+		// Create saves the token details, including the spaces the token is restricted to.
 		Create(ctx context.Context, token *types.Token) error
 
+		// UpdateLastUsed updates the time the token with the given id was last used.
+		UpdateLastUsed(ctx context.Context, id int64, lastUsed int64) error
+
 		// Delete deletes the token with the given id.
 		Delete(ctx context.Context, id int64) error
 
@@ -253,6 +263,24 @@ type (
 		Count(ctx context.Context, principalID int64, tokenType enum.TokenType) (int64, error)
 	}
 
+	// SpacePinStore defines the storage of items pinned to the space landing page.
+	SpacePinStore interface {
+		// Find finds the pinned item by id.
+		Find(ctx context.Context, id int64) (*types.SpacePin, error)
+
+		// Create creates a new pinned item.
+		Create(ctx context.Context, pin *types.SpacePin) error
+
+		// Update updates the pinned item.
+		Update(ctx context.Context, pin *types.SpacePin) error
+
+		// Delete deletes the pinned item.
+		Delete(ctx context.Context, id int64) error
+
+		// List returns all items pinned to the space ordered by their position.
+		List(ctx context.Context, spaceID int64) ([]*types.SpacePin, error)
+	}
+
 	// PullReqStore defines the pull request data storage.
 	PullReqStore interface {
 		// Find the pull request by id.
@@ -290,6 +318,10 @@ type (
 
 		// List returns a list of pull requests in a space.
 		List(ctx context.Context, opts *types.PullReqFilter) ([]*types.PullReq, error)
+
+		// ListByMergeSHAs returns the merged pull requests of the target repository
+		// whose merge commit is one of the provided SHAs.
+		ListByMergeSHAs(ctx context.Context, repoID int64, shas []string) ([]*types.PullReq, error)
 	}
 
 	PullReqActivityStore interface {
@@ -321,6 +353,9 @@ type (
 
 		// List returns a list of pull request activities in a pull request (a timeline).
 		List(ctx context.Context, prID int64, opts *types.PullReqActivityFilter) ([]*types.PullReqActivity, error)
+
+		// ListAll returns a list of pull request activities of all pull requests, ordered by the creation time.
+		ListAll(ctx context.Context, opts *types.PullReqActivityFilter) ([]*types.PullReqActivity, error)
 	}
 
 	// CodeCommentView is to manipulate only code-comment subset of PullReqActivity.
@@ -366,6 +401,13 @@ type (
 		List(ctx context.Context, prID int64) ([]*types.PullReqReviewer, error)
 	}
 
+	// PullReqMentionStore defines the storage of principals mentioned in pull requests.
+	PullReqMentionStore interface {
+		// Add records that the principals have been mentioned in the pull request.
+		// Principals that are already recorded or don't exist are ignored.
+		Add(ctx context.Context, prID int64, principalIDs []int64) error
+	}
+
 	// PullReqFileViewStore stores information about what file a user viewed.
 	PullReqFileViewStore interface {
 		// Upsert inserts or updates the latest viewed sha for a file in a PR.
@@ -381,6 +423,510 @@ type (
 		List(ctx context.Context, prID int64, principalID int64) ([]*types.PullReqFileView, error)
 	}
 
+	// PullReqDependencyStore stores "depends on" relationships between pull requests.
+	PullReqDependencyStore interface {
+		// Find returns the pull request dependency by its ID.
+		Find(ctx context.Context, id int64) (*types.PullReqDependency, error)
+
+		// Create creates a new pull request dependency.
+		Create(ctx context.Context, dep *types.PullReqDependency) error
+
+		// Delete deletes the pull request dependency by its ID.
+		Delete(ctx context.Context, id int64) error
+
+		// ListDependencies returns all pull request dependencies of the pull request,
+		// i.e. pull requests the pull request depends on.
+		ListDependencies(ctx context.Context, prID int64) ([]*types.PullReqDependency, error)
+
+		// ListDependents returns all pull request dependents of the pull request,
+		// i.e. pull requests that depend on the pull request.
+		ListDependents(ctx context.Context, prID int64) ([]*types.PullReqDependency, error)
+	}
+
+	// MergeTemplateStore defines the merge template data storage.
+	MergeTemplateStore interface {
+		// Find returns the merge template of the repository for the merge method.
+		Find(ctx context.Context, repoID int64, method enum.MergeMethod) (*types.MergeTemplate, error)
+
+		// Upsert creates a new or updates the existing merge template.
+		Upsert(ctx context.Context, tmpl *types.MergeTemplate) error
+
+		// Delete deletes the merge template of the repository for the merge method.
+		Delete(ctx context.Context, repoID int64, method enum.MergeMethod) error
+
+		// List returns all merge templates of the repository.
+		List(ctx context.Context, repoID int64) ([]*types.MergeTemplate, error)
+	}
+
+	// RepoAliasStore defines the repository alias data storage.
+	RepoAliasStore interface {
+		// Find finds the repository alias by id.
+		Find(ctx context.Context, id int64) (*types.RepoAlias, error)
+
+		// FindByUID finds the repository alias with the given uid (case insensitive) in the space.
+		FindByUID(ctx context.Context, spaceID int64, uid string) (*types.RepoAlias, error)
+
+		// Create creates a new repository alias.
+		Create(ctx context.Context, alias *types.RepoAlias) error
+
+		// Delete deletes the repository alias.
+		Delete(ctx context.Context, id int64) error
+
+		// List returns all aliases of the repository.
+		List(ctx context.Context, repoID int64) ([]*types.RepoAlias, error)
+
+		// CountAll returns the number of repository aliases of all repositories.
+		CountAll(ctx context.Context) (int64, error)
+
+		// ListAll returns a page of repository aliases of all repositories.
+		ListAll(ctx context.Context, opts *types.Pagination) ([]*types.RepoAlias, error)
+	}
+
+	// DefaultReviewerStore defines the storage of users and user groups
+	// that are automatically added as reviewers to new pull requests.
+	DefaultReviewerStore interface {
+		// Find finds the default reviewer by id.
+		Find(ctx context.Context, id int64) (*types.DefaultReviewer, error)
+
+		// Create creates a new default reviewer.
+		Create(ctx context.Context, reviewer *types.DefaultReviewer) error
+
+		// Delete deletes the default reviewer.
+		Delete(ctx context.Context, id int64) error
+
+		// List returns all default reviewers of the repository.
+		List(ctx context.Context, repoID int64) ([]*types.DefaultReviewer, error)
+	}
+
+	// WatchStore defines the repository and pull request watch data storage.
+	WatchStore interface {
+		// FindRepo returns the watch level of the principal for the repository.
+		FindRepo(ctx context.Context, repoID, principalID int64) (*types.RepoWatch, error)
+
+		// UpsertRepo creates a new or updates the existing repository watch.
+		UpsertRepo(ctx context.Context, watch *types.RepoWatch) error
+
+		// DeleteRepo deletes the repository watch of the principal.
+		DeleteRepo(ctx context.Context, repoID, principalID int64) error
+
+		// ListRepo returns all watches of the repository.
+		ListRepo(ctx context.Context, repoID int64) ([]*types.RepoWatch, error)
+
+		// FindPullReq returns the watch level of the principal for the pull request.
+		FindPullReq(ctx context.Context, pullReqID, principalID int64) (*types.PullReqWatch, error)
+
+		// UpsertPullReq creates a new or updates the existing pull request watch.
+		UpsertPullReq(ctx context.Context, watch *types.PullReqWatch) error
+
+		// DeletePullReq deletes the pull request watch of the principal.
+		DeletePullReq(ctx context.Context, pullReqID, principalID int64) error
+
+		// ListPullReq returns all watches of the pull request.
+		ListPullReq(ctx context.Context, pullReqID int64) ([]*types.PullReqWatch, error)
+	}
+
+	// RequiredFileStore defines the required file policy data storage.
+	RequiredFileStore interface {
+		// Find finds the required file policy by id.
+		Find(ctx context.Context, id int64) (*types.RequiredFile, error)
+
+		// Create creates a new required file policy.
+		Create(ctx context.Context, file *types.RequiredFile) error
+
+		// Update updates the required file policy.
+		Update(ctx context.Context, file *types.RequiredFile) error
+
+		// Delete deletes the required file policy.
+		Delete(ctx context.Context, id int64) error
+
+		// List returns all required file policies defined directly in the space.
+		List(ctx context.Context, spaceID int64) ([]*types.RequiredFile, error)
+	}
+
+	// RepoComplianceStore defines the repository compliance data storage.
+	RepoComplianceStore interface {
+		// Find returns the latest compliance result of the repository.
+		Find(ctx context.Context, repoID int64) (*types.RepoCompliance, error)
+
+		// Upsert creates a new or replaces the existing compliance result of the repository.
+		Upsert(ctx context.Context, compliance *types.RepoCompliance) error
+
+		// Delete deletes the compliance result of the repository.
+		Delete(ctx context.Context, repoID int64) error
+
+		// List returns the compliance results of all repositories directly in the space.
+		List(ctx context.Context, spaceID int64) ([]*types.RepoCompliance, error)
+	}
+
+	// ComplianceSnapshotStore defines the compliance snapshot data storage.
+	// Compliance snapshots are immutable, they can't be updated or deleted.
+	ComplianceSnapshotStore interface {
+		// Create saves a new compliance snapshot.
+		Create(ctx context.Context, snapshot *types.ComplianceSnapshot) error
+
+		// Last returns the most recent compliance snapshot.
+		Last(ctx context.Context) (*types.ComplianceSnapshot, error)
+
+		// Count returns the number of compliance snapshots.
+		Count(ctx context.Context) (int64, error)
+
+		// List returns a page of compliance snapshots, the most recent first.
+		List(ctx context.Context, opts *types.Pagination) ([]*types.ComplianceSnapshot, error)
+
+		// ListAll returns all compliance snapshots in the order they were created.
+		ListAll(ctx context.Context) ([]*types.ComplianceSnapshot, error)
+	}
+
+	// GitUsageStore defines the git usage data storage.
+	// The usage of git service pack invocations is aggregated per day.
+	GitUsageStore interface {
+		// Record adds the usage to the usage of the principal, repository and service on the provided day.
+		Record(ctx context.Context, day time.Time, usage *types.GitUsage) error
+
+		// List returns the usage matching the filter, aggregated per principal, repository and service.
+		List(ctx context.Context, filter *types.GitUsageFilter) ([]*types.GitUsage, error)
+
+		// DeleteOld removes the usage of all days that ended before the provided time.
+		DeleteOld(ctx context.Context, olderThan time.Time) (int64, error)
+	}
+
+	// RepoStatsStore defines the repository stats data storage.
+	RepoStatsStore interface {
+		// Find returns the statistics of the repository.
+		Find(ctx context.Context, repoID int64) (*types.RepositoryStats, error)
+
+		// Upsert creates or replaces the statistics of the repository.
+		Upsert(ctx context.Context, stats *types.RepositoryStats) error
+	}
+
+	// RepoMirrorStore defines the repository mirror data storage.
+	RepoMirrorStore interface {
+		// Find returns the mirror configuration of the repository.
+		Find(ctx context.Context, repoID int64) (*types.RepositoryMirror, error)
+
+		// Create creates the mirror configuration of a repository.
+		Create(ctx context.Context, mirror *types.RepositoryMirror) error
+
+		// Update updates the mirror configuration of a repository.
+		Update(ctx context.Context, mirror *types.RepositoryMirror) error
+
+		// UpdateOptLock updates the mirror configuration using the optimistic locking mechanism.
+		UpdateOptLock(ctx context.Context,
+			mirror *types.RepositoryMirror,
+			mutateFn func(mirror *types.RepositoryMirror) error,
+		) (*types.RepositoryMirror, error)
+
+		// Delete deletes the mirror configuration of the repository.
+		Delete(ctx context.Context, repoID int64) error
+
+		// ListDue lists the enabled mirrors whose next synchronization is at or before the provided time.
+		ListDue(ctx context.Context, now int64, limit int) ([]*types.RepositoryMirror, error)
+	}
+
+	// CommitIndexStore defines the commit search index data storage.
+	CommitIndexStore interface {
+		// FindState returns the state of the commit index of the repository.
+		FindState(ctx context.Context, repoID int64) (*types.CommitIndexState, error)
+
+		// UpsertState creates or replaces the state of the commit index of the repository.
+		UpsertState(ctx context.Context, state *types.CommitIndexState) error
+
+		// Insert adds the commits to the index of the repository, already indexed commits are skipped.
+		Insert(ctx context.Context, repoID int64, commits []types.Commit) error
+
+		// DeleteAll removes all commits and the state of the index of the repository.
+		DeleteAll(ctx context.Context, repoID int64) error
+
+		// Search returns the indexed commits of the repository matching the filter, newest first.
+		Search(ctx context.Context, repoID int64, filter *types.CommitSearchFilter) ([]types.Commit, error)
+
+		// Count returns the number of the indexed commits of the repository matching the filter.
+		Count(ctx context.Context, repoID int64, filter *types.CommitSearchFilter) (int64, error)
+	}
+
+	// PipelineCacheStore defines the pipeline build cache data storage.
+	PipelineCacheStore interface {
+		// FindByID returns the cache entry with the provided ID.
+		FindByID(ctx context.Context, id int64) (*types.PipelineCache, error)
+
+		// Find returns the cache entry of the repository with the provided key.
+		Find(ctx context.Context, repoID int64, key string) (*types.PipelineCache, error)
+
+		// FindByKeyPrefix returns the most recently updated cache entry of the repository
+		// with a key that starts with the provided prefix.
+		FindByKeyPrefix(ctx context.Context, repoID int64, prefix string) (*types.PipelineCache, error)
+
+		// Upsert creates a new cache entry or replaces the existing entry with the same key.
+		Upsert(ctx context.Context, cache *types.PipelineCache) error
+
+		// Touch updates the last access time of the cache entry.
+		Touch(ctx context.Context, id int64, accessed int64) error
+
+		// List returns the cache entries of the repository, most recently accessed first.
+		List(ctx context.Context, repoID int64, filter *types.ListQueryFilter) ([]*types.PipelineCache, error)
+
+		// ListLeastRecentlyAccessed returns the cache entries of the repository, least recently accessed first.
+		ListLeastRecentlyAccessed(ctx context.Context, repoID int64, limit int) ([]*types.PipelineCache, error)
+
+		// Count returns the number of cache entries of the repository.
+		Count(ctx context.Context, repoID int64, filter *types.ListQueryFilter) (int64, error)
+
+		// TotalSize returns the total size of all cache entries of the repository.
+		TotalSize(ctx context.Context, repoID int64) (int64, error)
+
+		// Delete deletes the cache entry.
+		Delete(ctx context.Context, id int64) error
+	}
+
+	// PipelineArtifactStore defines the pipeline artifact data storage.
+	PipelineArtifactStore interface {
+		// FindByID returns the artifact with the provided ID.
+		FindByID(ctx context.Context, id int64) (*types.PipelineArtifact, error)
+
+		// Upsert creates a new artifact or replaces the existing artifact of the execution with the same name.
+		Upsert(ctx context.Context, artifact *types.PipelineArtifact) error
+
+		// List returns the artifacts of the execution, ordered by name.
+		List(ctx context.Context, executionID int64) ([]*types.PipelineArtifact, error)
+
+		// ListOlderThan returns the artifacts created before the provided time, oldest first.
+		ListOlderThan(ctx context.Context, olderThan time.Time, limit int) ([]*types.PipelineArtifact, error)
+
+		// Delete deletes the artifact.
+		Delete(ctx context.Context, id int64) error
+	}
+
+	// SigningKeyStore defines the repository signing key data storage.
+	SigningKeyStore interface {
+		// Find returns the signing key of the repository.
+		Find(ctx context.Context, repoID int64) (*types.SigningKey, error)
+
+		// Upsert creates a new or replaces the existing signing key of the repository.
+		Upsert(ctx context.Context, key *types.SigningKey) error
+
+		// Delete deletes the signing key of the repository.
+		Delete(ctx context.Context, repoID int64) error
+	}
+
+	// AutolinkStore defines the autolink rule data storage.
+	AutolinkStore interface {
+		// Find finds the autolink rule by id.
+		Find(ctx context.Context, id int64) (*types.Autolink, error)
+
+		// Create creates a new autolink rule.
+		Create(ctx context.Context, autolink *types.Autolink) error
+
+		// Update updates the autolink rule.
+		Update(ctx context.Context, autolink *types.Autolink) error
+
+		// Delete deletes the autolink rule.
+		Delete(ctx context.Context, id int64) error
+
+		// ListBySpace returns all autolink rules defined directly in the space.
+		ListBySpace(ctx context.Context, spaceID int64) ([]*types.Autolink, error)
+
+		// ListByRepo returns all autolink rules defined directly in the repository.
+		ListByRepo(ctx context.Context, repoID int64) ([]*types.Autolink, error)
+	}
+
+	// SavedComparisonStore defines the storage of named comparisons of repository references.
+	SavedComparisonStore interface {
+		// FindByUID finds the saved comparison with the given uid (case insensitive) in the repository.
+		FindByUID(ctx context.Context, repoID int64, uid string) (*types.SavedComparison, error)
+
+		// Create creates a new saved comparison.
+		Create(ctx context.Context, comparison *types.SavedComparison) error
+
+		// Update updates the saved comparison.
+		Update(ctx context.Context, comparison *types.SavedComparison) error
+
+		// Delete deletes the saved comparison.
+		Delete(ctx context.Context, id int64) error
+
+		// List returns all saved comparisons of the repository.
+		List(ctx context.Context, repoID int64) ([]*types.SavedComparison, error)
+	}
+
+	// CommitCommentStore defines the storage of comments made directly on commits.
+	CommitCommentStore interface {
+		// Find returns the commit comment with the given id.
+		Find(ctx context.Context, id int64) (*types.CommitComment, error)
+
+		// List returns all comments of the commit in the repository, the oldest first.
+		List(ctx context.Context, repoID int64, commitSHA string) ([]*types.CommitComment, error)
+
+		// Create creates a new commit comment.
+		Create(ctx context.Context, comment *types.CommitComment) error
+
+		// Update updates the text and the edited and deleted time of the commit comment.
+		Update(ctx context.Context, comment *types.CommitComment) error
+	}
+
+	// UserSigningKeyStore defines the instance-managed user signing key data storage.
+	UserSigningKeyStore interface {
+		// Find returns the user signing key with the given id.
+		Find(ctx context.Context, id int64) (*types.UserSigningKey, error)
+
+		// FindActive returns the signing key of the principal that is currently used for signing commits.
+		FindActive(ctx context.Context, principalID int64) (*types.UserSigningKey, error)
+
+		// FindByFingerprint returns the user signing key by the fingerprint of its public key.
+		FindByFingerprint(ctx context.Context, fingerprint string) (*types.UserSigningKey, error)
+
+		// List returns all signing keys of the principal.
+		List(ctx context.Context, principalID int64) ([]*types.UserSigningKey, error)
+
+		// Create creates a new user signing key.
+		Create(ctx context.Context, key *types.UserSigningKey) error
+
+		// Update updates the rotation and the revocation time of the user signing key.
+		Update(ctx context.Context, key *types.UserSigningKey) error
+	}
+
+	// PublicKeyStore defines the storage of the public keys users register to verify their signatures.
+	PublicKeyStore interface {
+		// Find returns the public key with the given id.
+		Find(ctx context.Context, id int64) (*types.PublicKey, error)
+
+		// FindByFingerprint returns the public key by its fingerprint.
+		FindByFingerprint(ctx context.Context, fingerprint string) (*types.PublicKey, error)
+
+		// List returns all public keys of the principal.
+		List(ctx context.Context, principalID int64) ([]*types.PublicKey, error)
+
+		// Create creates a new public key.
+		Create(ctx context.Context, key *types.PublicKey) error
+
+		// Delete deletes the public key with the given id.
+		Delete(ctx context.Context, id int64) error
+	}
+
+	// PasskeyStore defines the passkey (WebAuthn credential) data storage.
+	PasskeyStore interface {
+		// Find returns the passkey with the given id.
+		Find(ctx context.Context, id int64) (*types.Passkey, error)
+
+		// FindByCredentialID returns the passkey by its WebAuthn credential ID.
+		FindByCredentialID(ctx context.Context, credentialID []byte) (*types.Passkey, error)
+
+		// List returns all passkeys of the principal.
+		List(ctx context.Context, principalID int64) ([]*types.Passkey, error)
+
+		// Count returns the number of passkeys of the principal.
+		Count(ctx context.Context, principalID int64) (int64, error)
+
+		// Create creates a new passkey.
+		Create(ctx context.Context, passkey *types.Passkey) error
+
+		// UpdateUsage updates the signature counter and the last used time of the passkey.
+		UpdateUsage(ctx context.Context, passkey *types.Passkey) error
+
+		// Delete deletes the passkey with the given id.
+		Delete(ctx context.Context, id int64) error
+	}
+
+	// WorkingHoursStore defines the user working hours data storage.
+	WorkingHoursStore interface {
+		// Find returns the working hours of the principal.
+		Find(ctx context.Context, principalID int64) (*types.WorkingHours, error)
+
+		// Map returns the working hours of the principals, keyed by principal ID.
+		// Principals without working hours are omitted.
+		Map(ctx context.Context, principalIDs []int64) (map[int64]*types.WorkingHours, error)
+
+		// Upsert creates new or updates the existing working hours of the principal.
+		Upsert(ctx context.Context, hours *types.WorkingHours) error
+
+		// Delete deletes the working hours of the principal.
+		Delete(ctx context.Context, principalID int64) error
+	}
+
+	// PasskeyChallengeStore defines the storage of pending WebAuthn ceremonies.
+	PasskeyChallengeStore interface {
+		// Create creates a new passkey challenge.
+		Create(ctx context.Context, challenge *types.PasskeyChallenge) error
+
+		// Consume finds and deletes the passkey challenge, so each challenge can be used only once.
+		Consume(ctx context.Context, uid string) (*types.PasskeyChallenge, error)
+
+		// DeleteExpired deletes all challenges that expired before the provided time (unix milliseconds).
+		DeleteExpired(ctx context.Context, before int64) (int64, error)
+	}
+
+	// VariableStore defines the pipeline variable data storage.
+	VariableStore interface {
+		// Find finds the variable by id.
+		Find(ctx context.Context, id int64) (*types.Variable, error)
+
+		// FindByKey finds the variable with the given key for the given parent.
+		FindByKey(ctx context.Context, parentType enum.VariableParent, parentID int64, key string) (*types.Variable, error)
+
+		// Create creates a new variable.
+		Create(ctx context.Context, variable *types.Variable) error
+
+		// Update updates an existing variable.
+		Update(ctx context.Context, variable *types.Variable) error
+
+		// UpdateOptLock updates the variable using the optimistic locking mechanism.
+		UpdateOptLock(ctx context.Context, variable *types.Variable,
+			mutateFn func(variable *types.Variable) error) (*types.Variable, error)
+
+		// Delete deletes the variable with the given id.
+		Delete(ctx context.Context, id int64) error
+
+		// Count counts the variables for the given parent.
+		Count(ctx context.Context, parentType enum.VariableParent, parentID int64,
+			filter *types.ListQueryFilter) (int64, error)
+
+		// List lists the variables for the given parent.
+		List(ctx context.Context, parentType enum.VariableParent, parentID int64,
+			filter *types.ListQueryFilter) ([]*types.Variable, error)
+
+		// ListAll lists all variables for the given parent.
+		ListAll(ctx context.Context, parentType enum.VariableParent, parentID int64) ([]*types.Variable, error)
+	}
+
+	// VariableAuditStore defines the audit log data storage of the pipeline variables.
+	VariableAuditStore interface {
+		// Create creates a new audit log entry.
+		Create(ctx context.Context, audit *types.VariableAudit) error
+
+		// Count counts the audit log entries for the given parent.
+		Count(ctx context.Context, parentType enum.VariableParent, parentID int64,
+			filter *types.ListQueryFilter) (int64, error)
+
+		// List lists the audit log entries for the given parent, newest first.
+		List(ctx context.Context, parentType enum.VariableParent, parentID int64,
+			filter *types.ListQueryFilter) ([]*types.VariableAudit, error)
+	}
+
+	// RunnerStore defines the self-hosted runner data storage.
+	RunnerStore interface {
+		// Find returns the runner with the given id.
+		Find(ctx context.Context, id int64) (*types.Runner, error)
+
+		// FindByUID returns the runner with the given uid.
+		FindByUID(ctx context.Context, uid string) (*types.Runner, error)
+
+		// FindByTokenHash returns the runner that authenticates with the token of the given hash.
+		FindByTokenHash(ctx context.Context, tokenHash string) (*types.Runner, error)
+
+		// List returns all runners.
+		List(ctx context.Context) ([]*types.Runner, error)
+
+		// Create creates a new runner.
+		Create(ctx context.Context, runner *types.Runner) error
+
+		// UpdateState updates the state of the runner.
+		UpdateState(ctx context.Context, runner *types.Runner) error
+
+		// UpdateHeartbeat updates the time of the last heartbeat of the runner.
+		UpdateHeartbeat(ctx context.Context, runner *types.Runner) error
+
+		// Delete deletes the runner with the given id.
+		Delete(ctx context.Context, id int64) error
+	}
+
 	// RuleStore defines database interface for protection rules.
 	RuleStore interface {
 		// Find finds a protection rule by ID.
@@ -464,6 +1010,9 @@ type (
 	}
 
 	CheckStore interface {
+		// Find returns the status check result of a specific commit in a repo.
+		Find(ctx context.Context, repoID int64, commitSHA string, uid string) (types.Check, error)
+
 		// Upsert creates new or updates an existing status check result.
 		Upsert(ctx context.Context, check *types.Check) error
 
@@ -567,6 +1116,12 @@ type (
 		// FindByNumber returns a execution given a pipeline and an execution number
 		FindByNumber(ctx context.Context, pipelineID int64, num int64) (*types.Execution, error)
 
+		// FindLatestByRef returns the most recent execution of a pipeline for the provided git reference.
+		FindLatestByRef(ctx context.Context, pipelineID int64, ref string) (*types.Execution, error)
+
+		// ListIncomplete returns the executions that aren't done yet, ordered by their id.
+		ListIncomplete(ctx context.Context) ([]*types.Execution, error)
+
 		// Create creates a new execution in the datastore.
 		Create(ctx context.Context, execution *types.Execution) error
 
@@ -576,6 +1131,9 @@ type (
 		// List lists the executions for a given pipeline ID
 		List(ctx context.Context, pipelineID int64, pagination types.Pagination) ([]*types.Execution, error)
 
+		// ListInRange lists the executions of a pipeline created in the time range of the filter.
+		ListInRange(ctx context.Context, pipelineID int64, filter types.PipelineStatsFilter) ([]*types.Execution, error)
+
 		// Delete deletes an execution given a pipeline ID and an execution number
 		Delete(ctx context.Context, pipelineID int64, num int64) error
 
@@ -591,6 +1149,9 @@ type (
 		// List returns a list of stages corresponding to an execution ID.
 		List(ctx context.Context, executionID int64) ([]*types.Stage, error)
 
+		// ListInRange returns the stages of the executions of a pipeline created in the time range of the filter.
+		ListInRange(ctx context.Context, pipelineID int64, filter types.PipelineStatsFilter) ([]*types.Stage, error)
+
 		// ListWithSteps returns a stage list from the datastore corresponding to an execution,
 		// with the individual steps included.
 		ListWithSteps(ctx context.Context, executionID int64) ([]*types.Stage, error)
@@ -610,6 +1171,9 @@ type (
 	}
 
 	StepStore interface {
+		// Find returns a step from the datastore by ID.
+		Find(ctx context.Context, stepID int64) (*types.Step, error)
+
 		// FindByNumber returns a step from the datastore by number.
 		FindByNumber(ctx context.Context, stageID int64, stepNum int) (*types.Step, error)
 
@@ -709,6 +1273,49 @@ type (
 		ListAllEnabled(ctx context.Context, repoID int64) ([]*types.Trigger, error)
 	}
 
+	ScheduleStore interface {
+		// FindByUID returns a schedule given a pipeline and a schedule UID.
+		FindByUID(ctx context.Context, pipelineID int64, uid string) (*types.Schedule, error)
+
+		// Create creates a new schedule in the datastore.
+		Create(ctx context.Context, schedule *types.Schedule) error
+
+		// Update tries to update a schedule.
+		Update(ctx context.Context, schedule *types.Schedule) error
+
+		// UpdateOptLock updates the schedule using the optimistic locking mechanism.
+		UpdateOptLock(ctx context.Context, schedule *types.Schedule,
+			mutateFn func(schedule *types.Schedule) error) (*types.Schedule, error)
+
+		// List lists the schedules for a given pipeline ID.
+		List(ctx context.Context, pipelineID int64, filter types.ListQueryFilter) ([]*types.Schedule, error)
+
+		// Count the number of schedules in a pipeline.
+		Count(ctx context.Context, pipelineID int64, filter types.ListQueryFilter) (int64, error)
+
+		// DeleteByUID deletes a schedule given a pipeline ID and a schedule UID.
+		DeleteByUID(ctx context.Context, pipelineID int64, uid string) error
+
+		// ListDue lists the enabled schedules whose next run is at or before the provided time.
+		// It's used only internally to trigger builds.
+		ListDue(ctx context.Context, now int64, limit int) ([]*types.Schedule, error)
+	}
+
+	ApprovalStore interface {
+		// FindByStageID returns the approval gate of a stage.
+		FindByStageID(ctx context.Context, stageID int64) (*types.Approval, error)
+
+		// Create creates a new approval gate in the datastore.
+		Create(ctx context.Context, approval *types.Approval) error
+
+		// Update tries to update an approval gate.
+		Update(ctx context.Context, approval *types.Approval) error
+
+		// ListExpired lists the pending approval gates of blocked stages that timed out.
+		// It's used only internally to expire approvals.
+		ListExpired(ctx context.Context, now int64, limit int) ([]*types.Approval, error)
+	}
+
 	PluginStore interface {
 		// List returns back the list of plugins matching the given filter
 		// along with their associated schemas.
@@ -0,0 +1,256 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+	sqlxtypes "github.com/jmoiron/sqlx/types"
+	"github.com/pkg/errors"
+)
+
+var _ store.ApprovalStore = (*approvalStore)(nil)
+
+type approval struct {
+	ID        int64              `db:"approval_id"`
+	StageID   int64              `db:"approval_stage_id"`
+	Approvers sqlxtypes.JSONText `db:"approval_approvers"`
+	Timeout   int64              `db:"approval_timeout"`
+	State     enum.ApprovalState `db:"approval_state"`
+	DecidedBy *int64             `db:"approval_decided_by"`
+	Decided   int64              `db:"approval_decided"`
+	Comment   string             `db:"approval_comment"`
+	Created   int64              `db:"approval_created"`
+	Updated   int64              `db:"approval_updated"`
+	Version   int64              `db:"approval_version"`
+}
+
+func mapInternalToApproval(in *approval) (*types.Approval, error) {
+	var approvers []string
+	err := json.Unmarshal(in.Approvers, &approvers)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshal approval.approvers: %w", err)
+	}
+	return &types.Approval{
+		ID:        in.ID,
+		StageID:   in.StageID,
+		Approvers: approvers,
+		Timeout:   in.Timeout,
+		State:     in.State,
+		DecidedBy: in.DecidedBy,
+		Decided:   in.Decided,
+		Comment:   in.Comment,
+		Created:   in.Created,
+		Updated:   in.Updated,
+		Version:   in.Version,
+	}, nil
+}
+
+func mapInternalToApprovalList(in []*approval) ([]*types.Approval, error) {
+	approvals := make([]*types.Approval, len(in))
+	for i, a := range in {
+		var err error
+		approvals[i], err = mapInternalToApproval(a)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return approvals, nil
+}
+
+func mapApprovalToInternal(in *types.Approval) *approval {
+	approvers := in.Approvers
+	if approvers == nil {
+		approvers = []string{}
+	}
+	return &approval{
+		ID:        in.ID,
+		StageID:   in.StageID,
+		Approvers: EncodeToSQLXJSON(approvers),
+		Timeout:   in.Timeout,
+		State:     in.State,
+		DecidedBy: in.DecidedBy,
+		Decided:   in.Decided,
+		Comment:   in.Comment,
+		Created:   in.Created,
+		Updated:   in.Updated,
+		Version:   in.Version,
+	}
+}
+
+// NewApprovalStore returns a new ApprovalStore.
+func NewApprovalStore(db *sqlx.DB) store.ApprovalStore {
+	return &approvalStore{
+		db: db,
+	}
+}
+
+type approvalStore struct {
+	db *sqlx.DB
+}
+
+const (
+	approvalColumns = `
+		approval_id
+		,approval_stage_id
+		,approval_approvers
+		,approval_timeout
+		,approval_state
+		,approval_decided_by
+		,approval_decided
+		,approval_comment
+		,approval_created
+		,approval_updated
+		,approval_version
+	`
+)
+
+// FindByStageID returns the approval gate of a stage.
+func (s *approvalStore) FindByStageID(ctx context.Context, stageID int64) (*types.Approval, error) {
+	const findQueryStmt = `
+	SELECT` + approvalColumns + `
+	FROM approvals
+	WHERE approval_stage_id = $1`
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := new(approval)
+	if err := db.GetContext(ctx, dst, findQueryStmt, stageID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find approval")
+	}
+	return mapInternalToApproval(dst)
+}
+
+// Create creates a new approval gate in the datastore.
+func (s *approvalStore) Create(ctx context.Context, a *types.Approval) error {
+	const approvalInsertStmt = `
+	INSERT INTO approvals (
+		approval_stage_id
+		,approval_approvers
+		,approval_timeout
+		,approval_state
+		,approval_decided_by
+		,approval_decided
+		,approval_comment
+		,approval_created
+		,approval_updated
+		,approval_version
+	) VALUES (
+		:approval_stage_id
+		,:approval_approvers
+		,:approval_timeout
+		,:approval_state
+		,:approval_decided_by
+		,:approval_decided
+		,:approval_comment
+		,:approval_created
+		,:approval_updated
+		,:approval_version
+	) RETURNING approval_id`
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(approvalInsertStmt, mapApprovalToInternal(a))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind approval object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&a.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Approval query failed")
+	}
+
+	return nil
+}
+
+// Update tries to update an approval gate in the datastore with optimistic locking.
+func (s *approvalStore) Update(ctx context.Context, a *types.Approval) error {
+	const approvalUpdateStmt = `
+	UPDATE approvals
+	SET
+		approval_state = :approval_state
+		,approval_decided_by = :approval_decided_by
+		,approval_decided = :approval_decided
+		,approval_comment = :approval_comment
+		,approval_updated = :approval_updated
+		,approval_version = :approval_version
+	WHERE approval_id = :approval_id AND approval_version = :approval_version - 1`
+	dbApproval := mapApprovalToInternal(a)
+
+	dbApproval.Version++
+	dbApproval.Updated = time.Now().UnixMilli()
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(approvalUpdateStmt, dbApproval)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind approval object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to update approval")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrVersionConflict
+	}
+
+	a.Version = dbApproval.Version
+	a.Updated = dbApproval.Updated
+	return nil
+}
+
+// ListExpired lists the pending approval gates of blocked stages
+// that have been waiting for a decision longer than their timeout.
+func (s *approvalStore) ListExpired(ctx context.Context, now int64, limit int) ([]*types.Approval, error) {
+	stmt := database.Builder.
+		Select(approvalColumns).
+		From("approvals").
+		InnerJoin("stages ON stage_id = approval_stage_id").
+		Where("approval_state = ?", enum.ApprovalStatePending).
+		Where("approval_timeout > 0").
+		Where("stage_status = ?", enum.CIStatusBlocked).
+		Where("stage_started + approval_timeout <= ?", now).
+		OrderBy("approval_id").
+		Limit(uint64(limit))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*approval{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list expired approvals query")
+	}
+
+	return mapInternalToApprovalList(dst)
+}
@@ -0,0 +1,211 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.AutolinkStore = (*AutolinkStore)(nil)
+
+// NewAutolinkStore returns a new AutolinkStore.
+func NewAutolinkStore(db *sqlx.DB) *AutolinkStore {
+	return &AutolinkStore{
+		db: db,
+	}
+}
+
+// AutolinkStore implements store.AutolinkStore backed by a relational database.
+type AutolinkStore struct {
+	db *sqlx.DB
+}
+
+// autolink is used to fetch autolink rule data from the database.
+type autolink struct {
+	ID          int64  `db:"autolink_id"`
+	SpaceID     *int64 `db:"autolink_space_id"`
+	RepoID      *int64 `db:"autolink_repo_id"`
+	Pattern     string `db:"autolink_pattern"`
+	TargetURL   string `db:"autolink_target_url"`
+	Description string `db:"autolink_description"`
+
+	CreatedBy int64 `db:"autolink_created_by"`
+	Created   int64 `db:"autolink_created"`
+	Updated   int64 `db:"autolink_updated"`
+}
+
+const (
+	autolinkColumns = `
+		 autolink_id
+		,autolink_space_id
+		,autolink_repo_id
+		,autolink_pattern
+		,autolink_target_url
+		,autolink_description
+		,autolink_created_by
+		,autolink_created
+		,autolink_updated`
+
+	autolinkSelectBase = `
+	SELECT` + autolinkColumns + `
+	FROM autolinks`
+)
+
+// Find finds the autolink rule by id.
+func (s *AutolinkStore) Find(ctx context.Context, id int64) (*types.Autolink, error) {
+	const sqlQuery = autolinkSelectBase + `
+	WHERE autolink_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &autolink{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find autolink")
+	}
+
+	return mapAutolink(dst), nil
+}
+
+// Create creates a new autolink rule.
+func (s *AutolinkStore) Create(ctx context.Context, link *types.Autolink) error {
+	const sqlQuery = `
+	INSERT INTO autolinks (
+		 autolink_space_id
+		,autolink_repo_id
+		,autolink_pattern
+		,autolink_target_url
+		,autolink_description
+		,autolink_created_by
+		,autolink_created
+		,autolink_updated
+	) values (
+		 :autolink_space_id
+		,:autolink_repo_id
+		,:autolink_pattern
+		,:autolink_target_url
+		,:autolink_description
+		,:autolink_created_by
+		,:autolink_created
+		,:autolink_updated
+	) RETURNING autolink_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalAutolink(link))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind autolink object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&link.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to insert autolink")
+	}
+
+	return nil
+}
+
+// Update updates the pattern, the target URL and the description of the autolink rule.
+func (s *AutolinkStore) Update(ctx context.Context, link *types.Autolink) error {
+	const sqlQuery = `
+	UPDATE autolinks
+	SET
+		 autolink_pattern = :autolink_pattern
+		,autolink_target_url = :autolink_target_url
+		,autolink_description = :autolink_description
+		,autolink_updated = :autolink_updated
+	WHERE autolink_id = :autolink_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbLink := mapInternalAutolink(link)
+	dbLink.Updated = time.Now().UnixMilli()
+
+	query, arg, err := db.BindNamed(sqlQuery, dbLink)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind autolink object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to update autolink")
+	}
+
+	link.Updated = dbLink.Updated
+
+	return nil
+}
+
+// Delete deletes the autolink rule.
+func (s *AutolinkStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+	DELETE FROM autolinks
+	WHERE autolink_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete autolink")
+	}
+
+	return nil
+}
+
+// ListBySpace returns all autolink rules defined directly in the space.
+func (s *AutolinkStore) ListBySpace(ctx context.Context, spaceID int64) ([]*types.Autolink, error) {
+	const sqlQuery = autolinkSelectBase + `
+	WHERE autolink_space_id = $1
+	ORDER BY autolink_id`
+
+	return s.list(ctx, sqlQuery, spaceID)
+}
+
+// ListByRepo returns all autolink rules defined directly in the repository.
+func (s *AutolinkStore) ListByRepo(ctx context.Context, repoID int64) ([]*types.Autolink, error) {
+	const sqlQuery = autolinkSelectBase + `
+	WHERE autolink_repo_id = $1
+	ORDER BY autolink_id`
+
+	return s.list(ctx, sqlQuery, repoID)
+}
+
+func (s *AutolinkStore) list(ctx context.Context, sqlQuery string, arg any) ([]*types.Autolink, error) {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*autolink
+	if err := db.SelectContext(ctx, &dst, sqlQuery, arg); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list autolinks")
+	}
+
+	result := make([]*types.Autolink, len(dst))
+	for i, link := range dst {
+		result[i] = mapAutolink(link)
+	}
+
+	return result, nil
+}
+
+func mapAutolink(v *autolink) *types.Autolink {
+	return (*types.Autolink)(v) // the two types are identical, except for the tags
+}
+
+func mapInternalAutolink(v *types.Autolink) *autolink {
+	return (*autolink)(v) // the two types are identical, except for the tags
+}
@@ -85,6 +85,40 @@ type check struct {
 	PayloadVersion string                `db:"check_payload_version"`
 }
 
+// Find returns the status check result of a specific commit in a repo.
+func (s *CheckStore) Find(ctx context.Context,
+	repoID int64,
+	commitSHA string,
+	uid string,
+) (types.Check, error) {
+	stmt := database.Builder.
+		Select(checkColumns).
+		From("checks").
+		Where("check_repo_id = ?", repoID).
+		Where("check_commit_sha = ?", commitSHA).
+		Where("check_uid = ?", uid)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return types.Check{}, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	dst := &check{}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return types.Check{}, database.ProcessSQLErrorf(err, "Failed to find status check")
+	}
+
+	result, err := s.mapSliceCheck(ctx, []*check{dst})
+	if err != nil {
+		return types.Check{}, err
+	}
+
+	return result[0], nil
+}
+
 // Upsert creates new or updates an existing status check result.
 func (s *CheckStore) Upsert(ctx context.Context, check *types.Check) error {
 	const sqlQuery = `
@@ -16,6 +16,7 @@ package database
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
 	"github.com/harness/gitness/app/store"
@@ -24,6 +25,7 @@ import (
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
+	"github.com/guregu/null"
 	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
@@ -43,6 +45,12 @@ type CodeCommentView struct {
 	db *sqlx.DB
 }
 
+// codeCommentRow is used to store the code comment anchor as JSON.
+type codeCommentRow struct {
+	types.CodeComment
+	AnchorJSON null.String `db:"pullreq_activity_code_comment_anchor"`
+}
+
 // ListNotAtSourceSHA lists all code comments not already at the provided source SHA.
 func (s *CodeCommentView) ListNotAtSourceSHA(ctx context.Context,
 	prID int64, sourceSHA string,
@@ -74,7 +82,9 @@ func (s *CodeCommentView) list(ctx context.Context,
 		,coalesce(pullreq_activity_code_comment_line_new, 1) as "pullreq_activity_code_comment_line_new"
 		,coalesce(pullreq_activity_code_comment_span_new, 0) as "pullreq_activity_code_comment_span_new"
 		,coalesce(pullreq_activity_code_comment_line_old, 1) as "pullreq_activity_code_comment_line_old"
-		,coalesce(pullreq_activity_code_comment_span_old, 0) as "pullreq_activity_code_comment_span_old"`
+		,coalesce(pullreq_activity_code_comment_span_old, 0) as "pullreq_activity_code_comment_span_old"
+		,coalesce(pullreq_activity_code_comment_anchor_status, '') as "pullreq_activity_code_comment_anchor_status"
+		,pullreq_activity_code_comment_anchor`
 
 	stmt := database.Builder.
 		Select(codeCommentColumns).
@@ -101,14 +111,20 @@ func (s *CodeCommentView) list(ctx context.Context,
 		return nil, errors.Wrap(err, "Failed to convert pull request activity query to sql")
 	}
 
-	result := make([]*types.CodeComment, 0)
+	dst := make([]*codeCommentRow, 0)
 
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	if err = db.SelectContext(ctx, &result, sql, args...); err != nil {
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
 		return nil, database.ProcessSQLErrorf(err, "Failed executing code comment list query")
 	}
 
+	result := make([]*types.CodeComment, len(dst))
+	for i, cc := range dst {
+		cc.Anchor = decodeCodeCommentAnchor(cc.AnchorJSON)
+		result[i] = &cc.CodeComment
+	}
+
 	return result, nil
 }
 
@@ -131,6 +147,8 @@ func (s *CodeCommentView) UpdateAll(ctx context.Context, codeComments []*types.C
 		,pullreq_activity_code_comment_span_new = :pullreq_activity_code_comment_span_new
 		,pullreq_activity_code_comment_line_old = :pullreq_activity_code_comment_line_old
 		,pullreq_activity_code_comment_span_old = :pullreq_activity_code_comment_span_old
+		,pullreq_activity_code_comment_anchor_status = :pullreq_activity_code_comment_anchor_status
+		,pullreq_activity_code_comment_anchor = :pullreq_activity_code_comment_anchor
 	WHERE pullreq_activity_id = :pullreq_activity_id AND pullreq_activity_version = :pullreq_activity_version - 1`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -147,7 +165,10 @@ func (s *CodeCommentView) UpdateAll(ctx context.Context, codeComments []*types.C
 		codeComment.Version++
 		codeComment.Updated = updatedAt.UnixMilli()
 
-		result, err := stmt.ExecContext(ctx, codeComment)
+		result, err := stmt.ExecContext(ctx, &codeCommentRow{
+			CodeComment: *codeComment,
+			AnchorJSON:  encodeCodeCommentAnchor(codeComment.Anchor),
+		})
 		if err != nil {
 			return database.ProcessSQLErrorf(err, "Failed to update code comment=%d", codeComment.ID)
 		}
@@ -165,3 +186,32 @@ func (s *CodeCommentView) UpdateAll(ctx context.Context, codeComments []*types.C
 
 	return nil
 }
+
+// encodeCodeCommentAnchor converts the code comment anchor to JSON.
+func encodeCodeCommentAnchor(anchor *types.CodeCommentAnchor) null.String {
+	if anchor == nil {
+		return null.String{}
+	}
+
+	data, err := json.Marshal(anchor)
+	if err != nil {
+		return null.String{}
+	}
+
+	return null.StringFrom(string(data))
+}
+
+// decodeCodeCommentAnchor parses the code comment anchor stored as JSON.
+// Code comments without a valid anchor can't be re-anchored, so any parsing error is ignored.
+func decodeCodeCommentAnchor(data null.String) *types.CodeCommentAnchor {
+	if !data.Valid || data.String == "" {
+		return nil
+	}
+
+	anchor := &types.CodeCommentAnchor{}
+	if err := json.Unmarshal([]byte(data.String), anchor); err != nil {
+		return nil
+	}
+
+	return anchor
+}
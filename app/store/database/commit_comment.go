@@ -0,0 +1,237 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.CommitCommentStore = (*CommitCommentStore)(nil)
+
+// NewCommitCommentStore returns a new CommitCommentStore.
+func NewCommitCommentStore(db *sqlx.DB) *CommitCommentStore {
+	return &CommitCommentStore{
+		db: db,
+	}
+}
+
+// CommitCommentStore implements store.CommitCommentStore backed by a relational database.
+type CommitCommentStore struct {
+	db *sqlx.DB
+}
+
+// commitComment is used to fetch commit comment data from the database.
+type commitComment struct {
+	ID           int64  `db:"commit_comment_id"`
+	RepoID       int64  `db:"commit_comment_repo_id"`
+	CommitSHA    string `db:"commit_comment_commit_sha"`
+	ParentID     *int64 `db:"commit_comment_parent_id"`
+	CreatedBy    int64  `db:"commit_comment_created_by"`
+	Created      int64  `db:"commit_comment_created"`
+	Updated      int64  `db:"commit_comment_updated"`
+	Edited       int64  `db:"commit_comment_edited"`
+	Deleted      *int64 `db:"commit_comment_deleted"`
+	Text         string `db:"commit_comment_text"`
+	Path         string `db:"commit_comment_path"`
+	LineStart    int    `db:"commit_comment_line_start"`
+	LineStartNew bool   `db:"commit_comment_line_start_new"`
+	LineEnd      int    `db:"commit_comment_line_end"`
+	LineEndNew   bool   `db:"commit_comment_line_end_new"`
+}
+
+const (
+	commitCommentColumns = `
+		 commit_comment_id
+		,commit_comment_repo_id
+		,commit_comment_commit_sha
+		,commit_comment_parent_id
+		,commit_comment_created_by
+		,commit_comment_created
+		,commit_comment_updated
+		,commit_comment_edited
+		,commit_comment_deleted
+		,commit_comment_text
+		,commit_comment_path
+		,commit_comment_line_start
+		,commit_comment_line_start_new
+		,commit_comment_line_end
+		,commit_comment_line_end_new`
+
+	commitCommentSelectBase = `
+	SELECT` + commitCommentColumns + `
+	FROM commit_comments`
+)
+
+// Find finds the commit comment by id.
+func (s *CommitCommentStore) Find(ctx context.Context, id int64) (*types.CommitComment, error) {
+	const sqlQuery = commitCommentSelectBase + `
+	WHERE commit_comment_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &commitComment{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find commit comment")
+	}
+
+	return mapCommitComment(dst), nil
+}
+
+// List returns all comments of the commit in the repository, the oldest first.
+func (s *CommitCommentStore) List(
+	ctx context.Context,
+	repoID int64,
+	commitSHA string,
+) ([]*types.CommitComment, error) {
+	const sqlQuery = commitCommentSelectBase + `
+	WHERE commit_comment_repo_id = $1 AND commit_comment_commit_sha = $2
+	ORDER BY commit_comment_created ASC, commit_comment_id ASC`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*commitComment{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, repoID, commitSHA); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list commit comments")
+	}
+
+	comments := make([]*types.CommitComment, len(dst))
+	for i := range dst {
+		comments[i] = mapCommitComment(dst[i])
+	}
+
+	return comments, nil
+}
+
+// Create creates a new commit comment.
+func (s *CommitCommentStore) Create(ctx context.Context, comment *types.CommitComment) error {
+	const sqlQuery = `
+	INSERT INTO commit_comments (
+		 commit_comment_repo_id
+		,commit_comment_commit_sha
+		,commit_comment_parent_id
+		,commit_comment_created_by
+		,commit_comment_created
+		,commit_comment_updated
+		,commit_comment_edited
+		,commit_comment_deleted
+		,commit_comment_text
+		,commit_comment_path
+		,commit_comment_line_start
+		,commit_comment_line_start_new
+		,commit_comment_line_end
+		,commit_comment_line_end_new
+	) VALUES (
+		 :commit_comment_repo_id
+		,:commit_comment_commit_sha
+		,:commit_comment_parent_id
+		,:commit_comment_created_by
+		,:commit_comment_created
+		,:commit_comment_updated
+		,:commit_comment_edited
+		,:commit_comment_deleted
+		,:commit_comment_text
+		,:commit_comment_path
+		,:commit_comment_line_start
+		,:commit_comment_line_start_new
+		,:commit_comment_line_end
+		,:commit_comment_line_end_new
+	)
+	RETURNING commit_comment_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalCommitComment(comment))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind commit comment object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&comment.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Update updates the text and the edited and deleted time of the commit comment.
+func (s *CommitCommentStore) Update(ctx context.Context, comment *types.CommitComment) error {
+	const sqlQuery = `
+	UPDATE commit_comments
+	SET
+		 commit_comment_updated = :commit_comment_updated
+		,commit_comment_edited = :commit_comment_edited
+		,commit_comment_deleted = :commit_comment_deleted
+		,commit_comment_text = :commit_comment_text
+	WHERE commit_comment_id = :commit_comment_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalCommitComment(comment))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind commit comment object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Update query failed")
+	}
+
+	return nil
+}
+
+func mapCommitComment(c *commitComment) *types.CommitComment {
+	return &types.CommitComment{
+		ID:           c.ID,
+		RepoID:       c.RepoID,
+		CommitSHA:    c.CommitSHA,
+		ParentID:     c.ParentID,
+		CreatedBy:    c.CreatedBy,
+		Created:      c.Created,
+		Updated:      c.Updated,
+		Edited:       c.Edited,
+		Deleted:      c.Deleted,
+		Text:         c.Text,
+		Path:         c.Path,
+		LineStart:    c.LineStart,
+		LineStartNew: c.LineStartNew,
+		LineEnd:      c.LineEnd,
+		LineEndNew:   c.LineEndNew,
+	}
+}
+
+func mapInternalCommitComment(c *types.CommitComment) *commitComment {
+	return &commitComment{
+		ID:           c.ID,
+		RepoID:       c.RepoID,
+		CommitSHA:    c.CommitSHA,
+		ParentID:     c.ParentID,
+		CreatedBy:    c.CreatedBy,
+		Created:      c.Created,
+		Updated:      c.Updated,
+		Edited:       c.Edited,
+		Deleted:      c.Deleted,
+		Text:         c.Text,
+		Path:         c.Path,
+		LineStart:    c.LineStart,
+		LineStartNew: c.LineStartNew,
+		LineEnd:      c.LineEnd,
+		LineEndNew:   c.LineEndNew,
+	}
+}
@@ -0,0 +1,341 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var _ store.CommitIndexStore = (*CommitIndexStore)(nil)
+
+// commitIndexInsertBatchSize is the maximum number of commits inserted with a single query.
+const commitIndexInsertBatchSize = 200
+
+// NewCommitIndexStore returns a new CommitIndexStore.
+func NewCommitIndexStore(db *sqlx.DB) *CommitIndexStore {
+	return &CommitIndexStore{
+		db: db,
+	}
+}
+
+// CommitIndexStore implements store.CommitIndexStore backed by a relational database.
+type CommitIndexStore struct {
+	db *sqlx.DB
+}
+
+type commitIndexEntry struct {
+	RepoID         int64  `db:"commit_index_repo_id"`
+	SHA            string `db:"commit_index_sha"`
+	Title          string `db:"commit_index_title"`
+	Message        string `db:"commit_index_message"`
+	AuthorName     string `db:"commit_index_author_name"`
+	AuthorEmail    string `db:"commit_index_author_email"`
+	AuthorDate     int64  `db:"commit_index_author_date"`
+	CommitterName  string `db:"commit_index_committer_name"`
+	CommitterEmail string `db:"commit_index_committer_email"`
+	CommitterDate  int64  `db:"commit_index_committer_date"`
+}
+
+type commitIndexState struct {
+	RepoID    int64  `db:"commit_index_state_repo_id"`
+	CommitSHA string `db:"commit_index_state_commit_sha"`
+	Updated   int64  `db:"commit_index_state_updated"`
+}
+
+const commitIndexColumns = `
+	 commit_index_repo_id
+	,commit_index_sha
+	,commit_index_title
+	,commit_index_message
+	,commit_index_author_name
+	,commit_index_author_email
+	,commit_index_author_date
+	,commit_index_committer_name
+	,commit_index_committer_email
+	,commit_index_committer_date`
+
+const commitIndexStateColumns = `
+	 commit_index_state_repo_id
+	,commit_index_state_commit_sha
+	,commit_index_state_updated`
+
+// FindState returns the state of the commit index of the repository.
+func (s *CommitIndexStore) FindState(ctx context.Context, repoID int64) (*types.CommitIndexState, error) {
+	const sqlQuery = `
+		SELECT` + commitIndexStateColumns + `
+		FROM commit_index_states
+		WHERE commit_index_state_repo_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &commitIndexState{}
+	if err := db.GetContext(ctx, dst, sqlQuery, repoID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find commit index state")
+	}
+
+	return &types.CommitIndexState{
+		RepoID:    dst.RepoID,
+		CommitSHA: dst.CommitSHA,
+		Updated:   dst.Updated,
+	}, nil
+}
+
+// UpsertState creates or replaces the state of the commit index of the repository.
+func (s *CommitIndexStore) UpsertState(ctx context.Context, state *types.CommitIndexState) error {
+	const sqlQuery = `
+		INSERT INTO commit_index_states (` + commitIndexStateColumns + `
+		) VALUES (
+			 :commit_index_state_repo_id
+			,:commit_index_state_commit_sha
+			,:commit_index_state_updated
+		)
+		ON CONFLICT (commit_index_state_repo_id) DO
+		UPDATE SET
+			 commit_index_state_commit_sha = EXCLUDED.commit_index_state_commit_sha
+			,commit_index_state_updated = EXCLUDED.commit_index_state_updated`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, &commitIndexState{
+		RepoID:    state.RepoID,
+		CommitSHA: state.CommitSHA,
+		Updated:   state.Updated,
+	})
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind commit index state object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Upsert commit index state query failed")
+	}
+
+	return nil
+}
+
+// Insert adds the commits to the index of the repository, already indexed commits are skipped.
+func (s *CommitIndexStore) Insert(ctx context.Context, repoID int64, commits []types.Commit) error {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	for len(commits) > 0 {
+		batch := commits
+		if len(batch) > commitIndexInsertBatchSize {
+			batch = batch[:commitIndexInsertBatchSize]
+		}
+		commits = commits[len(batch):]
+
+		stmt := database.Builder.
+			Insert("commit_index").
+			Columns(commitIndexColumns).
+			Suffix("ON CONFLICT DO NOTHING")
+
+		for i := range batch {
+			e := mapToInternalCommitIndexEntry(repoID, &batch[i])
+			stmt = stmt.Values(
+				e.RepoID,
+				e.SHA,
+				e.Title,
+				e.Message,
+				e.AuthorName,
+				e.AuthorEmail,
+				e.AuthorDate,
+				e.CommitterName,
+				e.CommitterEmail,
+				e.CommitterDate,
+			)
+		}
+
+		sql, args, err := stmt.ToSql()
+		if err != nil {
+			return errors.Wrap(err, "Failed to convert query to sql")
+		}
+
+		if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+			return database.ProcessSQLErrorf(err, "Failed to insert commits to the commit index")
+		}
+	}
+
+	return nil
+}
+
+// DeleteAll removes all commits and the state of the index of the repository.
+func (s *CommitIndexStore) DeleteAll(ctx context.Context, repoID int64) error {
+	const sqlQueryState = `
+		DELETE FROM commit_index_states
+		WHERE commit_index_state_repo_id = $1`
+
+	const sqlQueryCommits = `
+		DELETE FROM commit_index
+		WHERE commit_index_repo_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQueryState, repoID); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete commit index state")
+	}
+
+	if _, err := db.ExecContext(ctx, sqlQueryCommits, repoID); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete commit index")
+	}
+
+	return nil
+}
+
+// Search returns the indexed commits of the repository matching the filter, newest first.
+func (s *CommitIndexStore) Search(
+	ctx context.Context,
+	repoID int64,
+	filter *types.CommitSearchFilter,
+) ([]types.Commit, error) {
+	stmt := database.Builder.
+		Select(commitIndexColumns).
+		From("commit_index").
+		Where("commit_index_repo_id = ?", repoID)
+
+	stmt = applyCommitSearchFilter(stmt, filter)
+
+	stmt = stmt.OrderBy("commit_index_committer_date DESC", "commit_index_sha")
+	stmt = stmt.Limit(database.Limit(filter.Limit))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Limit))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*commitIndexEntry{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing commit search query")
+	}
+
+	commits := make([]types.Commit, len(dst))
+	for i, e := range dst {
+		commits[i] = mapToIndexedCommit(e)
+	}
+
+	return commits, nil
+}
+
+// Count returns the number of the indexed commits of the repository matching the filter.
+func (s *CommitIndexStore) Count(
+	ctx context.Context,
+	repoID int64,
+	filter *types.CommitSearchFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("COUNT(*)").
+		From("commit_index").
+		Where("commit_index_repo_id = ?", repoID)
+
+	stmt = applyCommitSearchFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(err, "Failed executing commit search count query")
+	}
+
+	return count, nil
+}
+
+func applyCommitSearchFilter(
+	stmt squirrel.SelectBuilder,
+	filter *types.CommitSearchFilter,
+) squirrel.SelectBuilder {
+	if filter.Query != "" {
+		query := fmt.Sprintf("%%%s%%", strings.ToLower(filter.Query))
+		stmt = stmt.Where("(LOWER(commit_index_title) LIKE ? OR LOWER(commit_index_message) LIKE ?)",
+			query, query)
+	}
+
+	if filter.Author != "" {
+		author := fmt.Sprintf("%%%s%%", strings.ToLower(filter.Author))
+		stmt = stmt.Where("(LOWER(commit_index_author_name) LIKE ? OR LOWER(commit_index_author_email) LIKE ?)",
+			author, author)
+	}
+
+	if filter.Committer != "" {
+		committer := fmt.Sprintf("%%%s%%", strings.ToLower(filter.Committer))
+		stmt = stmt.Where(
+			"(LOWER(commit_index_committer_name) LIKE ? OR LOWER(commit_index_committer_email) LIKE ?)",
+			committer, committer)
+	}
+
+	if filter.Since > 0 {
+		stmt = stmt.Where("commit_index_committer_date >= ?", time.Unix(filter.Since, 0).UnixMilli())
+	}
+
+	if filter.Until > 0 {
+		stmt = stmt.Where("commit_index_committer_date <= ?", time.Unix(filter.Until, 0).UnixMilli())
+	}
+
+	return stmt
+}
+
+func mapToInternalCommitIndexEntry(repoID int64, commit *types.Commit) *commitIndexEntry {
+	return &commitIndexEntry{
+		RepoID:         repoID,
+		SHA:            commit.SHA,
+		Title:          commit.Title,
+		Message:        commit.Message,
+		AuthorName:     commit.Author.Identity.Name,
+		AuthorEmail:    commit.Author.Identity.Email,
+		AuthorDate:     commit.Author.When.UnixMilli(),
+		CommitterName:  commit.Committer.Identity.Name,
+		CommitterEmail: commit.Committer.Identity.Email,
+		CommitterDate:  commit.Committer.When.UnixMilli(),
+	}
+}
+
+func mapToIndexedCommit(in *commitIndexEntry) types.Commit {
+	return types.Commit{
+		SHA:     in.SHA,
+		Title:   in.Title,
+		Message: in.Message,
+		Author: types.Signature{
+			Identity: types.Identity{
+				Name:  in.AuthorName,
+				Email: in.AuthorEmail,
+			},
+			When: time.UnixMilli(in.AuthorDate),
+		},
+		Committer: types.Signature{
+			Identity: types.Identity{
+				Name:  in.CommitterName,
+				Email: in.CommitterEmail,
+			},
+			When: time.UnixMilli(in.CommitterDate),
+		},
+	}
+}
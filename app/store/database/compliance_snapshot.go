@@ -0,0 +1,196 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.ComplianceSnapshotStore = (*ComplianceSnapshotStore)(nil)
+
+// NewComplianceSnapshotStore returns a new ComplianceSnapshotStore.
+func NewComplianceSnapshotStore(db *sqlx.DB) *ComplianceSnapshotStore {
+	return &ComplianceSnapshotStore{
+		db: db,
+	}
+}
+
+// ComplianceSnapshotStore implements store.ComplianceSnapshotStore backed by a relational database.
+type ComplianceSnapshotStore struct {
+	db *sqlx.DB
+}
+
+// complianceSnapshot is used to fetch compliance snapshot data from the database.
+type complianceSnapshot struct {
+	ID          int64  `db:"compliance_snapshot_id"`
+	PeriodStart int64  `db:"compliance_snapshot_period_start"`
+	PeriodEnd   int64  `db:"compliance_snapshot_period_end"`
+	Data        string `db:"compliance_snapshot_data"`
+	PrevHash    string `db:"compliance_snapshot_prev_hash"`
+	Hash        string `db:"compliance_snapshot_hash"`
+	Created     int64  `db:"compliance_snapshot_created"`
+}
+
+const (
+	complianceSnapshotColumns = `
+		 compliance_snapshot_id
+		,compliance_snapshot_period_start
+		,compliance_snapshot_period_end
+		,compliance_snapshot_data
+		,compliance_snapshot_prev_hash
+		,compliance_snapshot_hash
+		,compliance_snapshot_created`
+
+	complianceSnapshotSelectBase = `
+	SELECT` + complianceSnapshotColumns + `
+	FROM compliance_snapshots`
+)
+
+// Create saves a new compliance snapshot.
+func (s *ComplianceSnapshotStore) Create(ctx context.Context, snapshot *types.ComplianceSnapshot) error {
+	const sqlQuery = `
+	INSERT INTO compliance_snapshots (
+		 compliance_snapshot_period_start
+		,compliance_snapshot_period_end
+		,compliance_snapshot_data
+		,compliance_snapshot_prev_hash
+		,compliance_snapshot_hash
+		,compliance_snapshot_created
+	) values (
+		 :compliance_snapshot_period_start
+		,:compliance_snapshot_period_end
+		,:compliance_snapshot_data
+		,:compliance_snapshot_prev_hash
+		,:compliance_snapshot_hash
+		,:compliance_snapshot_created
+	) RETURNING compliance_snapshot_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalComplianceSnapshot(snapshot))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind compliance snapshot object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&snapshot.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to insert compliance snapshot")
+	}
+
+	return nil
+}
+
+// Last returns the most recent compliance snapshot.
+func (s *ComplianceSnapshotStore) Last(ctx context.Context) (*types.ComplianceSnapshot, error) {
+	const sqlQuery = complianceSnapshotSelectBase + `
+	ORDER BY compliance_snapshot_id DESC
+	LIMIT 1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &complianceSnapshot{}
+	if err := db.GetContext(ctx, dst, sqlQuery); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find last compliance snapshot")
+	}
+
+	return mapComplianceSnapshot(dst), nil
+}
+
+// Count returns the number of compliance snapshots.
+func (s *ComplianceSnapshotStore) Count(ctx context.Context) (int64, error) {
+	const sqlQuery = `
+	SELECT count(*)
+	FROM compliance_snapshots`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err := db.QueryRowContext(ctx, sqlQuery).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(err, "Failed to count compliance snapshots")
+	}
+
+	return count, nil
+}
+
+// List returns a page of compliance snapshots, the most recent first.
+func (s *ComplianceSnapshotStore) List(
+	ctx context.Context,
+	opts *types.Pagination,
+) ([]*types.ComplianceSnapshot, error) {
+	const sqlQuery = complianceSnapshotSelectBase + `
+	ORDER BY compliance_snapshot_id DESC
+	LIMIT $1 OFFSET $2`
+
+	return s.list(ctx, sqlQuery, database.Limit(opts.Size), database.Offset(opts.Page, opts.Size))
+}
+
+// ListAll returns all compliance snapshots in the order they were created.
+func (s *ComplianceSnapshotStore) ListAll(ctx context.Context) ([]*types.ComplianceSnapshot, error) {
+	const sqlQuery = complianceSnapshotSelectBase + `
+	ORDER BY compliance_snapshot_id`
+
+	return s.list(ctx, sqlQuery)
+}
+
+func (s *ComplianceSnapshotStore) list(
+	ctx context.Context,
+	sqlQuery string,
+	args ...any,
+) ([]*types.ComplianceSnapshot, error) {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*complianceSnapshot
+	if err := db.SelectContext(ctx, &dst, sqlQuery, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list compliance snapshots")
+	}
+
+	result := make([]*types.ComplianceSnapshot, len(dst))
+	for i, snapshot := range dst {
+		result[i] = mapComplianceSnapshot(snapshot)
+	}
+
+	return result, nil
+}
+
+func mapComplianceSnapshot(v *complianceSnapshot) *types.ComplianceSnapshot {
+	return &types.ComplianceSnapshot{
+		ID:          v.ID,
+		PeriodStart: v.PeriodStart,
+		PeriodEnd:   v.PeriodEnd,
+		Data:        json.RawMessage(v.Data),
+		PrevHash:    v.PrevHash,
+		Hash:        v.Hash,
+		Created:     v.Created,
+	}
+}
+
+func mapInternalComplianceSnapshot(v *types.ComplianceSnapshot) *complianceSnapshot {
+	return &complianceSnapshot{
+		ID:          v.ID,
+		PeriodStart: v.PeriodStart,
+		PeriodEnd:   v.PeriodEnd,
+		Data:        string(v.Data),
+		PrevHash:    v.PrevHash,
+		Hash:        v.Hash,
+		Created:     v.Created,
+	}
+}
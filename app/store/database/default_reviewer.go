@@ -0,0 +1,192 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.DefaultReviewerStore = (*DefaultReviewerStore)(nil)
+
+// NewDefaultReviewerStore returns a new DefaultReviewerStore.
+func NewDefaultReviewerStore(db *sqlx.DB) *DefaultReviewerStore {
+	return &DefaultReviewerStore{
+		db: db,
+	}
+}
+
+// DefaultReviewerStore implements store.DefaultReviewerStore backed by a relational database.
+type DefaultReviewerStore struct {
+	db *sqlx.DB
+}
+
+// defaultReviewer is used to fetch default reviewer data from the database.
+type defaultReviewer struct {
+	ID             int64  `db:"default_reviewer_id"`
+	RepoID         int64  `db:"default_reviewer_repo_id"`
+	PrincipalID    *int64 `db:"default_reviewer_principal_id"`
+	UserGroupID    string `db:"default_reviewer_user_group_id"`
+	TargetBranches string `db:"default_reviewer_target_branches"`
+
+	CreatedBy int64 `db:"default_reviewer_created_by"`
+	Created   int64 `db:"default_reviewer_created"`
+	Updated   int64 `db:"default_reviewer_updated"`
+}
+
+const (
+	defaultReviewerColumns = `
+		 default_reviewer_id
+		,default_reviewer_repo_id
+		,default_reviewer_principal_id
+		,default_reviewer_user_group_id
+		,default_reviewer_target_branches
+		,default_reviewer_created_by
+		,default_reviewer_created
+		,default_reviewer_updated`
+
+	defaultReviewerSelectBase = `
+	SELECT` + defaultReviewerColumns + `
+	FROM default_reviewers`
+)
+
+// Find finds the default reviewer by id.
+func (s *DefaultReviewerStore) Find(ctx context.Context, id int64) (*types.DefaultReviewer, error) {
+	const sqlQuery = defaultReviewerSelectBase + `
+	WHERE default_reviewer_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &defaultReviewer{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find default reviewer")
+	}
+
+	return mapDefaultReviewer(dst), nil
+}
+
+// Create creates a new default reviewer.
+func (s *DefaultReviewerStore) Create(ctx context.Context, reviewer *types.DefaultReviewer) error {
+	const sqlQuery = `
+	INSERT INTO default_reviewers (
+		 default_reviewer_repo_id
+		,default_reviewer_principal_id
+		,default_reviewer_user_group_id
+		,default_reviewer_target_branches
+		,default_reviewer_created_by
+		,default_reviewer_created
+		,default_reviewer_updated
+	) values (
+		 :default_reviewer_repo_id
+		,:default_reviewer_principal_id
+		,:default_reviewer_user_group_id
+		,:default_reviewer_target_branches
+		,:default_reviewer_created_by
+		,:default_reviewer_created
+		,:default_reviewer_updated
+	) RETURNING default_reviewer_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalDefaultReviewer(reviewer))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind default reviewer object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&reviewer.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to insert default reviewer")
+	}
+
+	return nil
+}
+
+// Delete deletes the default reviewer.
+func (s *DefaultReviewerStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+	DELETE FROM default_reviewers
+	WHERE default_reviewer_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete default reviewer")
+	}
+
+	return nil
+}
+
+// List returns all default reviewers of the repository.
+func (s *DefaultReviewerStore) List(ctx context.Context, repoID int64) ([]*types.DefaultReviewer, error) {
+	const sqlQuery = defaultReviewerSelectBase + `
+	WHERE default_reviewer_repo_id = $1
+	ORDER BY default_reviewer_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*defaultReviewer
+	if err := db.SelectContext(ctx, &dst, sqlQuery, repoID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list default reviewers")
+	}
+
+	result := make([]*types.DefaultReviewer, len(dst))
+	for i, reviewer := range dst {
+		result[i] = mapDefaultReviewer(reviewer)
+	}
+
+	return result, nil
+}
+
+func mapDefaultReviewer(v *defaultReviewer) *types.DefaultReviewer {
+	var targetBranches json.RawMessage
+	if v.TargetBranches != "" && v.TargetBranches != "{}" {
+		targetBranches = json.RawMessage(v.TargetBranches)
+	}
+
+	return &types.DefaultReviewer{
+		ID:             v.ID,
+		RepoID:         v.RepoID,
+		PrincipalID:    v.PrincipalID,
+		UserGroupID:    v.UserGroupID,
+		TargetBranches: targetBranches,
+		CreatedBy:      v.CreatedBy,
+		Created:        v.Created,
+		Updated:        v.Updated,
+	}
+}
+
+func mapInternalDefaultReviewer(v *types.DefaultReviewer) *defaultReviewer {
+	targetBranches := string(v.TargetBranches)
+	if targetBranches == "" {
+		targetBranches = "{}"
+	}
+
+	return &defaultReviewer{
+		ID:             v.ID,
+		RepoID:         v.RepoID,
+		PrincipalID:    v.PrincipalID,
+		UserGroupID:    v.UserGroupID,
+		TargetBranches: targetBranches,
+		CreatedBy:      v.CreatedBy,
+		Created:        v.Created,
+		Updated:        v.Updated,
+	}
+}
@@ -77,6 +77,9 @@ type execution struct {
 	Deploy       string             `db:"execution_deploy"`
 	DeployID     int64              `db:"execution_deploy_id"`
 	Debug        bool               `db:"execution_debug"`
+	Concurrency  string             `db:"execution_concurrency_group"`
+	Timeout      int64              `db:"execution_timeout"`
+	Reason       string             `db:"execution_failure_reason"`
 	Started      int64              `db:"execution_started"`
 	Finished     int64              `db:"execution_finished"`
 	Created      int64              `db:"execution_created"`
@@ -117,6 +120,9 @@ const (
 		,execution_deploy
 		,execution_deploy_id
 		,execution_debug
+		,execution_concurrency_group
+		,execution_timeout
+		,execution_failure_reason
 		,execution_started
 		,execution_finished
 		,execution_created
@@ -160,6 +166,43 @@ func (s *executionStore) FindByNumber(
 	return mapInternalToExecution(dst)
 }
 
+// FindLatestByRef returns the most recent execution of a pipeline for the provided git reference.
+func (s *executionStore) FindLatestByRef(
+	ctx context.Context,
+	pipelineID int64,
+	ref string,
+) (*types.Execution, error) {
+	const findQueryStmt = `
+	SELECT` + executionColumns + `
+	FROM executions
+	WHERE execution_pipeline_id = $1 AND execution_ref = $2
+	ORDER BY execution_number DESC
+	LIMIT 1`
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := new(execution)
+	if err := db.GetContext(ctx, dst, findQueryStmt, pipelineID, ref); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find latest execution")
+	}
+	return mapInternalToExecution(dst)
+}
+
+// ListIncomplete returns the executions that aren't done yet, ordered by their id.
+func (s *executionStore) ListIncomplete(ctx context.Context) ([]*types.Execution, error) {
+	const queryListIncomplete = `
+	SELECT` + executionColumns + `
+	FROM executions
+	WHERE execution_status IN ('waiting_on_dependencies', 'pending', 'running', 'blocked')
+	ORDER BY execution_id ASC`
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*execution{}
+	if err := db.SelectContext(ctx, &dst, queryListIncomplete); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find incomplete executions")
+	}
+	return mapInternalToExecutionList(dst)
+}
+
 // Create creates a new execution in the datastore.
 func (s *executionStore) Create(ctx context.Context, execution *types.Execution) error {
 	const executionInsertStmt = `
@@ -194,6 +237,9 @@ func (s *executionStore) Create(ctx context.Context, execution *types.Execution)
 		,execution_deploy
 		,execution_deploy_id
 		,execution_debug
+		,execution_concurrency_group
+		,execution_timeout
+		,execution_failure_reason
 		,execution_started
 		,execution_finished
 		,execution_created
@@ -230,6 +276,9 @@ func (s *executionStore) Create(ctx context.Context, execution *types.Execution)
 		,:execution_deploy
 		,:execution_deploy_id
 		,:execution_debug
+		,:execution_concurrency_group
+		,:execution_timeout
+		,:execution_failure_reason
 		,:execution_started
 		,:execution_finished
 		,:execution_created
@@ -257,6 +306,7 @@ func (s *executionStore) Update(ctx context.Context, e *types.Execution) error {
 	SET
 		execution_status = :execution_status
 		,execution_error = :execution_error
+		,execution_failure_reason = :execution_failure_reason
 		,execution_event = :execution_event
 		,execution_started = :execution_started
 		,execution_finished = :execution_finished
@@ -334,6 +384,26 @@ func (s *executionStore) List(
 	return mapInternalToExecutionList(dst)
 }
 
+// ListInRange lists the executions of a pipeline created in the time range of the filter.
+func (s *executionStore) ListInRange(
+	ctx context.Context,
+	pipelineID int64,
+	filter types.PipelineStatsFilter,
+) ([]*types.Execution, error) {
+	const queryListInRange = `
+	SELECT` + executionColumns + `
+	FROM executions
+	WHERE execution_pipeline_id = $1 AND execution_created >= $2 AND execution_created < $3
+	ORDER BY execution_created ASC`
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*execution{}
+	if err := db.SelectContext(ctx, &dst, queryListInRange, pipelineID, filter.After, filter.Before); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list executions in range")
+	}
+	return mapInternalToExecutionList(dst)
+}
+
 // Count of executions in a pipeline, if pipelineID is 0 then return total number of executions.
 func (s *executionStore) Count(ctx context.Context, pipelineID int64) (int64, error) {
 	stmt := database.Builder.
@@ -16,6 +16,7 @@ package database
 
 import (
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
 func mapInternalToExecution(in *execution) (*types.Execution, error) {
@@ -61,6 +62,10 @@ func mapInternalToExecution(in *execution) (*types.Execution, error) {
 		Created:      in.Created,
 		Updated:      in.Updated,
 		Version:      in.Version,
+
+		ConcurrencyGroup: in.Concurrency,
+		Timeout:          in.Timeout,
+		FailureReason:    enum.ExecutionFailureReason(in.Reason),
 	}, nil
 }
 
@@ -102,6 +107,10 @@ func mapExecutionToInternal(in *types.Execution) *execution {
 		Created:      in.Created,
 		Updated:      in.Updated,
 		Version:      in.Version,
+
+		Concurrency: in.ConcurrencyGroup,
+		Timeout:     in.Timeout,
+		Reason:      string(in.FailureReason),
 	}
 }
 
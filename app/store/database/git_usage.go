@@ -0,0 +1,215 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.GitUsageStore = (*GitUsageStore)(nil)
+
+// NewGitUsageStore returns a new GitUsageStore.
+func NewGitUsageStore(db *sqlx.DB) *GitUsageStore {
+	return &GitUsageStore{
+		db: db,
+	}
+}
+
+// GitUsageStore implements store.GitUsageStore backed by a relational database.
+type GitUsageStore struct {
+	db *sqlx.DB
+}
+
+// gitUsage is used to fetch git usage data from the database.
+type gitUsage struct {
+	Day           int64               `db:"git_usage_day"`
+	PrincipalID   int64               `db:"git_usage_principal_id"`
+	RepoID        int64               `db:"git_usage_repo_id"`
+	Service       enum.GitServiceType `db:"git_usage_service"`
+	Invocations   int64               `db:"git_usage_invocations"`
+	CPUTime       int64               `db:"git_usage_cpu_time"`
+	WallTime      int64               `db:"git_usage_wall_time"`
+	BytesReceived int64               `db:"git_usage_bytes_received"`
+	BytesSent     int64               `db:"git_usage_bytes_sent"`
+}
+
+const gitUsageDay = 24 * time.Hour
+
+// Record adds the usage to the usage of the principal, repository and service on the provided day.
+func (s *GitUsageStore) Record(ctx context.Context, day time.Time, usage *types.GitUsage) error {
+	const sqlQuery = `
+	INSERT INTO git_usages (
+		 git_usage_day
+		,git_usage_principal_id
+		,git_usage_repo_id
+		,git_usage_service
+		,git_usage_invocations
+		,git_usage_cpu_time
+		,git_usage_wall_time
+		,git_usage_bytes_received
+		,git_usage_bytes_sent
+	) VALUES (
+		 :git_usage_day
+		,:git_usage_principal_id
+		,:git_usage_repo_id
+		,:git_usage_service
+		,:git_usage_invocations
+		,:git_usage_cpu_time
+		,:git_usage_wall_time
+		,:git_usage_bytes_received
+		,:git_usage_bytes_sent
+	)
+	ON CONFLICT (git_usage_day, git_usage_principal_id, git_usage_repo_id, git_usage_service) DO
+	UPDATE SET
+		 git_usage_invocations = git_usages.git_usage_invocations + EXCLUDED.git_usage_invocations
+		,git_usage_cpu_time = git_usages.git_usage_cpu_time + EXCLUDED.git_usage_cpu_time
+		,git_usage_wall_time = git_usages.git_usage_wall_time + EXCLUDED.git_usage_wall_time
+		,git_usage_bytes_received = git_usages.git_usage_bytes_received + EXCLUDED.git_usage_bytes_received
+		,git_usage_bytes_sent = git_usages.git_usage_bytes_sent + EXCLUDED.git_usage_bytes_sent`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, &gitUsage{
+		Day:           day.UTC().Truncate(gitUsageDay).UnixMilli(),
+		PrincipalID:   usage.PrincipalID,
+		RepoID:        usage.RepoID,
+		Service:       usage.Service,
+		Invocations:   usage.Invocations,
+		CPUTime:       usage.CPUTime,
+		WallTime:      usage.WallTime,
+		BytesReceived: usage.BytesReceived,
+		BytesSent:     usage.BytesSent,
+	})
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind git usage object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Record git usage query failed")
+	}
+
+	return nil
+}
+
+// List returns the usage matching the filter, aggregated per principal, repository and service.
+func (s *GitUsageStore) List(ctx context.Context, filter *types.GitUsageFilter) ([]*types.GitUsage, error) {
+	stmt := database.Builder.
+		Select(
+			"git_usage_principal_id",
+			"git_usage_repo_id",
+			"git_usage_service",
+			"SUM(git_usage_invocations) AS git_usage_invocations",
+			"SUM(git_usage_cpu_time) AS git_usage_cpu_time",
+			"SUM(git_usage_wall_time) AS git_usage_wall_time",
+			"SUM(git_usage_bytes_received) AS git_usage_bytes_received",
+			"SUM(git_usage_bytes_sent) AS git_usage_bytes_sent",
+		).
+		From("git_usages").
+		GroupBy("git_usage_principal_id", "git_usage_repo_id", "git_usage_service")
+
+	if filter.Since > 0 {
+		since := time.UnixMilli(filter.Since).UTC().Truncate(gitUsageDay)
+		stmt = stmt.Where("git_usage_day >= ?", since.UnixMilli())
+	}
+
+	if filter.Until > 0 {
+		stmt = stmt.Where("git_usage_day < ?", filter.Until)
+	}
+
+	if filter.PrincipalID != 0 {
+		stmt = stmt.Where("git_usage_principal_id = ?", filter.PrincipalID)
+	}
+
+	if filter.RepoID != 0 {
+		stmt = stmt.Where("git_usage_repo_id = ?", filter.RepoID)
+	}
+
+	order := "DESC"
+	if filter.Order == enum.OrderAsc {
+		order = "ASC"
+	}
+
+	sort, _ := filter.Sort.Sanitize()
+
+	stmt = stmt.
+		OrderBy(fmt.Sprintf("git_usage_%s %s", sort, order)).
+		OrderBy("git_usage_principal_id", "git_usage_repo_id", "git_usage_service").
+		Limit(database.Limit(filter.Size)).
+		Offset(database.Offset(filter.Page, filter.Size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert git usage query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*gitUsage{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing git usage list query")
+	}
+
+	result := make([]*types.GitUsage, len(dst))
+	for i, u := range dst {
+		result[i] = &types.GitUsage{
+			PrincipalID:   u.PrincipalID,
+			RepoID:        u.RepoID,
+			Service:       u.Service,
+			Invocations:   u.Invocations,
+			CPUTime:       u.CPUTime,
+			WallTime:      u.WallTime,
+			BytesReceived: u.BytesReceived,
+			BytesSent:     u.BytesSent,
+		}
+	}
+
+	return result, nil
+}
+
+// DeleteOld removes the usage of all days that ended before the provided time.
+func (s *GitUsageStore) DeleteOld(ctx context.Context, olderThan time.Time) (int64, error) {
+	stmt := database.Builder.
+		Delete("git_usages").
+		Where("git_usage_day < ?", olderThan.Add(-gitUsageDay).UnixMilli())
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert delete git usage query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(err, "failed to execute delete git usage query")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(err, "failed to get number of deleted git usage entries")
+	}
+
+	return n, nil
+}
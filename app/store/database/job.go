@@ -64,7 +64,8 @@ const (
 		,job_recurring_cron
 		,job_consecutive_failures
 		,job_last_failure_error
-		,job_group_id`
+		,job_group_id
+		,job_space_id`
 
 	jobSelectBase = `
 	SELECT` + jobColumns + `
@@ -153,6 +154,7 @@ func (s *JobStore) Create(ctx context.Context, job *job.Job) error {
 			,:job_consecutive_failures
 			,:job_last_failure_error
 			,:job_group_id
+			,:job_space_id
 		)`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -196,6 +198,7 @@ func (s *JobStore) Upsert(ctx context.Context, job *job.Job) error {
 			,:job_consecutive_failures
 			,:job_last_failure_error
 			,:job_group_id
+			,:job_space_id
 		)
 		ON CONFLICT (job_uid) DO
 		UPDATE SET
@@ -350,50 +353,72 @@ func (s *JobStore) UpdateProgress(ctx context.Context, job *job.Job) error {
 	return nil
 }
 
-// CountRunning returns number of jobs that are currently being run.
-func (s *JobStore) CountRunning(ctx context.Context) (int, error) {
+// CountRunningPerSpace returns number of jobs that are currently being run, grouped by space ID.
+func (s *JobStore) CountRunningPerSpace(ctx context.Context) (map[int64]int, error) {
 	stmt := database.Builder.
-		Select("count(*)").
+		Select("job_space_id, count(*)").
 		From("jobs").
-		Where("job_state = ?", enum.JobStateRunning)
+		Where("job_state = ?", enum.JobStateRunning).
+		GroupBy("job_space_id")
 
 	sql, args, err := stmt.ToSql()
 	if err != nil {
-		return 0, fmt.Errorf("failed to convert count running jobs query to sql: %w", err)
+		return nil, fmt.Errorf("failed to convert count running jobs query to sql: %w", err)
 	}
 
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	var count int64
-	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
+	rows, err := db.QueryContext(ctx, sql, args...)
 	if err != nil {
-		return 0, database.ProcessSQLErrorf(err, "failed executing count running jobs query")
+		return nil, database.ProcessSQLErrorf(err, "failed executing count running jobs query")
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	result := make(map[int64]int)
+	for rows.Next() {
+		var (
+			spaceID int64
+			count   int64
+		)
+		if err = rows.Scan(&spaceID, &count); err != nil {
+			return nil, database.ProcessSQLErrorf(err, "failed to scan running jobs count")
+		}
+		result[spaceID] = int(count)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "failed to read running jobs count")
 	}
 
-	return int(count), nil
+	return result, nil
 }
 
 // ListReady returns a list of jobs that are ready for execution:
 // The jobs with state="scheduled" and scheduled time in the past.
-func (s *JobStore) ListReady(ctx context.Context, now time.Time, limit int) ([]*job.Job, error) {
-	stmt := database.Builder.
-		Select(jobColumns).
-		From("jobs").
-		Where("job_state = ?", enum.JobStateScheduled).
-		Where("job_scheduled <= ?", now.UnixMilli()).
-		OrderBy("job_priority desc, job_scheduled asc, job_uid asc").
-		Limit(uint64(limit))
-
-	sql, args, err := stmt.ToSql()
-	if err != nil {
-		return nil, fmt.Errorf("failed to convert list scheduled jobs query to sql: %w", err)
-	}
+// At most limitPerSpace jobs are returned for each space.
+func (s *JobStore) ListReady(ctx context.Context, now time.Time, limitPerSpace int) ([]*job.Job, error) {
+	const sqlQuery = `
+	SELECT` + jobColumns + `
+	FROM (
+		SELECT` + jobColumns + `
+			,ROW_NUMBER() OVER (
+				PARTITION BY job_space_id
+				ORDER BY job_priority DESC, job_scheduled ASC, job_uid ASC
+			) AS job_space_rank
+		FROM jobs
+		WHERE job_state = $1 AND job_scheduled <= $2
+	) ready_jobs
+	WHERE job_space_rank <= $3
+	ORDER BY job_priority DESC, job_scheduled ASC, job_uid ASC`
 
 	result := make([]*job.Job, 0)
 
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	if err = db.SelectContext(ctx, &result, sql, args...); err != nil {
+	err := db.SelectContext(ctx, &result, sqlQuery, enum.JobStateScheduled, now.UnixMilli(), limitPerSpace)
+	if err != nil {
 		return nil, database.ProcessSQLErrorf(err, "failed to execute list scheduled jobs query")
 	}
 
@@ -0,0 +1,172 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.MergeTemplateStore = (*MergeTemplateStore)(nil)
+
+// NewMergeTemplateStore returns a new MergeTemplateStore.
+func NewMergeTemplateStore(db *sqlx.DB) *MergeTemplateStore {
+	return &MergeTemplateStore{
+		db: db,
+	}
+}
+
+// MergeTemplateStore implements store.MergeTemplateStore backed by a relational database.
+type MergeTemplateStore struct {
+	db *sqlx.DB
+}
+
+// mergeTemplate is used to fetch merge template data from the database.
+type mergeTemplate struct {
+	RepoID  int64            `db:"merge_template_repo_id"`
+	Method  enum.MergeMethod `db:"merge_template_method"`
+	Title   string           `db:"merge_template_title"`
+	Message string           `db:"merge_template_message"`
+
+	CreatedBy int64 `db:"merge_template_created_by"`
+	Created   int64 `db:"merge_template_created"`
+	Updated   int64 `db:"merge_template_updated"`
+}
+
+const (
+	mergeTemplateColumns = `
+		 merge_template_repo_id
+		,merge_template_method
+		,merge_template_title
+		,merge_template_message
+		,merge_template_created_by
+		,merge_template_created
+		,merge_template_updated`
+
+	mergeTemplateSelectBase = `
+	SELECT` + mergeTemplateColumns + `
+	FROM merge_templates`
+)
+
+// Find finds the merge template of the repository for the merge method.
+func (s *MergeTemplateStore) Find(
+	ctx context.Context,
+	repoID int64,
+	method enum.MergeMethod,
+) (*types.MergeTemplate, error) {
+	const sqlQuery = mergeTemplateSelectBase + `
+	WHERE merge_template_repo_id = $1 AND merge_template_method = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &mergeTemplate{}
+	if err := db.GetContext(ctx, dst, sqlQuery, repoID, method); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find merge template")
+	}
+
+	return mapMergeTemplate(dst), nil
+}
+
+// Upsert creates a new or updates the existing merge template.
+func (s *MergeTemplateStore) Upsert(ctx context.Context, tmpl *types.MergeTemplate) error {
+	const sqlQuery = `
+	INSERT INTO merge_templates (
+		 merge_template_repo_id
+		,merge_template_method
+		,merge_template_title
+		,merge_template_message
+		,merge_template_created_by
+		,merge_template_created
+		,merge_template_updated
+	) VALUES (
+		 :merge_template_repo_id
+		,:merge_template_method
+		,:merge_template_title
+		,:merge_template_message
+		,:merge_template_created_by
+		,:merge_template_created
+		,:merge_template_updated
+	)
+	ON CONFLICT (merge_template_repo_id, merge_template_method) DO
+	UPDATE SET
+		 merge_template_title = :merge_template_title
+		,merge_template_message = :merge_template_message
+		,merge_template_updated = :merge_template_updated
+	RETURNING merge_template_created_by, merge_template_created`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalMergeTemplate(tmpl))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind merge template object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&tmpl.CreatedBy, &tmpl.Created); err != nil {
+		return database.ProcessSQLErrorf(err, "Upsert query failed")
+	}
+
+	return nil
+}
+
+// Delete deletes the merge template of the repository for the merge method.
+func (s *MergeTemplateStore) Delete(ctx context.Context, repoID int64, method enum.MergeMethod) error {
+	const sqlQuery = `
+	DELETE FROM merge_templates
+	WHERE merge_template_repo_id = $1 AND merge_template_method = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, repoID, method); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete merge template")
+	}
+
+	return nil
+}
+
+// List returns all merge templates of the repository.
+func (s *MergeTemplateStore) List(ctx context.Context, repoID int64) ([]*types.MergeTemplate, error) {
+	const sqlQuery = mergeTemplateSelectBase + `
+	WHERE merge_template_repo_id = $1
+	ORDER BY merge_template_method`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*mergeTemplate
+	if err := db.SelectContext(ctx, &dst, sqlQuery, repoID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list merge templates")
+	}
+
+	result := make([]*types.MergeTemplate, len(dst))
+	for i, tmpl := range dst {
+		result[i] = mapMergeTemplate(tmpl)
+	}
+
+	return result, nil
+}
+
+func mapMergeTemplate(v *mergeTemplate) *types.MergeTemplate {
+	return (*types.MergeTemplate)(v) // the two types are identical, except for the tags
+}
+
+func mapInternalMergeTemplate(v *types.MergeTemplate) *mergeTemplate {
+	return (*mergeTemplate)(v) // the two types are identical, except for the tags
+}
@@ -0,0 +1,25 @@
+CREATE TABLE pullreq_dependencies (
+ pullreq_dependency_id SERIAL PRIMARY KEY
+,pullreq_dependency_pullreq_id INTEGER NOT NULL
+,pullreq_dependency_depends_on_id INTEGER NOT NULL
+,pullreq_dependency_created_by INTEGER NOT NULL
+,pullreq_dependency_created BIGINT NOT NULL
+,CONSTRAINT fk_pullreq_dependency_pullreq_id FOREIGN KEY (pullreq_dependency_pullreq_id)
+    REFERENCES pullreqs (pullreq_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_pullreq_dependency_depends_on_id FOREIGN KEY (pullreq_dependency_depends_on_id)
+    REFERENCES pullreqs (pullreq_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_pullreq_dependency_created_by FOREIGN KEY (pullreq_dependency_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+);
+
+CREATE UNIQUE INDEX pullreq_dependencies_pullreq_id_depends_on_id
+    ON pullreq_dependencies(pullreq_dependency_pullreq_id, pullreq_dependency_depends_on_id);
+
+CREATE INDEX pullreq_dependencies_depends_on_id
+    ON pullreq_dependencies(pullreq_dependency_depends_on_id);
@@ -0,0 +1,16 @@
+CREATE TABLE repo_signing_keys (
+ repo_signing_key_repo_id INTEGER PRIMARY KEY
+,repo_signing_key_format TEXT NOT NULL
+,repo_signing_key_private_key TEXT NOT NULL
+,repo_signing_key_created_by INTEGER NOT NULL
+,repo_signing_key_created BIGINT NOT NULL
+,repo_signing_key_updated BIGINT NOT NULL
+,CONSTRAINT fk_repo_signing_key_repo_id FOREIGN KEY (repo_signing_key_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_repo_signing_key_created_by FOREIGN KEY (repo_signing_key_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+);
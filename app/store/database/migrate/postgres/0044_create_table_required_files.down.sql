@@ -0,0 +1,2 @@
+DROP TABLE repo_compliance;
+DROP TABLE required_files;
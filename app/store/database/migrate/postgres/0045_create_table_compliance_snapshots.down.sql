@@ -0,0 +1,3 @@
+DROP TRIGGER compliance_snapshots_immutable ON compliance_snapshots;
+DROP FUNCTION compliance_snapshots_immutable();
+DROP TABLE compliance_snapshots;
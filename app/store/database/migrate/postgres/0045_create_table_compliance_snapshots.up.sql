@@ -0,0 +1,23 @@
+CREATE TABLE compliance_snapshots (
+ compliance_snapshot_id SERIAL PRIMARY KEY
+,compliance_snapshot_period_start BIGINT NOT NULL
+,compliance_snapshot_period_end BIGINT NOT NULL
+,compliance_snapshot_data TEXT NOT NULL
+,compliance_snapshot_prev_hash TEXT NOT NULL
+,compliance_snapshot_hash TEXT NOT NULL
+,compliance_snapshot_created BIGINT NOT NULL
+);
+
+CREATE UNIQUE INDEX compliance_snapshots_period_start
+    ON compliance_snapshots(compliance_snapshot_period_start);
+
+CREATE OR REPLACE FUNCTION compliance_snapshots_immutable()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'compliance snapshots are immutable';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER compliance_snapshots_immutable
+BEFORE UPDATE OR DELETE ON compliance_snapshots
+FOR EACH ROW EXECUTE PROCEDURE compliance_snapshots_immutable();
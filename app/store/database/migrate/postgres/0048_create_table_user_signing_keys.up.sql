@@ -0,0 +1,25 @@
+CREATE TABLE user_signing_keys (
+ user_signing_key_id SERIAL PRIMARY KEY
+,user_signing_key_principal_id INTEGER NOT NULL
+,user_signing_key_format TEXT NOT NULL
+,user_signing_key_fingerprint TEXT NOT NULL
+,user_signing_key_public_key TEXT NOT NULL
+,user_signing_key_private_key TEXT NOT NULL
+,user_signing_key_created BIGINT NOT NULL
+,user_signing_key_rotated BIGINT
+,user_signing_key_revoked BIGINT
+,CONSTRAINT fk_user_signing_key_principal_id FOREIGN KEY (user_signing_key_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX user_signing_keys_fingerprint
+    ON user_signing_keys(user_signing_key_fingerprint);
+
+CREATE INDEX user_signing_keys_principal_id
+    ON user_signing_keys(user_signing_key_principal_id);
+
+CREATE UNIQUE INDEX user_signing_keys_principal_id_active
+    ON user_signing_keys(user_signing_key_principal_id)
+    WHERE user_signing_key_rotated IS NULL AND user_signing_key_revoked IS NULL;
@@ -0,0 +1,5 @@
+DROP INDEX pullreqs_created_by;
+DROP INDEX pullreqs_target_repo_id_updated;
+DROP INDEX pullreqs_target_repo_id_created;
+DROP INDEX pullreq_reviewers_principal_id;
+DROP TABLE pullreq_mentions;
@@ -0,0 +1,31 @@
+CREATE TABLE autolinks (
+ autolink_id SERIAL PRIMARY KEY
+,autolink_space_id INTEGER
+,autolink_repo_id INTEGER
+,autolink_pattern TEXT NOT NULL
+,autolink_target_url TEXT NOT NULL
+,autolink_description TEXT NOT NULL
+,autolink_created_by INTEGER NOT NULL
+,autolink_created BIGINT NOT NULL
+,autolink_updated BIGINT NOT NULL
+,CONSTRAINT fk_autolink_space_id FOREIGN KEY (autolink_space_id)
+    REFERENCES spaces (space_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_autolink_repo_id FOREIGN KEY (autolink_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_autolink_created_by FOREIGN KEY (autolink_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+);
+
+CREATE UNIQUE INDEX autolinks_space_id_pattern
+    ON autolinks(autolink_space_id, autolink_pattern)
+    WHERE autolink_space_id IS NOT NULL;
+
+CREATE UNIQUE INDEX autolinks_repo_id_pattern
+    ON autolinks(autolink_repo_id, autolink_pattern)
+    WHERE autolink_repo_id IS NOT NULL;
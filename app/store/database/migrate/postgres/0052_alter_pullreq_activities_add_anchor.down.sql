@@ -0,0 +1,3 @@
+ALTER TABLE pullreq_activities
+    DROP COLUMN pullreq_activity_code_comment_anchor_status,
+    DROP COLUMN pullreq_activity_code_comment_anchor;
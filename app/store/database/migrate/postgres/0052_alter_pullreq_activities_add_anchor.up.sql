@@ -0,0 +1,3 @@
+ALTER TABLE pullreq_activities
+    ADD COLUMN pullreq_activity_code_comment_anchor_status TEXT,
+    ADD COLUMN pullreq_activity_code_comment_anchor TEXT;
@@ -0,0 +1,2 @@
+DROP TABLE pullreq_watches;
+DROP TABLE repo_watches;
@@ -0,0 +1,25 @@
+CREATE TABLE approvals (
+ approval_id SERIAL PRIMARY KEY
+,approval_stage_id INTEGER NOT NULL
+,approval_approvers TEXT NOT NULL
+,approval_timeout BIGINT NOT NULL
+,approval_state TEXT NOT NULL
+,approval_decided_by INTEGER
+,approval_decided BIGINT NOT NULL
+,approval_comment TEXT NOT NULL
+,approval_created BIGINT NOT NULL
+,approval_updated BIGINT NOT NULL
+,approval_version INTEGER NOT NULL
+,UNIQUE (approval_stage_id)
+,CONSTRAINT fk_approval_stage_id FOREIGN KEY (approval_stage_id)
+    REFERENCES stages (stage_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_approval_decided_by FOREIGN KEY (approval_decided_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE SET NULL
+);
+
+CREATE INDEX approvals_state
+    ON approvals(approval_state);
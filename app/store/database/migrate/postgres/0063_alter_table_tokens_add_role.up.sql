@@ -0,0 +1 @@
+ALTER TABLE tokens ADD COLUMN token_role TEXT NOT NULL DEFAULT '';
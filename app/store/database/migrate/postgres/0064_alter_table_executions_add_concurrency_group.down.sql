@@ -0,0 +1,3 @@
+DROP INDEX ix_execution_in_progress;
+
+ALTER TABLE executions DROP COLUMN execution_concurrency_group;
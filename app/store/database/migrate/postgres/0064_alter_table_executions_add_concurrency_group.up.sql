@@ -0,0 +1,4 @@
+ALTER TABLE executions ADD COLUMN execution_concurrency_group TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX ix_execution_in_progress ON executions (execution_status)
+WHERE execution_status IN ('waiting_on_dependencies', 'pending', 'running', 'blocked');
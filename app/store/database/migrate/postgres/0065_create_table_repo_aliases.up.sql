@@ -0,0 +1,26 @@
+CREATE TABLE repo_aliases (
+ repo_alias_id SERIAL PRIMARY KEY
+,repo_alias_repo_id INTEGER NOT NULL
+,repo_alias_space_id INTEGER NOT NULL
+,repo_alias_uid TEXT NOT NULL
+,repo_alias_created_by INTEGER NOT NULL
+,repo_alias_created BIGINT NOT NULL
+,CONSTRAINT fk_repo_alias_repo_id FOREIGN KEY (repo_alias_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_repo_alias_space_id FOREIGN KEY (repo_alias_space_id)
+    REFERENCES spaces (space_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_repo_alias_created_by FOREIGN KEY (repo_alias_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+);
+
+CREATE UNIQUE INDEX repo_aliases_space_id_uid
+    ON repo_aliases(repo_alias_space_id, LOWER(repo_alias_uid));
+
+CREATE INDEX repo_aliases_repo_id
+    ON repo_aliases(repo_alias_repo_id);
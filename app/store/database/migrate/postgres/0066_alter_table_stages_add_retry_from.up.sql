@@ -0,0 +1 @@
+ALTER TABLE stages ADD COLUMN stage_retry_from TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,38 @@
+CREATE TABLE passkeys (
+ passkey_id SERIAL PRIMARY KEY
+,passkey_principal_id INTEGER NOT NULL
+,passkey_name TEXT NOT NULL
+,passkey_credential_id TEXT NOT NULL
+,passkey_public_key TEXT NOT NULL
+,passkey_attestation_type TEXT NOT NULL
+,passkey_aaguid TEXT NOT NULL
+,passkey_sign_count BIGINT NOT NULL
+,passkey_created BIGINT NOT NULL
+,passkey_last_used BIGINT
+,CONSTRAINT fk_passkey_principal_id FOREIGN KEY (passkey_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX passkeys_credential_id
+    ON passkeys(passkey_credential_id);
+
+CREATE INDEX passkeys_principal_id
+    ON passkeys(passkey_principal_id);
+
+CREATE TABLE passkey_challenges (
+ passkey_challenge_uid TEXT PRIMARY KEY
+,passkey_challenge_principal_id INTEGER NOT NULL
+,passkey_challenge_kind TEXT NOT NULL
+,passkey_challenge_session TEXT NOT NULL
+,passkey_challenge_created BIGINT NOT NULL
+,passkey_challenge_expires BIGINT NOT NULL
+,CONSTRAINT fk_passkey_challenge_principal_id FOREIGN KEY (passkey_challenge_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX passkey_challenges_expires
+    ON passkey_challenges(passkey_challenge_expires);
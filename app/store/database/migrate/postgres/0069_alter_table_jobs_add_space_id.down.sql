@@ -0,0 +1 @@
+ALTER TABLE jobs DROP COLUMN job_space_id;
@@ -0,0 +1,19 @@
+CREATE TABLE runners (
+ runner_id SERIAL PRIMARY KEY
+,runner_uid TEXT NOT NULL
+,runner_labels TEXT NOT NULL
+,runner_os TEXT NOT NULL
+,runner_arch TEXT NOT NULL
+,runner_version TEXT NOT NULL
+,runner_state TEXT NOT NULL
+,runner_token_hash TEXT NOT NULL
+,runner_last_heartbeat BIGINT NOT NULL
+,runner_created BIGINT NOT NULL
+,runner_updated BIGINT NOT NULL
+);
+
+CREATE UNIQUE INDEX runners_uid
+    ON runners(LOWER(runner_uid));
+
+CREATE UNIQUE INDEX runners_token_hash
+    ON runners(runner_token_hash);
@@ -0,0 +1,2 @@
+DROP TABLE variable_audits;
+DROP TABLE variables;
@@ -0,0 +1,57 @@
+CREATE TABLE variables (
+ variable_id SERIAL PRIMARY KEY
+,variable_version INTEGER NOT NULL
+,variable_space_id INTEGER
+,variable_repo_id INTEGER
+,variable_key TEXT NOT NULL
+,variable_value TEXT NOT NULL
+,variable_secret_uid TEXT NOT NULL
+,variable_created_by INTEGER NOT NULL
+,variable_updated_by INTEGER NOT NULL
+,variable_created BIGINT NOT NULL
+,variable_updated BIGINT NOT NULL
+,CONSTRAINT fk_variable_space_id FOREIGN KEY (variable_space_id)
+    REFERENCES spaces (space_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_variable_repo_id FOREIGN KEY (variable_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX variables_space_id_key
+    ON variables(variable_space_id, variable_key)
+    WHERE variable_space_id IS NOT NULL;
+
+CREATE UNIQUE INDEX variables_repo_id_key
+    ON variables(variable_repo_id, variable_key)
+    WHERE variable_repo_id IS NOT NULL;
+
+CREATE TABLE variable_audits (
+ variable_audit_id SERIAL PRIMARY KEY
+,variable_audit_space_id INTEGER
+,variable_audit_repo_id INTEGER
+,variable_audit_key TEXT NOT NULL
+,variable_audit_action TEXT NOT NULL
+,variable_audit_value TEXT NOT NULL
+,variable_audit_secret_uid TEXT NOT NULL
+,variable_audit_principal_id INTEGER NOT NULL
+,variable_audit_created BIGINT NOT NULL
+,CONSTRAINT fk_variable_audit_space_id FOREIGN KEY (variable_audit_space_id)
+    REFERENCES spaces (space_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_variable_audit_repo_id FOREIGN KEY (variable_audit_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX variable_audits_space_id_created
+    ON variable_audits(variable_audit_space_id, variable_audit_created)
+    WHERE variable_audit_space_id IS NOT NULL;
+
+CREATE INDEX variable_audits_repo_id_created
+    ON variable_audits(variable_audit_repo_id, variable_audit_created)
+    WHERE variable_audit_repo_id IS NOT NULL;
@@ -0,0 +1,14 @@
+CREATE TABLE user_working_hours (
+ working_hours_principal_id INTEGER NOT NULL
+,working_hours_timezone TEXT NOT NULL
+,working_hours_days TEXT NOT NULL
+,working_hours_start TEXT NOT NULL
+,working_hours_end TEXT NOT NULL
+,working_hours_created BIGINT NOT NULL
+,working_hours_updated BIGINT NOT NULL
+,CONSTRAINT pk_user_working_hours PRIMARY KEY (working_hours_principal_id)
+,CONSTRAINT fk_working_hours_principal_id FOREIGN KEY (working_hours_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
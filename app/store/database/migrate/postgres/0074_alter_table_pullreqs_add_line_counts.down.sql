@@ -0,0 +1,3 @@
+ALTER TABLE pullreqs
+    DROP COLUMN pullreq_deletions,
+    DROP COLUMN pullreq_additions;
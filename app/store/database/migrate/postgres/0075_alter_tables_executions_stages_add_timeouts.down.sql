@@ -0,0 +1,7 @@
+ALTER TABLE stages
+    DROP COLUMN stage_step_timeouts,
+    DROP COLUMN stage_timeout;
+
+ALTER TABLE executions
+    DROP COLUMN execution_failure_reason,
+    DROP COLUMN execution_timeout;
@@ -0,0 +1,7 @@
+ALTER TABLE executions
+    ADD COLUMN execution_timeout BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN execution_failure_reason TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE stages
+    ADD COLUMN stage_timeout BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN stage_step_timeouts TEXT NOT NULL DEFAULT '{}';
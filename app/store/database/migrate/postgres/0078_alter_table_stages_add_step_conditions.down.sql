@@ -0,0 +1 @@
+ALTER TABLE stages DROP COLUMN stage_step_conditions;
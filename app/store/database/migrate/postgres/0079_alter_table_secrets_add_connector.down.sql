@@ -0,0 +1,3 @@
+ALTER TABLE secrets
+    DROP COLUMN secret_connector,
+    DROP COLUMN secret_reference;
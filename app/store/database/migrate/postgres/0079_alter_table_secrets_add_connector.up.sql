@@ -0,0 +1,3 @@
+ALTER TABLE secrets
+    ADD COLUMN secret_connector TEXT NOT NULL DEFAULT '',
+    ADD COLUMN secret_reference TEXT NOT NULL DEFAULT '';
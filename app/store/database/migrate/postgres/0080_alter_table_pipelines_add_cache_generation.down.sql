@@ -0,0 +1 @@
+ALTER TABLE pipelines DROP COLUMN pipeline_cache_generation;
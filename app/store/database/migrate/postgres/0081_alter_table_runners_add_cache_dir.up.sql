@@ -0,0 +1 @@
+ALTER TABLE runners ADD COLUMN runner_cache_dir TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+DROP INDEX pullreqs_lower_title_trgm;
+DROP INDEX pullreqs_lower_description_trgm;
@@ -0,0 +1,5 @@
+CREATE INDEX pullreqs_lower_title_trgm
+ON pullreqs USING gin (LOWER(pullreq_title) gin_trgm_ops);
+
+CREATE INDEX pullreqs_lower_description_trgm
+ON pullreqs USING gin (LOWER(pullreq_description) gin_trgm_ops);
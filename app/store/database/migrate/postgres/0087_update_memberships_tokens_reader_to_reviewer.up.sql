@@ -0,0 +1,2 @@
+UPDATE memberships SET membership_role = 'reviewer' WHERE membership_role = 'reader';
+UPDATE tokens SET token_role = 'reviewer' WHERE token_role = 'reader';
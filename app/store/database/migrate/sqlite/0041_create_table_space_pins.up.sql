@@ -0,0 +1,29 @@
+CREATE TABLE space_pins (
+ space_pin_id INTEGER PRIMARY KEY AUTOINCREMENT
+,space_pin_space_id INTEGER NOT NULL
+,space_pin_type TEXT NOT NULL
+,space_pin_repo_id INTEGER NOT NULL
+,space_pin_number INTEGER NOT NULL
+,space_pin_tag TEXT NOT NULL
+,space_pin_title TEXT NOT NULL
+,space_pin_position INTEGER NOT NULL
+,space_pin_audience TEXT NOT NULL
+,space_pin_created_by INTEGER NOT NULL
+,space_pin_created BIGINT NOT NULL
+,space_pin_updated BIGINT NOT NULL
+,CONSTRAINT fk_space_pin_space_id FOREIGN KEY (space_pin_space_id)
+    REFERENCES spaces (space_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_space_pin_repo_id FOREIGN KEY (space_pin_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_space_pin_created_by FOREIGN KEY (space_pin_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+);
+
+CREATE UNIQUE INDEX space_pins_space_id_type_repo_id_number_tag
+    ON space_pins(space_pin_space_id, space_pin_type, space_pin_repo_id, space_pin_number, space_pin_tag);
@@ -0,0 +1 @@
+DROP TABLE merge_templates;
@@ -0,0 +1,18 @@
+CREATE TABLE merge_templates (
+ merge_template_repo_id INTEGER NOT NULL
+,merge_template_method TEXT NOT NULL
+,merge_template_title TEXT NOT NULL
+,merge_template_message TEXT NOT NULL
+,merge_template_created_by INTEGER NOT NULL
+,merge_template_created BIGINT NOT NULL
+,merge_template_updated BIGINT NOT NULL
+,CONSTRAINT pk_merge_templates PRIMARY KEY (merge_template_repo_id, merge_template_method)
+,CONSTRAINT fk_merge_template_repo_id FOREIGN KEY (merge_template_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_merge_template_created_by FOREIGN KEY (merge_template_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+);
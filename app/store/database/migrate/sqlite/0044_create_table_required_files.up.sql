@@ -0,0 +1,31 @@
+CREATE TABLE required_files (
+ required_file_id INTEGER PRIMARY KEY AUTOINCREMENT
+,required_file_space_id INTEGER NOT NULL
+,required_file_path TEXT NOT NULL
+,required_file_template TEXT NOT NULL
+,required_file_create_pullreq BOOLEAN NOT NULL
+,required_file_created_by INTEGER NOT NULL
+,required_file_created BIGINT NOT NULL
+,required_file_updated BIGINT NOT NULL
+,CONSTRAINT fk_required_file_space_id FOREIGN KEY (required_file_space_id)
+    REFERENCES spaces (space_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_required_file_created_by FOREIGN KEY (required_file_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+);
+
+CREATE UNIQUE INDEX required_files_space_id_path
+    ON required_files(required_file_space_id, LOWER(required_file_path));
+
+CREATE TABLE repo_compliance (
+ repo_compliance_repo_id INTEGER PRIMARY KEY
+,repo_compliance_missing_files TEXT NOT NULL
+,repo_compliance_checked BIGINT NOT NULL
+,CONSTRAINT fk_repo_compliance_repo_id FOREIGN KEY (repo_compliance_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
@@ -0,0 +1,3 @@
+DROP TRIGGER compliance_snapshots_immutable_delete;
+DROP TRIGGER compliance_snapshots_immutable_update;
+DROP TABLE compliance_snapshots;
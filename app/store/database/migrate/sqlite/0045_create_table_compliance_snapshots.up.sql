@@ -0,0 +1,24 @@
+CREATE TABLE compliance_snapshots (
+ compliance_snapshot_id INTEGER PRIMARY KEY AUTOINCREMENT
+,compliance_snapshot_period_start BIGINT NOT NULL
+,compliance_snapshot_period_end BIGINT NOT NULL
+,compliance_snapshot_data TEXT NOT NULL
+,compliance_snapshot_prev_hash TEXT NOT NULL
+,compliance_snapshot_hash TEXT NOT NULL
+,compliance_snapshot_created BIGINT NOT NULL
+);
+
+CREATE UNIQUE INDEX compliance_snapshots_period_start
+    ON compliance_snapshots(compliance_snapshot_period_start);
+
+CREATE TRIGGER compliance_snapshots_immutable_update
+BEFORE UPDATE ON compliance_snapshots
+BEGIN
+    SELECT RAISE(ABORT, 'compliance snapshots are immutable');
+END;
+
+CREATE TRIGGER compliance_snapshots_immutable_delete
+BEFORE DELETE ON compliance_snapshots
+BEGIN
+    SELECT RAISE(ABORT, 'compliance snapshots are immutable');
+END;
@@ -0,0 +1,9 @@
+CREATE TABLE token_spaces (
+ token_space_token_id INTEGER NOT NULL
+,token_space_space_id INTEGER NOT NULL
+,CONSTRAINT pk_token_spaces PRIMARY KEY (token_space_token_id, token_space_space_id)
+,CONSTRAINT fk_token_space_token_id FOREIGN KEY (token_space_token_id)
+    REFERENCES tokens (token_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
@@ -0,0 +1,29 @@
+CREATE TABLE pullreq_mentions (
+ pullreq_mention_pullreq_id INTEGER NOT NULL
+,pullreq_mention_principal_id INTEGER NOT NULL
+,pullreq_mention_created BIGINT NOT NULL
+,CONSTRAINT pk_pullreq_mentions PRIMARY KEY (pullreq_mention_pullreq_id, pullreq_mention_principal_id)
+,CONSTRAINT fk_pullreq_mention_pullreq_id FOREIGN KEY (pullreq_mention_pullreq_id)
+    REFERENCES pullreqs (pullreq_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_pullreq_mention_principal_id FOREIGN KEY (pullreq_mention_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX pullreq_mentions_principal_id
+    ON pullreq_mentions(pullreq_mention_principal_id);
+
+CREATE INDEX pullreq_reviewers_principal_id
+    ON pullreq_reviewers(pullreq_reviewer_principal_id);
+
+CREATE INDEX pullreqs_target_repo_id_created
+    ON pullreqs(pullreq_target_repo_id, pullreq_created);
+
+CREATE INDEX pullreqs_target_repo_id_updated
+    ON pullreqs(pullreq_target_repo_id, pullreq_updated);
+
+CREATE INDEX pullreqs_created_by
+    ON pullreqs(pullreq_created_by);
@@ -0,0 +1,32 @@
+CREATE TABLE commit_comments (
+ commit_comment_id INTEGER PRIMARY KEY AUTOINCREMENT
+,commit_comment_repo_id INTEGER NOT NULL
+,commit_comment_commit_sha TEXT NOT NULL
+,commit_comment_parent_id INTEGER
+,commit_comment_created_by INTEGER NOT NULL
+,commit_comment_created BIGINT NOT NULL
+,commit_comment_updated BIGINT NOT NULL
+,commit_comment_edited BIGINT NOT NULL
+,commit_comment_deleted BIGINT
+,commit_comment_text TEXT NOT NULL
+,commit_comment_path TEXT NOT NULL
+,commit_comment_line_start INTEGER NOT NULL
+,commit_comment_line_start_new BOOLEAN NOT NULL
+,commit_comment_line_end INTEGER NOT NULL
+,commit_comment_line_end_new BOOLEAN NOT NULL
+,CONSTRAINT fk_commit_comment_repo_id FOREIGN KEY (commit_comment_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_commit_comment_parent_id FOREIGN KEY (commit_comment_parent_id)
+    REFERENCES commit_comments (commit_comment_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_commit_comment_created_by FOREIGN KEY (commit_comment_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+);
+
+CREATE INDEX commit_comments_repo_id_commit_sha
+    ON commit_comments(commit_comment_repo_id, commit_comment_commit_sha);
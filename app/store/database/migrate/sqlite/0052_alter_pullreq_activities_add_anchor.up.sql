@@ -0,0 +1,2 @@
+ALTER TABLE pullreq_activities ADD COLUMN pullreq_activity_code_comment_anchor_status TEXT;
+ALTER TABLE pullreq_activities ADD COLUMN pullreq_activity_code_comment_anchor TEXT;
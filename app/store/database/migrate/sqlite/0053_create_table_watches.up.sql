@@ -0,0 +1,33 @@
+CREATE TABLE repo_watches (
+ repo_watch_repo_id INTEGER NOT NULL
+,repo_watch_principal_id INTEGER NOT NULL
+,repo_watch_level TEXT NOT NULL
+,repo_watch_created BIGINT NOT NULL
+,repo_watch_updated BIGINT NOT NULL
+,CONSTRAINT pk_repo_watches PRIMARY KEY (repo_watch_repo_id, repo_watch_principal_id)
+,CONSTRAINT fk_repo_watch_repo_id FOREIGN KEY (repo_watch_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_repo_watch_principal_id FOREIGN KEY (repo_watch_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE TABLE pullreq_watches (
+ pullreq_watch_pullreq_id INTEGER NOT NULL
+,pullreq_watch_principal_id INTEGER NOT NULL
+,pullreq_watch_level TEXT NOT NULL
+,pullreq_watch_created BIGINT NOT NULL
+,pullreq_watch_updated BIGINT NOT NULL
+,CONSTRAINT pk_pullreq_watches PRIMARY KEY (pullreq_watch_pullreq_id, pullreq_watch_principal_id)
+,CONSTRAINT fk_pullreq_watch_pullreq_id FOREIGN KEY (pullreq_watch_pullreq_id)
+    REFERENCES pullreqs (pullreq_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_pullreq_watch_principal_id FOREIGN KEY (pullreq_watch_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
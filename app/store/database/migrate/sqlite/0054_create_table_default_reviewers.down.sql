@@ -0,0 +1 @@
+DROP TABLE default_reviewers;
@@ -0,0 +1,25 @@
+CREATE TABLE default_reviewers (
+ default_reviewer_id INTEGER PRIMARY KEY AUTOINCREMENT
+,default_reviewer_repo_id INTEGER NOT NULL
+,default_reviewer_principal_id INTEGER
+,default_reviewer_user_group_id TEXT NOT NULL
+,default_reviewer_target_branches TEXT NOT NULL
+,default_reviewer_created_by INTEGER NOT NULL
+,default_reviewer_created BIGINT NOT NULL
+,default_reviewer_updated BIGINT NOT NULL
+,CONSTRAINT fk_default_reviewer_repo_id FOREIGN KEY (default_reviewer_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_default_reviewer_principal_id FOREIGN KEY (default_reviewer_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_default_reviewer_created_by FOREIGN KEY (default_reviewer_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+);
+
+CREATE INDEX default_reviewers_repo_id
+    ON default_reviewers(default_reviewer_repo_id);
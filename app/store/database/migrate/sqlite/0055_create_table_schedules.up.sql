@@ -0,0 +1,33 @@
+CREATE TABLE schedules (
+ schedule_id INTEGER PRIMARY KEY AUTOINCREMENT
+,schedule_uid TEXT NOT NULL
+,schedule_pipeline_id INTEGER NOT NULL
+,schedule_repo_id INTEGER NOT NULL
+,schedule_description TEXT NOT NULL
+,schedule_cron TEXT NOT NULL
+,schedule_branch TEXT NOT NULL
+,schedule_disabled BOOLEAN NOT NULL
+,schedule_last_run BIGINT NOT NULL
+,schedule_next_run BIGINT NOT NULL
+,schedule_created_by INTEGER NOT NULL
+,schedule_created BIGINT NOT NULL
+,schedule_updated BIGINT NOT NULL
+,schedule_version INTEGER NOT NULL
+,UNIQUE (schedule_pipeline_id, schedule_uid)
+,CONSTRAINT fk_schedule_pipeline_id FOREIGN KEY (schedule_pipeline_id)
+    REFERENCES pipelines (pipeline_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_schedule_repo_id FOREIGN KEY (schedule_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_schedule_created_by FOREIGN KEY (schedule_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+);
+
+CREATE INDEX schedules_next_run
+    ON schedules(schedule_next_run)
+    WHERE schedule_disabled = FALSE;
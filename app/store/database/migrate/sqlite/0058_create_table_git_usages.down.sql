@@ -0,0 +1 @@
+DROP TABLE git_usages;
@@ -0,0 +1,19 @@
+CREATE TABLE git_usages (
+ git_usage_day INTEGER NOT NULL
+,git_usage_principal_id INTEGER NOT NULL
+,git_usage_repo_id INTEGER NOT NULL
+,git_usage_service TEXT NOT NULL
+,git_usage_invocations INTEGER NOT NULL
+,git_usage_cpu_time INTEGER NOT NULL
+,git_usage_wall_time INTEGER NOT NULL
+,git_usage_bytes_received INTEGER NOT NULL
+,git_usage_bytes_sent INTEGER NOT NULL
+,CONSTRAINT pk_git_usages PRIMARY KEY (git_usage_day, git_usage_principal_id, git_usage_repo_id, git_usage_service)
+,CONSTRAINT fk_git_usage_repo_id FOREIGN KEY (git_usage_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX git_usages_repo_id
+    ON git_usages(git_usage_repo_id);
@@ -0,0 +1 @@
+ALTER TABLE repositories ADD COLUMN repo_metadata TEXT NOT NULL DEFAULT '{}';
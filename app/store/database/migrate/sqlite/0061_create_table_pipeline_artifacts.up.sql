@@ -0,0 +1,12 @@
+CREATE TABLE pipeline_artifacts (
+ pipeline_artifact_id INTEGER PRIMARY KEY AUTOINCREMENT
+,pipeline_artifact_execution_id INTEGER NOT NULL
+,pipeline_artifact_name TEXT NOT NULL
+,pipeline_artifact_size BIGINT NOT NULL
+,pipeline_artifact_created_by INTEGER NOT NULL
+,pipeline_artifact_created BIGINT NOT NULL
+,UNIQUE (pipeline_artifact_execution_id, pipeline_artifact_name)
+);
+
+CREATE INDEX pipeline_artifacts_created
+    ON pipeline_artifacts(pipeline_artifact_created);
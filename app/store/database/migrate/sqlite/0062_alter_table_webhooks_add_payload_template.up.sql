@@ -0,0 +1,2 @@
+ALTER TABLE webhooks ADD COLUMN webhook_payload_template_type TEXT NOT NULL DEFAULT '';
+ALTER TABLE webhooks ADD COLUMN webhook_payload_template TEXT NOT NULL DEFAULT '';
@@ -0,0 +1 @@
+ALTER TABLE schedules ADD COLUMN schedule_timezone TEXT NOT NULL DEFAULT '';
@@ -0,0 +1 @@
+ALTER TABLE jobs ADD COLUMN job_space_id INTEGER NOT NULL DEFAULT 0;
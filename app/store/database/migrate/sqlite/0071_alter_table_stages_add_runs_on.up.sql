@@ -0,0 +1 @@
+ALTER TABLE stages ADD COLUMN stage_runs_on TEXT NOT NULL DEFAULT '[]';
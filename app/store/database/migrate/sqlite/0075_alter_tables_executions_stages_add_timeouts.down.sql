@@ -0,0 +1,5 @@
+ALTER TABLE stages DROP COLUMN stage_step_timeouts;
+ALTER TABLE stages DROP COLUMN stage_timeout;
+
+ALTER TABLE executions DROP COLUMN execution_failure_reason;
+ALTER TABLE executions DROP COLUMN execution_timeout;
@@ -0,0 +1,22 @@
+CREATE TABLE saved_comparisons (
+ saved_comparison_id INTEGER PRIMARY KEY AUTOINCREMENT
+,saved_comparison_repo_id INTEGER NOT NULL
+,saved_comparison_uid TEXT NOT NULL
+,saved_comparison_description TEXT NOT NULL
+,saved_comparison_base_ref TEXT NOT NULL
+,saved_comparison_head_ref TEXT NOT NULL
+,saved_comparison_created_by INTEGER NOT NULL
+,saved_comparison_created BIGINT NOT NULL
+,saved_comparison_updated BIGINT NOT NULL
+,CONSTRAINT fk_saved_comparison_repo_id FOREIGN KEY (saved_comparison_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_saved_comparison_created_by FOREIGN KEY (saved_comparison_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+);
+
+CREATE UNIQUE INDEX saved_comparisons_repo_id_uid
+    ON saved_comparisons(saved_comparison_repo_id, LOWER(saved_comparison_uid));
@@ -0,0 +1 @@
+ALTER TABLE stages ADD COLUMN stage_step_conditions TEXT NOT NULL DEFAULT '{}';
@@ -0,0 +1,2 @@
+ALTER TABLE secrets DROP COLUMN secret_connector;
+ALTER TABLE secrets DROP COLUMN secret_reference;
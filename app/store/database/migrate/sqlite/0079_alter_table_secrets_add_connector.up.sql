@@ -0,0 +1,2 @@
+ALTER TABLE secrets ADD COLUMN secret_connector TEXT NOT NULL DEFAULT '';
+ALTER TABLE secrets ADD COLUMN secret_reference TEXT NOT NULL DEFAULT '';
@@ -0,0 +1 @@
+ALTER TABLE pipelines ADD COLUMN pipeline_cache_generation INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,19 @@
+CREATE TABLE repository_stats (
+ repo_stats_repo_id INTEGER PRIMARY KEY
+,repo_stats_disk_size BIGINT NOT NULL
+,repo_stats_loose_objects INTEGER NOT NULL
+,repo_stats_loose_size BIGINT NOT NULL
+,repo_stats_packed_objects INTEGER NOT NULL
+,repo_stats_packs INTEGER NOT NULL
+,repo_stats_pack_size BIGINT NOT NULL
+,repo_stats_blobs INTEGER NOT NULL
+,repo_stats_blobs_size BIGINT NOT NULL
+,repo_stats_largest_blobs TEXT NOT NULL
+,repo_stats_lfs_objects INTEGER NOT NULL
+,repo_stats_lfs_size BIGINT NOT NULL
+,repo_stats_updated BIGINT NOT NULL
+,CONSTRAINT fk_repo_stats_repo_id FOREIGN KEY (repo_stats_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
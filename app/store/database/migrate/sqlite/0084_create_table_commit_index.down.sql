@@ -0,0 +1,2 @@
+DROP TABLE commit_index_states;
+DROP TABLE commit_index;
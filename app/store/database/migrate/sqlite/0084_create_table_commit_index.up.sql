@@ -0,0 +1,30 @@
+CREATE TABLE commit_index (
+ commit_index_repo_id INTEGER NOT NULL
+,commit_index_sha TEXT NOT NULL
+,commit_index_title TEXT NOT NULL
+,commit_index_message TEXT NOT NULL
+,commit_index_author_name TEXT NOT NULL
+,commit_index_author_email TEXT NOT NULL
+,commit_index_author_date BIGINT NOT NULL
+,commit_index_committer_name TEXT NOT NULL
+,commit_index_committer_email TEXT NOT NULL
+,commit_index_committer_date BIGINT NOT NULL
+,CONSTRAINT pk_commit_index PRIMARY KEY (commit_index_repo_id, commit_index_sha)
+,CONSTRAINT fk_commit_index_repo_id FOREIGN KEY (commit_index_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX commit_index_repo_id_committer_date
+    ON commit_index(commit_index_repo_id, commit_index_committer_date);
+
+CREATE TABLE commit_index_states (
+ commit_index_state_repo_id INTEGER PRIMARY KEY
+,commit_index_state_commit_sha TEXT NOT NULL
+,commit_index_state_updated BIGINT NOT NULL
+,CONSTRAINT fk_commit_index_state_repo_id FOREIGN KEY (commit_index_state_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
@@ -0,0 +1,30 @@
+CREATE TABLE repository_mirrors (
+ mirror_repo_id INTEGER PRIMARY KEY
+,mirror_remote_url TEXT NOT NULL
+,mirror_username TEXT NOT NULL
+,mirror_password TEXT NOT NULL
+,mirror_sync_interval INTEGER NOT NULL
+,mirror_disabled BOOLEAN NOT NULL
+,mirror_sync_state TEXT NOT NULL
+,mirror_sync_error TEXT NOT NULL
+,mirror_last_sync_started BIGINT NOT NULL
+,mirror_last_sync_finished BIGINT NOT NULL
+,mirror_last_synced BIGINT NOT NULL
+,mirror_next_sync BIGINT NOT NULL
+,mirror_created_by INTEGER NOT NULL
+,mirror_created BIGINT NOT NULL
+,mirror_updated BIGINT NOT NULL
+,mirror_version INTEGER NOT NULL
+,CONSTRAINT fk_mirror_repo_id FOREIGN KEY (mirror_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_mirror_created_by FOREIGN KEY (mirror_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+);
+
+CREATE INDEX repository_mirrors_next_sync
+    ON repository_mirrors(mirror_next_sync)
+    WHERE mirror_disabled = FALSE;
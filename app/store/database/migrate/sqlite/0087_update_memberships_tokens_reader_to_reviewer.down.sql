@@ -0,0 +1,2 @@
+-- Can't migrate down from this point: readers and reviewers can't be told apart anymore.
+-- This file must be present here.
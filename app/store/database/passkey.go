@@ -0,0 +1,373 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.PasskeyStore = (*PasskeyStore)(nil)
+
+// NewPasskeyStore returns a new PasskeyStore.
+func NewPasskeyStore(db *sqlx.DB) *PasskeyStore {
+	return &PasskeyStore{
+		db: db,
+	}
+}
+
+// PasskeyStore implements store.PasskeyStore backed by a relational database.
+type PasskeyStore struct {
+	db *sqlx.DB
+}
+
+// passkey is used to fetch passkey data from the database.
+// Binary values are stored base64url encoded.
+type passkey struct {
+	ID              int64  `db:"passkey_id"`
+	PrincipalID     int64  `db:"passkey_principal_id"`
+	Name            string `db:"passkey_name"`
+	CredentialID    string `db:"passkey_credential_id"`
+	PublicKey       string `db:"passkey_public_key"`
+	AttestationType string `db:"passkey_attestation_type"`
+	AAGUID          string `db:"passkey_aaguid"`
+	SignCount       int64  `db:"passkey_sign_count"`
+	Created         int64  `db:"passkey_created"`
+	LastUsed        *int64 `db:"passkey_last_used"`
+}
+
+const (
+	passkeyColumns = `
+		 passkey_id
+		,passkey_principal_id
+		,passkey_name
+		,passkey_credential_id
+		,passkey_public_key
+		,passkey_attestation_type
+		,passkey_aaguid
+		,passkey_sign_count
+		,passkey_created
+		,passkey_last_used`
+
+	passkeySelectBase = `
+	SELECT` + passkeyColumns + `
+	FROM passkeys`
+)
+
+// Find finds the passkey by id.
+func (s *PasskeyStore) Find(ctx context.Context, id int64) (*types.Passkey, error) {
+	const sqlQuery = passkeySelectBase + `
+	WHERE passkey_id = $1`
+
+	return s.find(ctx, sqlQuery, id)
+}
+
+// FindByCredentialID finds the passkey by its WebAuthn credential ID.
+func (s *PasskeyStore) FindByCredentialID(ctx context.Context, credentialID []byte) (*types.Passkey, error) {
+	const sqlQuery = passkeySelectBase + `
+	WHERE passkey_credential_id = $1`
+
+	return s.find(ctx, sqlQuery, encodePasskeyBytes(credentialID))
+}
+
+func (s *PasskeyStore) find(ctx context.Context, sqlQuery string, arg any) (*types.Passkey, error) {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &passkey{}
+	if err := db.GetContext(ctx, dst, sqlQuery, arg); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find passkey")
+	}
+
+	return mapPasskey(dst)
+}
+
+// List returns all passkeys of the principal, the oldest first.
+func (s *PasskeyStore) List(ctx context.Context, principalID int64) ([]*types.Passkey, error) {
+	const sqlQuery = passkeySelectBase + `
+	WHERE passkey_principal_id = $1
+	ORDER BY passkey_created, passkey_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*passkey{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list passkeys")
+	}
+
+	passkeys := make([]*types.Passkey, len(dst))
+	for i := range dst {
+		var err error
+		if passkeys[i], err = mapPasskey(dst[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return passkeys, nil
+}
+
+// Count returns the number of passkeys of the principal.
+func (s *PasskeyStore) Count(ctx context.Context, principalID int64) (int64, error) {
+	const sqlQuery = `
+	SELECT COUNT(*)
+	FROM passkeys
+	WHERE passkey_principal_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err := db.QueryRowContext(ctx, sqlQuery, principalID).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(err, "Failed to count passkeys")
+	}
+
+	return count, nil
+}
+
+// Create creates a new passkey.
+func (s *PasskeyStore) Create(ctx context.Context, key *types.Passkey) error {
+	const sqlQuery = `
+	INSERT INTO passkeys (
+		 passkey_principal_id
+		,passkey_name
+		,passkey_credential_id
+		,passkey_public_key
+		,passkey_attestation_type
+		,passkey_aaguid
+		,passkey_sign_count
+		,passkey_created
+		,passkey_last_used
+	) VALUES (
+		 :passkey_principal_id
+		,:passkey_name
+		,:passkey_credential_id
+		,:passkey_public_key
+		,:passkey_attestation_type
+		,:passkey_aaguid
+		,:passkey_sign_count
+		,:passkey_created
+		,:passkey_last_used
+	)
+	RETURNING passkey_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalPasskey(key))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind passkey object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&key.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// UpdateUsage updates the signature counter and the last used time of the passkey.
+func (s *PasskeyStore) UpdateUsage(ctx context.Context, key *types.Passkey) error {
+	const sqlQuery = `
+	UPDATE passkeys
+	SET
+		 passkey_sign_count = :passkey_sign_count
+		,passkey_last_used = :passkey_last_used
+	WHERE passkey_id = :passkey_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalPasskey(key))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind passkey object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Update query failed")
+	}
+
+	return nil
+}
+
+// Delete deletes the passkey with the given id.
+func (s *PasskeyStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+	DELETE FROM passkeys
+	WHERE passkey_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(err, "Delete query failed")
+	}
+
+	return nil
+}
+
+func mapPasskey(v *passkey) (*types.Passkey, error) {
+	credentialID, err := decodePasskeyBytes(v.CredentialID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode passkey credential id: %w", err)
+	}
+
+	publicKey, err := decodePasskeyBytes(v.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode passkey public key: %w", err)
+	}
+
+	aaguid, err := decodePasskeyBytes(v.AAGUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode passkey aaguid: %w", err)
+	}
+
+	return &types.Passkey{
+		ID:              v.ID,
+		PrincipalID:     v.PrincipalID,
+		Name:            v.Name,
+		CredentialID:    credentialID,
+		PublicKey:       publicKey,
+		AttestationType: v.AttestationType,
+		AAGUID:          aaguid,
+		SignCount:       uint32(v.SignCount),
+		Created:         v.Created,
+		LastUsed:        v.LastUsed,
+	}, nil
+}
+
+func mapInternalPasskey(v *types.Passkey) *passkey {
+	return &passkey{
+		ID:              v.ID,
+		PrincipalID:     v.PrincipalID,
+		Name:            v.Name,
+		CredentialID:    encodePasskeyBytes(v.CredentialID),
+		PublicKey:       encodePasskeyBytes(v.PublicKey),
+		AttestationType: v.AttestationType,
+		AAGUID:          encodePasskeyBytes(v.AAGUID),
+		SignCount:       int64(v.SignCount),
+		Created:         v.Created,
+		LastUsed:        v.LastUsed,
+	}
+}
+
+func encodePasskeyBytes(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodePasskeyBytes(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(s)
+}
+
+var _ store.PasskeyChallengeStore = (*PasskeyChallengeStore)(nil)
+
+// NewPasskeyChallengeStore returns a new PasskeyChallengeStore.
+func NewPasskeyChallengeStore(db *sqlx.DB) *PasskeyChallengeStore {
+	return &PasskeyChallengeStore{
+		db: db,
+	}
+}
+
+// PasskeyChallengeStore implements store.PasskeyChallengeStore backed by a relational database.
+type PasskeyChallengeStore struct {
+	db *sqlx.DB
+}
+
+// passkeyChallenge is used to fetch passkey challenge data from the database.
+type passkeyChallenge struct {
+	UID         string                    `db:"passkey_challenge_uid"`
+	PrincipalID int64                     `db:"passkey_challenge_principal_id"`
+	Kind        enum.PasskeyChallengeKind `db:"passkey_challenge_kind"`
+	Session     string                    `db:"passkey_challenge_session"`
+	Created     int64                     `db:"passkey_challenge_created"`
+	Expires     int64                     `db:"passkey_challenge_expires"`
+}
+
+const passkeyChallengeColumns = `
+		 passkey_challenge_uid
+		,passkey_challenge_principal_id
+		,passkey_challenge_kind
+		,passkey_challenge_session
+		,passkey_challenge_created
+		,passkey_challenge_expires`
+
+// Create creates a new passkey challenge.
+func (s *PasskeyChallengeStore) Create(ctx context.Context, challenge *types.PasskeyChallenge) error {
+	const sqlQuery = `
+	INSERT INTO passkey_challenges (` + passkeyChallengeColumns + `
+	) VALUES (
+		 :passkey_challenge_uid
+		,:passkey_challenge_principal_id
+		,:passkey_challenge_kind
+		,:passkey_challenge_session
+		,:passkey_challenge_created
+		,:passkey_challenge_expires
+	)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, (*passkeyChallenge)(challenge))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind passkey challenge object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Consume finds and deletes the passkey challenge in a single statement,
+// so concurrent requests can't use the same challenge twice.
+func (s *PasskeyChallengeStore) Consume(ctx context.Context, uid string) (*types.PasskeyChallenge, error) {
+	const sqlQuery = `
+	DELETE FROM passkey_challenges
+	WHERE passkey_challenge_uid = $1
+	RETURNING` + passkeyChallengeColumns
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &passkeyChallenge{}
+	if err := db.GetContext(ctx, dst, sqlQuery, uid); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to consume passkey challenge")
+	}
+
+	return (*types.PasskeyChallenge)(dst), nil
+}
+
+// DeleteExpired deletes all challenges that expired before the provided time (unix milliseconds).
+func (s *PasskeyChallengeStore) DeleteExpired(ctx context.Context, before int64) (int64, error) {
+	const sqlQuery = `
+	DELETE FROM passkey_challenges
+	WHERE passkey_challenge_expires < $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, before)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(err, "Failed to delete expired passkey challenges")
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(err, "Failed to get number of deleted passkey challenges")
+	}
+
+	return n, nil
+}
@@ -51,6 +51,7 @@ const (
 	,pipeline_created
 	,pipeline_updated
 	,pipeline_version
+	,pipeline_cache_generation
 	`
 )
 
@@ -106,6 +107,7 @@ func (s *pipelineStore) Create(ctx context.Context, pipeline *types.Pipeline) er
 		,pipeline_created
 		,pipeline_updated
 		,pipeline_version
+		,pipeline_cache_generation
 	) VALUES (
 		:pipeline_description,
 		:pipeline_uid,
@@ -117,7 +119,8 @@ func (s *pipelineStore) Create(ctx context.Context, pipeline *types.Pipeline) er
 		:pipeline_config_path,
 		:pipeline_created,
 		:pipeline_updated,
-		:pipeline_version
+		:pipeline_version,
+		:pipeline_cache_generation
 	) RETURNING pipeline_id`
 	db := dbtx.GetAccessor(ctx, s.db)
 
@@ -145,7 +148,8 @@ func (s *pipelineStore) Update(ctx context.Context, p *types.Pipeline) error {
 		pipeline_default_branch = :pipeline_default_branch,
 		pipeline_config_path = :pipeline_config_path,
 		pipeline_updated = :pipeline_updated,
-		pipeline_version = :pipeline_version
+		pipeline_version = :pipeline_version,
+		pipeline_cache_generation = :pipeline_cache_generation
 	WHERE pipeline_id = :pipeline_id AND pipeline_version = :pipeline_version - 1`
 	updatedAt := time.Now()
 	pipeline := *p
@@ -0,0 +1,214 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.PipelineArtifactStore = (*PipelineArtifactStore)(nil)
+
+// NewPipelineArtifactStore returns a new PipelineArtifactStore.
+func NewPipelineArtifactStore(db *sqlx.DB) *PipelineArtifactStore {
+	return &PipelineArtifactStore{
+		db: db,
+	}
+}
+
+// PipelineArtifactStore implements store.PipelineArtifactStore backed by a relational database.
+type PipelineArtifactStore struct {
+	db *sqlx.DB
+}
+
+type pipelineArtifact struct {
+	ID          int64  `db:"pipeline_artifact_id"`
+	ExecutionID int64  `db:"pipeline_artifact_execution_id"`
+	Name        string `db:"pipeline_artifact_name"`
+	Size        int64  `db:"pipeline_artifact_size"`
+	CreatedBy   int64  `db:"pipeline_artifact_created_by"`
+	Created     int64  `db:"pipeline_artifact_created"`
+}
+
+const (
+	pipelineArtifactColumns = `
+		 pipeline_artifact_id
+		,pipeline_artifact_execution_id
+		,pipeline_artifact_name
+		,pipeline_artifact_size
+		,pipeline_artifact_created_by
+		,pipeline_artifact_created`
+)
+
+// FindByID returns the artifact with the provided ID.
+func (s *PipelineArtifactStore) FindByID(ctx context.Context, id int64) (*types.PipelineArtifact, error) {
+	stmt := database.Builder.
+		Select(pipelineArtifactColumns).
+		From("pipeline_artifacts").
+		Where("pipeline_artifact_id = ?", id)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert pipeline artifact query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &pipelineArtifact{}
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find pipeline artifact")
+	}
+
+	return mapToPipelineArtifact(dst), nil
+}
+
+// Upsert creates a new artifact or replaces the existing artifact of the execution with the same name.
+func (s *PipelineArtifactStore) Upsert(ctx context.Context, artifact *types.PipelineArtifact) error {
+	const sqlQuery = `
+	INSERT INTO pipeline_artifacts (
+		 pipeline_artifact_execution_id
+		,pipeline_artifact_name
+		,pipeline_artifact_size
+		,pipeline_artifact_created_by
+		,pipeline_artifact_created
+	) VALUES (
+		 :pipeline_artifact_execution_id
+		,:pipeline_artifact_name
+		,:pipeline_artifact_size
+		,:pipeline_artifact_created_by
+		,:pipeline_artifact_created
+	)
+	ON CONFLICT (pipeline_artifact_execution_id, pipeline_artifact_name) DO
+	UPDATE SET
+		 pipeline_artifact_size = EXCLUDED.pipeline_artifact_size
+		,pipeline_artifact_created_by = EXCLUDED.pipeline_artifact_created_by
+		,pipeline_artifact_created = EXCLUDED.pipeline_artifact_created
+	RETURNING pipeline_artifact_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalPipelineArtifact(artifact))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind pipeline artifact object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&artifact.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Upsert pipeline artifact query failed")
+	}
+
+	return nil
+}
+
+// List returns the artifacts of the execution, ordered by name.
+func (s *PipelineArtifactStore) List(ctx context.Context, executionID int64) ([]*types.PipelineArtifact, error) {
+	stmt := database.Builder.
+		Select(pipelineArtifactColumns).
+		From("pipeline_artifacts").
+		Where("pipeline_artifact_execution_id = ?", executionID).
+		OrderBy("pipeline_artifact_name ASC")
+
+	return s.list(ctx, stmt)
+}
+
+// ListOlderThan returns the artifacts created before the provided time, oldest first.
+func (s *PipelineArtifactStore) ListOlderThan(
+	ctx context.Context,
+	olderThan time.Time,
+	limit int,
+) ([]*types.PipelineArtifact, error) {
+	stmt := database.Builder.
+		Select(pipelineArtifactColumns).
+		From("pipeline_artifacts").
+		Where("pipeline_artifact_created < ?", olderThan.UnixMilli()).
+		OrderBy("pipeline_artifact_created ASC", "pipeline_artifact_id ASC").
+		Limit(uint64(limit))
+
+	return s.list(ctx, stmt)
+}
+
+func (s *PipelineArtifactStore) list(
+	ctx context.Context,
+	stmt squirrel.SelectBuilder,
+) ([]*types.PipelineArtifact, error) {
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert pipeline artifact list query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*pipelineArtifact{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing pipeline artifact list query")
+	}
+
+	result := make([]*types.PipelineArtifact, len(dst))
+	for i, a := range dst {
+		result[i] = mapToPipelineArtifact(a)
+	}
+
+	return result, nil
+}
+
+// Delete deletes the artifact.
+func (s *PipelineArtifactStore) Delete(ctx context.Context, id int64) error {
+	stmt := database.Builder.
+		Delete("pipeline_artifacts").
+		Where("pipeline_artifact_id = ?", id)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert delete pipeline artifact query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete pipeline artifact")
+	}
+
+	return nil
+}
+
+func mapToPipelineArtifact(a *pipelineArtifact) *types.PipelineArtifact {
+	return &types.PipelineArtifact{
+		ID:          a.ID,
+		ExecutionID: a.ExecutionID,
+		Name:        a.Name,
+		Size:        a.Size,
+		CreatedBy:   a.CreatedBy,
+		Created:     a.Created,
+	}
+}
+
+func mapToInternalPipelineArtifact(a *types.PipelineArtifact) *pipelineArtifact {
+	return &pipelineArtifact{
+		ID:          a.ID,
+		ExecutionID: a.ExecutionID,
+		Name:        a.Name,
+		Size:        a.Size,
+		CreatedBy:   a.CreatedBy,
+		Created:     a.Created,
+	}
+}
@@ -0,0 +1,332 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.PipelineCacheStore = (*PipelineCacheStore)(nil)
+
+// NewPipelineCacheStore returns a new PipelineCacheStore.
+func NewPipelineCacheStore(db *sqlx.DB) *PipelineCacheStore {
+	return &PipelineCacheStore{
+		db: db,
+	}
+}
+
+// PipelineCacheStore implements store.PipelineCacheStore backed by a relational database.
+type PipelineCacheStore struct {
+	db *sqlx.DB
+}
+
+type pipelineCache struct {
+	ID        int64  `db:"pipeline_cache_id"`
+	RepoID    int64  `db:"pipeline_cache_repo_id"`
+	Key       string `db:"pipeline_cache_key"`
+	Size      int64  `db:"pipeline_cache_size"`
+	CreatedBy int64  `db:"pipeline_cache_created_by"`
+	Created   int64  `db:"pipeline_cache_created"`
+	Updated   int64  `db:"pipeline_cache_updated"`
+	Accessed  int64  `db:"pipeline_cache_accessed"`
+}
+
+const (
+	pipelineCacheColumns = `
+		 pipeline_cache_id
+		,pipeline_cache_repo_id
+		,pipeline_cache_key
+		,pipeline_cache_size
+		,pipeline_cache_created_by
+		,pipeline_cache_created
+		,pipeline_cache_updated
+		,pipeline_cache_accessed`
+)
+
+// FindByID returns the cache entry with the provided ID.
+func (s *PipelineCacheStore) FindByID(ctx context.Context, id int64) (*types.PipelineCache, error) {
+	stmt := database.Builder.
+		Select(pipelineCacheColumns).
+		From("pipeline_caches").
+		Where("pipeline_cache_id = ?", id)
+
+	return s.find(ctx, stmt)
+}
+
+// Find returns the cache entry of the repository with the provided key.
+func (s *PipelineCacheStore) Find(ctx context.Context, repoID int64, key string) (*types.PipelineCache, error) {
+	stmt := database.Builder.
+		Select(pipelineCacheColumns).
+		From("pipeline_caches").
+		Where("pipeline_cache_repo_id = ?", repoID).
+		Where("pipeline_cache_key = ?", key)
+
+	return s.find(ctx, stmt)
+}
+
+// FindByKeyPrefix returns the most recently updated cache entry of the repository
+// with a key that starts with the provided prefix.
+func (s *PipelineCacheStore) FindByKeyPrefix(
+	ctx context.Context,
+	repoID int64,
+	prefix string,
+) (*types.PipelineCache, error) {
+	// SUBSTR is used instead of LIKE to avoid escaping the wildcards in the prefix.
+	stmt := database.Builder.
+		Select(pipelineCacheColumns).
+		From("pipeline_caches").
+		Where("pipeline_cache_repo_id = ?", repoID).
+		Where("SUBSTR(pipeline_cache_key, 1, ?) = ?", utf8.RuneCountInString(prefix), prefix).
+		OrderBy("pipeline_cache_updated DESC", "pipeline_cache_id DESC").
+		Limit(1)
+
+	return s.find(ctx, stmt)
+}
+
+func (s *PipelineCacheStore) find(ctx context.Context, stmt squirrel.SelectBuilder) (*types.PipelineCache, error) {
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert pipeline cache query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &pipelineCache{}
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find pipeline cache")
+	}
+
+	return mapToPipelineCache(dst), nil
+}
+
+// Upsert creates a new cache entry or replaces the existing entry with the same key.
+func (s *PipelineCacheStore) Upsert(ctx context.Context, cache *types.PipelineCache) error {
+	const sqlQuery = `
+	INSERT INTO pipeline_caches (
+		 pipeline_cache_repo_id
+		,pipeline_cache_key
+		,pipeline_cache_size
+		,pipeline_cache_created_by
+		,pipeline_cache_created
+		,pipeline_cache_updated
+		,pipeline_cache_accessed
+	) VALUES (
+		 :pipeline_cache_repo_id
+		,:pipeline_cache_key
+		,:pipeline_cache_size
+		,:pipeline_cache_created_by
+		,:pipeline_cache_created
+		,:pipeline_cache_updated
+		,:pipeline_cache_accessed
+	)
+	ON CONFLICT (pipeline_cache_repo_id, pipeline_cache_key) DO
+	UPDATE SET
+		 pipeline_cache_size = EXCLUDED.pipeline_cache_size
+		,pipeline_cache_created_by = EXCLUDED.pipeline_cache_created_by
+		,pipeline_cache_updated = EXCLUDED.pipeline_cache_updated
+		,pipeline_cache_accessed = EXCLUDED.pipeline_cache_accessed
+	RETURNING pipeline_cache_id, pipeline_cache_created`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalPipelineCache(cache))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind pipeline cache object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&cache.ID, &cache.Created); err != nil {
+		return database.ProcessSQLErrorf(err, "Upsert pipeline cache query failed")
+	}
+
+	return nil
+}
+
+// Touch updates the last access time of the cache entry.
+func (s *PipelineCacheStore) Touch(ctx context.Context, id int64, accessed int64) error {
+	stmt := database.Builder.
+		Update("pipeline_caches").
+		Set("pipeline_cache_accessed", accessed).
+		Where("pipeline_cache_id = ?", id)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert touch pipeline cache query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to touch pipeline cache")
+	}
+
+	return nil
+}
+
+// List returns the cache entries of the repository, most recently accessed first.
+func (s *PipelineCacheStore) List(
+	ctx context.Context,
+	repoID int64,
+	filter *types.ListQueryFilter,
+) ([]*types.PipelineCache, error) {
+	stmt := database.Builder.
+		Select(pipelineCacheColumns).
+		From("pipeline_caches").
+		Where("pipeline_cache_repo_id = ?", repoID)
+
+	if filter.Query != "" {
+		stmt = stmt.Where("LOWER(pipeline_cache_key) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(filter.Query)))
+	}
+
+	stmt = stmt.
+		OrderBy("pipeline_cache_accessed DESC", "pipeline_cache_id DESC").
+		Limit(database.Limit(filter.Size)).
+		Offset(database.Offset(filter.Page, filter.Size))
+
+	return s.list(ctx, stmt)
+}
+
+// ListLeastRecentlyAccessed returns the cache entries of the repository, least recently accessed first.
+func (s *PipelineCacheStore) ListLeastRecentlyAccessed(
+	ctx context.Context,
+	repoID int64,
+	limit int,
+) ([]*types.PipelineCache, error) {
+	stmt := database.Builder.
+		Select(pipelineCacheColumns).
+		From("pipeline_caches").
+		Where("pipeline_cache_repo_id = ?", repoID).
+		OrderBy("pipeline_cache_accessed ASC", "pipeline_cache_id ASC").
+		Limit(uint64(limit))
+
+	return s.list(ctx, stmt)
+}
+
+func (s *PipelineCacheStore) list(ctx context.Context, stmt squirrel.SelectBuilder) ([]*types.PipelineCache, error) {
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert pipeline cache list query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*pipelineCache{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing pipeline cache list query")
+	}
+
+	result := make([]*types.PipelineCache, len(dst))
+	for i, c := range dst {
+		result[i] = mapToPipelineCache(c)
+	}
+
+	return result, nil
+}
+
+// Count returns the number of cache entries of the repository.
+func (s *PipelineCacheStore) Count(ctx context.Context, repoID int64, filter *types.ListQueryFilter) (int64, error) {
+	stmt := database.Builder.
+		Select("COUNT(*)").
+		From("pipeline_caches").
+		Where("pipeline_cache_repo_id = ?", repoID)
+
+	if filter.Query != "" {
+		stmt = stmt.Where("LOWER(pipeline_cache_key) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(filter.Query)))
+	}
+
+	return s.sum(ctx, stmt)
+}
+
+// TotalSize returns the total size of all cache entries of the repository.
+func (s *PipelineCacheStore) TotalSize(ctx context.Context, repoID int64) (int64, error) {
+	stmt := database.Builder.
+		Select("COALESCE(SUM(pipeline_cache_size), 0)").
+		From("pipeline_caches").
+		Where("pipeline_cache_repo_id = ?", repoID)
+
+	return s.sum(ctx, stmt)
+}
+
+func (s *PipelineCacheStore) sum(ctx context.Context, stmt squirrel.SelectBuilder) (int64, error) {
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert pipeline cache query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var result int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&result); err != nil {
+		return 0, database.ProcessSQLErrorf(err, "Failed executing pipeline cache query")
+	}
+
+	return result, nil
+}
+
+// Delete deletes the cache entry.
+func (s *PipelineCacheStore) Delete(ctx context.Context, id int64) error {
+	stmt := database.Builder.
+		Delete("pipeline_caches").
+		Where("pipeline_cache_id = ?", id)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert delete pipeline cache query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete pipeline cache")
+	}
+
+	return nil
+}
+
+func mapToPipelineCache(c *pipelineCache) *types.PipelineCache {
+	return &types.PipelineCache{
+		ID:        c.ID,
+		RepoID:    c.RepoID,
+		Key:       c.Key,
+		Size:      c.Size,
+		CreatedBy: c.CreatedBy,
+		Created:   c.Created,
+		Updated:   c.Updated,
+		Accessed:  c.Accessed,
+	}
+}
+
+func mapToInternalPipelineCache(c *types.PipelineCache) *pipelineCache {
+	return &pipelineCache{
+		ID:        c.ID,
+		RepoID:    c.RepoID,
+		Key:       c.Key,
+		Size:      c.Size,
+		CreatedBy: c.CreatedBy,
+		Created:   c.Created,
+		Updated:   c.Updated,
+		Accessed:  c.Accessed,
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.PublicKeyStore = (*PublicKeyStore)(nil)
+
+// NewPublicKeyStore returns a new PublicKeyStore.
+func NewPublicKeyStore(db *sqlx.DB) *PublicKeyStore {
+	return &PublicKeyStore{
+		db: db,
+	}
+}
+
+// PublicKeyStore implements store.PublicKeyStore backed by a relational database.
+type PublicKeyStore struct {
+	db *sqlx.DB
+}
+
+// publicKey is used to fetch public key data from the database.
+type publicKey struct {
+	ID          int64                 `db:"public_key_id"`
+	PrincipalID int64                 `db:"public_key_principal_id"`
+	Format      enum.SigningKeyFormat `db:"public_key_format"`
+	Fingerprint string                `db:"public_key_fingerprint"`
+	Content     string                `db:"public_key_content"`
+	Comment     string                `db:"public_key_comment"`
+	Created     int64                 `db:"public_key_created"`
+}
+
+const (
+	publicKeyColumns = `
+		 public_key_id
+		,public_key_principal_id
+		,public_key_format
+		,public_key_fingerprint
+		,public_key_content
+		,public_key_comment
+		,public_key_created`
+
+	publicKeySelectBase = `
+	SELECT` + publicKeyColumns + `
+	FROM public_keys`
+)
+
+// Find finds the public key by id.
+func (s *PublicKeyStore) Find(ctx context.Context, id int64) (*types.PublicKey, error) {
+	const sqlQuery = publicKeySelectBase + `
+	WHERE public_key_id = $1`
+
+	return s.find(ctx, sqlQuery, id)
+}
+
+// FindByFingerprint finds the public key by its fingerprint.
+func (s *PublicKeyStore) FindByFingerprint(ctx context.Context, fingerprint string) (*types.PublicKey, error) {
+	const sqlQuery = publicKeySelectBase + `
+	WHERE public_key_fingerprint = $1`
+
+	return s.find(ctx, sqlQuery, fingerprint)
+}
+
+func (s *PublicKeyStore) find(ctx context.Context, sqlQuery string, arg any) (*types.PublicKey, error) {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &publicKey{}
+	if err := db.GetContext(ctx, dst, sqlQuery, arg); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find public key")
+	}
+
+	return mapPublicKey(dst), nil
+}
+
+// List returns all public keys of the principal, the oldest first.
+func (s *PublicKeyStore) List(ctx context.Context, principalID int64) ([]*types.PublicKey, error) {
+	const sqlQuery = publicKeySelectBase + `
+	WHERE public_key_principal_id = $1
+	ORDER BY public_key_created, public_key_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*publicKey{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list public keys")
+	}
+
+	keys := make([]*types.PublicKey, len(dst))
+	for i := range dst {
+		keys[i] = mapPublicKey(dst[i])
+	}
+
+	return keys, nil
+}
+
+// Create creates a new public key.
+func (s *PublicKeyStore) Create(ctx context.Context, key *types.PublicKey) error {
+	const sqlQuery = `
+	INSERT INTO public_keys (
+		 public_key_principal_id
+		,public_key_format
+		,public_key_fingerprint
+		,public_key_content
+		,public_key_comment
+		,public_key_created
+	) VALUES (
+		 :public_key_principal_id
+		,:public_key_format
+		,:public_key_fingerprint
+		,:public_key_content
+		,:public_key_comment
+		,:public_key_created
+	)
+	RETURNING public_key_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalPublicKey(key))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind public key object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&key.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Delete deletes the public key with the given id.
+func (s *PublicKeyStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+	DELETE FROM public_keys
+	WHERE public_key_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(err, "Delete query failed")
+	}
+
+	return nil
+}
+
+func mapPublicKey(v *publicKey) *types.PublicKey {
+	return &types.PublicKey{
+		ID:          v.ID,
+		PrincipalID: v.PrincipalID,
+		Format:      v.Format,
+		Fingerprint: v.Fingerprint,
+		Content:     v.Content,
+		Comment:     v.Comment,
+		Created:     v.Created,
+	}
+}
+
+func mapInternalPublicKey(v *types.PublicKey) *publicKey {
+	return &publicKey{
+		ID:          v.ID,
+		PrincipalID: v.PrincipalID,
+		Format:      v.Format,
+		Fingerprint: v.Fingerprint,
+		Content:     v.Content,
+		Comment:     v.Comment,
+		Created:     v.Created,
+	}
+}
@@ -92,6 +92,8 @@ type pullReq struct {
 
 	CommitCount null.Int `db:"pullreq_commit_count"`
 	FileCount   null.Int `db:"pullreq_file_count"`
+	Additions   null.Int `db:"pullreq_additions"`
+	Deletions   null.Int `db:"pullreq_deletions"`
 }
 
 const (
@@ -124,7 +126,9 @@ const (
 		,pullreq_merge_sha
 		,pullreq_merge_conflicts
 		,pullreq_commit_count
-		,pullreq_file_count`
+		,pullreq_file_count
+		,pullreq_additions
+		,pullreq_deletions`
 
 	pullReqSelectBase = `
 	SELECT` + pullReqColumns + `
@@ -216,6 +220,8 @@ func (s *PullReqStore) Create(ctx context.Context, pr *types.PullReq) error {
 		,pullreq_merge_conflicts
 		,pullreq_commit_count
 		,pullreq_file_count
+		,pullreq_additions
+		,pullreq_deletions
 	) values (
 		 :pullreq_version
 		,:pullreq_number
@@ -245,6 +251,8 @@ func (s *PullReqStore) Create(ctx context.Context, pr *types.PullReq) error {
 		,:pullreq_merge_conflicts
 		,:pullreq_commit_count
 		,:pullreq_file_count
+		,:pullreq_additions
+		,:pullreq_deletions
 	) RETURNING pullreq_id`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -287,6 +295,8 @@ func (s *PullReqStore) Update(ctx context.Context, pr *types.PullReq) error {
 		,pullreq_merge_conflicts = :pullreq_merge_conflicts
 		,pullreq_commit_count = :pullreq_commit_count 
 		,pullreq_file_count = :pullreq_file_count
+		,pullreq_additions = :pullreq_additions
+		,pullreq_deletions = :pullreq_deletions
 	WHERE pullreq_id = :pullreq_id AND pullreq_version = :pullreq_version - 1`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -372,6 +382,8 @@ func (s *PullReqStore) UpdateMergeCheckStatus(
 		,pullreq_version = pullreq_version + 1
 		,pullreq_commit_count = NULL
 		,pullreq_file_count = NULL
+		,pullreq_additions = NULL
+		,pullreq_deletions = NULL
 	WHERE pullreq_target_repo_id = $3 AND
 		pullreq_target_branch = $4 AND
 		pullreq_state not in ($5, $6)`
@@ -408,35 +420,7 @@ func (s *PullReqStore) Count(ctx context.Context, opts *types.PullReqFilter) (in
 		Select("count(*)").
 		From("pullreqs")
 
-	if len(opts.States) == 1 {
-		stmt = stmt.Where("pullreq_state = ?", opts.States[0])
-	} else if len(opts.States) > 1 {
-		stmt = stmt.Where(squirrel.Eq{"pullreq_state": opts.States})
-	}
-
-	if opts.SourceRepoID != 0 {
-		stmt = stmt.Where("pullreq_source_repo_id = ?", opts.SourceRepoID)
-	}
-
-	if opts.SourceBranch != "" {
-		stmt = stmt.Where("pullreq_source_branch = ?", opts.SourceBranch)
-	}
-
-	if opts.TargetRepoID != 0 {
-		stmt = stmt.Where("pullreq_target_repo_id = ?", opts.TargetRepoID)
-	}
-
-	if opts.TargetBranch != "" {
-		stmt = stmt.Where("pullreq_target_branch = ?", opts.TargetBranch)
-	}
-
-	if opts.Query != "" {
-		stmt = stmt.Where("LOWER(pullreq_title) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(opts.Query)))
-	}
-
-	if opts.CreatedBy != 0 {
-		stmt = stmt.Where("pullreq_created_by = ?", opts.CreatedBy)
-	}
+	stmt = applyPullReqFilter(stmt, opts)
 
 	sql, args, err := stmt.ToSql()
 	if err != nil {
@@ -460,6 +444,75 @@ func (s *PullReqStore) List(ctx context.Context, opts *types.PullReqFilter) ([]*
 		Select(pullReqColumns).
 		From("pullreqs")
 
+	stmt = applyPullReqFilter(stmt, opts)
+
+	stmt = stmt.Limit(database.Limit(opts.Size))
+	stmt = stmt.Offset(database.Offset(opts.Page, opts.Size))
+
+	// NOTE: string concatenation is safe because the
+	// order attribute is an enum and is not user-defined,
+	// and is therefore not subject to injection attacks.
+	opts.Sort, _ = opts.Sort.Sanitize()
+	stmt = stmt.OrderBy("pullreq_" + string(opts.Sort) + " " + opts.Order.String())
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	dst := make([]*pullReq, 0)
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing custom list query")
+	}
+
+	result, err := s.mapSlicePullReq(ctx, dst)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ListByMergeSHAs returns the merged pull requests of the target repository
+// whose merge commit is one of the provided SHAs.
+func (s *PullReqStore) ListByMergeSHAs(
+	ctx context.Context,
+	repoID int64,
+	shas []string,
+) ([]*types.PullReq, error) {
+	if len(shas) == 0 {
+		return []*types.PullReq{}, nil
+	}
+
+	stmt := database.Builder.
+		Select(pullReqColumns).
+		From("pullreqs").
+		Where("pullreq_target_repo_id = ?", repoID).
+		Where("pullreq_state = ?", enum.PullReqStateMerged).
+		Where(squirrel.Eq{"pullreq_merge_sha": shas}).
+		OrderBy("pullreq_merged ASC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	dst := make([]*pullReq, 0)
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list pull requests by merge SHAs")
+	}
+
+	return s.mapSlicePullReq(ctx, dst)
+}
+
+// applyPullReqFilter adds the conditions of the pull request filter to the query.
+func applyPullReqFilter(stmt squirrel.SelectBuilder, opts *types.PullReqFilter) squirrel.SelectBuilder {
 	if len(opts.States) == 1 {
 		stmt = stmt.Where("pullreq_state = ?", opts.States[0])
 	} else if len(opts.States) > 1 {
@@ -483,41 +536,46 @@ func (s *PullReqStore) List(ctx context.Context, opts *types.PullReqFilter) ([]*
 	}
 
 	if opts.Query != "" {
-		stmt = stmt.Where("LOWER(pullreq_title) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(opts.Query)))
+		// in postgres the expressions are backed by trigram indexes, sqlite has to scan the pull requests.
+		query := fmt.Sprintf("%%%s%%", strings.ToLower(opts.Query))
+		stmt = stmt.Where("(LOWER(pullreq_title) LIKE ? OR LOWER(pullreq_description) LIKE ?)", query, query)
 	}
 
 	if opts.CreatedBy != 0 {
 		stmt = stmt.Where("pullreq_created_by = ?", opts.CreatedBy)
 	}
 
-	stmt = stmt.Limit(database.Limit(opts.Size))
-	stmt = stmt.Offset(database.Offset(opts.Page, opts.Size))
+	if opts.IsDraft != nil {
+		stmt = stmt.Where("pullreq_is_draft = ?", *opts.IsDraft)
+	}
 
-	// NOTE: string concatenation is safe because the
-	// order attribute is an enum and is not user-defined,
-	// and is therefore not subject to injection attacks.
-	opts.Sort, _ = opts.Sort.Sanitize()
-	stmt = stmt.OrderBy("pullreq_" + string(opts.Sort) + " " + opts.Order.String())
+	if opts.ReviewerID != 0 {
+		stmt = stmt.Where(`EXISTS (SELECT 1 FROM pullreq_reviewers
+			WHERE pullreq_reviewer_pullreq_id = pullreq_id AND pullreq_reviewer_principal_id = ?)`, opts.ReviewerID)
+	}
 
-	sql, args, err := stmt.ToSql()
-	if err != nil {
-		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	if opts.MentionedID != 0 {
+		stmt = stmt.Where(`EXISTS (SELECT 1 FROM pullreq_mentions
+			WHERE pullreq_mention_pullreq_id = pullreq_id AND pullreq_mention_principal_id = ?)`, opts.MentionedID)
 	}
 
-	dst := make([]*pullReq, 0)
+	if opts.CreatedGt > 0 {
+		stmt = stmt.Where("pullreq_created > ?", opts.CreatedGt)
+	}
 
-	db := dbtx.GetAccessor(ctx, s.db)
+	if opts.CreatedLt > 0 {
+		stmt = stmt.Where("pullreq_created < ?", opts.CreatedLt)
+	}
 
-	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
-		return nil, database.ProcessSQLErrorf(err, "Failed executing custom list query")
+	if opts.UpdatedGt > 0 {
+		stmt = stmt.Where("pullreq_updated > ?", opts.UpdatedGt)
 	}
 
-	result, err := s.mapSlicePullReq(ctx, dst)
-	if err != nil {
-		return nil, err
+	if opts.UpdatedLt > 0 {
+		stmt = stmt.Where("pullreq_updated < ?", opts.UpdatedLt)
 	}
 
-	return result, nil
+	return stmt
 }
 
 func mapPullReq(pr *pullReq) *types.PullReq {
@@ -526,6 +584,14 @@ func mapPullReq(pr *pullReq) *types.PullReq {
 		mergeConflicts = strings.Split(pr.MergeConflicts.String, "\n")
 	}
 
+	diffStats := types.DiffStats{
+		Commits:      pr.CommitCount.Ptr(),
+		FilesChanged: pr.FileCount.Ptr(),
+		Additions:    pr.Additions.Ptr(),
+		Deletions:    pr.Deletions.Ptr(),
+	}
+	diffStats.Size = diffStats.PullReqSize()
+
 	return &types.PullReq{
 		ID:               pr.ID,
 		Version:          pr.Version,
@@ -559,10 +625,7 @@ func mapPullReq(pr *pullReq) *types.PullReq {
 		Stats: types.PullReqStats{
 			Conversations:   pr.CommentCount,
 			UnresolvedCount: pr.UnresolvedCount,
-			DiffStats: types.DiffStats{
-				Commits:      pr.CommitCount.Ptr(),
-				FilesChanged: pr.FileCount.Ptr(),
-			},
+			DiffStats:       diffStats,
 		},
 	}
 }
@@ -599,6 +662,8 @@ func mapInternalPullReq(pr *types.PullReq) *pullReq {
 		MergeConflicts:   null.NewString(mergeConflicts, mergeConflicts != ""),
 		CommitCount:      null.IntFromPtr(pr.Stats.Commits),
 		FileCount:        null.IntFromPtr(pr.Stats.FilesChanged),
+		Additions:        null.IntFromPtr(pr.Stats.Additions),
+		Deletions:        null.IntFromPtr(pr.Stats.Deletions),
 	}
 
 	return m
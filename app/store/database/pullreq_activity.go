@@ -91,6 +91,8 @@ type pullReqActivity struct {
 	CodeCommentSpanNew      null.Int    `db:"pullreq_activity_code_comment_span_new"`
 	CodeCommentLineOld      null.Int    `db:"pullreq_activity_code_comment_line_old"`
 	CodeCommentSpanOld      null.Int    `db:"pullreq_activity_code_comment_span_old"`
+	CodeCommentAnchorStatus null.String `db:"pullreq_activity_code_comment_anchor_status"`
+	CodeCommentAnchor       null.String `db:"pullreq_activity_code_comment_anchor"`
 }
 
 const (
@@ -122,7 +124,9 @@ const (
 		,pullreq_activity_code_comment_line_new
 		,pullreq_activity_code_comment_span_new
 		,pullreq_activity_code_comment_line_old
-		,pullreq_activity_code_comment_span_old`
+		,pullreq_activity_code_comment_span_old
+		,pullreq_activity_code_comment_anchor_status
+		,pullreq_activity_code_comment_anchor`
 
 	pullreqActivitySelectBase = `
 	SELECT` + pullreqActivityColumns + `
@@ -175,6 +179,8 @@ func (s *PullReqActivityStore) Create(ctx context.Context, act *types.PullReqAct
 		,pullreq_activity_code_comment_span_new
 		,pullreq_activity_code_comment_line_old
 		,pullreq_activity_code_comment_span_old
+		,pullreq_activity_code_comment_anchor_status
+		,pullreq_activity_code_comment_anchor
 	) values (
 		 :pullreq_activity_version
 		,:pullreq_activity_created_by
@@ -203,6 +209,8 @@ func (s *PullReqActivityStore) Create(ctx context.Context, act *types.PullReqAct
 		,:pullreq_activity_code_comment_span_new
 		,:pullreq_activity_code_comment_line_old
 		,:pullreq_activity_code_comment_span_old
+		,:pullreq_activity_code_comment_anchor_status
+		,:pullreq_activity_code_comment_anchor
 	) RETURNING pullreq_activity_id`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -272,6 +280,8 @@ func (s *PullReqActivityStore) Update(ctx context.Context, act *types.PullReqAct
 		,pullreq_activity_code_comment_span_new = :pullreq_activity_code_comment_span_new
 		,pullreq_activity_code_comment_line_old = :pullreq_activity_code_comment_line_old
 		,pullreq_activity_code_comment_span_old = :pullreq_activity_code_comment_span_old
+		,pullreq_activity_code_comment_anchor_status = :pullreq_activity_code_comment_anchor_status
+		,pullreq_activity_code_comment_anchor = :pullreq_activity_code_comment_anchor
 	WHERE pullreq_activity_id = :pullreq_activity_id AND pullreq_activity_version = :pullreq_activity_version - 1`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -390,6 +400,32 @@ func (s *PullReqActivityStore) List(ctx context.Context,
 		From("pullreq_activities").
 		Where("pullreq_activity_pullreq_id = ?", prID)
 
+	stmt = applyPullReqActivityFilter(stmt, opts)
+
+	stmt = stmt.OrderBy("pullreq_activity_order asc", "pullreq_activity_sub_order asc")
+
+	return s.list(ctx, stmt)
+}
+
+// ListAll returns a list of pull request activities of all pull requests, ordered by the creation time.
+func (s *PullReqActivityStore) ListAll(ctx context.Context,
+	opts *types.PullReqActivityFilter,
+) ([]*types.PullReqActivity, error) {
+	stmt := database.Builder.
+		Select(pullreqActivityColumns).
+		From("pullreq_activities")
+
+	stmt = applyPullReqActivityFilter(stmt, opts)
+
+	stmt = stmt.OrderBy("pullreq_activity_created asc", "pullreq_activity_id asc")
+
+	return s.list(ctx, stmt)
+}
+
+func applyPullReqActivityFilter(
+	stmt squirrel.SelectBuilder,
+	opts *types.PullReqActivityFilter,
+) squirrel.SelectBuilder {
 	if len(opts.Types) == 1 {
 		stmt = stmt.Where("pullreq_activity_type = ?", opts.Types[0])
 	} else if len(opts.Types) > 1 {
@@ -414,8 +450,13 @@ func (s *PullReqActivityStore) List(ctx context.Context,
 		stmt = stmt.Limit(database.Limit(opts.Limit))
 	}
 
-	stmt = stmt.OrderBy("pullreq_activity_order asc", "pullreq_activity_sub_order asc")
+	return stmt
+}
 
+func (s *PullReqActivityStore) list(
+	ctx context.Context,
+	stmt squirrel.SelectBuilder,
+) ([]*types.PullReqActivity, error) {
 	sql, args, err := stmt.ToSql()
 	if err != nil {
 		return nil, errors.Wrap(err, "Failed to convert pull request activity query to sql")
@@ -498,6 +539,8 @@ func mapPullReqActivity(act *pullReqActivity) *types.PullReqActivity {
 			SpanNew:      int(act.CodeCommentSpanNew.Int64),
 			LineOld:      int(act.CodeCommentLineOld.Int64),
 			SpanOld:      int(act.CodeCommentSpanOld.Int64),
+			AnchorStatus: enum.CodeCommentAnchorStatus(act.CodeCommentAnchorStatus.String),
+			Anchor:       decodeCodeCommentAnchor(act.CodeCommentAnchor),
 		}
 	}
 
@@ -538,6 +581,9 @@ func mapInternalPullReqActivity(act *types.PullReqActivity) *pullReqActivity {
 		m.CodeCommentSpanNew = null.IntFrom(int64(act.CodeComment.SpanNew))
 		m.CodeCommentLineOld = null.IntFrom(int64(act.CodeComment.LineOld))
 		m.CodeCommentSpanOld = null.IntFrom(int64(act.CodeComment.SpanOld))
+		m.CodeCommentAnchorStatus = null.NewString(string(act.CodeComment.AnchorStatus),
+			act.CodeComment.AnchorStatus != "")
+		m.CodeCommentAnchor = encodeCodeCommentAnchor(act.CodeComment.Anchor)
 	}
 
 	m.Metadata, _ = json.Marshal(act.Metadata)
@@ -0,0 +1,174 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.PullReqDependencyStore = (*PullReqDependencyStore)(nil)
+
+// NewPullReqDependencyStore returns a new PullReqDependencyStore.
+func NewPullReqDependencyStore(db *sqlx.DB) *PullReqDependencyStore {
+	return &PullReqDependencyStore{
+		db: db,
+	}
+}
+
+// PullReqDependencyStore implements store.PullReqDependencyStore backed by a relational database.
+type PullReqDependencyStore struct {
+	db *sqlx.DB
+}
+
+// pullReqDependency is used to fetch pull request dependency data from the database.
+type pullReqDependency struct {
+	ID          int64 `db:"pullreq_dependency_id"`
+	PullReqID   int64 `db:"pullreq_dependency_pullreq_id"`
+	DependsOnID int64 `db:"pullreq_dependency_depends_on_id"`
+
+	CreatedBy int64 `db:"pullreq_dependency_created_by"`
+	Created   int64 `db:"pullreq_dependency_created"`
+}
+
+const (
+	pullreqDependencyColumns = `
+		 pullreq_dependency_id
+		,pullreq_dependency_pullreq_id
+		,pullreq_dependency_depends_on_id
+		,pullreq_dependency_created_by
+		,pullreq_dependency_created`
+
+	pullreqDependencySelectBase = `
+	SELECT` + pullreqDependencyColumns + `
+	FROM pullreq_dependencies`
+)
+
+// Find finds the pull request dependency by id.
+func (s *PullReqDependencyStore) Find(ctx context.Context, id int64) (*types.PullReqDependency, error) {
+	const sqlQuery = pullreqDependencySelectBase + `
+	WHERE pullreq_dependency_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &pullReqDependency{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find pull request dependency")
+	}
+
+	return mapPullReqDependency(dst), nil
+}
+
+// Create creates a new pull request dependency.
+func (s *PullReqDependencyStore) Create(ctx context.Context, v *types.PullReqDependency) error {
+	const sqlQuery = `
+	INSERT INTO pullreq_dependencies (
+		 pullreq_dependency_pullreq_id
+		,pullreq_dependency_depends_on_id
+		,pullreq_dependency_created_by
+		,pullreq_dependency_created
+	) values (
+		 :pullreq_dependency_pullreq_id
+		,:pullreq_dependency_depends_on_id
+		,:pullreq_dependency_created_by
+		,:pullreq_dependency_created
+	) RETURNING pullreq_dependency_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalPullReqDependency(v))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind pull request dependency object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&v.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to insert pull request dependency")
+	}
+
+	return nil
+}
+
+// Delete deletes the pull request dependency by id.
+func (s *PullReqDependencyStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+	DELETE FROM pullreq_dependencies
+	WHERE pullreq_dependency_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete pull request dependency")
+	}
+
+	return nil
+}
+
+// ListDependencies returns all dependencies of the pull request.
+func (s *PullReqDependencyStore) ListDependencies(
+	ctx context.Context,
+	prID int64,
+) ([]*types.PullReqDependency, error) {
+	const sqlQuery = pullreqDependencySelectBase + `
+	WHERE pullreq_dependency_pullreq_id = $1
+	ORDER BY pullreq_dependency_id`
+
+	return s.list(ctx, sqlQuery, prID)
+}
+
+// ListDependents returns all dependents of the pull request.
+func (s *PullReqDependencyStore) ListDependents(
+	ctx context.Context,
+	prID int64,
+) ([]*types.PullReqDependency, error) {
+	const sqlQuery = pullreqDependencySelectBase + `
+	WHERE pullreq_dependency_depends_on_id = $1
+	ORDER BY pullreq_dependency_id`
+
+	return s.list(ctx, sqlQuery, prID)
+}
+
+func (s *PullReqDependencyStore) list(
+	ctx context.Context,
+	sqlQuery string,
+	prID int64,
+) ([]*types.PullReqDependency, error) {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*pullReqDependency
+	if err := db.SelectContext(ctx, &dst, sqlQuery, prID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list pull request dependencies")
+	}
+
+	result := make([]*types.PullReqDependency, len(dst))
+	for i, dep := range dst {
+		result[i] = mapPullReqDependency(dep)
+	}
+
+	return result, nil
+}
+
+func mapPullReqDependency(v *pullReqDependency) *types.PullReqDependency {
+	return (*types.PullReqDependency)(v) // the two types are identical, except for the tags
+}
+
+func mapInternalPullReqDependency(v *types.PullReqDependency) *pullReqDependency {
+	return (*pullReqDependency)(v) // the two types are identical, except for the tags
+}
@@ -0,0 +1,81 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var _ store.PullReqMentionStore = (*PullReqMentionStore)(nil)
+
+// NewPullReqMentionStore returns a new PullReqMentionStore.
+func NewPullReqMentionStore(db *sqlx.DB) *PullReqMentionStore {
+	return &PullReqMentionStore{
+		db: db,
+	}
+}
+
+// PullReqMentionStore implements store.PullReqMentionStore backed by a relational database.
+type PullReqMentionStore struct {
+	db *sqlx.DB
+}
+
+// Add records that the principals have been mentioned in the pull request.
+func (s *PullReqMentionStore) Add(ctx context.Context, prID int64, principalIDs []int64) error {
+	if len(principalIDs) == 0 {
+		return nil
+	}
+
+	// selecting from the principals table skips the IDs of principals that don't exist.
+	principals := database.Builder.
+		Select().
+		Column("CAST(? AS BIGINT)", prID).
+		Column("principal_id").
+		Column("CAST(? AS BIGINT)", time.Now().UnixMilli()).
+		From("principals").
+		Where(squirrel.Eq{"principal_id": principalIDs})
+
+	stmt := database.Builder.
+		Insert("pullreq_mentions").
+		Columns(
+			"pullreq_mention_pullreq_id",
+			"pullreq_mention_principal_id",
+			"pullreq_mention_created",
+		).
+		Select(principals).
+		Suffix("ON CONFLICT DO NOTHING")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to add pull request mentions")
+	}
+
+	return nil
+}
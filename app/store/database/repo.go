@@ -16,6 +16,7 @@ package database
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
@@ -29,7 +30,9 @@ import (
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
+	"github.com/Masterminds/squirrel"
 	"github.com/jmoiron/sqlx"
+	sqlxtypes "github.com/jmoiron/sqlx/types"
 	"github.com/pkg/errors"
 )
 
@@ -82,6 +85,8 @@ type repository struct {
 	NumMergedPulls int `db:"repo_num_merged_pulls"`
 
 	Importing bool `db:"repo_importing"`
+
+	Metadata sqlxtypes.JSONText `db:"repo_metadata"`
 }
 
 const (
@@ -106,7 +111,8 @@ const (
 		,repo_num_closed_pulls
 		,repo_num_open_pulls
 		,repo_num_merged_pulls
-		,repo_importing`
+		,repo_importing
+		,repo_metadata`
 
 	repoSelectBase = `
 		SELECT` + repoColumnsForJoin + `
@@ -144,6 +150,7 @@ func (s *RepoStore) FindByUID(ctx context.Context, spaceID int64, uid string) (*
 }
 
 // FindByRef finds the repo using the repoRef as either the id or the repo path.
+// A repo path that doesn't match any repo is resolved using the repo aliases of the space.
 func (s *RepoStore) FindByRef(ctx context.Context, repoRef string) (*types.Repository, error) {
 	// ASSUMPTION: digits only is not a valid repo path
 	id, err := strconv.ParseInt(repoRef, 10, 64)
@@ -157,12 +164,33 @@ func (s *RepoStore) FindByRef(ctx context.Context, repoRef string) (*types.Repos
 			return nil, fmt.Errorf("failed to get space path: %w", err)
 		}
 
-		return s.FindByUID(ctx, pathObject.SpaceID, repoUID)
+		repo, err := s.FindByUID(ctx, pathObject.SpaceID, repoUID)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return s.findByAlias(ctx, pathObject.SpaceID, repoUID)
+		}
+
+		return repo, err
 	}
 
 	return s.Find(ctx, id)
 }
 
+// findByAlias finds the repo that has an alias with the given UID in the given space ID.
+func (s *RepoStore) findByAlias(ctx context.Context, spaceID int64, alias string) (*types.Repository, error) {
+	const sqlQuery = repoSelectBase + `
+		INNER JOIN repo_aliases ON repo_alias_repo_id = repo_id
+		WHERE repo_alias_space_id = $1 AND LOWER(repo_alias_uid) = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := new(repository)
+	if err := db.GetContext(ctx, dst, sqlQuery, spaceID, strings.ToLower(alias)); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find repo by alias")
+	}
+
+	return s.mapToRepo(ctx, dst)
+}
+
 // Create creates a new repository.
 func (s *RepoStore) Create(ctx context.Context, repo *types.Repository) error {
 	const sqlQuery = `
@@ -187,6 +215,7 @@ func (s *RepoStore) Create(ctx context.Context, repo *types.Repository) error {
 			,repo_num_open_pulls
 			,repo_num_merged_pulls
 			,repo_importing
+			,repo_metadata
 		) values (
 			:repo_version
 			,:repo_parent_id
@@ -208,6 +237,7 @@ func (s *RepoStore) Create(ctx context.Context, repo *types.Repository) error {
 			,:repo_num_open_pulls
 			,:repo_num_merged_pulls
 			,:repo_importing
+			,:repo_metadata
 		) RETURNING repo_id`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -250,6 +280,7 @@ func (s *RepoStore) Update(ctx context.Context, repo *types.Repository) error {
 			,repo_num_open_pulls = :repo_num_open_pulls
 			,repo_num_merged_pulls = :repo_num_merged_pulls
 			,repo_importing = :repo_importing
+			,repo_metadata = :repo_metadata
 		WHERE repo_id = :repo_id AND repo_version = :repo_version - 1`
 
 	dbRepo := mapToInternalRepo(repo)
@@ -402,6 +433,23 @@ func (s *RepoStore) List(ctx context.Context, parentID int64, opts *types.RepoFi
 		From("repositories").
 		Where("repo_parent_id = ?", fmt.Sprint(parentID))
 
+	return s.list(ctx, stmt, opts)
+}
+
+// Search returns a list of repos from all spaces matching the query of the filter.
+func (s *RepoStore) Search(ctx context.Context, opts *types.RepoFilter) ([]*types.Repository, error) {
+	stmt := database.Builder.
+		Select(repoColumnsForJoin).
+		From("repositories")
+
+	return s.list(ctx, stmt, opts)
+}
+
+func (s *RepoStore) list(
+	ctx context.Context,
+	stmt squirrel.SelectBuilder,
+	opts *types.RepoFilter,
+) ([]*types.Repository, error) {
 	if opts.Query != "" {
 		stmt = stmt.Where("LOWER(repo_uid) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(opts.Query)))
 	}
@@ -493,6 +541,10 @@ func (s *RepoStore) mapToRepo(
 		// Path: is set below
 	}
 
+	if err = json.Unmarshal(in.Metadata, &res.Metadata); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal repo metadata: %w", err)
+	}
+
 	res.Path, err = s.getRepoPath(ctx, in.ParentID, in.UID)
 	if err != nil {
 		return nil, err
@@ -546,6 +598,10 @@ func (s *RepoStore) mapToRepoSizes(
 }
 
 func mapToInternalRepo(in *types.Repository) *repository {
+	metadata := in.Metadata
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
 	return &repository{
 		ID:             in.ID,
 		Version:        in.Version,
@@ -568,5 +624,6 @@ func mapToInternalRepo(in *types.Repository) *repository {
 		NumOpenPulls:   in.NumOpenPulls,
 		NumMergedPulls: in.NumMergedPulls,
 		Importing:      in.Importing,
+		Metadata:       EncodeToSQLXJSON(metadata),
 	}
 }
@@ -0,0 +1,217 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.RepoAliasStore = (*RepoAliasStore)(nil)
+
+// NewRepoAliasStore returns a new RepoAliasStore.
+func NewRepoAliasStore(db *sqlx.DB) *RepoAliasStore {
+	return &RepoAliasStore{
+		db: db,
+	}
+}
+
+// RepoAliasStore implements store.RepoAliasStore backed by a relational database.
+type RepoAliasStore struct {
+	db *sqlx.DB
+}
+
+// repoAlias is used to fetch repository alias data from the database.
+type repoAlias struct {
+	ID        int64  `db:"repo_alias_id"`
+	RepoID    int64  `db:"repo_alias_repo_id"`
+	SpaceID   int64  `db:"repo_alias_space_id"`
+	UID       string `db:"repo_alias_uid"`
+	CreatedBy int64  `db:"repo_alias_created_by"`
+	Created   int64  `db:"repo_alias_created"`
+}
+
+const (
+	repoAliasColumns = `
+		 repo_alias_id
+		,repo_alias_repo_id
+		,repo_alias_space_id
+		,repo_alias_uid
+		,repo_alias_created_by
+		,repo_alias_created`
+
+	repoAliasSelectBase = `
+	SELECT` + repoAliasColumns + `
+	FROM repo_aliases`
+)
+
+// Find finds the repository alias by id.
+func (s *RepoAliasStore) Find(ctx context.Context, id int64) (*types.RepoAlias, error) {
+	const sqlQuery = repoAliasSelectBase + `
+	WHERE repo_alias_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &repoAlias{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find repo alias")
+	}
+
+	return mapRepoAlias(dst), nil
+}
+
+// FindByUID finds the repository alias with the given uid (case insensitive) in the space.
+func (s *RepoAliasStore) FindByUID(ctx context.Context, spaceID int64, uid string) (*types.RepoAlias, error) {
+	const sqlQuery = repoAliasSelectBase + `
+	WHERE repo_alias_space_id = $1 AND LOWER(repo_alias_uid) = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &repoAlias{}
+	if err := db.GetContext(ctx, dst, sqlQuery, spaceID, strings.ToLower(uid)); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find repo alias by uid")
+	}
+
+	return mapRepoAlias(dst), nil
+}
+
+// Create creates a new repository alias.
+func (s *RepoAliasStore) Create(ctx context.Context, alias *types.RepoAlias) error {
+	const sqlQuery = `
+	INSERT INTO repo_aliases (
+		 repo_alias_repo_id
+		,repo_alias_space_id
+		,repo_alias_uid
+		,repo_alias_created_by
+		,repo_alias_created
+	) values (
+		 :repo_alias_repo_id
+		,:repo_alias_space_id
+		,:repo_alias_uid
+		,:repo_alias_created_by
+		,:repo_alias_created
+	) RETURNING repo_alias_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalRepoAlias(alias))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind repo alias object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&alias.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to insert repo alias")
+	}
+
+	return nil
+}
+
+// Delete deletes the repository alias.
+func (s *RepoAliasStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+	DELETE FROM repo_aliases
+	WHERE repo_alias_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete repo alias")
+	}
+
+	return nil
+}
+
+// List returns all aliases of the repository.
+func (s *RepoAliasStore) List(ctx context.Context, repoID int64) ([]*types.RepoAlias, error) {
+	const sqlQuery = repoAliasSelectBase + `
+	WHERE repo_alias_repo_id = $1
+	ORDER BY repo_alias_uid`
+
+	return s.list(ctx, sqlQuery, repoID)
+}
+
+// CountAll returns the number of repository aliases of all repositories.
+func (s *RepoAliasStore) CountAll(ctx context.Context) (int64, error) {
+	const sqlQuery = `
+	SELECT count(*)
+	FROM repo_aliases`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err := db.QueryRowContext(ctx, sqlQuery).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(err, "Failed to count repo aliases")
+	}
+
+	return count, nil
+}
+
+// ListAll returns a page of repository aliases of all repositories.
+func (s *RepoAliasStore) ListAll(ctx context.Context, opts *types.Pagination) ([]*types.RepoAlias, error) {
+	const sqlQuery = repoAliasSelectBase + `
+	ORDER BY repo_alias_id
+	LIMIT $1 OFFSET $2`
+
+	return s.list(ctx, sqlQuery, database.Limit(opts.Size), database.Offset(opts.Page, opts.Size))
+}
+
+func (s *RepoAliasStore) list(
+	ctx context.Context,
+	sqlQuery string,
+	args ...any,
+) ([]*types.RepoAlias, error) {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*repoAlias
+	if err := db.SelectContext(ctx, &dst, sqlQuery, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list repo aliases")
+	}
+
+	result := make([]*types.RepoAlias, len(dst))
+	for i, alias := range dst {
+		result[i] = mapRepoAlias(alias)
+	}
+
+	return result, nil
+}
+
+func mapRepoAlias(v *repoAlias) *types.RepoAlias {
+	return &types.RepoAlias{
+		ID:        v.ID,
+		RepoID:    v.RepoID,
+		SpaceID:   v.SpaceID,
+		UID:       v.UID,
+		CreatedBy: v.CreatedBy,
+		Created:   v.Created,
+	}
+}
+
+func mapInternalRepoAlias(v *types.RepoAlias) *repoAlias {
+	return &repoAlias{
+		ID:        v.ID,
+		RepoID:    v.RepoID,
+		SpaceID:   v.SpaceID,
+		UID:       v.UID,
+		CreatedBy: v.CreatedBy,
+		Created:   v.Created,
+	}
+}
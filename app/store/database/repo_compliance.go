@@ -0,0 +1,166 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.RepoComplianceStore = (*RepoComplianceStore)(nil)
+
+// NewRepoComplianceStore returns a new RepoComplianceStore.
+func NewRepoComplianceStore(db *sqlx.DB) *RepoComplianceStore {
+	return &RepoComplianceStore{
+		db: db,
+	}
+}
+
+// RepoComplianceStore implements store.RepoComplianceStore backed by a relational database.
+type RepoComplianceStore struct {
+	db *sqlx.DB
+}
+
+// repoCompliance is used to fetch repository compliance data from the database.
+type repoCompliance struct {
+	RepoID       int64  `db:"repo_compliance_repo_id"`
+	RepoUID      string `db:"repo_uid"`
+	MissingFiles string `db:"repo_compliance_missing_files"`
+	Checked      int64  `db:"repo_compliance_checked"`
+}
+
+const (
+	repoComplianceSelectBase = `
+	SELECT
+		 repo_compliance_repo_id
+		,repo_uid
+		,repo_compliance_missing_files
+		,repo_compliance_checked
+	FROM repo_compliance
+	INNER JOIN repositories ON repo_id = repo_compliance_repo_id`
+)
+
+// Find returns the latest compliance result of the repository.
+func (s *RepoComplianceStore) Find(ctx context.Context, repoID int64) (*types.RepoCompliance, error) {
+	const sqlQuery = repoComplianceSelectBase + `
+	WHERE repo_compliance_repo_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &repoCompliance{}
+	if err := db.GetContext(ctx, dst, sqlQuery, repoID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find repository compliance")
+	}
+
+	return mapRepoCompliance(dst), nil
+}
+
+// Upsert creates a new or replaces the existing compliance result of the repository.
+func (s *RepoComplianceStore) Upsert(ctx context.Context, compliance *types.RepoCompliance) error {
+	const sqlQuery = `
+	INSERT INTO repo_compliance (
+		 repo_compliance_repo_id
+		,repo_compliance_missing_files
+		,repo_compliance_checked
+	) VALUES (
+		 :repo_compliance_repo_id
+		,:repo_compliance_missing_files
+		,:repo_compliance_checked
+	)
+	ON CONFLICT (repo_compliance_repo_id) DO
+	UPDATE SET
+		 repo_compliance_missing_files = :repo_compliance_missing_files
+		,repo_compliance_checked = :repo_compliance_checked`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalRepoCompliance(compliance))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind repository compliance object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Upsert query failed")
+	}
+
+	return nil
+}
+
+// Delete deletes the compliance result of the repository.
+func (s *RepoComplianceStore) Delete(ctx context.Context, repoID int64) error {
+	const sqlQuery = `
+	DELETE FROM repo_compliance
+	WHERE repo_compliance_repo_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, repoID); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete repository compliance")
+	}
+
+	return nil
+}
+
+// List returns the compliance results of all repositories directly in the space.
+func (s *RepoComplianceStore) List(ctx context.Context, spaceID int64) ([]*types.RepoCompliance, error) {
+	const sqlQuery = repoComplianceSelectBase + `
+	WHERE repo_parent_id = $1
+	ORDER BY repo_uid`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*repoCompliance
+	if err := db.SelectContext(ctx, &dst, sqlQuery, spaceID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list repository compliance")
+	}
+
+	result := make([]*types.RepoCompliance, len(dst))
+	for i, c := range dst {
+		result[i] = mapRepoCompliance(c)
+	}
+
+	return result, nil
+}
+
+func mapRepoCompliance(v *repoCompliance) *types.RepoCompliance {
+	missingFiles := []string{}
+	if v.MissingFiles != "" {
+		missingFiles = strings.Split(v.MissingFiles, "\n")
+	}
+
+	return &types.RepoCompliance{
+		RepoID:       v.RepoID,
+		RepoUID:      v.RepoUID,
+		Compliant:    len(missingFiles) == 0,
+		MissingFiles: missingFiles,
+		Checked:      v.Checked,
+	}
+}
+
+func mapInternalRepoCompliance(v *types.RepoCompliance) *repoCompliance {
+	return &repoCompliance{
+		RepoID:       v.RepoID,
+		RepoUID:      v.RepoUID,
+		MissingFiles: strings.Join(v.MissingFiles, "\n"),
+		Checked:      v.Checked,
+	}
+}
@@ -0,0 +1,302 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var _ store.RepoMirrorStore = (*RepoMirrorStore)(nil)
+
+// NewRepoMirrorStore returns a new RepoMirrorStore.
+func NewRepoMirrorStore(db *sqlx.DB) *RepoMirrorStore {
+	return &RepoMirrorStore{
+		db: db,
+	}
+}
+
+// RepoMirrorStore implements store.RepoMirrorStore backed by a relational database.
+type RepoMirrorStore struct {
+	db *sqlx.DB
+}
+
+type repoMirror struct {
+	RepoID           int64                `db:"mirror_repo_id"`
+	RemoteURL        string               `db:"mirror_remote_url"`
+	Username         string               `db:"mirror_username"`
+	Password         string               `db:"mirror_password"`
+	SyncInterval     int64                `db:"mirror_sync_interval"`
+	Disabled         bool                 `db:"mirror_disabled"`
+	SyncState        enum.MirrorSyncState `db:"mirror_sync_state"`
+	SyncError        string               `db:"mirror_sync_error"`
+	LastSyncStarted  int64                `db:"mirror_last_sync_started"`
+	LastSyncFinished int64                `db:"mirror_last_sync_finished"`
+	LastSynced       int64                `db:"mirror_last_synced"`
+	NextSync         int64                `db:"mirror_next_sync"`
+	CreatedBy        int64                `db:"mirror_created_by"`
+	Created          int64                `db:"mirror_created"`
+	Updated          int64                `db:"mirror_updated"`
+	Version          int64                `db:"mirror_version"`
+}
+
+const repoMirrorColumns = `
+	 mirror_repo_id
+	,mirror_remote_url
+	,mirror_username
+	,mirror_password
+	,mirror_sync_interval
+	,mirror_disabled
+	,mirror_sync_state
+	,mirror_sync_error
+	,mirror_last_sync_started
+	,mirror_last_sync_finished
+	,mirror_last_synced
+	,mirror_next_sync
+	,mirror_created_by
+	,mirror_created
+	,mirror_updated
+	,mirror_version`
+
+// Find returns the mirror configuration of the repository.
+func (s *RepoMirrorStore) Find(ctx context.Context, repoID int64) (*types.RepositoryMirror, error) {
+	const sqlQuery = `
+		SELECT` + repoMirrorColumns + `
+		FROM repository_mirrors
+		WHERE mirror_repo_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &repoMirror{}
+	if err := db.GetContext(ctx, dst, sqlQuery, repoID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find repository mirror")
+	}
+
+	return mapToRepoMirror(dst), nil
+}
+
+// Create creates the mirror configuration of a repository.
+func (s *RepoMirrorStore) Create(ctx context.Context, mirror *types.RepositoryMirror) error {
+	const sqlQuery = `
+		INSERT INTO repository_mirrors (` + repoMirrorColumns + `
+		) VALUES (
+			 :mirror_repo_id
+			,:mirror_remote_url
+			,:mirror_username
+			,:mirror_password
+			,:mirror_sync_interval
+			,:mirror_disabled
+			,:mirror_sync_state
+			,:mirror_sync_error
+			,:mirror_last_sync_started
+			,:mirror_last_sync_finished
+			,:mirror_last_synced
+			,:mirror_next_sync
+			,:mirror_created_by
+			,:mirror_created
+			,:mirror_updated
+			,:mirror_version
+		)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalRepoMirror(mirror))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind repository mirror object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert repository mirror query failed")
+	}
+
+	return nil
+}
+
+// Update updates the mirror configuration of a repository.
+func (s *RepoMirrorStore) Update(ctx context.Context, mirror *types.RepositoryMirror) error {
+	const sqlQuery = `
+		UPDATE repository_mirrors
+		SET
+			 mirror_remote_url = :mirror_remote_url
+			,mirror_username = :mirror_username
+			,mirror_password = :mirror_password
+			,mirror_sync_interval = :mirror_sync_interval
+			,mirror_disabled = :mirror_disabled
+			,mirror_sync_state = :mirror_sync_state
+			,mirror_sync_error = :mirror_sync_error
+			,mirror_last_sync_started = :mirror_last_sync_started
+			,mirror_last_sync_finished = :mirror_last_sync_finished
+			,mirror_last_synced = :mirror_last_synced
+			,mirror_next_sync = :mirror_next_sync
+			,mirror_updated = :mirror_updated
+			,mirror_version = :mirror_version
+		WHERE mirror_repo_id = :mirror_repo_id AND mirror_version = :mirror_version - 1`
+
+	dbMirror := mapToInternalRepoMirror(mirror)
+
+	dbMirror.Version++
+	dbMirror.Updated = time.Now().UnixMilli()
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, dbMirror)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind repository mirror object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to update repository mirror")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrVersionConflict
+	}
+
+	mirror.Version = dbMirror.Version
+	mirror.Updated = dbMirror.Updated
+
+	return nil
+}
+
+// UpdateOptLock updates the mirror configuration using the optimistic locking mechanism.
+func (s *RepoMirrorStore) UpdateOptLock(ctx context.Context,
+	mirror *types.RepositoryMirror,
+	mutateFn func(mirror *types.RepositoryMirror) error,
+) (*types.RepositoryMirror, error) {
+	for {
+		dup := *mirror
+
+		err := mutateFn(&dup)
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.Update(ctx, &dup)
+		if err == nil {
+			return &dup, nil
+		}
+		if !errors.Is(err, gitness_store.ErrVersionConflict) {
+			return nil, err
+		}
+
+		mirror, err = s.Find(ctx, mirror.RepoID)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Delete deletes the mirror configuration of the repository.
+func (s *RepoMirrorStore) Delete(ctx context.Context, repoID int64) error {
+	const sqlQuery = `
+		DELETE FROM repository_mirrors
+		WHERE mirror_repo_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, repoID); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete repository mirror")
+	}
+
+	return nil
+}
+
+// ListDue lists the enabled mirrors whose next synchronization is at or before the provided time.
+func (s *RepoMirrorStore) ListDue(ctx context.Context, now int64, limit int) ([]*types.RepositoryMirror, error) {
+	stmt := database.Builder.
+		Select(repoMirrorColumns).
+		From("repository_mirrors").
+		Where("mirror_disabled = false").
+		Where("mirror_next_sync <= ?", now).
+		OrderBy("mirror_next_sync").
+		Limit(uint64(limit))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*repoMirror{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list due repository mirrors query")
+	}
+
+	mirrors := make([]*types.RepositoryMirror, len(dst))
+	for i, m := range dst {
+		mirrors[i] = mapToRepoMirror(m)
+	}
+
+	return mirrors, nil
+}
+
+func mapToRepoMirror(in *repoMirror) *types.RepositoryMirror {
+	return &types.RepositoryMirror{
+		RepoID:           in.RepoID,
+		RemoteURL:        in.RemoteURL,
+		Username:         in.Username,
+		Password:         in.Password,
+		SyncInterval:     in.SyncInterval,
+		Disabled:         in.Disabled,
+		SyncState:        in.SyncState,
+		SyncError:        in.SyncError,
+		LastSyncStarted:  in.LastSyncStarted,
+		LastSyncFinished: in.LastSyncFinished,
+		LastSynced:       in.LastSynced,
+		NextSync:         in.NextSync,
+		CreatedBy:        in.CreatedBy,
+		Created:          in.Created,
+		Updated:          in.Updated,
+		Version:          in.Version,
+	}
+}
+
+func mapToInternalRepoMirror(in *types.RepositoryMirror) *repoMirror {
+	return &repoMirror{
+		RepoID:           in.RepoID,
+		RemoteURL:        in.RemoteURL,
+		Username:         in.Username,
+		Password:         in.Password,
+		SyncInterval:     in.SyncInterval,
+		Disabled:         in.Disabled,
+		SyncState:        in.SyncState,
+		SyncError:        in.SyncError,
+		LastSyncStarted:  in.LastSyncStarted,
+		LastSyncFinished: in.LastSyncFinished,
+		LastSynced:       in.LastSynced,
+		NextSync:         in.NextSync,
+		CreatedBy:        in.CreatedBy,
+		Created:          in.Created,
+		Updated:          in.Updated,
+		Version:          in.Version,
+	}
+}
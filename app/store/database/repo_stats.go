@@ -0,0 +1,187 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+	sqlxtypes "github.com/jmoiron/sqlx/types"
+)
+
+var _ store.RepoStatsStore = (*RepoStatsStore)(nil)
+
+// NewRepoStatsStore returns a new RepoStatsStore.
+func NewRepoStatsStore(db *sqlx.DB) *RepoStatsStore {
+	return &RepoStatsStore{
+		db: db,
+	}
+}
+
+// RepoStatsStore implements store.RepoStatsStore backed by a relational database.
+type RepoStatsStore struct {
+	db *sqlx.DB
+}
+
+type repoStats struct {
+	RepoID        int64              `db:"repo_stats_repo_id"`
+	DiskSize      int64              `db:"repo_stats_disk_size"`
+	LooseObjects  int                `db:"repo_stats_loose_objects"`
+	LooseSize     int64              `db:"repo_stats_loose_size"`
+	PackedObjects int                `db:"repo_stats_packed_objects"`
+	Packs         int                `db:"repo_stats_packs"`
+	PackSize      int64              `db:"repo_stats_pack_size"`
+	Blobs         int                `db:"repo_stats_blobs"`
+	BlobsSize     int64              `db:"repo_stats_blobs_size"`
+	LargestBlobs  sqlxtypes.JSONText `db:"repo_stats_largest_blobs"`
+	LFSObjects    int                `db:"repo_stats_lfs_objects"`
+	LFSSize       int64              `db:"repo_stats_lfs_size"`
+	Updated       int64              `db:"repo_stats_updated"`
+}
+
+const repoStatsColumns = `
+	 repo_stats_repo_id
+	,repo_stats_disk_size
+	,repo_stats_loose_objects
+	,repo_stats_loose_size
+	,repo_stats_packed_objects
+	,repo_stats_packs
+	,repo_stats_pack_size
+	,repo_stats_blobs
+	,repo_stats_blobs_size
+	,repo_stats_largest_blobs
+	,repo_stats_lfs_objects
+	,repo_stats_lfs_size
+	,repo_stats_updated`
+
+// Find returns the statistics of the repository.
+func (s *RepoStatsStore) Find(ctx context.Context, repoID int64) (*types.RepositoryStats, error) {
+	const sqlQuery = `
+		SELECT` + repoStatsColumns + `
+		FROM repository_stats
+		WHERE repo_stats_repo_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &repoStats{}
+	if err := db.GetContext(ctx, dst, sqlQuery, repoID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find repository stats")
+	}
+
+	return mapToRepoStats(dst)
+}
+
+// Upsert creates or replaces the statistics of the repository.
+func (s *RepoStatsStore) Upsert(ctx context.Context, stats *types.RepositoryStats) error {
+	const sqlQuery = `
+		INSERT INTO repository_stats (` + repoStatsColumns + `
+		) VALUES (
+			 :repo_stats_repo_id
+			,:repo_stats_disk_size
+			,:repo_stats_loose_objects
+			,:repo_stats_loose_size
+			,:repo_stats_packed_objects
+			,:repo_stats_packs
+			,:repo_stats_pack_size
+			,:repo_stats_blobs
+			,:repo_stats_blobs_size
+			,:repo_stats_largest_blobs
+			,:repo_stats_lfs_objects
+			,:repo_stats_lfs_size
+			,:repo_stats_updated
+		)
+		ON CONFLICT (repo_stats_repo_id) DO
+		UPDATE SET
+			 repo_stats_disk_size = EXCLUDED.repo_stats_disk_size
+			,repo_stats_loose_objects = EXCLUDED.repo_stats_loose_objects
+			,repo_stats_loose_size = EXCLUDED.repo_stats_loose_size
+			,repo_stats_packed_objects = EXCLUDED.repo_stats_packed_objects
+			,repo_stats_packs = EXCLUDED.repo_stats_packs
+			,repo_stats_pack_size = EXCLUDED.repo_stats_pack_size
+			,repo_stats_blobs = EXCLUDED.repo_stats_blobs
+			,repo_stats_blobs_size = EXCLUDED.repo_stats_blobs_size
+			,repo_stats_largest_blobs = EXCLUDED.repo_stats_largest_blobs
+			,repo_stats_lfs_objects = EXCLUDED.repo_stats_lfs_objects
+			,repo_stats_lfs_size = EXCLUDED.repo_stats_lfs_size
+			,repo_stats_updated = EXCLUDED.repo_stats_updated`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalRepoStats(stats))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind repository stats object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Upsert repository stats query failed")
+	}
+
+	return nil
+}
+
+func mapToRepoStats(in *repoStats) (*types.RepositoryStats, error) {
+	largestBlobs := []types.RepositoryStatsBlob{}
+	if err := json.Unmarshal(in.LargestBlobs, &largestBlobs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal largest blobs of repository stats: %w", err)
+	}
+
+	return &types.RepositoryStats{
+		RepoID:        in.RepoID,
+		DiskSize:      in.DiskSize,
+		LooseObjects:  in.LooseObjects,
+		LooseSize:     in.LooseSize,
+		PackedObjects: in.PackedObjects,
+		Packs:         in.Packs,
+		PackSize:      in.PackSize,
+		Blobs:         in.Blobs,
+		BlobsSize:     in.BlobsSize,
+		LargestBlobs:  largestBlobs,
+		LFS: types.RepositoryStatsLFSUsage{
+			Objects: in.LFSObjects,
+			Size:    in.LFSSize,
+		},
+		Updated: in.Updated,
+	}, nil
+}
+
+func mapToInternalRepoStats(in *types.RepositoryStats) *repoStats {
+	largestBlobs := in.LargestBlobs
+	if largestBlobs == nil {
+		largestBlobs = []types.RepositoryStatsBlob{}
+	}
+
+	return &repoStats{
+		RepoID:        in.RepoID,
+		DiskSize:      in.DiskSize,
+		LooseObjects:  in.LooseObjects,
+		LooseSize:     in.LooseSize,
+		PackedObjects: in.PackedObjects,
+		Packs:         in.Packs,
+		PackSize:      in.PackSize,
+		Blobs:         in.Blobs,
+		BlobsSize:     in.BlobsSize,
+		LargestBlobs:  EncodeToSQLXJSON(largestBlobs),
+		LFSObjects:    in.LFS.Objects,
+		LFSSize:       in.LFS.Size,
+		Updated:       in.Updated,
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.RequiredFileStore = (*RequiredFileStore)(nil)
+
+// NewRequiredFileStore returns a new RequiredFileStore.
+func NewRequiredFileStore(db *sqlx.DB) *RequiredFileStore {
+	return &RequiredFileStore{
+		db: db,
+	}
+}
+
+// RequiredFileStore implements store.RequiredFileStore backed by a relational database.
+type RequiredFileStore struct {
+	db *sqlx.DB
+}
+
+// requiredFile is used to fetch required file policy data from the database.
+type requiredFile struct {
+	ID            int64  `db:"required_file_id"`
+	SpaceID       int64  `db:"required_file_space_id"`
+	Path          string `db:"required_file_path"`
+	Template      string `db:"required_file_template"`
+	CreatePullReq bool   `db:"required_file_create_pullreq"`
+
+	CreatedBy int64 `db:"required_file_created_by"`
+	Created   int64 `db:"required_file_created"`
+	Updated   int64 `db:"required_file_updated"`
+}
+
+const (
+	requiredFileColumns = `
+		 required_file_id
+		,required_file_space_id
+		,required_file_path
+		,required_file_template
+		,required_file_create_pullreq
+		,required_file_created_by
+		,required_file_created
+		,required_file_updated`
+
+	requiredFileSelectBase = `
+	SELECT` + requiredFileColumns + `
+	FROM required_files`
+)
+
+// Find finds the required file policy by id.
+func (s *RequiredFileStore) Find(ctx context.Context, id int64) (*types.RequiredFile, error) {
+	const sqlQuery = requiredFileSelectBase + `
+	WHERE required_file_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &requiredFile{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find required file")
+	}
+
+	return mapRequiredFile(dst), nil
+}
+
+// Create creates a new required file policy.
+func (s *RequiredFileStore) Create(ctx context.Context, file *types.RequiredFile) error {
+	const sqlQuery = `
+	INSERT INTO required_files (
+		 required_file_space_id
+		,required_file_path
+		,required_file_template
+		,required_file_create_pullreq
+		,required_file_created_by
+		,required_file_created
+		,required_file_updated
+	) values (
+		 :required_file_space_id
+		,:required_file_path
+		,:required_file_template
+		,:required_file_create_pullreq
+		,:required_file_created_by
+		,:required_file_created
+		,:required_file_updated
+	) RETURNING required_file_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalRequiredFile(file))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind required file object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&file.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to insert required file")
+	}
+
+	return nil
+}
+
+// Update updates the path, the template and the pull request setting of the required file policy.
+func (s *RequiredFileStore) Update(ctx context.Context, file *types.RequiredFile) error {
+	const sqlQuery = `
+	UPDATE required_files
+	SET
+		 required_file_path = :required_file_path
+		,required_file_template = :required_file_template
+		,required_file_create_pullreq = :required_file_create_pullreq
+		,required_file_updated = :required_file_updated
+	WHERE required_file_id = :required_file_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbFile := mapInternalRequiredFile(file)
+	dbFile.Updated = time.Now().UnixMilli()
+
+	query, arg, err := db.BindNamed(sqlQuery, dbFile)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind required file object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to update required file")
+	}
+
+	file.Updated = dbFile.Updated
+
+	return nil
+}
+
+// Delete deletes the required file policy.
+func (s *RequiredFileStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+	DELETE FROM required_files
+	WHERE required_file_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete required file")
+	}
+
+	return nil
+}
+
+// List returns all required file policies defined directly in the space.
+func (s *RequiredFileStore) List(ctx context.Context, spaceID int64) ([]*types.RequiredFile, error) {
+	const sqlQuery = requiredFileSelectBase + `
+	WHERE required_file_space_id = $1
+	ORDER BY required_file_path`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*requiredFile
+	if err := db.SelectContext(ctx, &dst, sqlQuery, spaceID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list required files")
+	}
+
+	result := make([]*types.RequiredFile, len(dst))
+	for i, file := range dst {
+		result[i] = mapRequiredFile(file)
+	}
+
+	return result, nil
+}
+
+func mapRequiredFile(v *requiredFile) *types.RequiredFile {
+	return (*types.RequiredFile)(v) // the two types are identical, except for the tags
+}
+
+func mapInternalRequiredFile(v *types.RequiredFile) *requiredFile {
+	return (*requiredFile)(v) // the two types are identical, except for the tags
+}
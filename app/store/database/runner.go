@@ -0,0 +1,279 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+	sqlxtypes "github.com/jmoiron/sqlx/types"
+)
+
+var _ store.RunnerStore = (*RunnerStore)(nil)
+
+// NewRunnerStore returns a new RunnerStore.
+func NewRunnerStore(db *sqlx.DB) *RunnerStore {
+	return &RunnerStore{
+		db: db,
+	}
+}
+
+// RunnerStore implements store.RunnerStore backed by a relational database.
+type RunnerStore struct {
+	db *sqlx.DB
+}
+
+// runner is used to fetch runner data from the database.
+type runner struct {
+	ID            int64              `db:"runner_id"`
+	UID           string             `db:"runner_uid"`
+	Labels        sqlxtypes.JSONText `db:"runner_labels"`
+	OS            string             `db:"runner_os"`
+	Arch          string             `db:"runner_arch"`
+	Version       string             `db:"runner_version"`
+	State         enum.RunnerState   `db:"runner_state"`
+	CacheDir      string             `db:"runner_cache_dir"`
+	TokenHash     string             `db:"runner_token_hash"`
+	LastHeartbeat int64              `db:"runner_last_heartbeat"`
+	Created       int64              `db:"runner_created"`
+	Updated       int64              `db:"runner_updated"`
+}
+
+const (
+	runnerColumns = `
+		 runner_id
+		,runner_uid
+		,runner_labels
+		,runner_os
+		,runner_arch
+		,runner_version
+		,runner_state
+		,runner_cache_dir
+		,runner_token_hash
+		,runner_last_heartbeat
+		,runner_created
+		,runner_updated`
+
+	runnerSelectBase = `
+	SELECT` + runnerColumns + `
+	FROM runners`
+)
+
+// Find finds the runner by id.
+func (s *RunnerStore) Find(ctx context.Context, id int64) (*types.Runner, error) {
+	const sqlQuery = runnerSelectBase + `
+	WHERE runner_id = $1`
+
+	return s.find(ctx, sqlQuery, id)
+}
+
+// FindByUID finds the runner by uid.
+func (s *RunnerStore) FindByUID(ctx context.Context, uid string) (*types.Runner, error) {
+	const sqlQuery = runnerSelectBase + `
+	WHERE LOWER(runner_uid) = LOWER($1)`
+
+	return s.find(ctx, sqlQuery, uid)
+}
+
+// FindByTokenHash finds the runner that authenticates with the token of the given hash.
+func (s *RunnerStore) FindByTokenHash(ctx context.Context, tokenHash string) (*types.Runner, error) {
+	const sqlQuery = runnerSelectBase + `
+	WHERE runner_token_hash = $1`
+
+	return s.find(ctx, sqlQuery, tokenHash)
+}
+
+func (s *RunnerStore) find(ctx context.Context, sqlQuery string, arg any) (*types.Runner, error) {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &runner{}
+	if err := db.GetContext(ctx, dst, sqlQuery, arg); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find runner")
+	}
+
+	return mapRunner(dst)
+}
+
+// List returns all runners ordered by uid.
+func (s *RunnerStore) List(ctx context.Context) ([]*types.Runner, error) {
+	const sqlQuery = runnerSelectBase + `
+	ORDER BY LOWER(runner_uid)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*runner{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list runners")
+	}
+
+	runners := make([]*types.Runner, len(dst))
+	for i := range dst {
+		var err error
+		if runners[i], err = mapRunner(dst[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return runners, nil
+}
+
+// Create creates a new runner.
+func (s *RunnerStore) Create(ctx context.Context, r *types.Runner) error {
+	const sqlQuery = `
+	INSERT INTO runners (
+		 runner_uid
+		,runner_labels
+		,runner_os
+		,runner_arch
+		,runner_version
+		,runner_state
+		,runner_cache_dir
+		,runner_token_hash
+		,runner_last_heartbeat
+		,runner_created
+		,runner_updated
+	) VALUES (
+		 :runner_uid
+		,:runner_labels
+		,:runner_os
+		,:runner_arch
+		,:runner_version
+		,:runner_state
+		,:runner_cache_dir
+		,:runner_token_hash
+		,:runner_last_heartbeat
+		,:runner_created
+		,:runner_updated
+	)
+	RETURNING runner_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalRunner(r))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind runner object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&r.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// UpdateState updates the state of the runner.
+func (s *RunnerStore) UpdateState(ctx context.Context, r *types.Runner) error {
+	const sqlQuery = `
+	UPDATE runners
+	SET
+		 runner_state = :runner_state
+		,runner_updated = :runner_updated
+	WHERE runner_id = :runner_id`
+
+	return s.update(ctx, sqlQuery, r)
+}
+
+// UpdateHeartbeat updates the time of the last heartbeat of the runner.
+func (s *RunnerStore) UpdateHeartbeat(ctx context.Context, r *types.Runner) error {
+	const sqlQuery = `
+	UPDATE runners
+	SET
+		runner_last_heartbeat = :runner_last_heartbeat
+	WHERE runner_id = :runner_id`
+
+	return s.update(ctx, sqlQuery, r)
+}
+
+func (s *RunnerStore) update(ctx context.Context, sqlQuery string, r *types.Runner) error {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalRunner(r))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind runner object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Update query failed")
+	}
+
+	return nil
+}
+
+// Delete deletes the runner with the given id.
+func (s *RunnerStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+	DELETE FROM runners
+	WHERE runner_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(err, "Delete query failed")
+	}
+
+	return nil
+}
+
+func mapRunner(v *runner) (*types.Runner, error) {
+	labels := []string{}
+	if err := json.Unmarshal(v.Labels, &labels); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal runner labels: %w", err)
+	}
+
+	return &types.Runner{
+		ID:            v.ID,
+		UID:           v.UID,
+		Labels:        labels,
+		OS:            v.OS,
+		Arch:          v.Arch,
+		Version:       v.Version,
+		State:         v.State,
+		CacheDir:      v.CacheDir,
+		TokenHash:     v.TokenHash,
+		LastHeartbeat: v.LastHeartbeat,
+		Created:       v.Created,
+		Updated:       v.Updated,
+	}, nil
+}
+
+func mapInternalRunner(v *types.Runner) *runner {
+	labels := v.Labels
+	if labels == nil {
+		labels = []string{}
+	}
+
+	return &runner{
+		ID:            v.ID,
+		UID:           v.UID,
+		Labels:        EncodeToSQLXJSON(labels),
+		OS:            v.OS,
+		Arch:          v.Arch,
+		Version:       v.Version,
+		State:         v.State,
+		CacheDir:      v.CacheDir,
+		TokenHash:     v.TokenHash,
+		LastHeartbeat: v.LastHeartbeat,
+		Created:       v.Created,
+		Updated:       v.Updated,
+	}
+}
@@ -0,0 +1,224 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.SavedComparisonStore = (*SavedComparisonStore)(nil)
+
+// NewSavedComparisonStore returns a new SavedComparisonStore.
+func NewSavedComparisonStore(db *sqlx.DB) *SavedComparisonStore {
+	return &SavedComparisonStore{
+		db: db,
+	}
+}
+
+// SavedComparisonStore implements store.SavedComparisonStore backed by a relational database.
+type SavedComparisonStore struct {
+	db *sqlx.DB
+}
+
+// savedComparison is used to fetch saved comparison data from the database.
+type savedComparison struct {
+	ID          int64  `db:"saved_comparison_id"`
+	RepoID      int64  `db:"saved_comparison_repo_id"`
+	UID         string `db:"saved_comparison_uid"`
+	Description string `db:"saved_comparison_description"`
+	BaseRef     string `db:"saved_comparison_base_ref"`
+	HeadRef     string `db:"saved_comparison_head_ref"`
+
+	CreatedBy int64 `db:"saved_comparison_created_by"`
+	Created   int64 `db:"saved_comparison_created"`
+	Updated   int64 `db:"saved_comparison_updated"`
+}
+
+const (
+	savedComparisonColumns = `
+		 saved_comparison_id
+		,saved_comparison_repo_id
+		,saved_comparison_uid
+		,saved_comparison_description
+		,saved_comparison_base_ref
+		,saved_comparison_head_ref
+		,saved_comparison_created_by
+		,saved_comparison_created
+		,saved_comparison_updated`
+
+	savedComparisonSelectBase = `
+	SELECT` + savedComparisonColumns + `
+	FROM saved_comparisons`
+)
+
+// FindByUID finds the saved comparison with the given uid (case insensitive) in the repository.
+func (s *SavedComparisonStore) FindByUID(
+	ctx context.Context,
+	repoID int64,
+	uid string,
+) (*types.SavedComparison, error) {
+	const sqlQuery = savedComparisonSelectBase + `
+	WHERE saved_comparison_repo_id = $1 AND LOWER(saved_comparison_uid) = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &savedComparison{}
+	if err := db.GetContext(ctx, dst, sqlQuery, repoID, strings.ToLower(uid)); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find saved comparison by uid")
+	}
+
+	return mapSavedComparison(dst), nil
+}
+
+// Create creates a new saved comparison.
+func (s *SavedComparisonStore) Create(ctx context.Context, comparison *types.SavedComparison) error {
+	const sqlQuery = `
+	INSERT INTO saved_comparisons (
+		 saved_comparison_repo_id
+		,saved_comparison_uid
+		,saved_comparison_description
+		,saved_comparison_base_ref
+		,saved_comparison_head_ref
+		,saved_comparison_created_by
+		,saved_comparison_created
+		,saved_comparison_updated
+	) values (
+		 :saved_comparison_repo_id
+		,:saved_comparison_uid
+		,:saved_comparison_description
+		,:saved_comparison_base_ref
+		,:saved_comparison_head_ref
+		,:saved_comparison_created_by
+		,:saved_comparison_created
+		,:saved_comparison_updated
+	) RETURNING saved_comparison_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalSavedComparison(comparison))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind saved comparison object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&comparison.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to insert saved comparison")
+	}
+
+	return nil
+}
+
+// Update updates the uid, the description and the references of the saved comparison.
+func (s *SavedComparisonStore) Update(ctx context.Context, comparison *types.SavedComparison) error {
+	const sqlQuery = `
+	UPDATE saved_comparisons
+	SET
+		 saved_comparison_uid = :saved_comparison_uid
+		,saved_comparison_description = :saved_comparison_description
+		,saved_comparison_base_ref = :saved_comparison_base_ref
+		,saved_comparison_head_ref = :saved_comparison_head_ref
+		,saved_comparison_updated = :saved_comparison_updated
+	WHERE saved_comparison_id = :saved_comparison_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbComparison := mapInternalSavedComparison(comparison)
+	dbComparison.Updated = time.Now().UnixMilli()
+
+	query, arg, err := db.BindNamed(sqlQuery, dbComparison)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind saved comparison object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to update saved comparison")
+	}
+
+	comparison.Updated = dbComparison.Updated
+
+	return nil
+}
+
+// Delete deletes the saved comparison.
+func (s *SavedComparisonStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+	DELETE FROM saved_comparisons
+	WHERE saved_comparison_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete saved comparison")
+	}
+
+	return nil
+}
+
+// List returns all saved comparisons of the repository ordered by uid.
+func (s *SavedComparisonStore) List(ctx context.Context, repoID int64) ([]*types.SavedComparison, error) {
+	const sqlQuery = savedComparisonSelectBase + `
+	WHERE saved_comparison_repo_id = $1
+	ORDER BY LOWER(saved_comparison_uid)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*savedComparison
+	if err := db.SelectContext(ctx, &dst, sqlQuery, repoID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list saved comparisons")
+	}
+
+	result := make([]*types.SavedComparison, len(dst))
+	for i, comparison := range dst {
+		result[i] = mapSavedComparison(comparison)
+	}
+
+	return result, nil
+}
+
+func mapSavedComparison(v *savedComparison) *types.SavedComparison {
+	return &types.SavedComparison{
+		ID:          v.ID,
+		RepoID:      v.RepoID,
+		UID:         v.UID,
+		Description: v.Description,
+		BaseRef:     v.BaseRef,
+		HeadRef:     v.HeadRef,
+		CreatedBy:   v.CreatedBy,
+		Created:     v.Created,
+		Updated:     v.Updated,
+	}
+}
+
+func mapInternalSavedComparison(v *types.SavedComparison) *savedComparison {
+	return &savedComparison{
+		ID:          v.ID,
+		RepoID:      v.RepoID,
+		UID:         v.UID,
+		Description: v.Description,
+		BaseRef:     v.BaseRef,
+		HeadRef:     v.HeadRef,
+		CreatedBy:   v.CreatedBy,
+		Created:     v.Created,
+		Updated:     v.Updated,
+	}
+}
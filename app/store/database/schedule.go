@@ -0,0 +1,370 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var _ store.ScheduleStore = (*scheduleStore)(nil)
+
+type schedule struct {
+	ID          int64  `db:"schedule_id"`
+	UID         string `db:"schedule_uid"`
+	Description string `db:"schedule_description"`
+	PipelineID  int64  `db:"schedule_pipeline_id"`
+	RepoID      int64  `db:"schedule_repo_id"`
+	CreatedBy   int64  `db:"schedule_created_by"`
+	Cron        string `db:"schedule_cron"`
+	Timezone    string `db:"schedule_timezone"`
+	Branch      string `db:"schedule_branch"`
+	Disabled    bool   `db:"schedule_disabled"`
+	LastRun     int64  `db:"schedule_last_run"`
+	NextRun     int64  `db:"schedule_next_run"`
+	Created     int64  `db:"schedule_created"`
+	Updated     int64  `db:"schedule_updated"`
+	Version     int64  `db:"schedule_version"`
+}
+
+func mapInternalToSchedule(s *schedule) *types.Schedule {
+	return &types.Schedule{
+		ID:          s.ID,
+		UID:         s.UID,
+		Description: s.Description,
+		PipelineID:  s.PipelineID,
+		RepoID:      s.RepoID,
+		CreatedBy:   s.CreatedBy,
+		Cron:        s.Cron,
+		Timezone:    s.Timezone,
+		Branch:      s.Branch,
+		Disabled:    s.Disabled,
+		LastRun:     s.LastRun,
+		NextRun:     s.NextRun,
+		Created:     s.Created,
+		Updated:     s.Updated,
+		Version:     s.Version,
+	}
+}
+
+func mapInternalToScheduleList(schedules []*schedule) []*types.Schedule {
+	ret := make([]*types.Schedule, len(schedules))
+	for i, s := range schedules {
+		ret[i] = mapInternalToSchedule(s)
+	}
+	return ret
+}
+
+func mapScheduleToInternal(s *types.Schedule) *schedule {
+	return &schedule{
+		ID:          s.ID,
+		UID:         s.UID,
+		Description: s.Description,
+		PipelineID:  s.PipelineID,
+		RepoID:      s.RepoID,
+		CreatedBy:   s.CreatedBy,
+		Cron:        s.Cron,
+		Timezone:    s.Timezone,
+		Branch:      s.Branch,
+		Disabled:    s.Disabled,
+		LastRun:     s.LastRun,
+		NextRun:     s.NextRun,
+		Created:     s.Created,
+		Updated:     s.Updated,
+		Version:     s.Version,
+	}
+}
+
+// NewScheduleStore returns a new ScheduleStore.
+func NewScheduleStore(db *sqlx.DB) store.ScheduleStore {
+	return &scheduleStore{
+		db: db,
+	}
+}
+
+type scheduleStore struct {
+	db *sqlx.DB
+}
+
+const (
+	scheduleColumns = `
+		schedule_id
+		,schedule_uid
+		,schedule_description
+		,schedule_pipeline_id
+		,schedule_repo_id
+		,schedule_created_by
+		,schedule_cron
+		,schedule_timezone
+		,schedule_branch
+		,schedule_disabled
+		,schedule_last_run
+		,schedule_next_run
+		,schedule_created
+		,schedule_updated
+		,schedule_version
+	`
+)
+
+// FindByUID returns a schedule given a pipeline ID and a schedule UID.
+func (s *scheduleStore) FindByUID(ctx context.Context, pipelineID int64, uid string) (*types.Schedule, error) {
+	const findQueryStmt = `
+	SELECT` + scheduleColumns + `
+	FROM schedules
+	WHERE schedule_pipeline_id = $1 AND schedule_uid = $2`
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := new(schedule)
+	if err := db.GetContext(ctx, dst, findQueryStmt, pipelineID, uid); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find schedule")
+	}
+	return mapInternalToSchedule(dst), nil
+}
+
+// Create creates a new schedule in the datastore.
+func (s *scheduleStore) Create(ctx context.Context, sc *types.Schedule) error {
+	const scheduleInsertStmt = `
+	INSERT INTO schedules (
+		schedule_uid
+		,schedule_description
+		,schedule_pipeline_id
+		,schedule_repo_id
+		,schedule_created_by
+		,schedule_cron
+		,schedule_timezone
+		,schedule_branch
+		,schedule_disabled
+		,schedule_last_run
+		,schedule_next_run
+		,schedule_created
+		,schedule_updated
+		,schedule_version
+	) VALUES (
+		:schedule_uid
+		,:schedule_description
+		,:schedule_pipeline_id
+		,:schedule_repo_id
+		,:schedule_created_by
+		,:schedule_cron
+		,:schedule_timezone
+		,:schedule_branch
+		,:schedule_disabled
+		,:schedule_last_run
+		,:schedule_next_run
+		,:schedule_created
+		,:schedule_updated
+		,:schedule_version
+	) RETURNING schedule_id`
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(scheduleInsertStmt, mapScheduleToInternal(sc))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind schedule object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&sc.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Schedule query failed")
+	}
+
+	return nil
+}
+
+// Update tries to update a schedule in the datastore with optimistic locking.
+func (s *scheduleStore) Update(ctx context.Context, sc *types.Schedule) error {
+	const scheduleUpdateStmt = `
+	UPDATE schedules
+	SET
+		schedule_uid = :schedule_uid
+		,schedule_description = :schedule_description
+		,schedule_cron = :schedule_cron
+		,schedule_timezone = :schedule_timezone
+		,schedule_branch = :schedule_branch
+		,schedule_disabled = :schedule_disabled
+		,schedule_last_run = :schedule_last_run
+		,schedule_next_run = :schedule_next_run
+		,schedule_updated = :schedule_updated
+		,schedule_version = :schedule_version
+	WHERE schedule_id = :schedule_id AND schedule_version = :schedule_version - 1`
+	updatedAt := time.Now()
+	dbSchedule := mapScheduleToInternal(sc)
+
+	dbSchedule.Version++
+	dbSchedule.Updated = updatedAt.UnixMilli()
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(scheduleUpdateStmt, dbSchedule)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind schedule object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to update schedule")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrVersionConflict
+	}
+
+	sc.Version = dbSchedule.Version
+	sc.Updated = dbSchedule.Updated
+	return nil
+}
+
+// UpdateOptLock updates the schedule using the optimistic locking mechanism.
+func (s *scheduleStore) UpdateOptLock(ctx context.Context,
+	sc *types.Schedule,
+	mutateFn func(schedule *types.Schedule) error,
+) (*types.Schedule, error) {
+	for {
+		dup := *sc
+
+		err := mutateFn(&dup)
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.Update(ctx, &dup)
+		if err == nil {
+			return &dup, nil
+		}
+		if !errors.Is(err, gitness_store.ErrVersionConflict) {
+			return nil, err
+		}
+
+		sc, err = s.FindByUID(ctx, sc.PipelineID, sc.UID)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// List lists the schedules for a given pipeline ID.
+func (s *scheduleStore) List(
+	ctx context.Context,
+	pipelineID int64,
+	filter types.ListQueryFilter,
+) ([]*types.Schedule, error) {
+	stmt := database.Builder.
+		Select(scheduleColumns).
+		From("schedules").
+		Where("schedule_pipeline_id = ?", pipelineID)
+
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+
+	if filter.Query != "" {
+		stmt = stmt.Where("LOWER(schedule_uid) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(filter.Query)))
+	}
+
+	stmt = stmt.OrderBy("schedule_uid")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*schedule{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing custom list query")
+	}
+
+	return mapInternalToScheduleList(dst), nil
+}
+
+// Count of schedules under a given pipeline.
+func (s *scheduleStore) Count(ctx context.Context, pipelineID int64, filter types.ListQueryFilter) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("schedules").
+		Where("schedule_pipeline_id = ?", pipelineID)
+
+	if filter.Query != "" {
+		stmt = stmt.Where("LOWER(schedule_uid) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(filter.Query)))
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(err, "Failed executing count query")
+	}
+	return count, nil
+}
+
+// DeleteByUID deletes a schedule given a pipeline ID and a schedule UID.
+func (s *scheduleStore) DeleteByUID(ctx context.Context, pipelineID int64, uid string) error {
+	const scheduleDeleteStmt = `
+		DELETE FROM schedules
+		WHERE schedule_pipeline_id = $1 AND schedule_uid = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, scheduleDeleteStmt, pipelineID, uid); err != nil {
+		return database.ProcessSQLErrorf(err, "Could not delete schedule")
+	}
+
+	return nil
+}
+
+// ListDue lists the enabled schedules whose next run is at or before the provided time.
+func (s *scheduleStore) ListDue(ctx context.Context, now int64, limit int) ([]*types.Schedule, error) {
+	stmt := database.Builder.
+		Select(scheduleColumns).
+		From("schedules").
+		Where("schedule_disabled = false").
+		Where("schedule_next_run <= ?", now).
+		OrderBy("schedule_next_run").
+		Limit(uint64(limit))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*schedule{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list due schedules query")
+	}
+
+	return mapInternalToScheduleList(dst), nil
+}
@@ -47,7 +47,9 @@ const (
 	secret_data,
 	secret_created,
 	secret_updated,
-	secret_version
+	secret_version,
+	secret_connector,
+	secret_reference
 	`
 )
 
@@ -100,7 +102,9 @@ func (s *secretStore) Create(ctx context.Context, secret *types.Secret) error {
 		secret_data,
 		secret_created,
 		secret_updated,
-		secret_version
+		secret_version,
+		secret_connector,
+		secret_reference
 	) VALUES (
 		:secret_description,
 		:secret_space_id,
@@ -109,7 +113,9 @@ func (s *secretStore) Create(ctx context.Context, secret *types.Secret) error {
 		:secret_data,
 		:secret_created,
 		:secret_updated,
-		:secret_version
+		:secret_version,
+		:secret_connector,
+		:secret_reference
 	) RETURNING secret_id`
 	db := dbtx.GetAccessor(ctx, s.db)
 
@@ -132,6 +138,8 @@ func (s *secretStore) Update(ctx context.Context, p *types.Secret) error {
 		secret_description = :secret_description,
 		secret_uid = :secret_uid,
 		secret_data = :secret_data,
+		secret_connector = :secret_connector,
+		secret_reference = :secret_reference,
 		secret_updated = :secret_updated,
 		secret_version = :secret_version
 	WHERE secret_id = :secret_id AND secret_version = :secret_version - 1`
@@ -0,0 +1,143 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.SigningKeyStore = (*SigningKeyStore)(nil)
+
+// NewSigningKeyStore returns a new SigningKeyStore.
+func NewSigningKeyStore(db *sqlx.DB) *SigningKeyStore {
+	return &SigningKeyStore{
+		db: db,
+	}
+}
+
+// SigningKeyStore implements store.SigningKeyStore backed by a relational database.
+type SigningKeyStore struct {
+	db *sqlx.DB
+}
+
+// signingKey is used to fetch repository signing key data from the database.
+type signingKey struct {
+	RepoID     int64                 `db:"repo_signing_key_repo_id"`
+	Format     enum.SigningKeyFormat `db:"repo_signing_key_format"`
+	PrivateKey string                `db:"repo_signing_key_private_key"`
+
+	CreatedBy int64 `db:"repo_signing_key_created_by"`
+	Created   int64 `db:"repo_signing_key_created"`
+	Updated   int64 `db:"repo_signing_key_updated"`
+}
+
+const (
+	signingKeyColumns = `
+		 repo_signing_key_repo_id
+		,repo_signing_key_format
+		,repo_signing_key_private_key
+		,repo_signing_key_created_by
+		,repo_signing_key_created
+		,repo_signing_key_updated`
+
+	signingKeySelectBase = `
+	SELECT` + signingKeyColumns + `
+	FROM repo_signing_keys`
+)
+
+// Find finds the signing key of the repository.
+func (s *SigningKeyStore) Find(ctx context.Context, repoID int64) (*types.SigningKey, error) {
+	const sqlQuery = signingKeySelectBase + `
+	WHERE repo_signing_key_repo_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &signingKey{}
+	if err := db.GetContext(ctx, dst, sqlQuery, repoID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find signing key")
+	}
+
+	return mapSigningKey(dst), nil
+}
+
+// Upsert creates a new or replaces the existing signing key of the repository.
+func (s *SigningKeyStore) Upsert(ctx context.Context, key *types.SigningKey) error {
+	const sqlQuery = `
+	INSERT INTO repo_signing_keys (
+		 repo_signing_key_repo_id
+		,repo_signing_key_format
+		,repo_signing_key_private_key
+		,repo_signing_key_created_by
+		,repo_signing_key_created
+		,repo_signing_key_updated
+	) VALUES (
+		 :repo_signing_key_repo_id
+		,:repo_signing_key_format
+		,:repo_signing_key_private_key
+		,:repo_signing_key_created_by
+		,:repo_signing_key_created
+		,:repo_signing_key_updated
+	)
+	ON CONFLICT (repo_signing_key_repo_id) DO
+	UPDATE SET
+		 repo_signing_key_format = :repo_signing_key_format
+		,repo_signing_key_private_key = :repo_signing_key_private_key
+		,repo_signing_key_updated = :repo_signing_key_updated
+	RETURNING repo_signing_key_created_by, repo_signing_key_created`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalSigningKey(key))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind signing key object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&key.CreatedBy, &key.Created); err != nil {
+		return database.ProcessSQLErrorf(err, "Upsert query failed")
+	}
+
+	return nil
+}
+
+// Delete deletes the signing key of the repository.
+func (s *SigningKeyStore) Delete(ctx context.Context, repoID int64) error {
+	const sqlQuery = `
+	DELETE FROM repo_signing_keys
+	WHERE repo_signing_key_repo_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, repoID); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete signing key")
+	}
+
+	return nil
+}
+
+func mapSigningKey(v *signingKey) *types.SigningKey {
+	return (*types.SigningKey)(v) // the two types are identical, except for the tags
+}
+
+func mapInternalSigningKey(v *types.SigningKey) *signingKey {
+	return (*signingKey)(v) // the two types are identical, except for the tags
+}
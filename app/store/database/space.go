@@ -28,6 +28,7 @@ import (
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
+	"github.com/Masterminds/squirrel"
 	"github.com/guregu/null"
 	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
@@ -292,6 +293,23 @@ func (s *SpaceStore) List(ctx context.Context, id int64, opts *types.SpaceFilter
 		From("spaces").
 		Where("space_parent_id = ?", fmt.Sprint(id))
 
+	return s.list(ctx, stmt, opts)
+}
+
+// Search returns a list of spaces from all levels of the space hierarchy matching the query of the filter.
+func (s *SpaceStore) Search(ctx context.Context, opts *types.SpaceFilter) ([]*types.Space, error) {
+	stmt := database.Builder.
+		Select(spaceColumns).
+		From("spaces")
+
+	return s.list(ctx, stmt, opts)
+}
+
+func (s *SpaceStore) list(
+	ctx context.Context,
+	stmt squirrel.SelectBuilder,
+	opts *types.SpaceFilter,
+) ([]*types.Space, error) {
 	stmt = stmt.Limit(database.Limit(opts.Size))
 	stmt = stmt.Offset(database.Offset(opts.Page, opts.Size))
 
@@ -0,0 +1,237 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.SpacePinStore = (*SpacePinStore)(nil)
+
+// NewSpacePinStore returns a new SpacePinStore.
+func NewSpacePinStore(db *sqlx.DB) *SpacePinStore {
+	return &SpacePinStore{
+		db: db,
+	}
+}
+
+// SpacePinStore implements store.SpacePinStore backed by a relational database.
+type SpacePinStore struct {
+	db *sqlx.DB
+}
+
+// spacePin is used to fetch pinned item data from the database.
+type spacePin struct {
+	ID       int64                 `db:"space_pin_id"`
+	SpaceID  int64                 `db:"space_pin_space_id"`
+	Type     enum.SpacePinType     `db:"space_pin_type"`
+	RepoID   int64                 `db:"space_pin_repo_id"`
+	Number   int64                 `db:"space_pin_number"`
+	Tag      string                `db:"space_pin_tag"`
+	Title    string                `db:"space_pin_title"`
+	Position int64                 `db:"space_pin_position"`
+	Audience enum.SpacePinAudience `db:"space_pin_audience"`
+
+	CreatedBy int64 `db:"space_pin_created_by"`
+	Created   int64 `db:"space_pin_created"`
+	Updated   int64 `db:"space_pin_updated"`
+}
+
+const (
+	spacePinColumns = `
+		 space_pin_id
+		,space_pin_space_id
+		,space_pin_type
+		,space_pin_repo_id
+		,space_pin_number
+		,space_pin_tag
+		,space_pin_title
+		,space_pin_position
+		,space_pin_audience
+		,space_pin_created_by
+		,space_pin_created
+		,space_pin_updated`
+
+	spacePinSelectBase = `
+	SELECT` + spacePinColumns + `
+	FROM space_pins`
+)
+
+// Find finds the pinned item by id.
+func (s *SpacePinStore) Find(ctx context.Context, id int64) (*types.SpacePin, error) {
+	const sqlQuery = spacePinSelectBase + `
+	WHERE space_pin_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &spacePin{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find space pin")
+	}
+
+	return mapSpacePin(dst), nil
+}
+
+// Create creates a new pinned item.
+func (s *SpacePinStore) Create(ctx context.Context, pin *types.SpacePin) error {
+	const sqlQuery = `
+	INSERT INTO space_pins (
+		 space_pin_space_id
+		,space_pin_type
+		,space_pin_repo_id
+		,space_pin_number
+		,space_pin_tag
+		,space_pin_title
+		,space_pin_position
+		,space_pin_audience
+		,space_pin_created_by
+		,space_pin_created
+		,space_pin_updated
+	) values (
+		 :space_pin_space_id
+		,:space_pin_type
+		,:space_pin_repo_id
+		,:space_pin_number
+		,:space_pin_tag
+		,:space_pin_title
+		,:space_pin_position
+		,:space_pin_audience
+		,:space_pin_created_by
+		,:space_pin_created
+		,:space_pin_updated
+	) RETURNING space_pin_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalSpacePin(pin))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind space pin object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&pin.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to insert space pin")
+	}
+
+	return nil
+}
+
+// Update updates the title, the position and the audience of the pinned item.
+func (s *SpacePinStore) Update(ctx context.Context, pin *types.SpacePin) error {
+	const sqlQuery = `
+	UPDATE space_pins
+	SET
+		 space_pin_title = :space_pin_title
+		,space_pin_position = :space_pin_position
+		,space_pin_audience = :space_pin_audience
+		,space_pin_updated = :space_pin_updated
+	WHERE space_pin_id = :space_pin_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbPin := mapInternalSpacePin(pin)
+	dbPin.Updated = time.Now().UnixMilli()
+
+	query, arg, err := db.BindNamed(sqlQuery, dbPin)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind space pin object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to update space pin")
+	}
+
+	pin.Updated = dbPin.Updated
+
+	return nil
+}
+
+// Delete deletes the pinned item.
+func (s *SpacePinStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+	DELETE FROM space_pins
+	WHERE space_pin_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete space pin")
+	}
+
+	return nil
+}
+
+// List returns all items pinned to the space ordered by their position.
+func (s *SpacePinStore) List(ctx context.Context, spaceID int64) ([]*types.SpacePin, error) {
+	const sqlQuery = spacePinSelectBase + `
+	WHERE space_pin_space_id = $1
+	ORDER BY space_pin_position, space_pin_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*spacePin
+	if err := db.SelectContext(ctx, &dst, sqlQuery, spaceID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list space pins")
+	}
+
+	result := make([]*types.SpacePin, len(dst))
+	for i, pin := range dst {
+		result[i] = mapSpacePin(pin)
+	}
+
+	return result, nil
+}
+
+func mapSpacePin(pin *spacePin) *types.SpacePin {
+	return &types.SpacePin{
+		ID:        pin.ID,
+		SpaceID:   pin.SpaceID,
+		Type:      pin.Type,
+		RepoID:    pin.RepoID,
+		Number:    pin.Number,
+		Tag:       pin.Tag,
+		Title:     pin.Title,
+		Position:  pin.Position,
+		Audience:  pin.Audience,
+		CreatedBy: pin.CreatedBy,
+		Created:   pin.Created,
+		Updated:   pin.Updated,
+	}
+}
+
+func mapInternalSpacePin(pin *types.SpacePin) *spacePin {
+	return &spacePin{
+		ID:        pin.ID,
+		SpaceID:   pin.SpaceID,
+		Type:      pin.Type,
+		RepoID:    pin.RepoID,
+		Number:    pin.Number,
+		Tag:       pin.Tag,
+		Title:     pin.Title,
+		Position:  pin.Position,
+		Audience:  pin.Audience,
+		CreatedBy: pin.CreatedBy,
+		Created:   pin.Created,
+		Updated:   pin.Updated,
+	}
+}
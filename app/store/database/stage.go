@@ -61,38 +61,50 @@ const (
 	,stage_on_failure
 	,stage_depends_on
 	,stage_labels
+	,stage_matrix
+	,stage_retry_from
+	,stage_runs_on
+	,stage_timeout
+	,stage_step_timeouts
+	,stage_step_conditions
 	`
 )
 
 type stage struct {
-	ID            int64              `db:"stage_id"`
-	ExecutionID   int64              `db:"stage_execution_id"`
-	RepoID        int64              `db:"stage_repo_id"`
-	Number        int64              `db:"stage_number"`
-	Name          string             `db:"stage_name"`
-	Kind          string             `db:"stage_kind"`
-	Type          string             `db:"stage_type"`
-	Status        enum.CIStatus      `db:"stage_status"`
-	Error         string             `db:"stage_error"`
-	ParentGroupID int64              `db:"stage_parent_group_id"`
-	ErrIgnore     bool               `db:"stage_errignore"`
-	ExitCode      int                `db:"stage_exit_code"`
-	Machine       string             `db:"stage_machine"`
-	OS            string             `db:"stage_os"`
-	Arch          string             `db:"stage_arch"`
-	Variant       string             `db:"stage_variant"`
-	Kernel        string             `db:"stage_kernel"`
-	Limit         int                `db:"stage_limit"`
-	LimitRepo     int                `db:"stage_limit_repo"`
-	Started       int64              `db:"stage_started"`
-	Stopped       int64              `db:"stage_stopped"`
-	Created       int64              `db:"stage_created"`
-	Updated       int64              `db:"stage_updated"`
-	Version       int64              `db:"stage_version"`
-	OnSuccess     bool               `db:"stage_on_success"`
-	OnFailure     bool               `db:"stage_on_failure"`
-	DependsOn     sqlxtypes.JSONText `db:"stage_depends_on"`
-	Labels        sqlxtypes.JSONText `db:"stage_labels"`
+	ID             int64              `db:"stage_id"`
+	ExecutionID    int64              `db:"stage_execution_id"`
+	RepoID         int64              `db:"stage_repo_id"`
+	Number         int64              `db:"stage_number"`
+	Name           string             `db:"stage_name"`
+	Kind           string             `db:"stage_kind"`
+	Type           string             `db:"stage_type"`
+	Status         enum.CIStatus      `db:"stage_status"`
+	Error          string             `db:"stage_error"`
+	ParentGroupID  int64              `db:"stage_parent_group_id"`
+	ErrIgnore      bool               `db:"stage_errignore"`
+	ExitCode       int                `db:"stage_exit_code"`
+	Machine        string             `db:"stage_machine"`
+	OS             string             `db:"stage_os"`
+	Arch           string             `db:"stage_arch"`
+	Variant        string             `db:"stage_variant"`
+	Kernel         string             `db:"stage_kernel"`
+	Limit          int                `db:"stage_limit"`
+	LimitRepo      int                `db:"stage_limit_repo"`
+	Started        int64              `db:"stage_started"`
+	Stopped        int64              `db:"stage_stopped"`
+	Created        int64              `db:"stage_created"`
+	Updated        int64              `db:"stage_updated"`
+	Version        int64              `db:"stage_version"`
+	OnSuccess      bool               `db:"stage_on_success"`
+	OnFailure      bool               `db:"stage_on_failure"`
+	DependsOn      sqlxtypes.JSONText `db:"stage_depends_on"`
+	Labels         sqlxtypes.JSONText `db:"stage_labels"`
+	Matrix         sqlxtypes.JSONText `db:"stage_matrix"`
+	RetryFrom      string             `db:"stage_retry_from"`
+	RunsOn         sqlxtypes.JSONText `db:"stage_runs_on"`
+	Timeout        int64              `db:"stage_timeout"`
+	StepTimeouts   sqlxtypes.JSONText `db:"stage_step_timeouts"`
+	StepConditions sqlxtypes.JSONText `db:"stage_step_conditions"`
 }
 
 // NewStageStore returns a new StageStore.
@@ -152,6 +164,12 @@ func (s *stageStore) Create(ctx context.Context, st *types.Stage) error {
 			,stage_on_failure
 			,stage_depends_on
 			,stage_labels
+			,stage_matrix
+			,stage_retry_from
+			,stage_runs_on
+			,stage_timeout
+			,stage_step_timeouts
+			,stage_step_conditions
 		) VALUES (
 			:stage_execution_id
 			,:stage_repo_id
@@ -180,7 +198,12 @@ func (s *stageStore) Create(ctx context.Context, st *types.Stage) error {
 			,:stage_on_failure
 			,:stage_depends_on
 			,:stage_labels
-
+			,:stage_matrix
+			,:stage_retry_from
+			,:stage_runs_on
+			,:stage_timeout
+			,:stage_step_timeouts
+			,:stage_step_conditions
 		) RETURNING stage_id`
 	db := dbtx.GetAccessor(ctx, s.db)
 
@@ -192,6 +215,7 @@ func (s *stageStore) Create(ctx context.Context, st *types.Stage) error {
 	if err = db.QueryRowContext(ctx, query, arg...).Scan(&stage.ID); err != nil {
 		return database.ProcessSQLErrorf(err, "Stage query failed")
 	}
+	st.ID = stage.ID
 	return nil
 }
 
@@ -232,6 +256,27 @@ func (s *stageStore) Find(ctx context.Context, stageID int64) (*types.Stage, err
 	return mapInternalToStage(dst)
 }
 
+// ListInRange returns the stages of the executions of a pipeline created in the time range of the filter.
+func (s *stageStore) ListInRange(
+	ctx context.Context,
+	pipelineID int64,
+	filter types.PipelineStatsFilter,
+) ([]*types.Stage, error) {
+	const queryListInRange = `
+	SELECT` + stageColumns + `
+	FROM stages
+	INNER JOIN executions ON execution_id = stage_execution_id
+	WHERE execution_pipeline_id = $1 AND execution_created >= $2 AND execution_created < $3
+	ORDER BY stage_id ASC`
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*stage{}
+	if err := db.SelectContext(ctx, &dst, queryListInRange, pipelineID, filter.After, filter.Before); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list stages in range")
+	}
+	return mapInternalToStageList(dst)
+}
+
 // ListIncomplete returns a list of stages with a pending status.
 func (s *stageStore) ListIncomplete(ctx context.Context) ([]*types.Stage, error) {
 	const queryListIncomplete = `
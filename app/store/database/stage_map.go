@@ -82,6 +82,26 @@ func mapInternalToStage(in *stage) (*types.Stage, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "could not unmarshal stage.labels")
 	}
+	var matrix map[string]string
+	err = json.Unmarshal(in.Matrix, &matrix)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal stage.matrix")
+	}
+	var runsOn []string
+	err = json.Unmarshal(in.RunsOn, &runsOn)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal stage.runs_on")
+	}
+	var stepTimeouts map[string]int64
+	err = json.Unmarshal(in.StepTimeouts, &stepTimeouts)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal stage.step_timeouts")
+	}
+	var stepConditions map[string]types.ConditionResult
+	err = json.Unmarshal(in.StepConditions, &stepConditions)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal stage.step_conditions")
+	}
 	return &types.Stage{
 		ID:          in.ID,
 		ExecutionID: in.ExecutionID,
@@ -110,6 +130,13 @@ func mapInternalToStage(in *stage) (*types.Stage, error) {
 		OnFailure:   in.OnFailure,
 		DependsOn:   dependsOn,
 		Labels:      labels,
+		Matrix:      matrix,
+		RetryFrom:   in.RetryFrom,
+		RunsOn:      runsOn,
+		Timeout:     in.Timeout,
+
+		StepTimeouts:   stepTimeouts,
+		StepConditions: stepConditions,
 	}, nil
 }
 
@@ -142,6 +169,13 @@ func mapStageToInternal(in *types.Stage) *stage {
 		OnFailure:   in.OnFailure,
 		DependsOn:   EncodeToSQLXJSON(in.DependsOn),
 		Labels:      EncodeToSQLXJSON(in.Labels),
+		Matrix:      EncodeToSQLXJSON(in.Matrix),
+		RetryFrom:   in.RetryFrom,
+		RunsOn:      EncodeToSQLXJSON(in.RunsOn),
+		Timeout:     in.Timeout,
+
+		StepTimeouts:   EncodeToSQLXJSON(in.StepTimeouts),
+		StepConditions: EncodeToSQLXJSON(in.StepConditions),
 	}
 }
 
@@ -180,6 +214,9 @@ func scanRowsWithSteps(rows *sql.Rows) ([]*types.Stage, error) {
 			if err != nil {
 				return nil, err
 			}
+			if cond, ok := curr.StepConditions[convertedStep.Name]; ok {
+				convertedStep.Condition = &cond
+			}
 			curr.Steps = append(curr.Steps, convertedStep)
 		}
 	}
@@ -191,6 +228,10 @@ func scanRowsWithSteps(rows *sql.Rows) ([]*types.Stage, error) {
 func scanRowStep(rows *sql.Rows, stage *types.Stage, step *nullstep) error {
 	depJSON := sqlxtypes.JSONText{}
 	labJSON := sqlxtypes.JSONText{}
+	matJSON := sqlxtypes.JSONText{}
+	runsOnJSON := sqlxtypes.JSONText{}
+	stepTimeoutsJSON := sqlxtypes.JSONText{}
+	stepConditionsJSON := sqlxtypes.JSONText{}
 	stepDepJSON := sqlxtypes.JSONText{}
 	err := rows.Scan(
 		&stage.ID,
@@ -220,6 +261,12 @@ func scanRowStep(rows *sql.Rows, stage *types.Stage, step *nullstep) error {
 		&stage.OnFailure,
 		&depJSON,
 		&labJSON,
+		&matJSON,
+		&stage.RetryFrom,
+		&runsOnJSON,
+		&stage.Timeout,
+		&stepTimeoutsJSON,
+		&stepConditionsJSON,
 		&step.ID,
 		&step.StageID,
 		&step.Number,
@@ -247,6 +294,22 @@ func scanRowStep(rows *sql.Rows, stage *types.Stage, step *nullstep) error {
 	if err != nil {
 		return fmt.Errorf("failed to unmarshal labJSON: %w", err)
 	}
+	err = json.Unmarshal(matJSON, &stage.Matrix)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal matJSON: %w", err)
+	}
+	err = json.Unmarshal(runsOnJSON, &stage.RunsOn)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal runsOnJSON: %w", err)
+	}
+	err = json.Unmarshal(stepTimeoutsJSON, &stage.StepTimeouts)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal stepTimeoutsJSON: %w", err)
+	}
+	err = json.Unmarshal(stepConditionsJSON, &stage.StepConditions)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal stepConditionsJSON: %w", err)
+	}
 	if step.ID.Valid {
 		// try to unmarshal step dependencies if step exists
 		err = json.Unmarshal(stepDepJSON, &step.DependsOn)
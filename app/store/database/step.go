@@ -96,6 +96,21 @@ func (s *stepStore) FindByNumber(ctx context.Context, stageID int64, stepNum int
 	return mapInternalToStep(dst)
 }
 
+// Find returns a step given the step ID.
+func (s *stepStore) Find(ctx context.Context, stepID int64) (*types.Step, error) {
+	const findQueryStmt = `
+		SELECT` + stepColumns + `
+		FROM steps
+		WHERE step_id = $1`
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := new(step)
+	if err := db.GetContext(ctx, dst, findQueryStmt, stepID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find step")
+	}
+	return mapInternalToStep(dst)
+}
+
 // Create creates a step.
 func (s *stepStore) Create(ctx context.Context, step *types.Step) error {
 	const stepInsertStmt = `
@@ -50,6 +50,10 @@ func (s *TokenStore) Find(ctx context.Context, id int64) (*types.Token, error) {
 		return nil, database.ProcessSQLErrorf(err, "Failed to find token")
 	}
 
+	if err := s.mapSpaceIDs(ctx, []*types.Token{dst}); err != nil {
+		return nil, err
+	}
+
 	return dst, nil
 }
 
@@ -62,6 +66,10 @@ func (s *TokenStore) FindByUID(ctx context.Context, principalID int64, tokenUID
 		return nil, database.ProcessSQLErrorf(err, "Failed to find token by UID")
 	}
 
+	if err := s.mapSpaceIDs(ctx, []*types.Token{dst}); err != nil {
+		return nil, err
+	}
+
 	return dst, nil
 }
 
@@ -78,6 +86,23 @@ func (s *TokenStore) Create(ctx context.Context, token *types.Token) error {
 		return database.ProcessSQLErrorf(err, "Insert query failed")
 	}
 
+	for _, spaceID := range token.SpaceIDs {
+		if _, err = db.ExecContext(ctx, tokenSpaceInsert, token.ID, spaceID); err != nil {
+			return database.ProcessSQLErrorf(err, "Failed to insert token space")
+		}
+	}
+
+	return nil
+}
+
+// UpdateLastUsed updates the time the token with the given id was last used.
+func (s *TokenStore) UpdateLastUsed(ctx context.Context, id int64, lastUsed int64) error {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, tokenUpdateLastUsed, lastUsed, id); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to update token last used time")
+	}
+
 	return nil
 }
 
@@ -154,9 +179,66 @@ func (s *TokenStore) List(ctx context.Context,
 	if err != nil {
 		return nil, database.ProcessSQLErrorf(err, "Failed executing token list query")
 	}
+
+	if err = s.mapSpaceIDs(ctx, dst); err != nil {
+		return nil, err
+	}
+
 	return dst, nil
 }
 
+// mapSpaceIDs populates the spaces the tokens are restricted to.
+func (s *TokenStore) mapSpaceIDs(ctx context.Context, tokens []*types.Token) error {
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	tokenMap := make(map[int64]*types.Token, len(tokens))
+	tokenIDs := make([]int64, len(tokens))
+	for i, token := range tokens {
+		tokenMap[token.ID] = token
+		tokenIDs[i] = token.ID
+	}
+
+	stmt := database.Builder.
+		Select("token_space_token_id", "token_space_space_id").
+		From("token_spaces").
+		Where(squirrel.Eq{"token_space_token_id": tokenIDs}).
+		OrderBy("token_space_space_id")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert token spaces query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	rows, err := db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed executing token spaces query")
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	for rows.Next() {
+		var tokenID, spaceID int64
+		if err = rows.Scan(&tokenID, &spaceID); err != nil {
+			return database.ProcessSQLErrorf(err, "Failed to scan token space")
+		}
+
+		if token, ok := tokenMap[tokenID]; ok {
+			token.SpaceIDs = append(token.SpaceIDs, spaceID)
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to read token spaces")
+	}
+
+	return nil
+}
+
 const tokenSelectBase = `
 SELECT
 token_id
@@ -166,6 +248,8 @@ token_id
 ,token_expires_at
 ,token_issued_at
 ,token_created_by
+,token_last_used
+,token_role
 FROM tokens
 ` //#nosec G101
 
@@ -188,6 +272,12 @@ const TokenSelectByPrincipalIDAndUID = tokenSelectBase + `
 WHERE token_principal_id = $1 AND token_uid = $2
 `
 
+const tokenUpdateLastUsed = `
+UPDATE tokens
+SET token_last_used = $1
+WHERE token_id = $2
+`
+
 const tokenDelete = `
 DELETE FROM tokens
 WHERE token_id = $1
@@ -201,6 +291,7 @@ INSERT INTO tokens (
 	,token_expires_at
 	,token_issued_at
 	,token_created_by
+	,token_role
 ) values (
 	:token_type
 	,:token_uid
@@ -208,5 +299,16 @@ INSERT INTO tokens (
 	,:token_expires_at
 	,:token_issued_at
 	,:token_created_by
+	,:token_role
 ) RETURNING token_id
 `
+
+const tokenSpaceInsert = `
+INSERT INTO token_spaces (
+	token_space_token_id
+	,token_space_space_id
+) values (
+	$1
+	,$2
+)
+`
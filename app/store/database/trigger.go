@@ -46,6 +46,7 @@ type trigger struct {
 	CreatedBy   int64              `db:"trigger_created_by"`
 	Disabled    bool               `db:"trigger_disabled"`
 	Actions     sqlxtypes.JSONText `db:"trigger_actions"`
+	Paths       sqlxtypes.JSONText `db:"trigger_paths"`
 	Created     int64              `db:"trigger_created"`
 	Updated     int64              `db:"trigger_updated"`
 	Version     int64              `db:"trigger_version"`
@@ -58,6 +59,12 @@ func mapInternalToTrigger(trigger *trigger) (*types.Trigger, error) {
 		return nil, errors.Wrap(err, "could not unmarshal trigger.actions")
 	}
 
+	var paths types.TriggerPaths
+	err = json.Unmarshal(trigger.Paths, &paths)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal trigger.paths")
+	}
+
 	return &types.Trigger{
 		ID:          trigger.ID,
 		Description: trigger.Description,
@@ -68,6 +75,7 @@ func mapInternalToTrigger(trigger *trigger) (*types.Trigger, error) {
 		CreatedBy:   trigger.CreatedBy,
 		Disabled:    trigger.Disabled,
 		Actions:     actions,
+		Paths:       paths,
 		UID:         trigger.UID,
 		Created:     trigger.Created,
 		Updated:     trigger.Updated,
@@ -99,6 +107,7 @@ func mapTriggerToInternal(t *types.Trigger) *trigger {
 		CreatedBy:   t.CreatedBy,
 		Disabled:    t.Disabled,
 		Actions:     EncodeToSQLXJSON(t.Actions),
+		Paths:       EncodeToSQLXJSON(t.Paths),
 		Created:     t.Created,
 		Updated:     t.Updated,
 		Version:     t.Version,
@@ -122,6 +131,7 @@ const (
 		,trigger_uid
 		,trigger_disabled
 		,trigger_actions
+		,trigger_paths
 		,trigger_description
 		,trigger_pipeline_id
 		,trigger_created
@@ -152,6 +162,7 @@ func (s *triggerStore) Create(ctx context.Context, t *types.Trigger) error {
 		trigger_uid
 		,trigger_description
 		,trigger_actions
+		,trigger_paths
 		,trigger_disabled
 		,trigger_type
 		,trigger_secret
@@ -165,6 +176,7 @@ func (s *triggerStore) Create(ctx context.Context, t *types.Trigger) error {
 		:trigger_uid
 		,:trigger_description
 		,:trigger_actions
+		,:trigger_paths
 		,:trigger_disabled
 		,:trigger_type
 		,:trigger_secret
@@ -200,6 +212,7 @@ func (s *triggerStore) Update(ctx context.Context, t *types.Trigger) error {
 		,trigger_disabled = :trigger_disabled
 		,trigger_updated = :trigger_updated
 		,trigger_actions = :trigger_actions
+		,trigger_paths = :trigger_paths
 		,trigger_version = :trigger_version
 	WHERE trigger_id = :trigger_id AND trigger_version = :trigger_version - 1`
 	updatedAt := time.Now()
@@ -0,0 +1,201 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.UserSigningKeyStore = (*UserSigningKeyStore)(nil)
+
+// NewUserSigningKeyStore returns a new UserSigningKeyStore.
+func NewUserSigningKeyStore(db *sqlx.DB) *UserSigningKeyStore {
+	return &UserSigningKeyStore{
+		db: db,
+	}
+}
+
+// UserSigningKeyStore implements store.UserSigningKeyStore backed by a relational database.
+type UserSigningKeyStore struct {
+	db *sqlx.DB
+}
+
+// userSigningKey is used to fetch user signing key data from the database.
+type userSigningKey struct {
+	ID          int64                 `db:"user_signing_key_id"`
+	PrincipalID int64                 `db:"user_signing_key_principal_id"`
+	Format      enum.SigningKeyFormat `db:"user_signing_key_format"`
+	Fingerprint string                `db:"user_signing_key_fingerprint"`
+	PublicKey   string                `db:"user_signing_key_public_key"`
+	PrivateKey  string                `db:"user_signing_key_private_key"`
+	Created     int64                 `db:"user_signing_key_created"`
+	Rotated     *int64                `db:"user_signing_key_rotated"`
+	Revoked     *int64                `db:"user_signing_key_revoked"`
+}
+
+const (
+	userSigningKeyColumns = `
+		 user_signing_key_id
+		,user_signing_key_principal_id
+		,user_signing_key_format
+		,user_signing_key_fingerprint
+		,user_signing_key_public_key
+		,user_signing_key_private_key
+		,user_signing_key_created
+		,user_signing_key_rotated
+		,user_signing_key_revoked`
+
+	userSigningKeySelectBase = `
+	SELECT` + userSigningKeyColumns + `
+	FROM user_signing_keys`
+)
+
+// Find finds the user signing key by id.
+func (s *UserSigningKeyStore) Find(ctx context.Context, id int64) (*types.UserSigningKey, error) {
+	const sqlQuery = userSigningKeySelectBase + `
+	WHERE user_signing_key_id = $1`
+
+	return s.find(ctx, sqlQuery, id)
+}
+
+// FindActive finds the signing key of the principal that is currently used for signing commits.
+func (s *UserSigningKeyStore) FindActive(ctx context.Context, principalID int64) (*types.UserSigningKey, error) {
+	const sqlQuery = userSigningKeySelectBase + `
+	WHERE user_signing_key_principal_id = $1
+		AND user_signing_key_rotated IS NULL
+		AND user_signing_key_revoked IS NULL`
+
+	return s.find(ctx, sqlQuery, principalID)
+}
+
+// FindByFingerprint finds the user signing key by the fingerprint of its public key.
+func (s *UserSigningKeyStore) FindByFingerprint(
+	ctx context.Context,
+	fingerprint string,
+) (*types.UserSigningKey, error) {
+	const sqlQuery = userSigningKeySelectBase + `
+	WHERE user_signing_key_fingerprint = $1`
+
+	return s.find(ctx, sqlQuery, fingerprint)
+}
+
+func (s *UserSigningKeyStore) find(ctx context.Context, sqlQuery string, arg any) (*types.UserSigningKey, error) {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &userSigningKey{}
+	if err := db.GetContext(ctx, dst, sqlQuery, arg); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find user signing key")
+	}
+
+	return mapUserSigningKey(dst), nil
+}
+
+// List returns all signing keys of the principal, the newest first.
+func (s *UserSigningKeyStore) List(ctx context.Context, principalID int64) ([]*types.UserSigningKey, error) {
+	const sqlQuery = userSigningKeySelectBase + `
+	WHERE user_signing_key_principal_id = $1
+	ORDER BY user_signing_key_created DESC, user_signing_key_id DESC`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*userSigningKey{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list user signing keys")
+	}
+
+	keys := make([]*types.UserSigningKey, len(dst))
+	for i := range dst {
+		keys[i] = mapUserSigningKey(dst[i])
+	}
+
+	return keys, nil
+}
+
+// Create creates a new user signing key.
+func (s *UserSigningKeyStore) Create(ctx context.Context, key *types.UserSigningKey) error {
+	const sqlQuery = `
+	INSERT INTO user_signing_keys (
+		 user_signing_key_principal_id
+		,user_signing_key_format
+		,user_signing_key_fingerprint
+		,user_signing_key_public_key
+		,user_signing_key_private_key
+		,user_signing_key_created
+		,user_signing_key_rotated
+		,user_signing_key_revoked
+	) VALUES (
+		 :user_signing_key_principal_id
+		,:user_signing_key_format
+		,:user_signing_key_fingerprint
+		,:user_signing_key_public_key
+		,:user_signing_key_private_key
+		,:user_signing_key_created
+		,:user_signing_key_rotated
+		,:user_signing_key_revoked
+	)
+	RETURNING user_signing_key_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalUserSigningKey(key))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind user signing key object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&key.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Update updates the rotation and the revocation time of the user signing key.
+func (s *UserSigningKeyStore) Update(ctx context.Context, key *types.UserSigningKey) error {
+	const sqlQuery = `
+	UPDATE user_signing_keys
+	SET
+		 user_signing_key_rotated = :user_signing_key_rotated
+		,user_signing_key_revoked = :user_signing_key_revoked
+	WHERE user_signing_key_id = :user_signing_key_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalUserSigningKey(key))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind user signing key object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Update query failed")
+	}
+
+	return nil
+}
+
+func mapUserSigningKey(v *userSigningKey) *types.UserSigningKey {
+	return (*types.UserSigningKey)(v) // the two types are identical, except for the tags
+}
+
+func mapInternalUserSigningKey(v *types.UserSigningKey) *userSigningKey {
+	return (*userSigningKey)(v) // the two types are identical, except for the tags
+}
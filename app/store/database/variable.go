@@ -0,0 +1,455 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var _ store.VariableStore = (*VariableStore)(nil)
+
+// NewVariableStore returns a new VariableStore.
+func NewVariableStore(db *sqlx.DB) *VariableStore {
+	return &VariableStore{
+		db: db,
+	}
+}
+
+// VariableStore implements store.VariableStore backed by a relational database.
+type VariableStore struct {
+	db *sqlx.DB
+}
+
+// variable is used to fetch variable data from the database.
+type variable struct {
+	ID        int64    `db:"variable_id"`
+	Version   int64    `db:"variable_version"`
+	RepoID    null.Int `db:"variable_repo_id"`
+	SpaceID   null.Int `db:"variable_space_id"`
+	Key       string   `db:"variable_key"`
+	Value     string   `db:"variable_value"`
+	SecretUID string   `db:"variable_secret_uid"`
+	CreatedBy int64    `db:"variable_created_by"`
+	UpdatedBy int64    `db:"variable_updated_by"`
+	Created   int64    `db:"variable_created"`
+	Updated   int64    `db:"variable_updated"`
+}
+
+const (
+	variableColumns = `
+		 variable_id
+		,variable_version
+		,variable_repo_id
+		,variable_space_id
+		,variable_key
+		,variable_value
+		,variable_secret_uid
+		,variable_created_by
+		,variable_updated_by
+		,variable_created
+		,variable_updated`
+
+	variableSelectBase = `
+	SELECT` + variableColumns + `
+	FROM variables`
+)
+
+// Find finds the variable by id.
+func (s *VariableStore) Find(ctx context.Context, id int64) (*types.Variable, error) {
+	const sqlQuery = variableSelectBase + `
+	WHERE variable_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &variable{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find variable")
+	}
+
+	return mapToVariable(dst)
+}
+
+// FindByKey finds the variable with the given key for the given parent.
+func (s *VariableStore) FindByKey(
+	ctx context.Context,
+	parentType enum.VariableParent,
+	parentID int64,
+	key string,
+) (*types.Variable, error) {
+	stmt := database.Builder.
+		Select(variableColumns).
+		From("variables").
+		Where("variable_key = ?", key)
+
+	stmt, err := variableParentWhere(stmt, "variable", parentType, parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &variable{}
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find variable")
+	}
+
+	return mapToVariable(dst)
+}
+
+// Create creates a new variable.
+func (s *VariableStore) Create(ctx context.Context, v *types.Variable) error {
+	const sqlQuery = `
+	INSERT INTO variables (
+		 variable_version
+		,variable_repo_id
+		,variable_space_id
+		,variable_key
+		,variable_value
+		,variable_secret_uid
+		,variable_created_by
+		,variable_updated_by
+		,variable_created
+		,variable_updated
+	) VALUES (
+		 :variable_version
+		,:variable_repo_id
+		,:variable_space_id
+		,:variable_key
+		,:variable_value
+		,:variable_secret_uid
+		,:variable_created_by
+		,:variable_updated_by
+		,:variable_created
+		,:variable_updated
+	) RETURNING variable_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbVariable, err := mapToInternalVariable(v)
+	if err != nil {
+		return err
+	}
+
+	query, arg, err := db.BindNamed(sqlQuery, dbVariable)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind variable object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&v.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Update updates an existing variable.
+func (s *VariableStore) Update(ctx context.Context, v *types.Variable) error {
+	const sqlQuery = `
+	UPDATE variables
+	SET
+		 variable_version = :variable_version
+		,variable_value = :variable_value
+		,variable_secret_uid = :variable_secret_uid
+		,variable_updated_by = :variable_updated_by
+		,variable_updated = :variable_updated
+	WHERE variable_id = :variable_id AND variable_version = :variable_version - 1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbVariable, err := mapToInternalVariable(v)
+	if err != nil {
+		return err
+	}
+
+	dbVariable.Version++
+	dbVariable.Updated = time.Now().UnixMilli()
+
+	query, arg, err := db.BindNamed(sqlQuery, dbVariable)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind variable object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to update variable")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrVersionConflict
+	}
+
+	v.Version = dbVariable.Version
+	v.Updated = dbVariable.Updated
+
+	return nil
+}
+
+// UpdateOptLock updates the variable using the optimistic locking mechanism.
+func (s *VariableStore) UpdateOptLock(
+	ctx context.Context,
+	v *types.Variable,
+	mutateFn func(variable *types.Variable) error,
+) (*types.Variable, error) {
+	for {
+		dup := *v
+
+		err := mutateFn(&dup)
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.Update(ctx, &dup)
+		if err == nil {
+			return &dup, nil
+		}
+		if !errors.Is(err, gitness_store.ErrVersionConflict) {
+			return nil, err
+		}
+
+		v, err = s.Find(ctx, v.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Delete deletes the variable with the given id.
+func (s *VariableStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+	DELETE FROM variables
+	WHERE variable_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(err, "Delete query failed")
+	}
+
+	return nil
+}
+
+// Count counts the variables for the given parent.
+func (s *VariableStore) Count(
+	ctx context.Context,
+	parentType enum.VariableParent,
+	parentID int64,
+	filter *types.ListQueryFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("variables")
+
+	stmt, err := variableParentWhere(stmt, "variable", parentType, parentID)
+	if err != nil {
+		return 0, err
+	}
+
+	if filter.Query != "" {
+		stmt = stmt.Where("LOWER(variable_key) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(filter.Query)))
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(err, "Failed executing count query")
+	}
+
+	return count, nil
+}
+
+// List lists the variables for the given parent.
+func (s *VariableStore) List(
+	ctx context.Context,
+	parentType enum.VariableParent,
+	parentID int64,
+	filter *types.ListQueryFilter,
+) ([]*types.Variable, error) {
+	stmt := database.Builder.
+		Select(variableColumns).
+		From("variables").
+		OrderBy("variable_key ASC")
+
+	stmt, err := variableParentWhere(stmt, "variable", parentType, parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if filter.Query != "" {
+		stmt = stmt.Where("LOWER(variable_key) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(filter.Query)))
+	}
+
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+
+	return s.list(ctx, stmt)
+}
+
+// ListAll lists all variables for the given parent.
+func (s *VariableStore) ListAll(
+	ctx context.Context,
+	parentType enum.VariableParent,
+	parentID int64,
+) ([]*types.Variable, error) {
+	stmt := database.Builder.
+		Select(variableColumns).
+		From("variables").
+		OrderBy("variable_key ASC")
+
+	stmt, err := variableParentWhere(stmt, "variable", parentType, parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.list(ctx, stmt)
+}
+
+func (s *VariableStore) list(ctx context.Context, stmt squirrel.SelectBuilder) ([]*types.Variable, error) {
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*variable{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing variable list query")
+	}
+
+	res := make([]*types.Variable, len(dst))
+	for i := range dst {
+		if res[i], err = mapToVariable(dst[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+// variableParentWhere limits the query to the given parent of variables (or variable audit log entries).
+func variableParentWhere(
+	stmt squirrel.SelectBuilder,
+	prefix string,
+	parentType enum.VariableParent,
+	parentID int64,
+) (squirrel.SelectBuilder, error) {
+	switch parentType {
+	case enum.VariableParentRepo:
+		return stmt.Where(prefix+"_repo_id = ?", parentID), nil
+	case enum.VariableParentSpace:
+		return stmt.Where(prefix+"_space_id = ?", parentID), nil
+	default:
+		return stmt, fmt.Errorf("variable parent type '%s' is not supported", parentType)
+	}
+}
+
+// variableParent returns the parent of a variable (or a variable audit log entry) from its DB columns.
+func variableParent(repoID, spaceID null.Int) (enum.VariableParent, int64, error) {
+	switch {
+	case repoID.Valid && spaceID.Valid:
+		return "", 0, errors.New("both repoID and spaceID are set")
+	case repoID.Valid:
+		return enum.VariableParentRepo, repoID.Int64, nil
+	case spaceID.Valid:
+		return enum.VariableParentSpace, spaceID.Int64, nil
+	default:
+		return "", 0, errors.New("neither repoID nor spaceID are set")
+	}
+}
+
+// variableParentColumns returns the DB columns of the parent of a variable (or a variable audit log entry).
+func variableParentColumns(parentType enum.VariableParent, parentID int64) (null.Int, null.Int, error) {
+	switch parentType {
+	case enum.VariableParentRepo:
+		return null.IntFrom(parentID), null.Int{}, nil
+	case enum.VariableParentSpace:
+		return null.Int{}, null.IntFrom(parentID), nil
+	default:
+		return null.Int{}, null.Int{}, fmt.Errorf("variable parent type '%s' is not supported", parentType)
+	}
+}
+
+func mapToVariable(v *variable) (*types.Variable, error) {
+	parentType, parentID, err := variableParent(v.RepoID, v.SpaceID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid parent of variable %d: %w", v.ID, err)
+	}
+
+	return &types.Variable{
+		ID:         v.ID,
+		Version:    v.Version,
+		ParentID:   parentID,
+		ParentType: parentType,
+		Key:        v.Key,
+		Value:      v.Value,
+		SecretUID:  v.SecretUID,
+		CreatedBy:  v.CreatedBy,
+		UpdatedBy:  v.UpdatedBy,
+		Created:    v.Created,
+		Updated:    v.Updated,
+	}, nil
+}
+
+func mapToInternalVariable(v *types.Variable) (*variable, error) {
+	repoID, spaceID, err := variableParentColumns(v.ParentType, v.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &variable{
+		ID:        v.ID,
+		Version:   v.Version,
+		RepoID:    repoID,
+		SpaceID:   spaceID,
+		Key:       v.Key,
+		Value:     v.Value,
+		SecretUID: v.SecretUID,
+		CreatedBy: v.CreatedBy,
+		UpdatedBy: v.UpdatedBy,
+		Created:   v.Created,
+		Updated:   v.Updated,
+	}, nil
+}
@@ -0,0 +1,213 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.VariableAuditStore = (*VariableAuditStore)(nil)
+
+// NewVariableAuditStore returns a new VariableAuditStore.
+func NewVariableAuditStore(db *sqlx.DB) *VariableAuditStore {
+	return &VariableAuditStore{
+		db: db,
+	}
+}
+
+// VariableAuditStore implements store.VariableAuditStore backed by a relational database.
+type VariableAuditStore struct {
+	db *sqlx.DB
+}
+
+// variableAudit is used to fetch variable audit log data from the database.
+type variableAudit struct {
+	ID          int64                    `db:"variable_audit_id"`
+	RepoID      null.Int                 `db:"variable_audit_repo_id"`
+	SpaceID     null.Int                 `db:"variable_audit_space_id"`
+	Key         string                   `db:"variable_audit_key"`
+	Action      enum.VariableAuditAction `db:"variable_audit_action"`
+	Value       string                   `db:"variable_audit_value"`
+	SecretUID   string                   `db:"variable_audit_secret_uid"`
+	PrincipalID int64                    `db:"variable_audit_principal_id"`
+	Created     int64                    `db:"variable_audit_created"`
+}
+
+const variableAuditColumns = `
+	 variable_audit_id
+	,variable_audit_repo_id
+	,variable_audit_space_id
+	,variable_audit_key
+	,variable_audit_action
+	,variable_audit_value
+	,variable_audit_secret_uid
+	,variable_audit_principal_id
+	,variable_audit_created`
+
+// Create creates a new audit log entry.
+func (s *VariableAuditStore) Create(ctx context.Context, a *types.VariableAudit) error {
+	const sqlQuery = `
+	INSERT INTO variable_audits (
+		 variable_audit_repo_id
+		,variable_audit_space_id
+		,variable_audit_key
+		,variable_audit_action
+		,variable_audit_value
+		,variable_audit_secret_uid
+		,variable_audit_principal_id
+		,variable_audit_created
+	) VALUES (
+		 :variable_audit_repo_id
+		,:variable_audit_space_id
+		,:variable_audit_key
+		,:variable_audit_action
+		,:variable_audit_value
+		,:variable_audit_secret_uid
+		,:variable_audit_principal_id
+		,:variable_audit_created
+	) RETURNING variable_audit_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	repoID, spaceID, err := variableParentColumns(a.ParentType, a.ParentID)
+	if err != nil {
+		return err
+	}
+
+	query, arg, err := db.BindNamed(sqlQuery, &variableAudit{
+		RepoID:      repoID,
+		SpaceID:     spaceID,
+		Key:         a.Key,
+		Action:      a.Action,
+		Value:       a.Value,
+		SecretUID:   a.SecretUID,
+		PrincipalID: a.PrincipalID,
+		Created:     a.Created,
+	})
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind variable audit object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&a.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Count counts the audit log entries for the given parent.
+func (s *VariableAuditStore) Count(
+	ctx context.Context,
+	parentType enum.VariableParent,
+	parentID int64,
+	filter *types.ListQueryFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("variable_audits")
+
+	stmt, err := variableParentWhere(stmt, "variable_audit", parentType, parentID)
+	if err != nil {
+		return 0, err
+	}
+
+	if filter.Query != "" {
+		stmt = stmt.Where("LOWER(variable_audit_key) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(filter.Query)))
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(err, "Failed executing count query")
+	}
+
+	return count, nil
+}
+
+// List lists the audit log entries for the given parent, newest first.
+func (s *VariableAuditStore) List(
+	ctx context.Context,
+	parentType enum.VariableParent,
+	parentID int64,
+	filter *types.ListQueryFilter,
+) ([]*types.VariableAudit, error) {
+	stmt := database.Builder.
+		Select(variableAuditColumns).
+		From("variable_audits").
+		OrderBy("variable_audit_created DESC", "variable_audit_id DESC")
+
+	stmt, err := variableParentWhere(stmt, "variable_audit", parentType, parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if filter.Query != "" {
+		stmt = stmt.Where("LOWER(variable_audit_key) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(filter.Query)))
+	}
+
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*variableAudit{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing variable audit list query")
+	}
+
+	res := make([]*types.VariableAudit, len(dst))
+	for i, a := range dst {
+		parentType, parentID, err := variableParent(a.RepoID, a.SpaceID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid parent of variable audit log entry %d: %w", a.ID, err)
+		}
+
+		res[i] = &types.VariableAudit{
+			ID:          a.ID,
+			ParentID:    parentID,
+			ParentType:  parentType,
+			Key:         a.Key,
+			Action:      a.Action,
+			Value:       a.Value,
+			SecretUID:   a.SecretUID,
+			PrincipalID: a.PrincipalID,
+			Created:     a.Created,
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,257 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.WatchStore = (*WatchStore)(nil)
+
+// NewWatchStore returns a new WatchStore.
+func NewWatchStore(db *sqlx.DB) *WatchStore {
+	return &WatchStore{
+		db: db,
+	}
+}
+
+// WatchStore implements store.WatchStore backed by a relational database.
+type WatchStore struct {
+	db *sqlx.DB
+}
+
+// repoWatch is used to fetch repository watch data from the database.
+type repoWatch struct {
+	RepoID      int64           `db:"repo_watch_repo_id"`
+	PrincipalID int64           `db:"repo_watch_principal_id"`
+	Level       enum.WatchLevel `db:"repo_watch_level"`
+	Created     int64           `db:"repo_watch_created"`
+	Updated     int64           `db:"repo_watch_updated"`
+}
+
+// pullReqWatch is used to fetch pull request watch data from the database.
+type pullReqWatch struct {
+	PullReqID   int64           `db:"pullreq_watch_pullreq_id"`
+	PrincipalID int64           `db:"pullreq_watch_principal_id"`
+	Level       enum.WatchLevel `db:"pullreq_watch_level"`
+	Created     int64           `db:"pullreq_watch_created"`
+	Updated     int64           `db:"pullreq_watch_updated"`
+}
+
+const (
+	repoWatchColumns = `
+		 repo_watch_repo_id
+		,repo_watch_principal_id
+		,repo_watch_level
+		,repo_watch_created
+		,repo_watch_updated`
+
+	repoWatchSelectBase = `
+	SELECT` + repoWatchColumns + `
+	FROM repo_watches`
+
+	pullReqWatchColumns = `
+		 pullreq_watch_pullreq_id
+		,pullreq_watch_principal_id
+		,pullreq_watch_level
+		,pullreq_watch_created
+		,pullreq_watch_updated`
+
+	pullReqWatchSelectBase = `
+	SELECT` + pullReqWatchColumns + `
+	FROM pullreq_watches`
+)
+
+// FindRepo returns the watch level of the principal for the repository.
+func (s *WatchStore) FindRepo(ctx context.Context, repoID, principalID int64) (*types.RepoWatch, error) {
+	const sqlQuery = repoWatchSelectBase + `
+	WHERE repo_watch_repo_id = $1 AND repo_watch_principal_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &repoWatch{}
+	if err := db.GetContext(ctx, dst, sqlQuery, repoID, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find repository watch")
+	}
+
+	return (*types.RepoWatch)(dst), nil
+}
+
+// UpsertRepo creates a new or updates the existing repository watch.
+func (s *WatchStore) UpsertRepo(ctx context.Context, watch *types.RepoWatch) error {
+	const sqlQuery = `
+	INSERT INTO repo_watches (
+		 repo_watch_repo_id
+		,repo_watch_principal_id
+		,repo_watch_level
+		,repo_watch_created
+		,repo_watch_updated
+	) VALUES (
+		 :repo_watch_repo_id
+		,:repo_watch_principal_id
+		,:repo_watch_level
+		,:repo_watch_created
+		,:repo_watch_updated
+	)
+	ON CONFLICT (repo_watch_repo_id, repo_watch_principal_id) DO
+	UPDATE SET
+		 repo_watch_level = :repo_watch_level
+		,repo_watch_updated = :repo_watch_updated
+	RETURNING repo_watch_created`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, (*repoWatch)(watch))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind repository watch object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&watch.Created); err != nil {
+		return database.ProcessSQLErrorf(err, "Upsert query failed")
+	}
+
+	return nil
+}
+
+// DeleteRepo deletes the repository watch of the principal.
+func (s *WatchStore) DeleteRepo(ctx context.Context, repoID, principalID int64) error {
+	const sqlQuery = `
+	DELETE FROM repo_watches
+	WHERE repo_watch_repo_id = $1 AND repo_watch_principal_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, repoID, principalID); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete repository watch")
+	}
+
+	return nil
+}
+
+// ListRepo returns all watches of the repository.
+func (s *WatchStore) ListRepo(ctx context.Context, repoID int64) ([]*types.RepoWatch, error) {
+	const sqlQuery = repoWatchSelectBase + `
+	WHERE repo_watch_repo_id = $1
+	ORDER BY repo_watch_principal_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*repoWatch
+	if err := db.SelectContext(ctx, &dst, sqlQuery, repoID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list repository watches")
+	}
+
+	result := make([]*types.RepoWatch, len(dst))
+	for i, w := range dst {
+		result[i] = (*types.RepoWatch)(w)
+	}
+
+	return result, nil
+}
+
+// FindPullReq returns the watch level of the principal for the pull request.
+func (s *WatchStore) FindPullReq(ctx context.Context, pullReqID, principalID int64) (*types.PullReqWatch, error) {
+	const sqlQuery = pullReqWatchSelectBase + `
+	WHERE pullreq_watch_pullreq_id = $1 AND pullreq_watch_principal_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &pullReqWatch{}
+	if err := db.GetContext(ctx, dst, sqlQuery, pullReqID, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find pull request watch")
+	}
+
+	return (*types.PullReqWatch)(dst), nil
+}
+
+// UpsertPullReq creates a new or updates the existing pull request watch.
+func (s *WatchStore) UpsertPullReq(ctx context.Context, watch *types.PullReqWatch) error {
+	const sqlQuery = `
+	INSERT INTO pullreq_watches (
+		 pullreq_watch_pullreq_id
+		,pullreq_watch_principal_id
+		,pullreq_watch_level
+		,pullreq_watch_created
+		,pullreq_watch_updated
+	) VALUES (
+		 :pullreq_watch_pullreq_id
+		,:pullreq_watch_principal_id
+		,:pullreq_watch_level
+		,:pullreq_watch_created
+		,:pullreq_watch_updated
+	)
+	ON CONFLICT (pullreq_watch_pullreq_id, pullreq_watch_principal_id) DO
+	UPDATE SET
+		 pullreq_watch_level = :pullreq_watch_level
+		,pullreq_watch_updated = :pullreq_watch_updated
+	RETURNING pullreq_watch_created`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, (*pullReqWatch)(watch))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind pull request watch object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&watch.Created); err != nil {
+		return database.ProcessSQLErrorf(err, "Upsert query failed")
+	}
+
+	return nil
+}
+
+// DeletePullReq deletes the pull request watch of the principal.
+func (s *WatchStore) DeletePullReq(ctx context.Context, pullReqID, principalID int64) error {
+	const sqlQuery = `
+	DELETE FROM pullreq_watches
+	WHERE pullreq_watch_pullreq_id = $1 AND pullreq_watch_principal_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, pullReqID, principalID); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete pull request watch")
+	}
+
+	return nil
+}
+
+// ListPullReq returns all watches of the pull request.
+func (s *WatchStore) ListPullReq(ctx context.Context, pullReqID int64) ([]*types.PullReqWatch, error) {
+	const sqlQuery = pullReqWatchSelectBase + `
+	WHERE pullreq_watch_pullreq_id = $1
+	ORDER BY pullreq_watch_principal_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*pullReqWatch
+	if err := db.SelectContext(ctx, &dst, sqlQuery, pullReqID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list pull request watches")
+	}
+
+	result := make([]*types.PullReqWatch, len(dst))
+	for i, w := range dst {
+		result[i] = (*types.PullReqWatch)(w)
+	}
+
+	return result, nil
+}
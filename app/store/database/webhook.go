@@ -67,6 +67,8 @@ type webhook struct {
 	Insecure              bool        `db:"webhook_insecure"`
 	Triggers              string      `db:"webhook_triggers"`
 	LatestExecutionResult null.String `db:"webhook_latest_execution_result"`
+	PayloadTemplateType   string      `db:"webhook_payload_template_type"`
+	PayloadTemplate       string      `db:"webhook_payload_template"`
 }
 
 const (
@@ -87,7 +89,9 @@ const (
 		,webhook_insecure
 		,webhook_triggers
 		,webhook_latest_execution_result
-		,webhook_internal`
+		,webhook_internal
+		,webhook_payload_template_type
+		,webhook_payload_template`
 
 	webhookSelectBase = `
 	SELECT` + webhookColumns + `
@@ -174,6 +178,8 @@ func (s *WebhookStore) Create(ctx context.Context, hook *types.Webhook) error {
 			,webhook_triggers
 			,webhook_latest_execution_result
 			,webhook_internal
+			,webhook_payload_template_type
+			,webhook_payload_template
 		) values (
 			:webhook_repo_id
 			,:webhook_space_id
@@ -190,6 +196,8 @@ func (s *WebhookStore) Create(ctx context.Context, hook *types.Webhook) error {
 			,:webhook_triggers
 			,:webhook_latest_execution_result
 			,:webhook_internal
+			,:webhook_payload_template_type
+			,:webhook_payload_template
 		) RETURNING webhook_id`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -228,6 +236,8 @@ func (s *WebhookStore) Update(ctx context.Context, hook *types.Webhook) error {
 			,webhook_triggers = :webhook_triggers
 			,webhook_latest_execution_result = :webhook_latest_execution_result
 			,webhook_internal = :webhook_internal
+			,webhook_payload_template_type = :webhook_payload_template_type
+			,webhook_payload_template = :webhook_payload_template
 		WHERE webhook_id = :webhook_id and webhook_version = :webhook_version - 1`
 
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -458,6 +468,8 @@ func mapToWebhook(hook *webhook) (*types.Webhook, error) {
 		Triggers:              triggersFromString(hook.Triggers),
 		LatestExecutionResult: (*enum.WebhookExecutionResult)(hook.LatestExecutionResult.Ptr()),
 		Internal:              hook.Internal,
+		PayloadTemplateType:   enum.WebhookPayloadTemplateType(hook.PayloadTemplateType),
+		PayloadTemplate:       hook.PayloadTemplate,
 	}
 
 	switch {
@@ -493,6 +505,8 @@ func mapToInternalWebhook(hook *types.Webhook) (*webhook, error) {
 		Triggers:              triggersToString(hook.Triggers),
 		LatestExecutionResult: null.StringFromPtr((*string)(hook.LatestExecutionResult)),
 		Internal:              hook.Internal,
+		PayloadTemplateType:   string(hook.PayloadTemplateType),
+		PayloadTemplate:       hook.PayloadTemplate,
 	}
 
 	switch hook.ParentType {
@@ -21,6 +21,7 @@ import (
 	"github.com/harness/gitness/app/store/database/migrate"
 	"github.com/harness/gitness/job"
 	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
 
 	"github.com/google/wire"
 	"github.com/jmoiron/sqlx"
@@ -33,6 +34,7 @@ var WireSet = wire.NewSet(
 	ProvidePrincipalInfoView,
 	ProvideSpacePathStore,
 	ProvideSpaceStore,
+	ProvideSpacePinStore,
 	ProvideRepoStore,
 	ProvideRuleStore,
 	ProvideJobStore,
@@ -50,6 +52,33 @@ var WireSet = wire.NewSet(
 	ProvidePullReqReviewStore,
 	ProvidePullReqReviewerStore,
 	ProvidePullReqFileViewStore,
+	ProvidePullReqMentionStore,
+	ProvidePullReqDependencyStore,
+	ProvideMergeTemplateStore,
+	ProvideSigningKeyStore,
+	ProvideUserSigningKeyStore,
+	ProvidePublicKeyStore,
+	ProvidePasskeyStore,
+	ProvidePasskeyChallengeStore,
+	ProvideWorkingHoursStore,
+	ProvideRunnerStore,
+	ProvideVariableStore,
+	ProvideVariableAuditStore,
+	ProvideCommitCommentStore,
+	ProvideAutolinkStore,
+	ProvideWatchStore,
+	ProvideDefaultReviewerStore,
+	ProvideRepoAliasStore,
+	ProvideRequiredFileStore,
+	ProvideRepoComplianceStore,
+	ProvideComplianceSnapshotStore,
+	ProvideGitUsageStore,
+	ProvideRepoStatsStore,
+	ProvideRepoMirrorStore,
+	ProvideCommitIndexStore,
+	ProvidePipelineCacheStore,
+	ProvidePipelineArtifactStore,
+	ProvideSavedComparisonStore,
 	ProvideWebhookStore,
 	ProvideWebhookExecutionStore,
 	ProvideCheckStore,
@@ -57,6 +86,8 @@ var WireSet = wire.NewSet(
 	ProvideConnectorStore,
 	ProvideTemplateStore,
 	ProvideTriggerStore,
+	ProvideScheduleStore,
+	ProvideApprovalStore,
 	ProvidePluginStore,
 )
 
@@ -68,6 +99,8 @@ func migrator(ctx context.Context, db *sqlx.DB) error {
 
 // ProvideDatabase provides a database connection.
 func ProvideDatabase(ctx context.Context, config database.Config) (*sqlx.DB, error) {
+	dbtx.SetSlowQueryThreshold(config.SlowQueryThreshold)
+
 	return database.ConnectAndMigrate(
 		ctx,
 		config.Driver,
@@ -103,6 +136,11 @@ func ProvideSpaceStore(
 	return NewSpaceStore(db, spacePathCache, spacePathStore)
 }
 
+// ProvideSpacePinStore provides a space pin store.
+func ProvideSpacePinStore(db *sqlx.DB) store.SpacePinStore {
+	return NewSpacePinStore(db)
+}
+
 // ProvideRepoStore provides a repo store.
 func ProvideRepoStore(
 	db *sqlx.DB,
@@ -160,6 +198,16 @@ func ProvideTriggerStore(db *sqlx.DB) store.TriggerStore {
 	return NewTriggerStore(db)
 }
 
+// ProvideScheduleStore provides a schedule store.
+func ProvideScheduleStore(db *sqlx.DB) store.ScheduleStore {
+	return NewScheduleStore(db)
+}
+
+// ProvideApprovalStore provides an approval store.
+func ProvideApprovalStore(db *sqlx.DB) store.ApprovalStore {
+	return NewApprovalStore(db)
+}
+
 // ProvideExecutionStore provides an execution store.
 func ProvideExecutionStore(db *sqlx.DB) store.ExecutionStore {
 	return NewExecutionStore(db)
@@ -224,6 +272,126 @@ func ProvidePullReqFileViewStore(db *sqlx.DB) store.PullReqFileViewStore {
 	return NewPullReqFileViewStore(db)
 }
 
+// ProvidePullReqMentionStore provides a pull request mention store.
+func ProvidePullReqMentionStore(db *sqlx.DB) store.PullReqMentionStore {
+	return NewPullReqMentionStore(db)
+}
+
+// ProvidePullReqDependencyStore provides a pull request dependency store.
+func ProvidePullReqDependencyStore(db *sqlx.DB) store.PullReqDependencyStore {
+	return NewPullReqDependencyStore(db)
+}
+
+// ProvideWatchStore provides a watch store.
+func ProvideWatchStore(db *sqlx.DB) store.WatchStore {
+	return NewWatchStore(db)
+}
+
+// ProvideDefaultReviewerStore provides a default reviewer store.
+func ProvideDefaultReviewerStore(db *sqlx.DB) store.DefaultReviewerStore {
+	return NewDefaultReviewerStore(db)
+}
+
+// ProvideRepoAliasStore provides a repository alias store.
+func ProvideRepoAliasStore(db *sqlx.DB) store.RepoAliasStore {
+	return NewRepoAliasStore(db)
+}
+
+// ProvideMergeTemplateStore provides a merge template store.
+func ProvideMergeTemplateStore(db *sqlx.DB) store.MergeTemplateStore {
+	return NewMergeTemplateStore(db)
+}
+
+// ProvideSigningKeyStore provides a repository signing key store.
+func ProvideSigningKeyStore(db *sqlx.DB) store.SigningKeyStore {
+	return NewSigningKeyStore(db)
+}
+
+// ProvideUserSigningKeyStore provides a user signing key store.
+func ProvideUserSigningKeyStore(db *sqlx.DB) store.UserSigningKeyStore {
+	return NewUserSigningKeyStore(db)
+}
+
+// ProvidePublicKeyStore provides a public key store.
+func ProvidePublicKeyStore(db *sqlx.DB) store.PublicKeyStore {
+	return NewPublicKeyStore(db)
+}
+
+// ProvidePasskeyStore provides a passkey store.
+func ProvidePasskeyStore(db *sqlx.DB) store.PasskeyStore {
+	return NewPasskeyStore(db)
+}
+
+// ProvidePasskeyChallengeStore provides a passkey challenge store.
+func ProvidePasskeyChallengeStore(db *sqlx.DB) store.PasskeyChallengeStore {
+	return NewPasskeyChallengeStore(db)
+}
+
+// ProvideWorkingHoursStore provides a user working hours store.
+func ProvideWorkingHoursStore(db *sqlx.DB) store.WorkingHoursStore {
+	return NewWorkingHoursStore(db)
+}
+
+// ProvideRunnerStore provides a runner store.
+func ProvideRunnerStore(db *sqlx.DB) store.RunnerStore {
+	return NewRunnerStore(db)
+}
+
+// ProvideVariableStore provides a pipeline variable store.
+func ProvideVariableStore(db *sqlx.DB) store.VariableStore {
+	return NewVariableStore(db)
+}
+
+// ProvideVariableAuditStore provides a pipeline variable audit log store.
+func ProvideVariableAuditStore(db *sqlx.DB) store.VariableAuditStore {
+	return NewVariableAuditStore(db)
+}
+
+// ProvideCommitCommentStore provides a commit comment store.
+func ProvideCommitCommentStore(db *sqlx.DB) store.CommitCommentStore {
+	return NewCommitCommentStore(db)
+}
+
+// ProvideAutolinkStore provides an autolink rule store.
+func ProvideAutolinkStore(db *sqlx.DB) store.AutolinkStore {
+	return NewAutolinkStore(db)
+}
+
+// ProvideRequiredFileStore provides a required file policy store.
+func ProvideRequiredFileStore(db *sqlx.DB) store.RequiredFileStore {
+	return NewRequiredFileStore(db)
+}
+
+// ProvideRepoComplianceStore provides a repository compliance store.
+func ProvideRepoComplianceStore(db *sqlx.DB) store.RepoComplianceStore {
+	return NewRepoComplianceStore(db)
+}
+
+// ProvideComplianceSnapshotStore provides a compliance snapshot store.
+func ProvideComplianceSnapshotStore(db *sqlx.DB) store.ComplianceSnapshotStore {
+	return NewComplianceSnapshotStore(db)
+}
+
+// ProvideGitUsageStore provides a git usage store.
+func ProvideGitUsageStore(db *sqlx.DB) store.GitUsageStore {
+	return NewGitUsageStore(db)
+}
+
+// ProvideCommitIndexStore provides a commit search index store.
+func ProvideCommitIndexStore(db *sqlx.DB) store.CommitIndexStore {
+	return NewCommitIndexStore(db)
+}
+
+// ProvideRepoStatsStore provides a repository stats store.
+func ProvideRepoStatsStore(db *sqlx.DB) store.RepoStatsStore {
+	return NewRepoStatsStore(db)
+}
+
+// ProvideRepoMirrorStore provides a repository mirror store.
+func ProvideRepoMirrorStore(db *sqlx.DB) store.RepoMirrorStore {
+	return NewRepoMirrorStore(db)
+}
+
 // ProvideWebhookStore provides a webhook store.
 func ProvideWebhookStore(db *sqlx.DB) store.WebhookStore {
 	return NewWebhookStore(db)
@@ -247,3 +415,18 @@ func ProvideReqCheckStore(db *sqlx.DB,
 ) store.ReqCheckStore {
 	return NewReqCheckStore(db, principalInfoCache)
 }
+
+// ProvidePipelineCacheStore provides a pipeline cache store.
+func ProvidePipelineCacheStore(db *sqlx.DB) store.PipelineCacheStore {
+	return NewPipelineCacheStore(db)
+}
+
+// ProvidePipelineArtifactStore provides a pipeline artifact store.
+func ProvidePipelineArtifactStore(db *sqlx.DB) store.PipelineArtifactStore {
+	return NewPipelineArtifactStore(db)
+}
+
+// ProvideSavedComparisonStore provides a saved comparison store.
+func ProvideSavedComparisonStore(db *sqlx.DB) store.SavedComparisonStore {
+	return NewSavedComparisonStore(db)
+}
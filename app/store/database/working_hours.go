@@ -0,0 +1,212 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	sqlxtypes "github.com/jmoiron/sqlx/types"
+)
+
+var _ store.WorkingHoursStore = (*WorkingHoursStore)(nil)
+
+// NewWorkingHoursStore returns a new WorkingHoursStore.
+func NewWorkingHoursStore(db *sqlx.DB) *WorkingHoursStore {
+	return &WorkingHoursStore{
+		db: db,
+	}
+}
+
+// WorkingHoursStore implements store.WorkingHoursStore backed by a relational database.
+type WorkingHoursStore struct {
+	db *sqlx.DB
+}
+
+// workingHours is used to fetch working hours data from the database.
+type workingHours struct {
+	PrincipalID int64              `db:"working_hours_principal_id"`
+	Timezone    string             `db:"working_hours_timezone"`
+	Days        sqlxtypes.JSONText `db:"working_hours_days"`
+	Start       string             `db:"working_hours_start"`
+	End         string             `db:"working_hours_end"`
+	Created     int64              `db:"working_hours_created"`
+	Updated     int64              `db:"working_hours_updated"`
+}
+
+const (
+	workingHoursColumns = `
+		 working_hours_principal_id
+		,working_hours_timezone
+		,working_hours_days
+		,working_hours_start
+		,working_hours_end
+		,working_hours_created
+		,working_hours_updated`
+
+	workingHoursSelectBase = `
+	SELECT` + workingHoursColumns + `
+	FROM user_working_hours`
+)
+
+// Find returns the working hours of the principal.
+func (s *WorkingHoursStore) Find(ctx context.Context, principalID int64) (*types.WorkingHours, error) {
+	const sqlQuery = workingHoursSelectBase + `
+	WHERE working_hours_principal_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &workingHours{}
+	if err := db.GetContext(ctx, dst, sqlQuery, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find working hours")
+	}
+
+	return mapWorkingHours(dst)
+}
+
+// Map returns the working hours of the principals, keyed by principal ID.
+func (s *WorkingHoursStore) Map(
+	ctx context.Context,
+	principalIDs []int64,
+) (map[int64]*types.WorkingHours, error) {
+	result := make(map[int64]*types.WorkingHours)
+	if len(principalIDs) == 0 {
+		return result, nil
+	}
+
+	stmt := database.Builder.
+		Select(workingHoursColumns).
+		From("user_working_hours").
+		Where(squirrel.Eq{"working_hours_principal_id": principalIDs})
+
+	sqlQuery, params, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*workingHours
+	if err = db.SelectContext(ctx, &dst, sqlQuery, params...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list working hours")
+	}
+
+	for _, v := range dst {
+		hours, err := mapWorkingHours(v)
+		if err != nil {
+			return nil, err
+		}
+
+		result[hours.PrincipalID] = hours
+	}
+
+	return result, nil
+}
+
+// Upsert creates new or updates the existing working hours of the principal.
+func (s *WorkingHoursStore) Upsert(ctx context.Context, hours *types.WorkingHours) error {
+	const sqlQuery = `
+	INSERT INTO user_working_hours (
+		 working_hours_principal_id
+		,working_hours_timezone
+		,working_hours_days
+		,working_hours_start
+		,working_hours_end
+		,working_hours_created
+		,working_hours_updated
+	) VALUES (
+		 :working_hours_principal_id
+		,:working_hours_timezone
+		,:working_hours_days
+		,:working_hours_start
+		,:working_hours_end
+		,:working_hours_created
+		,:working_hours_updated
+	)
+	ON CONFLICT (working_hours_principal_id) DO
+	UPDATE SET
+		 working_hours_timezone = :working_hours_timezone
+		,working_hours_days = :working_hours_days
+		,working_hours_start = :working_hours_start
+		,working_hours_end = :working_hours_end
+		,working_hours_updated = :working_hours_updated
+	RETURNING working_hours_created`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapInternalWorkingHours(hours))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind working hours object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&hours.Created); err != nil {
+		return database.ProcessSQLErrorf(err, "Upsert query failed")
+	}
+
+	return nil
+}
+
+// Delete deletes the working hours of the principal.
+func (s *WorkingHoursStore) Delete(ctx context.Context, principalID int64) error {
+	const sqlQuery = `
+	DELETE FROM user_working_hours
+	WHERE working_hours_principal_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, principalID); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete working hours")
+	}
+
+	return nil
+}
+
+func mapWorkingHours(v *workingHours) (*types.WorkingHours, error) {
+	var days []enum.Weekday
+	if err := json.Unmarshal(v.Days, &days); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal working days: %w", err)
+	}
+
+	return &types.WorkingHours{
+		PrincipalID: v.PrincipalID,
+		Timezone:    v.Timezone,
+		Days:        days,
+		Start:       v.Start,
+		End:         v.End,
+		Created:     v.Created,
+		Updated:     v.Updated,
+	}, nil
+}
+
+func mapInternalWorkingHours(v *types.WorkingHours) *workingHours {
+	return &workingHours{
+		PrincipalID: v.PrincipalID,
+		Timezone:    v.Timezone,
+		Days:        EncodeToSQLXJSON(v.Days),
+		Start:       v.Start,
+		End:         v.End,
+		Created:     v.Created,
+		Updated:     v.Updated,
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Policies contains the token lifetime policies per principal type.
+type Policies map[enum.PrincipalType]types.TokenPolicy
+
+func NewPolicies(config *types.Config) Policies {
+	return Policies{
+		enum.PrincipalTypeUser: {
+			MaxLifetime:    config.Token.User.MaxLifetime,
+			IdleTimeout:    config.Token.User.IdleTimeout,
+			ReauthInterval: config.Token.User.ReauthInterval,
+		},
+		enum.PrincipalTypeServiceAccount: {
+			MaxLifetime:    config.Token.ServiceAccount.MaxLifetime,
+			IdleTimeout:    config.Token.ServiceAccount.IdleTimeout,
+			ReauthInterval: config.Token.ServiceAccount.ReauthInterval,
+		},
+		enum.PrincipalTypeService: {
+			MaxLifetime:    config.Token.Service.MaxLifetime,
+			IdleTimeout:    config.Token.Service.IdleTimeout,
+			ReauthInterval: config.Token.Service.ReauthInterval,
+		},
+	}
+}
+
+// checkLifetime returns an error if the requested lifetime of a new token isn't allowed for the principal type.
+// A token without a lifetime never expires, hence it isn't allowed if the max lifetime is set.
+func (p Policies) checkLifetime(principalType enum.PrincipalType, lifetime *time.Duration) error {
+	maxLifetime := p[principalType].MaxLifetime
+	if maxLifetime <= 0 {
+		return nil
+	}
+
+	if lifetime == nil || *lifetime > maxLifetime {
+		return errors.InvalidArgument("The life time of a token can't exceed %s.", maxLifetime)
+	}
+
+	return nil
+}
+
+// limitLifetime returns the lifetime reduced to the max lifetime allowed for the principal type.
+func (p Policies) limitLifetime(principalType enum.PrincipalType, lifetime time.Duration) time.Duration {
+	maxLifetime := p[principalType].MaxLifetime
+	if maxLifetime > 0 && lifetime > maxLifetime {
+		return maxLifetime
+	}
+
+	return lifetime
+}
+
+// Validate returns an error if the token can't be used anymore because of the max lifetime,
+// the idle timeout or the re-authentication interval of the principal type.
+// The max lifetime is enforced here too, as it limits tokens issued before the policy was set or lowered.
+func (p Policies) Validate(principalType enum.PrincipalType, token *types.Token, now time.Time) error {
+	policy := p[principalType]
+
+	if policy.MaxLifetime > 0 && now.Sub(time.UnixMilli(token.IssuedAt)) > policy.MaxLifetime {
+		return fmt.Errorf("token %d was issued more than %s ago, it exceeds the max lifetime",
+			token.ID, policy.MaxLifetime)
+	}
+
+	if policy.ReauthInterval > 0 && now.Sub(time.UnixMilli(token.IssuedAt)) > policy.ReauthInterval {
+		return fmt.Errorf("token %d was issued more than %s ago, re-authentication is required",
+			token.ID, policy.ReauthInterval)
+	}
+
+	if policy.IdleTimeout > 0 {
+		lastUsed := token.IssuedAt
+		if token.LastUsed != nil {
+			lastUsed = *token.LastUsed
+		}
+
+		if now.Sub(time.UnixMilli(lastUsed)) > policy.IdleTimeout {
+			return fmt.Errorf("token %d wasn't used for more than %s", token.ID, policy.IdleTimeout)
+		}
+	}
+
+	return nil
+}
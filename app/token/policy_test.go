@@ -0,0 +1,98 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token
+
+import (
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+)
+
+func TestPolicies_checkLifetime(t *testing.T) {
+	policies := Policies{
+		enum.PrincipalTypeServiceAccount: {MaxLifetime: 24 * time.Hour},
+	}
+
+	tests := []struct {
+		name          string
+		principalType enum.PrincipalType
+		lifetime      *time.Duration
+		wantErr       bool
+	}{
+		{name: "no policy", principalType: enum.PrincipalTypeUser, lifetime: nil},
+		{name: "within max", principalType: enum.PrincipalTypeServiceAccount, lifetime: ptr.Duration(time.Hour)},
+		{name: "above max", principalType: enum.PrincipalTypeServiceAccount, lifetime: ptr.Duration(48 * time.Hour),
+			wantErr: true},
+		{name: "no expiration", principalType: enum.PrincipalTypeServiceAccount, lifetime: nil, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policies.checkLifetime(tt.principalType, tt.lifetime)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("want error=%t got=%v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestPolicies_Validate(t *testing.T) {
+	now := time.Now()
+	hourAgo := now.Add(-time.Hour).UnixMilli()
+	dayAgo := now.Add(-24 * time.Hour).UnixMilli()
+
+	policies := Policies{
+		enum.PrincipalTypeUser: {
+			IdleTimeout:    2 * time.Hour,
+			ReauthInterval: 12 * time.Hour,
+		},
+		enum.PrincipalTypeService: {
+			MaxLifetime: 2 * time.Hour,
+		},
+	}
+
+	tests := []struct {
+		name          string
+		principalType enum.PrincipalType
+		token         types.Token
+		wantErr       bool
+	}{
+		{name: "no policy", principalType: enum.PrincipalTypeServiceAccount, token: types.Token{IssuedAt: dayAgo}},
+		{name: "recently issued", principalType: enum.PrincipalTypeUser, token: types.Token{IssuedAt: hourAgo}},
+		{name: "reauth required", principalType: enum.PrincipalTypeUser,
+			token: types.Token{IssuedAt: dayAgo, LastUsed: &hourAgo}, wantErr: true},
+		{name: "idle", principalType: enum.PrincipalTypeUser,
+			token: types.Token{IssuedAt: now.Add(-3 * time.Hour).UnixMilli()}, wantErr: true},
+		{name: "recently used", principalType: enum.PrincipalTypeUser,
+			token: types.Token{IssuedAt: now.Add(-3 * time.Hour).UnixMilli(), LastUsed: &hourAgo}},
+		{name: "within max lifetime", principalType: enum.PrincipalTypeService,
+			token: types.Token{IssuedAt: hourAgo, LastUsed: &hourAgo}},
+		{name: "max lifetime exceeded", principalType: enum.PrincipalTypeService,
+			token: types.Token{IssuedAt: dayAgo, LastUsed: &hourAgo}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policies.Validate(tt.principalType, &tt.token, now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("want error=%t got=%v", tt.wantErr, err)
+			}
+		})
+	}
+}
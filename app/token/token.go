@@ -36,6 +36,7 @@ const (
 func CreateUserSession(
 	ctx context.Context,
 	tokenStore store.TokenStore,
+	policies Policies,
 	user *types.User,
 	uid string,
 ) (*types.Token, string, error) {
@@ -47,18 +48,26 @@ func CreateUserSession(
 		principal,
 		principal,
 		uid,
-		ptr.Duration(userSessionTokenLifeTime),
+		ptr.Duration(policies.limitLifetime(enum.PrincipalTypeUser, userSessionTokenLifeTime)),
+		nil,
+		"",
 	)
 }
 
 func CreatePAT(
 	ctx context.Context,
 	tokenStore store.TokenStore,
+	policies Policies,
 	createdBy *types.Principal,
 	createdFor *types.User,
 	uid string,
 	lifetime *time.Duration,
+	spaceIDs []int64,
 ) (*types.Token, string, error) {
+	if err := policies.checkLifetime(enum.PrincipalTypeUser, lifetime); err != nil {
+		return nil, "", err
+	}
+
 	return create(
 		ctx,
 		tokenStore,
@@ -67,17 +76,24 @@ func CreatePAT(
 		createdFor.ToPrincipal(),
 		uid,
 		lifetime,
+		spaceIDs,
+		"",
 	)
 }
 
 func CreateSAT(
 	ctx context.Context,
 	tokenStore store.TokenStore,
+	policies Policies,
 	createdBy *types.Principal,
 	createdFor *types.ServiceAccount,
 	uid string,
 	lifetime *time.Duration,
 ) (*types.Token, string, error) {
+	if err := policies.checkLifetime(enum.PrincipalTypeServiceAccount, lifetime); err != nil {
+		return nil, "", err
+	}
+
 	return create(
 		ctx,
 		tokenStore,
@@ -86,6 +102,38 @@ func CreateSAT(
 		createdFor.ToPrincipal(),
 		uid,
 		lifetime,
+		nil,
+		"",
+	)
+}
+
+// CreateSpaceToken creates a space access token. The token belongs to the automation service account
+// of the space and grants the permissions of the role within the space (and everything within it).
+func CreateSpaceToken(
+	ctx context.Context,
+	tokenStore store.TokenStore,
+	policies Policies,
+	createdBy *types.Principal,
+	createdFor *types.ServiceAccount,
+	space *types.Space,
+	role enum.MembershipRole,
+	uid string,
+	lifetime *time.Duration,
+) (*types.Token, string, error) {
+	if err := policies.checkLifetime(enum.PrincipalTypeServiceAccount, lifetime); err != nil {
+		return nil, "", err
+	}
+
+	return create(
+		ctx,
+		tokenStore,
+		enum.TokenTypeSpace,
+		createdBy,
+		createdFor.ToPrincipal(),
+		uid,
+		lifetime,
+		[]int64{space.ID},
+		role,
 	)
 }
 
@@ -97,6 +145,8 @@ func create(
 	createdFor *types.Principal,
 	uid string,
 	lifetime *time.Duration,
+	spaceIDs []int64,
+	role enum.MembershipRole,
 ) (*types.Token, string, error) {
 	issuedAt := time.Now()
 
@@ -113,6 +163,8 @@ func create(
 		IssuedAt:    issuedAt.UnixMilli(),
 		ExpiresAt:   expiresAt,
 		CreatedBy:   createdBy.ID,
+		SpaceIDs:    spaceIDs,
+		Role:        role,
 	}
 
 	err := tokenStore.Create(ctx, &token)
@@ -40,6 +40,10 @@ type Provider interface {
 	// NOTE: url is guaranteed to not have any trailing '/'.
 	GetInternalAPIURL() string
 
+	// GetContainerAPIURL returns the url of the api that can be used by CI container builds.
+	// NOTE: url is guaranteed to not have any trailing '/'.
+	GetContainerAPIURL() string
+
 	// GenerateContainerGITCloneURL generates a URL that can be used by CI container builds to
 	// interact with gitness and clone a repo.
 	GenerateContainerGITCloneURL(repoPath string) string
@@ -143,6 +147,10 @@ func (p *provider) GetInternalAPIURL() string {
 	return p.internalURL.JoinPath(APIMount).String()
 }
 
+func (p *provider) GetContainerAPIURL() string {
+	return p.containerURL.JoinPath(APIMount).String()
+}
+
 func (p *provider) GenerateContainerGITCloneURL(repoPath string) string {
 	repoPath = path.Clean(repoPath)
 	if !strings.HasSuffix(repoPath, GITSuffix) {
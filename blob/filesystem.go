@@ -16,6 +16,7 @@ package blob
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -25,7 +26,8 @@ import (
 )
 
 const (
-	fileDiskPathFmt = "%s/%s"
+	fileDiskPathFmt     = "%s/%s"
+	metadataDiskPathFmt = "%s/.metadata/%s.json"
 )
 
 type FileSystemStore struct {
@@ -92,3 +94,48 @@ func (c *FileSystemStore) Download(_ context.Context, filePath string) (io.ReadC
 	}
 	return io.ReadCloser(file), nil
 }
+
+// SetMetadata stores the metadata as a json file in a separate directory of the base path.
+func (c *FileSystemStore) SetMetadata(_ context.Context, filePath string, metadata map[string]string) error {
+	fileDiskPath := fmt.Sprintf(fileDiskPathFmt, c.basePath, filePath)
+	if _, err := os.Stat(fileDiskPath); os.IsNotExist(err) {
+		return ErrNotFound
+	}
+
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+
+	metadataDiskPath := fmt.Sprintf(metadataDiskPathFmt, c.basePath, filePath)
+
+	dir, _ := path.Split(metadataDiskPath)
+	if err = os.MkdirAll(dir, os.ModeDir|os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create parent directory for the metadata: %w", err)
+	}
+
+	if err = os.WriteFile(metadataDiskPath, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write metadata to filesystem: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes the file along with its metadata.
+func (c *FileSystemStore) Delete(_ context.Context, filePath string) error {
+	fileDiskPath := fmt.Sprintf(fileDiskPathFmt, c.basePath, filePath)
+	err := os.Remove(fileDiskPath)
+	if os.IsNotExist(err) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to remove file: %w", err)
+	}
+
+	metadataDiskPath := fmt.Sprintf(metadataDiskPathFmt, c.basePath, filePath)
+	if err = os.Remove(metadataDiskPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove metadata: %w", err)
+	}
+
+	return nil
+}
@@ -34,4 +34,10 @@ type Store interface {
 
 	// Download returns a reader for a file in the blob store.
 	Download(ctx context.Context, filePath string) (io.ReadCloser, error)
+
+	// SetMetadata replaces the custom metadata of a file in the blob store.
+	SetMetadata(ctx context.Context, filePath string, metadata map[string]string) error
+
+	// Delete removes a file from the blob store.
+	Delete(ctx context.Context, filePath string) error
 }
@@ -15,8 +15,10 @@
 package cache
 
 import (
+	"context"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestDeduplicate(t *testing.T) {
@@ -67,3 +69,46 @@ func TestDeduplicate(t *testing.T) {
 		})
 	}
 }
+
+type countingGetter struct {
+	calls int
+}
+
+func (g *countingGetter) Find(_ context.Context, key int) (int, error) {
+	g.calls++
+	return key * 10, nil
+}
+
+func TestTTLCacheNotBefore(t *testing.T) {
+	getter := &countingGetter{}
+	c := New[int, int](getter, time.Minute)
+	defer c.Stop()
+
+	ctx := context.Background()
+
+	if _, err := c.Get(ctx, 1); err != nil {
+		t.Fatalf("failed to get: %v", err)
+	}
+	if _, err := c.Get(ctx, 1); err != nil {
+		t.Fatalf("failed to get: %v", err)
+	}
+	if want, got := 1, getter.calls; want != got {
+		t.Errorf("cached entry should be used: want=%d got=%d", want, got)
+	}
+
+	// an entry added before the not before time must be reloaded
+	if _, err := c.Get(WithNotBefore(ctx, time.Now().Add(time.Second)), 1); err != nil {
+		t.Fatalf("failed to get: %v", err)
+	}
+	if want, got := 2, getter.calls; want != got {
+		t.Errorf("stale entry should be reloaded: want=%d got=%d", want, got)
+	}
+
+	// an entry added after the not before time can be used
+	if _, err := c.Get(WithNotBefore(ctx, time.Now().Add(-time.Minute/2)), 1); err != nil {
+		t.Fatalf("failed to get: %v", err)
+	}
+	if want, got := 2, getter.calls; want != got {
+		t.Errorf("fresh entry should be used: want=%d got=%d", want, got)
+	}
+}
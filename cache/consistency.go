@@ -0,0 +1,41 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+type notBeforeKey struct{}
+
+// WithNotBefore returns a copy of the context that instructs the caches to treat
+// all entries added before the provided time as stale.
+// It's used to guarantee that a read reflects the caller's own earlier write.
+func WithNotBefore(ctx context.Context, t time.Time) context.Context {
+	return context.WithValue(ctx, notBeforeKey{}, t)
+}
+
+// NotBeforeFrom returns the time set with WithNotBefore, if any.
+func NotBeforeFrom(ctx context.Context) (time.Time, bool) {
+	t, ok := ctx.Value(notBeforeKey{}).(time.Time)
+	return t, ok
+}
+
+// isStale returns true if the entry added at the provided time must not be served for the context.
+func isStale(ctx context.Context, added time.Time) bool {
+	notBefore, ok := NotBeforeFrom(ctx)
+	return ok && added.Before(notBefore)
+}
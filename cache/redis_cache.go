@@ -77,10 +77,16 @@ func (c *Redis[K, V]) Get(ctx context.Context, key K) (V, error) {
 
 	raw, err := c.client.Get(ctx, strKey).Result()
 	if err == nil {
-		c.countHit++
-		return c.codec.Decode(raw)
-	}
-	if !errors.Is(err, redis.Nil) {
+		var stale bool
+		stale, err = c.isStale(ctx, strKey)
+		if err != nil {
+			return nothing, err
+		}
+		if !stale {
+			c.countHit++
+			return c.codec.Decode(raw)
+		}
+	} else if !errors.Is(err, redis.Nil) {
 		return nothing, err
 	}
 
@@ -98,3 +104,18 @@ func (c *Redis[K, V]) Get(ctx context.Context, key K) (V, error) {
 
 	return item, nil
 }
+
+// isStale returns true if the entry must not be served for the context.
+// The time the entry was added is derived from its remaining time to live.
+func (c *Redis[K, V]) isStale(ctx context.Context, strKey string) (bool, error) {
+	if _, ok := NotBeforeFrom(ctx); !ok {
+		return false, nil
+	}
+
+	ttl, err := c.client.PTTL(ctx, strKey).Result()
+	if err != nil {
+		return false, err
+	}
+
+	return isStale(ctx, time.Now().Add(ttl-c.duration)), nil
+}
@@ -116,12 +116,12 @@ func (c *TTLCache[K, V]) Stats() (int64, int64) {
 	return c.countHit, c.countMiss
 }
 
-func (c *TTLCache[K, V]) fetch(key K, now time.Time) (V, bool) {
+func (c *TTLCache[K, V]) fetch(ctx context.Context, key K, now time.Time) (V, bool) {
 	c.mx.RLock()
 	defer c.mx.RUnlock()
 
 	item, ok := c.cache[key]
-	if !ok || now.Sub(item.added) > c.maxAge {
+	if !ok || now.Sub(item.added) > c.maxAge || isStale(ctx, item.added) {
 		c.countMiss++
 		var nothing V
 		return nothing, false
@@ -148,7 +148,7 @@ func (c *ExtendedTTLCache[K, V]) Map(ctx context.Context, keys []K) (map[K]V, er
 	for idx < len(keys) {
 		key := keys[idx]
 
-		item, ok := c.fetch(key, now)
+		item, ok := c.fetch(ctx, key, now)
 		if !ok {
 			idx++
 			continue
@@ -191,7 +191,7 @@ func (c *TTLCache[K, V]) Get(ctx context.Context, key K) (V, error) {
 	now := time.Now()
 	var nothing V
 
-	item, ok := c.fetch(key, now)
+	item, ok := c.fetch(ctx, key, now)
 	if ok {
 		return item, nil
 	}
@@ -0,0 +1,91 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundle
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"text/template"
+
+	"github.com/harness/gitness/cli/provide"
+
+	"github.com/drone/funcmap"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type applyCommand struct {
+	file    string
+	repoRef string
+	prune   bool
+
+	json bool
+	tmpl string
+}
+
+func (c *applyCommand) run(*kingpin.ParseContext) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	f, err := os.Open(c.file)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	repo, err := provide.Client().RepoApplyBundle(ctx, c.repoRef, c.prune, f)
+	if err != nil {
+		return err
+	}
+	if c.json {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(repo)
+	}
+	tmpl, err := template.New("_").Funcs(funcmap.Funcs).Parse(c.tmpl)
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(os.Stdout, repo)
+}
+
+// RegisterApply helper function to register the apply-bundle command.
+func RegisterApply(app *kingpin.Application) {
+	c := &applyCommand{}
+
+	cmd := app.Command("apply-bundle", "update an existing repository from a bundle").
+		Action(c.run)
+
+	cmd.Arg("file", "the bundle file").
+		Required().
+		ExistingFileVar(&c.file)
+
+	cmd.Arg("repo", "the path or id of the repository").
+		Required().
+		StringVar(&c.repoRef)
+
+	cmd.Flag("prune", "delete the branches and tags that aren't part of the bundle").
+		BoolVar(&c.prune)
+
+	cmd.Flag("json", "json encode the output").
+		BoolVar(&c.json)
+
+	cmd.Flag("format", "format the output using a Go template").
+		Default(repoTmpl).
+		Hidden().
+		StringVar(&c.tmpl)
+}
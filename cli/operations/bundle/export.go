@@ -0,0 +1,72 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundle
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"github.com/harness/gitness/cli/provide"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type exportCommand struct {
+	repoRef  string
+	file     string
+	since    string
+	metadata bool
+}
+
+func (c *exportCommand) run(*kingpin.ParseContext) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	f, err := os.OpenFile(c.file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+
+	err = provide.Client().RepoExportBundle(ctx, c.repoRef, c.since, c.metadata, f)
+	if err = errors.Join(err, f.Close()); err != nil {
+		_ = os.Remove(c.file)
+		return err
+	}
+
+	return nil
+}
+
+// RegisterExport helper function to register the export-bundle command.
+func RegisterExport(app *kingpin.Application) {
+	c := &exportCommand{}
+
+	cmd := app.Command("export-bundle", "export a repository as bundle").
+		Action(c.run)
+
+	cmd.Arg("repo", "the path or id of the repository").
+		Required().
+		StringVar(&c.repoRef)
+
+	cmd.Arg("file", "the bundle file to create").
+		Required().
+		StringVar(&c.file)
+
+	cmd.Flag("since", "only export the changes since the commit (the bundle can be applied using apply-bundle)").
+		StringVar(&c.since)
+
+	cmd.Flag("metadata", "also export the pull requests, protection rules and webhooks (requires edit permission)").
+		BoolVar(&c.metadata)
+}
@@ -0,0 +1,98 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundle
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"text/template"
+
+	"github.com/harness/gitness/cli/provide"
+
+	"github.com/drone/funcmap"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const repoTmpl = `
+id:             {{ .ID }}
+path:           {{ .Path }}
+default branch: {{ .DefaultBranch }}
+git url:        {{ .GitURL }}
+`
+
+type importCommand struct {
+	file      string
+	parentRef string
+	uid       string
+
+	json bool
+	tmpl string
+}
+
+func (c *importCommand) run(*kingpin.ParseContext) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	f, err := os.Open(c.file)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	repo, err := provide.Client().RepoImportBundle(ctx, c.parentRef, c.uid, f)
+	if err != nil {
+		return err
+	}
+	if c.json {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(repo)
+	}
+	tmpl, err := template.New("_").Funcs(funcmap.Funcs).Parse(c.tmpl)
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(os.Stdout, repo)
+}
+
+// RegisterImport helper function to register the import-bundle command.
+func RegisterImport(app *kingpin.Application) {
+	c := &importCommand{}
+
+	cmd := app.Command("import-bundle", "create a repository from a bundle").
+		Action(c.run)
+
+	cmd.Arg("file", "the bundle file").
+		Required().
+		ExistingFileVar(&c.file)
+
+	cmd.Arg("parent", "the path or id of the parent space").
+		Required().
+		StringVar(&c.parentRef)
+
+	cmd.Flag("uid", "the uid of the repository, defaults to the uid stored in the bundle").
+		StringVar(&c.uid)
+
+	cmd.Flag("json", "json encode the output").
+		BoolVar(&c.json)
+
+	cmd.Flag("format", "format the output using a Go template").
+		Default(repoTmpl).
+		Hidden().
+		StringVar(&c.tmpl)
+}
@@ -0,0 +1,109 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pipeline provides the commands to work with pipeline definitions.
+package pipeline
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/harness/gitness/app/pipeline/convert"
+	"github.com/harness/gitness/types/enum"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type convertCommand struct {
+	files     []string
+	format    string
+	outputDir string
+}
+
+func (c *convertCommand) run(*kingpin.ParseContext) error {
+	if len(c.files) > 1 && c.outputDir == "" {
+		return errors.New("an output directory is required to convert multiple files")
+	}
+
+	for _, file := range c.files {
+		if err := c.convertFile(file); err != nil {
+			return fmt.Errorf("failed to convert %s: %w", file, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *convertCommand) convertFile(file string) error {
+	format := enum.PipelineFormat(c.format)
+	if format == "" {
+		var ok bool
+		if format, ok = convert.Detect(filepath.ToSlash(file)); !ok {
+			return errors.New("unknown pipeline format, use --format")
+		}
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	result, err := convert.Convert(format, data)
+	if err != nil {
+		return err
+	}
+
+	for _, issue := range result.Unsupported {
+		fmt.Fprintf(os.Stderr, "%s: %s: %s\n", file, issue.Path, issue.Message)
+	}
+
+	if c.outputDir == "" {
+		_, err = os.Stdout.WriteString(result.Data)
+		return err
+	}
+
+	base := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	if format == enum.PipelineFormatGitLabCI {
+		base = "pipeline"
+	}
+
+	if err = os.MkdirAll(c.outputDir, 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(c.outputDir, base+".yaml"), []byte(result.Data), 0o600)
+}
+
+// RegisterConvert helper function to register the convert-pipeline command.
+func RegisterConvert(app *kingpin.Application) {
+	c := &convertCommand{}
+
+	cmd := app.Command("convert-pipeline",
+		"convert GitHub Actions workflows and GitLab CI configurations to gitness pipelines").
+		Action(c.run)
+
+	cmd.Arg("files", "the pipeline definitions to convert").
+		Required().
+		StringsVar(&c.files)
+
+	cmd.Flag("format", "the format of the pipeline definitions, detected from the file path if not set").
+		EnumVar(&c.format, string(enum.PipelineFormatGitHubActions), string(enum.PipelineFormatGitLabCI))
+
+	cmd.Flag("output-dir", "the directory the converted pipelines are written to, "+
+		"the pipeline is printed if not set").
+		StringVar(&c.outputDir)
+}
@@ -32,8 +32,10 @@ import (
 	"github.com/harness/gitness/events"
 	gittypes "github.com/harness/gitness/git/types"
 	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/leader"
 	"github.com/harness/gitness/lock"
 	"github.com/harness/gitness/pubsub"
+	"github.com/harness/gitness/scanner"
 	"github.com/harness/gitness/store/database"
 	"github.com/harness/gitness/types"
 
@@ -95,6 +97,10 @@ func LoadConfig() (*types.Config, error) {
 		}
 	}
 
+	if config.Git.PackCache.Dir == "" {
+		config.Git.PackCache.Dir = filepath.Join(config.Git.Root, "pack-cache")
+	}
+
 	return config, nil
 }
 
@@ -234,8 +240,9 @@ func getSanitizedMachineName() (string, error) {
 // ProvideDatabaseConfig loads the database config from the main config.
 func ProvideDatabaseConfig(config *types.Config) database.Config {
 	return database.Config{
-		Driver:     config.Database.Driver,
-		Datasource: config.Database.Datasource,
+		Driver:             config.Database.Driver,
+		Datasource:         config.Database.Datasource,
+		SlowQueryThreshold: config.Database.SlowQueryThreshold,
 	}
 }
 
@@ -260,6 +267,15 @@ func ProvideBlobStoreConfig(config *types.Config) (blob.Config, error) {
 	}, nil
 }
 
+// ProvideScannerConfig loads the scanner config from the main config.
+func ProvideScannerConfig(config *types.Config) scanner.Config {
+	return scanner.Config{
+		Provider: config.Scanner.Provider,
+		Address:  config.Scanner.Address,
+		Timeout:  config.Scanner.Timeout,
+	}
+}
+
 // ProvideGitConfig loads the git config from the main config.
 func ProvideGitConfig(config *types.Config) gittypes.Config {
 	return gittypes.Config{
@@ -267,10 +283,26 @@ func ProvideGitConfig(config *types.Config) gittypes.Config {
 		Root:     config.Git.Root,
 		TmpDir:   config.Git.TmpDir,
 		HookPath: config.Git.HookPath,
+
+		PartialClone: config.Git.PartialClone,
 		LastCommitCache: gittypes.LastCommitCacheConfig{
 			Mode:     config.Git.LastCommitCache.Mode,
 			Duration: config.Git.LastCommitCache.Duration,
 		},
+		ObjectCache: gittypes.ObjectCacheConfig{
+			Size:          config.Git.ObjectCache.Size,
+			MaxObjectSize: config.Git.ObjectCache.MaxObjectSize,
+		},
+		PackCache: gittypes.PackCacheConfig{
+			Enabled: config.Git.PackCache.Enabled,
+			Dir:     config.Git.PackCache.Dir,
+			MaxAge:  config.Git.PackCache.MaxAge,
+			MaxSize: config.Git.PackCache.MaxSize,
+		},
+		Signing: gittypes.SigningConfig{
+			Format:  config.Git.Signing.Format,
+			KeyPath: config.Git.Signing.KeyPath,
+		},
 	}
 }
 
@@ -328,6 +360,16 @@ func ProvideLockConfig(config *types.Config) lock.Config {
 	}
 }
 
+// ProvideLeaderConfig loads the leader election config from the main config.
+func ProvideLeaderConfig(config *types.Config) leader.Config {
+	return leader.Config{
+		InstanceID:    config.InstanceID,
+		TTL:           config.Leader.TTL,
+		RenewInterval: config.Leader.RenewInterval,
+		RetryInterval: config.Leader.RetryInterval,
+	}
+}
+
 // ProvidePubsubConfig loads the pubsub config from the main config.
 func ProvidePubsubConfig(config *types.Config) pubsub.Config {
 	return pubsub.Config{
@@ -344,6 +386,8 @@ func ProvidePubsubConfig(config *types.Config) pubsub.Config {
 func ProvideCleanupConfig(config *types.Config) cleanup.Config {
 	return cleanup.Config{
 		WebhookExecutionsRetentionTime: config.Webhook.RetentionTime,
+		GitUsageRetentionTime:          config.GitUsage.RetentionTime,
+		PipelineArtifactRetentionTime:  config.CI.Artifacts.RetentionTime,
 	}
 }
 
@@ -368,5 +412,6 @@ func ProvideJobsConfig(config *types.Config) job.Config {
 		InstanceID:                  config.InstanceID,
 		BackgroundJobsMaxRunning:    config.BackgroundJobs.MaxRunning,
 		BackgroundJobsRetentionTime: config.BackgroundJobs.RetentionTime,
+		BackgroundJobsSpaceWeights:  config.BackgroundJobs.SpaceWeights,
 	}
 }
@@ -84,30 +84,17 @@ func (c *command) run(*kingpin.ParseContext) error {
 	// - ctx is canceled
 	g, gCtx := errgroup.WithContext(ctx)
 
+	// the job scheduler runs on every instance, the jobs are distributed among them.
 	g.Go(func() error {
-		// initialize metric collector
-		if system.services.MetricCollector != nil {
-			if err := system.services.MetricCollector.Register(gCtx); err != nil {
-				log.Error().Err(err).Msg("failed to register metric collector")
-				return err
-			}
-		}
-
-		if system.services.RepoSizeCalculator != nil {
-			if err := system.services.RepoSizeCalculator.Register(gCtx); err != nil {
-				log.Error().Err(err).Msg("failed to register repo size calculator")
-				return err
-			}
-		}
-
-		if err := system.services.Cleanup.Register(gCtx); err != nil {
-			log.Error().Err(err).Msg("failed to register cleanup service")
-			return err
-		}
-
 		return system.services.JobScheduler.Run(gCtx)
 	})
 
+	// the singleton background services run only on the leader instance,
+	// other instances are warm standby and take over if the leader goes away.
+	g.Go(func() error {
+		return system.leaderElector.Run(gCtx, system.runSingletonServices)
+	})
+
 	// start server
 	gHTTP, shutdownHTTP := system.server.ListenAndServe()
 	g.Go(gHTTP.Wait)
@@ -15,12 +15,16 @@
 package server
 
 import (
+	"context"
+
 	"github.com/harness/gitness/app/bootstrap"
 	"github.com/harness/gitness/app/pipeline/plugin"
 	"github.com/harness/gitness/app/server"
 	"github.com/harness/gitness/app/services"
+	"github.com/harness/gitness/leader"
 
 	"github.com/drone/runner-go/poller"
+	"github.com/rs/zerolog/log"
 )
 
 // System stores high level System sub-routines.
@@ -30,16 +34,103 @@ type System struct {
 	pluginManager *plugin.Manager
 	poller        *poller.Poller
 	services      services.Services
+	leaderElector *leader.Elector
 }
 
 // NewSystem returns a new system structure.
 func NewSystem(bootstrap bootstrap.Bootstrap, server *server.Server, poller *poller.Poller,
-	pluginManager *plugin.Manager, services services.Services) *System {
+	pluginManager *plugin.Manager, services services.Services, leaderElector *leader.Elector) *System {
 	return &System{
 		bootstrap:     bootstrap,
 		server:        server,
 		poller:        poller,
 		pluginManager: pluginManager,
 		services:      services,
+		leaderElector: leaderElector,
+	}
+}
+
+// runSingletonServices registers the recurring jobs of the background services. Only the leader
+// instance registers them, the jobs themselves are executed by the job schedulers of all instances.
+// It's a blocking call. It blocks until the provided context is done.
+func (s *System) runSingletonServices(ctx context.Context) error {
+	// initialize metric collector
+	if s.services.MetricCollector != nil {
+		if err := s.services.MetricCollector.Register(ctx); err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("failed to register metric collector")
+			return err
+		}
+	}
+
+	if s.services.RepoSizeCalculator != nil {
+		if err := s.services.RepoSizeCalculator.Register(ctx); err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("failed to register repo size calculator")
+			return err
+		}
+	}
+
+	if s.services.RepoMaintenance != nil {
+		if err := s.services.RepoMaintenance.Register(ctx); err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("failed to register repo maintenance service")
+			return err
+		}
+	}
+
+	if s.services.RepoStats != nil {
+		if err := s.services.RepoStats.Register(ctx); err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("failed to register repo stats service")
+			return err
+		}
+	}
+
+	if s.services.RepoMirror != nil {
+		if err := s.services.RepoMirror.Register(ctx); err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("failed to register repo mirror service")
+			return err
+		}
+	}
+
+	if s.services.Compliance != nil {
+		if err := s.services.Compliance.Register(ctx); err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("failed to register compliance service")
+			return err
+		}
+	}
+
+	if s.services.ComplianceSnapshot != nil {
+		if err := s.services.ComplianceSnapshot.Register(ctx); err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("failed to register compliance snapshot service")
+			return err
+		}
+	}
+
+	if s.services.PipelineSchedule != nil {
+		if err := s.services.PipelineSchedule.Register(ctx); err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("failed to register pipeline schedule service")
+			return err
+		}
+	}
+
+	if s.services.PipelineApproval != nil {
+		if err := s.services.PipelineApproval.Register(ctx); err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("failed to register pipeline approval service")
+			return err
+		}
 	}
+
+	if s.services.PipelineTimeout != nil {
+		if err := s.services.PipelineTimeout.Register(ctx); err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("failed to register pipeline timeout service")
+			return err
+		}
+	}
+
+	if err := s.services.Cleanup.Register(ctx); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("failed to register cleanup service")
+		return err
+	}
+
+	<-ctx.Done()
+
+	return ctx.Err()
 }
@@ -23,6 +23,7 @@ import (
 	"net/http"
 	"net/http/httputil"
 	"net/url"
+	"strconv"
 
 	"github.com/harness/gitness/app/api/controller/user"
 	"github.com/harness/gitness/types"
@@ -143,6 +144,71 @@ func (c *HTTPClient) UserDelete(ctx context.Context, key string) error {
 	return err
 }
 
+//
+// Repository Endpoints
+//
+
+// RepoExportBundle writes the portable bundle of a repository to the provided writer.
+// The bundle only contains the changes since the provided commit, if any.
+// If metadata is true, the bundle also contains the pull requests, protection rules and webhooks.
+func (c *HTTPClient) RepoExportBundle(
+	ctx context.Context,
+	repoRef string,
+	sinceSHA string,
+	metadata bool,
+	w io.Writer,
+) error {
+	query := url.Values{}
+	if sinceSHA != "" {
+		query.Set("since_sha", sinceSHA)
+	}
+	if metadata {
+		query.Set("metadata", "true")
+	}
+	uri := fmt.Sprintf("%s/api/v1/repos/%s/bundle?%s", c.base, url.PathEscape(repoRef), query.Encode())
+	body, err := c.stream(ctx, uri, "GET", false, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer func(body io.ReadCloser) {
+		_ = body.Close()
+	}(body)
+
+	_, err = io.Copy(w, body)
+	return err
+}
+
+// RepoImportBundle creates a new repository in the parent space from a portable bundle.
+func (c *HTTPClient) RepoImportBundle(
+	ctx context.Context,
+	parentRef string,
+	uid string,
+	bundle io.Reader,
+) (*types.Repository, error) {
+	out := new(types.Repository)
+	query := url.Values{}
+	query.Set("parent_ref", parentRef)
+	query.Set("uid", uid)
+	uri := fmt.Sprintf("%s/api/v1/repos/import-bundle?%s", c.base, query.Encode())
+	err := c.post(ctx, uri, false, bundle, out)
+	return out, err
+}
+
+// RepoApplyBundle updates an existing repository from a portable bundle.
+func (c *HTTPClient) RepoApplyBundle(
+	ctx context.Context,
+	repoRef string,
+	prune bool,
+	bundle io.Reader,
+) (*types.Repository, error) {
+	out := new(types.Repository)
+	query := url.Values{}
+	query.Set("prune", strconv.FormatBool(prune))
+	uri := fmt.Sprintf("%s/api/v1/repos/%s/bundle?%s", c.base, url.PathEscape(repoRef), query.Encode())
+	err := c.post(ctx, uri, false, bundle, out)
+	return out, err
+}
+
 //
 // http request helper functions
 //
@@ -198,22 +264,30 @@ func (c *HTTPClient) stream(ctx context.Context, rawurl, method string, noToken
 	}
 
 	// if we are posting or putting data, we need to
-	// write it to the body of the request.
-	var buf io.ReadWriter
-	if in != nil {
-		buf = &bytes.Buffer{}
+	// write it to the body of the request. Readers are
+	// sent as is, anything else is json encoded.
+	var body io.Reader
+	contentType := "application/json"
+	switch in := in.(type) {
+	case nil:
+	case io.Reader:
+		body = in
+		contentType = "application/octet-stream"
+	default:
+		buf := &bytes.Buffer{}
 		if err = json.NewEncoder(buf).Encode(in); err != nil {
 			return nil, err
 		}
+		body = buf
 	}
 
 	// creates a new http request.
-	req, err := http.NewRequestWithContext(ctx, method, uri.String(), buf)
+	req, err := http.NewRequestWithContext(ctx, method, uri.String(), body)
 	if err != nil {
 		return nil, err
 	}
 	if in != nil {
-		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Content-Type", contentType)
 	}
 	if !noToken && c.token != "" {
 		req.Header.Set("Authorization", "Bearer "+c.token)
@@ -16,6 +16,7 @@ package client
 
 import (
 	"context"
+	"io"
 
 	"github.com/harness/gitness/app/api/controller/user"
 	"github.com/harness/gitness/types"
@@ -49,6 +50,17 @@ type Client interface {
 
 	// UserCreatePAT creates a new PAT for the user.
 	UserCreatePAT(ctx context.Context, in user.CreateTokenInput) (*types.TokenResponse, error)
+
+	// RepoExportBundle writes the portable bundle of a repository to the provided writer.
+	// The bundle only contains the changes since the provided commit, if any.
+	// If metadata is true, the bundle also contains the pull requests, protection rules and webhooks.
+	RepoExportBundle(ctx context.Context, repoRef string, sinceSHA string, metadata bool, w io.Writer) error
+
+	// RepoImportBundle creates a new repository in the parent space from a portable bundle.
+	RepoImportBundle(ctx context.Context, parentRef string, uid string, bundle io.Reader) (*types.Repository, error)
+
+	// RepoApplyBundle updates an existing repository from a portable bundle.
+	RepoApplyBundle(ctx context.Context, repoRef string, prune bool, bundle io.Reader) (*types.Repository, error)
 }
 
 // remoteError store the error payload returned
@@ -17,8 +17,10 @@ package main
 import (
 	"github.com/harness/gitness/cli"
 	"github.com/harness/gitness/cli/operations/account"
+	"github.com/harness/gitness/cli/operations/bundle"
 	"github.com/harness/gitness/cli/operations/hooks"
 	"github.com/harness/gitness/cli/operations/migrate"
+	"github.com/harness/gitness/cli/operations/pipeline"
 	"github.com/harness/gitness/cli/operations/user"
 	"github.com/harness/gitness/cli/operations/users"
 	"github.com/harness/gitness/cli/server"
@@ -47,6 +49,12 @@ func main() {
 	account.RegisterRegister(app)
 	account.RegisterLogout(app)
 
+	bundle.RegisterImport(app)
+	bundle.RegisterExport(app)
+	bundle.RegisterApply(app)
+
+	pipeline.RegisterConvert(app)
+
 	hooks.Register(app)
 
 	cli.RegisterSwagger(app)
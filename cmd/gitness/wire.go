@@ -21,6 +21,7 @@ import (
 	"github.com/harness/gitness/app/api/controller/principal"
 	"github.com/harness/gitness/app/api/controller/pullreq"
 	"github.com/harness/gitness/app/api/controller/repo"
+	controllerrunner "github.com/harness/gitness/app/api/controller/runner"
 	"github.com/harness/gitness/app/api/controller/secret"
 	"github.com/harness/gitness/app/api/controller/service"
 	"github.com/harness/gitness/app/api/controller/serviceaccount"
@@ -30,6 +31,7 @@ import (
 	controllertrigger "github.com/harness/gitness/app/api/controller/trigger"
 	"github.com/harness/gitness/app/api/controller/upload"
 	"github.com/harness/gitness/app/api/controller/user"
+	controllervariable "github.com/harness/gitness/app/api/controller/variable"
 	controllerwebhook "github.com/harness/gitness/app/api/controller/webhook"
 	"github.com/harness/gitness/app/auth/authn"
 	"github.com/harness/gitness/app/auth/authz"
@@ -45,30 +47,52 @@ import (
 	pluginmanager "github.com/harness/gitness/app/pipeline/plugin"
 	"github.com/harness/gitness/app/pipeline/runner"
 	"github.com/harness/gitness/app/pipeline/scheduler"
+	"github.com/harness/gitness/app/pipeline/secrets"
 	"github.com/harness/gitness/app/pipeline/triggerer"
 	"github.com/harness/gitness/app/router"
 	"github.com/harness/gitness/app/server"
 	"github.com/harness/gitness/app/services"
+	"github.com/harness/gitness/app/services/approval"
+	"github.com/harness/gitness/app/services/auditsnapshot"
+	"github.com/harness/gitness/app/services/autolink"
+	"github.com/harness/gitness/app/services/backup"
+	"github.com/harness/gitness/app/services/blobscan"
 	"github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/codecomments"
 	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/commitindex"
+	"github.com/harness/gitness/app/services/compliance"
 	"github.com/harness/gitness/app/services/exporter"
+	"github.com/harness/gitness/app/services/githookext"
+	"github.com/harness/gitness/app/services/gitusage"
 	"github.com/harness/gitness/app/services/importer"
 	"github.com/harness/gitness/app/services/keywordsearch"
 	"github.com/harness/gitness/app/services/metric"
+	"github.com/harness/gitness/app/services/mirror"
 	"github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/services/notification/mailer"
+	"github.com/harness/gitness/app/services/passkey"
+	"github.com/harness/gitness/app/services/pipelineartifact"
+	"github.com/harness/gitness/app/services/pipelinecache"
+	"github.com/harness/gitness/app/services/pipelinetimeout"
+	"github.com/harness/gitness/app/services/policyhook"
 	"github.com/harness/gitness/app/services/protection"
 	pullreqservice "github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/pullreqbulk"
+	"github.com/harness/gitness/app/services/repomaintenance"
 	"github.com/harness/gitness/app/services/reposize"
+	"github.com/harness/gitness/app/services/repostats"
+	"github.com/harness/gitness/app/services/schedule"
 	"github.com/harness/gitness/app/services/trigger"
 	"github.com/harness/gitness/app/services/usergroup"
+	"github.com/harness/gitness/app/services/usersigning"
 	"github.com/harness/gitness/app/services/webhook"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/store/cache"
 	"github.com/harness/gitness/app/store/database"
 	"github.com/harness/gitness/app/store/logs"
+	"github.com/harness/gitness/app/token"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/blob"
 	cliserver "github.com/harness/gitness/cli/server"
@@ -78,9 +102,11 @@ import (
 	"github.com/harness/gitness/git/adapter"
 	"github.com/harness/gitness/git/storage"
 	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/leader"
 	"github.com/harness/gitness/livelog"
 	"github.com/harness/gitness/lock"
 	"github.com/harness/gitness/pubsub"
+	"github.com/harness/gitness/scanner"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
@@ -99,6 +125,9 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		mailer.WireSet,
 		notification.WireSet,
 		blob.WireSet,
+		cliserver.ProvideScannerConfig,
+		scanner.WireSet,
+		blobscan.WireSet,
 		dbtx.WireSet,
 		cache.WireSet,
 		router.WireSet,
@@ -118,6 +147,7 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		principal.WireSet,
 		system.WireSet,
 		authn.WireSet,
+		token.WireSet,
 		authz.WireSet,
 		gitevents.WireSet,
 		pullreqevents.WireSet,
@@ -139,6 +169,8 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		githook.WireSet,
 		cliserver.ProvideLockConfig,
 		lock.WireSet,
+		leader.WireSet,
+		cliserver.ProvideLeaderConfig,
 		cliserver.ProvidePubsubConfig,
 		pubsub.WireSet,
 		cliserver.ProvideCleanupConfig,
@@ -147,6 +179,8 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		cliserver.ProvideJobsConfig,
 		job.WireSet,
 		protection.WireSet,
+		githookext.WireSet,
+		policyhook.WireSet,
 		checkcontroller.WireSet,
 		execution.WireSet,
 		pipeline.WireSet,
@@ -162,6 +196,7 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		runner.WireSet,
 		sse.WireSet,
 		scheduler.WireSet,
+		secrets.WireSet,
 		commit.WireSet,
 		controllertrigger.WireSet,
 		plugin.WireSet,
@@ -171,12 +206,31 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		exporter.WireSet,
 		metric.WireSet,
 		reposize.WireSet,
+		repomaintenance.WireSet,
+		repostats.WireSet,
+		mirror.WireSet,
+		backup.WireSet,
+		commitindex.WireSet,
+		compliance.WireSet,
+		pullreqbulk.WireSet,
+		auditsnapshot.WireSet,
+		schedule.WireSet,
+		approval.WireSet,
+		pipelinetimeout.WireSet,
+		gitusage.WireSet,
+		pipelinecache.WireSet,
+		pipelineartifact.WireSet,
 		cliserver.ProvideCodeOwnerConfig,
 		codeowners.WireSet,
 		cliserver.ProvideKeywordSearchConfig,
 		keywordsearch.WireSet,
 		controllerkeywordsearch.WireSet,
+		controllerrunner.WireSet,
+		controllervariable.WireSet,
 		usergroup.WireSet,
+		usersigning.WireSet,
+		passkey.WireSet,
+		autolink.WireSet,
 	)
 	return &cliserver.System{}, nil
 }
@@ -20,6 +20,7 @@ import (
 	"github.com/harness/gitness/app/api/controller/principal"
 	pullreq2 "github.com/harness/gitness/app/api/controller/pullreq"
 	"github.com/harness/gitness/app/api/controller/repo"
+	runner2 "github.com/harness/gitness/app/api/controller/runner"
 	"github.com/harness/gitness/app/api/controller/secret"
 	"github.com/harness/gitness/app/api/controller/service"
 	"github.com/harness/gitness/app/api/controller/serviceaccount"
@@ -29,6 +30,7 @@ import (
 	"github.com/harness/gitness/app/api/controller/trigger"
 	"github.com/harness/gitness/app/api/controller/upload"
 	"github.com/harness/gitness/app/api/controller/user"
+	"github.com/harness/gitness/app/api/controller/variable"
 	webhook2 "github.com/harness/gitness/app/api/controller/webhook"
 	"github.com/harness/gitness/app/auth/authn"
 	"github.com/harness/gitness/app/auth/authz"
@@ -44,30 +46,52 @@ import (
 	plugin2 "github.com/harness/gitness/app/pipeline/plugin"
 	"github.com/harness/gitness/app/pipeline/runner"
 	"github.com/harness/gitness/app/pipeline/scheduler"
+	"github.com/harness/gitness/app/pipeline/secrets"
 	"github.com/harness/gitness/app/pipeline/triggerer"
 	"github.com/harness/gitness/app/router"
 	server2 "github.com/harness/gitness/app/server"
 	"github.com/harness/gitness/app/services"
+	"github.com/harness/gitness/app/services/approval"
+	"github.com/harness/gitness/app/services/auditsnapshot"
+	"github.com/harness/gitness/app/services/autolink"
+	"github.com/harness/gitness/app/services/backup"
+	"github.com/harness/gitness/app/services/blobscan"
 	"github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/codecomments"
 	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/commitindex"
+	"github.com/harness/gitness/app/services/compliance"
 	"github.com/harness/gitness/app/services/exporter"
+	"github.com/harness/gitness/app/services/githookext"
+	"github.com/harness/gitness/app/services/gitusage"
 	"github.com/harness/gitness/app/services/importer"
 	"github.com/harness/gitness/app/services/keywordsearch"
 	"github.com/harness/gitness/app/services/metric"
+	"github.com/harness/gitness/app/services/mirror"
 	"github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/services/notification/mailer"
+	"github.com/harness/gitness/app/services/passkey"
+	"github.com/harness/gitness/app/services/pipelineartifact"
+	"github.com/harness/gitness/app/services/pipelinecache"
+	"github.com/harness/gitness/app/services/pipelinetimeout"
+	"github.com/harness/gitness/app/services/policyhook"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/pullreqbulk"
+	"github.com/harness/gitness/app/services/repomaintenance"
 	"github.com/harness/gitness/app/services/reposize"
+	"github.com/harness/gitness/app/services/repostats"
+	"github.com/harness/gitness/app/services/schedule"
 	trigger2 "github.com/harness/gitness/app/services/trigger"
 	"github.com/harness/gitness/app/services/usergroup"
+	"github.com/harness/gitness/app/services/usersigning"
 	"github.com/harness/gitness/app/services/webhook"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/store/cache"
 	"github.com/harness/gitness/app/store/database"
 	"github.com/harness/gitness/app/store/logs"
+	"github.com/harness/gitness/app/token"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/cli/server"
@@ -77,9 +101,11 @@ import (
 	"github.com/harness/gitness/git/adapter"
 	"github.com/harness/gitness/git/storage"
 	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/leader"
 	"github.com/harness/gitness/livelog"
 	"github.com/harness/gitness/lock"
 	"github.com/harness/gitness/pubsub"
+	"github.com/harness/gitness/scanner"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
@@ -112,10 +138,25 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	principalUIDTransformation := store.ProvidePrincipalUIDTransformation()
 	principalStore := database.ProvidePrincipalStore(db, principalUIDTransformation)
 	tokenStore := database.ProvideTokenStore(db)
-	controller := user.ProvideController(transactor, principalUID, authorizer, principalStore, tokenStore, membershipStore)
+	policies := token.ProvidePolicies(config)
+	userSigningKeyStore := database.ProvideUserSigningKeyStore(db)
+	publicKeyStore := database.ProvidePublicKeyStore(db)
+	encrypter, err := encrypt.ProvideEncrypter(config)
+	if err != nil {
+		return nil, err
+	}
+	usersigningService := usersigning.ProvideService(config, transactor, userSigningKeyStore, publicKeyStore, principalStore, principalInfoCache, encrypter)
+	passkeyStore := database.ProvidePasskeyStore(db)
+	passkeyChallengeStore := database.ProvidePasskeyChallengeStore(db)
+	passkeyService, err := passkey.ProvideService(config, transactor, passkeyStore, passkeyChallengeStore, principalStore)
+	if err != nil {
+		return nil, err
+	}
+	workingHoursStore := database.ProvideWorkingHoursStore(db)
+	controller := user.ProvideController(config, transactor, principalUID, authorizer, principalStore, tokenStore, membershipStore, spaceStore, policies, usersigningService, passkeyService, workingHoursStore)
 	serviceController := service.NewController(principalUID, authorizer, principalStore)
 	bootstrapBootstrap := bootstrap.ProvideBootstrap(config, controller, serviceController)
-	authenticator := authn.ProvideAuthenticator(config, principalStore, tokenStore)
+	authenticator := authn.ProvideAuthenticator(config, principalStore, tokenStore, policies, passkeyService)
 	provider, err := url.ProvideURLProvider(config)
 	if err != nil {
 		return nil, err
@@ -124,6 +165,14 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	repoStore := database.ProvideRepoStore(db, spacePathCache, spacePathStore)
 	pipelineStore := database.ProvidePipelineStore(db)
 	ruleStore := database.ProvideRuleStore(db, principalInfoCache)
+	mergeTemplateStore := database.ProvideMergeTemplateStore(db)
+	signingKeyStore := database.ProvideSigningKeyStore(db)
+	commitCommentStore := database.ProvideCommitCommentStore(db)
+	autolinkStore := database.ProvideAutolinkStore(db)
+	watchStore := database.ProvideWatchStore(db)
+	defaultReviewerStore := database.ProvideDefaultReviewerStore(db)
+	repoAliasStore := database.ProvideRepoAliasStore(db)
+	autolinkService := autolink.ProvideService(autolinkStore, spaceStore)
 	protectionManager, err := protection.ProvideManager(ruleStore)
 	if err != nil {
 		return nil, err
@@ -148,10 +197,7 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 		return nil, err
 	}
 	triggerStore := database.ProvideTriggerStore(db)
-	encrypter, err := encrypt.ProvideEncrypter(config)
-	if err != nil {
-		return nil, err
-	}
+	scheduleStore := database.ProvideScheduleStore(db)
 	jobStore := database.ProvideJobStore(db)
 	pubsubConfig := server.ProvidePubsubConfig(config)
 	pubSub := pubsub.ProvidePubSub(pubsubConfig, universalClient)
@@ -166,7 +212,10 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	streamer := sse.ProvideEventsStreaming(pubSub)
 	localIndexSearcher := keywordsearch.ProvideLocalIndexSearcher()
 	indexer := keywordsearch.ProvideIndexer(localIndexSearcher)
-	repository, err := importer.ProvideRepoImporter(config, provider, gitInterface, transactor, repoStore, pipelineStore, triggerStore, encrypter, jobScheduler, executor, streamer, indexer)
+	pullReqStore := database.ProvidePullReqStore(db, principalInfoCache)
+	pullReqActivityStore := database.ProvidePullReqActivityStore(db, principalInfoCache)
+	webhookStore := database.ProvideWebhookStore(db)
+	repository, err := importer.ProvideRepoImporter(config, provider, gitInterface, transactor, repoStore, pipelineStore, triggerStore, pullReqStore, pullReqActivityStore, webhookStore, encrypter, jobScheduler, executor, streamer, indexer)
 	if err != nil {
 		return nil, err
 	}
@@ -182,72 +231,127 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	resourceLimiter, err := limiter.ProvideLimiter()
+	resourceLimiter, err := limiter.ProvideLimiter(config)
 	if err != nil {
 		return nil, err
 	}
-	repoController := repo.ProvideController(config, transactor, provider, pathUID, authorizer, repoStore, spaceStore, pipelineStore, principalStore, ruleStore, principalInfoCache, protectionManager, gitInterface, repository, codeownersService, reporter, indexer, resourceLimiter)
-	executionStore := database.ProvideExecutionStore(db)
-	checkStore := database.ProvideCheckStore(db, principalInfoCache)
-	stageStore := database.ProvideStageStore(db)
-	schedulerScheduler, err := scheduler.ProvideScheduler(stageStore, mutexManager)
+	eventsReporter, err := events3.ProvideReporter(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	stepStore := database.ProvideStepStore(db)
-	cancelerCanceler := canceler.ProvideCanceler(executionStore, streamer, repoStore, schedulerScheduler, stageStore, stepStore)
-	commitService := commit.ProvideService(gitInterface)
-	fileService := file.ProvideService(gitInterface)
-	triggererTriggerer := triggerer.ProvideTriggerer(executionStore, checkStore, stageStore, transactor, pipelineStore, fileService, schedulerScheduler, repoStore, provider)
-	executionController := execution.ProvideController(transactor, authorizer, executionStore, checkStore, cancelerCanceler, commitService, triggererTriggerer, repoStore, stageStore, pipelineStore)
-	logStore := logs.ProvideLogStore(db, config)
-	logStream := livelog.ProvideLogStream()
-	logsController := logs2.ProvideController(authorizer, executionStore, repoStore, pipelineStore, stageStore, stepStore, logStore, logStream)
-	secretStore := database.ProvideSecretStore(db)
-	connectorStore := database.ProvideConnectorStore(db)
-	templateStore := database.ProvideTemplateStore(db)
-	exporterRepository, err := exporter.ProvideSpaceExporter(provider, gitInterface, repoStore, jobScheduler, executor, encrypter, streamer)
+	readerFactory, err := events4.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	spaceController := space.ProvideController(config, transactor, provider, streamer, pathUID, authorizer, spacePathStore, pipelineStore, secretStore, connectorStore, templateStore, spaceStore, repoStore, principalStore, repoController, membershipStore, repository, exporterRepository, resourceLimiter)
-	pipelineController := pipeline.ProvideController(pathUID, repoStore, triggerStore, authorizer, pipelineStore)
-	secretController := secret.ProvideController(pathUID, encrypter, secretStore, authorizer, spaceStore)
-	triggerController := trigger.ProvideController(authorizer, triggerStore, pathUID, pipelineStore, repoStore)
-	connectorController := connector.ProvideController(pathUID, connectorStore, authorizer, spaceStore)
-	templateController := template.ProvideController(pathUID, templateStore, authorizer, spaceStore)
-	pluginStore := database.ProvidePluginStore(db)
-	pluginController := plugin.ProvideController(pluginStore)
-	pullReqStore := database.ProvidePullReqStore(db, principalInfoCache)
-	pullReqActivityStore := database.ProvidePullReqActivityStore(db, principalInfoCache)
+	checkStore := database.ProvideCheckStore(db, principalInfoCache)
 	codeCommentView := database.ProvideCodeCommentView(db)
 	pullReqReviewStore := database.ProvidePullReqReviewStore(db)
 	pullReqReviewerStore := database.ProvidePullReqReviewerStore(db, principalInfoCache)
+	pullReqMentionStore := database.ProvidePullReqMentionStore(db)
 	pullReqFileViewStore := database.ProvidePullReqFileViewStore(db)
-	eventsReporter, err := events3.ProvideReporter(eventsSystem)
+	pullReqDependencyStore := database.ProvidePullReqDependencyStore(db)
+	migrator := codecomments.ProvideMigrator(gitInterface)
+	eventsReaderFactory, err := events3.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	migrator := codecomments.ProvideMigrator(gitInterface)
-	readerFactory, err := events4.ProvideReaderFactory(eventsSystem)
+	repoGitInfoView := database.ProvideRepoGitInfoView(db)
+	repoGitInfoCache := cache.ProvideRepoGitInfoCache(repoGitInfoView)
+	pullreqService, err := pullreq.ProvideService(ctx, config, readerFactory, eventsReaderFactory, eventsReporter, gitInterface, repoGitInfoCache, repoStore, pullReqStore, pullReqActivityStore, codeCommentView, migrator, pullReqFileViewStore, pubSub, provider, streamer)
 	if err != nil {
 		return nil, err
 	}
-	eventsReaderFactory, err := events3.ProvideReaderFactory(eventsSystem)
+	pullreqController := pullreq2.ProvideController(config, transactor, provider, authorizer, pullReqStore, pullReqActivityStore, codeCommentView, pullReqReviewStore, pullReqReviewerStore, pullReqMentionStore, repoStore, principalStore, pullReqFileViewStore, pullReqDependencyStore, mergeTemplateStore, signingKeyStore, membershipStore, checkStore, watchStore, defaultReviewerStore, workingHoursStore, gitInterface, eventsReporter, mutexManager, migrator, pullreqService, protectionManager, streamer, codeownersService, encrypter, jobScheduler, autolinkService, resolver)
+	requiredFileStore := database.ProvideRequiredFileStore(db)
+	repoComplianceStore := database.ProvideRepoComplianceStore(db)
+	complianceService, err := compliance.ProvideService(ctx, config, readerFactory, gitInterface, provider, repoStore, spaceStore, requiredFileStore, repoComplianceStore, pullreqController, jobScheduler, executor)
 	if err != nil {
 		return nil, err
 	}
-	repoGitInfoView := database.ProvideRepoGitInfoView(db)
-	repoGitInfoCache := cache.ProvideRepoGitInfoCache(repoGitInfoView)
-	pullreqService, err := pullreq.ProvideService(ctx, config, readerFactory, eventsReaderFactory, eventsReporter, gitInterface, repoGitInfoCache, repoStore, pullReqStore, pullReqActivityStore, codeCommentView, migrator, pullReqFileViewStore, pubSub, provider, streamer)
+	gitUsageStore := database.ProvideGitUsageStore(db)
+	recorder := gitusage.ProvideRecorder(gitUsageStore)
+	blobConfig, err := server.ProvideBlobStoreConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	blobStore, err := blob.ProvideStore(ctx, blobConfig)
+	if err != nil {
+		return nil, err
+	}
+	pipelineCacheStore := database.ProvidePipelineCacheStore(db)
+	pipelinecacheService := pipelinecache.ProvideService(config, pipelineCacheStore, blobStore)
+	savedComparisonStore := database.ProvideSavedComparisonStore(db)
+	repoStatsStore := database.ProvideRepoStatsStore(db)
+	repostatsService, err := repostats.ProvideService(config, gitInterface, repoStore, repoStatsStore, jobScheduler, executor)
+	if err != nil {
+		return nil, err
+	}
+	repoMirrorStore := database.ProvideRepoMirrorStore(db)
+	mirrorService, err := mirror.ProvideService(config, gitInterface, repoStore, repoMirrorStore, encrypter, indexer, jobScheduler, executor)
+	if err != nil {
+		return nil, err
+	}
+	commitIndexStore := database.ProvideCommitIndexStore(db)
+	commitindexService, err := commitindex.ProvideService(ctx, config, readerFactory, gitInterface, repoStore, commitIndexStore, jobScheduler, executor)
 	if err != nil {
 		return nil, err
 	}
-	pullreqController := pullreq2.ProvideController(transactor, provider, authorizer, pullReqStore, pullReqActivityStore, codeCommentView, pullReqReviewStore, pullReqReviewerStore, repoStore, principalStore, pullReqFileViewStore, membershipStore, checkStore, gitInterface, eventsReporter, mutexManager, migrator, pullreqService, protectionManager, streamer, codeownersService)
 	webhookConfig := server.ProvideWebhookConfig(config)
-	webhookStore := database.ProvideWebhookStore(db)
+	backupService := backup.ProvideService(webhookConfig, transactor, gitInterface, repoStore, principalStore, pullReqStore, pullReqActivityStore, ruleStore, webhookStore, principalInfoCache, protectionManager, encrypter)
+	repoController := repo.ProvideController(config, transactor, provider, pathUID, authorizer, repoStore, spaceStore, pipelineStore, principalStore, pullReqStore, ruleStore, mergeTemplateStore, signingKeyStore, commitCommentStore, autolinkStore, watchStore, defaultReviewerStore, repoAliasStore, savedComparisonStore, principalInfoCache, protectionManager, gitInterface, repository, codeownersService, reporter, indexer, resourceLimiter, encrypter, complianceService, usersigningService, autolinkService, resolver, recorder, pipelinecacheService, repostatsService, commitindexService, mirrorService, backupService, pullreqController)
+	executionStore := database.ProvideExecutionStore(db)
+	stageStore := database.ProvideStageStore(db)
+	approvalStore := database.ProvideApprovalStore(db)
+	schedulerScheduler, err := scheduler.ProvideScheduler(config, stageStore, executionStore, repoStore, mutexManager)
+	if err != nil {
+		return nil, err
+	}
+	stepStore := database.ProvideStepStore(db)
+	cancelerCanceler := canceler.ProvideCanceler(executionStore, streamer, repoStore, schedulerScheduler, stageStore, stepStore, reporter)
+	commitService := commit.ProvideService(gitInterface)
+	fileService := file.ProvideService(gitInterface)
+	templateStore := database.ProvideTemplateStore(db)
+	variableStore := database.ProvideVariableStore(db)
+	connectorStore := database.ProvideConnectorStore(db)
+	secretStore := database.ProvideSecretStore(db)
+	secretsConfig := secrets.ProvideConfig(config)
+	secretsResolver := secrets.ProvideResolver(secretsConfig, connectorStore, secretStore, encrypter)
+	triggererTriggerer := triggerer.ProvideTriggerer(config, executionStore, checkStore, stageStore, approvalStore, transactor, pipelineStore, fileService, schedulerScheduler, cancelerCanceler, repoStore, provider, spaceStore, templateStore, variableStore, secretsResolver)
+	logStore := logs.ProvideLogStore(db, config)
+	logStream := livelog.ProvideLogStream()
+	logsController := logs2.ProvideController(authorizer, executionStore, repoStore, pipelineStore, stageStore, stepStore, logStore, logStream)
+	runnerStore := database.ProvideRunnerStore(db)
+	executionManager := manager.ProvideExecutionManager(config, executionStore, pipelineStore, provider, streamer, fileService, logStore, logStream, checkStore, repoStore, schedulerScheduler, secretStore, stageStore, stepStore, principalStore, spaceStore, templateStore, variableStore, runnerStore, secretsResolver, reporter)
+	approvalService, err := approval.ProvideService(config, approvalStore, stageStore, executionManager, jobScheduler, executor)
+	if err != nil {
+		return nil, err
+	}
+	pipelinetimeoutService, err := pipelinetimeout.ProvideService(config, executionStore, stageStore, pipelineStore, repoStore, checkStore, cancelerCanceler, jobScheduler, executor)
+	if err != nil {
+		return nil, err
+	}
+	pipelineArtifactStore := database.ProvidePipelineArtifactStore(db)
+	pipelineartifactService := pipelineartifact.ProvideService(config, pipelineArtifactStore, blobStore)
+	executionController := execution.ProvideController(transactor, authorizer, executionStore, checkStore, cancelerCanceler, commitService, triggererTriggerer, repoStore, stageStore, pipelineStore, approvalStore, principalInfoCache, approvalService, pipelineartifactService)
+	exporterRepository, err := exporter.ProvideSpaceExporter(provider, gitInterface, repoStore, jobScheduler, executor, encrypter, streamer)
+	if err != nil {
+		return nil, err
+	}
+	spacePinStore := database.ProvideSpacePinStore(db)
+	spaceController := space.ProvideController(config, transactor, provider, streamer, pathUID, authorizer, spacePathStore, pipelineStore, secretStore, connectorStore, templateStore, spaceStore, repoStore, principalStore, repoController, membershipStore, spacePinStore, pullReqStore, requiredFileStore, repoComplianceStore, autolinkStore, repository, exporterRepository, resourceLimiter, tokenStore, policies, repoAliasStore)
+	pipelineController := pipeline.ProvideController(config, pathUID, repoStore, triggerStore, authorizer, pipelineStore, executionStore, stageStore, fileService, triggererTriggerer)
+	secretController := secret.ProvideController(pathUID, encrypter, secretStore, connectorStore, authorizer, spaceStore)
+	triggerController := trigger.ProvideController(authorizer, triggerStore, scheduleStore, pathUID, pipelineStore, repoStore)
+	connectorController := connector.ProvideController(pathUID, connectorStore, authorizer, spaceStore, secretsConfig)
+	templateController := template.ProvideController(pathUID, templateStore, authorizer, spaceStore)
+	pluginStore := database.ProvidePluginStore(db)
+	pluginController := plugin.ProvideController(pluginStore)
 	webhookExecutionStore := database.ProvideWebhookExecutionStore(db)
-	webhookService, err := webhook.ProvideService(ctx, webhookConfig, readerFactory, eventsReaderFactory, webhookStore, webhookExecutionStore, repoStore, pullReqStore, pullReqActivityStore, provider, principalStore, gitInterface, encrypter)
+	readerFactory2, err := events2.ProvideReaderFactory(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	webhookService, err := webhook.ProvideService(ctx, webhookConfig, readerFactory, eventsReaderFactory, readerFactory2, webhookStore, webhookExecutionStore, repoStore, pullReqStore, pullReqActivityStore, commitCommentStore, checkStore, executionStore, pipelineStore, provider, principalStore, gitInterface, encrypter, autolinkService)
 	if err != nil {
 		return nil, err
 	}
@@ -256,30 +360,42 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	githookController := githook.ProvideController(authorizer, principalStore, repoStore, reporter2, gitInterface, pullReqStore, provider, protectionManager, clientFactory)
-	serviceaccountController := serviceaccount.NewController(principalUID, authorizer, principalStore, spaceStore, repoStore, tokenStore)
+	githookextManager, err := githookext.ProvideManager(config)
+	if err != nil {
+		return nil, err
+	}
+	policyhookService := policyhook.ProvideService(config, gitInterface)
+	githookController := githook.ProvideController(authorizer, principalStore, repoStore, reporter2, gitInterface, pullReqStore, provider, protectionManager, resourceLimiter, githookextManager, policyhookService, repoMirrorStore, passkeyService, clientFactory)
+	serviceaccountController := serviceaccount.NewController(principalUID, authorizer, principalStore, spaceStore, repoStore, tokenStore, policies)
 	principalController := principal.ProvideController(principalStore)
 	v := check2.ProvideCheckSanitizers()
-	checkController := check2.ProvideController(transactor, authorizer, repoStore, checkStore, gitInterface, v)
-	systemController := system.NewController(principalStore, config)
-	blobConfig, err := server.ProvideBlobStoreConfig(config)
+	checkController := check2.ProvideController(transactor, authorizer, repoStore, checkStore, gitInterface, v, reporter)
+	complianceSnapshotStore := database.ProvideComplianceSnapshotStore(db)
+	leaderConfig := server.ProvideLeaderConfig(config)
+	elector := leader.ProvideElector(mutexManager, leaderConfig)
+	repomaintenanceService, err := repomaintenance.ProvideService(config, gitInterface, repoStore, jobScheduler, executor)
 	if err != nil {
 		return nil, err
 	}
-	blobStore, err := blob.ProvideStore(ctx, blobConfig)
+	systemController := system.NewController(principalStore, complianceSnapshotStore, gitUsageStore, repoStore, repoAliasStore, principalInfoCache, elector, repomaintenanceService, config)
+	scannerConfig := server.ProvideScannerConfig(config)
+	scannerScanner, err := scanner.ProvideScanner(scannerConfig)
 	if err != nil {
 		return nil, err
 	}
-	uploadController := upload.ProvideController(authorizer, repoStore, blobStore)
+	blobscanService := blobscan.ProvideService(config, scannerScanner, blobStore)
+	uploadController := upload.ProvideController(authorizer, repoStore, blobStore, blobscanService)
 	searcher := keywordsearch.ProvideSearcher(localIndexSearcher)
-	keywordsearchController := keywordsearch2.ProvideController(authorizer, searcher, repoController, spaceController)
-	apiHandler := router.ProvideAPIHandler(ctx, config, authenticator, repoController, executionController, logsController, spaceController, pipelineController, secretController, triggerController, connectorController, templateController, pluginController, pullreqController, webhookController, githookController, serviceaccountController, controller, principalController, checkController, systemController, uploadController, keywordsearchController)
+	keywordsearchController := keywordsearch2.ProvideController(authorizer, searcher, repoController, spaceController, repoStore, spaceStore, principalStore, pullReqStore)
+	client := manager.ProvideExecutionClient(executionManager, provider, config)
+	runnerController := runner2.NewController(config, runnerStore, stageStore, stepStore, executionManager, client)
+	variableAuditStore := database.ProvideVariableAuditStore(db)
+	variableController := variable.NewController(transactor, authorizer, repoStore, spaceStore, secretStore, variableStore, variableAuditStore)
+	apiHandler := router.ProvideAPIHandler(ctx, config, authenticator, repoController, executionController, logsController, spaceController, pipelineController, secretController, triggerController, connectorController, templateController, pluginController, pullreqController, webhookController, githookController, serviceaccountController, controller, principalController, checkController, systemController, uploadController, keywordsearchController, runnerController, variableController)
 	gitHandler := router.ProvideGitHandler(provider, authenticator, repoController)
 	webHandler := router.ProvideWebHandler(config)
 	routerRouter := router.ProvideRouter(apiHandler, gitHandler, webHandler, provider)
 	serverServer := server2.ProvideServer(config, routerRouter)
-	executionManager := manager.ProvideExecutionManager(config, executionStore, pipelineStore, provider, streamer, fileService, logStore, logStream, checkStore, repoStore, schedulerScheduler, secretStore, stageStore, stepStore, principalStore)
-	client := manager.ProvideExecutionClient(executionManager, provider, config)
 	pluginManager := plugin2.ProvidePluginManager(config, pluginStore)
 	runtimeRunner, err := runner.ProvideExecutionRunner(config, client, pluginManager)
 	if err != nil {
@@ -300,14 +416,14 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 		return nil, err
 	}
 	cleanupConfig := server.ProvideCleanupConfig(config)
-	cleanupService, err := cleanup.ProvideService(cleanupConfig, jobScheduler, executor, webhookExecutionStore, tokenStore)
+	cleanupService, err := cleanup.ProvideService(cleanupConfig, jobScheduler, executor, webhookExecutionStore, tokenStore, gitUsageStore, pipelineartifactService)
 	if err != nil {
 		return nil, err
 	}
 	mailerMailer := mailer.ProvideMailClient(config)
 	notificationClient := notification.ProvideMailClient(mailerMailer)
 	notificationConfig := server.ProvideNotificationConfig(config)
-	notificationService, err := notification.ProvideNotificationService(ctx, notificationClient, notificationConfig, eventsReaderFactory, pullReqStore, repoStore, principalInfoView, principalInfoCache, pullReqReviewerStore, pullReqActivityStore, spacePathStore, provider)
+	notificationService, err := notification.ProvideNotificationService(ctx, notificationClient, notificationConfig, eventsReaderFactory, readerFactory, pullReqStore, repoStore, principalInfoView, principalInfoCache, pullReqReviewerStore, pullReqActivityStore, spacePathStore, watchStore, principalStore, authorizer, provider)
 	if err != nil {
 		return nil, err
 	}
@@ -316,7 +432,19 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	servicesServices := services.ProvideServices(webhookService, pullreqService, triggerService, jobScheduler, collector, calculator, cleanupService, notificationService, keywordsearchService)
-	serverSystem := server.NewSystem(bootstrapBootstrap, serverServer, poller, pluginManager, servicesServices)
+	auditsnapshotService, err := auditsnapshot.ProvideService(config, complianceSnapshotStore, pullReqActivityStore, repoStore, jobScheduler, executor)
+	if err != nil {
+		return nil, err
+	}
+	scheduleService, err := schedule.ProvideService(config, scheduleStore, pipelineStore, repoStore, commitService, triggererTriggerer, jobScheduler, executor)
+	if err != nil {
+		return nil, err
+	}
+	pullreqbulkService, err := pullreqbulk.ProvideService(pullreqController, principalStore, executor)
+	if err != nil {
+		return nil, err
+	}
+	servicesServices := services.ProvideServices(webhookService, pullreqService, triggerService, jobScheduler, collector, calculator, cleanupService, notificationService, keywordsearchService, complianceService, auditsnapshotService, scheduleService, approvalService, pipelinetimeoutService, repomaintenanceService, repostatsService, mirrorService, pullreqbulkService)
+	serverSystem := server.NewSystem(bootstrapBootstrap, serverServer, poller, pluginManager, servicesServices, elector)
 	return serverSystem, nil
 }
@@ -34,12 +34,17 @@ type Adapter interface {
 	SharedRepository(tmp string, repoUID string, remotePath string) (*adapter.SharedRepo, error)
 	Config(ctx context.Context, repoPath, key, value string) error
 	CountObjects(ctx context.Context, repoPath string) (types.ObjectCount, error)
+	GarbageCollect(ctx context.Context, repoPath string) error
+	Repack(ctx context.Context, repoPath string) error
+	WriteCommitGraph(ctx context.Context, repoPath string) error
+	HasCommitGraph(ctx context.Context, repoPath string) (bool, error)
+	HasBitmapIndex(ctx context.Context, repoPath string) (bool, error)
 
 	SetDefaultBranch(ctx context.Context, repoPath string,
 		defaultBranch string, allowEmpty bool) error
 	GetDefaultBranch(ctx context.Context, repoPath string) (string, error)
 	GetRemoteDefaultBranch(ctx context.Context,
-		remoteURL string) (string, error)
+		remoteURL string, resolve []string) (string, error)
 	HasBranches(ctx context.Context, repoPath string) (bool, error)
 
 	Clone(ctx context.Context, from, to string, opts types.CloneRepoOptions) error
@@ -49,8 +54,11 @@ type Adapter interface {
 	ReadTree(ctx context.Context, repoPath, ref string, w io.Writer, args ...string) error
 	GetTreeNode(ctx context.Context, repoPath string, ref string, treePath string) (*types.TreeNode, error)
 	ListTreeNodes(ctx context.Context, repoPath string, ref string, treePath string) ([]types.TreeNode, error)
+	ListTreeNodesPage(ctx context.Context, repoPath string, ref string, treePath string,
+		opts types.ListTreeNodesOptions) ([]types.TreeNode, bool, error)
 	PathsDetails(ctx context.Context, repoPath string, ref string, paths []string) ([]types.PathDetails, error)
 	GetSubmodule(ctx context.Context, repoPath string, ref string, treePath string) (*types.Submodule, error)
+	ListSubmodules(ctx context.Context, repoPath string, ref string) ([]types.Submodule, error)
 	GetBlob(ctx context.Context, repoPath string, sha string, sizeLimit int64) (*types.BlobReader, error)
 	WalkReferences(ctx context.Context, repoPath string, handler types.WalkReferencesHandler,
 		opts *types.WalkReferencesOptions) error
@@ -60,6 +68,8 @@ type Adapter interface {
 		ref string, page int, limit int, filter types.CommitFilter) ([]types.Commit, []types.PathRenameDetails, error)
 	ListCommitSHAs(ctx context.Context, repoPath string,
 		ref string, page int, limit int, filter types.CommitFilter) ([]string, error)
+	WalkCommits(ctx context.Context, repoPath string, rev string, exclude string,
+		fn func(commit *types.Commit) error) error
 	GetLatestCommit(ctx context.Context, repoPath string, ref string, treePath string) (*types.Commit, error)
 	GetFullCommitID(ctx context.Context, repoPath, shortID string) (string, error)
 	GetAnnotatedTag(ctx context.Context, repoPath string, sha string) (*types.Tag, error)
@@ -68,16 +78,25 @@ type Adapter interface {
 	GetBranch(ctx context.Context, repoPath string, branchName string) (*types.Branch, error)
 	GetCommitDivergences(ctx context.Context, repoPath string,
 		requests []types.CommitDivergenceRequest, max int32) ([]types.CommitDivergence, error)
+	GetBranchDivergences(ctx context.Context, repoPath string,
+		baseRef string, branches []string) ([]types.CommitDivergence, error)
 	GetRef(ctx context.Context, repoPath string, reference string) (string, error)
 	UpdateRef(ctx context.Context, envVars map[string]string, repoPath, reference, newValue, oldValue string) error
 	CreateTemporaryRepoForPR(ctx context.Context, reposTempPath string, pr *types.PullRequest,
 		baseBranch, trackingBranch string) (types.TempRepository, error)
 	Merge(ctx context.Context, pr *types.PullRequest, mergeMethod enum.MergeMethod, baseBranch, trackingBranch string,
-		tmpBasePath string, mergeMsg string, identity *types.Identity, env ...string) (types.MergeResult, error)
+		tmpBasePath string, mergeMsg string, signing types.CommitSigning, identity *types.Identity,
+		env ...string) (types.MergeResult, error)
+	Revert(ctx context.Context, repoPath string, mainline int, commits []string, message string,
+		signing types.CommitSigning, env ...string) (types.MergeResult, error)
+	CherryPick(ctx context.Context, repoPath string, mainline int, commits []string, message string,
+		signing types.CommitSigning, env ...string) (types.MergeResult, error)
+	ConfigureSigning(ctx context.Context, repoPath string, key types.SigningKey) (types.CommitSigning, error)
 	GetMergeBase(ctx context.Context, repoPath, remote, base, head string) (string, string, error)
+	ResolveRev(ctx context.Context, repoPath string, rev string) (string, error)
 	IsAncestor(ctx context.Context, repoPath, ancestorCommitSHA, descendantCommitSHA string) (bool, error)
-	Blame(ctx context.Context, repoPath, rev, file string, lineFrom, lineTo int) types.BlameReader
-	Sync(ctx context.Context, repoPath string, source string, refSpecs []string) error
+	Blame(ctx context.Context, repoPath, rev, file string, lineFrom, lineTo int, ignoreRevs []string) types.BlameReader
+	Sync(ctx context.Context, repoPath string, source string, refSpecs []string, resolve []string) error
 
 	//
 	// Diff operations
@@ -93,7 +112,8 @@ type Adapter interface {
 		base,
 		head string,
 		mergeBase bool,
-		w io.Writer) error
+		w io.Writer,
+		paths ...string) error
 
 	CommitDiff(ctx context.Context,
 		repoPath,
@@ -104,7 +124,8 @@ type Adapter interface {
 		repoPath string,
 		baseRef string,
 		headRef string,
-		useMergeBase bool) (types.DiffShortStat, error)
+		useMergeBase bool,
+		paths ...string) (types.DiffShortStat, error)
 
 	GetDiffHunkHeaders(ctx context.Context,
 		repoPath string,
@@ -125,6 +146,41 @@ type Adapter interface {
 		regExpDef string,
 		maxSize int) ([]types.FileContent, error)
 
+	FindBinaryBlobs(ctx context.Context,
+		repoPath string,
+		alternateObjectDirs []string,
+		revs []string) ([]types.BinaryBlob, error)
+
+	FindNewChangedPaths(ctx context.Context,
+		repoPath string,
+		alternateObjectDirs []string,
+		revs []string) ([]string, error)
+
+	ListTreePaths(ctx context.Context,
+		repoPath string,
+		alternateObjectDirs []string,
+		rev string) ([]string, error)
+
+	GetBlobStats(ctx context.Context,
+		repoPath string,
+		largestLimit int) (types.BlobStats, error)
+
+	CreateBundle(ctx context.Context,
+		repoPath string,
+		since string,
+		w io.Writer) error
+
+	Archive(ctx context.Context,
+		repoPath string,
+		rev string,
+		format enum.ArchiveFormat,
+		prefix string,
+		w io.Writer) error
+
+	TreeSize(ctx context.Context,
+		repoPath string,
+		rev string) (int64, error)
+
 	// http
 	InfoRefs(
 		ctx context.Context,
@@ -140,7 +196,7 @@ type Adapter interface {
 		stdin io.Reader,
 		stdout io.Writer,
 		env ...string,
-	) error
+	) (types.ServicePackStats, error)
 	DiffFileName(ctx context.Context,
 		repoPath string,
 		baseRef string,
@@ -16,6 +16,7 @@ package adapter
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/harness/gitness/cache"
 	"github.com/harness/gitness/git/hook"
@@ -27,8 +28,11 @@ import (
 
 type Adapter struct {
 	traceGit        bool
+	partialClone    bool
 	lastCommitCache cache.Cache[CommitEntryKey, *types.Commit]
 	githookFactory  hook.ClientFactory
+	objectCache     *objectCache
+	packCache       *packCache
 }
 
 func New(
@@ -44,9 +48,17 @@ func New(
 		return Adapter{}, err
 	}
 
+	packCache, err := newPackCache(config.PackCache)
+	if err != nil {
+		return Adapter{}, fmt.Errorf("failed to create pack cache: %w", err)
+	}
+
 	return Adapter{
 		traceGit:        config.Trace,
+		partialClone:    config.PartialClone,
 		lastCommitCache: lastCommitCache,
 		githookFactory:  githookFactory,
+		objectCache:     newObjectCache(config.ObjectCache),
+		packCache:       packCache,
 	}, nil
 }
@@ -99,3 +99,16 @@ func testParseTagDataFromCatFileFor(t *testing.T, object string, typ types.GitOb
 	require.Equal(t, tagger.Identity.Email, res.Tagger.Identity.Email, data)
 	require.Equal(t, tagger.When, res.Tagger.When, data)
 }
+
+func TestParseTagDataFromCatFile_AppendedSignature(t *testing.T) {
+	payload := "object sha012\ntype commit\ntag name3\ntagger max <max@mail.com> 1666401234 -0700\n\nsome message\n"
+	signature := "-----BEGIN PGP SIGNATURE-----\n\nw...B\n-----END PGP SIGNATURE-----\n"
+
+	res, err := parseTagDataFromCatFile([]byte(payload + signature))
+	require.NoError(t, err)
+
+	require.Equal(t, "some message", res.Message)
+	require.NotNil(t, res.Signature)
+	require.Equal(t, signature, res.Signature.Signature)
+	require.Equal(t, payload, res.Signature.Payload)
+}
@@ -0,0 +1,113 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/enum"
+
+	gitea "code.gitea.io/gitea/modules/git"
+)
+
+// Archive writes an archive of the tree of the revision to the provided writer.
+// Files with the export-ignore attribute are left out of the archive.
+func (a Adapter) Archive(
+	ctx context.Context,
+	repoPath string,
+	rev string,
+	format enum.ArchiveFormat,
+	prefix string,
+	w io.Writer,
+) error {
+	if repoPath == "" {
+		return ErrRepositoryPathEmpty
+	}
+
+	args := []string{"archive", "--format=" + string(format)}
+	if prefix != "" {
+		args = append(args, "--prefix="+prefix)
+	}
+	args = append(args, rev)
+
+	stderr := new(bytes.Buffer)
+	cmd := gitea.NewCommand(ctx, args...)
+	if err := cmd.Run(&gitea.RunOpts{
+		Dir:    repoPath,
+		Stdout: w,
+		Stderr: stderr,
+	}); err != nil {
+		return processGiteaErrorf(err, "failed to create archive: %v", stderr)
+	}
+
+	return nil
+}
+
+// TreeSize returns the total size of the files in the tree of the revision.
+func (a Adapter) TreeSize(
+	ctx context.Context,
+	repoPath string,
+	rev string,
+) (int64, error) {
+	if repoPath == "" {
+		return 0, ErrRepositoryPathEmpty
+	}
+
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	cmd := gitea.NewCommand(ctx, "ls-tree", "-r", "-l", "-z", rev)
+	if err := cmd.Run(&gitea.RunOpts{
+		Dir:    repoPath,
+		Stdout: stdout,
+		Stderr: stderr,
+	}); err != nil {
+		if strings.Contains(stderr.String(), "fatal: Not a valid object name") {
+			return 0, errors.NotFound("revision %q not found", rev)
+		}
+		return 0, processGiteaErrorf(err, "failed to list tree: %v", stderr)
+	}
+
+	var total int64
+	scan := bufio.NewScanner(stdout)
+	scan.Split(scanZeroSeparated)
+	for scan.Scan() {
+		// format: <mode> SP <type> SP <object> SP+ <size> TAB <path>
+		meta, _, ok := strings.Cut(scan.Text(), "\t")
+		if !ok {
+			return 0, fmt.Errorf("unrecognized format of git tree listing")
+		}
+
+		fields := strings.Fields(meta)
+		if len(fields) != 4 || fields[1] != "blob" {
+			// submodules don't have a size and aren't part of the archive.
+			continue
+		}
+
+		size, err := strconv.ParseInt(fields[3], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse size of git blob: %w", err)
+		}
+
+		total += size
+	}
+
+	return total, scan.Err()
+}
@@ -0,0 +1,69 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/harness/gitness/git/enum"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAdapter_Archive(t *testing.T) {
+	git := setupGit(t)
+	repo, teardown := setupRepo(t, git, "testarchive")
+	defer teardown()
+
+	writeFile(t, repo, ".gitattributes", "secret.txt export-ignore\n", nil)
+	writeFile(t, repo, "secret.txt", "secret", nil)
+	sha := writeFile(t, repo, "readme.md", "text", nil)
+
+	buf := &bytes.Buffer{}
+	err := git.Archive(context.Background(), repo.Path, sha.String(), enum.ArchiveFormatZip, "repo-main/", buf)
+	if err != nil {
+		t.Fatalf("failed to create archive: %v", err)
+	}
+
+	r, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("failed to read archive: %v", err)
+	}
+
+	var names []string
+	for _, f := range r.File {
+		names = append(names, f.Name)
+	}
+	sort.Strings(names)
+
+	want := []string{"repo-main/", "repo-main/.gitattributes", "repo-main/readme.md"}
+	if diff := cmp.Diff(want, names); diff != "" {
+		t.Errorf("unexpected archive content (-want +got):\n%s", diff)
+	}
+
+	size, err := git.TreeSize(context.Background(), repo.Path, sha.String())
+	if err != nil {
+		t.Fatalf("failed to get tree size: %v", err)
+	}
+
+	// includes the ignored file, the limit is checked against everything in the tree.
+	if want := int64(len("secret.txt export-ignore\n") + len("secret") + len("text")); size != want {
+		t.Errorf("expected tree size %d, got %d", want, size)
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/harness/gitness/git/types"
+
+	gitea "code.gitea.io/gitea/modules/git"
+)
+
+// binarySniffLen is the number of bytes inspected to determine whether a blob is binary.
+// It's the same heuristic git uses - a blob is binary if it contains a NUL byte in its first 8000 bytes.
+const binarySniffLen = 8000
+
+// FindBinaryBlobs returns the binary blobs that are reachable from the provided revisions,
+// but not from any of the existing references of the repository.
+// The alternate object directories allow to inspect objects that are still in quarantine (pre-receive).
+func (a Adapter) FindBinaryBlobs(
+	ctx context.Context,
+	repoPath string,
+	alternateObjectDirs []string,
+	revs []string,
+) ([]types.BinaryBlob, error) {
+	if repoPath == "" {
+		return nil, ErrRepositoryPathEmpty
+	}
+	if len(revs) == 0 {
+		return nil, nil
+	}
+
+	env := alternateObjectDirsEnv(alternateObjectDirs)
+
+	args := make([]string, 0, len(revs)+4)
+	args = append(args, "rev-list", "--objects")
+	args = append(args, revs...)
+	args = append(args, "--not", "--all")
+
+	stdout, _, runErr := gitea.NewCommand(ctx, args...).RunStdBytes(&gitea.RunOpts{Dir: repoPath, Env: env})
+	if runErr != nil {
+		return nil, processGiteaErrorf(runErr, "failed to trigger rev-list command")
+	}
+
+	// rev-list outputs objects in the form "<sha> SP <path>", only trees and blobs have a path.
+	paths := make(map[string]string)
+	shas := make([]string, 0)
+	for _, line := range parseLinesToSlice(stdout) {
+		sha, path, ok := strings.Cut(line, " ")
+		if !ok || path == "" {
+			continue
+		}
+		if _, exists := paths[sha]; exists {
+			continue
+		}
+
+		paths[sha] = path
+		shas = append(shas, sha)
+	}
+
+	if len(shas) == 0 {
+		return nil, nil
+	}
+
+	pr, pw := io.Pipe()
+	defer pr.Close()
+
+	go func() {
+		stderr := &bytes.Buffer{}
+		err := gitea.NewCommand(ctx, "cat-file", "--batch").Run(&gitea.RunOpts{
+			Dir:    repoPath,
+			Env:    env,
+			Stdin:  strings.NewReader(strings.Join(shas, "\n") + "\n"),
+			Stdout: pw,
+			Stderr: stderr,
+		})
+		if err != nil {
+			_ = pw.CloseWithError(gitea.ConcatenateError(err, stderr.String()))
+			return
+		}
+		_ = pw.Close()
+	}()
+
+	reader := bufio.NewReader(pr)
+	blobs := make([]types.BinaryBlob, 0)
+
+	for range shas {
+		sha, objectType, size, err := gitea.ReadBatchLine(reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cat-file batch header: %w", err)
+		}
+
+		sniffLen := size
+		if sniffLen > binarySniffLen {
+			sniffLen = binarySniffLen
+		}
+
+		head := make([]byte, sniffLen)
+		if _, err = io.ReadFull(reader, head); err != nil {
+			return nil, fmt.Errorf("failed to read cat-file content: %w", err)
+		}
+
+		// discard the rest of the object and the trailing LF
+		if _, err = io.CopyN(io.Discard, reader, size-sniffLen+1); err != nil {
+			return nil, fmt.Errorf("failed to discard cat-file content: %w", err)
+		}
+
+		if objectType != string(gitea.ObjectBlob) || bytes.IndexByte(head, 0) < 0 {
+			continue
+		}
+
+		blobs = append(blobs, types.BinaryBlob{
+			SHA:  string(sha),
+			Path: paths[string(sha)],
+			Size: size,
+		})
+	}
+
+	return blobs, nil
+}
@@ -0,0 +1,103 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/git/types"
+)
+
+func TestAdapter_FindBinaryBlobs(t *testing.T) {
+	git := setupGit(t)
+	repo, teardown := setupRepo(t, git, "testfindbinaryblobs")
+	defer teardown()
+
+	binaryContent := "\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"
+
+	sha1 := writeFile(t, repo, "readme.md", "text", nil)
+	sha2 := writeFile(t, repo, "image.png", binaryContent, []string{sha1.String()})
+	sha3 := writeFile(t, repo, "notes.txt", "more text", []string{sha2.String()})
+
+	err := repo.SetReference("refs/heads/main", sha1.String())
+	if err != nil {
+		t.Fatalf("failed updating reference 'main': %v", err)
+	}
+
+	binaryBlob := types.BinaryBlob{
+		SHA:  "",
+		Path: "image.png",
+		Size: int64(len(binaryContent)),
+	}
+
+	tests := []struct {
+		name string
+		ref  string
+		revs []string
+		want []types.BinaryBlob
+	}{
+		{
+			name: "new binary blob",
+			ref:  sha1.String(),
+			revs: []string{sha2.String()},
+			want: []types.BinaryBlob{binaryBlob},
+		},
+		{
+			name: "new binary blob in history",
+			ref:  sha1.String(),
+			revs: []string{sha3.String()},
+			want: []types.BinaryBlob{binaryBlob},
+		},
+		{
+			name: "binary blob already reachable",
+			ref:  sha2.String(),
+			revs: []string{sha3.String()},
+			want: []types.BinaryBlob{},
+		},
+		{
+			name: "no revisions",
+			ref:  sha1.String(),
+			revs: nil,
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := repo.SetReference("refs/heads/main", tt.ref)
+			if err != nil {
+				t.Fatalf("failed updating reference 'main': %v", err)
+			}
+
+			got, err := git.FindBinaryBlobs(context.Background(), repo.Path, nil, tt.revs)
+			if err != nil {
+				t.Fatalf("failed to find binary blobs: %v", err)
+			}
+
+			for i := range got {
+				if got[i].SHA == "" {
+					t.Errorf("expected blob sha to be set for %q", got[i].Path)
+				}
+				got[i].SHA = ""
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("want=%+v got=%+v", tt.want, got)
+			}
+		})
+	}
+}
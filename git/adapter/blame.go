@@ -44,10 +44,14 @@ func (a Adapter) Blame(
 	file string,
 	lineFrom int,
 	lineTo int,
+	ignoreRevs []string,
 ) types.BlameReader {
 	// prepare the git command line arguments
-	args := make([]string, 0, 8)
+	args := make([]string, 0, 8+2*len(ignoreRevs))
 	args = append(args, "blame", "--porcelain", "--encoding=UTF-8")
+	for _, ignoreRev := range ignoreRevs {
+		args = append(args, "--ignore-rev", ignoreRev)
+	}
 	if lineFrom > 0 || lineTo > 0 {
 		var lines string
 		if lineFrom > 0 {
@@ -121,6 +125,7 @@ func (r *BlameReader) unreadLine(line string) {
 func (r *BlameReader) NextPart() (*types.BlamePart, error) {
 	var commit *types.Commit
 	var lines []string
+	var lineFrom int
 	var err error
 
 	for {
@@ -139,6 +144,9 @@ func (r *BlameReader) NextPart() (*types.BlamePart, error) {
 					commit = &types.Commit{SHA: sha}
 				}
 
+				// At index 3 there's the line number of the first line of the part in the final file.
+				lineFrom, _ = strconv.Atoi(matches[3])
+
 				if matches[5] != "" {
 					// At index 5 there's number of lines in this section. However, the resulting
 					// BlamePart might contain more than this because we join consecutive sections
@@ -155,8 +163,9 @@ func (r *BlameReader) NextPart() (*types.BlamePart, error) {
 				r.commitCache[commit.SHA] = commit
 
 				return &types.BlamePart{
-					Commit: commit,
-					Lines:  lines,
+					Commit:   commit,
+					LineFrom: lineFrom,
+					Lines:    lines,
 				}, nil
 			}
 
@@ -211,8 +220,9 @@ func (r *BlameReader) NextPart() (*types.BlamePart, error) {
 
 	if commit != nil && len(lines) > 0 {
 		part = &types.BlamePart{
-			Commit: commit,
-			Lines:  lines,
+			Commit:   commit,
+			LineFrom: lineFrom,
+			Lines:    lines,
 		}
 	}
 
@@ -36,7 +36,7 @@ func TestBlameEmptyFile(t *testing.T) {
 		t.Fatalf("failed updating reference '%s': %v", baseBranch, err)
 	}
 
-	reader := git.Blame(context.Background(), repo.Path, "main", "file.txt", 0, 0)
+	reader := git.Blame(context.Background(), repo.Path, "main", "file.txt", 0, 0, nil)
 
 	part, err := reader.NextPart()
 	if err != nil {
@@ -52,3 +52,57 @@ func TestBlameEmptyFile(t *testing.T) {
 		return
 	}
 }
+
+func TestBlameIgnoreRevs(t *testing.T) {
+	git := setupGit(t)
+	repo, teardown := setupRepo(t, git, "testblameignorerevs")
+	defer teardown()
+
+	firstSHA := writeFile(t, repo, "file.txt", "line 1\nline 2\n", nil)
+	secondSHA := writeFile(t, repo, "file.txt", "line 1\nline  2\n", []string{firstSHA.String()})
+
+	err := repo.SetReference("refs/heads/main", secondSHA.String())
+	if err != nil {
+		t.Fatalf("failed updating reference 'main': %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		ignoreRevs []string
+		wantSHA    string
+	}{
+		{
+			name:       "without ignore revs",
+			ignoreRevs: nil,
+			wantSHA:    secondSHA.String(),
+		},
+		{
+			name:       "with ignore revs",
+			ignoreRevs: []string{secondSHA.String()},
+			wantSHA:    firstSHA.String(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := git.Blame(context.Background(), repo.Path, "main", "file.txt", 2, 2, tt.ignoreRevs)
+
+			part, err := reader.NextPart()
+			if err != nil && !errors.Is(err, io.EOF) {
+				t.Fatalf("Blame reader returned an error: %v", err)
+			}
+
+			if part == nil {
+				t.Fatal("Blame reader should return a part")
+			}
+
+			if part.Commit.SHA != tt.wantSHA {
+				t.Errorf("expected line to be attributed to %s, got %s", tt.wantSHA, part.Commit.SHA)
+			}
+
+			if part.LineFrom != 2 {
+				t.Errorf("expected line from to be 2, got %d", part.LineFrom)
+			}
+		})
+	}
+}
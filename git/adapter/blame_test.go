@@ -103,16 +103,19 @@ filename file_name.go
 
 	want := []*types.BlamePart{
 		{
-			Commit: commit1,
-			Lines:  []string{"Line 10", "Line 11"},
+			Commit:   commit1,
+			LineFrom: 10,
+			Lines:    []string{"Line 10", "Line 11"},
 		},
 		{
-			Commit: commit2,
-			Lines:  []string{"Line 12"},
+			Commit:   commit2,
+			LineFrom: 12,
+			Lines:    []string{"Line 12"},
 		},
 		{
-			Commit: commit1,
-			Lines:  []string{"Line 13", "Line 14"},
+			Commit:   commit1,
+			LineFrom: 13,
+			Lines:    []string{"Line 13", "Line 14"},
 		},
 	}
 
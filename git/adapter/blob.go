@@ -15,6 +15,7 @@
 package adapter
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -32,6 +33,10 @@ func (a Adapter) GetBlob(
 	sha string,
 	sizeLimit int64,
 ) (*types.BlobReader, error) {
+	if content, ok := a.objectCache.getBlob(repoPath, sha); ok {
+		return newCachedBlobReader(sha, content, sizeLimit), nil
+	}
+
 	stdIn, stdOut, cancel := git.CatFileBatch(ctx, repoPath)
 
 	_, err := stdIn.Write([]byte(sha + "\n"))
@@ -56,6 +61,20 @@ func (a Adapter) GetBlob(
 			"cat-file returned object type '%s' but expected '%s'", objectType, git.ObjectBlob)
 	}
 
+	// small blobs are read fully and cached, as they are often read repeatedly (e.g. READMEs).
+	if a.objectCache.fits(objectSize) {
+		content := make([]byte, objectSize)
+		_, err = io.ReadFull(stdOut, content)
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read blob content: %w", err)
+		}
+
+		a.objectCache.addBlob(repoPath, sha, content)
+
+		return newCachedBlobReader(sha, content, sizeLimit), nil
+	}
+
 	contentSize := objectSize
 	if sizeLimit > 0 && sizeLimit < contentSize {
 		contentSize = sizeLimit
@@ -69,6 +88,21 @@ func (a Adapter) GetBlob(
 	}, nil
 }
 
+func newCachedBlobReader(sha string, content []byte, sizeLimit int64) *types.BlobReader {
+	size := int64(len(content))
+	contentSize := size
+	if sizeLimit > 0 && sizeLimit < contentSize {
+		contentSize = sizeLimit
+	}
+
+	return &types.BlobReader{
+		SHA:         sha,
+		Size:        size,
+		ContentSize: contentSize,
+		Content:     io.NopCloser(bytes.NewReader(content[:contentSize])),
+	}
+}
+
 func newLimitReaderCloser(reader io.Reader, limit int64, stop func()) limitReaderCloser {
 	return limitReaderCloser{
 		reader: io.LimitReader(reader, limit),
@@ -0,0 +1,222 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/harness/gitness/git/types"
+
+	gitea "code.gitea.io/gitea/modules/git"
+)
+
+const (
+	// lfsPointerMaxSize is the maximum size of a git LFS pointer file.
+	lfsPointerMaxSize = 1024
+	lfsPointerPrefix  = "version https://git-lfs.github.com/spec/v1\n"
+)
+
+// GetBlobStats returns statistics about the blobs reachable from any reference of the repository:
+// the total count and size, the largest blobs and the LFS objects referenced by LFS pointer files.
+func (a Adapter) GetBlobStats(
+	ctx context.Context,
+	repoPath string,
+	largestLimit int,
+) (types.BlobStats, error) {
+	if repoPath == "" {
+		return types.BlobStats{}, ErrRepositoryPathEmpty
+	}
+
+	stdout, _, runErr := gitea.NewCommand(ctx, "rev-list", "--objects", "--all").
+		RunStdBytes(&gitea.RunOpts{Dir: repoPath})
+	if runErr != nil {
+		return types.BlobStats{}, processGiteaErrorf(runErr, "failed to trigger rev-list command")
+	}
+
+	// rev-list outputs objects in the form "<sha> SP <path>", only trees and blobs have a path.
+	paths := make(map[string]string)
+	shas := make([]string, 0)
+	for _, line := range parseLinesToSlice(stdout) {
+		sha, path, ok := strings.Cut(line, " ")
+		if !ok || path == "" {
+			continue
+		}
+		if _, exists := paths[sha]; exists {
+			continue
+		}
+
+		paths[sha] = path
+		shas = append(shas, sha)
+	}
+
+	if len(shas) == 0 {
+		return types.BlobStats{}, nil
+	}
+
+	stdout, _, runErr = gitea.NewCommand(ctx, "cat-file",
+		"--batch-check=%(objectname) %(objecttype) %(objectsize)").
+		RunStdBytes(&gitea.RunOpts{
+			Dir:   repoPath,
+			Stdin: strings.NewReader(strings.Join(shas, "\n") + "\n"),
+		})
+	if runErr != nil {
+		return types.BlobStats{}, processGiteaErrorf(runErr, "failed to trigger cat-file command")
+	}
+
+	stats := types.BlobStats{}
+	blobs := make([]types.BlobSize, 0, len(shas))
+	lfsCandidates := make([]string, 0)
+
+	for _, line := range parseLinesToSlice(stdout) {
+		fields := strings.Fields(line)
+		if len(fields) != 3 || fields[1] != string(gitea.ObjectBlob) {
+			continue
+		}
+
+		size, err := strconv.ParseInt(fields[2], 10, 64)
+		if err != nil {
+			return types.BlobStats{}, fmt.Errorf("failed to parse size of object %s: %w", fields[0], err)
+		}
+
+		stats.Blobs++
+		stats.Size += size
+		blobs = append(blobs, types.BlobSize{
+			SHA:  fields[0],
+			Path: paths[fields[0]],
+			Size: size,
+		})
+
+		if size <= lfsPointerMaxSize {
+			lfsCandidates = append(lfsCandidates, fields[0])
+		}
+	}
+
+	sort.SliceStable(blobs, func(i, j int) bool {
+		return blobs[i].Size > blobs[j].Size
+	})
+	if len(blobs) > largestLimit {
+		blobs = blobs[:largestLimit]
+	}
+	stats.Largest = blobs
+
+	lfsObjects, lfsSize, err := getLFSUsage(ctx, repoPath, lfsCandidates)
+	if err != nil {
+		return types.BlobStats{}, err
+	}
+
+	stats.LFSObjects = lfsObjects
+	stats.LFSSize = lfsSize
+
+	return stats, nil
+}
+
+// getLFSUsage reads the provided blobs and returns the number and the total size
+// of the distinct LFS objects referenced by those that are LFS pointer files.
+func getLFSUsage(ctx context.Context, repoPath string, shas []string) (int, int64, error) {
+	if len(shas) == 0 {
+		return 0, 0, nil
+	}
+
+	pr, pw := io.Pipe()
+	defer pr.Close()
+
+	go func() {
+		stderr := &bytes.Buffer{}
+		err := gitea.NewCommand(ctx, "cat-file", "--batch").Run(&gitea.RunOpts{
+			Dir:    repoPath,
+			Stdin:  strings.NewReader(strings.Join(shas, "\n") + "\n"),
+			Stdout: pw,
+			Stderr: stderr,
+		})
+		if err != nil {
+			_ = pw.CloseWithError(gitea.ConcatenateError(err, stderr.String()))
+			return
+		}
+		_ = pw.Close()
+	}()
+
+	reader := bufio.NewReader(pr)
+	objects := make(map[string]int64)
+
+	for range shas {
+		_, _, size, err := gitea.ReadBatchLine(reader)
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to read cat-file batch header: %w", err)
+		}
+
+		// read the content and the trailing LF
+		content := make([]byte, size+1)
+		if _, err = io.ReadFull(reader, content); err != nil {
+			return 0, 0, fmt.Errorf("failed to read cat-file content: %w", err)
+		}
+
+		oid, lfsSize, ok := parseLFSPointer(content[:size])
+		if !ok {
+			continue
+		}
+
+		objects[oid] = lfsSize
+	}
+
+	var total int64
+	for _, size := range objects {
+		total += size
+	}
+
+	return len(objects), total, nil
+}
+
+// parseLFSPointer parses the content of a git LFS pointer file and returns the oid and the size of the LFS object.
+func parseLFSPointer(content []byte) (string, int64, bool) {
+	if !bytes.HasPrefix(content, []byte(lfsPointerPrefix)) {
+		return "", 0, false
+	}
+
+	var (
+		oid  string
+		size int64 = -1
+	)
+
+	for _, line := range strings.Split(string(content), "\n") {
+		key, value, ok := strings.Cut(line, " ")
+		if !ok {
+			continue
+		}
+
+		switch key {
+		case "oid":
+			oid = value
+		case "size":
+			v, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || v < 0 {
+				return "", 0, false
+			}
+			size = v
+		}
+	}
+
+	if oid == "" || size < 0 {
+		return "", 0, false
+	}
+
+	return oid, size, true
+}
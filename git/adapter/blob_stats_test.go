@@ -0,0 +1,71 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestAdapter_GetBlobStats(t *testing.T) {
+	git := setupGit(t)
+	repo, teardown := setupRepo(t, git, "testblobstats")
+	defer teardown()
+
+	const (
+		lfsPointer1 = "version https://git-lfs.github.com/spec/v1\n" +
+			"oid sha256:4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393\n" +
+			"size 12345\n"
+		lfsPointer2 = "version https://git-lfs.github.com/spec/v1\n" +
+			"oid sha256:a3f9d8c1e0b7a6a1d2c3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d1e2f3a4b5\n" +
+			"size 100\n"
+	)
+
+	sha := writeFile(t, repo, "small.txt", "hello", nil)
+	sha = writeFile(t, repo, "large.bin", strings.Repeat("x", 5000), []string{sha.String()})
+	sha = writeFile(t, repo, "asset1.psd", lfsPointer1, []string{sha.String()})
+	// the same LFS object stored under a different path must be counted only once
+	sha = writeFile(t, repo, "copy/asset1.psd", lfsPointer1, []string{sha.String()})
+	sha = writeFile(t, repo, "asset2.psd", lfsPointer2, []string{sha.String()})
+	if err := repo.SetReference("refs/heads/main", sha.String()); err != nil {
+		t.Fatalf("failed updating reference: %v", err)
+	}
+
+	stats, err := git.GetBlobStats(context.Background(), repo.Path, 1)
+	if err != nil {
+		t.Fatalf("GetBlobStats() returned an error: %v", err)
+	}
+
+	if stats.Blobs != 4 {
+		t.Errorf("expected 4 blobs, got %d", stats.Blobs)
+	}
+
+	wantSize := int64(len("hello") + 5000 + len(lfsPointer1) + len(lfsPointer2))
+	if stats.Size != wantSize {
+		t.Errorf("expected blobs size %d, got %d", wantSize, stats.Size)
+	}
+
+	if len(stats.Largest) != 1 || stats.Largest[0].Path != "large.bin" || stats.Largest[0].Size != 5000 {
+		t.Errorf("unexpected largest blobs: %+v", stats.Largest)
+	}
+
+	if stats.LFSObjects != 2 {
+		t.Errorf("expected 2 LFS objects, got %d", stats.LFSObjects)
+	}
+	if stats.LFSSize != 12445 {
+		t.Errorf("expected LFS size 12445, got %d", stats.LFSSize)
+	}
+}
@@ -15,8 +15,10 @@
 package adapter
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/harness/gitness/git/types"
@@ -70,3 +72,136 @@ func (a Adapter) HasBranches(
 
 	return strings.TrimSpace(string(stdout)) == "", nil
 }
+
+// GetBranchDivergences returns the count of diverging commits of each of the provided branches
+// relative to the base reference. Branches that don't exist get a divergence of (-1, -1).
+// With git 2.41 or newer all divergences are calculated with a single git invocation,
+// otherwise the calculation falls back to one rev-list call per existing branch.
+func (a Adapter) GetBranchDivergences(
+	ctx context.Context,
+	repoPath string,
+	baseRef string,
+	branches []string,
+) ([]types.CommitDivergence, error) {
+	if repoPath == "" {
+		return nil, ErrRepositoryPathEmpty
+	}
+	if len(branches) == 0 {
+		return []types.CommitDivergence{}, nil
+	}
+
+	if gitea.CheckGitVersionAtLeast("2.41") == nil {
+		return a.getBranchDivergencesAheadBehind(ctx, repoPath, baseRef, branches)
+	}
+
+	// fallback for older git versions - skip branches that don't exist, as rev-list would fail for them.
+	stdOut, _, runErr := gitea.NewCommand(ctx, branchesForEachRefArgs("%(refname)", branches)...).
+		RunStdBytes(&gitea.RunOpts{Dir: repoPath})
+	if runErr != nil {
+		return nil, processGiteaErrorf(runErr, "failed to list branch references")
+	}
+
+	existingRefs := make(map[string]struct{})
+	for _, ref := range parseLinesToSlice(stdOut) {
+		existingRefs[ref] = struct{}{}
+	}
+
+	res := make([]types.CommitDivergence, len(branches))
+	for i, branch := range branches {
+		ref := GetReferenceFromBranchName(branch)
+		if _, ok := existingRefs[ref]; !ok {
+			res[i] = types.CommitDivergence{Ahead: -1, Behind: -1}
+			continue
+		}
+
+		div, err := a.getCommitDivergence(ctx, repoPath, types.CommitDivergenceRequest{From: ref, To: baseRef}, 0)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = div
+	}
+
+	return res, nil
+}
+
+func branchesForEachRefArgs(format string, branches []string) []string {
+	args := make([]string, 0, 2+len(branches))
+	args = append(args, "for-each-ref", "--format="+format)
+	for _, branch := range branches {
+		args = append(args, GetReferenceFromBranchName(branch))
+	}
+
+	return args
+}
+
+// getBranchDivergencesAheadBehind uses the ahead-behind atom of git for-each-ref
+// to calculate the divergences of all branches in one git invocation.
+func (a Adapter) getBranchDivergencesAheadBehind(
+	ctx context.Context,
+	repoPath string,
+	baseRef string,
+	branches []string,
+) ([]types.CommitDivergence, error) {
+	args := branchesForEachRefArgs("%(refname)%00%(ahead-behind:"+baseRef+")", branches)
+
+	stdOut, _, runErr := gitea.NewCommand(ctx, args...).RunStdBytes(&gitea.RunOpts{Dir: repoPath})
+	if runErr != nil {
+		return nil, processGiteaErrorf(runErr, "git for-each-ref failed for base '%s'", baseRef)
+	}
+
+	divergencesByRef, err := parseAheadBehindOutput(stdOut)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]types.CommitDivergence, len(branches))
+	for i, branch := range branches {
+		div, ok := divergencesByRef[GetReferenceFromBranchName(branch)]
+		if !ok {
+			res[i] = types.CommitDivergence{Ahead: -1, Behind: -1}
+			continue
+		}
+		res[i] = div
+	}
+
+	return res, nil
+}
+
+// parseAheadBehindOutput parses the output of git for-each-ref with format '%(refname)%00%(ahead-behind:base)'.
+// NOTE: for-each-ref patterns also match refs nested below them (e.g. 'refs/heads/a' matches 'refs/heads/a/b'),
+// the extra references are ignored by the caller as it looks up the exact reference names.
+func parseAheadBehindOutput(output []byte) (map[string]types.CommitDivergence, error) {
+	res := make(map[string]types.CommitDivergence)
+
+	for _, line := range bytes.Split(output, []byte{'\n'}) {
+		if len(line) == 0 {
+			continue
+		}
+
+		refName, rawCounts, ok := bytes.Cut(line, []byte{0})
+		if !ok {
+			return nil, fmt.Errorf("git for-each-ref returned unexpected output '%s'", line)
+		}
+
+		rawAhead, rawBehind, ok := strings.Cut(strings.TrimSpace(string(rawCounts)), " ")
+		if !ok {
+			return nil, fmt.Errorf("git for-each-ref returned unexpected ahead-behind output '%s'", rawCounts)
+		}
+
+		ahead, err := strconv.ParseInt(rawAhead, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse ahead count '%s': %w", rawAhead, err)
+		}
+		behind, err := strconv.ParseInt(rawBehind, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse behind count '%s': %w", rawBehind, err)
+		}
+
+		res[string(refName)] = types.CommitDivergence{
+			Ahead:  int32(ahead),
+			Behind: int32(behind),
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/git/types"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAdapter_GetBranchDivergences(t *testing.T) {
+	git := setupGit(t)
+	repo, teardown := setupRepo(t, git, "testbranchdivergences")
+	defer teardown()
+
+	baseSHA := writeFile(t, repo, "file.txt", "base", nil)
+	mainSHA := writeFile(t, repo, "file.txt", "main", []string{baseSHA.String()})
+	featureSHA := writeFile(t, repo, "feature.txt", "feature", []string{baseSHA.String()})
+	featureSHA = writeFile(t, repo, "feature.txt", "feature 2", []string{featureSHA.String()})
+
+	refs := map[string]string{
+		"refs/heads/main":    mainSHA.String(),
+		"refs/heads/feature": featureSHA.String(),
+		"refs/heads/old":     baseSHA.String(),
+	}
+	for ref, sha := range refs {
+		if err := repo.SetReference(ref, sha); err != nil {
+			t.Fatalf("failed updating reference '%s': %v", ref, err)
+		}
+	}
+
+	got, err := git.GetBranchDivergences(context.Background(), repo.Path, "main",
+		[]string{"feature", "old", "main", "missing"})
+	if err != nil {
+		t.Fatalf("GetBranchDivergences() returned an error: %v", err)
+	}
+
+	want := []types.CommitDivergence{
+		{Ahead: 2, Behind: 1},
+		{Ahead: 0, Behind: 1},
+		{Ahead: 0, Behind: 0},
+		{Ahead: -1, Behind: -1},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GetBranchDivergences() mismatch (-want +got):\n%s", diff)
+	}
+}
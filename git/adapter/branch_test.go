@@ -0,0 +1,45 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/git/types"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseAheadBehindOutput(t *testing.T) {
+	output := []byte("refs/heads/feature\x002 1\nrefs/heads/main\x000 0\n")
+
+	got, err := parseAheadBehindOutput(output)
+	if err != nil {
+		t.Fatalf("parseAheadBehindOutput() returned an error: %v", err)
+	}
+
+	want := map[string]types.CommitDivergence{
+		"refs/heads/feature": {Ahead: 2, Behind: 1},
+		"refs/heads/main":    {Ahead: 0, Behind: 0},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("parseAheadBehindOutput() mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err = parseAheadBehindOutput([]byte("refs/heads/feature 2 1\n")); err == nil {
+		t.Error("parseAheadBehindOutput() expected an error for malformed output")
+	}
+}
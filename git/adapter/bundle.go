@@ -0,0 +1,60 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+
+	"github.com/harness/gitness/errors"
+
+	gitea "code.gitea.io/gitea/modules/git"
+)
+
+// CreateBundle writes a git bundle containing all references of the repository to the provided writer.
+// If since is provided, the bundle is incremental: it only contains the objects that aren't reachable
+// from the since commit, which has to be present in the repository the bundle is applied to.
+func (a Adapter) CreateBundle(
+	ctx context.Context,
+	repoPath string,
+	since string,
+	w io.Writer,
+) error {
+	if repoPath == "" {
+		return ErrRepositoryPathEmpty
+	}
+
+	args := []string{"bundle", "create", "-", "--all"}
+	if since != "" {
+		args = append(args, "^"+since)
+	}
+
+	stderr := new(bytes.Buffer)
+	cmd := gitea.NewCommand(ctx, args...)
+	if err := cmd.Run(&gitea.RunOpts{
+		Dir:    repoPath,
+		Stdout: w,
+		Stderr: stderr,
+	}); err != nil {
+		if strings.Contains(stderr.String(), "Refusing to create empty bundle") {
+			return errors.PreconditionFailed("there are no changes since commit %s", since)
+		}
+		return processGiteaErrorf(err, "failed to create bundle: %v", stderr)
+	}
+
+	return nil
+}
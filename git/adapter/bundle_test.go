@@ -0,0 +1,99 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/errors"
+
+	gitea "code.gitea.io/gitea/modules/git"
+)
+
+func TestAdapter_CreateBundle(t *testing.T) {
+	git := setupGit(t)
+	repo, teardown := setupRepo(t, git, "testcreatebundle")
+	defer teardown()
+
+	sha := writeFile(t, repo, "readme.md", "text", nil)
+	if err := repo.SetReference("refs/heads/main", sha.String()); err != nil {
+		t.Fatalf("failed updating reference 'main': %v", err)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := git.CreateBundle(context.Background(), repo.Path, "", buf); err != nil {
+		t.Fatalf("failed to create bundle: %v", err)
+	}
+
+	bundlePath := filepath.Join(t.TempDir(), "repo.bundle")
+	if err := os.WriteFile(bundlePath, buf.Bytes(), 0o600); err != nil {
+		t.Fatalf("failed to write bundle: %v", err)
+	}
+
+	stdout, _, err := gitea.NewCommand(context.Background(), "bundle", "list-heads", bundlePath).
+		RunStdString(&gitea.RunOpts{Dir: repo.Path})
+	if err != nil {
+		t.Fatalf("failed to list bundle heads: %v", err)
+	}
+
+	want := sha.String() + " refs/heads/main"
+	if !strings.Contains(stdout, want) {
+		t.Errorf("expected bundle heads to contain %q, got %q", want, stdout)
+	}
+}
+
+func TestAdapter_CreateBundleIncremental(t *testing.T) {
+	git := setupGit(t)
+	repo, teardown := setupRepo(t, git, "testcreatebundleincremental")
+	defer teardown()
+
+	ctx := context.Background()
+
+	first := writeFile(t, repo, "readme.md", "first", nil)
+	second := writeFile(t, repo, "readme.md", "second", []string{first.String()})
+	if err := repo.SetReference("refs/heads/main", second.String()); err != nil {
+		t.Fatalf("failed updating reference 'main': %v", err)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := git.CreateBundle(ctx, repo.Path, first.String(), buf); err != nil {
+		t.Fatalf("failed to create incremental bundle: %v", err)
+	}
+
+	bundlePath := filepath.Join(t.TempDir(), "repo.bundle")
+	if err := os.WriteFile(bundlePath, buf.Bytes(), 0o600); err != nil {
+		t.Fatalf("failed to write bundle: %v", err)
+	}
+
+	// the header of an incremental bundle lists the commits it's based on as prerequisites.
+	stdout, _, err := gitea.NewCommand(ctx, "bundle", "verify", bundlePath).
+		RunStdString(&gitea.RunOpts{Dir: repo.Path})
+	if err != nil {
+		t.Fatalf("failed to verify bundle: %v", err)
+	}
+	if !strings.Contains(stdout, "requires this ref") || !strings.Contains(stdout, first.String()) {
+		t.Errorf("expected the bundle to require commit %s, got %q", first, stdout)
+	}
+
+	errEmpty := git.CreateBundle(ctx, repo.Path, second.String(), &bytes.Buffer{})
+	if errors.AsStatus(errEmpty) != errors.StatusPreconditionFailed {
+		t.Errorf("expected precondition failed error for a bundle without changes, got: %v", errEmpty)
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter_test
+
+import (
+	"context"
+	"io"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/git/tempdir"
+	"github.com/harness/gitness/git/types"
+)
+
+func TestAdapter_CherryPick(t *testing.T) {
+	git := setupGit(t)
+	repo, teardown := setupRepo(t, git, "testcherrypick")
+	defer teardown()
+
+	sha1 := writeFile(t, repo, "file1.txt", "a", nil)
+	sha2 := writeFile(t, repo, "file1.txt", "b", []string{sha1.String()})
+	sha3 := writeFile(t, repo, "file2.txt", "x", []string{sha2.String()})
+	sha4 := writeFile(t, repo, "file1.txt", "c", []string{sha3.String()})
+
+	env := []string{
+		"GIT_AUTHOR_NAME=" + testAuthor.Name,
+		"GIT_AUTHOR_EMAIL=" + testAuthor.Email,
+		"GIT_COMMITTER_NAME=" + testCommitter.Name,
+		"GIT_COMMITTER_EMAIL=" + testCommitter.Email,
+	}
+
+	tests := []struct {
+		name          string
+		commits       []string
+		wantConflicts []string
+		wantErr       bool
+	}{
+		{
+			name:    "clean",
+			commits: []string{sha3.String()},
+		},
+		{
+			name:    "range",
+			commits: []string{sha1.String() + ".." + sha3.String()},
+		},
+		{
+			name:          "conflict",
+			commits:       []string{sha4.String()},
+			wantConflicts: []string{"file1.txt"},
+		},
+		{
+			name:    "already applied",
+			commits: []string{sha1.String()},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			err := repo.SetReference("refs/heads/release", sha1.String())
+			if err != nil {
+				t.Fatalf("failed updating reference 'release': %v", err)
+			}
+
+			tmpRepo, err := git.CreateTemporaryRepoForPR(ctx, os.TempDir(), &types.PullRequest{
+				BaseRepoPath: repo.Path,
+				BaseBranch:   "release",
+				HeadBranch:   "release",
+			}, "base", "tracking")
+			if err != nil {
+				t.Fatalf("failed to create temporary repository: %v", err)
+			}
+			defer func() {
+				_ = tempdir.RemoveTemporaryPath(tmpRepo.Path)
+			}()
+
+			if err = git.ReadTree(ctx, tmpRepo.Path, "HEAD", io.Discard); err != nil {
+				t.Fatalf("failed to read tree: %v", err)
+			}
+
+			result, err := git.CherryPick(ctx, tmpRepo.Path, 0, tt.commits, "cherry-pick", types.CommitSigning{}, env...)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("cherry-pick should fail")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("cherry-pick failed: %v", err)
+			}
+
+			if !reflect.DeepEqual(result.ConflictFiles, tt.wantConflicts) {
+				t.Errorf("conflict files: want=%v got=%v", tt.wantConflicts, result.ConflictFiles)
+			}
+
+			if len(tt.wantConflicts) > 0 {
+				return
+			}
+
+			commit, err := git.GetCommit(ctx, tmpRepo.Path, "base")
+			if err != nil {
+				t.Fatalf("failed to get cherry-pick commit: %v", err)
+			}
+
+			if commit.SHA == sha1.String() || commit.Title != "cherry-pick" {
+				t.Errorf("cherry-pick commit not created on top of the base branch: %s %q", commit.SHA, commit.Title)
+			}
+
+			if _, err = git.GetTreeNode(ctx, tmpRepo.Path, "base", "file2.txt"); err != nil {
+				t.Errorf("file2.txt should be added by the cherry-pick: %v", err)
+			}
+		})
+	}
+}
@@ -15,12 +15,15 @@
 package adapter
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git/types"
@@ -79,6 +82,10 @@ func (a Adapter) listCommitSHAs(
 		// ^REF tells the rev-list command to return only commits that aren't reachable by SHA
 		args = append(args, fmt.Sprintf("^%s", filter.AfterRef))
 	}
+	if filter.FirstParent {
+		args = append(args, "--first-parent")
+	}
+
 	// add refCommitSHA as starting point
 	args = append(args, ref)
 
@@ -292,7 +299,41 @@ func (a Adapter) GetCommit(
 		return nil, ErrRepositoryPathEmpty
 	}
 
-	return getCommit(ctx, repoPath, rev, "")
+	commit, err := getCommit(ctx, repoPath, rev, "")
+	if err != nil {
+		return nil, err
+	}
+
+	commit.Signature, err = getCommitSignature(ctx, repoPath, commit.SHA)
+	if err != nil {
+		return nil, err
+	}
+
+	return commit, nil
+}
+
+// getCommitSignature returns the signature of the commit, or nil if the commit isn't signed.
+func getCommitSignature(
+	ctx context.Context,
+	repoPath string,
+	sha string,
+) (*types.CommitSignature, error) {
+	output, _, runErr := gitea.NewCommand(ctx, "cat-file", "commit", sha).RunStdBytes(&gitea.RunOpts{Dir: repoPath})
+	if runErr != nil {
+		return nil, fmt.Errorf("failed to read commit object: %w", runErr)
+	}
+
+	id, err := gitea.NewIDFromString(sha)
+	if err != nil {
+		return nil, fmt.Errorf("invalid commit sha %q: %w", sha, err)
+	}
+
+	giteaCommit, err := gitea.CommitFromReader(nil, id, bytes.NewReader(output))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse commit object: %w", err)
+	}
+
+	return mapGiteaCommitSignature(giteaCommit.Signature), nil
 }
 
 func (a Adapter) GetFullCommitID(
@@ -445,24 +486,28 @@ func parseLinesToSlice(output []byte) []string {
 	return slice
 }
 
+// commitColumnsFormat is the git log format used to read the commit data required by parseCommitColumns.
+const commitColumnsFormat = "" +
+	fmtCommitHash + fmtZero + // 0
+	fmtAuthorName + fmtZero + // 1
+	fmtAuthorEmail + fmtZero + // 2
+	fmtAuthorTime + fmtZero + // 3
+	fmtCommitterName + fmtZero + // 4
+	fmtCommitterEmail + fmtZero + // 5
+	fmtCommitterTime + fmtZero + // 6
+	fmtSubject + fmtZero + // 7
+	fmtBody // 8
+
+// commitColumnCount is the number of columns produced by commitColumnsFormat.
+const commitColumnCount = 9
+
 func getCommit(
 	ctx context.Context,
 	repoPath string,
 	rev string,
 	path string,
 ) (*types.Commit, error) {
-	const format = "" +
-		fmtCommitHash + fmtZero + // 0
-		fmtAuthorName + fmtZero + // 1
-		fmtAuthorEmail + fmtZero + // 2
-		fmtAuthorTime + fmtZero + // 3
-		fmtCommitterName + fmtZero + // 4
-		fmtCommitterEmail + fmtZero + // 5
-		fmtCommitterTime + fmtZero + // 6
-		fmtSubject + fmtZero + // 7
-		fmtBody // 8
-
-	args := []string{"log", "--max-count=1", "--format=" + format, rev}
+	args := []string{"log", "--max-count=1", "--format=" + commitColumnsFormat, rev}
 	if path != "" {
 		args = append(args, "--", path)
 	}
@@ -479,14 +524,17 @@ func getCommit(
 		return nil, errors.InvalidArgument("path %q not found in %s", path, rev)
 	}
 
-	const columnCount = 9
-
 	commitData := strings.Split(strings.TrimSpace(commitLine), separatorZero)
-	if len(commitData) != columnCount {
+	if len(commitData) != commitColumnCount {
 		return nil, fmt.Errorf(
-			"unexpected git log formatted output, expected %d, but got %d columns", columnCount, len(commitData))
+			"unexpected git log formatted output, expected %d, but got %d columns", commitColumnCount, len(commitData))
 	}
 
+	return parseCommitColumns(commitData), nil
+}
+
+// parseCommitColumns creates a commit from the columns of the git log output formatted with commitColumnsFormat.
+func parseCommitColumns(commitData []string) *types.Commit {
 	sha := commitData[0]
 	authorName := commitData[1]
 	authorEmail := commitData[2]
@@ -495,7 +543,7 @@ func getCommit(
 	committerEmail := commitData[5]
 	committerTimestamp := commitData[6]
 	subject := commitData[7]
-	body := commitData[8]
+	body := strings.TrimRightFunc(commitData[8], unicode.IsSpace)
 
 	authorTime, _ := time.Parse(time.RFC3339Nano, authorTimestamp)
 	committerTime, _ := time.Parse(time.RFC3339Nano, committerTimestamp)
@@ -518,5 +566,85 @@ func getCommit(
 			},
 			When: committerTime,
 		},
-	}, nil
+	}
+}
+
+// WalkCommits calls the provided function for each commit reachable from the revision, newest first.
+// If the exclude revision is provided, commits reachable from it are skipped.
+// The commit history is streamed, so the function can be used on repositories with very long histories.
+func (a Adapter) WalkCommits(
+	ctx context.Context,
+	repoPath string,
+	rev string,
+	exclude string,
+	fn func(commit *types.Commit) error,
+) error {
+	if repoPath == "" {
+		return ErrRepositoryPathEmpty
+	}
+
+	args := []string{"log", "--format=" + fmtRecordSeparator + commitColumnsFormat, "-z", rev}
+	if exclude != "" {
+		args = append(args, "^"+exclude)
+	}
+	args = append(args, "--")
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pipeRead, pipeWrite := io.Pipe()
+	stderr := &bytes.Buffer{}
+	go func() {
+		var err error
+
+		defer func() {
+			_ = pipeWrite.CloseWithError(err)
+		}()
+
+		err = gitea.NewCommand(ctx, args...).Run(&gitea.RunOpts{
+			Dir:    repoPath,
+			Stdout: pipeWrite,
+			Stderr: stderr,
+		})
+	}()
+
+	defer func() {
+		_ = pipeRead.Close()
+	}()
+
+	scanner := bufio.NewScanner(pipeRead)
+	scanner.Buffer(nil, 16*1024*1024) // commit messages can be large
+	scanner.Split(scanZeroSeparated)
+
+	columns := make([]string, 0, commitColumnCount)
+	for scanner.Scan() {
+		token := scanner.Text()
+
+		if strings.HasPrefix(token, recordSeparator) {
+			columns = append(columns[:0], token[len(recordSeparator):])
+			continue
+		}
+
+		if len(columns) == 0 || len(columns) == commitColumnCount {
+			return fmt.Errorf("unexpected git log formatted output: %q", token)
+		}
+
+		columns = append(columns, token)
+		if len(columns) < commitColumnCount {
+			continue
+		}
+
+		if err := fn(parseCommitColumns(columns)); err != nil {
+			return err
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		if strings.Contains(stderr.String(), "unknown revision") {
+			return errors.NotFound("revision %q not found", rev)
+		}
+		return fmt.Errorf("failed to read git log output: %w (stderr: %s)", err, stderr.String())
+	}
+
+	return nil
 }
@@ -0,0 +1,76 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/git/types"
+)
+
+func TestAdapter_WalkCommits(t *testing.T) {
+	git := setupGit(t)
+	repo, teardown := setupRepo(t, git, "testwalkcommits")
+	defer teardown()
+
+	var shas []string
+	var parents []string
+	for _, file := range []string{"a.txt", "b.txt", "c.txt"} {
+		sha := writeFile(t, repo, file, file, parents)
+		parents = []string{sha.String()}
+		shas = append(shas, sha.String())
+	}
+
+	walk := func(exclude string) []*types.Commit {
+		var commits []*types.Commit
+		err := git.WalkCommits(context.Background(), repo.Path, shas[2], exclude, func(c *types.Commit) error {
+			commits = append(commits, c)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("failed to walk commits: %v", err)
+		}
+		return commits
+	}
+
+	commits := walk("")
+	if len(commits) != 3 {
+		t.Fatalf("expected 3 commits, got %d", len(commits))
+	}
+	for i, commit := range commits {
+		if want := shas[2-i]; commit.SHA != want {
+			t.Errorf("expected commit %d to be %s, got %s", i, want, commit.SHA)
+		}
+		if commit.Title != "write file operation" {
+			t.Errorf("unexpected title of commit %s: %q", commit.SHA, commit.Title)
+		}
+		if commit.Author.Identity.Email != testAuthor.Email || commit.Author.When.IsZero() {
+			t.Errorf("unexpected author of commit %s: %+v", commit.SHA, commit.Author)
+		}
+	}
+
+	commits = walk(shas[0])
+	if len(commits) != 2 || commits[0].SHA != shas[2] || commits[1].SHA != shas[1] {
+		t.Errorf("expected only the commits after the excluded one, got %d commits", len(commits))
+	}
+
+	err := git.WalkCommits(context.Background(), repo.Path, "nonexistent", "", func(*types.Commit) error {
+		return nil
+	})
+	if err == nil {
+		t.Errorf("expected an error for a nonexistent revision")
+	}
+}
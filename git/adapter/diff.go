@@ -35,6 +35,7 @@ func (a Adapter) RawDiff(
 	headRef string,
 	mergeBase bool,
 	w io.Writer,
+	paths ...string,
 ) error {
 	if repoPath == "" {
 		return ErrRepositoryPathEmpty
@@ -56,6 +57,10 @@ func (a Adapter) RawDiff(
 		args = append(args, "--merge-base")
 	}
 	args = append(args, baseRef, headRef)
+	if len(paths) > 0 {
+		args = append(args, "--")
+		args = append(args, paths...)
+	}
 
 	cmd := git.NewCommand(ctx, args...)
 	cmd.SetDescription(fmt.Sprintf("GetDiffRange [repo_path: %s]", repoPath))
@@ -107,6 +112,7 @@ func (a Adapter) DiffShortStat(
 	baseRef string,
 	headRef string,
 	useMergeBase bool,
+	paths ...string,
 ) (types.DiffShortStat, error) {
 	if repoPath == "" {
 		return types.DiffShortStat{}, ErrRepositoryPathEmpty
@@ -120,6 +126,10 @@ func (a Adapter) DiffShortStat(
 	if len(baseRef) == 0 || baseRef == git.EmptySHA {
 		shortstatArgs = []string{git.EmptyTreeSHA, headRef}
 	}
+	if len(paths) > 0 {
+		shortstatArgs = append(shortstatArgs, "--")
+		shortstatArgs = append(shortstatArgs, paths...)
+	}
 	numFiles, totalAdditions, totalDeletions, err := git.GetDiffShortStat(ctx, repoPath, shortstatArgs...)
 	if err != nil {
 		return types.DiffShortStat{}, processGiteaErrorf(err, "failed to get diff short stat between %s and %s",
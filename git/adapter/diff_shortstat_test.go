@@ -0,0 +1,68 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/git/types"
+)
+
+func TestAdapter_DiffShortStat(t *testing.T) {
+	git := setupGit(t)
+	repo, teardown := setupRepo(t, git, "testdiffshortstat")
+	defer teardown()
+
+	sha1 := writeFile(t, repo, "readme.md", "text\n", nil)
+	sha2 := writeFile(t, repo, "docs/guide.md", "line1\nline2\n", []string{sha1.String()})
+	sha3 := writeFile(t, repo, "readme.md", "text\nmore text\n", []string{sha2.String()})
+
+	tests := []struct {
+		name  string
+		paths []string
+		want  types.DiffShortStat
+	}{
+		{
+			name:  "all paths",
+			paths: nil,
+			want:  types.DiffShortStat{Files: 2, Additions: 3, Deletions: 0},
+		},
+		{
+			name:  "single path",
+			paths: []string{"docs/guide.md"},
+			want:  types.DiffShortStat{Files: 1, Additions: 2, Deletions: 0},
+		},
+		{
+			name:  "unchanged path",
+			paths: []string{"unknown.md"},
+			want:  types.DiffShortStat{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := git.DiffShortStat(context.Background(), repo.Path, sha1.String(), sha3.String(), false,
+				tt.paths...)
+			if err != nil {
+				t.Fatalf("failed to get diff short stat: %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("want=%+v got=%+v", tt.want, got)
+			}
+		})
+	}
+}
@@ -19,6 +19,8 @@ const (
 	fmtPerc = "%%"
 	fmtZero = "%x00"
 
+	fmtRecordSeparator = "%x1e"
+
 	fmtCommitHash = "%H"
 	fmtTreeHash   = "%T"
 	fmtParentHash = "%T"
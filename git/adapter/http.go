@@ -17,14 +17,16 @@ package adapter
 import (
 	"bytes"
 	"context"
-	"fmt"
 	"io"
+	"os/exec"
 	"strconv"
 	"strings"
 
 	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/types"
 
 	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/process"
 	"github.com/rs/zerolog/log"
 )
 
@@ -36,7 +38,8 @@ func (a Adapter) InfoRefs(
 	env ...string,
 ) error {
 	cmd := &bytes.Buffer{}
-	if err := git.NewCommand(ctx, service, "--stateless-rpc", "--advertise-refs", ".").
+	args := append(a.serviceConfig(service), service, "--stateless-rpc", "--advertise-refs", ".")
+	if err := git.NewCommand(ctx, args...).
 		Run(&git.RunOpts{
 			Env:    env,
 			Dir:    repoPath,
@@ -58,6 +61,10 @@ func (a Adapter) InfoRefs(
 	return nil
 }
 
+// ServicePack runs the stateless-rpc part of the given git service and returns the
+// resources consumed by the git process (and its waited-for children, e.g. pack-objects)
+// and, for upload-pack, the statistics of the negotiation.
+// The stats are returned even if the command failed, as long as the process was started.
 func (a Adapter) ServicePack(
 	ctx context.Context,
 	repoPath string,
@@ -65,27 +72,108 @@ func (a Adapter) ServicePack(
 	stdin io.Reader,
 	stdout io.Writer,
 	env ...string,
-) error {
+) (types.ServicePackStats, error) {
 	// set this for allow pre-receive and post-receive execute
 	env = append(env, "SSH_ORIGINAL_COMMAND="+service)
 
 	var (
-		stderr bytes.Buffer
+		stderr      bytes.Buffer
+		commitCache func() error
+		stats       types.ServicePackStats
 	)
-	cmd := git.NewCommand(ctx, service, "--stateless-rpc", repoPath)
-	cmd.SetDescription(fmt.Sprintf("%s %s %s [repo_path: %s]", git.GitExecutable, service, "--stateless-rpc", repoPath))
-	err := cmd.Run(&git.RunOpts{
-		Dir:               repoPath,
-		Env:               env,
-		Stdout:            stdout,
-		Stdin:             stdin,
-		Stderr:            &stderr,
-		UseContextTimeout: true,
-	})
+
+	// the negotiation stats are collected while git reads the request.
+	if service == "upload-pack" {
+		negotiation := newNegotiationReader(stdin, env)
+		stdin = negotiation
+		stats.Negotiation = &negotiation.stats
+	}
+
+	serviceConfig := a.serviceConfig(service)
+
+	// responses to clones are served from (and stored in) the pack cache.
+	if service == "upload-pack" && a.packCache != nil {
+		key, request, err := a.packCache.requestKey(repoPath, serviceConfig, stdin, env)
+		if err != nil {
+			return stats, err
+		}
+		stdin = request
+
+		if key != "" {
+			if served, err := a.packCache.serve(key, stdout); served {
+				stats.Negotiation.PackCached = true
+				return stats, err
+			}
+
+			cacheWriter, err := a.packCache.create(key)
+			if err != nil {
+				log.Ctx(ctx).Warn().Err(err).Msg("failed to create pack cache entry")
+			} else {
+				defer cacheWriter.abort()
+				stdout = io.MultiWriter(stdout, cacheWriter)
+				commitCache = cacheWriter.commit
+			}
+		}
+	}
+
+	// NOTE: the command is executed directly (not via gitea's command wrapper)
+	// as the process state is required to account for the consumed resources.
+	args := append(serviceConfig, service, "--stateless-rpc", repoPath)
+	cmd := exec.CommandContext(ctx, git.GitExecutable, args...)
+	process.SetSysProcAttribute(cmd)
+	cmd.Env = append(env, git.CommonGitCmdEnvs()...)
+	cmd.Dir = repoPath
+	cmd.Stdout = stdout
+	cmd.Stdin = stdin
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+
+	if cmd.ProcessState != nil {
+		stats.UserTime = cmd.ProcessState.UserTime()
+		stats.SystemTime = cmd.ProcessState.SystemTime()
+	}
+
 	if err != nil && err.Error() != "signal: killed" {
 		log.Ctx(ctx).Err(err).Msgf("Fail to serve RPC(%s) in %s: %v - %s", service, repoPath, err, stderr.String())
 	}
-	return err
+	if err == nil && commitCache != nil {
+		if cErr := commitCache(); cErr != nil {
+			log.Ctx(ctx).Warn().Err(cErr).Msg("failed to cache pack")
+		}
+	}
+	return stats, err
+}
+
+// serviceConfig returns the config arguments of the git service.
+// Receive-pack advertises the push-options capability, so the options sent by clients reach the git hooks.
+// Upload-pack always uses the reachability bitmaps (if the repository has them) to count the objects to send
+// and reuses the packed objects as they are. Refs by name (uploadpack.allowRefInWant) aren't advertised,
+// as clones requesting them couldn't be served from the pack cache.
+// With partial clones enabled, upload-pack advertises the filter capability and serves the
+// objects the partial clones fetch on demand, which aren't necessarily the tips of refs.
+func (a Adapter) serviceConfig(service string) []string {
+	if service == "receive-pack" {
+		return []string{"-c", "receive.advertisePushOptions=true"}
+	}
+
+	if service != "upload-pack" {
+		return nil
+	}
+
+	config := []string{
+		"-c", "pack.useBitmaps=true",
+		"-c", "pack.allowPackReuse=true",
+	}
+
+	if a.partialClone {
+		config = append(config,
+			"-c", "uploadpack.allowFilter=true",
+			"-c", "uploadpack.allowAnySHA1InWant=true",
+		)
+	}
+
+	return config
 }
 
 func packetWrite(str string) []byte {
@@ -0,0 +1,171 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/git/adapter"
+	"github.com/harness/gitness/git/types"
+)
+
+func TestAdapter_PartialClone(t *testing.T) {
+	git, err := adapter.New(
+		types.Config{PartialClone: true},
+		adapter.NewInMemoryLastCommitCache(5*time.Minute),
+		&mockClientFactory{},
+	)
+	if err != nil {
+		t.Fatalf("error initializing adapter: %v", err)
+	}
+
+	repo, teardown := setupRepo(t, git, "testpartialclone")
+	defer teardown()
+
+	first := writeFile(t, repo, "readme.md", "first", nil)
+	second := writeFile(t, repo, "readme.md", "second", []string{first.String()})
+	if err = repo.SetReference("refs/heads/main", second.String()); err != nil {
+		t.Fatalf("failed updating reference 'main': %v", err)
+	}
+
+	server := httptest.NewServer(smartHTTPHandler(git, repo.Path))
+	defer server.Close()
+
+	for _, version := range []string{"0", "2"} {
+		t.Run("protocol v"+version, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "clone")
+			runGit(t, "", "-c", "protocol.version="+version,
+				"clone", "--filter=blob:none", "--no-checkout", server.URL, dir)
+
+			// the blobs of the cloned commits are missing until they're needed.
+			missing := runGit(t, dir, "rev-list", "--objects", "--missing=print", "--all")
+			if !strings.Contains(missing, "?") {
+				t.Fatalf("expected missing blobs in the partial clone, got:\n%s", missing)
+			}
+
+			// the follow-up fetch of the blobs goes through upload-pack as well.
+			if content := runGit(t, dir, "-c", "protocol.version="+version,
+				"show", first.String()+":readme.md"); content != "first" {
+				t.Errorf("expected the content of the old blob, got %q", content)
+			}
+
+			runGit(t, dir, "-c", "protocol.version="+version, "checkout", "main")
+			if content := runGit(t, dir, "show", "HEAD:readme.md"); content != "second" {
+				t.Errorf("expected the content of the checked out blob, got %q", content)
+			}
+		})
+	}
+}
+
+// smartHTTPHandler serves the repository using git's smart http protocol.
+func smartHTTPHandler(git adapter.Adapter, repoPath string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var env []string
+		if protocol := r.Header.Get("Git-Protocol"); protocol != "" {
+			env = append(env, "GIT_PROTOCOL="+protocol)
+		}
+
+		var err error
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/info/refs"):
+			service := strings.TrimPrefix(r.URL.Query().Get("service"), "git-")
+			w.Header().Set("Content-Type", fmt.Sprintf("application/x-git-%s-advertisement", service))
+			err = git.InfoRefs(r.Context(), repoPath, service, w, env...)
+		case strings.HasSuffix(r.URL.Path, "/git-upload-pack"):
+			w.Header().Set("Content-Type", "application/x-git-upload-pack-result")
+			_, err = git.ServicePack(r.Context(), repoPath, "upload-pack", r.Body, w, env...)
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+
+	cmd := exec.CommandContext(context.Background(), "git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s failed: %v\n%s", strings.Join(args, " "), err, out)
+	}
+
+	return strings.TrimSpace(string(out))
+}
+
+func TestAdapter_PackCache(t *testing.T) {
+	cacheDir := t.TempDir()
+	git, err := adapter.New(
+		types.Config{
+			PackCache: types.PackCacheConfig{
+				Enabled: true,
+				Dir:     cacheDir,
+				MaxAge:  time.Minute,
+				MaxSize: 1 << 20,
+			},
+		},
+		adapter.NewInMemoryLastCommitCache(5*time.Minute),
+		&mockClientFactory{},
+	)
+	if err != nil {
+		t.Fatalf("error initializing adapter: %v", err)
+	}
+
+	repo, teardown := setupRepo(t, git, "testpackcache")
+	defer teardown()
+
+	sha := writeFile(t, repo, "readme.md", "content", nil)
+	if err = repo.SetReference("refs/heads/main", sha.String()); err != nil {
+		t.Fatalf("failed updating reference 'main': %v", err)
+	}
+
+	server := httptest.NewServer(smartHTTPHandler(git, repo.Path))
+	defer server.Close()
+
+	for _, version := range []string{"0", "2"} {
+		t.Run("protocol v"+version, func(t *testing.T) {
+			// the first clone stores the pack in the cache, the second one is served from it.
+			for i := 0; i < 2; i++ {
+				dir := filepath.Join(t.TempDir(), fmt.Sprintf("clone%d", i))
+				runGit(t, "", "-c", "protocol.version="+version, "clone", server.URL, dir)
+
+				if content := runGit(t, dir, "show", "HEAD:readme.md"); content != "content" {
+					t.Errorf("expected the content of the cloned blob, got %q", content)
+				}
+			}
+		})
+	}
+
+	entries, err := os.ReadDir(cacheDir)
+	if err != nil {
+		t.Fatalf("failed to read pack cache directory: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("expected a cached pack per protocol version, got %d cache entries", len(entries))
+	}
+}
@@ -0,0 +1,223 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/harness/gitness/git/types"
+
+	gitea "code.gitea.io/gitea/modules/git"
+	"github.com/rs/zerolog/log"
+)
+
+// lastCommitBatch resolves the last commits of a set of paths with a single walk of the commit history.
+// It's attached to the context of the last commit cache lookups, and it's evaluated lazily -
+// only when the first of the paths is not found in the cache.
+type lastCommitBatch struct {
+	repoPath  string
+	commitSHA string
+	paths     []string
+
+	once    sync.Once
+	commits map[string]*types.Commit
+}
+
+type lastCommitBatchKey struct{}
+
+func withLastCommitBatch(ctx context.Context, batch *lastCommitBatch) context.Context {
+	return context.WithValue(ctx, lastCommitBatchKey{}, batch)
+}
+
+func lastCommitBatchFrom(ctx context.Context) *lastCommitBatch {
+	batch, _ := ctx.Value(lastCommitBatchKey{}).(*lastCommitBatch)
+	return batch
+}
+
+// get returns the last commit of the path if the path is a part of the batch.
+// The second return value is false if the path should be resolved on its own.
+func (b *lastCommitBatch) get(
+	ctx context.Context,
+	repoPath string,
+	commitSHA string,
+	path string,
+) (*types.Commit, bool) {
+	if b.repoPath != repoPath || b.commitSHA != commitSHA {
+		return nil, false
+	}
+
+	b.once.Do(func() {
+		var err error
+		b.commits, err = getLastCommits(ctx, repoPath, commitSHA, b.paths)
+		if err != nil {
+			// not fatal, the paths are resolved one by one instead.
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to get last commits of paths in batch")
+		}
+	})
+
+	commit, ok := b.commits[path]
+
+	return commit, ok
+}
+
+// getLastCommits returns the last commit that changed each of the provided (cleaned) paths.
+// The history is walked only once, using a single pathspec (the deepest common parent directory of the paths),
+// so the changed-path bloom filters of the commit-graph file, if available, can be used by git to skip commits.
+// Paths that couldn't be found in the history (for example because they are changed only by merge commits)
+// are not present in the result.
+func getLastCommits(
+	ctx context.Context,
+	repoPath string,
+	rev string,
+	paths []string,
+) (map[string]*types.Commit, error) {
+	pending := make(map[string]struct{}, len(paths))
+	for _, p := range paths {
+		pending[p] = struct{}{}
+	}
+
+	commits := make(map[string]*types.Commit, len(pending))
+	if len(pending) == 0 {
+		return commits, nil
+	}
+
+	args := []string{
+		"log",
+		"--format=" + fmtRecordSeparator + commitColumnsFormat,
+		"-z",
+		"--name-only",
+		"--no-renames",
+		rev,
+	}
+	if pathspec := commonParentPath(paths); pathspec != "" {
+		args = append(args, "--", pathspec)
+	}
+
+	// the context is canceled as soon as all paths are resolved to stop the git process.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pipeRead, pipeWrite := io.Pipe()
+	stderr := &bytes.Buffer{}
+	go func() {
+		var err error
+
+		defer func() {
+			_ = pipeWrite.CloseWithError(err)
+		}()
+
+		err = gitea.NewCommand(ctx, args...).Run(&gitea.RunOpts{
+			Dir:    repoPath,
+			Stdout: pipeWrite,
+			Stderr: stderr,
+		})
+	}()
+
+	defer func() {
+		_ = pipeRead.Close()
+	}()
+
+	scanner := bufio.NewScanner(pipeRead)
+	scanner.Buffer(nil, 16*1024*1024) // commit messages can be large
+	scanner.Split(scanZeroSeparated)
+
+	var commit *types.Commit
+	firstFile := false
+
+	for len(pending) > 0 && scanner.Scan() {
+		token := scanner.Text()
+
+		if strings.HasPrefix(token, recordSeparator) {
+			columns := make([]string, 1, commitColumnCount)
+			columns[0] = token[len(recordSeparator):]
+			for len(columns) < commitColumnCount && scanner.Scan() {
+				columns = append(columns, scanner.Text())
+			}
+			if len(columns) != commitColumnCount {
+				break
+			}
+
+			commit = parseCommitColumns(columns)
+			firstFile = true
+
+			continue
+		}
+
+		if commit == nil || token == "" {
+			continue
+		}
+
+		// the list of changed files is separated from the commit data by a new line.
+		if firstFile {
+			token = strings.TrimPrefix(token, "\n")
+			firstFile = false
+		}
+
+		// the changed file resolves the file itself and all of its parent directories, including the root.
+		for p := token; ; p = parentPath(p) {
+			if _, ok := pending[p]; ok {
+				commits[p] = commit
+				delete(pending, p)
+			}
+			if p == "" {
+				break
+			}
+		}
+	}
+
+	if len(pending) == 0 {
+		return commits, nil
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read git log output: %w (stderr: %s)", err, stderr.String())
+	}
+
+	return commits, nil
+}
+
+const recordSeparator = "\x1e"
+
+// parentPath returns the parent directory of the (cleaned) path, or an empty string for the top level paths.
+func parentPath(p string) string {
+	dir := path.Dir(p)
+	if dir == "." {
+		return ""
+	}
+	return dir
+}
+
+// commonParentPath returns the deepest (cleaned) path that is the same as, or a parent of, all provided paths.
+func commonParentPath(paths []string) string {
+	if len(paths) == 0 {
+		return ""
+	}
+
+	common := paths[0]
+	for _, p := range paths[1:] {
+		for common != "" && p != common && !strings.HasPrefix(p, common+"/") {
+			common = parentPath(common)
+		}
+	}
+
+	return common
+}
@@ -117,6 +117,12 @@ func (c commitEntryGetter) Find(
 ) (*types.Commit, error) {
 	repoPath, commitSHA, path := key.Split()
 
+	if batch := lastCommitBatchFrom(ctx); batch != nil {
+		if commit, ok := batch.get(ctx, repoPath, commitSHA, path); ok {
+			return commit, nil
+		}
+	}
+
 	if path == "" {
 		path = "."
 	}
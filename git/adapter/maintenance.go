@@ -0,0 +1,111 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	gitea "code.gitea.io/gitea/modules/git"
+)
+
+// GarbageCollect runs git gc on the repository. It packs loose objects
+// and prunes unreachable objects that are older than the default prune expiry.
+// The reachability bitmap index is written explicitly, regardless of the git configuration.
+func (a Adapter) GarbageCollect(ctx context.Context, repoPath string) error {
+	if repoPath == "" {
+		return ErrRepositoryPathEmpty
+	}
+
+	_, _, err := gitea.NewCommand(ctx, "-c", "repack.writeBitmaps=true", "gc", "--quiet").
+		RunStdString(&gitea.RunOpts{Dir: repoPath})
+	if err != nil {
+		return processGiteaErrorf(err, "failed to run git gc")
+	}
+
+	return nil
+}
+
+// Repack repacks all objects of the repository into a single pack and removes the redundant packs.
+// Objects borrowed from alternates aren't included in the new pack.
+// The pack gets a reachability bitmap index, which lets upload-pack count the objects of large fetches
+// without walking the history and reuse the packed objects as they are.
+func (a Adapter) Repack(ctx context.Context, repoPath string) error {
+	if repoPath == "" {
+		return ErrRepositoryPathEmpty
+	}
+
+	_, _, err := gitea.NewCommand(ctx, "repack", "-a", "-d", "-l", "--write-bitmap-index", "--quiet").
+		RunStdString(&gitea.RunOpts{Dir: repoPath})
+	if err != nil {
+		return processGiteaErrorf(err, "failed to run git repack")
+	}
+
+	return nil
+}
+
+// WriteCommitGraph writes the commit-graph file for all reachable commits of the repository.
+func (a Adapter) WriteCommitGraph(ctx context.Context, repoPath string) error {
+	if repoPath == "" {
+		return ErrRepositoryPathEmpty
+	}
+
+	_, _, err := gitea.NewCommand(ctx, "commit-graph", "write", "--reachable", "--changed-paths").
+		RunStdString(&gitea.RunOpts{Dir: repoPath})
+	if err != nil {
+		return processGiteaErrorf(err, "failed to write commit-graph")
+	}
+
+	return nil
+}
+
+// HasCommitGraph returns true if the repository has a commit-graph file (single file or split chain).
+func (a Adapter) HasCommitGraph(_ context.Context, repoPath string) (bool, error) {
+	if repoPath == "" {
+		return false, ErrRepositoryPathEmpty
+	}
+
+	for _, path := range []string{
+		filepath.Join(repoPath, "objects", "info", "commit-graph"),
+		filepath.Join(repoPath, "objects", "info", "commit-graphs", "commit-graph-chain"),
+	} {
+		_, err := os.Stat(path)
+		if err == nil {
+			return true, nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return false, fmt.Errorf("failed to check commit-graph file %q: %w", path, err)
+		}
+	}
+
+	return false, nil
+}
+
+// HasBitmapIndex returns true if any pack of the repository has a reachability bitmap index.
+func (a Adapter) HasBitmapIndex(_ context.Context, repoPath string) (bool, error) {
+	if repoPath == "" {
+		return false, ErrRepositoryPathEmpty
+	}
+
+	bitmaps, err := filepath.Glob(filepath.Join(repoPath, "objects", "pack", "pack-*.bitmap"))
+	if err != nil {
+		return false, fmt.Errorf("failed to find bitmap index files: %w", err)
+	}
+
+	return len(bitmaps) > 0, nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter_test
+
+import (
+	"context"
+	"testing"
+)
+
+func TestAdapter_Maintenance(t *testing.T) {
+	git := setupGit(t)
+	repo, teardown := setupRepo(t, git, "testmaintenance")
+	defer teardown()
+
+	ctx := context.Background()
+
+	baseSHA := writeFile(t, repo, "file.txt", "base", nil)
+	mainSHA := writeFile(t, repo, "file.txt", "main", []string{baseSHA.String()})
+	if err := repo.SetReference("refs/heads/main", mainSHA.String()); err != nil {
+		t.Fatalf("failed updating reference: %v", err)
+	}
+
+	has, err := git.HasCommitGraph(ctx, repo.Path)
+	if err != nil {
+		t.Fatalf("HasCommitGraph() returned an error: %v", err)
+	}
+	if has {
+		t.Fatalf("expected no commit-graph in a new repository")
+	}
+
+	if err = git.WriteCommitGraph(ctx, repo.Path); err != nil {
+		t.Fatalf("WriteCommitGraph() returned an error: %v", err)
+	}
+
+	has, err = git.HasCommitGraph(ctx, repo.Path)
+	if err != nil {
+		t.Fatalf("HasCommitGraph() returned an error: %v", err)
+	}
+	if !has {
+		t.Fatalf("expected commit-graph to be written")
+	}
+
+	before, err := git.CountObjects(ctx, repo.Path)
+	if err != nil {
+		t.Fatalf("CountObjects() returned an error: %v", err)
+	}
+	if before.Count == 0 {
+		t.Fatalf("expected loose objects before gc")
+	}
+
+	if err = git.GarbageCollect(ctx, repo.Path); err != nil {
+		t.Fatalf("GarbageCollect() returned an error: %v", err)
+	}
+
+	if err = git.Repack(ctx, repo.Path); err != nil {
+		t.Fatalf("Repack() returned an error: %v", err)
+	}
+
+	hasBitmap, err := git.HasBitmapIndex(ctx, repo.Path)
+	if err != nil {
+		t.Fatalf("HasBitmapIndex() returned an error: %v", err)
+	}
+	if !hasBitmap {
+		t.Errorf("expected bitmap index to be written by repack")
+	}
+
+	after, err := git.CountObjects(ctx, repo.Path)
+	if err != nil {
+		t.Fatalf("CountObjects() returned an error: %v", err)
+	}
+	if after.Count != 0 {
+		t.Errorf("expected no loose objects after gc, got %d", after.Count)
+	}
+	if after.Packs != 1 {
+		t.Errorf("expected a single pack after repack, got %d", after.Packs)
+	}
+	if after.InPack != before.Count+before.InPack {
+		t.Errorf("expected %d packed objects, got %d", before.Count+before.InPack, after.InPack)
+	}
+}
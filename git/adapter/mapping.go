@@ -79,9 +79,21 @@ func mapGiteaCommit(giteaCommit *gitea.Commit) (*types.Commit, error) {
 		Message:   strings.TrimRight(giteaCommit.Message(), "\n"),
 		Author:    author,
 		Committer: committer,
+		Signature: mapGiteaCommitSignature(giteaCommit.Signature),
 	}, nil
 }
 
+func mapGiteaCommitSignature(giteaSignature *gitea.CommitGPGSignature) *types.CommitSignature {
+	if giteaSignature == nil {
+		return nil
+	}
+
+	return &types.CommitSignature{
+		Signature: giteaSignature.Signature,
+		Payload:   giteaSignature.Payload,
+	}
+}
+
 func mapGiteaSignature(
 	giteaSignature *gitea.Signature,
 ) (types.Signature, error) {
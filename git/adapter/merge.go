@@ -290,6 +290,7 @@ func (a Adapter) Merge(
 	trackingBranch string,
 	tmpBasePath string,
 	mergeMsg string,
+	signing types.CommitSigning,
 	identity *types.Identity,
 	env ...string,
 ) (types.MergeResult, error) {
@@ -302,8 +303,12 @@ func (a Adapter) Merge(
 	}
 
 	stagingBranch := "staging"
-	// TODO: sign merge commit
-	signArg := "--no-gpg-sign"
+
+	signArg := signing.Arg
+	if signArg == "" {
+		signArg = "--no-gpg-sign"
+	}
+	env = append(env, signing.Env...)
 
 	switch mergeMethod {
 	case enum.MergeMethodMerge:
@@ -371,9 +376,16 @@ func (a Adapter) Merge(
 
 		var conflicts bool
 
+		// The rebased commits keep their original authors, so only the signing environment is passed.
+		var rebaseEnv []string
+		if len(signing.Env) > 0 {
+			rebaseEnv = append(os.Environ(), signing.Env...)
+		}
+
 		// Rebase before merging
-		if err := git.NewCommand(ctx, "rebase", baseBranch).
+		if err := git.NewCommand(ctx, "rebase", signArg, baseBranch).
 			Run(&git.RunOpts{
+				Env:    rebaseEnv,
 				Dir:    tmpBasePath,
 				Stdout: &outbuf,
 				Stderr: &errbuf,
@@ -0,0 +1,116 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/harness/gitness/git/types"
+)
+
+// negotiationReader passes the upload-pack request through and collects the statistics
+// of the negotiation from its pkt-lines. Only the incomplete trailing pkt-line is buffered.
+// Parsing stops at the first malformed pkt-line, the data is still passed through as it is.
+type negotiationReader struct {
+	r       io.Reader
+	stats   types.UploadPackNegotiation
+	pending []byte
+	broken  bool
+}
+
+func newNegotiationReader(r io.Reader, env []string) *negotiationReader {
+	return &negotiationReader{
+		r: r,
+		stats: types.UploadPackNegotiation{
+			ProtocolVersion: protocolVersion(protocolFromEnv(env)),
+		},
+	}
+}
+
+func (r *negotiationReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if n > 0 && !r.broken {
+		r.parse(p[:n])
+	}
+
+	return n, err
+}
+
+func (r *negotiationReader) parse(data []byte) {
+	r.pending = append(r.pending, data...)
+
+	offset := 0
+	for len(r.pending)-offset >= 4 {
+		length, err := strconv.ParseUint(string(r.pending[offset:offset+4]), 16, 16)
+		if err != nil {
+			r.broken = true
+			r.pending = nil
+			return
+		}
+
+		// flush, delimiter and response-end packets don't have a payload.
+		if length < 4 {
+			offset += 4
+			continue
+		}
+		if offset+int(length) > len(r.pending) {
+			break
+		}
+
+		r.line(strings.TrimSuffix(string(r.pending[offset+4:offset+int(length)]), "\n"))
+		offset += int(length)
+	}
+
+	r.pending = append(r.pending[:0], r.pending[offset:]...)
+}
+
+func (r *negotiationReader) line(line string) {
+	switch {
+	case strings.HasPrefix(line, "command="):
+		r.stats.Command = strings.TrimPrefix(line, "command=")
+	case strings.HasPrefix(line, "want-ref "):
+		r.stats.WantRefs++
+	case strings.HasPrefix(line, "want "):
+		r.stats.Wants++
+	case strings.HasPrefix(line, "have "):
+		r.stats.Haves++
+	case strings.HasPrefix(line, "ref-prefix "):
+		r.stats.RefPrefixes++
+	case strings.HasPrefix(line, "filter "):
+		r.stats.Filter = true
+	case strings.HasPrefix(line, "deepen"):
+		r.stats.Deepen = true
+	case line == "done":
+		r.stats.Done = true
+	}
+}
+
+// protocolVersion returns the wire protocol version requested with the git protocol parameters
+// (colon separated key=value pairs, e.g. "version=2").
+func protocolVersion(protocol string) int {
+	for _, param := range strings.Split(protocol, ":") {
+		if v, ok := strings.CutPrefix(param, "version="); ok {
+			version, err := strconv.Atoi(v)
+			if err != nil {
+				return 0
+			}
+			return version
+		}
+	}
+
+	return 0
+}
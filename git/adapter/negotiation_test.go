@@ -0,0 +1,106 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"testing/iotest"
+
+	"github.com/harness/gitness/git/types"
+)
+
+func TestNegotiationReader(t *testing.T) {
+	const sha = "1234567890123456789012345678901234567890"
+
+	tests := []struct {
+		name     string
+		protocol string
+		lines    []string
+		want     types.UploadPackNegotiation
+	}{
+		{
+			name: "clone",
+			lines: []string{"want " + sha + " multi_ack_detailed side-band-64k ofs-delta\n", "want " + sha + "\n",
+				"", "done\n"},
+			want: types.UploadPackNegotiation{Wants: 2, Done: true},
+		},
+		{
+			name:  "fetch with negotiation",
+			lines: []string{"want " + sha + "\n", "deepen 1\n", "", "have " + sha + "\n", "have " + sha + "\n"},
+			want:  types.UploadPackNegotiation{Wants: 1, Haves: 2, Deepen: true},
+		},
+		{
+			name:     "protocol v2 ls-refs",
+			protocol: "version=2",
+			lines: []string{"command=ls-refs\n", "agent=git/2.39\n", "\x01", "peel\n",
+				"ref-prefix HEAD\n", "ref-prefix refs/heads/\n", ""},
+			want: types.UploadPackNegotiation{ProtocolVersion: 2, Command: "ls-refs", RefPrefixes: 2},
+		},
+		{
+			name:     "protocol v2 partial fetch",
+			protocol: "version=2",
+			lines: []string{"command=fetch\n", "\x01", "thin-pack\n", "filter blob:none\n",
+				"want-ref refs/heads/main\n", "have " + sha + "\n", "done\n", ""},
+			want: types.UploadPackNegotiation{
+				ProtocolVersion: 2, Command: "fetch", WantRefs: 1, Haves: 1, Done: true, Filter: true,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var data []byte
+			for _, line := range test.lines {
+				switch line {
+				case "":
+					data = append(data, "0000"...)
+				case "\x01":
+					data = append(data, "0001"...)
+				default:
+					data = append(data, packetWrite(line)...)
+				}
+			}
+
+			var env []string
+			if test.protocol != "" {
+				env = append(env, "GIT_PROTOCOL="+test.protocol)
+			}
+
+			// the request is read byte by byte, so every pkt-line is split between reads.
+			r := newNegotiationReader(iotest.OneByteReader(bytes.NewReader(data)), env)
+
+			got, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatalf("failed to read request: %v", err)
+			}
+			if !bytes.Equal(got, data) {
+				t.Errorf("expected the request to be passed through unchanged")
+			}
+			if r.stats != test.want {
+				t.Errorf("expected negotiation %+v, got %+v", test.want, r.stats)
+			}
+		})
+	}
+
+	r := newNegotiationReader(bytes.NewReader([]byte("00zzwant "+sha)), nil)
+	if _, err := io.ReadAll(r); err != nil {
+		t.Fatalf("failed to read malformed request: %v", err)
+	}
+	if r.stats != (types.UploadPackNegotiation{}) {
+		t.Errorf("expected no stats of a malformed request, got %+v", r.stats)
+	}
+}
@@ -0,0 +1,192 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/harness/gitness/git/types"
+)
+
+// objectCache is an in-memory LRU cache of frequently read git objects (e.g. READMEs and root trees).
+// Only immutable data is cached, the cache keys contain the SHA of the object (or of the commit it's read from).
+// A nil objectCache is valid and caches nothing.
+type objectCache struct {
+	mx            sync.Mutex
+	maxSize       int64
+	maxObjectSize int64
+	size          int64
+	entries       map[string]*list.Element
+	lru           *list.List
+	countHit      int64
+	countMiss     int64
+}
+
+type objectCacheEntry struct {
+	key   string
+	value any
+	size  int64
+}
+
+// objectCacheEntryOverhead is the approximate memory used by a cache entry in addition to its value.
+const objectCacheEntryOverhead = 128
+
+func newObjectCache(config types.ObjectCacheConfig) *objectCache {
+	if config.Size <= 0 {
+		return nil
+	}
+
+	maxObjectSize := config.MaxObjectSize
+	if maxObjectSize <= 0 || maxObjectSize > config.Size {
+		maxObjectSize = config.Size
+	}
+
+	return &objectCache{
+		maxSize:       config.Size,
+		maxObjectSize: maxObjectSize,
+		entries:       make(map[string]*list.Element),
+		lru:           list.New(),
+	}
+}
+
+// Stats returns the number of cache hits and misses.
+func (c *objectCache) Stats() (int64, int64) {
+	if c == nil {
+		return 0, 0
+	}
+
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	return c.countHit, c.countMiss
+}
+
+// fits returns true if an object of the provided size can be stored in the cache.
+func (c *objectCache) fits(size int64) bool {
+	return c != nil && size <= c.maxObjectSize
+}
+
+// getBlob returns the content of the blob with the provided SHA.
+func (c *objectCache) getBlob(repoPath, sha string) ([]byte, bool) {
+	v, ok := c.get(objectCacheKey("blob", repoPath, sha))
+	if !ok {
+		return nil, false
+	}
+
+	return v.([]byte), true
+}
+
+// addBlob stores the full content of the blob with the provided SHA.
+func (c *objectCache) addBlob(repoPath, sha string, content []byte) {
+	c.add(objectCacheKey("blob", repoPath, sha), content, int64(len(content)))
+}
+
+// getTreeNodes returns the child nodes of the tree at the path in the commit.
+func (c *objectCache) getTreeNodes(repoPath, commitSHA, treePath string) ([]types.TreeNode, bool) {
+	v, ok := c.get(objectCacheKey("tree", repoPath, commitSHA, treePath))
+	if !ok {
+		return nil, false
+	}
+
+	nodes := v.([]types.TreeNode)
+
+	// return a copy to protect the cached nodes from modifications by the caller
+	return append([]types.TreeNode(nil), nodes...), true
+}
+
+// addTreeNodes stores the child nodes of the tree at the path in the commit.
+func (c *objectCache) addTreeNodes(repoPath, commitSHA, treePath string, nodes []types.TreeNode) {
+	var size int64
+	for i := range nodes {
+		size += int64(len(nodes[i].Sha)+len(nodes[i].Name)+len(nodes[i].Path)) + objectCacheEntryOverhead
+	}
+
+	nodes = append([]types.TreeNode(nil), nodes...)
+
+	c.add(objectCacheKey("tree", repoPath, commitSHA, treePath), nodes, size)
+}
+
+func (c *objectCache) get(key string) (any, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		c.countMiss++
+		return nil, false
+	}
+
+	c.countHit++
+	c.lru.MoveToFront(elem)
+
+	return elem.Value.(*objectCacheEntry).value, true
+}
+
+func (c *objectCache) add(key string, value any, size int64) {
+	size += int64(len(key)) + objectCacheEntryOverhead
+	if !c.fits(size) {
+		return
+	}
+
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		// the cached data is immutable, no need to replace the existing entry.
+		c.lru.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.lru.PushFront(&objectCacheEntry{key: key, value: value, size: size})
+	c.size += size
+
+	for c.size > c.maxSize {
+		elem := c.lru.Back()
+		entry := elem.Value.(*objectCacheEntry)
+
+		c.lru.Remove(elem)
+		delete(c.entries, entry.key)
+		c.size -= entry.size
+	}
+}
+
+func objectCacheKey(kind string, parts ...string) string {
+	key := kind
+	for _, part := range parts {
+		key += separatorZero + part
+	}
+
+	return key
+}
+
+// isFullSHA returns true if the revision is a full object SHA (which, unlike references, can't change).
+func isFullSHA(rev string) bool {
+	if len(rev) != 40 && len(rev) != 64 {
+		return false
+	}
+
+	for _, r := range rev {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') {
+			return false
+		}
+	}
+
+	return true
+}
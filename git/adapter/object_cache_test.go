@@ -0,0 +1,70 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/git/types"
+)
+
+func TestObjectCache_Eviction(t *testing.T) {
+	c := newObjectCache(types.ObjectCacheConfig{
+		Size:          3 * (objectCacheEntryOverhead + 100),
+		MaxObjectSize: objectCacheEntryOverhead + 100,
+	})
+
+	content := make([]byte, 64)
+	c.addBlob("repo", "a", content)
+	c.addBlob("repo", "b", content)
+	c.addBlob("repo", "c", content)
+
+	// use "a" so that "b" is the least recently used blob
+	if _, ok := c.getBlob("repo", "a"); !ok {
+		t.Fatal("expected blob a to be cached")
+	}
+
+	c.addBlob("repo", "d", content)
+
+	if _, ok := c.getBlob("repo", "b"); ok {
+		t.Error("expected blob b to be evicted")
+	}
+	for _, sha := range []string{"a", "c", "d"} {
+		if _, ok := c.getBlob("repo", sha); !ok {
+			t.Errorf("expected blob %s to be cached", sha)
+		}
+	}
+
+	c.addBlob("repo", "large", make([]byte, 200))
+	if _, ok := c.getBlob("repo", "large"); ok {
+		t.Error("expected blob exceeding the max object size not to be cached")
+	}
+
+	if _, ok := c.getBlob("other-repo", "a"); ok {
+		t.Error("expected blobs to be cached per repository")
+	}
+}
+
+func TestObjectCache_Disabled(t *testing.T) {
+	c := newObjectCache(types.ObjectCacheConfig{})
+	if c != nil {
+		t.Fatal("expected the cache to be disabled")
+	}
+
+	c.addBlob("repo", "a", []byte("content"))
+	if _, ok := c.getBlob("repo", "a"); ok {
+		t.Error("expected disabled cache not to return blobs")
+	}
+}
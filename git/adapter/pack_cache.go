@@ -0,0 +1,344 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/harness/gitness/git/types"
+)
+
+const (
+	// packCacheMaxRequestSize is the maximum size of the upload-pack requests whose responses are cached.
+	// Requests without negotiation (clones) are small, as they only contain the wanted objects.
+	packCacheMaxRequestSize = 64 << 10 // 64 KiB
+
+	// packCacheCleanupInterval is the minimum duration between two cleanups of the pack cache directory.
+	packCacheCleanupInterval = time.Minute
+
+	// packCacheTmpSuffix is the suffix of the cache files that are still being written.
+	packCacheTmpSuffix = ".tmp"
+)
+
+// packCache is a disk cache of the packfiles upload-pack serves to clients.
+// Only requests that don't negotiate common objects (no "have" lines, e.g. clones) are cached,
+// as their response is fully determined by the request - the wanted objects are identified by their SHAs.
+// A nil packCache is valid and caches nothing.
+type packCache struct {
+	dir         string
+	maxAge      time.Duration
+	maxSize     int64
+	lastCleanup atomic.Int64
+	cleaning    atomic.Bool
+}
+
+func newPackCache(config types.PackCacheConfig) (*packCache, error) {
+	if !config.Enabled {
+		return nil, nil
+	}
+	if config.Dir == "" {
+		return nil, errors.New("pack cache directory is required")
+	}
+	if config.MaxAge <= 0 || config.MaxSize <= 0 {
+		return nil, errors.New("pack cache max age and max size must be positive")
+	}
+
+	if err := os.MkdirAll(config.Dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create pack cache directory: %w", err)
+	}
+
+	return &packCache{
+		dir:     config.Dir,
+		maxAge:  config.MaxAge,
+		maxSize: config.MaxSize,
+	}, nil
+}
+
+// requestKey reads the upload-pack request and returns the cache key of its response.
+// The key is empty if the response can't be cached. The returned reader replays the full request.
+func (c *packCache) requestKey(
+	repoPath string,
+	args []string,
+	request io.Reader,
+	env []string,
+) (string, io.Reader, error) {
+	if c == nil {
+		return "", request, nil
+	}
+
+	data, err := io.ReadAll(io.LimitReader(request, packCacheMaxRequestSize+1))
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read upload-pack request: %w", err)
+	}
+
+	request = io.MultiReader(bytes.NewReader(data), request)
+
+	if len(data) > packCacheMaxRequestSize || !isCacheableUploadPackRequest(data) {
+		return "", request, nil
+	}
+
+	h := sha256.New()
+	for _, part := range append([]string{repoPath, protocolFromEnv(env)}, args...) {
+		h.Write([]byte(part))
+		h.Write([]byte(separatorZero))
+	}
+	h.Write(data)
+
+	return hex.EncodeToString(h.Sum(nil)), request, nil
+}
+
+// serve writes the cached response to the writer. It returns false if there's no fresh cached response.
+func (c *packCache) serve(key string, w io.Writer) (bool, error) {
+	f, err := os.Open(filepath.Join(c.dir, key))
+	if err != nil {
+		return false, nil //nolint:nilerr // a missing or unreadable cache entry is a cache miss.
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil || time.Since(info.ModTime()) > c.maxAge {
+		return false, nil //nolint:nilerr // an expired cache entry is a cache miss.
+	}
+
+	if _, err = io.Copy(w, f); err != nil {
+		return true, fmt.Errorf("failed to write cached pack: %w", err)
+	}
+
+	return true, nil
+}
+
+// create returns a writer that stores the response in the cache once it's committed.
+func (c *packCache) create(key string) (*packCacheWriter, error) {
+	f, err := os.CreateTemp(c.dir, key+"-*"+packCacheTmpSuffix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pack cache file: %w", err)
+	}
+
+	return &packCacheWriter{
+		cache: c,
+		key:   key,
+		file:  f,
+		buf:   bufio.NewWriter(f),
+	}, nil
+}
+
+// cleanup removes the expired cache files and the oldest ones if the cache exceeds its maximum size.
+// It runs in the background and at most once per cleanup interval.
+func (c *packCache) cleanup() {
+	now := time.Now()
+	if now.Sub(time.Unix(0, c.lastCleanup.Load())) < packCacheCleanupInterval {
+		return
+	}
+	if !c.cleaning.CompareAndSwap(false, true) {
+		return
+	}
+
+	c.lastCleanup.Store(now.UnixNano())
+
+	go func() {
+		defer c.cleaning.Store(false)
+
+		// abandoned temporary files (e.g. after a crash) are removed once they can't be in use anymore.
+		tmpMaxAge := c.maxAge
+		if tmpMaxAge < time.Hour {
+			tmpMaxAge = time.Hour
+		}
+
+		var (
+			files []fs.FileInfo
+			size  int64
+		)
+
+		entries, err := os.ReadDir(c.dir)
+		if err != nil {
+			return
+		}
+
+		for _, entry := range entries {
+			info, err := entry.Info()
+			if err != nil || info.IsDir() {
+				continue
+			}
+
+			age := now.Sub(info.ModTime())
+			if strings.HasSuffix(info.Name(), packCacheTmpSuffix) {
+				if age > tmpMaxAge {
+					_ = os.Remove(filepath.Join(c.dir, info.Name()))
+				}
+				continue
+			}
+
+			if age > c.maxAge {
+				_ = os.Remove(filepath.Join(c.dir, info.Name()))
+				continue
+			}
+
+			files = append(files, info)
+			size += info.Size()
+		}
+
+		sort.Slice(files, func(i, j int) bool {
+			return files[i].ModTime().Before(files[j].ModTime())
+		})
+
+		for i := 0; size > c.maxSize && i < len(files); i++ {
+			if err := os.Remove(filepath.Join(c.dir, files[i].Name())); err == nil {
+				size -= files[i].Size()
+			}
+		}
+	}()
+}
+
+// packCacheWriter writes a response to a temporary cache file. Failures to write the file are ignored,
+// they only prevent the response from being cached.
+type packCacheWriter struct {
+	cache   *packCache
+	key     string
+	file    *os.File
+	buf     *bufio.Writer
+	written int64
+	failed  bool
+	done    bool
+}
+
+func (w *packCacheWriter) Write(p []byte) (int, error) {
+	if w.failed {
+		return len(p), nil
+	}
+
+	w.written += int64(len(p))
+	if w.written > w.cache.maxSize {
+		w.failed = true
+		return len(p), nil
+	}
+
+	if _, err := w.buf.Write(p); err != nil {
+		w.failed = true
+	}
+
+	return len(p), nil
+}
+
+// commit stores the written response in the cache.
+func (w *packCacheWriter) commit() error {
+	if w.done {
+		return nil
+	}
+	w.done = true
+
+	tmpPath := w.file.Name()
+
+	err := w.buf.Flush()
+	if cErr := w.file.Close(); err == nil {
+		err = cErr
+	}
+	if err == nil && w.failed {
+		err = errors.New("response couldn't be written to the cache")
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, filepath.Join(w.cache.dir, w.key))
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to store pack in cache: %w", err)
+	}
+
+	w.cache.cleanup()
+
+	return nil
+}
+
+// abort discards the written response. It's a no-op if the response has been committed.
+func (w *packCacheWriter) abort() {
+	if w.done {
+		return
+	}
+	w.done = true
+
+	_ = w.file.Close()
+	_ = os.Remove(w.file.Name())
+}
+
+// isCacheableUploadPackRequest returns true if the upload-pack request (a sequence of pkt-lines)
+// fetches objects by their SHAs without negotiating common objects with the client.
+// Requests referring to references (e.g. "want-ref", "deepen-not") or listing them aren't cacheable.
+func isCacheableUploadPackRequest(data []byte) bool {
+	var wants, done bool
+
+	for len(data) > 0 {
+		if len(data) < 4 {
+			return false
+		}
+
+		length, err := strconv.ParseUint(string(data[:4]), 16, 16)
+		if err != nil {
+			return false
+		}
+
+		// flush, delimiter and response-end packets don't have a payload.
+		if length < 4 {
+			data = data[4:]
+			continue
+		}
+		if int(length) > len(data) {
+			return false
+		}
+
+		line := strings.TrimSuffix(string(data[4:length]), "\n")
+		data = data[length:]
+
+		switch {
+		case strings.HasPrefix(line, "command="):
+			if line != "command=fetch" {
+				return false
+			}
+		case strings.HasPrefix(line, "want-ref "),
+			strings.HasPrefix(line, "deepen-not "),
+			strings.HasPrefix(line, "have "):
+			return false
+		case strings.HasPrefix(line, "want "):
+			wants = true
+		case line == "done":
+			done = true
+		}
+	}
+
+	return wants && done
+}
+
+// protocolFromEnv returns the git protocol set in the environment variables.
+func protocolFromEnv(env []string) string {
+	for _, kv := range env {
+		if protocol, ok := strings.CutPrefix(kv, "GIT_PROTOCOL="); ok {
+			return protocol
+		}
+	}
+
+	return ""
+}
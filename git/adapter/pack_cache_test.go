@@ -0,0 +1,85 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"testing"
+)
+
+func TestIsCacheableUploadPackRequest(t *testing.T) {
+	const sha = "1234567890123456789012345678901234567890"
+
+	tests := []struct {
+		name  string
+		lines []string
+		want  bool
+	}{
+		{
+			name:  "clone",
+			lines: []string{"want " + sha + " multi_ack_detailed side-band-64k ofs-delta\n", "", "done\n"},
+			want:  true,
+		},
+		{
+			name: "protocol v2 clone",
+			lines: []string{"command=fetch\n", "agent=git/2.39\n", "\x01", "thin-pack\n",
+				"want " + sha + "\n", "done\n", ""},
+			want: true,
+		},
+		{
+			name:  "fetch with negotiation",
+			lines: []string{"want " + sha + "\n", "", "have " + sha + "\n", "done\n"},
+			want:  false,
+		},
+		{
+			name:  "negotiation not done",
+			lines: []string{"want " + sha + "\n", ""},
+			want:  false,
+		},
+		{
+			name:  "protocol v2 ls-refs",
+			lines: []string{"command=ls-refs\n", "\x01", "peel\n", ""},
+			want:  false,
+		},
+		{
+			name:  "want ref",
+			lines: []string{"command=fetch\n", "\x01", "want-ref refs/heads/main\n", "done\n", ""},
+			want:  false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var data []byte
+			for _, line := range test.lines {
+				switch line {
+				case "":
+					data = append(data, "0000"...)
+				case "\x01":
+					data = append(data, "0001"...)
+				default:
+					data = append(data, packetWrite(line)...)
+				}
+			}
+
+			if got := isCacheableUploadPackRequest(data); got != test.want {
+				t.Errorf("isCacheableUploadPackRequest() = %t, want %t", got, test.want)
+			}
+		})
+	}
+
+	if isCacheableUploadPackRequest([]byte("00zzwant")) {
+		t.Error("expected malformed request not to be cacheable")
+	}
+}
@@ -33,12 +33,23 @@ func (a Adapter) PathsDetails(ctx context.Context,
 		return nil, fmt.Errorf("failed to get path details: %w", err)
 	}
 
-	results := make([]types.PathDetails, len(paths))
-
+	// use cleaned-up paths for calculations to avoid not-founds.
+	cleanPaths := make([]string, len(paths))
 	for i, path := range paths {
-		results[i].Path = path
+		cleanPaths[i] = cleanTreePath(path)
+	}
+
+	// paths not found in the cache are all resolved together, with a single walk of the commit history.
+	ctx = withLastCommitBatch(ctx, &lastCommitBatch{
+		repoPath:  repoPath,
+		commitSHA: commitSHA,
+		paths:     cleanPaths,
+	})
+
+	results := make([]types.PathDetails, len(paths))
 
-		path = cleanTreePath(path) // use cleaned-up path for calculations to avoid not-founds.
+	for i, path := range cleanPaths {
+		results[i].Path = paths[i]
 
 		commitEntry, err := a.lastCommitCache.Get(ctx, makeCommitEntryKey(repoPath, commitSHA, path))
 		if err != nil {
@@ -0,0 +1,75 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+)
+
+func TestAdapter_PathsDetails(t *testing.T) {
+	git := setupGit(t)
+	repo, teardown := setupRepo(t, git, "testpathsdetails")
+	defer teardown()
+
+	var parents []string
+	for i, file := range []string{"readme.md", "src/a.go", "src/lib/b.go", "docs/c.md", "src/a.go", "readme.md"} {
+		sha := writeFile(t, repo, file, "version "+strconv.Itoa(i), parents)
+		parents = []string{sha.String()}
+	}
+
+	if err := repo.SetReference("refs/heads/main", parents[0]); err != nil {
+		t.Fatalf("failed updating reference 'main': %v", err)
+	}
+
+	paths := []string{"", "readme.md", "src", "src/a.go", "src/lib", "/src/lib/b.go", "docs/"}
+
+	ctx := context.Background()
+
+	check := func(t *testing.T) {
+		details, err := setupGit(t).PathsDetails(ctx, repo.Path, "main", paths)
+		if err != nil {
+			t.Fatalf("failed to get paths details: %v", err)
+		}
+
+		if len(details) != len(paths) {
+			t.Fatalf("expected %d path details, got %d", len(paths), len(details))
+		}
+
+		for i, detail := range details {
+			if detail.Path != paths[i] {
+				t.Errorf("expected path %q, got %q", paths[i], detail.Path)
+			}
+
+			want, err := git.GetLatestCommit(ctx, repo.Path, "main", paths[i])
+			if err != nil {
+				t.Fatalf("failed to get latest commit of %q: %v", paths[i], err)
+			}
+
+			if detail.LastCommit == nil || detail.LastCommit.SHA != want.SHA {
+				t.Errorf("expected last commit of %q to be %s, got %+v", paths[i], want.SHA, detail.LastCommit)
+			}
+		}
+	}
+
+	t.Run("without commit-graph", check)
+
+	if err := git.WriteCommitGraph(ctx, repo.Path); err != nil {
+		t.Fatalf("failed to write commit-graph: %v", err)
+	}
+
+	t.Run("with commit-graph", check)
+}
@@ -0,0 +1,110 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	gitea "code.gitea.io/gitea/modules/git"
+)
+
+// FindNewChangedPaths returns the paths of the files that are added or modified by the commits
+// that are reachable from the provided revisions, but not from any of the existing references of the repository.
+// Commits are compared with their first parent, so files brought in by merges aren't reported for the merge commit.
+// The alternate object directories allow to inspect objects that are still in quarantine (pre-receive).
+func (a Adapter) FindNewChangedPaths(
+	ctx context.Context,
+	repoPath string,
+	alternateObjectDirs []string,
+	revs []string,
+) ([]string, error) {
+	if repoPath == "" {
+		return nil, ErrRepositoryPathEmpty
+	}
+	if len(revs) == 0 {
+		return nil, nil
+	}
+
+	args := make([]string, 0, len(revs)+10)
+	args = append(args, "log", "--format=", "--name-only", "-z", "--no-renames", "--diff-filter=d")
+	args = append(args, revs...)
+	args = append(args, "--not", "--all", "--")
+
+	stdout, _, err := gitea.NewCommand(ctx, args...).RunStdString(&gitea.RunOpts{
+		Dir: repoPath,
+		Env: alternateObjectDirsEnv(alternateObjectDirs),
+	})
+	if err != nil {
+		return nil, processGiteaErrorf(err, "failed to list paths changed by new commits")
+	}
+
+	return splitZeroSeparatedUnique(stdout), nil
+}
+
+// ListTreePaths returns the paths of all files of the tree of the provided revision.
+// The alternate object directories allow to inspect objects that are still in quarantine (pre-receive).
+func (a Adapter) ListTreePaths(
+	ctx context.Context,
+	repoPath string,
+	alternateObjectDirs []string,
+	rev string,
+) ([]string, error) {
+	if repoPath == "" {
+		return nil, ErrRepositoryPathEmpty
+	}
+
+	stdout, _, err := gitea.NewCommand(ctx, "ls-tree", "-r", "--name-only", "-z", rev).
+		RunStdString(&gitea.RunOpts{
+			Dir: repoPath,
+			Env: alternateObjectDirsEnv(alternateObjectDirs),
+		})
+	if err != nil {
+		return nil, processGiteaErrorf(err, "failed to list tree paths")
+	}
+
+	return splitZeroSeparatedUnique(stdout), nil
+}
+
+// alternateObjectDirsEnv returns the environment variables git needs to access objects of the alternate directories.
+func alternateObjectDirsEnv(alternateObjectDirs []string) []string {
+	if len(alternateObjectDirs) == 0 {
+		return nil
+	}
+
+	return []string{
+		"GIT_ALTERNATE_OBJECT_DIRECTORIES=" + strings.Join(alternateObjectDirs, string(os.PathListSeparator)),
+	}
+}
+
+func splitZeroSeparatedUnique(s string) []string {
+	seen := make(map[string]struct{})
+	paths := make([]string, 0)
+
+	for _, path := range strings.Split(s, "\x00") {
+		if path == "" {
+			continue
+		}
+		if _, ok := seen[path]; ok {
+			continue
+		}
+
+		seen[path] = struct{}{}
+		paths = append(paths, path)
+	}
+
+	return paths
+}
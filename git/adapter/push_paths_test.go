@@ -0,0 +1,100 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestAdapter_FindNewChangedPaths(t *testing.T) {
+	git := setupGit(t)
+	repo, teardown := setupRepo(t, git, "testfindnewchangedpaths")
+	defer teardown()
+
+	sha1 := writeFile(t, repo, "readme.md", "text", nil)
+	sha2 := writeFile(t, repo, "config/.env", "secret", []string{sha1.String()})
+	sha3 := writeFile(t, repo, "readme.md", "more text", []string{sha2.String()})
+
+	tests := []struct {
+		name string
+		ref  string
+		revs []string
+		want []string
+	}{
+		{
+			name: "single new commit",
+			ref:  sha1.String(),
+			revs: []string{sha2.String()},
+			want: []string{"config/.env"},
+		},
+		{
+			name: "multiple new commits",
+			ref:  sha1.String(),
+			revs: []string{sha3.String()},
+			want: []string{"readme.md", "config/.env"},
+		},
+		{
+			name: "commits already reachable",
+			ref:  sha3.String(),
+			revs: []string{sha2.String()},
+			want: []string{},
+		},
+		{
+			name: "no revisions",
+			ref:  sha1.String(),
+			revs: nil,
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := repo.SetReference("refs/heads/main", tt.ref)
+			if err != nil {
+				t.Fatalf("failed updating reference 'main': %v", err)
+			}
+
+			got, err := git.FindNewChangedPaths(context.Background(), repo.Path, nil, tt.revs)
+			if err != nil {
+				t.Fatalf("failed to find changed paths: %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("want=%v got=%v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestAdapter_ListTreePaths(t *testing.T) {
+	git := setupGit(t)
+	repo, teardown := setupRepo(t, git, "testlisttreepaths")
+	defer teardown()
+
+	sha1 := writeFile(t, repo, "readme.md", "text", nil)
+	sha2 := writeFile(t, repo, "docs/guide/intro.md", "intro", []string{sha1.String()})
+
+	got, err := git.ListTreePaths(context.Background(), repo.Path, nil, sha2.String())
+	if err != nil {
+		t.Fatalf("failed to list tree paths: %v", err)
+	}
+
+	want := []string{"docs/guide/intro.md", "readme.md"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want=%v got=%v", want, got)
+	}
+}
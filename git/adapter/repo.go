@@ -117,15 +117,19 @@ func (a Adapter) GetDefaultBranch(
 func (a Adapter) GetRemoteDefaultBranch(
 	ctx context.Context,
 	remoteURL string,
+	resolve []string,
 ) (string, error) {
 	args := []string{
 		"-c", "credential.helper=",
+	}
+	args = append(args, resolveArgs(resolve)...)
+	args = append(args,
 		"ls-remote",
 		"--symref",
 		"-q",
 		remoteURL,
 		"HEAD",
-	}
+	)
 
 	cmd := gitea.NewCommand(ctx, args...)
 	stdOut, _, err := cmd.RunStdString(nil)
@@ -176,6 +180,7 @@ func (a Adapter) Sync(
 	repoPath string,
 	source string,
 	refSpecs []string,
+	resolve []string,
 ) error {
 	if repoPath == "" {
 		return ErrRepositoryPathEmpty
@@ -186,6 +191,9 @@ func (a Adapter) Sync(
 	args := []string{
 		"-c", "advice.fetchShowForcedUpdates=false",
 		"-c", "credential.helper=",
+	}
+	args = append(args, resolveArgs(resolve)...)
+	args = append(args,
 		"fetch",
 		"--quiet",
 		"--prune",
@@ -194,7 +202,7 @@ func (a Adapter) Sync(
 		"--no-write-fetch-head",
 		"--no-show-forced-updates",
 		source,
-	}
+	)
 	args = append(args, refSpecs...)
 
 	cmd := gitea.NewCommand(ctx, args...)
@@ -284,6 +292,9 @@ func (a Adapter) Push(
 	if opts.Mirror {
 		cmd.AddArguments("--mirror")
 	}
+	if opts.Prune {
+		cmd.AddArguments("--prune")
+	}
 	cmd.AddArguments("--", opts.Remote)
 
 	if len(opts.Branch) > 0 {
@@ -420,3 +431,19 @@ func parseGitCountObjectsOutput(ctx context.Context, output string) types.Object
 
 	return info
 }
+
+// resolveArgs returns the git config arguments that pin the remote host to the provided addresses
+// (curl "HOST:PORT:ADDRESS[,ADDRESS]" format). Pinned requests don't follow redirects,
+// as a redirect could point to a host that wasn't checked by the caller.
+func resolveArgs(resolve []string) []string {
+	if len(resolve) == 0 {
+		return nil
+	}
+
+	args := make([]string, 0, 2*len(resolve)+2)
+	for _, entry := range resolve {
+		args = append(args, "-c", "http.curloptResolve="+entry)
+	}
+
+	return append(args, "-c", "http.followRedirects=false")
+}
@@ -0,0 +1,153 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/types"
+
+	"code.gitea.io/gitea/modules/git"
+)
+
+// Revert reverts the provided commits (or commit ranges) on top of the currently checked out branch
+// and commits the result as a single commit. The author and the committer are taken from the environment.
+// If mainline is greater than zero, it's used as the parent number of the merge commit that is reverted.
+// In case of conflicts nothing is committed and the list of the conflicting files is returned.
+func (a Adapter) Revert(
+	ctx context.Context,
+	repoPath string,
+	mainline int,
+	commits []string,
+	message string,
+	signing types.CommitSigning,
+	env ...string,
+) (types.MergeResult, error) {
+	return applyCommits(ctx, "revert", repoPath, mainline, commits, message, signing, env...)
+}
+
+// CherryPick applies the changes of the provided commits (or commit ranges) on top of the currently
+// checked out branch and commits the result as a single commit. It behaves the same way as Revert.
+func (a Adapter) CherryPick(
+	ctx context.Context,
+	repoPath string,
+	mainline int,
+	commits []string,
+	message string,
+	signing types.CommitSigning,
+	env ...string,
+) (types.MergeResult, error) {
+	return applyCommits(ctx, "cherry-pick", repoPath, mainline, commits, message, signing, env...)
+}
+
+// applyCommits runs the provided git sub command (revert or cherry-pick) without committing
+// and commits the result as a single commit.
+func applyCommits(
+	ctx context.Context,
+	subCommand string,
+	repoPath string,
+	mainline int,
+	commits []string,
+	message string,
+	signing types.CommitSigning,
+	env ...string,
+) (types.MergeResult, error) {
+	if repoPath == "" {
+		return types.MergeResult{}, ErrRepositoryPathEmpty
+	}
+
+	signArg := signing.Arg
+	if signArg == "" {
+		signArg = "--no-gpg-sign"
+	}
+	env = append(env, signing.Env...)
+
+	cmd := git.NewCommand(ctx, subCommand, "--no-commit")
+	if subCommand == "revert" {
+		cmd.AddArguments("--no-edit")
+	}
+	if mainline > 0 {
+		cmd.AddArguments("--mainline", strconv.Itoa(mainline))
+	}
+	cmd.AddArguments(commits...)
+
+	var outbuf, errbuf strings.Builder
+	if err := cmd.Run(&git.RunOpts{
+		Dir:    repoPath,
+		Stdout: &outbuf,
+		Stderr: &errbuf,
+		Env:    env,
+	}); err != nil {
+		// The sub command leaves the conflicting files unmerged in the index
+		files, cfErr := unmergedFiles(ctx, repoPath, env)
+		if cfErr != nil {
+			return types.MergeResult{}, cfErr
+		}
+		if len(files) > 0 {
+			return types.MergeResult{ConflictFiles: files}, nil
+		}
+
+		giteaErr := &giteaRunStdError{err: err, stderr: errbuf.String()}
+		return types.MergeResult{}, processGiteaErrorf(giteaErr, "git %s %v\n%s\n%s",
+			subCommand, commits, outbuf.String(), errbuf.String())
+	}
+	outbuf.Reset()
+	errbuf.Reset()
+
+	// The index is unchanged if the changes are already present on the branch, there's nothing to commit.
+	if err := git.NewCommand(ctx, "diff", "--cached", "--quiet", "HEAD").
+		Run(&git.RunOpts{
+			Env: env,
+			Dir: repoPath,
+		}); err == nil {
+		return types.MergeResult{}, errors.PreconditionFailed("git %s %v results in no changes", subCommand, commits)
+	}
+
+	if err := git.NewCommand(ctx, "commit", signArg, "-m", message).
+		Run(&git.RunOpts{
+			Env:    env,
+			Dir:    repoPath,
+			Stdout: &outbuf,
+			Stderr: &errbuf,
+		}); err != nil {
+		return types.MergeResult{}, processGiteaErrorf(err, "git commit %s %v\n%s\n%s",
+			subCommand, commits, outbuf.String(), errbuf.String())
+	}
+
+	return types.MergeResult{}, nil
+}
+
+// unmergedFiles returns the list of files with unresolved conflicts in the index.
+func unmergedFiles(ctx context.Context, repoPath string, env []string) ([]string, error) {
+	stdout, stderr, err := git.NewCommand(
+		ctx, "diff", "--name-only", "--diff-filter=U", "--relative",
+	).RunStdString(&git.RunOpts{
+		Env: env,
+		Dir: repoPath,
+	})
+	if err != nil {
+		return nil, processGiteaErrorf(err, "failed to list unmerged files, stderr: %v, err: %v", stderr, err)
+	}
+
+	var files []string
+	if len(stdout) > 0 {
+		files = strings.Split(strings.TrimSuffix(stdout, "\n"), "\n")
+	}
+
+	return files, nil
+}
@@ -283,6 +283,7 @@ func (r *SharedRepo) CommitTreeWithDate(
 	author, committer *types.Identity,
 	treeHash, message string,
 	signoff bool,
+	signing types.CommitSigning,
 	authorDate, committerDate time.Time,
 ) (string, error) {
 	// setup environment variables used by git-commit-tree
@@ -306,8 +307,12 @@ func (r *SharedRepo) CommitTreeWithDate(
 		args = []string{"commit-tree", treeHash}
 	}
 
-	// temporary no signing
-	args = append(args, "--no-gpg-sign")
+	if signing.Arg != "" {
+		args = append(args, signing.Arg)
+		env = append(env, signing.Env...)
+	} else {
+		args = append(args, "--no-gpg-sign")
+	}
 
 	if signoff {
 		giteaSignature := &gitea.Signature{
@@ -395,6 +400,48 @@ func (r *SharedRepo) PushDeleteTag(
 	return r.push(ctx, "", refTag, force, env...)
 }
 
+// FetchBundle fetches the branches and tags of the git bundle into the repository.
+// The branches and tags that aren't part of the bundle are deleted if prune is true.
+func (r *SharedRepo) FetchBundle(ctx context.Context, bundlePath string, prune bool) error {
+	args := []string{"fetch", "--quiet", "--force", "--no-write-fetch-head"}
+	if prune {
+		args = append(args, "--prune")
+	}
+	args = append(args, bundlePath, "+refs/heads/*:refs/heads/*", "+refs/tags/*:refs/tags/*")
+
+	stderr := &bytes.Buffer{}
+	err := gitea.NewCommand(ctx, args...).Run(&gitea.RunOpts{
+		Dir:    r.tmpPath,
+		Stderr: stderr,
+	})
+	if strings.Contains(stderr.String(), "lacks these prerequisite commits") {
+		return errors.PreconditionFailed("the repository doesn't contain the commits the bundle is based on")
+	}
+	if err != nil {
+		return processGiteaErrorf(err, "failed to fetch bundle: %s", stderr)
+	}
+
+	return nil
+}
+
+// PushAll pushes all branches and tags to the original repository.
+// The branches and tags that don't exist in the temporary repository are deleted if prune is true.
+func (r *SharedRepo) PushAll(ctx context.Context, prune bool, env ...string) error {
+	for _, refSpec := range []string{"refs/heads/*:refs/heads/*", "refs/tags/*:refs/tags/*"} {
+		if err := r.adapter.Push(ctx, r.tmpPath, types.PushOptions{
+			Remote: r.remoteRepoPath,
+			Branch: refSpec,
+			Env:    env,
+			Force:  true,
+			Prune:  prune,
+		}); err != nil {
+			return fmt.Errorf("unable to push back to repo from temporary repo: %w", err)
+		}
+	}
+
+	return nil
+}
+
 // push pushes the provided references to the provided branch in the original repository.
 func (r *SharedRepo) push(
 	ctx context.Context,
@@ -0,0 +1,165 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/types"
+)
+
+const (
+	signingKeyFile = "signing_key"
+	gnupgHomeDir   = "gnupg"
+)
+
+// ConfigureSigning prepares the (temporary) repository for signing commits with the provided key.
+// It returns the argument and the environment variables that should be used with the commit commands.
+func (a Adapter) ConfigureSigning(
+	ctx context.Context,
+	repoPath string,
+	key types.SigningKey,
+) (types.CommitSigning, error) {
+	if repoPath == "" {
+		return types.CommitSigning{}, ErrRepositoryPathEmpty
+	}
+	if key.PrivateKey == "" {
+		return types.CommitSigning{}, errors.InvalidArgument("signing key cannot be empty")
+	}
+
+	switch key.Format {
+	case enum.SigningFormatSSH:
+		return a.configureSigningSSH(ctx, repoPath, key.PrivateKey)
+	case enum.SigningFormatOpenPGP:
+		return a.configureSigningOpenPGP(ctx, repoPath, key.PrivateKey)
+	default:
+		return types.CommitSigning{}, errors.InvalidArgument("unsupported signing key format: %s", key.Format)
+	}
+}
+
+func (a Adapter) configureSigningSSH(
+	ctx context.Context,
+	repoPath string,
+	privateKey string,
+) (types.CommitSigning, error) {
+	program, err := exec.LookPath("ssh-keygen")
+	if err != nil {
+		return types.CommitSigning{}, fmt.Errorf("ssh-keygen is required for signing commits: %w", err)
+	}
+
+	keyPath := filepath.Join(gitDir(repoPath), signingKeyFile)
+	if err = os.WriteFile(keyPath, []byte(strings.TrimSpace(privateKey)+"\n"), 0o600); err != nil {
+		return types.CommitSigning{}, fmt.Errorf("failed to write signing key: %w", err)
+	}
+
+	for _, kv := range [][2]string{
+		{"gpg.format", "ssh"},
+		{"gpg.ssh.program", program},
+		{"user.signingkey", keyPath},
+	} {
+		if err = a.Config(ctx, repoPath, kv[0], kv[1]); err != nil {
+			return types.CommitSigning{}, err
+		}
+	}
+
+	return types.CommitSigning{Arg: "--gpg-sign"}, nil
+}
+
+func (a Adapter) configureSigningOpenPGP(
+	ctx context.Context,
+	repoPath string,
+	privateKey string,
+) (types.CommitSigning, error) {
+	program, err := exec.LookPath("gpg")
+	if err != nil {
+		return types.CommitSigning{}, fmt.Errorf("gpg is required for signing commits: %w", err)
+	}
+
+	home := filepath.Join(gitDir(repoPath), gnupgHomeDir)
+	if err = os.MkdirAll(home, 0o700); err != nil {
+		return types.CommitSigning{}, fmt.Errorf("failed to create gnupg home directory: %w", err)
+	}
+
+	env := append(os.Environ(), "GNUPGHOME="+home)
+
+	importCmd := exec.CommandContext(ctx, program, "--batch", "--import")
+	importCmd.Env = env
+	importCmd.Stdin = strings.NewReader(privateKey)
+	if out, err := importCmd.CombinedOutput(); err != nil {
+		return types.CommitSigning{}, fmt.Errorf("failed to import signing key: %w\n%s", err, out)
+	}
+
+	listCmd := exec.CommandContext(ctx, program, "--batch", "--with-colons", "--list-secret-keys")
+	listCmd.Env = env
+	out, err := listCmd.Output()
+	if err != nil {
+		return types.CommitSigning{}, fmt.Errorf("failed to list signing keys: %w", err)
+	}
+
+	fingerprint := parseGPGFingerprint(string(out))
+	if fingerprint == "" {
+		return types.CommitSigning{}, errors.InvalidArgument("signing key doesn't contain a secret key")
+	}
+
+	if err = a.Config(ctx, repoPath, "gpg.program", program); err != nil {
+		return types.CommitSigning{}, err
+	}
+
+	return types.CommitSigning{
+		Arg: "--gpg-sign=" + fingerprint,
+		Env: []string{"GNUPGHOME=" + home},
+	}, nil
+}
+
+// gitDir returns the git directory of the repository, which is the repository itself for bare repositories.
+func gitDir(repoPath string) string {
+	dotGit := filepath.Join(repoPath, ".git")
+	if info, err := os.Stat(dotGit); err == nil && info.IsDir() {
+		return dotGit
+	}
+
+	return repoPath
+}
+
+// parseGPGFingerprint returns the fingerprint of the first secret key
+// from the output of gpg --with-colons --list-secret-keys.
+func parseGPGFingerprint(output string) string {
+	var inSecretKey bool
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		fields := strings.Split(scanner.Text(), ":")
+		switch fields[0] {
+		case "sec":
+			inSecretKey = true
+		case "fpr":
+			if inSecretKey && len(fields) > 9 {
+				return fields[9]
+			}
+		case "ssb", "sub":
+			inSecretKey = false
+		}
+	}
+
+	return ""
+}
@@ -16,6 +16,7 @@ package adapter
 
 import (
 	"context"
+	"strings"
 
 	"github.com/harness/gitness/git/types"
 
@@ -57,3 +58,73 @@ func (a Adapter) GetSubmodule(
 		URL:  giteaSubmodule.URL,
 	}, nil
 }
+
+// ListSubmodules returns the submodules defined in the .gitmodules file reachable from ref.
+// Submodules without a path are skipped. If the .gitmodules file doesn't exist, an empty list is returned.
+// Note: ref can be Branch / Tag / CommitSHA.
+func (a Adapter) ListSubmodules(
+	ctx context.Context,
+	repoPath string,
+	ref string,
+) ([]types.Submodule, error) {
+	if repoPath == "" {
+		return nil, ErrRepositoryPathEmpty
+	}
+
+	stdout, stderr, err := gitea.NewCommand(ctx,
+		"config", "-z", "--blob", ref+":.gitmodules", "--get-regexp", `^submodule\.`,
+	).RunStdString(&gitea.RunOpts{Dir: repoPath})
+	if err != nil {
+		// the .gitmodules file doesn't exist, or it doesn't contain any submodule
+		if strings.Contains(stderr, "unable to resolve config blob") || (err.IsExitCode(1) && stderr == "") {
+			return []types.Submodule{}, nil
+		}
+		return nil, processGiteaErrorf(err, "failed to read .gitmodules")
+	}
+
+	return parseSubmodulesConfig(stdout), nil
+}
+
+// parseSubmodulesConfig parses the output of "git config -z --get-regexp ^submodule\."
+// Each entry is in the form "submodule.<name>.<key>\n<value>\0".
+func parseSubmodulesConfig(output string) []types.Submodule {
+	submodules := make([]types.Submodule, 0)
+	indexes := make(map[string]int)
+
+	for _, entry := range strings.Split(output, "\x00") {
+		key, value, ok := strings.Cut(entry, "\n")
+		if !ok {
+			continue
+		}
+
+		key = strings.TrimPrefix(key, "submodule.")
+		idx := strings.LastIndexByte(key, '.')
+		if idx <= 0 {
+			continue
+		}
+		name, variable := key[:idx], key[idx+1:]
+
+		i, exists := indexes[name]
+		if !exists {
+			i = len(submodules)
+			indexes[name] = i
+			submodules = append(submodules, types.Submodule{Name: name})
+		}
+
+		switch variable {
+		case "path":
+			submodules[i].Path = value
+		case "url":
+			submodules[i].URL = value
+		}
+	}
+
+	result := submodules[:0]
+	for _, submodule := range submodules {
+		if submodule.Path != "" {
+			result = append(result, submodule)
+		}
+	}
+
+	return result
+}
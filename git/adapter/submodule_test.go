@@ -0,0 +1,60 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/git/types"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAdapter_ListSubmodules(t *testing.T) {
+	git := setupGit(t)
+	repo, teardown := setupRepo(t, git, "testlistsubmodules")
+	defer teardown()
+
+	ctx := context.Background()
+
+	withoutSHA := writeFile(t, repo, "README.md", "readme", nil)
+
+	got, err := git.ListSubmodules(ctx, repo.Path, withoutSHA.String())
+	if err != nil {
+		t.Fatalf("ListSubmodules() returned an error: %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("expected no submodules without .gitmodules, got %+v", got)
+	}
+
+	gitmodules := "[submodule \"libs.a\"]\n\tpath = libs/a\n\turl = ../a.git\n" +
+		"[submodule \"b\"]\n\turl = https://example.com/b.git\n\tpath = b\n" +
+		"[submodule \"nopath\"]\n\turl = https://example.com/nopath.git\n"
+	withSHA := writeFile(t, repo, ".gitmodules", gitmodules, []string{withoutSHA.String()})
+
+	got, err = git.ListSubmodules(ctx, repo.Path, withSHA.String())
+	if err != nil {
+		t.Fatalf("ListSubmodules() returned an error: %v", err)
+	}
+
+	want := []types.Submodule{
+		{Name: "libs.a", Path: "libs/a", URL: "../a.git"},
+		{Name: "b", Path: "b", URL: "https://example.com/b.git"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ListSubmodules() mismatch (-want +got):\n%s", diff)
+	}
+}
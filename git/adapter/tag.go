@@ -32,6 +32,7 @@ import (
 const (
 	pgpSignatureBeginToken = "\n-----BEGIN PGP SIGNATURE-----\n" //#nosec G101
 	pgpSignatureEndToken   = "\n-----END PGP SIGNATURE-----"     //#nosec G101
+	sshSignatureBeginToken = "\n-----BEGIN SSH SIGNATURE-----\n" //#nosec G101
 )
 
 // GetAnnotatedTag returns the tag for a specific tag sha.
@@ -89,6 +90,11 @@ func (a Adapter) CreateTag(
 			"GIT_COMMITTER_EMAIL="+opts.Tagger.Identity.Email,
 			"GIT_COMMITTER_DATE="+opts.Tagger.When.Format(time.RFC3339),
 		)
+
+		if opts.Signing.Arg != "" {
+			args = append(args, tagSigningArg(opts.Signing.Arg))
+			env = append(env, opts.Signing.Env...)
+		}
 	}
 
 	args = append(args,
@@ -105,6 +111,15 @@ func (a Adapter) CreateTag(
 	return nil
 }
 
+// tagSigningArg converts the commit signing argument to its git tag equivalent:
+// "--gpg-sign" signs with the configured key and "--gpg-sign=<key>" signs with the provided key.
+func tagSigningArg(commitArg string) string {
+	if key, ok := strings.CutPrefix(commitArg, "--gpg-sign="); ok {
+		return "--local-user=" + key
+	}
+	return "--sign"
+}
+
 // giteaGetAnnotatedTag is a custom implementation to retrieve an annotated tag from a sha.
 // The code is following parts of the gitea implementation.
 func giteaGetAnnotatedTags(
@@ -200,8 +215,23 @@ func parseTagDataFromCatFile(data []byte) (tag types.Tag, err error) {
 		return tag, err
 	}
 
+	// git appends the signature of a signed tag to the message, the signed payload is everything before it.
+	body := data[p:]
+	i := bytes.Index(body, []byte(pgpSignatureBeginToken))
+	if i == -1 {
+		i = bytes.Index(body, []byte(sshSignatureBeginToken))
+	}
+	if i > -1 {
+		signatureStart := p + i + 1
+		tag.Signature = &types.CommitSignature{
+			Signature: string(data[signatureStart:]),
+			Payload:   string(data[:signatureStart]),
+		}
+		body = body[:i+1]
+	}
+
 	// remainder is message and gpg (remove leading and tailing new lines)
-	message := string(bytes.Trim(data[p:], "\n"))
+	message := string(bytes.Trim(body, "\n"))
 
 	// handle gpg signature
 	pgpEnd := strings.Index(message, pgpSignatureEndToken)
@@ -0,0 +1,87 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter_test
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/types"
+)
+
+func TestAdapter_CreateTagSigned(t *testing.T) {
+	if _, err := exec.LookPath("ssh-keygen"); err != nil {
+		t.Skip("ssh-keygen is not available")
+	}
+
+	git := setupGit(t)
+	repo, teardown := setupRepo(t, git, "testcreatetagsigned")
+	defer teardown()
+
+	ctx := context.Background()
+
+	keyPath := filepath.Join(t.TempDir(), "id_ed25519")
+	out, err := exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-f", keyPath).CombinedOutput()
+	if err != nil {
+		t.Fatalf("failed to generate ssh key: %v: %s", err, out)
+	}
+	privateKey, err := os.ReadFile(keyPath)
+	if err != nil {
+		t.Fatalf("failed to read ssh key: %v", err)
+	}
+
+	sha := writeFile(t, repo, "README.md", "readme", nil)
+
+	signing, err := git.ConfigureSigning(ctx, repo.Path, types.SigningKey{
+		Format:     enum.SigningFormatSSH,
+		PrivateKey: string(privateKey),
+	})
+	if err != nil {
+		t.Fatalf("ConfigureSigning() returned an error: %v", err)
+	}
+
+	err = git.CreateTag(ctx, repo.Path, "v1.0.0", sha.String(), &types.CreateTagOptions{
+		Message: "release v1.0.0",
+		Tagger: types.Signature{
+			Identity: types.Identity{Name: "tagger", Email: "tagger@example.com"},
+			When:     time.Now(),
+		},
+		Signing: signing,
+	})
+	if err != nil {
+		t.Fatalf("CreateTag() returned an error: %v", err)
+	}
+
+	tag, err := git.GetAnnotatedTag(ctx, repo.Path, "v1.0.0")
+	if err != nil {
+		t.Fatalf("GetAnnotatedTag() returned an error: %v", err)
+	}
+
+	if tag.Signature == nil {
+		t.Fatal("expected the tag to be signed")
+	}
+	if !strings.HasPrefix(tag.Signature.Signature, "-----BEGIN SSH SIGNATURE-----") {
+		t.Errorf("unexpected tag signature: %q", tag.Signature.Signature)
+	}
+	if strings.TrimSpace(tag.Message) != "release v1.0.0" {
+		t.Errorf("unexpected tag message: %q", tag.Message)
+	}
+}
@@ -96,32 +96,41 @@ func lsTree(
 	scan := bufio.NewScanner(strings.NewReader(output))
 	scan.Split(scanZeroSeparated)
 	for scan.Scan() {
-		columns := regexpLsTreeColumns.FindStringSubmatch(scan.Text())
-		if columns == nil {
-			return nil, errors.New("unrecognized format of git directory listing")
-		}
-
-		nodeType, nodeMode, err := parseTreeNodeMode(columns[1])
+		node, err := parseLsTreeLine(scan.Text())
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse git mode: %w", err)
+			return nil, err
 		}
 
-		nodeSha := columns[3]
-		nodePath := columns[4]
-		nodeName := path.Base(nodePath)
-
-		list = append(list, types.TreeNode{
-			NodeType: nodeType,
-			Mode:     nodeMode,
-			Sha:      nodeSha,
-			Name:     nodeName,
-			Path:     nodePath,
-		})
+		list = append(list, node)
 	}
 
 	return list, nil
 }
 
+func parseLsTreeLine(line string) (types.TreeNode, error) {
+	columns := regexpLsTreeColumns.FindStringSubmatch(line)
+	if columns == nil {
+		return types.TreeNode{}, errors.New("unrecognized format of git directory listing")
+	}
+
+	nodeType, nodeMode, err := parseTreeNodeMode(columns[1])
+	if err != nil {
+		return types.TreeNode{}, fmt.Errorf("failed to parse git mode: %w", err)
+	}
+
+	nodeSha := columns[3]
+	nodePath := columns[4]
+	nodeName := path.Base(nodePath)
+
+	return types.TreeNode{
+		NodeType: nodeType,
+		Mode:     nodeMode,
+		Sha:      nodeSha,
+		Name:     nodeName,
+		Path:     nodePath,
+	}, nil
+}
+
 // lsFile returns all tree node entries in the requested directory.
 func lsDirectory(
 	ctx context.Context,
@@ -194,12 +203,24 @@ func (a Adapter) GetTreeNode(ctx context.Context, repoPath, rev, treePath string
 }
 
 // ListTreeNodes lists the child nodes of a tree reachable from ref via the specified path.
+// Listings of trees reached from a commit SHA are cached, as they can't change.
 func (a Adapter) ListTreeNodes(ctx context.Context, repoPath, rev, treePath string) ([]types.TreeNode, error) {
+	cacheable := isFullSHA(rev)
+	if cacheable {
+		if list, ok := a.objectCache.getTreeNodes(repoPath, rev, treePath); ok {
+			return list, nil
+		}
+	}
+
 	list, err := lsDirectory(ctx, repoPath, rev, treePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list tree nodes: %w", err)
 	}
 
+	if cacheable {
+		a.objectCache.addTreeNodes(repoPath, rev, treePath, list)
+	}
+
 	return list, nil
 }
 
@@ -0,0 +1,174 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/types"
+
+	gitea "code.gitea.io/gitea/modules/git"
+)
+
+// ListTreeNodesPage lists a page of nodes of the tree reachable from rev via the specified path, in git tree order.
+// The output of git ls-tree is streamed and the command is stopped as soon as the page is full,
+// which keeps listings of very large trees cheap. Along with the nodes it returns whether more nodes follow.
+//
+//nolint:gocognit
+func (a Adapter) ListTreeNodesPage(
+	ctx context.Context,
+	repoPath string,
+	rev string,
+	treePath string,
+	opts types.ListTreeNodesOptions,
+) ([]types.TreeNode, bool, error) {
+	if repoPath == "" {
+		return nil, false, ErrRepositoryPathEmpty
+	}
+
+	for _, pattern := range opts.Patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, false, errors.InvalidArgument("invalid path pattern %q", pattern)
+		}
+	}
+
+	treePath = cleanTreePath(treePath)
+
+	args := []string{"ls-tree", "-z"}
+	if opts.Recursive {
+		args = append(args, "-r", "-t")
+	}
+	args = append(args, rev, "--")
+	if treePath != "" {
+		args = append(args, treePath+"/")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pipeRead, pipeWrite := io.Pipe()
+	stderr := &bytes.Buffer{}
+	go func() {
+		var err error
+
+		defer func() {
+			_ = pipeWrite.CloseWithError(err)
+		}()
+
+		err = gitea.NewCommand(ctx, args...).Run(&gitea.RunOpts{
+			Dir:    repoPath,
+			Stdout: pipeWrite,
+			Stderr: stderr,
+		})
+	}()
+
+	defer func() {
+		_ = pipeRead.Close()
+	}()
+
+	scanner := bufio.NewScanner(pipeRead)
+	scanner.Split(scanZeroSeparated)
+
+	var nodes []types.TreeNode
+	empty := true
+	afterFound := opts.After == ""
+
+	for scanner.Scan() {
+		empty = false
+
+		node, err := parseLsTreeLine(scanner.Text())
+		if err != nil {
+			return nil, false, err
+		}
+
+		// with -t the listed tree itself is part of the output.
+		if node.Path == treePath {
+			continue
+		}
+
+		if !afterFound {
+			afterFound = node.Path == opts.After
+			continue
+		}
+
+		if opts.MaxDepth > 0 && treeNodeDepth(treePath, node.Path) > opts.MaxDepth {
+			continue
+		}
+
+		if !matchTreeNode(node, opts.Patterns) {
+			continue
+		}
+
+		if opts.Limit > 0 && len(nodes) == opts.Limit {
+			return nodes, true, nil
+		}
+
+		nodes = append(nodes, node)
+	}
+
+	if err := scanner.Err(); err != nil {
+		if strings.Contains(stderr.String(), "Not a valid object name") {
+			return nil, false, errors.NotFound("revision %q not found", rev)
+		}
+		return nil, false, fmt.Errorf("failed to read git ls-tree output: %w (stderr: %s)", err, stderr.String())
+	}
+
+	if empty && treePath != "" {
+		return nil, false, &types.PathNotFoundError{Path: treePath}
+	}
+
+	if !afterFound {
+		return nil, false, errors.InvalidArgument("the path %q to continue the listing after is not in the tree", opts.After)
+	}
+
+	return nodes, false, nil
+}
+
+// treeNodeDepth returns the depth of the node relative to the tree path, direct children of the tree have depth 1.
+func treeNodeDepth(treePath, nodePath string) int {
+	if treePath != "" {
+		nodePath = strings.TrimPrefix(nodePath, treePath+"/")
+	}
+
+	return strings.Count(nodePath, "/") + 1
+}
+
+// matchTreeNode returns true if the node matches any of the patterns or if there are no patterns.
+func matchTreeNode(node types.TreeNode, patterns []string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+
+	for _, pattern := range patterns {
+		name := node.Path
+		if !strings.Contains(pattern, "/") {
+			name = node.Name
+		}
+
+		// patterns are validated upfront, so the error can be ignored.
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+
+	return false
+}
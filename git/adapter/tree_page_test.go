@@ -0,0 +1,126 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/git/types"
+)
+
+func TestAdapter_ListTreeNodesPage(t *testing.T) {
+	git := setupGit(t)
+	repo, teardown := setupRepo(t, git, "testlisttreenodespage")
+	defer teardown()
+
+	var parents []string
+	for _, file := range []string{"readme.md", "src/a.go", "src/lib/b.go", "src/lib/c.txt", "docs/d.md"} {
+		sha := writeFile(t, repo, file, "content of "+file, parents)
+		parents = []string{sha.String()}
+	}
+
+	ctx := context.Background()
+
+	list := func(t *testing.T, treePath string, opts types.ListTreeNodesOptions) ([]string, bool) {
+		nodes, hasMore, err := git.ListTreeNodesPage(ctx, repo.Path, parents[0], treePath, opts)
+		if err != nil {
+			t.Fatalf("failed to list tree nodes: %v", err)
+		}
+
+		paths := make([]string, len(nodes))
+		for i := range nodes {
+			paths[i] = nodes[i].Path
+		}
+
+		return paths, hasMore
+	}
+
+	tests := []struct {
+		name     string
+		treePath string
+		opts     types.ListTreeNodesOptions
+		want     []string
+		wantMore bool
+	}{
+		{
+			name: "root",
+			want: []string{"docs", "readme.md", "src"},
+		},
+		{
+			name: "recursive",
+			opts: types.ListTreeNodesOptions{Recursive: true},
+			want: []string{
+				"docs", "docs/d.md", "readme.md", "src", "src/a.go", "src/lib", "src/lib/b.go", "src/lib/c.txt",
+			},
+		},
+		{
+			name:     "recursive subtree with max depth",
+			treePath: "/src/",
+			opts:     types.ListTreeNodesOptions{Recursive: true, MaxDepth: 1},
+			want:     []string{"src/a.go", "src/lib"},
+		},
+		{
+			name: "recursive with patterns",
+			opts: types.ListTreeNodesOptions{Recursive: true, Patterns: []string{"*.go", "docs/*"}},
+			want: []string{"docs/d.md", "src/a.go", "src/lib/b.go"},
+		},
+		{
+			name:     "first page",
+			opts:     types.ListTreeNodesOptions{Recursive: true, Limit: 3},
+			want:     []string{"docs", "docs/d.md", "readme.md"},
+			wantMore: true,
+		},
+		{
+			name:     "middle page",
+			opts:     types.ListTreeNodesOptions{Recursive: true, After: "readme.md", Limit: 3},
+			want:     []string{"src", "src/a.go", "src/lib"},
+			wantMore: true,
+		},
+		{
+			name: "last page",
+			opts: types.ListTreeNodesOptions{Recursive: true, After: "src/lib", Limit: 3},
+			want: []string{"src/lib/b.go", "src/lib/c.txt"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, gotMore := list(t, test.treePath, test.opts)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("expected nodes %v, got %v", test.want, got)
+			}
+			if gotMore != test.wantMore {
+				t.Errorf("expected has more to be %t, got %t", test.wantMore, gotMore)
+			}
+		})
+	}
+
+	t.Run("unknown path", func(t *testing.T) {
+		_, _, err := git.ListTreeNodesPage(ctx, repo.Path, parents[0], "unknown", types.ListTreeNodesOptions{})
+		if !types.IsPathNotFoundError(err) {
+			t.Errorf("expected path not found error, got %v", err)
+		}
+	})
+
+	t.Run("unknown after path", func(t *testing.T) {
+		_, _, err := git.ListTreeNodesPage(ctx, repo.Path, parents[0], "",
+			types.ListTreeNodesOptions{After: "unknown"})
+		if err == nil {
+			t.Errorf("expected an error for an unknown after path")
+		}
+	})
+}
@@ -0,0 +1,92 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/enum"
+)
+
+type ArchiveParams struct {
+	ReadParams
+	// GitRef is the branch, tag or commit sha the archive is created from.
+	GitRef string
+	Format enum.ArchiveFormat
+	// Prefix is prepended to the paths of the files in the archive, e.g. "repo-main/".
+	Prefix string
+	// MaxSize is the maximum total size (in bytes) of the files in the archive, zero means no limit.
+	MaxSize int64
+}
+
+func (p *ArchiveParams) Validate() error {
+	if err := p.ReadParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.GitRef == "" || strings.HasPrefix(p.GitRef, "-") {
+		return errors.InvalidArgument("a valid git reference must be provided")
+	}
+
+	switch p.Format {
+	case enum.ArchiveFormatZip, enum.ArchiveFormatTarGz:
+	default:
+		return errors.InvalidArgument("unsupported archive format %q", p.Format)
+	}
+
+	return nil
+}
+
+// Archive writes an archive of the tree at the git reference to the provided writer.
+// The size limit is checked before anything is written.
+func (s *Service) Archive(
+	ctx context.Context,
+	params *ArchiveParams,
+	w io.Writer,
+) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	commit, err := s.adapter.GetCommit(ctx, repoPath, params.GitRef)
+	if err != nil {
+		return fmt.Errorf("Archive: failed to get commit: %w", err)
+	}
+
+	if params.MaxSize > 0 {
+		size, err := s.adapter.TreeSize(ctx, repoPath, commit.SHA)
+		if err != nil {
+			return fmt.Errorf("Archive: failed to get tree size: %w", err)
+		}
+
+		if size > params.MaxSize {
+			return errors.PreconditionFailed(
+				"the files at %q have %d bytes, exceeding the archive size limit of %d bytes",
+				params.GitRef, size, params.MaxSize)
+		}
+	}
+
+	if err = s.adapter.Archive(ctx, repoPath, commit.SHA, params.Format, params.Prefix, w); err != nil {
+		return fmt.Errorf("Archive: failed to create archive: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/types"
+)
+
+type FindBinaryBlobsParams struct {
+	ReadParams
+	// Revs are the revisions (e.g. the new commits of a push) whose new blobs are inspected.
+	// Blobs that are reachable from any existing reference of the repository are ignored.
+	Revs []string
+	// AlternateObjectDirs are additional object directories required to access all objects,
+	// e.g. the quarantine directory of a push that's in progress.
+	AlternateObjectDirs []string
+}
+
+func (p *FindBinaryBlobsParams) Validate() error {
+	if err := p.ReadParams.Validate(); err != nil {
+		return err
+	}
+
+	for _, rev := range p.Revs {
+		if !isValidGitSHA(rev) {
+			return errors.InvalidArgument("the revision %q isn't a valid commit sha", rev)
+		}
+	}
+
+	return nil
+}
+
+type FindBinaryBlobsOutput struct {
+	Blobs []types.BinaryBlob
+}
+
+// FindBinaryBlobs returns the binary blobs introduced by the provided revisions.
+func (s *Service) FindBinaryBlobs(
+	ctx context.Context,
+	params *FindBinaryBlobsParams,
+) (*FindBinaryBlobsOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	blobs, err := s.adapter.FindBinaryBlobs(ctx, repoPath, params.AlternateObjectDirs, params.Revs)
+	if err != nil {
+		return nil, fmt.Errorf("FindBinaryBlobs: failed to find binary blobs: %w", err)
+	}
+
+	return &FindBinaryBlobsOutput{
+		Blobs: blobs,
+	}, nil
+}
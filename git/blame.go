@@ -34,6 +34,11 @@ type BlameParams struct {
 	// LineTo allows to restrict the blame output to only lines up to the provided line number (inclusive).
 	// Optional, ignored if value is 0.
 	LineTo int
+
+	// IgnoreRevs contains commit SHAs that blame should skip over when assigning lines,
+	// typically loaded from a .git-blame-ignore-revs file.
+	// Optional.
+	IgnoreRevs []string
 }
 
 func (params *BlameParams) Validate() error {
@@ -61,12 +66,19 @@ func (params *BlameParams) Validate() error {
 		return errors.InvalidArgument("line from can't be after line after")
 	}
 
+	for _, ignoreRev := range params.IgnoreRevs {
+		if !ValidateCommitSHA(ignoreRev) {
+			return errors.InvalidArgument("ignore revs must contain only commit SHAs")
+		}
+	}
+
 	return nil
 }
 
 type BlamePart struct {
-	Commit *Commit  `json:"commit"`
-	Lines  []string `json:"lines"`
+	Commit   *Commit  `json:"commit"`
+	LineFrom int      `json:"line_from"`
+	Lines    []string `json:"lines"`
 }
 
 // Blame processes and streams the git blame output data.
@@ -90,7 +102,7 @@ func (s *Service) Blame(ctx context.Context, params *BlameParams) (<-chan *Blame
 
 		reader := s.adapter.Blame(ctx,
 			repoPath, params.GitRef, params.Path,
-			params.LineFrom, params.LineTo)
+			params.LineFrom, params.LineTo, params.IgnoreRevs)
 
 		for {
 			part, errRead := reader.NextPart()
@@ -108,7 +120,7 @@ func (s *Service) Blame(ctx context.Context, params *BlameParams) (<-chan *Blame
 			lines := make([]string, len(part.Lines))
 			copy(lines, part.Lines)
 
-			ch <- &BlamePart{Commit: commit, Lines: lines}
+			ch <- &BlamePart{Commit: commit, LineFrom: part.LineFrom, Lines: lines}
 
 			if errRead != nil && errors.Is(errRead, io.EOF) {
 				return
@@ -15,14 +15,26 @@
 package git
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"io"
+	"net/http"
+
+	"github.com/harness/gitness/errors"
 )
 
+// contentSniffLen is the number of bytes inspected to detect the content type of a blob.
+// It's the same number of bytes git inspects to determine whether a blob is binary.
+const contentSniffLen = 8000
+
 type GetBlobParams struct {
 	ReadParams
 	SHA       string
 	SizeLimit int64
+	// Offset is the number of bytes at the start of the blob that are skipped.
+	// If provided, the SizeLimit is applied to the content after the offset.
+	Offset int64
 }
 
 type GetBlobOutput struct {
@@ -31,6 +43,10 @@ type GetBlobOutput struct {
 	Size int64
 	// ContentSize is the total number of bytes returned by the Content Reader.
 	ContentSize int64
+	// ContentType is the media type of the blob detected from the start of its content.
+	ContentType string
+	// IsBinary is true if the blob is binary, using the same heuristic as git (a NUL byte at the start).
+	IsBinary bool
 	// Content contains the (partial) content of the blob.
 	Content io.ReadCloser
 }
@@ -39,19 +55,61 @@ func (s *Service) GetBlob(ctx context.Context, params *GetBlobParams) (*GetBlobO
 	if params == nil {
 		return nil, ErrNoParamsProvided
 	}
+	if params.Offset < 0 {
+		return nil, errors.InvalidArgument("offset can't be negative")
+	}
 
 	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
 
+	sizeLimit := params.SizeLimit
+	if sizeLimit > 0 {
+		sizeLimit += params.Offset
+	}
+
 	// TODO: do we need to validate request for nil?
-	reader, err := s.adapter.GetBlob(ctx, repoPath, params.SHA, params.SizeLimit)
+	reader, err := s.adapter.GetBlob(ctx, repoPath, params.SHA, sizeLimit)
 	if err != nil {
 		return nil, err
 	}
 
+	if params.Offset > reader.Size {
+		_ = reader.Content.Close()
+		return nil, errors.InvalidArgument("offset %d is larger than the blob size %d", params.Offset, reader.Size)
+	}
+
+	// the start of the content is read to detect the content type, and then served again from the buffer.
+	headSize := reader.ContentSize
+	if headSize > contentSniffLen {
+		headSize = contentSniffLen
+	}
+
+	head := make([]byte, headSize)
+	if _, err = io.ReadFull(reader.Content, head); err != nil {
+		_ = reader.Content.Close()
+		return nil, fmt.Errorf("failed to read start of blob content: %w", err)
+	}
+
+	content := readCloser{
+		Reader: io.MultiReader(bytes.NewReader(head), reader.Content),
+		Closer: reader.Content,
+	}
+
+	if _, err = io.CopyN(io.Discard, content, params.Offset); err != nil {
+		_ = content.Close()
+		return nil, fmt.Errorf("failed to skip blob content up to the offset: %w", err)
+	}
+
 	return &GetBlobOutput{
 		SHA:         reader.SHA,
 		Size:        reader.Size,
-		ContentSize: reader.ContentSize,
-		Content:     reader.Content,
+		ContentSize: reader.ContentSize - params.Offset,
+		ContentType: http.DetectContentType(head),
+		IsBinary:    bytes.IndexByte(head, 0) >= 0,
+		Content:     content,
 	}, nil
 }
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,68 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/types"
+)
+
+type GetBlobStatsParams struct {
+	ReadParams
+	// LargestLimit is the maximum number of the largest blobs that are returned.
+	LargestLimit int
+}
+
+func (p *GetBlobStatsParams) Validate() error {
+	if p == nil {
+		return ErrNoParamsProvided
+	}
+
+	if err := p.ReadParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.LargestLimit < 0 {
+		return errors.InvalidArgument("limit of the largest blobs can't be negative")
+	}
+
+	return nil
+}
+
+type GetBlobStatsOutput struct {
+	types.BlobStats
+}
+
+// GetBlobStats returns statistics about the blobs reachable from the references of the repository,
+// including the largest blobs and the usage of git LFS.
+func (s *Service) GetBlobStats(ctx context.Context, params *GetBlobStatsParams) (*GetBlobStatsOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	stats, err := s.adapter.GetBlobStats(ctx, repoPath, params.LargestLimit)
+	if err != nil {
+		return nil, fmt.Errorf("GetBlobStats: failed to get blob stats: %w", err)
+	}
+
+	return &GetBlobStatsOutput{
+		BlobStats: stats,
+	}, nil
+}
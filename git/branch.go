@@ -292,3 +292,62 @@ func listBranchesWalkReferencesHandler(
 		return nil
 	}
 }
+
+type GetBranchDivergencesParams struct {
+	ReadParams
+	// BaseRef is the reference against which the divergences of the branches are calculated.
+	BaseRef  string
+	Branches []string
+}
+
+type GetBranchDivergencesOutput struct {
+	Divergences []BranchDivergence
+}
+
+// BranchDivergence contains the count of diverging commits between a branch and the base reference.
+type BranchDivergence struct {
+	Branch string
+	// Ahead is the count of commits the branch is ahead of the base reference.
+	Ahead int32
+	// Behind is the count of commits the branch is behind the base reference.
+	Behind int32
+}
+
+// GetBranchDivergences returns the ahead/behind counts of the provided branches relative to the base reference.
+func (s *Service) GetBranchDivergences(
+	ctx context.Context,
+	params *GetBranchDivergencesParams,
+) (*GetBranchDivergencesOutput, error) {
+	if params == nil {
+		return nil, ErrNoParamsProvided
+	}
+
+	if params.BaseRef == "" {
+		return nil, errors.InvalidArgument("base reference needs to be provided")
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	branches := make([]string, len(params.Branches))
+	for i, branch := range params.Branches {
+		branches[i] = strings.TrimPrefix(branch, gitReferenceNamePrefixBranch)
+	}
+
+	divergences, err := s.adapter.GetBranchDivergences(ctx, repoPath, params.BaseRef, branches)
+	if err != nil {
+		return nil, err
+	}
+
+	output := make([]BranchDivergence, len(divergences))
+	for i := range divergences {
+		output[i] = BranchDivergence{
+			Branch: branches[i],
+			Ahead:  divergences[i].Ahead,
+			Behind: divergences[i].Behind,
+		}
+	}
+
+	return &GetBranchDivergencesOutput{
+		Divergences: output,
+	}, nil
+}
@@ -0,0 +1,126 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/harness/gitness/errors"
+)
+
+type CreateBundleParams struct {
+	ReadParams
+	// Since (optional) makes the bundle incremental - it only contains the changes since the commit.
+	Since string
+}
+
+// CreateBundle writes a git bundle with all references of the repository to the provided writer.
+// The bundle can be used as a source of SyncRepository to recreate the repository elsewhere,
+// or, if it's incremental, applied to a copy of the repository using ApplyBundle.
+func (s *Service) CreateBundle(
+	ctx context.Context,
+	params *CreateBundleParams,
+	w io.Writer,
+) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	isEmpty, err := s.adapter.HasBranches(ctx, repoPath)
+	if err != nil {
+		return fmt.Errorf("CreateBundle: failed to check if repository is empty: %w", err)
+	}
+	if isEmpty {
+		return errors.PreconditionFailed("can't create a bundle of an empty repository")
+	}
+
+	var since string
+	if params.Since != "" {
+		since, err = s.adapter.ResolveRev(ctx, repoPath, params.Since+"^{commit}")
+		if err != nil {
+			return fmt.Errorf("CreateBundle: failed to resolve commit '%s': %w", params.Since, err)
+		}
+	}
+
+	if err = s.adapter.CreateBundle(ctx, repoPath, since, w); err != nil {
+		return fmt.Errorf("CreateBundle: failed to create bundle: %w", err)
+	}
+
+	return nil
+}
+
+type ApplyBundleParams struct {
+	WriteParams
+	// BundlePath is the path of the git bundle file.
+	BundlePath string
+	// Prune deletes the branches and tags of the repository that aren't part of the bundle.
+	// It should only be used for bundles with all references of a repository (not incremental ones).
+	Prune bool
+}
+
+func (p *ApplyBundleParams) Validate() error {
+	if p == nil {
+		return ErrNoParamsProvided
+	}
+
+	if err := p.WriteParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.BundlePath == "" {
+		return errors.InvalidArgument("bundle path is mandatory")
+	}
+
+	return nil
+}
+
+// ApplyBundle updates the branches and tags of the repository with the ones of a git bundle.
+// The references are pushed to the repository, so the git hooks run like for any other push.
+func (s *Service) ApplyBundle(
+	ctx context.Context,
+	params *ApplyBundleParams,
+) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	sharedRepo, err := s.adapter.SharedRepository(s.tmpDir, params.RepoUID, repoPath)
+	if err != nil {
+		return fmt.Errorf("ApplyBundle: failed to create new shared repo: %w", err)
+	}
+
+	defer sharedRepo.Close(ctx)
+
+	if err = sharedRepo.Clone(ctx, ""); err != nil {
+		return fmt.Errorf("ApplyBundle: failed to clone shared repo: %w", err)
+	}
+
+	if err = sharedRepo.FetchBundle(ctx, params.BundlePath, params.Prune); err != nil {
+		return fmt.Errorf("ApplyBundle: %w", err)
+	}
+
+	envs := CreateEnvironmentForPush(ctx, params.WriteParams)
+	if err = sharedRepo.PushAll(ctx, params.Prune, envs...); err != nil {
+		return fmt.Errorf("ApplyBundle: failed to push the bundle references: %w", err)
+	}
+
+	return nil
+}
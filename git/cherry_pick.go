@@ -0,0 +1,247 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/check"
+	"github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/types"
+)
+
+// CherryPickParams is input structure object for cherry-picking a merged pull request.
+type CherryPickParams struct {
+	WriteParams
+
+	// TargetBranch is the branch on top of which the cherry-picked changes are committed.
+	TargetBranch string
+	// CherryPickBranch is the name of the new branch that will point to the cherry-pick commit.
+	CherryPickBranch string
+
+	// Method is the method that was used to merge the changes that are cherry-picked.
+	Method enum.MergeMethod
+	// MergeSHA is the commit created by merging the changes (the last commit for the rebase method).
+	MergeSHA string
+	// MergeTargetSHA is the commit of the base branch the changes were merged on top of.
+	MergeTargetSHA string
+
+	Title   string
+	Message string
+
+	// Committer overwrites the git committer used for committing the cherry-pick
+	// (optional, default: actor)
+	Committer *Identity
+	// CommitterDate overwrites the git committer date used for committing the cherry-pick
+	// (optional, default: current time on server)
+	CommitterDate *time.Time
+	// Author overwrites the git author used for committing the cherry-pick
+	// (optional, default: committer)
+	Author *Identity
+	// AuthorDate overwrites the git author date used for committing the cherry-pick
+	// (optional, default: committer date)
+	AuthorDate *time.Time
+
+	// SigningKey overwrites the key used for signing the cherry-pick commit
+	// (optional, default: the server signing key, if configured)
+	SigningKey *SigningKey
+}
+
+func (p *CherryPickParams) Validate() error {
+	if err := p.WriteParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.TargetBranch == "" {
+		return errors.InvalidArgument("target branch is mandatory")
+	}
+
+	if p.CherryPickBranch == "" {
+		return errors.InvalidArgument("cherry-pick branch is mandatory")
+	}
+
+	if err := check.BranchName(p.CherryPickBranch); err != nil {
+		return errors.InvalidArgument(err.Error())
+	}
+
+	if !ValidateCommitSHA(p.MergeSHA) {
+		return errors.InvalidArgument("merge SHA is not a valid commit SHA")
+	}
+
+	if p.Method == enum.MergeMethodRebase && !ValidateCommitSHA(p.MergeTargetSHA) {
+		return errors.InvalidArgument("merge target SHA is not a valid commit SHA")
+	}
+
+	return nil
+}
+
+// CherryPickOutput is result object of the cherry-pick operation.
+type CherryPickOutput struct {
+	// TargetSHA is the sha of the latest commit on the target branch that was used for cherry-picking.
+	TargetSHA string
+	// CherryPickSHA is the sha of the cherry-pick commit.
+	CherryPickSHA string
+}
+
+// CherryPick creates a new branch from the target branch with a single commit that applies the merged changes.
+// The commits that are cherry-picked depend on the method that was used to merge them (see applyMergedChanges).
+// If the changes can't be applied cleanly, an error with the precondition failed status is returned
+// and the list of conflicting files is provided in its details.
+func (s *Service) CherryPick(ctx context.Context, params *CherryPickParams) (CherryPickOutput, error) {
+	if err := params.Validate(); err != nil {
+		return CherryPickOutput{}, fmt.Errorf("CherryPick: params not valid: %w", err)
+	}
+
+	out, err := s.applyMergedChanges(ctx, mergedChangesParams{
+		WriteParams:    params.WriteParams,
+		operation:      "cherry-pick",
+		apply:          s.adapter.CherryPick,
+		baseBranch:     params.TargetBranch,
+		newBranch:      params.CherryPickBranch,
+		method:         params.Method,
+		mergeSHA:       params.MergeSHA,
+		mergeTargetSHA: params.MergeTargetSHA,
+		title:          params.Title,
+		message:        params.Message,
+		committer:      params.Committer,
+		committerDate:  params.CommitterDate,
+		author:         params.Author,
+		authorDate:     params.AuthorDate,
+		signingKey:     params.SigningKey,
+	})
+	if err != nil {
+		return CherryPickOutput{}, fmt.Errorf("CherryPick: %w", err)
+	}
+
+	return CherryPickOutput{
+		TargetSHA:     out.baseSHA,
+		CherryPickSHA: out.newSHA,
+	}, nil
+}
+
+// CherryPickCommitsParams is input structure object for cherry-picking commits onto a branch.
+type CherryPickCommitsParams struct {
+	WriteParams
+
+	// Branch is the branch on top of which the commits are cherry-picked.
+	Branch string
+	// NewBranch is the name of the new branch that will point to the last cherry-picked commit.
+	// (optional, if not provided the Branch is updated)
+	NewBranch string
+
+	// Commits are the SHAs of the commits that are cherry-picked, in the order they are applied.
+	Commits []string
+	// Mainline is the parent number used as the base of merge commits that are cherry-picked.
+	// (optional, required only if any of the commits is a merge commit)
+	Mainline int
+
+	// Committer overwrites the git committer used for committing the cherry-picked commits
+	// (optional, default: actor)
+	Committer *Identity
+	// CommitterDate overwrites the git committer date used for committing the cherry-picked commits
+	// (optional, default: current time on server)
+	CommitterDate *time.Time
+
+	// SigningKey overwrites the key used for signing the cherry-picked commits
+	// (optional, default: the server signing key, if configured)
+	SigningKey *SigningKey
+}
+
+func (p *CherryPickCommitsParams) Validate() error {
+	if err := p.WriteParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.Branch == "" {
+		return errors.InvalidArgument("branch is mandatory")
+	}
+
+	if p.NewBranch != "" {
+		if err := check.BranchName(p.NewBranch); err != nil {
+			return errors.InvalidArgument(err.Error())
+		}
+	}
+
+	if len(p.Commits) == 0 {
+		return errors.InvalidArgument("at least one commit must be provided")
+	}
+
+	for _, commit := range p.Commits {
+		if !ValidateCommitSHA(commit) {
+			return errors.InvalidArgument("%q is not a valid commit SHA", commit)
+		}
+	}
+
+	if p.Mainline < 0 {
+		return errors.InvalidArgument("mainline can't be negative")
+	}
+
+	return nil
+}
+
+// CherryPickCommitsOutput is result object of the commit cherry-pick operation.
+type CherryPickCommitsOutput struct {
+	// BranchSHA is the sha of the latest commit on the branch that was used for cherry-picking.
+	BranchSHA string
+	// CommitSHA is the sha of the last cherry-picked commit.
+	CommitSHA string
+}
+
+// CherryPickCommits applies the changes of each of the provided commits on top of the branch, creating
+// one new commit per cherry-picked commit that keeps the original author and message. The result is pushed
+// to the branch, or to the new branch if one is provided.
+// If any of the commits can't be applied cleanly, an error with the precondition failed status is returned
+// and the conflicting commit and the list of conflicting files are provided in its details.
+func (s *Service) CherryPickCommits(
+	ctx context.Context,
+	params *CherryPickCommitsParams,
+) (CherryPickCommitsOutput, error) {
+	if err := params.Validate(); err != nil {
+		return CherryPickCommitsOutput{}, fmt.Errorf("CherryPickCommits: params not valid: %w", err)
+	}
+
+	out, err := s.applyCommitChanges(ctx, commitChangesParams{
+		WriteParams: params.WriteParams,
+		operation:   "cherry-pick",
+		apply:       s.adapter.CherryPick,
+		branch:      params.Branch,
+		newBranch:   params.NewBranch,
+		commits:     params.Commits,
+		mainline:    params.Mainline,
+		commitInfo: func(commit *types.Commit) (string, Identity, time.Time) {
+			message := commit.Title
+			if commit.Message != "" {
+				message += "\n\n" + commit.Message
+			}
+			message += "\n\n(cherry picked from commit " + commit.SHA + ")"
+			author := Identity{Name: commit.Author.Identity.Name, Email: commit.Author.Identity.Email}
+			return message, author, commit.Author.When
+		},
+		committer:     params.Committer,
+		committerDate: params.CommitterDate,
+		signingKey:    params.SigningKey,
+	})
+	if err != nil {
+		return CherryPickCommitsOutput{}, fmt.Errorf("CherryPickCommits: %w", err)
+	}
+
+	return CherryPickCommitsOutput{
+		BranchSHA: out.baseSHA,
+		CommitSHA: out.newSHA,
+	}, nil
+}
@@ -35,6 +35,15 @@ type Commit struct {
 	Message   string    `json:"message,omitempty"`
 	Author    Signature `json:"author"`
 	Committer Signature `json:"committer"`
+
+	// Signature is the cryptographic signature of the commit (nil if the commit isn't signed).
+	Signature *CommitSignature `json:"signature,omitempty"`
+}
+
+// CommitSignature holds the signature of a commit and the signed payload.
+type CommitSignature struct {
+	Signature string `json:"signature"`
+	Payload   string `json:"payload"`
 }
 
 type GetCommitOutput struct {
@@ -177,6 +186,75 @@ func (s *Service) ListCommits(ctx context.Context, params *ListCommitsParams) (*
 	}, nil
 }
 
+type WalkCommitsParams struct {
+	ReadParams
+	// GitREF is a git reference (branch / tag / commit SHA) the commit history is walked from.
+	GitREF string
+	// ExcludeSHA is an optional commit SHA, commits reachable from it are skipped.
+	ExcludeSHA string
+}
+
+func (params *WalkCommitsParams) Validate() error {
+	if params == nil {
+		return ErrNoParamsProvided
+	}
+
+	if err := params.ReadParams.Validate(); err != nil {
+		return err
+	}
+
+	if params.GitREF == "" {
+		return errors.InvalidArgument("git ref needs to be provided")
+	}
+
+	if params.ExcludeSHA != "" && !ValidateCommitSHA(params.ExcludeSHA) {
+		return errors.InvalidArgument("the excluded commit must be a commit SHA")
+	}
+
+	return nil
+}
+
+// WalkCommits streams all commits reachable from the git reference, newest first.
+// The function returns two channels: The data channel and the error channel.
+// If any error happens during the operation it will be put to the error channel
+// and the streaming will stop. Maximum of one error can be put on the channel.
+func (s *Service) WalkCommits(ctx context.Context, params *WalkCommitsParams) (<-chan *Commit, <-chan error) {
+	ch := make(chan *Commit)
+	chErr := make(chan error, 1)
+
+	go func() {
+		defer close(ch)
+		defer close(chErr)
+
+		if err := params.Validate(); err != nil {
+			chErr <- err
+			return
+		}
+
+		repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+		err := s.adapter.WalkCommits(ctx, repoPath, params.GitREF, params.ExcludeSHA,
+			func(c *types.Commit) error {
+				commit, err := mapCommit(c)
+				if err != nil {
+					return fmt.Errorf("failed to map rpc commit: %w", err)
+				}
+
+				select {
+				case ch <- commit:
+					return nil
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			})
+		if err != nil {
+			chErr <- err
+		}
+	}()
+
+	return ch, chErr
+}
+
 type GetCommitDivergencesParams struct {
 	ReadParams
 	MaxCount int32
@@ -0,0 +1,128 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/errors"
+)
+
+type CompareParams struct {
+	ReadParams
+	BaseRef string
+	HeadRef string
+	// MergeBase selects the three-dot semantics, the changes are compared against
+	// the merge base of the base and the head ref instead of against the base ref itself.
+	MergeBase bool
+
+	// Page and Limit are used for paginating the list of the compared commits.
+	Page  int32
+	Limit int32
+}
+
+func (p *CompareParams) Validate() error {
+	if p == nil {
+		return ErrNoParamsProvided
+	}
+
+	if err := p.ReadParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.BaseRef == "" || p.HeadRef == "" {
+		return errors.InvalidArgument("both base and head refs must be provided")
+	}
+
+	return nil
+}
+
+type CompareOutput struct {
+	BaseSHA string
+	HeadSHA string
+	// MergeBaseSHA is the merge base of the base and the head commit.
+	// It's empty if the commits have no common ancestor (possible only with the two-dot semantics).
+	MergeBaseSHA string
+
+	// Commits are the commits reachable from the head ref that aren't reachable from the base ref
+	// (the same for both the two-dot and the three-dot semantics).
+	Commits      []Commit
+	TotalCommits int
+
+	// Stats contain the summary of the diff between the base (or the merge base) and the head commit.
+	Stats DiffShortStatOutput
+}
+
+// Compare compares two revisions of the repository and returns
+// the commits between them and the summary of their diff.
+func (s *Service) Compare(ctx context.Context, params *CompareParams) (*CompareOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	baseSHA, err := s.adapter.ResolveRev(ctx, repoPath, params.BaseRef+"^{commit}")
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve base ref: %w", err)
+	}
+
+	headSHA, err := s.adapter.ResolveRev(ctx, repoPath, params.HeadRef+"^{commit}")
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve head ref: %w", err)
+	}
+
+	mergeBaseSHA, _, err := s.adapter.GetMergeBase(ctx, repoPath, "", baseSHA, headSHA)
+	if err != nil {
+		// git merge-base fails if the commits have no common ancestor,
+		// which is relevant only if the merge base is used for the diff.
+		if params.MergeBase {
+			return nil, errors.InvalidArgument("failed to find merge base of %q and %q: %s",
+				params.BaseRef, params.HeadRef, err)
+		}
+		mergeBaseSHA = ""
+	}
+
+	commits, err := s.ListCommits(ctx, &ListCommitsParams{
+		ReadParams: params.ReadParams,
+		GitREF:     headSHA,
+		After:      baseSHA,
+		Page:       params.Page,
+		Limit:      params.Limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list compared commits: %w", err)
+	}
+
+	stats, err := s.DiffShortStat(ctx, &DiffParams{
+		ReadParams: params.ReadParams,
+		BaseRef:    baseSHA,
+		HeadRef:    headSHA,
+		MergeBase:  params.MergeBase,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get diff stats: %w", err)
+	}
+
+	return &CompareOutput{
+		BaseSHA:      baseSHA,
+		HeadSHA:      headSHA,
+		MergeBaseSHA: mergeBaseSHA,
+		Commits:      commits.Commits,
+		TotalCommits: commits.TotalCommits,
+		Stats:        stats,
+	}, nil
+}
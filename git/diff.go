@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"io"
 	"sync"
+	"sync/atomic"
 
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git/diff"
@@ -35,6 +36,32 @@ type DiffParams struct {
 	HeadRef      string
 	MergeBase    bool
 	IncludePatch bool
+
+	// Paths limits the diff to the provided file paths (optional).
+	Paths []string
+
+	// SkipFiles and MaxFiles are used for paginating the list of changed files (optional).
+	SkipFiles int
+	MaxFiles  int
+
+	// Limits restricts the size of the returned patches (optional).
+	Limits DiffLimits
+}
+
+// DiffLimits restricts the size of the patches returned by Diff. Zero values mean no limit.
+// Files that exceed any of the limits are returned without the patch and with IsTooLarge set.
+type DiffLimits struct {
+	// MaxFiles limits the number of files that are returned with the patch.
+	MaxFiles int
+
+	// MaxFileLines and MaxFileBytes limit the size of the patch of a single file.
+	MaxFileLines int
+	MaxFileBytes int
+
+	// MaxLines and MaxBytes limit the combined size of all returned patches.
+	// Once reached, the patches of the remaining files are omitted.
+	MaxLines int
+	MaxBytes int
 }
 
 func (p DiffParams) Validate() error {
@@ -59,7 +86,7 @@ func (s *Service) rawDiff(ctx context.Context, params *DiffParams, w io.Writer)
 
 	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
 
-	err := s.adapter.RawDiff(ctx, repoPath, params.BaseRef, params.HeadRef, params.MergeBase, w)
+	err := s.adapter.RawDiff(ctx, repoPath, params.BaseRef, params.HeadRef, params.MergeBase, w, params.Paths...)
 	if err != nil {
 		return err
 	}
@@ -95,6 +122,7 @@ func (s *Service) DiffShortStat(ctx context.Context, params *DiffParams) (DiffSh
 		params.BaseRef,
 		params.HeadRef,
 		params.MergeBase,
+		params.Paths...,
 	)
 	if err != nil {
 		return DiffShortStatOutput{}, err
@@ -109,6 +137,8 @@ func (s *Service) DiffShortStat(ctx context.Context, params *DiffParams) (DiffSh
 type DiffStatsOutput struct {
 	Commits      int
 	FilesChanged int
+	Additions    int
+	Deletions    int
 }
 
 func (s *Service) DiffStats(ctx context.Context, params *DiffParams) (DiffStatsOutput, error) {
@@ -116,8 +146,10 @@ func (s *Service) DiffStats(ctx context.Context, params *DiffParams) (DiffStatsO
 	// no need for atomic operations because writing and reading variable
 	// doesn't happen at the same time
 	var (
-		totalCommits int
-		totalFiles   int
+		totalCommits   int
+		totalFiles     int
+		totalAdditions int
+		totalDeletions int
 	)
 
 	errGroup, groupCtx := errgroup.WithContext(ctx)
@@ -157,6 +189,8 @@ func (s *Service) DiffStats(ctx context.Context, params *DiffParams) (DiffStatsO
 			return err
 		}
 		totalFiles = stat.Files
+		totalAdditions = stat.Additions
+		totalDeletions = stat.Deletions
 		return nil
 	})
 
@@ -168,6 +202,8 @@ func (s *Service) DiffStats(ctx context.Context, params *DiffParams) (DiffStatsO
 	return DiffStatsOutput{
 		Commits:      totalCommits,
 		FilesChanged: totalFiles,
+		Additions:    totalAdditions,
+		Deletions:    totalDeletions,
 	}, nil
 }
 
@@ -309,6 +345,10 @@ type FileDiff struct {
 	Patch       []byte         `json:"patch,omitempty"`
 	IsBinary    bool           `json:"is_binary"`
 	IsSubmodule bool           `json:"is_submodule"`
+
+	// IsTooLarge is set when the patch is omitted because the diff exceeds the server limits.
+	// The patch of the file can be fetched separately.
+	IsTooLarge bool `json:"is_too_large"`
 }
 
 type FileDiffStatus string
@@ -339,7 +379,7 @@ func parseFileDiffStatus(ftype diff.FileType) FileDiffStatus {
 	}
 }
 
-//nolint:gocognit
+//nolint:gocognit,funlen
 func (s *Service) Diff(
 	ctx context.Context,
 	params *DiffParams,
@@ -350,6 +390,9 @@ func (s *Service) Diff(
 
 	pr, pw := io.Pipe()
 
+	// stopped is set when the parser stops reading before the end of the diff (all requested files are read).
+	stopped := atomic.Bool{}
+
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
@@ -361,7 +404,7 @@ func (s *Service) Diff(
 		}
 
 		err := s.rawDiff(ctx, params, pw)
-		if err != nil {
+		if err != nil && !stopped.Load() {
 			cherr <- err
 			return
 		}
@@ -372,36 +415,13 @@ func (s *Service) Diff(
 		defer wg.Done()
 		defer pr.Close()
 
-		parser := diff.Parser{
-			Reader: bufio.NewReader(pr),
-		}
-
-		err := parser.Parse(func(f *diff.File) error {
-			patch := bytes.Buffer{}
-			if params.IncludePatch {
-				for _, sec := range f.Sections {
-					for _, line := range sec.Lines {
-						if line.Type != diff.DiffLinePlain {
-							patch.WriteString(line.Content)
-						}
-					}
-				}
-			}
-			ch <- &FileDiff{
-				SHA:         f.SHA,
-				OldSHA:      f.OldSHA,
-				Path:        f.Path,
-				OldPath:     f.OldPath,
-				Status:      parseFileDiffStatus(f.Type),
-				Additions:   int64(f.NumAdditions()),
-				Deletions:   int64(f.NumDeletions()),
-				Changes:     int64(f.NumChanges()),
-				Patch:       patch.Bytes(),
-				IsBinary:    f.IsBinary,
-				IsSubmodule: f.IsSubmodule,
-			}
-			return nil
+		err := parseFileDiffs(pr, params, func(f *FileDiff) {
+			ch <- f
 		})
+		if errors.Is(err, errDiffStop) {
+			stopped.Store(true)
+			return
+		}
 		if err != nil {
 			cherr <- err
 			return
@@ -417,6 +437,106 @@ func (s *Service) Diff(
 	return ch, cherr
 }
 
+// parseFileDiffs parses the raw diff and calls fn for every file, honoring the pagination and the limits of params.
+// It returns errDiffStop if the parsing stopped before the end of the diff because all requested files are read.
+func parseFileDiffs(r io.Reader, params *DiffParams, fn func(f *FileDiff)) error {
+	parser := diff.Parser{
+		Reader: bufio.NewReader(r),
+	}
+
+	limiter := newDiffLimiter(params.Limits)
+	fileIdx := 0
+
+	return parser.Parse(func(f *diff.File) error {
+		fileIdx++
+		if fileIdx <= params.SkipFiles {
+			return nil
+		}
+		if params.MaxFiles > 0 && fileIdx > params.SkipFiles+params.MaxFiles {
+			return errDiffStop
+		}
+
+		var (
+			patch      bytes.Buffer
+			patchLines int
+		)
+		if params.IncludePatch {
+			for _, sec := range f.Sections {
+				for _, line := range sec.Lines {
+					if line.Type != diff.DiffLinePlain {
+						patch.WriteString(line.Content)
+						patchLines++
+					}
+				}
+			}
+		}
+
+		isTooLarge := params.IncludePatch && !limiter.add(patchLines, patch.Len())
+		if isTooLarge {
+			patch.Reset()
+		}
+
+		fn(&FileDiff{
+			SHA:         f.SHA,
+			OldSHA:      f.OldSHA,
+			Path:        f.Path,
+			OldPath:     f.OldPath,
+			Status:      parseFileDiffStatus(f.Type),
+			Additions:   int64(f.NumAdditions()),
+			Deletions:   int64(f.NumDeletions()),
+			Changes:     int64(f.NumChanges()),
+			Patch:       patch.Bytes(),
+			IsBinary:    f.IsBinary,
+			IsSubmodule: f.IsSubmodule,
+			IsTooLarge:  isTooLarge,
+		})
+		return nil
+	})
+}
+
+// errDiffStop is used to stop parsing of the diff once all requested files are read.
+var errDiffStop = errors.New("diff stop")
+
+// diffLimiter keeps track of the size of the returned patches.
+type diffLimiter struct {
+	limits  DiffLimits
+	files   int
+	lines   int
+	bytes   int
+	reached bool
+}
+
+func newDiffLimiter(limits DiffLimits) *diffLimiter {
+	return &diffLimiter{limits: limits}
+}
+
+// add returns true if the patch of a file with the provided size fits in the limits.
+// Once the combined limit is reached, the patches of all remaining files are rejected,
+// so the returned files remain a contiguous prefix of the diff.
+func (l *diffLimiter) add(lines, size int) bool {
+	if l.reached {
+		return false
+	}
+
+	if (l.limits.MaxFileLines > 0 && lines > l.limits.MaxFileLines) ||
+		(l.limits.MaxFileBytes > 0 && size > l.limits.MaxFileBytes) {
+		return false
+	}
+
+	if (l.limits.MaxFiles > 0 && l.files >= l.limits.MaxFiles) ||
+		(l.limits.MaxLines > 0 && l.lines+lines > l.limits.MaxLines) ||
+		(l.limits.MaxBytes > 0 && l.bytes+size > l.limits.MaxBytes) {
+		l.reached = true
+		return false
+	}
+
+	l.files++
+	l.lines += lines
+	l.bytes += size
+
+	return true
+}
+
 type DiffFileNamesOutput struct {
 	Files []string
 }
@@ -0,0 +1,87 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"testing"
+)
+
+func TestDiffLimiter(t *testing.T) {
+	type file struct {
+		lines int
+		size  int
+	}
+
+	tests := []struct {
+		name   string
+		limits DiffLimits
+		files  []file
+		want   []bool
+	}{
+		{
+			name:   "no limits",
+			limits: DiffLimits{},
+			files:  []file{{lines: 1000, size: 100000}, {lines: 1000, size: 100000}},
+			want:   []bool{true, true},
+		},
+		{
+			name:   "max files",
+			limits: DiffLimits{MaxFiles: 2},
+			files:  []file{{lines: 1, size: 1}, {lines: 1, size: 1}, {lines: 1, size: 1}},
+			want:   []bool{true, true, false},
+		},
+		{
+			name:   "max file lines skips only the large file",
+			limits: DiffLimits{MaxFileLines: 10},
+			files:  []file{{lines: 5, size: 1}, {lines: 11, size: 1}, {lines: 10, size: 1}},
+			want:   []bool{true, false, true},
+		},
+		{
+			name:   "max file bytes skips only the large file",
+			limits: DiffLimits{MaxFileBytes: 100},
+			files:  []file{{lines: 1, size: 101}, {lines: 1, size: 100}},
+			want:   []bool{false, true},
+		},
+		{
+			name:   "max lines rejects all remaining files",
+			limits: DiffLimits{MaxLines: 10},
+			files:  []file{{lines: 6, size: 1}, {lines: 6, size: 1}, {lines: 1, size: 1}},
+			want:   []bool{true, false, false},
+		},
+		{
+			name:   "max bytes rejects all remaining files",
+			limits: DiffLimits{MaxBytes: 100},
+			files:  []file{{lines: 1, size: 50}, {lines: 1, size: 50}, {lines: 1, size: 1}},
+			want:   []bool{true, true, false},
+		},
+		{
+			name:   "too large file does not count towards combined limits",
+			limits: DiffLimits{MaxFileLines: 10, MaxLines: 15},
+			files:  []file{{lines: 8, size: 1}, {lines: 20, size: 1}, {lines: 7, size: 1}},
+			want:   []bool{true, false, true},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			l := newDiffLimiter(test.limits)
+			for i, f := range test.files {
+				if got := l.add(f.lines, f.size); got != test.want[i] {
+					t.Errorf("file %d: want=%t got=%t", i, test.want[i], got)
+				}
+			}
+		})
+	}
+}
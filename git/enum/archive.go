@@ -0,0 +1,55 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+import "strings"
+
+// ArchiveFormat represents the format of a repository archive.
+type ArchiveFormat string
+
+const (
+	// ArchiveFormatZip is a zip archive.
+	ArchiveFormatZip ArchiveFormat = "zip"
+	// ArchiveFormatTarGz is a gzip compressed tar archive.
+	ArchiveFormatTarGz ArchiveFormat = "tar.gz"
+)
+
+// ArchiveFormats returns all archive formats.
+func ArchiveFormats() []ArchiveFormat {
+	return []ArchiveFormat{ArchiveFormatZip, ArchiveFormatTarGz}
+}
+
+// ParseArchiveFormat splits the file name of an archive into its base name and format.
+func ParseArchiveFormat(name string) (string, ArchiveFormat, bool) {
+	for _, format := range ArchiveFormats() {
+		if base, ok := strings.CutSuffix(name, "."+string(format)); ok && base != "" {
+			return base, format, true
+		}
+	}
+
+	return "", "", false
+}
+
+// ContentType returns the media type of the archive.
+func (f ArchiveFormat) ContentType() string {
+	switch f {
+	case ArchiveFormatZip:
+		return "application/zip"
+	case ArchiveFormatTarGz:
+		return "application/gzip"
+	default:
+		return "application/octet-stream"
+	}
+}
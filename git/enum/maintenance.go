@@ -0,0 +1,42 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// MaintenanceTask represents a repository maintenance task.
+type MaintenanceTask string
+
+const (
+	// MaintenanceTaskGC runs git gc, which packs loose objects and prunes unreachable objects.
+	MaintenanceTaskGC MaintenanceTask = "gc"
+	// MaintenanceTaskRepack repacks all objects into a single pack with a reachability bitmap index.
+	MaintenanceTaskRepack MaintenanceTask = "repack"
+	// MaintenanceTaskCommitGraph writes the commit-graph file that speeds up history traversal.
+	MaintenanceTaskCommitGraph MaintenanceTask = "commit-graph"
+)
+
+// MaintenanceTasks returns all maintenance tasks.
+func MaintenanceTasks() []MaintenanceTask {
+	return []MaintenanceTask{MaintenanceTaskGC, MaintenanceTaskRepack, MaintenanceTaskCommitGraph}
+}
+
+func (t MaintenanceTask) Sanitize() (MaintenanceTask, bool) {
+	for _, task := range MaintenanceTasks() {
+		if t == task {
+			return t, true
+		}
+	}
+
+	return "", false
+}
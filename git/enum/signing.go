@@ -0,0 +1,34 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// SigningFormat represents the format of the key used to sign commits.
+type SigningFormat string
+
+const (
+	// SigningFormatOpenPGP signs commits with an OpenPGP (GPG) key.
+	SigningFormatOpenPGP SigningFormat = "openpgp"
+	// SigningFormatSSH signs commits with an SSH key.
+	SigningFormatSSH SigningFormat = "ssh"
+)
+
+func (f SigningFormat) Sanitize() (SigningFormat, bool) {
+	switch f {
+	case SigningFormatOpenPGP, SigningFormatSSH:
+		return f, true
+	default:
+		return "", false
+	}
+}
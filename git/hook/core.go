@@ -20,7 +20,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -48,7 +51,12 @@ func (c *CLICore) PreReceive(ctx context.Context) error {
 	}
 
 	in := PreReceiveInput{
-		RefUpdates: refUpdates,
+		RefUpdates:  refUpdates,
+		PackSize:    getQuarantineSize(),
+		PushOptions: getPushOptions(),
+		Environment: Environment{
+			AlternateObjectDirs: getAlternateObjectDirs(),
+		},
 	}
 
 	out, err := c.client.PreReceive(ctx, in)
@@ -79,7 +87,8 @@ func (c *CLICore) PostReceive(ctx context.Context) error {
 	}
 
 	in := PostReceiveInput{
-		RefUpdates: refUpdates,
+		RefUpdates:  refUpdates,
+		PushOptions: getPushOptions(),
 	}
 
 	out, err := c.client.PostReceive(ctx, in)
@@ -110,6 +119,81 @@ func handleServerHookOutput(out Output, err error) error {
 	return nil
 }
 
+// getQuarantineSize returns the size (in KiB) of the objects git received as part of the push.
+// Git keeps the received objects in a quarantine directory until all references are updated.
+// For more details see https://git-scm.com/docs/git-receive-pack#_quarantine_environment
+func getQuarantineSize() int64 {
+	quarantinePath, ok := os.LookupEnv(envNameQuarantinePath)
+	if !ok || quarantinePath == "" {
+		return 0
+	}
+
+	var size int64
+	err := filepath.WalkDir(quarantinePath, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		size += info.Size()
+
+		return nil
+	})
+	if err != nil {
+		// the size is only used for enforcing limits on the server, don't fail the push.
+		return 0
+	}
+
+	return (size + 1023) / 1024
+}
+
+// getAlternateObjectDirs returns the object directories git uses for the current operation.
+// During a push the received objects are stored in a separate (quarantine) object directory.
+// The paths are made absolute as the server doesn't share the working directory of the hook.
+func getAlternateObjectDirs() []string {
+	var dirs []string
+
+	if objectDir, ok := os.LookupEnv(envNameObjectDirectory); ok && objectDir != "" {
+		dirs = append(dirs, objectDir)
+	}
+
+	if alternateDirs, ok := os.LookupEnv(envNameAlternateObjectDirectories); ok && alternateDirs != "" {
+		dirs = append(dirs, filepath.SplitList(alternateDirs)...)
+	}
+
+	for i := range dirs {
+		if absDir, err := filepath.Abs(dirs[i]); err == nil {
+			dirs[i] = absDir
+		}
+	}
+
+	return dirs
+}
+
+// getPushOptions returns the push options git received from the client.
+// Git only provides them to the hooks if the server advertises the push-options capability.
+// For more details see https://git-scm.com/docs/githooks#pre-receive
+func getPushOptions() []string {
+	count, err := strconv.Atoi(os.Getenv(envNamePushOptionCount))
+	if err != nil || count <= 0 {
+		return nil
+	}
+
+	options := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		options = append(options, os.Getenv(envNamePrefixPushOption+strconv.Itoa(i)))
+	}
+
+	return options
+}
+
 // getUpdatedReferencesFromStdIn reads the updated references provided by git from stdin.
 // The expected format is "<old-value> SP <new-value> SP <ref-name> LF"
 // For more details see https://git-scm.com/docs/githooks#pre-receive
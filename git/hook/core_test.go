@@ -0,0 +1,63 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hook
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGetPushOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want []string
+	}{
+		{
+			name: "no push options",
+			env:  map[string]string{},
+			want: nil,
+		},
+		{
+			name: "invalid count",
+			env:  map[string]string{envNamePushOptionCount: "abc"},
+			want: nil,
+		},
+		{
+			name: "push options",
+			env: map[string]string{
+				envNamePushOptionCount:        "2",
+				envNamePrefixPushOption + "0": PushOptionStrictRules,
+				envNamePrefixPushOption + "1": "ci.skip=true",
+			},
+			want: []string{PushOptionStrictRules, "ci.skip=true"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv(envNamePushOptionCount, "")
+			for k, v := range test.env {
+				t.Setenv(k, v)
+			}
+
+			got := getPushOptions()
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("push options mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
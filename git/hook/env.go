@@ -26,6 +26,24 @@ import (
 const (
 	// envNamePayload defines the environment variable name used to send the payload to githook binary.
 	envNamePayload = "GIT_HOOK_PAYLOAD"
+
+	// envNameQuarantinePath defines the environment variable name git uses to provide
+	// the directory containing the objects received as part of a push.
+	envNameQuarantinePath = "GIT_QUARANTINE_PATH"
+
+	// envNameObjectDirectory defines the environment variable name git uses to provide the object directory.
+	envNameObjectDirectory = "GIT_OBJECT_DIRECTORY"
+
+	// envNameAlternateObjectDirectories defines the environment variable name git uses
+	// to provide additional object directories.
+	envNameAlternateObjectDirectories = "GIT_ALTERNATE_OBJECT_DIRECTORIES"
+
+	// envNamePushOptionCount defines the environment variable name git uses to provide the number of push options.
+	// The push options themselves are provided in GIT_PUSH_OPTION_0, GIT_PUSH_OPTION_1, ...
+	envNamePushOptionCount = "GIT_PUSH_OPTION_COUNT"
+
+	// envNamePrefixPushOption defines the prefix of the environment variable names containing the push options.
+	envNamePrefixPushOption = "GIT_PUSH_OPTION_"
 )
 
 var (
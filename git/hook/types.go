@@ -37,12 +37,32 @@ type ReferenceUpdate struct {
 type PostReceiveInput struct {
 	// RefUpdates contains all references that got updated as part of the git operation.
 	RefUpdates []ReferenceUpdate `json:"ref_updates"`
+	// PushOptions contains the push options the client sent (e.g. `git push -o <option>`).
+	PushOptions []string `json:"push_options,omitempty"`
 }
 
 // PreReceiveInput represents the input of the pre-receive git hook.
 type PreReceiveInput struct {
 	// RefUpdates contains all references that are being updated as part of the git operation.
 	RefUpdates []ReferenceUpdate `json:"ref_updates"`
+	// PackSize is the size (in KiB) of the objects received as part of the git operation.
+	// It's zero in case no objects were received or the size couldn't be determined.
+	PackSize int64 `json:"pack_size,omitempty"`
+	// PushOptions contains the push options the client sent (e.g. `git push -o <option>`).
+	PushOptions []string `json:"push_options,omitempty"`
+	// Environment contains the git environment of the operation.
+	Environment Environment `json:"environment"`
+}
+
+// PushOptionStrictRules is the push option a client sends to have the protection rules of the repository
+// enforced even if the principal is allowed to bypass them (e.g. `git push -o strict_rules`).
+const PushOptionStrictRules = "strict_rules"
+
+// Environment contains the information required to access the git environment of a hook.
+type Environment struct {
+	// AlternateObjectDirs contains the object directories required to access all objects of the git operation
+	// (e.g. the quarantine directory of a push that's in progress).
+	AlternateObjectDirs []string `json:"alternate_object_dirs,omitempty"`
 }
 
 // UpdateInput represents the input of the update git hook.
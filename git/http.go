@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"io"
 	"regexp"
+	"time"
 
 	"github.com/harness/gitness/errors"
 )
@@ -37,8 +38,9 @@ func (s *Service) GetInfoRefs(ctx context.Context, w io.Writer, params *InfoRefs
 		return err
 	}
 
+	// the git protocol header is passed to git as it is, so only safe values are accepted (same as for ServicePack).
 	environ := []string{}
-	if params.GitProtocol != "" {
+	if params.GitProtocol != "" && safeGitProtocolHeader.MatchString(params.GitProtocol) {
 		environ = append(environ, "GIT_PROTOCOL="+params.GitProtocol)
 	}
 
@@ -66,9 +68,41 @@ func (p *ServicePackParams) Validate() error {
 	return nil
 }
 
-func (s *Service) ServicePack(ctx context.Context, w io.Writer, params *ServicePackParams) error {
+type ServicePackOutput struct {
+	// UserTime is the CPU time the git process (and its children) spent in user mode.
+	UserTime time.Duration
+	// SystemTime is the CPU time the git process (and its children) spent in kernel mode.
+	SystemTime time.Duration
+	// Negotiation describes the request of an upload-pack invocation, it's nil for other services.
+	Negotiation *UploadPackNegotiation
+}
+
+// UploadPackNegotiation describes the request of an upload-pack invocation. With the smart http protocol,
+// every request is a single round of the negotiation (or, with protocol v2, a single command).
+type UploadPackNegotiation struct {
+	// ProtocolVersion is the version of the git wire protocol, versions 0 and 1 are equivalent for upload-pack.
+	ProtocolVersion int
+	// Command is the protocol v2 command (ls-refs or fetch), it's empty for older protocol versions.
+	Command     string
+	Wants       int
+	WantRefs    int
+	Haves       int
+	RefPrefixes int
+	// Done is true if the client ended the negotiation and the response contains the pack.
+	Done bool
+	// Filter is true if the client requested a partial clone/fetch.
+	Filter bool
+	// Deepen is true if the client requested a shallow clone/fetch.
+	Deepen bool
+	// PackCached is true if the response has been served from the pack cache.
+	PackCached bool
+}
+
+// ServicePack executes the requested git service.
+// The output is populated even if an error is returned, as long as the git process was started.
+func (s *Service) ServicePack(ctx context.Context, w io.Writer, params *ServicePackParams) (ServicePackOutput, error) {
 	if err := params.Validate(); err != nil {
-		return err
+		return ServicePackOutput{}, err
 	}
 
 	var (
@@ -79,27 +113,35 @@ func (s *Service) ServicePack(ctx context.Context, w io.Writer, params *ServiceP
 	switch params.Service {
 	case "upload-pack":
 		if err := params.ReadParams.Validate(); err != nil {
-			return errors.InvalidArgument("upload-pack requires ReadParams")
+			return ServicePackOutput{}, errors.InvalidArgument("upload-pack requires ReadParams")
 		}
 		repoPath = getFullPathForRepo(s.reposRoot, params.ReadParams.RepoUID)
 	case "receive-pack":
 		if err := params.WriteParams.Validate(); err != nil {
-			return errors.InvalidArgument("receive-pack requires WriteParams")
+			return ServicePackOutput{}, errors.InvalidArgument("receive-pack requires WriteParams")
 		}
 		env = CreateEnvironmentForPush(ctx, *params.WriteParams)
 		repoPath = getFullPathForRepo(s.reposRoot, params.WriteParams.RepoUID)
 	default:
-		return errors.InvalidArgument("unsupported service provided: %s", params.Service)
+		return ServicePackOutput{}, errors.InvalidArgument("unsupported service provided: %s", params.Service)
 	}
 
 	if params.GitProtocol != "" && safeGitProtocolHeader.MatchString(params.GitProtocol) {
 		env = append(env, "GIT_PROTOCOL="+params.GitProtocol)
 	}
 
-	err := s.adapter.ServicePack(ctx, repoPath, params.Service, params.Data, w, env...)
+	stats, err := s.adapter.ServicePack(ctx, repoPath, params.Service, params.Data, w, env...)
+	output := ServicePackOutput{
+		UserTime:   stats.UserTime,
+		SystemTime: stats.SystemTime,
+	}
+	if stats.Negotiation != nil {
+		negotiation := UploadPackNegotiation(*stats.Negotiation)
+		output.Negotiation = &negotiation
+	}
 	if err != nil {
-		return fmt.Errorf("failed to execute git %s: %w", params.Service, err)
+		return output, fmt.Errorf("failed to execute git %s: %w", params.Service, err)
 	}
 
-	return nil
+	return output, nil
 }
@@ -24,7 +24,9 @@ type Interface interface {
 	DeleteRepository(ctx context.Context, params *DeleteRepositoryParams) error
 	GetTreeNode(ctx context.Context, params *GetTreeNodeParams) (*GetTreeNodeOutput, error)
 	ListTreeNodes(ctx context.Context, params *ListTreeNodeParams) (*ListTreeNodeOutput, error)
+	ListTree(ctx context.Context, params *ListTreeParams) (*ListTreeOutput, error)
 	GetSubmodule(ctx context.Context, params *GetSubmoduleParams) (*GetSubmoduleOutput, error)
+	ListSubmodules(ctx context.Context, params *ListSubmodulesParams) (*ListSubmodulesOutput, error)
 	GetBlob(ctx context.Context, params *GetBlobParams) (*GetBlobOutput, error)
 	CreateBranch(ctx context.Context, params *CreateBranchParams) (*CreateBranchOutput, error)
 	CreateCommitTag(ctx context.Context, params *CreateCommitTagParams) (*CreateCommitTagOutput, error)
@@ -32,10 +34,13 @@ type Interface interface {
 	GetBranch(ctx context.Context, params *GetBranchParams) (*GetBranchOutput, error)
 	DeleteBranch(ctx context.Context, params *DeleteBranchParams) error
 	ListBranches(ctx context.Context, params *ListBranchesParams) (*ListBranchesOutput, error)
+	GetBranchDivergences(ctx context.Context, params *GetBranchDivergencesParams) (*GetBranchDivergencesOutput, error)
 	GetRef(ctx context.Context, params GetRefParams) (GetRefResponse, error)
 	PathsDetails(ctx context.Context, params PathsDetailsParams) (PathsDetailsOutput, error)
 
 	GetRepositorySize(ctx context.Context, params *GetRepositorySizeParams) (*GetRepositorySizeOutput, error)
+	GetObjectStats(ctx context.Context, params *GetObjectStatsParams) (ObjectStats, error)
+	RunMaintenance(ctx context.Context, params *RunMaintenanceParams) error
 
 	// UpdateRef creates, updates or deletes a git ref. If the OldValue is defined it must match the reference value
 	// prior to the call. To remove a ref use the zero ref as the NewValue. To require the creation of a new one and
@@ -45,12 +50,20 @@ type Interface interface {
 	SyncRepository(ctx context.Context, params *SyncRepositoryParams) (*SyncRepositoryOutput, error)
 
 	MatchFiles(ctx context.Context, params *MatchFilesParams) (*MatchFilesOutput, error)
+	FindBinaryBlobs(ctx context.Context, params *FindBinaryBlobsParams) (*FindBinaryBlobsOutput, error)
+	FindNewChangedPaths(ctx context.Context, params *FindNewChangedPathsParams) (*FindNewChangedPathsOutput, error)
+	ListTreePaths(ctx context.Context, params *ListTreePathsParams) (*ListTreePathsOutput, error)
+	GetBlobStats(ctx context.Context, params *GetBlobStatsParams) (*GetBlobStatsOutput, error)
+	CreateBundle(ctx context.Context, params *CreateBundleParams, w io.Writer) error
+	ApplyBundle(ctx context.Context, params *ApplyBundleParams) error
+	Archive(ctx context.Context, params *ArchiveParams, w io.Writer) error
 
 	/*
 	 * Commits service
 	 */
 	GetCommit(ctx context.Context, params *GetCommitParams) (*GetCommitOutput, error)
 	ListCommits(ctx context.Context, params *ListCommitsParams) (*ListCommitsOutput, error)
+	WalkCommits(ctx context.Context, params *WalkCommitsParams) (<-chan *Commit, <-chan error)
 	ListCommitTags(ctx context.Context, params *ListCommitTagsParams) (*ListCommitTagsOutput, error)
 	GetCommitDivergences(ctx context.Context, params *GetCommitDivergencesParams) (*GetCommitDivergencesOutput, error)
 	CommitFiles(ctx context.Context, params *CommitFilesParams) (CommitFilesResponse, error)
@@ -61,7 +74,7 @@ type Interface interface {
 	 * Git Cli Service
 	 */
 	GetInfoRefs(ctx context.Context, w io.Writer, params *InfoRefsParams) error
-	ServicePack(ctx context.Context, w io.Writer, params *ServicePackParams) error
+	ServicePack(ctx context.Context, w io.Writer, params *ServicePackParams) (ServicePackOutput, error)
 
 	/*
 	 * Diff services
@@ -71,6 +84,7 @@ type Interface interface {
 	DiffFileNames(ctx context.Context, in *DiffParams) (DiffFileNamesOutput, error)
 	CommitDiff(ctx context.Context, params *GetCommitParams, w io.Writer) error
 	DiffShortStat(ctx context.Context, params *DiffParams) (DiffShortStatOutput, error)
+	Compare(ctx context.Context, params *CompareParams) (*CompareOutput, error)
 	DiffStats(ctx context.Context, params *DiffParams) (DiffStatsOutput, error)
 
 	GetDiffHunkHeaders(ctx context.Context, params GetDiffHunkHeadersParams) (GetDiffHunkHeadersOutput, error)
@@ -80,6 +94,10 @@ type Interface interface {
 	 * Merge services
 	 */
 	Merge(ctx context.Context, in *MergeParams) (MergeOutput, error)
+	Revert(ctx context.Context, params *RevertParams) (RevertOutput, error)
+	RevertCommit(ctx context.Context, params *RevertCommitParams) (RevertCommitOutput, error)
+	CherryPick(ctx context.Context, params *CherryPickParams) (CherryPickOutput, error)
+	CherryPickCommits(ctx context.Context, params *CherryPickCommitsParams) (CherryPickCommitsOutput, error)
 
 	/*
 	 * Blame services
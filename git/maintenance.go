@@ -0,0 +1,145 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type GetObjectStatsParams struct {
+	ReadParams
+}
+
+// ObjectStats contains statistics about the objects stored in a repository. Sizes are in bytes.
+type ObjectStats struct {
+	LooseObjects   int   `json:"loose_objects"`
+	LooseSize      int64 `json:"loose_size"`
+	PackedObjects  int   `json:"packed_objects"`
+	Packs          int   `json:"packs"`
+	PackSize       int64 `json:"pack_size"`
+	PrunePackable  int   `json:"prune_packable"`
+	Garbage        int   `json:"garbage"`
+	GarbageSize    int64 `json:"garbage_size"`
+	HasCommitGraph bool  `json:"has_commit_graph"`
+	HasBitmapIndex bool  `json:"has_bitmap_index"`
+}
+
+// GetObjectStats returns statistics about the objects stored in the repository.
+func (s *Service) GetObjectStats(ctx context.Context, params *GetObjectStatsParams) (ObjectStats, error) {
+	if params == nil {
+		return ObjectStats{}, ErrNoParamsProvided
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	count, err := s.adapter.CountObjects(ctx, repoPath)
+	if err != nil {
+		return ObjectStats{}, fmt.Errorf("failed to count objects: %w", err)
+	}
+
+	hasCommitGraph, err := s.adapter.HasCommitGraph(ctx, repoPath)
+	if err != nil {
+		return ObjectStats{}, fmt.Errorf("failed to check commit-graph: %w", err)
+	}
+
+	hasBitmapIndex, err := s.adapter.HasBitmapIndex(ctx, repoPath)
+	if err != nil {
+		return ObjectStats{}, fmt.Errorf("failed to check bitmap index: %w", err)
+	}
+
+	// git count-objects reports sizes in KiB
+	const kib = 1024
+
+	return ObjectStats{
+		LooseObjects:   count.Count,
+		LooseSize:      count.Size * kib,
+		PackedObjects:  count.InPack,
+		Packs:          count.Packs,
+		PackSize:       count.SizePack * kib,
+		PrunePackable:  count.PrunePackable,
+		Garbage:        count.Garbage,
+		GarbageSize:    count.SizeGarbage * kib,
+		HasCommitGraph: hasCommitGraph,
+		HasBitmapIndex: hasBitmapIndex,
+	}, nil
+}
+
+type RunMaintenanceParams struct {
+	ReadParams
+	// Tasks are the maintenance tasks that are run, in the provided order.
+	Tasks []enum.MaintenanceTask
+}
+
+func (p *RunMaintenanceParams) Validate() error {
+	if p == nil {
+		return ErrNoParamsProvided
+	}
+
+	if err := p.ReadParams.Validate(); err != nil {
+		return err
+	}
+
+	if len(p.Tasks) == 0 {
+		return errors.InvalidArgument("at least one maintenance task must be provided")
+	}
+
+	for _, task := range p.Tasks {
+		if _, ok := task.Sanitize(); !ok {
+			return errors.InvalidArgument("unknown maintenance task %q", task)
+		}
+	}
+
+	return nil
+}
+
+// RunMaintenance runs the maintenance tasks on the repository.
+func (s *Service) RunMaintenance(ctx context.Context, params *RunMaintenanceParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	done := make(map[enum.MaintenanceTask]struct{}, len(params.Tasks))
+	for _, task := range params.Tasks {
+		if _, ok := done[task]; ok {
+			continue
+		}
+		done[task] = struct{}{}
+
+		log.Ctx(ctx).Debug().Str("repo_uid", params.RepoUID).Msgf("run maintenance task %s", task)
+
+		var err error
+		switch task {
+		case enum.MaintenanceTaskGC:
+			err = s.adapter.GarbageCollect(ctx, repoPath)
+		case enum.MaintenanceTaskRepack:
+			err = s.adapter.Repack(ctx, repoPath)
+		case enum.MaintenanceTaskCommitGraph:
+			err = s.adapter.WriteCommitGraph(ctx, repoPath)
+		}
+		if err != nil {
+			return fmt.Errorf("maintenance task %s failed: %w", task, err)
+		}
+	}
+
+	return nil
+}
@@ -62,9 +62,21 @@ func mapCommit(c *types.Commit) (*Commit, error) {
 		Message:   c.Message,
 		Author:    *author,
 		Committer: *comitter,
+		Signature: mapCommitSignature(c.Signature),
 	}, nil
 }
 
+func mapCommitSignature(s *types.CommitSignature) *CommitSignature {
+	if s == nil {
+		return nil
+	}
+
+	return &CommitSignature{
+		Signature: s.Signature,
+		Payload:   s.Payload,
+	}
+}
+
 func mapSignature(s *types.Signature) (*Signature, error) {
 	if s == nil {
 		return nil, fmt.Errorf("rpc signature is nil")
@@ -114,6 +126,7 @@ func mapAnnotatedTag(tag *types.Tag) *CommitTag {
 		Title:       tag.Title,
 		Message:     tag.Message,
 		Tagger:      tagger,
+		Signature:   mapCommitSignature(tag.Signature),
 		IsAnnotated: true,
 		Commit:      nil,
 	}
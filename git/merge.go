@@ -66,6 +66,21 @@ type MergeParams struct {
 	DeleteHeadBranch bool
 
 	Method enum.MergeMethod
+
+	// SigningKey overwrites the key used for signing the commits created by the merge
+	// (optional, default: the server signing key, if configured)
+	SigningKey *SigningKey
+
+	// Preview makes a merge check (RefType undefined) also return the commits the merge would add
+	// to the base branch and the changes they introduce. PreviewLimits restrict the size of the patches.
+	Preview       bool
+	PreviewLimits DiffLimits
+}
+
+// SigningKey is a private key used for signing commits.
+type SigningKey struct {
+	Format     enum.SigningFormat
+	PrivateKey string
 }
 
 func (p *MergeParams) Validate() error {
@@ -101,7 +116,15 @@ type MergeOutput struct {
 
 	CommitCount      int
 	ChangedFileCount int
+	Additions        int
+	Deletions        int
 	ConflictFiles    []string
+
+	// PreviewCommits and PreviewDiff are only populated for merge previews (see MergeParams.Preview).
+	// PreviewCommits are the first-parent commits the merge would add to the base branch, the newest first.
+	PreviewCommits []Commit
+	// PreviewDiff are the changes the merge would introduce to the base branch.
+	PreviewDiff []FileDiff
 }
 
 // Merge method executes git merge operation. Refs can be sha, branch or tag.
@@ -280,6 +303,23 @@ func (s *Service) Merge(ctx context.Context, params *MergeParams) (MergeOutput,
 		params.Method = enum.MergeMethodMerge
 	}
 
+	var signing types.CommitSigning
+	signingKey := s.signingKey
+	if params.SigningKey != nil {
+		signingKey = &types.SigningKey{
+			Format:     params.SigningKey.Format,
+			PrivateKey: params.SigningKey.PrivateKey,
+		}
+	}
+	if signingKey != nil && params.RefType != enum.RefTypeUndefined {
+		log.Debug().Msg("configure commit signing")
+
+		signing, err = s.adapter.ConfigureSigning(ctx, tmpRepo.Path, *signingKey)
+		if err != nil {
+			return MergeOutput{}, fmt.Errorf("failed to configure commit signing: %w", err)
+		}
+	}
+
 	log.Debug().Msg("perform merge")
 
 	result, err := s.adapter.Merge(
@@ -290,6 +330,7 @@ func (s *Service) Merge(ctx context.Context, params *MergeParams) (MergeOutput,
 		trackingBranch,
 		tmpRepo.Path,
 		mergeMsg,
+		signing,
 		&types.Identity{
 			Name:  author.Name,
 			Email: author.Email,
@@ -307,6 +348,8 @@ func (s *Service) Merge(ctx context.Context, params *MergeParams) (MergeOutput,
 			MergeSHA:         "",
 			CommitCount:      commitCount,
 			ChangedFileCount: changedFileCount,
+			Additions:        shortStat.Additions,
+			Deletions:        shortStat.Deletions,
 			ConflictFiles:    result.ConflictFiles,
 		}, nil
 	}
@@ -319,6 +362,18 @@ func (s *Service) Merge(ctx context.Context, params *MergeParams) (MergeOutput,
 	}
 
 	if params.RefType == enum.RefTypeUndefined {
+		var previewCommits []Commit
+		var previewDiff []FileDiff
+		if params.Preview {
+			log.Debug().Msg("get merge preview")
+
+			previewCommits, previewDiff, err = s.mergePreview(ctx, tmpRepo.Path, tmpRepo.BaseSHA, mergeCommitSHA,
+				params.PreviewLimits)
+			if err != nil {
+				return MergeOutput{}, err
+			}
+		}
+
 		log.Debug().Msg("done (merge-check only)")
 
 		return MergeOutput{
@@ -328,7 +383,11 @@ func (s *Service) Merge(ctx context.Context, params *MergeParams) (MergeOutput,
 			MergeSHA:         mergeCommitSHA,
 			CommitCount:      commitCount,
 			ChangedFileCount: changedFileCount,
+			Additions:        shortStat.Additions,
+			Deletions:        shortStat.Deletions,
 			ConflictFiles:    nil,
+			PreviewCommits:   previewCommits,
+			PreviewDiff:      previewDiff,
 		}, nil
 	}
 
@@ -360,10 +419,57 @@ func (s *Service) Merge(ctx context.Context, params *MergeParams) (MergeOutput,
 		MergeSHA:         mergeCommitSHA,
 		CommitCount:      commitCount,
 		ChangedFileCount: changedFileCount,
+		Additions:        shortStat.Additions,
+		Deletions:        shortStat.Deletions,
 		ConflictFiles:    nil,
 	}, nil
 }
 
+// maxMergePreviewCommits limits the number of commits returned by a merge preview.
+const maxMergePreviewCommits = 100
+
+// mergePreview returns the commits and the changes that the merge commit adds on top of the base commit.
+// It's executed in the temporary repository, because the merge commit doesn't exist in the original repository.
+func (s *Service) mergePreview(
+	ctx context.Context,
+	repoPath string,
+	baseSHA string,
+	mergeSHA string,
+	limits DiffLimits,
+) ([]Commit, []FileDiff, error) {
+	gitCommits, _, err := s.adapter.ListCommits(ctx, repoPath, mergeSHA, 0, maxMergePreviewCommits,
+		types.CommitFilter{AfterRef: baseSHA, FirstParent: true})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list merge preview commits: %w", err)
+	}
+
+	commits := make([]Commit, len(gitCommits))
+	for i := range gitCommits {
+		commit, err := mapCommit(&gitCommits[i])
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to map merge preview commit: %w", err)
+		}
+		commits[i] = *commit
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		err := s.adapter.RawDiff(ctx, repoPath, baseSHA, mergeSHA, false, pw)
+		_ = pw.CloseWithError(err)
+	}()
+	defer pr.Close()
+
+	files := make([]FileDiff, 0)
+	err = parseFileDiffs(pr, &DiffParams{IncludePatch: true, Limits: limits}, func(f *FileDiff) {
+		files = append(files, *f)
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get merge preview diff: %w", err)
+	}
+
+	return commits, files, nil
+}
+
 type MergeBaseParams struct {
 	ReadParams
 	Ref1 string
@@ -0,0 +1,463 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/tempdir"
+	"github.com/harness/gitness/git/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// applyCommitsFunc applies the changes of the commits on top of the checked out branch of the repository.
+type applyCommitsFunc func(
+	ctx context.Context,
+	repoPath string,
+	mainline int,
+	commits []string,
+	message string,
+	signing types.CommitSigning,
+	env ...string,
+) (types.MergeResult, error)
+
+// mergedChangesParams is the input for applying the changes of a merged pull request to a branch.
+type mergedChangesParams struct {
+	WriteParams
+
+	// operation is the name of the operation used in logs and errors (e.g. revert, cherry-pick).
+	operation string
+	apply     applyCommitsFunc
+
+	baseBranch string
+	newBranch  string
+
+	method         enum.MergeMethod
+	mergeSHA       string
+	mergeTargetSHA string
+
+	title   string
+	message string
+
+	committer     *Identity
+	committerDate *time.Time
+	author        *Identity
+	authorDate    *time.Time
+	signingKey    *SigningKey
+}
+
+// mergedChangesOutput is the result of applying the changes of a merged pull request to a branch.
+type mergedChangesOutput struct {
+	baseSHA string
+	newSHA  string
+}
+
+// applyMergedChanges creates a new branch from the base branch with a single commit that applies
+// (or reverts, depending on the apply function) the changes of a merged pull request.
+// The commits that are applied depend on the method that was used to merge the changes:
+//
+//	enum.MergeMethodMerge -> the merge commit, against its first parent.
+//	enum.MergeMethodSquash -> the squash commit.
+//	enum.MergeMethodRebase -> all rebased commits between mergeTargetSHA and mergeSHA.
+//
+// If the changes can't be applied cleanly, an error with the precondition failed status is returned
+// and the list of conflicting files is provided in its details.
+func (s *Service) applyMergedChanges(
+	ctx context.Context,
+	params mergedChangesParams,
+) (mergedChangesOutput, error) {
+	log := log.Ctx(ctx).With().
+		Str("repo_uid", params.RepoUID).
+		Str("operation", params.operation).
+		Logger()
+
+	var (
+		mainline   int
+		commits    []string
+		diffFromTo [2]string
+	)
+
+	switch params.method {
+	case enum.MergeMethodMerge:
+		mainline = 1
+		commits = []string{params.mergeSHA}
+		diffFromTo = [2]string{params.mergeSHA + "^1", params.mergeSHA}
+	case enum.MergeMethodSquash:
+		commits = []string{params.mergeSHA}
+		diffFromTo = [2]string{params.mergeSHA + "^1", params.mergeSHA}
+	case enum.MergeMethodRebase:
+		commits = []string{params.mergeTargetSHA + ".." + params.mergeSHA}
+		diffFromTo = [2]string{params.mergeTargetSHA, params.mergeSHA}
+	default:
+		return mergedChangesOutput{}, errors.InvalidArgument("unsupported merge method: %s", params.method)
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	baseBranch := "base"
+
+	// The base branch is used for tracking too, the merged commits are available through the alternates.
+	pr := &types.PullRequest{
+		BaseRepoPath: repoPath,
+		BaseBranch:   params.baseBranch,
+		HeadBranch:   params.baseBranch,
+	}
+
+	log.Debug().Msg("create temporary repository")
+
+	tmpRepo, err := s.adapter.CreateTemporaryRepoForPR(ctx, s.tmpDir, pr, baseBranch, "tracking")
+	if err != nil {
+		return mergedChangesOutput{}, fmt.Errorf("failed to initialize temporary repo: %w", err)
+	}
+	defer func() {
+		rmErr := tempdir.RemoveTemporaryPath(tmpRepo.Path)
+		if rmErr != nil {
+			log.Warn().Msgf("Removing temporary location %s for %s operation was not successful",
+				tmpRepo.Path, params.operation)
+		}
+	}()
+
+	log.Debug().Msg("prepare sparse-checkout")
+
+	sparseCheckoutList, err := s.adapter.GetDiffTree(ctx, tmpRepo.Path, diffFromTo[0], diffFromTo[1])
+	if err != nil {
+		return mergedChangesOutput{}, fmt.Errorf("execution of GetDiffTree failed: %w", err)
+	}
+
+	if err = s.prepareSparseCheckout(ctx, tmpRepo.Path, sparseCheckoutList); err != nil {
+		return mergedChangesOutput{}, err
+	}
+
+	committer := params.Actor
+	if params.committer != nil {
+		committer = *params.committer
+	}
+	committerDate := time.Now().UTC()
+	if params.committerDate != nil {
+		committerDate = *params.committerDate
+	}
+
+	author := committer
+	if params.author != nil {
+		author = *params.author
+	}
+	authorDate := committerDate
+	if params.authorDate != nil {
+		authorDate = *params.authorDate
+	}
+
+	env := append(CreateEnvironmentForPush(ctx, params.WriteParams),
+		"GIT_AUTHOR_NAME="+author.Name,
+		"GIT_AUTHOR_EMAIL="+author.Email,
+		"GIT_AUTHOR_DATE="+authorDate.Format(time.RFC3339),
+		"GIT_COMMITTER_NAME="+committer.Name,
+		"GIT_COMMITTER_EMAIL="+committer.Email,
+		"GIT_COMMITTER_DATE="+committerDate.Format(time.RFC3339),
+	)
+
+	signing, err := s.configureCommitSigning(ctx, tmpRepo.Path, params.signingKey)
+	if err != nil {
+		return mergedChangesOutput{}, err
+	}
+
+	commitMsg := strings.TrimSpace(params.title)
+	if len(params.message) > 0 {
+		commitMsg += "\n\n" + strings.TrimSpace(params.message)
+	}
+
+	log.Debug().Msgf("perform %s", params.operation)
+
+	result, err := params.apply(ctx, tmpRepo.Path, mainline, commits, commitMsg, signing, env...)
+	if err != nil {
+		return mergedChangesOutput{}, fmt.Errorf("%s failed: %w", params.operation, err)
+	}
+
+	if len(result.ConflictFiles) > 0 {
+		return mergedChangesOutput{}, errors.PreconditionFailed(
+			"the changes can't be applied cleanly on top of branch '%s'",
+			params.baseBranch,
+			errors.Arg{Key: "conflict_files", Value: result.ConflictFiles})
+	}
+
+	newSHA, err := s.adapter.GetFullCommitID(ctx, tmpRepo.Path, baseBranch)
+	if err != nil {
+		return mergedChangesOutput{}, fmt.Errorf("failed to get full commit id of the new commit: %w", err)
+	}
+
+	refPath, err := GetRefPath(params.newBranch, enum.RefTypeBranch)
+	if err != nil {
+		return mergedChangesOutput{}, fmt.Errorf("failed to generate full reference for branch '%s': %w",
+			params.newBranch, err)
+	}
+
+	log.Debug().Msg("push to original repo")
+
+	if err = s.adapter.Push(ctx, tmpRepo.Path, types.PushOptions{
+		Remote: "origin",
+		Branch: baseBranch + ":" + refPath,
+		Env:    env,
+	}); err != nil {
+		return mergedChangesOutput{}, fmt.Errorf("failed to push %s commit to ref '%s': %w",
+			params.operation, refPath, err)
+	}
+
+	log.Debug().Msg("done")
+
+	return mergedChangesOutput{
+		baseSHA: tmpRepo.BaseSHA,
+		newSHA:  newSHA,
+	}, nil
+}
+
+// commitInfoFunc returns the message, the author and the author date of the new commit
+// that is created for the provided commit.
+type commitInfoFunc func(commit *types.Commit) (message string, author Identity, authorDate time.Time)
+
+// commitChangesParams is the input for applying the changes of individual commits to a branch.
+type commitChangesParams struct {
+	WriteParams
+
+	// operation is the name of the operation used in logs and errors (e.g. revert, cherry-pick).
+	operation  string
+	apply      applyCommitsFunc
+	commitInfo commitInfoFunc
+
+	branch    string
+	newBranch string
+
+	commits  []string
+	mainline int
+
+	committer     *Identity
+	committerDate *time.Time
+	signingKey    *SigningKey
+}
+
+// applyCommitChanges applies (or reverts, depending on the apply function) the changes of each of
+// the commits on top of the branch, creating one new commit per provided commit. The result is pushed
+// to the branch, or to the new branch if one is provided.
+// If any of the commits can't be applied cleanly, an error with the precondition failed status is returned
+// and the conflicting commit and the list of conflicting files are provided in its details.
+//
+//nolint:gocognit // refactor if needed
+func (s *Service) applyCommitChanges(
+	ctx context.Context,
+	params commitChangesParams,
+) (mergedChangesOutput, error) {
+	log := log.Ctx(ctx).With().
+		Str("repo_uid", params.RepoUID).
+		Str("operation", params.operation).
+		Logger()
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	commits := make([]*types.Commit, len(params.commits))
+	for i, sha := range params.commits {
+		commit, err := s.adapter.GetCommit(ctx, repoPath, sha)
+		if err != nil {
+			return mergedChangesOutput{}, fmt.Errorf("failed to get commit %s: %w", sha, err)
+		}
+		commits[i] = commit
+	}
+
+	baseBranch := "base"
+
+	pr := &types.PullRequest{
+		BaseRepoPath: repoPath,
+		BaseBranch:   params.branch,
+		HeadBranch:   params.branch,
+	}
+
+	log.Debug().Msg("create temporary repository")
+
+	tmpRepo, err := s.adapter.CreateTemporaryRepoForPR(ctx, s.tmpDir, pr, baseBranch, "tracking")
+	if err != nil {
+		return mergedChangesOutput{}, fmt.Errorf("failed to initialize temporary repo: %w", err)
+	}
+	defer func() {
+		rmErr := tempdir.RemoveTemporaryPath(tmpRepo.Path)
+		if rmErr != nil {
+			log.Warn().Msgf("Removing temporary location %s for %s operation was not successful",
+				tmpRepo.Path, params.operation)
+		}
+	}()
+
+	log.Debug().Msg("prepare sparse-checkout")
+
+	parent := "^1"
+	if params.mainline > 0 {
+		parent = "^" + strconv.Itoa(params.mainline)
+	}
+
+	sparseCheckoutList := strings.Builder{}
+	for _, commit := range commits {
+		list, err := s.adapter.GetDiffTree(ctx, tmpRepo.Path, commit.SHA+parent, commit.SHA)
+		if err != nil {
+			return mergedChangesOutput{}, fmt.Errorf("execution of GetDiffTree failed: %w", err)
+		}
+		sparseCheckoutList.WriteString(list)
+	}
+
+	if err = s.prepareSparseCheckout(ctx, tmpRepo.Path, sparseCheckoutList.String()); err != nil {
+		return mergedChangesOutput{}, err
+	}
+
+	committer := params.Actor
+	if params.committer != nil {
+		committer = *params.committer
+	}
+	committerDate := time.Now().UTC()
+	if params.committerDate != nil {
+		committerDate = *params.committerDate
+	}
+
+	signing, err := s.configureCommitSigning(ctx, tmpRepo.Path, params.signingKey)
+	if err != nil {
+		return mergedChangesOutput{}, err
+	}
+
+	for _, commit := range commits {
+		log.Debug().Msgf("perform %s of commit %s", params.operation, commit.SHA)
+
+		message, author, authorDate := params.commitInfo(commit)
+
+		env := append(CreateEnvironmentForPush(ctx, params.WriteParams),
+			"GIT_AUTHOR_NAME="+author.Name,
+			"GIT_AUTHOR_EMAIL="+author.Email,
+			"GIT_AUTHOR_DATE="+authorDate.Format(time.RFC3339),
+			"GIT_COMMITTER_NAME="+committer.Name,
+			"GIT_COMMITTER_EMAIL="+committer.Email,
+			"GIT_COMMITTER_DATE="+committerDate.Format(time.RFC3339),
+		)
+
+		result, err := params.apply(ctx, tmpRepo.Path, params.mainline, []string{commit.SHA}, message, signing, env...)
+		if err != nil {
+			return mergedChangesOutput{}, fmt.Errorf("%s of commit %s failed: %w", params.operation, commit.SHA, err)
+		}
+
+		if len(result.ConflictFiles) > 0 {
+			return mergedChangesOutput{}, errors.PreconditionFailed(
+				"commit %s can't be applied cleanly on top of branch '%s'",
+				commit.SHA,
+				params.branch,
+				errors.Arg{Key: "commit_sha", Value: commit.SHA},
+				errors.Arg{Key: "conflict_files", Value: result.ConflictFiles})
+		}
+	}
+
+	newSHA, err := s.adapter.GetFullCommitID(ctx, tmpRepo.Path, baseBranch)
+	if err != nil {
+		return mergedChangesOutput{}, fmt.Errorf("failed to get full commit id of the new commit: %w", err)
+	}
+
+	targetBranch := params.branch
+	if params.newBranch != "" {
+		targetBranch = params.newBranch
+	}
+
+	refPath, err := GetRefPath(targetBranch, enum.RefTypeBranch)
+	if err != nil {
+		return mergedChangesOutput{}, fmt.Errorf("failed to generate full reference for branch '%s': %w",
+			targetBranch, err)
+	}
+
+	log.Debug().Msg("push to original repo")
+
+	if err = s.adapter.Push(ctx, tmpRepo.Path, types.PushOptions{
+		Remote: "origin",
+		Branch: baseBranch + ":" + refPath,
+		Env:    CreateEnvironmentForPush(ctx, params.WriteParams),
+	}); err != nil {
+		return mergedChangesOutput{}, fmt.Errorf("failed to push %s commits to ref '%s': %w",
+			params.operation, refPath, err)
+	}
+
+	log.Debug().Msg("done")
+
+	return mergedChangesOutput{
+		baseSHA: tmpRepo.BaseSHA,
+		newSHA:  newSHA,
+	}, nil
+}
+
+// prepareSparseCheckout limits the working tree of the temporary repository to the provided list of files
+// and populates the index from HEAD.
+func (s *Service) prepareSparseCheckout(ctx context.Context, tmpRepoPath string, sparseCheckoutList string) error {
+	infoPath := filepath.Join(tmpRepoPath, ".git", "info")
+	if err := os.MkdirAll(infoPath, 0o700); err != nil {
+		return fmt.Errorf("unable to create .git/info in tmpRepo.Path: %w", err)
+	}
+
+	sparseCheckoutListPath := filepath.Join(infoPath, "sparse-checkout")
+	if err := os.WriteFile(sparseCheckoutListPath, []byte(sparseCheckoutList), 0o600); err != nil {
+		return fmt.Errorf("unable to write .git/info/sparse-checkout file in tmpRepo.Path: %w", err)
+	}
+
+	for _, kv := range [][2]string{
+		{"filter.lfs.process", ""},
+		{"filter.lfs.required", "false"},
+		{"filter.lfs.clean", ""},
+		{"filter.lfs.smudge", ""},
+		{"core.sparseCheckout", "true"},
+	} {
+		if err := s.adapter.Config(ctx, tmpRepoPath, kv[0], kv[1]); err != nil {
+			return err
+		}
+	}
+
+	if err := s.adapter.ReadTree(ctx, tmpRepoPath, "HEAD", io.Discard); err != nil {
+		return fmt.Errorf("failed to read tree: %w", err)
+	}
+
+	return nil
+}
+
+// configureCommitSigning configures signing of commits in the temporary repository.
+// The provided key takes precedence over the server signing key. If neither is available, commits aren't signed.
+func (s *Service) configureCommitSigning(
+	ctx context.Context,
+	tmpRepoPath string,
+	key *SigningKey,
+) (types.CommitSigning, error) {
+	signingKey := s.signingKey
+	if key != nil {
+		signingKey = &types.SigningKey{
+			Format:     key.Format,
+			PrivateKey: key.PrivateKey,
+		}
+	}
+	if signingKey == nil {
+		return types.CommitSigning{}, nil
+	}
+
+	log.Ctx(ctx).Debug().Msg("configure commit signing")
+
+	signing, err := s.adapter.ConfigureSigning(ctx, tmpRepoPath, *signingKey)
+	if err != nil {
+		return types.CommitSigning{}, fmt.Errorf("failed to configure commit signing: %w", err)
+	}
+
+	return signing, nil
+}
@@ -81,6 +81,9 @@ type CommitFilesParams struct {
 	// AuthorDate overwrites the git author date used for committing the files
 	// (optional, default: committer date)
 	AuthorDate *time.Time
+
+	// SigningKey is the key used for signing the commit (optional, default: the commit isn't signed)
+	SigningKey *SigningKey
 }
 
 func (p *CommitFilesParams) Validate() error {
@@ -169,6 +172,19 @@ func (s *Service) CommitFiles(ctx context.Context, params *CommitFilesParams) (C
 		}
 	}
 
+	var signing types.CommitSigning
+	if params.SigningKey != nil {
+		log.Debug().Msg("configure commit signing")
+
+		signing, err = s.adapter.ConfigureSigning(ctx, shared.Path(), types.SigningKey{
+			Format:     params.SigningKey.Format,
+			PrivateKey: params.SigningKey.PrivateKey,
+		})
+		if err != nil {
+			return CommitFilesResponse{}, fmt.Errorf("failed to configure commit signing: %w", err)
+		}
+	}
+
 	log.Debug().Msg("write tree")
 
 	// Now write the tree
@@ -199,6 +215,7 @@ func (s *Service) CommitFiles(ctx context.Context, params *CommitFilesParams) (C
 		treeHash,
 		message,
 		false,
+		signing,
 		authorDate,
 		committerDate,
 	)
@@ -0,0 +1,114 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/errors"
+)
+
+type FindNewChangedPathsParams struct {
+	ReadParams
+	// Revs are the revisions (e.g. the new commits of a push) whose new commits are inspected.
+	// Commits that are reachable from any existing reference of the repository are ignored.
+	Revs []string
+	// AlternateObjectDirs are additional object directories required to access all objects,
+	// e.g. the quarantine directory of a push that's in progress.
+	AlternateObjectDirs []string
+}
+
+func (p *FindNewChangedPathsParams) Validate() error {
+	if err := p.ReadParams.Validate(); err != nil {
+		return err
+	}
+
+	for _, rev := range p.Revs {
+		if !isValidGitSHA(rev) {
+			return errors.InvalidArgument("the revision %q isn't a valid commit sha", rev)
+		}
+	}
+
+	return nil
+}
+
+type FindNewChangedPathsOutput struct {
+	Paths []string
+}
+
+// FindNewChangedPaths returns the paths of the files added or modified by the new commits of the provided revisions.
+func (s *Service) FindNewChangedPaths(
+	ctx context.Context,
+	params *FindNewChangedPathsParams,
+) (*FindNewChangedPathsOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	paths, err := s.adapter.FindNewChangedPaths(ctx, repoPath, params.AlternateObjectDirs, params.Revs)
+	if err != nil {
+		return nil, fmt.Errorf("FindNewChangedPaths: failed to find changed paths: %w", err)
+	}
+
+	return &FindNewChangedPathsOutput{
+		Paths: paths,
+	}, nil
+}
+
+type ListTreePathsParams struct {
+	ReadParams
+	// Rev is the commit sha whose tree is listed.
+	Rev string
+	// AlternateObjectDirs are additional object directories required to access all objects,
+	// e.g. the quarantine directory of a push that's in progress.
+	AlternateObjectDirs []string
+}
+
+func (p *ListTreePathsParams) Validate() error {
+	if err := p.ReadParams.Validate(); err != nil {
+		return err
+	}
+
+	if !isValidGitSHA(p.Rev) {
+		return errors.InvalidArgument("the revision %q isn't a valid commit sha", p.Rev)
+	}
+
+	return nil
+}
+
+type ListTreePathsOutput struct {
+	Paths []string
+}
+
+// ListTreePaths returns the paths of all files in the tree of the commit.
+func (s *Service) ListTreePaths(ctx context.Context, params *ListTreePathsParams) (*ListTreePathsOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	paths, err := s.adapter.ListTreePaths(ctx, repoPath, params.AlternateObjectDirs, params.Rev)
+	if err != nil {
+		return nil, fmt.Errorf("ListTreePaths: failed to list tree paths: %w", err)
+	}
+
+	return &ListTreePathsOutput{
+		Paths: paths,
+	}, nil
+}
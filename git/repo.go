@@ -111,6 +111,10 @@ type SyncRepositoryParams struct {
 	// RefSpecs [OPTIONAL] allows to override the refspecs that are being synced from the remote repository.
 	// By default all references present on the remote repository will be fetched (including scm internal ones).
 	RefSpecs []string
+
+	// Resolve [OPTIONAL] pins the host of the source to the provided addresses (curl "HOST:PORT:ADDRESS" format).
+	// If set, redirects are not followed.
+	Resolve []string
 }
 
 type SyncRepositoryOutput struct {
@@ -267,13 +271,13 @@ func (s *Service) SyncRepository(
 	}
 
 	// sync repo content
-	err = s.adapter.Sync(ctx, repoPath, params.Source, params.RefSpecs)
+	err = s.adapter.Sync(ctx, repoPath, params.Source, params.RefSpecs, params.Resolve)
 	if err != nil {
 		return nil, fmt.Errorf("SyncRepository: failed to sync git repo: %w", err)
 	}
 
 	// get remote default branch
-	defaultBranch, err := s.adapter.GetRemoteDefaultBranch(ctx, params.Source)
+	defaultBranch, err := s.adapter.GetRemoteDefaultBranch(ctx, params.Source, params.Resolve)
 	if errors.Is(err, types.ErrNoDefaultBranch) {
 		return &SyncRepositoryOutput{
 			DefaultBranch: "",
@@ -0,0 +1,262 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/check"
+	"github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/types"
+)
+
+// RevertParams is input structure object for reverting a merged pull request.
+type RevertParams struct {
+	WriteParams
+
+	// BaseBranch is the branch on top of which the revert commit is created.
+	BaseBranch string
+	// RevertBranch is the name of the new branch that will point to the revert commit.
+	RevertBranch string
+
+	// Method is the method that was used to merge the changes that are reverted.
+	Method enum.MergeMethod
+	// MergeSHA is the commit created by merging the changes (the last commit for the rebase method).
+	MergeSHA string
+	// MergeTargetSHA is the commit of the base branch the changes were merged on top of.
+	MergeTargetSHA string
+
+	Title   string
+	Message string
+
+	// Committer overwrites the git committer used for committing the revert
+	// (optional, default: actor)
+	Committer *Identity
+	// CommitterDate overwrites the git committer date used for committing the revert
+	// (optional, default: current time on server)
+	CommitterDate *time.Time
+	// Author overwrites the git author used for committing the revert
+	// (optional, default: committer)
+	Author *Identity
+	// AuthorDate overwrites the git author date used for committing the revert
+	// (optional, default: committer date)
+	AuthorDate *time.Time
+
+	// SigningKey overwrites the key used for signing the revert commit
+	// (optional, default: the server signing key, if configured)
+	SigningKey *SigningKey
+}
+
+func (p *RevertParams) Validate() error {
+	if err := p.WriteParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.BaseBranch == "" {
+		return errors.InvalidArgument("base branch is mandatory")
+	}
+
+	if p.RevertBranch == "" {
+		return errors.InvalidArgument("revert branch is mandatory")
+	}
+
+	if err := check.BranchName(p.RevertBranch); err != nil {
+		return errors.InvalidArgument(err.Error())
+	}
+
+	if !ValidateCommitSHA(p.MergeSHA) {
+		return errors.InvalidArgument("merge SHA is not a valid commit SHA")
+	}
+
+	if p.Method == enum.MergeMethodRebase && !ValidateCommitSHA(p.MergeTargetSHA) {
+		return errors.InvalidArgument("merge target SHA is not a valid commit SHA")
+	}
+
+	return nil
+}
+
+// RevertOutput is result object of the revert operation.
+type RevertOutput struct {
+	// BaseSHA is the sha of the latest commit on the base branch that was used for reverting.
+	BaseSHA string
+	// RevertSHA is the sha of the revert commit.
+	RevertSHA string
+}
+
+// Revert creates a new branch from the base branch with a single commit that reverts the merged changes.
+// The commits that are reverted depend on the method that was used to merge them (see applyMergedChanges).
+// If the changes can't be reverted cleanly, an error with the precondition failed status is returned
+// and the list of conflicting files is provided in its details.
+func (s *Service) Revert(ctx context.Context, params *RevertParams) (RevertOutput, error) {
+	if err := params.Validate(); err != nil {
+		return RevertOutput{}, fmt.Errorf("Revert: params not valid: %w", err)
+	}
+
+	out, err := s.applyMergedChanges(ctx, mergedChangesParams{
+		WriteParams:    params.WriteParams,
+		operation:      "revert",
+		apply:          s.adapter.Revert,
+		baseBranch:     params.BaseBranch,
+		newBranch:      params.RevertBranch,
+		method:         params.Method,
+		mergeSHA:       params.MergeSHA,
+		mergeTargetSHA: params.MergeTargetSHA,
+		title:          params.Title,
+		message:        params.Message,
+		committer:      params.Committer,
+		committerDate:  params.CommitterDate,
+		author:         params.Author,
+		authorDate:     params.AuthorDate,
+		signingKey:     params.SigningKey,
+	})
+	if err != nil {
+		return RevertOutput{}, fmt.Errorf("Revert: %w", err)
+	}
+
+	return RevertOutput{
+		BaseSHA:   out.baseSHA,
+		RevertSHA: out.newSHA,
+	}, nil
+}
+
+// RevertCommitParams is input structure object for reverting a single commit.
+type RevertCommitParams struct {
+	WriteParams
+
+	// Branch is the branch on top of which the revert commit is created.
+	Branch string
+	// NewBranch is the name of the new branch that will point to the revert commit.
+	// (optional, if not provided the Branch is updated)
+	NewBranch string
+
+	// CommitSHA is the commit that is reverted.
+	CommitSHA string
+	// Mainline is the parent number of the merge commit that is used as the base of the revert.
+	// (optional, required only if the commit is a merge commit)
+	Mainline int
+
+	// Title and Message of the revert commit (optional, by default they reference the reverted commit).
+	Title   string
+	Message string
+
+	// Committer overwrites the git committer used for committing the revert
+	// (optional, default: actor)
+	Committer *Identity
+	// CommitterDate overwrites the git committer date used for committing the revert
+	// (optional, default: current time on server)
+	CommitterDate *time.Time
+	// Author overwrites the git author used for committing the revert
+	// (optional, default: committer)
+	Author *Identity
+
+	// SigningKey overwrites the key used for signing the revert commit
+	// (optional, default: the server signing key, if configured)
+	SigningKey *SigningKey
+}
+
+func (p *RevertCommitParams) Validate() error {
+	if err := p.WriteParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.Branch == "" {
+		return errors.InvalidArgument("branch is mandatory")
+	}
+
+	if p.NewBranch != "" {
+		if err := check.BranchName(p.NewBranch); err != nil {
+			return errors.InvalidArgument(err.Error())
+		}
+	}
+
+	if !ValidateCommitSHA(p.CommitSHA) {
+		return errors.InvalidArgument("commit SHA is not a valid commit SHA")
+	}
+
+	if p.Mainline < 0 {
+		return errors.InvalidArgument("mainline can't be negative")
+	}
+
+	return nil
+}
+
+// RevertCommitOutput is result object of the commit revert operation.
+type RevertCommitOutput struct {
+	// BranchSHA is the sha of the latest commit on the branch that was used for reverting.
+	BranchSHA string
+	// RevertSHA is the sha of the revert commit.
+	RevertSHA string
+}
+
+// RevertCommit creates a commit that reverts the changes of a single commit on top of the branch.
+// The result is pushed to the branch, or to the new branch if one is provided.
+// If the changes can't be reverted cleanly, an error with the precondition failed status is returned
+// and the list of conflicting files is provided in its details.
+func (s *Service) RevertCommit(ctx context.Context, params *RevertCommitParams) (RevertCommitOutput, error) {
+	if err := params.Validate(); err != nil {
+		return RevertCommitOutput{}, fmt.Errorf("RevertCommit: params not valid: %w", err)
+	}
+
+	committerDate := time.Now().UTC()
+	if params.CommitterDate != nil {
+		committerDate = *params.CommitterDate
+	}
+
+	out, err := s.applyCommitChanges(ctx, commitChangesParams{
+		WriteParams: params.WriteParams,
+		operation:   "revert",
+		apply:       s.adapter.Revert,
+		branch:      params.Branch,
+		newBranch:   params.NewBranch,
+		commits:     []string{params.CommitSHA},
+		mainline:    params.Mainline,
+		commitInfo: func(commit *types.Commit) (string, Identity, time.Time) {
+			title := strings.TrimSpace(params.Title)
+			if title == "" {
+				title = fmt.Sprintf("Revert %q", commit.Title)
+			}
+
+			message := strings.TrimSpace(params.Message)
+			if message == "" {
+				message = fmt.Sprintf("This reverts commit %s.", commit.SHA)
+			}
+
+			author := params.Actor
+			if params.Committer != nil {
+				author = *params.Committer
+			}
+			if params.Author != nil {
+				author = *params.Author
+			}
+
+			return title + "\n\n" + message, author, committerDate
+		},
+		committer:     params.Committer,
+		committerDate: &committerDate,
+		signingKey:    params.SigningKey,
+	})
+	if err != nil {
+		return RevertCommitOutput{}, fmt.Errorf("RevertCommit: %w", err)
+	}
+
+	return RevertCommitOutput{
+		BranchSHA: out.baseSHA,
+		RevertSHA: out.newSHA,
+	}, nil
+}
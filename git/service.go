@@ -15,6 +15,7 @@
 package git
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 
@@ -35,6 +36,7 @@ type Service struct {
 	store          storage.Store
 	gitHookPath    string
 	reposGraveyard string
+	signingKey     *types.SigningKey
 }
 
 func New(
@@ -58,6 +60,25 @@ func New(
 			return nil, errdir
 		}
 	}
+
+	// load the server signing key, if configured
+	var signingKey *types.SigningKey
+	if config.Signing.Format != "" {
+		if _, ok := config.Signing.Format.Sanitize(); !ok {
+			return nil, errors.InvalidArgument("invalid signing key format: %s", config.Signing.Format)
+		}
+
+		privateKey, err := os.ReadFile(config.Signing.KeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read signing key: %w", err)
+		}
+
+		signingKey = &types.SigningKey{
+			Format:     config.Signing.Format,
+			PrivateKey: string(privateKey),
+		}
+	}
+
 	return &Service{
 		reposRoot:      reposRoot,
 		tmpDir:         config.TmpDir,
@@ -65,5 +86,6 @@ func New(
 		adapter:        adapter,
 		store:          storage,
 		gitHookPath:    config.HookPath,
+		signingKey:     signingKey,
 	}, nil
 }
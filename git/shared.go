@@ -45,6 +45,7 @@ type SharedRepo interface {
 		author, committer *types.Identity,
 		treeHash, message string,
 		signoff bool,
+		signing types.CommitSigning,
 		authorDate, committerDate time.Time,
 	) (string, error)
 	PushDeleteBranch(
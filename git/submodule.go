@@ -31,6 +31,7 @@ type GetSubmoduleOutput struct {
 }
 type Submodule struct {
 	Name string
+	Path string
 	URL  string
 }
 
@@ -53,3 +54,43 @@ func (s *Service) GetSubmodule(ctx context.Context, params *GetSubmoduleParams)
 		},
 	}, nil
 }
+
+type ListSubmodulesParams struct {
+	ReadParams
+	// GitREF is a git reference (branch / tag / commit SHA)
+	GitREF string
+}
+
+type ListSubmodulesOutput struct {
+	Submodules []Submodule
+}
+
+// ListSubmodules returns the submodules defined in the .gitmodules file of the provided git reference.
+func (s *Service) ListSubmodules(ctx context.Context, params *ListSubmodulesParams) (*ListSubmodulesOutput, error) {
+	if params == nil {
+		return nil, ErrNoParamsProvided
+	}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	gitSubmodules, err := s.adapter.ListSubmodules(ctx, repoPath, params.GitREF)
+	if err != nil {
+		return nil, fmt.Errorf("ListSubmodules: failed to list submodules: %w", err)
+	}
+
+	submodules := make([]Submodule, len(gitSubmodules))
+	for i, submodule := range gitSubmodules {
+		submodules[i] = Submodule{
+			Name: submodule.Name,
+			Path: submodule.Path,
+			URL:  submodule.URL,
+		}
+	}
+
+	return &ListSubmodulesOutput{
+		Submodules: submodules,
+	}, nil
+}
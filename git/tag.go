@@ -69,7 +69,9 @@ type CommitTag struct {
 	Title       string
 	Message     string
 	Tagger      *Signature
-	Commit      *Commit
+	// Signature is the cryptographic signature of the annotated tag (nil if the tag isn't signed).
+	Signature *CommitSignature
+	Commit    *Commit
 }
 
 type CreateCommitTagParams struct {
@@ -89,6 +91,12 @@ type CreateCommitTagParams struct {
 	// TaggerDate overwrites the git author date used in case the tag is annotated
 	// (optional, default: current time on server)
 	TaggerDate *time.Time
+
+	// Sign indicates that the tag should be signed. Only annotated tags can be signed.
+	Sign bool
+	// SigningKey overwrites the key used for signing the tag
+	// (optional, default: signing key of the server)
+	SigningKey *SigningKey
 }
 
 func (p *CreateCommitTagParams) Validate() error {
@@ -102,6 +110,9 @@ func (p *CreateCommitTagParams) Validate() error {
 	if p.Target == "" {
 		return errors.New("target cannot be empty")
 	}
+	if p.Sign && p.Message == "" {
+		return errors.InvalidArgument("only annotated tags can be signed, message cannot be empty")
+	}
 
 	return nil
 }
@@ -195,6 +206,7 @@ func (s *Service) ListCommitTags(
 				return nil, fmt.Errorf("signature mapping error: %w", err)
 			}
 			tags[wi].Tagger = tagger
+			tags[wi].Signature = mapCommitSignature(aTags[ai].Signature)
 
 			ai++
 			wi++
@@ -280,6 +292,15 @@ func (s *Service) CreateCommitTag(ctx context.Context, params *CreateCommitTagPa
 			When: taggerDate,
 		},
 	}
+	if params.Sign {
+		createTagRequest.Signing, err = s.configureCommitSigning(ctx, sharedRepo.Path(), params.SigningKey)
+		if err != nil {
+			return nil, fmt.Errorf("CreateCommitTag: %w", err)
+		}
+		if createTagRequest.Signing.Arg == "" {
+			return nil, errors.PreconditionFailed("no signing key is configured for signing the tag")
+		}
+	}
 	err = s.adapter.CreateTag(
 		ctx,
 		sharedRepo.Path(),
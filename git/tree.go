@@ -17,6 +17,9 @@ package git
 import (
 	"context"
 	"fmt"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/types"
 )
 
 // TreeNodeType specifies the different types of nodes in a git tree.
@@ -47,6 +50,8 @@ type TreeNode struct {
 	SHA  string
 	Name string
 	Path string
+	// LastCommit is the last commit that changed the node, it's set only if requested.
+	LastCommit *Commit
 }
 
 type ListTreeNodeParams struct {
@@ -145,11 +150,124 @@ func (s *Service) ListTreeNodes(ctx context.Context, params *ListTreeNodeParams)
 		nodes[i] = n
 	}
 
+	if params.IncludeLatestCommit && len(nodes) > 0 {
+		paths := make([]string, len(nodes))
+		for i := range nodes {
+			paths[i] = nodes[i].Path
+		}
+
+		// last commits of all nodes are found together, which is much faster than finding them one by one.
+		pathDetails, err := s.adapter.PathsDetails(ctx, repoPath, params.GitREF, paths)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get last commits of tree nodes in '%s': %w", params.GitREF, err)
+		}
+
+		for i := range pathDetails {
+			if pathDetails[i].LastCommit == nil {
+				continue
+			}
+
+			nodes[i].LastCommit, err = mapCommit(pathDetails[i].LastCommit)
+			if err != nil {
+				return nil, fmt.Errorf("failed to map last commit: %w", err)
+			}
+		}
+	}
+
 	return &ListTreeNodeOutput{
 		Nodes: nodes,
 	}, nil
 }
 
+type ListTreeParams struct {
+	ReadParams
+	// GitREF is a git reference (branch / tag / commit SHA)
+	GitREF string
+	Path   string
+	// Recursive includes the nodes of all subtrees, each tree is listed right before its content.
+	Recursive bool
+	// MaxDepth limits the depth of the nodes relative to Path, zero means no limit.
+	MaxDepth int
+	// Patterns are glob patterns of which at least one has to match a node.
+	// Patterns without a slash are matched against the node name, others against the full node path.
+	Patterns []string
+	// After is the path of the last node of the previous page.
+	After string
+	Limit int
+}
+
+func (params *ListTreeParams) Validate() error {
+	if params == nil {
+		return ErrNoParamsProvided
+	}
+
+	if err := params.ReadParams.Validate(); err != nil {
+		return err
+	}
+
+	if params.GitREF == "" {
+		return errors.InvalidArgument("git ref needs to be provided")
+	}
+
+	if params.MaxDepth < 0 {
+		return errors.InvalidArgument("max depth can't be negative")
+	}
+
+	if params.Limit <= 0 {
+		return errors.InvalidArgument("limit must be positive")
+	}
+
+	return nil
+}
+
+type ListTreeOutput struct {
+	// CommitSHA is the commit the git reference got resolved to.
+	// Following pages should be requested for it to get a consistent listing.
+	CommitSHA string
+	Nodes     []TreeNode
+	HasMore   bool
+}
+
+// ListTree lists a page of nodes of the tree reachable from the git reference via the specified path.
+func (s *Service) ListTree(ctx context.Context, params *ListTreeParams) (*ListTreeOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	commitSHA, err := s.adapter.ResolveRev(ctx, repoPath, params.GitREF+"^{commit}")
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve git ref '%s': %w", params.GitREF, err)
+	}
+
+	res, hasMore, err := s.adapter.ListTreeNodesPage(ctx, repoPath, commitSHA, params.Path,
+		types.ListTreeNodesOptions{
+			Recursive: params.Recursive,
+			MaxDepth:  params.MaxDepth,
+			Patterns:  params.Patterns,
+			After:     params.After,
+			Limit:     params.Limit,
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tree nodes: %w", err)
+	}
+
+	nodes := make([]TreeNode, len(res))
+	for i := range res {
+		nodes[i], err = mapTreeNode(&res[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to map rpc node: %w", err)
+		}
+	}
+
+	return &ListTreeOutput{
+		CommitSHA: commitSHA,
+		Nodes:     nodes,
+		HasMore:   hasMore,
+	}, nil
+}
+
 type PathsDetailsParams struct {
 	ReadParams
 	GitREF string
@@ -31,9 +31,30 @@ type Config struct {
 	TmpDir string
 	// HookPath points to the binary used as git server hook.
 	HookPath string
+	// PartialClone specifies whether upload-pack serves filtered packs and
+	// the objects missing in partial clones.
+	PartialClone bool
 
 	// LastCommitCache holds configuration options for the last commit cache.
 	LastCommitCache LastCommitCacheConfig
+
+	// ObjectCache holds configuration options for the cache of frequently read git objects.
+	ObjectCache ObjectCacheConfig
+
+	// PackCache holds configuration options for the cache of packfiles served to cloning clients.
+	PackCache PackCacheConfig
+
+	// Signing holds configuration options for signing commits created by the server.
+	Signing SigningConfig
+}
+
+// SigningConfig holds configuration options for signing commits created by the server.
+type SigningConfig struct {
+	// Format is the format of the signing key. Commits are not signed if it's empty.
+	Format enum.SigningFormat
+
+	// KeyPath is the path to the file containing the private signing key.
+	KeyPath string
 }
 
 // LastCommitCacheConfig holds configuration options for the last commit cache.
@@ -44,3 +65,27 @@ type LastCommitCacheConfig struct {
 	// Duration defines cache duration of last commit.
 	Duration time.Duration
 }
+
+// ObjectCacheConfig holds configuration options for the in-memory cache of frequently read git objects.
+type ObjectCacheConfig struct {
+	// Size is the maximum total size (in bytes) of the cached objects. The cache is disabled if it's not positive.
+	Size int64
+
+	// MaxObjectSize is the maximum size (in bytes) of a single cached object.
+	MaxObjectSize int64
+}
+
+// PackCacheConfig holds configuration options for the disk cache of packfiles served to cloning clients.
+type PackCacheConfig struct {
+	// Enabled specifies whether the packfiles are cached.
+	Enabled bool
+
+	// Dir is the directory containing the cached packfiles.
+	Dir string
+
+	// MaxAge is the duration for which a cached packfile is served.
+	MaxAge time.Duration
+
+	// MaxSize is the maximum total size (in bytes) of the cached packfiles.
+	MaxSize int64
+}
@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/enum"
 )
 
 const NilSHA = "0000000000000000000000000000000000000000"
@@ -146,6 +147,15 @@ type Commit struct {
 	Message   string    `json:"message,omitempty"`
 	Author    Signature `json:"author"`
 	Committer Signature `json:"committer"`
+
+	// Signature is the cryptographic signature of the commit (nil if the commit isn't signed).
+	Signature *CommitSignature `json:"signature,omitempty"`
+}
+
+// CommitSignature holds the signature of a commit and the signed payload (the commit object without the signature).
+type CommitSignature struct {
+	Signature string `json:"signature"`
+	Payload   string `json:"payload"`
 }
 
 type Branch struct {
@@ -171,6 +181,8 @@ type Tag struct {
 	Title      string
 	Message    string
 	Tagger     Signature
+	// Signature is the signature of the tag and the signed payload (nil if the tag isn't signed).
+	Signature *CommitSignature
 }
 
 type CreateTagOptions struct {
@@ -180,6 +192,9 @@ type CreateTagOptions struct {
 
 	// Tagger is the information used in case the tag is annotated (Message is provided).
 	Tagger Signature
+
+	// Signing holds the signing configuration in case the annotated tag should be signed.
+	Signing CommitSigning
 }
 
 // Signature represents the Author or Committer information.
@@ -224,6 +239,8 @@ type PushOptions struct {
 	Env            []string
 	Timeout        time.Duration
 	Mirror         bool
+	// Prune deletes the remote references matched by the pushed refspec that don't exist locally.
+	Prune bool
 }
 
 type TreeNodeWithCommit struct {
@@ -239,6 +256,21 @@ type TreeNode struct {
 	Path     string
 }
 
+// ListTreeNodesOptions specifies which nodes of a tree are listed.
+type ListTreeNodesOptions struct {
+	// Recursive includes the nodes of all subtrees, each tree is listed right before its content.
+	Recursive bool
+	// MaxDepth limits the depth of the nodes relative to the listed tree, zero means no limit.
+	MaxDepth int
+	// Patterns are glob patterns of which at least one has to match a node.
+	// Patterns without a slash are matched against the node name, others against the full node path.
+	Patterns []string
+	// After is the path of the node after which the listing continues.
+	After string
+	// Limit is the maximum number of returned nodes.
+	Limit int
+}
+
 // TreeNodeType specifies the different types of nodes in a git tree.
 // IMPORTANT: has to be consistent with rpc.TreeNodeType (proto).
 type TreeNodeType int
@@ -263,6 +295,8 @@ const (
 
 type Submodule struct {
 	Name string
+	// Path is the path of the submodule in the tree. It's populated only when listing submodules.
+	Path string
 	URL  string
 }
 
@@ -332,8 +366,9 @@ type BlameReader interface {
 }
 
 type BlamePart struct {
-	Commit *Commit  `json:"commit"`
-	Lines  []string `json:"lines"`
+	Commit   *Commit  `json:"commit"`
+	LineFrom int      `json:"line_from"`
+	Lines    []string `json:"lines"`
 }
 
 type PathRenameDetails struct {
@@ -344,11 +379,12 @@ type PathRenameDetails struct {
 }
 
 type CommitFilter struct {
-	Path      string
-	AfterRef  string
-	Since     int64
-	Until     int64
-	Committer string
+	Path        string
+	AfterRef    string
+	Since       int64
+	Until       int64
+	Committer   string
+	FirstParent bool
 }
 
 type TempRepository struct {
@@ -367,10 +403,50 @@ type FileContent struct {
 	Content []byte
 }
 
+// BinaryBlob describes a blob that contains binary content.
+type BinaryBlob struct {
+	SHA  string
+	Path string
+	Size int64
+}
+
+// BlobStats contains statistics about the blobs reachable from the references of a repository.
+type BlobStats struct {
+	// Blobs is the number of reachable blobs.
+	Blobs int
+	// Size is the total (uncompressed) size of the reachable blobs.
+	Size int64
+	// Largest are the largest reachable blobs, ordered by size in descending order.
+	Largest []BlobSize
+	// LFSObjects is the number of distinct LFS objects referenced by LFS pointer blobs.
+	LFSObjects int
+	// LFSSize is the total size of the distinct LFS objects referenced by LFS pointer blobs.
+	LFSSize int64
+}
+
+// BlobSize describes the size of a blob and a path under which it's stored.
+type BlobSize struct {
+	SHA  string
+	Path string
+	Size int64
+}
+
 type MergeResult struct {
 	ConflictFiles []string
 }
 
+// SigningKey is a private key used to sign commits.
+type SigningKey struct {
+	Format     enum.SigningFormat
+	PrivateKey string
+}
+
+// CommitSigning holds the git argument and the environment variables used for signing commits.
+type CommitSigning struct {
+	Arg string
+	Env []string
+}
+
 // ObjectCount represents the parsed information from the `git count-objects -v` command.
 // For field meanings, see https://git-scm.com/docs/git-count-objects#_options.
 type ObjectCount struct {
@@ -383,3 +459,37 @@ type ObjectCount struct {
 	Garbage       int
 	SizeGarbage   int64
 }
+
+// ProcessUsage describes the resources consumed by a git process.
+type ProcessUsage struct {
+	UserTime   time.Duration
+	SystemTime time.Duration
+}
+
+// ServicePackStats describes a single invocation of a git service.
+type ServicePackStats struct {
+	ProcessUsage
+	// Negotiation describes the request of an upload-pack invocation, it's nil for other services.
+	Negotiation *UploadPackNegotiation
+}
+
+// UploadPackNegotiation describes the request of an upload-pack invocation. With the smart http protocol,
+// every request is a single round of the negotiation (or, with protocol v2, a single command).
+type UploadPackNegotiation struct {
+	// ProtocolVersion is the version of the git wire protocol, versions 0 and 1 are equivalent for upload-pack.
+	ProtocolVersion int
+	// Command is the protocol v2 command (ls-refs or fetch), it's empty for older protocol versions.
+	Command     string
+	Wants       int
+	WantRefs    int
+	Haves       int
+	RefPrefixes int
+	// Done is true if the client ended the negotiation and the response contains the pack.
+	Done bool
+	// Filter is true if the client requested a partial clone/fetch.
+	Filter bool
+	// Deepen is true if the client requested a shallow clone/fetch.
+	Deepen bool
+	// PackCached is true if the response has been served from the pack cache.
+	PackCached bool
+}
@@ -27,4 +27,6 @@ type Config struct {
 	// finished and failed jobs will be purged from the DB.
 	BackgroundJobsRetentionTime time.Duration `envconfig:"JOBS_RETENTION_TIME" default:"120h"` // 5 days
 
+	// SpaceWeights are the scheduling weights of spaces, used to fairly share execution slots between spaces.
+	BackgroundJobsSpaceWeights map[int64]int `envconfig:"JOBS_SPACE_WEIGHTS"`
 }
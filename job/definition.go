@@ -25,6 +25,11 @@ type Definition struct {
 	MaxRetries int
 	Timeout    time.Duration
 	Data       string
+
+	// SpaceID is the ID of the space the job is run for. It's used to fairly
+	// distribute the available execution slots between spaces.
+	// Zero means the job doesn't belong to any space.
+	SpaceID int64
 }
 
 func (def *Definition) Validate() error {
@@ -70,5 +75,6 @@ func (def *Definition) toNewJob() *Job {
 		RecurringCron:       "",
 		ConsecutiveFailures: 0,
 		LastFailureError:    "",
+		SpaceID:             def.SpaceID,
 	}
 }
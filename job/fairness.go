@@ -0,0 +1,115 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+// spaceWeights holds the scheduling weights of spaces.
+// A space with a higher weight gets a proportionally larger share of the available execution slots.
+type spaceWeights struct {
+	weights       map[int64]int
+	defaultWeight int
+}
+
+func newSpaceWeights(weights map[int64]int) spaceWeights {
+	w := make(map[int64]int, len(weights))
+	for spaceID, weight := range weights {
+		if weight < 1 {
+			continue
+		}
+		w[spaceID] = weight
+	}
+
+	return spaceWeights{
+		weights:       w,
+		defaultWeight: 1,
+	}
+}
+
+func (sw spaceWeights) get(spaceID int64) int {
+	if weight, ok := sw.weights[spaceID]; ok {
+		return weight
+	}
+	return sw.defaultWeight
+}
+
+// selectFair picks up to n jobs from the list of ready jobs.
+// The ready jobs must be sorted by priority (highest first) and then by scheduled time (earliest first).
+// Jobs with higher priority are always picked first. Among the jobs with the same priority,
+// the job of the space with the lowest number of running and already picked jobs,
+// relative to its weight, is picked. Ties are resolved in favor of the earlier job.
+func selectFair(ready []*Job, running map[int64]int, weights spaceWeights, n int) []*Job {
+	if n <= 0 || len(ready) == 0 {
+		return nil
+	}
+
+	// split the jobs into per-space queues, preserving the order of the jobs.
+	queues := make(map[int64][]int)
+	spaceIDs := make([]int64, 0)
+	for i, job := range ready {
+		if _, ok := queues[job.SpaceID]; !ok {
+			spaceIDs = append(spaceIDs, job.SpaceID)
+		}
+		queues[job.SpaceID] = append(queues[job.SpaceID], i)
+	}
+
+	load := make(map[int64]int, len(spaceIDs))
+	for _, spaceID := range spaceIDs {
+		load[spaceID] = running[spaceID]
+	}
+
+	selected := make([]*Job, 0, n)
+
+	for len(selected) < n {
+		best := int64(0)
+		bestIdx := -1
+
+		for _, spaceID := range spaceIDs {
+			queue := queues[spaceID]
+			if len(queue) == 0 {
+				continue
+			}
+
+			idx := queue[0]
+			if bestIdx < 0 || isFairer(ready[idx], ready[bestIdx], idx, bestIdx,
+				load[spaceID], weights.get(spaceID), load[best], weights.get(best)) {
+				best = spaceID
+				bestIdx = idx
+			}
+		}
+
+		if bestIdx < 0 {
+			break
+		}
+
+		selected = append(selected, ready[bestIdx])
+		queues[best] = queues[best][1:]
+		load[best]++
+	}
+
+	return selected
+}
+
+// isFairer returns true if the job a should be picked before the job b.
+func isFairer(a, b *Job, idxA, idxB int, loadA, weightA, loadB, weightB int) bool {
+	if a.Priority != b.Priority {
+		return a.Priority > b.Priority
+	}
+
+	// compare loadA/weightA with loadB/weightB
+	if l, r := loadA*weightB, loadB*weightA; l != r {
+		return l < r
+	}
+
+	return idxA < idxB
+}
@@ -0,0 +1,122 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"testing"
+)
+
+func TestSelectFair(t *testing.T) {
+	job := func(uid string, spaceID int64, priority Priority) *Job {
+		return &Job{UID: uid, SpaceID: spaceID, Priority: priority}
+	}
+
+	tests := []struct {
+		name    string
+		ready   []*Job
+		running map[int64]int
+		weights map[int64]int
+		n       int
+		exp     []string
+	}{
+		{
+			name: "no-slots",
+			ready: []*Job{
+				job("a1", 1, JobPriorityNormal),
+			},
+			n:   0,
+			exp: []string{},
+		},
+		{
+			name: "round-robin",
+			ready: []*Job{
+				job("a1", 1, JobPriorityNormal),
+				job("a2", 1, JobPriorityNormal),
+				job("a3", 1, JobPriorityNormal),
+				job("b1", 2, JobPriorityNormal),
+				job("c1", 0, JobPriorityNormal),
+			},
+			n:   4,
+			exp: []string{"a1", "b1", "c1", "a2"},
+		},
+		{
+			name: "priority-first",
+			ready: []*Job{
+				job("a1", 1, JobPriorityElevated),
+				job("a2", 1, JobPriorityElevated),
+				job("b1", 2, JobPriorityNormal),
+			},
+			n:   2,
+			exp: []string{"a1", "a2"},
+		},
+		{
+			name: "running-jobs",
+			ready: []*Job{
+				job("a1", 1, JobPriorityNormal),
+				job("a2", 1, JobPriorityNormal),
+				job("b1", 2, JobPriorityNormal),
+				job("b2", 2, JobPriorityNormal),
+			},
+			running: map[int64]int{1: 5},
+			n:       2,
+			exp:     []string{"b1", "b2"},
+		},
+		{
+			name: "weights",
+			ready: []*Job{
+				job("a1", 1, JobPriorityNormal),
+				job("a2", 1, JobPriorityNormal),
+				job("a3", 1, JobPriorityNormal),
+				job("a4", 1, JobPriorityNormal),
+				job("b1", 2, JobPriorityNormal),
+				job("b2", 2, JobPriorityNormal),
+			},
+			weights: map[int64]int{1: 3},
+			n:       4,
+			exp:     []string{"a1", "b1", "a2", "a3"},
+		},
+		{
+			name: "invalid-weight",
+			ready: []*Job{
+				job("a1", 1, JobPriorityNormal),
+				job("a2", 1, JobPriorityNormal),
+				job("b1", 2, JobPriorityNormal),
+			},
+			weights: map[int64]int{2: 0},
+			n:       2,
+			exp:     []string{"a1", "b1"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			selected := selectFair(test.ready, test.running, newSpaceWeights(test.weights), test.n)
+
+			got := make([]string, len(selected))
+			for i, job := range selected {
+				got[i] = job.UID
+			}
+
+			if len(got) != len(test.exp) {
+				t.Fatalf("want: %v, got: %v", test.exp, got)
+			}
+			for i := range got {
+				if got[i] != test.exp[i] {
+					t.Fatalf("want: %v, got: %v", test.exp, got)
+				}
+			}
+		})
+	}
+}
@@ -24,8 +24,9 @@ import (
 )
 
 const (
-	PubSubTopicCancelJob   = "gitness:job:cancel_job"
-	PubSubTopicStateChange = "gitness:job:state_change"
+	PubSubTopicCancelJob          = "gitness:job:cancel_job"
+	PubSubTopicStateChange        = "gitness:job:state_change"
+	PubSubTopicScheduleProcessing = "gitness:job:schedule_processing"
 )
 
 func encodeStateChange(job *Job) ([]byte, error) {
@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"runtime/debug"
+	"strconv"
 	"sync"
 	"time"
 
@@ -41,10 +42,14 @@ type Scheduler struct {
 	instanceID    string
 	maxRunning    int
 	retentionTime time.Duration
+	spaceWeights  spaceWeights
 
 	// synchronization stuff
+	runMx        sync.Mutex
 	signal       chan time.Time
 	done         chan struct{}
+	registerOnce sync.Once
+	registerErr  error
 	wgRunning    sync.WaitGroup
 	cancelJobMx  sync.Mutex
 	cancelJobMap map[string]context.CancelFunc
@@ -58,6 +63,7 @@ func NewScheduler(
 	instanceID string,
 	maxRunning int,
 	retentionTime time.Duration,
+	spaceWeights map[int64]int,
 ) (*Scheduler, error) {
 	if maxRunning < 1 {
 		maxRunning = 1
@@ -71,6 +77,7 @@ func NewScheduler(
 		instanceID:    instanceID,
 		maxRunning:    maxRunning,
 		retentionTime: retentionTime,
+		spaceWeights:  newSpaceWeights(spaceWeights),
 
 		cancelJobMap: map[string]context.CancelFunc{},
 	}, nil
@@ -78,39 +85,62 @@ func NewScheduler(
 
 // Run runs the background job scheduler.
 // It's a blocking call. It blocks until the provided context is done.
+// Every instance runs its own scheduler, the jobs are distributed among them.
+// The scheduler can be started again after the previous call of Run has finished.
 //
 //nolint:gocognit // refactor if needed.
 func (s *Scheduler) Run(ctx context.Context) error {
+	s.runMx.Lock()
 	if s.done != nil {
+		s.runMx.Unlock()
 		return errors.New("already started")
 	}
+	done := make(chan struct{})
+	signal := make(chan time.Time, 1)
+	s.done = done
+	s.signal = signal
+	s.runMx.Unlock()
 
-	consumer := s.pubsubService.Subscribe(ctx, PubSubTopicCancelJob, s.handleCancelJob)
 	defer func() {
-		err := consumer.Close()
+		s.runMx.Lock()
+		close(done)
+		s.done = nil
+		s.signal = nil
+		s.runMx.Unlock()
+	}()
+
+	consumerCancel := s.pubsubService.Subscribe(ctx, PubSubTopicCancelJob, s.handleCancelJob)
+	defer func() {
+		err := consumerCancel.Close()
 		if err != nil {
 			log.Ctx(ctx).Err(err).
 				Msg("job scheduler: failed to close pubsub cancel job consumer")
 		}
 	}()
 
+	consumerSchedule := s.pubsubService.Subscribe(ctx, PubSubTopicScheduleProcessing, s.handleScheduleProcessing)
+	defer func() {
+		err := consumerSchedule.Close()
+		if err != nil {
+			log.Ctx(ctx).Err(err).
+				Msg("job scheduler: failed to close pubsub schedule processing consumer")
+		}
+	}()
+
 	if err := s.createNecessaryJobs(ctx); err != nil {
 		return fmt.Errorf("failed to create necessary jobs: %w", err)
 	}
 
-	if err := s.registerNecessaryJobs(); err != nil {
-		return fmt.Errorf("failed to register scheduler's internal jobs: %w", err)
+	s.registerOnce.Do(func() {
+		s.registerErr = s.registerNecessaryJobs()
+		s.executor.finishRegistration()
+	})
+	if s.registerErr != nil {
+		return fmt.Errorf("failed to register scheduler's internal jobs: %w", s.registerErr)
 	}
 
-	s.executor.finishRegistration()
-
 	log.Ctx(ctx).Debug().Msg("job scheduler: starting")
 
-	s.done = make(chan struct{})
-	defer close(s.done)
-
-	s.signal = make(chan time.Time, 1)
-
 	timer := newSchedulerTimer()
 	defer timer.Stop()
 
@@ -129,7 +159,7 @@ func (s *Scheduler) Run(ctx context.Context) error {
 			case <-ctx.Done():
 				return ctx.Err()
 
-			case newTime := <-s.signal:
+			case newTime := <-signal:
 				dur := timer.RescheduleEarlier(newTime)
 				if dur > 0 {
 					log.Ctx(ctx).Trace().
@@ -253,14 +283,50 @@ func (s *Scheduler) handleCancelJob(payload []byte) error {
 }
 
 // scheduleProcessing triggers processing of ready jobs.
-// This should be run after adding new jobs to the database.
-func (s *Scheduler) scheduleProcessing(scheduled time.Time) {
+// It returns false if the scheduler isn't running on this instance.
+func (s *Scheduler) scheduleProcessing(scheduled time.Time) bool {
+	s.runMx.Lock()
+	done, signal := s.done, s.signal
+	s.runMx.Unlock()
+
+	if done == nil {
+		return false
+	}
+
 	go func() {
 		select {
-		case <-s.done:
-		case s.signal <- scheduled:
+		case <-done:
+		case signal <- scheduled:
 		}
 	}()
+
+	return true
+}
+
+// notifyProcessing triggers processing of ready jobs on the instance that runs the scheduler.
+// This should be run after adding new jobs to the database.
+func (s *Scheduler) notifyProcessing(ctx context.Context, scheduled time.Time) {
+	if s.scheduleProcessing(scheduled) {
+		return
+	}
+
+	payload := []byte(strconv.FormatInt(scheduled.UnixMilli(), 10))
+
+	err := s.pubsubService.Publish(ctx, PubSubTopicScheduleProcessing, payload)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to publish job processing notification")
+	}
+}
+
+func (s *Scheduler) handleScheduleProcessing(payload []byte) error {
+	scheduled, err := strconv.ParseInt(string(payload), 10, 64)
+	if err != nil {
+		return nil // ignore malformed notifications
+	}
+
+	s.scheduleProcessing(time.UnixMilli(scheduled))
+
+	return nil
 }
 
 // scheduleIfHaveMoreJobs triggers processing of ready jobs if the timer is edgy.
@@ -284,7 +350,7 @@ func (s *Scheduler) RunJob(ctx context.Context, def Definition) error {
 		return fmt.Errorf("failed to add new job to the database: %w", err)
 	}
 
-	s.scheduleProcessing(time.UnixMilli(job.Scheduled))
+	s.notifyProcessing(ctx, time.UnixMilli(job.Scheduled))
 
 	return nil
 }
@@ -311,7 +377,7 @@ func (s *Scheduler) RunJobs(ctx context.Context, groupID string, defs []Definiti
 		}
 	}
 
-	s.scheduleProcessing(time.Now())
+	s.notifyProcessing(ctx, time.Now())
 
 	return nil
 }
@@ -337,13 +403,15 @@ func (s *Scheduler) processReadyJobs(ctx context.Context, now time.Time) (int, t
 		}
 	}()
 
-	availableCount, err := s.availableSlots(ctx)
+	running, err := s.store.CountRunningPerSpace(ctx)
 	if err != nil {
 		return 0, time.Time{}, false,
-			fmt.Errorf("failed to count available slots for job execution: %w", err)
+			fmt.Errorf("failed to count running jobs: %w", err)
 	}
 
-	// get one over the limit to check if all ready jobs are fetched
+	availableCount := s.availableSlots(running)
+
+	// get one over the limit for each space to check if all ready jobs are fetched
 	jobs, err := s.store.ListReady(ctx, now, availableCount+1)
 	if err != nil {
 		return 0, time.Time{}, false,
@@ -357,8 +425,8 @@ func (s *Scheduler) processReadyJobs(ctx context.Context, now time.Time) (int, t
 	)
 
 	if len(jobs) > availableCount {
-		// More jobs are ready than we are able to run.
-		jobs = jobs[:availableCount]
+		// More jobs are ready than we are able to run. Share the available slots fairly between spaces.
+		jobs = selectFair(jobs, running, s.spaceWeights, availableCount)
 	} else {
 		gotAllJobs = true
 		knownNextExecTime, err = s.store.NextScheduledTime(ctx, now)
@@ -397,18 +465,18 @@ func (s *Scheduler) processReadyJobs(ctx context.Context, now time.Time) (int, t
 	return countExecuted, knownNextExecTime, gotAllJobs, nil
 }
 
-func (s *Scheduler) availableSlots(ctx context.Context) (int, error) {
-	countRunning, err := s.store.CountRunning(ctx)
-	if err != nil {
-		return 0, err
+func (s *Scheduler) availableSlots(running map[int64]int) int {
+	countRunning := 0
+	for _, count := range running {
+		countRunning += count
 	}
 
 	availableCount := s.maxRunning - countRunning
 	if availableCount < 0 {
-		return 0, nil
+		return 0
 	}
 
-	return availableCount, nil
+	return availableCount
 }
 
 // runJob updates the job in the database and starts it in a separate goroutine.
@@ -45,11 +45,12 @@ type Store interface {
 	// UpdateProgress is used to update a job progress data.
 	UpdateProgress(ctx context.Context, job *Job) error
 
-	// CountRunning returns number of jobs that are currently being run.
-	CountRunning(ctx context.Context) (int, error)
+	// CountRunningPerSpace returns number of jobs that are currently being run, grouped by space ID.
+	CountRunningPerSpace(ctx context.Context) (map[int64]int, error)
 
-	// ListReady returns a list of jobs that are ready for execution.
-	ListReady(ctx context.Context, now time.Time, limit int) ([]*Job, error)
+	// ListReady returns a list of jobs that are ready for execution,
+	// with at most limitPerSpace jobs for each space.
+	ListReady(ctx context.Context, now time.Time, limitPerSpace int) ([]*Job, error)
 
 	// ListDeadlineExceeded returns a list of jobs that have exceeded their execution deadline.
 	ListDeadlineExceeded(ctx context.Context, now time.Time) ([]*Job, error)
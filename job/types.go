@@ -36,6 +36,7 @@ type Job struct {
 	ConsecutiveFailures int      `db:"job_consecutive_failures"`
 	LastFailureError    string   `db:"job_last_failure_error"`
 	GroupID             string   `db:"job_group_id"`
+	SpaceID             int64    `db:"job_space_id"`
 }
 
 type StateChange struct {
@@ -0,0 +1,32 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package leader
+
+import "time"
+
+type Config struct {
+	// InstanceID is the ID of the instance, used only for logging.
+	InstanceID string
+
+	// TTL is the duration after which the leadership expires if it isn't renewed.
+	TTL time.Duration
+
+	// RenewInterval is the interval at which the leader renews its leadership.
+	// It must be shorter than the TTL.
+	RenewInterval time.Duration
+
+	// RetryInterval is the interval at which standby instances try to acquire the leadership.
+	RetryInterval time.Duration
+}
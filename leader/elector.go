@@ -0,0 +1,161 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package leader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/harness/gitness/lock"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	lockNamespace = "leader"
+	lockKey       = "gitness"
+)
+
+// Elector elects a single leader among all instances sharing the same distributed lock service.
+// The leadership is held as a lock that the leader periodically extends. If the leader goes away
+// the lock expires and one of the standby instances takes over.
+type Elector struct {
+	mxManager lock.MutexManager
+	config    Config
+
+	isLeader atomic.Bool
+}
+
+func NewElector(mxManager lock.MutexManager, config Config) *Elector {
+	if config.TTL <= 0 {
+		config.TTL = 30 * time.Second
+	}
+	if config.RenewInterval <= 0 || config.RenewInterval >= config.TTL {
+		config.RenewInterval = config.TTL / 3
+	}
+	if config.RetryInterval <= 0 {
+		config.RetryInterval = config.RenewInterval / 2
+	}
+
+	return &Elector{
+		mxManager: mxManager,
+		config:    config,
+	}
+}
+
+// IsLeader returns true if this instance currently holds the leadership.
+func (e *Elector) IsLeader() bool {
+	return e.isLeader.Load()
+}
+
+// Run campaigns for the leadership until the provided context is done.
+// Every time the instance becomes the leader, the function fn is called with a context
+// that is canceled as soon as the leadership is lost. After fn returns the instance
+// becomes a standby and continues to campaign for the leadership.
+// If fn returns while the instance is still the leader, the leadership is released
+// and Run returns the error fn returned. It's a blocking call.
+func (e *Elector) Run(ctx context.Context, fn func(ctx context.Context) error) error {
+	log.Ctx(ctx).Info().
+		Str("instance_id", e.config.InstanceID).
+		Msg("leader election: campaigning for leadership")
+
+	for {
+		mx, err := e.mxManager.NewMutex(lockKey,
+			lock.WithNamespace(lockNamespace),
+			lock.WithExpiry(e.config.TTL),
+			lock.WithTries(1))
+		if err != nil {
+			return fmt.Errorf("failed to create leader election mutex: %w", err)
+		}
+
+		if err = mx.Lock(ctx); err == nil {
+			done, err := e.lead(ctx, mx, fn)
+			if done {
+				return err
+			}
+		} else {
+			log.Ctx(ctx).Trace().Err(err).Msg("leader election: leadership is held by another instance")
+		}
+
+		timer := time.NewTimer(e.config.RetryInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// lead runs the function fn and renews the leadership while the function is running.
+// It returns true if the function has finished while holding the leadership,
+// or false if the leadership has been lost.
+func (e *Elector) lead(
+	ctx context.Context,
+	mx lock.Mutex,
+	fn func(ctx context.Context) error,
+) (bool, error) {
+	log.Ctx(ctx).Info().
+		Str("instance_id", e.config.InstanceID).
+		Msg("leader election: acquired leadership")
+
+	e.isLeader.Store(true)
+	defer e.isLeader.Store(false)
+
+	leaderCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- fn(leaderCtx)
+	}()
+
+	ticker := time.NewTicker(e.config.RenewInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case err := <-errCh:
+			// Release the leadership immediately, so that a standby instance can take over without waiting.
+			// Use the background context because the context is likely done at this point.
+			if errUnlock := mx.Unlock(context.Background()); errUnlock != nil {
+				log.Ctx(ctx).Warn().Err(errUnlock).Msg("leader election: failed to release leadership")
+			} else {
+				log.Ctx(ctx).Info().Msg("leader election: released leadership")
+			}
+
+			return true, err
+
+		case <-ticker.C:
+			err := mx.Extend(leaderCtx)
+			if err != nil && ctx.Err() != nil {
+				continue // shutting down, the function will return shortly
+			}
+			if err != nil {
+				log.Ctx(ctx).Warn().Err(err).Msg("leader election: lost leadership")
+
+				cancel()
+				if err := <-errCh; err != nil && !errors.Is(err, context.Canceled) {
+					log.Ctx(ctx).Err(err).Msg("leader election: function failed after the leadership was lost")
+				}
+
+				return false, nil
+			}
+		}
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package leader
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/lock"
+)
+
+func TestElector_Failover(t *testing.T) {
+	mxManager := lock.NewInMemory(lock.Config{
+		App:        "gitness",
+		Namespace:  "default",
+		Expiry:     time.Second,
+		Tries:      1,
+		RetryDelay: 10 * time.Millisecond,
+	})
+
+	config := Config{
+		TTL:           300 * time.Millisecond,
+		RenewInterval: 50 * time.Millisecond,
+		RetryInterval: 20 * time.Millisecond,
+	}
+
+	elector1 := NewElector(mxManager, config)
+	elector2 := NewElector(mxManager, config)
+
+	leading1 := make(chan struct{})
+	leading2 := make(chan struct{})
+
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	defer cancel1()
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	defer cancel2()
+
+	done1 := make(chan error, 1)
+	go func() {
+		done1 <- elector1.Run(ctx1, func(ctx context.Context) error {
+			close(leading1)
+			<-ctx.Done()
+			return ctx.Err()
+		})
+	}()
+
+	select {
+	case <-leading1:
+	case <-time.After(time.Second):
+		t.Fatal("the first elector didn't become the leader")
+	}
+
+	done2 := make(chan error, 1)
+	go func() {
+		done2 <- elector2.Run(ctx2, func(ctx context.Context) error {
+			close(leading2)
+			<-ctx.Done()
+			return ctx.Err()
+		})
+	}()
+
+	// the second elector must stay standby for longer than the TTL
+	select {
+	case <-leading2:
+		t.Fatal("the second elector became the leader while the first one is still leading")
+	case <-time.After(2 * config.TTL):
+	}
+
+	if !elector1.IsLeader() || elector2.IsLeader() {
+		t.Fatalf("unexpected leadership status: first=%t second=%t", elector1.IsLeader(), elector2.IsLeader())
+	}
+
+	// stop the leader, the standby must take over
+	cancel1()
+	if err := <-done1; !errors.Is(err, context.Canceled) {
+		t.Fatalf("unexpected error from the first elector: %v", err)
+	}
+
+	select {
+	case <-leading2:
+	case <-time.After(time.Second):
+		t.Fatal("the second elector didn't take over the leadership")
+	}
+
+	if elector1.IsLeader() || !elector2.IsLeader() {
+		t.Fatalf("unexpected leadership status: first=%t second=%t", elector1.IsLeader(), elector2.IsLeader())
+	}
+
+	cancel2()
+	<-done2
+}
+
+func TestElector_FunctionFinished(t *testing.T) {
+	mxManager := lock.NewInMemory(lock.Config{
+		App:        "gitness",
+		Namespace:  "default",
+		Expiry:     time.Second,
+		Tries:      1,
+		RetryDelay: 10 * time.Millisecond,
+	})
+
+	elector := NewElector(mxManager, Config{TTL: time.Second})
+
+	errTest := errors.New("test")
+
+	err := elector.Run(context.Background(), func(context.Context) error {
+		return errTest
+	})
+	if !errors.Is(err, errTest) {
+		t.Fatalf("expected the function's error, got: %v", err)
+	}
+
+	if elector.IsLeader() {
+		t.Fatal("elector must not be the leader after the function has finished")
+	}
+
+	// the leadership must have been released
+	mx, err := mxManager.NewMutex(lockKey, lock.WithNamespace(lockNamespace))
+	if err != nil {
+		t.Fatalf("failed to create mutex: %v", err)
+	}
+	if err = mx.Lock(context.Background()); err != nil {
+		t.Fatalf("the leadership wasn't released: %v", err)
+	}
+}
@@ -14,7 +14,14 @@
 
 package livelog
 
-import "context"
+import (
+	"context"
+	"errors"
+)
+
+// ErrSubscriberLagged is returned when a subscriber doesn't keep up with the log stream
+// and lines had to be dropped. The subscriber should resume tailing from the last received line.
+var ErrSubscriberLagged = errors.New("stream: subscriber lagged behind")
 
 // Line represents a line in the logs.
 type Line struct {
@@ -47,6 +54,9 @@ type LogStream interface {
 	// Tail tails the log stream.
 	Tail(context.Context, int64) (<-chan *Line, <-chan error)
 
+	// TailFrom tails the log stream, skipping the buffered lines positioned before the offset.
+	TailFrom(ctx context.Context, id int64, offset int) (<-chan *Line, <-chan error)
+
 	// Info returns internal stream information.
 	Info(context.Context) *LogStreamInfo
 }
@@ -68,13 +68,17 @@ func (s *streamer) Write(_ context.Context, id int64, line *Line) error {
 }
 
 func (s *streamer) Tail(ctx context.Context, id int64) (<-chan *Line, <-chan error) {
+	return s.TailFrom(ctx, id, 0)
+}
+
+func (s *streamer) TailFrom(ctx context.Context, id int64, offset int) (<-chan *Line, <-chan error) {
 	s.Lock()
 	stream, ok := s.streams[id]
 	s.Unlock()
 	if !ok {
 		return nil, nil
 	}
-	return stream.subscribe(ctx)
+	return stream.subscribe(ctx, offset)
 }
 
 func (s *streamer) Info(_ context.Context) *LogStreamInfo {
@@ -25,11 +25,17 @@ import (
 // including any logdata stored in these structures.
 const bufferSize = 5000
 
+// this is the maximum size of the log data stored in the
+// history of a stream. Without it a few very long lines
+// would make the memory used by the history unbounded.
+const bufferMaxBytes = 4 << 20 // 4 MiB
+
 type stream struct {
 	sync.Mutex
 
-	hist []*Line
-	list map[*subscriber]struct{}
+	hist      []*Line
+	histBytes int
+	list      map[*subscriber]struct{}
 }
 
 func newStream() *stream {
@@ -41,28 +47,38 @@ func newStream() *stream {
 func (s *stream) write(line *Line) error {
 	s.Lock()
 	s.hist = append(s.hist, line)
+	s.histBytes += len(line.Message)
 	for l := range s.list {
-		l.publish(line)
+		if !l.publish(line) {
+			delete(s.list, l)
+		}
 	}
 	// the history should not be unbounded. The history
-	// slice is capped and items are removed in a FIFO
-	// ordering when capacity is reached.
-	if size := len(s.hist); size >= bufferSize {
-		s.hist = s.hist[size-bufferSize:]
+	// slice is capped by the number of lines and by their
+	// size, and items are removed in a FIFO ordering when
+	// capacity is reached. The last line is always kept.
+	n := 0
+	for size := len(s.hist); size-n > bufferSize || (s.histBytes > bufferMaxBytes && size-n > 1); n++ {
+		s.histBytes -= len(s.hist[n].Message)
+		s.hist[n] = nil
 	}
+	s.hist = s.hist[n:]
 	s.Unlock()
 	return nil
 }
 
-func (s *stream) subscribe(ctx context.Context) (<-chan *Line, <-chan error) {
+func (s *stream) subscribe(ctx context.Context, offset int) (<-chan *Line, <-chan error) {
 	sub := &subscriber{
 		handler: make(chan *Line, bufferSize),
 		closec:  make(chan struct{}),
 	}
-	err := make(chan error)
+	err := make(chan error, 1)
 
 	s.Lock()
 	for _, line := range s.hist {
+		if line.Number < offset {
+			continue
+		}
 		sub.publish(line)
 	}
 	s.list[sub] = struct{}{}
@@ -75,6 +91,14 @@ func (s *stream) subscribe(ctx context.Context) (<-chan *Line, <-chan error) {
 		case <-ctx.Done():
 			sub.close()
 		}
+
+		s.Lock()
+		delete(s.list, sub)
+		s.Unlock()
+
+		if sub.isLagged() {
+			err <- ErrSubscriberLagged
+		}
 	}()
 	return sub.handler, err
 }
@@ -0,0 +1,111 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package livelog
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStreamTailFrom(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := NewMemory()
+	_ = s.Create(ctx, 1)
+	for i := 0; i < 10; i++ {
+		_ = s.Write(ctx, 1, &Line{Number: i})
+	}
+
+	linec, errc := s.TailFrom(ctx, 1, 7)
+	_ = s.Write(ctx, 1, &Line{Number: 10})
+	_ = s.Delete(ctx, 1)
+
+	got := drain(t, linec, errc)
+	want := []int{7, 8, 9, 10}
+	if len(got) != len(want) {
+		t.Fatalf("want lines %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("want lines %v, got %v", want, got)
+		}
+	}
+}
+
+func TestStreamHistoryBytes(t *testing.T) {
+	s := newStream()
+	message := strings.Repeat("x", bufferMaxBytes/4)
+	for i := 0; i < 10; i++ {
+		_ = s.write(&Line{Number: i, Message: message})
+	}
+
+	if s.histBytes > bufferMaxBytes {
+		t.Errorf("history size %d exceeds the limit %d", s.histBytes, bufferMaxBytes)
+	}
+	if len(s.hist) != 4 || s.hist[0].Number != 6 {
+		t.Errorf("expected the last 4 lines in the history, got %d lines", len(s.hist))
+	}
+}
+
+func TestStreamSubscriberLagged(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := newStream()
+	_, errc := s.subscribe(ctx, 0)
+	for i := 0; i <= bufferSize; i++ {
+		_ = s.write(&Line{Number: i})
+	}
+
+	select {
+	case err := <-errc:
+		if !errors.Is(err, ErrSubscriberLagged) {
+			t.Errorf("expected lagged error, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("lagged subscriber wasn't closed")
+	}
+}
+
+// drain returns the positions of the lines received until the stream ends.
+func drain(t *testing.T, linec <-chan *Line, errc <-chan error) []int {
+	t.Helper()
+
+	var positions []int
+	for {
+		select {
+		case line := <-linec:
+			positions = append(positions, line.Number)
+		case err := <-errc:
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			for {
+				select {
+				case line := <-linec:
+					positions = append(positions, line.Number)
+				default:
+					return positions
+				}
+			}
+		case <-time.After(time.Second):
+			t.Fatal("stream didn't end")
+		}
+	}
+}
@@ -24,20 +24,37 @@ type subscriber struct {
 	handler chan *Line
 	closec  chan struct{}
 	closed  bool
+	lagged  bool
 }
 
-func (s *subscriber) publish(line *Line) {
+// publish sends the line to the subscriber. It returns false
+// if the subscriber is closed and should no longer receive lines.
+func (s *subscriber) publish(line *Line) bool {
 	select {
 	case <-s.closec:
+		return false
 	case s.handler <- line:
+		return true
 	default:
 		// lines are sent on a buffered channel. If there
 		// is a slow consumer that is not processing events,
-		// the buffered channel will fill and newer messages
-		// are ignored.
+		// the buffered channel will fill. Instead of silently
+		// dropping newer messages, the subscriber is closed,
+		// so the consumer can resume from its last line.
+		s.Lock()
+		s.lagged = true
+		s.Unlock()
+		s.close()
+		return false
 	}
 }
 
+func (s *subscriber) isLagged() bool {
+	s.Lock()
+	defer s.Unlock()
+	return s.lagged
+}
+
 func (s *subscriber) close() {
 	s.Lock()
 	if !s.closed {
@@ -146,3 +146,39 @@ func Test_inMemMutex_LockAndWait(t *testing.T) {
 	go fn(3)
 	wg.Wait()
 }
+
+func Test_inMemMutex_Extend(t *testing.T) {
+	manager := NewInMemory(Config{
+		App:        "gitness",
+		Namespace:  "leader",
+		Expiry:     300 * time.Millisecond,
+		Tries:      1,
+		RetryDelay: 100 * time.Millisecond,
+	})
+
+	mx, err := manager.NewMutex("key1")
+	require.NoError(t, err)
+
+	err = mx.Extend(context.Background())
+	require.Error(t, err, "extending a lock that isn't held must fail")
+
+	err = mx.Lock(context.Background())
+	require.NoError(t, err)
+
+	// keep extending the lock past its original expiry
+	for i := 0; i < 3; i++ {
+		time.Sleep(200 * time.Millisecond)
+		require.NoError(t, mx.Extend(context.Background()))
+	}
+
+	other, err := manager.NewMutex("key1")
+	require.NoError(t, err)
+	require.Error(t, other.Lock(context.Background()), "extended lock must still be held")
+
+	// let the lock expire and have it taken over
+	time.Sleep(400 * time.Millisecond)
+	require.NoError(t, other.Lock(context.Background()))
+	require.Error(t, mx.Extend(context.Background()), "extending an expired lock must fail")
+
+	require.NoError(t, other.Unlock(context.Background()))
+}
@@ -0,0 +1,163 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanner
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	clamdChunkSize = 32 << 10 // 32 KiB
+
+	clamdResponseOK    = "OK"
+	clamdResponseFound = " FOUND"
+	clamdResponseError = " ERROR"
+)
+
+// ClamdScanner scans files by streaming them to a clamd daemon using the INSTREAM command.
+// See https://linux.die.net/man/8/clamd for the details of the protocol.
+type ClamdScanner struct {
+	network string
+	address string
+	timeout time.Duration
+}
+
+func NewClamdScanner(config Config) (Scanner, error) {
+	u, err := url.Parse(config.Address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse clamd address: %w", err)
+	}
+
+	s := &ClamdScanner{
+		timeout: config.Timeout,
+	}
+
+	switch u.Scheme {
+	case "tcp":
+		s.network = "tcp"
+		s.address = u.Host
+	case "unix":
+		s.network = "unix"
+		s.address = u.Path
+	default:
+		return nil, fmt.Errorf("unsupported clamd address scheme %q, expected tcp or unix", u.Scheme)
+	}
+
+	if s.address == "" {
+		return nil, errors.New("clamd address is required")
+	}
+
+	return s, nil
+}
+
+func (s *ClamdScanner) Scan(ctx context.Context, content io.Reader) (Result, error) {
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, s.network, s.address)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to connect to clamd: %w", err)
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	if err = clamdStream(conn, content); err != nil {
+		return Result{}, err
+	}
+
+	// the response is terminated with a null character as the command is prefixed with "z".
+	response, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return Result{}, fmt.Errorf("failed to read clamd response: %w", err)
+	}
+
+	return parseClamdResponse(response)
+}
+
+// clamdStream sends the content to clamd using the INSTREAM command:
+// the content is sent in chunks, each prefixed with its length, and terminated by a zero-length chunk.
+func clamdStream(w io.Writer, content io.Reader) error {
+	if _, err := w.Write([]byte("zINSTREAM\x00")); err != nil {
+		return fmt.Errorf("failed to send INSTREAM command to clamd: %w", err)
+	}
+
+	buf := make([]byte, 4+clamdChunkSize)
+	for {
+		n, err := io.ReadFull(content, buf[4:])
+		if n > 0 {
+			binary.BigEndian.PutUint32(buf[:4], uint32(n))
+			if _, wErr := w.Write(buf[:4+n]); wErr != nil {
+				return fmt.Errorf("failed to send content to clamd: %w", wErr)
+			}
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read content: %w", err)
+		}
+	}
+
+	if _, err := w.Write([]byte{0, 0, 0, 0}); err != nil {
+		return fmt.Errorf("failed to terminate content stream to clamd: %w", err)
+	}
+
+	return nil
+}
+
+// parseClamdResponse parses the clamd response to the INSTREAM command,
+// e.g. "stream: OK" or "stream: Eicar-Signature FOUND".
+func parseClamdResponse(response string) (Result, error) {
+	response = strings.TrimSpace(strings.TrimRight(response, "\x00"))
+
+	_, verdict, ok := strings.Cut(response, ": ")
+	if !ok {
+		return Result{}, fmt.Errorf("unexpected clamd response: %q", response)
+	}
+
+	switch {
+	case verdict == clamdResponseOK:
+		return Result{
+			Status: StatusClean,
+			Engine: ProviderClamd,
+		}, nil
+	case strings.HasSuffix(verdict, clamdResponseFound):
+		return Result{
+			Status: StatusInfected,
+			Threat: strings.TrimSuffix(verdict, clamdResponseFound),
+			Engine: ProviderClamd,
+		}, nil
+	case strings.HasSuffix(verdict, clamdResponseError):
+		return Result{}, fmt.Errorf("clamd failed to scan the content: %s", strings.TrimSuffix(verdict, clamdResponseError))
+	default:
+		return Result{}, fmt.Errorf("unexpected clamd response: %q", response)
+	}
+}
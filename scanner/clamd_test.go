@@ -0,0 +1,131 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanner
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeClamd accepts a single INSTREAM command and responds with the provided response.
+func fakeClamd(t *testing.T, response string) (string, <-chan string) {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %s", err)
+	}
+	t.Cleanup(func() { _ = l.Close() })
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		r := bufio.NewReader(conn)
+		if cmd, _ := r.ReadString(0); cmd != "zINSTREAM\x00" {
+			return
+		}
+
+		var content strings.Builder
+		for {
+			var size uint32
+			if err := binary.Read(r, binary.BigEndian, &size); err != nil || size == 0 {
+				break
+			}
+			if _, err := io.CopyN(&content, r, int64(size)); err != nil {
+				return
+			}
+		}
+
+		received <- content.String()
+		_, _ = conn.Write([]byte(response + "\x00"))
+	}()
+
+	return "tcp://" + l.Addr().String(), received
+}
+
+func TestClamdScanner(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		want     Result
+		wantErr  bool
+	}{
+		{
+			name:     "clean",
+			response: "stream: OK",
+			want:     Result{Status: StatusClean, Engine: ProviderClamd},
+		},
+		{
+			name:     "infected",
+			response: "stream: Eicar-Signature FOUND",
+			want:     Result{Status: StatusInfected, Threat: "Eicar-Signature", Engine: ProviderClamd},
+		},
+		{
+			name:     "error",
+			response: "INSTREAM size limit exceeded. ERROR",
+			wantErr:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			address, received := fakeClamd(t, test.response)
+
+			s, err := NewClamdScanner(Config{Address: address, Timeout: 5 * time.Second})
+			if err != nil {
+				t.Fatalf("failed to create scanner: %s", err)
+			}
+
+			content := strings.Repeat("x", 3*clamdChunkSize+7)
+
+			got, err := s.Scan(context.Background(), strings.NewReader(content))
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got %+v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to scan: %s", err)
+			}
+
+			if got != test.want {
+				t.Errorf("want=%+v got=%+v", test.want, got)
+			}
+			if r := <-received; r != content {
+				t.Errorf("clamd received %d bytes, expected %d", len(r), len(content))
+			}
+		})
+	}
+}
+
+func TestNewClamdScannerInvalidAddress(t *testing.T) {
+	for _, address := range []string{"", "localhost:3310", "http://localhost:3310", "tcp://"} {
+		if _, err := NewClamdScanner(Config{Address: address}); err == nil {
+			t.Errorf("expected an error for address %q", address)
+		}
+	}
+}
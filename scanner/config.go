@@ -0,0 +1,47 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanner
+
+import "time"
+
+type Provider string
+
+const (
+	// ProviderNone disables the scanning of files.
+	ProviderNone Provider = "none"
+	// ProviderClamd scans files using the INSTREAM command of a clamd daemon.
+	ProviderClamd Provider = "clamd"
+	// ProviderICAP scans files using the RESPMOD method of an ICAP (RFC 3507) service.
+	ProviderICAP Provider = "icap"
+)
+
+// Action defines what happens with files that are found to be infected.
+type Action string
+
+const (
+	// ActionBlock rejects infected files, they are never stored.
+	ActionBlock Action = "block"
+	// ActionQuarantine stores infected files in quarantine, where they are not accessible to users.
+	ActionQuarantine Action = "quarantine"
+)
+
+type Config struct {
+	Provider Provider
+	// Address is the address of the scanning service,
+	// e.g. "tcp://localhost:3310" or "unix:///run/clamd.ctl" for clamd,
+	// or "icap://localhost:1344/avscan" for ICAP.
+	Address string
+	Timeout time.Duration
+}
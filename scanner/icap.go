@@ -0,0 +1,212 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanner
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	icapDefaultPort = "1344"
+	icapChunkSize   = 32 << 10 // 32 KiB
+
+	// icapHTTPResponseHeader is the encapsulated http response header of the scanned content.
+	icapHTTPResponseHeader = "HTTP/1.1 200 OK\r\nContent-Type: application/octet-stream\r\n\r\n"
+)
+
+// icapThreatHeaders are the (non-standard) headers used by ICAP services to report the found threats.
+var icapThreatHeaders = []string{
+	"X-Infection-Found",
+	"X-Violations-Found",
+	"X-Virus-Id",
+	"X-Virus-Name",
+}
+
+// ICAPScanner scans files by sending them to an ICAP service (RFC 3507) using the RESPMOD method.
+// The service is expected to respond with "204 No Content" for clean files.
+type ICAPScanner struct {
+	address string
+	service string
+	timeout time.Duration
+}
+
+func NewICAPScanner(config Config) (Scanner, error) {
+	u, err := url.Parse(config.Address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse icap address: %w", err)
+	}
+
+	if u.Scheme != "icap" {
+		return nil, fmt.Errorf("unsupported icap address scheme %q, expected icap", u.Scheme)
+	}
+
+	if u.Hostname() == "" {
+		return nil, errors.New("icap address is required")
+	}
+
+	address := u.Host
+	if u.Port() == "" {
+		address = net.JoinHostPort(u.Hostname(), icapDefaultPort)
+	}
+
+	// the service URI sent in the request line always contains the port.
+	u.Host = address
+
+	return &ICAPScanner{
+		address: address,
+		service: u.String(),
+		timeout: config.Timeout,
+	}, nil
+}
+
+func (s *ICAPScanner) Scan(ctx context.Context, content io.Reader) (Result, error) {
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", s.address)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to connect to icap service: %w", err)
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	w := bufio.NewWriter(conn)
+	if err = s.writeRequest(w, content); err != nil {
+		return Result{}, err
+	}
+	if err = w.Flush(); err != nil {
+		return Result{}, fmt.Errorf("failed to send request to icap service: %w", err)
+	}
+
+	return readICAPResponse(bufio.NewReader(conn))
+}
+
+// writeRequest writes the RESPMOD request, encapsulating the content as the body of an http response.
+func (s *ICAPScanner) writeRequest(w *bufio.Writer, content io.Reader) error {
+	host, _, _ := net.SplitHostPort(s.address)
+
+	_, _ = fmt.Fprintf(w, "RESPMOD %s ICAP/1.0\r\n", s.service)
+	_, _ = fmt.Fprintf(w, "Host: %s\r\n", host)
+	_, _ = fmt.Fprintf(w, "Allow: 204\r\n")
+	_, _ = fmt.Fprintf(w, "Encapsulated: res-hdr=0, res-body=%d\r\n", len(icapHTTPResponseHeader))
+	_, _ = fmt.Fprintf(w, "\r\n")
+	_, _ = w.WriteString(icapHTTPResponseHeader)
+
+	// the encapsulated body is always sent using the chunked transfer encoding.
+	buf := make([]byte, icapChunkSize)
+	for {
+		n, err := io.ReadFull(content, buf)
+		if n > 0 {
+			_, _ = fmt.Fprintf(w, "%x\r\n", n)
+			_, _ = w.Write(buf[:n])
+			_, _ = w.WriteString("\r\n")
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read content: %w", err)
+		}
+	}
+
+	_, err := w.WriteString("0\r\n\r\n")
+	if err != nil {
+		return fmt.Errorf("failed to send request to icap service: %w", err)
+	}
+
+	return nil
+}
+
+// readICAPResponse reads the status and the headers of the ICAP response.
+// The encapsulated content of the response is ignored.
+func readICAPResponse(r *bufio.Reader) (Result, error) {
+	tp := textproto.NewReader(r)
+
+	statusLine, err := tp.ReadLine()
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to read icap response: %w", err)
+	}
+
+	// the status line is in the format "ICAP/1.0 204 No Content"
+	parts := strings.SplitN(statusLine, " ", 3)
+	if len(parts) < 2 || !strings.HasPrefix(parts[0], "ICAP/") {
+		return Result{}, fmt.Errorf("unexpected icap response: %q", statusLine)
+	}
+
+	status, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return Result{}, fmt.Errorf("unexpected icap response status: %q", statusLine)
+	}
+
+	header, err := tp.ReadMIMEHeader()
+	if err != nil && !errors.Is(err, io.EOF) {
+		return Result{}, fmt.Errorf("failed to read icap response headers: %w", err)
+	}
+
+	switch status {
+	case 204:
+		return Result{
+			Status: StatusClean,
+			Engine: ProviderICAP,
+		}, nil
+	case 200:
+		// the service modified the content, which is how threats are reported.
+		threat := "unknown"
+		for _, h := range icapThreatHeaders {
+			if v := header.Get(h); v != "" {
+				threat = parseICAPThreat(v)
+				break
+			}
+		}
+
+		return Result{
+			Status: StatusInfected,
+			Threat: threat,
+			Engine: ProviderICAP,
+		}, nil
+	default:
+		return Result{}, fmt.Errorf("icap service failed to scan the content: %s", statusLine)
+	}
+}
+
+// parseICAPThreat extracts the name of the threat from a threat header,
+// e.g. "Type=0; Resolution=2; Threat=Eicar-Test-Signature;" returns "Eicar-Test-Signature".
+func parseICAPThreat(value string) string {
+	for _, attr := range strings.Split(value, ";") {
+		k, v, ok := strings.Cut(strings.TrimSpace(attr), "=")
+		if ok && strings.EqualFold(k, "Threat") {
+			return strings.TrimSpace(v)
+		}
+	}
+
+	return strings.TrimSpace(value)
+}
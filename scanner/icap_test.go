@@ -0,0 +1,113 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanner
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeICAP accepts a single RESPMOD request and responds with the provided response.
+func fakeICAP(t *testing.T, response string) string {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %s", err)
+	}
+	t.Cleanup(func() { _ = l.Close() })
+
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		// read the request up to the last (empty) chunk of the encapsulated body.
+		tp := textproto.NewReader(bufio.NewReader(conn))
+		for {
+			line, err := tp.ReadLine()
+			if err != nil || line == "0" {
+				break
+			}
+		}
+
+		_, _ = conn.Write([]byte(response))
+	}()
+
+	return "icap://" + l.Addr().String() + "/avscan"
+}
+
+func TestICAPScanner(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		want     Result
+		wantErr  bool
+	}{
+		{
+			name:     "clean",
+			response: "ICAP/1.0 204 No Content\r\nISTag: \"1\"\r\n\r\n",
+			want:     Result{Status: StatusClean, Engine: ProviderICAP},
+		},
+		{
+			name: "infected",
+			response: "ICAP/1.0 200 OK\r\nISTag: \"1\"\r\n" +
+				"X-Infection-Found: Type=0; Resolution=2; Threat=Eicar-Test-Signature;\r\n" +
+				"Encapsulated: res-hdr=0, null-body=19\r\n\r\nHTTP/1.1 403 Forbidden\r\n\r\n",
+			want: Result{Status: StatusInfected, Threat: "Eicar-Test-Signature", Engine: ProviderICAP},
+		},
+		{
+			name:     "modified",
+			response: "ICAP/1.0 200 OK\r\nEncapsulated: res-hdr=0, null-body=19\r\n\r\nHTTP/1.1 403 Forbidden\r\n\r\n",
+			want:     Result{Status: StatusInfected, Threat: "unknown", Engine: ProviderICAP},
+		},
+		{
+			name:     "error",
+			response: "ICAP/1.0 500 Server Error\r\n\r\n",
+			wantErr:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, err := NewICAPScanner(Config{Address: fakeICAP(t, test.response), Timeout: 5 * time.Second})
+			if err != nil {
+				t.Fatalf("failed to create scanner: %s", err)
+			}
+
+			got, err := s.Scan(context.Background(), strings.NewReader(strings.Repeat("x", icapChunkSize+1)))
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got %+v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to scan: %s", err)
+			}
+
+			if got != test.want {
+				t.Errorf("want=%+v got=%+v", test.want, got)
+			}
+		})
+	}
+}
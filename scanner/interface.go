@@ -0,0 +1,49 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanner
+
+import (
+	"context"
+	"io"
+)
+
+// Status is the outcome of scanning a file.
+type Status string
+
+const (
+	// StatusClean is set for files that have been scanned and no threat was found.
+	StatusClean Status = "clean"
+	// StatusInfected is set for files that have been scanned and a threat was found.
+	StatusInfected Status = "infected"
+	// StatusSkipped is set for files that haven't been scanned as scanning is disabled.
+	StatusSkipped Status = "skipped"
+	// StatusFailed is set for files that couldn't be scanned, but were accepted regardless.
+	StatusFailed Status = "failed"
+)
+
+// Result is the result of scanning a file.
+type Result struct {
+	Status Status
+	// Threat is the name of the detected threat, empty if the file isn't infected.
+	Threat string
+	// Engine is the provider that scanned the file.
+	Engine Provider
+}
+
+type Scanner interface {
+	// Scan scans the content and returns the result.
+	// An error is returned only if the content couldn't be scanned.
+	Scan(ctx context.Context, content io.Reader) (Result, error)
+}
@@ -0,0 +1,34 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanner
+
+import (
+	"context"
+	"io"
+)
+
+// NoopScanner is used if scanning is disabled, it doesn't scan any file.
+type NoopScanner struct{}
+
+func NewNoopScanner() Scanner {
+	return NoopScanner{}
+}
+
+func (NoopScanner) Scan(context.Context, io.Reader) (Result, error) {
+	return Result{
+		Status: StatusSkipped,
+		Engine: ProviderNone,
+	}, nil
+}
@@ -0,0 +1,38 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scanner
+
+import (
+	"fmt"
+
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	ProvideScanner,
+)
+
+func ProvideScanner(config Config) (Scanner, error) {
+	switch config.Provider {
+	case ProviderNone, "":
+		return NewNoopScanner(), nil
+	case ProviderClamd:
+		return NewClamdScanner(config)
+	case ProviderICAP:
+		return NewICAPScanner(config)
+	default:
+		return nil, fmt.Errorf("invalid scanner provider: %s", config.Provider)
+	}
+}
@@ -14,8 +14,11 @@
 
 package database
 
+import "time"
+
 // Config specifies the config for the database package.
 type Config struct {
-	Driver     string
-	Datasource string
+	Driver             string
+	Datasource         string
+	SlowQueryThreshold time.Duration
 }
@@ -25,7 +25,7 @@ import (
 func New(db *sqlx.DB) AccessorTx {
 	mx := getLocker(db)
 	run := &runnerDB{
-		db: sqlDB{db},
+		db: newInstrumentedDB(sqlDB{db}),
 		mx: mx,
 	}
 	return run
@@ -0,0 +1,108 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtx
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// instrumentedDB records the timings of all queries executed directly or within a transaction.
+type instrumentedDB struct {
+	instrumentedAccessor
+	db transactor
+}
+
+var _ transactor = instrumentedDB{}
+
+func newInstrumentedDB(db transactor) instrumentedDB {
+	return instrumentedDB{
+		instrumentedAccessor: instrumentedAccessor{Accessor: db},
+		db:                   db,
+	}
+}
+
+func (i instrumentedDB) startTx(ctx context.Context, opts *sql.TxOptions) (TransactionAccessor, error) {
+	tx, err := i.db.startTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return instrumentedTx{
+		instrumentedAccessor: instrumentedAccessor{Accessor: tx},
+		Transaction:          tx,
+	}, nil
+}
+
+// instrumentedTx records the timings of all queries executed within a transaction.
+type instrumentedTx struct {
+	instrumentedAccessor
+	Transaction
+}
+
+var _ TransactionAccessor = instrumentedTx{}
+
+// instrumentedAccessor records the query timings of the wrapped Accessor.
+// Note that for the calls returning rows only the time until the first row is available is recorded.
+type instrumentedAccessor struct {
+	Accessor
+}
+
+func (i instrumentedAccessor) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	defer recordQuery(ctx, query, time.Now())
+	return i.Accessor.QueryContext(ctx, query, args...)
+}
+
+func (i instrumentedAccessor) QueryxContext(
+	ctx context.Context,
+	query string,
+	args ...interface{},
+) (*sqlx.Rows, error) {
+	defer recordQuery(ctx, query, time.Now())
+	return i.Accessor.QueryxContext(ctx, query, args...)
+}
+
+func (i instrumentedAccessor) QueryRowxContext(ctx context.Context, query string, args ...interface{}) *sqlx.Row {
+	defer recordQuery(ctx, query, time.Now())
+	return i.Accessor.QueryRowxContext(ctx, query, args...)
+}
+
+func (i instrumentedAccessor) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	defer recordQuery(ctx, query, time.Now())
+	return i.Accessor.QueryRowContext(ctx, query, args...)
+}
+
+func (i instrumentedAccessor) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	defer recordQuery(ctx, query, time.Now())
+	return i.Accessor.ExecContext(ctx, query, args...)
+}
+
+func (i instrumentedAccessor) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	defer recordQuery(ctx, query, time.Now())
+	return i.Accessor.GetContext(ctx, dest, query, args...)
+}
+
+func (i instrumentedAccessor) SelectContext(
+	ctx context.Context,
+	dest interface{},
+	query string,
+	args ...interface{},
+) error {
+	defer recordQuery(ctx, query, time.Now())
+	return i.Accessor.SelectContext(ctx, dest, query, args...)
+}
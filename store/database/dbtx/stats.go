@@ -0,0 +1,305 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtx
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// DefaultSlowQueryThreshold is the duration after which a query is reported as slow.
+	DefaultSlowQueryThreshold = 200 * time.Millisecond
+
+	// maxSlowQueries is the number of most recent slow queries kept in memory.
+	maxSlowQueries = 100
+
+	// maxQueryStatements is the maximum number of distinct normalized statements tracked.
+	maxQueryStatements = 1000
+)
+
+var globalQueryStats = newQueryStats(DefaultSlowQueryThreshold)
+
+// SetSlowQueryThreshold sets the duration after which a query is reported as slow.
+// A non-positive threshold disables slow query reporting.
+func SetSlowQueryThreshold(threshold time.Duration) {
+	globalQueryStats.setThreshold(threshold)
+}
+
+// GetQueryReport returns the statistics of the queries executed since the server start (or the last reset).
+func GetQueryReport() QueryReport {
+	return globalQueryStats.report()
+}
+
+// ResetQueryStats discards all collected query statistics.
+func ResetQueryStats() {
+	globalQueryStats.reset()
+}
+
+// QueryReport contains the query statistics and the most recent slow queries.
+type QueryReport struct {
+	SlowThreshold time.Duration    `json:"slow_threshold"`
+	Since         int64            `json:"since"`
+	Statements    []StatementStats `json:"statements"`
+	SlowQueries   []SlowQuery      `json:"slow_queries"`
+}
+
+// StatementStats contains the aggregated timings of a single normalized statement.
+type StatementStats struct {
+	Statement string        `json:"statement"`
+	Count     int64         `json:"count"`
+	SlowCount int64         `json:"slow_count"`
+	Total     time.Duration `json:"total"`
+	Average   time.Duration `json:"average"`
+	Max       time.Duration `json:"max"`
+	LastSeen  int64         `json:"last_seen"`
+}
+
+// SlowQuery describes a single execution of a query that took longer than the slow query threshold.
+type SlowQuery struct {
+	Statement string        `json:"statement"`
+	Duration  time.Duration `json:"duration"`
+	Executed  int64         `json:"executed"`
+}
+
+type queryStats struct {
+	mx         sync.Mutex
+	threshold  time.Duration
+	since      time.Time
+	statements map[string]*StatementStats
+	slow       []SlowQuery // ring buffer of the most recent slow queries
+	slowNext   int
+}
+
+func newQueryStats(threshold time.Duration) *queryStats {
+	return &queryStats{
+		threshold:  threshold,
+		since:      time.Now(),
+		statements: make(map[string]*StatementStats),
+	}
+}
+
+func (s *queryStats) setThreshold(threshold time.Duration) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.threshold = threshold
+}
+
+func (s *queryStats) reset() {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.since = time.Now()
+	s.statements = make(map[string]*StatementStats)
+	s.slow = nil
+	s.slowNext = 0
+}
+
+// record adds a single execution of the normalized statement to the statistics and reports whether the query was slow.
+func (s *queryStats) record(statement string, started time.Time, duration time.Duration) bool {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	slow := s.threshold > 0 && duration >= s.threshold
+
+	stats, ok := s.statements[statement]
+	if !ok {
+		if len(s.statements) >= maxQueryStatements {
+			return slow
+		}
+		stats = &StatementStats{Statement: statement}
+		s.statements[statement] = stats
+	}
+
+	stats.Count++
+	stats.Total += duration
+	if duration > stats.Max {
+		stats.Max = duration
+	}
+	stats.LastSeen = started.UnixMilli()
+
+	if !slow {
+		return false
+	}
+
+	stats.SlowCount++
+
+	entry := SlowQuery{
+		Statement: statement,
+		Duration:  duration,
+		Executed:  started.UnixMilli(),
+	}
+	if len(s.slow) < maxSlowQueries {
+		s.slow = append(s.slow, entry)
+	} else {
+		s.slow[s.slowNext] = entry
+	}
+	s.slowNext = (s.slowNext + 1) % maxSlowQueries
+
+	return true
+}
+
+func (s *queryStats) report() QueryReport {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	statements := make([]StatementStats, 0, len(s.statements))
+	for _, stats := range s.statements {
+		st := *stats
+		st.Average = st.Total / time.Duration(st.Count)
+		statements = append(statements, st)
+	}
+
+	// the statements that consumed the most database time come first.
+	sort.Slice(statements, func(i, j int) bool {
+		if statements[i].Total != statements[j].Total {
+			return statements[i].Total > statements[j].Total
+		}
+		return statements[i].Statement < statements[j].Statement
+	})
+
+	// the most recent slow queries come first.
+	slow := make([]SlowQuery, 0, len(s.slow))
+	for i := 1; i <= len(s.slow); i++ {
+		slow = append(slow, s.slow[(s.slowNext-i+len(s.slow))%len(s.slow)])
+	}
+
+	return QueryReport{
+		SlowThreshold: s.threshold,
+		Since:         s.since.UnixMilli(),
+		Statements:    statements,
+		SlowQueries:   slow,
+	}
+}
+
+// normalizeQuery collapses whitespace and replaces literals and bind parameters with a question mark,
+// so that executions of the same statement with different arguments are aggregated together.
+// Lists of parameters, like the ones generated for IN clauses, are collapsed to a single one.
+func normalizeQuery(query string) string {
+	out := make([]byte, 0, len(query))
+
+	space := false
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			space = len(out) > 0
+			continue
+		case c == '\'':
+			// string literal, with '' as the escaped quote
+			for i++; i < len(query); i++ {
+				if query[i] != '\'' {
+					continue
+				}
+				if i+1 < len(query) && query[i+1] == '\'' {
+					i++
+					continue
+				}
+				break
+			}
+			c = '?'
+		case c == '$' && i+1 < len(query) && isDigit(query[i+1]):
+			for i+1 < len(query) && isDigit(query[i+1]) {
+				i++
+			}
+			c = '?'
+		case isDigit(c) && (len(out) == 0 || space || !isIdentifierPart(out[len(out)-1])):
+			for i+1 < len(query) && (isDigit(query[i+1]) || query[i+1] == '.') {
+				i++
+			}
+			c = '?'
+		}
+
+		// collapse parameter lists: "?, ?, ?" becomes "?"
+		if c == '?' {
+			if n := len(out); n >= 2 && out[n-1] == ',' && out[n-2] == '?' {
+				out = out[:n-1]
+				space = false
+				continue
+			}
+		}
+
+		if space {
+			out = append(out, ' ')
+			space = false
+		}
+
+		out = append(out, c)
+	}
+
+	return string(out)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentifierPart(c byte) bool {
+	return c == '_' || c == '.' || isDigit(c) || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+type ctxKeyQueryTimer struct{}
+
+// QueryTimer accumulates the number of queries and the database time spent on behalf of a single request.
+type QueryTimer struct {
+	count    atomic.Int64
+	duration atomic.Int64
+}
+
+// WithQueryTimer returns a context with a new QueryTimer that collects all queries executed with the context.
+func WithQueryTimer(ctx context.Context) (context.Context, *QueryTimer) {
+	timer := &QueryTimer{}
+	return context.WithValue(ctx, ctxKeyQueryTimer{}, timer), timer
+}
+
+// Count returns the number of queries executed so far.
+func (t *QueryTimer) Count() int64 {
+	return t.count.Load()
+}
+
+// Duration returns the total time spent on the queries executed so far.
+func (t *QueryTimer) Duration() time.Duration {
+	return time.Duration(t.duration.Load())
+}
+
+// recordQuery adds the query execution to the global statistics and to the query timer of the context.
+func recordQuery(ctx context.Context, query string, started time.Time) {
+	duration := time.Since(started)
+
+	if timer, ok := ctx.Value(ctxKeyQueryTimer{}).(*QueryTimer); ok {
+		timer.count.Add(1)
+		timer.duration.Add(int64(duration))
+	}
+
+	statement := normalizeQuery(query)
+	if globalQueryStats.record(statement, started, duration) {
+		logger := log.Ctx(ctx)
+		if logger.GetLevel() == zerolog.Disabled {
+			// not all contexts carry a logger (e.g. background jobs), fall back to the global one.
+			logger = &log.Logger
+		}
+
+		logger.Warn().
+			Dur("duration", duration).
+			Str("query", statement).
+			Msg("slow database query")
+	}
+}
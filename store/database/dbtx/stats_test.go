@@ -0,0 +1,121 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtx
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestNormalizeQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{
+			query: "SELECT repo_id\n\tFROM repositories\n\tWHERE repo_id = $1",
+			want:  "SELECT repo_id FROM repositories WHERE repo_id = ?",
+		},
+		{
+			query: "SELECT * FROM pullreqs WHERE pullreq_id IN ($1,$2, $3) LIMIT 10",
+			want:  "SELECT * FROM pullreqs WHERE pullreq_id IN (?) LIMIT ?",
+		},
+		{
+			query: "UPDATE t SET s = 'it''s', v2 = 3.14 WHERE id = ?",
+			want:  "UPDATE t SET s = ?, v2 = ? WHERE id = ?",
+		},
+		{
+			query: "  SELECT 1  ",
+			want:  "SELECT ?",
+		},
+	}
+
+	for _, test := range tests {
+		if got := normalizeQuery(test.query); got != test.want {
+			t.Errorf("query %q: want=%q got=%q", test.query, test.want, got)
+		}
+	}
+}
+
+func TestQueryStats(t *testing.T) {
+	stats := newQueryStats(100 * time.Millisecond)
+	now := time.Now()
+
+	if stats.record("SELECT ?", now, time.Millisecond) {
+		t.Errorf("fast query reported as slow")
+	}
+	if !stats.record("SELECT ?", now, 200*time.Millisecond) {
+		t.Errorf("slow query not reported as slow")
+	}
+	if !stats.record("DELETE FROM t", now, 300*time.Millisecond) {
+		t.Errorf("slow query not reported as slow")
+	}
+
+	report := stats.report()
+
+	if len(report.Statements) != 2 {
+		t.Fatalf("want 2 statements, got %d", len(report.Statements))
+	}
+	if s := report.Statements[0]; s.Statement != "DELETE FROM t" || s.Count != 1 || s.SlowCount != 1 {
+		t.Errorf("unexpected first statement: %+v", s)
+	}
+	if s := report.Statements[1]; s.Count != 2 || s.SlowCount != 1 ||
+		s.Max != 200*time.Millisecond || s.Average != 100500*time.Microsecond {
+		t.Errorf("unexpected second statement: %+v", s)
+	}
+
+	if len(report.SlowQueries) != 2 || report.SlowQueries[0].Statement != "DELETE FROM t" {
+		t.Errorf("unexpected slow queries: %+v", report.SlowQueries)
+	}
+
+	stats.reset()
+	if report = stats.report(); len(report.Statements) != 0 || len(report.SlowQueries) != 0 {
+		t.Errorf("stats not reset: %+v", report)
+	}
+}
+
+func TestQueryStatsSlowQueriesLimit(t *testing.T) {
+	stats := newQueryStats(time.Millisecond)
+	now := time.Now()
+
+	for i := 0; i < maxSlowQueries+10; i++ {
+		stats.record("SELECT ?", now.Add(time.Duration(i)*time.Millisecond), time.Second)
+	}
+
+	report := stats.report()
+	if len(report.SlowQueries) != maxSlowQueries {
+		t.Fatalf("want %d slow queries, got %d", maxSlowQueries, len(report.SlowQueries))
+	}
+
+	// the most recent one comes first
+	if want := now.Add((maxSlowQueries + 9) * time.Millisecond).UnixMilli(); report.SlowQueries[0].Executed != want {
+		t.Errorf("want most recent slow query executed at %d, got %d", want, report.SlowQueries[0].Executed)
+	}
+}
+
+func TestQueryTimer(t *testing.T) {
+	ctx, timer := WithQueryTimer(context.Background())
+
+	recordQuery(ctx, "SELECT 1", time.Now().Add(-time.Millisecond))
+	recordQuery(ctx, "SELECT 2", time.Now().Add(-time.Millisecond))
+
+	if timer.Count() != 2 {
+		t.Errorf("want 2 queries, got %d", timer.Count())
+	}
+	if timer.Duration() < 2*time.Millisecond {
+		t.Errorf("want at least 2ms, got %s", timer.Duration())
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// Approval is a manual approval gate of a pipeline execution stage.
+// The stage stays blocked until a user approves or rejects it, or until the approval times out.
+type Approval struct {
+	ID        int64              `json:"-"`
+	StageID   int64              `json:"-"`
+	Approvers []string           `json:"approvers"`
+	Timeout   int64              `json:"timeout"`
+	Deadline  int64              `json:"deadline,omitempty"`
+	State     enum.ApprovalState `json:"state"`
+	DecidedBy *int64             `json:"-"`
+	Decider   *PrincipalInfo     `json:"decider,omitempty"`
+	Decided   int64              `json:"decided,omitempty"`
+	Comment   string             `json:"comment,omitempty"`
+	Created   int64              `json:"created"`
+	Updated   int64              `json:"updated"`
+	Version   int64              `json:"-"`
+}
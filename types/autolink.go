@@ -0,0 +1,43 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// Autolink is a rule that turns references to external resources (e.g. issue tracker tickets) into links.
+// It's defined either on a space, and then applies to all repositories in the space and its subspaces,
+// or on a single repository.
+type Autolink struct {
+	ID      int64  `json:"id"`
+	SpaceID *int64 `json:"space_id,omitempty"`
+	RepoID  *int64 `json:"repo_id,omitempty"`
+
+	// Pattern is the regular expression that matches the references, e.g. `JIRA-(\d+)`.
+	Pattern string `json:"pattern"`
+
+	// TargetURL is the template of the link. Placeholder {0} is replaced with the whole reference
+	// and placeholders {1}, {2}, ... with the corresponding capture groups of the pattern.
+	TargetURL string `json:"target_url"`
+
+	Description string `json:"description"`
+
+	CreatedBy int64 `json:"created_by"`
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+}
+
+// AutolinkReference is a reference found in a text by an autolink rule and the link it resolves to.
+type AutolinkReference struct {
+	Text string `json:"text"`
+	URL  string `json:"url"`
+}
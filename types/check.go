@@ -74,10 +74,29 @@ type CheckPayloadText struct {
 	Details string `json:"details"`
 }
 
+// CheckPayloadReport is the structured output of an external status check.
+type CheckPayloadReport struct {
+	// Summary is the markdown summary of the status check output.
+	Summary     string            `json:"summary"`
+	Annotations []CheckAnnotation `json:"annotations"`
+}
+
+// CheckAnnotation points to a range of lines in a file that the status check reported something about.
+type CheckAnnotation struct {
+	Path      string                    `json:"path"`
+	LineStart int                       `json:"line_start"`
+	LineEnd   int                       `json:"line_end"`
+	Level     enum.CheckAnnotationLevel `json:"level"`
+	Title     string                    `json:"title,omitempty"`
+	Message   string                    `json:"message"`
+}
+
 // CheckPayloadInternal is for internal use for more seamless integration for
 // gitness CI status checks.
 type CheckPayloadInternal struct {
 	Number     int64 `json:"execution_number"`
 	RepoID     int64 `json:"repo_id"`
 	PipelineID int64 `json:"pipeline_id"`
+	// StageNumber is set for status checks reporting a single pipeline stage.
+	StageNumber int64 `json:"stage_number,omitempty"`
 }
@@ -32,6 +32,10 @@ const (
 	maxEmailLength = 250
 
 	maxDescriptionLength = 1024
+
+	maxMetadataEntries     = 32
+	maxMetadataKeyLength   = 64
+	maxMetadataValueLength = 256
 )
 
 var (
@@ -63,6 +67,17 @@ var (
 
 	ErrInvalidCharacters = &ValidationError{"Input contains invalid characters."}
 
+	ErrMetadataEntries = &ValidationError{
+		fmt.Sprintf("Metadata can have at most %d entries.", maxMetadataEntries),
+	}
+	ErrMetadataKey = &ValidationError{
+		fmt.Sprintf("Metadata keys have to be at most %d in length, start with a letter (or _) "+
+			"and only contain the following characters [a-zA-Z0-9-_.].", maxMetadataKeyLength),
+	}
+	ErrMetadataValueTooLong = &ValidationError{
+		fmt.Sprintf("Metadata values can be at most %d in length.", maxMetadataValueLength),
+	}
+
 	ErrIllegalRootSpaceUID = &ValidationError{
 		fmt.Sprintf("The following names are not allowed for a root space: %v", illegalRootSpaceUIDs),
 	}
@@ -88,6 +103,31 @@ func Description(description string) error {
 	return ForControlCharacters(description)
 }
 
+// Metadata checks the provided custom metadata key/value pairs and returns an error if they aren't valid.
+func Metadata(metadata map[string]string) error {
+	if len(metadata) > maxMetadataEntries {
+		return ErrMetadataEntries
+	}
+
+	for key, value := range metadata {
+		if len(key) > maxMetadataKeyLength {
+			return ErrMetadataKey
+		}
+		if ok, _ := regexp.MatchString(uidRegex, key); !ok {
+			return ErrMetadataKey
+		}
+
+		if len(value) > maxMetadataValueLength {
+			return ErrMetadataValueTooLong
+		}
+		if err := ForControlCharacters(value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // ForControlCharacters ensures that there are no control characters in the provided string.
 func ForControlCharacters(s string) error {
 	for _, r := range s {
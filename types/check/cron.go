@@ -0,0 +1,91 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gorhill/cronexpr"
+)
+
+// cronFields is the number of fields of a standard cron expression (minute, hour, day of month, month, day of week).
+const cronFields = 5
+
+var (
+	ErrCronExpressionRequired = &ValidationError{"A cron expression is required."}
+	ErrCronExpressionFields   = &ValidationError{
+		"A cron expression has to consist of exactly five fields (minute, hour, day of month, month, day of week) " +
+			"or be one of the descriptors @yearly, @monthly, @weekly, @daily or @hourly.",
+	}
+)
+
+// CronExpression checks the provided cron expression and returns an error if it isn't valid.
+// It accepts standard five field expressions and predefined descriptors (e.g. "@daily").
+// Expressions with seconds or years aren't supported. The expression is evaluated
+// in the provided timezone (an IANA name like "Europe/Berlin", empty for UTC)
+// and has to match at least once in the future.
+func CronExpression(expr string, timezone string) error {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return ErrCronExpressionRequired
+	}
+
+	if !strings.HasPrefix(expr, "@") && len(strings.Fields(expr)) != cronFields {
+		return ErrCronExpressionFields
+	}
+
+	loc, err := location(timezone)
+	if err != nil {
+		return err
+	}
+
+	exp, err := cronexpr.Parse(expr)
+	if err != nil {
+		return NewValidationErrorf("The cron expression %q is invalid: %s.", expr, err)
+	}
+
+	if exp.Next(time.Now().In(loc)).IsZero() {
+		return NewValidationErrorf("The cron expression %q never matches.", expr)
+	}
+
+	return nil
+}
+
+// Timezone checks the provided timezone and returns an error if it isn't a known IANA timezone name.
+// An empty timezone is valid and stands for UTC.
+func Timezone(timezone string) error {
+	_, err := location(timezone)
+	return err
+}
+
+func location(timezone string) (*time.Location, error) {
+	if timezone == "" {
+		return time.UTC, nil
+	}
+
+	// time.LoadLocation treats "Local" as the timezone of the server, which isn't a stable choice.
+	if timezone == "Local" {
+		return nil, NewValidationErrorf("The timezone %q is invalid.", timezone)
+	}
+
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, NewValidationErrorf("The timezone %q is invalid, it has to be an IANA timezone name "+
+			"like \"Europe/Berlin\".", timezone)
+	}
+
+	return loc, nil
+}
@@ -0,0 +1,39 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package check
+
+import (
+	"time"
+)
+
+// Duration checks the provided duration string (e.g. "1h30m") and returns an error if it isn't valid
+// or isn't within the provided bounds. A zero maximum means the duration isn't bounded from above.
+func Duration(s string, minDur, maxDur time.Duration) error {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return NewValidationErrorf("The duration %q is invalid, it has to be a sequence of numbers "+
+			"with a unit suffix like \"90s\" or \"1h30m\".", s)
+	}
+
+	if d < minDur {
+		return NewValidationErrorf("The duration %q has to be at least %s.", s, minDur)
+	}
+
+	if maxDur > 0 && d > maxDur {
+		return NewValidationErrorf("The duration %q can be at most %s.", s, maxDur)
+	}
+
+	return nil
+}
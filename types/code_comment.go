@@ -14,6 +14,8 @@
 
 package types
 
+import "github.com/harness/gitness/types/enum"
+
 type CodeComment struct {
 	ID      int64 `db:"pullreq_activity_id"`
 	Version int64 `db:"pullreq_activity_version"`
@@ -31,4 +33,22 @@ type CodeCommentFields struct {
 	SpanNew      int    `db:"pullreq_activity_code_comment_span_new" json:"span_new"`
 	LineOld      int    `db:"pullreq_activity_code_comment_line_old" json:"line_old"`
 	SpanOld      int    `db:"pullreq_activity_code_comment_span_old" json:"span_old"`
+
+	// AnchorStatus is the outcome of the latest attempt to follow the commented lines.
+	AnchorStatus enum.CodeCommentAnchorStatus `db:"pullreq_activity_code_comment_anchor_status" json:"anchor_status,omitempty"`
+
+	// Anchor is the content of the file the code comment is attached to.
+	// It's used to re-anchor the code comment after the history of the source branch gets rewritten.
+	Anchor *CodeCommentAnchor `db:"-" json:"-"`
+}
+
+// CodeCommentAnchor holds the commented lines of a file (the "+" side of the diff) along with the surrounding lines.
+type CodeCommentAnchor struct {
+	// BlobSHA is the SHA of the file's blob the anchor was taken from.
+	BlobSHA string `json:"blob_sha"`
+	// Line is the line number of the first commented line in the blob.
+	Line   int      `json:"line"`
+	Before []string `json:"before,omitempty"`
+	Lines  []string `json:"lines"`
+	After  []string `json:"after,omitempty"`
 }
@@ -20,3 +20,47 @@ type CommitFilesResponse struct {
 	CommitID       string           `json:"commit_id"`
 	RuleViolations []RuleViolations `json:"rule_violations,omitempty"`
 }
+
+// CherryPickCommitsResponse holds the id of the last cherry-picked commit.
+type CherryPickCommitsResponse struct {
+	DryRunRules    bool             `json:"dry_run_rules,omitempty"`
+	CommitID       string           `json:"commit_id"`
+	RuleViolations []RuleViolations `json:"rule_violations,omitempty"`
+}
+
+// RevertCommitResponse holds the id of the revert commit and the branch it was committed to.
+type RevertCommitResponse struct {
+	DryRunRules    bool             `json:"dry_run_rules,omitempty"`
+	CommitID       string           `json:"commit_id"`
+	Branch         string           `json:"branch"`
+	PullReq        *PullReq         `json:"pull_request,omitempty"`
+	RuleViolations []RuleViolations `json:"rule_violations,omitempty"`
+}
+
+// CommitComment represents a comment made directly on a commit, outside of any pull request.
+type CommitComment struct {
+	ID        int64  `json:"id"`
+	RepoID    int64  `json:"repo_id"`
+	CommitSHA string `json:"commit_sha"`
+	ParentID  *int64 `json:"parent_id,omitempty"`
+	CreatedBy int64  `json:"-"` // not returned, because the author info is in the Author field
+	Created   int64  `json:"created"`
+	Updated   int64  `json:"updated"`
+	Edited    int64  `json:"edited"`
+	Deleted   *int64 `json:"deleted,omitempty"`
+	Text      string `json:"text"`
+
+	// Path and line fields are set only for inline comments.
+	Path         string `json:"path,omitempty"`
+	LineStart    int    `json:"line_start,omitempty"`
+	LineStartNew bool   `json:"line_start_new,omitempty"`
+	LineEnd      int    `json:"line_end,omitempty"`
+	LineEndNew   bool   `json:"line_end_new,omitempty"`
+
+	Author PrincipalInfo `json:"author"`
+}
+
+// IsInline returns true if the comment is attached to lines of a file changed by the commit.
+func (c *CommitComment) IsInline() bool {
+	return c.Path != ""
+}
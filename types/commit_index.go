@@ -0,0 +1,26 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// CommitIndexState describes the commit search index of a repository.
+// The index contains the commits reachable from the default branch, it's updated incrementally on each push.
+type CommitIndexState struct {
+	RepoID int64 `json:"repo_id"`
+
+	// CommitSHA is the commit of the default branch the repository is indexed up to.
+	CommitSHA string `json:"commit_sha"`
+
+	Updated int64 `json:"updated"`
+}
@@ -0,0 +1,90 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+)
+
+// ComplianceSnapshot is an immutable record of the audit aggregates for a period of time.
+// Snapshots are chained: the hash of every snapshot covers the hash of the previous one,
+// so any modification of a stored snapshot invalidates all snapshots that follow it.
+type ComplianceSnapshot struct {
+	ID          int64           `json:"id"`
+	PeriodStart int64           `json:"period_start"`
+	PeriodEnd   int64           `json:"period_end"`
+	Data        json.RawMessage `json:"data"`
+	PrevHash    string          `json:"prev_hash"`
+	Hash        string          `json:"hash"`
+	Created     int64           `json:"created"`
+}
+
+// ComputeHash returns the hash of the snapshot, calculated from the previous hash, the period and the data.
+func (s *ComplianceSnapshot) ComputeHash() string {
+	h := sha256.New()
+	h.Write([]byte(s.PrevHash))
+	h.Write([]byte{'\n'})
+	h.Write([]byte(strconv.FormatInt(s.PeriodStart, 10)))
+	h.Write([]byte{'\n'})
+	h.Write([]byte(strconv.FormatInt(s.PeriodEnd, 10)))
+	h.Write([]byte{'\n'})
+	h.Write(s.Data)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// VerifyComplianceSnapshots verifies the hash chain of the snapshots, provided in the order they were created.
+// It returns the ID of the first snapshot that breaks the chain, or zero if the chain is intact.
+func VerifyComplianceSnapshots(snapshots []*ComplianceSnapshot) int64 {
+	prevHash := ""
+	for _, snapshot := range snapshots {
+		if snapshot.PrevHash != prevHash || snapshot.Hash != snapshot.ComputeHash() {
+			return snapshot.ID
+		}
+
+		prevHash = snapshot.Hash
+	}
+
+	return 0
+}
+
+// ComplianceSnapshotData holds the aggregates frozen in a compliance snapshot.
+type ComplianceSnapshotData struct {
+	PullReqsMerged int                          `json:"pullreqs_merged"`
+	RuleBypasses   int                          `json:"rule_bypasses"`
+	Repos          []ComplianceSnapshotRepoData `json:"repos"`
+}
+
+// ComplianceSnapshotRepoData holds the aggregates of a single repository.
+type ComplianceSnapshotRepoData struct {
+	RepoID         int64  `json:"repo_id"`
+	RepoPath       string `json:"repo_path"`
+	PullReqsMerged int    `json:"pullreqs_merged"`
+	RuleBypasses   int    `json:"rule_bypasses"`
+
+	// BypassedBy lists IDs of the principals that merged pull requests bypassing the rules.
+	BypassedBy []int64 `json:"bypassed_by,omitempty"`
+}
+
+// ComplianceSnapshotExport is the verifiable export of the complete chain of compliance snapshots.
+type ComplianceSnapshotExport struct {
+	Exported  int64                 `json:"exported"`
+	Valid     bool                  `json:"valid"`
+	InvalidID int64                 `json:"invalid_id,omitempty"`
+	Snapshots []*ComplianceSnapshot `json:"snapshots"`
+}
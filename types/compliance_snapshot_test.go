@@ -0,0 +1,83 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "testing"
+
+func TestVerifyComplianceSnapshots(t *testing.T) {
+	chain := func() []*ComplianceSnapshot {
+		var snapshots []*ComplianceSnapshot
+		prevHash := ""
+		for i := int64(1); i <= 3; i++ {
+			s := &ComplianceSnapshot{
+				ID:          i,
+				PeriodStart: i * 100,
+				PeriodEnd:   (i + 1) * 100,
+				Data:        []byte(`{"pullreqs_merged":1}`),
+				PrevHash:    prevHash,
+			}
+			s.Hash = s.ComputeHash()
+			prevHash = s.Hash
+			snapshots = append(snapshots, s)
+		}
+		return snapshots
+	}
+
+	tests := []struct {
+		name   string
+		modify func(snapshots []*ComplianceSnapshot)
+		exp    int64
+	}{
+		{
+			name:   "intact",
+			modify: func([]*ComplianceSnapshot) {},
+			exp:    0,
+		},
+		{
+			name: "modified-data",
+			modify: func(snapshots []*ComplianceSnapshot) {
+				snapshots[1].Data = []byte(`{"pullreqs_merged":2}`)
+			},
+			exp: 2,
+		},
+		{
+			name: "rehashed-data",
+			modify: func(snapshots []*ComplianceSnapshot) {
+				snapshots[1].Data = []byte(`{"pullreqs_merged":2}`)
+				snapshots[1].Hash = snapshots[1].ComputeHash()
+			},
+			exp: 3,
+		},
+		{
+			name: "removed-snapshot",
+			modify: func(snapshots []*ComplianceSnapshot) {
+				copy(snapshots[1:], snapshots[2:])
+				snapshots[2] = snapshots[1]
+			},
+			exp: 3,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			snapshots := chain()
+			test.modify(snapshots)
+
+			if want, got := test.exp, VerifyComplianceSnapshots(snapshots); want != got {
+				t.Errorf("want=%d got=%d", want, got)
+			}
+		})
+	}
+}
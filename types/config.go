@@ -22,6 +22,7 @@ import (
 	gitenum "github.com/harness/gitness/git/enum"
 	"github.com/harness/gitness/lock"
 	"github.com/harness/gitness/pubsub"
+	"github.com/harness/gitness/scanner"
 )
 
 // Config stores the system configuration.
@@ -98,6 +99,12 @@ type Config struct {
 		TmpDir string `envconfig:"GITNESS_GIT_TMP_DIR"`
 		// HookPath points to the binary used as git server hook.
 		HookPath string `envconfig:"GITNESS_GIT_HOOK_PATH"`
+		// PartialClone specifies whether clients can request filtered packs (e.g. `--filter=blob:none`)
+		// and fetch the missing objects on demand.
+		PartialClone bool `envconfig:"GITNESS_GIT_PARTIAL_CLONE" default:"true"`
+		// ArchiveMaxSize is the maximum total size (in bytes) of the files of a downloadable archive.
+		// A non-positive value disables the limit.
+		ArchiveMaxSize int64 `envconfig:"GITNESS_GIT_ARCHIVE_MAX_SIZE" default:"1073741824"` // 1 GiB
 
 		// LastCommitCache holds configuration options for the last commit cache.
 		LastCommitCache struct {
@@ -107,6 +114,45 @@ type Config struct {
 			// Duration defines cache duration of last commit.
 			Duration time.Duration `envconfig:"GITNESS_GIT_LAST_COMMIT_CACHE_DURATION" default:"12h"`
 		}
+
+		// ObjectCache holds configuration options for the in-memory cache of frequently read git objects
+		// (e.g. READMEs and root trees).
+		ObjectCache struct {
+			// Size is the maximum total size (in bytes) of the cached objects. A non-positive value disables the cache.
+			Size int64 `envconfig:"GITNESS_GIT_OBJECT_CACHE_SIZE" default:"67108864"` // 64 MiB
+
+			// MaxObjectSize is the maximum size (in bytes) of a single cached object.
+			MaxObjectSize int64 `envconfig:"GITNESS_GIT_OBJECT_CACHE_MAX_OBJECT_SIZE" default:"1048576"` // 1 MiB
+		}
+
+		// PackCache holds configuration options for the disk cache of packfiles served to cloning clients.
+		PackCache struct {
+			// Enabled specifies whether the packfiles served for clones are cached.
+			Enabled bool `envconfig:"GITNESS_GIT_PACK_CACHE_ENABLED"`
+
+			// Dir is the directory containing the cached packfiles (default: "pack-cache" in the git root).
+			Dir string `envconfig:"GITNESS_GIT_PACK_CACHE_DIR"`
+
+			// MaxAge is the duration for which a cached packfile is served.
+			MaxAge time.Duration `envconfig:"GITNESS_GIT_PACK_CACHE_MAX_AGE" default:"10m"`
+
+			// MaxSize is the maximum total size (in bytes) of the cached packfiles.
+			MaxSize int64 `envconfig:"GITNESS_GIT_PACK_CACHE_MAX_SIZE" default:"5368709120"` // 5 GiB
+		}
+
+		// Signing holds configuration options for signing commits created by the server (merge, squash, rebase).
+		Signing struct {
+			// Format is the format of the signing key. Valid values are "openpgp" and "ssh".
+			// Commits are not signed if empty.
+			Format gitenum.SigningFormat `envconfig:"GITNESS_GIT_SIGNING_FORMAT"`
+
+			// KeyPath is the path to the file containing the private signing key.
+			KeyPath string `envconfig:"GITNESS_GIT_SIGNING_KEY_PATH"`
+
+			// UserKeysEnabled enables instance-managed per-user signing keys. Commits created on behalf
+			// of a user (e.g. web edits) are signed with the user's key, and commit signatures are verified.
+			UserKeysEnabled bool `envconfig:"GITNESS_GIT_SIGNING_USER_KEYS_ENABLED"`
+		}
 	}
 
 	// Encrypter defines the parameters for the encrypter
@@ -139,12 +185,85 @@ type Config struct {
 		// This could be a local path or an external location.
 		//nolint:lll
 		PluginsZipURL string `envconfig:"GITNESS_CI_PLUGINS_ZIP_URL" default:"https://github.com/bradrydzewski/plugins/archive/refs/heads/master.zip"`
+
+		// ExecutionLimitPerSpace is the maximum number of executions running at a time
+		// in a top level space (including its subspaces). Zero means no limit.
+		ExecutionLimitPerSpace int `envconfig:"GITNESS_CI_EXECUTION_LIMIT_PER_SPACE" default:"0"`
+
+		// Timeout is the maximum duration of an execution of a pipeline that doesn't declare its own timeout.
+		// Zero means no timeout.
+		Timeout time.Duration `envconfig:"GITNESS_CI_TIMEOUT" default:"1h"`
+
+		// QueuePriorities defines the priority of pending executions by trigger event. The execution queue
+		// dispatches stages of executions with higher priority first, and in the order of creation
+		// within the same priority. Events that aren't listed have priority zero.
+		//nolint:lll
+		QueuePriorities map[string]int `envconfig:"GITNESS_CI_QUEUE_PRIORITIES" default:"manual:3,pull_request:2,push:1,tag:1,release:1,cron:0"`
+
+		// Cache defines the build caches saved and restored by the cache steps of pipelines.
+		Cache struct {
+			// Image is the container image that runs the cache steps, it requires sh, tar and curl.
+			Image string `envconfig:"GITNESS_CI_CACHE_IMAGE" default:"alpine/curl:8.5.0"`
+
+			// MaxRepoSize is the total size (in bytes) of the caches of a repository. When it's exceeded,
+			// the least recently accessed caches get evicted.
+			MaxRepoSize int64 `envconfig:"GITNESS_CI_CACHE_MAX_REPO_SIZE" default:"5368709120"` // 5 GiB
+
+			// MaxEntrySize is the maximum size (in bytes) of a single cache.
+			MaxEntrySize int64 `envconfig:"GITNESS_CI_CACHE_MAX_ENTRY_SIZE" default:"1073741824"` // 1 GiB
+
+			// VolumesDir is the directory on the runner machines that holds the cache volumes of pipelines.
+			// Self-hosted runners can report their own directory when they register.
+			VolumesDir string `envconfig:"GITNESS_CI_CACHE_VOLUMES_DIR" default:"/var/lib/gitness/cache-volumes"`
+		}
+
+		// Artifacts defines the files uploaded by the artifact steps of pipelines.
+		Artifacts struct {
+			// Image is the container image that runs the artifact steps, it requires sh and curl.
+			Image string `envconfig:"GITNESS_CI_ARTIFACTS_IMAGE" default:"alpine/curl:8.5.0"`
+
+			// MaxSize is the maximum size (in bytes) of a single artifact.
+			MaxSize int64 `envconfig:"GITNESS_CI_ARTIFACTS_MAX_SIZE" default:"1073741824"` // 1 GiB
+
+			// RetentionTime is the duration after which the artifacts get deleted.
+			RetentionTime time.Duration `envconfig:"GITNESS_CI_ARTIFACTS_RETENTION_TIME" default:"720h"` // 30 days
+		}
+
+		// Badges defines the status badges of pipelines.
+		Badges struct {
+			// Unauthenticated allows fetching the badges of all pipelines without authentication,
+			// including pipelines of private repositories. If disabled (default), the pipeline view
+			// permission is required, unless the repository is public.
+			Unauthenticated bool `envconfig:"GITNESS_CI_BADGES_UNAUTHENTICATED" default:"false"`
+		}
+
+		// Runners defines the self-hosted runners that execute pipeline stages next to the embedded runner.
+		Runners struct {
+			// RegistrationToken is the secret runners have to provide to register.
+			// If empty, runner registration is disabled.
+			RegistrationToken string `envconfig:"GITNESS_CI_RUNNERS_REGISTRATION_TOKEN"`
+
+			// HeartbeatTimeout is the duration after which a runner that didn't contact the server is reported offline.
+			HeartbeatTimeout time.Duration `envconfig:"GITNESS_CI_RUNNERS_HEARTBEAT_TIMEOUT" default:"2m"`
+		}
+
+		// SecretManagers defines the external secret managers that pipeline secrets can be stored in.
+		SecretManagers struct {
+			// AllowServerCredentials allows AWS and GCP secret manager connectors without credentials,
+			// which then use the cloud credentials of the server (instance role or application default credentials).
+			// Every space owner could read the secrets of the cloud account of the server, so it's disabled by default.
+			AllowServerCredentials bool `envconfig:"GITNESS_CI_SECRET_MANAGERS_ALLOW_SERVER_CREDENTIALS" default:"false"`
+		}
 	}
 
 	// Database defines the database configuration parameters.
 	Database struct {
 		Driver     string `envconfig:"GITNESS_DATABASE_DRIVER" default:"sqlite3"`
 		Datasource string `envconfig:"GITNESS_DATABASE_DATASOURCE" default:"database.sqlite3"`
+
+		// SlowQueryThreshold is the duration after which a query is logged and reported as slow.
+		// Slow query reporting is disabled if set to zero.
+		SlowQueryThreshold time.Duration `envconfig:"GITNESS_DATABASE_SLOW_QUERY_THRESHOLD" default:"200ms"`
 	}
 
 	// BlobStore defines the blob storage configuration parameters.
@@ -164,10 +283,102 @@ type Config struct {
 		ImpersonationLifetime time.Duration `envconfig:"GITNESS_BLOBSTORE_IMPERSONATION_LIFETIME" default:"12h"`
 	}
 
+	// Scanner defines the malware scanning of uploaded files.
+	Scanner struct {
+		// Provider is the scanning service, one of none, clamd or icap.
+		Provider scanner.Provider `envconfig:"GITNESS_SCANNER_PROVIDER" default:"none"`
+
+		// Address of the scanning service, e.g. "tcp://localhost:3310" or "unix:///run/clamd.ctl" for clamd,
+		// or "icap://localhost:1344/avscan" for ICAP.
+		Address string `envconfig:"GITNESS_SCANNER_ADDRESS"`
+
+		// Timeout is the maximum duration of scanning a single file.
+		Timeout time.Duration `envconfig:"GITNESS_SCANNER_TIMEOUT" default:"30s"`
+
+		// Action defines what happens with infected files, one of block or quarantine.
+		Action scanner.Action `envconfig:"GITNESS_SCANNER_ACTION" default:"block"`
+
+		// FailOpen accepts files that couldn't be scanned (e.g. the scanning service isn't reachable).
+		FailOpen bool `envconfig:"GITNESS_SCANNER_FAIL_OPEN" default:"false"`
+	}
+
+	// Deletion defines the protection of repositories and spaces against accidental deletion.
+	// If enabled, deleting a repository or space above any of the thresholds requires an explicit confirmation token.
+	Deletion struct {
+		// RequireConfirmation enables the confirmation of deletions above the thresholds.
+		// Disabled by default, as clients have to handle the 428 response and repeat the request with the token.
+		RequireConfirmation bool `envconfig:"GITNESS_DELETION_REQUIRE_CONFIRMATION" default:"false"`
+
+		// SizeThreshold is the total size of the repositories (in KiB) above which deletion requires confirmation.
+		// A non-positive value disables the threshold.
+		SizeThreshold int64 `envconfig:"GITNESS_DELETION_SIZE_THRESHOLD" default:"1048576"`
+
+		// PullReqThreshold is the total number of pull requests above which deletion requires confirmation.
+		// A non-positive value disables the threshold.
+		PullReqThreshold int `envconfig:"GITNESS_DELETION_PULLREQ_THRESHOLD" default:"100"`
+
+		// ConfirmationLifetime is the duration for which a deletion confirmation token is valid.
+		ConfirmationLifetime time.Duration `envconfig:"GITNESS_DELETION_CONFIRMATION_LIFETIME" default:"5m"`
+	}
+
 	// Token defines token configuration parameters.
 	Token struct {
 		CookieName string        `envconfig:"GITNESS_TOKEN_COOKIE_NAME" default:"token"`
 		Expire     time.Duration `envconfig:"GITNESS_TOKEN_EXPIRE" default:"720h"`
+
+		// PATRequireSpaces requires personal access tokens to be restricted to an explicit list of spaces.
+		PATRequireSpaces bool `envconfig:"GITNESS_TOKEN_PAT_REQUIRE_SPACES" default:"false"`
+
+		// User defines the lifetime policy of the tokens of users (sessions and personal access tokens).
+		User struct {
+			MaxLifetime    time.Duration `envconfig:"GITNESS_TOKEN_USER_MAX_LIFETIME"`
+			IdleTimeout    time.Duration `envconfig:"GITNESS_TOKEN_USER_IDLE_TIMEOUT"`
+			ReauthInterval time.Duration `envconfig:"GITNESS_TOKEN_USER_REAUTH_INTERVAL"`
+		}
+
+		// ServiceAccount defines the lifetime policy of the tokens of service accounts.
+		ServiceAccount struct {
+			MaxLifetime    time.Duration `envconfig:"GITNESS_TOKEN_SERVICE_ACCOUNT_MAX_LIFETIME"`
+			IdleTimeout    time.Duration `envconfig:"GITNESS_TOKEN_SERVICE_ACCOUNT_IDLE_TIMEOUT"`
+			ReauthInterval time.Duration `envconfig:"GITNESS_TOKEN_SERVICE_ACCOUNT_REAUTH_INTERVAL"`
+		}
+
+		// Service defines the lifetime policy of the tokens of services (bots).
+		Service struct {
+			MaxLifetime    time.Duration `envconfig:"GITNESS_TOKEN_SERVICE_MAX_LIFETIME"`
+			IdleTimeout    time.Duration `envconfig:"GITNESS_TOKEN_SERVICE_IDLE_TIMEOUT"`
+			ReauthInterval time.Duration `envconfig:"GITNESS_TOKEN_SERVICE_REAUTH_INTERVAL"`
+		}
+	}
+
+	// WebAuthn defines the passkey (WebAuthn) login configuration.
+	WebAuthn struct {
+		// Enabled allows users to register passkeys and sign in with them.
+		// Users with a registered passkey can no longer sign in with their password alone.
+		Enabled bool `envconfig:"GITNESS_WEBAUTHN_ENABLED" default:"false"`
+
+		// RPID is the relying party identifier, the domain the passkeys are bound to.
+		// Value is derived from RPOrigin unless explicitly specified (e.g. example.com).
+		RPID string `envconfig:"GITNESS_WEBAUTHN_RP_ID"`
+
+		// RPOrigin is the origin of the UI the WebAuthn ceremonies are performed on.
+		// Value is derived from URL.UI unless explicitly specified (e.g. https://example.com).
+		RPOrigin string `envconfig:"GITNESS_WEBAUTHN_RP_ORIGIN"`
+
+		// RPDisplayName is the name of the relying party shown by the authenticator.
+		RPDisplayName string `envconfig:"GITNESS_WEBAUTHN_RP_DISPLAY_NAME" default:"Gitness"`
+
+		// Timeout is the duration a user has to complete a registration or a login ceremony.
+		Timeout time.Duration `envconfig:"GITNESS_WEBAUTHN_TIMEOUT" default:"5m"`
+
+		// Passwordless allows passkeys as the only factor. If disabled, the password has to be provided
+		// when the login ceremony begins and passkeys are used as the second factor.
+		Passwordless bool `envconfig:"GITNESS_WEBAUTHN_PASSWORDLESS" default:"true"`
+
+		// RequireForAdmins requires admin accounts to sign in with a passkey and prevents them from deleting
+		// their last passkey. Admins without a passkey can sign in with the password to register one,
+		// but they don't get admin permissions until they registered a passkey.
+		RequireForAdmins bool `envconfig:"GITNESS_WEBAUTHN_REQUIRE_FOR_ADMINS" default:"false"`
 	}
 
 	Logs struct {
@@ -184,8 +395,8 @@ type Config struct {
 	Cors struct {
 		AllowedOrigins   []string `envconfig:"GITNESS_CORS_ALLOWED_ORIGINS"   default:"*"`
 		AllowedMethods   []string `envconfig:"GITNESS_CORS_ALLOWED_METHODS"   default:"GET,POST,PATCH,PUT,DELETE,OPTIONS"`
-		AllowedHeaders   []string `envconfig:"GITNESS_CORS_ALLOWED_HEADERS"   default:"Origin,Accept,Accept-Language,Authorization,Content-Type,Content-Language,X-Requested-With,X-Request-Id"` //nolint:lll // struct tags can't be multiline
-		ExposedHeaders   []string `envconfig:"GITNESS_CORS_EXPOSED_HEADERS"   default:"Link"`
+		AllowedHeaders   []string `envconfig:"GITNESS_CORS_ALLOWED_HEADERS"   default:"Origin,Accept,Accept-Language,Authorization,Content-Type,Content-Language,X-Requested-With,X-Request-Id,X-Consistency-Token"` //nolint:lll // struct tags can't be multiline
+		ExposedHeaders   []string `envconfig:"GITNESS_CORS_EXPOSED_HEADERS"   default:"Link,X-Consistency-Token"`
 		AllowCredentials bool     `envconfig:"GITNESS_CORS_ALLOW_CREDENTIALS" default:"true"`
 		MaxAge           int      `envconfig:"GITNESS_CORS_MAX_AGE"           default:"300"`
 	}
@@ -282,6 +493,27 @@ type Config struct {
 		// RetentionTime is the duration after which non-recurring,
 		// finished and failed jobs will be purged from the DB.
 		RetentionTime time.Duration `envconfig:"GITNESS_JOBS_RETENTION_TIME" default:"120h"` // 5 days
+
+		// SpaceWeights are the scheduling weights of spaces in the format "spaceID:weight,spaceID:weight".
+		// When more jobs are ready than can be run, the execution slots are shared between spaces
+		// proportionally to their weights. Spaces without explicit weight have weight 1.
+		// Space ID 0 is used for jobs that don't belong to any space (e.g. maintenance jobs).
+		SpaceWeights map[int64]int `envconfig:"GITNESS_JOBS_SPACE_WEIGHTS"`
+	}
+
+	// Leader contains the configuration of the leader election of gitness instances.
+	// Only the leader runs the singleton background services (e.g. the registration of recurring jobs),
+	// other instances are kept as warm standby and take over if the leader goes away.
+	// Running more than one instance requires a distributed lock provider (redis).
+	Leader struct {
+		// TTL is the duration after which the leadership expires if it isn't renewed.
+		TTL time.Duration `envconfig:"GITNESS_LEADER_TTL" default:"30s"`
+
+		// RenewInterval is the interval at which the leader renews its leadership.
+		RenewInterval time.Duration `envconfig:"GITNESS_LEADER_RENEW_INTERVAL" default:"10s"`
+
+		// RetryInterval is the interval at which standby instances try to acquire the leadership.
+		RetryInterval time.Duration `envconfig:"GITNESS_LEADER_RETRY_INTERVAL" default:"5s"`
 	}
 
 	Webhook struct {
@@ -299,6 +531,12 @@ type Config struct {
 		RetentionTime time.Duration `envconfig:"GITNESS_WEBHOOK_RETENTION_TIME" default:"168h"` // 7 days
 	}
 
+	GitUsage struct {
+		// RetentionTime is the duration after which the usage of git service pack invocations
+		// (upload-pack and receive-pack) will be purged from the DB.
+		RetentionTime time.Duration `envconfig:"GITNESS_GIT_USAGE_RETENTION_TIME" default:"720h"` // 30 days
+	}
+
 	Trigger struct {
 		Concurrency int `envconfig:"GITNESS_TRIGGER_CONCURRENCY" default:"4"`
 		MaxRetries  int `envconfig:"GITNESS_TRIGGER_MAX_RETRIES" default:"3"`
@@ -315,12 +553,144 @@ type Config struct {
 		CRON        string        `envconfig:"GITNESS_REPO_SIZE_CRON" default:"* * 0 * * * *"`
 		MaxDuration time.Duration `envconfig:"GITNESS_REPO_SIZE_MAX_DURATION" default:"15m"`
 		NumWorkers  int           `envconfig:"GITNESS_REPO_SIZE_NUM_WORKERS" default:"5"`
+
+		// Limit is the maximum size of a repository (in KiB). Pushes that would exceed it are rejected.
+		// A non-positive value disables the limit.
+		Limit int64 `envconfig:"GITNESS_REPO_SIZE_LIMIT" default:"0"`
+
+		// LimitWarnOnly only warns about pushes exceeding the limit instead of rejecting them.
+		LimitWarnOnly bool `envconfig:"GITNESS_REPO_SIZE_LIMIT_WARN_ONLY" default:"false"`
+	}
+
+	// CommitIndex defines the commit search index of the repositories.
+	// The index is updated incrementally when the default branch of a repository is updated.
+	CommitIndex struct {
+		Enabled     bool `envconfig:"GITNESS_COMMIT_INDEX_ENABLED" default:"true"`
+		Concurrency int  `envconfig:"GITNESS_COMMIT_INDEX_CONCURRENCY" default:"4"`
+		MaxRetries  int  `envconfig:"GITNESS_COMMIT_INDEX_MAX_RETRIES" default:"3"`
+
+		// JobMaxDuration is the maximum duration of indexing of a single repository.
+		JobMaxDuration time.Duration `envconfig:"GITNESS_COMMIT_INDEX_JOB_MAX_DURATION" default:"1h"`
+	}
+
+	// RepoStats defines the periodic calculation of the repository disk usage and object statistics.
+	RepoStats struct {
+		Enabled     bool          `envconfig:"GITNESS_REPO_STATS_ENABLED" default:"true"`
+		CRON        string        `envconfig:"GITNESS_REPO_STATS_CRON" default:"0 0 3 * * * *"`
+		MaxDuration time.Duration `envconfig:"GITNESS_REPO_STATS_MAX_DURATION" default:"2h"`
+		NumWorkers  int           `envconfig:"GITNESS_REPO_STATS_NUM_WORKERS" default:"2"`
+
+		// JobMaxDuration is the maximum duration of the calculation of the stats of a single repository
+		// when the refresh is requested explicitly.
+		JobMaxDuration time.Duration `envconfig:"GITNESS_REPO_STATS_JOB_MAX_DURATION" default:"15m"`
+
+		// LargestBlobs is the number of the largest blobs that are reported per repository.
+		LargestBlobs int `envconfig:"GITNESS_REPO_STATS_LARGEST_BLOBS" default:"20"`
+	}
+
+	// RepoMirror defines the periodic synchronization of mirror repositories with their upstream repositories.
+	RepoMirror struct {
+		Enabled bool `envconfig:"GITNESS_REPO_MIRROR_ENABLED" default:"true"`
+		// CRON defines when the mirrors are checked for a due synchronization.
+		CRON        string        `envconfig:"GITNESS_REPO_MIRROR_CRON" default:"0 * * * * * *"`
+		MaxDuration time.Duration `envconfig:"GITNESS_REPO_MIRROR_MAX_DURATION" default:"1m"`
+
+		// JobMaxDuration is the maximum duration of the synchronization of a single mirror.
+		JobMaxDuration time.Duration `envconfig:"GITNESS_REPO_MIRROR_JOB_MAX_DURATION" default:"30m"`
+
+		// DefaultSyncInterval is used for mirrors that don't define their own interval,
+		// MinSyncInterval is the shortest interval a mirror can define.
+		DefaultSyncInterval time.Duration `envconfig:"GITNESS_REPO_MIRROR_DEFAULT_SYNC_INTERVAL" default:"1h"`
+		MinSyncInterval     time.Duration `envconfig:"GITNESS_REPO_MIRROR_MIN_SYNC_INTERVAL" default:"5m"`
+	}
+
+	// RepoMaintenance defines the background maintenance (gc, repack, commit-graph) of the git repositories.
+	RepoMaintenance struct {
+		Enabled bool `envconfig:"GITNESS_REPO_MAINTENANCE_ENABLED" default:"true"`
+		// CRON defines when the repositories are scanned for the need of maintenance.
+		CRON        string        `envconfig:"GITNESS_REPO_MAINTENANCE_CRON" default:"0 30 2 * * * *"`
+		MaxDuration time.Duration `envconfig:"GITNESS_REPO_MAINTENANCE_MAX_DURATION" default:"30m"`
+		NumWorkers  int           `envconfig:"GITNESS_REPO_MAINTENANCE_NUM_WORKERS" default:"2"`
+
+		// JobMaxDuration is the maximum duration of the maintenance of a single repository.
+		JobMaxDuration time.Duration `envconfig:"GITNESS_REPO_MAINTENANCE_JOB_MAX_DURATION" default:"1h"`
+
+		// LooseObjectsThreshold is the number of loose objects at which git gc is run.
+		LooseObjectsThreshold int `envconfig:"GITNESS_REPO_MAINTENANCE_LOOSE_OBJECTS_THRESHOLD" default:"1000"`
+		// PacksThreshold is the number of packs at which the repository is repacked.
+		PacksThreshold int `envconfig:"GITNESS_REPO_MAINTENANCE_PACKS_THRESHOLD" default:"20"`
+	}
+
+	// GithookExtensions defines the custom logic that runs in the server side git hooks.
+	GithookExtensions struct {
+		// ConfigPath is the path to a JSON file listing the external extensions (executables or HTTP endpoints).
+		ConfigPath string `envconfig:"GITNESS_GITHOOK_EXTENSIONS_CONFIG_PATH"`
+		// Timeout is the timeout of extensions that don't define their own.
+		Timeout time.Duration `envconfig:"GITNESS_GITHOOK_EXTENSIONS_TIMEOUT" default:"10s"`
+	}
+
+	// PolicyHooks defines the policy hooks that repositories declare in a file of their default branch,
+	// which are evaluated when branches are pushed.
+	PolicyHooks struct {
+		Enabled  bool   `envconfig:"GITNESS_POLICY_HOOKS_ENABLED" default:"true"`
+		FilePath string `envconfig:"GITNESS_POLICY_HOOKS_FILE_PATH" default:".gitness/hooks.yaml"`
+	}
+
+	// Compliance defines the configuration of the job that evaluates the required files policies of spaces.
+	Compliance struct {
+		Enabled     bool          `envconfig:"GITNESS_COMPLIANCE_ENABLED" default:"true"`
+		CRON        string        `envconfig:"GITNESS_COMPLIANCE_CRON" default:"0 0 3 * * * *"`
+		MaxDuration time.Duration `envconfig:"GITNESS_COMPLIANCE_MAX_DURATION" default:"30m"`
+
+		// Concurrency and MaxRetries configure the evaluation of repositories on pushes to their default branch.
+		Concurrency int `envconfig:"GITNESS_COMPLIANCE_CONCURRENCY" default:"4"`
+		MaxRetries  int `envconfig:"GITNESS_COMPLIANCE_MAX_RETRIES" default:"3"`
+	}
+
+	// ComplianceSnapshot defines the configuration of the monthly job that freezes audit aggregates
+	// into immutable, hash chained compliance snapshots.
+	ComplianceSnapshot struct {
+		Enabled     bool          `envconfig:"GITNESS_COMPLIANCE_SNAPSHOT_ENABLED" default:"true"`
+		CRON        string        `envconfig:"GITNESS_COMPLIANCE_SNAPSHOT_CRON" default:"0 0 4 1 * * *"`
+		MaxDuration time.Duration `envconfig:"GITNESS_COMPLIANCE_SNAPSHOT_MAX_DURATION" default:"1h"`
+	}
+
+	// PipelineSchedule defines the configuration of the job that triggers the executions of scheduled pipelines.
+	PipelineSchedule struct {
+		Enabled     bool          `envconfig:"GITNESS_PIPELINE_SCHEDULE_ENABLED" default:"true"`
+		CRON        string        `envconfig:"GITNESS_PIPELINE_SCHEDULE_CRON" default:"0 * * * * * *"`
+		MaxDuration time.Duration `envconfig:"GITNESS_PIPELINE_SCHEDULE_MAX_DURATION" default:"1m"`
+	}
+
+	// PipelineApproval defines the configuration of the job that expires timed out pipeline approvals.
+	PipelineApproval struct {
+		CRON        string        `envconfig:"GITNESS_PIPELINE_APPROVAL_CRON" default:"0 * * * * * *"`
+		MaxDuration time.Duration `envconfig:"GITNESS_PIPELINE_APPROVAL_MAX_DURATION" default:"1m"`
+	}
+
+	// PipelineTimeout defines the configuration of the job that aborts the timed out pipeline executions.
+	PipelineTimeout struct {
+		CRON        string        `envconfig:"GITNESS_PIPELINE_TIMEOUT_CRON" default:"0 * * * * * *"`
+		MaxDuration time.Duration `envconfig:"GITNESS_PIPELINE_TIMEOUT_MAX_DURATION" default:"1m"`
 	}
 
 	CodeOwners struct {
 		FilePaths []string `envconfig:"GITNESS_CODEOWNERS_FILEPATH" default:"CODEOWNERS,.harness/CODEOWNERS"`
 	}
 
+	// Diff defines the server-side limits of the pull request diffs returned by the API.
+	// Files exceeding the limits are returned without the patch and marked as too large.
+	Diff struct {
+		// MaxFiles is the maximum number of files returned with the patch in a single response.
+		MaxFiles int `envconfig:"GITNESS_DIFF_MAX_FILES" default:"300"`
+		// MaxFileLines and MaxFileBytes limit the size of the patch of a single file.
+		MaxFileLines int `envconfig:"GITNESS_DIFF_MAX_FILE_LINES" default:"10000"`
+		MaxFileBytes int `envconfig:"GITNESS_DIFF_MAX_FILE_BYTES" default:"1048576"`
+		// MaxLines and MaxBytes limit the combined size of all patches in a single response.
+		MaxLines int `envconfig:"GITNESS_DIFF_MAX_LINES" default:"50000"`
+		MaxBytes int `envconfig:"GITNESS_DIFF_MAX_BYTES" default:"10485760"`
+	}
+
 	SMTP struct {
 		Host     string `envconfig:"GITNESS_SMTP_HOST"`
 		Port     int    `envconfig:"GITNESS_SMTP_PORT"`
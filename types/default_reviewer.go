@@ -0,0 +1,38 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "encoding/json"
+
+// DefaultReviewer is a user or a user group that is automatically added as a reviewer
+// to every new pull request of a repository.
+type DefaultReviewer struct {
+	ID     int64 `json:"id"`
+	RepoID int64 `json:"repo_id"`
+
+	// Either the PrincipalID or the UserGroupID is set.
+	PrincipalID *int64 `json:"principal_id,omitempty"`
+	UserGroupID string `json:"user_group_id,omitempty"`
+
+	// TargetBranches is a branch pattern. If set, the reviewer is added
+	// only to pull requests with a matching target branch.
+	TargetBranches json.RawMessage `json:"target_branches,omitempty"`
+
+	CreatedBy int64 `json:"created_by"`
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+
+	Principal *PrincipalInfo `json:"principal,omitempty"`
+}
@@ -0,0 +1,33 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// DeletionImpact summarizes everything that gets deleted together with a repository or a space.
+// The size is the total size of the repositories in KiB.
+type DeletionImpact struct {
+	Spaces       int   `json:"spaces"`
+	Repositories int   `json:"repositories"`
+	Size         int64 `json:"size"`
+	PullReqs     int   `json:"pull_requests"`
+	OpenPullReqs int   `json:"open_pull_requests"`
+}
+
+// Add adds the repository to the impact.
+func (i *DeletionImpact) Add(repo *Repository) {
+	i.Repositories++
+	i.Size += repo.Size
+	i.PullReqs += repo.NumPulls
+	i.OpenPullReqs += repo.NumOpenPulls
+}
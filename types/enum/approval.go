@@ -0,0 +1,57 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// ApprovalState defines the state of a pipeline approval gate.
+type ApprovalState string
+
+func (ApprovalState) Enum() []interface{}                    { return toInterfaceSlice(approvalStates) }
+func (s ApprovalState) Sanitize() (ApprovalState, bool)      { return Sanitize(s, GetAllApprovalStates) }
+func GetAllApprovalStates() ([]ApprovalState, ApprovalState) { return approvalStates, "" }
+
+// ApprovalState enumeration.
+const (
+	ApprovalStatePending  ApprovalState = "pending"
+	ApprovalStateApproved ApprovalState = "approved"
+	ApprovalStateRejected ApprovalState = "rejected"
+	ApprovalStateExpired  ApprovalState = "expired"
+)
+
+var approvalStates = sortEnum([]ApprovalState{
+	ApprovalStatePending,
+	ApprovalStateApproved,
+	ApprovalStateRejected,
+	ApprovalStateExpired,
+})
+
+// ApprovalDecision defines the decision a user can make on a pipeline approval gate.
+type ApprovalDecision string
+
+func (ApprovalDecision) Enum() []interface{} { return toInterfaceSlice(approvalDecisions) }
+func (d ApprovalDecision) Sanitize() (ApprovalDecision, bool) {
+	return Sanitize(d, GetAllApprovalDecisions)
+}
+func GetAllApprovalDecisions() ([]ApprovalDecision, ApprovalDecision) { return approvalDecisions, "" }
+
+// ApprovalDecision enumeration.
+const (
+	ApprovalDecisionApprove ApprovalDecision = "approve"
+	ApprovalDecisionReject  ApprovalDecision = "reject"
+)
+
+var approvalDecisions = sortEnum([]ApprovalDecision{
+	ApprovalDecisionApprove,
+	ApprovalDecisionReject,
+})
@@ -55,6 +55,7 @@ const (
 	CheckPayloadKindRaw      CheckPayloadKind = "raw"
 	CheckPayloadKindMarkdown CheckPayloadKind = "markdown"
 	CheckPayloadKindPipeline CheckPayloadKind = "pipeline"
+	CheckPayloadKindReport   CheckPayloadKind = "report"
 )
 
 var checkPayloadTypes = sortEnum([]CheckPayloadKind{
@@ -62,4 +63,29 @@ var checkPayloadTypes = sortEnum([]CheckPayloadKind{
 	CheckPayloadKindRaw,
 	CheckPayloadKindMarkdown,
 	CheckPayloadKindPipeline,
+	CheckPayloadKindReport,
+})
+
+// CheckAnnotationLevel defines the severity of a status check annotation.
+type CheckAnnotationLevel string
+
+func (CheckAnnotationLevel) Enum() []interface{} { return toInterfaceSlice(checkAnnotationLevels) }
+func (l CheckAnnotationLevel) Sanitize() (CheckAnnotationLevel, bool) {
+	return Sanitize(l, GetAllCheckAnnotationLevels)
+}
+func GetAllCheckAnnotationLevels() ([]CheckAnnotationLevel, CheckAnnotationLevel) {
+	return checkAnnotationLevels, CheckAnnotationLevelNotice
+}
+
+// CheckAnnotationLevel enumeration.
+const (
+	CheckAnnotationLevelNotice  CheckAnnotationLevel = "notice"
+	CheckAnnotationLevelWarning CheckAnnotationLevel = "warning"
+	CheckAnnotationLevelFailure CheckAnnotationLevel = "failure"
+)
+
+var checkAnnotationLevels = sortEnum([]CheckAnnotationLevel{
+	CheckAnnotationLevelNotice,
+	CheckAnnotationLevelWarning,
+	CheckAnnotationLevelFailure,
 })
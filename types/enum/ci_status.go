@@ -37,13 +37,13 @@ const (
 )
 
 func (status CIStatus) ConvertToCheckStatus() CheckStatus {
-	if status == CIStatusPending || status == CIStatusWaitingOnDeps {
+	if status == CIStatusPending || status == CIStatusWaitingOnDeps || status == CIStatusBlocked {
 		return CheckStatusPending
 	}
 	if status == CIStatusSuccess || status == CIStatusSkipped {
 		return CheckStatusSuccess
 	}
-	if status == CIStatusFailure {
+	if status == CIStatusFailure || status == CIStatusDeclined {
 		return CheckStatusFailure
 	}
 	if status == CIStatusRunning {
@@ -85,5 +85,33 @@ func (status CIStatus) IsDone() bool {
 func (status CIStatus) IsFailed() bool {
 	return status == CIStatusFailure ||
 		status == CIStatusKilled ||
-		status == CIStatusError
+		status == CIStatusError ||
+		status == CIStatusDeclined
 }
+
+// ExecutionFailureReason defines why an execution was aborted by the server.
+type ExecutionFailureReason string
+
+func (ExecutionFailureReason) Enum() []interface{} { return toInterfaceSlice(executionFailureReasons) }
+func (r ExecutionFailureReason) Sanitize() (ExecutionFailureReason, bool) {
+	return Sanitize(r, GetAllExecutionFailureReasons)
+}
+func GetAllExecutionFailureReasons() ([]ExecutionFailureReason, ExecutionFailureReason) {
+	return executionFailureReasons, ""
+}
+
+// ExecutionFailureReason enumeration.
+const (
+	// ExecutionFailureReasonPipelineTimeout means that the execution exceeded the pipeline timeout.
+	ExecutionFailureReasonPipelineTimeout ExecutionFailureReason = "pipeline_timeout"
+	// ExecutionFailureReasonStageTimeout means that a stage of the execution exceeded its timeout.
+	ExecutionFailureReasonStageTimeout ExecutionFailureReason = "stage_timeout"
+	// ExecutionFailureReasonStepTimeout means that a step of the execution exceeded its timeout.
+	ExecutionFailureReasonStepTimeout ExecutionFailureReason = "step_timeout"
+)
+
+var executionFailureReasons = sortEnum([]ExecutionFailureReason{
+	ExecutionFailureReasonPipelineTimeout,
+	ExecutionFailureReasonStageTimeout,
+	ExecutionFailureReasonStepTimeout,
+})
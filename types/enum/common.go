@@ -15,7 +15,11 @@
 package enum
 
 import (
+	"fmt"
+	"strings"
+
 	"golang.org/x/exp/constraints"
+	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slices"
 )
 
@@ -32,6 +36,55 @@ func Sanitize[E constraints.Ordered](element E, all func() ([]E, E)) (E, bool) {
 	return defValue, false
 }
 
+// SanitizeStrict is like Sanitize, but returns an error listing the accepted values
+// if the element isn't part of the enumeration, instead of falling back to the default value.
+func SanitizeStrict[E ~string](element E, all func() ([]E, E)) (E, error) {
+	if sanitized, ok := Sanitize(element, all); ok {
+		return sanitized, nil
+	}
+
+	allValues, _ := all()
+	accepted := make([]string, len(allValues))
+	for i, v := range allValues {
+		accepted[i] = string(v)
+	}
+
+	return "", errInvalidValue(string(element), accepted)
+}
+
+// parseEnum returns the enumeration value the string maps to in the table of the enumeration,
+// or the default value if the string isn't accepted. The string is compared case-insensitive.
+func parseEnum[E any](values map[string]E, s string, defValue E) E {
+	if v, ok := values[strings.ToLower(s)]; ok {
+		return v
+	}
+
+	return defValue
+}
+
+// parseEnumStrict is like parseEnum, but returns an error listing the accepted strings
+// if a non-empty string isn't accepted, instead of falling back to the default value.
+// The accepted strings are the keys of the same table, so they can't diverge from parseEnum.
+func parseEnumStrict[E any](values map[string]E, s string, defValue E) (E, error) {
+	if s == "" {
+		return defValue, nil
+	}
+
+	if v, ok := values[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+
+	accepted := maps.Keys(values)
+	slices.Sort(accepted)
+
+	var empty E
+	return empty, errInvalidValue(s, accepted)
+}
+
+func errInvalidValue(value string, accepted []string) error {
+	return fmt.Errorf("invalid value %q, accepted values are: %s", value, strings.Join(accepted, ", "))
+}
+
 const (
 	id            = "id"
 	uid           = "uid"
@@ -28,17 +28,23 @@ const (
 	BranchSortOptionDate
 )
 
+// branchSortOptionStrings maps the accepted (lower case) strings to the BranchSortOption enumeration,
+// it's used by ParseBranchSortOption and ParseBranchSortOptionStrict.
+var branchSortOptionStrings = map[string]BranchSortOption{
+	name: BranchSortOptionName,
+	date: BranchSortOptionDate,
+}
+
 // ParseBranchSortOption parses the branch sort option string
 // and returns the equivalent enumeration.
 func ParseBranchSortOption(s string) BranchSortOption {
-	switch strings.ToLower(s) {
-	case name:
-		return BranchSortOptionName
-	case date:
-		return BranchSortOptionDate
-	default:
-		return BranchSortOptionDefault
-	}
+	return parseEnum(branchSortOptionStrings, s, BranchSortOptionDefault)
+}
+
+// ParseBranchSortOptionStrict parses the branch sort option string like ParseBranchSortOption,
+// but returns an error for values that aren't accepted.
+func ParseBranchSortOptionStrict(s string) (BranchSortOption, error) {
+	return parseEnumStrict(branchSortOptionStrings, s, BranchSortOptionDefault)
 }
 
 // String returns a string representation of the branch sort option.
@@ -64,17 +70,23 @@ const (
 	TagSortOptionDate
 )
 
+// tagSortOptionStrings maps the accepted (lower case) strings to the TagSortOption enumeration,
+// it's used by ParseTagSortOption and ParseTagSortOptionStrict.
+var tagSortOptionStrings = map[string]TagSortOption{
+	name: TagSortOptionName,
+	date: TagSortOptionDate,
+}
+
 // ParseTagSortOption parses the tag sort option string
 // and returns the equivalent enumeration.
 func ParseTagSortOption(s string) TagSortOption {
-	switch strings.ToLower(s) {
-	case name:
-		return TagSortOptionName
-	case date:
-		return TagSortOptionDate
-	default:
-		return TagSortOptionDefault
-	}
+	return parseEnum(tagSortOptionStrings, s, TagSortOptionDefault)
+}
+
+// ParseTagSortOptionStrict parses the tag sort option string like ParseTagSortOption,
+// but returns an error for values that aren't accepted.
+func ParseTagSortOptionStrict(s string) (TagSortOption, error) {
+	return parseEnumStrict(tagSortOptionStrings, s, TagSortOptionDefault)
 }
 
 // String returns a string representation of the tag sort option.
@@ -0,0 +1,39 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// GitUsageSort defines the git usage attribute that can be used for sorting.
+type GitUsageSort string
+
+func (GitUsageSort) Enum() []interface{}                  { return toInterfaceSlice(gitUsageSorts) }
+func (s GitUsageSort) Sanitize() (GitUsageSort, bool)     { return Sanitize(s, GetAllGitUsageSorts) }
+func GetAllGitUsageSorts() ([]GitUsageSort, GitUsageSort) { return gitUsageSorts, GitUsageSortCPUTime }
+
+// GitUsageSort enumeration.
+const (
+	GitUsageSortInvocations   GitUsageSort = "invocations"
+	GitUsageSortCPUTime       GitUsageSort = "cpu_time"
+	GitUsageSortWallTime      GitUsageSort = "wall_time"
+	GitUsageSortBytesReceived GitUsageSort = "bytes_received"
+	GitUsageSortBytesSent     GitUsageSort = "bytes_sent"
+)
+
+var gitUsageSorts = sortEnum([]GitUsageSort{
+	GitUsageSortInvocations,
+	GitUsageSortCPUTime,
+	GitUsageSortWallTime,
+	GitUsageSortBytesReceived,
+	GitUsageSortBytesSent,
+})
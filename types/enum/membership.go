@@ -14,10 +14,6 @@
 
 package enum
 
-import (
-	"strings"
-)
-
 // MembershipUserSort represents membership user sort order.
 type MembershipUserSort string
 
@@ -40,17 +36,24 @@ func GetAllMembershipUserSorts() ([]MembershipUserSort, MembershipUserSort) {
 	return membershipUserSorts, MembershipUserSortName
 }
 
+// membershipUserSortStrings maps the accepted (lower case) strings to the MembershipUserSort enumeration,
+// it's used by ParseMembershipUserSort and ParseMembershipUserSortStrict.
+var membershipUserSortStrings = map[string]MembershipUserSort{
+	name:      MembershipUserSortName,
+	created:   MembershipUserSortCreated,
+	createdAt: MembershipUserSortCreated,
+}
+
 // ParseMembershipUserSort parses the membership user sort attribute string
 // and returns the equivalent enumeration.
 func ParseMembershipUserSort(s string) MembershipUserSort {
-	switch strings.ToLower(s) {
-	case name:
-		return MembershipUserSortName
-	case created, createdAt:
-		return MembershipUserSortCreated
-	default:
-		return MembershipUserSortName
-	}
+	return parseEnum(membershipUserSortStrings, s, MembershipUserSortName)
+}
+
+// ParseMembershipUserSortStrict parses the membership user sort attribute string like ParseMembershipUserSort,
+// but returns an error for values that aren't accepted.
+func ParseMembershipUserSortStrict(s string) (MembershipUserSort, error) {
+	return parseEnumStrict(membershipUserSortStrings, s, MembershipUserSortName)
 }
 
 // String returns the string representation of the attribute.
@@ -87,17 +90,24 @@ func GetAllMembershipSpaceSorts() ([]MembershipSpaceSort, MembershipSpaceSort) {
 	return membershipSpaceSorts, MembershipSpaceSortUID
 }
 
+// membershipSpaceSortStrings maps the accepted (lower case) strings to the MembershipSpaceSort enumeration,
+// it's used by ParseMembershipSpaceSort and ParseMembershipSpaceSortStrict.
+var membershipSpaceSortStrings = map[string]MembershipSpaceSort{
+	name:      MembershipSpaceSortUID,
+	created:   MembershipSpaceSortCreated,
+	createdAt: MembershipSpaceSortCreated,
+}
+
 // ParseMembershipSpaceSort parses the membership space sort attribute string
 // and returns the equivalent enumeration.
 func ParseMembershipSpaceSort(s string) MembershipSpaceSort {
-	switch strings.ToLower(s) {
-	case name:
-		return MembershipSpaceSortUID
-	case created, createdAt:
-		return MembershipSpaceSortCreated
-	default:
-		return MembershipSpaceSortUID
-	}
+	return parseEnum(membershipSpaceSortStrings, s, MembershipSpaceSortUID)
+}
+
+// ParseMembershipSpaceSortStrict parses the membership space sort attribute string like ParseMembershipSpaceSort,
+// but returns an error for values that aren't accepted.
+func ParseMembershipSpaceSortStrict(s string) (MembershipSpaceSort, error) {
+	return parseEnumStrict(membershipSpaceSortStrings, s, MembershipSpaceSortUID)
 }
 
 // String returns the string representation of the attribute.
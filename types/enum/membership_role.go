@@ -25,11 +25,14 @@ func GetAllMembershipRoles() ([]MembershipRole, MembershipRole) { return Members
 
 var MembershipRoles = sortEnum([]MembershipRole{
 	MembershipRoleReader,
+	MembershipRoleReviewer,
 	MembershipRoleExecutor,
 	MembershipRoleContributor,
 	MembershipRoleSpaceOwner,
 })
 
+// membershipRoleReaderPermissions are view-only, readers can't comment on or review pull requests.
+// Readers that existed before PermissionRepoReview was introduced were migrated to the reviewer role.
 var membershipRoleReaderPermissions = slices.Clip(slices.Insert([]Permission{}, 0,
 	PermissionRepoView,
 	PermissionSpaceView,
@@ -40,7 +43,14 @@ var membershipRoleReaderPermissions = slices.Clip(slices.Insert([]Permission{},
 	PermissionTemplateView,
 ))
 
+// membershipRoleReviewerPermissions are the permissions of the reviewer role,
+// which grants metadata contributions (comments, reviews and approvals) without push or merge rights.
+var membershipRoleReviewerPermissions = slices.Clip(slices.Insert(membershipRoleReaderPermissions, 0,
+	PermissionRepoReview,
+))
+
 var membershipRoleExecutorPermissions = slices.Clip(slices.Insert(membershipRoleReaderPermissions, 0,
+	PermissionRepoReview,
 	PermissionRepoReportCommitCheck,
 	PermissionPipelineExecute,
 	PermissionSecretAccess,
@@ -49,7 +59,9 @@ var membershipRoleExecutorPermissions = slices.Clip(slices.Insert(membershipRole
 ))
 
 var membershipRoleContributorPermissions = slices.Clip(slices.Insert(membershipRoleReaderPermissions, 0,
+	PermissionRepoReview,
 	PermissionRepoPush,
+	PermissionRepoMerge,
 ))
 
 var membershipRoleSpaceOwnerPermissions = slices.Clip(slices.Insert(membershipRoleReaderPermissions, 0,
@@ -57,6 +69,8 @@ var membershipRoleSpaceOwnerPermissions = slices.Clip(slices.Insert(membershipRo
 	PermissionRepoDelete,
 	PermissionRepoPush,
 	PermissionRepoReportCommitCheck,
+	PermissionRepoReview,
+	PermissionRepoMerge,
 
 	PermissionSpaceEdit,
 	PermissionSpaceCreate,
@@ -89,6 +103,7 @@ var membershipRoleSpaceOwnerPermissions = slices.Clip(slices.Insert(membershipRo
 
 func init() {
 	slices.Sort(membershipRoleReaderPermissions)
+	slices.Sort(membershipRoleReviewerPermissions)
 	slices.Sort(membershipRoleExecutorPermissions)
 	slices.Sort(membershipRoleContributorPermissions)
 	slices.Sort(membershipRoleSpaceOwnerPermissions)
@@ -99,6 +114,8 @@ func (m MembershipRole) Permissions() []Permission {
 	switch m {
 	case MembershipRoleReader:
 		return membershipRoleReaderPermissions
+	case MembershipRoleReviewer:
+		return membershipRoleReviewerPermissions
 	case MembershipRoleExecutor:
 		return membershipRoleExecutorPermissions
 	case MembershipRoleContributor:
@@ -112,6 +129,7 @@ func (m MembershipRole) Permissions() []Permission {
 
 const (
 	MembershipRoleReader      MembershipRole = "reader"
+	MembershipRoleReviewer    MembershipRole = "reviewer"
 	MembershipRoleExecutor    MembershipRole = "executor"
 	MembershipRoleContributor MembershipRole = "contributor"
 	MembershipRoleSpaceOwner  MembershipRole = "space_owner"
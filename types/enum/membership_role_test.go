@@ -0,0 +1,52 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+import (
+	"testing"
+
+	"golang.org/x/exp/slices"
+)
+
+func TestMembershipRolePermissions(t *testing.T) {
+	tests := []struct {
+		role       MembershipRole
+		permission Permission
+		want       bool
+	}{
+		{MembershipRoleReader, PermissionRepoView, true},
+		{MembershipRoleReader, PermissionRepoReview, false},
+		{MembershipRoleReader, PermissionRepoPush, false},
+		{MembershipRoleExecutor, PermissionRepoReview, true},
+		{MembershipRoleReviewer, PermissionRepoReview, true},
+		{MembershipRoleReviewer, PermissionRepoPush, false},
+		{MembershipRoleReviewer, PermissionRepoMerge, false},
+		{MembershipRoleContributor, PermissionRepoReview, true},
+		{MembershipRoleContributor, PermissionRepoPush, true},
+		{MembershipRoleContributor, PermissionRepoMerge, true},
+		{MembershipRoleContributor, PermissionRepoEdit, false},
+		{MembershipRoleSpaceOwner, PermissionRepoReview, true},
+		{MembershipRoleSpaceOwner, PermissionRepoMerge, true},
+		{MembershipRoleSpaceOwner, PermissionRepoEdit, true},
+	}
+
+	for _, test := range tests {
+		// the authorizer relies on the permissions being sorted
+		_, got := slices.BinarySearch(test.role.Permissions(), test.permission)
+		if got != test.want {
+			t.Errorf("Want role %q to have permission %q: %t, got %t", test.role, test.permission, test.want, got)
+		}
+	}
+}
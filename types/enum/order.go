@@ -14,10 +14,6 @@
 
 package enum
 
-import (
-	"strings"
-)
-
 // Order defines the sort order.
 type Order int
 
@@ -42,15 +38,23 @@ func (e Order) String() string {
 	}
 }
 
+// orderStrings maps the accepted (lower case) strings to the Order enumeration,
+// it's used by ParseOrder and ParseOrderStrict.
+var orderStrings = map[string]Order{
+	asc:        OrderAsc,
+	ascending:  OrderAsc,
+	desc:       OrderDesc,
+	descending: OrderDesc,
+}
+
 // ParseOrder parses the order string and returns
 // an order enumeration.
 func ParseOrder(s string) Order {
-	switch strings.ToLower(s) {
-	case asc, ascending:
-		return OrderAsc
-	case desc, descending:
-		return OrderDesc
-	default:
-		return OrderDefault
-	}
+	return parseEnum(orderStrings, s, OrderDefault)
+}
+
+// ParseOrderStrict parses the order string like ParseOrder,
+// but returns an error for values that aren't accepted.
+func ParseOrderStrict(s string) (Order, error) {
+	return parseEnumStrict(orderStrings, s, OrderDefault)
 }
@@ -14,7 +14,10 @@
 
 package enum
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestParseOrder(t *testing.T) {
 	tests := []struct {
@@ -42,3 +45,60 @@ func TestParseOrder(t *testing.T) {
 		}
 	}
 }
+
+func TestParseOrderStrict(t *testing.T) {
+	tests := []struct {
+		text    string
+		want    Order
+		wantErr bool
+	}{
+		{"asc", OrderAsc, false},
+		{"DESC", OrderDesc, false},
+		{"Descending", OrderDesc, false},
+		{"", OrderDefault, false},
+		{"invalid", OrderDefault, true},
+		{"default", OrderDefault, true},
+	}
+
+	for _, test := range tests {
+		got, err := ParseOrderStrict(test.text)
+		if (err != nil) != test.wantErr {
+			t.Errorf("Want order %q to fail %t, got err=%v", test.text, test.wantErr, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("Want order %q parsed as %q, got %q", test.text, test.want, got)
+		}
+	}
+}
+
+func TestSanitizeStrict(t *testing.T) {
+	sort, err := SanitizeStrict(PullReqSort(""), GetAllPullReqSorts)
+	if err != nil || sort != PullReqSortNumber {
+		t.Errorf("Want the default sort for an empty value, got %q (err=%v)", sort, err)
+	}
+
+	if _, err = SanitizeStrict(PullReqSort("numbr"), GetAllPullReqSorts); err == nil {
+		t.Errorf("Want an error for an unknown sort")
+	}
+}
+
+func TestParseEnumStrict(t *testing.T) {
+	values := map[string]Order{asc: OrderAsc, desc: OrderDesc}
+
+	for s, want := range values {
+		for _, text := range []string{s, strings.ToUpper(s)} {
+			if got := parseEnum(values, text, OrderDefault); got != want {
+				t.Errorf("Want %q parsed as %q, got %q", text, want, got)
+			}
+			if got, err := parseEnumStrict(values, text, OrderDefault); err != nil || got != want {
+				t.Errorf("Want %q strictly parsed as %q, got %q (err=%v)", text, want, got, err)
+			}
+		}
+	}
+
+	_, err := parseEnumStrict(values, "up", OrderDefault)
+	if err == nil || err.Error() != `invalid value "up", accepted values are: asc, desc` {
+		t.Errorf("Want an error listing the accepted values, got %v", err)
+	}
+}
@@ -51,6 +51,12 @@ const (
 	PermissionRepoDelete            Permission = "repo_delete"
 	PermissionRepoPush              Permission = "repo_push"
 	PermissionRepoReportCommitCheck Permission = "repo_reportCommitCheck"
+
+	// PermissionRepoReview allows metadata contributions to pull requests (comments, reviews and approvals)
+	// without granting any write access to the git content of the repository.
+	PermissionRepoReview Permission = "repo_review"
+	// PermissionRepoMerge allows merging pull requests into the target branch.
+	PermissionRepoMerge Permission = "repo_merge"
 )
 
 const (
@@ -0,0 +1,39 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// PipelineFormat defines the CI systems whose pipeline definitions can be converted to gitness pipelines.
+type PipelineFormat string
+
+func (PipelineFormat) Enum() []interface{} { return toInterfaceSlice(pipelineFormats) }
+func (f PipelineFormat) Sanitize() (PipelineFormat, bool) {
+	return Sanitize(f, GetAllPipelineFormats)
+}
+func GetAllPipelineFormats() ([]PipelineFormat, PipelineFormat) {
+	return pipelineFormats, ""
+}
+
+// PipelineFormat enumeration.
+const (
+	// PipelineFormatGitHubActions is a GitHub Actions workflow.
+	PipelineFormatGitHubActions PipelineFormat = "github_actions"
+	// PipelineFormatGitLabCI is a GitLab CI/CD configuration.
+	PipelineFormatGitLabCI PipelineFormat = "gitlab_ci"
+)
+
+var pipelineFormats = sortEnum([]PipelineFormat{
+	PipelineFormatGitHubActions,
+	PipelineFormatGitLabCI,
+})
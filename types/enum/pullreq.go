@@ -16,6 +16,8 @@ package enum
 
 import (
 	gitenum "github.com/harness/gitness/git/enum"
+
+	"golang.org/x/exp/slices"
 )
 
 // PullReqState defines pull request state.
@@ -85,6 +87,7 @@ const (
 	PullReqActivityTypeReviewSubmit PullReqActivityType = "review-submit"
 	PullReqActivityTypeBranchUpdate PullReqActivityType = "branch-update"
 	PullReqActivityTypeBranchDelete PullReqActivityType = "branch-delete"
+	PullReqActivityTypeTargetChange PullReqActivityType = "target-branch-change"
 	PullReqActivityTypeMerge        PullReqActivityType = "merge"
 )
 
@@ -96,6 +99,7 @@ var pullReqActivityTypes = sortEnum([]PullReqActivityType{
 	PullReqActivityTypeReviewSubmit,
 	PullReqActivityTypeBranchUpdate,
 	PullReqActivityTypeBranchDelete,
+	PullReqActivityTypeTargetChange,
 	PullReqActivityTypeMerge,
 })
 
@@ -199,12 +203,14 @@ const (
 	PullReqReviewerTypeRequested    PullReqReviewerType = "requested"
 	PullReqReviewerTypeAssigned     PullReqReviewerType = "assigned"
 	PullReqReviewerTypeSelfAssigned PullReqReviewerType = "self_assigned"
+	PullReqReviewerTypeDefault      PullReqReviewerType = "default"
 )
 
 var pullReqReviewerTypes = sortEnum([]PullReqReviewerType{
 	PullReqReviewerTypeRequested,
 	PullReqReviewerTypeAssigned,
 	PullReqReviewerTypeSelfAssigned,
+	PullReqReviewerTypeDefault,
 })
 
 type MergeMethod gitenum.MergeMethod
@@ -238,3 +244,119 @@ const (
 	// MergeCheckStatusMergeable branch can merged cleanly into the target branch.
 	MergeCheckStatusMergeable MergeCheckStatus = "mergeable"
 )
+
+// PullReqBulkOperation defines an operation that can be applied to many pull requests at once.
+type PullReqBulkOperation string
+
+func (PullReqBulkOperation) Enum() []interface{} {
+	return toInterfaceSlice(pullReqBulkOperations)
+}
+
+func (o PullReqBulkOperation) Sanitize() (PullReqBulkOperation, bool) {
+	return Sanitize(o, GetAllPullReqBulkOperations)
+}
+
+func GetAllPullReqBulkOperations() ([]PullReqBulkOperation, PullReqBulkOperation) {
+	return pullReqBulkOperations, "" // No default value
+}
+
+// PullReqBulkOperation enumeration.
+const (
+	PullReqBulkOperationClose     PullReqBulkOperation = "close"
+	PullReqBulkOperationRetarget  PullReqBulkOperation = "retarget"
+	PullReqBulkOperationReviewers PullReqBulkOperation = "reviewers"
+)
+
+var pullReqBulkOperations = sortEnum([]PullReqBulkOperation{
+	PullReqBulkOperationClose,
+	PullReqBulkOperationRetarget,
+	PullReqBulkOperationReviewers,
+})
+
+// CodeCommentAnchorStatus describes how the position of a code comment was determined
+// after the source branch of the pull request got updated.
+type CodeCommentAnchorStatus string
+
+func (CodeCommentAnchorStatus) Enum() []interface{} {
+	return toInterfaceSlice(codeCommentAnchorStatuses)
+}
+
+// CodeCommentAnchorStatus enumeration.
+const (
+	// CodeCommentAnchorStatusTracked means that the position of the code comment was followed through the diffs.
+	// The code comment can still be outdated if the commented lines of the target branch have changed.
+	CodeCommentAnchorStatusTracked CodeCommentAnchorStatus = "tracked"
+	// CodeCommentAnchorStatusReanchored means that the code comment was relocated by matching the commented lines
+	// in the latest version of the file, typically after a force push or a rebase.
+	CodeCommentAnchorStatusReanchored CodeCommentAnchorStatus = "reanchored"
+	// CodeCommentAnchorStatusLost means that the commented lines couldn't be found and the code comment is outdated.
+	CodeCommentAnchorStatusLost CodeCommentAnchorStatus = "lost"
+)
+
+var codeCommentAnchorStatuses = sortEnum([]CodeCommentAnchorStatus{
+	CodeCommentAnchorStatusTracked,
+	CodeCommentAnchorStatusReanchored,
+	CodeCommentAnchorStatusLost,
+})
+
+// PullReqSize is the size classification of a pull request based on the number of changed lines and files.
+type PullReqSize string
+
+func (PullReqSize) Enum() []interface{}                { return toInterfaceSlice(pullReqSizes) }
+func (s PullReqSize) Sanitize() (PullReqSize, bool)    { return Sanitize(s, GetAllPullReqSizes) }
+func GetAllPullReqSizes() ([]PullReqSize, PullReqSize) { return pullReqSizes, "" }
+
+// PullReqSize enumeration.
+const (
+	PullReqSizeXS PullReqSize = "XS"
+	PullReqSizeS  PullReqSize = "S"
+	PullReqSizeM  PullReqSize = "M"
+	PullReqSizeL  PullReqSize = "L"
+	PullReqSizeXL PullReqSize = "XL"
+)
+
+// pullReqSizesOrdered lists the pull request sizes from the smallest to the largest.
+var pullReqSizesOrdered = []PullReqSize{
+	PullReqSizeXS,
+	PullReqSizeS,
+	PullReqSizeM,
+	PullReqSizeL,
+	PullReqSizeXL,
+}
+
+var pullReqSizes = sortEnum(slices.Clone(pullReqSizesOrdered))
+
+// Upper bounds (exclusive) of the changed lines and changed files for each size, except for the largest one.
+var (
+	pullReqSizeLineLimits = []int64{10, 100, 500, 1000}
+	pullReqSizeFileLimits = []int64{5, 10, 25, 50}
+)
+
+// PullReqSizeOf returns the size of a pull request with the provided number of changed lines and files.
+// The size is the larger of the two sizes determined by the lines and by the files.
+func PullReqSizeOf(changedLines, changedFiles int64) PullReqSize {
+	idx := sizeIndex(changedLines, pullReqSizeLineLimits)
+	if idxFiles := sizeIndex(changedFiles, pullReqSizeFileLimits); idxFiles > idx {
+		idx = idxFiles
+	}
+	return pullReqSizesOrdered[idx]
+}
+
+// IsLargerThan returns true if the size is larger than the provided size.
+func (s PullReqSize) IsLargerThan(other PullReqSize) bool {
+	return slices.Index(pullReqSizesOrdered, s) > slices.Index(pullReqSizesOrdered, other)
+}
+
+// Label returns the label of the size, e.g. "size/XL".
+func (s PullReqSize) Label() string {
+	return "size/" + string(s)
+}
+
+func sizeIndex(value int64, limits []int64) int {
+	for i, limit := range limits {
+		if value < limit {
+			return i
+		}
+	}
+	return len(limits)
+}
@@ -0,0 +1,43 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+import "testing"
+
+func TestPullReqSizeOf(t *testing.T) {
+	tests := []struct {
+		lines int64
+		files int64
+		want  PullReqSize
+	}{
+		{0, 0, PullReqSizeXS},
+		{9, 4, PullReqSizeXS},
+		{10, 1, PullReqSizeS},
+		{5, 5, PullReqSizeS},
+		{99, 9, PullReqSizeS},
+		{120, 1, PullReqSizeM},
+		{500, 1, PullReqSizeL},
+		{20, 30, PullReqSizeL},
+		{1000, 1, PullReqSizeXL},
+		{1, 50, PullReqSizeXL},
+	}
+
+	for _, test := range tests {
+		got := PullReqSizeOf(test.lines, test.files)
+		if got != test.want {
+			t.Errorf("Want size %q for %d lines in %d files, got %q", test.want, test.lines, test.files, got)
+		}
+	}
+}
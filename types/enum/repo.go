@@ -14,10 +14,6 @@
 
 package enum
 
-import (
-	"strings"
-)
-
 // Defines repo attributes that can be used for sorting and filtering.
 type RepoAttr int
 
@@ -29,19 +25,26 @@ const (
 	RepoAttrUpdated
 )
 
+// repoAttrStrings maps the accepted (lower case) strings to the RepoAttr enumeration,
+// it's used by ParseRepoAttr and ParseRepoAttrStrict.
+var repoAttrStrings = map[string]RepoAttr{
+	uid:       RepoAttrUID,
+	created:   RepoAttrCreated,
+	createdAt: RepoAttrCreated,
+	updated:   RepoAttrUpdated,
+	updatedAt: RepoAttrUpdated,
+}
+
 // ParseRepoAttr parses the repo attribute string
 // and returns the equivalent enumeration.
 func ParseRepoAttr(s string) RepoAttr {
-	switch strings.ToLower(s) {
-	case uid:
-		return RepoAttrUID
-	case created, createdAt:
-		return RepoAttrCreated
-	case updated, updatedAt:
-		return RepoAttrUpdated
-	default:
-		return RepoAttrNone
-	}
+	return parseEnum(repoAttrStrings, s, RepoAttrNone)
+}
+
+// ParseRepoAttrStrict parses the repo attribute string like ParseRepoAttr,
+// but returns an error for values that aren't accepted.
+func ParseRepoAttrStrict(s string) (RepoAttr, error) {
+	return parseEnumStrict(repoAttrStrings, s, RepoAttrNone)
 }
 
 // String returns the string representation of the attribute.
@@ -14,10 +14,6 @@
 
 package enum
 
-import (
-	"strings"
-)
-
 // RuleState represents rule state.
 type RuleState string
 
@@ -65,14 +61,23 @@ func GetAllRuleSorts() ([]RuleSort, RuleSort) {
 	return ruleSorts, RuleSortCreated
 }
 
+// ruleSortStrings maps the accepted (lower case) strings to the RuleSort enumeration,
+// it's used by ParseRuleSortAttr and ParseRuleSortAttrStrict.
+var ruleSortStrings = map[string]RuleSort{
+	uid:       RuleSortUID,
+	created:   RuleSortCreated,
+	createdAt: RuleSortCreated,
+	updated:   RuleSortUpdated,
+	updatedAt: RuleSortUpdated,
+}
+
 // ParseRuleSortAttr parses the protection rule sorting option.
 func ParseRuleSortAttr(s string) RuleSort {
-	switch strings.ToLower(s) {
-	case created, createdAt:
-		return RuleSortCreated
-	case updated, updatedAt:
-		return RuleSortUpdated
-	}
+	return parseEnum(ruleSortStrings, s, RuleSortUID)
+}
 
-	return RuleSortUID
+// ParseRuleSortAttrStrict parses the protection rule sorting option string like ParseRuleSortAttr,
+// but returns an error for values that aren't accepted.
+func ParseRuleSortAttrStrict(s string) (RuleSort, error) {
+	return parseEnumStrict(ruleSortStrings, s, RuleSortUID)
 }
@@ -0,0 +1,34 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// RunnerState defines whether a self-hosted runner accepts new stages.
+type RunnerState string
+
+// RunnerState enumeration.
+const (
+	// RunnerStateActive is the state of a runner that accepts new stages.
+	RunnerStateActive RunnerState = "active"
+	// RunnerStateDraining is the state of a runner that finishes its running stages,
+	// but doesn't accept new ones.
+	RunnerStateDraining RunnerState = "draining"
+)
+
+var runnerStates = sortEnum([]RunnerState{
+	RunnerStateActive,
+	RunnerStateDraining,
+})
+
+func (RunnerState) Enum() []interface{} { return toInterfaceSlice(runnerStates) }
@@ -0,0 +1,43 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// SearchResultType defines the type of global search results.
+type SearchResultType string
+
+// SearchResultType enumeration.
+const (
+	SearchResultTypeRepo    SearchResultType = "repo"
+	SearchResultTypeSpace   SearchResultType = "space"
+	SearchResultTypeUser    SearchResultType = "user"
+	SearchResultTypePullReq SearchResultType = "pullreq"
+	SearchResultTypeCode    SearchResultType = "code"
+)
+
+var searchResultTypes = sortEnum([]SearchResultType{
+	SearchResultTypeRepo,
+	SearchResultTypeSpace,
+	SearchResultTypeUser,
+	SearchResultTypePullReq,
+	SearchResultTypeCode,
+})
+
+func (SearchResultType) Enum() []interface{} { return toInterfaceSlice(searchResultTypes) }
+func (t SearchResultType) Sanitize() (SearchResultType, bool) {
+	return Sanitize(t, GetAllSearchResultTypes)
+}
+func GetAllSearchResultTypes() ([]SearchResultType, SearchResultType) {
+	return searchResultTypes, ""
+}
@@ -0,0 +1,61 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// SigningKeyFormat defines the format of the key used to sign commits.
+type SigningKeyFormat string
+
+// SigningKeyFormat enumeration.
+const (
+	SigningKeyFormatOpenPGP SigningKeyFormat = "openpgp"
+	SigningKeyFormatSSH     SigningKeyFormat = "ssh"
+)
+
+var signingKeyFormats = sortEnum([]SigningKeyFormat{
+	SigningKeyFormatOpenPGP,
+	SigningKeyFormatSSH,
+})
+
+func (SigningKeyFormat) Enum() []interface{} { return toInterfaceSlice(signingKeyFormats) }
+func (f SigningKeyFormat) Sanitize() (SigningKeyFormat, bool) {
+	return Sanitize(f, GetAllSigningKeyFormats)
+}
+func GetAllSigningKeyFormats() ([]SigningKeyFormat, SigningKeyFormat) {
+	return signingKeyFormats, ""
+}
+
+// CommitVerificationReason defines the outcome of the verification of a commit signature.
+type CommitVerificationReason string
+
+// CommitVerificationReason enumeration.
+const (
+	CommitVerificationReasonValid             CommitVerificationReason = "valid"
+	CommitVerificationReasonUnknownKey        CommitVerificationReason = "unknown_key"
+	CommitVerificationReasonRevokedKey        CommitVerificationReason = "revoked_key"
+	CommitVerificationReasonBadSignature      CommitVerificationReason = "bad_signature"
+	CommitVerificationReasonUnsupportedFormat CommitVerificationReason = "unsupported_format"
+)
+
+var commitVerificationReasons = sortEnum([]CommitVerificationReason{
+	CommitVerificationReasonValid,
+	CommitVerificationReasonUnknownKey,
+	CommitVerificationReasonRevokedKey,
+	CommitVerificationReasonBadSignature,
+	CommitVerificationReasonUnsupportedFormat,
+})
+
+func (CommitVerificationReason) Enum() []interface{} {
+	return toInterfaceSlice(commitVerificationReasons)
+}
@@ -14,8 +14,6 @@
 
 package enum
 
-import "strings"
-
 // SpaceAttr defines space attributes that can be used for sorting and filtering.
 type SpaceAttr int
 
@@ -27,19 +25,26 @@ const (
 	SpaceAttrUpdated
 )
 
+// spaceAttrStrings maps the accepted (lower case) strings to the SpaceAttr enumeration,
+// it's used by ParseSpaceAttr and ParseSpaceAttrStrict.
+var spaceAttrStrings = map[string]SpaceAttr{
+	uid:       SpaceAttrUID,
+	created:   SpaceAttrCreated,
+	createdAt: SpaceAttrCreated,
+	updated:   SpaceAttrUpdated,
+	updatedAt: SpaceAttrUpdated,
+}
+
 // ParseSpaceAttr parses the space attribute string
 // and returns the equivalent enumeration.
 func ParseSpaceAttr(s string) SpaceAttr {
-	switch strings.ToLower(s) {
-	case uid:
-		return SpaceAttrUID
-	case created, createdAt:
-		return SpaceAttrCreated
-	case updated, updatedAt:
-		return SpaceAttrUpdated
-	default:
-		return SpaceAttrNone
-	}
+	return parseEnum(spaceAttrStrings, s, SpaceAttrNone)
+}
+
+// ParseSpaceAttrStrict parses the space attribute string like ParseSpaceAttr,
+// but returns an error for values that aren't accepted.
+func ParseSpaceAttrStrict(s string) (SpaceAttr, error) {
+	return parseEnumStrict(spaceAttrStrings, s, SpaceAttrNone)
 }
 
 // String returns the string representation of the attribute.
@@ -57,3 +62,54 @@ func (a SpaceAttr) String() string {
 		return undefined
 	}
 }
+
+// SpacePinType defines the type of item pinned to a space.
+type SpacePinType string
+
+// SpacePinType enumeration.
+const (
+	SpacePinTypeRepo    SpacePinType = "repo"
+	SpacePinTypePullReq SpacePinType = "pullreq"
+	SpacePinTypeRelease SpacePinType = "release"
+)
+
+var spacePinTypes = sortEnum([]SpacePinType{
+	SpacePinTypeRepo,
+	SpacePinTypePullReq,
+	SpacePinTypeRelease,
+})
+
+func (SpacePinType) Enum() []interface{} { return toInterfaceSlice(spacePinTypes) }
+func (t SpacePinType) Sanitize() (SpacePinType, bool) {
+	return Sanitize(t, GetAllSpacePinTypes)
+}
+func GetAllSpacePinTypes() ([]SpacePinType, SpacePinType) {
+	return spacePinTypes, ""
+}
+
+// SpacePinAudience defines who can see an item pinned to a space.
+type SpacePinAudience string
+
+// SpacePinAudience enumeration.
+const (
+	// SpacePinAudienceEveryone makes the pinned item visible to everyone who can view the space.
+	SpacePinAudienceEveryone SpacePinAudience = "everyone"
+	// SpacePinAudienceMembers makes the pinned item visible only to members of the space.
+	SpacePinAudienceMembers SpacePinAudience = "members"
+	// SpacePinAudienceAdmins makes the pinned item visible only to principals who can edit the space.
+	SpacePinAudienceAdmins SpacePinAudience = "admins"
+)
+
+var spacePinAudiences = sortEnum([]SpacePinAudience{
+	SpacePinAudienceEveryone,
+	SpacePinAudienceMembers,
+	SpacePinAudienceAdmins,
+})
+
+func (SpacePinAudience) Enum() []interface{} { return toInterfaceSlice(spacePinAudiences) }
+func (a SpacePinAudience) Sanitize() (SpacePinAudience, bool) {
+	return Sanitize(a, GetAllSpacePinAudiences)
+}
+func GetAllSpacePinAudiences() ([]SpacePinAudience, SpacePinAudience) {
+	return spacePinAudiences, SpacePinAudienceEveryone
+}
@@ -26,4 +26,7 @@ const (
 
 	// TokenTypeSAT is a service account access token.
 	TokenTypeSAT TokenType = "sat"
+
+	// TokenTypeSpace is a space access token, owned by the space instead of a user.
+	TokenTypeSpace TokenType = "space"
 )
@@ -30,6 +30,9 @@ const (
 	// TriggerActionTagUpdated gets triggered when a tag gets updated.
 	TriggerActionTagUpdated TriggerAction = "tag_updated"
 
+	// TriggerActionReleasePublished gets triggered when a release (an annotated tag) gets published.
+	TriggerActionReleasePublished TriggerAction = "release_published"
+
 	// TriggerActionPullReqCreated gets triggered when a pull request gets created.
 	TriggerActionPullReqCreated TriggerAction = "pullreq_created"
 	// TriggerActionPullReqReopened gets triggered when a pull request gets reopened.
@@ -55,6 +58,9 @@ func (t TriggerAction) GetTriggerEvent() TriggerEvent {
 	if t == TriggerActionTagCreated || t == TriggerActionTagUpdated {
 		return TriggerEventTag
 	}
+	if t == TriggerActionReleasePublished {
+		return TriggerEventRelease
+	}
 	if t == "" {
 		return TriggerEventManual
 	}
@@ -70,6 +76,7 @@ var triggerActions = sortEnum([]TriggerAction{
 	TriggerActionBranchUpdated,
 	TriggerActionTagCreated,
 	TriggerActionTagUpdated,
+	TriggerActionReleasePublished,
 	TriggerActionPullReqCreated,
 	TriggerActionPullReqReopened,
 	TriggerActionPullReqBranchUpdated,
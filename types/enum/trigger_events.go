@@ -24,4 +24,5 @@ const (
 	TriggerEventPush        = "push"
 	TriggerEventPullRequest = "pull_request"
 	TriggerEventTag         = "tag"
+	TriggerEventRelease     = "release"
 )
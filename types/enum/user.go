@@ -14,8 +14,6 @@
 
 package enum
 
-import "strings"
-
 // UserAttr defines user attributes that can be
 // used for sorting and filtering.
 type UserAttr int
@@ -31,23 +29,27 @@ const (
 	UserAttrUpdated
 )
 
+// userAttrStrings maps the accepted (lower case) strings to the UserAttr enumeration,
+// it's used by ParseUserAttr and ParseUserAttrStrict.
+var userAttrStrings = map[string]UserAttr{
+	uid:       UserAttrUID,
+	name:      UserAttrName,
+	email:     UserAttrEmail,
+	admin:     UserAttrAdmin,
+	created:   UserAttrCreated,
+	createdAt: UserAttrCreated,
+	updated:   UserAttrUpdated,
+	updatedAt: UserAttrUpdated,
+}
+
 // ParseUserAttr parses the user attribute string
 // and returns the equivalent enumeration.
 func ParseUserAttr(s string) UserAttr {
-	switch strings.ToLower(s) {
-	case uid:
-		return UserAttrUID
-	case name:
-		return UserAttrName
-	case email:
-		return UserAttrEmail
-	case admin:
-		return UserAttrAdmin
-	case created, createdAt:
-		return UserAttrCreated
-	case updated, updatedAt:
-		return UserAttrUpdated
-	default:
-		return UserAttrNone
-	}
+	return parseEnum(userAttrStrings, s, UserAttrNone)
+}
+
+// ParseUserAttrStrict parses the user attribute string like ParseUserAttr,
+// but returns an error for values that aren't accepted.
+func ParseUserAttrStrict(s string) (UserAttr, error) {
+	return parseEnumStrict(userAttrStrings, s, UserAttrNone)
 }
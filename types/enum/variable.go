@@ -0,0 +1,50 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// VariableParent defines different types of parents of a pipeline variable.
+type VariableParent string
+
+func (VariableParent) Enum() []interface{} { return toInterfaceSlice(variableParents) }
+
+const (
+	// VariableParentRepo describes a repo as variable owner.
+	VariableParentRepo VariableParent = "repo"
+
+	// VariableParentSpace describes a space as variable owner.
+	VariableParentSpace VariableParent = "space"
+)
+
+var variableParents = sortEnum([]VariableParent{
+	VariableParentRepo,
+	VariableParentSpace,
+})
+
+// VariableAuditAction defines the change of a pipeline variable recorded in the audit log.
+type VariableAuditAction string
+
+func (VariableAuditAction) Enum() []interface{} { return toInterfaceSlice(variableAuditActions) }
+
+const (
+	VariableAuditActionCreated VariableAuditAction = "created"
+	VariableAuditActionUpdated VariableAuditAction = "updated"
+	VariableAuditActionDeleted VariableAuditAction = "deleted"
+)
+
+var variableAuditActions = sortEnum([]VariableAuditAction{
+	VariableAuditActionCreated,
+	VariableAuditActionUpdated,
+	VariableAuditActionDeleted,
+})
@@ -0,0 +1,71 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// WatchLevel defines which notifications a user receives for a repository or a pull request.
+type WatchLevel string
+
+func (WatchLevel) Enum() []interface{}              { return toInterfaceSlice(watchLevels) }
+func (l WatchLevel) Sanitize() (WatchLevel, bool)   { return Sanitize(l, GetAllWatchLevels) }
+func GetAllWatchLevels() ([]WatchLevel, WatchLevel) { return watchLevels, "" }
+
+// IncludesPullReqs returns true if the watch level includes notifications about all pull request activity.
+func (l WatchLevel) IncludesPullReqs() bool {
+	return l == WatchLevelPullReqs || l == WatchLevelAll
+}
+
+// IncludesReleases returns true if the watch level includes notifications about new releases.
+func (l WatchLevel) IncludesReleases() bool {
+	return l == WatchLevelReleases || l == WatchLevelAll
+}
+
+// PullReqLevel converts a repository watch level to the equivalent pull request watch level.
+func (l WatchLevel) PullReqLevel() WatchLevel {
+	switch {
+	case l == WatchLevelIgnore:
+		return WatchLevelIgnore
+	case l.IncludesPullReqs():
+		return WatchLevelAll
+	default:
+		return WatchLevelParticipating
+	}
+}
+
+// IsValidForPullReq returns true if the watch level can be set for a single pull request.
+func (l WatchLevel) IsValidForPullReq() bool {
+	return l == WatchLevelIgnore || l == WatchLevelParticipating || l == WatchLevelAll
+}
+
+// WatchLevel enumeration.
+const (
+	// WatchLevelIgnore means that the user doesn't receive any notifications, not even as a participant.
+	WatchLevelIgnore WatchLevel = "ignore"
+	// WatchLevelParticipating means that the user is notified only when participating (the default).
+	WatchLevelParticipating WatchLevel = "participating"
+	// WatchLevelReleases means that the user is additionally notified about new releases (tags).
+	WatchLevelReleases WatchLevel = "releases"
+	// WatchLevelPullReqs means that the user is additionally notified about all pull request activity.
+	WatchLevelPullReqs WatchLevel = "pullreqs"
+	// WatchLevelAll means that the user is notified about all activity.
+	WatchLevelAll WatchLevel = "all"
+)
+
+var watchLevels = sortEnum([]WatchLevel{
+	WatchLevelIgnore,
+	WatchLevelParticipating,
+	WatchLevelReleases,
+	WatchLevelPullReqs,
+	WatchLevelAll,
+})
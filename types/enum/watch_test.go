@@ -0,0 +1,40 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+import "testing"
+
+func TestWatchLevelPullReqLevel(t *testing.T) {
+	tests := []struct {
+		level WatchLevel
+		want  WatchLevel
+	}{
+		{WatchLevelIgnore, WatchLevelIgnore},
+		{WatchLevelParticipating, WatchLevelParticipating},
+		{WatchLevelReleases, WatchLevelParticipating},
+		{WatchLevelPullReqs, WatchLevelAll},
+		{WatchLevelAll, WatchLevelAll},
+	}
+
+	for _, test := range tests {
+		got, want := test.level.PullReqLevel(), test.want
+		if got != want {
+			t.Errorf("Want watch level %q converted to %q, got %q", test.level, want, got)
+		}
+		if !got.IsValidForPullReq() {
+			t.Errorf("Watch level %q converted to %q which is not valid for pull requests", test.level, got)
+		}
+	}
+}
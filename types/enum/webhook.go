@@ -14,8 +14,6 @@
 
 package enum
 
-import "strings"
-
 // WebhookAttr defines webhook attributes that can be used for sorting and filtering.
 type WebhookAttr int
 
@@ -28,23 +26,28 @@ const (
 	WebhookAttrUpdated
 )
 
+// webhookAttrStrings maps the accepted (lower case) strings to the WebhookAttr enumeration,
+// it's used by ParseWebhookAttr and ParseWebhookAttrStrict.
+var webhookAttrStrings = map[string]WebhookAttr{
+	id:          WebhookAttrID,
+	uid:         WebhookAttrUID,
+	displayName: WebhookAttrDisplayName,
+	created:     WebhookAttrCreated,
+	createdAt:   WebhookAttrCreated,
+	updated:     WebhookAttrUpdated,
+	updatedAt:   WebhookAttrUpdated,
+}
+
 // ParseWebhookAttr parses the webhook attribute string
 // and returns the equivalent enumeration.
 func ParseWebhookAttr(s string) WebhookAttr {
-	switch strings.ToLower(s) {
-	case id:
-		return WebhookAttrID
-	case uid:
-		return WebhookAttrUID
-	case displayName:
-		return WebhookAttrDisplayName
-	case created, createdAt:
-		return WebhookAttrCreated
-	case updated, updatedAt:
-		return WebhookAttrUpdated
-	default:
-		return WebhookAttrNone
-	}
+	return parseEnum(webhookAttrStrings, s, WebhookAttrNone)
+}
+
+// ParseWebhookAttrStrict parses the webhook attribute string like ParseWebhookAttr,
+// but returns an error for values that aren't accepted.
+func ParseWebhookAttrStrict(s string) (WebhookAttr, error) {
+	return parseEnumStrict(webhookAttrStrings, s, WebhookAttrNone)
 }
 
 // String returns the string representation of the attribute.
@@ -107,6 +110,34 @@ var webhookExecutionResults = sortEnum([]WebhookExecutionResult{
 	WebhookExecutionResultFatalError,
 })
 
+// WebhookPayloadTemplateType defines the template languages that can be used to customize webhook payloads.
+type WebhookPayloadTemplateType string
+
+func (WebhookPayloadTemplateType) Enum() []interface{} {
+	return toInterfaceSlice(webhookPayloadTemplateTypes)
+}
+
+func (t WebhookPayloadTemplateType) Sanitize() (WebhookPayloadTemplateType, bool) {
+	return Sanitize(t, GetAllWebhookPayloadTemplateTypes)
+}
+
+func GetAllWebhookPayloadTemplateTypes() ([]WebhookPayloadTemplateType, WebhookPayloadTemplateType) {
+	return webhookPayloadTemplateTypes, "" // No default value
+}
+
+const (
+	// WebhookPayloadTemplateTypeGoTemplate describes a payload template written as a go text template.
+	WebhookPayloadTemplateTypeGoTemplate WebhookPayloadTemplateType = "gotemplate"
+
+	// WebhookPayloadTemplateTypeJQ describes a payload template written as a jq expression.
+	WebhookPayloadTemplateTypeJQ WebhookPayloadTemplateType = "jq"
+)
+
+var webhookPayloadTemplateTypes = sortEnum([]WebhookPayloadTemplateType{
+	WebhookPayloadTemplateTypeGoTemplate,
+	WebhookPayloadTemplateTypeJQ,
+})
+
 // WebhookTrigger defines the different types of webhook triggers available.
 type WebhookTrigger string
 
@@ -144,6 +175,21 @@ const (
 	WebhookTriggerPullReqCommentCreated WebhookTrigger = "pullreq_comment_created"
 	// WebhookTriggerPullReqMerged gets triggered when a pull request is merged.
 	WebhookTriggerPullReqMerged WebhookTrigger = "pullreq_merged"
+
+	// WebhookTriggerCommitCommentCreated gets triggered when a comment gets created directly on a commit.
+	WebhookTriggerCommitCommentCreated WebhookTrigger = "commit_comment_created"
+	// WebhookTriggerCommitCommentUpdated gets triggered when a comment made directly on a commit gets edited.
+	WebhookTriggerCommitCommentUpdated WebhookTrigger = "commit_comment_updated"
+
+	// WebhookTriggerCheckRerunRequested gets triggered when a user requests a re-run of a status check.
+	WebhookTriggerCheckRerunRequested WebhookTrigger = "check_rerun_requested"
+
+	// WebhookTriggerExecutionStarted gets triggered when a pipeline execution starts running.
+	WebhookTriggerExecutionStarted WebhookTrigger = "execution_started"
+	// WebhookTriggerExecutionCompleted gets triggered when a pipeline execution finishes successfully.
+	WebhookTriggerExecutionCompleted WebhookTrigger = "execution_completed"
+	// WebhookTriggerExecutionFailed gets triggered when a pipeline execution fails, errors or gets canceled.
+	WebhookTriggerExecutionFailed WebhookTrigger = "execution_failed"
 )
 
 var webhookTriggers = sortEnum([]WebhookTrigger{
@@ -159,4 +205,10 @@ var webhookTriggers = sortEnum([]WebhookTrigger{
 	WebhookTriggerPullReqClosed,
 	WebhookTriggerPullReqCommentCreated,
 	WebhookTriggerPullReqMerged,
+	WebhookTriggerCommitCommentCreated,
+	WebhookTriggerCommitCommentUpdated,
+	WebhookTriggerCheckRerunRequested,
+	WebhookTriggerExecutionStarted,
+	WebhookTriggerExecutionCompleted,
+	WebhookTriggerExecutionFailed,
 })
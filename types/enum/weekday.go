@@ -0,0 +1,67 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+import (
+	"time"
+
+	"golang.org/x/exp/slices"
+)
+
+// Weekday defines a day of the week.
+type Weekday string
+
+func (Weekday) Enum() []interface{}         { return toInterfaceSlice(weekdays) }
+func (d Weekday) Sanitize() (Weekday, bool) { return Sanitize(d, GetAllWeekdays) }
+func GetAllWeekdays() ([]Weekday, Weekday)  { return weekdays, "" }
+
+// WeekdayOf returns the weekday of the time.Weekday.
+func WeekdayOf(d time.Weekday) Weekday {
+	return weekdaysOrdered[d]
+}
+
+// Previous returns the day before the weekday.
+func (d Weekday) Previous() Weekday {
+	idx := slices.Index(weekdaysOrdered, d)
+	if idx < 0 {
+		return ""
+	}
+
+	return weekdaysOrdered[(idx+len(weekdaysOrdered)-1)%len(weekdaysOrdered)]
+}
+
+// Weekday enumeration.
+const (
+	WeekdaySunday    Weekday = "sunday"
+	WeekdayMonday    Weekday = "monday"
+	WeekdayTuesday   Weekday = "tuesday"
+	WeekdayWednesday Weekday = "wednesday"
+	WeekdayThursday  Weekday = "thursday"
+	WeekdayFriday    Weekday = "friday"
+	WeekdaySaturday  Weekday = "saturday"
+)
+
+// weekdaysOrdered lists the weekdays in the order of time.Weekday.
+var weekdaysOrdered = []Weekday{
+	WeekdaySunday,
+	WeekdayMonday,
+	WeekdayTuesday,
+	WeekdayWednesday,
+	WeekdayThursday,
+	WeekdayFriday,
+	WeekdaySaturday,
+}
+
+var weekdays = sortEnum(slices.Clone(weekdaysOrdered))
@@ -55,4 +55,35 @@ type Execution struct {
 	Updated      int64             `json:"updated"`
 	Version      int64             `json:"-"`
 	Stages       []*Stage          `json:"stages,omitempty"`
+
+	// ConcurrencyGroup is the concurrency group declared by the pipeline definition.
+	// Only one execution of a group runs at a time within a repository.
+	ConcurrencyGroup string `json:"concurrency_group,omitempty"`
+
+	// Timeout is the maximum duration of the execution in milliseconds, zero means no timeout.
+	Timeout int64 `json:"timeout,omitempty"`
+
+	// FailureReason tells why the execution was aborted, e.g. because it timed out.
+	FailureReason enum.ExecutionFailureReason `json:"failure_reason,omitempty"`
+}
+
+// ExecutionDAG is the graph of the stages of an execution, used to visualize the order the stages run in.
+type ExecutionDAG struct {
+	Nodes []ExecutionDAGNode `json:"nodes"`
+	Edges []ExecutionDAGEdge `json:"edges"`
+}
+
+// ExecutionDAGNode is a stage of an execution.
+type ExecutionDAGNode struct {
+	Stage  int64         `json:"stage"`
+	Name   string        `json:"name"`
+	Kind   string        `json:"kind,omitempty"`
+	Status enum.CIStatus `json:"status"`
+}
+
+// ExecutionDAGEdge is a dependency between two stages of an execution, identified by their numbers.
+// The stage To starts after the stage From is finished.
+type ExecutionDAGEdge struct {
+	From int64 `json:"from"`
+	To   int64 `json:"to"`
 }
@@ -38,6 +38,32 @@ type CommitFilter struct {
 	Committer string `json:"committer"`
 }
 
+// CommitSearchFilter stores commit search query parameters.
+type CommitSearchFilter struct {
+	PaginationFilter
+	// Query is the text searched for in the commit title and message.
+	Query string `json:"query"`
+	// Author and Committer are matched against both the name and the email.
+	Author    string `json:"author"`
+	Committer string `json:"committer"`
+	// Since and Until are UNIX timestamps (in seconds) compared with the committer date.
+	Since int64 `json:"since"`
+	Until int64 `json:"until"`
+}
+
+// TreeFilter stores tree listing query parameters.
+type TreeFilter struct {
+	Recursive bool `json:"recursive"`
+	// MaxDepth limits the depth of the entries relative to the listed path, zero means no limit.
+	MaxDepth int `json:"max_depth"`
+	// Include are glob patterns of which at least one has to match an entry.
+	// Patterns without a slash are matched against the entry name, others against the full entry path.
+	Include []string `json:"include"`
+	// Cursor is the opaque position returned with the previous page.
+	Cursor string `json:"cursor"`
+	Limit  int    `json:"limit"`
+}
+
 // BranchFilter stores branch query parameters.
 type BranchFilter struct {
 	Query string                `json:"query"`
@@ -62,6 +88,25 @@ type Commit struct {
 	Message   string    `json:"message"`
 	Author    Signature `json:"author"`
 	Committer Signature `json:"committer"`
+
+	// Verification is the result of the verification of the commit signature (nil if not signed or not verified).
+	Verification *CommitVerification `json:"verification,omitempty"`
+
+	// Comments are the comments made directly on the commit (populated only by the commit details API).
+	Comments []*CommitComment `json:"comments,omitempty"`
+
+	// Autolinks are the custom autolink references found in the commit message.
+	Autolinks []AutolinkReference `json:"autolinks,omitempty"`
+}
+
+// CommitVerification holds the result of verifying a commit or tag signature
+// against the instance-managed user keys and the public keys registered by users.
+type CommitVerification struct {
+	Verified       bool                          `json:"verified"`
+	Reason         enum.CommitVerificationReason `json:"reason"`
+	Format         enum.SigningKeyFormat         `json:"format,omitempty"`
+	KeyFingerprint string                        `json:"key_fingerprint,omitempty"`
+	Signer         *PrincipalInfo                `json:"signer,omitempty"`
 }
 
 type Signature struct {
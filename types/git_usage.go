@@ -0,0 +1,47 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// GitUsage contains the resources consumed by git service pack (upload-pack and receive-pack)
+// invocations of a principal on a repository. All durations are in milliseconds.
+type GitUsage struct {
+	PrincipalID   int64               `json:"principal_id"`
+	RepoID        int64               `json:"repo_id"`
+	Service       enum.GitServiceType `json:"service"`
+	Invocations   int64               `json:"invocations"`
+	CPUTime       int64               `json:"cpu_time"`
+	WallTime      int64               `json:"wall_time"`
+	BytesReceived int64               `json:"bytes_received"`
+	BytesSent     int64               `json:"bytes_sent"`
+
+	// populated by the controller, nil for anonymous access.
+	Principal *PrincipalInfo `json:"principal,omitempty"`
+	RepoPath  string         `json:"repo_path,omitempty"`
+}
+
+// GitUsageFilter stores git usage query parameters.
+type GitUsageFilter struct {
+	Pagination
+	// Since and Until restrict the time range of the aggregated usage (both are optional).
+	// The usage is tracked with a daily resolution.
+	Since       int64             `json:"since"`
+	Until       int64             `json:"until"`
+	PrincipalID int64             `json:"principal_id"`
+	RepoID      int64             `json:"repo_id"`
+	Sort        enum.GitUsageSort `json:"sort"`
+	Order       enum.Order        `json:"order"`
+}
@@ -0,0 +1,58 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// Passkey is a WebAuthn credential a user registered for signing in.
+type Passkey struct {
+	ID              int64  `json:"id"`
+	PrincipalID     int64  `json:"principal_id"`
+	Name            string `json:"name"`
+	CredentialID    []byte `json:"credential_id"`
+	AttestationType string `json:"attestation_type"`
+	AAGUID          []byte `json:"aaguid"`
+
+	// PublicKey holds the COSE encoded public key of the credential and is never returned by the API.
+	PublicKey []byte `json:"-"`
+
+	// SignCount is the signature counter last reported by the authenticator, used to detect cloned authenticators.
+	SignCount uint32 `json:"-"`
+
+	Created  int64  `json:"created"`
+	LastUsed *int64 `json:"last_used,omitempty"`
+}
+
+// PasskeyChallenge holds the state of a WebAuthn ceremony between its begin and finish steps.
+type PasskeyChallenge struct {
+	UID         string
+	PrincipalID int64
+	Kind        enum.PasskeyChallengeKind
+
+	// Session holds the JSON encoded WebAuthn session data.
+	Session string
+
+	Created int64
+	Expires int64
+}
+
+// PasskeyCeremony is returned when a WebAuthn ceremony begins.
+// The options are passed to the browser's credentials API, the challenge UID has to be provided
+// together with the browser's response to finish the ceremony.
+type PasskeyCeremony struct {
+	ChallengeUID string `json:"challenge_uid"`
+	Options      any    `json:"options"`
+	Expires      int64  `json:"expires"`
+}
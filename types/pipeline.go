@@ -30,4 +30,86 @@ type Pipeline struct {
 	Execution *Execution `db:"-"                        json:"execution,omitempty"`
 	Updated   int64      `db:"pipeline_updated"         json:"updated"`
 	Version   int64      `db:"pipeline_version"         json:"-"`
+	// CacheGeneration is incremented to invalidate the cache volumes of the pipeline.
+	CacheGeneration int64 `db:"pipeline_cache_generation" json:"cache_generation"`
+}
+
+// PipelineValidation is the result of validating a pipeline definition.
+type PipelineValidation struct {
+	Valid  bool                  `json:"valid"`
+	Errors []PipelineConfigError `json:"errors"`
+}
+
+// PipelineConfigError is a problem found in a pipeline definition.
+// Line and column are one based and zero if the position of the problem isn't known.
+type PipelineConfigError struct {
+	Message string `json:"message"`
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+}
+
+// PipelineConversion is the result of converting a pipeline definition of another CI system.
+type PipelineConversion struct {
+	// Data is the converted pipeline definition.
+	Data string `json:"data"`
+	// Unsupported lists the constructs of the source definition that weren't converted.
+	Unsupported []PipelineConversionIssue `json:"unsupported"`
+}
+
+// PipelineConversionIssue is a construct of a pipeline definition that couldn't be converted.
+type PipelineConversionIssue struct {
+	// Path is the location of the construct in the source definition, e.g. "jobs.build.needs".
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// PipelineStatsFilter stores the time range the pipeline statistics are computed over.
+type PipelineStatsFilter struct {
+	// After and Before are the bounds of the creation time of the executions in milliseconds.
+	After  int64 `json:"after"`
+	Before int64 `json:"before"`
+}
+
+// PipelineStats are the duration and reliability statistics of the executions of a pipeline.
+// Durations are in milliseconds.
+type PipelineStats struct {
+	After  int64 `json:"after"`
+	Before int64 `json:"before"`
+
+	// Total is the number of executions created in the time range,
+	// Completed the number of those that are done and weren't skipped.
+	Total     int `json:"total"`
+	Completed int `json:"completed"`
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+
+	// SuccessRate is the ratio of succeeded to completed executions, between 0 and 1.
+	SuccessRate float64 `json:"success_rate"`
+
+	// Duration is measured from the start to the end of the completed executions,
+	// QueueTime from the creation to the start of all started executions.
+	Duration  DurationStats `json:"duration"`
+	QueueTime DurationStats `json:"queue_time"`
+
+	// StageFailures are the stages that failed in the time range, the most failures first.
+	StageFailures []StageFailureStats `json:"stage_failures"`
+}
+
+// DurationStats summarizes a set of durations in milliseconds.
+type DurationStats struct {
+	Count   int   `json:"count"`
+	Average int64 `json:"average"`
+	P50     int64 `json:"p50"`
+	P95     int64 `json:"p95"`
+	Max     int64 `json:"max"`
+}
+
+// StageFailureStats are the failure statistics of a stage of a pipeline.
+type StageFailureStats struct {
+	Name        string  `json:"name"`
+	Completed   int     `json:"completed"`
+	Failed      int     `json:"failed"`
+	FailureRate float64 `json:"failure_rate"`
+	// LastFailed is the creation time of the last execution in which the stage failed.
+	LastFailed int64 `json:"last_failed"`
 }
@@ -0,0 +1,27 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// PipelineCache is a build cache entry of a repository, saved and restored by the cache steps of pipelines.
+type PipelineCache struct {
+	ID        int64  `json:"id"`
+	RepoID    int64  `json:"-"`
+	Key       string `json:"key"`
+	Size      int64  `json:"size"`
+	CreatedBy int64  `json:"created_by"`
+	Created   int64  `json:"created"`
+	Updated   int64  `json:"updated"`
+	Accessed  int64  `json:"accessed"`
+}
@@ -0,0 +1,28 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// PublicKey is a public key a user registered to get the signatures of their commits and tags verified.
+type PublicKey struct {
+	ID          int64                 `json:"id"`
+	PrincipalID int64                 `json:"principal_id"`
+	Format      enum.SigningKeyFormat `json:"format"`
+	Fingerprint string                `json:"fingerprint"`
+	Content     string                `json:"content"`
+	Comment     string                `json:"comment"`
+	Created     int64                 `json:"created"`
+}
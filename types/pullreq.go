@@ -59,21 +59,44 @@ type PullReq struct {
 	Author PrincipalInfo  `json:"author"`
 	Merger *PrincipalInfo `json:"merger"`
 	Stats  PullReqStats   `json:"stats"`
+
+	// Autolinks are the custom autolink references found in the title and the description.
+	Autolinks []AutolinkReference `json:"autolinks,omitempty"`
 }
 
-// DiffStats shows total number of commits and modified files.
+// DiffStats shows total number of commits, modified files and changed lines.
 type DiffStats struct {
-	Commits      *int64 `json:"commits,omitempty"`
-	FilesChanged *int64 `json:"files_changed,omitempty"`
+	Commits      *int64            `json:"commits,omitempty"`
+	FilesChanged *int64            `json:"files_changed,omitempty"`
+	Additions    *int64            `json:"additions,omitempty"`
+	Deletions    *int64            `json:"deletions,omitempty"`
+	Size         *enum.PullReqSize `json:"size,omitempty"`
 }
 
-func NewDiffStats(commitCount int, fileCount int) DiffStats {
+func NewDiffStats(commitCount, fileCount, additions, deletions int) DiffStats {
 	cc := int64(commitCount)
 	fc := int64(fileCount)
-	return DiffStats{
+	ac := int64(additions)
+	dc := int64(deletions)
+	stats := DiffStats{
 		Commits:      &cc,
 		FilesChanged: &fc,
+		Additions:    &ac,
+		Deletions:    &dc,
 	}
+	stats.Size = stats.PullReqSize()
+	return stats
+}
+
+// PullReqSize returns the size classification of the changes.
+// It returns nil if the number of changed files or lines is unknown.
+func (s DiffStats) PullReqSize() *enum.PullReqSize {
+	if s.FilesChanged == nil || s.Additions == nil || s.Deletions == nil {
+		return nil
+	}
+
+	size := enum.PullReqSizeOf(*s.Additions+*s.Deletions, *s.FilesChanged)
+	return &size
 }
 
 // PullReqStats shows Diff statistics and number of conversations.
@@ -97,6 +120,21 @@ type PullReqFilter struct {
 	States        []enum.PullReqState `json:"state"`
 	Sort          enum.PullReqSort    `json:"sort"`
 	Order         enum.Order          `json:"order"`
+
+	// IsDraft, if set, restricts the result to draft (true) or ready (false) pull requests.
+	IsDraft *bool `json:"is_draft"`
+
+	// ReviewerID and MentionedID restrict the result to pull requests
+	// where the principal is a reviewer or has been mentioned.
+	ReviewerID  int64 `json:"reviewer_id"`
+	MentionedID int64 `json:"mentioned_id"`
+
+	// CreatedGt, CreatedLt, UpdatedGt and UpdatedLt restrict the creation and the update time
+	// of the pull requests (unix milliseconds, exclusive). Ignored if zero.
+	CreatedGt int64 `json:"created_gt"`
+	CreatedLt int64 `json:"created_lt"`
+	UpdatedGt int64 `json:"updated_gt"`
+	UpdatedLt int64 `json:"updated_lt"`
 }
 
 // PullReqReview holds pull request review.
@@ -160,3 +198,37 @@ type MergeViolations struct {
 	ConflictFiles  []string         `json:"conflict_files,omitempty"`
 	RuleViolations []RuleViolations `json:"rule_violations,omitempty"`
 }
+
+// PullReqDependency represents a "depends on" relationship between two pull requests.
+// The pull request with ID PullReqID can't be merged while the pull request with ID DependsOnID is open.
+type PullReqDependency struct {
+	ID          int64 `json:"id"`
+	PullReqID   int64 `json:"-"`
+	DependsOnID int64 `json:"-"`
+
+	CreatedBy int64 `json:"-"`
+	Created   int64 `json:"created"`
+}
+
+// PullReqDependencyInfo holds basic info about a pull request that is part of a dependency relationship.
+type PullReqDependencyInfo struct {
+	ID       int64             `json:"id"` // ID of the dependency relationship
+	RepoID   int64             `json:"repo_id"`
+	RepoPath string            `json:"repo_path"`
+	Number   int64             `json:"number"`
+	Title    string            `json:"title"`
+	State    enum.PullReqState `json:"state"`
+	IsDraft  bool              `json:"is_draft"`
+	Merged   *int64            `json:"merged"`
+	Created  int64             `json:"created"` // time when the dependency relationship was created
+
+	// Restricted is set if the caller can't view the repository of the pull request,
+	// only the IDs and the number of the pull request are provided.
+	Restricted bool `json:"restricted,omitempty"`
+}
+
+// PullReqDependencies holds the dependencies and the dependents of a pull request.
+type PullReqDependencies struct {
+	Dependencies []PullReqDependencyInfo `json:"dependencies"`
+	Dependents   []PullReqDependencyInfo `json:"dependents"`
+}
@@ -89,6 +89,8 @@ func (a *PullReqActivity) AsCodeComment() *CodeComment {
 			SpanNew:      a.CodeComment.SpanNew,
 			LineOld:      a.CodeComment.LineOld,
 			SpanOld:      a.CodeComment.SpanOld,
+			AnchorStatus: a.CodeComment.AnchorStatus,
+			Anchor:       a.CodeComment.Anchor,
 		},
 	}
 }
@@ -190,6 +192,7 @@ var allPullReqActivityPayloads = func(
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadReviewSubmit{} },
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadBranchUpdate{} },
 	func() PullReqActivityPayload { return &PullRequestActivityPayloadBranchDelete{} },
+	func() PullReqActivityPayload { return &PullRequestActivityPayloadTargetChange{} },
 })
 
 // newPayloadForActivity returns a new payload instance for the requested activity type.
@@ -224,6 +227,9 @@ type PullRequestActivityPayloadMerge struct {
 	MergeSHA    string           `json:"merge_sha"`
 	TargetSHA   string           `json:"target_sha"`
 	SourceSHA   string           `json:"source_sha"`
+
+	// BypassedRules holds IDs of the rules that were bypassed to merge the pull request.
+	BypassedRules []int64 `json:"bypassed_rules,omitempty"`
 }
 
 func (a *PullRequestActivityPayloadMerge) ActivityType() enum.PullReqActivityType {
@@ -275,3 +281,12 @@ type PullRequestActivityPayloadBranchDelete struct {
 func (a *PullRequestActivityPayloadBranchDelete) ActivityType() enum.PullReqActivityType {
 	return enum.PullReqActivityTypeBranchDelete
 }
+
+type PullRequestActivityPayloadTargetChange struct {
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+func (a *PullRequestActivityPayloadTargetChange) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeTargetChange
+}
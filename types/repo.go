@@ -48,6 +48,10 @@ type Repository struct {
 
 	Importing bool `json:"importing"`
 
+	// Metadata contains custom key/value pairs of the repository, e.g. the owning team or the service name.
+	// It's included in the webhook payloads so downstream systems can route events without mapping tables.
+	Metadata map[string]string `json:"metadata"`
+
 	// git urls
 	GitURL string `json:"git_url"`
 }
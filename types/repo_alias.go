@@ -0,0 +1,31 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// RepoAlias is an alternate identifier of a repository within the space of the repository.
+// Repository references using the alias resolve to the repository, both in the API and in git URLs.
+type RepoAlias struct {
+	ID      int64  `json:"id"`
+	RepoID  int64  `json:"repo_id"`
+	SpaceID int64  `json:"space_id"`
+	UID     string `json:"uid"`
+
+	CreatedBy int64 `json:"created_by"`
+	Created   int64 `json:"created"`
+
+	// populated by the controller.
+	Path     string `json:"path,omitempty"`
+	RepoPath string `json:"repo_path,omitempty"`
+}
@@ -0,0 +1,45 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// RepositoryMirror is the configuration and the synchronization status of a repository
+// that mirrors the branches and tags of an upstream repository. Mirror repositories are read-only.
+type RepositoryMirror struct {
+	RepoID int64 `json:"repo_id"`
+
+	RemoteURL string `json:"remote_url"`
+	Username  string `json:"username"`
+	// Password is the encrypted password (or access token) used to authenticate to the remote.
+	Password string `json:"-"`
+
+	// SyncInterval is the number of seconds between two synchronizations.
+	SyncInterval int64 `json:"sync_interval"`
+	Disabled     bool  `json:"disabled"`
+
+	SyncState        enum.MirrorSyncState `json:"sync_state"`
+	SyncError        string               `json:"sync_error,omitempty"`
+	LastSyncStarted  int64                `json:"last_sync_started"`
+	LastSyncFinished int64                `json:"last_sync_finished"`
+	// LastSynced is the time of the last successful synchronization.
+	LastSynced int64 `json:"last_synced"`
+	NextSync   int64 `json:"next_sync"`
+
+	CreatedBy int64 `json:"created_by"`
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+	Version   int64 `json:"-"`
+}
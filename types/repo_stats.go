@@ -0,0 +1,50 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// RepositoryStats contains the disk usage and object statistics of a repository.
+// The statistics are refreshed periodically by a background job. All sizes are in bytes.
+type RepositoryStats struct {
+	RepoID int64 `json:"repo_id"`
+
+	// DiskSize is the size of all loose and packed objects stored on the disk.
+	DiskSize      int64 `json:"disk_size"`
+	LooseObjects  int   `json:"loose_objects"`
+	LooseSize     int64 `json:"loose_size"`
+	PackedObjects int   `json:"packed_objects"`
+	Packs         int   `json:"packs"`
+	PackSize      int64 `json:"pack_size"`
+
+	// Blobs and BlobsSize are the number and the uncompressed size of the blobs reachable from any reference.
+	Blobs        int                     `json:"blobs"`
+	BlobsSize    int64                   `json:"blobs_size"`
+	LargestBlobs []RepositoryStatsBlob   `json:"largest_blobs"`
+	LFS          RepositoryStatsLFSUsage `json:"lfs"`
+
+	Updated int64 `json:"updated"`
+}
+
+// RepositoryStatsBlob describes a large blob of a repository.
+type RepositoryStatsBlob struct {
+	SHA  string `json:"sha"`
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+}
+
+// RepositoryStatsLFSUsage describes the git LFS objects referenced by the LFS pointer files of a repository.
+type RepositoryStatsLFSUsage struct {
+	Objects int   `json:"objects"`
+	Size    int64 `json:"size"`
+}
@@ -0,0 +1,43 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// RequiredFile is a space policy that requires a file to exist on the default branch
+// of all repositories in the space and its subspaces (e.g. LICENSE, SECURITY.md or CODEOWNERS).
+type RequiredFile struct {
+	ID      int64  `json:"id"`
+	SpaceID int64  `json:"space_id"`
+	Path    string `json:"path"`
+
+	// Template is the content of the file that is added by automated pull requests.
+	Template string `json:"template"`
+
+	// CreatePullReq specifies whether the compliance job should open a pull request
+	// that adds the file from the template to non-compliant repositories.
+	CreatePullReq bool `json:"create_pullreq"`
+
+	CreatedBy int64 `json:"created_by"`
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+}
+
+// RepoCompliance holds the result of the latest evaluation of the required files policies of a repository.
+type RepoCompliance struct {
+	RepoID       int64    `json:"repo_id"`
+	RepoUID      string   `json:"repo_uid"`
+	Compliant    bool     `json:"compliant"`
+	MissingFiles []string `json:"missing_files"`
+	Checked      int64    `json:"checked"`
+}
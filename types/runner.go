@@ -0,0 +1,49 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// Runner is a self-hosted runner that executes pipeline stages.
+type Runner struct {
+	ID      int64            `json:"-"`
+	UID     string           `json:"uid"`
+	Labels  []string         `json:"labels"`
+	OS      string           `json:"os"`
+	Arch    string           `json:"arch"`
+	Version string           `json:"version"`
+	State   enum.RunnerState `json:"state"`
+
+	// CacheDir is the directory on the runner machine that holds the cache volumes of pipelines.
+	// If empty, the directory configured on the server is used.
+	CacheDir string `json:"cache_dir"`
+
+	// TokenHash is the hash of the token the runner uses to authenticate, it's never returned by the API.
+	TokenHash string `json:"-"`
+
+	LastHeartbeat int64 `json:"last_heartbeat"`
+	Created       int64 `json:"created"`
+	Updated       int64 `json:"updated"`
+
+	// Online is true if the runner sent a heartbeat recently, it's not stored.
+	Online bool `json:"online"`
+}
+
+// RunnerRegistration is returned when a runner registers.
+// The token is shown only once, the runner uses it to authenticate its requests.
+type RunnerRegistration struct {
+	Runner *Runner `json:"runner"`
+	Token  string  `json:"token"`
+}
@@ -0,0 +1,35 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// SavedComparison is a named comparison of two references of a repository,
+// e.g. a long-running release branch against the default branch.
+type SavedComparison struct {
+	ID          int64  `json:"id"`
+	RepoID      int64  `json:"repo_id"`
+	UID         string `json:"uid"`
+	Description string `json:"description"`
+	BaseRef     string `json:"base_ref"`
+	HeadRef     string `json:"head_ref"`
+
+	CreatedBy int64 `json:"created_by"`
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+
+	// populated by the controller.
+	URL    string `json:"url,omitempty"`
+	Ahead  *int32 `json:"ahead,omitempty"`
+	Behind *int32 `json:"behind,omitempty"`
+}
@@ -0,0 +1,39 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// Schedule periodically triggers executions of a pipeline based on a cron expression.
+type Schedule struct {
+	ID          int64  `json:"id"`
+	UID         string `json:"uid"`
+	Description string `json:"description"`
+	PipelineID  int64  `json:"pipeline_id"`
+	RepoID      int64  `json:"repo_id"`
+	CreatedBy   int64  `json:"created_by"`
+	// Cron is a standard five field cron expression (or a descriptor like "@daily") evaluated in the timezone.
+	Cron string `json:"cron"`
+	// Timezone is the IANA name of the timezone the cron expression is evaluated in. If empty, UTC is used.
+	Timezone string `json:"timezone"`
+	// Branch is the branch the pipeline is executed for. If empty, the default branch of the pipeline is used.
+	Branch   string `json:"branch"`
+	Disabled bool   `json:"disabled"`
+	// LastRun is the time of the last scheduled execution, or zero if the schedule didn't run yet.
+	LastRun int64 `json:"last_run"`
+	// NextRun is the time of the next scheduled execution.
+	NextRun int64 `json:"next_run"`
+	Created int64 `json:"created"`
+	Updated int64 `json:"updated"`
+	Version int64 `json:"-"`
+}
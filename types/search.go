@@ -14,6 +14,8 @@
 
 package types
 
+import "github.com/harness/gitness/types/enum"
+
 type (
 	SearchInput struct {
 		Query string `json:"query"`
@@ -62,6 +64,47 @@ type (
 		After string `json:"after"`
 	}
 
+	// GlobalSearchFilter stores global search query parameters.
+	GlobalSearchFilter struct {
+		Query string `json:"query"`
+
+		// Types contains the types of results to return. If empty, results of all types are returned.
+		Types []enum.SearchResultType `json:"types"`
+
+		// SpacePath restricts the results to the space and its subspaces. Required for code search.
+		SpacePath string `json:"space_path"`
+
+		// Page and Size are applied to each result group separately.
+		Page int `json:"page"`
+		Size int `json:"size"`
+	}
+
+	// GlobalSearchResult holds the results of the global search grouped by their type.
+	GlobalSearchResult struct {
+		Groups []GlobalSearchGroup `json:"groups"`
+	}
+
+	// GlobalSearchGroup holds a single page of global search results of the same type.
+	GlobalSearchGroup struct {
+		Type    enum.SearchResultType `json:"type"`
+		Page    int                   `json:"page"`
+		HasMore bool                  `json:"has_more"`
+		Items   []GlobalSearchItem    `json:"items"`
+	}
+
+	// GlobalSearchItem is a single global search result.
+	// Depending on the type of the result only one of the object fields is set.
+	GlobalSearchItem struct {
+		Type  enum.SearchResultType `json:"type"`
+		Score float64               `json:"score"`
+
+		Repo    *Repository    `json:"repo,omitempty"`
+		Space   *Space         `json:"space,omitempty"`
+		User    *PrincipalInfo `json:"user,omitempty"`
+		PullReq *PullReq       `json:"pullreq,omitempty"`
+		File    *FileMatch     `json:"file,omitempty"`
+	}
+
 	// Fragment holds data of a single contiguous match within a line.
 	Fragment struct {
 		Pre   string `json:"pre"`   // the string before the match within the line
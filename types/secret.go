@@ -24,6 +24,16 @@ type Secret struct {
 	Created     int64  `db:"secret_created"         json:"created"`
 	Updated     int64  `db:"secret_updated"         json:"updated"`
 	Version     int64  `db:"secret_version"         json:"-"`
+
+	// Connector is the UID of the secret manager connector of the space the secret is stored in.
+	// If set, the value isn't stored, but fetched from the secret manager using the reference.
+	Connector string `db:"secret_connector" json:"connector,omitempty"`
+	Reference string `db:"secret_reference" json:"reference,omitempty"`
+}
+
+// IsExternal returns true if the value of the secret is stored in a secret manager.
+func (s *Secret) IsExternal() bool {
+	return s.Connector != ""
 }
 
 // Copy makes a copy of the secret without the value.
@@ -33,6 +43,8 @@ func (s *Secret) CopyWithoutData() *Secret {
 		Description: s.Description,
 		UID:         s.UID,
 		SpaceID:     s.SpaceID,
+		Connector:   s.Connector,
+		Reference:   s.Reference,
 		Created:     s.Created,
 		Updated:     s.Updated,
 		Version:     s.Version,
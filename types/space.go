@@ -51,3 +51,29 @@ type SpaceFilter struct {
 	Sort  enum.SpaceAttr `json:"sort"`
 	Order enum.Order     `json:"order"`
 }
+
+// SpacePin represents an item (repository, pull request or release) pinned to the landing page of a space.
+type SpacePin struct {
+	ID       int64                 `json:"id"`
+	SpaceID  int64                 `json:"space_id"`
+	Type     enum.SpacePinType     `json:"type"`
+	RepoID   int64                 `json:"repo_id"`
+	Number   int64                 `json:"number,omitempty"` // pull request number, only for pinned pull requests
+	Tag      string                `json:"tag,omitempty"`    // tag name, only for pinned releases
+	Title    string                `json:"title"`
+	Position int64                 `json:"position"`
+	Audience enum.SpacePinAudience `json:"audience"`
+
+	CreatedBy int64 `json:"created_by"`
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+
+	// RepoPath is populated by the server when the pinned items are returned.
+	RepoPath string `json:"repo_path,omitempty"`
+}
+
+// SpaceLanding is the landing page content of a space.
+type SpaceLanding struct {
+	Space  *Space      `json:"space"`
+	Pinned []*SpacePin `json:"pinned"`
+}
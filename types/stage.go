@@ -44,5 +44,30 @@ type Stage struct {
 	OnFailure   bool              `json:"on_failure"`
 	DependsOn   []string          `json:"depends_on,omitempty"`
 	Labels      map[string]string `json:"labels,omitempty"`
+	RunsOn      []string          `json:"runs_on,omitempty"`
+	Matrix      map[string]string `json:"matrix,omitempty"`
 	Steps       []*Step           `json:"steps,omitempty"`
+	Approval    *Approval         `json:"approval,omitempty"`
+
+	// RetryFrom is the name of the step a retried stage starts from, the steps before it are skipped.
+	RetryFrom string `json:"retry_from,omitempty"`
+
+	// Timeout is the maximum duration of the stage in milliseconds, zero means no timeout.
+	Timeout int64 `json:"timeout,omitempty"`
+	// StepTimeouts are the maximum durations of the steps of the stage in milliseconds, keyed by the step name.
+	StepTimeouts map[string]int64 `json:"step_timeouts,omitempty"`
+	// StepConditions are the results of evaluating the `when` conditions of the steps of the stage,
+	// keyed by the step name. Steps without a condition aren't included.
+	StepConditions map[string]ConditionResult `json:"step_conditions,omitempty"`
+}
+
+// ConditionResult is the result of evaluating the `when` condition of a stage or a step
+// against the branch, event and variables of the execution.
+type ConditionResult struct {
+	// Matched is true if the condition matched the execution, regardless of the status.
+	Matched bool `json:"matched"`
+	// OnSuccess is true if it runs when everything before it succeeded.
+	OnSuccess bool `json:"on_success"`
+	// OnFailure is true if it runs when something before it failed.
+	OnFailure bool `json:"on_failure"`
 }
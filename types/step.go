@@ -37,6 +37,9 @@ type Step struct {
 	Image     string        `json:"image,omitempty"`
 	Detached  bool          `json:"detached"`
 	Schema    string        `json:"schema,omitempty"`
+
+	// Condition is the result of evaluating the `when` condition of the step, if it has one.
+	Condition *ConditionResult `json:"condition,omitempty"`
 }
 
 // Pretty print a step.
@@ -15,6 +15,8 @@
 package types
 
 import (
+	"time"
+
 	"github.com/harness/gitness/types/enum"
 )
 
@@ -30,6 +32,13 @@ type Token struct {
 	// IssuedAt is the unix time at which the token was issued.
 	IssuedAt  int64 `db:"token_issued_at"          json:"issued_at"`
 	CreatedBy int64 `db:"token_created_by"         json:"created_by"`
+	// LastUsed is the unix time at which the token was last used (updated with a delay of up to a minute).
+	LastUsed *int64 `db:"token_last_used"          json:"last_used,omitempty"`
+	// SpaceIDs optionally restricts the token to the listed spaces (and everything within them).
+	SpaceIDs []int64 `db:"-"                        json:"space_ids,omitempty"`
+	// Role optionally grants the permissions of the membership role within the spaces of the token,
+	// instead of the permissions of the principal (used by space access tokens).
+	Role enum.MembershipRole `db:"token_role"               json:"role,omitempty"`
 }
 
 // TokenResponse is returned as part of token creation for PAT / SAT / User Session.
@@ -37,3 +46,15 @@ type TokenResponse struct {
 	AccessToken string `json:"access_token"`
 	Token       Token  `json:"token"`
 }
+
+// TokenPolicy defines the lifetime restrictions of the tokens of a principal type.
+// A zero duration means that the restriction isn't enforced.
+type TokenPolicy struct {
+	// MaxLifetime is the maximum lifetime of tokens, it also limits tokens issued before it was set or lowered.
+	MaxLifetime time.Duration
+	// IdleTimeout is the duration after which a token that wasn't used becomes invalid.
+	IdleTimeout time.Duration
+	// ReauthInterval is the duration after which a token becomes invalid, independent of its expiration,
+	// forcing the principal to authenticate again (or to rotate the token).
+	ReauthInterval time.Duration
+}
@@ -26,8 +26,24 @@ type Trigger struct {
 	CreatedBy   int64                `json:"created_by"`
 	Disabled    bool                 `json:"disabled"`
 	Actions     []enum.TriggerAction `json:"actions"`
+	Paths       TriggerPaths         `json:"paths"`
 	UID         string               `json:"uid"`
 	Created     int64                `json:"created"`
 	Updated     int64                `json:"updated"`
 	Version     int64                `json:"-"`
 }
+
+// TriggerPaths restricts a trigger to changes of the matching file paths.
+// The patterns are evaluated against the files changed by a push or a pull request.
+type TriggerPaths struct {
+	// Include are the path patterns of which at least one has to match a changed file.
+	// If empty, all paths are included.
+	Include []string `json:"include,omitempty"`
+	// Exclude are the path patterns of the changed files that are ignored.
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// IsEmpty returns true if no path filters are configured.
+func (p TriggerPaths) IsEmpty() bool {
+	return len(p.Include) == 0 && len(p.Exclude) == 0
+}
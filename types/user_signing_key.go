@@ -0,0 +1,45 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// UserSigningKey is an instance-managed private key used to sign the commits
+// created by the server on behalf of a user (e.g. web edits).
+type UserSigningKey struct {
+	ID          int64                 `json:"id"`
+	PrincipalID int64                 `json:"principal_id"`
+	Format      enum.SigningKeyFormat `json:"format"`
+	Fingerprint string                `json:"fingerprint"`
+	PublicKey   string                `json:"public_key"`
+
+	// PrivateKey holds the encrypted private key and is never returned by the API.
+	PrivateKey string `json:"-"`
+
+	Created int64 `json:"created"`
+
+	// Rotated is set once the key got replaced by a newer key.
+	// Commits signed with a rotated key remain verified.
+	Rotated *int64 `json:"rotated,omitempty"`
+
+	// Revoked is set once the key got revoked.
+	// Commits signed with a revoked key are no longer verified.
+	Revoked *int64 `json:"revoked,omitempty"`
+}
+
+// IsActive returns true if the key is used for signing new commits.
+func (k *UserSigningKey) IsActive() bool {
+	return k.Rotated == nil && k.Revoked == nil
+}
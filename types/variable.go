@@ -0,0 +1,48 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// Variable is a pipeline variable of a repository or a space, injected into pipeline executions
+// as an environment variable. It either holds a plain, non-secret value, or references a secret
+// of the space (for repository variables: of the parent space) by its UID.
+type Variable struct {
+	ID         int64               `json:"-"`
+	Version    int64               `json:"-"`
+	ParentID   int64               `json:"parent_id"`
+	ParentType enum.VariableParent `json:"parent_type"`
+	Key        string              `json:"key"`
+	Value      string              `json:"value"`
+	SecretUID  string              `json:"secret_uid,omitempty"`
+	CreatedBy  int64               `json:"created_by"`
+	UpdatedBy  int64               `json:"updated_by"`
+	Created    int64               `json:"created"`
+	Updated    int64               `json:"updated"`
+}
+
+// VariableAudit is an entry of the audit log of the changes of pipeline variables.
+// Value and SecretUID hold the variable's state after the change, they are empty for deletions.
+type VariableAudit struct {
+	ID          int64                    `json:"id"`
+	ParentID    int64                    `json:"parent_id"`
+	ParentType  enum.VariableParent      `json:"parent_type"`
+	Key         string                   `json:"key"`
+	Action      enum.VariableAuditAction `json:"action"`
+	Value       string                   `json:"value"`
+	SecretUID   string                   `json:"secret_uid,omitempty"`
+	PrincipalID int64                    `json:"principal_id"`
+	Created     int64                    `json:"created"`
+}
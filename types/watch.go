@@ -0,0 +1,35 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// RepoWatch is the watch level of a user for a repository.
+type RepoWatch struct {
+	RepoID      int64           `json:"repo_id"`
+	PrincipalID int64           `json:"principal_id"`
+	Level       enum.WatchLevel `json:"level"`
+	Created     int64           `json:"created"`
+	Updated     int64           `json:"updated"`
+}
+
+// PullReqWatch is the watch level of a user for a pull request. It overrides the watch level of the repository.
+type PullReqWatch struct {
+	PullReqID   int64           `json:"pullreq_id"`
+	PrincipalID int64           `json:"principal_id"`
+	Level       enum.WatchLevel `json:"level"`
+	Created     int64           `json:"created"`
+	Updated     int64           `json:"updated"`
+}
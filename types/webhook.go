@@ -41,6 +41,11 @@ type Webhook struct {
 	Insecure              bool                         `json:"insecure"`
 	Triggers              []enum.WebhookTrigger        `json:"triggers"`
 	LatestExecutionResult *enum.WebhookExecutionResult `json:"latest_execution_result,omitempty"`
+
+	// PayloadTemplateType is the language of the payload template, empty if the canonical payload is sent.
+	PayloadTemplateType enum.WebhookPayloadTemplateType `json:"payload_template_type,omitempty"`
+	// PayloadTemplate transforms the canonical payload into the payload that is sent.
+	PayloadTemplate string `json:"payload_template,omitempty"`
 }
 
 // MarshalJSON overrides the default json marshaling for `Webhook` allowing us to inject the `HasSecret` field.
@@ -0,0 +1,84 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
+)
+
+// WorkingHoursTimeLayout is the layout of the start and the end of the working hours.
+const WorkingHoursTimeLayout = "15:04"
+
+// WorkingHours is the working hours profile of a user.
+type WorkingHours struct {
+	PrincipalID int64 `json:"-"`
+	// Timezone is the IANA name of the timezone of the working hours. If empty, UTC is used.
+	Timezone string         `json:"timezone"`
+	Days     []enum.Weekday `json:"days"`
+	// Start and End are the start and the end of a working day in the "15:04" format.
+	// If End is before Start, the working day ends on the following day.
+	Start string `json:"start"`
+	End   string `json:"end"`
+
+	Created int64 `json:"created"`
+	Updated int64 `json:"updated"`
+}
+
+// Contains returns true if the provided time is within the working hours.
+func (h *WorkingHours) Contains(t time.Time) (bool, error) {
+	loc := time.UTC
+	if h.Timezone != "" {
+		var err error
+		loc, err = time.LoadLocation(h.Timezone)
+		if err != nil {
+			return false, fmt.Errorf("failed to load timezone %q: %w", h.Timezone, err)
+		}
+	}
+
+	start, err := time.Parse(WorkingHoursTimeLayout, h.Start)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse start of working hours: %w", err)
+	}
+
+	end, err := time.Parse(WorkingHoursTimeLayout, h.End)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse end of working hours: %w", err)
+	}
+
+	local := t.In(loc)
+	day := enum.WeekdayOf(local.Weekday())
+	minute := local.Hour()*60 + local.Minute()
+	startMinute := start.Hour()*60 + start.Minute()
+	endMinute := end.Hour()*60 + end.Minute()
+
+	if startMinute < endMinute {
+		return slices.Contains(h.Days, day) && minute >= startMinute && minute < endMinute, nil
+	}
+
+	// the working day ends on the following day.
+	switch {
+	case minute >= startMinute:
+		return slices.Contains(h.Days, day), nil
+	case minute < endMinute:
+		return slices.Contains(h.Days, day.Previous()), nil
+	default:
+		return false, nil
+	}
+}
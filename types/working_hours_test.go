@@ -0,0 +1,100 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestWorkingHoursContains(t *testing.T) {
+	weekdays := []enum.Weekday{
+		enum.WeekdayMonday,
+		enum.WeekdayTuesday,
+		enum.WeekdayWednesday,
+		enum.WeekdayThursday,
+		enum.WeekdayFriday,
+	}
+
+	tests := []struct {
+		name  string
+		hours WorkingHours
+		time  string
+		exp   bool
+	}{
+		{
+			name:  "within",
+			hours: WorkingHours{Days: weekdays, Start: "09:00", End: "17:00"},
+			time:  "2024-03-04T09:00:00Z", // monday
+			exp:   true,
+		},
+		{
+			name:  "at-end",
+			hours: WorkingHours{Days: weekdays, Start: "09:00", End: "17:00"},
+			time:  "2024-03-04T17:00:00Z",
+			exp:   false,
+		},
+		{
+			name:  "weekend",
+			hours: WorkingHours{Days: weekdays, Start: "09:00", End: "17:00"},
+			time:  "2024-03-03T10:00:00Z", // sunday
+			exp:   false,
+		},
+		{
+			name:  "timezone",
+			hours: WorkingHours{Timezone: "America/New_York", Days: weekdays, Start: "09:00", End: "17:00"},
+			time:  "2024-03-04T15:00:00Z", // 10:00 in New York
+			exp:   true,
+		},
+		{
+			name:  "timezone-outside",
+			hours: WorkingHours{Timezone: "Asia/Tokyo", Days: weekdays, Start: "09:00", End: "17:00"},
+			time:  "2024-03-04T15:00:00Z", // 00:00 on tuesday in Tokyo
+			exp:   false,
+		},
+		{
+			name:  "overnight-after-midnight",
+			hours: WorkingHours{Days: []enum.Weekday{enum.WeekdayFriday}, Start: "22:00", End: "06:00"},
+			time:  "2024-03-09T02:00:00Z", // saturday
+			exp:   true,
+		},
+		{
+			name:  "overnight-previous-day-off",
+			hours: WorkingHours{Days: []enum.Weekday{enum.WeekdayFriday}, Start: "22:00", End: "06:00"},
+			time:  "2024-03-08T02:00:00Z", // friday
+			exp:   false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			at, err := time.Parse(time.RFC3339, test.time)
+			if err != nil {
+				t.Fatalf("failed to parse time: %s", err)
+			}
+
+			got, err := test.hours.Contains(at)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got != test.exp {
+				t.Errorf("expected %t, got %t", test.exp, got)
+			}
+		})
+	}
+}
@@ -18,9 +18,11 @@ import { useHistory } from 'react-router-dom'
 import React, { useCallback, useMemo, useRef, useState } from 'react'
 import { Container } from '@harnessio/uicore'
 import cx from 'classnames'
+import { escapeRegExp } from 'lodash-es'
 import MarkdownPreview from '@uiw/react-markdown-preview'
 import rehypeVideo from 'rehype-video'
 import rehypeExternalLinks from 'rehype-external-links'
+import type { TypesAutolinkReference } from 'services/code'
 import { INITIAL_ZOOM_LEVEL } from 'utils/Utils'
 import ImageCarousel from 'components/ImageCarousel/ImageCarousel'
 import css from './MarkdownViewer.module.scss'
@@ -30,9 +32,81 @@ interface MarkdownViewerProps {
   className?: string
   maxHeight?: string | number
   darkMode?: boolean
+  autolinks?: TypesAutolinkReference[] | null
 }
 
-export function MarkdownViewer({ source, className, maxHeight, darkMode }: MarkdownViewerProps) {
+interface HastNode {
+  type: string
+  tagName?: string
+  value?: string
+  properties?: Record<string, unknown>
+  children?: HastNode[]
+}
+
+// Elements whose content is never autolinked.
+const autolinkSkippedTags = ['a', 'code', 'pre']
+
+/**
+ * rehypeAutolinks turns the custom autolink references resolved by the server into links.
+ * References are matched in text nodes only, as whole words, and never inside links or code.
+ */
+function rehypeAutolinks(autolinks: TypesAutolinkReference[]) {
+  const urls = new Map(autolinks.filter(ref => ref.text && ref.url).map(ref => [ref.text as string, ref.url as string]))
+  const pattern = new RegExp(
+    `(?<![\\p{L}\\p{N}_])(${Array.from(urls.keys()).map(escapeRegExp).join('|')})(?![\\p{L}\\p{N}_])`,
+    'gu'
+  )
+
+  const linkText = (value: string): HastNode[] => {
+    const nodes: HastNode[] = []
+    let pos = 0
+    let match: RegExpExecArray | null
+
+    pattern.lastIndex = 0
+    while ((match = pattern.exec(value)) !== null) {
+      const { index } = match
+      if (index > pos) {
+        nodes.push({ type: 'text', value: value.slice(pos, index) })
+      }
+      nodes.push({
+        type: 'element',
+        tagName: 'a',
+        properties: { href: urls.get(match[0]) },
+        children: [{ type: 'text', value: match[0] }]
+      })
+      pos = index + match[0].length
+    }
+
+    if (pos < value.length) {
+      nodes.push({ type: 'text', value: value.slice(pos) })
+    }
+
+    return nodes
+  }
+
+  const visit = (node: HastNode) => {
+    if (!node.children || (node.tagName && autolinkSkippedTags.includes(node.tagName))) {
+      return
+    }
+
+    node.children = node.children.flatMap(child => {
+      if (child.type === 'text' && child.value) {
+        return linkText(child.value)
+      }
+
+      visit(child)
+      return [child]
+    })
+  }
+
+  return () => (tree: HastNode) => {
+    if (urls.size) {
+      visit(tree)
+    }
+  }
+}
+
+export function MarkdownViewer({ source, className, maxHeight, darkMode, autolinks }: MarkdownViewerProps) {
   const [isOpen, setIsOpen] = useState<boolean>(false)
   const history = useHistory()
   const [zoomLevel, setZoomLevel] = useState(INITIAL_ZOOM_LEVEL)
@@ -142,6 +216,7 @@ export function MarkdownViewer({ source, className, maxHeight, darkMode }: Markd
         }}
         rehypePlugins={[
           rehypeVideo,
+          ...(autolinks?.length ? [rehypeAutolinks(autolinks)] : []),
           [rehypeExternalLinks, { rel: ['nofollow noreferrer noopener'], target: '_blank' }]
         ]}
       />
@@ -101,7 +101,7 @@ export const DescriptionBox: React.FC<DescriptionBoxProps> = ({
           />
         )) || (
           <Container className={css.mdWrapper}>
-            <MarkdownViewer source={content} />
+            <MarkdownViewer source={content} autolinks={pullRequestMetadata.autolinks} />
             <Container className={css.menuWrapper}>
               <OptionsMenuButton
                 isDark={true}
@@ -603,6 +603,11 @@ export interface SystemConfigOutput {
 
 export type TimeDuration = number | null
 
+export interface TypesAutolinkReference {
+  text?: string
+  url?: string
+}
+
 export interface TypesCheck {
   created?: number
   id?: number
@@ -646,6 +651,7 @@ export interface TypesCodeOwnerEvaluationEntry {
 
 export interface TypesCommit {
   author?: TypesSignature
+  autolinks?: TypesAutolinkReference[] | null
   committer?: TypesSignature
   message?: string
   sha?: string
@@ -804,6 +810,7 @@ export interface TypesPrincipalInfo {
 
 export interface TypesPullReq {
   author?: TypesPrincipalInfo
+  autolinks?: TypesAutolinkReference[] | null
   created?: number
   description?: string
   edited?: number